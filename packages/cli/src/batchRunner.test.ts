@@ -0,0 +1,228 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, vi, beforeEach, afterEach } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { Config } from '@iechor/research-cli-core';
+import { GenerateContentResponse } from '@google/genai';
+import {
+  BatchResult,
+  buildBatchPrompt,
+  parseBatchInputs,
+  runBatch,
+} from './batchRunner.js';
+
+const answer = (text: string) =>
+  ({
+    candidates: [{ content: { parts: [{ text }] } }],
+    usageMetadata: { promptTokenCount: 10, candidatesTokenCount: 2 },
+  }) as GenerateContentResponse;
+
+describe('parseBatchInputs', () => {
+  it('should read strings and objects, skipping blank lines', () => {
+    expect(
+      parseBatchInputs('"What is 2+2?"\n\n{"id": "q2", "topic": "graphs"}\n'),
+    ).toEqual([
+      { id: '1', fields: { prompt: 'What is 2+2?' } },
+      { id: 'q2', fields: { id: 'q2', topic: 'graphs' } },
+    ]);
+  });
+
+  it('should name the line that is not valid', () => {
+    expect(() => parseBatchInputs('"ok"\n[1, 2]')).toThrow(
+      'line 2: expected a string or an object',
+    );
+    expect(() => parseBatchInputs('{"prompt": ')).toThrow(/^line 1: /);
+  });
+});
+
+describe('buildBatchPrompt', () => {
+  it('should fill the template from the fields', () => {
+    expect(
+      buildBatchPrompt(
+        { id: '1', fields: { topic: 'graphs', n: 3 } },
+        'Give {{ n }} facts about {{topic}}.',
+      ),
+    ).toBe('Give 3 facts about graphs.');
+  });
+
+  it('should reject a missing field or prompt', () => {
+    expect(() =>
+      buildBatchPrompt({ id: '1', fields: {} }, 'About {{topic}}'),
+    ).toThrow('no "topic" field');
+    expect(() => buildBatchPrompt({ id: '1', fields: {} })).toThrow(
+      'no "prompt" field',
+    );
+  });
+});
+
+describe('runBatch', () => {
+  let tempDir: string;
+  let generateContent: ReturnType<typeof vi.fn>;
+  let config: Config;
+
+  const readResults = (file: string): BatchResult[] =>
+    fs
+      .readFileSync(file, 'utf8')
+      .trim()
+      .split('\n')
+      .map((line) => JSON.parse(line))
+      .sort((a, b) => a.index - b.index);
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'batch-'));
+    vi.spyOn(console, 'log').mockImplementation(() => {});
+    vi.spyOn(console, 'error').mockImplementation(() => {});
+    generateContent = vi.fn();
+    config = {
+      getModel: () => 'research-pro',
+      getResearchClient: () => ({ generateContent }),
+    } as unknown as Config;
+  });
+
+  afterEach(() => {
+    vi.restoreAllMocks();
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should answer every prompt and retry failed requests', async () => {
+    const prompts = path.join(tempDir, 'prompts.jsonl');
+    fs.writeFileSync(prompts, '"First?"\n{"id": "b", "prompt": "Second?"}\n');
+    generateContent.mockImplementation(async ([content]) => {
+      const text = content.parts[0].text;
+      if (text === 'Second?' && generateContent.mock.calls.length < 3) {
+        throw new Error('rate limited');
+      }
+      return answer(`Answer to ${text}`);
+    });
+    const out = path.join(tempDir, 'out', 'results.jsonl');
+
+    const exitCode = await runBatch(config, {
+      prompts,
+      out,
+      concurrency: 1,
+      retryDelayMs: 0,
+    });
+
+    expect(exitCode).toBe(0);
+    expect(readResults(out)).toEqual([
+      expect.objectContaining({
+        id: '1',
+        output: 'Answer to First?',
+        attempts: 1,
+        model: 'research-pro',
+        promptTokens: 10,
+        completionTokens: 2,
+      }),
+      expect.objectContaining({
+        id: 'b',
+        output: 'Answer to Second?',
+        attempts: 2,
+      }),
+    ]);
+    expect(readResults(out)[1].error).toBeUndefined();
+  });
+
+  it('should record the prompts that fail and exit with 1', async () => {
+    const prompts = path.join(tempDir, 'prompts.jsonl');
+    fs.writeFileSync(prompts, '{"topic": "graphs"}\n{"other": 1}\n');
+    const template = path.join(tempDir, 'template.txt');
+    fs.writeFileSync(template, 'Tell me about {{topic}}.');
+    generateContent.mockRejectedValue(new Error('quota exceeded'));
+    const out = path.join(tempDir, 'results.jsonl');
+
+    const exitCode = await runBatch(config, {
+      prompts,
+      out,
+      template,
+      retries: 1,
+      retryDelayMs: 0,
+    });
+
+    expect(exitCode).toBe(1);
+    expect(readResults(out)).toEqual([
+      expect.objectContaining({
+        prompt: 'Tell me about graphs.',
+        error: 'quota exceeded',
+        attempts: 2,
+      }),
+      expect.objectContaining({
+        error: 'no "topic" field for the template',
+        attempts: 0,
+      }),
+    ]);
+    expect(generateContent).toHaveBeenCalledTimes(2);
+    // The client makes one attempt per call; --retries is the whole budget
+    expect(generateContent.mock.calls[0][4]).toBe(1);
+  });
+
+  it('should stop on Ctrl+C and exit with 130', async () => {
+    const prompts = path.join(tempDir, 'prompts.jsonl');
+    fs.writeFileSync(prompts, '"a"\n"b"\n"c"\n');
+    generateContent.mockImplementation(
+      (_contents, _config, signal: AbortSignal) =>
+        new Promise((_resolve, reject) => {
+          signal.addEventListener('abort', () =>
+            reject(new Error('aborted')),
+          );
+          process.emit('SIGINT');
+        }),
+    );
+    const out = path.join(tempDir, 'results.jsonl');
+    const listeners = process.listenerCount('SIGINT');
+
+    const exitCode = await runBatch(config, {
+      prompts,
+      out,
+      concurrency: 1,
+      retryDelayMs: 0,
+    });
+
+    expect(exitCode).toBe(130);
+    expect(generateContent).toHaveBeenCalledTimes(1);
+    expect(readResults(out)).toEqual([
+      expect.objectContaining({ id: '1', error: 'interrupted', attempts: 1 }),
+    ]);
+    expect(process.listenerCount('SIGINT')).toBe(listeners);
+  });
+
+  it('should keep to the concurrency limit', async () => {
+    const prompts = path.join(tempDir, 'prompts.jsonl');
+    fs.writeFileSync(prompts, '"a"\n"b"\n"c"\n"d"\n"e"\n');
+    let inFlight = 0;
+    let most = 0;
+    generateContent.mockImplementation(async () => {
+      inFlight++;
+      most = Math.max(most, inFlight);
+      await new Promise((resolve) => setTimeout(resolve, 5));
+      inFlight--;
+      return answer('ok');
+    });
+    const out = path.join(tempDir, 'results.jsonl');
+
+    await runBatch(config, { prompts, out, concurrency: 2 });
+
+    expect(most).toBe(2);
+    expect(readResults(out).map((result) => result.id)).toEqual([
+      '1',
+      '2',
+      '3',
+      '4',
+      '5',
+    ]);
+  });
+
+  it('should exit with 2 when the prompts cannot be read', async () => {
+    expect(
+      await runBatch(config, {
+        prompts: path.join(tempDir, 'missing.jsonl'),
+        out: path.join(tempDir, 'results.jsonl'),
+      }),
+    ).toBe(2);
+  });
+});
@@ -0,0 +1,286 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import { Config, getErrorMessage } from '@iechor/research-cli-core';
+import { GenerateContentResponse } from '@google/genai';
+
+export const DEFAULT_BATCH_CONCURRENCY = 4;
+export const DEFAULT_BATCH_RETRIES = 2;
+const DEFAULT_RETRY_DELAY_MS = 1000;
+
+export interface BatchOptions {
+  /** JSONL file of inputs, one per line. */
+  prompts: string;
+  /** JSONL file the results are written to, one per input. */
+  out: string;
+  /** File whose `{{field}}` placeholders are filled from each input. */
+  template?: string;
+  /** Prompts sent at the same time. */
+  concurrency?: number;
+  /** Further attempts for a prompt whose request failed. */
+  retries?: number;
+  /** Wait before the first retry, doubled for each one after it. */
+  retryDelayMs?: number;
+}
+
+export interface BatchInput {
+  id: string;
+  fields: Record<string, unknown>;
+}
+
+/** One line of the results file. */
+export interface BatchResult {
+  id: string;
+  /** Position of the input in the prompts file, from 0. */
+  index: number;
+  model: string;
+  prompt?: string;
+  output?: string;
+  error?: string;
+  attempts: number;
+  durationMs: number;
+  promptTokens?: number;
+  completionTokens?: number;
+}
+
+/**
+ * Reads the inputs of a batch. Each line is a JSON string, which is the
+ * prompt, or an object whose fields fill the template, the prompt being
+ * its `prompt` field without one. An `id` field names the input in the
+ * results; otherwise its line number does.
+ */
+export function parseBatchInputs(text: string): BatchInput[] {
+  const inputs: BatchInput[] = [];
+  for (const [i, line] of text.split('\n').entries()) {
+    if (!line.trim()) {
+      continue;
+    }
+    let value: unknown;
+    try {
+      value = JSON.parse(line);
+    } catch (e) {
+      throw new Error(`line ${i + 1}: ${getErrorMessage(e)}`);
+    }
+    if (typeof value === 'string') {
+      inputs.push({ id: String(i + 1), fields: { prompt: value } });
+    } else if (value && typeof value === 'object' && !Array.isArray(value)) {
+      const fields = value as Record<string, unknown>;
+      inputs.push({
+        id: fields.id === undefined ? String(i + 1) : String(fields.id),
+        fields,
+      });
+    } else {
+      throw new Error(`line ${i + 1}: expected a string or an object`);
+    }
+  }
+  return inputs;
+}
+
+/** The prompt of an input, with the template filled from its fields. */
+export function buildBatchPrompt(
+  input: BatchInput,
+  template?: string,
+): string {
+  if (template === undefined) {
+    const prompt = input.fields.prompt;
+    if (typeof prompt !== 'string' || !prompt.trim()) {
+      throw new Error('no "prompt" field, and no --template to fill');
+    }
+    return prompt;
+  }
+  return template.replace(/\{\{\s*([\w.-]+)\s*\}\}/g, (_match, name) => {
+    const value = input.fields[name];
+    if (value === undefined) {
+      throw new Error(`no "${name}" field for the template`);
+    }
+    return typeof value === 'string' ? value : JSON.stringify(value);
+  });
+}
+
+function getText(response: GenerateContentResponse): string {
+  return (response.candidates?.[0]?.content?.parts ?? [])
+    .filter((part) => part.text && !part.thought)
+    .map((part) => part.text)
+    .join('');
+}
+
+/**
+ * Sends one input as a conversation of its own, trying again after a
+ * failed request, and records what came back. The client's own backoff is
+ * limited to one attempt, so `retries` is the whole retry budget.
+ */
+async function runInput(
+  config: Config,
+  input: BatchInput,
+  index: number,
+  template: string | undefined,
+  options: Required<Pick<BatchOptions, 'retries' | 'retryDelayMs'>>,
+  signal: AbortSignal,
+): Promise<BatchResult> {
+  const start = Date.now();
+  const result: BatchResult = {
+    id: input.id,
+    index,
+    model: config.getModel(),
+    attempts: 0,
+    durationMs: 0,
+  };
+  try {
+    result.prompt = buildBatchPrompt(input, template);
+  } catch (e) {
+    result.error = getErrorMessage(e);
+    return result;
+  }
+  while (result.attempts <= options.retries && !signal.aborted) {
+    if (result.attempts > 0) {
+      const delayMs = options.retryDelayMs * 2 ** (result.attempts - 1);
+      await new Promise((resolve) => setTimeout(resolve, delayMs));
+    }
+    result.attempts++;
+    try {
+      const response = await config
+        .getResearchClient()
+        .generateContent(
+          [{ role: 'user', parts: [{ text: result.prompt }] }],
+          {},
+          signal,
+          undefined,
+          1,
+        );
+      result.output = getText(response);
+      result.promptTokens = response.usageMetadata?.promptTokenCount;
+      result.completionTokens = response.usageMetadata?.candidatesTokenCount;
+      delete result.error;
+      break;
+    } catch (e) {
+      result.error = signal.aborted ? 'interrupted' : getErrorMessage(e);
+    }
+  }
+  result.durationMs = Date.now() - start;
+  return result;
+}
+
+/**
+ * Runs every input of a prompts file through the configured model, a few
+ * at a time, and appends each result to the results file as it comes, so
+ * an interrupted run keeps what it got. Ctrl+C cancels the requests in
+ * flight and stops the run. Returns the process exit code: 0 when every
+ * prompt got an answer, 1 when some failed, 2 when the files could not be
+ * read or written, 130 when interrupted.
+ */
+export async function runBatch(
+  config: Config,
+  options: BatchOptions,
+): Promise<number> {
+  let inputs: BatchInput[];
+  let template: string | undefined;
+  try {
+    inputs = parseBatchInputs(
+      await fs.promises.readFile(options.prompts, 'utf8'),
+    );
+    if (options.template) {
+      template = await fs.promises.readFile(options.template, 'utf8');
+    }
+  } catch (e) {
+    console.error(`Could not read the batch inputs: ${getErrorMessage(e)}`);
+    return 2;
+  }
+  const concurrency = Math.max(
+    1,
+    options.concurrency ?? DEFAULT_BATCH_CONCURRENCY,
+  );
+  const retryOptions = {
+    retries: Math.max(0, options.retries ?? DEFAULT_BATCH_RETRIES),
+    retryDelayMs: options.retryDelayMs ?? DEFAULT_RETRY_DELAY_MS,
+  };
+
+  try {
+    await fs.promises.mkdir(path.dirname(path.resolve(options.out)), {
+      recursive: true,
+    });
+    await fs.promises.writeFile(options.out, '', 'utf8');
+  } catch (e) {
+    console.error(`Could not write ${options.out}: ${getErrorMessage(e)}`);
+    return 2;
+  }
+
+  console.log(
+    `Running ${inputs.length} prompts with ${config.getModel()}, ${concurrency} at a time`,
+  );
+  let next = 0;
+  let done = 0;
+  let failed = 0;
+  let writeError: unknown;
+  const abortController = new AbortController();
+  const onInterrupt = () => abortController.abort();
+  process.once('SIGINT', onInterrupt);
+  const worker = async () => {
+    while (
+      next < inputs.length &&
+      writeError === undefined &&
+      !abortController.signal.aborted
+    ) {
+      const index = next++;
+      const result = await runInput(
+        config,
+        inputs[index],
+        index,
+        template,
+        retryOptions,
+        abortController.signal,
+      );
+      done++;
+      try {
+        await fs.promises.appendFile(
+          options.out,
+          JSON.stringify(result) + '\n',
+          'utf8',
+        );
+      } catch (e) {
+        writeError = e;
+      }
+      const seconds = (result.durationMs / 1000).toFixed(1);
+      const attempts =
+        result.attempts > 1 ? `, ${result.attempts} attempts` : '';
+      if (result.error === undefined) {
+        console.log(
+          `[${done}/${inputs.length}] ✓ ${result.id} (${seconds}s${attempts})`,
+        );
+      } else {
+        failed++;
+        console.log(
+          `[${done}/${inputs.length}] ✗ ${result.id} (${seconds}s${attempts})\n    error: ${result.error}`,
+        );
+      }
+    }
+  };
+  try {
+    await Promise.all(
+      Array.from({ length: Math.min(concurrency, inputs.length) }, worker),
+    );
+  } finally {
+    process.off('SIGINT', onInterrupt);
+  }
+  if (writeError !== undefined) {
+    console.error(
+      `Could not write ${options.out}: ${getErrorMessage(writeError)}`,
+    );
+    return 2;
+  }
+  if (abortController.signal.aborted) {
+    console.log(
+      `\nInterrupted after ${done} of ${inputs.length} prompts. Wrote ${options.out}.`,
+    );
+    return 130;
+  }
+
+  console.log(
+    `\n${inputs.length - failed} of ${inputs.length} prompts answered. Wrote ${options.out}.`,
+  );
+  return failed === 0 ? 0 : 1;
+}
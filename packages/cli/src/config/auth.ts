@@ -11,7 +11,8 @@ export const validateAuthMethod = (authMethod: string): string | null => {
   loadEnvironment();
   if (
     authMethod === AuthType.LOGIN_WITH_GOOGLE ||
-    authMethod === AuthType.CLOUD_SHELL
+    authMethod === AuthType.CLOUD_SHELL ||
+    authMethod === AuthType.MOCK
   ) {
     return null;
   }
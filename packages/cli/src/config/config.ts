@@ -5,6 +5,7 @@
  */
 
 import yargs from 'yargs/yargs';
+import type { Options } from 'yargs';
 import { hideBin } from 'yargs/helpers';
 import process from 'node:process';
 import {
@@ -17,12 +18,23 @@ import {
   DEFAULT_RESEARCH_EMBEDDING_MODEL,
   FileDiscoveryService,
   TelemetryTarget,
+  parseRemoteTarget,
 } from '@iechor/research-cli-core';
 import { Settings } from './settings.js';
 
 import { Extension, filterActiveExtensions } from './extension.js';
-import { getCliVersion } from '../utils/version.js';
+import { getBuildInfo, getCliVersion } from '../utils/version.js';
+import {
+  COMPLETION_SHELLS,
+  CompletionShell,
+  generateCompletionScript,
+  generateManPage,
+} from '../utils/shellCompletion.js';
 import { loadSandboxConfig } from './sandboxConfig.js';
+import {
+  DEFAULT_BATCH_CONCURRENCY,
+  DEFAULT_BATCH_RETRIES,
+} from '../batchRunner.js';
 
 // Simple console logger for now - replace with actual logger if available
 const logger = {
@@ -54,126 +66,277 @@ export interface CliArgs {
   allowedMcpServerNames: string[] | undefined;
   extensions: string[] | undefined;
   listExtensions: boolean | undefined;
+  proxy: string | undefined;
+  incognito: boolean | undefined;
+  mock: string | undefined;
+  record: string | undefined;
+  remote: string | undefined;
+  profileCpu: string | undefined;
+  profileMem: string | undefined;
+  printOnExit: string | undefined;
+  /** The subcommand given, such as `eval` or `batch`. */
+  command: string | undefined;
+  /** Suite file of the `eval` command. */
+  suite: string | undefined;
+  junit: string | undefined;
+  json: string | undefined;
+  /** Inputs file of the `batch` command. */
+  prompts: string | undefined;
+  out: string | undefined;
+  template: string | undefined;
+  concurrency: number | undefined;
+  retries: number | undefined;
 }
 
-export async function parseArguments(): Promise<CliArgs> {
-  const yargsInstance = yargs(hideBin(process.argv))
-    .scriptName('research')
-    .usage('Usage: $0 [options]\n\nResearch CLI - Launch an interactive CLI, use -p/--prompt for non-interactive mode')
-    .option('model', {
+/**
+ * Command-line options. Kept as data so completion scripts and the man page
+ * can be generated from the same definitions the parser uses.
+ */
+export function getCliOptions(): Record<string, Options> {
+  return {
+    model: {
       alias: 'm',
       type: 'string',
       description: `Model`,
       default: process.env.RESEARCH_MODEL || DEFAULT_RESEARCH_MODEL,
-    })
-    .option('prompt', {
+    },
+    prompt: {
       alias: 'p',
       type: 'string',
       description: 'Prompt. Appended to input on stdin (if any).',
-    })
-    .option('prompt-interactive', {
+    },
+    'prompt-interactive': {
       alias: 'i',
       type: 'string',
       description:
         'Execute the provided prompt and continue in interactive mode',
-    })
-    .option('sandbox', {
+    },
+    sandbox: {
       alias: 's',
       type: 'boolean',
       description: 'Run in sandbox?',
-    })
-    .option('sandbox-image', {
+    },
+    'sandbox-image': {
       type: 'string',
       description: 'Sandbox image URI.',
-    })
-    .option('debug', {
+    },
+    debug: {
       alias: 'd',
       type: 'boolean',
       description: 'Run in debug mode?',
       default: false,
-    })
-    .option('all-files', {
+    },
+    'all-files': {
       alias: ['a'],
       type: 'boolean',
       description: 'Include ALL files in context?',
       default: false,
-    })
-    .option('all_files', {
+    },
+    all_files: {
       type: 'boolean',
       description: 'Include ALL files in context?',
       default: false,
-    })
-    .deprecateOption(
-      'all_files',
-      'Use --all-files instead. We will be removing --all_files in the coming weeks.',
-    )
-    .option('show-memory-usage', {
+      deprecated:
+        'Use --all-files instead. We will be removing --all_files in the coming weeks.',
+    },
+    'show-memory-usage': {
       type: 'boolean',
       description: 'Show memory usage in status bar',
       default: false,
-    })
-    .option('show_memory_usage', {
+    },
+    show_memory_usage: {
       type: 'boolean',
       description: 'Show memory usage in status bar',
       default: false,
-    })
-    .deprecateOption(
-      'show_memory_usage',
-      'Use --show-memory-usage instead. We will be removing --show_memory_usage in the coming weeks.',
-    )
-    .option('yolo', {
+      deprecated:
+        'Use --show-memory-usage instead. We will be removing --show_memory_usage in the coming weeks.',
+    },
+    yolo: {
       alias: 'y',
       type: 'boolean',
       description:
         'Automatically accept all actions (aka YOLO mode, see https://www.youtube.com/watch?v=xvFZjo5PgG0 for more details)?',
       default: false,
-    })
-    .option('telemetry', {
+    },
+    telemetry: {
       type: 'boolean',
       description:
         'Enable telemetry? This flag specifically controls if telemetry is sent. Other --telemetry-* flags set specific values but do not enable telemetry on their own.',
-    })
-    .option('telemetry-target', {
+    },
+    'telemetry-target': {
       type: 'string',
       choices: ['local', 'gcp'],
       description:
         'Set the telemetry target (local or gcp). Overrides settings files.',
-    })
-    .option('telemetry-otlp-endpoint', {
+    },
+    'telemetry-otlp-endpoint': {
       type: 'string',
       description:
         'Set the OTLP endpoint for telemetry. Overrides environment variables and settings files.',
-    })
-    .option('telemetry-log-prompts', {
+    },
+    'telemetry-log-prompts': {
       type: 'boolean',
       description:
         'Enable or disable logging of user prompts for telemetry. Overrides settings files.',
-    })
-    .option('checkpointing', {
+    },
+    checkpointing: {
       alias: 'c',
       type: 'boolean',
       description: 'Enables checkpointing of file edits',
       default: false,
-    })
-    .option('allowed-mcp-server-names', {
+    },
+    'allowed-mcp-server-names': {
       type: 'array',
       string: true,
       description: 'Allowed MCP server names',
-    })
-    .option('extensions', {
+    },
+    extensions: {
       alias: 'e',
       type: 'array',
       string: true,
       description:
         'A list of extensions to use. If not provided, all extensions are used.',
-    })
-    .option('list-extensions', {
+    },
+    'list-extensions': {
       alias: 'l',
       type: 'boolean',
       description: 'List all available extensions and exit.',
-    })
+    },
+    proxy: {
+      type: 'string',
+      description:
+        'Proxy for provider and tool requests, e.g. http://proxy.example.com:8080 or socks5://127.0.0.1:1080. Overrides settings and HTTPS_PROXY/HTTP_PROXY.',
+    },
+    incognito: {
+      type: 'boolean',
+      description:
+        'Start an incognito session: prompts, saved chats and checkpoints are never written to disk.',
+      default: false,
+    },
+    mock: {
+      type: 'string',
+      description:
+        'Replay canned responses instead of calling a provider. Optionally pass a JSON script of turns; no API key is needed.',
+    },
+    record: {
+      type: 'string',
+      description:
+        'Record provider requests and streamed responses, with secrets scrubbed, to this file. Replay it with --mock <file>.',
+    },
+    remote: {
+      type: 'string',
+      description:
+        'Run shell commands on an SSH host, given as [user@]host[:port][/path]. Overrides the remote setting.',
+    },
+    'print-on-exit': {
+      type: 'string',
+      description:
+        'Print the session to stdout on exit, as markdown (the default), text or prose, for scripts that pipe or capture it.',
+    },
+    'profile-cpu': {
+      type: 'string',
+      description:
+        'Write a V8 CPU profile to this file on exit (defaults to research-cpu-<time>.cpuprofile) and log slow UI frames.',
+    },
+    'profile-mem': {
+      type: 'string',
+      description:
+        'Write a sampling heap profile to this file on exit (defaults to research-heap-<time>.heapprofile) and log slow UI frames.',
+    },
+  };
+}
 
-    .version(await getCliVersion()) // This will enable the --version flag based on package.json
+export async function parseArguments(): Promise<CliArgs> {
+  const cliVersion = await getCliVersion();
+  const yargsInstance = yargs(hideBin(process.argv))
+    .scriptName('research')
+    .usage('Usage: $0 [options]\n\nResearch CLI - Launch an interactive CLI, use -p/--prompt for non-interactive mode')
+    .options(getCliOptions())
+    .command(
+      'completion <shell>',
+      'Print a shell completion script.',
+      (y) =>
+        y.positional('shell', {
+          choices: COMPLETION_SHELLS,
+          description: 'Target shell',
+        }),
+      (argv) => {
+        process.stdout.write(
+          generateCompletionScript(
+            argv.shell as CompletionShell,
+            'research',
+            getCliOptions(),
+          ),
+        );
+        process.exit(0);
+      },
+    )
+    .command(
+      'eval <suite>',
+      'Replay the scripted conversations of a YAML or JSON suite and check the tool calls and answers. Exits with 1 when a case fails.',
+      (y) =>
+        y
+          .positional('suite', {
+            type: 'string',
+            description: 'Suite file',
+          })
+          .option('junit', {
+            type: 'string',
+            description: 'Write a JUnit XML report to this file',
+          })
+          .option('json', {
+            type: 'string',
+            description: 'Write a JSON report to this file',
+          }),
+    )
+    .command(
+      'batch',
+      'Run each prompt of a JSONL file through the configured model, a few at a time, and write the answers to a JSONL file. Exits with 1 when a prompt fails.',
+      (y) =>
+        y
+          .option('prompts', {
+            type: 'string',
+            demandOption: true,
+            description:
+              'JSONL file of prompts: a string per line, or an object with a prompt or the template fields',
+          })
+          .option('out', {
+            type: 'string',
+            demandOption: true,
+            description: 'Write the results to this JSONL file',
+          })
+          .option('template', {
+            type: 'string',
+            description:
+              'Prompt file whose {{field}} placeholders are filled from each input',
+          })
+          .option('concurrency', {
+            type: 'number',
+            default: DEFAULT_BATCH_CONCURRENCY,
+            description: 'Prompts sent at the same time',
+          })
+          .option('retries', {
+            type: 'number',
+            default: DEFAULT_BATCH_RETRIES,
+            description: 'Further attempts for a prompt whose request failed',
+          })
+          .check((argv) => {
+            if (!Number.isInteger(argv.concurrency) || argv.concurrency < 1) {
+              throw new Error('--concurrency must be a whole number above 0');
+            }
+            if (!Number.isInteger(argv.retries) || argv.retries < 0) {
+              throw new Error('--retries must be a whole number from 0 up');
+            }
+            return true;
+          }),
+    )
+    .command('man', 'Print the man page (roff).', {}, () => {
+      process.stdout.write(
+        generateManPage('research', cliVersion, getCliOptions()),
+      );
+      process.exit(0);
+    })
+    .version(await getBuildInfo()) // Version plus commit, node and platform
     .alias('v', 'version')
     .help()
     .alias('h', 'help')
@@ -188,7 +351,8 @@ export async function parseArguments(): Promise<CliArgs> {
     });
 
   yargsInstance.wrap(yargsInstance.terminalWidth());
-  return yargsInstance.argv;
+  const argv = await yargsInstance.argv;
+  return { ...argv, command: argv._[0]?.toString() } as CliArgs;
 }
 
 // This function is now a thin wrapper around the server's implementation.
@@ -314,10 +478,23 @@ export async function loadCliConfig(
     },
     checkpointing: argv.checkpointing || settings.checkpointing?.enabled,
     proxy:
+      argv.proxy ||
+      settings.network?.proxy ||
       process.env.HTTPS_PROXY ||
       process.env.https_proxy ||
       process.env.HTTP_PROXY ||
       process.env.http_proxy,
+    network: settings.network,
+    modelFallbacks: settings.modelFallbacks,
+    redaction: settings.redaction,
+    incognito: argv.incognito,
+    mockScript: argv.mock || undefined,
+    recordFile: argv.record || undefined,
+    remote: argv.remote ? parseRemoteTarget(argv.remote) : settings.remote,
+    containerExecution: settings.containerExecution,
+    sqlDatabases: settings.databases,
+    httpRequest: settings.httpRequest,
+    calendar: settings.calendar,
     cwd: process.cwd(),
     fileDiscoveryService: fileService,
     bugCommand: settings.bugCommand,
@@ -4,10 +4,9 @@
  * SPDX-License-Identifier: Apache-2.0
  */
 
-import { MCPServerConfig } from '@iechor/research-cli-core';
+import { getUserResearchDir, MCPServerConfig } from '@iechor/research-cli-core';
 import * as fs from 'fs';
 import * as path from 'path';
-import * as os from 'os';
 
 export const EXTENSIONS_DIRECTORY_NAME = path.join('.research', 'extensions');
 export const EXTENSIONS_CONFIG_FILENAME = 'research-extension.json';
@@ -27,8 +26,8 @@ export interface ExtensionConfig {
 
 export function loadExtensions(workspaceDir: string): Extension[] {
   const allExtensions = [
-    ...loadExtensionsFromDir(workspaceDir),
-    ...loadExtensionsFromDir(os.homedir()),
+    ...loadExtensionsFromDir(path.join(workspaceDir, EXTENSIONS_DIRECTORY_NAME)),
+    ...loadExtensionsFromDir(path.join(getUserResearchDir(), 'extensions')),
   ];
 
   const uniqueExtensions = new Map<string, Extension>();
@@ -44,8 +43,7 @@ export function loadExtensions(workspaceDir: string): Extension[] {
   return Array.from(uniqueExtensions.values());
 }
 
-function loadExtensionsFromDir(dir: string): Extension[] {
-  const extensionsDir = path.join(dir, EXTENSIONS_DIRECTORY_NAME);
+function loadExtensionsFromDir(extensionsDir: string): Extension[] {
   if (!fs.existsSync(extensionsDir)) {
     return [];
   }
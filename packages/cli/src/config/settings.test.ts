@@ -183,6 +183,55 @@ describe('Settings Loading and Merging', () => {
       expect(settings.merged).toEqual(workspaceSettingsContent);
     });
 
+    it('should drop ssh options that run commands from workspace settings', () => {
+      (mockFsExistsSync as Mock).mockImplementation(
+        (p: fs.PathLike) => p === MOCK_WORKSPACE_SETTINGS_PATH,
+      );
+      (fs.readFileSync as Mock).mockReturnValue(
+        JSON.stringify({
+          remote: {
+            host: 'gpu01',
+            sshOptions: ['ConnectTimeout=10', 'ProxyCommand=sh -c id'],
+          },
+        }),
+      );
+      const warn = vi.spyOn(console, 'warn').mockImplementation(() => {});
+
+      const settings = loadSettings(MOCK_WORKSPACE_DIR);
+
+      expect(settings.merged.remote?.sshOptions).toEqual(['ConnectTimeout=10']);
+      expect(warn).toHaveBeenCalledWith(
+        expect.stringContaining('ProxyCommand=sh -c id'),
+      );
+    });
+
+    it('should warn about and drop redaction rules that do not compile', () => {
+      (mockFsExistsSync as Mock).mockImplementation(
+        (p: fs.PathLike) => p === USER_SETTINGS_PATH,
+      );
+      (fs.readFileSync as Mock).mockReturnValue(
+        JSON.stringify({
+          redaction: {
+            rules: [
+              { name: 'bad', pattern: '(' },
+              { name: 'mrn', pattern: 'MRN-\\d+' },
+            ],
+          },
+        }),
+      );
+      const warn = vi.spyOn(console, 'warn').mockImplementation(() => {});
+
+      const settings = loadSettings(MOCK_WORKSPACE_DIR);
+
+      expect(settings.errors).toEqual([]);
+      expect(settings.merged.redaction?.rules).toEqual([
+        { name: 'mrn', pattern: 'MRN-\\d+' },
+      ]);
+      expect(warn).toHaveBeenCalledWith(
+        expect.stringContaining("Invalid redaction rule 'bad'"),
+      );
+    });
+
     it('should merge user and workspace settings, with workspace taking precedence', () => {
       (mockFsExistsSync as Mock).mockReturnValue(true);
       const userSettingsContent = {
@@ -12,16 +12,32 @@ import {
   MCPServerConfig,
   RESEARCH_CONFIG_DIR as RESEARCH_DIR,
   getErrorMessage,
+  getUserResearchDir,
   BugCommandSettings,
   TelemetrySettings,
   AuthType,
+  NetworkSettings,
+  RedactionSettings,
+  RemoteTarget,
+  isSafeSshOption,
+  validateRedactionRules,
+  ContainerExecutionSettings,
+  SqlDatabaseConfig,
+  HttpRequestSettings,
+  WebhookConfig,
+  MailSettings,
+  CalendarSettings,
+  StorageSettings,
+  BackupSettings,
+  DataSyncSettings,
 } from '@iechor/research-cli-core';
 import stripJsonComments from 'strip-json-comments';
 import { DefaultLight } from '../ui/themes/default-light.js';
 import { DefaultDark } from '../ui/themes/default.js';
 
 export const SETTINGS_DIRECTORY_NAME = '.research';
-export const USER_SETTINGS_DIR = path.join(homedir(), SETTINGS_DIRECTORY_NAME);
+// `~/.research`, or `%APPDATA%\research` on Windows
+export const USER_SETTINGS_DIR = getUserResearchDir();
 export const USER_SETTINGS_PATH = path.join(USER_SETTINGS_DIR, 'settings.json');
 
 function getSystemSettingsPath(): string {
@@ -50,6 +66,90 @@ export interface AccessibilitySettings {
   disableLoadingPhrases?: boolean;
 }
 
+export interface DesktopNotificationSettings {
+  enabled?: boolean;
+  /** How long the terminal must be out of focus before notifying. */
+  afterSeconds?: number;
+}
+
+export interface SessionHistorySettings {
+  /** Defaults to true; false stops storing sessions for /resume. */
+  enabled?: boolean;
+  /** Sessions kept; the oldest are deleted. Defaults to 100. */
+  maxSessions?: number;
+}
+
+export interface PromptCheckSettings {
+  /** Defaults to true; false turns off every check. */
+  enabled?: boolean;
+  /** Warn about keys and passwords when redaction is off. */
+  secrets?: boolean;
+  /** Warn when the prompt and its attachments overflow the context. */
+  contextLimit?: boolean;
+  /** Warn about files the prompt names without attaching them. */
+  unattachedFiles?: boolean;
+  /** Warn when nothing is left of the prompt but @ paths. */
+  emptyPrompt?: boolean;
+}
+
+export interface ModelRoutingSettings {
+  /** Defaults to false; routing also needs both models. */
+  enabled?: boolean;
+  /** Model for short prompts on a short conversation. */
+  cheapModel?: string;
+  /** Model for everything else. */
+  premiumModel?: string;
+  /** Longest prompt, in characters, for the cheap model. Defaults to 500. */
+  maxCheapPromptLength?: number;
+  /** Most context tokens for the cheap model. Defaults to 16000. */
+  maxCheapContextTokens?: number;
+}
+
+export interface StartupScreenSettings {
+  /** Sections in the order they are shown; see DEFAULT_STARTUP_SECTIONS. */
+  sections?: string[];
+  /** Message of the day, shown by the `motd` section. */
+  motd?: string;
+}
+
+export interface MessageDisplaySettings {
+  /** Blank lines between messages; unset keeps the classic spacing. */
+  spacing?: number;
+  /** `symbols`, `names`, `initials` or `colors`. */
+  roleLabels?: string;
+  /** Drops borders and padding around messages. */
+  compact?: boolean;
+}
+
+export interface ModelStyle {
+  /** A color name, hex color, or theme color such as `AccentCyan`. */
+  color?: string;
+  /** Replaces the role label of the model's answers. */
+  prefix?: string;
+}
+
+export interface ModelStyleSettings {
+  /** Styles by model name; `*` matches any characters. */
+  models?: Record<string, ModelStyle>;
+  /** Styles for single themes, by theme name, over `models`. */
+  themes?: Record<string, Record<string, ModelStyle>>;
+}
+
+export interface StatusBarThemeSettings {
+  /** `powerline` draws segments; see resolveStatusBar for the fallback. */
+  style?: 'plain' | 'powerline';
+  /** Backgrounds of the segments, repeated as needed. */
+  segmentColors?: string[];
+  textColor?: string;
+}
+
+export interface StatusBarSettings extends StatusBarThemeSettings {
+  /** Confirms that the terminal font has the Nerd Font glyphs. */
+  nerdFont?: boolean;
+  /** Overrides for single themes, by theme name. */
+  themes?: Record<string, StatusBarThemeSettings>;
+}
+
 export interface Settings {
   theme?: string;
   selectedAuthType?: AuthType;
@@ -70,6 +170,54 @@ export interface Settings {
   checkpointing?: CheckpointingSettings;
   autoConfigureMaxOldSpaceSize?: boolean;
 
+  // Proxy and TLS settings for provider and tool HTTP clients.
+  network?: NetworkSettings;
+
+  // Models to retry on, in order, when the selected model errors or is rate-limited.
+  modelFallbacks?: string[];
+
+  // Redaction rules applied to prompts sent to remote providers and to logs.
+  redaction?: RedactionSettings;
+
+  // SSH host that shell commands run on instead of this machine.
+  remote?: RemoteTarget;
+
+  // Container that AI-run code and selected tools execute in.
+  containerExecution?: ContainerExecutionSettings;
+
+  // Databases the sql_query tool may read, by name.
+  databases?: Record<string, SqlDatabaseConfig>;
+
+  // Hosts the http_request tool may call.
+  httpRequest?: HttpRequestSettings;
+
+  // Days before a deadline from /deadlines that the footer starts warning.
+  deadlineWarningDays?: number;
+
+  // Chat channels /send-to can post to, by name.
+  webhooks?: Record<string, WebhookConfig>;
+
+  // Sender, recipients and transport (SMTP or sendmail) for /mail.
+  mail?: MailSettings;
+
+  // Where create_calendar_event writes follow-ups: .ics folder and CalDAV.
+  calendar?: CalendarSettings;
+
+  // Keeps token, tool and latency counts for /dashboard. Defaults to true.
+  usageHistory?: boolean;
+
+  // Shows the current and longest streak of active days in /dashboard.
+  activityStreaks?: boolean;
+
+  // Quota and retention of the data kept for each workspace, for /storage.
+  storage?: StorageSettings;
+
+  // Where /backup writes to, and how often backups are made at startup.
+  backup?: BackupSettings;
+
+  // Git remote or S3 bucket that /datasync syncs sessions and notes with.
+  dataSync?: DataSyncSettings;
+
   // Git-aware file filtering settings
   fileFiltering?: {
     respectGitIgnore?: boolean;
@@ -78,12 +226,48 @@ export interface Settings {
 
   // UI setting. Does not display the ANSI-controlled terminal title.
   hideWindowTitle?: boolean;
+  // Terminal title template with {project}, {title} and {state}.
+  windowTitle?: string;
   hideTips?: boolean;
   hideBanner?: boolean;
 
+  // Spacing, role labels and compact mode of the conversation.
+  messages?: MessageDisplaySettings;
+
+  // Colors and prefixes of answers by model, and per-theme overrides.
+  modelStyles?: ModelStyleSettings;
+
+  // Keeps the terminal cursor in the input box for input methods (IME).
+  // Defaults to on for Chinese, Japanese and Korean locales.
+  imeMode?: boolean;
+
+  // Powerline style for the status bar, and per-theme overrides.
+  statusBar?: StatusBarSettings;
+
+  // Notifies when a response finishes while the terminal is out of focus.
+  desktopNotifications?: DesktopNotificationSettings;
+
+  // Checks run on a prompt before it is sent; see PromptCheckSettings.
+  promptChecks?: PromptCheckSettings;
+
+  // Sends simple prompts to a cheap model and the rest to a premium one.
+  modelRouting?: ModelRoutingSettings;
+
+  // Sections shown under the banner at startup, and a message of the day.
+  startupScreen?: StartupScreenSettings;
+
+  // Opt-in check for new GitHub releases at startup, for standalone installs.
+  checkForUpdates?: boolean;
+
   // Setting for setting maximum number of user/model/tool turns in a session.
   maxSessionTurns?: number;
 
+  // Stores the messages of each session for /resume after a restart.
+  sessionHistory?: SessionHistorySettings;
+
+  // Number of history items kept in memory; older ones are paged to disk.
+  maxHistoryItems?: number;
+
   // Research-specific settings
   research?: import('@iechor/research-cli-core').ResearchSettings;
 
@@ -215,7 +399,7 @@ function findEnvFile(startDir: string): string | null {
     const parentDir = path.dirname(currentDir);
     if (parentDir === currentDir || !parentDir) {
       // check .env under home as fallback, again preferring research-specific .env
-      const homeResearchEnvPath = path.join(homedir(), RESEARCH_DIR, '.env');
+      const homeResearchEnvPath = path.join(USER_SETTINGS_DIR, '.env');
       if (fs.existsSync(homeResearchEnvPath)) {
         return homeResearchEnvPath;
       }
@@ -267,6 +451,21 @@ export function loadEnvironment(): void {
  * Loads settings from user and workspace directories.
  * Project settings override user settings.
  */
+/**
+ * Drops redaction rules that do not compile, with a warning, so that a
+ * typo in a pattern does not stop the CLI from starting.
+ */
+function dropInvalidRedactionRules(settings: Settings, filePath: string) {
+  if (!settings.redaction?.rules) {
+    return;
+  }
+  const { valid, errors } = validateRedactionRules(settings.redaction.rules);
+  for (const message of errors) {
+    console.warn(`[WARN] ${message} (${filePath}); the rule is ignored.`);
+  }
+  settings.redaction.rules = valid;
+}
+
 export function loadSettings(workspaceDir: string): LoadedSettings {
   loadEnvironment();
   let systemSettings: Settings = {};
@@ -334,6 +533,18 @@ export function loadSettings(workspaceDir: string): LoadedSettings {
       ) {
         workspaceSettings.theme = DefaultDark.name;
       }
+      // A project's settings come with its code, so they may not pass ssh
+      // options that run commands here.
+      const remote = workspaceSettings.remote;
+      const refused =
+        remote?.sshOptions?.filter((option) => !isSafeSshOption(option)) ??
+        [];
+      if (remote && refused.length > 0) {
+        console.warn(
+          `[WARN] Ignoring ssh options not allowed in project settings (${workspaceSettingsPath}): ${refused.join(', ')}. Set them in ${USER_SETTINGS_PATH} instead.`,
+        );
+        remote.sshOptions = remote.sshOptions?.filter(isSafeSshOption);
+      }
     }
   } catch (error: unknown) {
     settingsErrors.push({
@@ -342,6 +553,10 @@ export function loadSettings(workspaceDir: string): LoadedSettings {
     });
   }
 
+  dropInvalidRedactionRules(systemSettings, SYSTEM_SETTINGS_PATH);
+  dropInvalidRedactionRules(userSettings, USER_SETTINGS_PATH);
+  dropInvalidRedactionRules(workspaceSettings, workspaceSettingsPath);
+
   return new LoadedSettings(
     {
       path: SYSTEM_SETTINGS_PATH,
@@ -0,0 +1,144 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, vi, beforeEach, afterEach } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { Config, executeToolCall } from '@iechor/research-cli-core';
+import { GenerateContentResponse } from '@google/genai';
+import { runEvalSuite } from './evalSuite.js';
+
+vi.mock('@iechor/research-cli-core', async (importOriginal) => {
+  const actual =
+    await importOriginal<typeof import('@iechor/research-cli-core')>();
+  return { ...actual, executeToolCall: vi.fn() };
+});
+
+const SUITE = `
+name: Citations
+cases:
+  - name: looks up the DOI
+    turns:
+      - prompt: Find the DOI
+        expect:
+          tools: [{ name: web_search, args: { query: attention } }]
+          schema: { type: object, required: [doi] }
+  - name: stays offline
+    prompt: Answer from memory
+    expect:
+      notTools: [web_search]
+      contains: [Vaswani]
+`;
+
+function stream(...responses: GenerateContentResponse[]) {
+  return (async function* () {
+    yield* responses;
+  })();
+}
+
+describe('runEvalSuite', () => {
+  let tempDir: string;
+  let sendMessageStream: ReturnType<typeof vi.fn>;
+  let config: Config;
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'eval-suite-'));
+    fs.writeFileSync(path.join(tempDir, 'suite.yaml'), SUITE);
+    vi.spyOn(console, 'log').mockImplementation(() => {});
+    vi.spyOn(console, 'error').mockImplementation(() => {});
+    sendMessageStream = vi.fn();
+    const chat = { sendMessageStream };
+    config = {
+      getResearchClient: () => ({
+        getChat: () => chat,
+        resetChat: vi.fn(),
+      }),
+      getToolRegistry: async () => ({ getFunctionDeclarations: () => [] }),
+      getMaxSessionTurns: () => 10,
+    } as unknown as Config;
+    vi.mocked(executeToolCall).mockResolvedValue({
+      callId: 'fc1',
+      responseParts: { text: 'search results' },
+      resultDisplay: undefined,
+      error: undefined,
+    });
+  });
+
+  afterEach(() => {
+    vi.restoreAllMocks();
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should run tools, check each case and write the reports', async () => {
+    sendMessageStream
+      .mockResolvedValueOnce(
+        stream({
+          functionCalls: [
+            {
+              id: 'fc1',
+              name: 'web_search',
+              args: { query: 'attention is all you need' },
+            },
+          ],
+        } as GenerateContentResponse),
+      )
+      .mockResolvedValueOnce(
+        stream({
+          candidates: [
+            { content: { parts: [{ text: '{"doi": "10.48550/x"}' }] } },
+          ],
+        } as GenerateContentResponse),
+      )
+      .mockResolvedValueOnce(
+        stream({
+          candidates: [{ content: { parts: [{ text: 'I do not know.' }] } }],
+        } as GenerateContentResponse),
+      );
+    const junit = path.join(tempDir, 'reports', 'evals.xml');
+    const json = path.join(tempDir, 'evals.json');
+
+    const exitCode = await runEvalSuite(
+      config,
+      path.join(tempDir, 'suite.yaml'),
+      { junit, json },
+    );
+
+    expect(exitCode).toBe(1);
+    expect(executeToolCall).toHaveBeenCalledTimes(1);
+    expect(sendMessageStream.mock.calls[1][0].message).toEqual([
+      { text: 'search results' },
+    ]);
+    const report = JSON.parse(fs.readFileSync(json, 'utf8'));
+    expect(report.cases).toEqual([
+      expect.objectContaining({ name: 'looks up the DOI', failures: [] }),
+      expect.objectContaining({
+        name: 'stays offline',
+        failures: ['expected the answer to contain "Vaswani"'],
+      }),
+    ]);
+    expect(fs.readFileSync(junit, 'utf8')).toContain(
+      '<failure message="expected the answer to contain &quot;Vaswani&quot;">',
+    );
+  });
+
+  it('should report a case that fails to run as an error', async () => {
+    sendMessageStream.mockRejectedValue(new Error('quota exceeded'));
+    const json = path.join(tempDir, 'evals.json');
+
+    expect(
+      await runEvalSuite(config, path.join(tempDir, 'suite.yaml'), { json }),
+    ).toBe(1);
+    const report = JSON.parse(fs.readFileSync(json, 'utf8'));
+    expect(report.cases[0]).toMatchObject({ error: 'quota exceeded' });
+  });
+
+  it('should exit with 2 when the suite cannot be read', async () => {
+    expect(await runEvalSuite(config, path.join(tempDir, 'missing.yaml'))).toBe(
+      2,
+    );
+  });
+});
@@ -0,0 +1,220 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import {
+  BehaviorCase,
+  BehaviorCaseResult,
+  BehaviorSuiteResult,
+  BehaviorTurnOutput,
+  Config,
+  ToolCallRequestInfo,
+  checkBehaviorTurn,
+  executeToolCall,
+  formatJUnitReport,
+  getErrorMessage,
+  isBehaviorCasePassed,
+  parseBehaviorSuite,
+} from '@iechor/research-cli-core';
+import { FunctionCall, Part } from '@google/genai';
+
+export interface EvalSuiteOptions {
+  /** Write a JUnit XML report to this file. */
+  junit?: string;
+  /** Write a JSON report to this file. */
+  json?: string;
+}
+
+/**
+ * Sends one prompt and runs the tool calls it leads to, like the
+ * non-interactive mode, and records the final text and every tool call.
+ */
+async function runTurn(
+  config: Config,
+  prompt: string,
+  promptId: string,
+  abortSignal: AbortSignal,
+): Promise<BehaviorTurnOutput> {
+  const chat = await config.getResearchClient().getChat();
+  const toolRegistry = await config.getToolRegistry();
+  const output: BehaviorTurnOutput = { text: '', toolCalls: [] };
+  let message: Part[] = [{ text: prompt }];
+  for (let round = 1; ; round++) {
+    if (
+      config.getMaxSessionTurns() > 0 &&
+      round > config.getMaxSessionTurns()
+    ) {
+      throw new Error('Reached the maximum number of session turns');
+    }
+    const functionCalls: FunctionCall[] = [];
+    let text = '';
+    const responseStream = await chat.sendMessageStream(
+      {
+        message,
+        config: {
+          abortSignal,
+          tools: [
+            { functionDeclarations: toolRegistry.getFunctionDeclarations() },
+          ],
+        },
+      },
+      promptId,
+    );
+    for await (const response of responseStream) {
+      for (const part of response.candidates?.[0]?.content?.parts ?? []) {
+        if (part.text && !part.thought) {
+          text += part.text;
+        }
+      }
+      functionCalls.push(...(response.functionCalls ?? []));
+    }
+    if (text) {
+      output.text = text;
+    }
+    if (functionCalls.length === 0) {
+      return output;
+    }
+
+    const responseParts: Part[] = [];
+    for (const fc of functionCalls) {
+      const args = (fc.args ?? {}) as Record<string, unknown>;
+      output.toolCalls.push({ name: fc.name as string, args });
+      const requestInfo: ToolCallRequestInfo = {
+        callId: fc.id ?? `${fc.name}-${Date.now()}`,
+        name: fc.name as string,
+        args,
+        isClientInitiated: false,
+        prompt_id: promptId,
+      };
+      // A failing tool is sent back to the model, as in a real session
+      const toolResponse = await executeToolCall(
+        config,
+        requestInfo,
+        toolRegistry,
+        abortSignal,
+      );
+      const parts = Array.isArray(toolResponse.responseParts)
+        ? toolResponse.responseParts
+        : [toolResponse.responseParts];
+      for (const part of parts) {
+        if (typeof part === 'string') {
+          responseParts.push({ text: part });
+        } else if (part) {
+          responseParts.push(part);
+        }
+      }
+    }
+    message = responseParts;
+  }
+}
+
+async function runCase(
+  config: Config,
+  behaviorCase: BehaviorCase,
+): Promise<BehaviorCaseResult> {
+  const start = Date.now();
+  const result: BehaviorCaseResult = {
+    name: behaviorCase.name,
+    durationMs: 0,
+    failures: [],
+    turns: [],
+  };
+  const abortController = new AbortController();
+  try {
+    // Every case starts from a fresh conversation
+    await config.getResearchClient().resetChat();
+    const promptId = Math.random().toString(16).slice(2);
+    for (const [i, turn] of behaviorCase.turns.entries()) {
+      const output = await runTurn(
+        config,
+        turn.prompt,
+        promptId,
+        abortController.signal,
+      );
+      result.turns.push(output);
+      const prefix = behaviorCase.turns.length > 1 ? `turn ${i + 1}: ` : '';
+      result.failures.push(
+        ...checkBehaviorTurn(turn.expect, output).map((f) => prefix + f),
+      );
+    }
+  } catch (e) {
+    result.error = getErrorMessage(e);
+  }
+  result.durationMs = Date.now() - start;
+  return result;
+}
+
+/**
+ * Replays the scripted conversations of a suite file against the current
+ * configuration, prints a summary and writes the requested reports.
+ * Returns the process exit code: 0 when every case passed.
+ */
+export async function runEvalSuite(
+  config: Config,
+  suitePath: string,
+  options: EvalSuiteOptions = {},
+): Promise<number> {
+  let suite;
+  try {
+    suite = parseBehaviorSuite(
+      await fs.promises.readFile(suitePath, 'utf8'),
+      suitePath,
+    );
+  } catch (e) {
+    console.error(
+      `Could not read the suite ${suitePath}: ${getErrorMessage(e)}`,
+    );
+    return 2;
+  }
+
+  const started = new Date();
+  const result: BehaviorSuiteResult = {
+    name: suite.name,
+    file: suitePath,
+    started: started.toISOString(),
+    durationMs: 0,
+    cases: [],
+  };
+  console.log(`Running ${suite.cases.length} cases from ${suite.name}`);
+  for (const behaviorCase of suite.cases) {
+    const caseResult = await runCase(config, behaviorCase);
+    result.cases.push(caseResult);
+    const mark = isBehaviorCasePassed(caseResult) ? '✓' : '✗';
+    const seconds = (caseResult.durationMs / 1000).toFixed(1);
+    console.log(`${mark} ${caseResult.name} (${seconds}s)`);
+    if (caseResult.error) {
+      console.log(`    error: ${caseResult.error}`);
+    }
+    for (const failure of caseResult.failures) {
+      console.log(`    ${failure}`);
+    }
+  }
+  result.durationMs = Date.now() - started.getTime();
+
+  const passed = result.cases.filter(isBehaviorCasePassed).length;
+  console.log(`\n${passed} of ${result.cases.length} cases passed.`);
+  const reports: Array<[string | undefined, () => string]> = [
+    [options.junit, () => formatJUnitReport(result)],
+    [options.json, () => JSON.stringify(result, null, 2)],
+  ];
+  for (const [file, format] of reports) {
+    if (!file) {
+      continue;
+    }
+    try {
+      await fs.promises.mkdir(path.dirname(path.resolve(file)), {
+        recursive: true,
+      });
+      await fs.promises.writeFile(file, format(), 'utf8');
+      console.log(`Wrote ${file}.`);
+    } catch (e) {
+      console.error(`Could not write ${file}: ${getErrorMessage(e)}`);
+      return 2;
+    }
+  }
+  return passed === result.cases.length ? 0 : 1;
+}
@@ -4,7 +4,7 @@
  * SPDX-License-Identifier: Apache-2.0
  */
 
-import React from 'react';
+import React, { Profiler } from 'react';
 import { render } from 'ink';
 import { AppWrapper } from './ui/App.js';
 import { loadCliConfig, parseArguments, CliArgs } from './config/config.js';
@@ -23,10 +23,31 @@ import {
 import { themeManager } from './ui/themes/theme-manager.js';
 import { getStartupWarnings } from './utils/startupWarnings.js';
 import { getUserStartupWarnings } from './utils/userStartupWarnings.js';
+import {
+  DEFAULT_WINDOW_TITLE,
+  formatWindowTitle,
+} from './ui/utils/windowTitle.js';
+import {
+  composeStartupScreen,
+  getStartupProviders,
+} from './ui/utils/startupScreen.js';
 import { runNonInteractive } from './nonInteractiveCli.js';
+import { runEvalSuite } from './evalSuite.js';
+import { runBatch } from './batchRunner.js';
 import { loadExtensions, Extension } from './config/extension.js';
 import { cleanupCheckpoints, registerCleanup } from './utils/cleanup.js';
+import {
+  parsePrintFormat,
+  registerPrintOnExit,
+  setPrintOnExit,
+} from './utils/printOnExit.js';
 import { getCliVersion } from './utils/version.js';
+import {
+  getSessionRecorder,
+  setRecordedStream,
+} from './ui/utils/sessionRecorder.js';
+import { FrameTimeTracker, startProfiling } from './utils/profiling.js';
+import { getStoredSecretNames } from './ui/commands/api/index.js';
 import {
   ApprovalMode,
   Config,
@@ -37,6 +58,11 @@ import {
   logUserPrompt,
   AuthType,
   getOauthClient,
+  getUsageHistoryPath,
+  setUsageHistory,
+  cleanupWorkspaceStorage,
+  runScheduledBackup,
+  applyStoredSecrets,
 } from '@iechor/research-cli-core';
 import { validateAuthMethod } from './config/auth.js';
 import { setMaxSizedBoxDebugging } from './ui/components/shared/MaxSizedBox.js';
@@ -102,7 +128,15 @@ export async function main() {
     process.exit(1);
   }
 
+  // On Windows, API keys and passwords can be kept in the Credential Manager
+  try {
+    await applyStoredSecrets(getStoredSecretNames());
+  } catch (e) {
+    console.debug('Could not read the Credential Manager:', e);
+  }
+
   const argv = await parseArguments();
+  startProfiling({ cpu: argv.profileCpu, mem: argv.profileMem });
   const extensions = loadExtensions(workspaceRoot);
   const config = await loadCliConfig(
     settings.merged,
@@ -126,6 +160,11 @@ export async function main() {
     process.exit(0);
   }
 
+  if (argv.mock !== undefined) {
+    // Mock mode replaces auth for this run only; settings files are untouched.
+    settings.merged.selectedAuthType = AuthType.MOCK;
+  }
+
   // Set a default auth type if one isn't set.
   if (!settings.merged.selectedAuthType) {
     if (process.env.CLOUD_SHELL === 'true') {
@@ -141,6 +180,31 @@ export async function main() {
 
   await config.initialize();
 
+  if (settings.merged.usageHistory !== false) {
+    setUsageHistory(
+      getUsageHistoryPath(),
+      config.getTargetDir(),
+      config.getSessionId(),
+    );
+  }
+
+  if (settings.merged.storage) {
+    // Archives old sessions and prunes caches without delaying the start;
+    // the workspace is only searched for PDFs by /storage
+    cleanupWorkspaceStorage(
+      config.getTargetDir(),
+      settings.merged.storage,
+    ).catch((e) =>
+      console.debug('Could not clean up the workspace storage:', e),
+    );
+  }
+
+  if (settings.merged.backup?.intervalDays !== undefined) {
+    runScheduledBackup(config.getTargetDir(), settings.merged.backup).catch(
+      (e) => console.debug('Could not make the scheduled backup:', e),
+    );
+  }
+
   if (settings.merged.theme) {
     if (!themeManager.setActiveTheme(settings.merged.theme)) {
       // If the theme is not found during initial load, log a warning and continue.
@@ -189,6 +253,41 @@ export async function main() {
     await getOauthClient(settings.merged.selectedAuthType, config);
   }
 
+  if (argv.command === 'eval' && argv.suite) {
+    // Evals run headless, with the same tools as the non-interactive mode
+    const evalConfig = await loadNonInteractiveConfig(
+      config,
+      extensions,
+      settings,
+      argv,
+    );
+    process.exit(
+      await runEvalSuite(evalConfig, argv.suite, {
+        junit: argv.junit,
+        json: argv.json,
+      }),
+    );
+  }
+
+  if (argv.command === 'batch' && argv.prompts && argv.out) {
+    // Batches run headless; each prompt is a conversation of its own
+    const batchConfig = await loadNonInteractiveConfig(
+      config,
+      extensions,
+      settings,
+      argv,
+    );
+    process.exit(
+      await runBatch(batchConfig, {
+        prompts: argv.prompts,
+        out: argv.out,
+        template: argv.template,
+        concurrency: argv.concurrency,
+        retries: argv.retries,
+      }),
+    );
+  }
+
   let input = config.getQuestion();
   const startupWarnings = [
     ...(await getStartupWarnings()),
@@ -202,19 +301,78 @@ export async function main() {
   if (shouldBeInteractive) {
     const version = await getCliVersion();
     setWindowTitle(basename(workspaceRoot), settings);
+    const startupScreen = await composeStartupScreen(
+      {
+        projectRoot: config.getTargetDir(),
+        sessionId: config.getSessionId(),
+        motd: settings.merged.startupScreen?.motd,
+        now: new Date(),
+      },
+      settings.merged.startupScreen?.sections,
+    );
+    for (const id of startupScreen.unknown) {
+      startupWarnings.push(
+        `Unknown startup screen section "${id}" in settings. Known sections: ${getStartupProviders()
+          .map((p) => p.id)
+          .join(', ')}.`,
+      );
+    }
+    const app = (
+      <AppWrapper
+        config={config}
+        settings={settings}
+        startupWarnings={startupWarnings}
+        startupScreen={startupScreen.sections}
+        version={version}
+      />
+    );
+    // Profiling runs also track how long each UI commit takes.
+    const frameTracker =
+      argv.profileCpu !== undefined || argv.profileMem !== undefined
+        ? new FrameTimeTracker()
+        : undefined;
+    if (frameTracker) {
+      process.once('exit', () =>
+        process.stderr.write(`${frameTracker.formatSummary()}\n`),
+      );
+    }
+    if (argv.printOnExit !== undefined) {
+      const format = parsePrintFormat(argv.printOnExit);
+      if (!format) {
+        console.error(
+          `Unknown --print-on-exit format "${argv.printOnExit}". Use markdown or text.`,
+        );
+        process.exit(1);
+      }
+      setPrintOnExit(format);
+    }
+    // When a script captures stdout for the transcript, draw on stderr
+    const uiStream =
+      argv.printOnExit !== undefined && !process.stdout.isTTY
+        ? process.stderr
+        : process.stdout;
+    setRecordedStream(uiStream);
     const instance = render(
       <React.StrictMode>
-        <AppWrapper
-          config={config}
-          settings={settings}
-          startupWarnings={startupWarnings}
-          version={version}
-        />
+        {frameTracker ? (
+          <Profiler id="App" onRender={frameTracker.onRender}>
+            {app}
+          </Profiler>
+        ) : (
+          app
+        )}
       </React.StrictMode>,
-      { exitOnCtrlC: false },
+      {
+        exitOnCtrlC: false,
+        stdout: uiStream,
+      },
     );
+    // Registered after the UI, so the transcript follows its last frame
+    registerPrintOnExit(config);
 
     registerCleanup(() => instance.unmount());
+    // After the unmount, so the recording ends with the last frame
+    registerCleanup(() => getSessionRecorder().stop());
     return;
   }
   // If not a TTY, read from stdin
@@ -251,12 +409,12 @@ export async function main() {
 
 function setWindowTitle(title: string, settings: LoadedSettings) {
   if (!settings.merged.hideWindowTitle) {
-    const windowTitle = (
-      process.env.CLI_TITLE || `Research - ${title}`
-    ).replace(
-      // eslint-disable-next-line no-control-regex
-      /[\x00-\x1F\x7F]/g,
-      '',
+    // The app keeps it up to date once the session has a title
+    const windowTitle = formatWindowTitle(
+      process.env.CLI_TITLE ||
+        settings.merged.windowTitle ||
+        DEFAULT_WINDOW_TITLE,
+      { project: title, title, state: 'idle' },
     );
     process.stdout.write(`\x1b]2;${windowTitle}\x07`);
 
@@ -49,8 +49,8 @@ describe('CommandService', () => {
         await commandService.loadCommands();
         const tree = commandService.getCommands();
 
-        // Post-condition assertions - now includes more commands (7 core + 5 research + 2 panel = 14)
-        expect(tree.length).toBe(14);
+        // Post-condition assertions - now includes more commands (58 core + 5 research + 2 panel = 65)
+        expect(tree.length).toBe(65);
 
         const commandNames = tree.map((cmd) => cmd.name);
         expect(commandNames).toContain('memory');
@@ -67,14 +67,14 @@ describe('CommandService', () => {
       it('should overwrite any existing commands when called again', async () => {
         // Load once
         await commandService.loadCommands();
-        expect(commandService.getCommands().length).toBe(14);
+        expect(commandService.getCommands().length).toBe(65);
 
         // Load again
         await commandService.loadCommands();
         const tree = commandService.getCommands();
 
         // Should not append, but overwrite
-        expect(tree.length).toBe(14);
+        expect(tree.length).toBe(65);
       });
     });
 
@@ -86,7 +86,7 @@ describe('CommandService', () => {
         await commandService.loadCommands();
 
         const loadedTree = commandService.getCommands();
-        expect(loadedTree.length).toBe(14);
+        expect(loadedTree.length).toBe(65);
         // Just check that the core commands are present
         // Research commands are tested separately
         const commandNames = loadedTree.map((cmd) => cmd.name);
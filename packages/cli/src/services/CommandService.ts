@@ -6,11 +6,62 @@
 
 import { SlashCommand } from '../ui/commands/types.js';
 import { memoryCommand } from '../ui/commands/memoryCommand.js';
+import { redactCommand } from '../ui/commands/redactCommand.js';
+import { historyCommand } from '../ui/commands/historyCommand.js';
+import { updateCommand } from '../ui/commands/updateCommand.js';
+import { kernelCommand } from '../ui/commands/kernelCommand.js';
+import { notebookCommand } from '../ui/commands/notebookCommand.js';
+import { fetchCommand } from '../ui/commands/fetchCommand.js';
+import { wikiCommand } from '../ui/commands/wikiCommand.js';
+import { bibCommand } from '../ui/commands/bibCommand.js';
+import { deadlinesCommand } from '../ui/commands/deadlinesCommand.js';
+import { figuresCommand } from '../ui/commands/figuresCommand.js';
+import { comparePapersCommand } from '../ui/commands/comparePapersCommand.js';
+import { relatedWorkCommand } from '../ui/commands/relatedWorkCommand.js';
+import { reviewCommand } from '../ui/commands/reviewCommand.js';
+import { rebuttalCommand } from '../ui/commands/rebuttalCommand.js';
+import { overlapCommand } from '../ui/commands/overlapCommand.js';
+import { latexCommand } from '../ui/commands/latexCommand.js';
+import { citeCommand } from '../ui/commands/citeCommand.js';
+import { syncCommand } from '../ui/commands/syncCommand.js';
+import { wordcountCommand } from '../ui/commands/wordcountCommand.js';
+import { readabilityCommand } from '../ui/commands/readabilityCommand.js';
+import { glossaryCommand } from '../ui/commands/glossaryCommand.js';
+import { hubCommand } from '../ui/commands/hubCommand.js';
+import { repoCommand } from '../ui/commands/repoCommand.js';
+import { reviewDiffCommand } from '../ui/commands/reviewDiffCommand.js';
+import { exportCommand } from '../ui/commands/exportCommand.js';
+import { diffSessionCommand } from '../ui/commands/diffSessionCommand.js';
+import { resumeCommand } from '../ui/commands/resumeCommand.js';
+import { sessionCommand } from '../ui/commands/sessionCommand.js';
+import { forceModelCommand } from '../ui/commands/forceModelCommand.js';
+import { setCommand } from '../ui/commands/setCommand.js';
+import { sendToCommand } from '../ui/commands/sendToCommand.js';
+import { mailCommand } from '../ui/commands/mailCommand.js';
+import { todoCommand } from '../ui/commands/todoCommand.js';
+import { rateCommand } from '../ui/commands/rateCommand.js';
+import { feedbackCommand } from '../ui/commands/feedbackCommand.js';
+import { abCommand } from '../ui/commands/abCommand.js';
+import { dashboardCommand } from '../ui/commands/dashboardCommand.js';
+import { storageCommand } from '../ui/commands/storageCommand.js';
+import { backupCommand } from '../ui/commands/backupCommand.js';
+import { dataSyncCommand } from '../ui/commands/dataSyncCommand.js';
+import { presentCommand } from '../ui/commands/presentCommand.js';
+import { threadCommand } from '../ui/commands/threadCommand.js';
+import { contextCommand } from '../ui/commands/contextCommand.js';
+import { terminologyCommand } from '../ui/commands/terminologyCommand.js';
+import { outlineCommand } from '../ui/commands/outlineCommand.js';
+import { focusCommand } from '../ui/commands/focusCommand.js';
+import { printCommand } from '../ui/commands/printCommand.js';
+import { copyCommand } from '../ui/commands/copyCommand.js';
+import { shareCommand } from '../ui/commands/shareCommand.js';
+import { recordCommand } from '../ui/commands/recordCommand.js';
 import { helpCommand } from '../ui/commands/helpCommand.js';
 import { aboutCommand } from '../ui/commands/aboutCommand.js';
 import { clearCommand } from '../ui/commands/clearCommand.js';
 import { themeCommand } from '../ui/commands/themeCommand.js';
 import { modelCommand } from '../ui/commands/model/index.js';
+import { modelsCommand } from '../ui/commands/model/modelsCommand.js';
 import { apiCommand } from '../ui/commands/api/index.js';
 import { allResearchCommands } from '../ui/commands/research/index.js';
 import { configPanelCommand, docsPanelCommand } from '../ui/commands/panel/index.js';
@@ -20,8 +71,59 @@ const loadBuiltInCommands = async (): Promise<SlashCommand[]> => [
   helpCommand,
   aboutCommand,
   memoryCommand,
+  historyCommand,
   themeCommand,
   modelCommand,
+  modelsCommand,
+  redactCommand,
+  updateCommand,
+  kernelCommand,
+  notebookCommand,
+  fetchCommand,
+  wikiCommand,
+  bibCommand,
+  deadlinesCommand,
+  figuresCommand,
+  comparePapersCommand,
+  relatedWorkCommand,
+  reviewCommand,
+  rebuttalCommand,
+  overlapCommand,
+  latexCommand,
+  citeCommand,
+  syncCommand,
+  wordcountCommand,
+  readabilityCommand,
+  glossaryCommand,
+  hubCommand,
+  repoCommand,
+  reviewDiffCommand,
+  exportCommand,
+  diffSessionCommand,
+  resumeCommand,
+  sessionCommand,
+  setCommand,
+  forceModelCommand,
+  sendToCommand,
+  mailCommand,
+  todoCommand,
+  rateCommand,
+  feedbackCommand,
+  abCommand,
+  dashboardCommand,
+  storageCommand,
+  backupCommand,
+  dataSyncCommand,
+  presentCommand,
+  threadCommand,
+  contextCommand,
+  terminologyCommand,
+  outlineCommand,
+  focusCommand,
+  printCommand,
+  copyCommand,
+  shareCommand,
+  recordCommand,
   apiCommand,
   configPanelCommand,
   docsPanelCommand,
@@ -22,6 +22,7 @@ export interface SlashCommandResult {
 export interface SimpleCommandContext {
   config: Config | null;
   outputMessage: (message: string, type?: 'info' | 'error') => void;
+  abortSignal?: AbortSignal;
 }
 
 /**
@@ -88,6 +89,7 @@ export class SlashCommandProcessor {
             logger: new Logger('non-interactive'),
           },
           ui: {
+            history: [], // Nothing is displayed in non-interactive mode
             addItem: (itemData: Omit<HistoryItem, 'id'>, baseTimestamp: number): number => {
               // For non-interactive mode, just output the message if it's text
               if (itemData.type === 'info' || itemData.type === 'error') {
@@ -99,6 +101,7 @@ export class SlashCommandProcessor {
               return baseTimestamp; // Return a dummy ID
             },
             clear: () => {}, // No-op for non-interactive
+            loadOlderHistory: () => 0, // No history paging in non-interactive mode
             setDebugMessage: () => {}, // No-op for non-interactive
           },
           session: {
@@ -123,6 +126,7 @@ export class SlashCommandProcessor {
               promptCount: 0,
             } as SessionStatsState,
           },
+          abortSignal: context.abortSignal ?? new AbortController().signal,
         };
 
         try {
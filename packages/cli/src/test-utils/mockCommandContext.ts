@@ -41,8 +41,10 @@ export const createMockCommandContext = (
       } as any, // Cast because Logger is a class.
     },
     ui: {
+      history: [],
       addItem: vi.fn(),
       clear: vi.fn(),
+      loadOlderHistory: vi.fn(() => 0),
       setDebugMessage: vi.fn(),
     },
     session: {
@@ -62,6 +64,7 @@ export const createMockCommandContext = (
         },
       } as SessionStatsState,
     },
+    abortSignal: new AbortController().signal,
   };
 
   // eslint-disable-next-line @typescript-eslint/no-explicit-any
@@ -77,6 +80,7 @@ export const createMockCommandContext = (
           sourceValue &&
           typeof sourceValue === 'object' &&
           !Array.isArray(sourceValue) &&
+          Object.getPrototypeOf(sourceValue) === Object.prototype &&
           targetValue &&
           typeof targetValue === 'object' &&
           !Array.isArray(targetValue)
@@ -47,6 +47,9 @@ interface MockServerConfig {
 
   getApiKey: Mock<() => string>;
   getModel: Mock<() => string>;
+  getActiveFallbackModel: Mock<() => string | undefined>;
+  isIncognito: Mock<() => boolean>;
+  getProjectTempDir: Mock<() => string>;
   getSandbox: Mock<() => SandboxConfig | undefined>;
   getTargetDir: Mock<() => string>;
   getToolRegistry: Mock<() => ToolRegistry>; // Use imported ToolRegistry type
@@ -107,6 +110,9 @@ vi.mock('@iechor/research-cli-core', async (importOriginal) => {
 
         getApiKey: vi.fn(() => opts.apiKey || 'test-key'),
         getModel: vi.fn(() => opts.model || 'test-model-in-mock-factory'),
+        getActiveFallbackModel: vi.fn(() => undefined),
+        isIncognito: vi.fn(() => false),
+        getProjectTempDir: vi.fn(() => '/test/tmp'),
         getSandbox: vi.fn(() => opts.sandbox),
         getTargetDir: vi.fn(() => opts.targetDir || '/test/dir'),
         getToolRegistry: vi.fn(() => ({}) as ToolRegistry), // Simple mock
@@ -172,6 +178,21 @@ vi.mock('./hooks/useLogger', () => ({
   })),
 }));
 
+vi.mock('./hooks/useDeadlineWarning', () => ({
+  DEFAULT_DEADLINE_WARNING_DAYS: 7,
+  useDeadlineWarning: vi.fn(() => undefined),
+}));
+
+vi.mock('./hooks/useWindowTitle', () => ({
+  useWindowTitle: vi.fn(),
+}));
+
+vi.mock('./utils/layoutState.js', () => ({
+  getLayoutStatePath: vi.fn(() => '/test/tmp/layout.json'),
+  loadLayoutState: vi.fn(() => ({})),
+  saveLayoutState: vi.fn().mockResolvedValue(undefined),
+}));
+
 vi.mock('../config/config.js', async (importOriginal) => {
   const actual = await importOriginal();
   return {
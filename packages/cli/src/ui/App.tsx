@@ -36,11 +36,16 @@ import { ThemeDialog } from './components/ThemeDialog.js';
 import { AuthDialog } from './components/AuthDialog.js';
 import { AuthInProgress } from './components/AuthInProgress.js';
 import { EditorSettingsDialog } from './components/EditorSettingsDialog.js';
+import { OutlinePane } from './components/OutlinePane.js';
+import { SessionPicker } from './components/SessionPicker.js';
+import { PromptPreview } from './components/PromptPreview.js';
 import { Colors } from './colors.js';
 import { Help } from './components/Help.js';
+import { PromptWarnings } from './components/PromptWarnings.js';
 import { loadHierarchicalResearchMemory } from '../config/config.js';
 import { LoadedSettings } from '../config/settings.js';
 import { Tips } from './components/Tips.js';
+import { StartupScreen } from './components/StartupScreen.js';
 import { ConsolePatcher } from './utils/ConsolePatcher.js';
 import { registerCleanup } from '../utils/cleanup.js';
 import { DetailedMessagesDisplay } from './components/DetailedMessagesDisplay.js';
@@ -57,6 +62,7 @@ import {
   EditorType,
   FlashFallbackEvent,
   logFlashFallback,
+  getRedactor,
 } from '@iechor/research-cli-core';
 import { validateAuthMethod } from '../config/auth.js';
 import { useLogger } from './hooks/useLogger.js';
@@ -66,10 +72,57 @@ import {
   useSessionStats,
 } from './contexts/SessionContext.js';
 import { useGitBranchName } from './hooks/useGitBranchName.js';
+import {
+  DEFAULT_DEADLINE_WARNING_DAYS,
+  useDeadlineWarning,
+} from './hooks/useDeadlineWarning.js';
+import { useTerminology } from './hooks/useTerminology.js';
+import { useFocusTimer } from './hooks/useFocusTimer.js';
+import { useWindowTitle } from './hooks/useWindowTitle.js';
+import { useDesktopNotifications } from './hooks/useDesktopNotifications.js';
+import { useSharedSession } from './hooks/useSharedSession.js';
+import { getFocusTimer } from './utils/focusTimer.js';
+import { isSlashCommand } from './utils/commandUtils.js';
+import {
+  DEFAULT_WINDOW_TITLE,
+  deriveSessionTitle,
+} from './utils/windowTitle.js';
+import { DEFAULT_NOTIFY_AFTER_SECONDS } from './utils/desktopNotification.js';
+import { ZoomPane, getFocusedPane, isPaneVisible } from './utils/zoom.js';
+import { StartupSection } from './utils/startupScreen.js';
+import { resolveStatusBar } from './utils/statusBar.js';
+import { isImeModeEnabled } from './utils/imeCursor.js';
+import { resolveMessageLayout } from './utils/messageLayout.js';
+import { PromptWarning, lintPrompt } from './utils/promptLint.js';
+import {
+  PromptPreview as PromptPreviewData,
+  composePromptPreview,
+} from './utils/promptPreview.js';
+import { numberCodeBlocks } from './utils/codeBlocks.js';
+import {
+  SessionStore,
+  getSessionsDir,
+  toStoredMessage,
+} from './utils/sessionStore.js';
+import {
+  getSessionManager,
+  getSessionStoreId,
+} from './utils/sessionManager.js';
+import { MessageLayoutContext } from './contexts/MessageLayoutContext.js';
+import {
+  getLayoutStatePath,
+  loadLayoutState,
+  saveLayoutState,
+} from './utils/layoutState.js';
 import { useBracketedPaste } from './hooks/useBracketedPaste.js';
 import { useTextBuffer } from './components/shared/text-buffer.js';
 import * as fs from 'fs';
+import * as path from 'path';
 import { UpdateNotification } from './components/UpdateNotification.js';
+import { TerminalTooSmall } from './components/TerminalTooSmall.js';
+import { HistorySpillStore } from './utils/historySpillStore.js';
+import { RenderCache } from './utils/renderCache.js';
+import { themeManager } from './themes/theme-manager.js';
 import {
   isProQuotaExceededError,
   isGenericQuotaExceededError,
@@ -82,31 +135,126 @@ import { ShowMoreLines } from './components/ShowMoreLines.js';
 import { PrivacyNotice } from './privacy/PrivacyNotice.js';
 
 const CTRL_EXIT_PROMPT_DURATION_MS = 1000;
+const DEFAULT_MAX_HISTORY_ITEMS = 1000;
 
 interface AppProps {
   config: Config;
   settings: LoadedSettings;
   startupWarnings?: string[];
+  /** Sections composed for the startup screen before the app renders. */
+  startupScreen?: StartupSection[];
   version: string;
 }
 
 export const AppWrapper = (props: AppProps) => (
   <SessionStatsProvider>
-    <App {...props} />
+    <MessageLayoutContext.Provider
+      value={resolveMessageLayout(
+        props.settings.merged.messages,
+        props.settings.merged.modelStyles,
+      )}
+    >
+      <App {...props} />
+    </MessageLayoutContext.Provider>
   </SessionStatsProvider>
 );
 
-const App = ({ config, settings, startupWarnings = [], version }: AppProps) => {
+const App = ({
+  config,
+  settings,
+  startupWarnings = [],
+  startupScreen = [],
+  version,
+}: AppProps) => {
   useBracketedPaste();
   const [updateMessage, setUpdateMessage] = useState<string | null>(null);
   const { stdout } = useStdout();
   const nightly = version.includes('nightly');
 
   useEffect(() => {
-    checkForUpdates().then(setUpdateMessage);
-  }, []);
-
-  const { history, addItem, clearItems, loadHistory } = useHistory();
+    checkForUpdates(settings.merged.checkForUpdates).then(setUpdateMessage);
+  }, [settings.merged.checkForUpdates]);
+
+  const historySpillStore = useMemo(
+    () =>
+      // Incognito sessions never write messages to disk, so they are not capped.
+      config.isIncognito()
+        ? undefined
+        : new HistorySpillStore(
+            path.join(
+              config.getProjectTempDir(),
+              'history',
+              `${config.getSessionId()}.jsonl`,
+            ),
+          ),
+    [config],
+  );
+  useEffect(() => {
+    if (!historySpillStore) {
+      return;
+    }
+    const removeSpillFile = () => historySpillStore.clear();
+    process.on('exit', removeSpillFile);
+    return () => {
+      process.off('exit', removeSpillFile);
+      removeSpillFile();
+    };
+  }, [historySpillStore]);
+  const {
+    history,
+    addItem,
+    clearItems,
+    loadHistory,
+    loadOlderHistory,
+    olderItemCount,
+  } = useHistory({
+    maxItems: settings.merged.maxHistoryItems ?? DEFAULT_MAX_HISTORY_ITEMS,
+    spillStore: historySpillStore,
+  });
+  // Each named session is stored on its own; switching re-renders
+  const sessionName = getSessionManager().getCurrentName();
+  const sessionStore = useMemo(
+    () =>
+      config.isIncognito() ||
+      settings.merged.sessionHistory?.enabled === false
+        ? undefined
+        : new SessionStore(
+            getSessionsDir(),
+            getSessionStoreId(config.getSessionId(), sessionName),
+            settings.merged.sessionHistory?.maxSessions,
+          ),
+    [config, settings.merged.sessionHistory, sessionName],
+  );
+  // Ids of the history items already written to the session store
+  const storedItemIdsRef = useRef(new Set<number>());
+  const lastSessionStoreRef = useRef(sessionStore);
+  useEffect(() => {
+    if (!sessionStore) {
+      return;
+    }
+    if (lastSessionStoreRef.current !== sessionStore) {
+      // The items of a session switched back to are stored already
+      lastSessionStoreRef.current = sessionStore;
+      for (const item of history) {
+        storedItemIdsRef.current.add(item.id);
+      }
+      return;
+    }
+    for (const item of history) {
+      if (storedItemIdsRef.current.has(item.id)) {
+        continue;
+      }
+      storedItemIdsRef.current.add(item.id);
+      const message = toStoredMessage(item, new Date());
+      if (message) {
+        try {
+          sessionStore.append(message);
+        } catch (e) {
+          console.error('Could not store the session:', getErrorMessage(e));
+        }
+      }
+    }
+  }, [history, sessionStore]);
   const {
     consoleMessages,
     handleNewMessage,
@@ -130,19 +278,53 @@ const App = ({ config, settings, startupWarnings = [], version }: AppProps) => {
     setStaticKey((prev) => prev + 1);
   }, [setStaticKey, stdout]);
 
+  const layoutStatePath = getLayoutStatePath(config.getProjectTempDir());
+  // The layout of the last session, so the terminal reopens as it was left
+  const [savedLayout] = useState(() => loadLayoutState(layoutStatePath));
+
   const [researchMdFileCount, setResearchMdFileCount] = useState<number>(0);
   const [debugMessage, setDebugMessage] = useState<string>('');
   const [showHelp, setShowHelp] = useState<boolean>(false);
+  const [isOutlineOpen, setIsOutlineOpen] = useState(
+    savedLayout.outlineOpen ?? false,
+  );
+  const [outlineSelection, setOutlineSelection] = useState(
+    savedLayout.outlineSelection,
+  );
+  const [isSessionPickerOpen, setIsSessionPickerOpen] = useState(false);
+  const [isPromptPreviewOpen, setIsPromptPreviewOpen] = useState(false);
+  const [promptPreview, setPromptPreview] = useState<PromptPreviewData>();
+  const [zoomedPane, setZoomedPane] = useState<ZoomPane | undefined>(
+    savedLayout.zoomedPane,
+  );
+  // Named after the first message, for the terminal title
+  const [sessionTitle, setSessionTitle] = useState<string | undefined>(
+    undefined,
+  );
   const [themeError, setThemeError] = useState<string | null>(null);
   const [authError, setAuthError] = useState<string | null>(null);
   const [editorError, setEditorError] = useState<string | null>(null);
   const [footerHeight, setFooterHeight] = useState<number>(0);
   const [corgiMode, setCorgiMode] = useState(false);
   const [currentModel, setCurrentModel] = useState(config.getModel());
+  const [fallbackModel, setFallbackModel] = useState<string | undefined>(
+    undefined,
+  );
+  const [redactionCount, setRedactionCount] = useState(0);
   const [shellModeActive, setShellModeActive] = useState(false);
-  const [showErrorDetails, setShowErrorDetails] = useState<boolean>(false);
-  const [showToolDescriptions, setShowToolDescriptions] =
-    useState<boolean>(false);
+  const [showErrorDetails, setShowErrorDetails] = useState<boolean>(
+    savedLayout.consoleShown ?? false,
+  );
+  const [showToolDescriptions, setShowToolDescriptions] = useState<boolean>(
+    savedLayout.toolDescriptionsShown ?? false,
+  );
+  const [showExchangeInfo, setShowExchangeInfo] = useState<boolean>(
+    savedLayout.exchangeInfoShown ?? false,
+  );
+  const [promptWarnings, setPromptWarnings] = useState<{
+    prompt: string;
+    warnings: PromptWarning[];
+  } | null>(null);
   const [ctrlCPressedOnce, setCtrlCPressedOnce] = useState(false);
   const [quittingMessages, setQuittingMessages] = useState<
     HistoryItem[] | null
@@ -276,6 +458,8 @@ const App = ({ config, settings, startupWarnings = [], version }: AppProps) => {
       if (configModel !== currentModel) {
         setCurrentModel(configModel);
       }
+      setFallbackModel(config.getActiveFallbackModel());
+      setRedactionCount(getRedactor().getTotalCount());
     };
 
     // Check immediately and then periodically
@@ -354,6 +538,7 @@ const App = ({ config, settings, startupWarnings = [], version }: AppProps) => {
       config.setQuotaErrorOccurred(true);
       // Switch model for future use but return false to stop current retry
       config.setModel(fallbackModel);
+      config.setInQuotaFallback(true);
       logFlashFallback(
         config,
         new FlashFallbackEvent(config.getContentGeneratorConfig().authType!),
@@ -386,10 +571,33 @@ const App = ({ config, settings, startupWarnings = [], version }: AppProps) => {
     showToolDescriptions,
     setQuittingMessages,
     openPrivacyNotice,
+    loadOlderHistory,
+    () => setIsOutlineOpen(true),
+    () => setIsSessionPickerOpen(true),
   );
   const pendingHistoryItems = [...pendingSlashCommandHistoryItems];
 
-  const { rows: terminalHeight, columns: terminalWidth } = useTerminalSize();
+  const {
+    rows: terminalHeight,
+    columns: terminalWidth,
+    tooSmall: terminalTooSmall,
+  } = useTerminalSize();
+  const mainAreaWidth = Math.floor(terminalWidth * 0.9);
+  // Arbitrary threshold to ensure that items in the static area are large
+  // enough but not too large to make the terminal hard to use.
+  const staticAreaMaxItemHeight = Math.max(terminalHeight * 4, 100);
+  const themeName = themeManager.getActiveTheme().name;
+  const historyRenderCache = useMemo(() => new RenderCache(), []);
+  useEffect(() => {
+    historyRenderCache.invalidateExcept({
+      width: mainAreaWidth,
+      height: staticAreaMaxItemHeight,
+      theme: themeName,
+    });
+  }, [historyRenderCache, mainAreaWidth, staticAreaMaxItemHeight, themeName]);
+  useEffect(() => {
+    historyRenderCache.retain(history.map((h) => h.id));
+  }, [historyRenderCache, history]);
   const isInitialMount = useRef(true);
   const { stdin, setRawMode } = useStdin();
   const isValidPath = useCallback((filePath: string): boolean => {
@@ -415,6 +623,9 @@ const App = ({ config, settings, startupWarnings = [], version }: AppProps) => {
     isValidPath,
     shellModeActive,
   });
+  // The buffer as of the last render, for callbacks that finish later
+  const bufferRef = useRef(buffer);
+  bufferRef.current = buffer;
 
   const handleExit = useCallback(
     (
@@ -447,6 +658,34 @@ const App = ({ config, settings, startupWarnings = [], version }: AppProps) => {
     [slashCommands, commandContext],
   );
 
+  // Composes what sending the prompt in the input box would send
+  const openPromptPreview = useCallback(() => {
+    setPromptPreview(undefined);
+    setIsPromptPreviewOpen(true);
+    config
+      .getResearchClient()
+      .getRequestContext()
+      .then((requestContext) =>
+        composePromptPreview(buffer.text.trim(), {
+          ...requestContext,
+          targetDir: config.getTargetDir(),
+          model: config.getModel(),
+          userMemory: config.getUserMemory(),
+        }),
+      )
+      .then(setPromptPreview)
+      .catch((e) => {
+        setIsPromptPreviewOpen(false);
+        addItem(
+          {
+            type: MessageType.ERROR,
+            text: `Could not compose the prompt preview: ${getErrorMessage(e)}`,
+          },
+          Date.now(),
+        );
+      });
+  }, [config, buffer.text, addItem]);
+
   useInput((input: string, key: InkKeyType) => {
     let enteringConstrainHeightMode = false;
     if (!constrainHeight) {
@@ -468,6 +707,10 @@ const App = ({ config, settings, startupWarnings = [], version }: AppProps) => {
       if (Object.keys(mcpServers || {}).length > 0) {
         handleSlashCommand(newValue ? '/mcp desc' : '/mcp nodesc');
       }
+    } else if (key.ctrl && input === 'g') {
+      setShowExchangeInfo((prev) => !prev);
+      // The details of earlier exchanges are in the static history
+      refreshStatic();
     } else if (key.ctrl && (input === 'c' || input === 'C')) {
       handleExit(ctrlCPressedOnce, setCtrlCPressedOnce, ctrlCTimerRef);
     } else if (key.ctrl && (input === 'd' || input === 'D')) {
@@ -478,6 +721,13 @@ const App = ({ config, settings, startupWarnings = [], version }: AppProps) => {
       handleExit(ctrlDPressedOnce, setCtrlDPressedOnce, ctrlDTimerRef);
     } else if (key.ctrl && input === 's' && !enteringConstrainHeightMode) {
       setConstrainHeight(false);
+    } else if (key.ctrl && input === 'z') {
+      setZoomedPane((zoomed) => (zoomed ? undefined : focusedPane));
+    } else if (key.ctrl && input === 'n' && buffer.text.length === 0) {
+      // With text in the prompt, Ctrl+N goes through the input history
+      setIsSessionPickerOpen((open) => !open);
+    } else if (key.ctrl && input === 'r') {
+      openPromptPreview();
     }
   });
 
@@ -522,21 +772,128 @@ const App = ({ config, settings, startupWarnings = [], version }: AppProps) => {
     performMemoryRefresh,
     modelSwitchedFromQuotaError,
     setModelSwitchedFromQuotaError,
+    settings.merged.modelRouting,
   );
   pendingHistoryItems.push(...pendingResearchHistoryItems);
+
+  const focusedPane = getFocusedPane({
+    outlineOpen: isOutlineOpen,
+    consoleShown: showErrorDetails,
+    toolOutputShown: pendingHistoryItems.length > 0,
+  });
+  useEffect(() => {
+    // Restore the layout once the zoomed pane is closed or loses the focus
+    if (zoomedPane && zoomedPane !== focusedPane) {
+      setZoomedPane(undefined);
+    }
+  }, [zoomedPane, focusedPane]);
+  useEffect(() => {
+    // Incognito sessions leave nothing behind, not even the layout
+    if (config.isIncognito()) {
+      return;
+    }
+    saveLayoutState(layoutStatePath, {
+      outlineOpen: isOutlineOpen,
+      consoleShown: showErrorDetails,
+      toolDescriptionsShown: showToolDescriptions,
+      exchangeInfoShown: showExchangeInfo,
+      zoomedPane,
+      outlineSelection,
+    });
+  }, [
+    config,
+    layoutStatePath,
+    isOutlineOpen,
+    showErrorDetails,
+    showToolDescriptions,
+    showExchangeInfo,
+    zoomedPane,
+    outlineSelection,
+  ]);
   const { elapsedTime, currentLoadingPhrase } =
     useLoadingIndicator(streamingState);
   const showAutoAcceptIndicator = useAutoAcceptIndicator({ config });
 
+  const sendQuery = useCallback(
+    (trimmedValue: string) => {
+      if (!shellModeActive && !isSlashCommand(trimmedValue)) {
+        getFocusTimer().recordMessage();
+        setSessionTitle((title) => title ?? deriveSessionTitle(trimmedValue));
+      }
+      submitQuery(trimmedValue);
+    },
+    [submitQuery, shellModeActive],
+  );
+
   const handleFinalSubmit = useCallback(
     (submittedValue: string) => {
       const trimmedValue = submittedValue.trim();
-      if (trimmedValue.length > 0) {
-        submitQuery(trimmedValue);
+      if (trimmedValue.length === 0) {
+        return;
+      }
+      // A prompt sent again after its warnings goes out as it is
+      if (
+        shellModeActive ||
+        isSlashCommand(trimmedValue) ||
+        promptWarnings?.prompt === trimmedValue
+      ) {
+        setPromptWarnings(null);
+        sendQuery(trimmedValue);
+        return;
       }
+      lintPrompt(
+        trimmedValue,
+        {
+          targetDir: config.getTargetDir(),
+          model: config.getModel(),
+          historyTokens: sessionStats.lastPromptTokenCount,
+          redacting: getRedactor().isEnabled(),
+        },
+        settings.merged.promptChecks,
+      )
+        .catch(() => [])
+        .then((warnings) => {
+          if (warnings.length === 0) {
+            setPromptWarnings(null);
+            sendQuery(trimmedValue);
+            return;
+          }
+          // Give the prompt back, to be edited or sent anyway, unless
+          // something new was typed while the checks ran
+          if (bufferRef.current.text === '') {
+            bufferRef.current.setText(submittedValue);
+          }
+          setPromptWarnings({ prompt: trimmedValue, warnings });
+        });
     },
-    [submitQuery],
+    [
+      sendQuery,
+      shellModeActive,
+      promptWarnings,
+      config,
+      sessionStats.lastPromptTokenCount,
+      settings.merged.promptChecks,
+    ],
+  );
+
+  useWindowTitle(
+    settings.merged.hideWindowTitle || process.env.CLI_TITLE
+      ? undefined
+      : (settings.merged.windowTitle ?? DEFAULT_WINDOW_TITLE),
+    path.basename(config.getTargetDir()),
+    sessionTitle,
+    streamingState,
+  );
+  useDesktopNotifications(
+    settings.merged.desktopNotifications?.enabled
+      ? (settings.merged.desktopNotifications.afterSeconds ??
+          DEFAULT_NOTIFY_AFTER_SECONDS)
+      : undefined,
+    sessionTitle ?? path.basename(config.getTargetDir()),
+    streamingState,
+    history,
   );
+  useSharedSession(history, pendingHistoryItems, addItem);
 
   const logger = useLogger();
   const [userMessages, setUserMessages] = useState<string[]>([]);
@@ -594,7 +951,7 @@ const App = ({ config, settings, startupWarnings = [], version }: AppProps) => {
       const fullFooterMeasurement = measureElement(mainControlsRef.current);
       setFooterHeight(fullFooterMeasurement.height);
     }
-  }, [terminalHeight, consoleMessages, showErrorDetails]);
+  }, [terminalHeight, consoleMessages, showErrorDetails, zoomedPane]);
 
   const staticExtraHeight = /* margins and padding */ 3;
   const availableTerminalHeight = useMemo(
@@ -635,6 +992,11 @@ const App = ({ config, settings, startupWarnings = [], version }: AppProps) => {
   }, [consoleMessages, config]);
 
   const branchName = useGitBranchName(config.getTargetDir());
+  const deadlineWarning = useDeadlineWarning(
+    settings.merged.deadlineWarningDays ?? DEFAULT_DEADLINE_WARNING_DAYS,
+  );
+  const terminology = useTerminology(config.getTargetDir());
+  const focusStatus = useFocusTimer(config.getTargetDir(), addItem);
 
   const contextFileNames = useMemo(() => {
     const fromSettings = settings.merged.contextFileName;
@@ -690,11 +1052,15 @@ const App = ({ config, settings, startupWarnings = [], version }: AppProps) => {
       </Box>
     );
   }
-  const mainAreaWidth = Math.floor(terminalWidth * 0.9);
+  if (terminalTooSmall) {
+    return (
+      <TerminalTooSmall
+        columns={process.stdout.columns || 0}
+        rows={process.stdout.rows || 0}
+      />
+    );
+  }
   const debugConsoleMaxHeight = Math.floor(Math.max(terminalHeight * 0.2, 5));
-  // Arbitrary threshold to ensure that items in the static area are large
-  // enough but not too large to make the terminal hard to use.
-  const staticAreaMaxItemHeight = Math.max(terminalHeight * 4, 100);
   return (
     <StreamingContext.Provider value={streamingState}>
       <Box flexDirection="column" marginBottom={1} width="90%">
@@ -724,46 +1090,67 @@ const App = ({ config, settings, startupWarnings = [], version }: AppProps) => {
                 />
               )}
               {!settings.merged.hideTips && <Tips config={config} />}
+              <StartupScreen sections={startupScreen} />
+              {olderItemCount > 0 && (
+                <Text color={Colors.Gray}>
+                  ↑ {olderItemCount} earlier message(s) are stored on disk. Use
+                  /history older to show them.
+                </Text>
+              )}
             </Box>,
-            ...history.map((h) => (
-              <HistoryItemDisplay
-                terminalWidth={mainAreaWidth}
-                availableTerminalHeight={staticAreaMaxItemHeight}
-                key={h.id}
-                item={h}
-                isPending={false}
-                config={config}
-              />
-            )),
+            ...history
+              .filter((h) => showExchangeInfo || h.type !== 'exchange_info')
+              .map((h) =>
+                historyRenderCache.get(
+                  h.id,
+                  h,
+                  {
+                    width: mainAreaWidth,
+                    height: staticAreaMaxItemHeight,
+                    theme: themeName,
+                  },
+                  () => (
+                    <HistoryItemDisplay
+                      terminalWidth={mainAreaWidth}
+                      availableTerminalHeight={staticAreaMaxItemHeight}
+                      key={h.id}
+                      item={h}
+                      isPending={false}
+                      config={config}
+                    />
+                  ),
+                ),
+              ),
           ]}
         >
           {(item) => item}
         </Static>
         <OverflowProvider>
           <Box ref={pendingHistoryItemRef} flexDirection="column">
-            {pendingHistoryItems.map((item, i) => (
-              <HistoryItemDisplay
-                key={i}
-                availableTerminalHeight={
-                  constrainHeight ? availableTerminalHeight : undefined
-                }
-                terminalWidth={mainAreaWidth}
-                // TODO(taehykim): It seems like references to ids aren't necessary in
-                // HistoryItemDisplay. Refactor later. Use a fake id for now.
-                item={{ ...item, id: 0 }}
-                isPending={true}
-                config={config}
-                isFocused={!isEditorDialogOpen}
-              />
-            ))}
+            {isPaneVisible('tool output', zoomedPane) &&
+              pendingHistoryItems.map((item, i) => (
+                <HistoryItemDisplay
+                  key={i}
+                  availableTerminalHeight={
+                    constrainHeight ? availableTerminalHeight : undefined
+                  }
+                  terminalWidth={mainAreaWidth}
+                  // TODO(taehykim): It seems like references to ids aren't necessary in
+                  // HistoryItemDisplay. Refactor later. Use a fake id for now.
+                  item={numberCodeBlocks({ ...item, id: 0 }, history)}
+                  isPending={true}
+                  config={config}
+                  isFocused={!isEditorDialogOpen}
+                />
+              ))}
             <ShowMoreLines constrainHeight={constrainHeight} />
           </Box>
         </OverflowProvider>
 
-        {showHelp && <Help commands={slashCommands} />}
+        {showHelp && !zoomedPane && <Help commands={slashCommands} />}
 
         <Box flexDirection="column" ref={mainControlsRef}>
-          {startupWarnings.length > 0 && (
+          {startupWarnings.length > 0 && !zoomedPane && (
             <Box
               borderStyle="round"
               borderColor={Colors.AccentYellow}
@@ -848,6 +1235,60 @@ const App = ({ config, settings, startupWarnings = [], version }: AppProps) => {
               onExit={() => setShowPrivacyNotice(false)}
               config={config}
             />
+          ) : isPromptPreviewOpen ? (
+            <PromptPreview
+              preview={promptPreview}
+              onSend={() => {
+                const prompt = buffer.text;
+                setIsPromptPreviewOpen(false);
+                if (prompt.trim()) {
+                  buffer.setText('');
+                  handleFinalSubmit(prompt);
+                }
+              }}
+              onClose={() => setIsPromptPreviewOpen(false)}
+              availableTerminalHeight={
+                constrainHeight
+                  ? terminalHeight - staticExtraHeight
+                  : undefined
+              }
+            />
+          ) : isSessionPickerOpen ? (
+            <SessionPicker
+              sessions={getSessionManager().list({
+                items: history,
+                model: currentModel,
+              })}
+              onSelect={(name) => {
+                setIsSessionPickerOpen(false);
+                if (name !== getSessionManager().getCurrentName()) {
+                  handleSlashCommand(`/session switch ${name}`);
+                }
+              }}
+              onCreate={(name) => {
+                setIsSessionPickerOpen(false);
+                handleSlashCommand(`/session new ${name}`);
+              }}
+              onClose={() => setIsSessionPickerOpen(false)}
+            />
+          ) : isOutlineOpen ? (
+            <OutlinePane
+              projectRoot={config.getTargetDir()}
+              onDraft={(number) => {
+                setIsOutlineOpen(false);
+                handleSlashCommand(`/outline draft ${number}`);
+              }}
+              onClose={() => setIsOutlineOpen(false)}
+              initialSelection={outlineSelection}
+              onSelectionChange={setOutlineSelection}
+              availableTerminalHeight={
+                zoomedPane
+                  ? terminalHeight - 1
+                  : constrainHeight
+                    ? terminalHeight - staticExtraHeight
+                    : undefined
+              }
+            />
           ) : (
             <>
               <LoadingIndicator
@@ -864,60 +1305,73 @@ const App = ({ config, settings, startupWarnings = [], version }: AppProps) => {
                 }
                 elapsedTime={elapsedTime}
               />
-              <Box
-                marginTop={1}
-                display="flex"
-                justifyContent="space-between"
-                width="100%"
-              >
-                <Box>
-                  {process.env.RESEARCH_SYSTEM_MD && (
-                    <Text color={Colors.AccentRed}>|⌐■_■| </Text>
-                  )}
-                  {ctrlCPressedOnce ? (
-                    <Text color={Colors.AccentYellow}>
-                      Press Ctrl+C again to exit.
-                    </Text>
-                  ) : ctrlDPressedOnce ? (
-                    <Text color={Colors.AccentYellow}>
-                      Press Ctrl+D again to exit.
-                    </Text>
-                  ) : (
-                    <ContextSummaryDisplay
-                      researchMdFileCount={researchMdFileCount}
-                      contextFileNames={contextFileNames}
-                      mcpServers={config.getMcpServers()}
-                      showToolDescriptions={showToolDescriptions}
-                    />
-                  )}
-                </Box>
-                <Box>
-                  {showAutoAcceptIndicator !== ApprovalMode.DEFAULT &&
-                    !shellModeActive && (
-                      <AutoAcceptIndicator
-                        approvalMode={showAutoAcceptIndicator}
+              {(!zoomedPane || ctrlCPressedOnce || ctrlDPressedOnce) && (
+                <Box
+                  marginTop={1}
+                  display="flex"
+                  justifyContent="space-between"
+                  width="100%"
+                >
+                  <Box>
+                    {process.env.RESEARCH_SYSTEM_MD && (
+                      <Text color={Colors.AccentRed}>|⌐■_■| </Text>
+                    )}
+                    {ctrlCPressedOnce ? (
+                      <Text color={Colors.AccentYellow}>
+                        Press Ctrl+C again to exit.
+                      </Text>
+                    ) : ctrlDPressedOnce ? (
+                      <Text color={Colors.AccentYellow}>
+                        Press Ctrl+D again to exit.
+                      </Text>
+                    ) : (
+                      <ContextSummaryDisplay
+                        researchMdFileCount={researchMdFileCount}
+                        contextFileNames={contextFileNames}
+                        mcpServers={config.getMcpServers()}
+                        showToolDescriptions={showToolDescriptions}
                       />
                     )}
-                  {shellModeActive && <ShellModeIndicator />}
+                  </Box>
+                  <Box>
+                    {showAutoAcceptIndicator !== ApprovalMode.DEFAULT &&
+                      !shellModeActive && (
+                        <AutoAcceptIndicator
+                          approvalMode={showAutoAcceptIndicator}
+                        />
+                      )}
+                    {shellModeActive && <ShellModeIndicator />}
+                  </Box>
                 </Box>
-              </Box>
+              )}
 
-              {showErrorDetails && (
+              {showErrorDetails && isPaneVisible('console', zoomedPane) && (
                 <OverflowProvider>
                   <Box flexDirection="column">
                     <DetailedMessagesDisplay
                       messages={filteredConsoleMessages}
                       maxHeight={
-                        constrainHeight ? debugConsoleMaxHeight : undefined
+                        zoomedPane
+                          ? terminalHeight - staticExtraHeight - 1
+                          : constrainHeight
+                            ? debugConsoleMaxHeight
+                            : undefined
                       }
-                      width={inputWidth}
+                      width={zoomedPane ? mainAreaWidth : inputWidth}
                     />
                     <ShowMoreLines constrainHeight={constrainHeight} />
                   </Box>
                 </OverflowProvider>
               )}
 
-              {isInputActive && (
+              {isInputActive &&
+                isPaneVisible('input', zoomedPane) &&
+                promptWarnings &&
+                buffer.text.trim() === promptWarnings.prompt && (
+                  <PromptWarnings warnings={promptWarnings.warnings} />
+                )}
+
+              {isInputActive && isPaneVisible('input', zoomedPane) && (
                 <InputPrompt
                   buffer={buffer}
                   inputWidth={inputWidth}
@@ -930,6 +1384,8 @@ const App = ({ config, settings, startupWarnings = [], version }: AppProps) => {
                   commandContext={commandContext}
                   shellModeActive={shellModeActive}
                   setShellModeActive={setShellModeActive}
+                  terminology={terminology}
+                  imeMode={isImeModeEnabled(settings.merged.imeMode)}
                 />
               )}
             </>
@@ -967,21 +1423,37 @@ const App = ({ config, settings, startupWarnings = [], version }: AppProps) => {
               )}
             </Box>
           )}
-          <Footer
-            model={currentModel}
-            targetDir={config.getTargetDir()}
-            debugMode={config.getDebugMode()}
-            branchName={branchName}
-            debugMessage={debugMessage}
-            corgiMode={corgiMode}
-            errorCount={errorCount}
-            showErrorDetails={showErrorDetails}
-            showMemoryUsage={
-              config.getDebugMode() || config.getShowMemoryUsage()
-            }
-            promptTokenCount={sessionStats.lastPromptTokenCount}
-            nightly={nightly}
-          />
+          {isPaneVisible('footer', zoomedPane) ? (
+            <Footer
+              model={currentModel}
+              fallbackModel={fallbackModel}
+              redactionCount={redactionCount}
+              deadlineWarning={deadlineWarning}
+              focusStatus={focusStatus}
+              incognito={config.isIncognito()}
+              targetDir={config.getTargetDir()}
+              debugMode={config.getDebugMode()}
+              branchName={branchName}
+              debugMessage={debugMessage}
+              corgiMode={corgiMode}
+              errorCount={errorCount}
+              showErrorDetails={showErrorDetails}
+              showMemoryUsage={
+                config.getDebugMode() || config.getShowMemoryUsage()
+              }
+              promptTokenCount={sessionStats.lastPromptTokenCount}
+              nightly={nightly}
+              statusBar={resolveStatusBar(
+                settings.merged.statusBar,
+                themeName,
+                Colors,
+              )}
+            />
+          ) : (
+            <Text color={Colors.Gray}>
+              Zoomed to the {zoomedPane}. Press Ctrl+Z to restore the layout.
+            </Text>
+          )}
         </Box>
       </Box>
     </StreamingContext.Provider>
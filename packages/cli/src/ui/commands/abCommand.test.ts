@@ -0,0 +1,120 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { Content } from '@google/genai';
+import { Config, loadEvalRun } from '@iechor/research-cli-core';
+import { abCommand } from './abCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+
+describe('abCommand', () => {
+  let tempDir: string;
+  const generateContent = vi.fn(
+    async (
+      contents: Content[],
+      _config: unknown,
+      _signal: unknown,
+      model?: string,
+    ) => {
+      const text = `${model ?? 'default'} says ${contents[0].parts![0].text}`;
+      return { candidates: [{ content: { parts: [{ text }] } }] };
+    },
+  );
+
+  const subCommand = (name: string) =>
+    abCommand.subCommands!.find((c) => c.name === name)!;
+
+  const context = () =>
+    createMockCommandContext({
+      services: {
+        config: {
+          getTargetDir: () => tempDir,
+          getModel: () => 'big',
+          getResearchClient: () => ({ generateContent }),
+        } as unknown as Config,
+      },
+    });
+
+  const shownText = (ctx: ReturnType<typeof context>) =>
+    vi
+      .mocked(ctx.ui.addItem)
+      .mock.calls.map(([item]) => (item as { text: string }).text)
+      .pop();
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'ab-command-'));
+    fs.writeFileSync(path.join(tempDir, 'inputs.txt'), 'alpha\n---\nbeta\n');
+    fs.writeFileSync(path.join(tempDir, 'short.txt'), 'Briefly: {{input}}');
+    generateContent.mockClear();
+    // Show variant A first in every case
+    vi.spyOn(Math, 'random').mockReturnValue(0.1);
+  });
+
+  afterEach(() => {
+    vi.restoreAllMocks();
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should run both variants and show the first case blind', async () => {
+    const ctx = context();
+    await subCommand('run').action!(
+      ctx,
+      'inputs.txt --b short.txt --model-b small',
+    );
+
+    expect(generateContent).toHaveBeenCalledTimes(4);
+    const text = shownText(ctx);
+    expect(text).toContain('Case 1 of 2');
+    expect(text).toContain('── Output 1 ──\nbig says alpha');
+    expect(text).toContain('── Output 2 ──\nsmall says Briefly: alpha');
+    expect(text).not.toMatch(/\bA\b|\bB\b/);
+  });
+
+  it('should grade cases and reveal the scores per variant', async () => {
+    await subCommand('run').action!(context(), 'inputs.txt --model-b small');
+
+    const gradeContext = context();
+    await subCommand('grade').action!(gradeContext, '1 2 5');
+    expect(shownText(gradeContext)).toContain('Case 2 of 2');
+    expect(
+      await subCommand('grade').action!(context(), '2 4 4'),
+    ).toMatchObject({ content: 'That was the last case. See /ab results.' });
+
+    const run = await loadEvalRun(tempDir);
+    expect(run?.cases.map((c) => c.scores)).toEqual([
+      [2, 5],
+      [4, 4],
+    ]);
+    const resultsContext = context();
+    await subCommand('results').action!(resultsContext, '');
+    const results = shownText(resultsContext);
+    expect(results).toContain('2 of 2 cases graded');
+    expect(results).toContain('A  the input as is with big');
+    expect(results).toContain('mean score 3, better in 0 cases');
+    expect(results).toContain('B  the input as is with small');
+    expect(results).toContain('mean score 4.5, better in 1 cases');
+    expect(results).toContain('Ties: 1');
+  });
+
+  it('should refuse identical variants and bad grades', async () => {
+    expect(
+      await subCommand('run').action!(context(), 'inputs.txt'),
+    ).toMatchObject({
+      messageType: 'error',
+      content: expect.stringContaining('The two variants are the same'),
+    });
+    expect(
+      await subCommand('grade').action!(context(), '1 6 2'),
+    ).toMatchObject({
+      messageType: 'error',
+      content: 'Usage: /ab grade <case> <output 1> <output 2>',
+    });
+    expect(generateContent).not.toHaveBeenCalled();
+  });
+});
@@ -0,0 +1,261 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import {
+  EvalRun,
+  EvalVariant,
+  createEvalGenerator,
+  getBlindOutputs,
+  getErrorMessage,
+  gradeCase,
+  loadEvalRun,
+  parseEvalInputs,
+  runEval,
+  saveEvalRun,
+  summarizeEval,
+} from '@iechor/research-cli-core';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import { MessageType } from '../types.js';
+
+const RUN_USAGE =
+  'Usage: /ab run <inputs> [--a <template>] [--b <template>] [--model-a <model>] [--model-b <model>]';
+const NO_RUN = 'No comparison has been run yet. Start one with /ab run.';
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+function getRoot(context: CommandContext): string {
+  return context.services.config?.getTargetDir() ?? process.cwd();
+}
+
+function parseRunArgs(args: string): {
+  inputs?: string;
+  options: Record<string, string>;
+} {
+  const tokens = args.trim().split(/\s+/).filter(Boolean);
+  const options: Record<string, string> = {};
+  let inputs: string | undefined;
+  for (let i = 0; i < tokens.length; i++) {
+    const flag = tokens[i].match(/^--(a|b|model-a|model-b)$/);
+    if (flag && tokens[i + 1]) {
+      options[flag[1]] = tokens[++i];
+    } else if (!inputs) {
+      inputs = tokens[i];
+    }
+  }
+  return { inputs, options };
+}
+
+function describeVariant(variant: EvalVariant, defaultModel: string): string {
+  return `${variant.templateFile ?? 'the input as is'} with ${variant.model ?? defaultModel}`;
+}
+
+/** Shows the next ungraded case with its outputs in blind order. */
+function showNextCase(context: CommandContext, run: EvalRun): boolean {
+  const index = run.cases.findIndex((c) => !c.scores);
+  if (index < 0) {
+    return false;
+  }
+  const [first, second] = getBlindOutputs(run.cases[index]);
+  context.ui.addItem(
+    {
+      type: MessageType.INFO,
+      text: [
+        `Case ${index + 1} of ${run.cases.length}`,
+        '',
+        'Input:',
+        run.cases[index].input,
+        '',
+        '── Output 1 ──',
+        first || '(empty)',
+        '',
+        '── Output 2 ──',
+        second || '(empty)',
+        '',
+        `Grade both from 1 to 5 with /ab grade ${index + 1} <output 1> <output 2>.`,
+      ].join('\n'),
+    },
+    Date.now(),
+  );
+  return true;
+}
+
+export const abCommand: SlashCommand = {
+  name: 'ab',
+  description:
+    'Compare two prompt templates or two models on saved test inputs, with blind grading. Start with /ab run.',
+  subCommands: [
+    {
+      name: 'run',
+      description: `Run both variants over every input and save the outputs for grading. Templates are files with {{input}} where the input goes. ${RUN_USAGE}`,
+      action: async (context, args) => {
+        const config = context.services.config;
+        const { inputs: inputsArg, options } = parseRunArgs(args);
+        if (!config || !inputsArg) {
+          return error(RUN_USAGE);
+        }
+        if (
+          options['a'] === options['b'] &&
+          options['model-a'] === options['model-b']
+        ) {
+          return error(
+            `The two variants are the same; give different templates with --a and --b, or different models with --model-a and --model-b. ${RUN_USAGE}`,
+          );
+        }
+        const root = getRoot(context);
+        let inputs: string[];
+        let variants: [EvalVariant, EvalVariant];
+        try {
+          inputs = parseEvalInputs(
+            await fs.promises.readFile(path.resolve(root, inputsArg), 'utf8'),
+            inputsArg,
+          );
+          variants = (await Promise.all(
+            (['a', 'b'] as const).map(async (key) => ({
+              label: key.toUpperCase() as 'A' | 'B',
+              template: options[key]
+                ? await fs.promises.readFile(
+                    path.resolve(root, options[key]),
+                    'utf8',
+                  )
+                : undefined,
+              templateFile: options[key],
+              model: options[`model-${key}`],
+            })),
+          )) as [EvalVariant, EvalVariant];
+        } catch (e) {
+          return error(`Could not read the inputs: ${getErrorMessage(e)}`);
+        }
+        if (inputs.length === 0) {
+          return error(`No inputs found in ${inputsArg}.`);
+        }
+
+        let run: EvalRun;
+        try {
+          run = await runEval(
+            createEvalGenerator(
+              config.getResearchClient(),
+              context.abortSignal,
+            ),
+            inputsArg,
+            inputs,
+            variants,
+            (done, total) =>
+              context.ui.setDebugMessage(
+                `Comparing variants: ${done} of ${total} inputs...`,
+              ),
+          );
+          await saveEvalRun(root, run);
+        } catch (e) {
+          return error(`The comparison failed: ${getErrorMessage(e)}`);
+        }
+        context.ui.addItem(
+          {
+            type: MessageType.INFO,
+            text: `Ran ${inputs.length} inputs through both variants (run ${run.id}). The outputs are shown in random order, so you do not know which variant wrote which.`,
+          },
+          Date.now(),
+        );
+        showNextCase(context, run);
+      },
+    },
+    {
+      name: 'next',
+      description: 'Show the next ungraded case of the latest comparison.',
+      action: async (context) => {
+        const run = await loadEvalRun(getRoot(context));
+        if (!run) {
+          return info(NO_RUN);
+        }
+        if (!showNextCase(context, run)) {
+          return info('Every case is graded. See /ab results.');
+        }
+      },
+    },
+    {
+      name: 'grade',
+      description:
+        'Grade the two outputs of a case from 1 to 5. Usage: /ab grade <case> <output 1> <output 2>',
+      action: async (context, args) => {
+        const [caseArg, ...scoreArgs] = args.trim().split(/\s+/);
+        const scores = scoreArgs.map(Number);
+        if (
+          !/^\d+$/.test(caseArg ?? '') ||
+          scores.length !== 2 ||
+          !scores.every((s) => Number.isInteger(s) && s >= 1 && s <= 5)
+        ) {
+          return error('Usage: /ab grade <case> <output 1> <output 2>');
+        }
+        const root = getRoot(context);
+        const run = await loadEvalRun(root);
+        if (!run) {
+          return info(NO_RUN);
+        }
+        const index = Number(caseArg) - 1;
+        if (!run.cases[index]) {
+          return error(
+            `There is no case ${caseArg}; the comparison has ${run.cases.length}.`,
+          );
+        }
+        run.cases[index] = gradeCase(run.cases[index], [scores[0], scores[1]]);
+        try {
+          await saveEvalRun(root, run);
+        } catch (e) {
+          return error(`Could not save the grade: ${getErrorMessage(e)}`);
+        }
+        if (!showNextCase(context, run)) {
+          return info('That was the last case. See /ab results.');
+        }
+      },
+    },
+    {
+      name: 'results',
+      description:
+        'Show the scores of each variant, revealing which is which. Usage: /ab results [run]',
+      action: async (context, args) => {
+        const id = args.trim() || undefined;
+        const run = await loadEvalRun(getRoot(context), id);
+        if (!run) {
+          return info(id ? `No run ${id}.` : NO_RUN);
+        }
+        const summary = summarizeEval(run);
+        if (summary.graded === 0) {
+          return info(
+            `None of the ${summary.total} cases is graded yet. Grade them with /ab next.`,
+          );
+        }
+        const defaultModel = context.services.config?.getModel() ?? '';
+        const [a, b] = run.variants;
+        context.ui.addItem(
+          {
+            type: MessageType.INFO,
+            text: [
+              `Comparison ${run.id} on ${run.inputsFile}: ${summary.graded} of ${summary.total} cases graded`,
+              '',
+              `  A  ${describeVariant(a, defaultModel)}`,
+              `     mean score ${summary.meanScores[0]}, better in ${summary.wins[0]} cases`,
+              `  B  ${describeVariant(b, defaultModel)}`,
+              `     mean score ${summary.meanScores[1]}, better in ${summary.wins[1]} cases`,
+              `  Ties: ${summary.ties}`,
+            ].join('\n'),
+          },
+          Date.now(),
+        );
+      },
+    },
+  ],
+};
@@ -1,4 +1,9 @@
 import { SlashCommand, CommandContext } from '../types.js';
+import {
+  deleteSecret,
+  isCredentialStoreAvailable,
+  storeSecret,
+} from '@iechor/research-cli-core';
 import fs from 'fs';
 import path from 'path';
 import os from 'os';
@@ -92,6 +97,20 @@ function getAPIValue(provider: string): string | undefined {
   return apiInfo ? process.env[apiInfo.envVar] : undefined;
 }
 
+/**
+ * The environment variables whose secrets `/api set` put in the Credential
+ * Manager: their entry is kept, without a key or value, to record that.
+ */
+export function getStoredSecretNames(): string[] {
+  return Object.entries(readAPIConfig().apis)
+    .filter(([, entry]) => !entry.apiKey && !entry.value)
+    .map(
+      ([provider]) =>
+        SUPPORTED_APIS[provider as keyof typeof SUPPORTED_APIS]?.envVar,
+    )
+    .filter((name): name is string => Boolean(name));
+}
+
 // 掩码显示API key
 function maskAPIKey(key: string): string {
   if (key.length <= 8) {
@@ -153,12 +172,18 @@ Supported providers: ${Object.keys(SUPPORTED_APIS).join(', ')}`,
             config.apis[provider.toLowerCase()] = {};
           }
 
-          // 根据类型设置不同的字段
-          if (
+          const isKey =
             provider.toLowerCase().includes('key') ||
             provider.toLowerCase() === 'serpapi' ||
-            provider.toLowerCase() === 'gemini'
-          ) {
+            provider.toLowerCase() === 'gemini';
+
+          // On Windows, keys go to the Credential Manager rather than the
+          // file, and are loaded into the environment on startup
+          if (isKey && isCredentialStoreAvailable()) {
+            await storeSecret(apiInfo.envVar, value);
+            process.env[apiInfo.envVar] = value;
+            delete config.apis[provider.toLowerCase()].apiKey;
+          } else if (isKey) {
             config.apis[provider.toLowerCase()].apiKey = value;
           } else {
             config.apis[provider.toLowerCase()].value = value;
@@ -171,7 +196,10 @@ Supported providers: ${Object.keys(SUPPORTED_APIS).join(', ')}`,
           return {
             type: 'message',
             messageType: 'info',
-            content: `✅ ${apiInfo.name} has been saved to configuration file.`,
+            content:
+              isKey && isCredentialStoreAvailable()
+                ? `✅ ${apiInfo.name} has been saved to the Windows Credential Manager.`
+                : `✅ ${apiInfo.name} has been saved to configuration file.`,
           };
         }
 
@@ -275,6 +303,17 @@ Supported providers: ${Object.keys(SUPPORTED_APIS).join(', ')}`,
 
           delete config.apis[provider.toLowerCase()];
           writeAPIConfig(config);
+          const removedInfo =
+            SUPPORTED_APIS[
+              provider.toLowerCase() as keyof typeof SUPPORTED_APIS
+            ];
+          if (removedInfo) {
+            // Set by /api add for this session; the key is gone now
+            delete process.env[removedInfo.envVar];
+            if (isCredentialStoreAvailable()) {
+              await deleteSecret(removedInfo.envVar);
+            }
+          }
 
           return {
             type: 'message',
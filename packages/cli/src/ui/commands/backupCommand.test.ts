@@ -0,0 +1,97 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { BackupSettings, Config } from '@iechor/research-cli-core';
+import { LoadedSettings } from '../../config/settings.js';
+import { backupCommand } from './backupCommand.js';
+import { CommandContext } from './types.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+
+describe('backupCommand', () => {
+  let tempDir: string;
+  let projectRoot: string;
+  const subCommand = (name: string) =>
+    backupCommand.subCommands!.find((c) => c.name === name)!;
+
+  const context = (backup?: BackupSettings): CommandContext =>
+    createMockCommandContext({
+      services: {
+        config: { getTargetDir: () => projectRoot } as unknown as Config,
+        settings: { merged: { backup } } as unknown as LoadedSettings,
+      },
+    });
+
+  const shown = (ctx: CommandContext) =>
+    (vi.mocked(ctx.ui.addItem).mock.calls[0][0] as { text: string }).text;
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'backup-command-'));
+    projectRoot = path.join(tempDir, 'paper');
+    vi.stubEnv('HOME', path.join(tempDir, 'home'));
+    vi.stubEnv('RESEARCH_BACKUP_PASSPHRASE', 'pw');
+    fs.mkdirSync(projectRoot, { recursive: true });
+    fs.writeFileSync(path.join(projectRoot, 'references.bib'), '@misc{a,}');
+  });
+
+  afterEach(() => {
+    vi.unstubAllEnvs();
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should create a backup in the backup folder and list it', async () => {
+    expect(await subCommand('create').action!(context(), '')).toMatchObject({
+      messageType: 'info',
+      content: expect.stringMatching(
+        /^Backed up 1 files to .*research-backup-.*\.rbk/,
+      ),
+    });
+
+    const ctx = context({ intervalDays: 7 });
+    await backupCommand.action!(ctx, '');
+    expect(shown(ctx)).toMatch(/ {2}\d{4}-\d\d-\d\d \d\d:\d\d .*\.rbk/);
+    expect(shown(ctx)).toContain(
+      'Automatic backups every 7 days at startup, keeping 5.',
+    );
+  });
+
+  it('should keep existing files unless --force is given', async () => {
+    await subCommand('create').action!(context(), 'paper.rbk');
+    fs.writeFileSync(path.join(projectRoot, 'references.bib'), 'changed');
+
+    const ctx = context();
+    await subCommand('restore').action!(ctx, 'paper.rbk');
+
+    expect(shown(ctx)).toContain('Kept 1 existing files');
+    expect(
+      fs.readFileSync(path.join(projectRoot, 'references.bib'), 'utf8'),
+    ).toBe('changed');
+    await subCommand('restore').action!(context(), 'paper.rbk --force');
+    expect(
+      fs.readFileSync(path.join(projectRoot, 'references.bib'), 'utf8'),
+    ).toBe('@misc{a,}');
+  });
+
+  it('should ask for the passphrase in the environment', async () => {
+    vi.stubEnv('MY_PASSPHRASE', '');
+
+    expect(
+      await subCommand('create').action!(
+        context({ passphraseEnv: 'MY_PASSPHRASE' }),
+        '',
+      ),
+    ).toMatchObject({
+      messageType: 'error',
+      content: expect.stringContaining('Set MY_PASSPHRASE to the passphrase'),
+    });
+    expect(await subCommand('restore').action!(context(), '')).toMatchObject({
+      content: 'Usage: /backup restore <file> [--force]',
+    });
+  });
+});
@@ -0,0 +1,160 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import path from 'node:path';
+import {
+  createBackup,
+  getBackupDir,
+  getBackupFileName,
+  getBackupPassphrase,
+  getBackupPassphraseEnv,
+  getErrorMessage,
+  listBackups,
+  restoreBackup,
+} from '@iechor/research-cli-core';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import { MessageType } from '../types.js';
+import { formatBytes } from './storageCommand.js';
+
+const RESTORE_USAGE = 'Usage: /backup restore <file> [--force]';
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+const getRoot = (context: CommandContext) =>
+  context.services.config?.getTargetDir() ?? process.cwd();
+
+const getSettings = (context: CommandContext) =>
+  context.services.settings.merged.backup ?? {};
+
+function missingPassphrase(context: CommandContext) {
+  return error(
+    `Set ${getBackupPassphraseEnv(getSettings(context))} to the passphrase of the backup. It is read from the environment so that it never appears in the history.`,
+  );
+}
+
+const createCommand: SlashCommand = {
+  name: 'create',
+  description:
+    'Write an encrypted backup of the configuration (without secrets), saved chats, memories and bibliography. Usage: /backup create [file]',
+  action: async (context, args) => {
+    const settings = getSettings(context);
+    const passphrase = getBackupPassphrase(settings);
+    if (!passphrase) {
+      return missingPassphrase(context);
+    }
+    const root = getRoot(context);
+    const file = args.trim()
+      ? path.resolve(root, args.trim())
+      : path.join(getBackupDir(settings), getBackupFileName());
+    try {
+      const result = await createBackup(root, file, passphrase);
+      const skipped =
+        result.skipped.length > 0
+          ? ` Left out, as they could not be read as JSON to remove secrets: ${result.skipped.join(', ')}.`
+          : '';
+      return info(
+        `Backed up ${result.files} files to ${file} (${formatBytes(result.bytes)}).${skipped}`,
+      );
+    } catch (e) {
+      return error(`Could not create the backup: ${getErrorMessage(e)}`);
+    }
+  },
+};
+
+const restoreCommand: SlashCommand = {
+  name: 'restore',
+  description: `Restore a backup into ~/.research and this project, keeping existing files unless --force is given. ${RESTORE_USAGE}`,
+  action: async (context, args) => {
+    const tokens = args.trim().split(/\s+/).filter(Boolean);
+    const overwrite = tokens.includes('--force');
+    const rest = tokens.filter((t) => t !== '--force');
+    if (rest.length !== 1) {
+      return error(RESTORE_USAGE);
+    }
+    const passphrase = getBackupPassphrase(getSettings(context));
+    if (!passphrase) {
+      return missingPassphrase(context);
+    }
+    const root = getRoot(context);
+    try {
+      const result = await restoreBackup(
+        path.resolve(root, rest[0]),
+        root,
+        passphrase,
+        { overwrite },
+      );
+      const lines = [`Restored ${result.restored.length} files.`];
+      if (result.skipped.length > 0) {
+        lines.push(
+          `Kept ${result.skipped.length} existing files; run with --force to replace them:`,
+          ...result.skipped.map((name) => `  ${name}`),
+        );
+      }
+      lines.push('Restart the CLI to load the restored settings.');
+      context.ui.addItem(
+        { type: MessageType.INFO, text: lines.join('\n') },
+        Date.now(),
+      );
+    } catch (e) {
+      return error(`Could not restore the backup: ${getErrorMessage(e)}`);
+    }
+  },
+};
+
+export const backupCommand: SlashCommand = {
+  name: 'backup',
+  description:
+    'List backups, or create and restore encrypted backups of the application data.',
+  action: async (context) => {
+    const settings = getSettings(context);
+    const dir = getBackupDir(settings);
+    const lines: string[] = [];
+    try {
+      const backups = await listBackups(dir);
+      lines.push(
+        backups.length > 0
+          ? `Backups in ${dir}:`
+          : `No backups in ${dir} yet. Create one with /backup create.`,
+      );
+      for (const backup of backups) {
+        const date = new Date(backup.modifiedMs)
+          .toISOString()
+          .replace('T', ' ')
+          .slice(0, 16);
+        lines.push(
+          `  ${date}  ${formatBytes(backup.bytes).padStart(9)}  ${path.basename(backup.file)}`,
+        );
+      }
+    } catch (e) {
+      return error(`Could not list the backups: ${getErrorMessage(e)}`);
+    }
+    lines.push(
+      settings.intervalDays !== undefined
+        ? `Automatic backups every ${settings.intervalDays} days at startup, keeping ${settings.keep ?? 5}.`
+        : 'Automatic backups are off; see the backup setting.',
+    );
+    if (!getBackupPassphrase(settings)) {
+      lines.push(
+        `${getBackupPassphraseEnv(settings)} is not set, so no backup can be made or restored.`,
+      );
+    }
+    context.ui.addItem(
+      { type: MessageType.INFO, text: lines.join('\n') },
+      Date.now(),
+    );
+  },
+  subCommands: [createCommand, restoreCommand],
+};
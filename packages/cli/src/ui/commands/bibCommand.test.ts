@@ -0,0 +1,120 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { Config } from '@iechor/research-cli-core';
+import { bibCommand } from './bibCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+
+const BIB = `@article{vaswani2017,
+  title = {Attention Is All You Need},
+  author = {Vaswani, Ashish},
+  journal = {NeurIPS},
+  year = {2017},
+}
+
+@inproceedings{attention,
+  title = {Attention is all you need},
+  booktitle = {NeurIPS},
+  year = {2017},
+}
+`;
+
+describe('bibCommand', () => {
+  let tempDir: string;
+
+  const subCommand = (name: string) =>
+    bibCommand.subCommands!.find((c) => c.name === name)!;
+
+  const context = () =>
+    createMockCommandContext({
+      services: {
+        config: { getTargetDir: () => tempDir } as unknown as Config,
+      },
+    });
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'bib-command-'));
+  });
+
+  afterEach(() => {
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should list duplicates and merge the chosen group', async () => {
+    fs.writeFileSync(path.join(tempDir, 'references.bib'), BIB);
+
+    const listContext = context();
+    await subCommand('dedupe').action!(listContext, '');
+    expect(listContext.ui.addItem).toHaveBeenCalledWith(
+      expect.objectContaining({
+        text: expect.stringContaining('1. vaswani2017, attention (same title)'),
+      }),
+      expect.any(Number),
+    );
+
+    const result = await subCommand('dedupe').action!(context(), 'merge 1');
+    expect(result).toMatchObject({
+      messageType: 'info',
+      content: expect.stringContaining('attention → vaswani2017'),
+    });
+    const merged = fs.readFileSync(
+      path.join(tempDir, 'references.bib'),
+      'utf8',
+    );
+    expect(merged).toContain('@article{vaswani2017,');
+    expect(merged).toContain('booktitle = {NeurIPS},');
+    expect(merged).not.toContain('@inproceedings{attention');
+    expect(
+      fs.readFileSync(path.join(tempDir, 'references.bib.bak'), 'utf8'),
+    ).toBe(BIB);
+  });
+
+  it('should leave the file alone when there is nothing to merge', async () => {
+    const file = path.join(tempDir, 'references.bib');
+    fs.writeFileSync(file, '@misc{a, title = {One}}\n');
+
+    const result = await subCommand('dedupe').action!(context(), 'merge all');
+
+    expect(result).toMatchObject({
+      messageType: 'info',
+      content: 'No duplicate entries in references.bib.',
+    });
+    expect(fs.existsSync(`${file}.bak`)).toBe(false);
+  });
+
+  it('should fix lint problems in the file given with --file', async () => {
+    fs.mkdirSync(path.join(tempDir, 'paper'));
+    const file = path.join(tempDir, 'paper', 'refs.bib');
+    fs.writeFileSync(
+      file,
+      '@misc{gan, title = {Training GANs}, doi = {doi:10.1000/1}}\n',
+    );
+
+    const fixed = await subCommand('lint').action!(
+      context(),
+      'fix all --file paper/refs.bib',
+    );
+
+    expect(fixed).toMatchObject({
+      content: expect.stringContaining('Fixed 2 problems in refs.bib'),
+    });
+    expect(fs.readFileSync(file, 'utf8')).toBe(
+      '@misc{gan,\n  title = {Training {GANs}},\n  doi   = {10.1000/1},\n}\n',
+    );
+  });
+
+  it('should explain how to pick a file when none is found', async () => {
+    const result = await subCommand('lint').action!(context(), '');
+    expect(result).toMatchObject({
+      messageType: 'error',
+      content: expect.stringContaining('No .bib file found'),
+    });
+  });
+});
@@ -0,0 +1,287 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import {
+  BibLintIssue,
+  BibtexItem,
+  applyBibLintFixes,
+  findDuplicateGroups,
+  findUnresolvedDois,
+  getBibEntries,
+  getErrorMessage,
+  lintBibliography,
+  mergeDuplicateGroup,
+  parseBibtex,
+  serializeBibtex,
+} from '@iechor/research-cli-core';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import { MessageType } from '../types.js';
+
+/** Where the managed bibliography is looked for, in order. */
+const DEFAULT_BIB_FILES = ['references.bib', 'bibliography/references.bib'];
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+interface BibArgs {
+  file?: string;
+  online: boolean;
+  positional: string[];
+}
+
+function parseArgs(args: string): BibArgs {
+  const parsed: BibArgs = { online: false, positional: [] };
+  const tokens = args.trim().split(/\s+/).filter(Boolean);
+  for (let i = 0; i < tokens.length; i++) {
+    const token = tokens[i];
+    if (token === '--online') {
+      parsed.online = true;
+    } else if (token.startsWith('--file=')) {
+      parsed.file = token.slice('--file='.length);
+    } else if (token === '--file') {
+      parsed.file = tokens[++i];
+    } else {
+      parsed.positional.push(token);
+    }
+  }
+  return parsed;
+}
+
+/**
+ * Finds the managed .bib file: the --file argument, one of the default
+ * names, or the only .bib file in the project root.
+ */
+export function resolveBibFile(context: CommandContext, file?: string): string {
+  const root = context.services.config?.getTargetDir() ?? process.cwd();
+  if (file) {
+    return path.resolve(root, file);
+  }
+  for (const candidate of DEFAULT_BIB_FILES) {
+    const filePath = path.join(root, candidate);
+    if (fs.existsSync(filePath)) {
+      return filePath;
+    }
+  }
+  const bibFiles = fs
+    .readdirSync(root)
+    .filter((name) => name.endsWith('.bib'));
+  if (bibFiles.length === 1) {
+    return path.join(root, bibFiles[0]);
+  }
+  throw new Error(
+    bibFiles.length === 0
+      ? 'No .bib file found. Pass one with --file <path>.'
+      : `Several .bib files found (${bibFiles.join(', ')}). Pick one with --file <path>.`,
+  );
+}
+
+async function loadBibFile(
+  filePath: string,
+): Promise<{ items: BibtexItem[]; source: string }> {
+  const source = await fs.promises.readFile(filePath, 'utf8');
+  return { items: parseBibtex(source), source };
+}
+
+/** Writes the file, keeping the previous version next to it as .bak. */
+async function saveBibFile(
+  filePath: string,
+  source: string,
+  items: BibtexItem[],
+): Promise<void> {
+  await fs.promises.writeFile(`${filePath}.bak`, source, 'utf8');
+  await fs.promises.writeFile(filePath, serializeBibtex(items), 'utf8');
+}
+
+/** Parses "all" or a list of 1-based numbers like "1 3 4" or "1,3". */
+function parseSelection(
+  tokens: string[],
+  count: number,
+): number[] | undefined {
+  if (tokens.length === 1 && tokens[0] === 'all') {
+    return Array.from({ length: count }, (_, i) => i);
+  }
+  const numbers = tokens.flatMap((token) => token.split(',')).map(Number);
+  if (
+    numbers.length === 0 ||
+    numbers.some((n) => !Number.isInteger(n) || n < 1 || n > count)
+  ) {
+    return undefined;
+  }
+  return [...new Set(numbers)].map((n) => n - 1);
+}
+
+function formatIssue(issue: BibLintIssue, index: number): string {
+  const marker = issue.severity === 'error' ? '✖' : '⚠';
+  const fix = issue.fix ? ` → ${issue.fix.field} = {${issue.fix.value}}` : '';
+  return `${index + 1}. ${marker} ${issue.key}: ${issue.message}${fix}`;
+}
+
+const dedupeCommand: SlashCommand = {
+  name: 'dedupe',
+  description:
+    'Find duplicate entries in the .bib file and merge them. Usage: /bib dedupe [merge <n...>|all] [--file <path>]',
+  action: async (context, args) => {
+    const parsed = parseArgs(args);
+    let filePath: string;
+    let loaded: Awaited<ReturnType<typeof loadBibFile>>;
+    try {
+      filePath = resolveBibFile(context, parsed.file);
+      loaded = await loadBibFile(filePath);
+    } catch (e) {
+      return error(`Could not read the bibliography: ${getErrorMessage(e)}`);
+    }
+    const { items, source } = loaded;
+    const fileName = path.basename(filePath);
+    const groups = findDuplicateGroups(getBibEntries(items));
+    const [operation, ...selection] = parsed.positional;
+
+    if (!operation) {
+      if (groups.length === 0) {
+        return info(`No duplicate entries in ${fileName}.`);
+      }
+      const lines = groups.map(
+        (group, i) =>
+          `${i + 1}. ${group.entries.map((e) => e.key).join(', ')} (same ${group.reason})`,
+      );
+      context.ui.addItem(
+        {
+          type: MessageType.INFO,
+          text: `${groups.length} duplicate groups in ${fileName}:\n\n${lines.join('\n')}`,
+        },
+        Date.now(),
+      );
+      return info(
+        'Merge them with /bib dedupe merge <n...> or /bib dedupe merge all. The most complete entry of each group is kept.',
+      );
+    }
+
+    if (operation !== 'merge') {
+      return error('Usage: /bib dedupe [merge <n...>|all] [--file <path>]');
+    }
+    if (groups.length === 0) {
+      // Nothing to merge, so the file and its .bak are left alone
+      return info(`No duplicate entries in ${fileName}.`);
+    }
+    const selected = parseSelection(selection, groups.length);
+    if (!selected) {
+      return error(`Choose groups between 1 and ${groups.length}, or "all".`);
+    }
+
+    const replacements = selected.map((i) =>
+      mergeDuplicateGroup(items, groups[i]),
+    );
+    try {
+      await saveBibFile(filePath, source, items);
+    } catch (e) {
+      return error(`Could not write ${fileName}: ${getErrorMessage(e)}`);
+    }
+    const citeUpdates = replacements
+      .filter(({ replaced }) => replaced.length > 0)
+      .map(({ kept, replaced }) => `  ${replaced.join(', ')} → ${kept}`);
+    return info(
+      [
+        `Merged ${selected.length} duplicate groups in ${fileName} (previous version saved as ${fileName}.bak).`,
+        ...(citeUpdates.length > 0
+          ? ['Update these citation keys in your documents:', ...citeUpdates]
+          : []),
+      ].join('\n'),
+    );
+  },
+};
+
+const lintCommand: SlashCommand = {
+  name: 'lint',
+  description:
+    'Check the .bib file for missing fields, capitalization and broken DOIs. Usage: /bib lint [fix <n...>|all] [--online] [--file <path>]',
+  action: async (context, args) => {
+    const parsed = parseArgs(args);
+    let filePath: string;
+    let loaded: Awaited<ReturnType<typeof loadBibFile>>;
+    try {
+      filePath = resolveBibFile(context, parsed.file);
+      loaded = await loadBibFile(filePath);
+    } catch (e) {
+      return error(`Could not read the bibliography: ${getErrorMessage(e)}`);
+    }
+    const { items, source } = loaded;
+    const fileName = path.basename(filePath);
+    const entries = getBibEntries(items);
+    const issues = lintBibliography(entries);
+    const [operation, ...selection] = parsed.positional;
+
+    if (!operation) {
+      if (parsed.online) {
+        // Appended last so the numbers of fixable issues stay the same
+        // with and without --online
+        issues.push(...(await findUnresolvedDois(entries)));
+      }
+      if (issues.length === 0) {
+        return info(
+          `${fileName}: ${entries.length} entries, no problems found.`,
+        );
+      }
+      const fixable = issues.filter((issue) => issue.fix).length;
+      context.ui.addItem(
+        {
+          type: MessageType.INFO,
+          text: `${fileName}: ${issues.length} problems in ${entries.length} entries\n\n${issues.map(formatIssue).join('\n')}`,
+        },
+        Date.now(),
+      );
+      return info(
+        fixable > 0
+          ? `${fixable} problems can be fixed automatically: /bib lint fix <n...> or /bib lint fix all.`
+          : 'None of these can be fixed automatically.',
+      );
+    }
+
+    if (operation !== 'fix') {
+      return error(
+        'Usage: /bib lint [fix <n...>|all] [--online] [--file <path>]',
+      );
+    }
+    const selected = parseSelection(selection, issues.length);
+    if (!selected) {
+      return error(
+        issues.length === 0
+          ? `${fileName} has no problems to fix.`
+          : `Choose problems between 1 and ${issues.length}, or "all".`,
+      );
+    }
+    const applied = applyBibLintFixes(
+      entries,
+      selected.map((i) => issues[i]),
+    );
+    if (applied === 0) {
+      return info('None of the selected problems can be fixed automatically.');
+    }
+    try {
+      await saveBibFile(filePath, source, items);
+    } catch (e) {
+      return error(`Could not write ${fileName}: ${getErrorMessage(e)}`);
+    }
+    return info(
+      `Fixed ${applied} problems in ${fileName} (previous version saved as ${fileName}.bak).`,
+    );
+  },
+};
+
+export const bibCommand: SlashCommand = {
+  name: 'bib',
+  description: 'Find duplicates and problems in the project .bib file.',
+  subCommands: [dedupeCommand, lintCommand],
+};
@@ -0,0 +1,108 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { Config } from '@iechor/research-cli-core';
+import { citeCommand } from './citeCommand.js';
+import { latexCommand } from './latexCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+import { MessageType } from '../types.js';
+
+const BIB = `@article{vaswani2017,
+  author = {Vaswani, Ashish and Shazeer, Noam},
+  title = {Attention Is All You Need},
+  year = {2017}
+}
+@inproceedings{devlin2019,
+  author = {Devlin, Jacob},
+  title = {{BERT}: Pre-training of Deep Bidirectional Transformers},
+  year = {2019}
+}
+@article{dosovitskiy2021,
+  author = {Dosovitskiy, Alexey},
+  title = {An Image is Worth 16x16 Words},
+  year = {2021}
+}
+`;
+
+describe('citeCommand', () => {
+  let tempDir: string;
+
+  const context = () =>
+    createMockCommandContext({
+      services: {
+        config: { getTargetDir: () => tempDir } as unknown as Config,
+      },
+    });
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'cite-command-'));
+    fs.writeFileSync(path.join(tempDir, 'refs.bib'), BIB);
+    fs.writeFileSync(
+      path.join(tempDir, 'paper.tex'),
+      [
+        '\\documentclass{article}',
+        '\\section{Intro}\\label{sec:intro}',
+        'See \\citep{devlin2019} and \\citep{devlin2019,missing}; \\cref{sec:none}.',
+        '\\bibliography{refs}',
+      ].join('\n'),
+    );
+  });
+
+  afterEach(() => {
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it("should cite keys with the manuscript's cite command", async () => {
+    const result = await citeCommand.action!(context(), 'vaswani2017');
+    expect(result).toMatchObject({
+      messageType: 'info',
+      content:
+        '\\citep{vaswani2017}\n  vaswani2017: Vaswani, Ashish (2017) Attention Is All You Need',
+    });
+  });
+
+  it('should search the entries for words that are not keys', async () => {
+    const ctx = context();
+    await citeCommand.action!(ctx, 'bert');
+    expect(ctx.ui.addItem).toHaveBeenCalledWith(
+      {
+        type: MessageType.INFO,
+        text: '\\citep{devlin2019}  Devlin, Jacob (2019) BERT: Pre-training of Deep Bidirectional Transformers (cited 2 times)',
+      },
+      expect.any(Number),
+    );
+  });
+
+  it('should complete keys, already cited ones first', async () => {
+    expect(await citeCommand.completion!(context(), '')).toEqual([
+      'devlin2019',
+      'dosovitskiy2021',
+      'vaswani2017',
+    ]);
+    expect(await citeCommand.completion!(context(), 'vaswani2017,do')).toEqual(
+      ['dosovitskiy2021'],
+    );
+  });
+
+  it('should report undefined references and citations with /latex check', async () => {
+    const ctx = context();
+    await latexCommand.subCommands![0].action!(ctx, '');
+    expect(ctx.ui.addItem).toHaveBeenCalledWith(
+      {
+        type: MessageType.INFO,
+        text: [
+          'paper.tex:3  \\cref{sec:none} refers to an undefined label.',
+          'paper.tex:3  \\citep{missing} is not in refs.bib.',
+        ].join('\n'),
+      },
+      expect.any(Number),
+    );
+  });
+});
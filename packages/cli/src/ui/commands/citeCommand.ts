@@ -0,0 +1,163 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import {
+  BibtexEntry,
+  LatexProject,
+  getBibtexField,
+  getErrorMessage,
+  getLatexConventions,
+  isBibtexEntry,
+  parseBibtex,
+} from '@iechor/research-cli-core';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import { MessageType } from '../types.js';
+import { resolveBibFile } from './bibCommand.js';
+import { loadLatexProject } from './latexCommand.js';
+
+const USAGE = 'Usage: /cite <key|search words...>';
+const MAX_SUGGESTIONS = 50;
+const MAX_SEARCH_RESULTS = 10;
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+interface CitationScope {
+  entries: BibtexEntry[];
+  project?: LatexProject;
+}
+
+/**
+ * The entries that can be cited: those of the manuscript's \bibliography
+ * when there is a manuscript, otherwise of the project's .bib file.
+ */
+async function loadCitationScope(
+  context: CommandContext,
+): Promise<CitationScope> {
+  const project = await loadLatexProject(context);
+  const bibFiles =
+    project && project.bibFiles.length > 0
+      ? project.bibFiles.map((file) => path.join(project.root, file))
+      : [resolveBibFile(context)];
+  const entries: BibtexEntry[] = [];
+  for (const file of bibFiles) {
+    if (fs.existsSync(file)) {
+      entries.push(
+        ...parseBibtex(await fs.promises.readFile(file, 'utf8')).filter(
+          isBibtexEntry,
+        ),
+      );
+    }
+  }
+  return { entries, project };
+}
+
+/** How often each key is cited in the manuscript. */
+function countCitations(project?: LatexProject): Map<string, number> {
+  const counts = new Map<string, number>();
+  for (const use of project?.citations ?? []) {
+    counts.set(use.key, (counts.get(use.key) ?? 0) + 1);
+  }
+  return counts;
+}
+
+function describeEntry(entry: BibtexEntry): string {
+  const author = getBibtexField(entry, 'author')?.split(/\s+and\s+/)[0];
+  const year = getBibtexField(entry, 'year');
+  const title = getBibtexField(entry, 'title')?.replace(/[{}]/g, '');
+  return [author, year && `(${year})`, title].filter(Boolean).join(' ');
+}
+
+export const citeCommand: SlashCommand = {
+  name: 'cite',
+  description:
+    "Find bibliography entries and show the citation in the manuscript's style. " +
+    USAGE,
+  action: async (context: CommandContext, args: string) => {
+    const tokens = args.trim().split(/\s+/).filter(Boolean);
+    if (tokens.length === 0) {
+      return error(USAGE);
+    }
+    let scope: CitationScope;
+    try {
+      scope = await loadCitationScope(context);
+    } catch (e) {
+      return error(getErrorMessage(e));
+    }
+    const { entries, project } = scope;
+    const counts = countCitations(project);
+    const command = project ? getLatexConventions(project).citeCommand : 'cite';
+
+    const keys = tokens.flatMap((token) => token.split(',')).filter(Boolean);
+    const cited = keys
+      .map((key) => entries.find((entry) => entry.key === key))
+      .filter((entry): entry is BibtexEntry => entry !== undefined);
+    if (cited.length === keys.length) {
+      return info(
+        [
+          `\\${command}{${keys.join(',')}}`,
+          ...cited.map(
+            (entry) =>
+              `  ${entry.key}: ${describeEntry(entry)}${counts.has(entry.key) ? ` (cited ${counts.get(entry.key)} times)` : ''}`,
+          ),
+        ].join('\n'),
+      );
+    }
+
+    // Not all keys: search the entries for the words instead
+    const words = tokens.map((token) => token.toLowerCase());
+    const matches = entries
+      .filter((entry) => {
+        const text = `${entry.key} ${describeEntry(entry)}`.toLowerCase();
+        return words.every((word) => text.includes(word));
+      })
+      .slice(0, MAX_SEARCH_RESULTS);
+    if (matches.length === 0) {
+      return error(`No bibliography entry matches "${tokens.join(' ')}".`);
+    }
+    context.ui.addItem(
+      {
+        type: MessageType.INFO,
+        text: matches
+          .map(
+            (entry) =>
+              `\\${command}{${entry.key}}  ${describeEntry(entry)}${counts.has(entry.key) ? ` (cited ${counts.get(entry.key)} times)` : ''}`,
+          )
+          .join('\n'),
+      },
+      Date.now(),
+    );
+  },
+  completion: async (context: CommandContext, partialArg: string) => {
+    let scope: CitationScope;
+    try {
+      scope = await loadCitationScope(context);
+    } catch {
+      return [];
+    }
+    const counts = countCitations(scope.project);
+    const partial = (partialArg.split(/[\s,]+/).pop() ?? '').toLowerCase();
+    return scope.entries
+      .map((entry) => entry.key)
+      .filter((key) => key.toLowerCase().startsWith(partial))
+      .sort(
+        (a, b) =>
+          (counts.get(b) ?? 0) - (counts.get(a) ?? 0) || a.localeCompare(b),
+      )
+      .slice(0, MAX_SUGGESTIONS);
+  },
+};
@@ -0,0 +1,109 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { Config } from '@iechor/research-cli-core';
+import { comparePapersCommand } from './comparePapersCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+
+const BIB = `@article{vaswani2017,
+  title = {Attention Is All You Need},
+  abstract = {The Transformer, based solely on attention.},
+  year = {2017},
+}
+
+@article{bahdanau2015,
+  title = {Neural Machine Translation by Jointly Learning to Align and Translate},
+  year = {2015},
+}
+`;
+
+describe('comparePapersCommand', () => {
+  let tempDir: string;
+  const generateJson = vi.fn();
+  const addHistory = vi.fn();
+
+  const context = () =>
+    createMockCommandContext({
+      services: {
+        config: {
+          getTargetDir: () => tempDir,
+          getResearchClient: () => ({ generateJson, addHistory }),
+        } as unknown as Config,
+      },
+    });
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'compare-papers-'));
+    fs.writeFileSync(path.join(tempDir, 'references.bib'), BIB);
+    generateJson.mockResolvedValue({
+      papers: [
+        {
+          key: 'vaswani2017',
+          method: 'Transformer',
+          dataset: 'WMT 2014',
+          metrics: 'BLEU 28.4',
+          limitations: 'Quadratic attention',
+        },
+        {
+          key: 'bahdanau2015',
+          method: 'RNN with attention',
+          dataset: 'WMT 2014',
+          metrics: 'BLEU 26.8',
+          limitations: 'Slow training',
+        },
+      ],
+    });
+  });
+
+  afterEach(() => {
+    vi.clearAllMocks();
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should show the comparison and export it as LaTeX', async () => {
+    const commandContext = context();
+    const result = await comparePapersCommand.action!(
+      commandContext,
+      'vaswani2017,bahdanau2015 --export tables/related.tex',
+    );
+
+    expect(commandContext.ui.addItem).toHaveBeenCalledWith(
+      expect.objectContaining({
+        text: expect.stringContaining(
+          '| Attention Is All You Need [vaswani2017] | Transformer | WMT 2014 | BLEU 28.4 | Quadratic attention |',
+        ),
+      }),
+      expect.any(Number),
+    );
+    expect(addHistory).toHaveBeenCalled();
+    const exported = path.join(tempDir, 'tables', 'related.tex');
+    expect(result).toEqual({
+      type: 'message',
+      messageType: 'info',
+      content: `Exported the table to ${exported}.`,
+    });
+    expect(fs.readFileSync(exported, 'utf8')).toContain(
+      '\\cite{bahdanau2015} & RNN with attention',
+    );
+  });
+
+  it('should report keys that are not in the bibliography', async () => {
+    const result = await comparePapersCommand.action!(
+      context(),
+      'vaswani2017 devlin2019',
+    );
+
+    expect(result).toMatchObject({
+      messageType: 'error',
+      content: 'Not in references.bib: devlin2019',
+    });
+    expect(generateJson).not.toHaveBeenCalled();
+  });
+});
@@ -0,0 +1,136 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import {
+  PaperSource,
+  comparePapers,
+  formatComparison,
+  formatComparisonMarkdown,
+  getErrorMessage,
+  loadPaperSources,
+} from '@iechor/research-cli-core';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import { MessageType } from '../types.js';
+import { resolveBibFile } from './bibCommand.js';
+
+const USAGE =
+  'Usage: /compare-papers <key1> <key2> [...] [--file <bib>] [--export <table.md|table.tex>]';
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+interface CompareArgs {
+  keys: string[];
+  file?: string;
+  exportPath?: string;
+}
+
+function parseArgs(args: string): CompareArgs {
+  const parsed: CompareArgs = { keys: [] };
+  const tokens = args.trim().split(/\s+/).filter(Boolean);
+  for (let i = 0; i < tokens.length; i++) {
+    if (tokens[i] === '--file') {
+      parsed.file = tokens[++i];
+    } else if (tokens[i] === '--export') {
+      parsed.exportPath = tokens[++i];
+    } else {
+      // Keys may also be pasted as "a,b" or "\cite{a,b}"
+      parsed.keys.push(
+        ...tokens[i]
+          .replace(/^\\cite\w*\{|\}$/g, '')
+          .split(',')
+          .filter(Boolean),
+      );
+    }
+  }
+  return parsed;
+}
+
+export const comparePapersCommand: SlashCommand = {
+  name: 'compare-papers',
+  description:
+    'Compare the method, datasets, metrics and limitations of bibliography entries in a table. ' +
+    USAGE,
+  action: async (context: CommandContext, args: string) => {
+    const { keys, file, exportPath } = parseArgs(args);
+    const config = context.services.config;
+    if (keys.length < 2) {
+      return error(USAGE);
+    }
+    if (!config) {
+      return error('The comparison needs a configured model.');
+    }
+
+    const root = config.getTargetDir();
+    let bibFile: string;
+    let sources: PaperSource[];
+    try {
+      bibFile = resolveBibFile(context, file);
+      sources = await loadPaperSources(bibFile, keys);
+    } catch (e) {
+      return error(getErrorMessage(e));
+    }
+
+    const withText = sources.filter((source) => source.text).length;
+    context.ui.setDebugMessage(`Comparing ${keys.length} papers...`);
+    let table: string;
+    let exported: string | undefined;
+    try {
+      const rows = await comparePapers(
+        config.getResearchClient(),
+        sources,
+        context.abortSignal,
+      );
+      table = formatComparisonMarkdown(rows);
+      if (exportPath) {
+        exported = path.resolve(root, exportPath);
+        await fs.promises.mkdir(path.dirname(exported), { recursive: true });
+        const format = exported.endsWith('.tex') ? 'latex' : 'markdown';
+        await fs.promises.writeFile(
+          exported,
+          formatComparison(rows, format),
+          'utf8',
+        );
+      }
+    } catch (e) {
+      return error(`Could not compare the papers: ${getErrorMessage(e)}`);
+    }
+
+    context.ui.addItem(
+      {
+        type: MessageType.INFO,
+        text: [
+          table,
+          '',
+          `Based on the full text of ${withText} of ${keys.length} papers; the rest from their abstracts and metadata. PDFs are found via the file field or <key>.pdf next to the .bib.`,
+        ].join('\n'),
+      },
+      Date.now(),
+    );
+    await config.getResearchClient()?.addHistory({
+      role: 'user',
+      parts: [
+        {
+          text: `Comparison of ${keys.join(', ')} from ${bibFile}:\n\n${table}`,
+        },
+      ],
+    });
+    if (exported) {
+      return info(`Exported the table to ${exported}.`);
+    }
+  },
+};
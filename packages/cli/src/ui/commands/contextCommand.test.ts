@@ -0,0 +1,98 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, vi, beforeEach } from 'vitest';
+import { Content } from '@google/genai';
+import { Config, ContextFilter } from '@iechor/research-cli-core';
+import { contextCommand, parseSelection } from './contextCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+import { CommandContext } from './types.js';
+
+describe('parseSelection', () => {
+  it('should parse numbers, ranges and all', () => {
+    expect(parseSelection('1 3-4, 7')).toEqual([1, 3, 4, 7]);
+    expect(parseSelection('all')).toBe('all');
+    expect(parseSelection('')).toBeUndefined();
+    expect(parseSelection('two')).toBeUndefined();
+  });
+});
+
+describe('contextCommand', () => {
+  let context: CommandContext;
+  let filter: ContextFilter;
+  const history: Content[] = [
+    { role: 'user', parts: [{ text: 'Load the data.' }] },
+    { role: 'model', parts: [{ text: 'Loaded 120 rows.' }] },
+    { role: 'user', parts: [{ text: 'Tell me a joke.' }] },
+    { role: 'model', parts: [{ text: 'Why did the p-value...' }] },
+  ];
+  const shown = () => {
+    const calls = vi.mocked(context.ui.addItem).mock.calls;
+    return calls[calls.length - 1][0].text;
+  };
+  const subCommand = (name: string) =>
+    contextCommand.subCommands!.find((c) => c.name === name)!;
+
+  beforeEach(() => {
+    const client = {
+      isInitialized: () => true,
+      addContextFilter: (f: ContextFilter) => {
+        filter = f;
+        return () => {};
+      },
+      getChat: () => ({
+        getHistory: () => structuredClone(history),
+        getRequestHistory: () => filter(structuredClone(history)),
+      }),
+      getContentGenerator: () => ({
+        countTokens: async ({ contents }: { contents: Content[] }) => ({
+          totalTokens: contents.length * 1000,
+        }),
+      }),
+    };
+    context = createMockCommandContext({
+      services: {
+        config: {
+          getModel: () => 'gpt-4o',
+          getResearchClient: () => client,
+        } as unknown as Config,
+      },
+    });
+  });
+
+  it('should show every message with the token count', async () => {
+    await contextCommand.action!(context, '');
+
+    expect(shown()).toBe(
+      [
+        'Sent with the next request: 2 of 2 messages, 4,000 tokens.',
+        '  [x] 1  Load the data. → Loaded 120 rows.',
+        '  [x] 2  Tell me a joke. → Why did the p-value...',
+        'Change with /context include|exclude|only <n|from-to|all>...',
+      ].join('\n'),
+    );
+  });
+
+  it('should toggle messages and update the count', async () => {
+    await subCommand('exclude').action!(context, '2');
+
+    expect(shown()).toContain('1 of 2 messages, 2,000 tokens');
+    expect(shown()).toContain('  [ ] 2  Tell me a joke.');
+    expect(filter(history)).toEqual(history.slice(0, 2));
+
+    await subCommand('only').action!(context, '2');
+    expect(shown()).toContain('  [ ] 1  Load the data.');
+    await subCommand('include').action!(context, 'all');
+    expect(shown()).toContain('2 of 2 messages');
+  });
+
+  it('should reject malformed selections', async () => {
+    expect(await subCommand('exclude').action!(context, 'x')).toMatchObject({
+      messageType: 'error',
+      content: 'Usage: /context exclude <n|from-to|all>...',
+    });
+  });
+});
@@ -0,0 +1,132 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import {
+  ContextSelection,
+  getContextSelection,
+} from '@iechor/research-cli-core';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import { MessageType } from '../types.js';
+
+const EXCERPT_LENGTH = 40;
+const SELECTOR = '<n|from-to|all>...';
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+function excerpt(text: string): string {
+  const line = text.replace(/\s+/g, ' ').trim();
+  return line.length > EXCERPT_LENGTH
+    ? `${line.slice(0, EXCERPT_LENGTH - 1)}…`
+    : line;
+}
+
+/** Parses "3 5-7" into exchange numbers; undefined when malformed. */
+export function parseSelection(args: string): number[] | 'all' | undefined {
+  const tokens = args.trim().split(/[\s,]+/).filter(Boolean);
+  if (tokens.length === 0) {
+    return undefined;
+  }
+  if (tokens.length === 1 && tokens[0] === 'all') {
+    return 'all';
+  }
+  const numbers: number[] = [];
+  for (const token of tokens) {
+    const match = token.match(/^(\d+)(?:-(\d+))?$/);
+    if (!match) {
+      return undefined;
+    }
+    const from = Number(match[1]);
+    const to = Number(match[2] ?? match[1]);
+    for (let n = Math.min(from, to); n <= Math.max(from, to); n++) {
+      numbers.push(n);
+    }
+  }
+  return numbers;
+}
+
+function getSelection(context: CommandContext): ContextSelection | undefined {
+  const client = context.services.config?.getResearchClient();
+  return client?.isInitialized() ? getContextSelection(client) : undefined;
+}
+
+async function showSelection(
+  context: CommandContext,
+  selection: ContextSelection,
+): Promise<void> {
+  const entries = selection.list();
+  const included = entries.filter((entry) => entry.included).length;
+  let tokens: number | undefined;
+  try {
+    tokens = await selection.countTokens(context.services.config!.getModel());
+  } catch {
+    // Leave the count out when the provider cannot count
+  }
+  const width = String(entries.length).length;
+  const lines = [
+    `Sent with the next request: ${included} of ${entries.length} messages${tokens !== undefined ? `, ${tokens.toLocaleString('en-US')} tokens` : ''}.`,
+    ...entries.map(
+      (entry) =>
+        `  [${entry.included ? 'x' : ' '}] ${String(entry.exchange).padStart(width)}  ${excerpt(entry.prompt)} → ${excerpt(entry.answer)}`,
+    ),
+    `Change with /context include|exclude|only ${SELECTOR}`,
+  ];
+  context.ui.addItem(
+    { type: MessageType.INFO, text: lines.join('\n') },
+    Date.now(),
+  );
+}
+
+function selectCommand(
+  name: 'include' | 'exclude' | 'only',
+  description: string,
+): SlashCommand {
+  return {
+    name,
+    description: `${description} Usage: /context ${name} ${SELECTOR}`,
+    action: async (context, args) => {
+      const selection = getSelection(context);
+      if (!selection) {
+        return error('There is no conversation yet.');
+      }
+      const exchanges = parseSelection(args);
+      if (!exchanges) {
+        return error(`Usage: /context ${name} ${SELECTOR}`);
+      }
+      if (name === 'only') {
+        selection.setIncluded('all', false);
+      }
+      selection.setIncluded(exchanges, name !== 'exclude');
+      await showSelection(context, selection);
+    },
+  };
+}
+
+export const contextCommand: SlashCommand = {
+  name: 'context',
+  description:
+    'Show which messages are sent with the next request and how many tokens they take, or choose them.',
+  action: async (context) => {
+    const selection = getSelection(context);
+    if (!selection) {
+      return error('There is no conversation yet.');
+    }
+    await showSelection(context, selection);
+  },
+  subCommands: [
+    selectCommand('include', 'Send messages with the requests again.'),
+    selectCommand(
+      'exclude',
+      'Leave messages out of the requests; they stay in the history.',
+    ),
+    selectCommand('only', 'Send only these messages.'),
+  ],
+};
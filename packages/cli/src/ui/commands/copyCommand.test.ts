@@ -0,0 +1,58 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, vi, beforeEach } from 'vitest';
+import { copyCommand } from './copyCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+import { copyToClipboard } from '../utils/clipboardUtils.js';
+import { HistoryItem } from '../types.js';
+
+vi.mock('../utils/clipboardUtils.js', () => ({
+  copyToClipboard: vi.fn(),
+}));
+
+const history: HistoryItem[] = [
+  { id: 1, type: 'user', text: 'How?' },
+  {
+    id: 2,
+    type: 'research',
+    text: '```bash\npip install numpy\n```\n```py\nimport numpy\n```',
+    firstCodeBlock: 1,
+  },
+];
+
+describe('copyCommand', () => {
+  const context = createMockCommandContext({ ui: { history } });
+
+  beforeEach(() => {
+    vi.mocked(copyToClipboard).mockReset();
+  });
+
+  it('should copy the last block without a number', async () => {
+    expect(await copyCommand.action!(context, '')).toMatchObject({
+      messageType: 'info',
+      content: 'Copied code block 2 (py) to the clipboard.',
+    });
+    expect(copyToClipboard).toHaveBeenCalledWith('import numpy');
+  });
+
+  it('should copy a block by its number', async () => {
+    await copyCommand.action!(context, '1');
+    expect(copyToClipboard).toHaveBeenCalledWith('pip install numpy');
+  });
+
+  it('should report a block that is not there', async () => {
+    expect(await copyCommand.action!(context, '3')).toMatchObject({
+      messageType: 'error',
+      content:
+        'There is no code block 3 to copy; the blocks shown are numbered up to 2.',
+    });
+    expect(await copyCommand.action!(context, 'x')).toMatchObject({
+      content: 'Usage: /copy [n]',
+    });
+    expect(copyToClipboard).not.toHaveBeenCalled();
+  });
+});
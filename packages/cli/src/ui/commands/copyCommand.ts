@@ -0,0 +1,54 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { getErrorMessage } from '@iechor/research-cli-core';
+import { SlashCommand, SlashCommandActionReturn } from './types.js';
+import { findCodeBlock, nextCodeBlockNumber } from '../utils/codeBlocks.js';
+import { copyToClipboard } from '../utils/clipboardUtils.js';
+
+export const copyCommand: SlashCommand = {
+  name: 'copy',
+  description:
+    'Copy code block n of the answers to the clipboard, or the last one without n.',
+  action: async (context, args): Promise<SlashCommandActionReturn> => {
+    const { history } = context.ui;
+    const lastCodeBlock = nextCodeBlockNumber(history) - 1;
+    const arg = args.trim();
+    if (arg && !/^\d+$/.test(arg)) {
+      return {
+        type: 'message',
+        messageType: 'error',
+        content: 'Usage: /copy [n]',
+      };
+    }
+    const number = arg ? Number(arg) : lastCodeBlock;
+    const block = findCodeBlock(history, number);
+    if (!block) {
+      return {
+        type: 'message',
+        messageType: 'error',
+        content:
+          lastCodeBlock > 0
+            ? `There is no code block ${number} to copy; the blocks shown are numbered up to ${lastCodeBlock}.`
+            : 'There are no code blocks to copy yet.',
+      };
+    }
+    try {
+      await copyToClipboard(block.code);
+    } catch (e) {
+      return {
+        type: 'message',
+        messageType: 'error',
+        content: `Could not copy code block ${number}: ${getErrorMessage(e)}`,
+      };
+    }
+    return {
+      type: 'message',
+      messageType: 'info',
+      content: `Copied code block ${number}${block.language ? ` (${block.language})` : ''} to the clipboard.`,
+    };
+  },
+};
@@ -0,0 +1,207 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { Config, getUsageHistoryPath } from '@iechor/research-cli-core';
+import { LoadedSettings } from '../../config/settings.js';
+import {
+  bar,
+  dashboardCommand,
+  heatLevel,
+  sparkline,
+} from './dashboardCommand.js';
+import { CommandContext } from './types.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+
+describe('dashboardCommand', () => {
+  let tempDir: string;
+
+  const context = (
+    usageHistory?: boolean,
+    activityStreaks?: boolean,
+  ): CommandContext =>
+    createMockCommandContext({
+      services: {
+        config: { getTargetDir: () => '/work/paper' } as unknown as Config,
+        settings: {
+          merged: { usageHistory, activityStreaks },
+        } as unknown as LoadedSettings,
+      },
+    });
+
+  const writeHistory = (records: object[]) => {
+    const filePath = getUsageHistoryPath();
+    fs.mkdirSync(path.dirname(filePath), { recursive: true });
+    fs.writeFileSync(
+      filePath,
+      records.map((r) => JSON.stringify(r)).join('\n') + '\n',
+    );
+  };
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'dashboard-command-'));
+    // The usage history lives under the home directory
+    vi.stubEnv('HOME', tempDir);
+  });
+
+  afterEach(() => {
+    vi.useRealTimers();
+    vi.unstubAllEnvs();
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should draw sparklines and bars scaled to the largest value', () => {
+    expect(sparkline([0, 1, 4, 8])).toBe('·▂▅█');
+    expect(bar(5, 10, 10)).toBe('█████');
+    expect(bar(1, 1000, 10)).toBe('█');
+    expect(bar(0, 10, 10)).toBe('');
+    expect(heatLevel(0, 4)).toBe('·');
+    expect(heatLevel(1, 4)).toBe('░');
+    expect(heatLevel(3, 4)).toBe('▓');
+    expect(heatLevel(4, 4)).toBe('█');
+  });
+
+  it('should chart messages, models, tools and latency', async () => {
+    const now = new Date().toISOString();
+    writeHistory([
+      { kind: 'prompt', timestamp: now, project: '/work/paper' },
+      { kind: 'prompt', timestamp: now, project: '/work/other' },
+      {
+        kind: 'api',
+        timestamp: now,
+        project: '/work/paper',
+        model: 'gpt-4o-mini',
+        inputTokens: 20000,
+        outputTokens: 4000,
+        durationMs: 1500,
+      },
+      {
+        kind: 'tool',
+        timestamp: now,
+        project: '/work/paper',
+        tool: 'web_search',
+        durationMs: 800,
+        success: true,
+      },
+    ]);
+    const ctx = context();
+
+    await dashboardCommand.action!(ctx, '7');
+
+    const [item] = vi.mocked(ctx.ui.addItem).mock.calls[0];
+    const text = (item as { text: string }).text;
+    expect(text).toContain('Usage over the last 7 days (all projects)');
+    expect(text).toMatch(/Messages per day {2}······█ {2}total 2/);
+    expect(text).toContain('gpt-4o-mini  ' + '█'.repeat(24));
+    expect(text).toContain('24k tokens, $<0.01');
+    expect(text).toContain('20k in, 4000 out, 1 requests, avg 1.5s');
+    expect(text).toMatch(/web_search {2}█+ +1/);
+    expect(text).toContain('Average latency: 1.5s over 1 requests');
+  });
+
+  it('should keep to the current project with --project', async () => {
+    writeHistory([
+      {
+        kind: 'prompt',
+        timestamp: new Date().toISOString(),
+        project: '/work/other',
+      },
+    ]);
+
+    expect(await dashboardCommand.action!(context(), '--project')).toEqual({
+      type: 'message',
+      messageType: 'info',
+      content: 'No usage recorded in the last 14 days.',
+    });
+    expect(
+      await dashboardCommand.action!(context(false), '--project'),
+    ).toMatchObject({
+      content: expect.stringContaining('Usage history is turned off'),
+    });
+  });
+
+  it('should reject a bad number of days', async () => {
+    expect(await dashboardCommand.action!(context(), '0')).toMatchObject({
+      messageType: 'error',
+      content: 'Usage: /dashboard [days] [--project]',
+    });
+  });
+
+  describe('heatmap', () => {
+    const heatmap = dashboardCommand.subCommands![0];
+    const at = (day: number) => new Date(2025, 5, day, 12).toISOString();
+
+    beforeEach(() => {
+      // Wednesday, June 11th
+      vi.useFakeTimers({ toFake: ['Date'] });
+      vi.setSystemTime(new Date(2025, 5, 11, 18));
+      writeHistory([
+        { kind: 'prompt', timestamp: at(2), project: '/work/paper' },
+        { kind: 'prompt', timestamp: at(10), project: '/work/paper' },
+        {
+          kind: 'prompt',
+          timestamp: at(10),
+          project: '/work/paper',
+          session: 'other',
+        },
+        {
+          kind: 'tool',
+          timestamp: at(11),
+          project: '/work/other',
+          tool: 'save_memory',
+          durationMs: 5,
+          success: true,
+        },
+      ]);
+    });
+
+    it('should shade one column per week up to today', async () => {
+      const ctx = context(undefined, true);
+
+      await heatmap.action!(ctx, '2');
+
+      const [item] = vi.mocked(ctx.ui.addItem).mock.calls[0];
+      expect((item as { text: string }).text).toBe(
+        [
+          '    Jun',
+          'Mon ▒·',
+          '    ·█',
+          'Wed ·▒',
+          '    ·',
+          'Fri ·',
+          '    ·',
+          'Sun ·',
+          '    Less ·░▒▓█ More',
+          '',
+          '3 sessions and 1 notes on 3 active days, 2025-06-02 to 2025-06-11',
+          'Current streak: 2 days, longest: 2 days',
+        ].join('\n'),
+      );
+    });
+
+    it('should keep to the current project and leave out streaks', async () => {
+      const ctx = context();
+
+      await heatmap.action!(ctx, '--project 1');
+
+      const [item] = vi.mocked(ctx.ui.addItem).mock.calls[0];
+      const text = (item as { text: string }).text;
+      expect(text).toContain('Mon ·\n    █\nWed ·\n');
+      expect(text).toContain('2 sessions and 0 notes on 1 active days');
+      expect(text).not.toContain('streak');
+    });
+
+    it('should reject a bad number of weeks', async () => {
+      expect(await heatmap.action!(context(), '54')).toMatchObject({
+        messageType: 'error',
+        content: 'Usage: /dashboard heatmap [weeks] [--project]',
+      });
+    });
+  });
+});
@@ -0,0 +1,308 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import {
+  ActivitySummary,
+  getErrorMessage,
+  loadUsageHistory,
+  summarizeActivity,
+  summarizeUsage,
+  UsageSummary,
+} from '@iechor/research-cli-core';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import { MessageType } from '../types.js';
+
+const DEFAULT_DAYS = 14;
+const MAX_DAYS = 366;
+const BAR_WIDTH = 24;
+const SPARK_LEVELS = '▁▂▃▄▅▆▇█';
+const USAGE = 'Usage: /dashboard [days] [--project]';
+const DEFAULT_WEEKS = 26;
+const MAX_WEEKS = 53;
+const HEAT_LEVELS = '·░▒▓█';
+const HEATMAP_USAGE = 'Usage: /dashboard heatmap [weeks] [--project]';
+const WEEKDAYS = ['Mon', '', 'Wed', '', 'Fri', '', 'Sun'];
+const MONTHS = [
+  'Jan',
+  'Feb',
+  'Mar',
+  'Apr',
+  'May',
+  'Jun',
+  'Jul',
+  'Aug',
+  'Sep',
+  'Oct',
+  'Nov',
+  'Dec',
+];
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+/** One character per value, scaled to the largest; zero is a dot. */
+export function sparkline(values: number[]): string {
+  const max = Math.max(...values, 0);
+  return values
+    .map((value) =>
+      value <= 0
+        ? '·'
+        : SPARK_LEVELS[
+            Math.min(
+              SPARK_LEVELS.length - 1,
+              Math.floor((value / max) * SPARK_LEVELS.length),
+            )
+          ],
+    )
+    .join('');
+}
+
+export function bar(value: number, max: number, width = BAR_WIDTH): string {
+  if (value <= 0 || max <= 0) {
+    return '';
+  }
+  return '█'.repeat(Math.max(1, Math.round((value / max) * width)));
+}
+
+/**
+ * Shade of a heatmap cell, from a dot for nothing up to a full block for
+ * the busiest day.
+ */
+export function heatLevel(value: number, max: number): string {
+  if (value <= 0 || max <= 0) {
+    return HEAT_LEVELS[0];
+  }
+  const steps = HEAT_LEVELS.length - 1;
+  return HEAT_LEVELS[Math.min(steps, Math.ceil((value / max) * steps))];
+}
+
+function formatCount(n: number): string {
+  if (n >= 1_000_000) {
+    return `${(n / 1_000_000).toFixed(1)}M`;
+  }
+  if (n >= 10_000) {
+    return `${Math.round(n / 1000)}k`;
+  }
+  return String(n);
+}
+
+function formatSeconds(ms: number): string {
+  return `${(ms / 1000).toFixed(1)}s`;
+}
+
+function formatCost(cost: number | undefined, currency?: string): string {
+  if (cost === undefined) {
+    return 'cost unknown';
+  }
+  const symbol = currency === 'CNY' ? '¥' : '$';
+  return `${symbol}${cost < 0.01 && cost > 0 ? '<0.01' : cost.toFixed(2)}`;
+}
+
+function dailyChart(
+  label: string,
+  days: Array<{ date: string; count: number }>,
+): string {
+  const counts = days.map((d) => d.count);
+  const total = counts.reduce((sum, n) => sum + n, 0);
+  const peak = days.reduce((best, d) => (d.count > best.count ? d : best));
+  const peakText =
+    total > 0 ? `, peak ${formatCount(peak.count)} on ${peak.date}` : '';
+  return `${label.padEnd(18)}${sparkline(counts)}  total ${formatCount(total)}${peakText}`;
+}
+
+function formatDashboard(
+  summary: UsageSummary,
+  days: number,
+  scope: string,
+): string {
+  const dates = summary.messagesPerDay.map((d) => d.date);
+  const [first, last] = [dates[0], dates[dates.length - 1]];
+  const lines = [
+    `Usage over the last ${days} days (${scope}), ${first} to ${last}`,
+    '',
+    dailyChart('Messages per day', summary.messagesPerDay),
+    dailyChart('Tokens per day', summary.tokensPerDay),
+  ];
+
+  if (summary.models.length > 0) {
+    const tokens = (m: UsageSummary['models'][number]) =>
+      m.inputTokens + m.outputTokens;
+    const maxTokens = Math.max(...summary.models.map(tokens));
+    const nameWidth = Math.max(...summary.models.map((m) => m.model.length));
+    lines.push('', 'Tokens and cost by model');
+    for (const m of summary.models) {
+      const chart = bar(tokens(m), maxTokens).padEnd(BAR_WIDTH);
+      lines.push(
+        `  ${m.model.padEnd(nameWidth)}  ${chart}  ${formatCount(tokens(m))} tokens, ${formatCost(m.cost, m.currency)}`,
+        `  ${''.padEnd(nameWidth)}  ${formatCount(m.inputTokens)} in, ${formatCount(m.outputTokens)} out, ${m.requests} requests, avg ${formatSeconds(m.averageLatencyMs)}`,
+      );
+    }
+  }
+
+  if (summary.tools.length > 0) {
+    const maxCalls = summary.tools[0].calls;
+    const nameWidth = Math.max(...summary.tools.map((t) => t.tool.length));
+    lines.push('', 'Tool usage');
+    for (const t of summary.tools) {
+      const chart = bar(t.calls, maxCalls).padEnd(BAR_WIDTH);
+      const failed = t.failures > 0 ? ` (${t.failures} failed)` : '';
+      lines.push(
+        `  ${t.tool.padEnd(nameWidth)}  ${chart}  ${t.calls}${failed}`,
+      );
+    }
+  }
+
+  if (summary.requests > 0) {
+    lines.push(
+      '',
+      `Average latency: ${formatSeconds(summary.averageLatencyMs)} over ${summary.requests} requests`,
+    );
+  }
+  return lines.join('\n');
+}
+
+function formatStreaks(activity: ActivitySummary): string {
+  const days = (n: number) => `${n} ${n === 1 ? 'day' : 'days'}`;
+  return `Current streak: ${days(activity.currentStreak)}, longest: ${days(activity.longestStreak)}`;
+}
+
+/**
+ * Draws one column per week (Monday to Sunday) and one row per weekday,
+ * ending with the current week. Days after today are left blank.
+ */
+export function formatHeatmap(activity: ActivitySummary): string {
+  const { days } = activity;
+  const weeks = Math.ceil(days.length / 7);
+  const max = Math.max(...days.map((d) => d.sessions + d.notes), 0);
+
+  let months = '';
+  let lastMonth = -1;
+  for (let week = 0; week < weeks; week++) {
+    const month = Number(days[week * 7].date.slice(5, 7)) - 1;
+    if (month !== lastMonth && (!months || months.length < week)) {
+      months = months.padEnd(week) + MONTHS[month];
+    }
+    lastMonth = month;
+  }
+  const lines = [`    ${months}`.trimEnd()];
+  WEEKDAYS.forEach((label, weekday) => {
+    let row = '';
+    for (let week = 0; week < weeks; week++) {
+      const day = days[week * 7 + weekday];
+      row += day ? heatLevel(day.sessions + day.notes, max) : ' ';
+    }
+    lines.push(`${label.padEnd(4)}${row}`.trimEnd());
+  });
+
+  const sessions = days.reduce((sum, d) => sum + d.sessions, 0);
+  const notes = days.reduce((sum, d) => sum + d.notes, 0);
+  const active = days.filter((d) => d.sessions + d.notes > 0).length;
+  lines.push(
+    `    Less ${HEAT_LEVELS} More`,
+    '',
+    `${sessions} sessions and ${notes} notes on ${active} active days, ${days[0].date} to ${days[days.length - 1].date}`,
+  );
+  return lines.join('\n');
+}
+
+function parseScope(
+  context: CommandContext,
+  args: string,
+): { rest: string[]; project?: string } {
+  const tokens = args.trim().split(/\s+/).filter(Boolean);
+  const project = tokens.includes('--project')
+    ? context.services.config?.getTargetDir()
+    : undefined;
+  return { rest: tokens.filter((t) => t !== '--project'), project };
+}
+
+const heatmapCommand: SlashCommand = {
+  name: 'heatmap',
+  description: `Show a heatmap of research sessions and notes per day. ${HEATMAP_USAGE}`,
+  action: async (context, args) => {
+    const { rest, project } = parseScope(context, args);
+    const weeks = rest.length > 0 ? Number(rest[0]) : DEFAULT_WEEKS;
+    if (
+      rest.length > 1 ||
+      !Number.isInteger(weeks) ||
+      weeks < 1 ||
+      weeks > MAX_WEEKS
+    ) {
+      return error(HEATMAP_USAGE);
+    }
+
+    let records;
+    try {
+      records = await loadUsageHistory();
+    } catch (e) {
+      return error(`Could not read the usage history: ${getErrorMessage(e)}`);
+    }
+    // Start on a Monday, so that each column is one week
+    const weekday = (new Date().getDay() + 6) % 7;
+    const activity = summarizeActivity(records, {
+      days: (weeks - 1) * 7 + weekday + 1,
+      project,
+    });
+    let text = formatHeatmap(activity);
+    if (context.services.settings.merged.activityStreaks) {
+      text += `\n${formatStreaks(activity)}`;
+    }
+    context.ui.addItem({ type: MessageType.INFO, text }, Date.now());
+  },
+};
+
+export const dashboardCommand: SlashCommand = {
+  name: 'dashboard',
+  description: `Show charts of messages per day, tokens and cost per model, tool usage and latency from the stored usage history. ${USAGE}`,
+  action: async (context, args) => {
+    const { rest, project } = parseScope(context, args);
+    const days = rest.length > 0 ? Number(rest[0]) : DEFAULT_DAYS;
+    if (
+      rest.length > 1 ||
+      !Number.isInteger(days) ||
+      days < 1 ||
+      days > MAX_DAYS
+    ) {
+      return error(USAGE);
+    }
+
+    let records;
+    try {
+      records = await loadUsageHistory();
+    } catch (e) {
+      return error(`Could not read the usage history: ${getErrorMessage(e)}`);
+    }
+    const summary = summarizeUsage(records, { days, project });
+    if (summary.prompts === 0 && summary.requests === 0) {
+      const disabled =
+        context.services.settings.merged.usageHistory === false
+          ? ' Usage history is turned off by the usageHistory setting.'
+          : '';
+      return {
+        type: 'message',
+        messageType: 'info',
+        content: `No usage recorded in the last ${days} days.${disabled}`,
+      };
+    }
+
+    let text = formatDashboard(
+      summary,
+      days,
+      project ? 'this project' : 'all projects',
+    );
+    if (context.services.settings.merged.activityStreaks) {
+      const activity = summarizeActivity(records, { days: 1, project });
+      text += `\n\n${formatStreaks(activity)}`;
+    }
+    context.ui.addItem({ type: MessageType.INFO, text }, Date.now());
+  },
+  subCommands: [heatmapCommand],
+};
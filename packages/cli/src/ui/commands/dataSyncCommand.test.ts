@@ -0,0 +1,100 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, vi } from 'vitest';
+import {
+  DataSyncSettings,
+  resolveConflict,
+  syncData,
+} from '@iechor/research-cli-core';
+import { LoadedSettings } from '../../config/settings.js';
+import { dataSyncCommand } from './dataSyncCommand.js';
+import { CommandContext } from './types.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+
+vi.mock('@iechor/research-cli-core', async (importOriginal) => {
+  const actual =
+    await importOriginal<typeof import('@iechor/research-cli-core')>();
+  return {
+    ...actual,
+    createSyncBackend: vi.fn(() => ({})),
+    syncData: vi.fn(),
+    resolveConflict: vi.fn(),
+  };
+});
+
+describe('dataSyncCommand', () => {
+  const subCommand = (name: string) =>
+    dataSyncCommand.subCommands!.find((c) => c.name === name)!;
+
+  const context = (dataSync?: DataSyncSettings): CommandContext =>
+    createMockCommandContext({
+      services: {
+        settings: { merged: { dataSync } } as unknown as LoadedSettings,
+      },
+    });
+
+  const shown = (ctx: CommandContext) =>
+    (vi.mocked(ctx.ui.addItem).mock.calls[0][0] as { text: string }).text;
+
+  beforeEach(() => {
+    vi.clearAllMocks();
+    vi.mocked(syncData).mockResolvedValue({
+      pushed: ['RESEARCH.md'],
+      pulled: [],
+      conflicts: ['tmp/abc/checkpoint-draft.json'],
+      overwritten: [],
+    });
+  });
+
+  it('should sync with the configured strategy', async () => {
+    const ctx = context({
+      backend: 'git',
+      remote: 'git@example.org:me/notes.git',
+      conflicts: 'last-writer-wins',
+    });
+
+    await dataSyncCommand.action!(ctx, '');
+
+    expect(syncData).toHaveBeenCalledWith(
+      {},
+      { strategy: 'last-writer-wins', dryRun: false },
+    );
+    expect(shown(ctx)).toContain('Pushed:\n  RESEARCH.md');
+    expect(shown(ctx)).toContain('.remote suffix');
+    expect(shown(ctx)).toContain('  tmp/abc/checkpoint-draft.json');
+  });
+
+  it('should only preview with status', async () => {
+    const ctx = context({ backend: 's3' });
+
+    await subCommand('status').action!(ctx, '');
+
+    expect(vi.mocked(syncData).mock.calls[0][1]).toMatchObject({
+      dryRun: true,
+    });
+    expect(shown(ctx)).toContain('Would push:');
+  });
+
+  it('should explain how to set it up', async () => {
+    expect(await dataSyncCommand.action!(context(), '')).toMatchObject({
+      messageType: 'error',
+      content: expect.stringContaining('Add a dataSync setting'),
+    });
+  });
+
+  it('should resolve a conflict', async () => {
+    expect(
+      await subCommand('resolve').action!(context(), 'RESEARCH.md remote'),
+    ).toMatchObject({ content: "Took the other machine's RESEARCH.md." });
+    expect(resolveConflict).toHaveBeenCalledWith('RESEARCH.md', 'remote');
+    expect(
+      await subCommand('resolve').action!(context(), 'RESEARCH.md theirs'),
+    ).toMatchObject({
+      content: 'Usage: /datasync resolve <file> local|remote',
+    });
+  });
+});
@@ -0,0 +1,110 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import {
+  CONFLICT_SUFFIX,
+  createSyncBackend,
+  DataSyncResult,
+  getErrorMessage,
+  listConflicts,
+  resolveConflict,
+  syncData,
+} from '@iechor/research-cli-core';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import { MessageType } from '../types.js';
+
+const RESOLVE_USAGE = 'Usage: /datasync resolve <file> local|remote';
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+function formatResult(result: DataSyncResult, dryRun: boolean): string {
+  const section = (title: string, files: string[]) =>
+    files.length > 0 ? [title, ...files.map((f) => `  ${f}`)] : [];
+  const lines = [
+    ...section(dryRun ? 'Would push:' : 'Pushed:', result.pushed),
+    ...section(dryRun ? 'Would pull:' : 'Pulled:', result.pulled),
+    ...section(
+      'Changed on both machines, the newer copy won:',
+      result.overwritten,
+    ),
+    ...section(
+      `Changed on both machines; the other copy is next to yours with the ${CONFLICT_SUFFIX} suffix. Merge it or pick one with /datasync resolve:`,
+      result.conflicts,
+    ),
+  ];
+  return lines.length > 0 ? lines.join('\n') : 'Everything is in sync.';
+}
+
+async function runSync(
+  context: CommandContext,
+  dryRun: boolean,
+): Promise<void | SlashCommandActionReturn> {
+  const settings = context.services.settings.merged.dataSync;
+  if (!settings) {
+    return error(
+      'Data sync is not set up. Add a dataSync setting with a git remote or an S3 bucket.',
+    );
+  }
+  try {
+    const result = await syncData(createSyncBackend(settings), {
+      strategy: settings.conflicts,
+      dryRun,
+    });
+    context.ui.addItem(
+      { type: MessageType.INFO, text: formatResult(result, dryRun) },
+      Date.now(),
+    );
+  } catch (e) {
+    return error(`Could not sync: ${getErrorMessage(e)}`);
+  }
+}
+
+const statusCommand: SlashCommand = {
+  name: 'status',
+  description:
+    'Show what a sync would push and pull, without changing anything.',
+  action: (context) => runSync(context, true),
+};
+
+const resolveCommand: SlashCommand = {
+  name: 'resolve',
+  description: `Settle a file changed on two machines by keeping this machine's copy or the other one. ${RESOLVE_USAGE}`,
+  action: async (_context, args) => {
+    const [file, keep, ...rest] = args.trim().split(/\s+/).filter(Boolean);
+    if (!file || rest.length > 0 || (keep !== 'local' && keep !== 'remote')) {
+      return error(RESOLVE_USAGE);
+    }
+    try {
+      await resolveConflict(file, keep);
+    } catch (e) {
+      return error(getErrorMessage(e));
+    }
+    return info(
+      keep === 'local'
+        ? `Kept this machine's ${file}; the next sync pushes it.`
+        : `Took the other machine's ${file}.`,
+    );
+  },
+  completion: async () => listConflicts(),
+};
+
+export const dataSyncCommand: SlashCommand = {
+  name: 'datasync',
+  description:
+    'Sync saved chats, memories, deadlines, reviews and rebuttals with other machines through a git remote or an S3 bucket.',
+  action: (context) => runSync(context, false),
+  subCommands: [statusCommand, resolveCommand],
+};
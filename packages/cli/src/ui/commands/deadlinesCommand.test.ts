@@ -0,0 +1,92 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { Config, loadDeadlines } from '@iechor/research-cli-core';
+import { deadlinesCommand } from './deadlinesCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+import { MessageType } from '../types.js';
+
+describe('deadlinesCommand', () => {
+  let tempDir: string;
+
+  const subCommand = (name: string) =>
+    deadlinesCommand.subCommands!.find((c) => c.name === name)!;
+
+  const context = () =>
+    createMockCommandContext({
+      services: {
+        config: { getTargetDir: () => tempDir } as unknown as Config,
+      },
+    });
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'deadlines-command-'));
+    // Deadlines are stored under the home directory
+    vi.stubEnv('HOME', tempDir);
+    vi.useFakeTimers({ now: new Date(2025, 2, 1, 12, 0), toFake: ['Date'] });
+  });
+
+  afterEach(() => {
+    vi.useRealTimers();
+    vi.unstubAllEnvs();
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should add deadlines and list the upcoming ones with countdowns', async () => {
+    const added = await subCommand('add').action!(
+      context(),
+      '2025-03-04 18:30 ICML full paper --conference --url https://icml.cc',
+    );
+    expect(added).toMatchObject({
+      messageType: 'info',
+      content: expect.stringContaining('Added "ICML full paper"'),
+    });
+    await subCommand('add').action!(context(), '2025-02-01 Old grant --grant');
+
+    const listContext = context();
+    await deadlinesCommand.action!(listContext, '');
+
+    const [item] = vi.mocked(listContext.ui.addItem).mock.calls[0];
+    expect(item.type).toBe(MessageType.INFO);
+    const { text } = item as { text: string };
+    expect(text).toContain('Deadlines (1)');
+    expect(text).toMatch(
+      /⚠ \w{8} .* 3d 6h\s+conference\s+ICML full paper {2}https:\/\/icml\.cc/,
+    );
+    expect(text).toContain('1 passed deadlines hidden');
+
+    const removed = await subCommand('remove').action!(context(), 'past');
+    expect(removed).toMatchObject({ content: 'Removed 1 deadline.' });
+    expect((await loadDeadlines()).map((d) => d.title)).toEqual([
+      'ICML full paper',
+    ]);
+  });
+
+  it('should import a JSON feed relative to the project', async () => {
+    fs.writeFileSync(
+      path.join(tempDir, 'feed.json'),
+      JSON.stringify([{ title: 'ERC grant', deadline: '2025-10-14' }]),
+    );
+
+    const result = await subCommand('import').action!(context(), 'feed.json');
+
+    expect(result).toMatchObject({
+      content: 'Imported 1 deadlines from feed.json (1 new).',
+    });
+    expect(await loadDeadlines()).toMatchObject([
+      { title: 'ERC grant', kind: 'grant' },
+    ]);
+  });
+
+  it('should reject an add without a date', async () => {
+    const result = await subCommand('add').action!(context(), 'ICML');
+    expect(result).toMatchObject({ messageType: 'error' });
+  });
+});
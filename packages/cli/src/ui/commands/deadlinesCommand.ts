@@ -0,0 +1,250 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import path from 'node:path';
+import {
+  Deadline,
+  DeadlineKind,
+  createDeadlineId,
+  formatCountdown,
+  getErrorMessage,
+  loadDeadlines,
+  mergeDeadlines,
+  parseDeadlineDate,
+  readDeadlineFeed,
+  saveDeadlines,
+  sortDeadlines,
+} from '@iechor/research-cli-core';
+import stringWidth from 'string-width';
+import { padToWidth } from '../utils/textUtils.js';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import { MessageType } from '../types.js';
+import { DEFAULT_DEADLINE_WARNING_DAYS } from '../hooks/useDeadlineWarning.js';
+
+const DAY_MS = 24 * 60 * 60 * 1000;
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+function formatDue(due: string): string {
+  return new Date(due).toLocaleString(undefined, {
+    weekday: 'short',
+    day: 'numeric',
+    month: 'short',
+    year: 'numeric',
+    hour: '2-digit',
+    minute: '2-digit',
+  });
+}
+
+/**
+ * Renders deadlines as aligned columns, marking the ones inside the
+ * warning window.
+ */
+function renderDeadlines(
+  deadlines: Deadline[],
+  warningDays: number,
+  now: Date,
+): string {
+  const rows = deadlines.map((deadline) => {
+    const left = Date.parse(deadline.due) - now.getTime();
+    const marker = left >= 0 && left <= warningDays * DAY_MS ? '⚠' : ' ';
+    return [
+      `${marker} ${deadline.id}`,
+      formatDue(deadline.due),
+      formatCountdown(deadline.due, now),
+      deadline.kind,
+      deadline.title + (deadline.url ? `  ${deadline.url}` : ''),
+    ];
+  });
+  const header = ['  id', 'due', 'left', 'kind', 'title'];
+  const widths = header.map((_, column) =>
+    Math.max(...[header, ...rows].map((row) => stringWidth(row[column]))),
+  );
+  return [header, ...rows]
+    .map((row) =>
+      row
+        .map((cell, column) =>
+          column === row.length - 1 ? cell : padToWidth(cell, widths[column]),
+        )
+        .join('  '),
+    )
+    .join('\n');
+}
+
+export const deadlinesCommand: SlashCommand = {
+  name: 'deadlines',
+  description:
+    'Show conference and grant deadlines with countdowns. Usage: /deadlines [--all]',
+  action: async (context: CommandContext, args: string) => {
+    const showAll = args.trim() === '--all';
+    let deadlines: Deadline[];
+    try {
+      deadlines = sortDeadlines(await loadDeadlines());
+    } catch (e) {
+      return error(`Could not read deadlines: ${getErrorMessage(e)}`);
+    }
+    const now = new Date();
+    const shown = showAll
+      ? deadlines
+      : deadlines.filter((d) => Date.parse(d.due) >= now.getTime());
+    if (shown.length === 0) {
+      return info(
+        deadlines.length === 0
+          ? 'No deadlines yet. Add one with /deadlines add <YYYY-MM-DD> [HH:MM] <title>, or import a feed with /deadlines import <url|file>.'
+          : 'All deadlines have passed. Use /deadlines --all to see them.',
+      );
+    }
+
+    const warningDays =
+      context.services.settings.merged.deadlineWarningDays ??
+      DEFAULT_DEADLINE_WARNING_DAYS;
+    const hidden = deadlines.length - shown.length;
+    context.ui.addItem(
+      {
+        type: MessageType.INFO,
+        text: [
+          `Deadlines (${shown.length})`,
+          '',
+          renderDeadlines(shown, warningDays, now),
+          ...(hidden > 0
+            ? [
+                '',
+                `${hidden} passed deadlines hidden; /deadlines --all shows them.`,
+              ]
+            : []),
+        ].join('\n'),
+      },
+      Date.now(),
+    );
+  },
+  subCommands: [
+    {
+      name: 'add',
+      description:
+        'Add a deadline. Usage: /deadlines add <YYYY-MM-DD> [HH:MM] <title> [--grant|--conference] [--url <url>]',
+      action: async (_context, args) => {
+        const tokens = args.trim().split(/\s+/).filter(Boolean);
+        let kind: DeadlineKind = 'other';
+        let url: string | undefined;
+        const words: string[] = [];
+        for (let i = 0; i < tokens.length; i++) {
+          if (tokens[i] === '--grant') {
+            kind = 'grant';
+          } else if (tokens[i] === '--conference') {
+            kind = 'conference';
+          } else if (tokens[i] === '--url') {
+            url = tokens[++i];
+          } else {
+            words.push(tokens[i]);
+          }
+        }
+        const [date, maybeTime, ...rest] = words;
+        const hasTime = /^\d{1,2}:\d{2}$/.test(maybeTime ?? '');
+        const title = (hasTime ? rest : [maybeTime, ...rest])
+          .filter(Boolean)
+          .join(' ');
+        const due =
+          date &&
+          parseDeadlineDate(
+            hasTime ? `${date}T${maybeTime.padStart(5, '0')}` : date,
+          );
+        if (!due || !/^\d{4}-\d{2}-\d{2}$/.test(date) || !title) {
+          return error(
+            'Usage: /deadlines add <YYYY-MM-DD> [HH:MM] <title> [--grant|--conference] [--url <url>]',
+          );
+        }
+
+        try {
+          const { deadlines, added } = mergeDeadlines(await loadDeadlines(), [
+            {
+              id: createDeadlineId(title, due.toISOString()),
+              title,
+              due: due.toISOString(),
+              kind,
+              url,
+            },
+          ]);
+          await saveDeadlines(deadlines);
+          return info(
+            `${added ? 'Added' : 'Updated'} "${title}", due ${formatDue(due.toISOString())} (${formatCountdown(due.toISOString())}).`,
+          );
+        } catch (e) {
+          return error(`Could not save the deadline: ${getErrorMessage(e)}`);
+        }
+      },
+    },
+    {
+      name: 'remove',
+      description:
+        'Remove a deadline by id, or every deadline that has passed. Usage: /deadlines remove <id...>|past',
+      action: async (_context, args) => {
+        const ids = args.trim().split(/\s+/).filter(Boolean);
+        if (ids.length === 0) {
+          return error('Usage: /deadlines remove <id...>|past');
+        }
+        try {
+          const deadlines = await loadDeadlines();
+          const now = Date.now();
+          const keep = deadlines.filter((d) =>
+            ids.includes('past')
+              ? Date.parse(d.due) >= now
+              : !ids.includes(d.id),
+          );
+          const removed = deadlines.length - keep.length;
+          if (removed === 0) {
+            return error(`No deadline matches ${ids.join(', ')}.`);
+          }
+          await saveDeadlines(keep);
+          return info(
+            `Removed ${removed} deadline${removed === 1 ? '' : 's'}.`,
+          );
+        } catch (e) {
+          return error(`Could not update deadlines: ${getErrorMessage(e)}`);
+        }
+      },
+    },
+    {
+      name: 'import',
+      description:
+        'Import deadlines from an iCal (.ics) or JSON feed. Usage: /deadlines import <url|file>',
+      action: async (context, args) => {
+        const source = args.trim();
+        if (!source) {
+          return error('Usage: /deadlines import <url|file>');
+        }
+        const location = /^https?:\/\//.test(source)
+          ? source
+          : path.resolve(
+              context.services.config?.getTargetDir() ?? process.cwd(),
+              source,
+            );
+        try {
+          const imported = await readDeadlineFeed(location);
+          const { deadlines, added } = mergeDeadlines(
+            await loadDeadlines(),
+            imported,
+          );
+          await saveDeadlines(deadlines);
+          return info(
+            `Imported ${imported.length} deadlines from ${source} (${added} new).`,
+          );
+        } catch (e) {
+          return error(`Could not import ${source}: ${getErrorMessage(e)}`);
+        }
+      },
+    },
+  ],
+};
@@ -0,0 +1,107 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, vi, beforeEach, afterEach } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { Content } from '@google/genai';
+import { Config, Logger } from '@iechor/research-cli-core';
+import { diffSessionCommand } from './diffSessionCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+import { CommandContext } from './types.js';
+
+const conversation = (answer: string): Content[] => [
+  { role: 'user', parts: [{ text: 'Summarize the paper.' }] },
+  { role: 'model', parts: [{ text: answer }] },
+];
+
+describe('diffSessionCommand', () => {
+  let tempDir: string;
+  let context: CommandContext;
+  let checkpoints: Record<string, Content[]>;
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'diff-session-'));
+    checkpoints = { gpt: conversation('It is about graphs.') };
+    context = createMockCommandContext({
+      services: {
+        config: {
+          getTargetDir: () => tempDir,
+          getProjectTempDir: () => tempDir,
+          getResearchClient: () => ({
+            getChat: async () => ({
+              getHistory: () => conversation('It is about trees.'),
+            }),
+          }),
+        } as unknown as Config,
+        logger: {
+          initialize: vi.fn(),
+          loadCheckpoint: vi.fn(async (tag: string) => checkpoints[tag] ?? []),
+        } as unknown as Logger,
+      },
+    });
+  });
+
+  afterEach(() => {
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should show the usage without two sessions', async () => {
+    expect(await diffSessionCommand.action!(context, 'gpt')).toMatchObject({
+      messageType: 'error',
+      content: expect.stringContaining('Usage: /diff-session'),
+    });
+  });
+
+  it('should diff a saved tag against the current conversation', async () => {
+    await diffSessionCommand.action!(context, 'gpt current');
+
+    expect(context.ui.addItem).toHaveBeenCalledWith(
+      {
+        type: 'info',
+        text: expect.stringContaining(
+          '-It is about graphs.\n+It is about trees.',
+        ),
+      },
+      expect.any(Number),
+    );
+  });
+
+  it('should read saved .json files', async () => {
+    fs.writeFileSync(
+      path.join(tempDir, 'run.json'),
+      JSON.stringify(conversation('It is about graphs.')),
+    );
+
+    await diffSessionCommand.action!(context, 'gpt run.json --side-by-side');
+
+    expect(context.ui.addItem).toHaveBeenCalledWith(
+      {
+        type: 'info',
+        text: 'The answers in gpt and run.json are the same (1 turn).',
+      },
+      expect.any(Number),
+    );
+  });
+
+  it('should report a tag that was not saved', async () => {
+    expect(
+      await diffSessionCommand.action!(context, 'gpt llama'),
+    ).toMatchObject({
+      messageType: 'info',
+      content: expect.stringContaining('No saved conversation found for llama'),
+    });
+  });
+
+  it('should complete saved tags', async () => {
+    fs.writeFileSync(path.join(tempDir, 'checkpoint-gpt-4o.json'), '[]');
+
+    expect(await diffSessionCommand.completion!(context, 'g')).toEqual([
+      'gpt-4o',
+    ]);
+  });
+});
@@ -0,0 +1,124 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import { Content } from '@google/genai';
+import { getErrorMessage } from '@iechor/research-cli-core';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import { MessageType } from '../types.js';
+import { SessionDiffStyle, renderSessionDiff } from '../utils/sessionDiff.js';
+
+const USAGE =
+  'Usage: /diff-session <a> <b> [--side-by-side]. Each of <a> and <b> is a tag saved with /chat save, a saved .json file, or "current".';
+const CURRENT = 'current';
+const DEFAULT_COLUMNS = 100;
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+/** The tags of the conversations saved with /chat save. */
+async function savedTags(context: CommandContext): Promise<string[]> {
+  const dir = context.services.config?.getProjectTempDir();
+  if (!dir) {
+    return [];
+  }
+  try {
+    return (await fs.promises.readdir(dir))
+      .filter((file) => /^checkpoint-.+\.json$/.test(file))
+      .map((file) => file.slice('checkpoint-'.length, -'.json'.length));
+  } catch {
+    return [];
+  }
+}
+
+/**
+ * Loads the conversation a `/diff-session` argument names: the current
+ * one, a JSON file of the form /chat save writes, or a saved tag.
+ */
+async function loadSession(
+  context: CommandContext,
+  name: string,
+): Promise<Content[]> {
+  const config = context.services.config;
+  if (name === CURRENT) {
+    const chat = await config?.getResearchClient()?.getChat();
+    return chat?.getHistory() ?? [];
+  }
+  if (name.endsWith('.json')) {
+    const filePath = path.resolve(
+      config?.getTargetDir() ?? process.cwd(),
+      name,
+    );
+    const parsed = JSON.parse(await fs.promises.readFile(filePath, 'utf8'));
+    if (!Array.isArray(parsed)) {
+      throw new Error(`${name} is not a saved conversation.`);
+    }
+    return parsed as Content[];
+  }
+  await context.services.logger.initialize();
+  return context.services.logger.loadCheckpoint(name);
+}
+
+export const diffSessionCommand: SlashCommand = {
+  name: 'diff-session',
+  description:
+    'Compare the answers of two saved conversations prompt by prompt, e.g. the same prompts run with two models, as a unified or side-by-side diff. ' +
+    USAGE,
+  completion: async (context, partialArg) =>
+    [CURRENT, ...(await savedTags(context)), '--side-by-side'].filter(
+      (option) => option.startsWith(partialArg),
+    ),
+  action: async (context, args) => {
+    const tokens = args.trim().split(/\s+/).filter(Boolean);
+    const style: SessionDiffStyle = tokens.includes('--side-by-side')
+      ? 'side-by-side'
+      : 'unified';
+    const names = tokens.filter((token) => !token.startsWith('--'));
+    if (names.length !== 2) {
+      return error(USAGE);
+    }
+
+    const sessions: Content[][] = [];
+    for (const name of names) {
+      let history: Content[];
+      try {
+        history = await loadSession(context, name);
+      } catch (e) {
+        return error(`Could not read ${name}: ${getErrorMessage(e)}`);
+      }
+      if (history.length === 0) {
+        return info(
+          name === CURRENT
+            ? 'The current conversation is empty.'
+            : `No saved conversation found for ${name}. /chat list shows the saved tags.`,
+        );
+      }
+      sessions.push(history);
+    }
+
+    context.ui.addItem(
+      {
+        type: MessageType.INFO,
+        text: renderSessionDiff(
+          { label: names[0], history: sessions[0] },
+          { label: names[1], history: sessions[1] },
+          { style, width: process.stdout.columns || DEFAULT_COLUMNS },
+        ),
+      },
+      Date.now(),
+    );
+  },
+};
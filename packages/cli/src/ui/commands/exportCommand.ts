@@ -0,0 +1,237 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import { Content } from '@google/genai';
+import {
+  Config,
+  getErrorMessage,
+  getRedactor,
+  isSetupTurn,
+  renderConversationHtml,
+  renderConversationTranscript,
+} from '@iechor/research-cli-core';
+import {
+  convertSvgToPng,
+  renderScreenshotSvg,
+} from '../utils/screenshot.js';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+
+const USAGE =
+  'Usage: /export [file.html|file.txt|file.svg|file.png] [--title <text>] [--last <n>]';
+const DEFAULT_SCREENSHOT_COLUMNS = 100;
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+function parseArgs(args: string): {
+  file?: string;
+  title?: string;
+  last?: number;
+} {
+  let rest = args;
+  let last: number | undefined;
+  const lastMatch = rest.match(/(?:^|\s)--last\s+(\S+)/);
+  if (lastMatch) {
+    last = Number(lastMatch[1]);
+    rest = rest.replace(lastMatch[0], '');
+  }
+  const match = rest.match(/^(.*?)(?:\s*--title\s+(.+))?$/s);
+  return {
+    file: match?.[1]?.trim() || undefined,
+    title: match?.[2]?.trim() || undefined,
+    last,
+  };
+}
+
+export function defaultExportFileName(date: Date): string {
+  return `conversation-${date.toISOString().slice(0, 19).replace(/[:T]/g, '-')}.html`;
+}
+
+/**
+ * The conversation as it is exported. Exports are meant to be shared, so
+ * they get the same redaction as requests to remote providers, and leave
+ * out the environment context that opens the chat.
+ */
+async function exportableHistory(
+  config: Config,
+): Promise<{ history: Content[]; redactedCount: number }> {
+  const chat = await config.getResearchClient()?.getChat();
+  const history = (chat?.getHistory() ?? []).filter(
+    (_, i, all) => !isSetupTurn(all, i),
+  );
+  const { value, matches } = getRedactor().redactContents(history);
+  return { history: value, redactedCount: matches.length };
+}
+
+/**
+ * Renders the conversation as HTML, redacted and without the environment
+ * context. Returns undefined when there is no conversation yet.
+ */
+export async function renderConversationExport(
+  config: Config,
+  options: { title?: string; exportedAt?: Date } = {},
+): Promise<
+  { html: string; messageCount: number; redactedCount: number } | undefined
+> {
+  const { history, redactedCount } = await exportableHistory(config);
+  if (history.length === 0) {
+    return undefined;
+  }
+  return {
+    html: renderConversationHtml(history, {
+      ...options,
+      model: config.getModel(),
+    }),
+    messageCount: history.length,
+    redactedCount,
+  };
+}
+
+/** Writes the conversation to `filePath` as HTML. */
+export async function writeConversationExport(
+  config: Config,
+  filePath: string,
+  options: { title?: string; exportedAt?: Date } = {},
+): Promise<{ messageCount: number; redactedCount: number } | undefined> {
+  const rendered = await renderConversationExport(config, options);
+  if (!rendered) {
+    return undefined;
+  }
+  await fs.promises.mkdir(path.dirname(filePath), { recursive: true });
+  await fs.promises.writeFile(filePath, rendered.html, 'utf8');
+  return {
+    messageCount: rendered.messageCount,
+    redactedCount: rendered.redactedCount,
+  };
+}
+
+/**
+ * Writes the conversation as plain prose to read aloud, e.g. with a
+ * text-to-speech app: speakers are named and markdown, code and tool
+ * calls are left out. It is redacted like the HTML export.
+ */
+async function exportProse(
+  config: Config,
+  filePath: string,
+): Promise<SlashCommandActionReturn> {
+  const { history, redactedCount } = await exportableHistory(config);
+  if (history.length === 0) {
+    return info('The conversation is empty.');
+  }
+  try {
+    await fs.promises.mkdir(path.dirname(filePath), { recursive: true });
+    await fs.promises.writeFile(
+      filePath,
+      renderConversationTranscript(history, 'prose'),
+      'utf8',
+    );
+  } catch (e) {
+    return error(`Could not write ${filePath}: ${getErrorMessage(e)}`);
+  }
+  return info(
+    `Exported the conversation as prose to ${filePath}.` +
+      (redactedCount > 0 ? ` ${redactedCount} value(s) were redacted.` : ''),
+  );
+}
+
+/**
+ * Writes the history items shown in the terminal as an SVG or PNG image,
+ * rendered as they are on screen. Unlike the HTML export this is a picture
+ * of what is displayed, so it is not redacted.
+ */
+async function exportScreenshot(
+  context: CommandContext,
+  filePath: string,
+  options: { title?: string; last?: number },
+): Promise<SlashCommandActionReturn> {
+  if (
+    options.last !== undefined &&
+    (!Number.isInteger(options.last) || options.last < 1)
+  ) {
+    return error(`--last takes a number of items. ${USAGE}`);
+  }
+  const all = context.ui.history;
+  const items =
+    options.last !== undefined ? all.slice(-options.last) : [...all];
+  if (items.length === 0) {
+    return info('There is nothing on screen to export.');
+  }
+  try {
+    const svg = await renderScreenshotSvg(items, {
+      columns: process.stdout.columns || DEFAULT_SCREENSHOT_COLUMNS,
+      title: options.title,
+      config: context.services.config ?? undefined,
+    });
+    await fs.promises.mkdir(path.dirname(filePath), { recursive: true });
+    if (path.extname(filePath).toLowerCase() === '.png') {
+      await convertSvgToPng(svg, filePath);
+    } else {
+      await fs.promises.writeFile(filePath, svg, 'utf8');
+    }
+  } catch (e) {
+    return error(`Could not write ${filePath}: ${getErrorMessage(e)}`);
+  }
+  return info(
+    `Exported ${items.length} item${items.length === 1 ? '' : 's'} to ${filePath}.`,
+  );
+}
+
+export const exportCommand: SlashCommand = {
+  name: 'export',
+  description:
+    'Export the conversation as a self-contained HTML file, with each tool call and its output in a collapsible section, as plain prose to read aloud (.txt), or as an SVG or PNG screenshot of the terminal. ' +
+    USAGE,
+  action: async (context: CommandContext, args: string) => {
+    const config = context.services.config;
+    if (!config) {
+      return error('No conversation to export.');
+    }
+    const { file, title, last } = parseArgs(args);
+    const exportedAt = new Date();
+    const filePath = path.resolve(
+      config.getTargetDir(),
+      file ?? defaultExportFileName(exportedAt),
+    );
+    if (['.svg', '.png'].includes(path.extname(filePath).toLowerCase())) {
+      return exportScreenshot(context, filePath, { title, last });
+    }
+    if (last !== undefined) {
+      return error('--last only applies to .svg and .png screenshots.');
+    }
+    if (path.extname(filePath).toLowerCase() === '.txt') {
+      return exportProse(config, filePath);
+    }
+    let written: Awaited<ReturnType<typeof writeConversationExport>>;
+    try {
+      written = await writeConversationExport(config, filePath, {
+        title,
+        exportedAt,
+      });
+    } catch (e) {
+      return error(`Could not write ${filePath}: ${getErrorMessage(e)}`);
+    }
+    if (!written) {
+      return info('The conversation is empty.');
+    }
+    return info(
+      `Exported ${written.messageCount} message${written.messageCount === 1 ? '' : 's'} to ${filePath}.` +
+        (written.redactedCount > 0
+          ? ` ${written.redactedCount} value(s) were redacted.`
+          : ''),
+    );
+  },
+};
@@ -0,0 +1,123 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { Content } from '@google/genai';
+import {
+  Config,
+  getFeedbackPath,
+  loadFeedback,
+} from '@iechor/research-cli-core';
+import { feedbackCommand } from './feedbackCommand.js';
+import { rateCommand } from './rateCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+
+describe('feedbackCommand and rateCommand', () => {
+  let tempDir: string;
+  let history: Content[];
+
+  const context = () =>
+    createMockCommandContext({
+      services: {
+        config: {
+          getTargetDir: () => tempDir,
+          getSessionId: () => 'session-1',
+          getModel: () => 'test-model',
+          getResearchClient: () => ({
+            getChat: async () => ({ getHistory: () => history }),
+          }),
+        } as unknown as Config,
+      },
+    });
+
+  const exportCommand = feedbackCommand.subCommands!.find(
+    (c) => c.name === 'export',
+  )!;
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'feedback-command-'));
+    // Feedback is stored under the home directory
+    vi.stubEnv('HOME', tempDir);
+    history = [
+      { role: 'user', parts: [{ text: 'How accurate is run 12?' }] },
+      { role: 'model', parts: [{ text: 'Accuracy is 91.2%.' }] },
+    ];
+  });
+
+  afterEach(() => {
+    vi.unstubAllEnvs();
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should rate the last answer and add a note to it', async () => {
+    expect(await rateCommand.action!(context(), 'down')).toEqual({
+      type: 'message',
+      messageType: 'info',
+      content:
+        'Saved feedback on the answer to "How accurate is run 12?": 👎.',
+    });
+    expect(
+      await feedbackCommand.action!(context(), 'It used the train split'),
+    ).toMatchObject({
+      content: expect.stringContaining('👎, note "It used the train split"'),
+    });
+
+    const entries = await loadFeedback(getFeedbackPath(tempDir));
+    expect(entries).toEqual([
+      expect.objectContaining({
+        sessionId: 'session-1',
+        turn: 0,
+        model: 'test-model',
+        prompt: 'How accurate is run 12?',
+        response: 'Accuracy is 91.2%.',
+        rating: 'down',
+        note: 'It used the train split',
+      }),
+    ]);
+  });
+
+  it('should export rated pairs for fine-tuning', async () => {
+    await rateCommand.action!(context(), '5');
+    history = [
+      ...history,
+      { role: 'user', parts: [{ text: 'And run 13?' }] },
+      { role: 'model', parts: [{ text: 'Run 13 diverged.' }] },
+    ];
+    await rateCommand.action!(context(), '2');
+
+    const result = await exportCommand.action!(
+      context(),
+      'pairs.jsonl --format chat',
+    );
+
+    expect(result).toMatchObject({
+      content: 'Wrote 1 rated pairs to pairs.jsonl.',
+    });
+    expect(
+      JSON.parse(fs.readFileSync(path.join(tempDir, 'pairs.jsonl'), 'utf8')),
+    ).toEqual({
+      messages: [
+        { role: 'user', content: 'How accurate is run 12?' },
+        { role: 'assistant', content: 'Accuracy is 91.2%.' },
+      ],
+    });
+  });
+
+  it('should reject unknown ratings and missing answers', async () => {
+    expect(await rateCommand.action!(context(), 'great')).toMatchObject({
+      messageType: 'error',
+      content: 'Usage: /rate up|down|+|-|1-5 [note]',
+    });
+    history = [];
+    expect(await rateCommand.action!(context(), 'up')).toMatchObject({
+      messageType: 'error',
+      content: 'There is no answer to give feedback on yet.',
+    });
+  });
+});
@@ -0,0 +1,174 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import {
+  FeedbackEntry,
+  FeedbackExportFormat,
+  MessageRating,
+  exportRatedPairs,
+  formatRating,
+  getErrorMessage,
+  getFeedbackPath,
+  getLastExchange,
+  isIncognitoMode,
+  isPositiveRating,
+  loadFeedback,
+  recordFeedback,
+} from '@iechor/research-cli-core';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import { MessageType } from '../types.js';
+
+const EXPORT_FORMATS: FeedbackExportFormat[] = ['jsonl', 'chat'];
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+function getRoot(context: CommandContext): string {
+  return context.services.config?.getTargetDir() ?? process.cwd();
+}
+
+const truncate = (text: string, length: number) => {
+  const line = text.replace(/\s+/g, ' ');
+  return line.length > length ? `${line.slice(0, length - 1)}…` : line;
+};
+
+/**
+ * Saves a rating or note for the last answer of the session, merged with
+ * feedback already given on it.
+ */
+export async function recordLastAnswerFeedback(
+  context: CommandContext,
+  feedback: { rating?: MessageRating; note?: string },
+): Promise<SlashCommandActionReturn> {
+  if (isIncognitoMode()) {
+    return error('Feedback is not saved in incognito mode.');
+  }
+  const config = context.services.config;
+  const chat = await config?.getResearchClient()?.getChat();
+  const exchange = getLastExchange(chat?.getHistory(true) ?? []);
+  if (!config || !exchange) {
+    return error('There is no answer to give feedback on yet.');
+  }
+  let entry: FeedbackEntry;
+  try {
+    entry = await recordFeedback(getFeedbackPath(getRoot(context)), {
+      sessionId: config.getSessionId(),
+      turn: exchange.turn,
+      timestamp: new Date().toISOString(),
+      model: config.getModel(),
+      prompt: exchange.prompt,
+      response: exchange.response,
+      ...feedback,
+    });
+  } catch (e) {
+    return error(`Could not save the feedback: ${getErrorMessage(e)}`);
+  }
+  return info(
+    `Saved feedback on the answer to "${truncate(exchange.prompt, 50)}": ` +
+      [
+        entry.rating !== undefined ? formatRating(entry.rating) : 'not rated',
+        ...(entry.note ? [`note "${truncate(entry.note, 60)}"`] : []),
+      ].join(', ') +
+      '.',
+  );
+}
+
+export const feedbackCommand: SlashCommand = {
+  name: 'feedback',
+  description:
+    'Add a note to your feedback on the last answer, e.g. what was wrong with it. Rate answers with /rate. Usage: /feedback <note>',
+  action: async (context: CommandContext, args: string) => {
+    const note = args.trim();
+    if (!note) {
+      return error(
+        'Usage: /feedback <note>. See /feedback list for the feedback given so far.',
+      );
+    }
+    return recordLastAnswerFeedback(context, { note });
+  },
+  subCommands: [
+    {
+      name: 'list',
+      description: 'List the feedback given in this project.',
+      action: async (context) => {
+        let entries: FeedbackEntry[];
+        try {
+          entries = await loadFeedback(getFeedbackPath(getRoot(context)));
+        } catch (e) {
+          return error(`Could not read the feedback: ${getErrorMessage(e)}`);
+        }
+        if (entries.length === 0) {
+          return info(
+            'No feedback yet. Rate the last answer with /rate up|down|1-5.',
+          );
+        }
+        const rated = entries.filter((e) => e.rating !== undefined);
+        const positive = rated.filter((e) => isPositiveRating(e.rating!));
+        context.ui.addItem(
+          {
+            type: MessageType.INFO,
+            text: [
+              `Feedback (${entries.length} answers, ${positive.length} of ${rated.length} rated positively):`,
+              ...entries.map(
+                (e) =>
+                  `  ${e.timestamp.slice(0, 10)}  ${(e.rating !== undefined ? formatRating(e.rating) : '–').padEnd(3)}  ${truncate(e.prompt, 60)}${e.note ? `\n        note: ${e.note}` : ''}`,
+              ),
+            ].join('\n'),
+          },
+          Date.now(),
+        );
+      },
+    },
+    {
+      name: 'export',
+      description:
+        'Export the rated prompt and answer pairs as JSON Lines: with ratings and notes (jsonl), or only the positive ones in the chat messages format used for fine-tuning (chat). Usage: /feedback export [file] [--format jsonl|chat]',
+      action: async (context, args) => {
+        const formatMatch = args.match(/--format\s+(\S+)/);
+        const format = (formatMatch?.[1] ?? 'jsonl') as FeedbackExportFormat;
+        if (!EXPORT_FORMATS.includes(format)) {
+          return error(
+            `Unknown format "${format}". Use ${EXPORT_FORMATS.join(' or ')}.`,
+          );
+        }
+        const file =
+          args.replace(/--format\s+\S+/, '').trim() ||
+          `feedback-${format}.jsonl`;
+        const root = getRoot(context);
+        const filePath = path.resolve(root, file);
+        try {
+          const output = exportRatedPairs(
+            await loadFeedback(getFeedbackPath(root)),
+            format,
+          );
+          if (!output) {
+            return info(
+              format === 'chat'
+                ? 'No positively rated answers to export yet.'
+                : 'No rated answers to export yet.',
+            );
+          }
+          await fs.promises.writeFile(filePath, output, 'utf8');
+          const count = output.trimEnd().split('\n').length;
+          return info(`Wrote ${count} rated pairs to ${file}.`);
+        } catch (e) {
+          return error(`Could not export to ${file}: ${getErrorMessage(e)}`);
+        }
+      },
+    },
+  ],
+};
@@ -0,0 +1,89 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { fetchReadablePage, getErrorMessage } from '@iechor/research-cli-core';
+import { SlashCommand, SlashCommandActionReturn } from './types.js';
+import { MessageType } from '../types.js';
+
+const USAGE = 'Usage: /fetch [--refresh] <url>';
+const MAX_CONTEXT_LENGTH = 100000;
+const PREVIEW_LENGTH = 600;
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+export const fetchCommand: SlashCommand = {
+  name: 'fetch',
+  description: `Fetch a web page as readable text and add it to the conversation. ${USAGE}`,
+  action: async (context, args) => {
+    const parts = args.trim().split(/\s+/).filter(Boolean);
+    const refresh = parts.includes('--refresh');
+    const url = parts.find((part) => part !== '--refresh');
+    if (!url) {
+      return error(USAGE);
+    }
+    try {
+      const protocol = new URL(url).protocol;
+      if (protocol !== 'http:' && protocol !== 'https:') {
+        return error(`Only http and https URLs can be fetched: ${url}`);
+      }
+    } catch {
+      return error(`Invalid URL: ${url}`);
+    }
+
+    let result: Awaited<ReturnType<typeof fetchReadablePage>>;
+    try {
+      result = await fetchReadablePage(url, {
+        refresh,
+        // Incognito sessions leave no copy of the page on disk
+        noCache: context.services.config?.isIncognito(),
+      });
+    } catch (e) {
+      return error(`Could not fetch ${url}: ${getErrorMessage(e)}`);
+    }
+    const { page, cached } = result;
+
+    const wordCount = page.text.split(/\s+/).length;
+    const source = [page.siteName, page.byline].filter(Boolean).join(' · ');
+    const preview =
+      page.text.length > PREVIEW_LENGTH
+        ? `${page.text.slice(0, PREVIEW_LENGTH).trimEnd()}…`
+        : page.text;
+    context.ui.addItem(
+      {
+        type: MessageType.INFO,
+        text: [
+          page.title,
+          page.url,
+          ...(source ? [source] : []),
+          `${wordCount} words${cached ? `, cached ${new Date(page.fetchedAt).toLocaleString()}` : ''}`,
+          '',
+          preview,
+        ].join('\n'),
+      },
+      Date.now(),
+    );
+
+    const text =
+      page.text.length > MAX_CONTEXT_LENGTH
+        ? `${page.text.slice(0, MAX_CONTEXT_LENGTH)}\n... [page truncated]`
+        : page.text;
+    await context.services.config?.getResearchClient()?.addHistory({
+      role: 'user',
+      parts: [
+        {
+          text: `I fetched the web page "${page.title}" (${page.url}). Its readable text:\n\n${text}`,
+        },
+      ],
+    });
+    return {
+      type: 'message',
+      messageType: 'info',
+      content: `Added "${page.title}" to the conversation.${cached ? ' Use /fetch --refresh to download it again.' : ''}`,
+    };
+  },
+};
@@ -0,0 +1,142 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import {
+  Config,
+  extractPdfFigures,
+  renderPdfPage,
+} from '@iechor/research-cli-core';
+import { figuresCommand } from './figuresCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+
+vi.mock('@iechor/research-cli-core', async (importOriginal) => {
+  const actual =
+    await importOriginal<typeof import('@iechor/research-cli-core')>();
+  return {
+    ...actual,
+    extractPdfFigures: vi.fn(),
+    renderPdfPage: vi.fn(),
+  };
+});
+
+describe('figuresCommand', () => {
+  let tempDir: string;
+  const addHistory = vi.fn();
+
+  const subCommand = (name: string) =>
+    figuresCommand.subCommands!.find((c) => c.name === name)!;
+
+  const context = () =>
+    createMockCommandContext({
+      services: {
+        config: {
+          getTargetDir: () => tempDir,
+          getProjectTempDir: () => path.join(tempDir, 'tmp'),
+          isIncognito: () => false,
+          getResearchClient: () => ({ addHistory }),
+        } as unknown as Config,
+      },
+    });
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'figures-command-'));
+    fs.writeFileSync(path.join(tempDir, 'paper.pdf'), '%PDF-1.7');
+    fs.writeFileSync(path.join(tempDir, 'fig.png'), 'figure');
+    fs.writeFileSync(path.join(tempDir, 'page.png'), 'page');
+    vi.mocked(extractPdfFigures).mockResolvedValue([
+      {
+        index: 1,
+        page: 3,
+        width: 640,
+        height: 480,
+        file: path.join(tempDir, 'fig.png'),
+      },
+    ]);
+    vi.mocked(renderPdfPage).mockResolvedValue({
+      index: 0,
+      page: 5,
+      width: 1275,
+      height: 1650,
+      file: path.join(tempDir, 'page.png'),
+    });
+  });
+
+  afterEach(() => {
+    vi.clearAllMocks();
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should list the figures of a paper', async () => {
+    const listContext = context();
+    await figuresCommand.action!(listContext, 'paper.pdf');
+
+    expect(listContext.ui.addItem).toHaveBeenCalledWith(
+      expect.objectContaining({
+        text: expect.stringContaining(
+          'paper.pdf: 1 figures\n  Figure 1 (page 3, 640×480)',
+        ),
+      }),
+      expect.any(Number),
+    );
+    const [, dir] = vi.mocked(extractPdfFigures).mock.calls[0];
+    expect(path.dirname(dir)).toBe(path.join(tempDir, 'tmp', 'figures'));
+  });
+
+  it('should show figures as image items in the history', async () => {
+    const showContext = context();
+    await subCommand('show').action!(showContext, 'paper.pdf 1');
+
+    expect(showContext.ui.addItem).toHaveBeenCalledWith(
+      {
+        type: 'image',
+        caption: 'Figure 1 (page 3, 640×480)',
+        file: path.join(tempDir, 'fig.png'),
+      },
+      expect.any(Number),
+    );
+  });
+
+  it('should attach figures and rendered pages as images', async () => {
+    const result = await subCommand('attach').action!(
+      context(),
+      'paper.pdf 1 p5',
+    );
+
+    expect(result).toMatchObject({
+      messageType: 'info',
+      content: expect.stringContaining(
+        'Attached Figure 1 (page 3, 640×480), Page 5 (page 5, 1275×1650)',
+      ),
+    });
+    const [{ parts }] = addHistory.mock.calls[0];
+    expect(parts).toHaveLength(5);
+    expect(parts[2]).toEqual({
+      inlineData: {
+        mimeType: 'image/png',
+        data: Buffer.from('figure').toString('base64'),
+      },
+    });
+    expect(renderPdfPage).toHaveBeenCalledWith(
+      path.join(tempDir, 'paper.pdf'),
+      5,
+      expect.any(String),
+    );
+  });
+
+  it('should reject figure numbers the paper does not have', async () => {
+    const result = await subCommand('attach').action!(context(), 'paper.pdf 4');
+
+    expect(result).toMatchObject({
+      messageType: 'error',
+      content: expect.stringContaining('No figure 4'),
+    });
+    expect(addHistory).not.toHaveBeenCalled();
+  });
+});
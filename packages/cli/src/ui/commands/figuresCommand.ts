@@ -0,0 +1,218 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { Part } from '@google/genai';
+import {
+  PdfFigure,
+  extractPdfFigures,
+  getErrorMessage,
+  getPdfCacheKey,
+  renderPdfPage,
+} from '@iechor/research-cli-core';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import { MessageType } from '../types.js';
+import { detectImageProtocol } from '../utils/terminalImage.js';
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+function resolvePath(context: CommandContext, file: string): string {
+  const root = context.services.config?.getTargetDir() ?? process.cwd();
+  return path.resolve(root, file);
+}
+
+async function getFigureDir(
+  context: CommandContext,
+  pdfPath: string,
+): Promise<string> {
+  const config = context.services.config;
+  // Incognito sessions keep extracted images out of the project temp dir.
+  const root =
+    !config || config.isIncognito()
+      ? path.join(os.tmpdir(), 'research-figures')
+      : path.join(config.getProjectTempDir(), 'figures');
+  return path.join(root, await getPdfCacheKey(pdfPath));
+}
+
+function describeFigure(figure: PdfFigure): string {
+  const label =
+    figure.index > 0 ? `Figure ${figure.index}` : `Page ${figure.page}`;
+  return `${label} (page ${figure.page}, ${figure.width}×${figure.height})`;
+}
+
+/**
+ * Resolves selections such as `2 5 p7` to extracted figures (by number)
+ * and rendered pages (p<page>).
+ */
+async function selectFigures(
+  context: CommandContext,
+  pdfPath: string,
+  selections: string[],
+): Promise<PdfFigure[]> {
+  const dir = await getFigureDir(context, pdfPath);
+  const figures = await extractPdfFigures(pdfPath, dir);
+  const selected: PdfFigure[] = [];
+  for (const selection of selections) {
+    const page = selection.match(/^p(\d+)$/i);
+    if (page) {
+      selected.push(await renderPdfPage(pdfPath, Number(page[1]), dir));
+      continue;
+    }
+    const figure = figures.find((f) => String(f.index) === selection);
+    if (!figure) {
+      throw new Error(
+        `No figure ${selection}; the PDF has ${figures.length} figures. Use p<page> to render a whole page.`,
+      );
+    }
+    selected.push(figure);
+  }
+  return selected;
+}
+
+function parseSelectionArgs(args: string): {
+  file?: string;
+  selections: string[];
+} {
+  const [file, ...selections] = args.trim().split(/\s+/).filter(Boolean);
+  return { file, selections };
+}
+
+export const figuresCommand: SlashCommand = {
+  name: 'figures',
+  description: 'List the figures embedded in PDFs. Usage: /figures <pdf...>',
+  action: async (context: CommandContext, args: string) => {
+    const files = args.trim().split(/\s+/).filter(Boolean);
+    if (files.length === 0) {
+      return error('Usage: /figures <pdf...>');
+    }
+    const sections: string[] = [];
+    for (const file of files) {
+      const pdfPath = resolvePath(context, file);
+      try {
+        const figures = await extractPdfFigures(
+          pdfPath,
+          await getFigureDir(context, pdfPath),
+        );
+        sections.push(
+          figures.length === 0
+            ? `${file}: no embedded figures (vector figures and tables can be captured with /figures show ${file} p<page>)`
+            : [
+                `${file}: ${figures.length} figures`,
+                ...figures.map((figure) => `  ${describeFigure(figure)}`),
+              ].join('\n'),
+        );
+      } catch (e) {
+        return error(
+          `Could not read figures from ${file}: ${getErrorMessage(e)}`,
+        );
+      }
+    }
+    context.ui.addItem(
+      {
+        type: MessageType.INFO,
+        text: [
+          ...sections,
+          '',
+          'Preview with /figures show <pdf> <n...>, or attach to the next prompt with /figures attach <pdf> <n...>. Use p<page> for a whole page.',
+        ].join('\n'),
+      },
+      Date.now(),
+    );
+  },
+  subCommands: [
+    {
+      name: 'show',
+      description:
+        'Preview figures inline in kitty, Ghostty, iTerm2 or WezTerm. Usage: /figures show <pdf> <n|p<page>...>',
+      action: async (context, args) => {
+        const { file, selections } = parseSelectionArgs(args);
+        if (!file || selections.length === 0) {
+          return error('Usage: /figures show <pdf> <n|p<page>...>');
+        }
+        let figures: PdfFigure[];
+        try {
+          figures = await selectFigures(
+            context,
+            resolvePath(context, file),
+            selections,
+          );
+        } catch (e) {
+          return error(getErrorMessage(e));
+        }
+
+        for (const figure of figures) {
+          context.ui.addItem(
+            {
+              type: 'image',
+              caption: describeFigure(figure),
+              file: figure.file,
+            },
+            Date.now(),
+          );
+        }
+        if (!detectImageProtocol()) {
+          return info(
+            'This terminal cannot show images inline; open the files above instead.',
+          );
+        }
+      },
+    },
+    {
+      name: 'attach',
+      description:
+        'Attach figures to the conversation so the model can explain them. Usage: /figures attach <pdf> <n|p<page>...>',
+      action: async (context, args) => {
+        const { file, selections } = parseSelectionArgs(args);
+        if (!file || selections.length === 0) {
+          return error('Usage: /figures attach <pdf> <n|p<page>...>');
+        }
+        const pdfPath = resolvePath(context, file);
+        const parts: Part[] = [];
+        let figures: PdfFigure[];
+        try {
+          figures = await selectFigures(context, pdfPath, selections);
+          for (const figure of figures) {
+            parts.push(
+              { text: `${describeFigure(figure)} of ${pdfPath}:` },
+              {
+                inlineData: {
+                  mimeType: 'image/png',
+                  data: fs.readFileSync(figure.file).toString('base64'),
+                },
+              },
+            );
+          }
+        } catch (e) {
+          return error(getErrorMessage(e));
+        }
+        await context.services.config?.getResearchClient()?.addHistory({
+          role: 'user',
+          parts: [
+            {
+              text: `I attached ${figures.length} figure${figures.length === 1 ? '' : 's'} from ${pdfPath}. Use them to answer my next questions.`,
+            },
+            ...parts,
+          ],
+        });
+        return info(
+          `Attached ${figures.map(describeFigure).join(', ')}. Ask about them in your next prompt; explaining images needs a vision-capable model.`,
+        );
+      },
+    },
+  ],
+};
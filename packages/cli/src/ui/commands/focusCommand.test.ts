@@ -0,0 +1,73 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, vi, beforeEach, afterEach } from 'vitest';
+import { Config } from '@iechor/research-cli-core';
+import { focusCommand } from './focusCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+import { CommandContext } from './types.js';
+import { getFocusTimer } from '../utils/focusTimer.js';
+
+describe('focusCommand', () => {
+  let context: CommandContext;
+  const subCommand = (name: string) =>
+    focusCommand.subCommands!.find((c) => c.name === name)!;
+
+  beforeEach(() => {
+    vi.useFakeTimers();
+    vi.setSystemTime(new Date('2026-03-02T09:00:00'));
+    getFocusTimer().stop();
+    context = createMockCommandContext({
+      services: {
+        config: {
+          getTargetDir: () => '/nonexistent/project',
+        } as unknown as Config,
+      },
+    });
+  });
+
+  afterEach(() => {
+    vi.useRealTimers();
+  });
+
+  it('should start, pause and resume the timer', async () => {
+    expect(await subCommand('start').action!(context, '50')).toMatchObject({
+      content: 'Focus for 50 minutes, then a 10-minute break.',
+    });
+
+    vi.advanceTimersByTime(20 * 60 * 1000);
+    await subCommand('pause').action!(context, '');
+    await focusCommand.action!(context, '');
+    expect(context.ui.addItem).toHaveBeenCalledWith(
+      {
+        type: 'info',
+        text: expect.stringContaining('⏸ focus 30:00, 0 messages so far.'),
+      },
+      expect.any(Number),
+    );
+
+    expect(await subCommand('resume').action!(context, '')).toMatchObject({
+      content: 'Resumed.',
+    });
+    expect(await subCommand('stop').action!(context, '')).toMatchObject({
+      content: 'Stopped the focus timer.',
+    });
+  });
+
+  it('should say when the timer is not running', async () => {
+    expect(await subCommand('pause').action!(context, '')).toMatchObject({
+      messageType: 'error',
+      content: 'The focus timer is not running.',
+    });
+  });
+
+  it('should reject malformed lengths', async () => {
+    expect(await subCommand('start').action!(context, 'x')).toMatchObject({
+      messageType: 'error',
+      content: 'Usage: /focus start [minutes] [break minutes]',
+    });
+  });
+});
@@ -0,0 +1,130 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import { MessageType } from '../types.js';
+import {
+  DEFAULT_BREAK_MINUTES,
+  DEFAULT_FOCUS_MINUTES,
+  countNotesWords,
+  formatFocusInterval,
+  formatFocusStatus,
+  getFocusTimer,
+} from '../utils/focusTimer.js';
+
+const START_USAGE = 'Usage: /focus start [minutes] [break minutes]';
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+function getRoot(context: CommandContext): string {
+  return context.services.config?.getTargetDir() ?? process.cwd();
+}
+
+function showStats(context: CommandContext): void {
+  const timer = getFocusTimer();
+  const intervals = timer.getIntervals();
+  const status = formatFocusStatus(timer, Date.now());
+  const lines = [
+    status
+      ? `${status}, ${timer.getCurrentMessages()} messages so far.`
+      : 'The focus timer is not running. Start it with /focus start.',
+  ];
+  if (intervals.length > 0) {
+    const minutes = intervals.reduce((sum, i) => sum + i.minutes, 0);
+    const messages = intervals.reduce((sum, i) => sum + i.messages, 0);
+    const words = intervals.reduce((sum, i) => sum + (i.words ?? 0), 0);
+    lines.push(
+      '',
+      `${intervals.length} intervals this session: ${minutes} min, ${messages} messages, ${words} words written to notes.`,
+      ...intervals.map(
+        (interval) =>
+          `  ${new Date(interval.startedAt).toLocaleTimeString([], {
+            hour: '2-digit',
+            minute: '2-digit',
+          })}  ${formatFocusInterval(interval)}`,
+      ),
+    );
+  }
+  context.ui.addItem(
+    { type: MessageType.INFO, text: lines.join('\n') },
+    Date.now(),
+  );
+}
+
+export const focusCommand: SlashCommand = {
+  name: 'focus',
+  description:
+    'Show the focus timer and the stats of finished intervals. Time writing sprints with /focus start; the timer shows in the status bar and reminds you to take breaks.',
+  action: async (context) => {
+    showStats(context);
+  },
+  subCommands: [
+    {
+      name: 'start',
+      description: `Start a focus interval, ${DEFAULT_FOCUS_MINUTES} minutes with a ${DEFAULT_BREAK_MINUTES}-minute break by default. ${START_USAGE}`,
+      action: async (context, args) => {
+        const numbers = args.trim().split(/\s+/).filter(Boolean).map(Number);
+        if (
+          numbers.length > 2 ||
+          numbers.some((n) => !Number.isFinite(n) || n <= 0)
+        ) {
+          return error(START_USAGE);
+        }
+        const [minutes = DEFAULT_FOCUS_MINUTES, breakMinutes] = numbers;
+        const breakLength =
+          breakMinutes ?? Math.max(1, Math.round(minutes / 5));
+        getFocusTimer().start(
+          Date.now(),
+          minutes,
+          breakLength,
+          await countNotesWords(getRoot(context)),
+        );
+        return info(
+          `Focus for ${minutes} minutes, then a ${breakLength}-minute break.`,
+        );
+      },
+    },
+    {
+      name: 'pause',
+      description: 'Pause the timer.',
+      action: async () =>
+        getFocusTimer().pause(Date.now())
+          ? info('Paused. Continue with /focus resume.')
+          : error('The focus timer is not running.'),
+    },
+    {
+      name: 'resume',
+      description: 'Continue a paused timer.',
+      action: async () =>
+        getFocusTimer().resume(Date.now())
+          ? info('Resumed.')
+          : error('The focus timer is not paused.'),
+    },
+    {
+      name: 'stop',
+      description:
+        'Stop the timer. An interval stopped early is not counted.',
+      action: async () => {
+        const timer = getFocusTimer();
+        if (timer.getPhase() === 'idle') {
+          return error('The focus timer is not running.');
+        }
+        timer.stop();
+        return info('Stopped the focus timer.');
+      },
+    },
+  ],
+};
@@ -0,0 +1,92 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, vi, beforeEach } from 'vitest';
+import { Config } from '@iechor/research-cli-core';
+import { forceModelCommand } from './forceModelCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+import { CommandContext } from './types.js';
+import { LoadedSettings, ModelRoutingSettings } from '../../config/settings.js';
+import { getForcedModel, setForcedModel } from '../utils/modelRouter.js';
+
+describe('forceModelCommand', () => {
+  let setModel: ReturnType<typeof vi.fn>;
+
+  const contextWith = (modelRouting?: ModelRoutingSettings): CommandContext =>
+    createMockCommandContext({
+      services: {
+        config: {
+          getModel: () => 'research-pro',
+          setModel,
+          isInQuotaFallback: () => false,
+        } as unknown as Config,
+        settings: { merged: { modelRouting } } as LoadedSettings,
+      },
+    });
+
+  const routing: ModelRoutingSettings = {
+    enabled: true,
+    cheapModel: 'gpt-4o-mini',
+    premiumModel: 'gpt-4o',
+  };
+
+  beforeEach(() => {
+    setModel = vi.fn();
+    setForcedModel(undefined);
+  });
+
+  it('should describe the routing', async () => {
+    expect(
+      await forceModelCommand.action!(contextWith(routing), ''),
+    ).toMatchObject({
+      messageType: 'info',
+      content: expect.stringContaining(
+        'Short prompts go to gpt-4o-mini. Prompts over 500 characters',
+      ),
+    });
+    expect(await forceModelCommand.action!(contextWith(), '')).toMatchObject({
+      content: expect.stringContaining('Model routing is off'),
+    });
+  });
+
+  it('should force a model until turned off', async () => {
+    const context = contextWith(routing);
+    await forceModelCommand.action!(context, 'o3');
+    expect(getForcedModel()).toBe('o3');
+    expect(setModel).toHaveBeenCalledWith('o3');
+
+    expect(await forceModelCommand.action!(context, 'off')).toMatchObject({
+      content: 'Prompts are routed by the modelRouting settings again.',
+    });
+    expect(getForcedModel()).toBeUndefined();
+  });
+
+  it('should go back to the model in use before forcing', async () => {
+    const context = contextWith();
+    await forceModelCommand.action!(context, 'o3');
+    await forceModelCommand.action!(context, 'o4-mini');
+
+    expect(await forceModelCommand.action!(context, 'off')).toMatchObject({
+      content: 'Prompts go to research-pro again.',
+    });
+    expect(setModel).toHaveBeenLastCalledWith('research-pro');
+  });
+
+  it('should force the premium model of the routing', async () => {
+    await forceModelCommand.action!(contextWith(routing), 'premium');
+    expect(getForcedModel()).toBe('gpt-4o');
+  });
+
+  it('should need routing for premium and cheap', async () => {
+    expect(
+      await forceModelCommand.action!(contextWith(), 'cheap'),
+    ).toMatchObject({
+      messageType: 'error',
+      content: expect.stringContaining('no cheap model'),
+    });
+    expect(getForcedModel()).toBeUndefined();
+  });
+});
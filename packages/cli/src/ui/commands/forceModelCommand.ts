@@ -0,0 +1,99 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { getModelCatalog } from '@iechor/research-cli-core';
+import { SlashCommand, SlashCommandActionReturn } from './types.js';
+import {
+  DEFAULT_MAX_CHEAP_CONTEXT_TOKENS,
+  DEFAULT_MAX_CHEAP_PROMPT_LENGTH,
+  getForcedModel,
+  getModelBeforeForce,
+  isRoutingEnabled,
+  setForcedModel,
+} from '../utils/modelRouter.js';
+
+const USAGE =
+  'Usage: /force-model [<model> | premium | cheap | off]. Without an argument, shows how models are chosen.';
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+export const forceModelCommand: SlashCommand = {
+  name: 'force-model',
+  description:
+    'Send every prompt to one model, over the model routing, until turned off. ' +
+    USAGE,
+  completion: async (_context, partialArg) =>
+    [
+      'off',
+      'premium',
+      'cheap',
+      ...getModelCatalog().map((entry) => entry.id),
+    ].filter((option) => option.startsWith(partialArg)),
+  action: async (context, args) => {
+    const arg = args.trim();
+    const routing = context.services.settings.merged.modelRouting;
+
+    if (!arg) {
+      const forced = getForcedModel();
+      if (forced) {
+        return info(
+          `Every prompt goes to ${forced}, forced with /force-model. /force-model off goes back to ${isRoutingEnabled(routing) ? 'routing' : 'the selected model'}.`,
+        );
+      }
+      if (!isRoutingEnabled(routing)) {
+        return info(
+          'Model routing is off; prompts go to the selected model. Set modelRouting in settings to route them.',
+        );
+      }
+      return info(
+        `Short prompts go to ${routing.cheapModel}. Prompts over ${(routing.maxCheapPromptLength ?? DEFAULT_MAX_CHEAP_PROMPT_LENGTH).toLocaleString('en-US')} characters, conversations over ${(routing.maxCheapContextTokens ?? DEFAULT_MAX_CHEAP_CONTEXT_TOKENS).toLocaleString('en-US')} tokens, attachments and tool results go to ${routing.premiumModel}.`,
+      );
+    }
+
+    if (arg === 'off') {
+      if (!getForcedModel()) {
+        return info('No model is forced.');
+      }
+      const previous = getModelBeforeForce();
+      setForcedModel(undefined);
+      if (previous && !context.services.config?.isInQuotaFallback()) {
+        context.services.config?.setModel(previous);
+      }
+      return info(
+        isRoutingEnabled(routing)
+          ? 'Prompts are routed by the modelRouting settings again.'
+          : `Prompts go to ${previous ?? context.services.config?.getModel()} again.`,
+      );
+    }
+
+    let model = arg;
+    if (arg === 'premium' || arg === 'cheap') {
+      if (!isRoutingEnabled(routing)) {
+        return error(
+          `Model routing is off, so there is no ${arg} model. Set modelRouting in settings, or give a model name.`,
+        );
+      }
+      model = arg === 'premium' ? routing.premiumModel : routing.cheapModel;
+    } else if (/\s/.test(arg)) {
+      return error(USAGE);
+    }
+    const config = context.services.config;
+    setForcedModel(model, config?.getModel());
+    if (config?.isInQuotaFallback()) {
+      return info(
+        `${model} is forced, but after the quota error this session stays with ${config.getModel()}.`,
+      );
+    }
+    config?.setModel(model);
+    return info(`Every prompt goes to ${model} until /force-model off.`);
+  },
+};
@@ -0,0 +1,107 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { Config } from '@iechor/research-cli-core';
+import { glossaryCommand } from './glossaryCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+
+const MAIN = String.raw`\documentclass{article}
+\begin{document}
+\input{method}
+% glossary
+\end{document}
+`;
+
+const METHOD = String.raw`Each token $x_i$ is scaled by $\alpha$.`;
+
+describe('glossaryCommand', () => {
+  let tempDir: string;
+  const generateJson = vi.fn();
+
+  const context = () =>
+    createMockCommandContext({
+      services: {
+        config: {
+          getTargetDir: () => tempDir,
+          getResearchClient: () => ({ generateJson }),
+        } as unknown as Config,
+      },
+    });
+
+  const subCommand = (name: string) =>
+    glossaryCommand.subCommands!.find((c) => c.name === name)!;
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'glossary-command-'));
+    fs.writeFileSync(path.join(tempDir, 'main.tex'), MAIN);
+    fs.writeFileSync(path.join(tempDir, 'method.tex'), METHOD);
+    generateJson.mockReset();
+    generateJson.mockResolvedValue({
+      symbols: [
+        { symbol: 'x_i', definition: 'the $i$-th token' },
+        { symbol: '\\alpha', definition: 'scale' },
+      ],
+    });
+  });
+
+  afterEach(() => {
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should scan the manuscript and keep definitions the user set', async () => {
+    const ctx = context();
+    await subCommand('set').action!(ctx, '\\alpha learning rate');
+    await subCommand('scan').action!(ctx, '');
+
+    const prompt = generateJson.mock.calls[0][0][0].parts[0].text as string;
+    expect(prompt).toContain('- x_i: ...');
+    expect(prompt).toContain('- \\alpha: ...');
+    const { text } = vi.mocked(ctx.ui.addItem).mock.calls[0][0] as {
+      text: string;
+    };
+    expect(text).toContain('Added 1 new symbols (2 in the glossary)');
+    expect(text).toContain('\\alpha  learning rate');
+    expect(text).toContain('x_i     the $i$-th token');
+  });
+
+  it('should insert the table into a draft and update it in place', async () => {
+    const ctx = context();
+    await subCommand('set').action!(ctx, 'x_i input token');
+    expect(await subCommand('insert').action!(ctx, 'main.tex')).toMatchObject(
+      { messageType: 'info' },
+    );
+    await subCommand('set').action!(ctx, '\\theta parameters');
+    await subCommand('insert').action!(ctx, 'main.tex');
+
+    const draft = fs.readFileSync(path.join(tempDir, 'main.tex'), 'utf8');
+    expect(draft.match(/\\begin\{table\}/g)).toHaveLength(1);
+    expect(draft).toContain('    $x_i$ & input token \\\\');
+    expect(draft).toContain('    $\\theta$ & parameters \\\\');
+    expect(await subCommand('insert').action!(ctx, 'method.tex')).toEqual({
+      type: 'message',
+      messageType: 'error',
+      content:
+        'Add a line with only "% glossary" to method.tex where the notation table should go.',
+    });
+  });
+
+  it('should remove symbols and report an empty glossary', async () => {
+    const ctx = context();
+    await subCommand('set').action!(ctx, 'x_{i} input token');
+    expect(await subCommand('remove').completion!(ctx, 'x')).toEqual(['x_i']);
+    expect(await subCommand('remove').action!(ctx, 'x_i')).toMatchObject({
+      content: 'Removed x_i.',
+    });
+    expect(await glossaryCommand.action!(ctx, '')).toMatchObject({
+      messageType: 'info',
+      content: expect.stringContaining('The glossary is empty.'),
+    });
+  });
+});
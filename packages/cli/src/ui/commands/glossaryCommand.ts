@@ -0,0 +1,271 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import {
+  GlossaryEntry,
+  defineSymbols,
+  extractMathSymbols,
+  extractPdfText,
+  findGlossaryEntry,
+  formatGlossary,
+  getGlossaryMarkers,
+  getErrorMessage,
+  insertGlossary,
+  loadGlossary,
+  mergeGlossary,
+  normalizeSymbol,
+  saveGlossary,
+} from '@iechor/research-cli-core';
+import stringWidth from 'string-width';
+import { padToWidth } from '../utils/textUtils.js';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import { MessageType } from '../types.js';
+import { loadLatexProject } from './latexCommand.js';
+
+const EMPTY_GLOSSARY =
+  'The glossary is empty. Build it with /glossary scan [file], or add symbols with /glossary set <symbol> <definition>.';
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+function getRoot(context: CommandContext): string {
+  return context.services.config?.getTargetDir() ?? process.cwd();
+}
+
+function splitFirstWord(args: string): [string, string] {
+  const trimmed = args.trim();
+  const space = trimmed.search(/\s/);
+  return space === -1
+    ? [trimmed, '']
+    : [trimmed.slice(0, space), trimmed.slice(space + 1).trim()];
+}
+
+function formatEntries(entries: GlossaryEntry[]): string {
+  const width = Math.max(...entries.map((e) => stringWidth(e.symbol)));
+  return entries
+    .map((e) => `  ${padToWidth(e.symbol, width)}  ${e.definition}`)
+    .join('\n');
+}
+
+/**
+ * The text to scan: the given file, or every file of the LaTeX
+ * manuscript. PDFs have no markup to find the symbols in, so the model
+ * picks them.
+ */
+async function readScanTarget(
+  context: CommandContext,
+  file: string,
+): Promise<{ label: string; text: string; latex: boolean }> {
+  const root = getRoot(context);
+  if (!file) {
+    const project = await loadLatexProject(context);
+    if (!project) {
+      throw new Error(
+        'No LaTeX manuscript in the project. Usage: /glossary scan <file>',
+      );
+    }
+    const texts = await Promise.all(
+      project.files.map((f) =>
+        fs.promises.readFile(path.join(project.root, f), 'utf8'),
+      ),
+    );
+    return { label: project.mainFile, text: texts.join('\n'), latex: true };
+  }
+  const filePath = path.resolve(root, file.replace(/^@/, ''));
+  if (!fs.existsSync(filePath)) {
+    throw new Error(`File not found: ${file}`);
+  }
+  const label = path.relative(root, filePath);
+  if (filePath.toLowerCase().endsWith('.pdf')) {
+    return { label, text: await extractPdfText(filePath), latex: false };
+  }
+  return {
+    label,
+    text: await fs.promises.readFile(filePath, 'utf8'),
+    latex: true,
+  };
+}
+
+export const glossaryCommand: SlashCommand = {
+  name: 'glossary',
+  description:
+    'Show the notation glossary of this workspace. Build it from a paper with /glossary scan, then put it into a draft with /glossary insert.',
+  action: async (context: CommandContext) => {
+    let entries: GlossaryEntry[];
+    try {
+      entries = await loadGlossary(getRoot(context));
+    } catch (e) {
+      return error(`Could not read the glossary: ${getErrorMessage(e)}`);
+    }
+    if (entries.length === 0) {
+      return info(EMPTY_GLOSSARY);
+    }
+    context.ui.addItem(
+      {
+        type: MessageType.INFO,
+        text: `Notation (${entries.length} symbols):\n${formatEntries(entries)}`,
+      },
+      Date.now(),
+    );
+  },
+  subCommands: [
+    {
+      name: 'scan',
+      description:
+        'Find the symbols in a paper and let the model define them from context; definitions you set are kept. Usage: /glossary scan [<file.tex|file.md|file.pdf>] (default: the LaTeX manuscript)',
+      action: async (context, args) => {
+        const config = context.services.config;
+        if (!config) {
+          return error('Scanning needs a configured model.');
+        }
+        const root = getRoot(context);
+        let added: number;
+        let entries: GlossaryEntry[];
+        try {
+          const target = await readScanTarget(context, args.trim());
+          const candidates = target.latex
+            ? extractMathSymbols(target.text)
+            : undefined;
+          if (candidates?.length === 0) {
+            return info(`No math in ${target.label}.`);
+          }
+          context.ui.setDebugMessage(
+            `Defining the notation of ${target.label}...`,
+          );
+          const definitions = await defineSymbols(
+            config.getResearchClient(),
+            target.text,
+            candidates,
+            context.abortSignal,
+          );
+          entries = await loadGlossary(root);
+          added = mergeGlossary(entries, definitions, target.label);
+          await saveGlossary(root, entries);
+        } catch (e) {
+          return error(`Could not scan for notation: ${getErrorMessage(e)}`);
+        }
+        context.ui.addItem(
+          {
+            type: MessageType.INFO,
+            text: `Added ${added} new symbols (${entries.length} in the glossary):\n${formatEntries(entries)}\n\nCorrect a definition with /glossary set <symbol> <definition>.`,
+          },
+          Date.now(),
+        );
+      },
+    },
+    {
+      name: 'set',
+      description:
+        'Add or correct a definition; scans keep it. Usage: /glossary set <symbol> <definition>',
+      action: async (context, args) => {
+        const [symbol, definition] = splitFirstWord(args);
+        if (!symbol || !definition) {
+          return error('Usage: /glossary set <symbol> <definition>');
+        }
+        const root = getRoot(context);
+        try {
+          const entries = await loadGlossary(root);
+          const existing = findGlossaryEntry(entries, symbol);
+          if (existing) {
+            existing.definition = definition;
+            existing.edited = true;
+          } else {
+            entries.push({
+              symbol: normalizeSymbol(symbol),
+              definition,
+              edited: true,
+            });
+          }
+          await saveGlossary(root, entries);
+          return info(
+            `${existing ? 'Updated' : 'Added'} ${normalizeSymbol(symbol)}.`,
+          );
+        } catch (e) {
+          return error(`Could not save the glossary: ${getErrorMessage(e)}`);
+        }
+      },
+    },
+    {
+      name: 'remove',
+      description: 'Remove a symbol. Usage: /glossary remove <symbol>',
+      action: async (context, args) => {
+        const root = getRoot(context);
+        try {
+          const entries = await loadGlossary(root);
+          const entry = findGlossaryEntry(entries, args.trim());
+          if (!entry) {
+            return error(`Not in the glossary: ${args.trim()}`);
+          }
+          await saveGlossary(
+            root,
+            entries.filter((e) => e !== entry),
+          );
+          return info(`Removed ${entry.symbol}.`);
+        } catch (e) {
+          return error(`Could not save the glossary: ${getErrorMessage(e)}`);
+        }
+      },
+      completion: async (context, partialArg) => {
+        try {
+          return (await loadGlossary(getRoot(context)))
+            .map((e) => e.symbol)
+            .filter((symbol) => symbol.startsWith(partialArg));
+        } catch {
+          return [];
+        }
+      },
+    },
+    {
+      name: 'insert',
+      description: `Put the glossary into a draft as a notation table (Markdown for .md files, LaTeX otherwise), at a line with only "${getGlossaryMarkers('latex').placeholder}" ("${getGlossaryMarkers('markdown').placeholder}" in Markdown) or in place of the table inserted before. Usage: /glossary insert <file>`,
+      action: async (context, args) => {
+        const file = args.trim().replace(/^@/, '');
+        if (!file) {
+          return error('Usage: /glossary insert <file>');
+        }
+        const filePath = path.resolve(getRoot(context), file);
+        try {
+          const entries = await loadGlossary(getRoot(context));
+          if (entries.length === 0) {
+            return error(EMPTY_GLOSSARY);
+          }
+          if (!fs.existsSync(filePath)) {
+            return error(`File not found: ${file}`);
+          }
+          const draft = await fs.promises.readFile(filePath, 'utf8');
+          const format = filePath.endsWith('.md') ? 'markdown' : 'latex';
+          const updated = insertGlossary(
+            draft,
+            formatGlossary(entries, format),
+            format,
+          );
+          if (updated === undefined) {
+            return error(
+              `Add a line with only "${getGlossaryMarkers(format).placeholder}" to ${file} where the notation table should go.`,
+            );
+          }
+          await fs.promises.writeFile(filePath, updated, 'utf8');
+          return info(
+            `Inserted ${entries.length} symbols into ${file}.${format === 'markdown' ? '' : ' The table uses booktabs.'}`,
+          );
+        } catch (e) {
+          return error(`Could not insert the glossary: ${getErrorMessage(e)}`);
+        }
+      },
+    },
+  ],
+};
@@ -0,0 +1,56 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, vi } from 'vitest';
+import { historyCommand } from './historyCommand.js';
+import { type CommandContext } from './types.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+
+const olderCommand = historyCommand.subCommands!.find(
+  (c) => c.name === 'older',
+)!;
+
+describe('historyCommand', () => {
+  it('should load a default page of earlier messages', async () => {
+    const context: CommandContext = createMockCommandContext();
+    vi.mocked(context.ui.loadOlderHistory).mockReturnValue(50);
+
+    const result = await olderCommand.action!(context, '');
+
+    expect(context.ui.loadOlderHistory).toHaveBeenCalledWith(50);
+    expect(result).toEqual({
+      type: 'message',
+      messageType: 'info',
+      content: expect.stringContaining('Loaded 50 earlier message(s)'),
+    });
+  });
+
+  it('should report when nothing is left on disk', async () => {
+    const context: CommandContext = createMockCommandContext();
+
+    const result = await olderCommand.action!(context, '10');
+
+    expect(context.ui.loadOlderHistory).toHaveBeenCalledWith(10);
+    expect(result).toEqual({
+      type: 'message',
+      messageType: 'info',
+      content: 'All messages of this session are already shown.',
+    });
+  });
+
+  it('should reject an invalid count', async () => {
+    const context: CommandContext = createMockCommandContext();
+
+    const result = await olderCommand.action!(context, 'lots');
+
+    expect(context.ui.loadOlderHistory).not.toHaveBeenCalled();
+    expect(result).toEqual({
+      type: 'message',
+      messageType: 'error',
+      content: 'Usage: /history older [count]',
+    });
+  });
+});
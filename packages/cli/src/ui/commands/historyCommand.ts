@@ -0,0 +1,40 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { SlashCommand, SlashCommandActionReturn } from './types.js';
+
+const DEFAULT_PAGE_SIZE = 50;
+
+export const historyCommand: SlashCommand = {
+  name: 'history',
+  description: 'Page through earlier messages of a long session.',
+  subCommands: [
+    {
+      name: 'older',
+      description: `Load earlier messages from disk (default ${DEFAULT_PAGE_SIZE}). Usage: /history older [count]`,
+      action: (context, args): SlashCommandActionReturn => {
+        const trimmed = args?.trim() ?? '';
+        const count = trimmed === '' ? DEFAULT_PAGE_SIZE : Number(trimmed);
+        if (!Number.isInteger(count) || count <= 0) {
+          return {
+            type: 'message',
+            messageType: 'error',
+            content: 'Usage: /history older [count]',
+          };
+        }
+        const loaded = context.ui.loadOlderHistory(count);
+        return {
+          type: 'message',
+          messageType: 'info',
+          content:
+            loaded > 0
+              ? `Loaded ${loaded} earlier message(s). They are released again when the conversation continues.`
+              : 'All messages of this session are already shown.',
+        };
+      },
+    },
+  ],
+};
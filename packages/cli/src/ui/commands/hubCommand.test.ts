@@ -0,0 +1,78 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, afterEach, vi } from 'vitest';
+import { Config } from '@iechor/research-cli-core';
+import { hubCommand } from './hubCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+
+const DATASETS = [
+  {
+    id: 'openai/gsm8k',
+    downloads: 412345,
+    tags: ['license:mit', 'size_categories:1K<n<10K'],
+  },
+  { id: 'hendrycks/competition_math', downloads: 9000, tags: [] },
+];
+
+describe('hubCommand', () => {
+  const addHistory = vi.fn();
+
+  const context = () =>
+    createMockCommandContext({
+      services: {
+        config: {
+          getResearchClient: () => ({ addHistory }),
+        } as unknown as Config,
+      },
+    });
+
+  const subCommand = (name: string) =>
+    hubCommand.subCommands!.find((c) => c.name === name)!;
+
+  afterEach(() => {
+    vi.unstubAllGlobals();
+    addHistory.mockReset();
+  });
+
+  it('should list datasets and attach one with its README', async () => {
+    vi.stubGlobal(
+      'fetch',
+      vi.fn(async (url: string) =>
+        url.endsWith('README.md')
+          ? new Response('# GSM8K\n\n8.5K grade school math problems.')
+          : new Response(JSON.stringify(DATASETS)),
+      ),
+    );
+    const ctx = context();
+
+    await subCommand('datasets').action!(ctx, 'math --limit 2');
+    const { text } = vi.mocked(ctx.ui.addItem).mock.calls[0][0] as {
+      text: string;
+    };
+    expect(text).toContain('HuggingFace datasets for "math":');
+    expect(text).toContain(
+      '1. openai/gsm8k  (dataset, https://huggingface.co/datasets/openai/gsm8k)\n    license mit · 1K<n<10K examples · 412.3K downloads',
+    );
+    expect(text).toContain('2. hendrycks/competition_math');
+
+    expect(await subCommand('attach').action!(ctx, '1')).toMatchObject({
+      content: 'Added 1 result to the conversation.',
+    });
+    const attached = addHistory.mock.calls[0][0].parts[0].text as string;
+    expect(attached).toContain('openai/gsm8k');
+    expect(attached).toContain('README:\n# GSM8K');
+    expect(attached).not.toContain('competition_math');
+  });
+
+  it('should reject numbers outside the last results', async () => {
+    expect(await subCommand('attach').action!(context(), '9')).toMatchObject({
+      messageType: 'error',
+      content: 'Usage: /hub attach <n...> (1-2)',
+    });
+    expect(addHistory).not.toHaveBeenCalled();
+  });
+});
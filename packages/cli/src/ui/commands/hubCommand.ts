@@ -0,0 +1,165 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import {
+  HubCard,
+  HubSearchOptions,
+  HuggingFaceClient,
+  formatHubCard,
+  getErrorMessage,
+} from '@iechor/research-cli-core';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import { MessageType } from '../types.js';
+
+// The numbered results of the last search, for /hub attach
+let lastResults: HubCard[] = [];
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+function parseSearchArgs(args: string): {
+  query: string;
+  options: HubSearchOptions;
+} {
+  const tokens = args.trim().split(/\s+/).filter(Boolean);
+  const options: HubSearchOptions = {};
+  const query: string[] = [];
+  for (let i = 0; i < tokens.length; i++) {
+    if (tokens[i] === '--task') {
+      options.task = tokens[++i];
+    } else if (tokens[i] === '--limit') {
+      options.limit = Number(tokens[++i]);
+    } else {
+      query.push(tokens[i]);
+    }
+  }
+  return { query: query.join(' '), options };
+}
+
+function showResults(
+  context: CommandContext,
+  results: HubCard[],
+  heading: string,
+): void {
+  lastResults = results;
+  context.ui.addItem(
+    {
+      type: MessageType.INFO,
+      text: [
+        heading,
+        '',
+        ...results.map(
+          (result, i) =>
+            `${i + 1}. ${formatHubCard(result).replace(/\n/g, '\n  ')}`,
+        ),
+        '',
+        'Add results to the conversation with /hub attach <n...>.',
+      ].join('\n'),
+    },
+    Date.now(),
+  );
+}
+
+function searchHub(kind: 'datasets' | 'models'): SlashCommand {
+  const usage = `Usage: /hub ${kind} <query> [--task <task>] [--limit <n>]`;
+  return {
+    name: kind,
+    description: `Search HuggingFace ${kind}, most downloaded first, with license and size. ${usage}`,
+    action: async (context, args) => {
+      const { query, options } = parseSearchArgs(args);
+      if (!query || (options.limit !== undefined && !(options.limit > 0))) {
+        return error(usage);
+      }
+      const client = new HuggingFaceClient();
+      context.ui.setDebugMessage(`Searching HuggingFace ${kind}...`);
+      let cards: HubCard[];
+      try {
+        cards =
+          kind === 'datasets'
+            ? await client.searchDatasets(query, options)
+            : await client.searchModels(query, options);
+      } catch (e) {
+        return error(`Could not search HuggingFace: ${getErrorMessage(e)}`);
+      }
+      if (cards.length === 0) {
+        return info(`No HuggingFace ${kind} match "${query}".`);
+      }
+      showResults(context, cards, `HuggingFace ${kind} for "${query}":`);
+    },
+  };
+}
+
+export const hubCommand: SlashCommand = {
+  name: 'hub',
+  description:
+    'Find datasets and models for planning experiments. Usage: /hub datasets|models <query>, then /hub attach <n...>',
+  action: async () =>
+    info(
+      'Usage: /hub datasets <query> or /hub models <query>; then /hub attach <n...> to add results to the conversation.',
+    ),
+  subCommands: [
+    searchHub('datasets'),
+    searchHub('models'),
+    {
+      name: 'attach',
+      description:
+        'Add results of the last search to the conversation, with the README of datasets and models. Usage: /hub attach <n...>',
+      action: async (context, args) => {
+        const numbers = args
+          .trim()
+          .split(/[\s,]+/)
+          .filter(Boolean)
+          .map(Number);
+        const results = numbers.map((n) => lastResults[n - 1]);
+        if (numbers.length === 0 || results.some((r) => !r)) {
+          return error(
+            lastResults.length === 0
+              ? 'Search first with /hub datasets or /hub models.'
+              : `Usage: /hub attach <n...> (1-${lastResults.length})`,
+          );
+        }
+        const client = new HuggingFaceClient();
+        context.ui.setDebugMessage('Reading the cards...');
+        const texts: string[] = [];
+        for (const card of results) {
+          let text = formatHubCard(card);
+          try {
+            const readme = await client.fetchReadme(card);
+            if (readme) {
+              text += `\n\nREADME:\n${readme}`;
+            }
+          } catch (e) {
+            // The card alone still helps
+            console.warn(
+              `Could not read the README of ${card.id}: ${getErrorMessage(e)}`,
+            );
+          }
+          texts.push(text);
+        }
+        await context.services.config?.getResearchClient()?.addHistory({
+          role: 'user',
+          parts: [
+            {
+              text: `Datasets and models to consider when planning experiments:\n\n${texts.join('\n\n---\n\n')}`,
+            },
+          ],
+        });
+        return info(
+          `Added ${results.length} result${results.length === 1 ? '' : 's'} to the conversation.`,
+        );
+      },
+    },
+  ],
+};
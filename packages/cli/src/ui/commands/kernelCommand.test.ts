@@ -0,0 +1,119 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect } from 'vitest';
+import { Config } from '@iechor/research-cli-core';
+import {
+  formatExecutionResult,
+  getLatestCodeBlocks,
+  kernelCommand,
+} from './kernelCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+
+function configWithHistory(
+  history: Array<{ role: string; text: string }>,
+): Config {
+  return {
+    getResearchClient: () => ({
+      getHistory: () =>
+        history.map(({ role, text }) => ({ role, parts: [{ text }] })),
+    }),
+  } as unknown as Config;
+}
+
+describe('getLatestCodeBlocks', () => {
+  it('should use the latest model response that has code', () => {
+    const config = configWithHistory([
+      { role: 'model', text: '```python\nold()\n```' },
+      { role: 'user', text: 'now plot it' },
+      {
+        role: 'model',
+        text: '```python\na = 1\n```\nand\n```python\nb = 2\n```',
+      },
+      { role: 'user', text: 'thanks' },
+      { role: 'model', text: 'You are welcome.' },
+    ]);
+    expect(getLatestCodeBlocks(config).map((b) => b.code)).toEqual([
+      'a = 1\n',
+      'b = 2\n',
+    ]);
+  });
+});
+
+// The signature and IHDR chunk of a 640×480 PNG
+const PNG_640X480 = Buffer.concat([
+  Buffer.from('89504e470d0a1a0a0000000d49484452', 'hex'),
+  Buffer.from([0, 0, 2, 128, 0, 0, 1, 224]),
+]).toString('base64');
+
+describe('formatExecutionResult', () => {
+  it('should show text outputs under the execution count', () => {
+    expect(
+      formatExecutionResult({
+        status: 'ok',
+        executionCount: 3,
+        outputs: [
+          { type: 'stream', text: 'loading\n' },
+          { type: 'result', text: '42' },
+          { type: 'error', text: 'ignored here' },
+        ],
+      }),
+    ).toBe('Out [3]:\nloading\n42');
+  });
+
+  it('should replace figure reprs with the saved image paths', () => {
+    const result = formatExecutionResult(
+      {
+        status: 'ok',
+        executionCount: 4,
+        outputs: [
+          {
+            type: 'display',
+            text: '<Figure size 640x480>',
+            images: [{ mimeType: 'image/png', data: PNG_640X480 }],
+          },
+        ],
+      },
+      new Map([[0, ['/tmp/out-4-0-0.png']]]),
+    );
+    expect(result).toBe(
+      'Out [4]:\n🖼  /tmp/out-4-0-0.png (PNG image, 640×480)',
+    );
+  });
+
+  it('should describe images that were not saved', () => {
+    const result = formatExecutionResult({
+      status: 'ok',
+      executionCount: 5,
+      outputs: [
+        {
+          type: 'display',
+          text: '<Figure size 640x480>',
+          images: [{ mimeType: 'image/jpeg', data: '/9j/' }],
+        },
+      ],
+    });
+    expect(result).toBe('Out [5]:\n🖼  JPEG image');
+  });
+
+  it('should note when a successful cell printed nothing', () => {
+    expect(
+      formatExecutionResult({ status: 'ok', executionCount: 5, outputs: [] }),
+    ).toBe('Out [5]: (no output)');
+  });
+});
+
+describe('kernelCommand', () => {
+  it('should explain how to start a kernel when none is running', async () => {
+    const run = kernelCommand.subCommands!.find((c) => c.name === 'run')!;
+    const result = await run.action!(createMockCommandContext(), '');
+    expect(result).toEqual({
+      type: 'message',
+      messageType: 'error',
+      content: expect.stringContaining('No kernel is running'),
+    });
+  });
+});
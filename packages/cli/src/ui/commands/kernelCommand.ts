@@ -0,0 +1,306 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import {
+  CodeBlock,
+  Config,
+  JupyterKernelSession,
+  KernelExecutionResult,
+  KernelImage,
+  extractCodeBlocks,
+  getActiveKernel,
+  getErrorMessage,
+  setActiveKernel,
+} from '@iechor/research-cli-core';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import { MessageType } from '../types.js';
+import { registerCleanup } from '../../utils/cleanup.js';
+
+const DEFAULT_KERNEL = 'python3';
+
+const NO_KERNEL =
+  'No kernel is running. Start one with /kernel start [name] or /kernel connect <url> [token].';
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+/** Code blocks of the most recent model response that has any. */
+export function getLatestCodeBlocks(config: Config): CodeBlock[] {
+  const history = config.getResearchClient()?.getHistory() ?? [];
+  for (let i = history.length - 1; i >= 0; i--) {
+    if (history[i].role !== 'model') {
+      continue;
+    }
+    const text = (history[i].parts ?? [])
+      .map((part) => part.text ?? '')
+      .join('');
+    const blocks = extractCodeBlocks(text);
+    if (blocks.length > 0) {
+      return blocks;
+    }
+  }
+  return [];
+}
+
+function saveImages(
+  config: Config,
+  result: KernelExecutionResult,
+): Map<number, string[]> {
+  const saved = new Map<number, string[]>();
+  // Incognito sessions keep plots out of the project temp dir.
+  const dir = config.isIncognito()
+    ? path.join(os.tmpdir(), 'research-kernel')
+    : path.join(config.getProjectTempDir(), 'kernel');
+  result.outputs.forEach((output, index) => {
+    for (const [n, image] of (output.images ?? []).entries()) {
+      fs.mkdirSync(dir, { recursive: true });
+      const ext = image.mimeType.split('/')[1].replace('jpeg', 'jpg');
+      const file = path.join(
+        dir,
+        `out-${result.executionCount ?? 'x'}-${index}-${n}-${Date.now()}.${ext}`,
+      );
+      fs.writeFileSync(file, Buffer.from(image.data, 'base64'));
+      saved.set(index, [...(saved.get(index) ?? []), file]);
+    }
+  });
+  return saved;
+}
+
+/**
+ * Names the format of an image and, for PNG, its size in pixels, read from
+ * the IHDR chunk that follows the signature.
+ */
+export function describeImage(image: KernelImage): string {
+  const format = image.mimeType.split('/')[1].toUpperCase();
+  if (image.mimeType !== 'image/png') {
+    return `${format} image`;
+  }
+  const header = Buffer.from(image.data.slice(0, 32), 'base64');
+  if (header.length < 24 || header.toString('latin1', 12, 16) !== 'IHDR') {
+    return `${format} image`;
+  }
+  return `${format} image, ${header.readUInt32BE(16)}×${header.readUInt32BE(20)}`;
+}
+
+/**
+ * Renders kernel outputs as message text. Images are listed by the path
+ * they were saved to, or described when they were not saved.
+ */
+export function formatExecutionResult(
+  result: KernelExecutionResult,
+  imagePaths: Map<number, string[]> = new Map(),
+): string {
+  const label = `Out [${result.executionCount ?? ' '}]`;
+  const parts = result.outputs
+    .map((output, index) => {
+      if (output.type === 'error') {
+        return '';
+      }
+      const paths = imagePaths.get(index) ?? [];
+      const images = (output.images ?? []).map((image, n) =>
+        paths[n]
+          ? `🖼  ${paths[n]} (${describeImage(image)})`
+          : `🖼  ${describeImage(image)}`,
+      );
+      // A text/plain repr of a figure is noise when the image is shown.
+      const text = images.length > 0 ? '' : output.text.trimEnd();
+      return [text, ...images].filter(Boolean).join('\n');
+    })
+    .filter(Boolean);
+  if (parts.length === 0) {
+    return result.status === 'ok' ? `${label}: (no output)` : '';
+  }
+  return `${label}:\n${parts.join('\n')}`;
+}
+
+async function runCode(
+  context: CommandContext,
+  kernel: JupyterKernelSession,
+  code: string,
+): Promise<void> {
+  const config = context.services.config;
+  context.ui.addItem(
+    {
+      type: MessageType.INFO,
+      text: `In [*] (${kernel.kernelName}):\n${code.trimEnd()}`,
+    },
+    Date.now(),
+  );
+  let result: KernelExecutionResult;
+  try {
+    result = await kernel.execute(code, context.abortSignal);
+  } catch (e) {
+    context.ui.addItem(
+      { type: MessageType.ERROR, text: getErrorMessage(e) },
+      Date.now(),
+    );
+    return;
+  }
+  const images = config ? saveImages(config, result) : new Map();
+  const text = formatExecutionResult(result, images);
+  if (text) {
+    context.ui.addItem({ type: MessageType.INFO, text }, Date.now());
+  }
+  for (const output of result.outputs) {
+    if (output.type === 'error') {
+      context.ui.addItem(
+        { type: MessageType.ERROR, text: output.text },
+        Date.now(),
+      );
+    }
+  }
+}
+
+async function attach(
+  context: CommandContext,
+  open: () => Promise<JupyterKernelSession>,
+): Promise<SlashCommandActionReturn> {
+  const previous = getActiveKernel();
+  if (previous) {
+    return error(
+      `A ${previous.kernelName} kernel is already running. Use /kernel stop first.`,
+    );
+  }
+  context.ui.setDebugMessage('Starting Jupyter kernel...');
+  try {
+    const kernel = await open();
+    setActiveKernel(kernel);
+    registerCleanup(() => kernel.dispose());
+    return info(
+      `Started ${kernel.kernelName} kernel on ${kernel.baseUrl}. Run code blocks from the chat with /kernel run [n], or code with /kernel exec <code>.`,
+    );
+  } catch (e) {
+    return error(getErrorMessage(e));
+  }
+}
+
+export const kernelCommand: SlashCommand = {
+  name: 'kernel',
+  description: 'Run code from the chat in a Jupyter kernel.',
+  subCommands: [
+    {
+      name: 'start',
+      description: `Start a kernel via a local jupyter server. Usage: /kernel start [name] (default ${DEFAULT_KERNEL})`,
+      action: async (context, args) => {
+        const config = context.services.config;
+        const name = args.trim() || DEFAULT_KERNEL;
+        return attach(context, () =>
+          JupyterKernelSession.start(name, {
+            cwd: config?.getTargetDir() ?? process.cwd(),
+          }),
+        );
+      },
+    },
+    {
+      name: 'connect',
+      description:
+        'Start a kernel on a running Jupyter server. Usage: /kernel connect <url> [token] [name]',
+      action: async (context, args) => {
+        const [url, token, name] = args.trim().split(/\s+/).filter(Boolean);
+        if (!url) {
+          return error('Usage: /kernel connect <url> [token] [name]');
+        }
+        return attach(context, () =>
+          JupyterKernelSession.connect(
+            url,
+            token ?? process.env.JUPYTER_TOKEN ?? '',
+            name ?? DEFAULT_KERNEL,
+          ),
+        );
+      },
+    },
+    {
+      name: 'run',
+      description:
+        'Run the code blocks of the latest response, or only block n. Usage: /kernel run [n]',
+      action: async (context, args) => {
+        const kernel = getActiveKernel();
+        if (!kernel) {
+          return error(NO_KERNEL);
+        }
+        const config = context.services.config;
+        const blocks = config ? getLatestCodeBlocks(config) : [];
+        if (blocks.length === 0) {
+          return error('The conversation has no code blocks to run.');
+        }
+        const trimmed = args.trim();
+        let selected = blocks;
+        if (trimmed) {
+          const n = Number(trimmed);
+          if (!Number.isInteger(n) || n < 1 || n > blocks.length) {
+            return error(
+              `Usage: /kernel run [n], where n is between 1 and ${blocks.length}.`,
+            );
+          }
+          selected = [blocks[n - 1]];
+        }
+        for (const block of selected) {
+          if (context.abortSignal.aborted) {
+            break;
+          }
+          await runCode(context, kernel, block.code);
+        }
+        return;
+      },
+    },
+    {
+      name: 'exec',
+      description: 'Run code in the kernel. Usage: /kernel exec <code>',
+      action: async (context, args) => {
+        const kernel = getActiveKernel();
+        if (!kernel) {
+          return error(NO_KERNEL);
+        }
+        if (!args.trim()) {
+          return error('Usage: /kernel exec <code>');
+        }
+        await runCode(context, kernel, args);
+        return;
+      },
+    },
+    {
+      name: 'status',
+      description: 'Show the running kernel.',
+      action: () => {
+        const kernel = getActiveKernel();
+        return kernel
+          ? info(
+              `${kernel.kernelName} kernel ${kernel.kernelId} on ${kernel.baseUrl}`,
+            )
+          : info(NO_KERNEL);
+      },
+    },
+    {
+      name: 'stop',
+      description: 'Shut down the running kernel.',
+      action: async () => {
+        const kernel = getActiveKernel();
+        if (!kernel) {
+          return info(NO_KERNEL);
+        }
+        setActiveKernel(undefined);
+        try {
+          await kernel.shutdown();
+        } catch (e) {
+          return error(`Kernel stopped with an error: ${getErrorMessage(e)}`);
+        }
+        return info(`Stopped the ${kernel.kernelName} kernel.`);
+      },
+    },
+  ],
+};
@@ -0,0 +1,124 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import {
+  LatexProject,
+  LatexWarning,
+  checkLatexProject,
+  getErrorMessage,
+  getLatexConventions,
+  parseLatexProject,
+} from '@iechor/research-cli-core';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import { MessageType } from '../types.js';
+
+const NO_MANUSCRIPT =
+  'No LaTeX manuscript found: no .tex file in the project root has a \\documentclass.';
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+/** Parses the manuscript in the project root, if there is one. */
+export async function loadLatexProject(
+  context: CommandContext,
+): Promise<LatexProject | undefined> {
+  return parseLatexProject(
+    context.services.config?.getTargetDir() ?? process.cwd(),
+  );
+}
+
+function formatWarnings(warnings: LatexWarning[]): string {
+  return warnings
+    .map((warning) => `${warning.file}:${warning.line}  ${warning.message}`)
+    .join('\n');
+}
+
+function formatOverview(project: LatexProject): string {
+  const conventions = getLatexConventions(project);
+  const minLevel = Math.min(...project.sections.map((s) => s.level));
+  return [
+    `${project.mainFile}: ${project.files.length} files, ${project.sections.length} sections, ${project.labels.length} labels, ${new Set(project.citations.map((c) => c.key)).size} cited keys`,
+    ...(project.bibFiles.length > 0
+      ? [
+          `Bibliography: ${project.bibFiles.join(', ')}${project.bibKeys ? ` (${project.bibKeys.length} entries)` : ' (not found)'}`,
+        ]
+      : []),
+    `Conventions: \\${conventions.citeCommand}, \\${conventions.refCommand}${conventions.labelPrefixes.length > 0 ? `, labels ${conventions.labelPrefixes.join(' ')}` : ''}`,
+    '',
+    ...project.sections.map(
+      (section) =>
+        `${'  '.repeat(section.level - minLevel)}${section.title}${section.label ? `  (${section.label})` : ''}`,
+    ),
+  ].join('\n');
+}
+
+export const latexCommand: SlashCommand = {
+  name: 'latex',
+  description:
+    'Show the structure of the LaTeX manuscript and check its references. Usage: /latex [check]',
+  action: async (context: CommandContext) => {
+    let project: LatexProject | undefined;
+    try {
+      project = await loadLatexProject(context);
+    } catch (e) {
+      return error(`Could not read the manuscript: ${getErrorMessage(e)}`);
+    }
+    if (!project) {
+      return info(NO_MANUSCRIPT);
+    }
+    const warnings = checkLatexProject(project);
+    context.ui.addItem(
+      {
+        type: MessageType.INFO,
+        text: [
+          formatOverview(project),
+          '',
+          warnings.length > 0
+            ? `${warnings.length} warnings; list them with /latex check.`
+            : 'No undefined references or citations.',
+        ].join('\n'),
+      },
+      Date.now(),
+    );
+  },
+  subCommands: [
+    {
+      name: 'check',
+      description:
+        'List undefined references and citations, duplicate labels and missing included files. Usage: /latex check',
+      action: async (context: CommandContext) => {
+        let project: LatexProject | undefined;
+        try {
+          project = await loadLatexProject(context);
+        } catch (e) {
+          return error(`Could not read the manuscript: ${getErrorMessage(e)}`);
+        }
+        if (!project) {
+          return info(NO_MANUSCRIPT);
+        }
+        const warnings = checkLatexProject(project);
+        if (warnings.length === 0) {
+          return info(
+            `No undefined references or citations in ${project.files.length} files.`,
+          );
+        }
+        context.ui.addItem(
+          { type: MessageType.INFO, text: formatWarnings(warnings) },
+          Date.now(),
+        );
+      },
+    },
+  ],
+};
@@ -0,0 +1,107 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, vi } from 'vitest';
+import { Content } from '@google/genai';
+import { Config, MailSettings, sendMail } from '@iechor/research-cli-core';
+import { LoadedSettings } from '../../config/settings.js';
+import { mailCommand } from './mailCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+
+vi.mock('@iechor/research-cli-core', async (importOriginal) => {
+  const actual =
+    await importOriginal<typeof import('@iechor/research-cli-core')>();
+  return { ...actual, sendMail: vi.fn() };
+});
+
+describe('mailCommand', () => {
+  const mockSendMail = vi.mocked(sendMail);
+  const history: Content[] = [
+    { role: 'user', parts: [{ text: 'How accurate is the model?' }] },
+    { role: 'model', parts: [{ text: 'Accuracy is 91.2%.' }] },
+  ];
+
+  const context = (mail?: MailSettings) =>
+    createMockCommandContext({
+      services: {
+        config: {
+          getModel: () => 'test-model',
+          getResearchClient: () => ({
+            getChat: async () => ({ getHistory: () => history }),
+          }),
+        } as unknown as Config,
+        settings: { merged: { mail } } as unknown as LoadedSettings,
+      },
+    });
+
+  const subCommand = (name: string) =>
+    mailCommand.subCommands!.find((command) => command.name === name)!;
+
+  beforeEach(() => {
+    mockSendMail.mockReset();
+    mockSendMail.mockResolvedValue(undefined);
+  });
+
+  it('should send a test mail to the configured recipients', async () => {
+    const result = await subCommand('test').action!(
+      context({ from: 'me@lab.org', to: ['lab@lab.org'] }),
+      '',
+    );
+
+    expect(result).toEqual({
+      type: 'message',
+      messageType: 'info',
+      content: 'Sent "Research CLI test mail" to lab@lab.org.',
+    });
+    expect(mockSendMail).toHaveBeenCalledWith(
+      { from: 'me@lab.org', to: ['lab@lab.org'] },
+      expect.objectContaining({ to: ['lab@lab.org'] }),
+    );
+  });
+
+  it('should attach the conversation as HTML and honour --to', async () => {
+    await subCommand('export').action!(
+      context({ from: 'me@lab.org', to: ['lab@lab.org'] }),
+      '--to ada@lab.org,bob@lab.org For the meeting',
+    );
+
+    const message = mockSendMail.mock.calls[0][1];
+    expect(message.to).toEqual(['ada@lab.org', 'bob@lab.org']);
+    expect(message.text).toMatch(/^For the meeting\n\n/);
+    expect(message.attachments).toHaveLength(1);
+    expect(message.attachments![0].filename).toMatch(/\.html$/);
+    expect(message.attachments![0].content).toContain('Accuracy is 91.2%.');
+  });
+
+  it('should report missing settings and recipients', async () => {
+    expect(await subCommand('test').action!(context(), '')).toMatchObject({
+      messageType: 'error',
+      content: expect.stringContaining('Mail is not configured.'),
+    });
+    expect(
+      await subCommand('test').action!(context({ from: 'me@lab.org' }), ''),
+    ).toMatchObject({
+      messageType: 'error',
+      content: expect.stringContaining('No recipients.'),
+    });
+    expect(mockSendMail).not.toHaveBeenCalled();
+  });
+
+  it('should report delivery failures', async () => {
+    mockSendMail.mockRejectedValue(new Error('connection refused'));
+
+    expect(
+      await subCommand('test').action!(
+        context({ from: 'me@lab.org', to: ['lab@lab.org'] }),
+        '',
+      ),
+    ).toEqual({
+      type: 'message',
+      messageType: 'error',
+      content: 'Could not send the mail: connection refused',
+    });
+  });
+});
@@ -0,0 +1,161 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import {
+  MailMessage,
+  MailSettings,
+  getErrorMessage,
+  getRedactor,
+  sendMail,
+} from '@iechor/research-cli-core';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import {
+  defaultExportFileName,
+  renderConversationExport,
+} from './exportCommand.js';
+import { summarizeConversation } from './sendToCommand.js';
+
+const NOT_CONFIGURED =
+  'Mail is not configured. Add a "mail" block with "from", "to" and, to use an SMTP server instead of the local sendmail, "smtp" to your settings.json.';
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+/** Splits "--to a@x,b@y rest" into recipients and the rest. */
+function parseRecipients(
+  args: string,
+  settings: MailSettings,
+): { to: string[]; rest: string } {
+  const match = args.match(/(?:^|\s)--to\s+(\S+)/);
+  return {
+    to: match
+      ? match[1].split(',').filter(Boolean)
+      : (settings.to ?? []).filter(Boolean),
+    rest: args.replace(/(?:^|\s)--to\s+\S+/, '').trim(),
+  };
+}
+
+/**
+ * Runs a mail subcommand: checks the settings and recipients, builds the
+ * message and sends it, redacting text that is not already redacted.
+ */
+async function mail(
+  context: CommandContext,
+  args: string,
+  build: (rest: string) => Promise<Omit<MailMessage, 'to'> | string>,
+): Promise<SlashCommandActionReturn> {
+  const settings = context.services.settings.merged.mail;
+  if (!settings?.from) {
+    return error(NOT_CONFIGURED);
+  }
+  const { to, rest } = parseRecipients(args, settings);
+  if (to.length === 0) {
+    return error(
+      'No recipients. Set "to" in the mail settings or pass --to <address,...>.',
+    );
+  }
+
+  let message: Omit<MailMessage, 'to'> | string;
+  try {
+    message = await build(rest);
+  } catch (e) {
+    return error(`Could not prepare the mail: ${getErrorMessage(e)}`);
+  }
+  if (typeof message === 'string') {
+    return error(message);
+  }
+  const { value: text, matches } = getRedactor().redactText(message.text, true);
+  try {
+    context.ui.setDebugMessage(`Sending mail to ${to.join(', ')}...`);
+    await sendMail(settings, { ...message, text, to });
+  } catch (e) {
+    return error(`Could not send the mail: ${getErrorMessage(e)}`);
+  }
+  return info(
+    `Sent "${message.subject}" to ${to.join(', ')}.` +
+      (matches.length > 0 ? ` ${matches.length} value(s) were redacted.` : ''),
+  );
+}
+
+const today = () => new Date().toISOString().slice(0, 10);
+
+export const mailCommand: SlashCommand = {
+  name: 'mail',
+  description:
+    'Email a session summary or an HTML export of the conversation through SMTP or sendmail.',
+  subCommands: [
+    {
+      name: 'summary',
+      description:
+        'Mail a summary of the conversation written by the model. Usage: /mail summary [--to <address,...>] [note]',
+      action: (context, args) =>
+        mail(context, args, async (note) => {
+          const config = context.services.config;
+          const chat = await config?.getResearchClient()?.getChat();
+          const history = chat?.getHistory(true) ?? [];
+          if (!config || history.length === 0) {
+            return 'The conversation is empty.';
+          }
+          context.ui.setDebugMessage('Summarizing the conversation...');
+          const summary = await summarizeConversation(
+            config,
+            history,
+            context.abortSignal,
+          );
+          return {
+            subject: `Research session summary, ${today()}`,
+            text: note ? `${note}\n\n${summary}` : summary,
+          };
+        }),
+    },
+    {
+      name: 'export',
+      description:
+        'Mail the conversation as an attached HTML file, with its tool traces. Usage: /mail export [--to <address,...>] [note]',
+      action: (context, args) =>
+        mail(context, args, async (note) => {
+          const config = context.services.config;
+          const exportedAt = new Date();
+          const rendered = config
+            ? await renderConversationExport(config, { exportedAt })
+            : undefined;
+          if (!rendered) {
+            return 'The conversation is empty.';
+          }
+          return {
+            subject: `Research session export, ${today()}`,
+            text: `${note ? `${note}\n\n` : ''}The conversation (${rendered.messageCount} messages) is attached as an HTML file. Open it in a browser; tool calls are in collapsible sections.`,
+            attachments: [
+              {
+                filename: defaultExportFileName(exportedAt),
+                content: rendered.html,
+                contentType: 'text/html',
+              },
+            ],
+          };
+        }),
+    },
+    {
+      name: 'test',
+      description:
+        'Send a test mail to check the settings. Usage: /mail test [--to <address,...>]',
+      action: (context, args) =>
+        mail(context, args, async () => ({
+          subject: 'Research CLI test mail',
+          text: 'This is a test mail from the Research CLI. Mail is set up correctly.',
+        })),
+    },
+  ],
+};
@@ -9,7 +9,12 @@ import { memoryCommand } from './memoryCommand.js';
 import { type CommandContext, SlashCommand } from './types.js';
 import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
 import { MessageType } from '../types.js';
-import { getErrorMessage } from '@iechor/research-cli-core';
+import {
+  addMemoryEntry,
+  getErrorMessage,
+  readMemoryEntries,
+  updateMemoryEntry,
+} from '@iechor/research-cli-core';
 
 vi.mock('@iechor/research-cli-core', async (importOriginal) => {
   const original =
@@ -20,13 +25,18 @@ vi.mock('@iechor/research-cli-core', async (importOriginal) => {
       if (error instanceof Error) return error.message;
       return String(error);
     }),
+    addMemoryEntry: vi.fn(),
+    readMemoryEntries: vi.fn(),
+    updateMemoryEntry: vi.fn(),
   };
 });
 
 describe('memoryCommand', () => {
   let mockContext: CommandContext;
 
-  const getSubCommand = (name: 'show' | 'add' | 'refresh'): SlashCommand => {
+  const getSubCommand = (
+    name: 'show' | 'add' | 'list' | 'edit' | 'delete' | 'refresh',
+  ): SlashCommand => {
     const subCommand = memoryCommand.subCommands?.find(
       (cmd) => cmd.name === name,
     );
@@ -96,16 +106,25 @@ describe('memoryCommand', () => {
 
   describe('/memory add', () => {
     let addCommand: SlashCommand;
+    let mockRefreshMemory: Mock;
 
     beforeEach(() => {
       addCommand = getSubCommand('add');
-      mockContext = createMockCommandContext();
+      mockRefreshMemory = vi.fn();
+      mockContext = createMockCommandContext({
+        services: {
+          config: {
+            refreshMemory: mockRefreshMemory,
+            // eslint-disable-next-line @typescript-eslint/no-explicit-any
+          } as any,
+        },
+      });
     });
 
-    it('should return an error message if no arguments are provided', () => {
+    it('should return an error message if no arguments are provided', async () => {
       if (!addCommand.action) throw new Error('Command has no action');
 
-      const result = addCommand.action(mockContext, '  ');
+      const result = await addCommand.action(mockContext, '  ');
       expect(result).toEqual({
         type: 'message',
         messageType: 'error',
@@ -115,26 +134,65 @@ describe('memoryCommand', () => {
       expect(mockContext.ui.addItem).not.toHaveBeenCalled();
     });
 
-    it('should return a tool action and add an info message when arguments are provided', () => {
+    it('should save the fact without asking and reload the memory', async () => {
       if (!addCommand.action) throw new Error('Command has no action');
 
       const fact = 'remember this';
-      const result = addCommand.action(mockContext, `  ${fact}  `);
+      const result = await addCommand.action(mockContext, `  ${fact}  `);
 
-      expect(mockContext.ui.addItem).toHaveBeenCalledWith(
-        {
-          type: MessageType.INFO,
-          text: `Attempting to save to memory: "${fact}"`,
+      expect(addMemoryEntry).toHaveBeenCalledWith(fact);
+      expect(mockRefreshMemory).toHaveBeenCalled();
+      expect(result).toEqual({
+        type: 'message',
+        messageType: 'info',
+        content: `Remembered: "${fact}"`,
+      });
+    });
+  });
+
+  describe('/memory list, edit and delete', () => {
+    beforeEach(() => {
+      mockContext = createMockCommandContext({
+        services: {
+          // eslint-disable-next-line @typescript-eslint/no-explicit-any
+          config: { refreshMemory: vi.fn() } as any,
         },
-        expect.any(Number),
-      );
+      });
+    });
 
-      expect(result).toEqual({
-        type: 'tool',
-        toolName: 'save_memory',
-        toolArgs: { fact },
+    it('should list the saved facts with their numbers', async () => {
+      vi.mocked(readMemoryEntries).mockResolvedValue([
+        'My advisor is Dr. Lee',
+        'Our cluster uses SLURM',
+      ]);
+
+      const result = await getSubCommand('list').action!(mockContext, '');
+
+      expect(result).toMatchObject({
+        content: expect.stringContaining(
+          '  1. My advisor is Dr. Lee\n  2. Our cluster uses SLURM',
+        ),
       });
     });
+
+    it('should edit and delete facts by number', async () => {
+      vi.mocked(updateMemoryEntry).mockResolvedValue('Our cluster uses SLURM');
+
+      const edit = getSubCommand('edit').action!;
+      expect(
+        await edit(mockContext, '2 Our cluster uses PBS'),
+      ).toMatchObject({ content: 'Memory #2 is now: "Our cluster uses PBS"' });
+      expect(updateMemoryEntry).toHaveBeenCalledWith(1, 'Our cluster uses PBS');
+
+      expect(
+        await getSubCommand('delete').action!(mockContext, '2'),
+      ).toMatchObject({ content: 'Forgot: "Our cluster uses SLURM"' });
+      expect(updateMemoryEntry).toHaveBeenLastCalledWith(1, undefined);
+
+      expect(
+        await getSubCommand('delete').action!(mockContext, '0'),
+      ).toMatchObject({ messageType: 'error' });
+    });
   });
 
   describe('/memory refresh', () => {
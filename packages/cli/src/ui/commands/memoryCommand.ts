@@ -4,13 +4,55 @@
  * SPDX-License-Identifier: Apache-2.0
  */
 
-import { getErrorMessage } from '@iechor/research-cli-core';
+import {
+  addMemoryEntry,
+  getErrorMessage,
+  MemoryTool,
+  readMemoryEntries,
+  recordUsage,
+  updateMemoryEntry,
+} from '@iechor/research-cli-core';
 import { MessageType } from '../types.js';
-import { SlashCommand, SlashCommandActionReturn } from './types.js';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+/** Reloads the memory so the change reaches the next prompts. */
+async function saved(
+  context: CommandContext,
+  message: string,
+): Promise<SlashCommandActionReturn> {
+  try {
+    await context.services.config?.refreshMemory();
+  } catch (e) {
+    return error(
+      `${message} Reloading the memory failed: ${getErrorMessage(e)}`,
+    );
+  }
+  return info(message);
+}
+
+/** Parses the 1-based number of a saved fact; undefined when malformed. */
+function parseEntry(arg: string | undefined): number | undefined {
+  return arg && /^\d+$/.test(arg) && Number(arg) > 0
+    ? Number(arg) - 1
+    : undefined;
+}
 
 export const memoryCommand: SlashCommand = {
   name: 'memory',
-  description: 'Commands for interacting with memory.',
+  description:
+    'Manage the facts remembered across sessions and the memory loaded from RESEARCH.md files.',
   subCommands: [
     {
       name: 'show',
@@ -36,29 +78,77 @@ export const memoryCommand: SlashCommand = {
     },
     {
       name: 'add',
-      description: 'Add content to the memory.',
-      action: (context, args): SlashCommandActionReturn | void => {
-        if (!args || args.trim() === '') {
-          return {
-            type: 'message',
-            messageType: 'error',
-            content: 'Usage: /memory add <text to remember>',
-          };
+      description:
+        'Save a fact to remember in all future sessions, e.g. "our cluster uses SLURM".',
+      action: async (context, args) => {
+        const fact = args?.trim() ?? '';
+        if (fact === '') {
+          return error('Usage: /memory add <text to remember>');
         }
-
-        context.ui.addItem(
-          {
-            type: MessageType.INFO,
-            text: `Attempting to save to memory: "${args.trim()}"`,
-          },
-          Date.now(),
+        try {
+          await addMemoryEntry(fact);
+        } catch (e) {
+          return error(getErrorMessage(e));
+        }
+        // Counts as a note on /dashboard heatmap, like the model's saves
+        recordUsage({
+          kind: 'tool',
+          timestamp: new Date().toISOString(),
+          tool: MemoryTool.Name,
+          durationMs: 0,
+          success: true,
+        });
+        return saved(context, `Remembered: "${fact}"`);
+      },
+    },
+    {
+      name: 'list',
+      description: 'List the saved facts with their numbers.',
+      action: async () => {
+        const entries = await readMemoryEntries();
+        return info(
+          entries.length > 0
+            ? [
+                'Saved memories:',
+                ...entries.map((entry, i) => `  ${i + 1}. ${entry}`),
+                'Change them with /memory edit <n> <text> or /memory delete <n>.',
+              ].join('\n')
+            : 'No facts are saved yet. Add one with /memory add <text>.',
         );
-
-        return {
-          type: 'tool',
-          toolName: 'save_memory',
-          toolArgs: { fact: args.trim() },
-        };
+      },
+    },
+    {
+      name: 'edit',
+      description: 'Replace a saved fact. Usage: /memory edit <n> <text>',
+      action: async (context, args) => {
+        const [, number, fact] = args.trim().match(/^(\S+)\s+(.+)$/s) ?? [];
+        const index = parseEntry(number);
+        if (index === undefined || !fact) {
+          return error('Usage: /memory edit <n> <text>. See /memory list.');
+        }
+        try {
+          await updateMemoryEntry(index, fact);
+        } catch (e) {
+          return error(getErrorMessage(e));
+        }
+        return saved(context, `Memory #${index + 1} is now: "${fact.trim()}"`);
+      },
+    },
+    {
+      name: 'delete',
+      description: 'Forget a saved fact. Usage: /memory delete <n>',
+      action: async (context, args) => {
+        const index = parseEntry(args.trim());
+        if (index === undefined) {
+          return error('Usage: /memory delete <n>. See /memory list.');
+        }
+        let previous: string;
+        try {
+          previous = await updateMemoryEntry(index, undefined);
+        } catch (e) {
+          return error(getErrorMessage(e));
+        }
+        return saved(context, `Forgot: "${previous}"`);
       },
     },
     {
@@ -20,12 +20,15 @@ interface ModelConfig {
       apiKey?: string;
       baseUrl?: string;
       defaultModel?: string;
+      headers?: Record<string, string>;
+      modelAliases?: Record<string, string>;
+      apiVersion?: string;
     }
   >;
 }
 
 // 读取配置文件
-function readConfig(): ModelConfig {
+export function readConfig(): ModelConfig {
   try {
     if (fs.existsSync(CONFIG_FILE)) {
       const content = fs.readFileSync(CONFIG_FILE, 'utf-8');
@@ -53,7 +56,7 @@ function writeConfig(config: ModelConfig): void {
 }
 
 // 获取API key（优先从配置文件，然后是环境变量）
-function getApiKey(provider: string): string | undefined {
+export function getApiKey(provider: string): string | undefined {
   const config = readConfig();
   const providerConfig = config.providers[provider.toLowerCase()];
 
@@ -79,6 +82,8 @@ function getApiKey(provider: string): string | undefined {
     perplexity: 'PERPLEXITY_API_KEY',
     baidu: 'BAIDU_LLM_KEY',
     moonshot: 'MOONSHOT_API_KEY',
+    azure_openai: 'AZURE_OPENAI_API_KEY',
+    openai_compatible: 'OPENAI_COMPATIBLE_API_KEY',
   };
 
   const envVar = envVarMapping[provider.toLowerCase()];
@@ -131,7 +136,8 @@ export const modelCommand: SlashCommand = {
           if (provider && !providers.includes(provider)) {
             // 这个提供商在配置文件中但不在manager中，手动添加
             const apiKey = getApiKey(providerName);
-            if (apiKey) {
+            const entry = config.providers[providerName];
+            if (apiKey || entry?.baseUrl) {
               // 使用默认模型映射
               const defaultModels: Record<string, string> = {
                 'openai': 'gpt-4o-mini',
@@ -152,12 +158,20 @@ export const modelCommand: SlashCommand = {
                 'vertex_ai': 'gemini-1.5-flash',
                 'baidu': 'ernie-4.5-turbo-128k',
                 'moonshot': 'kimi-k2-0711-preview',
+                'azure_openai': 'azure/gpt-4o',
+                'openai_compatible': 'compat/default',
               };
 
               const providerConfig: any = {
                 provider,
-                model: defaultModels[providerName] || '',
+                model: entry?.defaultModel || defaultModels[providerName] || '',
                 apiKey,
+                baseUrl: entry?.baseUrl,
+                extra: {
+                  headers: entry?.headers,
+                  modelAliases: entry?.modelAliases,
+                  apiVersion: entry?.apiVersion,
+                },
                 temperature: 0.7,
                 maxTokens: 2048,
                 topP: 1.0,
@@ -181,12 +195,20 @@ export const modelCommand: SlashCommand = {
   /model config list - List all configured providers
   /model config remove <provider> - Remove API key for a provider
   /model config show - Show configuration file location
+  /model config endpoint <provider> <base-url> - Set a custom base URL
+  /model config header <provider> <name> <value> - Add a request header
+  /model config alias <provider> <alias> <model-or-deployment> - Add a model alias
+  /model config api-version <provider> <version> - Set the Azure OpenAI api-version
 
 Examples:
   /model config set openai sk-your-key-here
   /model config set deepseek your-deepseek-key-here
   /model config get openai
-  /model config list`,
+  /model config list
+  /model config endpoint azure_openai https://my-resource.openai.azure.com
+  /model config alias azure_openai gpt-4o my-gpt4o-deployment
+  /model config endpoint openai_compatible http://localhost:8000/v1
+  /model config header openai_compatible HTTP-Referer https://example.org`,
             };
           }
 
@@ -323,6 +345,121 @@ Examples:
               };
             }
 
+            case 'endpoint': {
+              if (argsArray.length < 4) {
+                return {
+                  type: 'message',
+                  messageType: 'error',
+                  content:
+                    'Usage: /model config endpoint <provider> <base-url>\nExample: /model config endpoint openai_compatible http://localhost:8000/v1',
+                };
+              }
+
+              const [, , provider, baseUrl] = argsArray;
+              try {
+                new URL(baseUrl);
+              } catch (_e) {
+                return {
+                  type: 'message',
+                  messageType: 'error',
+                  content: `Invalid URL: ${baseUrl}`,
+                };
+              }
+
+              const config = readConfig();
+              const key = provider.toLowerCase();
+              config.providers[key] = { ...config.providers[key], baseUrl };
+              writeConfig(config);
+
+              return {
+                type: 'message',
+                messageType: 'info',
+                content: `✅ Base URL for ${provider} set to ${baseUrl}`,
+              };
+            }
+
+            case 'header': {
+              if (argsArray.length < 5) {
+                return {
+                  type: 'message',
+                  messageType: 'error',
+                  content:
+                    'Usage: /model config header <provider> <name> <value>\nExample: /model config header openai_compatible X-Title research-cli',
+                };
+              }
+
+              const [, , provider, headerName, ...valueParts] = argsArray;
+              const config = readConfig();
+              const key = provider.toLowerCase();
+              const entry = config.providers[key] || {};
+              entry.headers = {
+                ...entry.headers,
+                [headerName]: valueParts.join(' '),
+              };
+              config.providers[key] = entry;
+              writeConfig(config);
+
+              return {
+                type: 'message',
+                messageType: 'info',
+                content: `✅ Header ${headerName} will be sent with ${provider} requests.`,
+              };
+            }
+
+            case 'alias': {
+              if (argsArray.length < 5) {
+                return {
+                  type: 'message',
+                  messageType: 'error',
+                  content:
+                    'Usage: /model config alias <provider> <alias> <model-or-deployment>\nExample: /model config alias azure_openai gpt-4o my-gpt4o-deployment',
+                };
+              }
+
+              const [, , provider, alias, target] = argsArray;
+              const config = readConfig();
+              const key = provider.toLowerCase();
+              const entry = config.providers[key] || {};
+              entry.modelAliases = { ...entry.modelAliases, [alias]: target };
+              config.providers[key] = entry;
+              writeConfig(config);
+
+              const prefix =
+                key === 'azure_openai'
+                  ? 'azure/'
+                  : key === 'openai_compatible'
+                    ? 'compat/'
+                    : '';
+              return {
+                type: 'message',
+                messageType: 'info',
+                content: `✅ ${prefix}${alias} now maps to ${target} on ${provider}.`,
+              };
+            }
+
+            case 'api-version': {
+              if (argsArray.length < 4) {
+                return {
+                  type: 'message',
+                  messageType: 'error',
+                  content:
+                    'Usage: /model config api-version <provider> <version>\nExample: /model config api-version azure_openai 2024-06-01',
+                };
+              }
+
+              const [, , provider, apiVersion] = argsArray;
+              const config = readConfig();
+              const key = provider.toLowerCase();
+              config.providers[key] = { ...config.providers[key], apiVersion };
+              writeConfig(config);
+
+              return {
+                type: 'message',
+                messageType: 'info',
+                content: `✅ api-version for ${provider} set to ${apiVersion}`,
+              };
+            }
+
             default: {
               return {
                 type: 'message',
@@ -442,10 +579,15 @@ Examples:
 
               config.setModel(modelName);
 
+              const warnings = core
+                .checkWorkflowSupport(modelId, ['tools'])
+                .map((warning: string) => `\n⚠️  ${warning}`)
+                .join('');
+
               return {
                 type: 'message',
                 messageType: 'info',
-                content: `Successfully switched to ${selectedModel.name} (${provider}/${modelId})\nCore model updated to: ${modelName}`,
+                content: `Successfully switched to ${selectedModel.name} (${provider}/${modelId})\nCore model updated to: ${modelName}${warnings}`,
               };
             } else {
               return {
@@ -488,6 +630,7 @@ Examples:
   /model current - Show current model
   /model providers - List model providers
   /model select <provider> <model-id> - Select a model
+  /models - Browse the model catalog by capability, context and price
   /model config - Manage API keys and provider configuration
   /model help - Show this help
 
@@ -496,6 +639,8 @@ Examples:
   /model select anthropic claude-3-5-sonnet-20241022
   /model select deepseek deepseek-chat
   /model select gemini gemini-1.5-pro
+  /model select azure_openai azure/gpt-4o
+  /model select openai_compatible compat/meta-llama/Llama-3.1-8B-Instruct
   /model config set openai sk-your-key-here
 
 Configuration:
@@ -0,0 +1,277 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import {
+  ModelProvider,
+  ModelCatalogEntry,
+  ModelWorkflow,
+  filterModelCatalog,
+  findCatalogEntry,
+  checkWorkflowSupport,
+} from '@iechor/research-cli-core';
+import { SlashCommand, SlashCommandActionReturn } from '../types.js';
+import {
+  parseCommandArgs,
+  getOptionValue,
+} from '../research/utils/commandParser.js';
+import { formatTable } from '../research/utils/outputFormatter.js';
+import { getApiKey } from './index.js';
+
+const WORKFLOWS: ModelWorkflow[] = ['vision', 'tools', 'audio'];
+
+const HELP_TEXT = `Model Catalog Commands:
+  /models [search] [filters] - List known models with capabilities and pricing
+  /models info <model-id> - Show details for a model
+  /models use <model-id> [--for vision,tools] - Switch to a model and check it supports your workflow
+
+Filters:
+  --provider <name>       Only show one provider (e.g. openai, deepseek)
+  --supports <list>       Required capabilities: ${WORKFLOWS.join(', ')}
+  --min-context <tokens>  Minimum context window, e.g. 128k or 1m
+  --max-price <usd>       Maximum input price per 1M tokens
+
+Examples:
+  /models --supports vision,tools
+  /models claude --min-context 200k
+  /models use deepseek-reasoner --for tools`;
+
+/**
+ * 解析 128k / 1m 形式的 token 数
+ */
+function parseTokenCount(value: string): number | undefined {
+  const match = value.trim().toLowerCase().match(/^(\d+(?:\.\d+)?)([km]?)$/);
+  if (!match) {
+    return undefined;
+  }
+  const multiplier =
+    match[2] === 'm' ? 1_000_000 : match[2] === 'k' ? 1000 : 1;
+  return Math.round(Number(match[1]) * multiplier);
+}
+
+function parseWorkflows(value: string | boolean): ModelWorkflow[] | string {
+  if (typeof value !== 'string') {
+    return `Expected a comma-separated list of: ${WORKFLOWS.join(', ')}`;
+  }
+  const workflows = value
+    .split(',')
+    .map((w) => w.trim().toLowerCase())
+    .filter((w) => w.length > 0);
+  const invalid = workflows.filter(
+    (w) => !WORKFLOWS.includes(w as ModelWorkflow),
+  );
+  if (invalid.length > 0) {
+    return `Unknown capability: ${invalid.join(', ')}. Valid values: ${WORKFLOWS.join(', ')}`;
+  }
+  return workflows as ModelWorkflow[];
+}
+
+function formatContext(tokens: number): string {
+  return tokens >= 1_000_000
+    ? `${(tokens / 1_000_000).toFixed(tokens % 1_000_000 === 0 ? 0 : 1)}M`
+    : `${Math.round(tokens / 1000)}K`;
+}
+
+function formatPricing(entry: ModelCatalogEntry): string {
+  if (!entry.pricing) {
+    return '-';
+  }
+  const symbol = entry.pricing.currency === 'CNY' ? '¥' : '$';
+  return `${symbol}${entry.pricing.input} / ${symbol}${entry.pricing.output}`;
+}
+
+function formatCapabilities(entry: ModelCatalogEntry): string {
+  const caps: string[] = [];
+  if (entry.modalities.includes('image')) caps.push('vision');
+  if (entry.modalities.includes('audio')) caps.push('audio');
+  if (entry.toolCalling) caps.push('tools');
+  if (entry.reasoning) caps.push('reasoning');
+  return caps.join(', ');
+}
+
+function isProviderConfigured(provider: ModelProvider): boolean {
+  return !!getApiKey(provider);
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+export const modelsCommand: SlashCommand = {
+  name: 'models',
+  description: 'Browse the model catalog and filter by capability',
+  action: async (context, args) => {
+    const { positional, options } = parseCommandArgs(args);
+    const subCommand = positional[0];
+
+    if (subCommand === 'help' || options.help) {
+      return { type: 'message', messageType: 'info', content: HELP_TEXT };
+    }
+
+    if (subCommand === 'info') {
+      const modelId = positional[1];
+      if (!modelId) {
+        return error('Usage: /models info <model-id>');
+      }
+      const entry = findCatalogEntry(modelId);
+      if (!entry) {
+        return error(
+          `Model not in catalog: ${modelId}\nUse '/models' to browse known models`,
+        );
+      }
+      const lines = [
+        `${entry.name} (${entry.id})`,
+        `  Provider:       ${entry.provider}${isProviderConfigured(entry.provider) ? ' (configured)' : ''}`,
+        `  Context window: ${entry.contextWindow.toLocaleString()} tokens`,
+      ];
+      if (entry.maxOutputTokens) {
+        lines.push(
+          `  Max output:     ${entry.maxOutputTokens.toLocaleString()} tokens`,
+        );
+      }
+      lines.push(
+        `  Input:          ${entry.modalities.join(', ')}`,
+        `  Tool calling:   ${entry.toolCalling ? 'yes' : 'no'}`,
+        `  Price (1M tok): ${formatPricing(entry)} (input / output)`,
+      );
+      return {
+        type: 'message',
+        messageType: 'info',
+        content: lines.join('\n'),
+      };
+    }
+
+    if (subCommand === 'use') {
+      const modelId = positional[1];
+      if (!modelId) {
+        return error('Usage: /models use <model-id> [--for vision,tools]');
+      }
+      const workflows = parseWorkflows(
+        getOptionValue<string | boolean>(options, 'for', 'tools'),
+      );
+      if (typeof workflows === 'string') {
+        return error(workflows);
+      }
+
+      const config = context.services.config;
+      if (!config) {
+        return error('Unable to access configuration service');
+      }
+      config.setModel(modelId);
+
+      const lines = [`Switched to ${modelId}`];
+      const entry = findCatalogEntry(modelId);
+      if (!entry) {
+        lines.push(
+          `⚠️  ${modelId} is not in the model catalog; its capabilities could not be checked.`,
+        );
+      } else {
+        for (const warning of checkWorkflowSupport(modelId, workflows)) {
+          lines.push(`⚠️  ${warning}`);
+        }
+        if (!isProviderConfigured(entry.provider)) {
+          lines.push(
+            `⚠️  No API key found for ${entry.provider}. Use: /model config set ${entry.provider} <api-key>`,
+          );
+        }
+      }
+      return {
+        type: 'message',
+        messageType: 'info',
+        content: lines.join('\n'),
+      };
+    }
+
+    // 默认：列出并过滤模型目录
+    const providerOption = String(
+      getOptionValue<string>(options, 'provider', ''),
+    );
+    let provider: ModelProvider | undefined;
+    if (providerOption) {
+      provider = Object.values(ModelProvider).find(
+        (p) => p === providerOption.toLowerCase(),
+      );
+      if (!provider) {
+        return error(`Unknown provider: ${providerOption}`);
+      }
+    }
+
+    let supports: ModelWorkflow[] | undefined;
+    if (options.supports !== undefined) {
+      const parsed = parseWorkflows(options.supports);
+      if (typeof parsed === 'string') {
+        return error(parsed);
+      }
+      supports = parsed;
+    }
+
+    let minContext: number | undefined;
+    const minContextOption = String(
+      getOptionValue<string>(options, 'min-context', ''),
+    );
+    if (minContextOption) {
+      minContext = parseTokenCount(minContextOption);
+      if (minContext === undefined) {
+        return error(
+          `Invalid --min-context value: ${minContextOption}. Use a number such as 32000, 128k or 1m`,
+        );
+      }
+    }
+
+    let maxInputPrice: number | undefined;
+    if (options['max-price'] !== undefined) {
+      maxInputPrice = Number(options['max-price']);
+      if (isNaN(maxInputPrice)) {
+        return error(`Invalid --max-price value: ${options['max-price']}`);
+      }
+    }
+
+    const models = filterModelCatalog({
+      provider,
+      supports,
+      minContext,
+      maxInputPrice,
+      search: positional.join(' ') || undefined,
+    });
+
+    if (models.length === 0) {
+      return {
+        type: 'message',
+        messageType: 'info',
+        content: 'No models match the given filters.',
+      };
+    }
+
+    const current = context.services.config?.getModel();
+    const rows = models.map((entry) => ({
+      current:
+        entry.id === current
+          ? '▶'
+          : isProviderConfigured(entry.provider)
+            ? '✓'
+            : ' ',
+      id: entry.id,
+      provider: entry.provider,
+      context: formatContext(entry.contextWindow),
+      capabilities: formatCapabilities(entry),
+      price: formatPricing(entry),
+    }));
+
+    const table = formatTable(rows, [
+      { key: 'current', title: ' ' },
+      { key: 'id', title: 'Model' },
+      { key: 'provider', title: 'Provider' },
+      { key: 'context', title: 'Context', align: 'right' },
+      { key: 'capabilities', title: 'Capabilities' },
+      { key: 'price', title: 'Price/1M (in/out)' },
+    ]);
+
+    return {
+      type: 'message',
+      messageType: 'info',
+      content: `${table}\n▶ current  ✓ provider configured\nUse '/models use <model-id>' to switch or '/models help' for filters.`,
+    };
+  },
+};
@@ -0,0 +1,82 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { Config } from '@iechor/research-cli-core';
+import { notebookCommand } from './notebookCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+import { MessageType } from '../types.js';
+
+describe('notebookCommand', () => {
+  let tempDir: string;
+  const addHistory = vi.fn();
+  const history = [
+    { role: 'user', parts: [{ text: 'Sum it' }] },
+    { role: 'model', parts: [{ text: '```python\nsum([1, 2])\n```' }] },
+  ];
+
+  const subCommand = (name: string) =>
+    notebookCommand.subCommands!.find((c) => c.name === name)!;
+
+  const context = () =>
+    createMockCommandContext({
+      services: {
+        config: {
+          getTargetDir: () => tempDir,
+          getResearchClient: () => ({
+            addHistory,
+            getHistory: () => history,
+          }),
+        } as unknown as Config,
+      },
+    });
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'notebook-command-'));
+    addHistory.mockClear();
+  });
+
+  afterEach(() => {
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should export the conversation and open it again as context', async () => {
+    const exported = await subCommand('export').action!(context(), 'out');
+    expect(exported).toEqual({
+      type: 'message',
+      messageType: 'info',
+      content: `Exported 2 cells (1 code) to ${path.join(tempDir, 'out.ipynb')}.`,
+    });
+
+    const openContext = context();
+    await subCommand('open').action!(openContext, 'out.ipynb');
+    expect(openContext.ui.addItem).toHaveBeenCalledWith(
+      {
+        type: MessageType.INFO,
+        text: expect.stringContaining('--- Cell 2 [code] In [ ] ---'),
+      },
+      expect.any(Number),
+    );
+    expect(addHistory).toHaveBeenCalledWith({
+      role: 'user',
+      parts: [{ text: expect.stringContaining('sum([1, 2])') }],
+    });
+  });
+
+  it('should report files that are not notebooks', async () => {
+    fs.writeFileSync(path.join(tempDir, 'bad.ipynb'), 'not json');
+    const result = await subCommand('open').action!(context(), 'bad.ipynb');
+    expect(result).toEqual({
+      type: 'message',
+      messageType: 'error',
+      content: expect.stringContaining('Could not open bad.ipynb'),
+    });
+    expect(addHistory).not.toHaveBeenCalled();
+  });
+});
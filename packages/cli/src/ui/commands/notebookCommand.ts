@@ -0,0 +1,109 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import {
+  conversationToNotebook,
+  getErrorMessage,
+  parseNotebook,
+  renderNotebook,
+  serializeNotebook,
+} from '@iechor/research-cli-core';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import { MessageType } from '../types.js';
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+function resolvePath(context: CommandContext, file: string): string {
+  const root = context.services.config?.getTargetDir() ?? process.cwd();
+  return path.resolve(root, file);
+}
+
+export const notebookCommand: SlashCommand = {
+  name: 'notebook',
+  description: 'Open a Jupyter notebook as context, or export the chat as one.',
+  subCommands: [
+    {
+      name: 'open',
+      description:
+        'Show the cells of a notebook and add them to the conversation. Usage: /notebook open <path.ipynb>',
+      action: async (context, args) => {
+        const file = args.trim();
+        if (!file) {
+          return error('Usage: /notebook open <path.ipynb>');
+        }
+        const filePath = resolvePath(context, file);
+        let rendered: string;
+        try {
+          rendered = renderNotebook(
+            parseNotebook(await fs.promises.readFile(filePath, 'utf8')),
+          );
+        } catch (e) {
+          return error(`Could not open ${file}: ${getErrorMessage(e)}`);
+        }
+        context.ui.addItem(
+          { type: MessageType.INFO, text: `${file}\n\n${rendered}` },
+          Date.now(),
+        );
+        await context.services.config?.getResearchClient()?.addHistory({
+          role: 'user',
+          parts: [
+            {
+              text: `I opened the notebook ${filePath}:\n\n${rendered}`,
+            },
+          ],
+        });
+        return info(
+          `Added ${file} to the conversation. Ask about its cells by number; edits are written with the notebook_edit tool.`,
+        );
+      },
+    },
+    {
+      name: 'export',
+      description:
+        'Write the conversation as a notebook of prompts, prose and code cells. Usage: /notebook export <path.ipynb> [language]',
+      action: async (context, args) => {
+        const [file, language = 'python'] = args.trim().split(/\s+/);
+        if (!file) {
+          return error('Usage: /notebook export <path.ipynb> [language]');
+        }
+        const history =
+          context.services.config?.getResearchClient()?.getHistory() ?? [];
+        const notebook = conversationToNotebook(history, language);
+        if (notebook.cells.length === 0) {
+          return error('The conversation is empty; there is nothing to export.');
+        }
+        const filePath = resolvePath(
+          context,
+          file.endsWith('.ipynb') ? file : `${file}.ipynb`,
+        );
+        try {
+          await fs.promises.mkdir(path.dirname(filePath), { recursive: true });
+          await fs.promises.writeFile(filePath, serializeNotebook(notebook));
+        } catch (e) {
+          return error(`Could not write ${filePath}: ${getErrorMessage(e)}`);
+        }
+        const codeCells = notebook.cells.filter(
+          (cell) => cell.cell_type === 'code',
+        ).length;
+        return info(
+          `Exported ${notebook.cells.length} cells (${codeCells} code) to ${filePath}.`,
+        );
+      },
+    },
+  ],
+};
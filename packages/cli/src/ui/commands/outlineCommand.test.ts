@@ -0,0 +1,142 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { Config, loadOutline } from '@iechor/research-cli-core';
+import { outlineCommand } from './outlineCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+import { CommandContext } from './types.js';
+
+describe('outlineCommand', () => {
+  let tempDir: string;
+  let context: CommandContext;
+  const generateJson = vi.fn();
+  const generateContent = vi.fn();
+
+  const subCommand = (name: string) =>
+    outlineCommand.subCommands!.find((c) => c.name === name)!;
+  const shown = () => {
+    const calls = vi.mocked(context.ui.addItem).mock.calls;
+    return calls[calls.length - 1][0].text;
+  };
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'outline-command-'));
+    generateJson.mockReset();
+    generateContent.mockReset();
+    const client = {
+      isInitialized: () => true,
+      getChat: () => ({
+        getHistory: () => [
+          { role: 'user', parts: [{ text: 'We got 91% accuracy.' }] },
+          { role: 'model', parts: [{ text: 'That beats the baseline.' }] },
+        ],
+      }),
+      generateJson,
+      generateContent,
+    };
+    context = createMockCommandContext({
+      services: {
+        config: {
+          getTargetDir: () => tempDir,
+          getResearchClient: () => client,
+        } as unknown as Config,
+      },
+    });
+  });
+
+  afterEach(() => {
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should open the outline pane', async () => {
+    expect(await outlineCommand.action!(context, '')).toEqual({
+      type: 'dialog',
+      dialog: 'outline',
+    });
+  });
+
+  it('should build and reorder the outline', async () => {
+    await subCommand('add').action!(context, 'Method');
+    await subCommand('add').action!(context, 'Introduction');
+    await subCommand('add').action!(context, '--under 1 Model');
+    await subCommand('move').action!(context, '2 up');
+    await subCommand('edit').action!(context, '2.1 Architecture');
+
+    expect(shown()).toBe(
+      [
+        'Outline (drafts go to notes/draft.md):',
+        '1 Introduction',
+        '2 Method',
+        '  2.1 Architecture',
+      ].join('\n'),
+    );
+
+    await subCommand('delete').action!(context, '2');
+    expect(shown()).toBe(
+      'Outline (drafts go to notes/draft.md):\n1 Introduction',
+    );
+  });
+
+  it('should reject sections that do not exist or cannot move', async () => {
+    await subCommand('add').action!(context, 'Method');
+
+    expect(await subCommand('edit').action!(context, '3 X')).toMatchObject({
+      messageType: 'error',
+      content: 'There is no section 3.',
+    });
+    expect(await subCommand('move').action!(context, '1 up')).toMatchObject({
+      messageType: 'error',
+      content: 'Section 1 cannot move up.',
+    });
+    expect(
+      await subCommand('move').action!(context, '1 left'),
+    ).toMatchObject({
+      messageType: 'error',
+      content: 'Usage: /outline move <n> up|down|in|out',
+    });
+  });
+
+  it('should generate an outline from the session', async () => {
+    generateJson.mockResolvedValue({
+      sections: [{ title: 'Results', bullets: ['91% accuracy'] }],
+    });
+
+    await subCommand('generate').action!(context, '');
+
+    expect(shown()).toContain('1 Results\n  1.1 91% accuracy');
+    expect(generateJson.mock.calls[0][0][0].parts[0].text).toContain(
+      'User: We got 91% accuracy.',
+    );
+    expect(await subCommand('generate').action!(context, '')).toMatchObject({
+      messageType: 'error',
+    });
+  });
+
+  it('should draft the next section into the notes file', async () => {
+    await subCommand('add').action!(context, 'Results');
+    generateContent.mockResolvedValue({
+      candidates: [
+        { content: { parts: [{ text: '## Results\nWe reach 91%.' }] } },
+      ],
+    });
+
+    await subCommand('draft').action!(context, 'next');
+
+    const notes = fs.readFileSync(
+      path.join(tempDir, 'notes', 'draft.md'),
+      'utf8',
+    );
+    expect(notes).toContain('## Results\nWe reach 91%.');
+    expect((await loadOutline(tempDir)).items[0].drafted).toBe(true);
+    expect(await subCommand('draft').action!(context, 'next')).toMatchObject({
+      content: 'Every section is drafted.',
+    });
+  });
+});
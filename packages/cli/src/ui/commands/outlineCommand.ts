@@ -0,0 +1,318 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import {
+  Config,
+  Outline,
+  OutlineMove,
+  createOutlineItem,
+  draftOutlineSection,
+  findOutlineRow,
+  flattenOutline,
+  formatOutline,
+  formatSessionText,
+  generateOutline,
+  getErrorMessage,
+  insertOutlineItem,
+  loadOutline,
+  moveOutlineItem,
+  removeOutlineItem,
+  saveOutline,
+  writeOutlineSection,
+} from '@iechor/research-cli-core';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import { MessageType } from '../types.js';
+
+const EMPTY_OUTLINE =
+  'The outline is empty. Open it with /outline to build it, add sections with /outline add <title>, or let the model outline the session with /outline generate.';
+const MOVES: OutlineMove[] = ['up', 'down', 'in', 'out'];
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+function getRoot(context: CommandContext): string {
+  return context.services.config?.getTargetDir() ?? process.cwd();
+}
+
+function splitFirstWord(args: string): [string, string] {
+  const trimmed = args.trim();
+  const space = trimmed.search(/\s/);
+  return space === -1
+    ? [trimmed, '']
+    : [trimmed.slice(0, space), trimmed.slice(space + 1).trim()];
+}
+
+function getSessionText(config: Config): string {
+  const client = config.getResearchClient();
+  return formatSessionText(
+    client?.isInitialized() ? client.getChat().getHistory(true) : [],
+  );
+}
+
+function showOutline(context: CommandContext, outline: Outline): void {
+  context.ui.addItem(
+    {
+      type: MessageType.INFO,
+      text:
+        outline.items.length > 0
+          ? `Outline (drafts go to ${outline.notesFile}):\n${formatOutline(outline.items)}`
+          : EMPTY_OUTLINE,
+    },
+    Date.now(),
+  );
+}
+
+/**
+ * Loads the outline, lets `change` edit it and saves it when `change`
+ * returns nothing; a returned result is passed on without saving.
+ */
+async function editOutline(
+  context: CommandContext,
+  change: (outline: Outline) => SlashCommandActionReturn | void,
+): Promise<SlashCommandActionReturn | void> {
+  const root = getRoot(context);
+  try {
+    const outline = await loadOutline(root);
+    const result = change(outline);
+    if (result) {
+      return result;
+    }
+    await saveOutline(root, outline);
+    showOutline(context, outline);
+  } catch (e) {
+    return error(`Could not save the outline: ${getErrorMessage(e)}`);
+  }
+}
+
+export const outlineCommand: SlashCommand = {
+  name: 'outline',
+  description:
+    'Open the outline pane to build and reorder the sections of a write-up, then draft it section by section into notes.',
+  action: async () => ({ type: 'dialog', dialog: 'outline' }),
+  subCommands: [
+    {
+      name: 'show',
+      description: 'Show the outline with its section numbers.',
+      action: async (context) => {
+        try {
+          showOutline(context, await loadOutline(getRoot(context)));
+        } catch (e) {
+          return error(`Could not read the outline: ${getErrorMessage(e)}`);
+        }
+      },
+    },
+    {
+      name: 'add',
+      description:
+        'Add a section at the end, or a bullet under a section. Usage: /outline add [--under <n>] <title>',
+      action: async (context, args) => {
+        const trimmed = args.trim();
+        const under = trimmed.match(/^--under\s+(\S+)\s+([\s\S]+)$/);
+        const title = under ? under[2].trim() : trimmed;
+        if (!title || (trimmed.startsWith('--under') && !under)) {
+          return error('Usage: /outline add [--under <n>] <title>');
+        }
+        return editOutline(context, (outline) => {
+          const parent = under && findOutlineRow(outline.items, under[1]);
+          if (under && !parent) {
+            return error(`There is no section ${under[1]}.`);
+          }
+          insertOutlineItem(
+            outline.items,
+            parent ? parent.path : undefined,
+            createOutlineItem(title),
+            true,
+          );
+        });
+      },
+    },
+    {
+      name: 'edit',
+      description: 'Retitle a section. Usage: /outline edit <n> <title>',
+      action: async (context, args) => {
+        const [number, title] = splitFirstWord(args);
+        if (!number || !title) {
+          return error('Usage: /outline edit <n> <title>');
+        }
+        return editOutline(context, (outline) => {
+          const row = findOutlineRow(outline.items, number);
+          if (!row) {
+            return error(`There is no section ${number}.`);
+          }
+          row.item.title = title;
+        });
+      },
+    },
+    {
+      name: 'delete',
+      description:
+        'Delete a section with its bullets. Usage: /outline delete <n>',
+      action: async (context, args) => {
+        const number = args.trim();
+        if (!number) {
+          return error('Usage: /outline delete <n>');
+        }
+        return editOutline(context, (outline) => {
+          const row = findOutlineRow(outline.items, number);
+          if (!row) {
+            return error(`There is no section ${number}.`);
+          }
+          removeOutlineItem(outline.items, row.path);
+        });
+      },
+    },
+    {
+      name: 'move',
+      description: `Move a section among its siblings, under the one before it (in) or out of its parent (out). Usage: /outline move <n> ${MOVES.join('|')}`,
+      completion: async (_context, partialArg) => {
+        const [number, move] = splitFirstWord(partialArg);
+        return number && partialArg.includes(' ')
+          ? MOVES.filter((m) => m.startsWith(move)).map((m) => `${number} ${m}`)
+          : [];
+      },
+      action: async (context, args) => {
+        const [number, move] = splitFirstWord(args);
+        if (!number || !MOVES.includes(move as OutlineMove)) {
+          return error(`Usage: /outline move <n> ${MOVES.join('|')}`);
+        }
+        return editOutline(context, (outline) => {
+          const row = findOutlineRow(outline.items, number);
+          if (!row) {
+            return error(`There is no section ${number}.`);
+          }
+          if (!moveOutlineItem(outline.items, row.path, move as OutlineMove)) {
+            return error(`Section ${number} cannot move ${move}.`);
+          }
+        });
+      },
+    },
+    {
+      name: 'generate',
+      description:
+        'Let the model outline the work discussed in this session. Replacing an outline that has sections needs --replace. Usage: /outline generate [--replace] [instructions]',
+      action: async (context, args) => {
+        const config = context.services.config;
+        if (!config) {
+          return error('Generating an outline needs a configured model.');
+        }
+        const replace = /^--replace\b/.test(args.trim());
+        const instructions = args.trim().replace(/^--replace\b/, '').trim();
+        const root = getRoot(context);
+        let outline: Outline;
+        try {
+          outline = await loadOutline(root);
+          if (outline.items.length > 0 && !replace) {
+            return error(
+              'The outline already has sections. Run /outline generate --replace to replace them.',
+            );
+          }
+          const sessionText = getSessionText(config);
+          if (!sessionText) {
+            return error('There is no conversation to outline yet.');
+          }
+          context.ui.setDebugMessage('Outlining the session...');
+          outline.items = await generateOutline(
+            config.getResearchClient(),
+            sessionText,
+            instructions,
+            context.abortSignal,
+          );
+          await saveOutline(root, outline);
+        } catch (e) {
+          return error(`Could not generate the outline: ${getErrorMessage(e)}`);
+        }
+        showOutline(context, outline);
+      },
+    },
+    {
+      name: 'draft',
+      description:
+        'Draft a section, or the next one not drafted yet, into the notes file; drafting it again replaces the earlier draft. Usage: /outline draft <n>|next',
+      action: async (context, args) => {
+        const config = context.services.config;
+        if (!config) {
+          return error('Drafting needs a configured model.');
+        }
+        const number = args.trim();
+        if (!number) {
+          return error('Usage: /outline draft <n>|next');
+        }
+        const root = getRoot(context);
+        try {
+          const outline = await loadOutline(root);
+          const row =
+            number === 'next'
+              ? flattenOutline(outline.items).find(
+                  (r) => r.depth === 0 && !r.item.drafted,
+                )
+              : findOutlineRow(outline.items, number);
+          if (!row) {
+            return number === 'next'
+              ? info('Every section is drafted.')
+              : error(`There is no section ${number}.`);
+          }
+          context.ui.setDebugMessage(`Drafting ${row.item.title}...`);
+          const draft = await draftOutlineSection(
+            config.getResearchClient(),
+            outline.items,
+            row,
+            getSessionText(config),
+            context.abortSignal,
+          );
+          const notesPath = path.resolve(root, outline.notesFile);
+          let notes = '';
+          try {
+            notes = await fs.promises.readFile(notesPath, 'utf8');
+          } catch {
+            // The first draft creates the notes file
+          }
+          await fs.promises.mkdir(path.dirname(notesPath), { recursive: true });
+          await fs.promises.writeFile(
+            notesPath,
+            writeOutlineSection(notes, row.item, draft),
+            'utf8',
+          );
+          row.item.drafted = true;
+          await saveOutline(root, outline);
+          context.ui.addItem(
+            {
+              type: MessageType.INFO,
+              text: `Drafted ${row.number} ${row.item.title} into ${outline.notesFile}:\n\n${draft}`,
+            },
+            Date.now(),
+          );
+        } catch (e) {
+          return error(`Could not draft the section: ${getErrorMessage(e)}`);
+        }
+      },
+    },
+    {
+      name: 'notes',
+      description:
+        'Set the notes file drafts are written into. Usage: /outline notes <file>',
+      action: async (context, args) => {
+        const file = args.trim();
+        if (!file) {
+          return error('Usage: /outline notes <file>');
+        }
+        return editOutline(context, (outline) => {
+          outline.notesFile = file;
+        });
+      },
+    },
+  ],
+};
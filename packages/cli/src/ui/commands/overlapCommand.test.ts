@@ -0,0 +1,85 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { Config } from '@iechor/research-cli-core';
+import { overlapCommand } from './overlapCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+import { MessageType } from '../types.js';
+
+describe('overlapCommand', () => {
+  let tempDir: string;
+
+  const context = () =>
+    createMockCommandContext({
+      services: {
+        config: { getTargetDir: () => tempDir } as unknown as Config,
+      },
+    });
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'overlap-command-'));
+    fs.mkdirSync(path.join(tempDir, 'own'));
+    fs.writeFileSync(
+      path.join(tempDir, 'own', 'icml2024.tex'),
+      'We propose a sparse attention mechanism that scales linearly with sequence length.',
+    );
+    fs.writeFileSync(
+      path.join(tempDir, 'intro.tex'),
+      'We propose a sparse attention mechanism that scales linearly with sequence length. Our new result is different from anything before.',
+    );
+  });
+
+  afterEach(() => {
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should list overlapping sentences with their source', async () => {
+    const ctx = context();
+    const result = await overlapCommand.action!(
+      ctx,
+      'intro.tex --against own',
+    );
+
+    expect(result).toBeUndefined();
+    expect(ctx.ui.addItem).toHaveBeenCalledWith(
+      {
+        type: MessageType.INFO,
+        text: expect.stringContaining(
+          `100%  ${path.join('own', 'icml2024.tex')}`,
+        ),
+      },
+      expect.any(Number),
+    );
+  });
+
+  it('should report a clean draft', async () => {
+    fs.writeFileSync(
+      path.join(tempDir, 'intro.tex'),
+      'An entirely different opening sentence about our new benchmark.',
+    );
+    const result = await overlapCommand.action!(
+      context(),
+      'intro.tex --against own --threshold 0.8',
+    );
+    expect(result).toMatchObject({
+      messageType: 'info',
+      content:
+        'No sentence of intro.tex overlaps 80% or more with the 1 files compared.',
+    });
+  });
+
+  it('should ask for a corpus when there is nothing to compare', async () => {
+    const result = await overlapCommand.action!(context(), 'intro.tex');
+    expect(result).toMatchObject({
+      messageType: 'error',
+      content: expect.stringContaining('Nothing to compare against'),
+    });
+  });
+});
@@ -0,0 +1,151 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import {
+  DEFAULT_OVERLAP_THRESHOLD,
+  OverlapMatch,
+  findOverlaps,
+  findPaperPdf,
+  getErrorMessage,
+  isBibtexEntry,
+  loadOverlapSources,
+  parseBibtex,
+  summarizeComment,
+} from '@iechor/research-cli-core';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import { MessageType } from '../types.js';
+import { resolveBibFile } from './bibCommand.js';
+
+const USAGE =
+  'Usage: /overlap <draft> [--against <file|dir>...] [--bib <file>] [--threshold 0.5]';
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+interface OverlapArgs {
+  draft?: string;
+  against: string[];
+  bib?: string;
+  threshold: number;
+}
+
+function parseArgs(args: string): OverlapArgs {
+  const parsed: OverlapArgs = {
+    against: [],
+    threshold: DEFAULT_OVERLAP_THRESHOLD,
+  };
+  const tokens = args.trim().split(/\s+/).filter(Boolean);
+  for (let i = 0; i < tokens.length; i++) {
+    if (tokens[i] === '--against') {
+      // Takes every path up to the next option
+      while (i + 1 < tokens.length && !tokens[i + 1].startsWith('--')) {
+        parsed.against.push(tokens[++i]);
+      }
+    } else if (tokens[i] === '--bib') {
+      parsed.bib = tokens[++i];
+    } else if (tokens[i] === '--threshold') {
+      parsed.threshold = Number(tokens[++i]);
+    } else if (!parsed.draft) {
+      parsed.draft = tokens[i];
+    }
+  }
+  return parsed;
+}
+
+/** The PDFs of the entries in the project's .bib file that can be found. */
+async function findCitedPdfs(
+  context: CommandContext,
+  bib?: string,
+): Promise<string[]> {
+  let bibFile: string;
+  try {
+    bibFile = resolveBibFile(context, bib);
+  } catch (e) {
+    if (bib) {
+      throw e;
+    }
+    // Without a .bib file only the --against paths are compared
+    return [];
+  }
+  const entries = parseBibtex(
+    await fs.promises.readFile(bibFile, 'utf8'),
+  ).filter(isBibtexEntry);
+  return entries
+    .map((entry) => findPaperPdf(entry, path.dirname(bibFile)))
+    .filter((pdf): pdf is string => pdf !== undefined);
+}
+
+export const overlapCommand: SlashCommand = {
+  name: 'overlap',
+  description:
+    'Flag draft sentences that nearly repeat your earlier papers or the cited PDFs. ' +
+    USAGE,
+  action: async (context: CommandContext, args: string) => {
+    const { draft, against, bib, threshold } = parseArgs(args);
+    if (!draft || !(threshold > 0 && threshold <= 1)) {
+      return error(USAGE);
+    }
+    const root = context.services.config?.getTargetDir() ?? process.cwd();
+    const draftPath = path.resolve(root, draft);
+
+    context.ui.setDebugMessage('Reading the corpus...');
+    let matches: OverlapMatch[];
+    let sourceCount: number;
+    try {
+      const draftText = await fs.promises.readFile(draftPath, 'utf8');
+      const sources = await loadOverlapSources(
+        [
+          ...(await findCitedPdfs(context, bib)),
+          ...against.map((p) => path.resolve(root, p)),
+        ],
+        [draftPath],
+      );
+      if (sources.length === 0) {
+        return error(
+          `Nothing to compare against: no PDF of a .bib entry was found. Pass your earlier papers with --against <file|dir>. ${USAGE}`,
+        );
+      }
+      sourceCount = sources.length;
+      matches = findOverlaps(draftText, sources, { threshold });
+    } catch (e) {
+      return error(`Could not check the overlap: ${getErrorMessage(e)}`);
+    }
+
+    if (matches.length === 0) {
+      return info(
+        `No sentence of ${draft} overlaps ${Math.round(threshold * 100)}% or more with the ${sourceCount} files compared.`,
+      );
+    }
+    context.ui.addItem(
+      {
+        type: MessageType.INFO,
+        text: [
+          `${matches.length} sentences of ${draft} overlap with the ${sourceCount} files compared:`,
+          ...matches.map((m) =>
+            [
+              '',
+              `${Math.round(m.score * 100)}%  ${path.relative(root, m.source) || m.source}`,
+              `  draft:  ${summarizeComment(m.sentence, 200)}`,
+              `  source: ${summarizeComment(m.match, 200)}`,
+            ].join('\n'),
+          ),
+        ].join('\n'),
+      },
+      Date.now(),
+    );
+  },
+};
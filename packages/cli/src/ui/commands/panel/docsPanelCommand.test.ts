@@ -22,8 +22,10 @@ describe('docsPanelCommand', () => {
         } as any
       },
       ui: {
+        history: [],
         addItem: vi.fn(),
         clear: vi.fn(),
+        loadOlderHistory: vi.fn(),
         setDebugMessage: vi.fn()
       },
       session: {
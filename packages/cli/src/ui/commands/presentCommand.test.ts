@@ -0,0 +1,95 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, afterEach } from 'vitest';
+import { Content } from '@google/genai';
+import { Config } from '@iechor/research-cli-core';
+import {
+  Presentation,
+  presentCommand,
+  startPresentation,
+} from './presentCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+
+describe('presentCommand', () => {
+  let presentation: Presentation | undefined;
+  const history: Content[] = [];
+  const chat = {
+    getHistory: () => history,
+    getHistoryRevision: () => history.length,
+  };
+  const config = {
+    getModel: () => 'gpt-4o',
+    getResearchClient: () => ({
+      isInitialized: () => true,
+      getHistory: () => history,
+      getChat: () => chat,
+    }),
+  } as unknown as Config;
+
+  afterEach(async () => {
+    await presentation?.stop();
+    presentation = undefined;
+    history.length = 0;
+  });
+
+  it('should serve the conversation without any input controls', async () => {
+    history.push(
+      { role: 'user', parts: [{ text: 'Summarize the results' }] },
+      { role: 'model', parts: [{ text: 'Accuracy improved by 3%.' }] },
+    );
+    presentation = await startPresentation(config);
+
+    const response = await fetch(presentation.url);
+    const html = await response.text();
+
+    expect(presentation.url).toMatch(
+      /^http:\/\/127\.0\.0\.1:\d+\/[0-9a-f]{24}\/$/,
+    );
+    expect(html).toContain('Accuracy improved by 3%.');
+    expect(html).toContain("new EventSource('events')");
+    expect(html).not.toMatch(/<(form|input|textarea)\b/);
+    expect((await fetch(presentation.url, { method: 'POST' })).status).toBe(
+      405,
+    );
+    expect((await fetch(new URL('/', presentation.url))).status).toBe(404);
+  });
+
+  it('should tell the page when the session changes', async () => {
+    presentation = await startPresentation(config, { pollMs: 10 });
+    const response = await fetch(new URL('events', presentation.url));
+    const reader = response.body!.getReader();
+    const decoder = new TextDecoder();
+    let received = '';
+
+    history.push({ role: 'user', parts: [{ text: 'Next slide' }] });
+    while (!received.includes('data: update')) {
+      const { value } = await reader.read();
+      received += decoder.decode(value);
+    }
+    await reader.cancel();
+
+    expect(received).toContain('data: update');
+  });
+
+  it('should start once and stop with the stop subcommand', async () => {
+    const context = createMockCommandContext({ services: { config } });
+
+    const started = await presentCommand.action!(context, '--port 0');
+    expect(started).toMatchObject({
+      content: expect.stringMatching(/^Presenting the session read-only at/),
+    });
+    expect(await presentCommand.action!(context, '')).toMatchObject({
+      content: expect.stringContaining('already presented'),
+    });
+    expect(
+      await presentCommand.subCommands![0].action!(context, ''),
+    ).toMatchObject({ content: 'Stopped presenting the session.' });
+    expect(await presentCommand.action!(context, '--port x')).toMatchObject({
+      messageType: 'error',
+    });
+  });
+});
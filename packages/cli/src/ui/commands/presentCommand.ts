@@ -0,0 +1,190 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import crypto from 'node:crypto';
+import http from 'node:http';
+import { AddressInfo } from 'node:net';
+import { Config, getErrorMessage } from '@iechor/research-cli-core';
+import { SlashCommand, SlashCommandActionReturn } from './types.js';
+import { renderConversationExport } from './exportCommand.js';
+import { registerCleanup } from '../../utils/cleanup.js';
+
+const DEFAULT_HOST = '127.0.0.1';
+const POLL_MS = 1000;
+const USAGE = 'Usage: /present [--port <n>] [--host <address>] | stop';
+
+/** Reloads the page body whenever the session changes. */
+const LIVE_SCRIPT = `<script>
+new EventSource('events').onmessage = function () {
+  fetch('.').then(function (r) { return r.text(); }).then(function (html) {
+    var doc = new DOMParser().parseFromString(html, 'text/html');
+    document.body.innerHTML = doc.body.innerHTML;
+    window.scrollTo(0, document.body.scrollHeight);
+  });
+};
+</script>`;
+
+const WAITING_PAGE = `<!doctype html><html><head><meta charset="utf-8"><title>Research CLI</title></head><body><p>Waiting for the conversation to start…</p></body></html>`;
+
+export interface Presentation {
+  url: string;
+  stop: () => Promise<void>;
+}
+
+let presentation: Presentation | undefined;
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+/** Which chat the session is on and how far its history has got. */
+function historyState(config: Config): { chat?: object; revision: number } {
+  const client = config.getResearchClient();
+  if (!client?.isInitialized()) {
+    return { revision: 0 };
+  }
+  const chat = client.getChat();
+  return { chat, revision: chat.getHistoryRevision() };
+}
+
+/**
+ * Serves the conversation as a read-only page that follows the session
+ * live. The page is rendered like /export, with the same redaction, and
+ * the server only answers GET requests under a random path, so nothing can
+ * be sent to the session through it.
+ */
+export async function startPresentation(
+  config: Config,
+  options: { port?: number; host?: string; pollMs?: number } = {},
+): Promise<Presentation> {
+  const key = crypto.randomBytes(12).toString('hex');
+  const listeners = new Set<http.ServerResponse>();
+
+  const server = http.createServer(async (request, response) => {
+    const route = request.url?.split('?')[0];
+    if (request.method !== 'GET') {
+      response.writeHead(405, { Allow: 'GET' }).end();
+    } else if (route === `/${key}/`) {
+      try {
+        const rendered = await renderConversationExport(config, {
+          title: 'Live session',
+        });
+        const html = (rendered?.html ?? WAITING_PAGE).replace(
+          '</body>',
+          `${LIVE_SCRIPT}</body>`,
+        );
+        response
+          .writeHead(200, {
+            'Content-Type': 'text/html; charset=utf-8',
+            'Cache-Control': 'no-store',
+          })
+          .end(html);
+      } catch (e) {
+        response.writeHead(500).end(getErrorMessage(e));
+      }
+    } else if (route === `/${key}/events`) {
+      response.writeHead(200, {
+        'Content-Type': 'text/event-stream',
+        'Cache-Control': 'no-store',
+        Connection: 'keep-alive',
+      });
+      response.write(': connected\n\n');
+      listeners.add(response);
+      request.on('close', () => listeners.delete(response));
+    } else {
+      response.writeHead(404).end();
+    }
+  });
+
+  let state = historyState(config);
+  const timer = setInterval(() => {
+    const next = historyState(config);
+    if (next.chat !== state.chat || next.revision !== state.revision) {
+      state = next;
+      for (const listener of listeners) {
+        listener.write('data: update\n\n');
+      }
+    }
+  }, options.pollMs ?? POLL_MS);
+  timer.unref();
+
+  const host = options.host ?? DEFAULT_HOST;
+  await new Promise<void>((resolve, reject) => {
+    server.once('error', reject);
+    server.listen(options.port ?? 0, host, resolve);
+  });
+  const { port } = server.address() as AddressInfo;
+  const shownHost = host === '0.0.0.0' ? 'localhost' : host;
+  return {
+    url: `http://${shownHost}:${port}/${key}/`,
+    stop: () =>
+      new Promise<void>((resolve) => {
+        clearInterval(timer);
+        for (const listener of listeners) {
+          listener.end();
+        }
+        server.close(() => resolve());
+      }),
+  };
+}
+
+const stopCommand: SlashCommand = {
+  name: 'stop',
+  description: 'Stop serving the session.',
+  action: async () => {
+    if (!presentation) {
+      return info('The session is not being presented.');
+    }
+    await presentation.stop();
+    presentation = undefined;
+    return info('Stopped presenting the session.');
+  },
+};
+
+export const presentCommand: SlashCommand = {
+  name: 'present',
+  description: `Serve the session as a read-only live page, e.g. to project it in a meeting. ${USAGE}`,
+  action: async (context, args) => {
+    const config = context.services.config;
+    if (!config) {
+      return error('No session to present.');
+    }
+    if (presentation) {
+      return info(`The session is already presented at ${presentation.url}`);
+    }
+    const tokens = args.trim().split(/\s+/).filter(Boolean);
+    let port: number | undefined;
+    let host: string | undefined;
+    for (let i = 0; i < tokens.length; i += 2) {
+      const value = tokens[i + 1];
+      if (tokens[i] === '--port' && /^\d+$/.test(value ?? '')) {
+        port = Number(value);
+      } else if (tokens[i] === '--host' && value) {
+        host = value;
+      } else {
+        return error(USAGE);
+      }
+    }
+    try {
+      presentation = await startPresentation(config, { port, host });
+    } catch (e) {
+      return error(`Could not start the server: ${getErrorMessage(e)}`);
+    }
+    registerCleanup(() => presentation?.stop());
+    const network =
+      host && host !== DEFAULT_HOST
+        ? ' Anyone on the network with this link can read the session.'
+        : '';
+    return info(
+      `Presenting the session read-only at ${presentation.url}. Open it in a browser; it follows the conversation as it goes.${network} Stop with /present stop.`,
+    );
+  },
+  subCommands: [stopCommand],
+};
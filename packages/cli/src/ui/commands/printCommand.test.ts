@@ -0,0 +1,48 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach } from 'vitest';
+import { printCommand } from './printCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+import { getPrintOnExit, setPrintOnExit } from '../../utils/printOnExit.js';
+
+describe('printCommand', () => {
+  const context = createMockCommandContext();
+
+  beforeEach(() => {
+    setPrintOnExit(undefined);
+  });
+
+  it('should print markdown on exit by default', async () => {
+    expect(await printCommand.action!(context, '')).toMatchObject({
+      messageType: 'info',
+      content: 'The session will be printed to stdout as markdown when you quit.',
+    });
+    expect(getPrintOnExit()).toBe('markdown');
+  });
+
+  it('should switch to text and back off', async () => {
+    await printCommand.action!(context, 'text');
+    expect(getPrintOnExit()).toBe('text');
+
+    expect(await printCommand.action!(context, 'off')).toMatchObject({
+      content: 'The session will not be printed on exit.',
+    });
+    expect(getPrintOnExit()).toBeUndefined();
+  });
+
+  it('should reject unknown formats', async () => {
+    expect(await printCommand.action!(context, 'html')).toMatchObject({
+      messageType: 'error',
+      content: 'Usage: /print [markdown|text|prose|off]',
+    });
+    expect(getPrintOnExit()).toBeUndefined();
+  });
+
+  it('should complete the formats', async () => {
+    expect(await printCommand.completion!(context, 't')).toEqual(['text']);
+  });
+});
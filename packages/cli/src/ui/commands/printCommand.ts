@@ -0,0 +1,47 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { SlashCommand, SlashCommandActionReturn } from './types.js';
+import {
+  getPrintOnExit,
+  parsePrintFormat,
+  setPrintOnExit,
+} from '../../utils/printOnExit.js';
+
+const USAGE = 'Usage: /print [markdown|text|prose|off]';
+
+export const printCommand: SlashCommand = {
+  name: 'print',
+  description:
+    'Print the session to stdout when you quit, as markdown (the default), text or prose to read aloud, so a wrapping script can pipe or capture it. /print off cancels it.',
+  completion: async (_context, partialArg) =>
+    ['markdown', 'text', 'prose', 'off'].filter((option) =>
+      option.startsWith(partialArg),
+    ),
+  action: async (_context, args): Promise<SlashCommandActionReturn> => {
+    if (args.trim() === 'off') {
+      const wasOn = getPrintOnExit() !== undefined;
+      setPrintOnExit(undefined);
+      return {
+        type: 'message',
+        messageType: 'info',
+        content: wasOn
+          ? 'The session will not be printed on exit.'
+          : 'Printing on exit was not on.',
+      };
+    }
+    const format = parsePrintFormat(args);
+    if (!format) {
+      return { type: 'message', messageType: 'error', content: USAGE };
+    }
+    setPrintOnExit(format);
+    return {
+      type: 'message',
+      messageType: 'info',
+      content: `The session will be printed to stdout as ${format} when you quit.`,
+    };
+  },
+};
@@ -0,0 +1,32 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { parseRating } from '@iechor/research-cli-core';
+import { CommandContext, SlashCommand } from './types.js';
+import { recordLastAnswerFeedback } from './feedbackCommand.js';
+
+const USAGE = 'Usage: /rate up|down|+|-|1-5 [note]';
+
+export const rateCommand: SlashCommand = {
+  name: 'rate',
+  description: `Rate the last answer with a thumb up or down, or a score from 1 to 5, and optionally a note. ${USAGE}`,
+  completion: async (_context, partialArg) =>
+    ['up', 'down', '1', '2', '3', '4', '5'].filter((value) =>
+      value.startsWith(partialArg),
+    ),
+  action: async (context: CommandContext, args: string) => {
+    const [value = '', ...rest] = args.trim().split(/\s+/).filter(Boolean);
+    const rating = parseRating(value);
+    if (!rating) {
+      return { type: 'message', messageType: 'error', content: USAGE };
+    }
+    const note = rest.join(' ');
+    return recordLastAnswerFeedback(
+      context,
+      note ? { rating, note } : { rating },
+    );
+  },
+};
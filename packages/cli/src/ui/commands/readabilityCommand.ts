@@ -0,0 +1,86 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import {
+  computeTextMetrics,
+  getErrorMessage,
+  isPassiveSentence,
+  splitSentences,
+  stripMarkup,
+  summarizeComment,
+} from '@iechor/research-cli-core';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import { MessageType } from '../types.js';
+import { TextTarget, readTextTarget } from './wordcountCommand.js';
+
+const USAGE =
+  'Usage: /readability [<file>[:<from>-<to>] [--section <title>] | <text>]';
+// Enough examples to rewrite without flooding the view
+const MAX_PASSIVE_EXAMPLES = 5;
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+function describeReadingEase(score: number): string {
+  if (score >= 60) {
+    return 'plain';
+  }
+  if (score >= 30) {
+    return 'difficult';
+  }
+  return 'very difficult';
+}
+
+export const readabilityCommand: SlashCommand = {
+  name: 'readability',
+  description:
+    'Report sentence length, passive voice and reading grade level of a draft. ' +
+    USAGE,
+  action: async (context: CommandContext, args: string) => {
+    let target: TextTarget;
+    try {
+      target = await readTextTarget(context, args, USAGE);
+    } catch (e) {
+      return error(getErrorMessage(e));
+    }
+    const metrics = computeTextMetrics(target.text);
+    if (metrics.sentences === 0) {
+      return error(`${target.label} has no sentences to measure.`);
+    }
+    const passive = splitSentences(stripMarkup(target.text)).filter(
+      isPassiveSentence,
+    );
+    const lines = [
+      `${target.label}:`,
+      `  Words:                ${metrics.words.toLocaleString('en-US')}`,
+      `  Sentences:            ${metrics.sentences.toLocaleString('en-US')} (${metrics.averageWordsPerSentence} words on average)`,
+      `  Passive voice:        ${Math.round(metrics.passiveRatio * 100)}% of sentences (${metrics.passiveSentences})`,
+      `  Flesch-Kincaid grade: ${metrics.fleschKincaidGrade}`,
+      `  Flesch reading ease:  ${metrics.fleschReadingEase} (${describeReadingEase(metrics.fleschReadingEase)})`,
+    ];
+    if (passive.length > 0) {
+      lines.push(
+        '',
+        'Passive sentences:',
+        ...passive
+          .slice(0, MAX_PASSIVE_EXAMPLES)
+          .map((sentence) => `  - ${summarizeComment(sentence, 120)}`),
+        ...(passive.length > MAX_PASSIVE_EXAMPLES
+          ? [`  ... and ${passive.length - MAX_PASSIVE_EXAMPLES} more`]
+          : []),
+      );
+    }
+    context.ui.addItem(
+      { type: MessageType.INFO, text: lines.join('\n') },
+      Date.now(),
+    );
+  },
+};
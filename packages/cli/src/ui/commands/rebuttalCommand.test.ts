@@ -0,0 +1,94 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { Config, listRebuttals } from '@iechor/research-cli-core';
+import { rebuttalCommand } from './rebuttalCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+
+describe('rebuttalCommand', () => {
+  let tempDir: string;
+
+  const subCommand = (name: string) =>
+    rebuttalCommand.subCommands!.find((c) => c.name === name)!;
+
+  const context = () =>
+    createMockCommandContext({
+      services: {
+        config: { getTargetDir: () => tempDir } as unknown as Config,
+      },
+    });
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'rebuttal-command-'));
+    // Rebuttals are stored under the home directory
+    vi.stubEnv('HOME', tempDir);
+  });
+
+  afterEach(() => {
+    vi.unstubAllEnvs();
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should track responses per point and assemble the rebuttal', async () => {
+    await subCommand('new').action!(context(), 'ICML 2025 --limit 60');
+    fs.writeFileSync(
+      path.join(tempDir, 'reviews.txt'),
+      'Reviewer 1\n1. Missing ablation.\n2. Unclear notation.\n',
+    );
+    const added = await subCommand('add').action!(
+      context(),
+      '--file reviews.txt',
+    );
+    expect(added).toMatchObject({
+      content: expect.stringContaining('Added 2 points.'),
+    });
+
+    const responded = await subCommand('respond').action!(
+      context(),
+      'r1.1 We added the ablation\nto Table 4.',
+    );
+    expect(responded).toMatchObject({
+      content: expect.stringContaining('R1.1 done.'),
+    });
+    expect(
+      await subCommand('done').action!(context(), 'R1.2'),
+    ).toMatchObject({
+      messageType: 'error',
+      content: 'Points need a response before they are done.',
+    });
+
+    const exported = await subCommand('export').action!(
+      context(),
+      'rebuttal.md',
+    );
+    expect(exported).toMatchObject({
+      content: expect.stringMatching(
+        /characters \(\d+ over\)\n1 points are still pending: R1\.2$/,
+      ),
+    });
+    expect(fs.readFileSync(path.join(tempDir, 'rebuttal.md'), 'utf8')).toBe(
+      '**Response to R1**\n\n**R1.1** _Missing ablation._\nWe added the ablation\nto Table 4.\n',
+    );
+
+    const [rebuttal] = await listRebuttals();
+    expect(rebuttal.points.map((p) => [p.id, p.status])).toEqual([
+      ['R1.1', 'done'],
+      ['R1.2', 'pending'],
+    ]);
+  });
+
+  it('should ask for a rebuttal before adding comments', async () => {
+    const result = await subCommand('add').action!(context(), '1. A point.');
+    expect(result).toMatchObject({
+      messageType: 'error',
+      content: expect.stringContaining('No rebuttal in progress'),
+    });
+  });
+});
@@ -0,0 +1,364 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import {
+  Rebuttal,
+  addRebuttalPoints,
+  assembleRebuttal,
+  createRebuttal,
+  draftRebuttalResponse,
+  findRebuttalPoint,
+  formatCharacterCount,
+  getErrorMessage,
+  listRebuttals,
+  parseReviewerComments,
+  saveRebuttal,
+  summarizeComment,
+} from '@iechor/research-cli-core';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import { MessageType } from '../types.js';
+
+const NO_REBUTTAL =
+  'No rebuttal in progress. Start one with /rebuttal new <title> [--limit <characters>].';
+
+// The rebuttal the subcommands work on; defaults to the most recently
+// updated one.
+let activeRebuttalId: string | undefined;
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+async function getActiveRebuttal(): Promise<Rebuttal | undefined> {
+  const rebuttals = await listRebuttals();
+  return rebuttals.find((r) => r.id === activeRebuttalId) ?? rebuttals[0];
+}
+
+/** Splits "<id> <rest>" keeping the line breaks of the rest. */
+function splitFirstWord(args: string): [string, string] {
+  const match = args.trim().match(/^(\S*)\s*([\s\S]*)$/);
+  return [match?.[1] ?? '', match?.[2] ?? ''];
+}
+
+function formatPoints(rebuttal: Rebuttal): string {
+  const done = rebuttal.points.filter((p) => p.status === 'done').length;
+  const text = assembleRebuttal(rebuttal);
+  return [
+    `${rebuttal.title}: ${done}/${rebuttal.points.length} points done, ${formatCharacterCount(text, rebuttal.charLimit)}`,
+    '',
+    ...rebuttal.points.map(
+      (point) =>
+        `${point.status === 'done' ? '☑' : point.response ? '✎' : '☐'} ${point.id.padEnd(6)} ${summarizeComment(point.comment, 70)}`,
+    ),
+  ].join('\n');
+}
+
+export const rebuttalCommand: SlashCommand = {
+  name: 'rebuttal',
+  description:
+    'Answer reviewer comments point by point and assemble the rebuttal. Usage: /rebuttal [new|add|respond|draft|done|export|list]',
+  action: async (context: CommandContext) => {
+    const rebuttal = await getActiveRebuttal();
+    if (!rebuttal) {
+      return info(NO_REBUTTAL);
+    }
+    context.ui.addItem(
+      { type: MessageType.INFO, text: formatPoints(rebuttal) },
+      Date.now(),
+    );
+  },
+  subCommands: [
+    {
+      name: 'new',
+      description:
+        'Start a rebuttal. Usage: /rebuttal new <title> [--limit <characters>]',
+      action: async (_context, args) => {
+        const tokens = args.trim().split(/\s+/).filter(Boolean);
+        const limitIndex = tokens.indexOf('--limit');
+        const limit =
+          limitIndex === -1
+            ? undefined
+            : Number(tokens.splice(limitIndex, 2)[1]);
+        const title = tokens.join(' ');
+        if (!title || (limit !== undefined && !(limit > 0))) {
+          return error('Usage: /rebuttal new <title> [--limit <characters>]');
+        }
+        const rebuttal = createRebuttal(title, limit);
+        try {
+          if ((await listRebuttals()).some((r) => r.id === rebuttal.id)) {
+            return error(
+              `A rebuttal called "${title}" already exists; pick another title.`,
+            );
+          }
+          await saveRebuttal(rebuttal);
+        } catch (e) {
+          return error(`Could not save the rebuttal: ${getErrorMessage(e)}`);
+        }
+        activeRebuttalId = rebuttal.id;
+        return info(
+          `Started "${title}". Paste the reviews with /rebuttal add <comments>, or read them from a file with /rebuttal add --file <path>.`,
+        );
+      },
+    },
+    {
+      name: 'add',
+      description:
+        'Add reviewer comments, split into points. Usage: /rebuttal add [--reviewer R2] <comments> | --file <path>',
+      action: async (context, args) => {
+        const rebuttal = await getActiveRebuttal();
+        if (!rebuttal) {
+          return error(NO_REBUTTAL);
+        }
+        let text = args.trim();
+        let reviewer: string | undefined;
+        const reviewerMatch = text.match(/^--reviewer\s+(\S+)\s*/);
+        if (reviewerMatch) {
+          reviewer = reviewerMatch[1].toUpperCase().replace(/^(\d)/, 'R$1');
+          text = text.slice(reviewerMatch[0].length);
+        }
+        const fileMatch = text.match(/^--file\s+(\S+)\s*$/);
+        try {
+          if (fileMatch) {
+            text = await fs.promises.readFile(
+              path.resolve(
+                context.services.config?.getTargetDir() ?? process.cwd(),
+                fileMatch[1],
+              ),
+              'utf8',
+            );
+          }
+          if (!text.trim()) {
+            return error(
+              'Usage: /rebuttal add [--reviewer R2] <comments> | --file <path>',
+            );
+          }
+          const added = addRebuttalPoints(
+            rebuttal,
+            parseReviewerComments(
+              text,
+              reviewer ??
+                `R${new Set(rebuttal.points.map((p) => p.reviewer)).size + 1}`,
+            ),
+          );
+          await saveRebuttal(rebuttal);
+          context.ui.addItem(
+            { type: MessageType.INFO, text: formatPoints(rebuttal) },
+            Date.now(),
+          );
+          return info(
+            `Added ${added.length} points. Answer them with /rebuttal respond <id> <text> or /rebuttal draft <id> [notes].`,
+          );
+        } catch (e) {
+          return error(`Could not add the comments: ${getErrorMessage(e)}`);
+        }
+      },
+    },
+    {
+      name: 'show',
+      description: 'Show a point and its response. Usage: /rebuttal show <id>',
+      action: async (context, args) => {
+        const rebuttal = await getActiveRebuttal();
+        if (!rebuttal) {
+          return error(NO_REBUTTAL);
+        }
+        const point = findRebuttalPoint(rebuttal, args.trim());
+        if (!point) {
+          return error(
+            `No point "${args.trim()}". Usage: /rebuttal show <id>`,
+          );
+        }
+        context.ui.addItem(
+          {
+            type: MessageType.INFO,
+            text: `${point.id} (${point.status})\n${point.comment}\n\nResponse:\n${point.response ?? '(none yet)'}`,
+          },
+          Date.now(),
+        );
+      },
+    },
+    {
+      name: 'respond',
+      description:
+        'Set the response to a point and mark it done. Usage: /rebuttal respond <id> <text>',
+      action: async (_context, args) => {
+        const rebuttal = await getActiveRebuttal();
+        if (!rebuttal) {
+          return error(NO_REBUTTAL);
+        }
+        const [id, text] = splitFirstWord(args);
+        const point = findRebuttalPoint(rebuttal, id);
+        if (!point || !text.trim()) {
+          return error('Usage: /rebuttal respond <id> <text>');
+        }
+        point.response = text.trim();
+        point.status = 'done';
+        rebuttal.updated = new Date().toISOString();
+        await saveRebuttal(rebuttal);
+        return info(
+          `${point.id} done. ${formatCharacterCount(assembleRebuttal(rebuttal), rebuttal.charLimit)}.`,
+        );
+      },
+    },
+    {
+      name: 'draft',
+      description:
+        'Let the model draft the response to a point; it stays pending until you mark it done. Usage: /rebuttal draft <id> [notes]',
+      action: async (context, args) => {
+        const config = context.services.config;
+        const rebuttal = await getActiveRebuttal();
+        if (!rebuttal) {
+          return error(NO_REBUTTAL);
+        }
+        if (!config) {
+          return error('Drafting needs a configured model.');
+        }
+        const [id, notes] = splitFirstWord(args);
+        const point = findRebuttalPoint(rebuttal, id);
+        if (!point) {
+          return error('Usage: /rebuttal draft <id> [notes]');
+        }
+        context.ui.setDebugMessage(`Drafting the response to ${point.id}...`);
+        try {
+          point.response = await draftRebuttalResponse(
+            config.getResearchClient(),
+            rebuttal,
+            point,
+            context.abortSignal,
+            notes.trim() || undefined,
+          );
+          point.status = 'pending';
+          rebuttal.updated = new Date().toISOString();
+          await saveRebuttal(rebuttal);
+        } catch (e) {
+          return error(`Could not draft a response: ${getErrorMessage(e)}`);
+        }
+        context.ui.addItem(
+          {
+            type: MessageType.INFO,
+            text: `${point.id} draft:\n${point.response}\n\nKeep it with /rebuttal done ${point.id}, or replace it with /rebuttal respond ${point.id} <text>.`,
+          },
+          Date.now(),
+        );
+      },
+    },
+    {
+      name: 'done',
+      description:
+        'Mark points done, or pending again with --pending. Usage: /rebuttal done <id...> [--pending]',
+      action: async (_context, args) => {
+        const rebuttal = await getActiveRebuttal();
+        if (!rebuttal) {
+          return error(NO_REBUTTAL);
+        }
+        const tokens = args.trim().split(/\s+/).filter(Boolean);
+        const status = tokens.includes('--pending') ? 'pending' : 'done';
+        const ids = tokens.filter((t) => t !== '--pending');
+        const points = ids.map((id) => findRebuttalPoint(rebuttal, id));
+        if (ids.length === 0 || points.some((p) => !p)) {
+          return error('Usage: /rebuttal done <id...> [--pending]');
+        }
+        if (status === 'done' && points.some((p) => !p!.response)) {
+          return error('Points need a response before they are done.');
+        }
+        points.forEach((point) => (point!.status = status));
+        rebuttal.updated = new Date().toISOString();
+        await saveRebuttal(rebuttal);
+        return info(`Marked ${ids.join(', ')} ${status}.`);
+      },
+    },
+    {
+      name: 'export',
+      description:
+        "Assemble the rebuttal, or one reviewer's part, and check it against the character limit. Usage: /rebuttal export [file] [--reviewer R2]",
+      action: async (context, args) => {
+        const rebuttal = await getActiveRebuttal();
+        if (!rebuttal) {
+          return error(NO_REBUTTAL);
+        }
+        const tokens = args.trim().split(/\s+/).filter(Boolean);
+        const reviewerIndex = tokens.indexOf('--reviewer');
+        const reviewer =
+          reviewerIndex === -1
+            ? undefined
+            : tokens.splice(reviewerIndex, 2)[1];
+        const [file] = tokens;
+        const text = assembleRebuttal(rebuttal, reviewer);
+        if (!text) {
+          return error('No point has a response yet.');
+        }
+        const pending = rebuttal.points.filter(
+          (p) =>
+            p.status === 'pending' &&
+            (!reviewer ||
+              p.reviewer.toLowerCase() === reviewer.toLowerCase()),
+        );
+        const summary = [
+          formatCharacterCount(text, rebuttal.charLimit),
+          ...(pending.length > 0
+            ? [
+                `${pending.length} points are still pending: ${pending.map((p) => p.id).join(', ')}`,
+              ]
+            : []),
+        ].join('\n');
+        if (!file) {
+          context.ui.addItem(
+            { type: MessageType.INFO, text: `${text}\n\n${summary}` },
+            Date.now(),
+          );
+          return;
+        }
+        const filePath = path.resolve(
+          context.services.config?.getTargetDir() ?? process.cwd(),
+          file,
+        );
+        try {
+          await fs.promises.mkdir(path.dirname(filePath), { recursive: true });
+          await fs.promises.writeFile(filePath, `${text}\n`, 'utf8');
+        } catch (e) {
+          return error(`Could not write ${filePath}: ${getErrorMessage(e)}`);
+        }
+        return info(`Wrote the rebuttal to ${filePath}.\n${summary}`);
+      },
+    },
+    {
+      name: 'list',
+      description:
+        'List saved rebuttals, or switch to one. Usage: /rebuttal list [id]',
+      action: async (_context, args) => {
+        const rebuttals = await listRebuttals();
+        const id = args.trim();
+        if (id) {
+          if (!rebuttals.some((r) => r.id === id)) {
+            return error(`No rebuttal "${id}".`);
+          }
+          activeRebuttalId = id;
+          return info(`Switched to ${id}.`);
+        }
+        if (rebuttals.length === 0) {
+          return info(NO_REBUTTAL);
+        }
+        return info(
+          rebuttals
+            .map((r) => {
+              const done = r.points.filter((p) => p.status === 'done').length;
+              return `${r.id}  ${done}/${r.points.length} done  ${r.title}`;
+            })
+            .join('\n'),
+        );
+      },
+    },
+  ],
+};
@@ -0,0 +1,101 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest';
+import { EventEmitter } from 'node:events';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { Config } from '@iechor/research-cli-core';
+import { recordCommand } from './recordCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+import {
+  getSessionRecorder,
+  RecordedStream,
+  setRecordedStream,
+} from '../utils/sessionRecorder.js';
+
+describe('recordCommand', () => {
+  let tempDir: string;
+
+  const subCommand = (name: string) =>
+    recordCommand.subCommands!.find((c) => c.name === name)!;
+  const context = () =>
+    createMockCommandContext({
+      services: {
+        config: { getTargetDir: () => tempDir } as unknown as Config,
+      },
+    });
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'record-command-'));
+    setRecordedStream(
+      Object.assign(new EventEmitter(), {
+        columns: 80,
+        rows: 24,
+        write: () => true,
+      }) as unknown as RecordedStream,
+    );
+  });
+
+  afterEach(() => {
+    getSessionRecorder().stop();
+    setRecordedStream(process.stdout);
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should record to the file given', async () => {
+    const filePath = path.join(tempDir, 'bug.cast');
+
+    const started = await subCommand('start').action!(
+      context(),
+      'bug.cast --title Scroll bug',
+    );
+    const status = await recordCommand.action!(context(), '');
+    const stopped = await subCommand('stop').action!(context(), '');
+
+    expect(started).toMatchObject({
+      messageType: 'info',
+      content: expect.stringContaining(`Recording the session to ${filePath}.`),
+    });
+    expect(status).toMatchObject({
+      content: expect.stringContaining(`Recording to ${filePath} for 0m 0s.`),
+    });
+    expect(stopped).toMatchObject({
+      content: expect.stringContaining(`asciinema play ${filePath}`),
+    });
+    const header = JSON.parse(fs.readFileSync(filePath, 'utf8').split('\n')[0]);
+    expect(header).toMatchObject({ version: 2, title: 'Scroll bug' });
+  });
+
+  it('should name the file after the time by default', async () => {
+    await subCommand('start').action!(context(), '');
+    getSessionRecorder().stop();
+
+    expect(fs.readdirSync(tempDir)).toEqual([
+      expect.stringMatching(
+        /^recording-\d{4}-\d{2}-\d{2}-\d{2}-\d{2}-\d{2}\.cast$/,
+      ),
+    ]);
+  });
+
+  it('should not start twice', async () => {
+    await subCommand('start').action!(context(), 'a.cast');
+
+    const result = await subCommand('start').action!(context(), 'b.cast');
+
+    expect(result).toMatchObject({
+      messageType: 'error',
+      content: expect.stringContaining('Already recording to'),
+    });
+  });
+
+  it('should say when nothing is recorded', async () => {
+    expect(await subCommand('stop').action!(context(), '')).toMatchObject({
+      content: 'Nothing is being recorded. Start with /record start.',
+    });
+  });
+});
@@ -0,0 +1,97 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import path from 'node:path';
+import { getErrorMessage } from '@iechor/research-cli-core';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import {
+  defaultRecordingFileName,
+  getRecordedStream,
+  getSessionRecorder,
+} from '../utils/sessionRecorder.js';
+
+const START_USAGE = 'Usage: /record start [file.cast] [--title <text>]';
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+function formatDuration(seconds: number): string {
+  const total = Math.round(seconds);
+  return `${Math.floor(total / 60)}m ${total % 60}s`;
+}
+
+function stop(): SlashCommandActionReturn {
+  const summary = getSessionRecorder().stop();
+  if (!summary) {
+    return info('Nothing is being recorded. Start with /record start.');
+  }
+  return info(
+    `Saved ${formatDuration(summary.duration)} of the session to ${summary.filePath}. Play it with: asciinema play ${summary.filePath}`,
+  );
+}
+
+export const recordCommand: SlashCommand = {
+  name: 'record',
+  description:
+    'Record the terminal into an asciicast file for playback with asciinema, e.g. for a demo or a UI bug report. Shows whether a recording is running.',
+  action: async () => {
+    const recorder = getSessionRecorder();
+    return recorder.isRecording()
+      ? info(
+          `Recording to ${recorder.getFilePath()} for ${formatDuration(recorder.getElapsed())}. Stop with /record stop.`,
+        )
+      : info('Nothing is being recorded. Start with /record start.');
+  },
+  subCommands: [
+    {
+      name: 'start',
+      description: `Start recording. The file goes to the project directory, named after the current time unless given. ${START_USAGE}`,
+      action: async (context: CommandContext, args: string) => {
+        const match = args.match(/^(.*?)(?:\s*--title\s+(.+))?$/s);
+        const file = match?.[1]?.trim() || undefined;
+        const title = match?.[2]?.trim() || undefined;
+        if (file && file.startsWith('--')) {
+          return error(START_USAGE);
+        }
+        const root = context.services.config?.getTargetDir() ?? process.cwd();
+        const filePath = path.resolve(
+          root,
+          file ?? defaultRecordingFileName(new Date()),
+        );
+        const recorder = getSessionRecorder();
+        if (recorder.isRecording()) {
+          return error(
+            `Already recording to ${recorder.getFilePath()}. Stop with /record stop first.`,
+          );
+        }
+        try {
+          recorder.start(filePath, getRecordedStream(), { title });
+        } catch (e) {
+          return error(
+            `Could not record to ${filePath}: ${getErrorMessage(e)}`,
+          );
+        }
+        return info(
+          `Recording the session to ${filePath}. Stop with /record stop; the recording is also saved when you quit.`,
+        );
+      },
+    },
+    {
+      name: 'stop',
+      description: 'Stop recording and save the file.',
+      action: async () => stop(),
+    },
+  ],
+};
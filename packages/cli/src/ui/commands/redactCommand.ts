@@ -0,0 +1,102 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { getRedactor } from '@iechor/research-cli-core';
+import { SlashCommand, SlashCommandActionReturn } from './types.js';
+
+const NOT_ENABLED =
+  'Redaction is not enabled. Add a "redaction" block to your settings.json to enable it.';
+
+export const redactCommand: SlashCommand = {
+  name: 'redact',
+  description: 'Inspect redaction of prompts sent to remote providers.',
+  subCommands: [
+    {
+      name: 'status',
+      description: 'Show whether redaction is enabled and what was redacted.',
+      action: (): SlashCommandActionReturn => {
+        const redactor = getRedactor();
+        if (!redactor.isEnabled()) {
+          return { type: 'message', messageType: 'info', content: NOT_ENABLED };
+        }
+        const count = redactor.getTotalCount();
+        return {
+          type: 'message',
+          messageType: 'info',
+          content:
+            count > 0
+              ? `Redaction is enabled. ${count} value(s) have been redacted from requests this session. Use /redact show to see them.`
+              : 'Redaction is enabled. Nothing has been redacted this session.',
+        };
+      },
+    },
+    {
+      name: 'show',
+      description:
+        'Show the original values that were redacted (local only, never sent).',
+      action: (): SlashCommandActionReturn => {
+        const redactor = getRedactor();
+        if (!redactor.isEnabled()) {
+          return { type: 'message', messageType: 'info', content: NOT_ENABLED };
+        }
+        const matches = redactor.getRecentMatches();
+        if (matches.length === 0) {
+          return {
+            type: 'message',
+            messageType: 'info',
+            content: 'Nothing has been redacted this session.',
+          };
+        }
+        const lines = matches.map(
+          (match) => `  ${match.rule}: ${match.original} → ${match.replacement}`,
+        );
+        return {
+          type: 'message',
+          messageType: 'info',
+          content: `Unredacted view (shown locally only):\n${lines.join('\n')}`,
+        };
+      },
+    },
+    {
+      name: 'test',
+      description: 'Preview how the rules redact some text.',
+      action: (_context, args): SlashCommandActionReturn => {
+        const redactor = getRedactor();
+        if (!redactor.isEnabled()) {
+          return { type: 'message', messageType: 'info', content: NOT_ENABLED };
+        }
+        if (!args || args.trim() === '') {
+          return {
+            type: 'message',
+            messageType: 'error',
+            content: 'Usage: /redact test <text>',
+          };
+        }
+        const { value, matches } = redactor.redactText(args.trim());
+        return {
+          type: 'message',
+          messageType: 'info',
+          content:
+            matches.length > 0
+              ? `${value}\n(${matches.length} match(es): ${matches.map((m) => m.rule).join(', ')})`
+              : `No rules matched:\n${value}`,
+        };
+      },
+    },
+    {
+      name: 'clear',
+      description: 'Forget the redacted values kept for /redact show.',
+      action: (): SlashCommandActionReturn => {
+        getRedactor().clearRecentMatches();
+        return {
+          type: 'message',
+          messageType: 'info',
+          content: 'Cleared the local list of redacted values.',
+        };
+      },
+    },
+  ],
+};
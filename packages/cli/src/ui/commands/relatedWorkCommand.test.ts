@@ -0,0 +1,90 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { Config } from '@iechor/research-cli-core';
+import { relatedWorkCommand } from './relatedWorkCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+
+const BIB = `@article{vaswani2017,
+  title = {Attention Is All You Need},
+  year = {2017},
+}
+
+@article{bahdanau2015,
+  title = {Neural Machine Translation by Jointly Learning to Align and Translate},
+  year = {2015},
+}
+`;
+
+const DRAFT =
+  '\\section{Related Work}\nAttention \\cite{bahdanau2015} led to the Transformer \\cite{vaswani2017,devlin2019}. \\ungrounded{It is used everywhere}.';
+
+describe('relatedWorkCommand', () => {
+  let tempDir: string;
+  const generateContent = vi.fn();
+  const addHistory = vi.fn();
+
+  const context = () =>
+    createMockCommandContext({
+      services: {
+        config: {
+          getTargetDir: () => tempDir,
+          getResearchClient: () => ({ generateContent, addHistory }),
+        } as unknown as Config,
+      },
+    });
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'related-work-'));
+    fs.writeFileSync(path.join(tempDir, 'references.bib'), BIB);
+    generateContent.mockResolvedValue({
+      candidates: [{ content: { parts: [{ text: DRAFT }] } }],
+    });
+  });
+
+  afterEach(() => {
+    vi.clearAllMocks();
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should write a draft that cites only bibliography keys', async () => {
+    const commandContext = context();
+    const result = await relatedWorkCommand.action!(
+      commandContext,
+      'attention for translation --keys vaswani2017 bahdanau2015 --output sections/related.tex',
+    );
+
+    const outputPath = path.join(tempDir, 'sections', 'related.tex');
+    expect(result).toMatchObject({
+      messageType: 'info',
+      content: `Wrote the draft to ${outputPath}.`,
+    });
+    const latex = fs.readFileSync(outputPath, 'utf8');
+    expect(latex).toContain('\\cite{vaswani2017}.');
+    expect(latex).not.toContain('devlin2019');
+
+    const [item] = vi.mocked(commandContext.ui.addItem).mock.calls[0];
+    const { text } = item as { text: string };
+    expect(text).toContain(
+      'Removed citations of keys not in the bibliography: devlin2019',
+    );
+    expect(text).toContain('  - It is used everywhere');
+    expect(generateContent.mock.calls[0][0][0].parts[0].text).toContain(
+      'attention for translation',
+    );
+    expect(addHistory).toHaveBeenCalled();
+  });
+
+  it('should require a topic and keys', async () => {
+    const result = await relatedWorkCommand.action!(context(), 'attention');
+    expect(result).toMatchObject({ messageType: 'error' });
+    expect(generateContent).not.toHaveBeenCalled();
+  });
+});
@@ -0,0 +1,150 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import {
+  RelatedWorkDraft,
+  draftRelatedWork,
+  getErrorMessage,
+  loadPaperSources,
+} from '@iechor/research-cli-core';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import { MessageType } from '../types.js';
+import { resolveBibFile } from './bibCommand.js';
+
+const USAGE =
+  'Usage: /related-work <topic> --keys <key1,key2,...> [--file <bib>] [--output <section.tex>]';
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+interface RelatedWorkArgs {
+  topic: string;
+  keys: string[];
+  file?: string;
+  output?: string;
+}
+
+function parseArgs(args: string): RelatedWorkArgs {
+  const parsed: RelatedWorkArgs = { topic: '', keys: [] };
+  const words: string[] = [];
+  const tokens = args.trim().split(/\s+/).filter(Boolean);
+  for (let i = 0; i < tokens.length; i++) {
+    if (tokens[i] === '--keys') {
+      // Keys may be separated by commas or spaces, or pasted as \cite{a,b}
+      while (i + 1 < tokens.length && !tokens[i + 1].startsWith('--')) {
+        parsed.keys.push(
+          ...tokens[++i]
+            .replace(/^\\cite\w*\{|\}$/g, '')
+            .split(',')
+            .filter(Boolean),
+        );
+      }
+    } else if (tokens[i] === '--file') {
+      parsed.file = tokens[++i];
+    } else if (tokens[i] === '--output') {
+      parsed.output = tokens[++i];
+    } else {
+      words.push(tokens[i]);
+    }
+  }
+  parsed.topic = words.join(' ');
+  return parsed;
+}
+
+function summarize(draft: RelatedWorkDraft): string[] {
+  const notes: string[] = [];
+  if (draft.unknownKeys.length > 0) {
+    notes.push(
+      `Removed citations of keys not in the bibliography: ${draft.unknownKeys.join(', ')}`,
+    );
+  }
+  if (draft.ungroundedClaims.length > 0) {
+    notes.push(
+      `${draft.ungroundedClaims.length} claims could not be grounded in the cited papers and are marked \\ungrounded{...}:`,
+      ...draft.ungroundedClaims.map((claim) => `  - ${claim}`),
+    );
+  }
+  if (draft.uncitedKeys.length > 0) {
+    notes.push(`Not cited: ${draft.uncitedKeys.join(', ')}`);
+  }
+  return notes.length > 0 ? notes : ['Every claim cites one of the papers.'];
+}
+
+export const relatedWorkCommand: SlashCommand = {
+  name: 'related-work',
+  description:
+    'Draft a related-work section that cites only papers in the .bib file. ' +
+    USAGE,
+  action: async (context: CommandContext, args: string) => {
+    const { topic, keys, file, output } = parseArgs(args);
+    const config = context.services.config;
+    if (!topic || keys.length === 0) {
+      return error(USAGE);
+    }
+    if (!config) {
+      return error('Drafting needs a configured model.');
+    }
+
+    let draft: RelatedWorkDraft;
+    let bibFile: string;
+    try {
+      bibFile = resolveBibFile(context, file);
+      const sources = await loadPaperSources(bibFile, keys);
+      context.ui.setDebugMessage(
+        `Drafting related work from ${keys.length} papers...`,
+      );
+      draft = await draftRelatedWork(
+        config.getResearchClient(),
+        topic,
+        sources,
+        context.abortSignal,
+      );
+    } catch (e) {
+      return error(`Could not draft the section: ${getErrorMessage(e)}`);
+    }
+
+    let outputPath: string | undefined;
+    if (output) {
+      outputPath = path.resolve(config.getTargetDir(), output);
+      try {
+        await fs.promises.mkdir(path.dirname(outputPath), { recursive: true });
+        await fs.promises.writeFile(outputPath, draft.latex, 'utf8');
+      } catch (e) {
+        return error(`Could not write ${outputPath}: ${getErrorMessage(e)}`);
+      }
+    }
+
+    context.ui.addItem(
+      {
+        type: MessageType.INFO,
+        text: [draft.latex.trimEnd(), '', ...summarize(draft)].join('\n'),
+      },
+      Date.now(),
+    );
+    await config.getResearchClient()?.addHistory({
+      role: 'user',
+      parts: [
+        {
+          text: `Draft related-work section on "${topic}", citing only keys from ${bibFile}:\n\n${draft.latex}`,
+        },
+      ],
+    });
+    if (outputPath) {
+      return info(`Wrote the draft to ${outputPath}.`);
+    }
+  },
+};
@@ -0,0 +1,95 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { Config, ToolRegistry } from '@iechor/research-cli-core';
+import { repoCommand } from './repoCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+
+describe('repoCommand', () => {
+  let tempDir: string;
+  let registry: ToolRegistry;
+  const addHistory = vi.fn();
+  const setTools = vi.fn();
+
+  const context = () =>
+    createMockCommandContext({
+      services: {
+        config: {
+          getTargetDir: () => tempDir,
+          getToolRegistry: async () => registry,
+          getResearchClient: () => ({ addHistory, setTools }),
+        } as unknown as Config,
+      },
+    });
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'repo-command-'));
+    fs.mkdirSync(path.join(tempDir, 'lab', 'src'), { recursive: true });
+    fs.writeFileSync(
+      path.join(tempDir, 'lab', 'README.md'),
+      '# Lab\n\nTrains small transformers.',
+    );
+    fs.writeFileSync(path.join(tempDir, 'lab', 'src', 'train.py'), 'pass\n');
+    registry = new ToolRegistry({} as Config);
+    addHistory.mockReset();
+    setTools.mockReset();
+  });
+
+  afterEach(() => {
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should add a local repository and register its tools', async () => {
+    const result = await repoCommand.action!(context(), 'lab');
+
+    const root = path.join(tempDir, 'lab');
+    expect(result).toMatchObject({
+      messageType: 'info',
+      content: expect.stringContaining(
+        `Loaded lab: 2 files, checked out at ${root}.\nAdded the file map and README.md, src/train.py to the conversation`,
+      ),
+    });
+    const text = addHistory.mock.calls[0][0].parts[0].text as string;
+    expect(text).toContain('--- README.md ---\n# Lab');
+    expect(text).toContain(`using absolute paths under ${root}`);
+    expect(registry.getTool('repo_read_file')?.description).toContain(
+      'Works only inside the lab repository',
+    );
+    expect(registry.getTool('repo_grep')).toBeDefined();
+    expect(setTools).toHaveBeenCalled();
+  });
+
+  it('should replace the tools of an earlier repository', async () => {
+    fs.mkdirSync(path.join(tempDir, 'other'));
+    await repoCommand.action!(context(), 'lab');
+    await repoCommand.action!(context(), 'other');
+
+    expect(
+      registry.getAllTools().filter((tool) => tool.name === 'repo_grep'),
+    ).toHaveLength(1);
+    expect(registry.getTool('repo_grep')?.displayName).toBe(
+      'SearchText (other)',
+    );
+    expect(await repoCommand.action!(context(), '')).toMatchObject({
+      content: expect.stringContaining(
+        `Loaded: other at ${path.join(tempDir, 'other')}.`,
+      ),
+    });
+  });
+
+  it('should reject paths that are not directories', async () => {
+    expect(await repoCommand.action!(context(), 'missing')).toEqual({
+      type: 'message',
+      messageType: 'error',
+      content: 'Could not load missing: Not a git URL or a directory: missing',
+    });
+    expect(addHistory).not.toHaveBeenCalled();
+  });
+});
@@ -0,0 +1,146 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import {
+  DEFAULT_REPOSITORY_TOKEN_BUDGET,
+  REPOSITORY_GREP_TOOL_NAME,
+  REPOSITORY_READ_FILE_TOOL_NAME,
+  RepositorySnapshot,
+  buildRepositoryContext,
+  cloneRepository,
+  getErrorMessage,
+  parseRepositoryUrl,
+  registerRepositoryTools,
+  scanRepository,
+} from '@iechor/research-cli-core';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+
+const USAGE = 'Usage: /repo <url|path> [--budget <tokens>] [--refresh]';
+
+// The repository the repo_* tools are scoped to
+let activeRepository: RepositorySnapshot | undefined;
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+interface RepoArgs {
+  target?: string;
+  budget: number;
+  refresh: boolean;
+}
+
+function parseArgs(args: string): RepoArgs {
+  const parsed: RepoArgs = {
+    budget: DEFAULT_REPOSITORY_TOKEN_BUDGET,
+    refresh: false,
+  };
+  const tokens = args.trim().split(/\s+/).filter(Boolean);
+  for (let i = 0; i < tokens.length; i++) {
+    if (tokens[i] === '--budget') {
+      parsed.budget = Number(tokens[++i]);
+    } else if (tokens[i] === '--refresh') {
+      parsed.refresh = true;
+    } else if (!parsed.target) {
+      parsed.target = tokens[i];
+    }
+  }
+  return parsed;
+}
+
+/** Clones a remote repository, or checks a local path. */
+async function checkOut(
+  context: CommandContext,
+  target: string,
+  refresh: boolean,
+): Promise<{ root: string; name: string; url?: string }> {
+  const remote = parseRepositoryUrl(target);
+  if (remote) {
+    context.ui.setDebugMessage(`Cloning ${remote.url}...`);
+    return {
+      root: await cloneRepository(remote, refresh),
+      name: remote.name,
+      url: remote.url,
+    };
+  }
+  const root = path.resolve(
+    context.services.config?.getTargetDir() ?? process.cwd(),
+    target,
+  );
+  if (!fs.existsSync(root) || !fs.statSync(root).isDirectory()) {
+    throw new Error(`Not a git URL or a directory: ${target}`);
+  }
+  return { root, name: path.basename(root) };
+}
+
+export const repoCommand: SlashCommand = {
+  name: 'repo',
+  description:
+    'Load a code repository (git URL or local path) for questions about it: adds a file map and its key files to the conversation, and lets the model read and search it. ' +
+    USAGE,
+  action: async (context: CommandContext, args: string) => {
+    const config = context.services.config;
+    const { target, budget, refresh } = parseArgs(args);
+    if (!target) {
+      return info(
+        activeRepository
+          ? `Loaded: ${activeRepository.name} at ${activeRepository.root}. ${USAGE}`
+          : USAGE,
+      );
+    }
+    if (!config) {
+      return error('Loading a repository needs a configured model.');
+    }
+    if (!(budget > 0)) {
+      return error(USAGE);
+    }
+
+    let snapshot: RepositorySnapshot;
+    let repositoryContext: { text: string; includedFiles: string[] };
+    try {
+      const { root, name, url } = await checkOut(context, target, refresh);
+      context.ui.setDebugMessage(`Scanning ${name}...`);
+      snapshot = await scanRepository(root, name, url);
+      repositoryContext = await buildRepositoryContext(snapshot, budget);
+      registerRepositoryTools(
+        await config.getToolRegistry(),
+        root,
+        name,
+        config,
+      );
+      const client = config.getResearchClient();
+      await client.setTools();
+      await client.addHistory({
+        role: 'user',
+        parts: [
+          {
+            text: `${repositoryContext.text}\n\nAnswer my questions about this repository from its code. Read files with ${REPOSITORY_READ_FILE_TOOL_NAME} and search them with ${REPOSITORY_GREP_TOOL_NAME}, using absolute paths under ${root}, rather than guessing.`,
+          },
+        ],
+      });
+    } catch (e) {
+      return error(`Could not load ${target}: ${getErrorMessage(e)}`);
+    }
+    activeRepository = snapshot;
+    return info(
+      [
+        `Loaded ${snapshot.name}${snapshot.commit ? ` at ${snapshot.commit}` : ''}: ${snapshot.files.length}${snapshot.truncated ? '+' : ''} files, checked out at ${snapshot.root}.`,
+        `Added the file map${repositoryContext.includedFiles.length > 0 ? ` and ${repositoryContext.includedFiles.join(', ')}` : ''} to the conversation (about ${Math.ceil(repositoryContext.text.length / 4).toLocaleString('en-US')} tokens).`,
+        `The model can now read and search the repository with ${REPOSITORY_READ_FILE_TOOL_NAME} and ${REPOSITORY_GREP_TOOL_NAME}.`,
+      ].join('\n'),
+    );
+  },
+};
@@ -77,7 +77,7 @@ export const investigateCommand: SlashCommand = {
           const databaseList = String(databases).split(',').map((db: string) => db.trim());
 
           // Validate databases
-          const validDatabases = ['arxiv', 'scholar', 'pubmed', 'ieee'];
+          const validDatabases = ['arxiv', 'scholar', 'pubmed', 'biorxiv', 'medrxiv', 'ieee'];
           for (const db of databaseList) {
             validateOption(db, validDatabases, 'database');
           }
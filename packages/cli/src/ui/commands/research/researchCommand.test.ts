@@ -21,8 +21,10 @@ describe('researchCommand', () => {
         logger: {} as any,
       },
       ui: {
+        history: [],
         addItem: vi.fn(),
         clear: vi.fn(),
+        loadOlderHistory: vi.fn(),
         setDebugMessage: vi.fn(),
       },
       session: {
@@ -81,6 +83,24 @@ describe('researchCommand', () => {
       }
     });
 
+    it('should pass the selected sources through', async () => {
+      const searchCommand = researchCommand.subCommands?.find(
+        (cmd) => cmd.name === 'search',
+      );
+
+      if (searchCommand?.action) {
+        const result = await searchCommand.action(
+          mockContext,
+          'CRISPR --db=pubmed,biorxiv,scholar',
+        );
+
+        expect(result).toMatchObject({
+          type: 'tool',
+          toolArgs: { databases: ['pubmed', 'biorxiv', 'google_scholar'] },
+        });
+      }
+    });
+
     it('should handle missing query argument', async () => {
       const searchCommand = researchCommand.subCommands?.find(
         (cmd) => cmd.name === 'search',
@@ -118,6 +138,35 @@ describe('researchCommand', () => {
     });
   });
 
+  describe('metasearch subcommand', () => {
+    it('should validate sources and year before searching', async () => {
+      const metasearchCommand = researchCommand.subCommands?.find(
+        (cmd) => cmd.name === 'metasearch',
+      );
+      expect(metasearchCommand).toBeDefined();
+
+      if (metasearchCommand?.action) {
+        await metasearchCommand.action(mockContext, 'gnn --sources=scopus');
+        await metasearchCommand.action(mockContext, 'gnn --year=recent');
+
+        expect(mockContext.ui.addItem).toHaveBeenCalledWith(
+          {
+            type: MessageType.ERROR,
+            text: expect.stringContaining("Invalid value 'scopus'"),
+          },
+          expect.any(Number),
+        );
+        expect(mockContext.ui.addItem).toHaveBeenCalledWith(
+          {
+            type: MessageType.ERROR,
+            text: expect.stringContaining("Invalid --year 'recent'"),
+          },
+          expect.any(Number),
+        );
+      }
+    });
+  });
+
   describe('analyze subcommand', () => {
     it('should handle valid analyze command', async () => {
       const analyzeCommand = researchCommand.subCommands?.find(
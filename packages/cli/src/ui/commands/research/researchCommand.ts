@@ -28,13 +28,20 @@ import {
   validateOption,
   executeWithErrorHandling,
 } from './utils/errorHandler.js';
+import {
+  METASEARCH_SOURCES,
+  MetasearchSource,
+  formatMetasearchResults,
+  metasearch,
+} from '@iechor/research-cli-core';
 import type { ResearchToolRegistry } from '@iechor/research-cli-core';
 
 /**
  * /research 命令实现
  *
  * 子命令：
- * - search <query> [--db=arxiv,pubmed,ieee] [--source=arxiv|scholar] [--limit=10] - 文献搜索
+ * - search <query> [--db=arxiv,pubmed,biorxiv] [--source=arxiv|scholar|pubmed|biorxiv|medrxiv] [--limit=10] - 文献搜索
+ * - metasearch <query> [--sources=arxiv,semantic_scholar,crossref,pubmed] [--limit=10] [--year=2019-2023] - 多源合并搜索
  * - analyze <file> [--type=structure|grammar|style] - 文档分析
  * - experiment <language> <method> - 生成实验代码
  * - data <operation> <file> - 数据分析
@@ -65,7 +72,14 @@ export const researchCommand: SlashCommand = {
             : [dbParam];
 
           // Validate each database
-          const validDatabases = ['arxiv', 'scholar', 'pubmed', 'ieee'];
+          const validDatabases = [
+            'arxiv',
+            'scholar',
+            'pubmed',
+            'biorxiv',
+            'medrxiv',
+            'ieee',
+          ];
           for (const db of databases) {
             validateOption(db, validDatabases, 'database');
           }
@@ -85,7 +99,10 @@ export const researchCommand: SlashCommand = {
             toolName: 'research_manage_bibliography',
             toolArgs: {
               query,
-              databases: databases,
+              // scholar is the short name of the google_scholar source
+              databases: databases.map((db) =>
+                db === 'scholar' ? 'google_scholar' : db,
+              ),
               maxResults: limit,
             },
           };
@@ -105,6 +122,90 @@ export const researchCommand: SlashCommand = {
       },
     },
 
+    {
+      name: 'metasearch',
+      description:
+        'Search arXiv, Semantic Scholar, Crossref and PubMed at once and merge the results',
+      action: async (
+        context: CommandContext,
+        args: string,
+      ): Promise<void | SlashCommandActionReturn> => {
+        try {
+          const parsed = parseCommandArgs(args);
+          validateArguments(parsed.positional, 1, 'research metasearch');
+
+          const query = parsed.positional.join(' ');
+          const sources = getOptionValue(
+            parsed.options,
+            'sources',
+            METASEARCH_SOURCES.join(','),
+          )
+            .split(',')
+            .map((source) => source.trim())
+            .filter(Boolean);
+          for (const source of sources) {
+            validateOption(source, [...METASEARCH_SOURCES], 'sources');
+          }
+          const limit = getOptionValue(parsed.options, 'limit', 10);
+          const year = String(getOptionValue(parsed.options, 'year', ''));
+          let yearRange: { start: number; end: number } | undefined;
+          if (year) {
+            const match = year.match(/^(\d{4})(?:-(\d{4}))?$/);
+            if (!match) {
+              throw new Error(
+                `Invalid --year '${year}'. Use a year or a range like 2019-2023`,
+              );
+            }
+            yearRange = {
+              start: Number(match[1]),
+              end: Number(match[2] ?? match[1]),
+            };
+          }
+
+          context.ui.addItem(
+            {
+              type: MessageType.INFO,
+              text: formatInfo(
+                `Searching ${sources.join(', ')} for: "${query}" (limit: ${limit})`,
+              ),
+            },
+            Date.now(),
+          );
+
+          const result = await metasearch(query, {
+            sources: sources as MetasearchSource[],
+            maxResults: limit,
+            yearRange,
+          });
+          const text = formatMetasearchResults(result);
+          context.ui.addItem({ type: MessageType.INFO, text }, Date.now());
+
+          if (result.hits.length > 0) {
+            await context.services.config?.getResearchClient()?.addHistory({
+              role: 'user',
+              parts: [
+                {
+                  text: `I searched the scholarly literature for "${query}". The merged results:\n\n${text}`,
+                },
+              ],
+            });
+          }
+        } catch (error) {
+          const errorResult = handleResearchError(error);
+          context.ui.addItem(
+            {
+              type: MessageType.ERROR,
+              text:
+                errorResult.message +
+                '\n\nSuggestions:\n' +
+                errorResult.suggestions.map((s) => `  • ${s}`).join('\n'),
+            },
+            Date.now(),
+          );
+        }
+      },
+    },
+
     {
       name: 'analyze',
       description: 'Analyze document structure, grammar, or style',
@@ -479,6 +580,8 @@ export const researchCommand: SlashCommand = {
           usage: '/research <subcommand> [options]',
           examples: [
             '/research search "machine learning" --db=arxiv,pubmed --limit=5',
+            '/research search "CRISPR screen" --db=pubmed,biorxiv,medrxiv',
+            '/research metasearch graph neural networks --year=2019-2023',
             '/research analyze paper.pdf --type=structure',
             '/research experiment python ml --output=./my-experiments',
             '/research data describe dataset.csv --format=report',
@@ -488,7 +591,25 @@ export const researchCommand: SlashCommand = {
               name: 'source',
               description: 'Literature search source',
               type: 'string',
-              choices: ['arxiv', 'scholar', 'pubmed', 'ieee'],
+              choices: [
+                'arxiv',
+                'scholar',
+                'pubmed',
+                'biorxiv',
+                'medrxiv',
+                'ieee',
+              ],
+            },
+            {
+              name: 'sources',
+              description:
+                'Comma-separated metasearch sources (arxiv, semantic_scholar, crossref, pubmed)',
+              type: 'string',
+            },
+            {
+              name: 'year',
+              description: 'Metasearch publication year or range, e.g. 2019-2023',
+              type: 'string',
             },
             {
               name: 'limit',
@@ -4,6 +4,9 @@
  * SPDX-License-Identifier: Apache-2.0
  */
 
+import stringWidth from 'string-width';
+import { padToWidth } from '../../../utils/textUtils.js';
+
 /**
  * ANSI颜色代码
  */
@@ -69,9 +72,9 @@ export function formatTable(
   const columnWidths = columns.map((col) => {
     if (col.width) return col.width;
 
-    const headerWidth = col.title.length;
+    const headerWidth = stringWidth(col.title);
     const maxDataWidth = Math.max(
-      ...data.map((row) => String(row[col.key] || '').length),
+      ...data.map((row) => stringWidth(String(row[col.key] || ''))),
     );
     return Math.max(headerWidth, maxDataWidth);
   });
@@ -92,7 +95,10 @@ export function formatTable(
   if (config.showHeaders) {
     const headerRow = columns
       .map((col, i) =>
-        colorize(padString(col.title, columnWidths[i], col.align), headerColor),
+        colorize(
+          padToWidth(col.title, columnWidths[i], col.align),
+          headerColor,
+        ),
       )
       .join(colorize(' │ ', borderColor));
 
@@ -115,7 +121,7 @@ export function formatTable(
       .map((col, i) => {
         const value = String(row[col.key] || '');
         const color = col.color || getColorByScheme(config.colorScheme, 'data');
-        return colorize(padString(value, columnWidths[i], col.align), color);
+        return colorize(padToWidth(value, columnWidths[i], col.align), color);
       })
       .join(colorize(' │ ', borderColor));
 
@@ -241,28 +247,6 @@ function colorize(text: string, color: string): string {
   return color + text + Colors.Reset;
 }
 
-/**
- * 辅助函数：填充字符串到指定宽度
- */
-function padString(
-  str: string,
-  width: number,
-  align: 'left' | 'center' | 'right' = 'left',
-): string {
-  if (str.length >= width) return str.substring(0, width);
-
-  const padding = width - str.length;
-  switch (align) {
-    case 'center':
-      const leftPad = Math.floor(padding / 2);
-      const rightPad = padding - leftPad;
-      return ' '.repeat(leftPad) + str + ' '.repeat(rightPad);
-    case 'right':
-      return ' '.repeat(padding) + str;
-    default:
-      return str + ' '.repeat(padding);
-  }
-}
 
 /**
  * 辅助函数：创建边框行
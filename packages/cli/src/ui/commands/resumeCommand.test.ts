@@ -0,0 +1,120 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, vi, beforeEach, afterEach } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { Config } from '@iechor/research-cli-core';
+import { resumeCommand } from './resumeCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+import { CommandContext } from './types.js';
+import { SessionStore } from '../utils/sessionStore.js';
+
+const { sessionsDir } = vi.hoisted(() => ({ sessionsDir: { path: '' } }));
+
+vi.mock('../utils/sessionStore.js', async (importOriginal) => ({
+  ...(await importOriginal<typeof import('../utils/sessionStore.js')>()),
+  getSessionsDir: () => sessionsDir.path,
+}));
+
+describe('resumeCommand', () => {
+  let context: CommandContext;
+  let resetChat: ReturnType<typeof vi.fn>;
+  let addHistory: ReturnType<typeof vi.fn>;
+
+  const store = (id: string, prompt: string, secondsAgo: number) => {
+    const session = new SessionStore(sessionsDir.path, id);
+    const timestamp = new Date().toISOString();
+    session.append({ role: 'user', content: prompt, timestamp });
+    session.append({ role: 'model', content: `About ${prompt}`, timestamp });
+    const time = new Date(Date.now() - secondsAgo * 1000);
+    fs.utimesSync(path.join(sessionsDir.path, `${id}.jsonl`), time, time);
+  };
+
+  beforeEach(() => {
+    sessionsDir.path = fs.mkdtempSync(path.join(os.tmpdir(), 'resume-'));
+    resetChat = vi.fn();
+    addHistory = vi.fn();
+    context = createMockCommandContext({
+      services: {
+        config: {
+          getSessionId: () => 'current',
+          getResearchClient: () => ({ resetChat, addHistory }),
+        } as unknown as Config,
+      },
+    });
+  });
+
+  afterEach(() => {
+    fs.rmSync(sessionsDir.path, { recursive: true, force: true });
+  });
+
+  it('should say when there is nothing to resume', async () => {
+    store('current', 'this session', 0);
+    expect(await resumeCommand.action!(context, '')).toMatchObject({
+      messageType: 'info',
+      content: 'There is no earlier session to resume.',
+    });
+  });
+
+  it('should resume the most recent earlier session', async () => {
+    store('current', 'this session', 0);
+    store('older-session', 'graphs', 120);
+    store('recent-session', 'trees', 60);
+
+    expect(await resumeCommand.action!(context, '')).toMatchObject({
+      messageType: 'info',
+      content: expect.stringContaining('Resumed session recent-s'),
+    });
+    expect(resetChat).toHaveBeenCalled();
+    expect(addHistory.mock.calls.map(([content]) => content)).toEqual([
+      { role: 'user', parts: [{ text: 'trees' }] },
+      { role: 'model', parts: [{ text: 'About trees' }] },
+    ]);
+    expect(context.ui.clear).toHaveBeenCalled();
+    expect(context.ui.addItem).toHaveBeenCalledWith(
+      { type: 'user', text: 'trees' },
+      expect.any(Number),
+    );
+  });
+
+  it('should resume a session by the start of its id', async () => {
+    store('older-session', 'graphs', 120);
+    store('recent-session', 'trees', 60);
+
+    await resumeCommand.action!(context, 'older');
+    expect(addHistory).toHaveBeenCalledWith({
+      role: 'user',
+      parts: [{ text: 'graphs' }],
+    });
+  });
+
+  it('should list the earlier sessions', async () => {
+    store('current', 'this session', 0);
+    store('older-session', 'graphs', 120);
+
+    const result = await resumeCommand.action!(context, 'list');
+    expect(result).toMatchObject({ messageType: 'info' });
+    expect(JSON.stringify(result)).toContain('older-se');
+    expect(JSON.stringify(result)).toContain('2 messages  graphs');
+    expect(JSON.stringify(result)).not.toContain('this session');
+  });
+
+  it('should report an unknown session', async () => {
+    store('older-session', 'graphs', 120);
+    expect(await resumeCommand.action!(context, 'nope')).toMatchObject({
+      messageType: 'error',
+    });
+  });
+
+  it('should complete the session ids', async () => {
+    store('older-session', 'graphs', 120);
+    expect(await resumeCommand.completion!(context, 'ol')).toEqual([
+      'older-session',
+    ]);
+  });
+});
@@ -0,0 +1,107 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import {
+  StoredSessionInfo,
+  getSessionsDir,
+  listSessions,
+  loadSession,
+  toChatHistory,
+  toHistoryItems,
+} from '../utils/sessionStore.js';
+import {
+  getSessionManager,
+  getSessionStoreId,
+} from '../utils/sessionManager.js';
+
+const USAGE =
+  'Usage: /resume [list | <session>]. Without a session, the most recent one before this one is resumed.';
+// Characters of a session id shown by /resume list; any prefix will do
+const SHORT_ID_LENGTH = 8;
+const MAX_TITLE_LENGTH = 60;
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+/** The stored sessions other than this one, the most recent first. */
+function earlierSessions(context: CommandContext): StoredSessionInfo[] {
+  const sessionId = context.services.config?.getSessionId();
+  const storeId =
+    sessionId &&
+    getSessionStoreId(sessionId, getSessionManager().getCurrentName());
+  return listSessions(getSessionsDir()).filter(
+    (session) => session.id !== storeId,
+  );
+}
+
+function describeSession(session: StoredSessionInfo): string {
+  const title =
+    session.title.length > MAX_TITLE_LENGTH
+      ? `${session.title.slice(0, MAX_TITLE_LENGTH - 3)}...`
+      : session.title;
+  return `${session.id.slice(0, SHORT_ID_LENGTH)}  ${session.modified.toLocaleString()}  ${session.messageCount} message${session.messageCount === 1 ? '' : 's'}  ${title}`;
+}
+
+export const resumeCommand: SlashCommand = {
+  name: 'resume',
+  description:
+    'Resume the conversation of an earlier session, after a restart. ' +
+    USAGE,
+  completion: async (context, partialArg) =>
+    ['list', ...earlierSessions(context).map((session) => session.id)].filter(
+      (option) => option.startsWith(partialArg),
+    ),
+  action: async (context, args) => {
+    const arg = args.trim();
+    const sessions = earlierSessions(context);
+    if (arg === 'list') {
+      return info(
+        sessions.length === 0
+          ? 'No earlier sessions are stored.'
+          : `Stored sessions, the most recent first:\n${sessions.map((session) => `  ${describeSession(session)}`).join('\n')}`,
+      );
+    }
+    if (sessions.length === 0) {
+      return info('There is no earlier session to resume.');
+    }
+    const session = arg
+      ? sessions.find((candidate) => candidate.id.startsWith(arg))
+      : sessions[0];
+    if (!session) {
+      return error(
+        `No stored session starts with ${arg}. /resume list shows them.`,
+      );
+    }
+
+    const client = context.services.config?.getResearchClient();
+    if (!client) {
+      return error('No chat client available to resume the conversation.');
+    }
+    const messages = loadSession(session.filePath);
+    // A fresh chat keeps the environment context it starts with
+    await client.resetChat();
+    for (const content of toChatHistory(messages)) {
+      await client.addHistory(content);
+    }
+    context.ui.clear();
+    for (const item of toHistoryItems(messages)) {
+      context.ui.addItem(item, Date.now());
+    }
+    return info(
+      `Resumed session ${session.id.slice(0, SHORT_ID_LENGTH)} of ${session.modified.toLocaleString()}.`,
+    );
+  },
+};
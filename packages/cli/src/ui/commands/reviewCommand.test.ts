@@ -0,0 +1,116 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { Content } from '@google/genai';
+import { Config, listReviews } from '@iechor/research-cli-core';
+import { reviewCommand } from './reviewCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+
+describe('reviewCommand', () => {
+  let tempDir: string;
+  let history: Content[];
+  const addHistory = vi.fn(async (content: Content) => {
+    history.push(content);
+  });
+
+  const subCommand = (name: string) =>
+    reviewCommand.subCommands!.find((c) => c.name === name)!;
+
+  const context = () =>
+    createMockCommandContext({
+      services: {
+        config: {
+          getTargetDir: () => tempDir,
+          getResearchClient: () => ({
+            addHistory,
+            getHistory: () => history,
+          }),
+        } as unknown as Config,
+      },
+    });
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'review-command-'));
+    // Reviews are stored under the home directory
+    vi.stubEnv('HOME', tempDir);
+    history = [];
+    fs.writeFileSync(path.join(tempDir, 'paper.pdf'), '%PDF-1.7');
+  });
+
+  afterEach(() => {
+    vi.unstubAllEnvs();
+    vi.clearAllMocks();
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should walk through the venue form and export it', async () => {
+    const startContext = context();
+    await reviewCommand.action!(startContext, 'paper.pdf --venue acl');
+
+    expect(addHistory).toHaveBeenCalledWith(
+      expect.objectContaining({
+        parts: expect.arrayContaining([
+          { inlineData: { mimeType: 'application/pdf', data: 'JVBERi0xLjc=' } },
+        ]),
+      }),
+    );
+    expect(startContext.ui.addItem).toHaveBeenCalledWith(
+      expect.objectContaining({
+        text: expect.stringContaining('Next: Paper Summary:'),
+      }),
+      expect.any(Number),
+    );
+    // Resuming the review does not send the paper again
+    await reviewCommand.action!(context(), 'paper.pdf --venue acl');
+    expect(addHistory).toHaveBeenCalledTimes(1);
+
+    const setContext = context();
+    await subCommand('set').action!(setContext, 'summary A new benchmark.');
+    expect(setContext.ui.addItem).toHaveBeenCalledWith(
+      expect.objectContaining({
+        text: expect.stringContaining('Next: Summary Of Strengths:'),
+      }),
+      expect.any(Number),
+    );
+    expect(
+      await subCommand('set').action!(context(), 'overall 9'),
+    ).toMatchObject({
+      messageType: 'error',
+      content: 'Overall Assessment is a score from 1 to 5.',
+    });
+    await subCommand('set').action!(context(), 'overall 4');
+
+    const result = await subCommand('export').action!(
+      context(),
+      'reviews/paper.md',
+    );
+    const exported = path.join(tempDir, 'reviews', 'paper.md');
+    expect(result).toMatchObject({
+      content: `Exported the review to ${exported}.`,
+    });
+    const markdown = fs.readFileSync(exported, 'utf8');
+    expect(markdown).toContain('# ACL Rolling Review review: paper.pdf');
+    expect(markdown).toContain('## Overall Assessment\n\n4: Conference');
+
+    const [review] = await listReviews();
+    expect(review.answers).toEqual({ summary: 'A new benchmark.', overall: 4 });
+  });
+
+  it('should reject unknown venues', async () => {
+    const result = await reviewCommand.action!(
+      context(),
+      'paper.pdf --venue cvpr',
+    );
+    expect(result).toMatchObject({
+      messageType: 'error',
+      content: expect.stringContaining('Unknown venue "cvpr"'),
+    });
+  });
+});
@@ -0,0 +1,293 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import {
+  PaperReview,
+  REVIEW_TEMPLATES,
+  ReviewVenue,
+  createReview,
+  describeReviewField,
+  draftReviewField,
+  extractPdfText,
+  findReviewField,
+  formatReviewForm,
+  formatReviewMarkdown,
+  getErrorMessage,
+  isReviewVenue,
+  listReviews,
+  nextReviewField,
+  saveReview,
+  setReviewAnswer,
+} from '@iechor/research-cli-core';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import { MessageType } from '../types.js';
+
+const DEFAULT_VENUE: ReviewVenue = 'neurips';
+
+const NO_REVIEW = 'No review in progress. Start one with /review <pdf>.';
+
+// The review that set, draft and export work on; defaults to the most
+// recently updated one.
+let activeReviewId: string | undefined;
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+async function getActiveReview(): Promise<PaperReview | undefined> {
+  const reviews = await listReviews();
+  return reviews.find((r) => r.id === activeReviewId) ?? reviews[0];
+}
+
+/** Progress through the form followed by what to fill in next. */
+function formatProgress(review: PaperReview): string {
+  const fields = REVIEW_TEMPLATES[review.venue].fields;
+  const done = fields.filter((f) => review.answers[f.id] !== undefined);
+  const next = nextReviewField(review);
+  return [
+    `${REVIEW_TEMPLATES[review.venue].name} review of ${path.basename(review.paper)}: ${done.length}/${fields.length} fields`,
+    ...fields.map(
+      (f) => `  ${review.answers[f.id] === undefined ? '☐' : '☑'} ${f.id}`,
+    ),
+    '',
+    next
+      ? `Next: ${describeReviewField(next)}\n\nAnswer with /review set ${next.id} <text>, or let the model draft it with /review draft ${next.id}.`
+      : 'All fields are filled in. Export the review with /review export [file].',
+  ].join('\n');
+}
+
+export const reviewCommand: SlashCommand = {
+  name: 'review',
+  description:
+    'Review a paper field by field with a venue review form. Usage: /review <pdf> [--venue neurips|icml|acl]',
+  action: async (context: CommandContext, args: string) => {
+    const tokens = args.trim().split(/\s+/).filter(Boolean);
+    const venueIndex = tokens.indexOf('--venue');
+    const venue =
+      venueIndex === -1 ? DEFAULT_VENUE : tokens.splice(venueIndex, 2)[1];
+    const file = tokens.join(' ');
+    if (!file) {
+      return error(
+        'Usage: /review <pdf> [--venue neurips|icml|acl]. /review show displays the review in progress.',
+      );
+    }
+    if (!venue || !isReviewVenue(venue)) {
+      return error(
+        `Unknown venue "${venue ?? ''}". Choose one of: ${Object.keys(REVIEW_TEMPLATES).join(', ')}.`,
+      );
+    }
+    const pdfPath = path.resolve(
+      context.services.config?.getTargetDir() ?? process.cwd(),
+      file,
+    );
+    if (!fs.existsSync(pdfPath)) {
+      return error(`${file} does not exist.`);
+    }
+
+    let review: PaperReview;
+    try {
+      const fresh = createReview(pdfPath, venue);
+      const existing = (await listReviews()).find((r) => r.id === fresh.id);
+      review = existing ?? fresh;
+      if (!existing) {
+        await saveReview(review);
+      }
+    } catch (e) {
+      return error(`Could not start the review: ${getErrorMessage(e)}`);
+    }
+    activeReviewId = review.id;
+
+    // The model gets the paper itself so the review can be discussed, once
+    // per conversation: resuming the review does not send it again
+    const client = context.services.config?.getResearchClient();
+    const intro = `I am reviewing ${path.basename(pdfPath)} for ${REVIEW_TEMPLATES[venue].name}. Help me assess it critically and fairly; do not write the review for me unless I ask.`;
+    const attached = client
+      ?.getHistory()
+      .some((content) => content.parts?.some((part) => part.text === intro));
+    if (client && !attached) {
+      await client.addHistory({
+        role: 'user',
+        parts: [
+          { text: intro },
+          {
+            inlineData: {
+              mimeType: 'application/pdf',
+              data: (await fs.promises.readFile(pdfPath)).toString('base64'),
+            },
+          },
+        ],
+      });
+    }
+    context.ui.addItem(
+      { type: MessageType.INFO, text: formatProgress(review) },
+      Date.now(),
+    );
+  },
+  subCommands: [
+    {
+      name: 'show',
+      description: 'Show the review in progress.',
+      action: async (context) => {
+        const review = await getActiveReview();
+        if (!review) {
+          return error(NO_REVIEW);
+        }
+        context.ui.addItem(
+          {
+            type: MessageType.INFO,
+            text: `${formatReviewForm(review)}\n\n${formatProgress(review)}`,
+          },
+          Date.now(),
+        );
+      },
+    },
+    {
+      name: 'set',
+      description:
+        'Answer a field of the review form. Usage: /review set <field> <text|score>',
+      action: async (context, args) => {
+        const [name, ...rest] = args.trim().split(' ');
+        const review = await getActiveReview();
+        if (!review) {
+          return error(NO_REVIEW);
+        }
+        const field = name && findReviewField(review.venue, name);
+        if (!field) {
+          return error(
+            `Usage: /review set <field> <text|score>, where field is one of: ${REVIEW_TEMPLATES[review.venue].fields.map((f) => f.id).join(', ')}.`,
+          );
+        }
+        try {
+          setReviewAnswer(review, field, rest.join(' '));
+          await saveReview(review);
+        } catch (e) {
+          return error(getErrorMessage(e));
+        }
+        context.ui.addItem(
+          { type: MessageType.INFO, text: formatProgress(review) },
+          Date.now(),
+        );
+      },
+    },
+    {
+      name: 'draft',
+      description:
+        'Let the model draft a field from the paper; edit it with /review set. Usage: /review draft [field]',
+      action: async (context, args) => {
+        const config = context.services.config;
+        const review = await getActiveReview();
+        if (!review) {
+          return error(NO_REVIEW);
+        }
+        if (!config) {
+          return error('Drafting needs a configured model.');
+        }
+        const name = args.trim();
+        const field = name
+          ? findReviewField(review.venue, name)
+          : nextReviewField(review);
+        if (!field) {
+          return error(
+            name
+              ? `${review.venue} reviews have no field "${name}".`
+              : 'All fields are filled in.',
+          );
+        }
+
+        let draft: string;
+        context.ui.setDebugMessage(`Drafting ${field.label}...`);
+        try {
+          draft = await draftReviewField(
+            config.getResearchClient(),
+            review,
+            field,
+            await extractPdfText(review.paper),
+            context.abortSignal,
+          );
+          setReviewAnswer(review, field, draft);
+          await saveReview(review);
+        } catch (e) {
+          return error(
+            `Could not draft ${field.label}: ${getErrorMessage(e)}`,
+          );
+        }
+        context.ui.addItem(
+          {
+            type: MessageType.INFO,
+            text: `${field.label} (draft, saved):\n${draft}\n\n${formatProgress(review)}`,
+          },
+          Date.now(),
+        );
+      },
+    },
+    {
+      name: 'export',
+      description:
+        'Write the review as the venue form (.txt), Markdown (.md) or a structured note (.json). Usage: /review export [file]',
+      action: async (context, args) => {
+        const review = await getActiveReview();
+        if (!review) {
+          return error(NO_REVIEW);
+        }
+        const file = args.trim();
+        if (!file) {
+          context.ui.addItem(
+            { type: MessageType.INFO, text: formatReviewForm(review) },
+            Date.now(),
+          );
+          return;
+        }
+        const filePath = path.resolve(
+          context.services.config?.getTargetDir() ?? process.cwd(),
+          file,
+        );
+        const content = file.endsWith('.json')
+          ? JSON.stringify(review, null, 2)
+          : file.endsWith('.md')
+            ? formatReviewMarkdown(review)
+            : formatReviewForm(review);
+        try {
+          await fs.promises.mkdir(path.dirname(filePath), { recursive: true });
+          await fs.promises.writeFile(filePath, `${content}\n`, 'utf8');
+        } catch (e) {
+          return error(`Could not write ${filePath}: ${getErrorMessage(e)}`);
+        }
+        return info(`Exported the review to ${filePath}.`);
+      },
+    },
+    {
+      name: 'list',
+      description: 'List saved reviews.',
+      action: async () => {
+        const reviews = await listReviews();
+        if (reviews.length === 0) {
+          return info(NO_REVIEW);
+        }
+        return info(
+          reviews
+            .map((review) => {
+              const fields = REVIEW_TEMPLATES[review.venue].fields;
+              const done = fields.filter(
+                (f) => review.answers[f.id] !== undefined,
+              ).length;
+              return `${review.id}  ${REVIEW_TEMPLATES[review.venue].name.padEnd(18)}  ${done}/${fields.length}  ${review.paper}`;
+            })
+            .join('\n'),
+        );
+      },
+    },
+  ],
+};
@@ -0,0 +1,113 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { Config } from '@iechor/research-cli-core';
+import { reviewDiffCommand } from './reviewDiffCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+
+const PATCH = `--- a/eval.py
++++ b/eval.py
+@@ -1,2 +1,2 @@
+ def accuracy(pred, gold):
+-    return (pred == gold).mean()
++    return (pred == gold).sum()
+`;
+
+describe('reviewDiffCommand', () => {
+  let tempDir: string;
+  const generateJson = vi.fn();
+  const addHistory = vi.fn();
+
+  const context = () =>
+    createMockCommandContext({
+      services: {
+        config: {
+          getTargetDir: () => tempDir,
+          getResearchClient: () => ({ generateJson, addHistory }),
+        } as unknown as Config,
+      },
+    });
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'review-diff-command-'));
+    fs.writeFileSync(path.join(tempDir, 'fix.patch'), PATCH);
+    generateJson.mockReset().mockResolvedValue({
+      summary: 'Changes how accuracy is computed.',
+      comments: [
+        {
+          file: 'eval.py',
+          line: 2,
+          severity: 'critical',
+          comment: 'This returns a count, not a fraction.',
+        },
+      ],
+    });
+    addHistory.mockReset();
+  });
+
+  afterEach(() => {
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should review a patch file and export the review', async () => {
+    const ctx = context();
+    const result = await reviewDiffCommand.action!(
+      ctx,
+      'fix.patch --focus metric definitions',
+    );
+
+    expect(result).toEqual({
+      type: 'message',
+      messageType: 'info',
+      content:
+        '1 comment on 1 file. Save them with /review-diff export [file].',
+    });
+    expect(ctx.ui.addItem).toHaveBeenCalledWith(
+      expect.objectContaining({
+        type: 'diff_review',
+        review: expect.objectContaining({
+          source: 'fix.patch',
+          comments: [expect.objectContaining({ file: 'eval.py', line: 2 })],
+        }),
+      }),
+      expect.any(Number),
+    );
+    expect(generateJson.mock.calls[0][0][0].parts[0].text).toContain(
+      'Pay particular attention to: metric definitions',
+    );
+    expect(addHistory).toHaveBeenCalled();
+
+    const exportCommand = reviewDiffCommand.subCommands!.find(
+      (c) => c.name === 'export',
+    )!;
+    await exportCommand.action!(ctx, 'reviews/fix.md');
+    const markdown = fs.readFileSync(
+      path.join(tempDir, 'reviews', 'fix.md'),
+      'utf8',
+    );
+    expect(markdown).toContain('# Review of fix.patch');
+    expect(markdown).toContain(
+      '- **Line 2** (critical): This returns a count, not a fraction.',
+    );
+  });
+
+  it('should not call the model for an empty patch', async () => {
+    fs.writeFileSync(path.join(tempDir, 'empty.patch'), '');
+
+    const result = await reviewDiffCommand.action!(context(), 'empty.patch');
+
+    expect(result).toEqual({
+      type: 'message',
+      messageType: 'info',
+      content: 'No changes in empty.patch.',
+    });
+    expect(generateJson).not.toHaveBeenCalled();
+  });
+});
@@ -0,0 +1,130 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import {
+  DiffFile,
+  DiffReview,
+  formatReviewMarkdown,
+  getErrorMessage,
+  parseUnifiedDiff,
+  readDiff,
+  reviewDiff,
+} from '@iechor/research-cli-core';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+
+const USAGE =
+  'Usage: /review-diff [--staged | <commit> | <from>..<to> | <file.patch>] [--focus <text>]';
+const DEFAULT_EXPORT_FILE = 'review.md';
+
+// The last review, for /review-diff export
+let lastReview: { review: DiffReview; files: DiffFile[] } | undefined;
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+function parseArgs(args: string): { source: string; focus?: string } {
+  const match = args.match(/^(.*?)(?:\s*--focus\s+(.+))?$/s);
+  return {
+    source: (match?.[1] ?? '').trim(),
+    focus: match?.[2]?.trim() || undefined,
+  };
+}
+
+const exportCommand: SlashCommand = {
+  name: 'export',
+  description: `Write the last review as Markdown (default ${DEFAULT_EXPORT_FILE}). Usage: /review-diff export [file]`,
+  action: async (context: CommandContext, args: string) => {
+    if (!lastReview) {
+      return error('Nothing to export yet. Run /review-diff first.');
+    }
+    const filePath = path.resolve(
+      context.services.config?.getTargetDir() ?? process.cwd(),
+      args.trim() || DEFAULT_EXPORT_FILE,
+    );
+    try {
+      await fs.promises.mkdir(path.dirname(filePath), { recursive: true });
+      await fs.promises.writeFile(
+        filePath,
+        formatReviewMarkdown(lastReview.review, lastReview.files),
+        'utf8',
+      );
+    } catch (e) {
+      return error(`Could not write ${filePath}: ${getErrorMessage(e)}`);
+    }
+    return info(`Wrote the review to ${filePath}.`);
+  },
+};
+
+export const reviewDiffCommand: SlashCommand = {
+  name: 'review-diff',
+  description:
+    'Review a git diff (staged changes by default, a commit, a range or a patch file): shows the diff with review comments on its lines. ' +
+    USAGE,
+  subCommands: [exportCommand],
+  action: async (context: CommandContext, args: string) => {
+    const config = context.services.config;
+    if (!config) {
+      return error('Reviewing a diff needs a configured model.');
+    }
+    const { source, focus } = parseArgs(args);
+
+    let label: string;
+    let files: DiffFile[];
+    try {
+      const diff = await readDiff(config.getTargetDir(), source);
+      label = diff.label;
+      files = parseUnifiedDiff(diff.diff);
+    } catch (e) {
+      return error(
+        `Could not read the diff for ${source || 'the staged changes'}: ${getErrorMessage(e)}`,
+      );
+    }
+    if (files.length === 0) {
+      return info(
+        source && source !== '--staged'
+          ? `No changes in ${label}.`
+          : `Nothing is staged. Stage changes with git add, or name a commit or patch file. ${USAGE}`,
+      );
+    }
+
+    let review: DiffReview;
+    try {
+      context.ui.setDebugMessage(
+        `Reviewing ${files.length} changed file${files.length === 1 ? '' : 's'}...`,
+      );
+      review = await reviewDiff(
+        config.getResearchClient(),
+        files,
+        label,
+        context.abortSignal,
+        focus,
+      );
+    } catch (e) {
+      return error(`Could not review ${label}: ${getErrorMessage(e)}`);
+    }
+    lastReview = { review, files };
+    context.ui.addItem({ type: 'diff_review', files, review }, Date.now());
+    // So follow-up questions about the review have it in context
+    await config.getResearchClient().addHistory({
+      role: 'user',
+      parts: [{ text: formatReviewMarkdown(review, files) }],
+    });
+    return info(
+      `${review.comments.length} comment${review.comments.length === 1 ? '' : 's'} on ${files.length} file${files.length === 1 ? '' : 's'}. Save them with /review-diff export [file].`,
+    );
+  },
+};
@@ -0,0 +1,113 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { Content } from '@google/genai';
+import { Config } from '@iechor/research-cli-core';
+import { LoadedSettings } from '../../config/settings.js';
+import { sendToCommand } from './sendToCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+
+describe('sendToCommand', () => {
+  let tempDir: string;
+  const fetchMock = vi.fn(
+    async (_url: string, _init?: RequestInit) => new Response('ok'),
+  );
+  const history: Content[] = [
+    { role: 'user', parts: [{ text: 'How accurate is the model?' }] },
+    { role: 'model', parts: [{ text: 'Accuracy is ' }] },
+    { role: 'model', parts: [{ text: '91.2% on the test split.' }] },
+  ];
+
+  const context = () =>
+    createMockCommandContext({
+      services: {
+        config: {
+          getTargetDir: () => tempDir,
+          getModel: () => 'test-model',
+          getResearchClient: () => ({
+            getChat: async () => ({ getHistory: () => history }),
+          }),
+        } as unknown as Config,
+        settings: {
+          merged: {
+            webhooks: {
+              lab: { url: 'https://hooks.slack.com/services/T0/B0/x' },
+              team: {
+                url: 'https://discord.com/api/webhooks/1/abc',
+                exportDir: 'shared',
+                exportBaseUrl: 'https://files.lab.org/runs/',
+              },
+            },
+          },
+        } as unknown as LoadedSettings,
+      },
+    });
+
+  const postedBody = (call = 0) =>
+    JSON.parse(fetchMock.mock.calls[call][1]!.body as string);
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'send-to-command-'));
+    fetchMock.mockClear();
+    vi.stubGlobal('fetch', fetchMock);
+  });
+
+  afterEach(() => {
+    vi.unstubAllGlobals();
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should post a message to the channel', async () => {
+    const result = await sendToCommand.action!(context(), 'lab Run 12 is done');
+
+    expect(result).toEqual({
+      type: 'message',
+      messageType: 'info',
+      content: 'Posted to lab.',
+    });
+    expect(fetchMock.mock.calls[0][0]).toBe(
+      'https://hooks.slack.com/services/T0/B0/x',
+    );
+    expect(postedBody()).toEqual({ text: 'Run 12 is done' });
+  });
+
+  it('should send the last answer joined across streamed chunks', async () => {
+    await sendToCommand.action!(context(), 'team --last Results:');
+
+    expect(postedBody()).toEqual({
+      content: 'Results:\n\nAccuracy is 91.2% on the test split.',
+    });
+  });
+
+  it('should export the conversation and post a link to it', async () => {
+    await sendToCommand.action!(context(), 'team --export Today');
+
+    const [file] = fs.readdirSync(path.join(tempDir, 'shared'));
+    expect(file).toMatch(/^conversation-.*\.html$/);
+    expect(postedBody().content).toBe(
+      `Today: https://files.lab.org/runs/${file}`,
+    );
+  });
+
+  it('should list channels and reject unknown ones', async () => {
+    expect(await sendToCommand.action!(context(), '')).toMatchObject({
+      content: expect.stringContaining(
+        'Channels: lab (slack), team (discord).',
+      ),
+    });
+    expect(await sendToCommand.action!(context(), 'other hi')).toEqual({
+      type: 'message',
+      messageType: 'error',
+      content: 'Unknown channel "other". Channels: lab, team.',
+    });
+    expect(await sendToCommand.completion!(context(), 't')).toEqual(['team']);
+    expect(fetchMock).not.toHaveBeenCalled();
+  });
+});
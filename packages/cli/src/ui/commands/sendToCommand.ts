@@ -0,0 +1,188 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import os from 'node:os';
+import path from 'node:path';
+import { Content, Type } from '@google/genai';
+import {
+  Config,
+  WebhookConfig,
+  getErrorMessage,
+  getLastExchange,
+  getRedactor,
+  getWebhookType,
+  postToWebhook,
+} from '@iechor/research-cli-core';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import {
+  defaultExportFileName,
+  writeConversationExport,
+} from './exportCommand.js';
+
+const USAGE =
+  'Usage: /send-to <channel> <message> | --last | --summary | --export [message]';
+const NO_WEBHOOKS =
+  'No channels are configured. Add a "webhooks" block to your settings.json, e.g. "webhooks": { "lab": { "url": "https://hooks.slack.com/services/..." } }.';
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+function getWebhooks(context: CommandContext): Record<string, WebhookConfig> {
+  return context.services.settings.merged.webhooks ?? {};
+}
+
+/** A summary of the conversation's findings, for people who missed it. */
+export async function summarizeConversation(
+  config: Config,
+  history: Content[],
+  signal: AbortSignal,
+): Promise<string> {
+  const response = await config.getResearchClient().generateJson(
+    [
+      ...history,
+      {
+        role: 'user',
+        parts: [
+          {
+            text: 'Summarize the findings of this conversation for colleagues in a lab chat channel who have not seen it: what was asked, what was found (with the key numbers) and open questions. Use a short title line and up to six bullet points. Leave out tool calls and dead ends.',
+          },
+        ],
+      },
+    ],
+    {
+      type: Type.OBJECT,
+      properties: { summary: { type: Type.STRING } },
+      required: ['summary'],
+    },
+    signal,
+  );
+  if (typeof response['summary'] !== 'string' || !response['summary'].trim()) {
+    throw new Error('The model returned no summary.');
+  }
+  return response['summary'].trim();
+}
+
+/** Writes an export for the channel and returns a link to it. */
+async function exportForChannel(
+  config: Config,
+  webhook: WebhookConfig,
+): Promise<string | undefined> {
+  const fileName = defaultExportFileName(new Date());
+  const exportDir = webhook.exportDir
+    ? path.resolve(
+        config.getTargetDir(),
+        webhook.exportDir.replace(/^~(?=$|\/)/, os.homedir()),
+      )
+    : config.getTargetDir();
+  const filePath = path.join(exportDir, fileName);
+  if (!(await writeConversationExport(config, filePath))) {
+    return undefined;
+  }
+  return webhook.exportBaseUrl
+    ? `${webhook.exportBaseUrl.replace(/\/+$/, '')}/${encodeURIComponent(fileName)}`
+    : filePath;
+}
+
+export const sendToCommand: SlashCommand = {
+  name: 'send-to',
+  description:
+    'Post a message, the last answer, a summary of the conversation or a link to an HTML export to a Slack, Discord or Mattermost channel configured in settings. ' +
+    USAGE,
+  completion: async (context, partialArg) =>
+    Object.keys(getWebhooks(context)).filter((name) =>
+      name.startsWith(partialArg),
+    ),
+  action: async (context: CommandContext, args: string) => {
+    const config = context.services.config;
+    const webhooks = getWebhooks(context);
+    const names = Object.keys(webhooks);
+    const [channel, ...rest] = args.trim().split(/\s+/);
+    if (!channel) {
+      return info(
+        names.length > 0
+          ? `Channels: ${names.map((name) => `${name} (${getWebhookType(webhooks[name])})`).join(', ')}. ${USAGE}`
+          : NO_WEBHOOKS,
+      );
+    }
+    const webhook = webhooks[channel];
+    if (!webhook?.url) {
+      return error(
+        names.length > 0
+          ? `Unknown channel "${channel}". Channels: ${names.join(', ')}.`
+          : NO_WEBHOOKS,
+      );
+    }
+    const mode = ['--last', '--summary', '--export'].includes(rest[0])
+      ? rest.shift()
+      : undefined;
+    const message = rest.join(' ').trim();
+    if (!mode && !message) {
+      return error(USAGE);
+    }
+
+    let text: string;
+    try {
+      const chat = await config?.getResearchClient()?.getChat();
+      const history = chat?.getHistory(true) ?? [];
+      if (mode && (!config || history.length === 0)) {
+        return error('The conversation is empty.');
+      }
+      if (mode === '--last') {
+        const answer = getLastExchange(history)?.response;
+        if (!answer) {
+          return error('There is no answer to send yet.');
+        }
+        text = message ? `${message}\n\n${answer}` : answer;
+      } else if (mode === '--summary') {
+        context.ui.setDebugMessage('Summarizing the conversation...');
+        const summary = await summarizeConversation(
+          config!,
+          history,
+          context.abortSignal,
+        );
+        text = message ? `${message}\n\n${summary}` : summary;
+      } else if (mode === '--export') {
+        const link = await exportForChannel(config!, webhook);
+        if (!link) {
+          return error('The conversation is empty.');
+        }
+        text = `${message || 'Conversation export'}: ${link}`;
+      } else {
+        text = message;
+      }
+    } catch (e) {
+      return error(`Could not prepare the message: ${getErrorMessage(e)}`);
+    }
+
+    // The message leaves the machine, so it is redacted like requests
+    const { value: redacted, matches } = getRedactor().redactText(text, true);
+    let parts: number;
+    try {
+      context.ui.setDebugMessage(`Posting to ${channel}...`);
+      parts = await postToWebhook(webhook, redacted);
+    } catch (e) {
+      return error(`Could not post to ${channel}: ${getErrorMessage(e)}`);
+    }
+    return info(
+      `Posted to ${channel}${parts > 1 ? ` in ${parts} messages` : ''}.` +
+        (matches.length > 0
+          ? ` ${matches.length} value(s) were redacted.`
+          : '') +
+        (mode === '--export' && !webhook.exportBaseUrl
+          ? ' The link is a local path; set exportDir and exportBaseUrl for the channel to share exports through a web server or shared drive.'
+          : ''),
+    );
+  },
+};
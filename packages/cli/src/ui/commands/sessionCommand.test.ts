@@ -0,0 +1,133 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, vi, beforeEach } from 'vitest';
+import { Content } from '@google/genai';
+import { Config, GenerationOptions } from '@iechor/research-cli-core';
+import { sessionCommand } from './sessionCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+import { CommandContext } from './types.js';
+import { SessionManager } from '../utils/sessionManager.js';
+
+const { managers } = vi.hoisted(() => ({
+  managers: { current: undefined as unknown },
+}));
+
+vi.mock('../utils/sessionManager.js', async (importOriginal) => ({
+  ...(await importOriginal<typeof import('../utils/sessionManager.js')>()),
+  getSessionManager: () => managers.current,
+}));
+
+const subCommand = (name: string) =>
+  sessionCommand.subCommands!.find((command) => command.name === name)!;
+
+describe('sessionCommand', () => {
+  let context: CommandContext;
+  let chatHistory: Content[];
+  let model: string;
+  let options: GenerationOptions;
+  let resetChat: ReturnType<typeof vi.fn>;
+
+  beforeEach(() => {
+    managers.current = new SessionManager();
+    chatHistory = [{ role: 'user', parts: [{ text: 'main prompt' }] }];
+    model = 'research-pro';
+    options = { maxOutputTokens: 100 };
+    resetChat = vi.fn(async () => {
+      chatHistory = [];
+    });
+    context = createMockCommandContext({
+      services: {
+        config: {
+          getModel: () => model,
+          setModel: (newModel: string) => {
+            model = newModel;
+          },
+          getResearchClient: () => ({
+            getHistory: () => chatHistory,
+            setHistory: (history: Content[]) => {
+              chatHistory = history;
+            },
+            resetChat,
+            getGenerationOptions: () => ({ ...options }),
+            setGenerationOptions: (newOptions: GenerationOptions) => {
+              options = newOptions;
+            },
+          }),
+        } as unknown as Config,
+      },
+      ui: {
+        history: [{ id: 1, type: 'user', text: 'main prompt' }],
+      },
+    });
+  });
+
+  it('should open the picker without a subcommand', async () => {
+    expect(await sessionCommand.action!(context, '')).toEqual({
+      type: 'dialog',
+      dialog: 'sessions',
+    });
+  });
+
+  it('should start a new session with a fresh chat', async () => {
+    expect(await subCommand('new').action!(context, 'lit')).toMatchObject({
+      messageType: 'info',
+      content: 'Started session lit (research-pro).',
+    });
+    expect(resetChat).toHaveBeenCalled();
+    expect(context.ui.clear).toHaveBeenCalled();
+    expect(context.ui.addItem).not.toHaveBeenCalled();
+  });
+
+  it('should name a new session when no name is given', async () => {
+    await subCommand('new').action!(context, '');
+    expect((managers.current as SessionManager).getCurrentName()).toBe(
+      'session-2',
+    );
+  });
+
+  it('should bring a session back as it was left', async () => {
+    await subCommand('new').action!(context, 'lit');
+    model = 'fast';
+    options = {};
+
+    expect(await subCommand('switch').action!(context, 'main')).toMatchObject(
+      { content: 'Switched to session main (research-pro).' },
+    );
+    expect(chatHistory).toEqual([
+      { role: 'user', parts: [{ text: 'main prompt' }] },
+    ]);
+    expect(options).toEqual({ maxOutputTokens: 100 });
+    expect(context.ui.addItem).toHaveBeenCalledWith(
+      { type: 'user', text: 'main prompt' },
+      expect.any(Number),
+    );
+  });
+
+  it.each([
+    ['new', 'main', 'already a session main'],
+    ['new', 'two words', 'up to 40'],
+    ['switch', 'nope', 'no session nope'],
+    ['delete', 'main', 'cannot be deleted'],
+  ])('should refuse /session %s %s', async (name, args, message) => {
+    expect(await subCommand(name).action!(context, args)).toMatchObject({
+      messageType: 'error',
+      content: expect.stringContaining(message),
+    });
+  });
+
+  it('should list and delete the other sessions', async () => {
+    await subCommand('new').action!(context, 'lit');
+    const result = await subCommand('list').action!(context, '');
+    expect(JSON.stringify(result)).toContain('* lit');
+    expect(JSON.stringify(result)).toContain('  main  1 message  research-pro');
+
+    expect(await subCommand('delete').action!(context, 'main')).toMatchObject(
+      { messageType: 'info', content: 'Deleted session main.' },
+    );
+    expect(await subCommand('switch').completion!(context, '')).toEqual([]);
+  });
+});
@@ -0,0 +1,178 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import {
+  SessionState,
+  SessionSummary,
+  getSessionManager,
+  isValidSessionName,
+} from '../utils/sessionManager.js';
+
+const USAGE =
+  'Usage: /session [new <name> | list | switch <name> | delete <name>]. Without a subcommand, or with Ctrl+N on an empty prompt, the session picker opens.';
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+function describeSession(session: SessionSummary): string {
+  const marker = session.current ? '*' : ' ';
+  return `${marker} ${session.name}  ${session.messageCount} message${session.messageCount === 1 ? '' : 's'}  ${session.model}`;
+}
+
+function listSessions(context: CommandContext): SessionSummary[] {
+  return getSessionManager().list({
+    items: context.ui.history,
+    model: context.services.config?.getModel() ?? '',
+  });
+}
+
+function completeNames(
+  context: CommandContext,
+  partialArg: string,
+): Promise<string[]> {
+  const current = getSessionManager().getCurrentName();
+  return Promise.resolve(
+    listSessions(context)
+      .map((session) => session.name)
+      .filter((name) => name !== current && name.startsWith(partialArg)),
+  );
+}
+
+/**
+ * Puts the current session aside and brings `name` on screen, or starts it
+ * with a fresh chat on the current model when it is new.
+ */
+async function switchSession(
+  context: CommandContext,
+  name: string,
+): Promise<SlashCommandActionReturn> {
+  const config = context.services.config;
+  const client = config?.getResearchClient();
+  if (!config || !client) {
+    return error('No chat client available to switch sessions.');
+  }
+  const current: SessionState = {
+    chatHistory: client.getHistory(),
+    items: context.ui.history.map(({ id: _id, ...item }) => item),
+    model: config.getModel(),
+    generationOptions: client.getGenerationOptions(),
+  };
+  const target = getSessionManager().switchTo(name, current);
+  if (target) {
+    client.setHistory(target.chatHistory);
+    config.setModel(target.model);
+    client.setGenerationOptions(target.generationOptions);
+  } else {
+    // A fresh chat keeps the environment context it starts with
+    await client.resetChat();
+  }
+  context.ui.clear();
+  for (const item of target?.items ?? []) {
+    context.ui.addItem(item, Date.now());
+  }
+  return info(
+    target
+      ? `Switched to session ${name} (${config.getModel()}).`
+      : `Started session ${name} (${config.getModel()}).`,
+  );
+}
+
+export const sessionCommand: SlashCommand = {
+  name: 'session',
+  description:
+    'Keep several conversations side by side, each with its own history, model and generation options. ' +
+    USAGE,
+  action: async () => ({ type: 'dialog', dialog: 'sessions' }),
+  subCommands: [
+    {
+      name: 'new',
+      description: 'Start a new session and switch to it.',
+      action: async (context, args) => {
+        const manager = getSessionManager();
+        let name = args.trim();
+        if (!name) {
+          let number = 2;
+          while (manager.has(`session-${number}`)) {
+            number++;
+          }
+          name = `session-${number}`;
+        }
+        if (!isValidSessionName(name)) {
+          return error(
+            'A session name is up to 40 letters, digits, ".", "-" or "_".',
+          );
+        }
+        if (manager.has(name)) {
+          return error(
+            `There is already a session ${name}. /session switch ${name} goes to it.`,
+          );
+        }
+        return switchSession(context, name);
+      },
+    },
+    {
+      name: 'list',
+      description: 'List the sessions, the current one marked with *.',
+      action: async (context) =>
+        info(
+          `Sessions:\n${listSessions(context)
+            .map((session) => `  ${describeSession(session)}`)
+            .join('\n')}`,
+        ),
+    },
+    {
+      name: 'switch',
+      description: 'Switch to another session.',
+      completion: completeNames,
+      action: async (context, args) => {
+        const name = args.trim();
+        const manager = getSessionManager();
+        if (!name) {
+          return error(USAGE);
+        }
+        if (name === manager.getCurrentName()) {
+          return info(`Session ${name} is the current one.`);
+        }
+        if (!manager.has(name)) {
+          return error(
+            `There is no session ${name}. /session new ${name} starts it.`,
+          );
+        }
+        return switchSession(context, name);
+      },
+    },
+    {
+      name: 'delete',
+      description: 'Delete a session other than the current one.',
+      completion: completeNames,
+      action: async (_context, args) => {
+        const name = args.trim();
+        const manager = getSessionManager();
+        if (!name) {
+          return error(USAGE);
+        }
+        if (name === manager.getCurrentName()) {
+          return error(
+            'The current session cannot be deleted. Switch to another one first.',
+          );
+        }
+        return manager.delete(name)
+          ? info(`Deleted session ${name}.`)
+          : error(`There is no session ${name}.`);
+      },
+    },
+  ],
+};
@@ -0,0 +1,115 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach } from 'vitest';
+import { Config, GenerationOptions } from '@iechor/research-cli-core';
+import { setCommand, splitArgs } from './setCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+import { CommandContext } from './types.js';
+
+describe('splitArgs', () => {
+  it('should keep quoted arguments together and unescape them', () => {
+    expect(splitArgs('stop "###" "\\n\\nQ:" END')).toEqual([
+      'stop',
+      '###',
+      '\n\nQ:',
+      'END',
+    ]);
+  });
+});
+
+describe('setCommand', () => {
+  let options: GenerationOptions;
+  let model: string;
+  let context: CommandContext;
+
+  beforeEach(() => {
+    options = {};
+    model = 'gemini-2.5-pro';
+    context = createMockCommandContext({
+      services: {
+        config: {
+          getModel: () => model,
+          getResearchClient: () => ({
+            getGenerationOptions: () => ({ ...options }),
+            setGenerationOptions: (newOptions: GenerationOptions) => {
+              options = newOptions;
+            },
+          }),
+        } as unknown as Config,
+      },
+    });
+  });
+
+  it('should show the options without arguments', async () => {
+    options = { stopSequences: ['END'] };
+    const result = await setCommand.action!(context, '');
+    expect(result).toMatchObject({ messageType: 'info' });
+    expect(JSON.stringify(result)).toContain('stop: \\"END\\"');
+    expect(JSON.stringify(result)).toContain('max-output: model default');
+  });
+
+  it('should set each option', async () => {
+    await setCommand.action!(context, 'stop "###" END');
+    await setCommand.action!(context, 'max-output 256');
+    await setCommand.action!(context, 'frequency-penalty 0.5');
+    await setCommand.action!(context, 'presence-penalty -1');
+    await setCommand.action!(context, 'seed 42');
+    expect(options).toEqual({
+      stopSequences: ['###', 'END'],
+      maxOutputTokens: 256,
+      frequencyPenalty: 0.5,
+      presencePenalty: -1,
+      seed: 42,
+    });
+  });
+
+  it('should warn when the provider does not take a seed', async () => {
+    expect(
+      JSON.stringify(await setCommand.action!(context, 'seed 7')),
+    ).not.toContain('does not take a seed');
+
+    model = 'claude-sonnet-4';
+    expect(await setCommand.action!(context, 'seed 7')).toMatchObject({
+      messageType: 'info',
+      content: expect.stringContaining(
+        'The provider of claude-sonnet-4 does not take a seed',
+      ),
+    });
+  });
+
+  it('should turn an option off and reset them all', async () => {
+    options = { stopSequences: ['END'], maxOutputTokens: 100 };
+    await setCommand.action!(context, 'stop off');
+    expect(options).toEqual({ maxOutputTokens: 100 });
+
+    await setCommand.action!(context, 'reset');
+    expect(options).toEqual({});
+  });
+
+  it.each([
+    ['max-output 0', 'whole number'],
+    ['max-output lots', 'one number'],
+    ['presence-penalty 3', 'from -2 to 2'],
+    ['seed -1', 'from 0 up'],
+    ['seed 1.5', 'from 0 up'],
+    ['stop a b c d e f', 'At most 5'],
+    ['temperature 1', 'Usage: /set'],
+    ['stop', 'Usage: /set'],
+  ])('should reject "%s"', async (args, message) => {
+    expect(await setCommand.action!(context, args)).toMatchObject({
+      messageType: 'error',
+      content: expect.stringContaining(message),
+    });
+    expect(options).toEqual({});
+  });
+
+  it('should complete the option names', async () => {
+    expect(await setCommand.completion!(context, 'pre')).toEqual([
+      'presence-penalty',
+    ]);
+  });
+});
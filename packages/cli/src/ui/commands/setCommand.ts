@@ -0,0 +1,160 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import {
+  GenerationOptions,
+  detectModelProvider,
+  supportsSeed,
+} from '@iechor/research-cli-core';
+import { SlashCommand, SlashCommandActionReturn } from './types.js';
+
+const USAGE =
+  'Usage: /set [stop <sequence>... | max-output <tokens> | frequency-penalty <-2..2> | presence-penalty <-2..2> | seed <number>], with "off" for the default, or /set reset.';
+const OFF = 'off';
+// The most stop sequences the providers take
+const MAX_STOP_SEQUENCES = 5;
+
+type OptionName =
+  | 'stop'
+  | 'max-output'
+  | 'frequency-penalty'
+  | 'presence-penalty'
+  | 'seed';
+
+const OPTION_KEYS: Record<OptionName, keyof GenerationOptions> = {
+  stop: 'stopSequences',
+  'max-output': 'maxOutputTokens',
+  'frequency-penalty': 'frequencyPenalty',
+  'presence-penalty': 'presencePenalty',
+  seed: 'seed',
+};
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+/**
+ * Splits the arguments at spaces, keeping double-quoted ones together.
+ * Quoted arguments take JSON escapes, so `"\n\n"` is two newlines.
+ */
+export function splitArgs(args: string): string[] {
+  const tokens: string[] = [];
+  for (const match of args.matchAll(/"((?:[^"\\]|\\.)*)"|(\S+)/g)) {
+    if (match[2] !== undefined) {
+      tokens.push(match[2]);
+      continue;
+    }
+    try {
+      tokens.push(JSON.parse(`"${match[1]}"`));
+    } catch {
+      tokens.push(match[1]);
+    }
+  }
+  return tokens;
+}
+
+function formatOptions(options: GenerationOptions): string {
+  return (Object.keys(OPTION_KEYS) as OptionName[])
+    .map((name) => {
+      const value = options[OPTION_KEYS[name]];
+      const shown =
+        value === undefined
+          ? 'model default'
+          : Array.isArray(value)
+            ? value.map((sequence) => JSON.stringify(sequence)).join(' ')
+            : String(value);
+      return `  ${name}: ${shown}`;
+    })
+    .join('\n');
+}
+
+/** The value of an option, or an error message for a value out of range. */
+function parseValue(
+  name: OptionName,
+  values: string[],
+): GenerationOptions[keyof GenerationOptions] | string {
+  if (name === 'stop') {
+    if (values.length > MAX_STOP_SEQUENCES) {
+      return `At most ${MAX_STOP_SEQUENCES} stop sequences can be set.`;
+    }
+    return values.some((value) => value === '')
+      ? 'A stop sequence cannot be empty.'
+      : values;
+  }
+  const number = Number(values[0]);
+  if (values.length !== 1 || !Number.isFinite(number)) {
+    return `${name} takes one number.`;
+  }
+  if (name === 'max-output') {
+    return Number.isInteger(number) && number > 0
+      ? number
+      : 'max-output takes a whole number of tokens above 0.';
+  }
+  if (name === 'seed') {
+    return Number.isSafeInteger(number) && number >= 0
+      ? number
+      : 'seed takes a whole number from 0 up.';
+  }
+  return number >= -2 && number <= 2
+    ? number
+    : `${name} takes a number from -2 to 2.`;
+}
+
+export const setCommand: SlashCommand = {
+  name: 'set',
+  description:
+    'Set stop sequences, the most output tokens, the frequency and presence penalties and the sampling seed of the answers in this session. ' +
+    USAGE,
+  completion: async (_context, partialArg) =>
+    [...Object.keys(OPTION_KEYS), 'reset'].filter((option) =>
+      option.startsWith(partialArg),
+    ),
+  action: async (context, args) => {
+    const client = context.services.config?.getResearchClient();
+    if (!client) {
+      return error('No chat client available to set options on.');
+    }
+    const [name, ...values] = splitArgs(args.trim());
+    if (!name) {
+      return info(
+        `Generation options for this session:\n${formatOptions(client.getGenerationOptions())}\n${USAGE}`,
+      );
+    }
+    if (name === 'reset') {
+      client.setGenerationOptions({});
+      return info('The generation options are back to the model defaults.');
+    }
+    if (!(name in OPTION_KEYS) || values.length === 0) {
+      return error(USAGE);
+    }
+
+    const option = name as OptionName;
+    const options = client.getGenerationOptions();
+    if (values.length === 1 && values[0] === OFF) {
+      delete options[OPTION_KEYS[option]];
+    } else {
+      const value = parseValue(option, values);
+      if (typeof value === 'string') {
+        return error(value);
+      }
+      Object.assign(options, { [OPTION_KEYS[option]]: value });
+    }
+    client.setGenerationOptions(options);
+    const model = context.services.config?.getModel() ?? '';
+    const seedIgnored =
+      options.seed !== undefined && !supportsSeed(detectModelProvider(model));
+    return info(
+      `Generation options for this session:\n${formatOptions(options)}` +
+        (seedIgnored
+          ? `\n⚠️  The provider of ${model} does not take a seed, so its answers will still vary.`
+          : ''),
+    );
+  },
+};
@@ -0,0 +1,92 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, afterEach, vi } from 'vitest';
+import {
+  SharedSessionEvent,
+  SharedSessionHub,
+} from '@iechor/research-cli-core';
+import { shareCommand } from './shareCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+import { getSharedSession, stopSharing } from '../../utils/sharedSession.js';
+
+describe('shareCommand', () => {
+  const context = createMockCommandContext();
+  const run = (name: string, args = '') =>
+    shareCommand.subCommands!.find((c) => c.name === name)!.action!(
+      context,
+      args,
+    );
+
+  afterEach(async () => {
+    await stopSharing();
+  });
+
+  it('should share the session and show how to join it', async () => {
+    const result = await run('start');
+    const session = getSharedSession();
+    if (session?.role !== 'host') {
+      throw new Error('The session is not shared');
+    }
+    expect(result).toMatchObject({
+      messageType: 'info',
+      content: expect.stringContaining(
+        `/share join ${session.url} ${session.hub.token} <name>`,
+      ),
+    });
+    expect(await run('start')).toMatchObject({ messageType: 'error' });
+  });
+
+  it('should let a guest talk and answer approvals', async () => {
+    const hub = new SharedSessionHub('student');
+    const url = `ws://127.0.0.1:${await hub.start()}/`;
+    const events: SharedSessionEvent[] = [];
+    hub.on('event', (event: SharedSessionEvent) => events.push(event));
+    try {
+      expect(await run('join', `${url} ${hub.token} advisor`)).toMatchObject({
+        messageType: 'info',
+      });
+      expect(getSharedSession()?.role).toBe('guest');
+
+      await run('say', 'Add the 2021 survey.');
+      await vi.waitFor(() =>
+        expect(events).toContainEqual(
+          expect.objectContaining({
+            author: 'advisor',
+            text: 'Add the 2021 survey.',
+          }),
+        ),
+      );
+
+      const decision = hub.requestApproval('Shell: latexmk -pdf main.tex');
+      await vi.waitFor(() =>
+        expect(getSharedSession()).toMatchObject({
+          pendingApprovals: [{ description: 'Shell: latexmk -pdf main.tex' }],
+        }),
+      );
+      await run('approve');
+      expect(await decision).toEqual({ approved: true, by: 'advisor' });
+    } finally {
+      await stopSharing();
+      await hub.stop();
+    }
+  });
+
+  it('should tell guests-only commands apart', async () => {
+    expect(await run('say', 'hello')).toMatchObject({
+      messageType: 'error',
+      content:
+        'Join a shared session first; as the host, your prompts are shared already.',
+    });
+    expect(await run('approve')).toMatchObject({ messageType: 'error' });
+    expect(await run('status')).toMatchObject({
+      content: 'This session is not shared.',
+    });
+    expect(await run('stop')).toMatchObject({
+      content: 'This session is not shared.',
+    });
+  });
+});
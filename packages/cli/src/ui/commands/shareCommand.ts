@@ -0,0 +1,160 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { getErrorMessage } from '@iechor/research-cli-core';
+import { SlashCommand, SlashCommandActionReturn } from './types.js';
+import {
+  getSharedSession,
+  joinSharedSession,
+  MODEL_AUTHOR,
+  startSharing,
+  stopSharing,
+} from '../../utils/sharedSession.js';
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+function decideCommand(approved: boolean): SlashCommand {
+  const name = approved ? 'approve' : 'deny';
+  return {
+    name,
+    description: `${approved ? 'Approve' : 'Deny'} a tool call waiting in the session you joined, the oldest without n. Usage: /share ${name} [n]`,
+    action: async (_context, args) => {
+      const session = getSharedSession();
+      if (session?.role !== 'guest') {
+        return error(
+          'Only guests answer approvals here; the host answers in the tool prompt.',
+        );
+      }
+      const n = args.trim() ? Number(args.trim()) : 1;
+      const pending = session.pendingApprovals[n - 1];
+      if (!pending) {
+        return error(
+          session.pendingApprovals.length > 0
+            ? `There is no approval ${args.trim()}; ${session.pendingApprovals.length} are waiting.`
+            : 'No tool call is waiting for approval.',
+        );
+      }
+      session.client.decide(pending.id, approved);
+    },
+  };
+}
+
+export const shareCommand: SlashCommand = {
+  name: 'share',
+  description:
+    "Share this session with others over WebSocket, or join someone else's. Usage: /share start|join|say|approve|deny|status|stop",
+  action: async () =>
+    info(
+      'Usage: /share start [port] [--host <address>] to share this session, /share join <url> <token> [name] to join one, /share stop to end either.',
+    ),
+  subCommands: [
+    {
+      name: 'start',
+      description:
+        'Share this session: others see every prompt and answer with its author, and can answer tool approvals. Usage: /share start [port] [--host <address>]',
+      action: async (_context, args) => {
+        const tokens = args.trim().split(/\s+/).filter(Boolean);
+        let port = 0;
+        let host: string | undefined;
+        for (let i = 0; i < tokens.length; i++) {
+          if (tokens[i] === '--host' && tokens[i + 1]) {
+            host = tokens[++i];
+          } else if (/^\d+$/.test(tokens[i])) {
+            port = Number(tokens[i]);
+          } else {
+            return error('Usage: /share start [port] [--host <address>]');
+          }
+        }
+        try {
+          const { hub, url } = await startSharing(port, host);
+          return info(
+            [
+              `Sharing this session at ${url} as ${hub.hostName}.`,
+              `Others join with: /share join ${url} ${hub.token} <name>`,
+              'Anyone with the token can answer your tool approvals; the first answer counts.',
+            ].join('\n'),
+          );
+        } catch (e) {
+          return error(`Could not share the session: ${getErrorMessage(e)}`);
+        }
+      },
+    },
+    {
+      name: 'join',
+      description:
+        "Join someone else's shared session. Usage: /share join <url> <token> [name]",
+      action: async (_context, args) => {
+        const [url, token, name] = args.trim().split(/\s+/).filter(Boolean);
+        if (!url || !token) {
+          return error('Usage: /share join <url> <token> [name]');
+        }
+        try {
+          await joinSharedSession(url, token, name);
+        } catch (e) {
+          return error(`Could not join ${url}: ${getErrorMessage(e)}`);
+        }
+        return info(
+          `Joined ${url}. Use /share say <text> to write to everyone and /share approve or /share deny to answer tool approvals.`,
+        );
+      },
+    },
+    {
+      name: 'say',
+      description:
+        'Write to everyone in the session you joined. Usage: /share say <text>',
+      action: async (_context, args) => {
+        const session = getSharedSession();
+        if (session?.role !== 'guest') {
+          return error(
+            'Join a shared session first; as the host, your prompts are shared already.',
+          );
+        }
+        if (!args.trim()) {
+          return error('Usage: /share say <text>');
+        }
+        session.client.say(args.trim());
+      },
+    },
+    decideCommand(true),
+    decideCommand(false),
+    {
+      name: 'status',
+      description: 'Show who is in the shared session.',
+      action: async () => {
+        const session = getSharedSession();
+        if (!session) {
+          return info('This session is not shared.');
+        }
+        if (session.role === 'guest') {
+          return info(
+            `Joined ${session.url}; ${session.pendingApprovals.length} tool call(s) waiting for approval.`,
+          );
+        }
+        return info(
+          [
+            `Sharing at ${session.url} with token ${session.hub.token}.`,
+            `In the session: ${session.hub.participants.join(', ')} (answers are labelled ${MODEL_AUTHOR}).`,
+            `${session.hub.pendingApprovals.length} tool call(s) waiting for approval.`,
+          ].join('\n'),
+        );
+      },
+    },
+    {
+      name: 'stop',
+      description: 'Stop sharing the session, or leave the one you joined.',
+      action: async () =>
+        (await stopSharing())
+          ? info('Left the shared session.')
+          : info('This session is not shared.'),
+    },
+  ],
+};
@@ -0,0 +1,108 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import {
+  Config,
+  getWorkspaceStorageDirs,
+  StorageSettings,
+} from '@iechor/research-cli-core';
+import { LoadedSettings } from '../../config/settings.js';
+import { formatBytes, storageCommand } from './storageCommand.js';
+import { CommandContext } from './types.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+
+const DAY_MS = 24 * 60 * 60 * 1000;
+
+describe('storageCommand', () => {
+  let tempDir: string;
+  let projectRoot: string;
+  const cleanup = storageCommand.subCommands![0];
+
+  const context = (storage?: StorageSettings): CommandContext =>
+    createMockCommandContext({
+      services: {
+        config: { getTargetDir: () => projectRoot } as unknown as Config,
+        settings: { merged: { storage } } as unknown as LoadedSettings,
+      },
+    });
+
+  const shown = (ctx: CommandContext) =>
+    (vi.mocked(ctx.ui.addItem).mock.calls[0][0] as { text: string }).text;
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'storage-command-'));
+    projectRoot = path.join(tempDir, 'paper');
+    vi.stubEnv('HOME', tempDir);
+
+    const { tempDir: projectTemp } = getWorkspaceStorageDirs(projectRoot);
+    fs.mkdirSync(projectTemp, { recursive: true });
+    const chat = path.join(projectTemp, 'checkpoint-draft.json');
+    fs.writeFileSync(chat, '[]');
+    const old = new Date(Date.now() - 60 * DAY_MS);
+    fs.utimesSync(chat, old, old);
+    fs.mkdirSync(projectRoot, { recursive: true });
+    fs.writeFileSync(path.join(projectRoot, 'paper.pdf'), 'x'.repeat(2048));
+  });
+
+  afterEach(() => {
+    vi.unstubAllEnvs();
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should format sizes', () => {
+    expect(formatBytes(512)).toBe('512 B');
+    expect(formatBytes(2048)).toBe('2.0 KB');
+    expect(formatBytes(5 * 1024 * 1024)).toBe('5.0 MB');
+  });
+
+  it('should show the usage per category and the quota', async () => {
+    const ctx = context({ quotaMb: 0.001, pruneCachesAfterDays: 3 });
+
+    await storageCommand.action!(ctx, '');
+
+    const text = shown(ctx);
+    expect(text).toMatch(/Saved chats +1 files +2 B/);
+    expect(text).toMatch(/PDFs +1 files +2\.0 KB +█{20}/);
+    expect(text).toContain('Total 2.0 KB');
+    expect(text).toContain('⚠ Over the quota of 0.001 MB');
+    expect(text).toContain('Retention at startup: prune caches after 3 days.');
+  });
+
+  it('should show what cleanup would do before doing it', async () => {
+    const ctx = context();
+
+    await cleanup.action!(ctx, '');
+
+    expect(shown(ctx)).toContain(
+      'Would archive 1 saved chats (2 B) and remove 0 cache files (0 B):',
+    );
+    expect(shown(ctx)).toContain('Run /storage cleanup --yes to go ahead.');
+    const { archiveDir } = getWorkspaceStorageDirs(projectRoot);
+    expect(fs.existsSync(archiveDir)).toBe(false);
+
+    expect(await cleanup.action!(context(), '--yes')).toMatchObject({
+      content: expect.stringContaining('Archived 1 saved chats'),
+    });
+    expect(fs.readdirSync(archiveDir)).toEqual(['checkpoint-draft.json.gz']);
+  });
+
+  it('should take the ages from the arguments', async () => {
+    expect(
+      await cleanup.action!(context(), '--sessions 90 --caches 1'),
+    ).toMatchObject({
+      content: expect.stringContaining(
+        'no saved chats older than 90 days and no cache files older than 1 days',
+      ),
+    });
+    expect(await cleanup.action!(context(), '--sessions')).toMatchObject({
+      messageType: 'error',
+    });
+  });
+});
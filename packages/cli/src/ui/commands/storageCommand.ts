@@ -0,0 +1,200 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import path from 'node:path';
+import {
+  applyCleanup,
+  CleanupAction,
+  getErrorMessage,
+  planCleanup,
+  RetentionPolicy,
+  scanWorkspaceStorage,
+  summarizeStorage,
+} from '@iechor/research-cli-core';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import { MessageType } from '../types.js';
+
+const DEFAULT_SESSION_DAYS = 30;
+const DEFAULT_CACHE_DAYS = 7;
+const BAR_WIDTH = 20;
+const MAX_LISTED = 20;
+const CLEANUP_USAGE =
+  'Usage: /storage cleanup [--sessions <days>] [--caches <days>] [--yes]';
+
+const LABELS = {
+  sessions: 'Saved chats',
+  archived: 'Archived chats',
+  pdfs: 'PDFs',
+  checkpoints: 'Restore points',
+  logs: 'Logs',
+  caches: 'Caches',
+};
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+const getRoot = (context: CommandContext) =>
+  context.services.config?.getTargetDir() ?? process.cwd();
+
+export function formatBytes(bytes: number): string {
+  const units = ['B', 'KB', 'MB', 'GB'];
+  let value = bytes;
+  let unit = 0;
+  while (value >= 1024 && unit < units.length - 1) {
+    value /= 1024;
+    unit++;
+  }
+  return unit === 0 ? `${bytes} B` : `${value.toFixed(1)} ${units[unit]}`;
+}
+
+function describeCleanup(actions: CleanupAction[], root: string): string {
+  const archive = actions.filter((a) => a.action === 'archive');
+  const remove = actions.filter((a) => a.action === 'delete');
+  const size = (list: CleanupAction[]) =>
+    formatBytes(list.reduce((sum, a) => sum + a.item.bytes, 0));
+  const lines = [
+    `Would archive ${archive.length} saved chats (${size(archive)}) and remove ${remove.length} cache files (${size(remove)}):`,
+  ];
+  for (const { action, item } of actions.slice(0, MAX_LISTED)) {
+    const shown = item.path.startsWith(root)
+      ? path.relative(root, item.path)
+      : item.path;
+    const date = new Date(item.modifiedMs).toISOString().slice(0, 10);
+    lines.push(`  ${action.padEnd(8)}${date}  ${shown}`);
+  }
+  if (actions.length > MAX_LISTED) {
+    lines.push(`  … and ${actions.length - MAX_LISTED} more`);
+  }
+  return lines.join('\n');
+}
+
+const cleanupCommand: SlashCommand = {
+  name: 'cleanup',
+  description: `Archive old saved chats and prune old caches, after showing what would go. ${CLEANUP_USAGE}`,
+  action: async (context, args) => {
+    const storage = context.services.settings.merged.storage ?? {};
+    const policy: RetentionPolicy = {
+      archiveSessionsAfterDays:
+        storage.archiveSessionsAfterDays ?? DEFAULT_SESSION_DAYS,
+      pruneCachesAfterDays: storage.pruneCachesAfterDays ?? DEFAULT_CACHE_DAYS,
+    };
+    let confirmed = false;
+    const tokens = args.trim().split(/\s+/).filter(Boolean);
+    for (let i = 0; i < tokens.length; i++) {
+      const token = tokens[i];
+      if (token === '--yes') {
+        confirmed = true;
+        continue;
+      }
+      const days = Number(tokens[i + 1]);
+      if (
+        !['--sessions', '--caches'].includes(token) ||
+        !Number.isInteger(days) ||
+        days < 0
+      ) {
+        return error(CLEANUP_USAGE);
+      }
+      if (token === '--sessions') {
+        policy.archiveSessionsAfterDays = days;
+      } else {
+        policy.pruneCachesAfterDays = days;
+      }
+      i++;
+    }
+
+    const root = getRoot(context);
+    try {
+      const items = await scanWorkspaceStorage(root, { pdfs: false });
+      const actions = planCleanup(items, policy);
+      if (actions.length === 0) {
+        return info(
+          `Nothing to clean up: no saved chats older than ${policy.archiveSessionsAfterDays} days and no cache files older than ${policy.pruneCachesAfterDays} days.`,
+        );
+      }
+      if (!confirmed) {
+        context.ui.addItem(
+          {
+            type: MessageType.INFO,
+            text: `${describeCleanup(actions, root)}\n\nRun /storage cleanup ${args.trim() ? `${args.trim()} ` : ''}--yes to go ahead.`,
+          },
+          Date.now(),
+        );
+        return;
+      }
+      const result = await applyCleanup(root, actions);
+      return info(
+        `Archived ${result.archived} saved chats and removed ${result.deleted} cache files, freeing ${formatBytes(result.bytesFreed)}.`,
+      );
+    } catch (e) {
+      return error(`Could not clean up the storage: ${getErrorMessage(e)}`);
+    }
+  },
+};
+
+export const storageCommand: SlashCommand = {
+  name: 'storage',
+  description:
+    'Show the disk space used by saved chats, PDFs, restore points, logs and caches of this workspace.',
+  action: async (context) => {
+    const root = getRoot(context);
+    let usage;
+    try {
+      usage = summarizeStorage(await scanWorkspaceStorage(root));
+    } catch (e) {
+      return error(`Could not measure the storage: ${getErrorMessage(e)}`);
+    }
+    const storage = context.services.settings.merged.storage ?? {};
+    const total = usage.reduce((sum, u) => sum + u.bytes, 0);
+    const largest = Math.max(...usage.map((u) => u.bytes), 0);
+    const lines = [`Storage of ${root}`, ''];
+    for (const { category, files, bytes } of usage) {
+      const chart =
+        bytes > 0
+          ? '█'.repeat(Math.max(1, Math.round((bytes / largest) * BAR_WIDTH)))
+          : '';
+      lines.push(
+        `  ${LABELS[category].padEnd(15)}${String(files).padStart(6)} files  ${formatBytes(bytes).padStart(9)}  ${chart}`,
+      );
+    }
+    lines.push('', `  Total ${formatBytes(total)}`);
+
+    if (storage.quotaMb !== undefined) {
+      const quota = storage.quotaMb * 1024 * 1024;
+      lines.push(
+        total > quota
+          ? `⚠ Over the quota of ${storage.quotaMb} MB by ${formatBytes(total - quota)}. Run /storage cleanup to free space.`
+          : `Within the quota of ${storage.quotaMb} MB (${Math.round((total / quota) * 100)}% used).`,
+      );
+    }
+    const retention = [
+      storage.archiveSessionsAfterDays !== undefined
+        ? `archive saved chats after ${storage.archiveSessionsAfterDays} days`
+        : undefined,
+      storage.pruneCachesAfterDays !== undefined
+        ? `prune caches after ${storage.pruneCachesAfterDays} days`
+        : undefined,
+    ].filter(Boolean);
+    lines.push(
+      retention.length > 0
+        ? `Retention at startup: ${retention.join(', ')}.`
+        : 'No retention policy is set; see the storage setting.',
+    );
+    context.ui.addItem(
+      { type: MessageType.INFO, text: lines.join('\n') },
+      Date.now(),
+    );
+  },
+  subCommands: [cleanupCommand],
+};
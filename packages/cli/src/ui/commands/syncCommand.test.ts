@@ -0,0 +1,72 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { Config, saveSyncSettings } from '@iechor/research-cli-core';
+import { syncCommand } from './syncCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+
+describe('syncCommand', () => {
+  let tempDir: string;
+
+  const subCommand = (name: string) =>
+    syncCommand.subCommands!.find((c) => c.name === name)!;
+
+  const context = () =>
+    createMockCommandContext({
+      services: {
+        config: { getTargetDir: () => tempDir } as unknown as Config,
+      },
+    });
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'sync-command-'));
+  });
+
+  afterEach(() => {
+    vi.unstubAllEnvs();
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should explain how to set up syncing', async () => {
+    expect(await syncCommand.action!(context(), '')).toMatchObject({
+      messageType: 'info',
+      content: expect.stringContaining('/sync clone <git-url>'),
+    });
+    expect(await subCommand('pull').action!(context(), '')).toMatchObject({
+      messageType: 'error',
+    });
+  });
+
+  it('should ask for the Overleaf token before cloning', async () => {
+    vi.stubEnv('OVERLEAF_TOKEN', '');
+    const result = await subCommand('clone').action!(
+      context(),
+      'https://git.overleaf.com/64f0c0ffee',
+    );
+    expect(result).toMatchObject({
+      messageType: 'error',
+      content: expect.stringContaining('Set OVERLEAF_TOKEN'),
+    });
+  });
+
+  it('should only push after the diff was reviewed', async () => {
+    await saveSyncSettings(tempDir, {
+      dir: 'paper',
+      remote: 'https://git.overleaf.com/64f0c0ffee',
+      branch: 'master',
+    });
+    expect(
+      await subCommand('push').action!(context(), 'Tighten the abstract'),
+    ).toMatchObject({
+      messageType: 'error',
+      content: 'Review the changes with /sync diff first.',
+    });
+  });
+});
@@ -0,0 +1,248 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import {
+  ManuscriptSync,
+  OVERLEAF_TOKEN_ENV,
+  getErrorMessage,
+  hashDiff,
+  loadSyncSettings,
+  parseLatexProject,
+} from '@iechor/research-cli-core';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import { MessageType } from '../types.js';
+
+const NOT_SET_UP =
+  "No manuscript is synced. Clone it with /sync clone <git-url> [dir], e.g. the git link from Overleaf's Menu > Git.";
+// Roughly 50k tokens; longer manuscripts are cut.
+const MAX_CONTEXT_CHARS = 200_000;
+
+// Hash of the diff shown by /sync diff; /sync push only pushes that diff.
+let reviewedDiffHash: string | undefined;
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+function getProjectRoot(context: CommandContext): string {
+  return context.services.config?.getTargetDir() ?? process.cwd();
+}
+
+async function getSync(
+  context: CommandContext,
+): Promise<ManuscriptSync | undefined> {
+  const root = getProjectRoot(context);
+  const settings = await loadSyncSettings(root);
+  return settings ? new ManuscriptSync(root, settings) : undefined;
+}
+
+/**
+ * The manuscript's files as one text: the main .tex file, what it
+ * includes and its .bib files.
+ */
+async function readManuscript(dir: string): Promise<string | undefined> {
+  const project = await parseLatexProject(dir);
+  if (!project) {
+    return undefined;
+  }
+  let text = '';
+  for (const file of [...project.files, ...project.bibFiles]) {
+    const filePath = path.join(dir, file);
+    if (!fs.existsSync(filePath)) {
+      continue;
+    }
+    const content = await fs.promises.readFile(filePath, 'utf8');
+    text += `--- ${file} ---\n${content}\n`;
+    if (text.length > MAX_CONTEXT_CHARS) {
+      return `${text.slice(0, MAX_CONTEXT_CHARS)}\n[The rest of the manuscript was cut.]`;
+    }
+  }
+  return text;
+}
+
+export const syncCommand: SlashCommand = {
+  name: 'sync',
+  description:
+    'Sync the manuscript with Overleaf or another git remote. Usage: /sync [clone|status|pull|diff|push]',
+  action: async (context: CommandContext) => {
+    const sync = await getSync(context);
+    if (!sync) {
+      return info(NOT_SET_UP);
+    }
+    return info(
+      `Syncing ${sync.settings.dir} with ${sync.settings.remote} (${sync.settings.branch}). Use /sync pull, /sync diff and /sync push <message>.`,
+    );
+  },
+  subCommands: [
+    {
+      name: 'clone',
+      description:
+        'Clone the manuscript repository into the project. Usage: /sync clone <git-url> [dir]',
+      action: async (context, args) => {
+        const [url, dir] = args.trim().split(/\s+/).filter(Boolean);
+        if (!url) {
+          return error('Usage: /sync clone <git-url> [dir]');
+        }
+        const root = getProjectRoot(context);
+        const existing = await loadSyncSettings(root);
+        if (existing) {
+          return error(
+            `${existing.dir} is already synced with ${existing.remote}.`,
+          );
+        }
+        if (
+          url.includes('git.overleaf.com') &&
+          !process.env[OVERLEAF_TOKEN_ENV]
+        ) {
+          return error(
+            `Set ${OVERLEAF_TOKEN_ENV} to an Overleaf git authentication token (Account Settings > Git Integration) first.`,
+          );
+        }
+        context.ui.setDebugMessage(`Cloning ${url}...`);
+        try {
+          const sync = await ManuscriptSync.clone(root, url, dir);
+          return info(
+            `Cloned the manuscript into ${sync.settings.dir}. Run /sync pull to load it into the conversation.`,
+          );
+        } catch (e) {
+          return error(`Could not clone ${url}: ${getErrorMessage(e)}`);
+        }
+      },
+    },
+    {
+      name: 'status',
+      description:
+        'Show local changes and commits ahead of or behind the remote. Usage: /sync status',
+      action: async (context) => {
+        const sync = await getSync(context);
+        if (!sync) {
+          return error(NOT_SET_UP);
+        }
+        try {
+          const status = await sync.status();
+          return info(
+            [
+              `${sync.settings.dir} (${status.branch}): ${status.ahead} commits ahead, ${status.behind} behind ${sync.settings.remote}.`,
+              status.changed.length > 0
+                ? `Changed: ${status.changed.join(', ')}`
+                : 'No local changes.',
+            ].join('\n'),
+          );
+        } catch (e) {
+          return error(`Could not get the status: ${getErrorMessage(e)}`);
+        }
+      },
+    },
+    {
+      name: 'pull',
+      description:
+        'Pull the latest manuscript and add it to the conversation. Usage: /sync pull',
+      action: async (context) => {
+        const sync = await getSync(context);
+        if (!sync) {
+          return error(NOT_SET_UP);
+        }
+        context.ui.setDebugMessage('Pulling the manuscript...');
+        let changed: string[];
+        let manuscript: string | undefined;
+        try {
+          changed = await sync.pull();
+          manuscript = await readManuscript(sync.dir);
+        } catch (e) {
+          return error(`Could not pull: ${getErrorMessage(e)}`);
+        }
+        reviewedDiffHash = undefined;
+        if (manuscript) {
+          await context.services.config?.getResearchClient()?.addHistory({
+            role: 'user',
+            parts: [
+              {
+                text: `The current manuscript, synced from ${sync.settings.remote} into ${sync.dir}. Edit these files in place:\n\n${manuscript}`,
+              },
+            ],
+          });
+        }
+        return info(
+          [
+            changed.length > 0
+              ? `Pulled changes to ${changed.join(', ')}.`
+              : 'Already up to date.',
+            manuscript
+              ? 'Added the manuscript to the conversation.'
+              : `No .tex file with a \\documentclass in ${sync.settings.dir}; nothing was added to the conversation.`,
+          ].join('\n'),
+        );
+      },
+    },
+    {
+      name: 'diff',
+      description:
+        'Show the changes that /sync push would commit. Usage: /sync diff',
+      action: async (context) => {
+        const sync = await getSync(context);
+        if (!sync) {
+          return error(NOT_SET_UP);
+        }
+        let diff: string;
+        try {
+          diff = await sync.diff();
+        } catch (e) {
+          return error(`Could not diff: ${getErrorMessage(e)}`);
+        }
+        if (!diff) {
+          reviewedDiffHash = undefined;
+          return info('There are no changes to push.');
+        }
+        reviewedDiffHash = hashDiff(diff);
+        context.ui.addItem(
+          {
+            type: MessageType.INFO,
+            text: `${diff}\nPush exactly these changes with /sync push <message>.`,
+          },
+          Date.now(),
+        );
+      },
+    },
+    {
+      name: 'push',
+      description:
+        'Commit the changes reviewed with /sync diff and push them. Usage: /sync push <message>',
+      action: async (context, args) => {
+        const sync = await getSync(context);
+        if (!sync) {
+          return error(NOT_SET_UP);
+        }
+        const message = args.trim();
+        if (!message) {
+          return error('Usage: /sync push <message>');
+        }
+        if (!reviewedDiffHash) {
+          return error('Review the changes with /sync diff first.');
+        }
+        context.ui.setDebugMessage('Pushing the manuscript...');
+        try {
+          const commit = await sync.commitAndPush(message, reviewedDiffHash);
+          reviewedDiffHash = undefined;
+          return info(
+            `Pushed ${commit.slice(0, 7)} to ${sync.settings.remote} (${sync.settings.branch}).`,
+          );
+        } catch (e) {
+          return error(`Could not push: ${getErrorMessage(e)}`);
+        }
+      },
+    },
+  ],
+};
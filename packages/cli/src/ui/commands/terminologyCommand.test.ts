@@ -0,0 +1,106 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { Config } from '@iechor/research-cli-core';
+import { terminologyCommand } from './terminologyCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+import { CommandContext } from './types.js';
+
+describe('terminologyCommand', () => {
+  let tempDir: string;
+  let context: CommandContext;
+
+  const check = terminologyCommand.subCommands!.find(
+    (c) => c.name === 'check',
+  )!;
+  const writeTerminology = () => {
+    fs.mkdirSync(path.join(tempDir, '.research'));
+    fs.writeFileSync(
+      path.join(tempDir, '.research', 'terminology.json'),
+      JSON.stringify({
+        preferred: { dataset: ['data set'] },
+        banned: ['novel'],
+        capitalization: ['PyTorch'],
+      }),
+    );
+  };
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'terminology-command-'));
+    context = createMockCommandContext({
+      services: {
+        config: { getTargetDir: () => tempDir } as unknown as Config,
+      },
+    });
+  });
+
+  afterEach(() => {
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should explain how to create the terminology when there is none', async () => {
+    const result = await terminologyCommand.action!(context, '');
+
+    expect(result).toMatchObject({ messageType: 'info' });
+    expect(result).toHaveProperty(
+      'content',
+      expect.stringContaining('Create .research/terminology.json'),
+    );
+  });
+
+  it('should show the rules', async () => {
+    writeTerminology();
+
+    await terminologyCommand.action!(context, '');
+
+    expect(context.ui.addItem).toHaveBeenCalledWith(
+      {
+        type: 'info',
+        text: [
+          'Terminology (3 rules):',
+          '  dataset  instead of data set',
+          '  never: novel',
+          '  always: PyTorch',
+        ].join('\n'),
+      },
+      expect.any(Number),
+    );
+  });
+
+  it('should report the issues of a file by line', async () => {
+    writeTerminology();
+    fs.writeFileSync(
+      path.join(tempDir, 'draft.md'),
+      'A novel method.\n\nWe train on the data set with pytorch.\n',
+    );
+
+    await check.action!(context, 'draft.md');
+
+    expect(context.ui.addItem).toHaveBeenCalledWith(
+      {
+        type: 'info',
+        text: [
+          '3 terminology issues:',
+          '  draft.md:1  "novel" is not to be used',
+          '  draft.md:3  "data set" → "dataset"',
+          '  draft.md:3  "pytorch" → "PyTorch"',
+        ].join('\n'),
+      },
+      expect.any(Number),
+    );
+  });
+
+  it('should require a file to check', async () => {
+    expect(await check.action!(context, '')).toMatchObject({
+      messageType: 'error',
+      content: 'Usage: /terminology check <file>',
+    });
+  });
+});
@@ -0,0 +1,130 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import {
+  Terminology,
+  checkTerminology,
+  formatTerminologyIssue,
+  getErrorMessage,
+  getTerminologyPath,
+  loadTerminology,
+} from '@iechor/research-cli-core';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import { MessageType } from '../types.js';
+
+const MAX_ISSUES_SHOWN = 50;
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+function getRoot(context: CommandContext): string {
+  return context.services.config?.getTargetDir() ?? process.cwd();
+}
+
+function noTerminology(root: string): SlashCommandActionReturn {
+  return info(
+    `This workspace has no terminology. Create ${path.relative(root, getTerminologyPath(root))} with "preferred" terms and the variants they replace, "banned" phrases and terms with fixed "capitalization".`,
+  );
+}
+
+function formatRules(terminology: Terminology): string[] {
+  return [
+    ...Object.entries(terminology.preferred ?? {}).map(
+      ([term, variants]) => `  ${term}  instead of ${variants.join(', ')}`,
+    ),
+    ...(terminology.banned ?? []).map((phrase) => `  never: ${phrase}`),
+    ...(terminology.capitalization ?? []).map((term) => `  always: ${term}`),
+  ];
+}
+
+export const terminologyCommand: SlashCommand = {
+  name: 'terminology',
+  description:
+    'Show the terminology of this workspace: preferred terms, banned phrases and capitalizations. The model follows it and the input box flags departures from it.',
+  action: async (context) => {
+    const root = getRoot(context);
+    let terminology: Terminology | undefined;
+    const warnings: string[] = [];
+    try {
+      terminology = await loadTerminology(root, (w) => warnings.push(w));
+    } catch (e) {
+      return error(`Could not read the terminology: ${getErrorMessage(e)}`);
+    }
+    if (!terminology) {
+      return noTerminology(root);
+    }
+    const rules = formatRules(terminology);
+    context.ui.addItem(
+      {
+        type: MessageType.INFO,
+        text:
+          rules.length > 0
+            ? `Terminology (${rules.length} rules):\n${rules.join('\n')}`
+            : 'The terminology has no rules yet.',
+      },
+      Date.now(),
+    );
+    if (warnings.length > 0) {
+      return error(
+        `Parts of ${path.relative(root, getTerminologyPath(root))} were ignored:\n${warnings.map((w) => `  ${w}`).join('\n')}`,
+      );
+    }
+  },
+  subCommands: [
+    {
+      name: 'check',
+      description:
+        'Check a file against the terminology. Usage: /terminology check <file>',
+      action: async (context, args) => {
+        const file = args.trim();
+        if (!file) {
+          return error('Usage: /terminology check <file>');
+        }
+        const root = getRoot(context);
+        let terminology: Terminology | undefined;
+        let text: string;
+        try {
+          terminology = await loadTerminology(root);
+          if (!terminology) {
+            return noTerminology(root);
+          }
+          text = await fs.promises.readFile(path.resolve(root, file), 'utf8');
+        } catch (e) {
+          return error(`Could not check ${file}: ${getErrorMessage(e)}`);
+        }
+        const issues = checkTerminology(text, terminology);
+        if (issues.length === 0) {
+          return info(`${file} follows the terminology.`);
+        }
+        const lines = issues.slice(0, MAX_ISSUES_SHOWN).map((issue) => {
+          const line = text.slice(0, issue.index).split('\n').length;
+          return `  ${file}:${line}  ${formatTerminologyIssue(issue)}`;
+        });
+        if (issues.length > MAX_ISSUES_SHOWN) {
+          lines.push(`  ... and ${issues.length - MAX_ISSUES_SHOWN} more`);
+        }
+        context.ui.addItem(
+          {
+            type: MessageType.INFO,
+            text: `${issues.length} terminology issue${issues.length === 1 ? '' : 's'}:\n${lines.join('\n')}`,
+          },
+          Date.now(),
+        );
+      },
+    },
+  ],
+};
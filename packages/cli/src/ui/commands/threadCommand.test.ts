@@ -0,0 +1,85 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, vi, beforeEach } from 'vitest';
+import { Content } from '@google/genai';
+import { Config, ContextFilter } from '@iechor/research-cli-core';
+import { threadCommand } from './threadCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+import { CommandContext } from './types.js';
+
+describe('threadCommand', () => {
+  let context: CommandContext;
+  let filter: ContextFilter;
+  const history: Content[] = [
+    { role: 'user', parts: [{ text: 'Which test should I use?' }] },
+    { role: 'model', parts: [{ text: 'A paired t-test.' }] },
+  ];
+  const subCommand = (name: string) =>
+    threadCommand.subCommands!.find((c) => c.name === name)!;
+
+  beforeEach(() => {
+    const client = {
+      isInitialized: () => true,
+      addContextFilter: (f: ContextFilter) => {
+        filter = f;
+        return () => {};
+      },
+      getChat: () => ({ getHistory: () => structuredClone(history) }),
+      generateContent: vi.fn().mockResolvedValue({
+        candidates: [{ content: { parts: [{ text: 'Samples pair up.' }] } }],
+      }),
+    };
+    context = createMockCommandContext({
+      services: {
+        config: { getResearchClient: () => client } as unknown as Config,
+      },
+    });
+  });
+
+  it('should show the reply indented under the answer', async () => {
+    expect(await threadCommand.action!(context, 'Why paired?')).toBe(
+      undefined,
+    );
+
+    expect(context.ui.addItem).toHaveBeenCalledWith(
+      {
+        type: 'thread',
+        threadId: 1,
+        answer: 'A paired t-test.',
+        exchanges: [{ question: 'Why paired?', answer: 'Samples pair up.' }],
+        included: false,
+        collapsed: false,
+      },
+      expect.any(Number),
+    );
+    expect(filter(history)).toEqual(history);
+  });
+
+  it('should add the thread to the context when included', async () => {
+    await threadCommand.action!(context, 'Why paired?');
+
+    expect(await subCommand('include').action!(context, '1')).toMatchObject({
+      content: 'Thread #1 is now sent with the conversation.',
+    });
+    expect(filter(history)).toHaveLength(4);
+    await subCommand('exclude').action!(context, '#1');
+    expect(filter(history)).toHaveLength(2);
+  });
+
+  it('should reject unknown threads and bad arguments', async () => {
+    expect(await threadCommand.action!(context, '--on x')).toMatchObject({
+      messageType: 'error',
+      content: 'Usage: /thread [--on <n>] <question>',
+    });
+    expect(
+      await threadCommand.action!(context, '--on 5 Why?'),
+    ).toMatchObject({ content: 'There is no such answer to reply to.' });
+    expect(
+      await subCommand('reply').action!(context, '9 Why?'),
+    ).toMatchObject({ content: 'Usage: /thread reply <id> <question>' });
+  });
+});
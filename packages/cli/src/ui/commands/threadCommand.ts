@@ -0,0 +1,199 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import {
+  ConversationThread,
+  ConversationThreads,
+  getConversationThreads,
+  getErrorMessage,
+} from '@iechor/research-cli-core';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import { HistoryItemThread, ThreadExchange } from '../types.js';
+
+const USAGE = 'Usage: /thread [--on <n>] <question>';
+const REPLY_USAGE = 'Usage: /thread reply <id> <question>';
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+function getThreads(context: CommandContext): ConversationThreads | undefined {
+  const client = context.services.config?.getResearchClient();
+  return client?.isInitialized() ? getConversationThreads(client) : undefined;
+}
+
+function toExchanges(thread: ConversationThread): ThreadExchange[] {
+  const text = (i: number) =>
+    thread.turns[i].parts?.map((part) => part.text ?? '').join('') ?? '';
+  const exchanges: ThreadExchange[] = [];
+  for (let i = 0; i + 1 < thread.turns.length; i += 2) {
+    exchanges.push({ question: text(i), answer: text(i + 1) });
+  }
+  return exchanges;
+}
+
+function showThread(
+  context: CommandContext,
+  thread: ConversationThread,
+  view: 'latest' | 'all' | 'collapsed',
+): void {
+  const exchanges = toExchanges(thread);
+  const item: Omit<HistoryItemThread, 'id'> = {
+    type: 'thread',
+    threadId: thread.id,
+    answer: thread.answer,
+    exchanges: view === 'latest' ? exchanges.slice(-1) : exchanges,
+    included: thread.included,
+    collapsed: view === 'collapsed',
+  };
+  context.ui.addItem(item, Date.now());
+}
+
+async function ask(
+  context: CommandContext,
+  threads: ConversationThreads,
+  thread: ConversationThread,
+  question: string,
+): Promise<void | SlashCommandActionReturn> {
+  try {
+    await threads.ask(thread, question, context.abortSignal);
+  } catch (e) {
+    return error(
+      `Could not ask in thread #${thread.id}: ${getErrorMessage(e)} Try again with /thread reply ${thread.id} <question>.`,
+    );
+  }
+  showThread(context, thread, 'latest');
+}
+
+/** Parses "<id> ...rest" into the thread and the rest. */
+function parseThread(
+  context: CommandContext,
+  args: string,
+): {
+  thread?: ConversationThread;
+  rest: string;
+  threads?: ConversationThreads;
+} {
+  const threads = getThreads(context);
+  const [, id, rest = ''] = args.trim().match(/^#?(\d+)\s*(.*)$/s) ?? [];
+  return { thread: id ? threads?.get(Number(id)) : undefined, rest, threads };
+}
+
+function completeIds(context: CommandContext, partialArg: string): string[] {
+  return (getThreads(context)?.list() ?? [])
+    .map((thread) => String(thread.id))
+    .filter((id) => id.startsWith(partialArg));
+}
+
+function setIncluded(included: boolean): SlashCommand['action'] {
+  return (context, args) => {
+    const { thread, threads } = parseThread(context, args);
+    if (!thread || !threads) {
+      return error(
+        `Usage: /thread ${included ? 'include' : 'exclude'} <id>. See /thread list.`,
+      );
+    }
+    thread.included = included;
+    return info(
+      included
+        ? `Thread #${thread.id} is now sent with the conversation.`
+        : `Thread #${thread.id} is now kept out of the conversation.`,
+    );
+  };
+}
+
+const replyCommand: SlashCommand = {
+  name: 'reply',
+  description: `Ask a follow-up in a thread. ${REPLY_USAGE}`,
+  action: async (context, args) => {
+    const { thread, rest, threads } = parseThread(context, args);
+    if (!thread || !threads || !rest.trim()) {
+      return error(REPLY_USAGE);
+    }
+    return ask(context, threads, thread, rest.trim());
+  },
+  completion: async (context, partialArg) => completeIds(context, partialArg),
+};
+
+const listCommand: SlashCommand = {
+  name: 'list',
+  description: 'List the threads of this session, collapsed.',
+  action: (context) => {
+    const threads = getThreads(context)?.list() ?? [];
+    if (threads.length === 0) {
+      return info(`No threads yet. ${USAGE}`);
+    }
+    for (const thread of threads) {
+      showThread(context, thread, 'collapsed');
+    }
+  },
+};
+
+const showCommand: SlashCommand = {
+  name: 'show',
+  description: 'Show a thread in full. Usage: /thread show <id>',
+  action: (context, args) => {
+    const { thread } = parseThread(context, args);
+    if (!thread) {
+      return error('Usage: /thread show <id>. See /thread list.');
+    }
+    showThread(context, thread, 'all');
+  },
+  completion: async (context, partialArg) => completeIds(context, partialArg),
+};
+
+const includeCommand: SlashCommand = {
+  name: 'include',
+  description:
+    'Send a thread with the conversation, right after the answer it replies to.',
+  action: setIncluded(true),
+  completion: async (context, partialArg) => completeIds(context, partialArg),
+};
+
+const excludeCommand: SlashCommand = {
+  name: 'exclude',
+  description: 'Keep a thread out of the conversation again.',
+  action: setIncluded(false),
+  completion: async (context, partialArg) => completeIds(context, partialArg),
+};
+
+export const threadCommand: SlashCommand = {
+  name: 'thread',
+  description: `Reply to an answer in a side thread that stays out of the conversation unless included. --on 2 replies to the answer before the last. ${USAGE}`,
+  action: async (context, args) => {
+    const threads = getThreads(context);
+    if (!threads) {
+      return error('There is no conversation to reply to.');
+    }
+    const on = args.trim().match(/^--on\s+(\d+)\s+(.*)$/s);
+    const question = (on ? on[2] : args).trim();
+    if (!question || (!on && question.startsWith('--on'))) {
+      return error(USAGE);
+    }
+    let thread: ConversationThread;
+    try {
+      thread = threads.start(on ? Number(on[1]) : 1);
+    } catch (e) {
+      return error(getErrorMessage(e));
+    }
+    return ask(context, threads, thread, question);
+  },
+  subCommands: [
+    replyCommand,
+    listCommand,
+    showCommand,
+    includeCommand,
+    excludeCommand,
+  ],
+};
@@ -0,0 +1,120 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { Config, loadActionItems } from '@iechor/research-cli-core';
+import { todoCommand } from './todoCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+
+describe('todoCommand', () => {
+  let tempDir: string;
+  const generateJson = vi.fn();
+
+  const subCommand = (name: string) =>
+    todoCommand.subCommands!.find((c) => c.name === name)!;
+
+  const context = () =>
+    createMockCommandContext({
+      services: {
+        config: {
+          getTargetDir: () => tempDir,
+          getResearchClient: () => ({
+            getChat: async () => ({
+              getHistory: () => [
+                { role: 'user', parts: [{ text: 'What should I do next?' }] },
+              ],
+            }),
+            generateJson,
+          }),
+        } as unknown as Config,
+      },
+    });
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'todo-command-'));
+    generateJson.mockReset();
+    generateJson.mockResolvedValue({
+      items: [
+        { text: 'Rerun the ablation with seed 3', due: '2025-06-06' },
+        { text: 'Redraw figure 2', priority: 'B', due: 'Friday' },
+      ],
+    });
+  });
+
+  afterEach(() => {
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should extract action items and not add them twice', async () => {
+    const first = await subCommand('extract').action!(context(), '');
+    expect(first).toMatchObject({
+      messageType: 'info',
+      content: expect.stringContaining('Found 2 action items, 2 new'),
+    });
+    await subCommand('extract').action!(context(), '');
+
+    const items = await loadActionItems(tempDir);
+    expect(items.map((item) => [item.text, item.due, item.priority])).toEqual([
+      ['Rerun the ablation with seed 3', '2025-06-06', undefined],
+      ['Redraw figure 2', undefined, 'B'],
+    ]);
+  });
+
+  it('should export to todo.txt and sync completed items back', async () => {
+    await subCommand('extract').action!(context(), '');
+    await subCommand('export').action!(context(), 'todotxt');
+
+    const todoPath = path.join(tempDir, 'todo.txt');
+    const lines = fs.readFileSync(todoPath, 'utf8').trim().split('\n');
+    expect(lines).toHaveLength(2);
+    fs.writeFileSync(todoPath, `x 2025-06-05 ${lines[0]}\n${lines[1]}\n`);
+
+    const result = await subCommand('import').action!(context(), 'todo.txt');
+
+    expect(result).toMatchObject({
+      content: 'Marked 1 action item done from todo.txt; 1 still open.',
+    });
+    const [ablation] = await loadActionItems(tempDir);
+    expect(ablation).toMatchObject({ done: true, completed: '2025-06-05' });
+  });
+
+  it('should export to Taskwarrior and read `task export` back', async () => {
+    await subCommand('extract').action!(context(), '');
+    await subCommand('export').action!(context(), 'taskwarrior tasks.json');
+
+    const tasks = JSON.parse(
+      fs.readFileSync(path.join(tempDir, 'tasks.json'), 'utf8'),
+    );
+    expect(tasks[1]).toMatchObject({
+      description: 'Redraw figure 2',
+      status: 'pending',
+      priority: 'M',
+      project: path.basename(tempDir),
+    });
+    tasks[1].status = 'completed';
+    fs.writeFileSync(path.join(tempDir, 'done.json'), JSON.stringify(tasks));
+
+    await subCommand('import').action!(context(), 'done.json');
+
+    const items = await loadActionItems(tempDir);
+    expect(items.map((item) => item.done)).toEqual([false, true]);
+  });
+
+  it('should mark items done by id and reject unknown ids', async () => {
+    await subCommand('extract').action!(context(), '');
+    const [ablation] = await loadActionItems(tempDir);
+
+    expect(
+      await subCommand('done').action!(context(), ablation.id),
+    ).toMatchObject({ content: 'Marked 1 action item done.' });
+    expect(await subCommand('done').action!(context(), 'nope')).toMatchObject(
+      { messageType: 'error', content: 'No open action item matches nope.' },
+    );
+  });
+});
@@ -0,0 +1,280 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import { Content, Type } from '@google/genai';
+import {
+  ActionItem,
+  ActionItemPriority,
+  Config,
+  ImportedItem,
+  createActionItemId,
+  formatTaskwarrior,
+  formatTodoTxt,
+  getErrorMessage,
+  loadActionItems,
+  mergeActionItems,
+  parseTaskwarrior,
+  parseTodoTxt,
+  saveActionItems,
+  syncCompletedItems,
+} from '@iechor/research-cli-core';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import { MessageType } from '../types.js';
+
+const FORMATS: Record<string, { file: string; label: string }> = {
+  todotxt: { file: 'todo.txt', label: 'todo.txt' },
+  taskwarrior: { file: 'tasks.json', label: 'Taskwarrior' },
+};
+
+const EMPTY =
+  'No action items yet. Extract them from the conversation with /todo extract.';
+
+function info(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'info', content };
+}
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+function getRoot(context: CommandContext): string {
+  return context.services.config?.getTargetDir() ?? process.cwd();
+}
+
+const today = () => new Date().toISOString().slice(0, 10);
+
+function formatItems(items: ActionItem[]): string {
+  return items
+    .map(
+      (item) =>
+        `  ${item.done ? '[x]' : '[ ]'} ${item.id}  ${item.priority ? `(${item.priority}) ` : ''}${item.text}${item.due ? `  due ${item.due}` : ''}`,
+    )
+    .join('\n');
+}
+
+/** Asks the model for the open action items agreed in the conversation. */
+async function extractActionItems(
+  config: Config,
+  history: Content[],
+  signal: AbortSignal,
+): Promise<ActionItem[]> {
+  const response = await config.getResearchClient().generateJson(
+    [
+      ...history,
+      {
+        role: 'user',
+        parts: [
+          {
+            text: `List the concrete action items for the user that came out of this conversation: experiments to run or rerun, analyses, things to write, check or send. Leave out what was already done. Write each as a short imperative that makes sense on its own, e.g. "Rerun the ablation with seed 3". Give a due date (YYYY-MM-DD; today is ${today()}) only if one was mentioned, and a priority A (urgent), B or C only if it is clear.`,
+          },
+        ],
+      },
+    ],
+    {
+      type: Type.OBJECT,
+      properties: {
+        items: {
+          type: Type.ARRAY,
+          items: {
+            type: Type.OBJECT,
+            properties: {
+              text: { type: Type.STRING },
+              due: { type: Type.STRING },
+              priority: { type: Type.STRING, enum: ['A', 'B', 'C'] },
+            },
+            required: ['text'],
+          },
+        },
+      },
+      required: ['items'],
+    },
+    signal,
+  );
+  const items = Array.isArray(response['items']) ? response['items'] : [];
+  return items
+    .filter(
+      (item: { text?: unknown }) =>
+        typeof item.text === 'string' && item.text.trim(),
+    )
+    .map((item: { text: string; due?: string; priority?: string }) => ({
+      id: createActionItemId(item.text),
+      text: item.text.trim(),
+      done: false,
+      created: today(),
+      due: /^\d{4}-\d{2}-\d{2}$/.test(item.due ?? '') ? item.due : undefined,
+      priority: ['A', 'B', 'C'].includes(item.priority ?? '')
+        ? (item.priority as ActionItemPriority)
+        : undefined,
+    }));
+}
+
+export const todoCommand: SlashCommand = {
+  name: 'todo',
+  description:
+    'Show the action items of this project. Extract them from the conversation with /todo extract, and keep them in sync with todo.txt or Taskwarrior with /todo export and /todo import.',
+  action: async (context: CommandContext) => {
+    let items: ActionItem[];
+    try {
+      items = await loadActionItems(getRoot(context));
+    } catch (e) {
+      return error(`Could not read the action items: ${getErrorMessage(e)}`);
+    }
+    if (items.length === 0) {
+      return info(EMPTY);
+    }
+    const open = items.filter((item) => !item.done);
+    context.ui.addItem(
+      {
+        type: MessageType.INFO,
+        text: `Action items (${open.length} open, ${items.length - open.length} done):\n${formatItems([...open, ...items.filter((item) => item.done)])}`,
+      },
+      Date.now(),
+    );
+  },
+  subCommands: [
+    {
+      name: 'extract',
+      description:
+        'Extract action items from the conversation and add the new ones.',
+      action: async (context) => {
+        const config = context.services.config;
+        const chat = await config?.getResearchClient()?.getChat();
+        const history = chat?.getHistory(true) ?? [];
+        if (!config || history.length === 0) {
+          return error('The conversation is empty.');
+        }
+        try {
+          context.ui.setDebugMessage('Extracting action items...');
+          const extracted = await extractActionItems(
+            config,
+            history,
+            context.abortSignal,
+          );
+          if (extracted.length === 0) {
+            return info('The conversation has no open action items.');
+          }
+          const root = getRoot(context);
+          const { items, added } = mergeActionItems(
+            await loadActionItems(root),
+            extracted,
+          );
+          await saveActionItems(root, items);
+          return info(
+            `Found ${extracted.length} action items, ${added} new:\n${formatItems(extracted)}`,
+          );
+        } catch (e) {
+          return error(
+            `Could not extract action items: ${getErrorMessage(e)}`,
+          );
+        }
+      },
+    },
+    {
+      name: 'done',
+      description: 'Mark action items done by id. Usage: /todo done <id...>',
+      action: async (context, args) => {
+        const ids = args.trim().split(/\s+/).filter(Boolean);
+        if (ids.length === 0) {
+          return error('Usage: /todo done <id...>');
+        }
+        try {
+          const root = getRoot(context);
+          const { items, completed } = syncCompletedItems(
+            await loadActionItems(root),
+            ids.map((id) => ({ id, text: '', done: true })),
+          );
+          if (completed === 0) {
+            return error(`No open action item matches ${ids.join(', ')}.`);
+          }
+          await saveActionItems(root, items);
+          return info(
+            `Marked ${completed} action item${completed === 1 ? '' : 's'} done.`,
+          );
+        } catch (e) {
+          return error(
+            `Could not update the action items: ${getErrorMessage(e)}`,
+          );
+        }
+      },
+    },
+    {
+      name: 'export',
+      description:
+        'Write the action items as todo.txt or as JSON for `task import`. Usage: /todo export todotxt|taskwarrior [file]',
+      completion: async (_context, partialArg) =>
+        Object.keys(FORMATS).filter((format) => format.startsWith(partialArg)),
+      action: async (context, args) => {
+        const [format, file] = args.trim().split(/\s+/);
+        if (!FORMATS[format]) {
+          return error('Usage: /todo export todotxt|taskwarrior [file]');
+        }
+        const root = getRoot(context);
+        const filePath = path.resolve(root, file || FORMATS[format].file);
+        try {
+          const items = await loadActionItems(root);
+          if (items.length === 0) {
+            return info(EMPTY);
+          }
+          const project = path.basename(root);
+          await fs.promises.writeFile(
+            filePath,
+            format === 'todotxt'
+              ? formatTodoTxt(items, project)
+              : formatTaskwarrior(items, project),
+            'utf8',
+          );
+          return info(
+            `Wrote ${items.length} action items to ${path.relative(root, filePath) || filePath}.` +
+              (format === 'taskwarrior'
+                ? ` Load them with: task import ${filePath}`
+                : ''),
+          );
+        } catch (e) {
+          return error(`Could not export to ${filePath}: ${getErrorMessage(e)}`);
+        }
+      },
+    },
+    {
+      name: 'import',
+      description:
+        'Mark action items done that are done in a todo.txt file or in the output of `task export`. Usage: /todo import <file>',
+      action: async (context, args) => {
+        const file = args.trim();
+        if (!file) {
+          return error('Usage: /todo import <file>');
+        }
+        const root = getRoot(context);
+        try {
+          const text = await fs.promises.readFile(
+            path.resolve(root, file),
+            'utf8',
+          );
+          const items = await loadActionItems(root);
+          const imported: ImportedItem[] = text.trimStart().startsWith('[')
+            ? parseTaskwarrior(text, items)
+            : parseTodoTxt(text);
+          const { items: updated, completed } = syncCompletedItems(
+            items,
+            imported,
+          );
+          await saveActionItems(root, updated);
+          const open = updated.filter((item) => !item.done).length;
+          return info(
+            `Marked ${completed} action item${completed === 1 ? '' : 's'} done from ${file}; ${open} still open.`,
+          );
+        } catch (e) {
+          return error(`Could not import ${file}: ${getErrorMessage(e)}`);
+        }
+      },
+    },
+  ],
+};
@@ -6,6 +6,7 @@
 
 import { Config, GitService, Logger } from '@iechor/research-cli-core';
 import { LoadedSettings } from '../../config/settings.js';
+import { HistoryItem } from '../types.js';
 import { UseHistoryManagerReturn } from '../hooks/useHistoryManager.js';
 import { SessionStatsState } from '../contexts/SessionContext.js';
 
@@ -23,13 +24,16 @@ export interface CommandContext {
   ui: {
     // TODO - As more commands are add some additions may be needed or reworked using this new context.
     // Ex.
-    // history: HistoryItem[];
     // pendingHistoryItems: HistoryItemWithoutId[];
 
+    /** The history items held in memory, oldest first. */
+    history: HistoryItem[];
     /** Adds a new item to the history display. */
     addItem: UseHistoryManagerReturn['addItem'];
     /** Clears all history items and the console screen. */
     clear: () => void;
+    /** Pages earlier history items back in from disk; returns how many. */
+    loadOlderHistory: (count: number) => number;
     /**
      * Sets the transient debug message displayed in the application footer in debug mode.
      */
@@ -39,6 +43,8 @@ export interface CommandContext {
   session: {
     stats: SessionStatsState;
   };
+  /** Aborted when the user cancels the command with Escape. */
+  abortSignal: AbortSignal;
 }
 
 /**
@@ -66,7 +72,7 @@ export interface MessageActionReturn {
 export interface OpenDialogActionReturn {
   type: 'dialog';
   // TODO: Add 'theme' | 'auth' | 'editor' | 'privacy' as migration happens.
-  dialog: 'help' | 'theme';
+  dialog: 'help' | 'theme' | 'outline' | 'sessions';
 }
 
 export type SlashCommandActionReturn =
@@ -0,0 +1,109 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { MessageType } from '../types.js';
+import { SlashCommand, SlashCommandActionReturn } from './types.js';
+import { getCliVersion } from '../../utils/version.js';
+import { getPackageJson } from '../../utils/package.js';
+import {
+  checkForRelease,
+  downloadVerifiedArchive,
+  getInstalledBundleDir,
+  getReleaseArchiveName,
+  installArchive,
+} from '../../utils/selfUpdate.js';
+
+function errorMessage(error: unknown): string {
+  return error instanceof Error ? error.message : String(error);
+}
+
+async function npmInstructions(): Promise<string> {
+  const name = (await getPackageJson())?.name ?? '@iechor/research-cli';
+  return `This installation is managed by npm. Run npm install -g ${name}@latest to update.`;
+}
+
+export const updateCommand: SlashCommand = {
+  name: 'update',
+  description: 'Download and install the latest release.',
+  action: async (context): Promise<SlashCommandActionReturn | void> => {
+    const bundleDir = getInstalledBundleDir();
+    if (!bundleDir) {
+      return {
+        type: 'message',
+        messageType: 'info',
+        content: await npmInstructions(),
+      };
+    }
+
+    const current = await getCliVersion();
+    let check;
+    try {
+      check = await checkForRelease(current);
+    } catch (error) {
+      return {
+        type: 'message',
+        messageType: 'error',
+        content: errorMessage(error),
+      };
+    }
+    if (!check.updateAvailable) {
+      return {
+        type: 'message',
+        messageType: 'info',
+        content: `Research CLI ${current} is up to date.`,
+      };
+    }
+
+    const archiveName = getReleaseArchiveName();
+    context.ui.addItem(
+      {
+        type: MessageType.INFO,
+        text: `Downloading ${check.release.tag} (${archiveName})...`,
+      },
+      Date.now(),
+    );
+    try {
+      const data = await downloadVerifiedArchive(check.release, archiveName);
+      installArchive(data, archiveName, bundleDir);
+    } catch (error) {
+      return {
+        type: 'message',
+        messageType: 'error',
+        content: `Update failed: ${errorMessage(error)}`,
+      };
+    }
+    return {
+      type: 'message',
+      messageType: 'info',
+      content: `Updated Research CLI ${current} → ${check.latest} (checksum verified). Restart the CLI to use the new version.`,
+    };
+  },
+  subCommands: [
+    {
+      name: 'check',
+      description: 'Check whether a newer release is available.',
+      action: async (): Promise<SlashCommandActionReturn> => {
+        const current = await getCliVersion();
+        try {
+          const check = await checkForRelease(current);
+          return {
+            type: 'message',
+            messageType: 'info',
+            content: check.updateAvailable
+              ? `Research CLI ${check.latest} is available (you have ${current}). Run /update to install it.`
+              : `Research CLI ${current} is up to date.`,
+          };
+        } catch (error) {
+          return {
+            type: 'message',
+            messageType: 'error',
+            content: errorMessage(error),
+          };
+        }
+      },
+    },
+  ],
+};
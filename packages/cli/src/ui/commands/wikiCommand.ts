@@ -0,0 +1,87 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import {
+  fetchWikiCard,
+  getErrorMessage,
+  renderWikiCard,
+} from '@iechor/research-cli-core';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import { MessageType } from '../types.js';
+
+const USAGE = 'Usage: /wiki [--lang <code>] <topic>';
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+/** The Wikipedia edition for the configured research language, e.g. `zh`. */
+async function getWikiLanguage(context: CommandContext): Promise<string> {
+  try {
+    const settings = await context.services.config
+      ?.getResearchConfigManager()
+      .getResearchConfig();
+    const language = settings?.defaults?.language;
+    if (language) {
+      return language.split(/[-_]/)[0].toLowerCase();
+    }
+  } catch {
+    // An unreadable research config falls back to English.
+  }
+  return 'en';
+}
+
+export const wikiCommand: SlashCommand = {
+  name: 'wiki',
+  description: `Show a Wikipedia summary with Wikidata facts and add it to the conversation. ${USAGE}`,
+  action: async (context, args) => {
+    let topic = args.trim();
+    let language: string | undefined;
+    const langMatch = topic.match(/^--lang(?:=|\s+)(\S+)\s*/);
+    if (langMatch) {
+      language = langMatch[1];
+      topic = topic.slice(langMatch[0].length);
+    }
+    if (!topic) {
+      return error(USAGE);
+    }
+    language ??= await getWikiLanguage(context);
+
+    let rendered: string;
+    let title: string;
+    try {
+      const card = await fetchWikiCard(topic, language);
+      if (!card) {
+        return error(
+          `No ${language}.wikipedia.org article was found for "${topic}".`,
+        );
+      }
+      rendered = renderWikiCard(card);
+      title = card.title;
+    } catch (e) {
+      return error(`Could not look up "${topic}": ${getErrorMessage(e)}`);
+    }
+
+    context.ui.addItem({ type: MessageType.INFO, text: rendered }, Date.now());
+    await context.services.config?.getResearchClient()?.addHistory({
+      role: 'user',
+      parts: [
+        {
+          text: `I looked up "${topic}" on Wikipedia and Wikidata:\n\n${rendered}`,
+        },
+      ],
+    });
+    return {
+      type: 'message',
+      messageType: 'info',
+      content: `Added the Wikipedia card for "${title}" to the conversation.`,
+    };
+  },
+};
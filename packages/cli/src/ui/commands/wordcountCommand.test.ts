@@ -0,0 +1,113 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { Config } from '@iechor/research-cli-core';
+import { wordcountCommand } from './wordcountCommand.js';
+import { readabilityCommand } from './readabilityCommand.js';
+import { createMockCommandContext } from '../../test-utils/mockCommandContext.js';
+import { MessageType } from '../types.js';
+
+const PAPER = String.raw`\documentclass{article}
+\begin{document}
+\section{Introduction}
+Large models are trained on web data~\citep{brown2020}.
+We propose a simple method.
+\section{Method}
+The encoder is frozen.
+\end{document}
+`;
+
+describe('wordcountCommand and readabilityCommand', () => {
+  let tempDir: string;
+
+  const context = (history: unknown[] = []) =>
+    createMockCommandContext({
+      services: {
+        config: {
+          getTargetDir: () => tempDir,
+          getResearchClient: () => ({ getHistory: () => history }),
+        } as unknown as Config,
+      },
+    });
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'wordcount-command-'));
+    fs.writeFileSync(path.join(tempDir, 'paper.tex'), PAPER);
+  });
+
+  afterEach(() => {
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should count a LaTeX file by section', async () => {
+    const ctx = context();
+    await wordcountCommand.action!(ctx, '@paper.tex');
+    expect(ctx.ui.addItem).toHaveBeenCalledWith(
+      {
+        type: MessageType.INFO,
+        text: [
+          'paper.tex: 18 words, 109 characters, 3 sentences',
+          '',
+          '  Introduction      12',
+          '  Method             4',
+        ].join('\n'),
+      },
+      expect.any(Number),
+    );
+  });
+
+  it('should count a section or a line range', async () => {
+    const ctx = context();
+    await wordcountCommand.action!(ctx, 'paper.tex --section meth');
+    await wordcountCommand.action!(ctx, 'paper.tex:5-5');
+    expect(ctx.ui.addItem).toHaveBeenNthCalledWith(
+      1,
+      {
+        type: MessageType.INFO,
+        text: 'paper.tex, section "meth": 5 words, 29 characters, 1 sentences',
+      },
+      expect.any(Number),
+    );
+    expect(ctx.ui.addItem).toHaveBeenNthCalledWith(
+      2,
+      {
+        type: MessageType.INFO,
+        text: 'paper.tex, lines 5-5: 5 words, 27 characters, 1 sentences',
+      },
+      expect.any(Number),
+    );
+  });
+
+  it('should measure the latest response without arguments', async () => {
+    const ctx = context([
+      { role: 'user', parts: [{ text: 'Shorten the abstract.' }] },
+      {
+        role: 'model',
+        parts: [{ text: 'The model was trained on a corpus. We test it.' }],
+      },
+    ]);
+    await readabilityCommand.action!(ctx, '');
+    const { text } = vi.mocked(ctx.ui.addItem).mock.calls[0][0] as {
+      text: string;
+    };
+    expect(text).toContain('the latest response:');
+    expect(text).toContain('Passive voice:        50% of sentences (1)');
+    expect(text).toContain('  - The model was trained on a corpus.');
+  });
+
+  it('should report a missing file', async () => {
+    expect(
+      await readabilityCommand.action!(context(), '@draft.tex'),
+    ).toMatchObject({
+      messageType: 'error',
+      content: 'File not found: draft.tex',
+    });
+  });
+});
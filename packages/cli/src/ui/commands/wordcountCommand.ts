@@ -0,0 +1,156 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import {
+  computeTextMetrics,
+  countWordsBySection,
+  extractLatexSection,
+  getErrorMessage,
+} from '@iechor/research-cli-core';
+import stringWidth from 'string-width';
+import { padToWidth } from '../utils/textUtils.js';
+import {
+  CommandContext,
+  SlashCommand,
+  SlashCommandActionReturn,
+} from './types.js';
+import { MessageType } from '../types.js';
+
+const USAGE =
+  'Usage: /wordcount [<file>[:<from>-<to>] [--section <title>] | <text>]';
+
+function error(content: string): SlashCommandActionReturn {
+  return { type: 'message', messageType: 'error', content };
+}
+
+export interface TextTarget {
+  /** What was counted, e.g. "paper.tex, section Introduction". */
+  label: string;
+  text: string;
+  /** A whole LaTeX file, which can be broken down by section. */
+  wholeLatexFile: boolean;
+}
+
+function getLatestResponse(context: CommandContext): string | undefined {
+  const history =
+    context.services.config?.getResearchClient()?.getHistory() ?? [];
+  for (let i = history.length - 1; i >= 0; i--) {
+    if (history[i].role === 'model') {
+      const text = (history[i].parts ?? [])
+        .map((part) => part.text ?? '')
+        .join('');
+      if (text.trim()) {
+        return text;
+      }
+    }
+  }
+  return undefined;
+}
+
+/**
+ * Resolves the text to measure: a file (`@` optional), a line range of it
+ * (`file:10-40`), a LaTeX section of it (`--section <title>`), the latest
+ * model response when there are no arguments, or the arguments as text.
+ * Throws `usage` when there is nothing to measure.
+ */
+export async function readTextTarget(
+  context: CommandContext,
+  args: string,
+  usage: string,
+): Promise<TextTarget> {
+  let rest = args.trim();
+  let section: string | undefined;
+  const sectionMatch = rest.match(/\s*--section\s+(.+)$/);
+  if (sectionMatch) {
+    section = sectionMatch[1].trim();
+    rest = rest.slice(0, sectionMatch.index).trim();
+  }
+
+  if (!rest) {
+    const response = getLatestResponse(context);
+    if (!response || section) {
+      throw new Error(usage);
+    }
+    return {
+      label: 'the latest response',
+      text: response,
+      wholeLatexFile: false,
+    };
+  }
+
+  const [, file, from, to] = rest.match(/^@?(\S+?)(?::(\d+)-(\d+))?$/) ?? [];
+  const root = context.services.config?.getTargetDir() ?? process.cwd();
+  const filePath = file ? path.resolve(root, file) : undefined;
+  if (!filePath || !fs.existsSync(filePath)) {
+    if (section || /^@/.test(rest)) {
+      throw new Error(`File not found: ${file ?? rest}`);
+    }
+    return { label: 'the given text', text: rest, wholeLatexFile: false };
+  }
+
+  let text = await fs.promises.readFile(filePath, 'utf8');
+  let label = path.relative(root, filePath);
+  if (from && to) {
+    text = text
+      .split('\n')
+      .slice(Number(from) - 1, Number(to))
+      .join('\n');
+    label += `, lines ${from}-${to}`;
+  }
+  if (section) {
+    const sectionText = extractLatexSection(text, section);
+    if (sectionText === undefined) {
+      throw new Error(`No section starting with "${section}" in ${label}.`);
+    }
+    text = sectionText;
+    label += `, section "${section}"`;
+  }
+  return {
+    label,
+    text,
+    wholeLatexFile: filePath.endsWith('.tex') && !from && !section,
+  };
+}
+
+export const wordcountCommand: SlashCommand = {
+  name: 'wordcount',
+  description:
+    'Count the words, characters and sentences of a draft, without LaTeX or Markdown markup. ' +
+    USAGE,
+  action: async (context: CommandContext, args: string) => {
+    let target: TextTarget;
+    try {
+      target = await readTextTarget(context, args, USAGE);
+    } catch (e) {
+      return error(getErrorMessage(e));
+    }
+    const metrics = computeTextMetrics(target.text);
+    const lines = [
+      `${target.label}: ${metrics.words.toLocaleString('en-US')} words, ${metrics.characters.toLocaleString('en-US')} characters, ${metrics.sentences.toLocaleString('en-US')} sentences`,
+    ];
+    if (target.wholeLatexFile) {
+      const sections = countWordsBySection(target.text);
+      if (sections.length > 1) {
+        const width = Math.max(
+          ...sections.map((s) => stringWidth(s.title)),
+        );
+        lines.push(
+          '',
+          ...sections.map(
+            (s) =>
+              `  ${padToWidth(s.title, width)}  ${String(s.words).padStart(6)}`,
+          ),
+        );
+      }
+    }
+    context.ui.addItem(
+      { type: MessageType.INFO, text: lines.join('\n') },
+      Date.now(),
+    );
+  },
+};
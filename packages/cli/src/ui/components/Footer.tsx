@@ -15,10 +15,21 @@ import {
 import { ConsoleSummaryDisplay } from './ConsoleSummaryDisplay.js';
 import process from 'node:process';
 import Gradient from 'ink-gradient';
-import { MemoryUsageDisplay } from './MemoryUsageDisplay.js';
+import { MemoryUsageDisplay, useMemoryUsage } from './MemoryUsageDisplay.js';
+import { SegmentedStatusBar } from './SegmentedStatusBar.js';
+import {
+  buildStatusSegments,
+  ResolvedStatusBar,
+} from '../utils/statusBar.js';
+import { truncateStartToWidth } from '../utils/textUtils.js';
 
 interface FooterProps {
   model: string;
+  fallbackModel?: string;
+  redactionCount?: number;
+  deadlineWarning?: string;
+  focusStatus?: string;
+  incognito?: boolean;
   targetDir: string;
   branchName?: string;
   debugMode: boolean;
@@ -29,10 +40,16 @@ interface FooterProps {
   showMemoryUsage?: boolean;
   promptTokenCount: number;
   nightly: boolean;
+  statusBar?: ResolvedStatusBar;
 }
 
 export const Footer: React.FC<FooterProps> = ({
   model,
+  fallbackModel,
+  redactionCount,
+  deadlineWarning,
+  focusStatus,
+  incognito,
   targetDir,
   branchName,
   debugMode,
@@ -43,9 +60,46 @@ export const Footer: React.FC<FooterProps> = ({
   showMemoryUsage,
   promptTokenCount,
   nightly,
+  statusBar,
 }) => {
   const limit = tokenLimit(model);
   const percentage = promptTokenCount / limit;
+  // shortenPath counts characters; wide ones take two columns each
+  const shownPath = truncateStartToWidth(
+    shortenPath(tildeifyPath(targetDir), 70),
+    70,
+  );
+
+  const segmented = !!statusBar && statusBar.style !== 'plain';
+  // The plain footer draws its own, which keeps itself up to date
+  const memoryUsage = useMemoryUsage(segmented && !!showMemoryUsage);
+
+  if (statusBar && segmented) {
+    const sandbox = process.env.SANDBOX;
+    const { left, right } = buildStatusSegments({
+      path: shownPath,
+      branchName,
+      sandbox:
+        sandbox === 'sandbox-exec'
+          ? `MacOS Seatbelt (${process.env.SEATBELT_PROFILE})`
+          : (sandbox?.replace(/^research-(?:cli-)?/, '') ??
+            'no sandbox (see /docs)'),
+      incognito,
+      debugMessage: debugMode ? debugMessage || '--debug' : undefined,
+      model,
+      fallbackModel,
+      contextLeftPercent: Number(((1 - percentage) * 100).toFixed(0)),
+      redactionCount,
+      deadlineWarning,
+      focusStatus,
+      errorCount: showErrorDetails ? 0 : errorCount,
+      corgiMode,
+      memoryUsage: showMemoryUsage ? memoryUsage.text : undefined,
+    });
+    return (
+      <SegmentedStatusBar left={left} right={right} statusBar={statusBar} />
+    );
+  }
 
   return (
     <Box marginTop={1} justifyContent="space-between" width="100%">
@@ -53,16 +107,22 @@ export const Footer: React.FC<FooterProps> = ({
         {nightly ? (
           <Gradient colors={Colors.GradientColors}>
             <Text>
-              {shortenPath(tildeifyPath(targetDir), 70)}
+              {shownPath}
               {branchName && <Text> ({branchName}*)</Text>}
             </Text>
           </Gradient>
         ) : (
           <Text color={Colors.LightBlue}>
-            {shortenPath(tildeifyPath(targetDir), 70)}
+            {shownPath}
             {branchName && <Text color={Colors.Gray}> ({branchName}*)</Text>}
           </Text>
         )}
+        {incognito && (
+          <Text color={Colors.AccentPurple} bold>
+            {' '}
+            🕶 incognito
+          </Text>
+        )}
         {debugMode && (
           <Text color={Colors.AccentRed}>
             {' ' + (debugMessage || '--debug')}
@@ -98,10 +158,33 @@ export const Footer: React.FC<FooterProps> = ({
         <Text color={Colors.AccentBlue}>
           {' '}
           {model}{' '}
+          {fallbackModel && (
+            <Text color={Colors.AccentYellow}>(↪ {fallbackModel}) </Text>
+          )}
           <Text color={Colors.Gray}>
             ({((1 - percentage) * 100).toFixed(0)}% context left)
           </Text>
         </Text>
+        {!!redactionCount && (
+          <Text>
+            <Text color={Colors.Gray}>| </Text>
+            <Text color={Colors.AccentYellow}>
+              🔒 {redactionCount} redacted{' '}
+            </Text>
+          </Text>
+        )}
+        {deadlineWarning && (
+          <Text>
+            <Text color={Colors.Gray}>| </Text>
+            <Text color={Colors.AccentYellow}>⏰ {deadlineWarning} </Text>
+          </Text>
+        )}
+        {focusStatus && (
+          <Text>
+            <Text color={Colors.Gray}>| </Text>
+            <Text color={Colors.AccentGreen}>{focusStatus} </Text>
+          </Text>
+        )}
         {corgiMode && (
           <Text>
             <Text color={Colors.Gray}>| </Text>
@@ -8,6 +8,7 @@ import React from 'react';
 import { Box, Text } from 'ink';
 import { Colors } from '../colors.js';
 import { SlashCommand } from '../commands/types.js';
+import { getPlatformKeyLabels } from '../utils/windowsTerminal.js';
 
 interface Help {
   commands: SlashCommand[];
@@ -123,10 +124,16 @@ export const Help: React.FC<Help> = ({ commands }) => (
     </Text>
     <Text color={Colors.Foreground}>
       <Text bold color={Colors.AccentPurple}>
-        Alt+Left/Right
+        {getPlatformKeyLabels().wordJump}
       </Text>{' '}
       - Jump through words in the input
     </Text>
+    <Text color={Colors.Foreground}>
+      <Text bold color={Colors.AccentPurple}>
+        {getPlatformKeyLabels().pasteImage}
+      </Text>{' '}
+      - Paste an image from the clipboard
+    </Text>
     <Text color={Colors.Foreground}>
       <Text bold color={Colors.AccentPurple}>
         Shift+Tab
@@ -139,6 +146,30 @@ export const Help: React.FC<Help> = ({ commands }) => (
       </Text>{' '}
       - Toggle YOLO mode
     </Text>
+    <Text color={Colors.Foreground}>
+      <Text bold color={Colors.AccentPurple}>
+        Ctrl+G
+      </Text>{' '}
+      - Show or hide the tokens and context of each exchange
+    </Text>
+    <Text color={Colors.Foreground}>
+      <Text bold color={Colors.AccentPurple}>
+        Ctrl+N
+      </Text>{' '}
+      - Pick a session to switch to, on an empty prompt
+    </Text>
+    <Text color={Colors.Foreground}>
+      <Text bold color={Colors.AccentPurple}>
+        Ctrl+R
+      </Text>{' '}
+      - Preview what the prompt would send, with its tokens
+    </Text>
+    <Text color={Colors.Foreground}>
+      <Text bold color={Colors.AccentPurple}>
+        Ctrl+Z
+      </Text>{' '}
+      - Zoom the focused pane to the full terminal, and back
+    </Text>
     <Text color={Colors.Foreground}>
       <Text bold color={Colors.AccentPurple}>
         Esc
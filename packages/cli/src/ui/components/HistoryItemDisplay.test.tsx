@@ -9,6 +9,8 @@ import { describe, it, expect, vi } from 'vitest';
 import { HistoryItemDisplay } from './HistoryItemDisplay.js';
 import { HistoryItem, MessageType } from '../types.js';
 import { SessionStatsProvider } from '../contexts/SessionContext.js';
+import { MessageLayoutContext } from '../contexts/MessageLayoutContext.js';
+import { resolveMessageLayout } from '../utils/messageLayout.js';
 
 // Mock child components
 vi.mock('./messages/ToolGroupMessage.js', () => ({
@@ -109,4 +111,52 @@ describe('<HistoryItemDisplay />', () => {
     );
     expect(lastFrame()).toContain('Agent powering down. Goodbye!');
   });
+
+  it('renders role names and no border in compact mode', () => {
+    const item: HistoryItem = {
+      ...baseItem,
+      type: MessageType.USER,
+      text: 'Hello',
+    };
+    const { lastFrame } = render(
+      <MessageLayoutContext.Provider
+        value={resolveMessageLayout({ compact: true, roleLabels: 'names' })}
+      >
+        <HistoryItemDisplay {...baseItem} item={item} />
+      </MessageLayoutContext.Provider>,
+    );
+    expect(lastFrame()).toBe('You: Hello');
+  });
+
+  it('renders the classic border and symbols by default', () => {
+    const item: HistoryItem = {
+      ...baseItem,
+      type: MessageType.USER,
+      text: 'Hello',
+    };
+    const { lastFrame } = render(
+      <HistoryItemDisplay {...baseItem} item={item} />,
+    );
+    expect(lastFrame()).toContain('╭');
+    expect(lastFrame()).toContain('> Hello');
+  });
+
+  it('renders the prefix set for the model of an answer', () => {
+    const item: HistoryItem = {
+      ...baseItem,
+      type: 'research',
+      text: 'An answer',
+      model: 'gpt-4o',
+    };
+    const { lastFrame } = render(
+      <MessageLayoutContext.Provider
+        value={resolveMessageLayout(undefined, {
+          models: { 'gpt-*': { color: 'AccentCyan', prefix: 'GPT' } },
+        })}
+      >
+        <HistoryItemDisplay {...baseItem} item={item} />
+      </MessageLayoutContext.Provider>,
+    );
+    expect(lastFrame()).toContain('GPT An answer');
+  });
 });
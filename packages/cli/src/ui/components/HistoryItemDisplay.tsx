@@ -14,6 +14,10 @@ import { ErrorMessage } from './messages/ErrorMessage.js';
 import { ToolGroupMessage } from './messages/ToolGroupMessage.js';
 import { ResearchMessageContent } from './messages/ResearchMessageContent.js';
 import { CompressionMessage } from './messages/CompressionMessage.js';
+import { DiffReviewMessage } from './messages/DiffReviewMessage.js';
+import { ThreadMessage } from './messages/ThreadMessage.js';
+import { ExchangeInfoMessage } from './messages/ExchangeInfoMessage.js';
+import { ImageMessage } from './messages/ImageMessage.js';
 import { Box } from 'ink';
 import { AboutBox } from './AboutBox.js';
 import { StatsDisplay } from './StatsDisplay.js';
@@ -21,6 +25,7 @@ import { ModelStatsDisplay } from './ModelStatsDisplay.js';
 import { ToolStatsDisplay } from './ToolStatsDisplay.js';
 import { SessionSummaryDisplay } from './SessionSummaryDisplay.js';
 import { Config } from '@iechor/research-cli-core';
+import { useMessageLayout } from '../contexts/MessageLayoutContext.js';
 
 interface HistoryItemDisplayProps {
   item: HistoryItem;
@@ -38,55 +43,91 @@ export const HistoryItemDisplay: React.FC<HistoryItemDisplayProps> = ({
   isPending,
   config,
   isFocused = true,
-}) => (
-  <Box flexDirection="column" key={item.id}>
-    {/* Render standard message types */}
-    {item.type === 'user' && <UserMessage text={item.text} />}
-    {item.type === 'user_shell' && <UserShellMessage text={item.text} />}
-    {item.type === 'research' && (
-      <ResearchMessage
-        text={item.text}
-        isPending={isPending}
-        availableTerminalHeight={availableTerminalHeight}
-        terminalWidth={terminalWidth}
-      />
-    )}
-    {item.type === 'research_content' && (
-      <ResearchMessageContent
-        text={item.text}
-        isPending={isPending}
-        availableTerminalHeight={availableTerminalHeight}
-        terminalWidth={terminalWidth}
-      />
-    )}
-    {item.type === 'info' && <InfoMessage text={item.text} />}
-    {item.type === 'error' && <ErrorMessage text={item.text} />}
-    {item.type === 'about' && (
-      <AboutBox
-        cliVersion={item.cliVersion}
-        osVersion={item.osVersion}
-        sandboxEnv={item.sandboxEnv}
-        modelVersion={item.modelVersion}
-        selectedAuthType={item.selectedAuthType}
-        gcpProject={item.gcpProject}
-      />
-    )}
-    {item.type === 'stats' && <StatsDisplay duration={item.duration} />}
-    {item.type === 'model_stats' && <ModelStatsDisplay />}
-    {item.type === 'tool_stats' && <ToolStatsDisplay />}
-    {item.type === 'quit' && <SessionSummaryDisplay duration={item.duration} />}
-    {item.type === 'tool_group' && (
-      <ToolGroupMessage
-        toolCalls={item.tools}
-        groupId={item.id}
-        availableTerminalHeight={availableTerminalHeight}
-        terminalWidth={terminalWidth}
-        config={config}
-        isFocused={isFocused}
-      />
-    )}
-    {item.type === 'compression' && (
-      <CompressionMessage compression={item.compression} />
-    )}
-  </Box>
-);
+}) => {
+  const { spacing } = useMessageLayout();
+  return (
+    <Box flexDirection="column" key={item.id} marginTop={spacing ?? 0}>
+      {/* Render standard message types */}
+      {item.type === 'user' && <UserMessage text={item.text} />}
+      {item.type === 'user_shell' && <UserShellMessage text={item.text} />}
+      {item.type === 'research' && (
+        <ResearchMessage
+          text={item.text}
+          model={item.model}
+          isPending={isPending}
+          availableTerminalHeight={availableTerminalHeight}
+          terminalWidth={terminalWidth}
+          firstCodeBlock={item.firstCodeBlock}
+        />
+      )}
+      {item.type === 'research_content' && (
+        <ResearchMessageContent
+          text={item.text}
+          model={item.model}
+          isPending={isPending}
+          availableTerminalHeight={availableTerminalHeight}
+          terminalWidth={terminalWidth}
+          firstCodeBlock={item.firstCodeBlock}
+        />
+      )}
+      {item.type === 'info' && <InfoMessage text={item.text} />}
+      {item.type === 'error' && <ErrorMessage text={item.text} />}
+      {item.type === 'about' && (
+        <AboutBox
+          cliVersion={item.cliVersion}
+          osVersion={item.osVersion}
+          sandboxEnv={item.sandboxEnv}
+          modelVersion={item.modelVersion}
+          selectedAuthType={item.selectedAuthType}
+          gcpProject={item.gcpProject}
+        />
+      )}
+      {item.type === 'stats' && <StatsDisplay duration={item.duration} />}
+      {item.type === 'model_stats' && <ModelStatsDisplay />}
+      {item.type === 'tool_stats' && <ToolStatsDisplay />}
+      {item.type === 'quit' && (
+        <SessionSummaryDisplay duration={item.duration} />
+      )}
+      {item.type === 'tool_group' && (
+        <ToolGroupMessage
+          toolCalls={item.tools}
+          groupId={item.id}
+          availableTerminalHeight={availableTerminalHeight}
+          terminalWidth={terminalWidth}
+          config={config}
+          isFocused={isFocused}
+        />
+      )}
+      {item.type === 'compression' && (
+        <CompressionMessage compression={item.compression} />
+      )}
+      {item.type === 'diff_review' && (
+        <DiffReviewMessage
+          files={item.files}
+          review={item.review}
+          availableTerminalHeight={availableTerminalHeight}
+          terminalWidth={terminalWidth}
+        />
+      )}
+      {item.type === 'thread' && (
+        <ThreadMessage
+          threadId={item.threadId}
+          answer={item.answer}
+          exchanges={item.exchanges}
+          included={item.included}
+          collapsed={item.collapsed}
+          terminalWidth={terminalWidth}
+        />
+      )}
+      {item.type === 'exchange_info' && <ExchangeInfoMessage item={item} />}
+      {item.type === 'image' && (
+        <ImageMessage
+          id={item.id}
+          caption={item.caption}
+          file={item.file}
+          terminalWidth={terminalWidth}
+        />
+      )}
+    </Box>
+  );
+};
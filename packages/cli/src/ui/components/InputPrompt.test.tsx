@@ -221,6 +221,31 @@ describe('InputPrompt', () => {
     unmount();
   });
 
+  it('should flag terminology issues while composing', async () => {
+    props.buffer.setText('We split the data set in pytorch.');
+    props.terminology = {
+      preferred: { dataset: ['data set'] },
+      capitalization: ['PyTorch'],
+    };
+    const { lastFrame, unmount } = render(<InputPrompt {...props} />);
+    await wait();
+
+    expect(lastFrame()).toContain(
+      'Terminology: "data set" → "dataset"; "pytorch" → "PyTorch"',
+    );
+    unmount();
+  });
+
+  it('should not check slash commands against the terminology', async () => {
+    props.buffer.setText('/memory add data set');
+    props.terminology = { preferred: { dataset: ['data set'] } };
+    const { lastFrame, unmount } = render(<InputPrompt {...props} />);
+    await wait();
+
+    expect(lastFrame()).not.toContain('Terminology:');
+    unmount();
+  });
+
   describe('clipboard image paste', () => {
     beforeEach(() => {
       vi.mocked(clipboardUtils.clipboardHasImage).mockResolvedValue(false);
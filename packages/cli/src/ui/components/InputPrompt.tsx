@@ -4,13 +4,19 @@
  * SPDX-License-Identifier: Apache-2.0
  */
 
-import React, { useCallback, useEffect, useState } from 'react';
-import { Box, Text } from 'ink';
+import React, {
+  useCallback,
+  useEffect,
+  useMemo,
+  useRef,
+  useState,
+} from 'react';
+import { Box, DOMElement, Text } from 'ink';
 import { Colors } from '../colors.js';
 import { SuggestionsDisplay } from './SuggestionsDisplay.js';
 import { useInputHistory } from '../hooks/useInputHistory.js';
 import { TextBuffer } from './shared/text-buffer.js';
-import { cpSlice, cpLen } from '../utils/textUtils.js';
+import { cpSlice, cpLen, truncateToWidth } from '../utils/textUtils.js';
 import chalk from 'chalk';
 import stringWidth from 'string-width';
 import { useShellHistory } from '../hooks/useShellHistory.js';
@@ -18,12 +24,19 @@ import { useCompletion } from '../hooks/useCompletion.js';
 import { useKeypress, Key } from '../hooks/useKeypress.js';
 import { isAtCommand, isSlashCommand } from '../utils/commandUtils.js';
 import { CommandContext, SlashCommand } from '../commands/types.js';
-import { Config } from '@iechor/research-cli-core';
+import {
+  checkTerminology,
+  Config,
+  formatTerminologyIssue,
+  Terminology,
+} from '@iechor/research-cli-core';
 import {
   clipboardHasImage,
   saveClipboardImage,
   cleanupOldClipboardImages,
 } from '../utils/clipboardUtils.js';
+import { isPasteImageKey } from '../utils/windowsTerminal.js';
+import { useImeCursor } from '../hooks/useImeCursor.js';
 import * as path from 'path';
 
 export interface InputPromptProps {
@@ -40,6 +53,9 @@ export interface InputPromptProps {
   suggestionsWidth: number;
   shellModeActive: boolean;
   setShellModeActive: (value: boolean) => void;
+  terminology?: Terminology;
+  /** Shows the terminal cursor at the input cursor, for input methods. */
+  imeMode?: boolean;
 }
 
 export const InputPrompt: React.FC<InputPromptProps> = ({
@@ -56,8 +72,17 @@ export const InputPrompt: React.FC<InputPromptProps> = ({
   suggestionsWidth,
   shellModeActive,
   setShellModeActive,
+  terminology,
+  imeMode = false,
 }) => {
   const [justNavigatedHistory, setJustNavigatedHistory] = useState(false);
+  const terminologyIssues = useMemo(
+    () =>
+      terminology && !shellModeActive && !isSlashCommand(buffer.text)
+        ? checkTerminology(buffer.text, terminology)
+        : [],
+    [terminology, shellModeActive, buffer.text],
+  );
   const completion = useCompletion(
     buffer.text,
     config.getTargetDir(),
@@ -376,8 +401,8 @@ export const InputPrompt: React.FC<InputPromptProps> = ({
         return;
       }
 
-      // Ctrl+V for clipboard image paste
-      if (key.ctrl && key.name === 'v') {
+      // Ctrl+V (Alt+V on Windows) for clipboard image paste
+      if (isPasteImageKey(key)) {
         handleClipboardImage();
         return;
       }
@@ -407,6 +432,18 @@ export const InputPrompt: React.FC<InputPromptProps> = ({
     buffer.visualCursor;
   const scrollVisualRow = buffer.visualScrollRow;
 
+  // An empty buffer shows the placeholder, with the cursor at its start
+  const textRef = useRef<DOMElement>(null);
+  const cursorRowInView = cursorVisualRowAbsolute - scrollVisualRow;
+  useImeCursor(
+    imeMode && focus,
+    textRef,
+    cursorRowInView,
+    stringWidth(
+      cpSlice(linesToRender[cursorRowInView] ?? '', 0, cursorVisualColAbsolute),
+    ),
+  );
+
   return (
     <>
       <Box
@@ -419,7 +456,7 @@ export const InputPrompt: React.FC<InputPromptProps> = ({
         >
           {shellModeActive ? '! ' : '> '}
         </Text>
-        <Box flexGrow={1} flexDirection="column">
+        <Box ref={textRef} flexGrow={1} flexDirection="column">
           {buffer.text.length === 0 && placeholder ? (
             focus ? (
               <Text>
@@ -432,7 +469,7 @@ export const InputPrompt: React.FC<InputPromptProps> = ({
           ) : (
             linesToRender.map((lineText, visualIdxInRenderedSet) => {
               const cursorVisualRow = cursorVisualRowAbsolute - scrollVisualRow;
-              let display = cpSlice(lineText, 0, inputWidth);
+              let display = truncateToWidth(lineText, inputWidth);
               const currentVisualWidth = stringWidth(display);
               if (currentVisualWidth < inputWidth) {
                 display = display + ' '.repeat(inputWidth - currentVisualWidth);
@@ -454,10 +491,10 @@ export const InputPrompt: React.FC<InputPromptProps> = ({
                       cpSlice(display, 0, relativeVisualColForHighlight) +
                       highlighted +
                       cpSlice(display, relativeVisualColForHighlight + 1);
-                  } else if (
-                    relativeVisualColForHighlight === cpLen(display) &&
-                    cpLen(display) === inputWidth
-                  ) {
+                  } else if (relativeVisualColForHighlight === cpLen(display)) {
+                    // The line fills the box, so there is no padding to
+                    // highlight; wide characters make this happen with
+                    // fewer code points than columns
                     display = display + chalk.inverse(' ');
                   }
                 }
@@ -469,6 +506,14 @@ export const InputPrompt: React.FC<InputPromptProps> = ({
           )}
         </Box>
       </Box>
+      {terminologyIssues.length > 0 && (
+        <Box paddingX={1}>
+          <Text color={Colors.AccentYellow} wrap="truncate-end">
+            Terminology:{' '}
+            {terminologyIssues.map(formatTerminologyIssue).join('; ')}
+          </Text>
+        </Box>
+      )}
       {completion.showSuggestions && (
         <Box>
           <SuggestionsDisplay
@@ -10,11 +10,21 @@ import { Colors } from '../colors.js';
 import process from 'node:process';
 import { formatMemoryUsage } from '../utils/formatters.js';
 
-export const MemoryUsageDisplay: React.FC = () => {
+/**
+ * The formatted memory use of the process, updated every two seconds while
+ * `enabled`, and the color to show it in.
+ */
+export function useMemoryUsage(enabled = true): {
+  text: string;
+  color: string;
+} {
   const [memoryUsage, setMemoryUsage] = useState<string>('');
   const [memoryUsageColor, setMemoryUsageColor] = useState<string>(Colors.Gray);
 
   useEffect(() => {
+    if (!enabled) {
+      return;
+    }
     const updateMemory = () => {
       const usage = process.memoryUsage().rss;
       setMemoryUsage(formatMemoryUsage(usage));
@@ -25,12 +35,18 @@ export const MemoryUsageDisplay: React.FC = () => {
     const intervalId = setInterval(updateMemory, 2000);
     updateMemory(); // Initial update
     return () => clearInterval(intervalId);
-  }, []);
+  }, [enabled]);
+
+  return { text: memoryUsage, color: memoryUsageColor };
+}
+
+export const MemoryUsageDisplay: React.FC = () => {
+  const { text, color } = useMemoryUsage();
 
   return (
     <Box>
       <Text color={Colors.Gray}>| </Text>
-      <Text color={memoryUsageColor}>{memoryUsage}</Text>
+      <Text color={color}>{text}</Text>
     </Box>
   );
 };
@@ -0,0 +1,132 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { render } from 'ink-testing-library';
+import { describe, it, expect, vi, beforeEach, afterEach } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import {
+  formatOutline,
+  loadOutline,
+  saveOutline,
+} from '@iechor/research-cli-core';
+import { OutlinePane } from './OutlinePane.js';
+
+const wait = (ms = 50) => new Promise((resolve) => setTimeout(resolve, ms));
+
+describe('OutlinePane', () => {
+  let tempDir: string;
+  const onDraft = vi.fn();
+  const onClose = vi.fn();
+
+  const renderPane = () =>
+    render(
+      <OutlinePane projectRoot={tempDir} onDraft={onDraft} onClose={onClose} />,
+    );
+
+  beforeEach(async () => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'outline-pane-'));
+    onDraft.mockReset();
+    onClose.mockReset();
+    await saveOutline(tempDir, {
+      notesFile: 'notes/draft.md',
+      items: [
+        { id: 'a', title: 'Introduction', children: [] },
+        {
+          id: 'b',
+          title: 'Method',
+          children: [{ id: 'c', title: 'Model', children: [] }],
+        },
+      ],
+    });
+  });
+
+  afterEach(() => {
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should show the outline with the first section selected', async () => {
+    const { lastFrame, unmount } = renderPane();
+    await wait();
+
+    expect(lastFrame()).toContain('drafts go to notes/draft.md');
+    expect(lastFrame()).toContain('› 1 Introduction');
+    expect(lastFrame()).toContain('    2.1 Model');
+    unmount();
+  });
+
+  it('should start from the saved selection and report changes', async () => {
+    const onSelectionChange = vi.fn();
+    const { stdin, lastFrame, unmount } = render(
+      <OutlinePane
+        projectRoot={tempDir}
+        onDraft={onDraft}
+        onClose={onClose}
+        initialSelection="c"
+        onSelectionChange={onSelectionChange}
+      />,
+    );
+    await wait();
+
+    expect(lastFrame()).toContain('›   2.1 Model');
+    stdin.write('k');
+    await wait();
+    expect(onSelectionChange).toHaveBeenLastCalledWith('b');
+    unmount();
+  });
+
+  it('should reorder sections with the keyboard and save them', async () => {
+    const { stdin, unmount } = renderPane();
+    await wait();
+
+    stdin.write('J');
+    await wait();
+
+    expect(formatOutline((await loadOutline(tempDir)).items)).toBe(
+      '1 Method\n  1.1 Model\n2 Introduction',
+    );
+    unmount();
+  });
+
+  it('should add a bullet under the selected section', async () => {
+    const { stdin, lastFrame, unmount } = renderPane();
+    await wait();
+
+    stdin.write('j');
+    await wait();
+    stdin.write('c');
+    await wait();
+    stdin.write('Data');
+    await wait();
+    expect(lastFrame()).toContain('New bullet: Data');
+    stdin.write('\r');
+    await wait();
+
+    expect(formatOutline((await loadOutline(tempDir)).items)).toBe(
+      '1 Introduction\n2 Method\n  2.1 Model\n  2.2 Data',
+    );
+    unmount();
+  });
+
+  it('should draft the selected section and close', async () => {
+    const { stdin, unmount } = renderPane();
+    await wait();
+
+    stdin.write('j');
+    await wait();
+    stdin.write('j');
+    await wait();
+    stdin.write('w');
+    await wait();
+    expect(onDraft).toHaveBeenCalledWith('2.1');
+
+    stdin.write('q');
+    await wait();
+    expect(onClose).toHaveBeenCalled();
+    unmount();
+  });
+});
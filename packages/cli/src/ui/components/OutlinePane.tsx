@@ -0,0 +1,267 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import React, { useEffect, useRef, useState } from 'react';
+import { Box, Text, useInput } from 'ink';
+import {
+  Outline,
+  OutlineItem,
+  createOutlineItem,
+  flattenOutline,
+  getErrorMessage,
+  insertOutlineItem,
+  loadOutline,
+  moveOutlineItem,
+  removeOutlineItem,
+  saveOutline,
+} from '@iechor/research-cli-core';
+import { Colors } from '../colors.js';
+
+const KEY_HELP =
+  '↑↓ select · J/K move down/up · →/← indent/outdent · a add · c add bullet · e edit · d delete · w draft · Esc close';
+
+interface OutlinePaneProps {
+  projectRoot: string;
+  /** Called with a section number such as "2.1" to draft it. */
+  onDraft: (number: string) => void;
+  onClose: () => void;
+  /** Id of the section to select first, such as the one saved last time. */
+  initialSelection?: string;
+  /** Called with the id of the selected section when it changes. */
+  onSelectionChange?: (id: string) => void;
+  availableTerminalHeight?: number;
+}
+
+type Entry = { mode: 'add' | 'child' | 'edit'; text: string };
+
+/**
+ * Edits `.research/outline.json` with the keyboard. Every change is saved
+ * at once, so /outline commands in the chat see it.
+ */
+export function OutlinePane({
+  projectRoot,
+  onDraft,
+  onClose,
+  initialSelection,
+  onSelectionChange,
+  availableTerminalHeight,
+}: OutlinePaneProps): React.JSX.Element {
+  const [outline, setOutline] = useState<Outline | undefined>(undefined);
+  const [selected, setSelected] = useState(0);
+  const [entry, setEntry] = useState<Entry | undefined>(undefined);
+  const [confirmDelete, setConfirmDelete] = useState(false);
+  const [status, setStatus] = useState<string | undefined>(undefined);
+  // Only used when the outline is first read
+  const initialSelectionRef = useRef(initialSelection);
+
+  useEffect(() => {
+    loadOutline(projectRoot).then(
+      (loaded) => {
+        setOutline(loaded);
+        setSelected(
+          Math.max(
+            0,
+            flattenOutline(loaded.items).findIndex(
+              (r) => r.item.id === initialSelectionRef.current,
+            ),
+          ),
+        );
+      },
+      (e) => setStatus(`Could not read the outline: ${getErrorMessage(e)}`),
+    );
+  }, [projectRoot]);
+
+  const rows = outline ? flattenOutline(outline.items) : [];
+  const row = rows[Math.min(selected, rows.length - 1)];
+
+  const selectedId = row?.item.id;
+  useEffect(() => {
+    if (selectedId !== undefined) {
+      onSelectionChange?.(selectedId);
+    }
+  }, [selectedId, onSelectionChange]);
+
+  const update = (change: (items: OutlineItem[]) => number[] | undefined) => {
+    if (!outline) {
+      return;
+    }
+    const items = structuredClone(outline.items);
+    const newPath = change(items);
+    if (!newPath) {
+      return;
+    }
+    const next = { ...outline, items };
+    setOutline(next);
+    setSelected(
+      Math.max(
+        0,
+        flattenOutline(items).findIndex(
+          (r) => r.path.join('.') === newPath.join('.'),
+        ),
+      ),
+    );
+    setStatus(undefined);
+    saveOutline(projectRoot, next).catch((e) =>
+      setStatus(`Could not save the outline: ${getErrorMessage(e)}`),
+    );
+  };
+
+  const commitEntry = ({ mode, text }: Entry) => {
+    const title = text.trim();
+    if (!title) {
+      return;
+    }
+    update((items) => {
+      if (mode === 'edit') {
+        if (!row) {
+          return undefined;
+        }
+        const [edited] = flattenOutline(items).filter(
+          (r) => r.item.id === row.item.id,
+        );
+        edited.item.title = title;
+        return edited.path;
+      }
+      return insertOutlineItem(
+        items,
+        row?.path,
+        createOutlineItem(title),
+        mode === 'child',
+      );
+    });
+  };
+
+  useInput((input, key) => {
+    if (entry) {
+      if (key.escape) {
+        setEntry(undefined);
+      } else if (key.return) {
+        commitEntry(entry);
+        setEntry(undefined);
+      } else if (key.backspace || key.delete) {
+        setEntry({ ...entry, text: entry.text.slice(0, -1) });
+      } else if (input && !key.ctrl && !key.meta) {
+        setEntry({ ...entry, text: entry.text + input });
+      }
+      return;
+    }
+
+    if (input !== 'd') {
+      setConfirmDelete(false);
+    }
+    if (key.escape || input === 'q') {
+      onClose();
+    } else if (key.upArrow || input === 'k') {
+      setSelected(Math.max(0, selected - 1));
+    } else if (key.downArrow || input === 'j') {
+      setSelected(Math.min(rows.length - 1, selected + 1));
+    } else if (input === 'a') {
+      setEntry({ mode: 'add', text: '' });
+    } else if (!row) {
+      return;
+    } else if (input === 'K' || input === 'J') {
+      update((items) =>
+        moveOutlineItem(items, row.path, input === 'K' ? 'up' : 'down'),
+      );
+    } else if (key.rightArrow || input === '>') {
+      update((items) => moveOutlineItem(items, row.path, 'in'));
+    } else if (key.leftArrow || input === '<') {
+      update((items) => moveOutlineItem(items, row.path, 'out'));
+    } else if (input === 'c') {
+      setEntry({ mode: 'child', text: '' });
+    } else if (input === 'e' || key.return) {
+      setEntry({ mode: 'edit', text: row.item.title });
+    } else if (input === 'd') {
+      if (confirmDelete) {
+        setConfirmDelete(false);
+        update((items) => {
+          removeOutlineItem(items, row.path);
+          return row.path[row.path.length - 1] > 0
+            ? [...row.path.slice(0, -1), row.path[row.path.length - 1] - 1]
+            : row.path.slice(0, -1);
+        });
+      } else {
+        setConfirmDelete(true);
+      }
+    } else if (input === 'w') {
+      onDraft(row.number);
+    }
+  });
+
+  // Keep the selection in view when the outline is taller than the screen
+  const maxRows =
+    availableTerminalHeight !== undefined
+      ? Math.max(availableTerminalHeight - 8, 3)
+      : rows.length;
+  const first = Math.min(
+    Math.max(0, selected - Math.floor(maxRows / 2)),
+    Math.max(0, rows.length - maxRows),
+  );
+
+  return (
+    <Box
+      borderStyle="round"
+      borderColor={Colors.Gray}
+      flexDirection="column"
+      padding={1}
+      width="100%"
+    >
+      <Text bold>
+        Outline
+        {outline && (
+          <Text color={Colors.Gray}> (drafts go to {outline.notesFile})</Text>
+        )}
+      </Text>
+      <Box flexDirection="column" marginTop={1}>
+        {outline && rows.length === 0 && (
+          <Text color={Colors.Gray}>
+            The outline is empty. Press a to add a section, or run /outline
+            generate to outline the session.
+          </Text>
+        )}
+        {rows.slice(first, first + maxRows).map((r) => {
+          const isSelected = r === row;
+          return (
+            <Text
+              key={r.item.id}
+              color={isSelected ? Colors.AccentBlue : undefined}
+              wrap="truncate-end"
+            >
+              {isSelected ? '› ' : '  '}
+              {'  '.repeat(r.depth)}
+              {r.number} {r.item.title}
+              {r.item.drafted && <Text color={Colors.AccentGreen}> ✓</Text>}
+            </Text>
+          );
+        })}
+      </Box>
+      <Box marginTop={1}>
+        {entry ? (
+          <Text>
+            {entry.mode === 'edit'
+              ? 'Title: '
+              : entry.mode === 'child'
+                ? 'New bullet: '
+                : 'New section: '}
+            {entry.text}
+            <Text inverse> </Text>
+          </Text>
+        ) : confirmDelete && row ? (
+          <Text color={Colors.AccentYellow}>
+            Press d again to delete {row.number} {row.item.title}
+            {row.item.children.length > 0 ? ' and everything under it' : ''}.
+          </Text>
+        ) : status ? (
+          <Text color={Colors.AccentRed}>{status}</Text>
+        ) : (
+          <Text color={Colors.Gray} wrap="wrap">
+            {KEY_HELP}
+          </Text>
+        )}
+      </Box>
+    </Box>
+  );
+}
@@ -0,0 +1,127 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import React, { useState } from 'react';
+import { Box, Text, useInput } from 'ink';
+import { Colors } from '../colors.js';
+import { PromptPreview as PromptPreviewData } from '../utils/promptPreview.js';
+
+const KEY_HELP = '↑↓ section · Enter send · Esc back to the prompt';
+
+// Lines of the open section shown when the terminal height is not known
+const DEFAULT_VISIBLE_LINES = 12;
+
+interface PromptPreviewProps {
+  /** Undefined while the request is being composed. */
+  preview: PromptPreviewData | undefined;
+  onSend: () => void;
+  onClose: () => void;
+  availableTerminalHeight?: number;
+}
+
+const formatTokens = (tokens: number) =>
+  `≈${tokens.toLocaleString('en-US')} tokens`;
+
+/**
+ * Shows what sending the prompt would send, a section at a time, with
+ * the estimated tokens of each.
+ */
+export function PromptPreview({
+  preview,
+  onSend,
+  onClose,
+  availableTerminalHeight,
+}: PromptPreviewProps): React.JSX.Element {
+  const [selected, setSelected] = useState(0);
+  const sections = preview?.sections ?? [];
+
+  useInput((_input, key) => {
+    if (key.escape) {
+      onClose();
+    } else if (key.return) {
+      onSend();
+    } else if (key.upArrow) {
+      setSelected(Math.max(0, selected - 1));
+    } else if (key.downArrow) {
+      setSelected(Math.min(sections.length - 1, selected + 1));
+    }
+  });
+
+  // The frame, the title and a row per section come off the height
+  const visibleLines =
+    availableTerminalHeight === undefined
+      ? DEFAULT_VISIBLE_LINES
+      : Math.max(3, availableTerminalHeight - sections.length - 10);
+  const open = sections[selected];
+  const shown = open ? open.lines.slice(0, visibleLines) : [];
+  const hidden = open ? open.lines.length - shown.length : 0;
+  const overLimit = !!preview && preview.totalTokens > preview.limit;
+
+  return (
+    <Box
+      borderStyle="round"
+      borderColor={Colors.Gray}
+      flexDirection="column"
+      padding={1}
+      width="100%"
+    >
+      <Text bold>
+        Prompt preview
+        {preview && (
+          <Text
+            bold={false}
+            color={overLimit ? Colors.AccentRed : Colors.Gray}
+          >
+            {`  ${formatTokens(preview.totalTokens)} of ${preview.limit.toLocaleString('en-US')} · ${preview.model}`}
+          </Text>
+        )}
+      </Text>
+      {!preview && <Text color={Colors.Gray}>Composing the request…</Text>}
+      <Box flexDirection="column" marginTop={1}>
+        {sections.map((section) => {
+          const isSelected = section === open;
+          return (
+            <Text
+              key={section.title}
+              color={isSelected ? Colors.AccentBlue : undefined}
+              wrap="truncate-end"
+            >
+              {isSelected ? '› ' : '  '}
+              {section.title}
+              <Text color={Colors.Gray}>
+                {`  ${formatTokens(section.tokens)}`}
+                {section.summary && ` · ${section.summary}`}
+              </Text>
+            </Text>
+          );
+        })}
+      </Box>
+      {open && shown.length > 0 && (
+        <Box
+          flexDirection="column"
+          marginTop={1}
+          borderStyle="single"
+          borderColor={Colors.Gray}
+          paddingX={1}
+        >
+          {shown.map((line, index) => (
+            <Text key={index} wrap="truncate-end">
+              {line || ' '}
+            </Text>
+          ))}
+          {hidden > 0 && (
+            <Text color={Colors.Gray}>
+              {`… ${hidden.toLocaleString('en-US')} more line${hidden === 1 ? '' : 's'}`}
+            </Text>
+          )}
+        </Box>
+      )}
+      <Box marginTop={1}>
+        <Text color={Colors.Gray}>{KEY_HELP}</Text>
+      </Box>
+    </Box>
+  );
+}
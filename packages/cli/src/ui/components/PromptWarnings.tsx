@@ -0,0 +1,30 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import React from 'react';
+import { Box, Text } from 'ink';
+import { Colors } from '../colors.js';
+import { PromptWarning } from '../utils/promptLint.js';
+
+interface PromptWarningsProps {
+  warnings: PromptWarning[];
+}
+
+/** The pre-flight warnings of the prompt in the input box. */
+export const PromptWarnings: React.FC<PromptWarningsProps> = ({
+  warnings,
+}) => (
+  <Box flexDirection="column" paddingLeft={1}>
+    {warnings.map((warning) => (
+      <Text key={warning.check} color={Colors.AccentYellow} wrap="wrap">
+        ⚠ {warning.message}
+      </Text>
+    ))}
+    <Text color={Colors.Gray}>
+      Press Enter again to send anyway, or edit the prompt.
+    </Text>
+  </Box>
+);
@@ -0,0 +1,93 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import React from 'react';
+import { Box, Text } from 'ink';
+import { Colors } from '../colors.js';
+import {
+  POWERLINE_GLYPHS,
+  ResolvedStatusBar,
+  StatusSegment,
+} from '../utils/statusBar.js';
+
+interface SegmentedStatusBarProps {
+  left: StatusSegment[];
+  right: StatusSegment[];
+  statusBar: ResolvedStatusBar;
+}
+
+const colorAt = (colors: string[], index: number) =>
+  colors[index % colors.length];
+
+/**
+ * Draws the segments of the left half as powerline blocks pointing right
+ * and those of the right half as blocks pointing left. In the ASCII style
+ * the segments are colored text between `|` separators.
+ */
+export const SegmentedStatusBar: React.FC<SegmentedStatusBarProps> = ({
+  left,
+  right,
+  statusBar,
+}) => {
+  const { style, segmentColors, textColor } = statusBar;
+
+  if (style !== 'powerline') {
+    const renderAscii = (segments: StatusSegment[], offset: number) =>
+      segments.map((segment, i) => (
+        <Text key={i}>
+          {i > 0 && <Text color={Colors.Gray}> | </Text>}
+          <Text color={colorAt(segmentColors, offset + i)}>
+            {segment.text}
+          </Text>
+        </Text>
+      ));
+    return (
+      <Box marginTop={1} justifyContent="space-between" width="100%">
+        <Box>{renderAscii(left, 0)}</Box>
+        <Box>{renderAscii(right, left.length)}</Box>
+      </Box>
+    );
+  }
+
+  return (
+    <Box marginTop={1} justifyContent="space-between" width="100%">
+      <Box>
+        {left.map((segment, i) => {
+          const background = colorAt(segmentColors, i);
+          const next =
+            i + 1 < left.length ? colorAt(segmentColors, i + 1) : undefined;
+          return (
+            <Text key={i}>
+              <Text color={textColor} backgroundColor={background}>
+                {` ${segment.icon} ${segment.text} `}
+              </Text>
+              <Text color={background} backgroundColor={next}>
+                {POWERLINE_GLYPHS.right}
+              </Text>
+            </Text>
+          );
+        })}
+      </Box>
+      <Box>
+        {right.map((segment, i) => {
+          const background = colorAt(segmentColors, left.length + i);
+          const previous =
+            i > 0 ? colorAt(segmentColors, left.length + i - 1) : undefined;
+          return (
+            <Text key={i}>
+              <Text color={background} backgroundColor={previous}>
+                {POWERLINE_GLYPHS.left}
+              </Text>
+              <Text color={textColor} backgroundColor={background}>
+                {` ${segment.icon} ${segment.text} `}
+              </Text>
+            </Text>
+          );
+        })}
+      </Box>
+    </Box>
+  );
+};
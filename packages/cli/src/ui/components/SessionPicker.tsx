@@ -0,0 +1,107 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import React, { useState } from 'react';
+import { Box, Text, useInput } from 'ink';
+import { Colors } from '../colors.js';
+import {
+  SessionSummary,
+  fuzzyFilter,
+  isValidSessionName,
+} from '../utils/sessionManager.js';
+
+const KEY_HELP = 'type to search · ↑↓ select · Enter switch · Esc close';
+
+interface SessionPickerProps {
+  sessions: SessionSummary[];
+  onSelect: (name: string) => void;
+  /** Called with the search text when nothing matches it. */
+  onCreate: (name: string) => void;
+  onClose: () => void;
+}
+
+/** Lists the named sessions to switch to, narrowed as the user types. */
+export function SessionPicker({
+  sessions,
+  onSelect,
+  onCreate,
+  onClose,
+}: SessionPickerProps): React.JSX.Element {
+  const [query, setQuery] = useState('');
+  const [selected, setSelected] = useState(0);
+
+  const matches = fuzzyFilter(sessions, query, (session) => session.name);
+  const session = matches[Math.min(selected, matches.length - 1)];
+  const canCreate = matches.length === 0 && isValidSessionName(query);
+
+  useInput((input, key) => {
+    if (key.escape) {
+      onClose();
+    } else if (key.return) {
+      if (session) {
+        onSelect(session.name);
+      } else if (canCreate) {
+        onCreate(query);
+      }
+    } else if (key.upArrow) {
+      setSelected(Math.max(0, selected - 1));
+    } else if (key.downArrow) {
+      setSelected(Math.min(matches.length - 1, selected + 1));
+    } else if (key.backspace || key.delete) {
+      setQuery(query.slice(0, -1));
+      setSelected(0);
+    } else if (input && !key.ctrl && !key.meta) {
+      setQuery(query + input);
+      setSelected(0);
+    }
+  });
+
+  return (
+    <Box
+      borderStyle="round"
+      borderColor={Colors.Gray}
+      flexDirection="column"
+      padding={1}
+      width="100%"
+    >
+      <Text bold>Sessions</Text>
+      <Text>
+        <Text color={Colors.AccentPurple}>› </Text>
+        {query}
+        <Text inverse> </Text>
+      </Text>
+      <Box flexDirection="column" marginTop={1}>
+        {matches.length === 0 && (
+          <Text color={Colors.Gray}>
+            {canCreate
+              ? `No session matches. Press Enter to start session ${query}.`
+              : 'No session matches.'}
+          </Text>
+        )}
+        {matches.map((s) => {
+          const isSelected = s === session;
+          return (
+            <Text
+              key={s.name}
+              color={isSelected ? Colors.AccentBlue : undefined}
+              wrap="truncate-end"
+            >
+              {isSelected ? '› ' : '  '}
+              {s.name}
+              {s.current && <Text color={Colors.AccentGreen}> (current)</Text>}
+              <Text color={Colors.Gray}>
+                {`  ${s.messageCount} message${s.messageCount === 1 ? '' : 's'} · ${s.model}`}
+              </Text>
+            </Text>
+          );
+        })}
+      </Box>
+      <Box marginTop={1}>
+        <Text color={Colors.Gray}>{KEY_HELP}</Text>
+      </Box>
+    </Box>
+  );
+}
@@ -0,0 +1,36 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import React from 'react';
+import { Box, Text } from 'ink';
+import { Colors } from '../colors.js';
+import { StartupSection } from '../utils/startupScreen.js';
+
+interface StartupScreenProps {
+  sections: StartupSection[];
+}
+
+export const StartupScreen: React.FC<StartupScreenProps> = ({ sections }) => {
+  if (sections.length === 0) {
+    return null;
+  }
+  return (
+    <Box flexDirection="column" marginBottom={1}>
+      {sections.map((section) => (
+        <Box key={section.id} flexDirection="column">
+          {section.lines.map((line, i) => (
+            <Text
+              key={i}
+              color={section.id === 'motd' ? Colors.AccentPurple : Colors.Gray}
+            >
+              {line}
+            </Text>
+          ))}
+        </Box>
+      ))}
+    </Box>
+  );
+};
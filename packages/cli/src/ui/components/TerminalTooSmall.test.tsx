@@ -0,0 +1,18 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { render } from 'ink-testing-library';
+import { describe, it, expect } from 'vitest';
+import { TerminalTooSmall } from './TerminalTooSmall.js';
+
+describe('<TerminalTooSmall />', () => {
+  it('shows the current and minimum sizes', () => {
+    const { lastFrame } = render(<TerminalTooSmall columns={30} rows={8} />);
+    const output = lastFrame();
+    expect(output).toContain('Terminal too small');
+    expect(output).toContain('30x8, need 40x10');
+  });
+});
@@ -0,0 +1,30 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { Box, Text } from 'ink';
+import { Colors } from '../colors.js';
+import {
+  MIN_TERMINAL_COLUMNS,
+  MIN_TERMINAL_ROWS,
+} from '../hooks/useTerminalSize.js';
+
+interface TerminalTooSmallProps {
+  columns: number;
+  rows: number;
+}
+
+/**
+ * Shown in place of the main layout while the terminal is below the minimum
+ * size, instead of rendering wrapped output that would come out garbled.
+ */
+export const TerminalTooSmall = ({ columns, rows }: TerminalTooSmallProps) => (
+  <Box flexDirection="column">
+    <Text color={Colors.AccentYellow}>Terminal too small</Text>
+    <Text color={Colors.Gray}>
+      {columns}x{rows}, need {MIN_TERMINAL_COLUMNS}x{MIN_TERMINAL_ROWS}
+    </Text>
+  </Box>
+);
@@ -0,0 +1,123 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import React from 'react';
+import { Box, Text } from 'ink';
+import {
+  DiffFile,
+  DiffReview,
+  ReviewSeverity,
+} from '@iechor/research-cli-core';
+import { Colors } from '../../colors.js';
+import { DiffRenderer } from './DiffRenderer.js';
+
+interface DiffReviewMessageProps {
+  files: DiffFile[];
+  review: DiffReview;
+  availableTerminalHeight?: number;
+  terminalWidth: number;
+}
+
+const severityColor = (severity: ReviewSeverity): string => {
+  switch (severity) {
+    case 'critical':
+      return Colors.AccentRed;
+    case 'major':
+      return Colors.AccentYellow;
+    case 'minor':
+      return Colors.AccentBlue;
+    default:
+      return Colors.Gray;
+  }
+};
+
+/*
+ * Shows a /review-diff result: each file's diff with the review comments
+ * for that file listed under it.
+ */
+export const DiffReviewMessage: React.FC<DiffReviewMessageProps> = ({
+  files,
+  review,
+  availableTerminalHeight,
+  terminalWidth,
+}) => {
+  // border, title, summary and a header line per file
+  const staticHeight = 2 + 2 + files.length;
+  const innerWidth = terminalWidth - 4;
+  const availableTerminalHeightPerFile = availableTerminalHeight
+    ? Math.max(
+        Math.floor(
+          (availableTerminalHeight -
+            staticHeight -
+            review.comments.length) /
+            Math.max(1, files.length),
+        ),
+        1,
+      )
+    : undefined;
+
+  return (
+    <Box
+      flexDirection="column"
+      borderStyle="round"
+      borderColor={Colors.Gray}
+      width="100%"
+      marginLeft={1}
+      paddingX={1}
+    >
+      <Text bold color={Colors.AccentPurple}>
+        Review of {review.source}
+      </Text>
+      {review.summary && <Text wrap="wrap">{review.summary}</Text>}
+      {files.map((file) => {
+        const comments = review.comments.filter((c) => c.file === file.path);
+        return (
+          <Box key={file.path} flexDirection="column" marginTop={1}>
+            <Text>
+              <Text bold color={Colors.AccentCyan}>
+                {file.path}
+              </Text>
+              <Text color={Colors.AccentGreen}> +{file.additions}</Text>
+              <Text color={Colors.AccentRed}> -{file.deletions}</Text>
+              {file.status !== 'modified' && (
+                <Text color={Colors.Gray}> ({file.status})</Text>
+              )}
+            </Text>
+            <DiffRenderer
+              diffContent={file.diff}
+              filename={file.path}
+              availableTerminalHeight={availableTerminalHeightPerFile}
+              terminalWidth={innerWidth}
+            />
+            {comments.map((comment, index) => (
+              <Box key={index} flexDirection="column" paddingLeft={2}>
+                <Text wrap="wrap">
+                  <Text color={severityColor(comment.severity)}>
+                    {comment.line !== undefined
+                      ? `L${comment.line}`
+                      : 'file'}{' '}
+                    [{comment.severity}]
+                  </Text>{' '}
+                  {comment.comment}
+                </Text>
+                {comment.suggestion && (
+                  <Box paddingLeft={2}>
+                    <Text color={Colors.Gray}>{comment.suggestion}</Text>
+                  </Box>
+                )}
+              </Box>
+            ))}
+          </Box>
+        );
+      })}
+      {review.comments.length === 0 && (
+        <Box marginTop={1}>
+          <Text color={Colors.AccentGreen}>No comments.</Text>
+        </Box>
+      )}
+    </Box>
+  );
+};
@@ -7,17 +7,20 @@
 import React from 'react';
 import { Text, Box } from 'ink';
 import { Colors } from '../../colors.js';
+import { useMessageLayout } from '../../contexts/MessageLayoutContext.js';
+import { getRoleLabel } from '../../utils/messageLayout.js';
 
 interface ErrorMessageProps {
   text: string;
 }
 
 export const ErrorMessage: React.FC<ErrorMessageProps> = ({ text }) => {
-  const prefix = '✕ ';
+  const { spacing, roleLabels } = useMessageLayout();
+  const prefix = getRoleLabel('error', roleLabels);
   const prefixWidth = prefix.length;
 
   return (
-    <Box flexDirection="row" marginBottom={1}>
+    <Box flexDirection="row" marginBottom={spacing === undefined ? 1 : 0}>
       <Box width={prefixWidth}>
         <Text color={Colors.AccentRed}>{prefix}</Text>
       </Box>
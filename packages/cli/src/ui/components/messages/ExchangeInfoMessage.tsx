@@ -0,0 +1,26 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import React from 'react';
+import { Box, Text } from 'ink';
+import { Colors } from '../../colors.js';
+import { HistoryItemExchangeInfo } from '../../types.js';
+import { formatExchangeInfo } from '../../utils/exchangeInfo.js';
+
+interface ExchangeInfoMessageProps {
+  item: HistoryItemExchangeInfo;
+}
+
+/** Tokens and context of the exchange above, shown with Ctrl+G. */
+export const ExchangeInfoMessage: React.FC<ExchangeInfoMessageProps> = ({
+  item,
+}) => (
+  <Box paddingLeft={2}>
+    <Text color={Colors.Gray} wrap="wrap">
+      ↳ {formatExchangeInfo(item)}
+    </Text>
+  </Box>
+);
@@ -0,0 +1,57 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import React, { useEffect } from 'react';
+import fs from 'node:fs';
+import { useStdout } from 'ink';
+import { InfoMessage } from './InfoMessage.js';
+import {
+  detectImageProtocol,
+  encodeInlineImage,
+} from '../../utils/terminalImage.js';
+
+const MAX_IMAGE_COLUMNS = 60;
+
+// Images already drawn. When the history is redrawn, e.g. after a resize,
+// they would land below everything else, so only the caption is shown.
+const drawn = new Set<number>();
+
+interface ImageMessageProps {
+  id: number;
+  caption: string;
+  file: string;
+  terminalWidth: number;
+}
+
+/**
+ * Shows the caption and draws the image below it in terminals that can.
+ * The escape sequence goes through Ink's stdout, which writes it above the
+ * live part of the screen instead of in the middle of it.
+ */
+export const ImageMessage: React.FC<ImageMessageProps> = ({
+  id,
+  caption,
+  file,
+  terminalWidth,
+}) => {
+  const { write } = useStdout();
+
+  useEffect(() => {
+    const protocol = detectImageProtocol();
+    if (!protocol || drawn.has(id)) {
+      return;
+    }
+    drawn.add(id);
+    try {
+      const columns = Math.min(MAX_IMAGE_COLUMNS, terminalWidth - 4);
+      write(`${encodeInlineImage(fs.readFileSync(file), protocol, columns)}\n`);
+    } catch {
+      // The caption names the file, which can still be opened by hand
+    }
+  }, [id, file, terminalWidth, write]);
+
+  return <InfoMessage text={`${caption}\n🖼  ${file}`} />;
+};
@@ -7,17 +7,20 @@
 import React from 'react';
 import { Text, Box } from 'ink';
 import { Colors } from '../../colors.js';
+import { useMessageLayout } from '../../contexts/MessageLayoutContext.js';
+import { getRoleLabel } from '../../utils/messageLayout.js';
 
 interface InfoMessageProps {
   text: string;
 }
 
 export const InfoMessage: React.FC<InfoMessageProps> = ({ text }) => {
-  const prefix = 'ℹ ';
+  const { spacing, roleLabels } = useMessageLayout();
+  const prefix = getRoleLabel('info', roleLabels);
   const prefixWidth = prefix.length;
 
   return (
-    <Box flexDirection="row" marginTop={1}>
+    <Box flexDirection="row" marginTop={spacing === undefined ? 1 : 0}>
       <Box width={prefixWidth}>
         <Text color={Colors.AccentYellow}>{prefix}</Text>
       </Box>
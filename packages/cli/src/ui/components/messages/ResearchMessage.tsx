@@ -6,29 +6,65 @@
 
 import React from 'react';
 import { Text, Box } from 'ink';
+import stringWidth from 'string-width';
 import { MarkdownDisplay } from '../../utils/MarkdownDisplay.js';
 import { Colors } from '../../colors.js';
+import { useMessageLayout } from '../../contexts/MessageLayoutContext.js';
+import { getRoleLabel } from '../../utils/messageLayout.js';
+import { resolveModelStyle } from '../../utils/modelStyles.js';
+import { themeManager } from '../../themes/theme-manager.js';
 
 interface ResearchMessageProps {
   text: string;
+  /** Model that wrote the answer, styled by the `modelStyles` setting. */
+  model?: string;
   isPending: boolean;
   availableTerminalHeight?: number;
   terminalWidth: number;
+  /** Number of its first code block, shown to copy blocks by number. */
+  firstCodeBlock?: number;
+}
+
+/**
+ * The label before an answer of `model`, and its width. The continuation
+ * pieces of a long answer are indented by the same width.
+ */
+export function useResearchPrefix(model?: string): {
+  prefix: string;
+  width: number;
+  color: string;
+} {
+  const { roleLabels, modelStyles } = useMessageLayout();
+  const modelStyle = resolveModelStyle(
+    modelStyles,
+    model,
+    themeManager.getActiveTheme().name,
+    Colors,
+  );
+  const prefix = modelStyle?.prefix
+    ? `${modelStyle.prefix} `
+    : getRoleLabel('research', roleLabels);
+  return {
+    prefix,
+    width: stringWidth(prefix),
+    color: modelStyle?.color ?? Colors.AccentPurple,
+  };
 }
 
 export const ResearchMessage: React.FC<ResearchMessageProps> = ({
   text,
+  model,
   isPending,
   availableTerminalHeight,
   terminalWidth,
+  firstCodeBlock,
 }) => {
-  const prefix = '✦ ';
-  const prefixWidth = prefix.length;
+  const { prefix, width, color } = useResearchPrefix(model);
 
   return (
     <Box flexDirection="row">
-      <Box width={prefixWidth}>
-        <Text color={Colors.AccentPurple}>{prefix}</Text>
+      <Box width={width}>
+        <Text color={color}>{prefix}</Text>
       </Box>
       <Box flexGrow={1} flexDirection="column">
         <MarkdownDisplay
@@ -36,6 +72,7 @@ export const ResearchMessage: React.FC<ResearchMessageProps> = ({
           isPending={isPending}
           availableTerminalHeight={availableTerminalHeight}
           terminalWidth={terminalWidth}
+          firstCodeBlock={firstCodeBlock}
         />
       </Box>
     </Box>
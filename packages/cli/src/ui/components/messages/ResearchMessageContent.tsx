@@ -7,12 +7,16 @@
 import React from 'react';
 import { Box } from 'ink';
 import { MarkdownDisplay } from '../../utils/MarkdownDisplay.js';
+import { useResearchPrefix } from './ResearchMessage.js';
 
 interface ResearchMessageContentProps {
   text: string;
+  /** Model of the answer this continues, whose label sets the indent. */
+  model?: string;
   isPending: boolean;
   availableTerminalHeight?: number;
   terminalWidth: number;
+  firstCodeBlock?: number;
 }
 
 /*
@@ -23,12 +27,13 @@ interface ResearchMessageContentProps {
  */
 export const ResearchMessageContent: React.FC<ResearchMessageContentProps> = ({
   text,
+  model,
   isPending,
   availableTerminalHeight,
   terminalWidth,
+  firstCodeBlock,
 }) => {
-  const originalPrefix = '✦ ';
-  const prefixWidth = originalPrefix.length;
+  const prefixWidth = useResearchPrefix(model).width;
 
   return (
     <Box flexDirection="column" paddingLeft={prefixWidth}>
@@ -37,6 +42,7 @@ export const ResearchMessageContent: React.FC<ResearchMessageContentProps> = ({
         isPending={isPending}
         availableTerminalHeight={availableTerminalHeight}
         terminalWidth={terminalWidth}
+        firstCodeBlock={firstCodeBlock}
       />
     </Box>
   );
@@ -0,0 +1,78 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import React from 'react';
+import { Box, Text } from 'ink';
+import { Colors } from '../../colors.js';
+import { ThreadExchange } from '../../types.js';
+import { MarkdownDisplay } from '../../utils/MarkdownDisplay.js';
+
+interface ThreadMessageProps {
+  threadId: number;
+  answer: string;
+  exchanges: ThreadExchange[];
+  included: boolean;
+  collapsed?: boolean;
+  terminalWidth: number;
+}
+
+const EXCERPT_LENGTH = 60;
+
+/*
+ * Shows a /thread exchange indented under a quote of the answer it replies
+ * to, or only that quote and a count when collapsed.
+ */
+export const ThreadMessage: React.FC<ThreadMessageProps> = ({
+  threadId,
+  answer,
+  exchanges,
+  included,
+  collapsed = false,
+  terminalWidth,
+}) => {
+  const firstLine = answer.split('\n')[0];
+  const excerpt =
+    firstLine.length > EXCERPT_LENGTH || firstLine !== answer
+      ? `${firstLine.slice(0, EXCERPT_LENGTH)}…`
+      : firstLine;
+
+  return (
+    <Box flexDirection="column" marginLeft={2}>
+      <Text color={Colors.Gray} wrap="truncate-end">
+        ↳ Thread #{threadId} on “{excerpt}”{' '}
+        <Text color={included ? Colors.AccentGreen : Colors.Gray}>
+          ({included ? 'in context' : 'not in context'})
+        </Text>
+        {collapsed &&
+          ` · ${exchanges.length} repl${exchanges.length === 1 ? 'y' : 'ies'}`}
+      </Text>
+      {!collapsed && (
+        <Box
+          flexDirection="column"
+          borderStyle="single"
+          borderColor={Colors.Gray}
+          borderTop={false}
+          borderRight={false}
+          borderBottom={false}
+          paddingLeft={1}
+        >
+          {exchanges.map((exchange, i) => (
+            <Box key={i} flexDirection="column">
+              <Text color={Colors.Gray} wrap="wrap">
+                &gt; {exchange.question}
+              </Text>
+              <MarkdownDisplay
+                text={exchange.answer}
+                isPending={false}
+                terminalWidth={terminalWidth - 6}
+              />
+            </Box>
+          ))}
+        </Box>
+      )}
+    </Box>
+  );
+};
@@ -10,6 +10,7 @@ import { IndividualToolCallDisplay, ToolCallStatus } from '../../types.js';
 import { ToolMessage } from './ToolMessage.js';
 import { ToolConfirmationMessage } from './ToolConfirmationMessage.js';
 import { Colors } from '../../colors.js';
+import { useMessageLayout } from '../../contexts/MessageLayoutContext.js';
 import { Config } from '@iechor/research-cli-core';
 
 interface ToolGroupMessageProps {
@@ -33,8 +34,9 @@ export const ToolGroupMessage: React.FC<ToolGroupMessageProps> = ({
     (t) => t.status === ToolCallStatus.Success,
   );
   const borderColor = hasPending ? Colors.AccentYellow : Colors.Gray;
+  const { compact } = useMessageLayout();
 
-  const staticHeight = /* border */ 2 + /* marginBottom */ 1;
+  const staticHeight = (compact ? 0 : /* border */ 2) + /* marginBottom */ 1;
   // This is a bit of a magic number, but it accounts for the border and
   // marginLeft.
   const innerWidth = terminalWidth - 4;
@@ -66,7 +68,7 @@ export const ToolGroupMessage: React.FC<ToolGroupMessageProps> = ({
   return (
     <Box
       flexDirection="column"
-      borderStyle="round"
+      borderStyle={compact ? undefined : 'round'}
       /*
         This width constraint is highly important and protects us from an Ink rendering bug.
         Since the ToolGroup can typically change rendering states frequently, it can cause
@@ -74,7 +76,7 @@ export const ToolGroupMessage: React.FC<ToolGroupMessageProps> = ({
         cause tearing.
       */
       width="100%"
-      marginLeft={1}
+      marginLeft={compact ? 0 : 1}
       borderDimColor={hasPending}
       borderColor={borderColor}
     >
@@ -7,23 +7,26 @@
 import React from 'react';
 import { Text, Box } from 'ink';
 import { Colors } from '../../colors.js';
+import { useMessageLayout } from '../../contexts/MessageLayoutContext.js';
+import { getRoleLabel } from '../../utils/messageLayout.js';
 
 interface UserMessageProps {
   text: string;
 }
 
 export const UserMessage: React.FC<UserMessageProps> = ({ text }) => {
-  const prefix = '> ';
+  const { spacing, roleLabels, compact } = useMessageLayout();
+  const prefix = getRoleLabel('user', roleLabels);
   const prefixWidth = prefix.length;
 
   return (
     <Box
-      borderStyle="round"
+      borderStyle={compact ? undefined : 'round'}
       borderColor={Colors.Gray}
       flexDirection="row"
-      paddingX={2}
+      paddingX={compact ? 0 : 2}
       paddingY={0}
-      marginY={1}
+      marginY={spacing === undefined ? 1 : 0}
       alignSelf="flex-start"
     >
       <Box width={prefixWidth}>
@@ -7,6 +7,8 @@
 import React from 'react';
 import { Box, Text } from 'ink';
 import { Colors } from '../../colors.js';
+import { useMessageLayout } from '../../contexts/MessageLayoutContext.js';
+import { getRoleLabel } from '../../utils/messageLayout.js';
 
 interface UserShellMessageProps {
   text: string;
@@ -15,10 +17,11 @@ interface UserShellMessageProps {
 export const UserShellMessage: React.FC<UserShellMessageProps> = ({ text }) => {
   // Remove leading '!' if present, as App.tsx adds it for the processor.
   const commandToDisplay = text.startsWith('!') ? text.substring(1) : text;
+  const prefix = getRoleLabel('shell', useMessageLayout().roleLabels);
 
   return (
     <Box>
-      <Text color={Colors.AccentCyan}>$ </Text>
+      <Text color={Colors.AccentCyan}>{prefix}</Text>
       <Text>{commandToDisplay}</Text>
     </Box>
   );
@@ -763,6 +763,43 @@ describe('useTextBuffer', () => {
       expect(state.cursor).toEqual([0, 1]);
       expect(state.visualCursor).toEqual([0, 1]);
     });
+
+    it('layout: should wrap CJK text by its display width', () => {
+      const { result } = renderHook(() =>
+        useTextBuffer({
+          initialText: '研究问题很重要',
+          viewport: { width: 6, height: 3 },
+          isValidPath: () => false,
+        }),
+      );
+      expect(result.current.allVisualLines).toEqual([
+        '研究问',
+        '题很重',
+        '要',
+      ]);
+    });
+
+    it('move: up/down should keep the display column across wide characters', () => {
+      const { result } = renderHook(() =>
+        useTextBuffer({
+          initialText: 'abcdef\n你好世界',
+          viewport,
+          isValidPath: () => false,
+        }),
+      );
+      act(() => result.current.move('home'));
+      for (let i = 0; i < 4; i++) {
+        act(() => result.current.move('right'));
+      }
+      act(() => result.current.move('down')); // column 4 is after '你好'
+      let state = getBufferState(result);
+      expect(state.cursor).toEqual([1, 2]);
+      expect(state.preferredCol).toBe(4);
+
+      act(() => result.current.move('up'));
+      state = getBufferState(result);
+      expect(state.cursor).toEqual([0, 4]);
+    });
   });
 
   describe('handleInput', () => {
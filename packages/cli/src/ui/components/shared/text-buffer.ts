@@ -12,7 +12,12 @@ import pathMod from 'path';
 import { useState, useCallback, useEffect, useMemo, useReducer } from 'react';
 import stringWidth from 'string-width';
 import { unescapePath } from '@iechor/research-cli-core';
-import { toCodePoints, cpLen, cpSlice } from '../../utils/textUtils.js';
+import {
+  toCodePoints,
+  cpLen,
+  cpSlice,
+  cpIndexAtWidth,
+} from '../../utils/textUtils.js';
 
 export type Direction =
   | 'left'
@@ -581,27 +586,24 @@ export function textBufferReducer(
           }
           break;
         case 'up':
-          if (newVisualRow > 0) {
-            if (newPreferredCol === null) newPreferredCol = newVisualCol;
-            newVisualRow--;
-            newVisualCol = clamp(
-              newPreferredCol,
-              0,
-              cpLen(visualLines[newVisualRow] ?? ''),
-            );
-          }
-          break;
-        case 'down':
-          if (newVisualRow < visualLines.length - 1) {
-            if (newPreferredCol === null) newPreferredCol = newVisualCol;
-            newVisualRow++;
-            newVisualCol = clamp(
+        case 'down': {
+          const targetRow = dir === 'up' ? newVisualRow - 1 : newVisualRow + 1;
+          if (targetRow >= 0 && targetRow < visualLines.length) {
+            // The preferred column is kept in terminal columns, so that
+            // moving across lines with wide characters stays in place
+            if (newPreferredCol === null) {
+              newPreferredCol = stringWidth(
+                cpSlice(visualLines[newVisualRow] ?? '', 0, newVisualCol),
+              );
+            }
+            newVisualRow = targetRow;
+            newVisualCol = cpIndexAtWidth(
+              visualLines[newVisualRow] ?? '',
               newPreferredCol,
-              0,
-              cpLen(visualLines[newVisualRow] ?? ''),
             );
           }
           break;
+        }
         case 'home':
           newPreferredCol = null;
           newVisualCol = 0;
@@ -0,0 +1,19 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import React, { createContext } from 'react';
+import {
+  DEFAULT_MESSAGE_LAYOUT,
+  MessageLayout,
+} from '../utils/messageLayout.js';
+
+// Without a provider, e.g. in screenshots, messages keep the default layout
+export const MessageLayoutContext = createContext<MessageLayout>(
+  DEFAULT_MESSAGE_LAYOUT,
+);
+
+export const useMessageLayout = (): MessageLayout =>
+  React.useContext(MessageLayoutContext);
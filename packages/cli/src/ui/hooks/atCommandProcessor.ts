@@ -34,7 +34,7 @@ interface HandleAtCommandResult {
   shouldProceed: boolean;
 }
 
-interface AtCommandPart {
+export interface AtCommandPart {
   type: 'text' | 'atPath';
   content: string;
 }
@@ -43,7 +43,7 @@ interface AtCommandPart {
  * Parses a query string to find all '@<path>' commands and text segments.
  * Handles \ escaped spaces within paths.
  */
-function parseAllAtCommands(query: string): AtCommandPart[] {
+export function parseAllAtCommands(query: string): AtCommandPart[] {
   const parts: AtCommandPart[] = [];
   let currentIndex = 0;
 
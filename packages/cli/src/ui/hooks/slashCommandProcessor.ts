@@ -18,6 +18,7 @@ import {
   MCPServerStatus,
   getMCPDiscoveryState,
   getMCPServerStatus,
+  isSetupTurn,
 } from '@iechor/research-cli-core';
 import { useSessionStats } from '../contexts/SessionContext.js';
 import {
@@ -77,6 +78,9 @@ export const useSlashCommandProcessor = (
   showToolDescriptions: boolean = false,
   setQuittingMessages: (message: HistoryItem[]) => void,
   openPrivacyNotice: () => void,
+  loadOlderHistory?: UseHistoryManagerReturn['loadOlderHistory'],
+  openOutlinePane?: () => void,
+  openSessionPicker?: () => void,
 ) => {
   const session = useSessionStats();
   const [commands, setCommands] = useState<SlashCommand[]>([]);
@@ -162,26 +166,39 @@ export const useSlashCommandProcessor = (
         logger,
       },
       ui: {
+        history,
         addItem,
         clear: () => {
           clearItems();
           console.clear();
           refreshStatic();
         },
+        loadOlderHistory: (count: number) => {
+          const loaded = loadOlderHistory?.(count) ?? 0;
+          if (loaded > 0) {
+            // Prepended items are only printed when <Static> is rebuilt.
+            refreshStatic();
+          }
+          return loaded;
+        },
         setDebugMessage: onDebugMessage,
       },
       session: {
         stats: session.stats,
       },
+      // Each command gets the signal of its own turn
+      abortSignal: new AbortController().signal,
     }),
     [
       config,
       settings,
       gitService,
       logger,
+      history,
       addItem,
       clearItems,
       refreshStatic,
+      loadOlderHistory,
       session.stats,
       onDebugMessage,
     ],
@@ -720,6 +737,15 @@ export const useSlashCommandProcessor = (
           }
           switch (subCommand) {
             case 'save': {
+              if (config?.isIncognito()) {
+                addMessage({
+                  type: MessageType.ERROR,
+                  content:
+                    'Saving conversations is disabled in incognito mode.',
+                  timestamp: new Date(),
+                });
+                return;
+              }
               if (!tag) {
                 addMessage({
                   type: MessageType.ERROR,
@@ -772,7 +798,6 @@ export const useSlashCommandProcessor = (
                 user: MessageType.USER,
                 model: MessageType.RESEARCH,
               };
-              let hasSystemPrompt = false;
               let i = 0;
               for (const item of conversation) {
                 i += 1;
@@ -790,10 +815,7 @@ export const useSlashCommandProcessor = (
                   // Parsing Part[] back to various non-text output not yet implemented.
                   continue;
                 }
-                if (i === 1 && text.match(/context for our chat/)) {
-                  hasSystemPrompt = true;
-                }
-                if (i > 2 || !hasSystemPrompt) {
+                if (!isSetupTurn(conversation, i - 1)) {
                   addItem(
                     {
                       type:
@@ -1058,6 +1080,7 @@ export const useSlashCommandProcessor = (
   const handleSlashCommand = useCallback(
     async (
       rawQuery: PartListUnion,
+      abortSignal?: AbortSignal,
     ): Promise<SlashCommandProcessorResult | false> => {
       process.stderr?.write(
         `[FORCE_DEBUG] handleSlashCommand called with: ${JSON.stringify(rawQuery)}\n`,
@@ -1122,7 +1145,13 @@ export const useSlashCommandProcessor = (
         const args = parts.slice(pathIndex).join(' ');
 
         if (commandToExecute.action) {
-          const result = await commandToExecute.action(commandContext, args);
+          const result = await commandToExecute.action(
+            {
+              ...commandContext,
+              abortSignal: abortSignal ?? new AbortController().signal,
+            },
+            args,
+          );
 
           if (result) {
             switch (result.type) {
@@ -1152,6 +1181,12 @@ export const useSlashCommandProcessor = (
                   case 'theme':
                     openThemeDialog();
                     return { type: 'handled' };
+                  case 'outline':
+                    openOutlinePane?.();
+                    return { type: 'handled' };
+                  case 'sessions':
+                    openSessionPicker?.();
+                    return { type: 'handled' };
                   default: {
                     const unhandled: never = result.dialog;
                     throw new Error(
@@ -1234,6 +1269,8 @@ export const useSlashCommandProcessor = (
       commandContext,
       addMessage,
       openThemeDialog,
+      openOutlinePane,
+      openSessionPicker,
     ],
   );
 
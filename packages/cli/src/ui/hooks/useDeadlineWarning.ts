@@ -0,0 +1,54 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { useEffect, useState } from 'react';
+import {
+  formatCountdown,
+  loadDeadlines,
+  upcomingDeadlines,
+} from '@iechor/research-cli-core';
+
+export const DEFAULT_DEADLINE_WARNING_DAYS = 7;
+const REFRESH_INTERVAL_MS = 60 * 1000;
+
+/**
+ * Returns "<title> in <countdown>" for the next deadline due within
+ * `warningDays`, re-read every minute so edits from /deadlines show up.
+ * A value of 0 or less turns the warning off.
+ */
+export function useDeadlineWarning(warningDays: number): string | undefined {
+  const [warning, setWarning] = useState<string | undefined>(undefined);
+
+  useEffect(() => {
+    if (warningDays <= 0) {
+      setWarning(undefined);
+      return;
+    }
+    let cancelled = false;
+    const refresh = async () => {
+      let next;
+      try {
+        [next] = upcomingDeadlines(await loadDeadlines(), warningDays);
+      } catch {
+        // An unreadable deadlines file just means no warning
+      }
+      if (!cancelled) {
+        setWarning(
+          next ? `${next.title} in ${formatCountdown(next.due)}` : undefined,
+        );
+      }
+    };
+
+    refresh();
+    const interval = setInterval(refresh, REFRESH_INTERVAL_MS);
+    return () => {
+      cancelled = true;
+      clearInterval(interval);
+    };
+  }, [warningDays]);
+
+  return warning;
+}
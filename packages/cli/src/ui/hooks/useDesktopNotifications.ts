@@ -0,0 +1,104 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { useEffect, useRef } from 'react';
+import { useStdin, useStdout } from 'ink';
+import { HistoryItem, StreamingState } from '../types.js';
+import {
+  DISABLE_FOCUS_REPORTING,
+  ENABLE_FOCUS_REPORTING,
+  FOCUS_IN,
+  FOCUS_OUT,
+  getNotificationBody,
+  sendDesktopNotification,
+} from '../utils/desktopNotification.js';
+
+/** The answer to the last message: the first model text after it. */
+export function getLastAnswer(history: HistoryItem[]): string | undefined {
+  let answer: string | undefined;
+  for (let i = history.length - 1; i >= 0; i--) {
+    const item = history[i];
+    if (item.type === 'user') {
+      break;
+    }
+    if (item.type === 'research' && item.text) {
+      answer = item.text;
+    }
+  }
+  return answer;
+}
+
+/**
+ * Shows a desktop notification when a response finishes after the
+ * terminal has been out of focus for `afterSeconds`. Focus changes come
+ * from the terminal's focus reporting, which is only turned on while
+ * notifications are; terminals without it never notify.
+ */
+export function useDesktopNotifications(
+  afterSeconds: number | undefined,
+  title: string,
+  streamingState: StreamingState,
+  history: HistoryItem[],
+): void {
+  const { stdin } = useStdin();
+  const { stdout } = useStdout();
+  const unfocusedSince = useRef<number | undefined>(undefined);
+  const previousState = useRef(streamingState);
+  const historyRef = useRef(history);
+
+  useEffect(() => {
+    historyRef.current = history;
+  }, [history]);
+
+  useEffect(() => {
+    if (afterSeconds === undefined || !stdin.isTTY) {
+      return;
+    }
+    const onData = (data: Buffer) => {
+      const text = data.toString();
+      const lastIn = text.lastIndexOf(FOCUS_IN);
+      const lastOut = text.lastIndexOf(FOCUS_OUT);
+      if (lastOut > lastIn) {
+        if (unfocusedSince.current === undefined) {
+          unfocusedSince.current = Date.now();
+        }
+      } else if (lastIn > lastOut) {
+        unfocusedSince.current = undefined;
+      }
+    };
+    const disable = () => {
+      stdout.write(DISABLE_FOCUS_REPORTING);
+    };
+    stdin.on('data', onData);
+    stdout.write(ENABLE_FOCUS_REPORTING);
+    process.on('exit', disable);
+    return () => {
+      stdin.removeListener('data', onData);
+      disable();
+      process.removeListener('exit', disable);
+      unfocusedSince.current = undefined;
+    };
+  }, [afterSeconds, stdin, stdout]);
+
+  useEffect(() => {
+    const wasBusy = previousState.current !== StreamingState.Idle;
+    previousState.current = streamingState;
+    if (
+      afterSeconds === undefined ||
+      !wasBusy ||
+      streamingState !== StreamingState.Idle ||
+      unfocusedSince.current === undefined ||
+      Date.now() - unfocusedSince.current < afterSeconds * 1000
+    ) {
+      return;
+    }
+    const answer = getLastAnswer(historyRef.current);
+    sendDesktopNotification(
+      title,
+      answer ? getNotificationBody(answer) : 'The response is done.',
+    );
+  }, [afterSeconds, title, streamingState]);
+}
@@ -0,0 +1,63 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { useEffect, useState } from 'react';
+import { MessageType } from '../types.js';
+import { UseHistoryManagerReturn } from './useHistoryManager.js';
+import {
+  countNotesWords,
+  formatFocusInterval,
+  formatFocusStatus,
+  getFocusTimer,
+} from '../utils/focusTimer.js';
+
+const TICK_INTERVAL_MS = 1000;
+
+/**
+ * Drives the focus timer started with /focus: returns its status bar
+ * text and reminds the user when a break starts and ends.
+ */
+export function useFocusTimer(
+  projectRoot: string,
+  addItem: UseHistoryManagerReturn['addItem'],
+): string | undefined {
+  const [status, setStatus] = useState<string | undefined>(undefined);
+
+  useEffect(() => {
+    const timer = getFocusTimer();
+    const tick = () => {
+      const now = Date.now();
+      const event = timer.tick(now);
+      setStatus(formatFocusStatus(timer, now));
+      if (event?.type === 'break') {
+        countNotesWords(projectRoot).then((words) => {
+          timer.countWords(event.interval, words);
+          addItem(
+            {
+              type: MessageType.INFO,
+              text: `🍅 Focus interval done: ${formatFocusInterval(event.interval)}. Time for a ${event.breakMinutes}-minute break.`,
+            },
+            Date.now(),
+          );
+        });
+      } else if (event?.type === 'breakOver') {
+        addItem(
+          {
+            type: MessageType.INFO,
+            text: '☕ Break is over. Start the next interval with /focus start.',
+          },
+          Date.now(),
+        );
+      }
+    };
+
+    tick();
+    const interval = setInterval(tick, TICK_INTERVAL_MS);
+    return () => clearInterval(interval);
+  }, [projectRoot, addItem]);
+
+  return status;
+}
@@ -4,10 +4,14 @@
  * SPDX-License-Identifier: Apache-2.0
  */
 
-import { describe, it, expect } from 'vitest';
+import { describe, it, expect, beforeEach, afterEach } from 'vitest';
 import { renderHook, act } from '@testing-library/react';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
 import { useHistory } from './useHistoryManager.js';
 import { HistoryItem } from '../types.js';
+import { HistorySpillStore } from '../utils/historySpillStore.js';
 
 describe('useHistoryManager', () => {
   it('should initialize with an empty history', () => {
@@ -199,4 +203,85 @@ describe('useHistoryManager', () => {
     expect(result.current.history[1].text).toBe('Research response');
     expect(result.current.history[2].text).toBe('Message 1');
   });
+
+  describe('with a memory cap', () => {
+    let tmpDir: string;
+    let spillStore: HistorySpillStore;
+
+    beforeEach(() => {
+      tmpDir = fs.mkdtempSync(path.join(os.tmpdir(), 'history-test-'));
+      spillStore = new HistorySpillStore(path.join(tmpDir, 'session.jsonl'));
+    });
+
+    afterEach(() => {
+      fs.rmSync(tmpDir, { recursive: true, force: true });
+    });
+
+    const addMessages = (
+      addItem: (item: Omit<HistoryItem, 'id'>, ts: number) => number,
+      from: number,
+      to: number,
+    ) => {
+      for (let i = from; i <= to; i++) {
+        act(() => {
+          addItem({ type: 'info', text: `m${i}` }, i * 1000);
+        });
+      }
+    };
+
+    it('should spill the oldest items to disk and page them back in', () => {
+      const { result } = renderHook(() =>
+        useHistory({ maxItems: 3, spillStore }),
+      );
+
+      addMessages(result.current.addItem, 1, 5);
+      expect(result.current.history.map((h) => h.text)).toEqual([
+        'm3',
+        'm4',
+        'm5',
+      ]);
+      expect(result.current.olderItemCount).toBe(2);
+      expect(spillStore.count()).toBe(2);
+
+      let loaded = 0;
+      act(() => {
+        loaded = result.current.loadOlderHistory(1);
+      });
+      expect(loaded).toBe(1);
+      expect(result.current.history.map((h) => h.text)).toEqual([
+        'm2',
+        'm3',
+        'm4',
+        'm5',
+      ]);
+      expect(result.current.olderItemCount).toBe(1);
+
+      // Adding a message releases the paged-in items without rewriting them.
+      addMessages(result.current.addItem, 6, 6);
+      expect(result.current.history.map((h) => h.text)).toEqual([
+        'm4',
+        'm5',
+        'm6',
+      ]);
+      expect(spillStore.read(0, spillStore.count()).map((h) => h.text)).toEqual(
+        ['m1', 'm2', 'm3'],
+      );
+    });
+
+    it('should remove the spill file when history is cleared', () => {
+      const { result } = renderHook(() =>
+        useHistory({ maxItems: 1, spillStore }),
+      );
+      addMessages(result.current.addItem, 1, 3);
+      expect(fs.existsSync(spillStore.filePath)).toBe(true);
+
+      act(() => {
+        result.current.clearItems();
+      });
+
+      expect(result.current.history).toEqual([]);
+      expect(result.current.olderItemCount).toBe(0);
+      expect(fs.existsSync(spillStore.filePath)).toBe(false);
+    });
+  });
 });
@@ -4,8 +4,10 @@
  * SPDX-License-Identifier: Apache-2.0
  */
 
-import { useState, useRef, useCallback } from 'react';
+import { useState, useRef, useCallback, useEffect } from 'react';
 import { HistoryItem } from '../types.js';
+import { HistorySpillStore } from '../utils/historySpillStore.js';
+import { numberCodeBlocks } from '../utils/codeBlocks.js';
 
 // Type for the updater function passed to updateHistoryItem
 type HistoryItemUpdater = (
@@ -21,17 +23,65 @@ export interface UseHistoryManagerReturn {
   ) => void;
   clearItems: () => void;
   loadHistory: (newHistory: HistoryItem[]) => void;
+  /** Pages up to `count` evicted items back in; returns how many were loaded. */
+  loadOlderHistory: (count: number) => number;
+  /** Number of earlier items that are on disk rather than in memory. */
+  olderItemCount: number;
+}
+
+export interface UseHistoryOptions {
+  /** Keep at most this many items in memory. Unlimited when unset. */
+  maxItems?: number;
+  /** Where items beyond `maxItems` are written. Required for the cap. */
+  spillStore?: HistorySpillStore;
 }
 
 /**
  * Custom hook to manage the chat history state.
  *
  * Encapsulates the history array, message ID generation, adding items,
- * updating items, and clearing the history.
+ * updating items, and clearing the history. With `maxItems` set, only the
+ * most recent items stay in memory and older ones are spilled to disk.
  */
-export function useHistory(): UseHistoryManagerReturn {
+export function useHistory(
+  options: UseHistoryOptions = {},
+): UseHistoryManagerReturn {
+  const { maxItems, spillStore } = options;
   const [history, setHistory] = useState<HistoryItem[]>([]);
   const messageIdCounterRef = useRef(0);
+  // Position of history[0] within the whole session.
+  const windowStartRef = useRef(0);
+  // Items paged back in; they stay until the next item is added.
+  const pagedInRef = useRef(0);
+  const [olderItemCount, setOlderItemCount] = useState(0);
+
+  const resetWindow = useCallback(() => {
+    windowStartRef.current = 0;
+    pagedInRef.current = 0;
+    setOlderItemCount(0);
+    spillStore?.clear();
+  }, [spillStore]);
+
+  // Evict the oldest items once the cap is exceeded. This runs after the
+  // commit that added them so <Static> has already printed the new items.
+  useEffect(() => {
+    if (!maxItems || !spillStore) {
+      return;
+    }
+    const excess = history.length - (maxItems + pagedInRef.current);
+    if (excess <= 0) {
+      return;
+    }
+    // Items paged back in are already on disk; only write the new ones.
+    const alreadyOnDisk = Math.max(
+      0,
+      spillStore.count() - windowStartRef.current,
+    );
+    spillStore.append(history.slice(alreadyOnDisk, excess));
+    windowStartRef.current += excess;
+    setOlderItemCount(windowStartRef.current);
+    setHistory((prevHistory) => prevHistory.slice(excess));
+  }, [history, maxItems, spillStore]);
 
   // Generates a unique message ID based on a timestamp and a counter.
   const getNextMessageId = useCallback((baseTimestamp: number): number => {
@@ -39,15 +89,44 @@ export function useHistory(): UseHistoryManagerReturn {
     return baseTimestamp + messageIdCounterRef.current;
   }, []);
 
-  const loadHistory = useCallback((newHistory: HistoryItem[]) => {
-    setHistory(newHistory);
-  }, []);
+  const loadHistory = useCallback(
+    (newHistory: HistoryItem[]) => {
+      resetWindow();
+      const numbered: HistoryItem[] = [];
+      for (const item of newHistory) {
+        numbered.push(numberCodeBlocks(item, numbered));
+      }
+      setHistory(numbered);
+    },
+    [resetWindow],
+  );
+
+  const loadOlderHistory = useCallback(
+    (count: number): number => {
+      if (!spillStore || count <= 0) {
+        return 0;
+      }
+      const end = windowStartRef.current;
+      const start = Math.max(0, end - count);
+      const items = spillStore.read(start, end);
+      if (items.length === 0) {
+        return 0;
+      }
+      windowStartRef.current = start;
+      pagedInRef.current += items.length;
+      setOlderItemCount(start);
+      setHistory((prevHistory) => [...items, ...prevHistory]);
+      return items.length;
+    },
+    [spillStore],
+  );
 
   // Adds a new item to the history state with a unique ID.
   const addItem = useCallback(
     (itemData: Omit<HistoryItem, 'id'>, baseTimestamp: number): number => {
       const id = getNextMessageId(baseTimestamp);
       const newItem: HistoryItem = { ...itemData, id } as HistoryItem;
+      pagedInRef.current = 0;
 
       setHistory((prevHistory) => {
         if (prevHistory.length > 0) {
@@ -61,7 +140,8 @@ export function useHistory(): UseHistoryManagerReturn {
             return prevHistory; // Don't add the duplicate
           }
         }
-        return [...prevHistory, newItem];
+        // Code blocks are numbered through the session, for copying
+        return [...prevHistory, numberCodeBlocks(newItem, prevHistory)];
       });
       return id; // Return the generated ID (even if not added, to keep signature)
     },
@@ -97,9 +177,10 @@ export function useHistory(): UseHistoryManagerReturn {
 
   // Clears the entire history state and resets the ID counter.
   const clearItems = useCallback(() => {
+    resetWindow();
     setHistory([]);
     messageIdCounterRef.current = 0;
-  }, []);
+  }, [resetWindow]);
 
   return {
     history,
@@ -107,5 +188,7 @@ export function useHistory(): UseHistoryManagerReturn {
     updateItem,
     clearItems,
     loadHistory,
+    loadOlderHistory,
+    olderItemCount,
   };
 }
@@ -0,0 +1,70 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { RefObject, useEffect, useRef } from 'react';
+import { DOMElement } from 'ink';
+import { getImeCursor, ImeCursorTarget } from '../utils/imeCursor.js';
+import { getRecordedStream } from '../utils/sessionRecorder.js';
+
+/**
+ * Finds a position inside `node` in the drawn frame. The frame is as tall
+ * as the root's layout, and the terminal cursor is left on the line below.
+ */
+export function locateInFrame(
+  node: DOMElement,
+  row: number,
+  column: number,
+): ImeCursorTarget | undefined {
+  let top = 0;
+  let left = 0;
+  let root: DOMElement = node;
+  for (
+    let current: DOMElement | undefined = node;
+    current;
+    current = current.parentNode
+  ) {
+    if (!current.yogaNode) {
+      return undefined;
+    }
+    top += current.yogaNode.getComputedTop();
+    left += current.yogaNode.getComputedLeft();
+    root = current;
+  }
+  const height = root.yogaNode?.getComputedHeight() ?? 0;
+  return { rowsUp: height - (top + row), column: left + column };
+}
+
+/**
+ * Keeps the terminal cursor at `row` and `column` (in terminal columns)
+ * of the element `ref` points to while `enabled`, for input methods.
+ */
+export function useImeCursor(
+  enabled: boolean,
+  ref: RefObject<DOMElement | null>,
+  row: number,
+  column: number,
+): void {
+  const position = useRef({ row, column });
+  position.current = { row, column };
+
+  useEffect(() => {
+    if (!enabled) {
+      return;
+    }
+    const cursor = getImeCursor();
+    cursor.attach(getRecordedStream());
+    cursor.setLocator(() =>
+      ref.current
+        ? locateInFrame(
+            ref.current,
+            position.current.row,
+            position.current.column,
+          )
+        : undefined,
+    );
+    return () => cursor.setLocator(undefined);
+  }, [enabled, ref]);
+}
@@ -8,6 +8,7 @@ import { useEffect, useRef } from 'react';
 import { useStdin } from 'ink';
 import readline from 'readline';
 import { PassThrough } from 'stream';
+import { isFocusReport } from '../utils/desktopNotification.js';
 
 export interface Key {
   name: string;
@@ -83,6 +84,10 @@ export function useKeypress(
         if (isPaste) {
           pasteBuffer = Buffer.concat([pasteBuffer, Buffer.from(key.sequence)]);
         } else {
+          // Focus changes reported for desktop notifications are not keys
+          if (isFocusReport(key.sequence)) {
+            return;
+          }
           // Handle special keys
           if (key.name === 'return' && key.sequence === '\x1B\r') {
             key.meta = true;
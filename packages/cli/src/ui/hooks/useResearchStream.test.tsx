@@ -40,6 +40,7 @@ const MockedResearchClientClass = vi.hoisted(() =>
     this.startChat = mockStartChat;
     this.sendMessageStream = mockSendMessageStream;
     this.addHistory = vi.fn();
+    this.getGenerationOptions = vi.fn(() => ({}));
   }),
 );
 
@@ -304,6 +305,8 @@ describe('useResearchStream', () => {
         () => ({ getToolSchemaList: vi.fn(() => []) }) as any,
       ),
       getProjectRoot: vi.fn(() => '/test/dir'),
+      getModel: vi.fn(() => 'research-pro'),
+      getActiveFallbackModel: vi.fn(() => undefined),
       getCheckpointingEnabled: vi.fn(() => false),
       getResearchClient: mockGetResearchClient,
       getUsageStatisticsEnabled: () => true,
@@ -314,6 +317,7 @@ describe('useResearchStream', () => {
       },
       setQuotaErrorOccurred: vi.fn(),
       getQuotaErrorOccurred: vi.fn(() => false),
+      isInQuotaFallback: vi.fn(() => false),
       getContentGeneratorConfig: vi
         .fn()
         .mockReturnValue(contentGeneratorConfig),
@@ -970,6 +974,7 @@ describe('useResearchStream', () => {
         (call) => call[0].type === 'research',
       );
       expect(lastCall?.[0].text).toBe('Initial');
+      expect(lastCall?.[0].model).toBe('research-pro');
 
       // The final state should be idle after cancellation
       expect(result.current.streamingState).toBe(StreamingState.Idle);
@@ -1048,7 +1053,10 @@ describe('useResearchStream', () => {
       });
 
       await waitFor(() => {
-        expect(mockHandleSlashCommand).toHaveBeenCalledWith('/help');
+        expect(mockHandleSlashCommand).toHaveBeenCalledWith(
+          '/help',
+          expect.any(AbortSignal),
+        );
         expect(mockScheduleToolCalls).not.toHaveBeenCalled();
         expect(mockSendMessageStream).not.toHaveBeenCalled(); // No LLM call made
       });
@@ -1122,6 +1130,35 @@ describe('useResearchStream', () => {
     });
   });
 
+  describe('Answering model', () => {
+    it('should label the answer with the fallback model that wrote it', async () => {
+      vi.mocked(mockConfig.getActiveFallbackModel).mockReturnValue(
+        'gemini-1.5-flash',
+      );
+      mockSendMessageStream.mockReturnValue(
+        (async function* () {
+          yield { type: 'content', value: 'Answer' };
+        })(),
+      );
+      const { result } = renderTestHook();
+
+      await act(async () => {
+        await result.current.submitQuery('question');
+      });
+
+      await waitFor(() => {
+        expect(mockAddItem).toHaveBeenCalledWith(
+          expect.objectContaining({
+            type: 'research',
+            text: 'Answer',
+            model: 'gemini-1.5-flash',
+          }),
+          expect.any(Number),
+        );
+      });
+    });
+  });
+
   describe('Error Handling', () => {
     it('should call parseAndFormatApiError with the correct authType on stream initialization failure', async () => {
       // 1. Setup
@@ -25,6 +25,7 @@ import {
   UnauthorizedError,
   UserPromptEvent,
   DEFAULT_RESEARCH_FLASH_MODEL,
+  uiTelemetryService,
 } from '@iechor/research-cli-core';
 import { type Part, type PartListUnion } from '@google/genai';
 import {
@@ -41,6 +42,7 @@ import { parseAndFormatApiError } from '../utils/errorParsing.js';
 import { useShellCommandProcessor } from './shellCommandProcessor.js';
 import { handleAtCommand } from './atCommandProcessor.js';
 import { findLastSafeSplitPoint } from '../utils/markdownUtilities.js';
+import { StreamCoalescer } from '../utils/streamCoalescer.js';
 import { useStateAndRef } from './useStateAndRef.js';
 import { UseHistoryManagerReturn } from './useHistoryManager.js';
 import { useLogger } from './useLogger.js';
@@ -54,6 +56,14 @@ import {
   TrackedCancelledToolCall,
 } from './useReactToolScheduler.js';
 import { useSessionStats } from '../contexts/SessionContext.js';
+import {
+  TokenUsage,
+  getAttachments,
+  getTokenUsage,
+  subtractTokenUsage,
+} from '../utils/exchangeInfo.js';
+import { getPromptLength, routeRequest } from '../utils/modelRouter.js';
+import { ModelRoutingSettings } from '../../config/settings.js';
 
 export function mergePartListUnions(list: PartListUnion[]): PartListUnion {
   const resultParts: PartListUnion = [];
@@ -86,6 +96,7 @@ export const useResearchStream = (
   onDebugMessage: (message: string) => void,
   handleSlashCommand: (
     cmd: PartListUnion,
+    abortSignal?: AbortSignal,
   ) => Promise<SlashCommandProcessorResult | false>,
   shellModeActive: boolean,
   getPreferredEditor: () => EditorType | undefined,
@@ -93,6 +104,7 @@ export const useResearchStream = (
   performMemoryRefresh: () => Promise<void>,
   modelSwitchedFromQuotaError: boolean,
   setModelSwitchedFromQuotaError: React.Dispatch<React.SetStateAction<boolean>>,
+  modelRouting?: ModelRoutingSettings,
 ) => {
   const [initError, setInitError] = useState<string | null>(null);
   const abortControllerRef = useRef<AbortController | null>(null);
@@ -178,6 +190,51 @@ export const useResearchStream = (
     return StreamingState.Idle;
   }, [isResponding, toolCalls]);
 
+  // The exchange in progress, from its prompt to the final answer
+  const exchangeRef = useRef<
+    | {
+        usageBefore: TokenUsage;
+        attachments: string[];
+        started: boolean;
+        // Models the exchange was routed to, with the reasons
+        routes: string[];
+      }
+    | undefined
+  >(undefined);
+
+  useEffect(() => {
+    const exchange = exchangeRef.current;
+    if (!exchange) {
+      return;
+    }
+    if (streamingState !== StreamingState.Idle) {
+      exchange.started = true;
+      return;
+    }
+    if (!exchange.started) {
+      return;
+    }
+    exchangeRef.current = undefined;
+    const usage = subtractTokenUsage(
+      getTokenUsage(uiTelemetryService.getMetrics()),
+      exchange.usageBefore,
+    );
+    if (usage.requests === 0) {
+      return;
+    }
+    addItem(
+      {
+        type: 'exchange_info',
+        ...usage,
+        attachments: exchange.attachments,
+        memoryFileCount: config.getResearchMdFileCount(),
+        seed: researchClient?.getGenerationOptions().seed,
+        routes: exchange.routes.length > 0 ? exchange.routes : undefined,
+      },
+      Date.now(),
+    );
+  }, [streamingState, addItem, config, researchClient]);
+
   useInput((_input, key) => {
     if (streamingState === StreamingState.Responding && key.escape) {
       if (turnCancelledRef.current) {
@@ -242,7 +299,17 @@ export const useResearchStream = (
           '[DEBUG] useResearchStream: About to call handleSlashCommand with:',
           trimmedQuery,
         );
-        const slashCommandResult = await handleSlashCommand(trimmedQuery);
+        // Escape cancels a long-running command as it does a response
+        setIsResponding(true);
+        let slashCommandResult: SlashCommandProcessorResult | false;
+        try {
+          slashCommandResult = await handleSlashCommand(
+            trimmedQuery,
+            abortSignal,
+          );
+        } finally {
+          setIsResponding(false);
+        }
         console.log(
           '[DEBUG] useResearchStream: handleSlashCommand returned:',
           slashCommandResult,
@@ -328,6 +395,9 @@ export const useResearchStream = (
         return '';
       }
       let newResearchMessageBuffer = currentResearchMessageBuffer + eventValue;
+      // Stamped as the text arrives: a fallback model may be answering in
+      // place of the selected one
+      const model = config.getActiveFallbackModel() ?? config.getModel();
       if (
         pendingHistoryItemRef.current?.type !== 'research' &&
         pendingHistoryItemRef.current?.type !== 'research_content'
@@ -335,7 +405,11 @@ export const useResearchStream = (
         if (pendingHistoryItemRef.current) {
           addItem(pendingHistoryItemRef.current, userMessageTimestamp);
         }
-        setPendingHistoryItem({ type: 'research', text: '' });
+        setPendingHistoryItem({
+          type: 'research',
+          text: '',
+          seed: researchClient?.getGenerationOptions().seed,
+        });
         newResearchMessageBuffer = eventValue;
       }
       // Split large messages for better rendering performance. Ideally,
@@ -343,10 +417,15 @@ export const useResearchStream = (
       const splitPoint = findLastSafeSplitPoint(newResearchMessageBuffer);
       if (splitPoint === newResearchMessageBuffer.length) {
         // Update the existing message with accumulated content
-        setPendingHistoryItem((item) => ({
-          type: item?.type as 'research' | 'research_content',
-          text: newResearchMessageBuffer,
-        }));
+        setPendingHistoryItem((item) =>
+          item?.type === 'research'
+            ? { ...item, text: newResearchMessageBuffer, model }
+            : {
+                type: 'research_content',
+                text: newResearchMessageBuffer,
+                model,
+              },
+        );
       } else {
         // This indicates that we need to split up this Research Message.
         // Splitting a message is primarily a performance consideration. There is a
@@ -358,21 +437,29 @@ export const useResearchStream = (
         // broken up so that there are more "statically" rendered.
         const beforeText = newResearchMessageBuffer.substring(0, splitPoint);
         const afterText = newResearchMessageBuffer.substring(splitPoint);
+        const pendingItem = pendingHistoryItemRef.current;
         addItem(
-          {
-            type: pendingHistoryItemRef.current?.type as
-              | 'research'
-              | 'research_content',
-            text: beforeText,
-          },
+          pendingItem?.type === 'research'
+            ? { ...pendingItem, text: beforeText, model }
+            : { type: 'research_content', text: beforeText, model },
           userMessageTimestamp,
         );
-        setPendingHistoryItem({ type: 'research_content', text: afterText });
+        setPendingHistoryItem({
+          type: 'research_content',
+          text: afterText,
+          model,
+        });
         newResearchMessageBuffer = afterText;
       }
       return newResearchMessageBuffer;
     },
-    [addItem, pendingHistoryItemRef, setPendingHistoryItem],
+    [
+      addItem,
+      pendingHistoryItemRef,
+      setPendingHistoryItem,
+      config,
+      researchClient,
+    ],
   );
 
   const handleUserCancelledEvent = useCallback(
@@ -470,43 +557,55 @@ export const useResearchStream = (
     ): Promise<StreamProcessingStatus> => {
       let researchMessageBuffer = '';
       const toolCallRequests: ToolCallRequestInfo[] = [];
-      for await (const event of stream) {
-        switch (event.type) {
-          case ServerResearchEventType.Thought:
-            setThought(event.value);
-            break;
-          case ServerResearchEventType.Content:
-            researchMessageBuffer = handleContentEvent(
-              event.value,
-              researchMessageBuffer,
-              userMessageTimestamp,
-            );
-            break;
-          case ServerResearchEventType.ToolCallRequest:
-            toolCallRequests.push(event.value);
-            break;
-          case ServerResearchEventType.UserCancelled:
-            handleUserCancelledEvent(userMessageTimestamp);
-            break;
-          case ServerResearchEventType.Error:
-            handleErrorEvent(event.value, userMessageTimestamp);
-            break;
-          case ServerResearchEventType.ChatCompressed:
-            handleChatCompressionEvent(event.value);
-            break;
-          case ServerResearchEventType.ToolCallConfirmation:
-          case ServerResearchEventType.ToolCallResponse:
-            // do nothing
-            break;
-          case ServerResearchEventType.MaxSessionTurns:
-            handleMaxSessionTurnsEvent();
-            break;
-          default: {
-            // enforces exhaustive switch-case
-            const unreachable: never = event;
-            return unreachable;
+      // Tokens can arrive much faster than the terminal redraws, so content
+      // is merged per frame before it reaches the pending history item.
+      const content = new StreamCoalescer((text) => {
+        researchMessageBuffer = handleContentEvent(
+          text,
+          researchMessageBuffer,
+          userMessageTimestamp,
+        );
+      });
+      try {
+        for await (const event of stream) {
+          if (event.type !== ServerResearchEventType.Content) {
+            content.flush();
+          }
+          switch (event.type) {
+            case ServerResearchEventType.Thought:
+              setThought(event.value);
+              break;
+            case ServerResearchEventType.Content:
+              content.push(event.value);
+              break;
+            case ServerResearchEventType.ToolCallRequest:
+              toolCallRequests.push(event.value);
+              break;
+            case ServerResearchEventType.UserCancelled:
+              handleUserCancelledEvent(userMessageTimestamp);
+              break;
+            case ServerResearchEventType.Error:
+              handleErrorEvent(event.value, userMessageTimestamp);
+              break;
+            case ServerResearchEventType.ChatCompressed:
+              handleChatCompressionEvent(event.value);
+              break;
+            case ServerResearchEventType.ToolCallConfirmation:
+            case ServerResearchEventType.ToolCallResponse:
+              // do nothing
+              break;
+            case ServerResearchEventType.MaxSessionTurns:
+              handleMaxSessionTurnsEvent();
+              break;
+            default: {
+              // enforces exhaustive switch-case
+              const unreachable: never = event;
+              return unreachable;
+            }
           }
         }
+      } finally {
+        content.flush();
       }
       if (toolCallRequests.length > 0) {
         scheduleToolCalls(toolCallRequests, signal);
@@ -564,8 +663,37 @@ export const useResearchStream = (
         return;
       }
 
+      const attachments = getAttachments(queryToSend);
       if (!options?.isContinuation) {
         startNewPrompt();
+        exchangeRef.current = {
+          usageBefore: getTokenUsage(uiTelemetryService.getMetrics()),
+          attachments,
+          started: false,
+          routes: [],
+        };
+      }
+      // After a quota error the fallback model answers for the rest of
+      // the session; routing would switch back to the exhausted model.
+      const route = config.isInQuotaFallback()
+        ? undefined
+        : routeRequest(
+            {
+              promptLength: getPromptLength(queryToSend),
+              contextTokens: uiTelemetryService.getLastPromptTokenCount(),
+              hasAttachments: attachments.length > 0,
+              isToolContinuation: !!options?.isContinuation,
+            },
+            modelRouting,
+          );
+      const switchesModel = route && route.model !== config.getModel();
+      // A continuation is only logged when it escalates to another model
+      if (route && (!options?.isContinuation || switchesModel)) {
+        if (switchesModel) {
+          config.setModel(route.model);
+        }
+        exchangeRef.current?.routes.push(`${route.model} (${route.reason})`);
+        onDebugMessage(`Routed to ${route.model}: ${route.reason}`);
       }
 
       setIsResponding(true);
@@ -628,6 +756,8 @@ export const useResearchStream = (
       config,
       startNewPrompt,
       getPromptCount,
+      modelRouting,
+      onDebugMessage,
     ],
   );
 
@@ -770,7 +900,7 @@ export const useResearchStream = (
 
   useEffect(() => {
     const saveRestorableToolCalls = async () => {
-      if (!config.getCheckpointingEnabled()) {
+      if (!config.getCheckpointingEnabled() || config.isIncognito()) {
         return;
       }
       const restorableToolCalls = toolCalls.filter(
@@ -0,0 +1,188 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { useEffect, useRef, useState } from 'react';
+import {
+  SharedSessionEvent,
+  ToolConfirmationOutcome,
+} from '@iechor/research-cli-core';
+import {
+  HistoryItem,
+  HistoryItemWithoutId,
+  MessageType,
+  ToolCallStatus,
+} from '../types.js';
+import { UseHistoryManagerReturn } from './useHistoryManager.js';
+import {
+  getSharedSession,
+  MODEL_AUTHOR,
+  onSharedSessionChange,
+  SharedSession,
+} from '../../utils/sharedSession.js';
+
+/**
+ * Connects the UI to a shared session (see /share). As the host, every
+ * prompt and answer is posted with its author, what others write is shown,
+ * and tool calls waiting for approval can be answered by anyone. As a
+ * guest, everything the host's session broadcasts is shown.
+ */
+export function useSharedSession(
+  history: HistoryItem[],
+  pendingHistoryItems: HistoryItemWithoutId[],
+  addItem: UseHistoryManagerReturn['addItem'],
+): void {
+  const [session, setSession] = useState<SharedSession | undefined>(
+    getSharedSession(),
+  );
+  // The last history item posted to the hub
+  const postedIdRef = useRef(0);
+  // The call ids of the tool calls shared for approval
+  const sharedApprovalsRef = useRef(new Set<string>());
+
+  useEffect(() => onSharedSessionChange(setSession), []);
+
+  useEffect(() => {
+    if (!session) {
+      return;
+    }
+    const show = (text: string) =>
+      addItem({ type: MessageType.INFO, text }, Date.now());
+    if (session.role === 'host') {
+      const { hub } = session;
+      // Only what is said from now on is shared
+      postedIdRef.current = history[history.length - 1]?.id ?? 0;
+      sharedApprovalsRef.current.clear();
+      const onEvent = (event: SharedSessionEvent) => {
+        if (
+          event.type === 'message' &&
+          event.author !== hub.hostName &&
+          event.author !== MODEL_AUTHOR
+        ) {
+          show(`${event.author}: ${event.text}`);
+        } else if (event.type === 'presence') {
+          show(`In the shared session: ${event.participants.join(', ')}`);
+        }
+      };
+      hub.on('event', onEvent);
+      return () => {
+        hub.off('event', onEvent);
+      };
+    }
+    const { client } = session;
+    const descriptions = new Map<string, string>();
+    const onEvent = (event: SharedSessionEvent) => {
+      switch (event.type) {
+        case 'message':
+          show(`${event.author}: ${event.text}`);
+          break;
+        case 'presence':
+          show(`In the shared session: ${event.participants.join(', ')}`);
+          break;
+        case 'approval-request': {
+          descriptions.set(event.id, event.description);
+          const n = session.pendingApprovals.length;
+          show(
+            `${event.author} asks to approve ${event.description} (/share approve ${n} or /share deny ${n})`,
+          );
+          break;
+        }
+        case 'approval':
+          show(
+            `${event.author} ${event.approved ? 'approved' : 'denied'} ${descriptions.get(event.id) ?? 'a tool call'}`,
+          );
+          descriptions.delete(event.id);
+          break;
+        default:
+          break;
+      }
+    };
+    const onClose = () => show(`Left the shared session at ${session.url}.`);
+    client.on('event', onEvent);
+    client.on('close', onClose);
+    return () => {
+      client.off('event', onEvent);
+      client.off('close', onClose);
+    };
+    // The history is read only when the session starts
+    // eslint-disable-next-line react-hooks/exhaustive-deps
+  }, [session, addItem]);
+
+  // Post what was said since the last post
+  useEffect(() => {
+    if (session?.role !== 'host') {
+      return;
+    }
+    for (const item of history) {
+      if (item.id <= postedIdRef.current) {
+        continue;
+      }
+      postedIdRef.current = item.id;
+      if (item.type === 'user') {
+        session.hub.post(session.hub.hostName, item.text);
+      } else if (
+        item.type === 'research' ||
+        item.type === 'research_content'
+      ) {
+        session.hub.post(MODEL_AUTHOR, item.text);
+      }
+    }
+  }, [session, history]);
+
+  // Put tool calls waiting for approval in the shared queue
+  useEffect(() => {
+    if (session?.role !== 'host') {
+      return;
+    }
+    const { hub } = session;
+    const shared = sharedApprovalsRef.current;
+    const statuses = new Map<string, ToolCallStatus>();
+    for (const item of pendingHistoryItems) {
+      if (item.type !== 'tool_group') {
+        continue;
+      }
+      for (const tool of item.tools) {
+        statuses.set(tool.callId, tool.status);
+        const details = tool.confirmationDetails;
+        if (
+          tool.status !== ToolCallStatus.Confirming ||
+          !details ||
+          shared.has(tool.callId)
+        ) {
+          continue;
+        }
+        shared.add(tool.callId);
+        const description = `${tool.name}: ${tool.description}`;
+        hub
+          .requestApproval(description, hub.hostName, tool.callId)
+          .then(({ approved, by }) => {
+            if (by === hub.hostName) {
+              return;
+            }
+            addItem(
+              {
+                type: MessageType.INFO,
+                text: `${by} ${approved ? 'approved' : 'denied'} ${description}`,
+              },
+              Date.now(),
+            );
+            details.onConfirm(
+              approved
+                ? ToolConfirmationOutcome.ProceedOnce
+                : ToolConfirmationOutcome.Cancel,
+            );
+          });
+      }
+    }
+    // Calls answered here, in the tool prompt, are settled for everyone
+    for (const callId of shared) {
+      const status = statuses.get(callId);
+      if (status !== ToolCallStatus.Confirming) {
+        hub.decide(callId, status !== ToolCallStatus.Canceled);
+        shared.delete(callId);
+      }
+    }
+  }, [session, pendingHistoryItems, addItem]);
+}
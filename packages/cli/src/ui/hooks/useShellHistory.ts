@@ -7,7 +7,11 @@
 import { useState, useEffect, useCallback } from 'react';
 import * as fs from 'fs/promises';
 import * as path from 'path';
-import { isNodeError, getProjectTempDir } from '@iechor/research-cli-core';
+import {
+  isNodeError,
+  getProjectTempDir,
+  isIncognitoMode,
+} from '@iechor/research-cli-core';
 
 const HISTORY_FILE = 'shell_history';
 const MAX_HISTORY_LENGTH = 100;
@@ -66,8 +70,11 @@ export function useShellHistory(projectRoot: string) {
         .slice(0, MAX_HISTORY_LENGTH)
         .filter(Boolean);
       setHistory(newHistory);
-      // Write to file in reverse order (oldest first)
-      writeHistoryFile(historyFilePath, [...newHistory].reverse());
+      // Incognito sessions keep their commands in memory only.
+      if (!isIncognitoMode()) {
+        // Write to file in reverse order (oldest first)
+        writeHistoryFile(historyFilePath, [...newHistory].reverse());
+      }
       setHistoryIndex(-1);
     },
     [history, historyFilePath],
@@ -8,22 +8,59 @@ import { useEffect, useState } from 'react';
 
 const TERMINAL_PADDING_X = 8;
 
-export function useTerminalSize(): { columns: number; rows: number } {
-  const [size, setSize] = useState({
-    columns: (process.stdout.columns || 60) - TERMINAL_PADDING_X,
-    rows: process.stdout.rows || 20,
-  });
+// Below this size the layout cannot render without corrupting output.
+export const MIN_TERMINAL_COLUMNS = 40;
+export const MIN_TERMINAL_ROWS = 10;
+
+// Dragging a window edge emits a burst of resize events; only the final size
+// is applied so layout is not recomputed (and rendered stale) for each one.
+export const RESIZE_DEBOUNCE_MS = 100;
+
+export interface TerminalSize {
+  columns: number;
+  rows: number;
+  /** True when the real terminal is smaller than the minimum supported size. */
+  tooSmall: boolean;
+}
+
+function readSize(): TerminalSize {
+  const columns = process.stdout.columns || 60;
+  const rows = process.stdout.rows || 20;
+  return {
+    columns: Math.max(columns - TERMINAL_PADDING_X, 1),
+    rows,
+    tooSmall: columns < MIN_TERMINAL_COLUMNS || rows < MIN_TERMINAL_ROWS,
+  };
+}
+
+export function useTerminalSize(): TerminalSize {
+  const [size, setSize] = useState(readSize);
 
   useEffect(() => {
+    let timer: NodeJS.Timeout | undefined;
+
     function updateSize() {
-      setSize({
-        columns: (process.stdout.columns || 60) - TERMINAL_PADDING_X,
-        rows: process.stdout.rows || 20,
-      });
+      if (timer) {
+        clearTimeout(timer);
+      }
+      timer = setTimeout(() => {
+        timer = undefined;
+        const next = readSize();
+        setSize((prev) =>
+          prev.columns === next.columns &&
+          prev.rows === next.rows &&
+          prev.tooSmall === next.tooSmall
+            ? prev
+            : next,
+        );
+      }, RESIZE_DEBOUNCE_MS);
     }
 
     process.stdout.on('resize', updateSize);
     return () => {
+      if (timer) {
+        clearTimeout(timer);
+      }
       process.stdout.off('resize', updateSize);
     };
   }, []);
@@ -0,0 +1,53 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { useEffect, useState } from 'react';
+import { loadTerminology, Terminology } from '@iechor/research-cli-core';
+
+const REFRESH_INTERVAL_MS = 60 * 1000;
+
+/**
+ * Returns the workspace's terminology, re-read every minute so edits to
+ * `.research/terminology.json` show up without a restart.
+ */
+export function useTerminology(projectRoot: string): Terminology | undefined {
+  const [terminology, setTerminology] = useState<Terminology | undefined>(
+    undefined,
+  );
+
+  useEffect(() => {
+    let cancelled = false;
+    // Warn once per problem, not on every refresh
+    let lastWarnings = '';
+    const refresh = async () => {
+      let loaded: Terminology | undefined;
+      const warnings: string[] = [];
+      try {
+        loaded = await loadTerminology(projectRoot, (w) => warnings.push(w));
+        if (warnings.join('\n') !== lastWarnings) {
+          lastWarnings = warnings.join('\n');
+          for (const warning of warnings) {
+            console.warn(`Terminology: ${warning}`);
+          }
+        }
+      } catch {
+        // An unreadable terminology file just means no checks
+      }
+      if (!cancelled) {
+        setTerminology(loaded);
+      }
+    };
+
+    refresh();
+    const interval = setInterval(refresh, REFRESH_INTERVAL_MS);
+    return () => {
+      cancelled = true;
+      clearInterval(interval);
+    };
+  }, [projectRoot]);
+
+  return terminology;
+}
@@ -0,0 +1,54 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { useEffect, useState } from 'react';
+import { useStdout } from 'ink';
+import { StreamingState } from '../types.js';
+import {
+  WindowTitleWriter,
+  formatWindowTitle,
+  getWindowTitleState,
+} from '../utils/windowTitle.js';
+
+/**
+ * Keeps the terminal title on the session title and whether a response is
+ * being generated. Does nothing while `template` is undefined.
+ */
+export function useWindowTitle(
+  template: string | undefined,
+  project: string,
+  sessionTitle: string | undefined,
+  streamingState: StreamingState,
+): void {
+  const { stdout } = useStdout();
+  const [writer, setWriter] = useState<WindowTitleWriter | undefined>(
+    undefined,
+  );
+
+  useEffect(() => {
+    if (template === undefined) {
+      return;
+    }
+    const titleWriter = new WindowTitleWriter((data) => stdout.write(data));
+    setWriter(titleWriter);
+    return () => {
+      titleWriter.dispose();
+      setWriter(undefined);
+    };
+  }, [template, stdout]);
+
+  useEffect(() => {
+    if (writer && template !== undefined) {
+      writer.set(
+        formatWindowTitle(template, {
+          project,
+          title: sessionTitle ?? project,
+          state: getWindowTitleState(streamingState),
+        }),
+      );
+    }
+  }, [writer, template, project, sessionTitle, streamingState]);
+}
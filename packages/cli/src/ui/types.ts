@@ -5,6 +5,8 @@
  */
 
 import {
+  DiffFile,
+  DiffReview,
   ToolCallConfirmationDetails,
   ToolResultDisplay,
 } from '@iechor/research-cli-core';
@@ -70,11 +72,17 @@ export type HistoryItemUser = HistoryItemBase & {
 export type HistoryItemResearch = HistoryItemBase & {
   type: 'research';
   text: string;
+  model?: string; // Model that wrote the answer, for per-model styles
+  seed?: number; // Sampling seed the answer was asked for with, if any
+  firstCodeBlock?: number; // Number of its first code block in the session
 };
 
 export type HistoryItemResearchContent = HistoryItemBase & {
   type: 'research_content';
   text: string;
+  /** Model of the answer this piece continues. */
+  model?: string;
+  firstCodeBlock?: number;
 };
 
 export type HistoryItemInfo = HistoryItemBase & {
@@ -130,6 +138,53 @@ export type HistoryItemCompression = HistoryItemBase & {
   compression: CompressionProps;
 };
 
+export type HistoryItemDiffReview = HistoryItemBase & {
+  type: 'diff_review';
+  files: DiffFile[];
+  review: DiffReview;
+};
+
+export interface ThreadExchange {
+  question: string;
+  answer: string;
+}
+
+export type HistoryItemThread = HistoryItemBase & {
+  type: 'thread';
+  threadId: number;
+  /** The answer the thread replies to. */
+  answer: string;
+  exchanges: ThreadExchange[];
+  /** Whether the thread is sent with the main conversation. */
+  included: boolean;
+  /** Shows a one-line summary instead of the exchanges. */
+  collapsed?: boolean;
+};
+
+export type HistoryItemExchangeInfo = HistoryItemBase & {
+  type: 'exchange_info';
+  /** Tokens over every request of the exchange, tool results included. */
+  promptTokens: number;
+  completionTokens: number;
+  requests: number;
+  /** Files read for @ paths, and images and files without a path. */
+  attachments: string[];
+  /** Memory files (RESEARCH.md) sent with each request. */
+  memoryFileCount: number;
+  /** Sampling seed set with /set seed, to reproduce the answers. */
+  seed?: number;
+  /** Models the exchange was routed to, such as `gpt-4o (tool use)`. */
+  routes?: string[];
+};
+
+export type HistoryItemImage = HistoryItemBase & {
+  type: 'image';
+  /** Shown above the image, and alone where images cannot be drawn. */
+  caption: string;
+  /** The PNG file to draw. */
+  file: string;
+};
+
 // Using Omit<HistoryItem, 'id'> seems to have some issues with typescript's
 // type inference e.g. historyItem.type === 'tool_group' isn't auto-inferring that
 // 'tools' in historyItem.
@@ -147,7 +202,11 @@ export type HistoryItemWithoutId =
   | HistoryItemModelStats
   | HistoryItemToolStats
   | HistoryItemQuit
-  | HistoryItemCompression;
+  | HistoryItemCompression
+  | HistoryItemDiffReview
+  | HistoryItemThread
+  | HistoryItemExchangeInfo
+  | HistoryItemImage;
 
 export type HistoryItem = HistoryItemWithoutId & { id: number };
 
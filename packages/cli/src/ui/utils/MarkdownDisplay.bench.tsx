@@ -0,0 +1,72 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { render } from 'ink-testing-library';
+import { bench, describe } from 'vitest';
+import { MarkdownDisplay } from './MarkdownDisplay.js';
+import { findLastSafeSplitPoint } from './markdownUtilities.js';
+
+// A response shaped like a long research answer: prose, lists, code, tables.
+const SECTION = `## Findings
+
+Transformer models scale predictably with compute, data and parameters.
+See **Kaplan et al.** and \`chinchilla\` for the relevant *scaling laws*.
+
+- Loss follows a power law in model size
+- Data-limited regimes flatten the curve
+- Optimal allocation grows data and parameters together
+
+\`\`\`python
+def loss(n, d):
+    return (n_c / n) ** alpha_n + (d_c / d) ** alpha_d
+\`\`\`
+
+| Model | Params | Tokens |
+|-------|--------|--------|
+| A     | 1B     | 20B    |
+| B     | 7B     | 140B   |
+`;
+
+const LONG_MESSAGE = Array.from({ length: 50 }, () => SECTION).join('\n');
+
+describe('message rendering', () => {
+  bench('render a long response at 80 columns', () => {
+    const { unmount } = render(
+      <MarkdownDisplay
+        text={LONG_MESSAGE}
+        isPending={false}
+        terminalWidth={80}
+      />,
+    );
+    unmount();
+  });
+
+  bench('render a long response at 200 columns', () => {
+    const { unmount } = render(
+      <MarkdownDisplay
+        text={LONG_MESSAGE}
+        isPending={false}
+        terminalWidth={200}
+      />,
+    );
+    unmount();
+  });
+});
+
+describe('stream throughput', () => {
+  // While streaming, the buffered response is re-scanned for a safe split
+  // point after every chunk.
+  bench('find split points while streaming a long response', () => {
+    let buffer = '';
+    for (let i = 0; i < LONG_MESSAGE.length; i += 64) {
+      buffer += LONG_MESSAGE.slice(i, i + 64);
+      const split = findLastSafeSplitPoint(buffer);
+      if (split < buffer.length) {
+        buffer = buffer.slice(split);
+      }
+    }
+  });
+});
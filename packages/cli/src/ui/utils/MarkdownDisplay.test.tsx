@@ -173,4 +173,21 @@ Another paragraph.
     );
     expect(lastFrame()).toMatchSnapshot();
   });
+
+  it('numbers the code blocks from firstCodeBlock', () => {
+    const text = '```python\nprint(1)\n```\n\nThen:\n\n```\nls\n```';
+    const { lastFrame } = render(
+      <MarkdownDisplay {...baseProps} text={text} firstCodeBlock={3} />,
+    );
+    expect(lastFrame()).toContain('[3] python');
+    expect(lastFrame()).toContain('[4]');
+  });
+
+  it('leaves code blocks unnumbered by default', () => {
+    const text = '```python\nprint(1)\n```';
+    const { lastFrame } = render(
+      <MarkdownDisplay {...baseProps} text={text} />,
+    );
+    expect(lastFrame()).not.toContain('[1]');
+  });
 });
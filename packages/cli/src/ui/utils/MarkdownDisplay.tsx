@@ -10,12 +10,15 @@ import { Colors } from '../colors.js';
 import { colorizeCode } from './CodeColorizer.js';
 import { TableRenderer } from './TableRenderer.js';
 import { RenderInline } from './InlineMarkdownRenderer.js';
+import { CODE_FENCE_REGEX } from './codeBlocks.js';
 
 interface MarkdownDisplayProps {
   text: string;
   isPending: boolean;
   availableTerminalHeight?: number;
   terminalWidth: number;
+  /** Numbers the code blocks from this one, so they can be copied. */
+  firstCodeBlock?: number;
 }
 
 // Constants for Markdown parsing and rendering
@@ -30,12 +33,13 @@ const MarkdownDisplayInternal: React.FC<MarkdownDisplayProps> = ({
   isPending,
   availableTerminalHeight,
   terminalWidth,
+  firstCodeBlock,
 }) => {
   if (!text) return <></>;
 
   const lines = text.split('\n');
   const headerRegex = /^ *(#{1,4}) +(.*)/;
-  const codeFenceRegex = /^ *(`{3,}|~{3,}) *(\w*?) *$/;
+  const codeFenceRegex = CODE_FENCE_REGEX;
   const ulItemRegex = /^([ \t]*)([-*+]) +(.*)/;
   const olItemRegex = /^([ \t]*)(\d+)\. +(.*)/;
   const hrRegex = /^ *([-*_] *){3,} *$/;
@@ -48,6 +52,7 @@ const MarkdownDisplayInternal: React.FC<MarkdownDisplayProps> = ({
   let codeBlockContent: string[] = [];
   let codeBlockLang: string | null = null;
   let codeBlockFence = '';
+  let codeBlockNumber = firstCodeBlock;
   let inTable = false;
   let tableRows: string[][] = [];
   let tableHeaders: string[] = [];
@@ -74,11 +79,15 @@ const MarkdownDisplayInternal: React.FC<MarkdownDisplayProps> = ({
             key={key}
             content={codeBlockContent}
             lang={codeBlockLang}
+            number={codeBlockNumber}
             isPending={isPending}
             availableTerminalHeight={availableTerminalHeight}
             terminalWidth={terminalWidth}
           />,
         );
+        if (codeBlockNumber !== undefined) {
+          codeBlockNumber++;
+        }
         inCodeBlock = false;
         codeBlockContent = [];
         codeBlockLang = null;
@@ -259,6 +268,7 @@ const MarkdownDisplayInternal: React.FC<MarkdownDisplayProps> = ({
         key="line-eof"
         content={codeBlockContent}
         lang={codeBlockLang}
+        number={codeBlockNumber}
         isPending={isPending}
         availableTerminalHeight={availableTerminalHeight}
         terminalWidth={terminalWidth}
@@ -286,6 +296,8 @@ const MarkdownDisplayInternal: React.FC<MarkdownDisplayProps> = ({
 interface RenderCodeBlockProps {
   content: string[];
   lang: string | null;
+  /** Shown above the code, with the language, when set. */
+  number?: number;
   isPending: boolean;
   availableTerminalHeight?: number;
   terminalWidth: number;
@@ -294,12 +306,18 @@ interface RenderCodeBlockProps {
 const RenderCodeBlockInternal: React.FC<RenderCodeBlockProps> = ({
   content,
   lang,
+  number,
   isPending,
   availableTerminalHeight,
   terminalWidth,
 }) => {
   const MIN_LINES_FOR_MESSAGE = 1; // Minimum lines to show before the "generating more" message
   const RESERVED_LINES = 2; // Lines reserved for the message itself and potential padding
+  const label = number !== undefined && (
+    <Text color={Colors.Gray}>
+      [{number}]{lang ? ` ${lang}` : ''}
+    </Text>
+  );
 
   if (isPending && availableTerminalHeight !== undefined) {
     const MAX_CODE_LINES_WHEN_PENDING = Math.max(
@@ -325,6 +343,7 @@ const RenderCodeBlockInternal: React.FC<RenderCodeBlockProps> = ({
       );
       return (
         <Box paddingLeft={CODE_BLOCK_PREFIX_PADDING} flexDirection="column">
+          {label}
           {colorizedTruncatedCode}
           <Text color={Colors.Gray}>... generating more ...</Text>
         </Box>
@@ -347,6 +366,7 @@ const RenderCodeBlockInternal: React.FC<RenderCodeBlockProps> = ({
       width={terminalWidth}
       flexShrink={0}
     >
+      {label}
       {colorizedCode}
     </Box>
   );
@@ -0,0 +1,77 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect } from 'vitest';
+import { ansiToSvg, parseAnsi, xterm256Color } from './ansiSvg.js';
+
+describe('ansiSvg', () => {
+  const options = { columns: 40, background: '#000000', foreground: '#FFFFFF' };
+
+  it('should split text into styled runs', () => {
+    const lines = parseAnsi(
+      'a \x1b[1;38;2;255;0;16mbold\x1b[22m red\x1b[0m\n\x1b[44mblue\x1b[49m\n\n',
+    );
+
+    expect(lines).toEqual([
+      [
+        { column: 0, text: 'a ', width: 2, style: {} },
+        {
+          column: 2,
+          text: 'bold',
+          width: 4,
+          style: { bold: true, fg: '#ff0010' },
+        },
+        {
+          column: 6,
+          text: ' red',
+          width: 4,
+          style: { bold: false, dim: false, fg: '#ff0010' },
+        },
+      ],
+      [{ column: 0, text: 'blue', width: 4, style: { bg: '#89B4FA' } }],
+    ]);
+  });
+
+  it('should count wide characters as two columns', () => {
+    const [[first, second]] = parseAnsi('表\x1b[31mx');
+
+    expect(first.width).toBe(2);
+    expect(second.column).toBe(2);
+  });
+
+  it('should drop hyperlinks and other escapes', () => {
+    expect(
+      parseAnsi('\x1b]8;;https://example.com\x07link\x1b]8;;\x07\x1b[2K'),
+    ).toEqual([[{ column: 0, text: 'link', width: 4, style: {} }]]);
+  });
+
+  it('should map the 256-color palette', () => {
+    expect(xterm256Color(1)).toBe('#F38BA8');
+    expect(xterm256Color(196)).toBe('#ff0000');
+    expect(xterm256Color(232)).toBe('#080808');
+  });
+
+  it('should draw a terminal window', () => {
+    const svg = ansiToSvg('\x1b[1mA <b> & c\x1b[0m\n  \x1b[7mx\x1b[27m', {
+      ...options,
+      title: 'Demo',
+    });
+
+    expect(svg).toMatch(/^<svg xmlns="http:\/\/www.w3.org\/2000\/svg"/);
+    expect(svg).toContain('fill="#000000"');
+    expect(svg).toContain('>Demo</text>');
+    expect(svg).toContain('font-weight="bold">A &lt;b&gt; &amp; c</text>');
+    expect(svg).toContain('fill="#FFFFFF"/>');
+    expect(svg).toContain('fill="#000000">x</text>');
+  });
+
+  it('should leave out the title bar without a title', () => {
+    const svg = ansiToSvg('hello', options);
+
+    expect(svg).not.toContain('<circle');
+    expect(svg).toContain('height="50"');
+  });
+});
@@ -0,0 +1,269 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import stringWidth from 'string-width';
+
+const FONT_SIZE = 14;
+const CELL_WIDTH = FONT_SIZE * 0.6;
+const LINE_HEIGHT = 18;
+const PADDING = 16;
+const TITLE_BAR_HEIGHT = 28;
+
+/** The 16 basic terminal colors, normal then bright. */
+const ANSI_COLORS = [
+  '#45475A',
+  '#F38BA8',
+  '#A6E3A1',
+  '#F9E2AF',
+  '#89B4FA',
+  '#F5C2E7',
+  '#94E2D5',
+  '#BAC2DE',
+  '#585B70',
+  '#F38BA8',
+  '#A6E3A1',
+  '#F9E2AF',
+  '#89B4FA',
+  '#F5C2E7',
+  '#94E2D5',
+  '#A6ADC8',
+];
+
+export interface SvgOptions {
+  /** Width of the terminal the text was rendered for. */
+  columns: number;
+  background: string;
+  foreground: string;
+  /** Shown in the window title bar; no title bar without it. */
+  title?: string;
+}
+
+interface CellStyle {
+  fg?: string;
+  bg?: string;
+  bold?: boolean;
+  dim?: boolean;
+  italic?: boolean;
+  underline?: boolean;
+  strikethrough?: boolean;
+  inverse?: boolean;
+}
+
+interface Run {
+  column: number;
+  text: string;
+  width: number;
+  style: CellStyle;
+}
+
+function hex(n: number): string {
+  return n.toString(16).padStart(2, '0');
+}
+
+/** A color of the 256-color palette: basic colors, a 6×6×6 cube, grays. */
+export function xterm256Color(n: number): string {
+  if (n < 16) {
+    return ANSI_COLORS[n];
+  }
+  if (n >= 232) {
+    const level = 8 + (n - 232) * 10;
+    return `#${hex(level)}${hex(level)}${hex(level)}`;
+  }
+  const levels = [0, 95, 135, 175, 215, 255];
+  const i = n - 16;
+  return `#${hex(levels[Math.floor(i / 36)])}${hex(levels[Math.floor(i / 6) % 6])}${hex(levels[i % 6])}`;
+}
+
+/** Applies the parameters of one SGR sequence (`\x1b[...m`) to `style`. */
+function applySgr(style: CellStyle, params: number[]): CellStyle {
+  const next = { ...style };
+  for (let i = 0; i < params.length; i++) {
+    const code = params[i];
+    if (code === 0) {
+      Object.keys(next).forEach((key) => delete next[key as keyof CellStyle]);
+    } else if (code === 1) {
+      next.bold = true;
+    } else if (code === 2) {
+      next.dim = true;
+    } else if (code === 3) {
+      next.italic = true;
+    } else if (code === 4) {
+      next.underline = true;
+    } else if (code === 7) {
+      next.inverse = true;
+    } else if (code === 9) {
+      next.strikethrough = true;
+    } else if (code === 22) {
+      next.bold = false;
+      next.dim = false;
+    } else if (code === 23) {
+      next.italic = false;
+    } else if (code === 24) {
+      next.underline = false;
+    } else if (code === 27) {
+      next.inverse = false;
+    } else if (code === 29) {
+      next.strikethrough = false;
+    } else if (code === 38 || code === 48) {
+      let color: string | undefined;
+      if (params[i + 1] === 5) {
+        color = xterm256Color(params[i + 2] ?? 0);
+        i += 2;
+      } else if (params[i + 1] === 2) {
+        const [r, g, b] = params.slice(i + 2, i + 5).map((v) => v ?? 0);
+        color = `#${hex(r)}${hex(g)}${hex(b)}`;
+        i += 4;
+      }
+      if (code === 38) {
+        next.fg = color;
+      } else {
+        next.bg = color;
+      }
+    } else if (code === 39) {
+      next.fg = undefined;
+    } else if (code === 49) {
+      next.bg = undefined;
+    } else if (code >= 30 && code <= 37) {
+      next.fg = ANSI_COLORS[code - 30];
+    } else if (code >= 40 && code <= 47) {
+      next.bg = ANSI_COLORS[code - 40];
+    } else if (code >= 90 && code <= 97) {
+      next.fg = ANSI_COLORS[code - 90 + 8];
+    } else if (code >= 100 && code <= 107) {
+      next.bg = ANSI_COLORS[code - 100 + 8];
+    }
+  }
+  return next;
+}
+
+// CSI sequences, and OSC sequences such as hyperlinks
+const ESCAPE_SEQUENCE =
+  // eslint-disable-next-line no-control-regex
+  /(\x1b\[[0-9;]*[A-Za-z]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\))/;
+
+const sameStyle = (a: CellStyle, b: CellStyle) =>
+  JSON.stringify(a) === JSON.stringify(b);
+
+/**
+ * Splits ANSI text into lines of styled runs. Escape sequences other than
+ * colors and text attributes, such as hyperlinks, are dropped.
+ */
+export function parseAnsi(text: string): Run[][] {
+  const lines: Run[][] = [];
+  let style: CellStyle = {};
+  for (const line of text.split('\n')) {
+    const runs: Run[] = [];
+    let column = 0;
+    const tokens = line.split(ESCAPE_SEQUENCE);
+    for (const token of tokens) {
+      if (!token) {
+        continue;
+      }
+      if (token.startsWith('\x1b')) {
+        if (token.startsWith('\x1b[') && token.endsWith('m')) {
+          const params = token
+            .slice(2, -1)
+            .split(';')
+            .map((p) => (p === '' ? 0 : Number(p)));
+          style = applySgr(style, params);
+        }
+        continue;
+      }
+      const width = stringWidth(token);
+      const last = runs[runs.length - 1];
+      if (last && sameStyle(last.style, style)) {
+        last.text += token;
+        last.width += width;
+      } else {
+        runs.push({ column, text: token, width, style });
+      }
+      column += width;
+    }
+    lines.push(runs);
+  }
+  while (lines.length > 0 && lines[lines.length - 1].length === 0) {
+    lines.pop();
+  }
+  return lines;
+}
+
+function escapeXml(text: string): string {
+  return text
+    .replace(/&/g, '&amp;')
+    .replace(/</g, '&lt;')
+    .replace(/>/g, '&gt;')
+    .replace(/"/g, '&quot;');
+}
+
+/**
+ * Draws ANSI terminal output as an SVG image of a terminal window, with
+ * the colors, attributes and box-drawing borders of the text.
+ */
+export function ansiToSvg(ansi: string, options: SvgOptions): string {
+  const lines = parseAnsi(ansi);
+  const top = options.title !== undefined ? TITLE_BAR_HEIGHT : 0;
+  const width = Math.ceil(options.columns * CELL_WIDTH + PADDING * 2);
+  const height = Math.ceil(top + lines.length * LINE_HEIGHT + PADDING * 2);
+
+  const body: string[] = [];
+  lines.forEach((runs, row) => {
+    const y = top + PADDING + row * LINE_HEIGHT;
+    for (const run of runs) {
+      const { style } = run;
+      const fg = style.inverse
+        ? (style.bg ?? options.background)
+        : (style.fg ?? options.foreground);
+      const bg = style.inverse ? (style.fg ?? options.foreground) : style.bg;
+      const x = PADDING + run.column * CELL_WIDTH;
+      if (bg) {
+        body.push(
+          `<rect x="${x.toFixed(1)}" y="${y}" width="${(run.width * CELL_WIDTH).toFixed(1)}" height="${LINE_HEIGHT}" fill="${bg}"/>`,
+        );
+      }
+      if (!run.text.trim()) {
+        continue;
+      }
+      const attributes = [
+        `x="${x.toFixed(1)}"`,
+        `y="${y + LINE_HEIGHT * 0.75}"`,
+        `fill="${fg}"`,
+        ...(style.bold ? ['font-weight="bold"'] : []),
+        ...(style.italic ? ['font-style="italic"'] : []),
+        ...(style.dim ? ['opacity="0.6"'] : []),
+        ...(style.underline || style.strikethrough
+          ? [
+              `text-decoration="${[
+                ...(style.underline ? ['underline'] : []),
+                ...(style.strikethrough ? ['line-through'] : []),
+              ].join(' ')}"`,
+            ]
+          : []),
+      ];
+      body.push(`<text ${attributes.join(' ')}>${escapeXml(run.text)}</text>`);
+    }
+  });
+
+  const titleBar =
+    options.title !== undefined
+      ? [
+          `<circle cx="${PADDING + 6}" cy="${TITLE_BAR_HEIGHT / 2 + 4}" r="6" fill="#FF5F57"/>`,
+          `<circle cx="${PADDING + 26}" cy="${TITLE_BAR_HEIGHT / 2 + 4}" r="6" fill="#FEBC2E"/>`,
+          `<circle cx="${PADDING + 46}" cy="${TITLE_BAR_HEIGHT / 2 + 4}" r="6" fill="#28C840"/>`,
+          `<text x="${width / 2}" y="${TITLE_BAR_HEIGHT / 2 + 8}" fill="${options.foreground}" opacity="0.7" text-anchor="middle">${escapeXml(options.title)}</text>`,
+        ]
+      : [];
+
+  return [
+    `<svg xmlns="http://www.w3.org/2000/svg" width="${width}" height="${height}" viewBox="0 0 ${width} ${height}">`,
+    `<rect width="${width}" height="${height}" rx="8" fill="${options.background}"/>`,
+    `<g font-family="ui-monospace, SFMono-Regular, Menlo, Consolas, 'DejaVu Sans Mono', monospace" font-size="${FONT_SIZE}" xml:space="preserve">`,
+    ...titleBar,
+    ...body,
+    '</g>',
+    '</svg>',
+    '',
+  ].join('\n');
+}
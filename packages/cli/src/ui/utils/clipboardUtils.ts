@@ -4,18 +4,47 @@
  * SPDX-License-Identifier: Apache-2.0
  */
 
-import { exec } from 'child_process';
+import { exec, execFile, spawn } from 'child_process';
 import { promisify } from 'util';
 import * as fs from 'fs/promises';
 import * as path from 'path';
+import { getPowerShellArgs } from '@iechor/research-cli-core';
 
 const execAsync = promisify(exec);
+const execFileAsync = promisify(execFile);
+
+const WINDOWS_CLIPBOARD_PREFIX =
+  'Add-Type -AssemblyName System.Windows.Forms, System.Drawing; ';
+
+async function runWindowsClipboardScript(
+  script: string,
+  env: NodeJS.ProcessEnv = process.env,
+): Promise<string> {
+  // The clipboard is only reachable from a single-threaded apartment
+  const { stdout } = await execFileAsync(
+    'powershell.exe',
+    ['-STA', ...getPowerShellArgs(WINDOWS_CLIPBOARD_PREFIX + script)],
+    { env, windowsHide: true },
+  );
+  return stdout.trim();
+}
 
 /**
- * Checks if the system clipboard contains an image (macOS only for now)
+ * Checks if the system clipboard contains an image (macOS and Windows)
  * @returns true if clipboard contains an image
  */
 export async function clipboardHasImage(): Promise<boolean> {
+  if (process.platform === 'win32') {
+    try {
+      return (
+        (await runWindowsClipboardScript(
+          '[Windows.Forms.Clipboard]::ContainsImage()',
+        )) === 'True'
+      );
+    } catch {
+      return false;
+    }
+  }
   if (process.platform !== 'darwin') {
     return false;
   }
@@ -33,14 +62,30 @@ export async function clipboardHasImage(): Promise<boolean> {
 }
 
 /**
- * Saves the image from clipboard to a temporary file (macOS only for now)
+ * Saves the Windows clipboard image as a PNG. The file name is passed in
+ * the environment, so it needs no quoting in the script.
+ */
+async function saveWindowsClipboardImage(
+  tempFilePath: string,
+): Promise<string | null> {
+  const result = await runWindowsClipboardScript(
+    '$image = [Windows.Forms.Clipboard]::GetImage(); ' +
+      'if ($image) { $image.Save($env:RESEARCH_CLIPBOARD_FILE, ' +
+      "[Drawing.Imaging.ImageFormat]::Png); 'success' }",
+    { ...process.env, RESEARCH_CLIPBOARD_FILE: tempFilePath },
+  );
+  return result === 'success' ? tempFilePath : null;
+}
+
+/**
+ * Saves the image from clipboard to a temporary file (macOS and Windows)
  * @param targetDir The target directory to create temp files within
  * @returns The path to the saved image file, or null if no image or error
  */
 export async function saveClipboardImage(
   targetDir?: string,
 ): Promise<string | null> {
-  if (process.platform !== 'darwin') {
+  if (process.platform !== 'darwin' && process.platform !== 'win32') {
     return null;
   }
 
@@ -54,6 +99,12 @@ export async function saveClipboardImage(
     // Generate a unique filename with timestamp
     const timestamp = new Date().getTime();
 
+    if (process.platform === 'win32') {
+      return await saveWindowsClipboardImage(
+        path.join(tempDir, `clipboard-${timestamp}.png`),
+      );
+    }
+
     // Try different image formats in order of preference
     const formats = [
       { class: 'PNGf', extension: 'png' },
@@ -147,3 +198,65 @@ export async function cleanupOldClipboardImages(
     // Ignore errors in cleanup
   }
 }
+
+/** Runs a command that reads the text to copy from its standard input. */
+function pipeToCommand(command: string, args: string[], text: string) {
+  return new Promise<void>((resolve, reject) => {
+    const child = spawn(command, args, {
+      stdio: ['pipe', 'ignore', 'ignore'],
+    });
+    child.on('error', reject);
+    child.on('close', (code) =>
+      code === 0 ? resolve() : reject(new Error(`${command} exited ${code}`)),
+    );
+    child.stdin.end(text);
+  });
+}
+
+// Copy commands tried in turn on Linux and the BSDs
+const UNIX_COPY_COMMANDS: Array<[string, string[]]> = [
+  ['wl-copy', []],
+  ['xclip', ['-selection', 'clipboard']],
+  ['xsel', ['--clipboard', '--input']],
+];
+
+/**
+ * Copies text to the system clipboard. Without a clipboard command, as
+ * over SSH, the text is sent to the terminal as an OSC 52 sequence, which
+ * most terminals put on the clipboard.
+ * @returns How the text was copied, e.g. `pbcopy` or `osc52`
+ */
+export async function copyToClipboard(text: string): Promise<string> {
+  if (process.platform === 'darwin') {
+    await pipeToCommand('pbcopy', [], text);
+    return 'pbcopy';
+  }
+  if (process.platform === 'win32') {
+    await runWindowsClipboardScript(
+      'Set-Clipboard -Value $env:RESEARCH_CLIPBOARD_TEXT',
+      { ...process.env, RESEARCH_CLIPBOARD_TEXT: text },
+    );
+    return 'Set-Clipboard';
+  }
+  if (!process.env.SSH_TTY) {
+    for (const [command, args] of UNIX_COPY_COMMANDS) {
+      if (command === 'wl-copy' && !process.env.WAYLAND_DISPLAY) {
+        continue;
+      }
+      try {
+        await pipeToCommand(command, args, text);
+        return command;
+      } catch {
+        // Not installed or no display; try the next one
+      }
+    }
+  }
+  const sequence = `\x1b]52;c;${Buffer.from(text).toString('base64')}\x07`;
+  // tmux passes the sequence on only when wrapped
+  process.stdout.write(
+    process.env.TMUX
+      ? `\x1bPtmux;${sequence.split('\x1b').join('\x1b\x1b')}\x1b\\`
+      : sequence,
+  );
+  return 'osc52';
+}
@@ -0,0 +1,99 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect } from 'vitest';
+import {
+  extractCodeBlocks,
+  findCodeBlock,
+  nextCodeBlockNumber,
+  numberCodeBlocks,
+} from './codeBlocks.js';
+import { HistoryItem, HistoryItemWithoutId } from '../types.js';
+
+const ANSWER = [
+  'Install it:',
+  '```bash',
+  'pip install numpy',
+  '```',
+  'Then:',
+  '~~~~',
+  'import numpy as np',
+  '```',
+  'np.zeros(3)',
+  '~~~~',
+].join('\n');
+
+describe('extractCodeBlocks', () => {
+  it('should find fenced blocks with their languages', () => {
+    expect(extractCodeBlocks(ANSWER)).toEqual([
+      { language: 'bash', code: 'pip install numpy' },
+      { language: null, code: 'import numpy as np\n```\nnp.zeros(3)' },
+    ]);
+  });
+
+  it('should count a block still being written', () => {
+    expect(extractCodeBlocks('```js\nconst a = 1;')).toEqual([
+      { language: 'js', code: 'const a = 1;' },
+    ]);
+  });
+
+  it('should find nothing in plain text', () => {
+    expect(extractCodeBlocks('No code here.')).toEqual([]);
+  });
+});
+
+describe('numbering', () => {
+  const history: HistoryItem[] = [];
+  const add = (item: HistoryItemWithoutId) => {
+    history.push({ ...numberCodeBlocks(item, history), id: history.length });
+  };
+
+  it('should number the blocks through the session', () => {
+    add({ type: 'user', text: 'How?' });
+    add({ type: 'research', text: 'No code.' });
+    add({ type: 'research', text: ANSWER });
+    add({ type: 'research_content', text: '```r\nsummary(x)\n```' });
+
+    expect(history[1]).not.toHaveProperty('firstCodeBlock');
+    expect(history[2]).toMatchObject({ firstCodeBlock: 1 });
+    expect(history[3]).toMatchObject({ firstCodeBlock: 3 });
+    expect(nextCodeBlockNumber(history)).toBe(4);
+  });
+
+  it('should find a block by its number', () => {
+    expect(findCodeBlock(history, 2)?.code).toContain('np.zeros');
+    expect(findCodeBlock(history, 3)).toEqual({
+      language: 'r',
+      code: 'summary(x)',
+    });
+    expect(findCodeBlock(history, 4)).toBeUndefined();
+    expect(findCodeBlock(history, 0)).toBeUndefined();
+  });
+
+  it('should start at one', () => {
+    expect(nextCodeBlockNumber([])).toBe(1);
+  });
+
+  it('should count a block split between two pieces once', () => {
+    const split: HistoryItem[] = [];
+    const addPiece = (item: HistoryItemWithoutId) => {
+      split.push({ ...numberCodeBlocks(item, split), id: split.length });
+    };
+    addPiece({ type: 'research', text: 'Run:\n~~~sh\nmake' });
+    addPiece({ type: 'research_content', text: '\nmake test\n~~~\nDone.' });
+    addPiece({ type: 'research_content', text: '\n```py\nprint(1)\n```' });
+
+    expect(split[0]).toMatchObject({ firstCodeBlock: 1 });
+    expect(split[1]).not.toHaveProperty('firstCodeBlock');
+    expect(split[2]).toMatchObject({ firstCodeBlock: 2 });
+    expect(nextCodeBlockNumber(split)).toBe(3);
+    expect(findCodeBlock(split, 1)).toEqual({
+      language: 'sh',
+      code: 'make\nmake test',
+    });
+    expect(findCodeBlock(split, 2)?.code).toBe('print(1)');
+  });
+});
@@ -0,0 +1,154 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { HistoryItem, HistoryItemWithoutId } from '../types.js';
+
+/** An opening or closing fence, with the language after an opening one. */
+export const CODE_FENCE_REGEX = /^ *(`{3,}|~{3,}) *(\w*?) *$/;
+
+export interface CodeBlock {
+  language: string | null;
+  code: string;
+}
+
+interface OpenBlock {
+  fence: string;
+  language: string | null;
+  lines: string[];
+}
+
+/**
+ * The fenced code blocks of a markdown text, found the way MarkdownDisplay
+ * finds them, so that the numbers shown match. A block still open at the
+ * end, as in a streaming answer, counts too.
+ */
+export function extractCodeBlocks(markdown: string): CodeBlock[] {
+  const blocks: CodeBlock[] = [];
+  let open: OpenBlock | null = null;
+  for (const line of markdown.split('\n')) {
+    const fence = line.match(CODE_FENCE_REGEX);
+    if (!open) {
+      if (fence) {
+        open = { fence: fence[1], language: fence[2] || null, lines: [] };
+      }
+    } else if (
+      fence &&
+      fence[1].startsWith(open.fence[0]) &&
+      fence[1].length >= open.fence.length
+    ) {
+      blocks.push({ language: open.language, code: open.lines.join('\n') });
+      open = null;
+    } else {
+      open.lines.push(line);
+    }
+  }
+  if (open) {
+    blocks.push({ language: open.language, code: open.lines.join('\n') });
+  }
+  return blocks;
+}
+
+const hasAnswerText = (
+  item: HistoryItemWithoutId,
+): item is Extract<
+  HistoryItemWithoutId,
+  { type: 'research' | 'research_content' }
+> => item.type === 'research' || item.type === 'research_content';
+
+/**
+ * The text of the answer that continues at `end`: its earlier pieces run
+ * back to the 'research' item they were split from. Joining them keeps a
+ * block split across two pieces from being counted twice.
+ */
+function answerTextBefore(
+  history: HistoryItemWithoutId[],
+  end: number,
+): string {
+  let start = end;
+  while (start > 0 && history[start - 1].type === 'research_content') {
+    start--;
+  }
+  if (start > 0 && history[start - 1].type === 'research') {
+    start--;
+  }
+  return history
+    .slice(start, end)
+    .map((item) => (hasAnswerText(item) ? item.text : ''))
+    .join('');
+}
+
+/** How many code blocks start in `text`, after the answer text `before`. */
+function countNewBlocks(before: string, text: string): number {
+  return (
+    extractCodeBlocks(before + text).length - extractCodeBlocks(before).length
+  );
+}
+
+/** The pieces of the answer before history item `index`, if it continues one. */
+function textBefore(history: HistoryItemWithoutId[], index: number): string {
+  return history[index].type === 'research_content'
+    ? answerTextBefore(history, index)
+    : '';
+}
+
+/** The number the next code block of the session gets. */
+export function nextCodeBlockNumber(history: HistoryItemWithoutId[]): number {
+  for (let i = history.length - 1; i >= 0; i--) {
+    const item = history[i];
+    if (hasAnswerText(item) && item.firstCodeBlock !== undefined) {
+      return (
+        item.firstCodeBlock + countNewBlocks(textBefore(history, i), item.text)
+      );
+    }
+  }
+  return 1;
+}
+
+/**
+ * Gives an answer with code blocks the number of the first one, following
+ * on from the answers before it. A piece that continues an answer is counted
+ * with the pieces before it. Other items are returned as they are.
+ */
+export function numberCodeBlocks<T extends HistoryItemWithoutId>(
+  item: T,
+  history: HistoryItemWithoutId[],
+): T {
+  if (!hasAnswerText(item)) {
+    return item;
+  }
+  const before =
+    item.type === 'research_content'
+      ? answerTextBefore(history, history.length)
+      : '';
+  return countNewBlocks(before, item.text) > 0
+    ? ({ ...item, firstCodeBlock: nextCodeBlockNumber(history) } as T)
+    : item;
+}
+
+/** Code block `number` of the session, if its answer is still in memory. */
+export function findCodeBlock(
+  history: HistoryItem[],
+  number: number,
+): CodeBlock | undefined {
+  for (let i = 0; i < history.length; i++) {
+    const item = history[i];
+    if (!hasAnswerText(item) || item.firstCodeBlock === undefined) {
+      continue;
+    }
+    const offset = number - item.firstCodeBlock;
+    const before = textBefore(history, i);
+    if (offset >= 0 && offset < countNewBlocks(before, item.text)) {
+      // The block may run on into the pieces after this one
+      let end = i + 1;
+      while (history[end]?.type === 'research_content') {
+        end++;
+      }
+      const blocks = extractCodeBlocks(answerTextBefore(history, end));
+      return blocks[extractCodeBlocks(before).length + offset];
+    }
+  }
+  return undefined;
+}
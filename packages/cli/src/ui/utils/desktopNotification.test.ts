@@ -0,0 +1,50 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect } from 'vitest';
+import {
+  getNotificationBody,
+  getNotificationCommand,
+  isFocusReport,
+} from './desktopNotification.js';
+
+describe('desktopNotification', () => {
+  it('should use the first line of the answer as the body', () => {
+    expect(getNotificationBody('\n## Results\n\nWe reach 91%.')).toBe(
+      'Results',
+    );
+    expect(getNotificationBody('x'.repeat(200))).toHaveLength(120);
+    expect(getNotificationBody('')).toBe('');
+  });
+
+  it('should use notify-send on Linux', () => {
+    expect(getNotificationCommand('linux', 'thesis', '-rf done')).toEqual({
+      command: 'notify-send',
+      args: ['--', 'thesis', '-rf done'],
+    });
+  });
+
+  it('should quote the text for osascript and PowerShell', () => {
+    const mac = getNotificationCommand('darwin', 'My "draft"', 'a\\b');
+    expect(mac?.args[1]).toBe(
+      'display notification "a\\\\b" with title "My \\"draft\\""',
+    );
+
+    const windows = getNotificationCommand('win32', "Ann's notes", 'done');
+    expect(windows?.command).toBe('powershell.exe');
+    expect(windows?.args[3]).toContain("CreateTextNode('Ann''s notes')");
+  });
+
+  it('should have no notifier on other platforms', () => {
+    expect(getNotificationCommand('aix', 't', 'b')).toBeUndefined();
+  });
+
+  it('should recognize focus reports', () => {
+    expect(isFocusReport('\x1b[I')).toBe(true);
+    expect(isFocusReport('\x1b[O')).toBe(true);
+    expect(isFocusReport('I')).toBe(false);
+  });
+});
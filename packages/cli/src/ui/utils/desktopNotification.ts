@@ -0,0 +1,105 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { spawn } from 'node:child_process';
+
+export const DEFAULT_NOTIFY_AFTER_SECONDS = 30;
+const MAX_BODY_LENGTH = 120;
+
+/** Asks the terminal to report focus changes as FOCUS_IN and FOCUS_OUT. */
+export const ENABLE_FOCUS_REPORTING = '\x1b[?1004h';
+export const DISABLE_FOCUS_REPORTING = '\x1b[?1004l';
+export const FOCUS_IN = '\x1b[I';
+export const FOCUS_OUT = '\x1b[O';
+
+export function isFocusReport(sequence: string): boolean {
+  return sequence === FOCUS_IN || sequence === FOCUS_OUT;
+}
+
+/** The first non-empty line of an answer, short enough for a notification. */
+export function getNotificationBody(text: string): string {
+  const line =
+    text
+      .split('\n')
+      .map((l) => l.replace(/^[#>*\-\s]+/, '').trim())
+      .find(Boolean) ?? '';
+  return line.length > MAX_BODY_LENGTH
+    ? `${line.slice(0, MAX_BODY_LENGTH - 1)}…`
+    : line;
+}
+
+function quoteAppleScript(text: string): string {
+  return `"${text.replace(/\\/g, '\\\\').replace(/"/g, '\\"')}"`;
+}
+
+function quotePowerShell(text: string): string {
+  return `'${text.replace(/'/g, "''")}'`;
+}
+
+/**
+ * The command that shows a notification on this platform: notify-send on
+ * Linux, osascript on macOS and a toast through PowerShell on Windows.
+ */
+export function getNotificationCommand(
+  platform: NodeJS.Platform,
+  title: string,
+  body: string,
+): { command: string; args: string[] } | undefined {
+  switch (platform) {
+    case 'linux':
+    case 'freebsd':
+    case 'openbsd':
+      return { command: 'notify-send', args: ['--', title, body] };
+    case 'darwin':
+      return {
+        command: 'osascript',
+        args: [
+          '-e',
+          `display notification ${quoteAppleScript(body)} with title ${quoteAppleScript(title)}`,
+        ],
+      };
+    case 'win32': {
+      const script = [
+        '[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] | Out-Null',
+        '$template = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)',
+        '$texts = $template.GetElementsByTagName("text")',
+        `$texts.Item(0).AppendChild($template.CreateTextNode(${quotePowerShell(title)})) | Out-Null`,
+        `$texts.Item(1).AppendChild($template.CreateTextNode(${quotePowerShell(body)})) | Out-Null`,
+        '$toast = [Windows.UI.Notifications.ToastNotification]::new($template)',
+        '[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier("Research CLI").Show($toast)',
+      ].join('; ');
+      return {
+        command: 'powershell.exe',
+        args: ['-NoProfile', '-NonInteractive', '-Command', script],
+      };
+    }
+    default:
+      return undefined;
+  }
+}
+
+/**
+ * Shows a desktop notification without waiting for it. A missing
+ * notifier only means no notification.
+ */
+export function sendDesktopNotification(title: string, body: string): void {
+  const notifier = getNotificationCommand(process.platform, title, body);
+  if (!notifier) {
+    return;
+  }
+  try {
+    const child = spawn(notifier.command, notifier.args, {
+      stdio: 'ignore',
+      detached: true,
+    });
+    child.on('error', (e) =>
+      console.debug('Could not show a desktop notification:', e),
+    );
+    child.unref();
+  } catch (e) {
+    console.debug('Could not show a desktop notification:', e);
+  }
+}
@@ -0,0 +1,130 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect } from 'vitest';
+import { SessionMetrics } from '@iechor/research-cli-core';
+import {
+  formatExchangeInfo,
+  getAttachments,
+  getTokenUsage,
+  subtractTokenUsage,
+} from './exchangeInfo.js';
+
+const model = (prompt: number, candidates: number, totalRequests: number) => ({
+  api: { totalRequests, totalErrors: 0, totalLatencyMs: 0 },
+  tokens: {
+    prompt,
+    candidates,
+    total: prompt + candidates,
+    cached: 0,
+    thoughts: 0,
+    tool: 0,
+  },
+});
+
+describe('getTokenUsage', () => {
+  it('adds up the tokens and requests of every model', () => {
+    const metrics = {
+      models: { pro: model(1000, 200, 2), flash: model(300, 50, 1) },
+    } as unknown as SessionMetrics;
+
+    expect(getTokenUsage(metrics)).toEqual({
+      promptTokens: 1300,
+      completionTokens: 250,
+      requests: 3,
+    });
+  });
+
+  it('gives the usage between two readings', () => {
+    expect(
+      subtractTokenUsage(
+        { promptTokens: 1300, completionTokens: 250, requests: 3 },
+        { promptTokens: 1000, completionTokens: 200, requests: 2 },
+      ),
+    ).toEqual({ promptTokens: 300, completionTokens: 50, requests: 1 });
+  });
+});
+
+describe('getAttachments', () => {
+  it('lists the @ files and the attachments without a path', () => {
+    expect(
+      getAttachments([
+        { text: 'Compare @a.csv with the chart' },
+        { text: '\n--- Content from referenced files ---' },
+        { text: '\nContent from @data/a.csv:\n' },
+        { text: 'x,y' },
+        { inlineData: { mimeType: 'image/png', data: '' } },
+        { text: '\n--- End of content ---' },
+      ]),
+    ).toEqual(['data/a.csv', 'image/png']);
+  });
+
+  it('finds nothing in a plain prompt', () => {
+    expect(getAttachments('Hello')).toEqual([]);
+  });
+});
+
+describe('formatExchangeInfo', () => {
+  it('summarizes the exchange on one line', () => {
+    expect(
+      formatExchangeInfo({
+        type: 'exchange_info',
+        promptTokens: 12345,
+        completionTokens: 678,
+        requests: 2,
+        attachments: ['data/a.csv'],
+        memoryFileCount: 1,
+      }),
+    ).toBe(
+      '12,345 prompt + 678 completion tokens in 2 requests · attached: data/a.csv · memory: 1 file',
+    );
+  });
+
+  it('says when nothing was attached', () => {
+    expect(
+      formatExchangeInfo({
+        type: 'exchange_info',
+        promptTokens: 10,
+        completionTokens: 5,
+        requests: 1,
+        attachments: [],
+        memoryFileCount: 0,
+      }),
+    ).toBe(
+      '10 prompt + 5 completion tokens in 1 request · nothing attached · no memory files',
+    );
+  });
+
+  it('gives the seed when one was set', () => {
+    expect(
+      formatExchangeInfo({
+        type: 'exchange_info',
+        promptTokens: 10,
+        completionTokens: 5,
+        requests: 1,
+        attachments: [],
+        memoryFileCount: 0,
+        seed: 42,
+      }),
+    ).toContain('no memory files · seed: 42');
+  });
+
+  it('gives the models the exchange was routed to', () => {
+    expect(
+      formatExchangeInfo({
+        type: 'exchange_info',
+        promptTokens: 10,
+        completionTokens: 5,
+        requests: 2,
+        attachments: [],
+        memoryFileCount: 0,
+        routes: ['gpt-4o-mini (short prompt)', 'gpt-4o (tool use)'],
+      }),
+    ).toContain(
+      'no memory files · routed to gpt-4o-mini (short prompt), then gpt-4o (tool use)',
+    );
+  });
+});
@@ -0,0 +1,90 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { Part, PartListUnion } from '@google/genai';
+import { SessionMetrics } from '@iechor/research-cli-core';
+import { HistoryItemExchangeInfo } from '../types.js';
+
+/** Token counts of the session so far, over every model. */
+export interface TokenUsage {
+  promptTokens: number;
+  completionTokens: number;
+  requests: number;
+}
+
+// The marker atCommandProcessor puts before the content of each @ file
+const ATTACHED_FILE_MARKER = /^\nContent from @(.+):\n$/;
+
+export function getTokenUsage(metrics: SessionMetrics): TokenUsage {
+  const usage: TokenUsage = {
+    promptTokens: 0,
+    completionTokens: 0,
+    requests: 0,
+  };
+  for (const model of Object.values(metrics.models)) {
+    usage.promptTokens += model.tokens.prompt;
+    usage.completionTokens += model.tokens.candidates;
+    usage.requests += model.api.totalRequests;
+  }
+  return usage;
+}
+
+/** The usage between two readings of getTokenUsage. */
+export function subtractTokenUsage(
+  after: TokenUsage,
+  before: TokenUsage,
+): TokenUsage {
+  return {
+    promptTokens: after.promptTokens - before.promptTokens,
+    completionTokens: after.completionTokens - before.completionTokens,
+    requests: after.requests - before.requests,
+  };
+}
+
+/**
+ * What was attached to a prompt as sent: the files read for its @ paths,
+ * and images and other files that have no path.
+ */
+export function getAttachments(query: PartListUnion): string[] {
+  const parts: Part[] = (Array.isArray(query) ? query : [query]).map(
+    (part) => (typeof part === 'string' ? { text: part } : part),
+  );
+  const attachments: string[] = [];
+  for (const part of parts) {
+    const file = part.text?.match(ATTACHED_FILE_MARKER);
+    if (file) {
+      attachments.push(file[1]);
+    } else if (part.inlineData) {
+      attachments.push(part.inlineData.mimeType ?? 'binary data');
+    } else if (part.fileData?.fileUri) {
+      attachments.push(part.fileData.fileUri);
+    }
+  }
+  return attachments;
+}
+
+const plural = (count: number, noun: string) =>
+  `${count.toLocaleString('en-US')} ${noun}${count === 1 ? '' : 's'}`;
+
+/**
+ * The one-line summary of an exchange: its tokens over all the requests
+ * it took, including those sending tool results, what was attached, how
+ * many memory files every request carried, the seed, if one was set, and
+ * the models it was routed to.
+ */
+export function formatExchangeInfo(item: HistoryItemExchangeInfo): string {
+  return [
+    `${item.promptTokens.toLocaleString('en-US')} prompt + ${item.completionTokens.toLocaleString('en-US')} completion tokens in ${plural(item.requests, 'request')}`,
+    item.attachments.length > 0
+      ? `attached: ${item.attachments.join(', ')}`
+      : 'nothing attached',
+    item.memoryFileCount > 0
+      ? `memory: ${plural(item.memoryFileCount, 'file')}`
+      : 'no memory files',
+    ...(item.seed !== undefined ? [`seed: ${item.seed}`] : []),
+    ...(item.routes ? [`routed to ${item.routes.join(', then ')}`] : []),
+  ].join(' · ');
+}
@@ -0,0 +1,72 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach } from 'vitest';
+import {
+  FocusTimer,
+  formatFocusInterval,
+  formatFocusStatus,
+} from './focusTimer.js';
+
+const MINUTE = 60 * 1000;
+
+describe('FocusTimer', () => {
+  let timer: FocusTimer;
+
+  beforeEach(() => {
+    timer = new FocusTimer();
+  });
+
+  it('should not show in the status bar until started', () => {
+    expect(formatFocusStatus(timer, 0)).toBeUndefined();
+    expect(timer.tick(0)).toBeUndefined();
+  });
+
+  it('should count down and start a break when the interval is over', () => {
+    timer.start(0, 25, 5, 100);
+    timer.recordMessage();
+    timer.recordMessage();
+    expect(formatFocusStatus(timer, 90 * 1000)).toBe('🍅 focus 23:30');
+    expect(timer.tick(24 * MINUTE)).toBeUndefined();
+
+    expect(timer.tick(25 * MINUTE)).toEqual({
+      type: 'break',
+      interval: { startedAt: 0, minutes: 25, messages: 2 },
+      breakMinutes: 5,
+    });
+    expect(formatFocusStatus(timer, 25 * MINUTE)).toBe('☕ break 5:00');
+
+    const [interval] = timer.getIntervals();
+    timer.countWords(interval, 340);
+    expect(formatFocusInterval(interval)).toBe(
+      '25 min, 2 messages, 240 words written to notes',
+    );
+
+    expect(timer.tick(30 * MINUTE)).toEqual({ type: 'breakOver' });
+    expect(timer.getPhase()).toBe('idle');
+  });
+
+  it('should stand still while paused', () => {
+    timer.start(0, 10, 2);
+    expect(timer.pause(4 * MINUTE)).toBe(true);
+    timer.recordMessage();
+
+    expect(timer.tick(60 * MINUTE)).toBeUndefined();
+    expect(formatFocusStatus(timer, 60 * MINUTE)).toBe('⏸ focus 6:00');
+    expect(timer.resume(60 * MINUTE)).toBe(true);
+    expect(timer.tick(66 * MINUTE)?.type).toBe('break');
+    expect(timer.getIntervals()[0].messages).toBe(0);
+  });
+
+  it('should not record an interval that was stopped early', () => {
+    timer.start(0);
+    timer.stop();
+
+    expect(timer.tick(60 * MINUTE)).toBeUndefined();
+    expect(timer.getIntervals()).toEqual([]);
+    expect(timer.pause(0)).toBe(false);
+  });
+});
@@ -0,0 +1,212 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import {
+  computeTextMetrics,
+  isNodeError,
+  loadOutline,
+} from '@iechor/research-cli-core';
+
+export const DEFAULT_FOCUS_MINUTES = 25;
+export const DEFAULT_BREAK_MINUTES = 5;
+const MINUTE_MS = 60 * 1000;
+
+/** A finished focus interval. */
+export interface FocusInterval {
+  startedAt: number;
+  minutes: number;
+  /** Messages sent to the model during the interval. */
+  messages: number;
+  /** Words added to the notes file; undefined until they are counted. */
+  words?: number;
+}
+
+export type FocusPhase = 'idle' | 'focus' | 'break';
+
+/** What `tick` reports when a phase runs out. */
+export type FocusEvent =
+  | { type: 'break'; interval: FocusInterval; breakMinutes: number }
+  | { type: 'breakOver' };
+
+/**
+ * A pomodoro timer: focus for a number of minutes, then take a break.
+ * Time is passed in, so the timer can be driven by a clock or by tests.
+ */
+export class FocusTimer {
+  private phase: FocusPhase = 'idle';
+  /** When the phase ends; undefined while paused. */
+  private endsAt: number | undefined;
+  /** Time left in the phase when it was paused. */
+  private pausedRemaining: number | undefined;
+  private focusMinutes = DEFAULT_FOCUS_MINUTES;
+  private breakMinutes = DEFAULT_BREAK_MINUTES;
+  private current: { startedAt: number; messages: number; words?: number } = {
+    startedAt: 0,
+    messages: 0,
+  };
+  private readonly intervals: FocusInterval[] = [];
+
+  getPhase(): FocusPhase {
+    return this.phase;
+  }
+
+  isPaused(): boolean {
+    return this.pausedRemaining !== undefined;
+  }
+
+  /** Milliseconds left in the current phase. */
+  getRemaining(now: number): number {
+    if (this.phase === 'idle') {
+      return 0;
+    }
+    return this.pausedRemaining ?? Math.max(0, this.endsAt! - now);
+  }
+
+  getIntervals(): readonly FocusInterval[] {
+    return this.intervals;
+  }
+
+  /** Messages sent so far in the running focus interval. */
+  getCurrentMessages(): number {
+    return this.phase === 'focus' ? this.current.messages : 0;
+  }
+
+  /**
+   * Starts a focus interval, ending a break early if one is running.
+   * `notesWords` is the size of the notes file now, to count the words
+   * written during the interval.
+   */
+  start(
+    now: number,
+    focusMinutes = DEFAULT_FOCUS_MINUTES,
+    breakMinutes = DEFAULT_BREAK_MINUTES,
+    notesWords?: number,
+  ): void {
+    this.phase = 'focus';
+    this.focusMinutes = focusMinutes;
+    this.breakMinutes = breakMinutes;
+    this.endsAt = now + focusMinutes * MINUTE_MS;
+    this.pausedRemaining = undefined;
+    this.current = { startedAt: now, messages: 0, words: notesWords };
+  }
+
+  pause(now: number): boolean {
+    if (this.phase === 'idle' || this.isPaused()) {
+      return false;
+    }
+    this.pausedRemaining = this.getRemaining(now);
+    this.endsAt = undefined;
+    return true;
+  }
+
+  resume(now: number): boolean {
+    if (!this.isPaused()) {
+      return false;
+    }
+    this.endsAt = now + this.pausedRemaining!;
+    this.pausedRemaining = undefined;
+    return true;
+  }
+
+  /** Stops the timer; a focus interval cut short is not recorded. */
+  stop(): void {
+    this.phase = 'idle';
+    this.endsAt = undefined;
+    this.pausedRemaining = undefined;
+  }
+
+  recordMessage(): void {
+    if (this.phase === 'focus' && !this.isPaused()) {
+      this.current.messages++;
+    }
+  }
+
+  /** Moves to the next phase when the current one has run out. */
+  tick(now: number): FocusEvent | undefined {
+    if (this.isPaused() || this.phase === 'idle' || now < this.endsAt!) {
+      return undefined;
+    }
+    if (this.phase === 'break') {
+      this.stop();
+      return { type: 'breakOver' };
+    }
+    const interval: FocusInterval = {
+      startedAt: this.current.startedAt,
+      minutes: this.focusMinutes,
+      messages: this.current.messages,
+    };
+    this.intervals.push(interval);
+    this.phase = 'break';
+    this.endsAt = now + this.breakMinutes * MINUTE_MS;
+    return { type: 'break', interval, breakMinutes: this.breakMinutes };
+  }
+
+  /** Records the words written in `interval` from the notes size now. */
+  countWords(interval: FocusInterval, notesWords: number | undefined): void {
+    const startWords = this.current.words;
+    if (notesWords !== undefined && startWords !== undefined) {
+      interval.words = Math.max(0, notesWords - startWords);
+    }
+  }
+}
+
+let focusTimer: FocusTimer | undefined;
+
+/** The focus timer of this session. */
+export function getFocusTimer(): FocusTimer {
+  if (!focusTimer) {
+    focusTimer = new FocusTimer();
+  }
+  return focusTimer;
+}
+
+/** "mm:ss" for the status bar. */
+export function formatFocusTime(ms: number): string {
+  const seconds = Math.ceil(ms / 1000);
+  return `${Math.floor(seconds / 60)}:${String(seconds % 60).padStart(2, '0')}`;
+}
+
+/** The status bar text of the timer, or undefined when it is not running. */
+export function formatFocusStatus(
+  timer: FocusTimer,
+  now: number,
+): string | undefined {
+  const phase = timer.getPhase();
+  if (phase === 'idle') {
+    return undefined;
+  }
+  const icon = timer.isPaused() ? '⏸' : phase === 'focus' ? '🍅' : '☕';
+  const label = phase === 'focus' ? 'focus' : 'break';
+  return `${icon} ${label} ${formatFocusTime(timer.getRemaining(now))}`;
+}
+
+export function formatFocusInterval(interval: FocusInterval): string {
+  const messages = `${interval.messages} message${interval.messages === 1 ? '' : 's'}`;
+  return interval.words === undefined
+    ? `${interval.minutes} min, ${messages}`
+    : `${interval.minutes} min, ${messages}, ${interval.words} words written to notes`;
+}
+
+/**
+ * Words in the notes file drafts from /outline go into; undefined when
+ * it cannot be read.
+ */
+export async function countNotesWords(
+  projectRoot: string,
+): Promise<number | undefined> {
+  try {
+    const { notesFile } = await loadOutline(projectRoot);
+    const text = await fs.promises.readFile(
+      path.resolve(projectRoot, notesFile),
+      'utf8',
+    );
+    return computeTextMetrics(text).words;
+  } catch (error) {
+    return isNodeError(error) && error.code === 'ENOENT' ? 0 : undefined;
+  }
+}
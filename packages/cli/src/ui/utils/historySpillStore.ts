@@ -0,0 +1,71 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import { HistoryItem } from '../types.js';
+
+/**
+ * Append-only JSONL file holding history items that were evicted from
+ * memory. Items keep their position, so a range can be read back when the
+ * user pages up. Only byte offsets are kept in memory.
+ */
+export class HistorySpillStore {
+  private offsets: number[] = [];
+  private size = 0;
+
+  constructor(readonly filePath: string) {}
+
+  /** Number of items written to disk. */
+  count(): number {
+    return this.offsets.length;
+  }
+
+  append(items: HistoryItem[]): void {
+    if (items.length === 0) {
+      return;
+    }
+    if (this.offsets.length === 0) {
+      fs.mkdirSync(path.dirname(this.filePath), { recursive: true });
+    }
+    let chunk = '';
+    for (const item of items) {
+      this.offsets.push(this.size + Buffer.byteLength(chunk));
+      chunk += JSON.stringify(item) + '\n';
+    }
+    fs.appendFileSync(this.filePath, chunk);
+    this.size += Buffer.byteLength(chunk);
+  }
+
+  /** Reads items [start, end) in their original order. */
+  read(start: number, end: number): HistoryItem[] {
+    start = Math.max(0, start);
+    end = Math.min(end, this.offsets.length);
+    if (start >= end) {
+      return [];
+    }
+    const from = this.offsets[start];
+    const to = end < this.offsets.length ? this.offsets[end] : this.size;
+    const buffer = Buffer.alloc(to - from);
+    const fd = fs.openSync(this.filePath, 'r');
+    try {
+      fs.readSync(fd, buffer, 0, buffer.length, from);
+    } finally {
+      fs.closeSync(fd);
+    }
+    return buffer
+      .toString('utf8')
+      .split('\n')
+      .filter((line) => line.length > 0)
+      .map((line) => JSON.parse(line) as HistoryItem);
+  }
+
+  clear(): void {
+    this.offsets = [];
+    this.size = 0;
+    fs.rmSync(this.filePath, { force: true });
+  }
+}
@@ -0,0 +1,83 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect } from 'vitest';
+import { ImeCursor, isImeModeEnabled } from './imeCursor.js';
+import { RecordedStream } from './sessionRecorder.js';
+
+function createStream() {
+  const written: string[] = [];
+  const stream = {
+    columns: 80,
+    rows: 24,
+    write: (chunk: string) => {
+      written.push(chunk);
+      return true;
+    },
+    on: () => stream,
+    off: () => stream,
+  };
+  return { stream: stream as unknown as RecordedStream, written };
+}
+
+describe('isImeModeEnabled', () => {
+  it('should follow the setting', () => {
+    expect(isImeModeEnabled(true, {})).toBe(true);
+    expect(isImeModeEnabled(false, { LANG: 'zh_CN.UTF-8' })).toBe(false);
+  });
+
+  it('should default to on for CJK locales', () => {
+    expect(isImeModeEnabled(undefined, { LANG: 'zh_CN.UTF-8' })).toBe(true);
+    expect(isImeModeEnabled(undefined, { LC_ALL: 'ja_JP.UTF-8' })).toBe(true);
+    expect(isImeModeEnabled(undefined, { LANG: 'ko' })).toBe(true);
+    expect(isImeModeEnabled(undefined, { LANG: 'en_US.UTF-8' })).toBe(false);
+    expect(isImeModeEnabled(undefined, {})).toBe(false);
+  });
+});
+
+describe('ImeCursor', () => {
+  it('should park the cursor after a frame and return it before the next', () => {
+    const { stream, written } = createStream();
+    const cursor = new ImeCursor();
+    cursor.attach(stream);
+    cursor.setLocator(() => ({ rowsUp: 3, column: 6 }));
+
+    stream.write('frame');
+
+    expect(written).toEqual([
+      '\u001B[3A\u001B[7G\u001B[?25h',
+      '\u001B[?25l\u001B[3B\r',
+      'frame',
+      '\u001B[3A\u001B[7G\u001B[?25h',
+    ]);
+  });
+
+  it('should ask for the position after every write', () => {
+    const { stream, written } = createStream();
+    const cursor = new ImeCursor();
+    cursor.attach(stream);
+    let rowsUp = 2;
+    cursor.setLocator(() => ({ rowsUp, column: 0 }));
+    rowsUp = 4;
+
+    stream.write('taller frame');
+
+    expect(written[written.length - 1]).toBe('\u001B[4A\u001B[1G\u001B[?25h');
+  });
+
+  it('should stay within the screen and leave the cursor alone when off', () => {
+    const { stream, written } = createStream();
+    const cursor = new ImeCursor();
+    cursor.attach(stream);
+    cursor.setLocator(() => ({ rowsUp: 100, column: 2 }));
+    expect(written).toEqual(['\u001B[23A\u001B[3G\u001B[?25h']);
+
+    cursor.setLocator(undefined);
+    stream.write('frame');
+
+    expect(written.slice(1)).toEqual(['\u001B[?25l\u001B[23B\r', 'frame']);
+  });
+});
@@ -0,0 +1,123 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { RecordedStream } from './sessionRecorder.js';
+
+/** Where the input cursor is, counted from the end of the last frame. */
+export interface ImeCursorTarget {
+  /** Rows above the line the terminal cursor is left on after a frame. */
+  rowsUp: number;
+  /** Zero-based terminal column. */
+  column: number;
+}
+
+const CSI = '\u001B[';
+const SHOW_CURSOR = `${CSI}?25h`;
+const HIDE_CURSOR = `${CSI}?25l`;
+
+/**
+ * Whether to use the composition mode: the `imeMode` setting, or by
+ * default a Chinese, Japanese or Korean locale, whose users type through
+ * an input method.
+ */
+export function isImeModeEnabled(
+  setting: boolean | undefined,
+  env: NodeJS.ProcessEnv = process.env,
+): boolean {
+  if (setting !== undefined) {
+    return setting;
+  }
+  const locale = env.LC_ALL || env.LC_CTYPE || env.LANG || '';
+  return /^(zh|ja|ko)([_.-]|$)/i.test(locale);
+}
+
+/**
+ * Moves the terminal cursor into the input box between frames. Input
+ * methods draw the text being composed at the terminal cursor, which the
+ * UI otherwise leaves hidden below its last line, where the composition
+ * pushes the layout out of place. The cursor is moved back before every
+ * write, so the UI finds it where it left it.
+ */
+export class ImeCursor {
+  private stream: RecordedStream | undefined;
+  private originalWrite: RecordedStream['write'] | undefined;
+  private locate: (() => ImeCursorTarget | undefined) | undefined;
+  /** Rows the cursor is currently moved up by, while parked. */
+  private parkedRowsUp: number | undefined;
+
+  attach(stream: RecordedStream): void {
+    if (this.stream) {
+      return;
+    }
+    const originalWrite = stream.write;
+    this.stream = stream;
+    this.originalWrite = originalWrite;
+    stream.write = ((chunk: string | Uint8Array, ...rest: unknown[]) => {
+      this.unpark();
+      const result = (originalWrite as (...args: unknown[]) => boolean).call(
+        stream,
+        chunk,
+        ...rest,
+      );
+      this.park();
+      return result;
+    }) as RecordedStream['write'];
+  }
+
+  /**
+   * Sets how to find where the cursor belongs, asked after every write so
+   * that it matches the frame just drawn; none keeps it below the UI.
+   */
+  setLocator(locate: (() => ImeCursorTarget | undefined) | undefined): void {
+    this.unpark();
+    this.locate = locate;
+    this.park();
+  }
+
+  private park(): void {
+    if (!this.stream || this.parkedRowsUp !== undefined) {
+      return;
+    }
+    const target = this.locate?.();
+    if (!target) {
+      return;
+    }
+    const maxRowsUp = Math.max((this.stream.rows ?? 24) - 1, 0);
+    const rowsUp = Math.min(Math.max(target.rowsUp, 0), maxRowsUp);
+    this.rawWrite(
+      (rowsUp > 0 ? `${CSI}${rowsUp}A` : '') +
+        `${CSI}${Math.max(target.column, 0) + 1}G${SHOW_CURSOR}`,
+    );
+    this.parkedRowsUp = rowsUp;
+  }
+
+  private unpark(): void {
+    if (this.parkedRowsUp === undefined) {
+      return;
+    }
+    const rowsUp = this.parkedRowsUp;
+    this.parkedRowsUp = undefined;
+    this.rawWrite(`${HIDE_CURSOR}${rowsUp > 0 ? `${CSI}${rowsUp}B` : ''}\r`);
+  }
+
+  private rawWrite(data: string): void {
+    if (this.stream && this.originalWrite) {
+      (this.originalWrite as (...args: unknown[]) => boolean).call(
+        this.stream,
+        data,
+      );
+    }
+  }
+}
+
+let imeCursor: ImeCursor | undefined;
+
+export function getImeCursor(): ImeCursor {
+  if (!imeCursor) {
+    imeCursor = new ImeCursor();
+  }
+  return imeCursor;
+}
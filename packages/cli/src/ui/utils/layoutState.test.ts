@@ -0,0 +1,78 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import {
+  getLayoutStatePath,
+  loadLayoutState,
+  saveLayoutState,
+} from './layoutState.js';
+
+describe('layoutState', () => {
+  let tempDir: string;
+  let filePath: string;
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'layout-state-'));
+    filePath = getLayoutStatePath(path.join(tempDir, 'project'));
+  });
+
+  afterEach(() => {
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should give the default layout when nothing was saved', () => {
+    expect(loadLayoutState(filePath)).toEqual({});
+  });
+
+  it('should restore a saved layout', async () => {
+    const state = {
+      outlineOpen: true,
+      consoleShown: false,
+      toolDescriptionsShown: true,
+      exchangeInfoShown: true,
+      zoomedPane: 'outline' as const,
+      outlineSelection: 'abc',
+    };
+
+    await saveLayoutState(filePath, state);
+
+    expect(loadLayoutState(filePath)).toEqual(state);
+  });
+
+  it('should keep the last of several quick saves', async () => {
+    saveLayoutState(filePath, { outlineOpen: true });
+    saveLayoutState(filePath, { outlineOpen: false });
+    await saveLayoutState(filePath, { consoleShown: true });
+
+    expect(loadLayoutState(filePath)).toEqual({ consoleShown: true });
+  });
+
+  it('should drop values it does not know', () => {
+    fs.mkdirSync(path.dirname(filePath), { recursive: true });
+    fs.writeFileSync(
+      filePath,
+      JSON.stringify({
+        outlineOpen: 'yes',
+        consoleShown: true,
+        zoomedPane: 'sidebar',
+        outlineSelection: 3,
+      }),
+    );
+
+    expect(loadLayoutState(filePath)).toEqual({ consoleShown: true });
+  });
+
+  it('should ignore a damaged file', () => {
+    fs.mkdirSync(path.dirname(filePath), { recursive: true });
+    fs.writeFileSync(filePath, '{"outlineOpen": tr');
+
+    expect(loadLayoutState(filePath)).toEqual({});
+  });
+});
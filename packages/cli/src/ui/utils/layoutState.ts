@@ -0,0 +1,92 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import { ZoomPane } from './zoom.js';
+
+const LAYOUT_STATE_FILE = 'layout.json';
+const ZOOM_PANES: readonly ZoomPane[] = [
+  'chat',
+  'tool output',
+  'console',
+  'outline',
+];
+
+/** The screen layout, restored when the terminal is opened again. */
+export interface LayoutState {
+  outlineOpen?: boolean;
+  consoleShown?: boolean;
+  toolDescriptionsShown?: boolean;
+  /** Token and context details under each exchange. */
+  exchangeInfoShown?: boolean;
+  zoomedPane?: ZoomPane;
+  /** Id of the section selected in the outline pane. */
+  outlineSelection?: string;
+}
+
+/** The layout is kept with the project's temporary files, not in the repo. */
+export function getLayoutStatePath(projectTempDir: string): string {
+  return path.join(projectTempDir, LAYOUT_STATE_FILE);
+}
+
+/**
+ * Reads the saved layout. It is read synchronously because the first
+ * frame is drawn from it; a missing or damaged file gives the default
+ * layout, and unknown values are dropped.
+ */
+export function loadLayoutState(filePath: string): LayoutState {
+  let raw: unknown;
+  try {
+    raw = JSON.parse(fs.readFileSync(filePath, 'utf8'));
+  } catch {
+    return {};
+  }
+  if (typeof raw !== 'object' || raw === null) {
+    return {};
+  }
+  const saved = raw as Record<string, unknown>;
+  const state: LayoutState = {};
+  for (const key of [
+    'outlineOpen',
+    'consoleShown',
+    'toolDescriptionsShown',
+    'exchangeInfoShown',
+  ] as const) {
+    if (typeof saved[key] === 'boolean') {
+      state[key] = saved[key] as boolean;
+    }
+  }
+  if (ZOOM_PANES.includes(saved.zoomedPane as ZoomPane)) {
+    state.zoomedPane = saved.zoomedPane as ZoomPane;
+  }
+  if (typeof saved.outlineSelection === 'string') {
+    state.outlineSelection = saved.outlineSelection;
+  }
+  return state;
+}
+
+let pendingWrite: Promise<void> = Promise.resolve();
+
+/**
+ * Saves the layout. Writes are queued so a quick run of changes cannot
+ * land out of order; a failed write only loses the layout.
+ */
+export function saveLayoutState(
+  filePath: string,
+  state: LayoutState,
+): Promise<void> {
+  const content = JSON.stringify(state, null, 2);
+  pendingWrite = pendingWrite.then(async () => {
+    try {
+      await fs.promises.mkdir(path.dirname(filePath), { recursive: true });
+      await fs.promises.writeFile(filePath, content);
+    } catch {
+      // The default layout is used next time
+    }
+  });
+  return pendingWrite;
+}
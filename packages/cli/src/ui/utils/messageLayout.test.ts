@@ -0,0 +1,52 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect } from 'vitest';
+import {
+  DEFAULT_MESSAGE_LAYOUT,
+  getRoleLabel,
+  resolveMessageLayout,
+} from './messageLayout.js';
+
+describe('resolveMessageLayout', () => {
+  it('should keep the classic layout without settings', () => {
+    expect(resolveMessageLayout(undefined)).toEqual({
+      ...DEFAULT_MESSAGE_LAYOUT,
+      spacing: undefined,
+    });
+  });
+
+  it('should remove the spacing in compact mode unless asked for', () => {
+    expect(resolveMessageLayout({ compact: true }).spacing).toBe(0);
+    expect(resolveMessageLayout({ compact: true, spacing: 1 }).spacing).toBe(
+      1,
+    );
+  });
+
+  it('should keep the spacing within bounds', () => {
+    expect(resolveMessageLayout({ spacing: -2 }).spacing).toBe(0);
+    expect(resolveMessageLayout({ spacing: 1.7 }).spacing).toBe(1);
+    expect(resolveMessageLayout({ spacing: 10 }).spacing).toBe(3);
+  });
+
+  it('should fall back to symbols for unknown label styles', () => {
+    expect(resolveMessageLayout({ roleLabels: 'initials' }).roleLabels).toBe(
+      'initials',
+    );
+    expect(resolveMessageLayout({ roleLabels: 'emoji' }).roleLabels).toBe(
+      'symbols',
+    );
+  });
+});
+
+describe('getRoleLabel', () => {
+  it('should label each role in every style', () => {
+    expect(getRoleLabel('user', 'symbols')).toBe('> ');
+    expect(getRoleLabel('research', 'names')).toBe('Research: ');
+    expect(getRoleLabel('error', 'initials')).toBe('E ');
+    expect(getRoleLabel('info', 'colors')).toBe('▍ ');
+  });
+});
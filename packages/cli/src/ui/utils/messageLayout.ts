@@ -0,0 +1,105 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import {
+  MessageDisplaySettings,
+  ModelStyleSettings,
+} from '../../config/settings.js';
+
+/**
+ * How the author of a message is shown: the classic symbols, full names,
+ * initials, or only a bar in the color of the role.
+ */
+export type RoleLabelStyle = 'symbols' | 'names' | 'initials' | 'colors';
+
+export type MessageRole = 'user' | 'research' | 'info' | 'error' | 'shell';
+
+export interface MessageLayout {
+  /**
+   * Blank lines between messages. Undefined keeps the classic spacing,
+   * where each kind of message has its own margins.
+   */
+  spacing?: number;
+  roleLabels: RoleLabelStyle;
+  /** Drops borders and padding around messages. */
+  compact: boolean;
+  /** Colors and prefixes of answers by model; see resolveModelStyle. */
+  modelStyles?: ModelStyleSettings;
+}
+
+export const DEFAULT_MESSAGE_LAYOUT: MessageLayout = {
+  roleLabels: 'symbols',
+  compact: false,
+};
+
+export const ROLE_LABEL_STYLES: readonly RoleLabelStyle[] = [
+  'symbols',
+  'names',
+  'initials',
+  'colors',
+];
+
+const MAX_SPACING = 3;
+
+const ROLE_LABELS: Record<RoleLabelStyle, Record<MessageRole, string>> = {
+  symbols: {
+    user: '> ',
+    research: '✦ ',
+    info: 'ℹ ',
+    error: '✕ ',
+    shell: '$ ',
+  },
+  names: {
+    user: 'You: ',
+    research: 'Research: ',
+    info: 'Info: ',
+    error: 'Error: ',
+    shell: 'Shell: ',
+  },
+  initials: {
+    user: 'Y ',
+    research: 'R ',
+    info: 'I ',
+    error: 'E ',
+    shell: 'S ',
+  },
+  colors: {
+    user: '▍ ',
+    research: '▍ ',
+    info: '▍ ',
+    error: '▍ ',
+    shell: '▍ ',
+  },
+};
+
+/**
+ * The message layout from the `messages` settings. Compact mode has no
+ * blank lines between messages unless `spacing` asks for them; unknown
+ * label styles fall back to symbols.
+ */
+export function resolveMessageLayout(
+  settings: MessageDisplaySettings | undefined,
+  modelStyles?: ModelStyleSettings,
+): MessageLayout {
+  const compact = settings?.compact ?? false;
+  const spacing = settings?.spacing ?? (compact ? 0 : undefined);
+  const roleLabels =
+    ROLE_LABEL_STYLES.find((style) => style === settings?.roleLabels) ??
+    'symbols';
+  return {
+    spacing:
+      spacing === undefined
+        ? undefined
+        : Math.min(Math.max(Math.floor(spacing), 0), MAX_SPACING),
+    roleLabels,
+    compact,
+    modelStyles,
+  };
+}
+
+export function getRoleLabel(role: MessageRole, style: RoleLabelStyle): string {
+  return ROLE_LABELS[style][role];
+}
@@ -0,0 +1,85 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect } from 'vitest';
+import { ModelRoutingSettings } from '../../config/settings.js';
+import {
+  RoutingRequest,
+  getPromptLength,
+  routeRequest,
+} from './modelRouter.js';
+
+const SETTINGS: ModelRoutingSettings = {
+  enabled: true,
+  cheapModel: 'gpt-4o-mini',
+  premiumModel: 'gpt-4o',
+};
+
+const request = (extra: Partial<RoutingRequest> = {}): RoutingRequest => ({
+  promptLength: 40,
+  contextTokens: 1000,
+  hasAttachments: false,
+  isToolContinuation: false,
+  ...extra,
+});
+
+describe('routeRequest', () => {
+  it('should send a short prompt to the cheap model', () => {
+    expect(routeRequest(request(), SETTINGS, undefined)).toEqual({
+      model: 'gpt-4o-mini',
+      reason: 'short prompt',
+    });
+  });
+
+  it.each([
+    [{ isToolContinuation: true }, 'tool use'],
+    [{ contextTokens: 20000 }, 'long context, 20,010 tokens'],
+    [{ promptLength: 800 }, 'long prompt'],
+    [{ hasAttachments: true }, 'attachments'],
+  ])('should escalate %j', (extra, reason) => {
+    expect(routeRequest(request(extra), SETTINGS, undefined)).toEqual({
+      model: 'gpt-4o',
+      reason,
+    });
+  });
+
+  it('should take the limits from the settings', () => {
+    const settings = { ...SETTINGS, maxCheapPromptLength: 2000 };
+    expect(
+      routeRequest(request({ promptLength: 800 }), settings, undefined)?.model,
+    ).toBe('gpt-4o-mini');
+  });
+
+  it('should leave the model alone when routing is off', () => {
+    expect(routeRequest(request(), undefined, undefined)).toBeUndefined();
+    expect(
+      routeRequest(request(), { ...SETTINGS, enabled: false }, undefined),
+    ).toBeUndefined();
+    expect(
+      routeRequest(request(), { enabled: true, cheapModel: 'a' }, undefined),
+    ).toBeUndefined();
+  });
+
+  it('should send everything to a forced model', () => {
+    expect(
+      routeRequest(request({ isToolContinuation: true }), SETTINGS, 'o3'),
+    ).toEqual({ model: 'o3', reason: 'forced with /force-model' });
+    expect(routeRequest(request(), undefined, 'o3')?.model).toBe('o3');
+  });
+});
+
+describe('getPromptLength', () => {
+  it('should count the text of every part', () => {
+    expect(getPromptLength('hello')).toBe(5);
+    expect(
+      getPromptLength([
+        { text: 'abc' },
+        'de',
+        { inlineData: { mimeType: 'image/png', data: '' } },
+      ]),
+    ).toBe(5);
+  });
+});
@@ -0,0 +1,123 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { PartListUnion } from '@google/genai';
+import { ModelRoutingSettings } from '../../config/settings.js';
+
+export const DEFAULT_MAX_CHEAP_PROMPT_LENGTH = 500;
+export const DEFAULT_MAX_CHEAP_CONTEXT_TOKENS = 16000;
+
+// Rough characters per token, as in the pre-flight checks
+const CHARS_PER_TOKEN = 4;
+
+/** What the router knows of a request before it is sent. */
+export interface RoutingRequest {
+  /** Characters of text sent, the contents of @ files included. */
+  promptLength: number;
+  /** Tokens of the conversation so far, as of the last request. */
+  contextTokens: number;
+  /** Files or images are attached to the prompt. */
+  hasAttachments: boolean;
+  /** The request sends tool results back during an exchange. */
+  isToolContinuation: boolean;
+}
+
+export interface RoutingDecision {
+  model: string;
+  /** Why the model was chosen, for the exchange details. */
+  reason: string;
+}
+
+let forcedModel: string | undefined;
+// The model in use before /force-model, to go back to
+let modelBeforeForce: string | undefined;
+
+/** The model set with /force-model, which every request goes to. */
+export function getForcedModel(): string | undefined {
+  return forcedModel;
+}
+
+/** The model that was in use when a model was first forced. */
+export function getModelBeforeForce(): string | undefined {
+  return modelBeforeForce;
+}
+
+/**
+ * Forces `model`, or stops forcing one with undefined. `current`, the
+ * model in use, is remembered when no model was forced yet.
+ */
+export function setForcedModel(
+  model: string | undefined,
+  current?: string,
+): void {
+  if (!model) {
+    modelBeforeForce = undefined;
+  } else if (!forcedModel) {
+    modelBeforeForce = current;
+  }
+  forcedModel = model;
+}
+
+export function isRoutingEnabled(
+  settings: ModelRoutingSettings | undefined,
+): settings is ModelRoutingSettings & {
+  cheapModel: string;
+  premiumModel: string;
+} {
+  return !!(settings?.enabled && settings.cheapModel && settings.premiumModel);
+}
+
+/** The characters of text in a request. */
+export function getPromptLength(query: PartListUnion): number {
+  return (Array.isArray(query) ? query : [query]).reduce<number>(
+    (length, part) =>
+      length + (typeof part === 'string' ? part : (part.text ?? '')).length,
+    0,
+  );
+}
+
+/**
+ * The model a request goes to: the forced one, or with routing on, the
+ * premium model for long prompts, long conversations, attachments and
+ * tool results, and the cheap one otherwise. Undefined leaves the model
+ * as it is.
+ */
+export function routeRequest(
+  request: RoutingRequest,
+  settings: ModelRoutingSettings | undefined,
+  forced: string | undefined = getForcedModel(),
+): RoutingDecision | undefined {
+  if (forced) {
+    return { model: forced, reason: 'forced with /force-model' };
+  }
+  if (!isRoutingEnabled(settings)) {
+    return undefined;
+  }
+  const premium = (reason: string) => ({
+    model: settings.premiumModel,
+    reason,
+  });
+  if (request.isToolContinuation) {
+    return premium('tool use');
+  }
+  const tokens =
+    request.contextTokens +
+    Math.ceil(request.promptLength / CHARS_PER_TOKEN);
+  const maxTokens =
+    settings.maxCheapContextTokens ?? DEFAULT_MAX_CHEAP_CONTEXT_TOKENS;
+  if (tokens > maxTokens) {
+    return premium(`long context, ${tokens.toLocaleString('en-US')} tokens`);
+  }
+  const maxLength =
+    settings.maxCheapPromptLength ?? DEFAULT_MAX_CHEAP_PROMPT_LENGTH;
+  if (request.promptLength > maxLength) {
+    return premium('long prompt');
+  }
+  if (request.hasAttachments) {
+    return premium('attachments');
+  }
+  return { model: settings.cheapModel, reason: 'short prompt' };
+}
@@ -0,0 +1,69 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect } from 'vitest';
+import { matchesModel, resolveModelStyle } from './modelStyles.js';
+import { darkTheme } from '../themes/theme.js';
+
+describe('matchesModel', () => {
+  it('matches whole names with wildcards, ignoring case', () => {
+    expect(matchesModel('gpt-*', 'GPT-4o')).toBe(true);
+    expect(matchesModel('*llama*', 'llama3.1:8b')).toBe(true);
+    expect(matchesModel('claude', 'claude-sonnet')).toBe(false);
+  });
+
+  it('treats other characters literally', () => {
+    expect(matchesModel('llama3.1', 'llama3x1')).toBe(false);
+    expect(matchesModel('(a)', '(a)')).toBe(true);
+  });
+});
+
+describe('resolveModelStyle', () => {
+  const settings = {
+    models: {
+      'gpt-*': { color: 'cyan', prefix: 'GPT' },
+      'claude-*': { color: 'AccentGreen' },
+      'gpt-4o-mini': { color: 'gray' },
+    },
+    themes: {
+      'GitHub Light': { 'gpt-*': { color: 'blue' } },
+    },
+  };
+
+  it('returns undefined without settings, model or match', () => {
+    expect(
+      resolveModelStyle(undefined, 'gpt-4o', 'Default', darkTheme),
+    ).toBeUndefined();
+    expect(
+      resolveModelStyle(settings, undefined, 'Default', darkTheme),
+    ).toBeUndefined();
+    expect(
+      resolveModelStyle(settings, 'gemini-pro', 'Default', darkTheme),
+    ).toBeUndefined();
+  });
+
+  it('prefers an exact name over patterns', () => {
+    expect(
+      resolveModelStyle(settings, 'gpt-4o-mini', 'Default', darkTheme),
+    ).toEqual({ color: 'gray', prefix: undefined });
+    expect(
+      resolveModelStyle(settings, 'gpt-4o', 'Default', darkTheme),
+    ).toEqual({ color: 'cyan', prefix: 'GPT' });
+  });
+
+  it('takes theme color names from the theme', () => {
+    expect(
+      resolveModelStyle(settings, 'claude-sonnet', 'Default', darkTheme)
+        ?.color,
+    ).toBe(darkTheme.AccentGreen);
+  });
+
+  it('looks at the entries of the active theme first', () => {
+    expect(
+      resolveModelStyle(settings, 'gpt-4o', 'GitHub Light', darkTheme),
+    ).toEqual({ color: 'blue', prefix: undefined });
+  });
+});
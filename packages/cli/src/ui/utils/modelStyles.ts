@@ -0,0 +1,70 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { ModelStyle, ModelStyleSettings } from '../../config/settings.js';
+import { ColorsTheme } from '../themes/theme.js';
+
+/**
+ * Whether a `modelStyles` key matches a model name. Keys match the whole
+ * name, ignoring case, and `*` matches any characters, so `gpt-*` covers
+ * every GPT model and `*llama*` every local Llama build.
+ */
+export function matchesModel(pattern: string, model: string): boolean {
+  const source = pattern
+    .split('*')
+    .map((part) => part.replace(/[.+?^${}()|[\]\\]/g, '\\$&'))
+    .join('.*');
+  return new RegExp(`^${source}$`, 'i').test(model);
+}
+
+function findStyle(
+  styles: Record<string, ModelStyle> | undefined,
+  model: string,
+): ModelStyle | undefined {
+  if (!styles) {
+    return undefined;
+  }
+  // An exact name wins over patterns, which are tried in the order written
+  const exact = Object.keys(styles).find(
+    (key) => key.toLowerCase() === model.toLowerCase(),
+  );
+  if (exact) {
+    return styles[exact];
+  }
+  const pattern = Object.keys(styles).find((key) => matchesModel(key, model));
+  return pattern ? styles[pattern] : undefined;
+}
+
+/**
+ * The color and prefix of answers from a model, or undefined to keep the
+ * defaults. The active theme's entries are looked at before the general
+ * ones, and a color naming a theme color, such as `AccentGreen`, takes
+ * that color from the theme.
+ */
+export function resolveModelStyle(
+  settings: ModelStyleSettings | undefined,
+  model: string | undefined,
+  themeName: string,
+  colors: ColorsTheme,
+): ModelStyle | undefined {
+  if (!settings || !model) {
+    return undefined;
+  }
+  const style =
+    findStyle(settings.themes?.[themeName], model) ??
+    findStyle(settings.models, model);
+  if (!style) {
+    return undefined;
+  }
+  const themeColor =
+    style.color && style.color !== 'type'
+      ? (colors as unknown as Record<string, unknown>)[style.color]
+      : undefined;
+  return {
+    color: typeof themeColor === 'string' ? themeColor : style.color,
+    prefix: style.prefix,
+  };
+}
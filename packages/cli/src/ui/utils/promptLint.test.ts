@@ -0,0 +1,92 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { tokenLimit } from '@iechor/research-cli-core';
+import { PromptLintContext, lintPrompt } from './promptLint.js';
+
+describe('lintPrompt', () => {
+  let tempDir: string;
+  let context: PromptLintContext;
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'prompt-lint-'));
+    fs.mkdirSync(path.join(tempDir, 'data'));
+    fs.writeFileSync(path.join(tempDir, 'data', 'run.csv'), 'a,b\n1,2\n');
+    fs.writeFileSync(path.join(tempDir, 'notes.md'), '# Notes\n');
+    context = {
+      targetDir: tempDir,
+      model: 'research-pro',
+      historyTokens: 0,
+      redacting: false,
+    };
+  });
+
+  afterEach(() => {
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  const checksOf = async (prompt: string) =>
+    (await lintPrompt(prompt, context)).map((warning) => warning.check);
+
+  it('should have nothing to say about a plain question', async () => {
+    expect(await checksOf('What is a p-value?')).toEqual([]);
+  });
+
+  it('should warn about a secret unless redaction is on', async () => {
+    const prompt = 'Why does api_key=abcdef123456 fail?';
+    const warnings = await lintPrompt(prompt, context);
+    expect(warnings).toHaveLength(1);
+    expect(warnings[0].message).toContain('credential');
+
+    context.redacting = true;
+    expect(await checksOf(prompt)).toEqual([]);
+  });
+
+  it('should warn when the conversation would overflow the context', async () => {
+    context.historyTokens = tokenLimit(context.model) - 2;
+    expect(await checksOf('Summarize @notes.md please')).toEqual([
+      'contextLimit',
+    ]);
+  });
+
+  it('should warn about files named but not attached', async () => {
+    const warnings = await lintPrompt(
+      'Plot data/run.csv, like in notes.md.',
+      context,
+    );
+    expect(warnings.map((warning) => warning.check)).toEqual([
+      'unattachedFiles',
+    ]);
+    expect(warnings[0].message).toContain('@data/run.csv, @notes.md');
+  });
+
+  it('should not warn about attached files, missing files or URLs', async () => {
+    expect(
+      await checksOf(
+        'Plot @data/run.csv as in data/run.csv, not other.csv or https://x.org/a.csv',
+      ),
+    ).toEqual([]);
+  });
+
+  it('should warn when only @ paths are left', async () => {
+    expect(await checksOf('@notes.md')).toEqual(['emptyPrompt']);
+  });
+
+  it('should skip the checks turned off', async () => {
+    expect(
+      await lintPrompt('@notes.md', context, { emptyPrompt: false }),
+    ).toEqual([]);
+    expect(
+      await lintPrompt('api_key=abcdef123456 in notes.md', context, {
+        enabled: false,
+      }),
+    ).toEqual([]);
+  });
+});
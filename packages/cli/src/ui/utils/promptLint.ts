@@ -0,0 +1,178 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import { Redactor, tokenLimit } from '@iechor/research-cli-core';
+import { PromptCheckSettings } from '../../config/settings.js';
+import { parseAllAtCommands } from '../hooks/atCommandProcessor.js';
+
+export type PromptCheck =
+  | 'secrets'
+  | 'contextLimit'
+  | 'unattachedFiles'
+  | 'emptyPrompt';
+
+export interface PromptWarning {
+  check: PromptCheck;
+  message: string;
+}
+
+/** What the checks need to know about the session. */
+export interface PromptLintContext {
+  targetDir: string;
+  model: string;
+  /** Tokens the conversation took up in the last request. */
+  historyTokens: number;
+  /** Whether outgoing text is redacted, which takes care of secrets. */
+  redacting: boolean;
+}
+
+// The usual estimate, as the context has not been counted yet
+const CHARS_PER_TOKEN = 4;
+
+// Words looked up on disk at most, so a pasted log does not stat for long
+const MAX_FILE_CANDIDATES = 20;
+
+// Detects secrets whatever the redaction settings are
+const secretDetector = new Redactor({ enabled: true });
+
+export function isCheckEnabled(
+  settings: PromptCheckSettings | undefined,
+  check: PromptCheck,
+): boolean {
+  return settings?.enabled !== false && settings?.[check] !== false;
+}
+
+function checkSecrets(text: string): PromptWarning | undefined {
+  const rules = [
+    ...new Set(secretDetector.redactText(text).matches.map((m) => m.rule)),
+  ];
+  if (rules.length === 0) {
+    return undefined;
+  }
+  return {
+    check: 'secrets',
+    message: `The prompt looks like it contains a secret (${rules.join(', ')}), and redaction is off, so it would be sent as is.`,
+  };
+}
+
+async function fileSize(filePath: string): Promise<number | undefined> {
+  try {
+    const stats = await fs.promises.stat(filePath);
+    return stats.isFile() ? stats.size : undefined;
+  } catch {
+    return undefined;
+  }
+}
+
+async function checkContextLimit(
+  text: string,
+  attachedFiles: string[],
+  context: PromptLintContext,
+): Promise<PromptWarning | undefined> {
+  // Folders and globs are left out: they would take a walk of the tree
+  let chars = text.length;
+  for (const file of attachedFiles) {
+    chars += (await fileSize(file)) ?? 0;
+  }
+  const promptTokens = Math.ceil(chars / CHARS_PER_TOKEN);
+  const limit = tokenLimit(context.model);
+  if (context.historyTokens + promptTokens <= limit) {
+    return undefined;
+  }
+  return {
+    check: 'contextLimit',
+    message: `With its attachments the prompt is about ${promptTokens.toLocaleString('en-US')} tokens, which with the ${context.historyTokens.toLocaleString('en-US')} of the conversation so far exceeds the ${limit.toLocaleString('en-US')} token context of ${context.model}.`,
+  };
+}
+
+/** Words of the prompt that look like a file path, e.g. `data/run.csv`. */
+function fileCandidates(text: string): string[] {
+  const candidates = text
+    .split(/\s+/)
+    .map((word) =>
+      word.replace(/^[`'"([<]+/, '').replace(/[`'")\]>.,;:!?]+$/, ''),
+    )
+    .filter(
+      (word) =>
+        !word.includes('://') &&
+        (/^[\w.-]*\w\.[A-Za-z0-9]{1,8}$/.test(word) ||
+          /^\.{0,2}\/?[\w.-]+(\/[\w.-]+)+$/.test(word)),
+    );
+  return [...new Set(candidates)].slice(0, MAX_FILE_CANDIDATES);
+}
+
+async function checkUnattachedFiles(
+  text: string,
+  attachedFiles: string[],
+  context: PromptLintContext,
+): Promise<PromptWarning | undefined> {
+  const unattached: string[] = [];
+  for (const word of fileCandidates(text)) {
+    const filePath = path.resolve(context.targetDir, word);
+    if (
+      !attachedFiles.includes(filePath) &&
+      (await fileSize(filePath)) !== undefined
+    ) {
+      unattached.push(word);
+    }
+  }
+  if (unattached.length === 0) {
+    return undefined;
+  }
+  return {
+    check: 'unattachedFiles',
+    message: `The prompt names ${unattached.join(', ')} without attaching ${unattached.length === 1 ? 'it' : 'them'}; write ${unattached.map((file) => `@${file}`).join(', ')} to send the contents.`,
+  };
+}
+
+/**
+ * Runs the pre-flight checks on a prompt about to be sent: secrets in it,
+ * a context overflow once the @ files are read in, files it names but
+ * does not attach, and nothing left to ask once the @ paths are taken
+ * out. Each check can be turned off in the `promptChecks` setting.
+ */
+export async function lintPrompt(
+  prompt: string,
+  context: PromptLintContext,
+  settings?: PromptCheckSettings,
+): Promise<PromptWarning[]> {
+  if (settings?.enabled === false) {
+    return [];
+  }
+  const parts = parseAllAtCommands(prompt);
+  const text = parts
+    .filter((part) => part.type === 'text')
+    .map((part) => part.content)
+    .join(' ');
+  const attachedFiles = parts
+    .filter((part) => part.type === 'atPath' && part.content !== '@')
+    .map((part) => path.resolve(context.targetDir, part.content.slice(1)));
+
+  const warnings: Array<PromptWarning | undefined> = [];
+  if (isCheckEnabled(settings, 'secrets') && !context.redacting) {
+    warnings.push(checkSecrets(prompt));
+  }
+  if (isCheckEnabled(settings, 'contextLimit')) {
+    warnings.push(await checkContextLimit(text, attachedFiles, context));
+  }
+  if (isCheckEnabled(settings, 'unattachedFiles')) {
+    warnings.push(await checkUnattachedFiles(text, attachedFiles, context));
+  }
+  if (
+    isCheckEnabled(settings, 'emptyPrompt') &&
+    attachedFiles.length > 0 &&
+    !text.trim()
+  ) {
+    warnings.push({
+      check: 'emptyPrompt',
+      message:
+        'Once the @ paths are read in, nothing is left of the prompt to ask about them.',
+    });
+  }
+  return warnings.filter((warning): warning is PromptWarning => !!warning);
+}
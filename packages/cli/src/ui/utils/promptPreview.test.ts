@@ -0,0 +1,112 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { tokenLimit } from '@iechor/research-cli-core';
+import {
+  PromptPreviewContext,
+  composePromptPreview,
+  formatContent,
+  splitMemory,
+} from './promptPreview.js';
+
+describe('splitMemory', () => {
+  it('should take the memory off the end of the system prompt', () => {
+    expect(
+      splitMemory(
+        'You are helpful.\n\n---\n\nUse SI units.',
+        ' Use SI units.\n',
+      ),
+    ).toEqual({ system: 'You are helpful.', memory: 'Use SI units.' });
+  });
+
+  it('should leave a system prompt without the memory whole', () => {
+    expect(splitMemory('From system.md', 'Use SI units.')).toEqual({
+      system: 'From system.md',
+      memory: '',
+    });
+  });
+});
+
+describe('formatContent', () => {
+  it('should show calls and results next to the text', () => {
+    expect(
+      formatContent({
+        role: 'model',
+        parts: [
+          { text: 'Looking.' },
+          { functionCall: { name: 'read_file', args: { path: 'a.md' } } },
+        ],
+      }),
+    ).toBe('model: Looking. [call read_file({"path":"a.md"})]');
+    expect(
+      formatContent({
+        role: 'user',
+        parts: [{ functionResponse: { name: 'read_file', response: {} } }],
+      }),
+    ).toBe('user: [result of read_file: {}]');
+  });
+});
+
+describe('composePromptPreview', () => {
+  let tempDir: string;
+  let context: PromptPreviewContext;
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'prompt-preview-'));
+    fs.writeFileSync(path.join(tempDir, 'notes.md'), 'x'.repeat(400));
+    context = {
+      targetDir: tempDir,
+      model: 'research-pro',
+      systemInstruction: 'You are helpful.\n\n---\n\nUse SI units.',
+      userMemory: 'Use SI units.',
+      history: [
+        { role: 'user', parts: [{ text: 'Hi' }] },
+        { role: 'model', parts: [{ text: 'Hello!' }] },
+      ],
+    };
+  });
+
+  afterEach(() => {
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should count every section of the request', async () => {
+    const preview = await composePromptPreview(
+      'Summarize @notes.md and @missing.md',
+      context,
+    );
+
+    expect(
+      preview.sections.map(({ title, tokens }) => [title, tokens]),
+    ).toEqual([
+      ['System prompt', 4],
+      ['Memory', 4],
+      ['History', 6],
+      ['Attachments', 100],
+      ['Prompt', 9],
+    ]);
+    expect(preview.sections[3].lines).toEqual([
+      '@notes.md  ≈100 tokens',
+      '@missing.md  not found, or a pattern',
+    ]);
+    expect(preview.totalTokens).toBe(123);
+    expect(preview.limit).toBe(tokenLimit('research-pro'));
+  });
+
+  it('should say when no memory is loaded', async () => {
+    context.userMemory = '';
+    const preview = await composePromptPreview('Hi', context);
+    expect(preview.sections[1]).toMatchObject({
+      tokens: 0,
+      lines: [],
+      summary: 'no RESEARCH.md files loaded',
+    });
+  });
+});
@@ -0,0 +1,176 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import { Content, Part } from '@google/genai';
+import { RequestContext, tokenLimit } from '@iechor/research-cli-core';
+import { parseAllAtCommands } from '../hooks/atCommandProcessor.js';
+
+// The usual estimate, as in the pre-flight checks
+const CHARS_PER_TOKEN = 4;
+
+export interface PreviewSection {
+  title: string;
+  /** Estimated tokens of the section. */
+  tokens: number;
+  /** The text the section sends, one entry per line. */
+  lines: string[];
+  /** What the section holds, e.g. `12 messages`. */
+  summary?: string;
+}
+
+/** The composed request as it would be sent, section by section. */
+export interface PromptPreview {
+  model: string;
+  sections: PreviewSection[];
+  totalTokens: number;
+  /** The context window of the model. */
+  limit: number;
+}
+
+export interface PromptPreviewContext extends RequestContext {
+  targetDir: string;
+  model: string;
+  userMemory: string;
+}
+
+export function estimateTokens(text: string): number {
+  return Math.ceil(text.length / CHARS_PER_TOKEN);
+}
+
+const plural = (count: number, noun: string) =>
+  `${count} ${noun}${count === 1 ? '' : 's'}`;
+
+/**
+ * Splits the user memory off the end of the system instruction, where
+ * the core prompt appends it after a `---` rule.
+ */
+export function splitMemory(
+  systemInstruction: string,
+  userMemory: string,
+): { system: string; memory: string } {
+  const memory = userMemory.trim();
+  const suffix = `\n\n---\n\n${memory}`;
+  if (!memory || !systemInstruction.endsWith(suffix)) {
+    return { system: systemInstruction, memory: '' };
+  }
+  return {
+    system: systemInstruction.slice(0, -suffix.length),
+    memory,
+  };
+}
+
+function formatPart(part: Part): string {
+  if (part.text !== undefined) {
+    return part.text;
+  }
+  if (part.functionCall) {
+    return `[call ${part.functionCall.name}(${JSON.stringify(part.functionCall.args ?? {})})]`;
+  }
+  if (part.functionResponse) {
+    return `[result of ${part.functionResponse.name}: ${JSON.stringify(part.functionResponse.response ?? {})}]`;
+  }
+  if (part.inlineData) {
+    return `[${part.inlineData.mimeType ?? 'inline data'}]`;
+  }
+  if (part.fileData) {
+    return `[file ${part.fileData.fileUri ?? ''}]`;
+  }
+  return JSON.stringify(part);
+}
+
+/** A message of the history as a line of text, led by its role. */
+export function formatContent(content: Content): string {
+  const text = (content.parts ?? []).map(formatPart).join(' ');
+  return `${content.role ?? 'user'}: ${text}`;
+}
+
+async function attachmentLine(
+  atPath: string,
+  targetDir: string,
+): Promise<{ line: string; tokens: number }> {
+  try {
+    const stats = await fs.promises.stat(path.resolve(targetDir, atPath));
+    if (stats.isFile()) {
+      const tokens = Math.ceil(stats.size / CHARS_PER_TOKEN);
+      return {
+        line: `@${atPath}  ≈${tokens.toLocaleString('en-US')} tokens`,
+        tokens,
+      };
+    }
+    // Folders would take a walk of the tree to count
+    return { line: `@${atPath}  folder, not counted`, tokens: 0 };
+  } catch {
+    return { line: `@${atPath}  not found, or a pattern`, tokens: 0 };
+  }
+}
+
+/**
+ * Composes what sending the prompt would send, in the order the model
+ * reads it: the system prompt, the user memory appended to it, the
+ * history the context filters leave, the @ attachments and the prompt.
+ * The token counts are estimates from the length of the text.
+ */
+export async function composePromptPreview(
+  prompt: string,
+  context: PromptPreviewContext,
+): Promise<PromptPreview> {
+  const { system, memory } = splitMemory(
+    context.systemInstruction,
+    context.userMemory,
+  );
+  const historyLines = context.history.map(formatContent);
+  const atPaths = parseAllAtCommands(prompt)
+    .filter((part) => part.type === 'atPath' && part.content !== '@')
+    .map((part) => part.content.slice(1));
+  const attachments = await Promise.all(
+    atPaths.map((atPath) => attachmentLine(atPath, context.targetDir)),
+  );
+
+  const sections: PreviewSection[] = [
+    {
+      title: 'System prompt',
+      tokens: estimateTokens(system),
+      lines: system.split('\n'),
+    },
+    {
+      title: 'Memory',
+      tokens: estimateTokens(memory),
+      lines: memory ? memory.split('\n') : [],
+      summary: memory ? undefined : 'no RESEARCH.md files loaded',
+    },
+    {
+      title: 'History',
+      tokens: historyLines.reduce(
+        (tokens, line) => tokens + estimateTokens(line),
+        0,
+      ),
+      lines: historyLines,
+      summary: plural(historyLines.length, 'message'),
+    },
+    {
+      title: 'Attachments',
+      tokens: attachments.reduce(
+        (tokens, attachment) => tokens + attachment.tokens,
+        0,
+      ),
+      lines: attachments.map((attachment) => attachment.line),
+      summary: plural(attachments.length, 'file'),
+    },
+    {
+      title: 'Prompt',
+      tokens: estimateTokens(prompt),
+      lines: prompt.split('\n'),
+    },
+  ];
+  return {
+    model: context.model,
+    sections,
+    totalTokens: sections.reduce((tokens, s) => tokens + s.tokens, 0),
+    limit: tokenLimit(context.model),
+  };
+}
@@ -0,0 +1,58 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, vi } from 'vitest';
+import { RenderCache } from './renderCache.js';
+
+const layout = { width: 80, height: 100, theme: 'Default' };
+
+describe('RenderCache', () => {
+  it('should reuse the render for the same item and layout', () => {
+    const cache = new RenderCache();
+    const item = { id: 1 };
+    const render = vi.fn(() => 'rendered');
+
+    expect(cache.get(1, item, layout, render)).toBe('rendered');
+    expect(cache.get(1, item, { ...layout }, render)).toBe('rendered');
+    expect(render).toHaveBeenCalledTimes(1);
+  });
+
+  it('should re-render when the item, width or theme changes', () => {
+    const cache = new RenderCache();
+    const item = { id: 1 };
+    const render = vi.fn(() => 'rendered');
+
+    cache.get(1, item, layout, render);
+    cache.get(1, { id: 1 }, layout, render);
+    cache.get(1, { id: 1 }, layout, render);
+    expect(render).toHaveBeenCalledTimes(3);
+
+    const updated = { id: 1 };
+    cache.get(1, updated, { ...layout, width: 120 }, render);
+    cache.get(1, updated, { ...layout, width: 120, theme: 'Dracula' }, render);
+    expect(render).toHaveBeenCalledTimes(5);
+  });
+
+  it('should only drop entries for a stale layout', () => {
+    const cache = new RenderCache();
+    cache.get(1, {}, layout, () => 'a');
+    cache.get(2, {}, { ...layout, width: 120 }, () => 'b');
+
+    cache.invalidateExcept({ ...layout, width: 120 });
+
+    expect(cache.size).toBe(1);
+  });
+
+  it('should forget items that are no longer in history', () => {
+    const cache = new RenderCache();
+    cache.get(1, {}, layout, () => 'a');
+    cache.get(2, {}, layout, () => 'b');
+
+    cache.retain([2]);
+
+    expect(cache.size).toBe(1);
+  });
+});
@@ -0,0 +1,78 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import React from 'react';
+
+export interface RenderLayout {
+  width: number;
+  /** Maximum height the item may take, when it is constrained. */
+  height?: number;
+  theme: string;
+}
+
+interface CacheEntry extends RenderLayout {
+  // Identity of the source object; history items are replaced on update.
+  source: object;
+  node: React.ReactNode;
+}
+
+/**
+ * Caches the rendered element tree of each message keyed by (id, width,
+ * theme), so adding a message or redrawing at the same size does not
+ * rebuild every earlier message. When the width or theme changes only the
+ * entries rendered for the old layout are dropped.
+ */
+export class RenderCache {
+  private entries = new Map<number, CacheEntry>();
+
+  get(
+    id: number,
+    source: object,
+    layout: RenderLayout,
+    render: () => React.ReactNode,
+  ): React.ReactNode {
+    const entry = this.entries.get(id);
+    if (
+      entry &&
+      entry.source === source &&
+      entry.width === layout.width &&
+      entry.height === layout.height &&
+      entry.theme === layout.theme
+    ) {
+      return entry.node;
+    }
+    const node = render();
+    this.entries.set(id, { ...layout, source, node });
+    return node;
+  }
+
+  /** Drops entries that were rendered for a different layout. */
+  invalidateExcept(layout: RenderLayout): void {
+    for (const [id, entry] of this.entries) {
+      if (
+        entry.width !== layout.width ||
+        entry.height !== layout.height ||
+        entry.theme !== layout.theme
+      ) {
+        this.entries.delete(id);
+      }
+    }
+  }
+
+  /** Keeps only the given ids, e.g. after history was cleared or trimmed. */
+  retain(ids: Iterable<number>): void {
+    const keep = new Set(ids);
+    for (const id of this.entries.keys()) {
+      if (!keep.has(id)) {
+        this.entries.delete(id);
+      }
+    }
+  }
+
+  get size(): number {
+    return this.entries.size;
+  }
+}
@@ -0,0 +1,160 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { execFile } from 'node:child_process';
+import { EventEmitter } from 'node:events';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { promisify } from 'node:util';
+import { Box, render } from 'ink';
+import { Config } from '@iechor/research-cli-core';
+import { HistoryItem } from '../types.js';
+import { HistoryItemDisplay } from '../components/HistoryItemDisplay.js';
+import { SessionStatsProvider } from '../contexts/SessionContext.js';
+import { Colors } from '../colors.js';
+import { darkTheme, lightTheme } from '../themes/theme.js';
+import { ansiToSvg } from './ansiSvg.js';
+
+const execFileAsync = promisify(execFile);
+
+/** Tried in order to turn the SVG into a PNG. */
+const PNG_CONVERTERS: Array<{
+  command: string;
+  args: (svg: string, png: string) => string[];
+}> = [
+  { command: 'rsvg-convert', args: (svg, png) => [svg, '-o', png] },
+  { command: 'magick', args: (svg, png) => [svg, png] },
+  {
+    command: 'inkscape',
+    args: (svg, png) => [svg, '--export-type=png', `--export-filename=${png}`],
+  },
+];
+
+/**
+ * Renders history items the way the terminal shows them, and returns the
+ * output with its ANSI colors. Ink draws to a stand-in stdout of the given
+ * width, so the screenshot matches the terminal whatever its size now.
+ */
+export async function renderHistoryToAnsi(
+  items: HistoryItem[],
+  options: { columns: number; config?: Config },
+): Promise<string> {
+  let frame = '';
+  const stdout = Object.assign(new EventEmitter(), {
+    columns: options.columns,
+    rows: 1000,
+    isTTY: false,
+    write: (data: string) => {
+      frame = data;
+      return true;
+    },
+  });
+  const stdin = Object.assign(new EventEmitter(), {
+    isTTY: false,
+    setRawMode: () => {},
+    setEncoding: () => {},
+    resume: () => {},
+    pause: () => {},
+    ref: () => {},
+    unref: () => {},
+    read: () => null,
+  });
+
+  const instance = render(
+    <SessionStatsProvider>
+      <Box flexDirection="column" width={options.columns}>
+        {items.map((item) => (
+          <HistoryItemDisplay
+            key={item.id}
+            item={item}
+            terminalWidth={options.columns}
+            isPending={false}
+            config={options.config}
+          />
+        ))}
+      </Box>
+    </SessionStatsProvider>,
+    {
+      stdout: stdout as unknown as NodeJS.WriteStream,
+      stdin: stdin as unknown as NodeJS.ReadStream,
+      debug: true,
+      patchConsole: false,
+      exitOnCtrlC: false,
+    },
+  );
+  // Let effects that fill in content run before the frame is taken
+  await new Promise((resolve) => setTimeout(resolve, 50));
+  instance.unmount();
+  return frame;
+}
+
+/**
+ * The active theme's window colors. Themes that name terminal colors
+ * rather than give them leave the terminal to choose, so the built-in
+ * dark or light colors stand in.
+ */
+function screenshotColors(): { background: string; foreground: string } {
+  const fallback = Colors.type === 'light' ? lightTheme : darkTheme;
+  const isHex = (color: string) => /^#[0-9a-f]{3,8}$/i.test(color);
+  return {
+    background: isHex(Colors.Background)
+      ? Colors.Background
+      : fallback.Background,
+    foreground: isHex(Colors.Foreground)
+      ? Colors.Foreground
+      : fallback.Foreground,
+  };
+}
+
+/** Renders history items as an SVG image of the terminal window. */
+export async function renderScreenshotSvg(
+  items: HistoryItem[],
+  options: { columns: number; title?: string; config?: Config },
+): Promise<string> {
+  const ansi = await renderHistoryToAnsi(items, options);
+  return ansiToSvg(ansi, {
+    columns: options.columns,
+    title: options.title,
+    ...screenshotColors(),
+  });
+}
+
+/**
+ * Converts an SVG to a PNG with the first converter found on the PATH.
+ * There is no image library among the dependencies, and these tools draw
+ * text far better than one would.
+ */
+export async function convertSvgToPng(
+  svg: string,
+  pngPath: string,
+): Promise<void> {
+  const tempDir = await fs.promises.mkdtemp(
+    path.join(os.tmpdir(), 'research-screenshot-'),
+  );
+  const svgPath = path.join(tempDir, 'screenshot.svg');
+  try {
+    await fs.promises.writeFile(svgPath, svg, 'utf8');
+    for (const converter of PNG_CONVERTERS) {
+      try {
+        await execFileAsync(
+          converter.command,
+          converter.args(svgPath, pngPath),
+        );
+        return;
+      } catch (e) {
+        if ((e as NodeJS.ErrnoException).code !== 'ENOENT') {
+          throw e;
+        }
+      }
+    }
+    throw new Error(
+      `Writing a PNG needs one of ${PNG_CONVERTERS.map((c) => c.command).join(', ')} on the PATH; export to .svg instead.`,
+    );
+  } finally {
+    await fs.promises.rm(tempDir, { recursive: true, force: true });
+  }
+}
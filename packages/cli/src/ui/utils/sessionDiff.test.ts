@@ -0,0 +1,125 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect } from 'vitest';
+import { Content } from '@google/genai';
+import { extractAnswerTurns, renderSessionDiff } from './sessionDiff.js';
+
+const conversation = (...turns: Array<[string, string]>): Content[] =>
+  turns.flatMap(([prompt, answer]) => [
+    { role: 'user', parts: [{ text: prompt }] },
+    { role: 'model', parts: [{ text: answer }] },
+  ]);
+
+describe('extractAnswerTurns', () => {
+  it('pairs prompts with their answers, leaving out tools and thoughts', () => {
+    const history: Content[] = [
+      {
+        role: 'user',
+        parts: [{ text: 'This is the context for our chat.' }],
+      },
+      { role: 'model', parts: [{ text: 'Got it.' }] },
+      { role: 'user', parts: [{ text: 'Count the rows.' }] },
+      {
+        role: 'model',
+        parts: [
+          { text: 'Reading the file.', thought: true },
+          { functionCall: { name: 'read_file', args: {} } },
+        ],
+      },
+      {
+        role: 'user',
+        parts: [
+          { functionResponse: { name: 'read_file', response: { output: '' } } },
+        ],
+      },
+      { role: 'model', parts: [{ text: 'There are ' }] },
+      { role: 'model', parts: [{ text: 'two rows.' }] },
+    ];
+
+    expect(extractAnswerTurns(history)).toEqual([
+      { prompt: 'Count the rows.', answer: 'There are two rows.' },
+    ]);
+  });
+});
+
+describe('renderSessionDiff', () => {
+  const a = {
+    label: 'gpt',
+    history: conversation(
+      ['Summarize.', 'Line one.\nLine two.'],
+      ['Conclude.', 'Same.'],
+    ),
+  };
+  const b = {
+    label: 'claude',
+    history: conversation(
+      ['Summarize.', 'Line one.\nLine 2.'],
+      ['Conclude.', 'Same.'],
+      ['Extra.', 'More.'],
+    ),
+  };
+
+  it('renders the turns whose answers differ as a unified diff', () => {
+    const text = renderSessionDiff(a, b);
+
+    expect(text).toContain(
+      [
+        '2 of 3 turns differ between gpt (2) and claude (3).',
+        '--- gpt',
+        '+++ claude',
+        '',
+        'Turn 1: Summarize.',
+        '@@ -1,2 +1,2 @@',
+        ' Line one.',
+        '-Line two.',
+        '+Line 2.',
+        '',
+        'Turn 3: Extra.',
+        'Only in claude.',
+      ].join('\n'),
+    );
+    expect(text).toMatch(/\+More\.$/);
+    expect(text).not.toContain('Turn 2');
+  });
+
+  it('renders changed lines next to each other side by side', () => {
+    const text = renderSessionDiff(a, b, { style: 'side-by-side', width: 43 });
+
+    // Columns of (43 - 3) / 2 = 20
+    expect(text).toContain(
+      [
+        `${'Line one.'.padEnd(20)}   Line one.`,
+        `${'Line two.'.padEnd(20)} | Line 2.`,
+      ].join('\n'),
+    );
+    expect(text).toContain(`${''.padEnd(20)} > More.`);
+  });
+
+  it('wraps long lines within their column', () => {
+    const long = {
+      label: 'long',
+      history: conversation(['Go.', 'alpha beta gamma delta epsilon']),
+    };
+    const short = { label: 'short', history: conversation(['Go.', 'alpha']) };
+
+    const text = renderSessionDiff(long, short, {
+      style: 'side-by-side',
+      width: 33,
+    });
+
+    // Columns of (33 - 3) / 2 = 15
+    expect(text).toContain(`${'alpha beta'.padEnd(15)} | alpha`);
+    expect(text).toContain(`${'gamma delta'.padEnd(15)} |\n`);
+    expect(text).toMatch(/epsilon +\|$/);
+  });
+
+  it('says so when the answers are the same', () => {
+    expect(renderSessionDiff(a, { ...a, label: 'copy' })).toBe(
+      'The answers in gpt and copy are the same (2 turns).',
+    );
+  });
+});
@@ -0,0 +1,251 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { Content } from '@google/genai';
+import * as Diff from 'diff';
+import { isSetupTurn, mergeStreamedText } from '@iechor/research-cli-core';
+import { cpIndexAtWidth, cpSlice, padToWidth } from './textUtils.js';
+
+export type SessionDiffStyle = 'unified' | 'side-by-side';
+
+/** A prompt and the text of the answers to it. */
+export interface AnswerTurn {
+  prompt: string;
+  answer: string;
+}
+
+export interface DiffedSession {
+  label: string;
+  history: Content[];
+}
+
+// Unchanged lines shown around each change
+const CONTEXT_LINES = 2;
+
+function partsText(content: Content): string {
+  return (content.parts ?? [])
+    .filter((part) => part.text && !part.thought)
+    .map((part) => part.text)
+    .join('')
+    .trim();
+}
+
+/**
+ * The prompts of a conversation, each with the text of its answers. Tool
+ * calls, tool output and thoughts are left out, so that two runs that
+ * took different tool paths still line up prompt by prompt.
+ */
+export function extractAnswerTurns(history: Content[]): AnswerTurn[] {
+  const turns: AnswerTurn[] = [];
+  const said = history.filter((_, i) => !isSetupTurn(history, i));
+  for (const content of mergeStreamedText(said)) {
+    const text = partsText(content);
+    if (!text) {
+      continue;
+    }
+    const last = turns[turns.length - 1];
+    if (content.role !== 'model') {
+      turns.push({ prompt: text, answer: '' });
+    } else if (last) {
+      last.answer = last.answer ? `${last.answer}\n\n${text}` : text;
+    } else {
+      turns.push({ prompt: '', answer: text });
+    }
+  }
+  return turns;
+}
+
+const splitLines = (text: string) =>
+  text ? text.replace(/\n$/, '').split('\n') : [];
+
+/** Cuts `line` into pieces of `width` columns, at spaces where possible. */
+function wrapToWidth(line: string, width: number): string[] {
+  const pieces: string[] = [];
+  let rest = line;
+  while (rest) {
+    let end = cpIndexAtWidth(rest, width);
+    if (end === 0) {
+      // A wide character in a column of one
+      end = 1;
+    }
+    const piece = cpSlice(rest, 0, end);
+    const space = piece.lastIndexOf(' ');
+    if (rest[piece.length] === ' ') {
+      pieces.push(piece);
+      rest = rest.slice(piece.length + 1);
+    } else if (piece !== rest && space > 0) {
+      pieces.push(piece.slice(0, space));
+      rest = rest.slice(space + 1);
+    } else {
+      pieces.push(piece);
+      rest = cpSlice(rest, end);
+    }
+  }
+  return pieces.length > 0 ? pieces : [''];
+}
+
+interface SideBySideRow {
+  left: string;
+  right: string;
+  /** ` ` unchanged, `|` changed, `<` only left, `>` only right. */
+  mark: string;
+}
+
+function sideBySideRows(before: string, after: string): SideBySideRow[] {
+  const changes = Diff.diffLines(
+    before ? `${before}\n` : '',
+    after ? `${after}\n` : '',
+  );
+  const rows: SideBySideRow[] = [];
+  for (let i = 0; i < changes.length; i++) {
+    const change = changes[i];
+    const lines = splitLines(change.value);
+    if (!change.added && !change.removed) {
+      const isFirst = i === 0;
+      const isLast = i === changes.length - 1;
+      const keepStart = isFirst ? 0 : CONTEXT_LINES;
+      const keepEnd = isLast ? 0 : CONTEXT_LINES;
+      if (lines.length > keepStart + keepEnd) {
+        const kept = [
+          ...lines.slice(0, keepStart),
+          undefined,
+          ...lines.slice(lines.length - keepEnd),
+        ];
+        for (const line of kept) {
+          rows.push(
+            line === undefined
+              ? { left: '...', right: '...', mark: ' ' }
+              : { left: line, right: line, mark: ' ' },
+          );
+        }
+      } else {
+        rows.push(
+          ...lines.map((line) => ({ left: line, right: line, mark: ' ' })),
+        );
+      }
+    } else if (change.removed && changes[i + 1]?.added) {
+      const added = splitLines(changes[++i].value);
+      for (let j = 0; j < Math.max(lines.length, added.length); j++) {
+        const left = lines[j];
+        const right = added[j];
+        rows.push({
+          left: left ?? '',
+          right: right ?? '',
+          mark: left === undefined ? '>' : right === undefined ? '<' : '|',
+        });
+      }
+    } else {
+      rows.push(
+        ...lines.map((line) =>
+          change.removed
+            ? { left: line, right: '', mark: '<' }
+            : { left: '', right: line, mark: '>' },
+        ),
+      );
+    }
+  }
+  return rows;
+}
+
+function renderSideBySide(
+  before: string,
+  after: string,
+  labels: [string, string],
+  width: number,
+): string[] {
+  const columnWidth = Math.max(Math.floor((width - 3) / 2), 10);
+  const output = [
+    `${padToWidth(labels[0], columnWidth)}   ${labels[1]}`,
+    `${'-'.repeat(columnWidth)}   ${'-'.repeat(columnWidth)}`,
+  ];
+  for (const row of sideBySideRows(before, after)) {
+    const left = wrapToWidth(row.left, columnWidth);
+    const right = wrapToWidth(row.right, columnWidth);
+    for (let i = 0; i < Math.max(left.length, right.length); i++) {
+      output.push(
+        `${padToWidth(left[i] ?? '', columnWidth)} ${row.mark} ${right[i] ?? ''}`.trimEnd(),
+      );
+    }
+  }
+  return output;
+}
+
+function renderUnified(
+  before: string,
+  after: string,
+  labels: [string, string],
+): string[] {
+  const patch = Diff.structuredPatch(
+    labels[0],
+    labels[1],
+    before ? `${before}\n` : '',
+    after ? `${after}\n` : '',
+    '',
+    '',
+    { context: CONTEXT_LINES },
+  );
+  return patch.hunks.flatMap((hunk) => [
+    `@@ -${hunk.oldStart},${hunk.oldLines} +${hunk.newStart},${hunk.newLines} @@`,
+    ...hunk.lines.filter((line) => !line.startsWith('\\')),
+  ]);
+}
+
+const firstLine = (text: string) => text.split('\n')[0];
+
+/**
+ * Compares the answers of two conversations prompt by prompt, e.g. the
+ * same pipeline of prompts run with two models, and renders the turns
+ * whose answers differ as a unified or a side-by-side diff.
+ */
+export function renderSessionDiff(
+  a: DiffedSession,
+  b: DiffedSession,
+  options: { style?: SessionDiffStyle; width?: number } = {},
+): string {
+  const style = options.style ?? 'unified';
+  const width = options.width ?? 100;
+  const turnsA = extractAnswerTurns(a.history);
+  const turnsB = extractAnswerTurns(b.history);
+  const labels: [string, string] = [a.label, b.label];
+  const turnCount = Math.max(turnsA.length, turnsB.length);
+
+  const sections: string[] = [];
+  for (let i = 0; i < turnCount; i++) {
+    const turnA = turnsA[i];
+    const turnB = turnsB[i];
+    if (turnA && turnB && turnA.answer === turnB.answer) {
+      continue;
+    }
+    const prompt = turnA?.prompt ?? turnB?.prompt ?? '';
+    const lines = [`Turn ${i + 1}: ${firstLine(prompt)}`];
+    if (!turnA || !turnB) {
+      lines.push(`Only in ${turnA ? a.label : b.label}.`);
+    } else if (turnA.prompt !== turnB.prompt) {
+      lines.push(
+        `The prompt in ${b.label} differs: ${firstLine(turnB.prompt)}`,
+      );
+    }
+    const before = turnA?.answer ?? '';
+    const after = turnB?.answer ?? '';
+    lines.push(
+      ...(style === 'side-by-side'
+        ? renderSideBySide(before, after, labels, width)
+        : renderUnified(before, after, labels)),
+    );
+    sections.push(lines.join('\n'));
+  }
+
+  const summary =
+    sections.length === 0
+      ? `The answers in ${a.label} and ${b.label} are the same (${turnCount} turn${turnCount === 1 ? '' : 's'}).`
+      : `${sections.length} of ${turnCount} turn${turnCount === 1 ? '' : 's'} differ between ${a.label} (${turnsA.length}) and ${b.label} (${turnsB.length}).`;
+  return [
+    style === 'unified' && sections.length > 0
+      ? `${summary}\n--- ${a.label}\n+++ ${b.label}`
+      : summary,
+    ...sections,
+  ].join('\n\n');
+}
@@ -0,0 +1,117 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect } from 'vitest';
+import {
+  DEFAULT_SESSION_NAME,
+  SessionManager,
+  SessionState,
+  fuzzyFilter,
+  fuzzyScore,
+  getSessionStoreId,
+  isValidSessionName,
+} from './sessionManager.js';
+
+const state = (prompt: string, model = 'research-pro'): SessionState => ({
+  chatHistory: [{ role: 'user', parts: [{ text: prompt }] }],
+  items: [
+    { type: 'user', text: prompt },
+    { type: 'research', text: 'Answer' },
+    { type: 'research_content', text: ' continued' },
+    { type: 'info', text: 'Saved.' },
+  ],
+  model,
+  generationOptions: { maxOutputTokens: 100 },
+});
+
+describe('fuzzyScore', () => {
+  it('should match the characters in order', () => {
+    expect(fuzzyScore('lr', 'literature')).toBeDefined();
+    expect(fuzzyScore('rl', 'lit')).toBeUndefined();
+  });
+
+  it('should prefer close and leading matches', () => {
+    expect(fuzzyScore('lit', 'literature')!).toBeLessThan(
+      fuzzyScore('lit', 'plot-it')!,
+    );
+  });
+});
+
+describe('fuzzyFilter', () => {
+  it('should keep the matches, the best first', () => {
+    const names = ['data-analysis', 'main', 'lit-review', 'analysis'];
+    expect(fuzzyFilter(names, 'ana', (name) => name)).toEqual([
+      'analysis',
+      'data-analysis',
+    ]);
+    expect(fuzzyFilter(names, '', (name) => name)).toEqual(names);
+  });
+});
+
+describe('isValidSessionName', () => {
+  it.each([
+    ['lit-review', true],
+    ['run_2.b', true],
+    ['two words', false],
+    ['../up', false],
+    ['', false],
+  ])('"%s" is %s', (name, valid) => {
+    expect(isValidSessionName(name)).toBe(valid);
+  });
+});
+
+describe('getSessionStoreId', () => {
+  it('should keep the session id for the first session', () => {
+    expect(getSessionStoreId('abc', DEFAULT_SESSION_NAME)).toBe('abc');
+    expect(getSessionStoreId('abc', 'lit')).toBe('abc-lit');
+  });
+});
+
+describe('SessionManager', () => {
+  it('should start in the main session', () => {
+    const manager = new SessionManager();
+    expect(manager.getCurrentName()).toBe(DEFAULT_SESSION_NAME);
+    expect(manager.has('main')).toBe(true);
+    expect(manager.has('other')).toBe(false);
+  });
+
+  it('should keep the state of the sessions switched away from', () => {
+    const manager = new SessionManager();
+    expect(manager.switchTo('lit', state('main prompt'))).toBeUndefined();
+    expect(manager.getCurrentName()).toBe('lit');
+
+    expect(manager.switchTo('main', state('lit prompt', 'other'))).toEqual(
+      state('main prompt'),
+    );
+    expect(manager.switchTo('lit', state('main prompt'))).toEqual(
+      state('lit prompt', 'other'),
+    );
+  });
+
+  it('should list the current session first', () => {
+    const manager = new SessionManager();
+    manager.switchTo('lit', state('main prompt'));
+    expect(
+      manager.list({ items: [{ type: 'user', text: 'Hi' }], model: 'fast' }),
+    ).toMatchObject([
+      { name: 'lit', current: true, messageCount: 1, model: 'fast' },
+      {
+        name: 'main',
+        current: false,
+        messageCount: 2,
+        model: 'research-pro',
+      },
+    ]);
+  });
+
+  it('should only delete sessions other than the current one', () => {
+    const manager = new SessionManager();
+    manager.switchTo('lit', state('main prompt'));
+    expect(manager.delete('lit')).toBe(false);
+    expect(manager.delete('main')).toBe(true);
+    expect(manager.has('main')).toBe(false);
+  });
+});
@@ -0,0 +1,180 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { Content } from '@google/genai';
+import { GenerationOptions } from '@iechor/research-cli-core';
+import { HistoryItemWithoutId } from '../types.js';
+
+/** The session every run starts in. */
+export const DEFAULT_SESSION_NAME = 'main';
+
+// Names end up in file names of the session store
+const SESSION_NAME_REGEX = /^[\w.-]{1,40}$/;
+
+/** Everything a session needs to pick up where it was left. */
+export interface SessionState {
+  /** The conversation as the model sees it. */
+  chatHistory: Content[];
+  /** The conversation as it was on screen. */
+  items: HistoryItemWithoutId[];
+  model: string;
+  generationOptions: GenerationOptions;
+}
+
+export interface NamedSession extends SessionState {
+  name: string;
+  lastUsed: Date;
+}
+
+export interface SessionSummary {
+  name: string;
+  current: boolean;
+  messageCount: number;
+  model: string;
+  lastUsed: Date;
+}
+
+export function isValidSessionName(name: string): boolean {
+  return SESSION_NAME_REGEX.test(name);
+}
+
+/** The prompts and answers among the items, without the split pieces. */
+export function countMessages(items: HistoryItemWithoutId[]): number {
+  return items.filter(
+    (item) => item.type === 'user' || item.type === 'research',
+  ).length;
+}
+
+/**
+ * Where the messages of a named session are written by the session store,
+ * so each can be brought back on its own with /resume.
+ */
+export function getSessionStoreId(sessionId: string, name: string): string {
+  return name === DEFAULT_SESSION_NAME ? sessionId : `${sessionId}-${name}`;
+}
+
+/**
+ * How well `query` matches `text` when its characters appear in order,
+ * lower being better, or undefined when they do not. Characters found
+ * next to each other and at the start count the most.
+ */
+export function fuzzyScore(query: string, text: string): number | undefined {
+  const lowerQuery = query.toLowerCase();
+  const lowerText = text.toLowerCase();
+  let score = 0;
+  let position = -1;
+  for (const char of lowerQuery) {
+    const found = lowerText.indexOf(char, position + 1);
+    if (found === -1) {
+      return undefined;
+    }
+    score += found - position - 1;
+    position = found;
+  }
+  return lowerText.startsWith(lowerQuery) ? score - 1 : score;
+}
+
+/** The items matching `query`, the best match first. */
+export function fuzzyFilter<T>(
+  items: T[],
+  query: string,
+  getText: (item: T) => string,
+): T[] {
+  if (!query) {
+    return items;
+  }
+  return items
+    .map((item, index) => ({
+      item,
+      index,
+      score: fuzzyScore(query, getText(item)),
+    }))
+    .filter(
+      (match): match is { item: T; index: number; score: number } =>
+        match.score !== undefined,
+    )
+    .sort((a, b) => a.score - b.score || a.index - b.index)
+    .map((match) => match.item);
+}
+
+/**
+ * Keeps the sessions that are not on screen for the life of the process.
+ * The current session lives in the chat and the history, and only its
+ * name is kept here until another one is switched to.
+ */
+export class SessionManager {
+  private readonly sessions = new Map<string, NamedSession>();
+  private currentName = DEFAULT_SESSION_NAME;
+  private currentSince = new Date();
+
+  getCurrentName(): string {
+    return this.currentName;
+  }
+
+  has(name: string): boolean {
+    return name === this.currentName || this.sessions.has(name);
+  }
+
+  /**
+   * The sessions, the current one first and then the most recently used,
+   * with `current` being the state on screen.
+   */
+  list(current: Pick<SessionState, 'items' | 'model'>): SessionSummary[] {
+    const others = [...this.sessions.values()]
+      .sort((a, b) => b.lastUsed.getTime() - a.lastUsed.getTime())
+      .map((session) => ({
+        name: session.name,
+        current: false,
+        messageCount: countMessages(session.items),
+        model: session.model,
+        lastUsed: session.lastUsed,
+      }));
+    return [
+      {
+        name: this.currentName,
+        current: true,
+        messageCount: countMessages(current.items),
+        model: current.model,
+        lastUsed: this.currentSince,
+      },
+      ...others,
+    ];
+  }
+
+  /**
+   * Keeps `current` as the state of the current session and makes `name`
+   * the current one. Returns the state `name` was left in, or undefined
+   * when it is a new session.
+   */
+  switchTo(name: string, current: SessionState): SessionState | undefined {
+    const now = new Date();
+    this.sessions.set(this.currentName, {
+      ...current,
+      name: this.currentName,
+      lastUsed: now,
+    });
+    const target = this.sessions.get(name);
+    this.sessions.delete(name);
+    this.currentName = name;
+    this.currentSince = now;
+    return target;
+  }
+
+  /** Forgets a session other than the current one; false if there is none. */
+  delete(name: string): boolean {
+    return this.sessions.delete(name);
+  }
+}
+
+let sessionManager: SessionManager | undefined;
+
+/** The named sessions of this run. */
+export function getSessionManager(): SessionManager {
+  if (!sessionManager) {
+    sessionManager = new SessionManager();
+  }
+  return sessionManager;
+}
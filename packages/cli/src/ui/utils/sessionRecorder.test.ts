@@ -0,0 +1,120 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, vi, beforeEach, afterEach } from 'vitest';
+import { EventEmitter } from 'node:events';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { RecordedStream, SessionRecorder } from './sessionRecorder.js';
+
+describe('SessionRecorder', () => {
+  let tempDir: string;
+  let filePath: string;
+  let written: string[];
+  let stream: RecordedStream & EventEmitter;
+
+  const readCast = () =>
+    fs
+      .readFileSync(filePath, 'utf8')
+      .trim()
+      .split('\n')
+      .map((line) => JSON.parse(line));
+
+  beforeEach(() => {
+    vi.useFakeTimers();
+    vi.setSystemTime(new Date('2026-10-16T09:00:00Z'));
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'session-recorder-'));
+    filePath = path.join(tempDir, 'demo', 'session.cast');
+    written = [];
+    stream = Object.assign(new EventEmitter(), {
+      columns: 100,
+      rows: 30,
+      write: (chunk: string) => {
+        written.push(chunk);
+        return true;
+      },
+    }) as unknown as RecordedStream & EventEmitter;
+  });
+
+  afterEach(() => {
+    vi.useRealTimers();
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should write an asciicast of what is drawn', () => {
+    const recorder = new SessionRecorder();
+    recorder.start(filePath, stream, { title: 'Demo' });
+
+    stream.write('hello');
+    vi.advanceTimersByTime(1500);
+    stream.columns = 80;
+    stream.rows = 20;
+    stream.emit('resize');
+    stream.write(Buffer.from('\x1b[2Kbye') as unknown as string);
+    vi.advanceTimersByTime(500);
+    const summary = recorder.stop();
+
+    expect(written).toEqual(['hello', Buffer.from('\x1b[2Kbye')]);
+    expect(summary).toEqual({ filePath, duration: 2, events: 3 });
+    expect(readCast()).toEqual([
+      {
+        version: 2,
+        width: 100,
+        height: 30,
+        timestamp: Date.parse('2026-10-16T09:00:00Z') / 1000,
+        env: { SHELL: process.env.SHELL, TERM: process.env.TERM },
+        title: 'Demo',
+      },
+      [0, 'o', 'hello'],
+      [1.5, 'r', '80x20'],
+      [1.5, 'o', '\x1b[2Kbye'],
+    ]);
+  });
+
+  it('should stop capturing when stopped', () => {
+    const recorder = new SessionRecorder();
+    recorder.start(filePath, stream);
+    recorder.stop();
+
+    stream.write('after');
+    stream.emit('resize');
+
+    expect(recorder.isRecording()).toBe(false);
+    expect(readCast()).toHaveLength(1);
+    expect(written).toEqual(['after']);
+  });
+
+  it('should keep a wrapper installed after it when stopped', () => {
+    const recorder = new SessionRecorder();
+    recorder.start(filePath, stream);
+    const recordingWrite = stream.write;
+    const wrapper = ((chunk: string) =>
+      recordingWrite.call(stream, `[${chunk}]`)) as RecordedStream['write'];
+    stream.write = wrapper;
+    recorder.stop();
+
+    stream.write('after');
+
+    expect(stream.write).toBe(wrapper);
+    expect(readCast()).toHaveLength(1);
+    expect(written).toEqual(['[after]']);
+  });
+
+  it('should not start a second recording', () => {
+    const recorder = new SessionRecorder();
+    recorder.start(filePath, stream);
+
+    expect(() => recorder.start(filePath, stream)).toThrow(
+      `Already recording to ${filePath}.`,
+    );
+    recorder.stop();
+  });
+
+  it('should report nothing when stopped without a recording', () => {
+    expect(new SessionRecorder().stop()).toBeUndefined();
+  });
+});
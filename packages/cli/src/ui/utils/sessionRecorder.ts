@@ -0,0 +1,166 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+
+/** The terminal stream a recording reads from. */
+export type RecordedStream = Pick<
+  NodeJS.WriteStream,
+  'write' | 'columns' | 'rows' | 'on' | 'off'
+>;
+
+export interface RecordingSummary {
+  filePath: string;
+  /** Seconds from the start to the end of the recording. */
+  duration: number;
+  events: number;
+}
+
+export function defaultRecordingFileName(date: Date): string {
+  return `recording-${date.toISOString().slice(0, 19).replace(/[:T]/g, '-')}.cast`;
+}
+
+/**
+ * Records what is drawn on the terminal into an asciicast v2 file, which
+ * `asciinema play` and the asciinema web player can replay. The header is
+ * one JSON object, followed by one `[seconds, "o", data]` line per write
+ * and `[seconds, "r", "COLSxROWS"]` when the terminal is resized. Keys
+ * typed are not recorded, since they can include secrets.
+ */
+export class SessionRecorder {
+  private fd: number | undefined;
+  private filePath = '';
+  private startedAt = 0;
+  private events = 0;
+  private stream: RecordedStream | undefined;
+  private originalWrite: RecordedStream['write'] | undefined;
+  private recordingWrite: RecordedStream['write'] | undefined;
+  private readonly onResize = () => {
+    if (this.stream) {
+      this.writeEvent('r', `${this.stream.columns}x${this.stream.rows}`);
+    }
+  };
+
+  isRecording(): boolean {
+    return this.fd !== undefined;
+  }
+
+  getFilePath(): string | undefined {
+    return this.isRecording() ? this.filePath : undefined;
+  }
+
+  /** Seconds recorded so far. */
+  getElapsed(now = Date.now()): number {
+    return this.isRecording() ? (now - this.startedAt) / 1000 : 0;
+  }
+
+  /**
+   * Starts recording `stream` to `filePath`, replacing the file. Events are
+   * written as they happen, so a crash leaves a playable file behind.
+   */
+  start(
+    filePath: string,
+    stream: RecordedStream,
+    options: { title?: string; now?: number } = {},
+  ): void {
+    if (this.isRecording()) {
+      throw new Error(`Already recording to ${this.filePath}.`);
+    }
+    fs.mkdirSync(path.dirname(filePath), { recursive: true });
+    this.fd = fs.openSync(filePath, 'w');
+    this.filePath = filePath;
+    this.startedAt = options.now ?? Date.now();
+    this.events = 0;
+    this.stream = stream;
+    const header = {
+      version: 2,
+      width: stream.columns || 80,
+      height: stream.rows || 24,
+      timestamp: Math.floor(this.startedAt / 1000),
+      env: { SHELL: process.env.SHELL, TERM: process.env.TERM },
+      ...(options.title ? { title: options.title } : {}),
+    };
+    fs.writeSync(this.fd, `${JSON.stringify(header)}\n`);
+
+    const originalWrite = stream.write;
+    this.originalWrite = originalWrite;
+    const write = ((chunk: string | Uint8Array, ...rest: unknown[]) => {
+      this.writeEvent(
+        'o',
+        typeof chunk === 'string'
+          ? chunk
+          : Buffer.from(chunk).toString('utf8'),
+      );
+      return (originalWrite as (...args: unknown[]) => boolean).call(
+        stream,
+        chunk,
+        ...rest,
+      );
+    }) as RecordedStream['write'];
+    this.recordingWrite = write;
+    stream.write = write;
+    stream.on('resize', this.onResize);
+  }
+
+  /** Stops recording and closes the file. */
+  stop(now = Date.now()): RecordingSummary | undefined {
+    if (this.fd === undefined) {
+      return undefined;
+    }
+    if (this.stream && this.originalWrite) {
+      // Whatever wrapped the stream since keeps working; the recording
+      // write then only passes data on
+      if (this.stream.write === this.recordingWrite) {
+        this.stream.write = this.originalWrite;
+      }
+      this.stream.off('resize', this.onResize);
+    }
+    fs.closeSync(this.fd);
+    this.fd = undefined;
+    this.stream = undefined;
+    this.originalWrite = undefined;
+    this.recordingWrite = undefined;
+    return {
+      filePath: this.filePath,
+      duration: (now - this.startedAt) / 1000,
+      events: this.events,
+    };
+  }
+
+  private writeEvent(type: 'o' | 'r', data: string): void {
+    if (this.fd === undefined || data === '') {
+      return;
+    }
+    const time = Number(((Date.now() - this.startedAt) / 1000).toFixed(6));
+    try {
+      fs.writeSync(this.fd, `${JSON.stringify([time, type, data])}\n`);
+      this.events++;
+    } catch {
+      // A failed write loses the frame, not the session
+    }
+  }
+}
+
+let sessionRecorder: SessionRecorder | undefined;
+let recordedStream: RecordedStream = process.stdout;
+
+/** The recorder of this session. */
+export function getSessionRecorder(): SessionRecorder {
+  if (!sessionRecorder) {
+    sessionRecorder = new SessionRecorder();
+  }
+  return sessionRecorder;
+}
+
+/** Sets the stream the UI is drawn on, if it is not stdout. */
+export function setRecordedStream(stream: RecordedStream): void {
+  recordedStream = stream;
+}
+
+export function getRecordedStream(): RecordedStream {
+  return recordedStream;
+}
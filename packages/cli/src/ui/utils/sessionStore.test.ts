@@ -0,0 +1,148 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import {
+  SessionStore,
+  StoredMessage,
+  listSessions,
+  loadSession,
+  toChatHistory,
+  toHistoryItems,
+  toStoredMessage,
+} from './sessionStore.js';
+
+const DATE = new Date('2025-06-01T10:00:00Z');
+
+const message = (
+  role: StoredMessage['role'],
+  content: string,
+  extra: Partial<StoredMessage> = {},
+): StoredMessage => ({
+  role,
+  content,
+  timestamp: DATE.toISOString(),
+  ...extra,
+});
+
+describe('toStoredMessage', () => {
+  it('should store prompts and answers with the model', () => {
+    expect(toStoredMessage({ type: 'user', text: 'Why?' }, DATE)).toEqual(
+      message('user', 'Why?'),
+    );
+    expect(
+      toStoredMessage(
+        { type: 'research', text: 'Because.', model: 'research-pro' },
+        DATE,
+      ),
+    ).toEqual(message('model', 'Because.', { model: 'research-pro' }));
+    expect(
+      toStoredMessage({ type: 'research', text: 'Again.', seed: 42 }, DATE),
+    ).toEqual(message('model', 'Again.', { seed: 42 }));
+    expect(
+      toStoredMessage({ type: 'research_content', text: ' More.' }, DATE),
+    ).toEqual(message('model', ' More.', { continued: true }));
+  });
+
+  it('should leave out slash commands and other items', () => {
+    expect(toStoredMessage({ type: 'user', text: '/stats' }, DATE)).toBe(
+      undefined,
+    );
+    expect(toStoredMessage({ type: 'info', text: 'Saved.' }, DATE)).toBe(
+      undefined,
+    );
+  });
+});
+
+describe('SessionStore', () => {
+  let dir: string;
+
+  beforeEach(() => {
+    dir = path.join(fs.mkdtempSync(path.join(os.tmpdir(), 'sessions-')), 's');
+  });
+
+  afterEach(() => {
+    fs.rmSync(path.dirname(dir), { recursive: true, force: true });
+  });
+
+  it('should write nothing until the first message', () => {
+    new SessionStore(dir, 'empty');
+    expect(listSessions(dir)).toEqual([]);
+  });
+
+  it('should append messages and read them back', () => {
+    const store = new SessionStore(dir, 'one');
+    store.append(message('user', 'Why?'));
+    store.append(message('model', 'Because.'));
+    fs.appendFileSync(path.join(dir, 'one.jsonl'), '{"role":"us');
+
+    expect(loadSession(path.join(dir, 'one.jsonl'))).toEqual([
+      message('user', 'Why?'),
+      message('model', 'Because.'),
+    ]);
+    expect(listSessions(dir)).toMatchObject([
+      { id: 'one', messageCount: 2, title: 'Why?' },
+    ]);
+  });
+
+  it('should read a session again only once it changed', () => {
+    const store = new SessionStore(dir, 'one');
+    store.append(message('user', 'Why?'));
+    const readFileSync = vi.spyOn(fs, 'readFileSync');
+    try {
+      expect(listSessions(dir)).toMatchObject([{ messageCount: 1 }]);
+      expect(listSessions(dir)).toMatchObject([{ messageCount: 1 }]);
+      expect(readFileSync).toHaveBeenCalledTimes(1);
+
+      store.append(message('model', 'Because.'));
+      expect(listSessions(dir)).toMatchObject([{ messageCount: 2 }]);
+      expect(readFileSync).toHaveBeenCalledTimes(2);
+    } finally {
+      readFileSync.mockRestore();
+    }
+  });
+
+  it('should delete the oldest sessions beyond the maximum', () => {
+    for (const [i, id] of ['a', 'b', 'c'].entries()) {
+      new SessionStore(dir, id).append(message('user', id));
+      const time = new Date(DATE.getTime() + i * 1000);
+      fs.utimesSync(path.join(dir, `${id}.jsonl`), time, time);
+    }
+    new SessionStore(dir, 'd', 3).append(message('user', 'd'));
+
+    expect(
+      listSessions(dir)
+        .map((session) => session.id)
+        .sort(),
+    ).toEqual(['b', 'c', 'd']);
+  });
+});
+
+describe('toChatHistory', () => {
+  it('should join split answers and turns from the same side', () => {
+    const messages = [
+      message('user', 'First?'),
+      message('user', 'Second?'),
+      message('model', 'One', { model: 'research-pro' }),
+      message('model', ' two.', { continued: true }),
+      message('model', 'After the tool.'),
+    ];
+    expect(toChatHistory(messages)).toEqual([
+      { role: 'user', parts: [{ text: 'First?\n\nSecond?' }] },
+      { role: 'model', parts: [{ text: 'One two.\n\nAfter the tool.' }] },
+    ]);
+    expect(toHistoryItems(messages)).toEqual([
+      { type: 'user', text: 'First?' },
+      { type: 'user', text: 'Second?' },
+      { type: 'research', text: 'One', model: 'research-pro' },
+      { type: 'research_content', text: ' two.' },
+      { type: 'research', text: 'After the tool.', model: undefined },
+    ]);
+  });
+});
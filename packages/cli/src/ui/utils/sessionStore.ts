@@ -0,0 +1,231 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import { Content } from '@google/genai';
+import { getRedactor, getUserResearchDir } from '@iechor/research-cli-core';
+import { HistoryItemWithoutId, MessageType } from '../types.js';
+import { isSlashCommand } from './commandUtils.js';
+
+/** One message of a stored session, a line of its JSONL file. */
+export interface StoredMessage {
+  role: 'user' | 'model';
+  content: string;
+  /** ISO time the message was shown. */
+  timestamp: string;
+  /** Model that wrote a model message. */
+  model?: string;
+  /** Sampling seed a model message was asked for with, set by /set seed. */
+  seed?: number;
+  /** Continues the message before it, which was split while streaming. */
+  continued?: boolean;
+}
+
+export interface StoredSessionInfo {
+  id: string;
+  filePath: string;
+  modified: Date;
+  messageCount: number;
+  /** The first prompt, to recognize the session by. */
+  title: string;
+}
+
+export const DEFAULT_MAX_SESSIONS = 100;
+
+const SESSION_FILE_SUFFIX = '.jsonl';
+
+export function getSessionsDir(): string {
+  return path.join(getUserResearchDir(), 'sessions');
+}
+
+/**
+ * The message a history item is stored as, or undefined for the items
+ * that are not part of the conversation, such as slash commands, tool
+ * output and info.
+ */
+export function toStoredMessage(
+  item: HistoryItemWithoutId,
+  date: Date,
+): StoredMessage | undefined {
+  if (
+    item.type !== 'user' &&
+    item.type !== 'research' &&
+    item.type !== 'research_content'
+  ) {
+    return undefined;
+  }
+  if (item.type === 'user' && isSlashCommand(item.text)) {
+    return undefined;
+  }
+  const message: StoredMessage = {
+    role: item.type === 'user' ? 'user' : 'model',
+    content: getRedactor().redactText(item.text).value,
+    timestamp: date.toISOString(),
+  };
+  if (item.type === 'research' && item.model) {
+    message.model = item.model;
+  }
+  if (item.type === 'research' && item.seed !== undefined) {
+    message.seed = item.seed;
+  }
+  if (item.type === 'research_content') {
+    message.continued = true;
+  }
+  return message;
+}
+
+/**
+ * Writes the messages of one session to `<dir>/<sessionId>.jsonl` as the
+ * conversation goes on, one JSON line each, so a crash loses nothing.
+ * The file is only created with the first message, and then the oldest
+ * sessions beyond `maxSessions` are deleted.
+ */
+export class SessionStore {
+  private readonly filePath: string;
+  private started = false;
+
+  constructor(
+    private readonly dir: string,
+    readonly sessionId: string,
+    private readonly maxSessions = DEFAULT_MAX_SESSIONS,
+  ) {
+    this.filePath = path.join(dir, `${sessionId}${SESSION_FILE_SUFFIX}`);
+  }
+
+  append(message: StoredMessage): void {
+    if (!this.started) {
+      fs.mkdirSync(this.dir, { recursive: true });
+      this.started = true;
+      this.prune();
+    }
+    fs.appendFileSync(this.filePath, `${JSON.stringify(message)}\n`);
+  }
+
+  private prune(): void {
+    const older = listSessions(this.dir).filter(
+      (session) => session.id !== this.sessionId,
+    );
+    // This session makes one more
+    for (const session of older.slice(Math.max(this.maxSessions - 1, 0))) {
+      fs.rmSync(session.filePath, { force: true });
+      sessionInfoCache.delete(session.filePath);
+    }
+  }
+}
+
+export function loadSession(filePath: string): StoredMessage[] {
+  const messages: StoredMessage[] = [];
+  for (const line of fs.readFileSync(filePath, 'utf8').split('\n')) {
+    if (!line.trim()) {
+      continue;
+    }
+    try {
+      messages.push(JSON.parse(line) as StoredMessage);
+    } catch {
+      // A line cut short when the terminal was killed mid-write
+    }
+  }
+  return messages;
+}
+
+// What was read of each session file, until the file changes. Listing
+// runs on every keystroke of /resume completion, and reading every
+// session each time would be slow with many long ones.
+const sessionInfoCache = new Map<
+  string,
+  { mtimeMs: number; size: number; info: StoredSessionInfo }
+>();
+
+function readSessionInfo(filePath: string, id: string): StoredSessionInfo {
+  const stats = fs.statSync(filePath);
+  const cached = sessionInfoCache.get(filePath);
+  if (cached?.mtimeMs === stats.mtimeMs && cached.size === stats.size) {
+    return cached.info;
+  }
+  const messages = loadSession(filePath);
+  const firstPrompt = messages.find((message) => message.role === 'user');
+  const info: StoredSessionInfo = {
+    id,
+    filePath,
+    modified: stats.mtime,
+    messageCount: messages.filter((message) => !message.continued).length,
+    title: firstPrompt?.content.split('\n')[0] ?? '',
+  };
+  sessionInfoCache.set(filePath, {
+    mtimeMs: stats.mtimeMs,
+    size: stats.size,
+    info,
+  });
+  return info;
+}
+
+/** The stored sessions in `dir`, the most recently written first. */
+export function listSessions(dir: string): StoredSessionInfo[] {
+  let files: string[];
+  try {
+    files = fs.readdirSync(dir);
+  } catch {
+    return [];
+  }
+  const sessions: StoredSessionInfo[] = [];
+  for (const file of files) {
+    if (!file.endsWith(SESSION_FILE_SUFFIX)) {
+      continue;
+    }
+    const filePath = path.join(dir, file);
+    try {
+      sessions.push(
+        readSessionInfo(filePath, file.slice(0, -SESSION_FILE_SUFFIX.length)),
+      );
+    } catch {
+      // Deleted while listing
+      sessionInfoCache.delete(filePath);
+    }
+  }
+  return sessions.sort((a, b) => b.modified.getTime() - a.modified.getTime());
+}
+
+/**
+ * The stored messages as a conversation for the model. The pieces of a
+ * message split while streaming are joined up again, and messages in a
+ * row from the same side, such as the answers around a tool call or a
+ * prompt that got no answer, become one turn.
+ */
+export function toChatHistory(messages: StoredMessage[]): Content[] {
+  const history: Content[] = [];
+  for (const message of messages) {
+    const last = history[history.length - 1];
+    const lastPart = last?.role === message.role ? last.parts?.[0] : undefined;
+    if (lastPart) {
+      const separator = message.continued ? '' : '\n\n';
+      lastPart.text = `${lastPart.text ?? ''}${separator}${message.content}`;
+    } else {
+      history.push({ role: message.role, parts: [{ text: message.content }] });
+    }
+  }
+  return history;
+}
+
+/** The stored messages as items to show, as they were shown before. */
+export function toHistoryItems(
+  messages: StoredMessage[],
+): HistoryItemWithoutId[] {
+  return messages.map((message): HistoryItemWithoutId => {
+    if (message.role === 'user') {
+      return { type: MessageType.USER, text: message.content };
+    }
+    if (message.continued) {
+      return { type: 'research_content', text: message.content };
+    }
+    return {
+      type: MessageType.RESEARCH,
+      text: message.content,
+      model: message.model,
+      seed: message.seed,
+    };
+  });
+}
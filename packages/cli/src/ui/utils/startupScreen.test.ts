@@ -0,0 +1,127 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, vi, beforeEach } from 'vitest';
+import {
+  loadDeadlines,
+  loadUsageHistory,
+  UsageRecord,
+} from '@iechor/research-cli-core';
+import {
+  composeStartupScreen,
+  findLastSession,
+  formatLastSession,
+  registerStartupProvider,
+} from './startupScreen.js';
+
+vi.mock('@iechor/research-cli-core', async (importOriginal) => ({
+  ...(await importOriginal<typeof import('@iechor/research-cli-core')>()),
+  loadDeadlines: vi.fn(),
+  loadUsageHistory: vi.fn(),
+}));
+
+const prompt = (
+  session: string,
+  timestamp: string,
+  project = '/p',
+): UsageRecord => ({ kind: 'prompt', timestamp, project, session });
+
+describe('startupScreen', () => {
+  const now = new Date(2026, 9, 16, 9, 0);
+  const context = { projectRoot: '/p', sessionId: 'current', now };
+
+  beforeEach(() => {
+    vi.mocked(loadDeadlines).mockResolvedValue([]);
+    vi.mocked(loadUsageHistory).mockResolvedValue([]);
+  });
+
+  it('should find the latest earlier session of the project', () => {
+    const records = [
+      prompt('a', '2026-10-13T10:00:00Z'),
+      prompt('b', '2026-10-14T10:00:00Z'),
+      prompt('c', '2026-10-15T10:00:00Z', '/other'),
+      prompt('current', '2026-10-16T08:00:00Z'),
+      prompt('a', '2026-10-13T11:00:00Z'),
+    ];
+
+    expect(findLastSession(records, '/p', 'current')).toEqual([records[1]]);
+  });
+
+  it('should summarize a session', () => {
+    const text = formatLastSession([
+      prompt('a', '2026-10-14T10:00:00Z'),
+      {
+        kind: 'tool',
+        timestamp: '2026-10-14T10:05:00Z',
+        tool: 'read_file',
+        durationMs: 5,
+        success: true,
+        project: '/p',
+        session: 'a',
+      },
+      prompt('a', '2026-10-14T10:30:00Z'),
+    ]);
+
+    expect(text).toMatch(/^Last session: /);
+    expect(text).toContain(': 2 messages, 1 tool call.');
+  });
+
+  it('should compose the sections in the order given', async () => {
+    vi.mocked(loadDeadlines).mockResolvedValue([
+      {
+        id: '1',
+        title: 'ICML abstract',
+        due: new Date(2026, 9, 16, 14, 0).toISOString(),
+        kind: 'conference',
+      },
+      {
+        id: '2',
+        title: 'Grant report',
+        due: new Date(2026, 9, 20).toISOString(),
+        kind: 'grant',
+      },
+    ]);
+
+    const { sections } = await composeStartupScreen(
+      { ...context, motd: 'Group meeting at 11.' },
+      ['deadlines', 'motd', 'last-session'],
+    );
+
+    expect(sections).toEqual([
+      { id: 'deadlines', lines: ['Due today:', '  ICML abstract (in 5h 0m)'] },
+      { id: 'motd', lines: ['Group meeting at 11.'] },
+    ]);
+  });
+
+  it('should show a tip by default', async () => {
+    const { sections } = await composeStartupScreen(context);
+
+    expect(sections.map((s) => s.id)).toEqual(['tip']);
+    expect(sections[0].lines[0]).toMatch(/^Tip of the day: /);
+  });
+
+  it('should use registered providers and report unknown ones', async () => {
+    registerStartupProvider({
+      id: 'arxiv',
+      description: 'New papers.',
+      compose: async () => ['3 new papers in cs.CL.'],
+    });
+    registerStartupProvider({
+      id: 'broken',
+      description: 'Always fails.',
+      compose: async () => {
+        throw new Error('offline');
+      },
+    });
+
+    expect(
+      await composeStartupScreen(context, ['arxiv', 'broken', 'weather']),
+    ).toEqual({
+      sections: [{ id: 'arxiv', lines: ['3 new papers in cs.CL.'] }],
+      unknown: ['weather'],
+    });
+  });
+});
@@ -0,0 +1,196 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import {
+  dayOf,
+  formatCountdown,
+  loadDeadlines,
+  loadUsageHistory,
+  UsageRecord,
+} from '@iechor/research-cli-core';
+
+/** What a startup provider gets to compose its section from. */
+export interface StartupContext {
+  projectRoot: string;
+  /** The session being started, so it is not mistaken for the last one. */
+  sessionId: string;
+  /** The `motd` setting. */
+  motd?: string;
+  now: Date;
+}
+
+/**
+ * One section of the startup screen. `compose` returns the lines to show,
+ * or nothing to leave the section out.
+ */
+export interface StartupProvider {
+  id: string;
+  description: string;
+  compose(context: StartupContext): Promise<string[] | undefined>;
+}
+
+export interface StartupSection {
+  id: string;
+  lines: string[];
+}
+
+export const DEFAULT_STARTUP_SECTIONS = [
+  'motd',
+  'last-session',
+  'deadlines',
+  'tip',
+];
+
+const TIPS = [
+  'Plan a write-up with /outline and draft it section by section with /outline draft next.',
+  'Time a writing sprint with /focus start; the countdown shows in the status bar.',
+  'Press Ctrl+Z to zoom the focused pane to the whole terminal, and again to restore the layout.',
+  'Add conference and grant deadlines with /deadlines; the footer warns when one comes close.',
+  'Keep the names of the project consistent with .research/terminology.json; /terminology shows the rules.',
+  'See your messages, tokens and streaks of the last weeks with /dashboard.',
+  'Reference a file in a prompt with @path/to/file, or run a shell command with !.',
+  'Load earlier messages of a long session with /history older.',
+  'Ask the model to remember a fact, and review what it saved with /memory list.',
+  'Press Ctrl+O to show the debug console, and Ctrl+T to show tool descriptions.',
+];
+
+const providers = new Map<string, StartupProvider>();
+
+/** Adds a section that the `startupScreen.sections` setting can list. */
+export function registerStartupProvider(provider: StartupProvider): void {
+  providers.set(provider.id, provider);
+}
+
+export function getStartupProviders(): StartupProvider[] {
+  return [...providers.values()];
+}
+
+function formatTime(date: Date): string {
+  return date.toLocaleTimeString([], { hour: '2-digit', minute: '2-digit' });
+}
+
+/** The most recent session of the project other than the current one. */
+export function findLastSession(
+  records: UsageRecord[],
+  projectRoot: string,
+  currentSession: string,
+): UsageRecord[] {
+  const sessions = new Map<string, UsageRecord[]>();
+  for (const record of records) {
+    if (
+      record.project !== projectRoot ||
+      !record.session ||
+      record.session === currentSession
+    ) {
+      continue;
+    }
+    const session = sessions.get(record.session) ?? [];
+    session.push(record);
+    sessions.set(record.session, session);
+  }
+  let last: UsageRecord[] = [];
+  for (const session of sessions.values()) {
+    if (
+      last.length === 0 ||
+      session[session.length - 1].timestamp > last[last.length - 1].timestamp
+    ) {
+      last = session;
+    }
+  }
+  return last;
+}
+
+/** "Tue, Oct 14, 14:02–14:47: 12 messages, 8 tool calls." */
+export function formatLastSession(session: UsageRecord[]): string {
+  const start = new Date(session[0].timestamp);
+  const end = new Date(session[session.length - 1].timestamp);
+  const messages = session.filter((r) => r.kind === 'prompt').length;
+  const tools = session.filter((r) => r.kind === 'tool').length;
+  const day = start.toLocaleDateString([], {
+    weekday: 'short',
+    month: 'short',
+    day: 'numeric',
+  });
+  return `Last session: ${day}, ${formatTime(start)}–${formatTime(end)}: ${messages} message${messages === 1 ? '' : 's'}, ${tools} tool call${tools === 1 ? '' : 's'}.`;
+}
+
+registerStartupProvider({
+  id: 'motd',
+  description: 'The message of the day from the `startupScreen.motd` setting.',
+  compose: async ({ motd }) => (motd?.trim() ? motd.split('\n') : undefined),
+});
+
+registerStartupProvider({
+  id: 'last-session',
+  description: 'When the last session in this project was, and how busy.',
+  compose: async ({ projectRoot, sessionId }) => {
+    const session = findLastSession(
+      await loadUsageHistory(),
+      projectRoot,
+      sessionId,
+    );
+    return session.length > 0 ? [formatLastSession(session)] : undefined;
+  },
+});
+
+registerStartupProvider({
+  id: 'deadlines',
+  description: 'Deadlines from /deadlines that are due today.',
+  compose: async ({ now }) => {
+    const today = (await loadDeadlines()).filter(
+      (d) => dayOf(d.due) === dayOf(now) && Date.parse(d.due) >= now.getTime(),
+    );
+    return today.length > 0
+      ? [
+          'Due today:',
+          ...today.map(
+            (d) => `  ${d.title} (in ${formatCountdown(d.due, now)})`,
+          ),
+        ]
+      : undefined;
+  },
+});
+
+registerStartupProvider({
+  id: 'tip',
+  description: 'A tip of the day, a different one each day.',
+  compose: async ({ now }) => {
+    const day = Math.floor(
+      (now.getTime() - now.getTimezoneOffset() * 60 * 1000) /
+        (24 * 60 * 60 * 1000),
+    );
+    return [`Tip of the day: ${TIPS[day % TIPS.length]}`];
+  },
+});
+
+/**
+ * Composes the listed sections in order. A section whose provider fails is
+ * left out rather than holding up the start; ids without a provider are
+ * returned so they can be reported.
+ */
+export async function composeStartupScreen(
+  context: StartupContext,
+  sectionIds: string[] = DEFAULT_STARTUP_SECTIONS,
+): Promise<{ sections: StartupSection[]; unknown: string[] }> {
+  const unknown = sectionIds.filter((id) => !providers.has(id));
+  const composed = await Promise.all(
+    sectionIds
+      .filter((id) => providers.has(id))
+      .map(async (id) => {
+        try {
+          const lines = await providers.get(id)!.compose(context);
+          return lines && lines.length > 0 ? { id, lines } : undefined;
+        } catch (e) {
+          console.debug(`Could not compose the ${id} startup section:`, e);
+          return undefined;
+        }
+      }),
+  );
+  return {
+    sections: composed.filter((s): s is StartupSection => s !== undefined),
+    unknown,
+  };
+}
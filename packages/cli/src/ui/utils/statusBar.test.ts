@@ -0,0 +1,168 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect } from 'vitest';
+import {
+  buildStatusSegments,
+  canShowGlyphs,
+  resolveStatusBar,
+  STATUS_ICONS,
+} from './statusBar.js';
+import { darkTheme } from '../themes/theme.js';
+
+const UTF8 = { LANG: 'en_US.UTF-8', TERM: 'xterm-256color' };
+
+describe('canShowGlyphs', () => {
+  it('should accept UTF-8 terminals', () => {
+    expect(canShowGlyphs(UTF8, 'linux')).toBe(true);
+    expect(canShowGlyphs({}, 'darwin')).toBe(true);
+  });
+
+  it('should reject the Linux console and other locales', () => {
+    expect(canShowGlyphs({ ...UTF8, TERM: 'linux' }, 'linux')).toBe(false);
+    expect(canShowGlyphs({ LANG: 'C' }, 'linux')).toBe(false);
+    expect(canShowGlyphs({ LC_ALL: 'de_DE.ISO-8859-1' }, 'linux')).toBe(false);
+  });
+
+  it('should only trust Windows Terminal and VS Code on Windows', () => {
+    expect(canShowGlyphs({}, 'win32')).toBe(false);
+    expect(canShowGlyphs({ WT_SESSION: 'abc' }, 'win32')).toBe(true);
+  });
+});
+
+describe('resolveStatusBar', () => {
+  it('should keep the plain footer by default', () => {
+    expect(resolveStatusBar(undefined, 'Default', darkTheme, UTF8).style).toBe(
+      'plain',
+    );
+  });
+
+  it('should draw powerline segments once the font is confirmed', () => {
+    const statusBar = resolveStatusBar(
+      { style: 'powerline', nerdFont: true },
+      'Default',
+      darkTheme,
+      UTF8,
+      'linux',
+    );
+
+    expect(statusBar).toEqual({
+      style: 'powerline',
+      segmentColors: [
+        darkTheme.AccentBlue,
+        darkTheme.AccentPurple,
+        darkTheme.AccentCyan,
+        darkTheme.AccentGreen,
+      ],
+      textColor: darkTheme.Background,
+    });
+  });
+
+  it('should fall back to ASCII without a confirmed font', () => {
+    expect(
+      resolveStatusBar({ style: 'powerline' }, 'Default', darkTheme, UTF8)
+        .style,
+    ).toBe('ascii');
+  });
+
+  it('should fall back to ASCII where the glyphs cannot be shown', () => {
+    const settings = { style: 'powerline' as const, nerdFont: true };
+
+    expect(
+      resolveStatusBar(settings, 'Default', darkTheme, { LANG: 'C' }, 'linux')
+        .style,
+    ).toBe('ascii');
+    expect(
+      resolveStatusBar(settings, 'No Color', darkTheme, UTF8, 'linux').style,
+    ).toBe('ascii');
+  });
+
+  it('should let a theme override the style and colors', () => {
+    const settings = {
+      style: 'powerline' as const,
+      nerdFont: true,
+      textColor: '#000000',
+      themes: {
+        'GitHub Light': { style: 'plain' as const },
+        Dracula: { segmentColors: ['#BD93F9'], textColor: '#282A36' },
+      },
+    };
+
+    expect(
+      resolveStatusBar(settings, 'Dracula', darkTheme, UTF8, 'linux'),
+    ).toEqual({
+      style: 'powerline',
+      segmentColors: ['#BD93F9'],
+      textColor: '#282A36',
+    });
+    expect(
+      resolveStatusBar(settings, 'GitHub Light', darkTheme, UTF8, 'linux')
+        .style,
+    ).toBe('plain');
+    expect(
+      resolveStatusBar(settings, 'Default', darkTheme, UTF8, 'linux')
+        .textColor,
+    ).toBe('#000000');
+  });
+});
+
+describe('buildStatusSegments', () => {
+  it('should only add the segments that have something to show', () => {
+    const { left, right } = buildStatusSegments({
+      path: '~/paper',
+      model: 'research-pro',
+      contextLeftPercent: 97,
+    });
+
+    expect(left).toEqual([{ icon: STATUS_ICONS.folder, text: '~/paper' }]);
+    expect(right).toEqual([
+      { icon: STATUS_ICONS.model, text: 'research-pro 97% context left' },
+    ]);
+  });
+
+  it('should show the branch, state and warnings', () => {
+    const { left, right } = buildStatusSegments({
+      path: '~/paper',
+      branchName: 'main',
+      incognito: true,
+      model: 'research-pro',
+      fallbackModel: 'research-flash',
+      contextLeftPercent: 50,
+      redactionCount: 2,
+      deadlineWarning: 'NeurIPS in 3 days',
+      errorCount: 1,
+    });
+
+    expect(left.map((s) => s.text)).toEqual(['~/paper', 'main*', 'incognito']);
+    expect(right.map((s) => s.text)).toEqual([
+      'NeurIPS in 3 days',
+      '2 redacted',
+      '1 error',
+      'research-pro -> research-flash 50% context left',
+    ]);
+  });
+
+  it('should show the sandbox, corgi and memory use', () => {
+    const { left, right } = buildStatusSegments({
+      path: '~/paper',
+      sandbox: 'no sandbox (see /docs)',
+      model: 'research-pro',
+      contextLeftPercent: 80,
+      corgiMode: true,
+      memoryUsage: '210.5 MB',
+    });
+
+    expect(left.map((s) => s.text)).toEqual([
+      '~/paper',
+      'no sandbox (see /docs)',
+    ]);
+    expect(right.map((s) => s.text)).toEqual([
+      'research-pro 80% context left',
+      '▼(´ᴥ`)▼',
+      '210.5 MB',
+    ]);
+  });
+});
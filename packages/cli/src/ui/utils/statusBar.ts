@@ -0,0 +1,187 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import {
+  StatusBarSettings,
+  StatusBarThemeSettings,
+} from '../../config/settings.js';
+import { ColorsTheme } from '../themes/theme.js';
+
+/**
+ * `plain` is the classic footer, `powerline` draws segments joined by
+ * powerline separators with Nerd Font icons, and `ascii` draws the same
+ * segments with ASCII separators where the glyphs cannot be shown.
+ */
+export type StatusBarStyle = 'plain' | 'powerline' | 'ascii';
+
+export interface ResolvedStatusBar {
+  style: StatusBarStyle;
+  /** Backgrounds of the segments, repeated when there are more segments. */
+  segmentColors: string[];
+  textColor: string;
+}
+
+export interface StatusSegment {
+  text: string;
+  /** Nerd Font icon, only drawn in the powerline style. */
+  icon: string;
+}
+
+export const POWERLINE_GLYPHS = {
+  right: '\ue0b0',
+  left: '\ue0b2',
+};
+
+// Nerd Font codepoints from the Font Awesome set, which every patched font has
+export const STATUS_ICONS = {
+  folder: '\uf07c',
+  branch: '\ue0a0',
+  model: '\uf2db',
+  sandbox: '\uf132',
+  incognito: '\uf070',
+  debug: '\uf188',
+  redaction: '\uf023',
+  deadline: '\uf017',
+  focus: '\uf140',
+  error: '\uf071',
+  corgi: '\uf1b0',
+  memory: '\uf0e4',
+};
+
+// The theme without colors has no backgrounds to draw segments with
+const NO_COLOR_THEME_NAME = 'No Color';
+
+/**
+ * Whether the terminal can show the powerline and Nerd Font glyphs at all,
+ * given that the font has them: the Linux virtual console has no such
+ * glyphs, a locale other than UTF-8 garbles them, and the legacy Windows
+ * console host does not draw private use characters.
+ */
+export function canShowGlyphs(
+  env: NodeJS.ProcessEnv = process.env,
+  platform: NodeJS.Platform = process.platform,
+): boolean {
+  if (env.TERM === 'linux' || env.TERM === 'dumb') {
+    return false;
+  }
+  if (platform === 'win32') {
+    return !!env.WT_SESSION || env.TERM_PROGRAM === 'vscode';
+  }
+  const locale = env.LC_ALL || env.LC_CTYPE || env.LANG;
+  return !locale || /utf-?8/i.test(locale);
+}
+
+/**
+ * Picks the status bar style and colors for a theme. The powerline style
+ * needs `nerdFont` confirmed in the settings and a terminal that can show
+ * the glyphs, and falls back to ASCII otherwise.
+ */
+export function resolveStatusBar(
+  settings: StatusBarSettings | undefined,
+  themeName: string,
+  colors: ColorsTheme,
+  env: NodeJS.ProcessEnv = process.env,
+  platform: NodeJS.Platform = process.platform,
+): ResolvedStatusBar {
+  const themeSettings: StatusBarThemeSettings =
+    settings?.themes?.[themeName] ?? {};
+  const requested = themeSettings.style ?? settings?.style ?? 'plain';
+  const segmentColors = themeSettings.segmentColors ??
+    settings?.segmentColors ?? [
+      colors.AccentBlue,
+      colors.AccentPurple,
+      colors.AccentCyan,
+      colors.AccentGreen,
+    ];
+  const textColor =
+    themeSettings.textColor ?? settings?.textColor ?? colors.Background;
+
+  let style: StatusBarStyle = requested;
+  if (
+    requested === 'powerline' &&
+    (!settings?.nerdFont ||
+      themeName === NO_COLOR_THEME_NAME ||
+      segmentColors.length === 0 ||
+      !canShowGlyphs(env, platform))
+  ) {
+    style = 'ascii';
+  }
+  return { style, segmentColors, textColor };
+}
+
+export interface StatusSegmentInput {
+  path: string;
+  branchName?: string;
+  sandbox?: string;
+  incognito?: boolean;
+  debugMessage?: string;
+  model: string;
+  fallbackModel?: string;
+  contextLeftPercent: number;
+  redactionCount?: number;
+  deadlineWarning?: string;
+  focusStatus?: string;
+  errorCount?: number;
+  corgiMode?: boolean;
+  /** Formatted memory use of the process, when it is shown. */
+  memoryUsage?: string;
+}
+
+/** The segments of the left and the right half of the status bar. */
+export function buildStatusSegments(input: StatusSegmentInput): {
+  left: StatusSegment[];
+  right: StatusSegment[];
+} {
+  const left: StatusSegment[] = [
+    { icon: STATUS_ICONS.folder, text: input.path },
+  ];
+  if (input.branchName) {
+    left.push({ icon: STATUS_ICONS.branch, text: `${input.branchName}*` });
+  }
+  if (input.sandbox) {
+    left.push({ icon: STATUS_ICONS.sandbox, text: input.sandbox });
+  }
+  if (input.incognito) {
+    left.push({ icon: STATUS_ICONS.incognito, text: 'incognito' });
+  }
+  if (input.debugMessage) {
+    left.push({ icon: STATUS_ICONS.debug, text: input.debugMessage });
+  }
+
+  const right: StatusSegment[] = [];
+  if (input.focusStatus) {
+    right.push({ icon: STATUS_ICONS.focus, text: input.focusStatus });
+  }
+  if (input.deadlineWarning) {
+    right.push({ icon: STATUS_ICONS.deadline, text: input.deadlineWarning });
+  }
+  if (input.redactionCount) {
+    right.push({
+      icon: STATUS_ICONS.redaction,
+      text: `${input.redactionCount} redacted`,
+    });
+  }
+  if (input.errorCount) {
+    right.push({
+      icon: STATUS_ICONS.error,
+      text: `${input.errorCount} error${input.errorCount === 1 ? '' : 's'}`,
+    });
+  }
+  right.push({
+    icon: STATUS_ICONS.model,
+    text:
+      (input.fallbackModel
+        ? `${input.model} -> ${input.fallbackModel}`
+        : input.model) + ` ${input.contextLeftPercent}% context left`,
+  });
+  if (input.corgiMode) {
+    right.push({ icon: STATUS_ICONS.corgi, text: '▼(´ᴥ`)▼' });
+  }
+  if (input.memoryUsage) {
+    right.push({ icon: STATUS_ICONS.memory, text: input.memoryUsage });
+  }
+  return { left, right };
+}
@@ -0,0 +1,65 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, vi, beforeEach, afterEach } from 'vitest';
+import { StreamCoalescer } from './streamCoalescer.js';
+
+describe('StreamCoalescer', () => {
+  beforeEach(() => {
+    vi.useFakeTimers();
+  });
+
+  afterEach(() => {
+    vi.useRealTimers();
+  });
+
+  it('should deliver the first chunk immediately', () => {
+    const onFlush = vi.fn();
+    const coalescer = new StreamCoalescer(onFlush, 30);
+
+    coalescer.push('Hello');
+    expect(onFlush).toHaveBeenCalledWith('Hello');
+  });
+
+  it('should merge chunks that arrive within one frame', () => {
+    const onFlush = vi.fn();
+    const coalescer = new StreamCoalescer(onFlush, 30);
+
+    coalescer.push('a');
+    coalescer.push('b');
+    coalescer.push('c');
+    expect(onFlush).toHaveBeenCalledTimes(1);
+
+    vi.advanceTimersByTime(30);
+    expect(onFlush).toHaveBeenCalledTimes(2);
+    expect(onFlush).toHaveBeenLastCalledWith('bc');
+  });
+
+  it('should flush early when too much text is pending', () => {
+    const onFlush = vi.fn();
+    const coalescer = new StreamCoalescer(onFlush, 30, 5);
+
+    coalescer.push('a');
+    coalescer.push('bcdef');
+    expect(onFlush).toHaveBeenLastCalledWith('bcdef');
+    vi.advanceTimersByTime(30);
+    expect(onFlush).toHaveBeenCalledTimes(2);
+  });
+
+  it('should deliver pending text on an explicit flush', () => {
+    const onFlush = vi.fn();
+    const coalescer = new StreamCoalescer(onFlush, 30);
+
+    coalescer.push('a');
+    coalescer.push('b');
+    coalescer.flush();
+    expect(onFlush).toHaveBeenLastCalledWith('b');
+
+    coalescer.flush();
+    vi.advanceTimersByTime(30);
+    expect(onFlush).toHaveBeenCalledTimes(2);
+  });
+});
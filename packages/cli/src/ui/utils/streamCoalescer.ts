@@ -0,0 +1,57 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// About one terminal frame; tokens arriving faster than this are merged.
+export const STREAM_FRAME_MS = 33;
+
+// Pending text is flushed early once it grows past this, so a stalled
+// renderer never holds an unbounded amount of streamed output.
+export const MAX_PENDING_CHARS = 16 * 1024;
+
+/**
+ * Merges streamed text chunks so the UI updates at most once per frame.
+ * The first chunk after a quiet period is delivered immediately; chunks
+ * that follow within the same frame are joined and delivered together.
+ */
+export class StreamCoalescer {
+  private pending = '';
+  private lastFlush = -Infinity;
+  private timer: ReturnType<typeof setTimeout> | undefined;
+
+  constructor(
+    private readonly onFlush: (text: string) => void,
+    private readonly frameMs = STREAM_FRAME_MS,
+    private readonly maxPendingChars = MAX_PENDING_CHARS,
+  ) {}
+
+  push(text: string): void {
+    this.pending += text;
+    const elapsed = Date.now() - this.lastFlush;
+    if (
+      elapsed >= this.frameMs ||
+      this.pending.length >= this.maxPendingChars
+    ) {
+      this.flush();
+    } else if (!this.timer) {
+      this.timer = setTimeout(() => this.flush(), this.frameMs - elapsed);
+    }
+  }
+
+  /** Delivers any pending text now. */
+  flush(): void {
+    if (this.timer) {
+      clearTimeout(this.timer);
+      this.timer = undefined;
+    }
+    if (!this.pending) {
+      return;
+    }
+    const text = this.pending;
+    this.pending = '';
+    this.lastFlush = Date.now();
+    this.onFlush(text);
+  }
+}
@@ -0,0 +1,41 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect } from 'vitest';
+import { detectImageProtocol, encodeInlineImage } from './terminalImage.js';
+
+describe('detectImageProtocol', () => {
+  it('should recognise kitty and iTerm2-compatible terminals', () => {
+    expect(detectImageProtocol({ KITTY_WINDOW_ID: '1' })).toBe('kitty');
+    expect(detectImageProtocol({ TERM_PROGRAM: 'iTerm.app' })).toBe('iterm');
+    expect(detectImageProtocol({ TERM_PROGRAM: 'Apple_Terminal' })).toBe(
+      undefined,
+    );
+  });
+
+  it('should not draw images inside tmux', () => {
+    expect(
+      detectImageProtocol({ KITTY_WINDOW_ID: '1', TMUX: '/tmp/tmux' }),
+    ).toBe(undefined);
+  });
+});
+
+describe('encodeInlineImage', () => {
+  it('should encode an iTerm2 inline file', () => {
+    expect(encodeInlineImage(Buffer.from('png'), 'iterm', 40)).toBe(
+      '\x1b]1337;File=inline=1;size=3;width=40;preserveAspectRatio=1:cG5n\x07',
+    );
+  });
+
+  it('should split kitty payloads into chunks', () => {
+    const sequence = encodeInlineImage(Buffer.alloc(4000), 'kitty', 60);
+    const chunks = sequence.split('\x1b\\').filter(Boolean);
+
+    expect(chunks).toHaveLength(2);
+    expect(chunks[0].startsWith('\x1b_Ga=T,f=100,c=60,m=1;')).toBe(true);
+    expect(chunks[1].startsWith('\x1b_Gm=0;')).toBe(true);
+  });
+});
@@ -0,0 +1,57 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+export type ImageProtocol = 'kitty' | 'iterm';
+
+const KITTY_CHUNK_SIZE = 4096;
+
+/**
+ * Picks the inline image protocol the terminal understands, if any.
+ * Multiplexers such as tmux swallow the escape sequences, so images are
+ * only drawn when running directly in a supporting terminal.
+ */
+export function detectImageProtocol(
+  env: NodeJS.ProcessEnv = process.env,
+): ImageProtocol | undefined {
+  if (env.TMUX || env.TERM?.startsWith('screen')) {
+    return undefined;
+  }
+  if (
+    env.KITTY_WINDOW_ID ||
+    env.TERM === 'xterm-kitty' ||
+    env.TERM_PROGRAM === 'ghostty'
+  ) {
+    return 'kitty';
+  }
+  if (env.TERM_PROGRAM === 'iTerm.app' || env.TERM_PROGRAM === 'WezTerm') {
+    return 'iterm';
+  }
+  return undefined;
+}
+
+/**
+ * Escape sequence that draws a PNG at the cursor, `columns` cells wide.
+ */
+export function encodeInlineImage(
+  png: Buffer,
+  protocol: ImageProtocol,
+  columns: number,
+): string {
+  const data = png.toString('base64');
+  if (protocol === 'iterm') {
+    return `\x1b]1337;File=inline=1;size=${png.length};width=${columns};preserveAspectRatio=1:${data}\x07`;
+  }
+  // Kitty takes the payload in chunks; m=1 marks that more follow.
+  const chunks: string[] = [];
+  for (let i = 0; i < data.length; i += KITTY_CHUNK_SIZE) {
+    const more = i + KITTY_CHUNK_SIZE < data.length ? 1 : 0;
+    const control = i === 0 ? `a=T,f=100,c=${columns},m=${more}` : `m=${more}`;
+    chunks.push(
+      `\x1b_G${control};${data.slice(i, i + KITTY_CHUNK_SIZE)}\x1b\\`,
+    );
+  }
+  return chunks.join('');
+}
@@ -4,7 +4,13 @@
  * SPDX-License-Identifier: Apache-2.0
  */
 
-import { isBinary } from './textUtils';
+import {
+  cpIndexAtWidth,
+  isBinary,
+  padToWidth,
+  truncateStartToWidth,
+  truncateToWidth,
+} from './textUtils';
 
 describe('textUtils', () => {
   describe('isBinary', () => {
@@ -38,4 +44,25 @@ describe('textUtils', () => {
       expect(isBinary(longBufferWithNullByteAtEnd, 512)).toBe(false);
     });
   });
+
+  describe('display width', () => {
+    it('should count wide characters as two columns', () => {
+      expect(cpIndexAtWidth('abc', 2)).toBe(2);
+      expect(cpIndexAtWidth('你好世界', 5)).toBe(2);
+      expect(cpIndexAtWidth('🐶🐱', 2)).toBe(1);
+    });
+
+    it('should never cut through a wide character', () => {
+      expect(truncateToWidth('研究问题', 5)).toBe('研究');
+      expect(truncateStartToWidth('~/论文/第一章', 9)).toBe('...第一章');
+      expect(truncateStartToWidth('~/paper', 9)).toBe('~/paper');
+    });
+
+    it('should pad by columns', () => {
+      expect(padToWidth('标题', 6)).toBe('标题  ');
+      expect(padToWidth('标题', 6, 'right')).toBe('  标题');
+      expect(padToWidth('标题', 7, 'center')).toBe(' 标题  ');
+      expect(padToWidth('title', 3)).toBe('tit');
+    });
+  });
 });
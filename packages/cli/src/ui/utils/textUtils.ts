@@ -4,6 +4,8 @@
  * SPDX-License-Identifier: Apache-2.0
  */
 
+import stringWidth from 'string-width';
+
 /**
  * Calculates the maximum width of a multi-line ASCII art string.
  * @param asciiArt The ASCII art string.
@@ -67,3 +69,69 @@ export function cpSlice(str: string, start: number, end?: number): string {
   const arr = toCodePoints(str).slice(start, end);
   return arr.join('');
 }
+
+/*
+ * Display width helpers. CJK characters and most emoji take two terminal
+ * columns, so layout must be measured with string-width rather than with
+ * code point counts.
+ */
+
+/**
+ * The number of leading code points of `str` that fit in `width` columns.
+ */
+export function cpIndexAtWidth(str: string, width: number): number {
+  let used = 0;
+  let index = 0;
+  for (const char of toCodePoints(str)) {
+    const charWidth = stringWidth(char);
+    if (used + charWidth > width) {
+      break;
+    }
+    used += charWidth;
+    index++;
+  }
+  return index;
+}
+
+/** Cuts `str` to at most `width` columns, never through a wide character. */
+export function truncateToWidth(str: string, width: number): string {
+  return cpSlice(str, 0, cpIndexAtWidth(str, width));
+}
+
+/**
+ * Keeps the end of `str` within `width` columns, marking the cut with
+ * `...`; for paths, whose end matters most.
+ */
+export function truncateStartToWidth(str: string, width: number): string {
+  if (stringWidth(str) <= width) {
+    return str;
+  }
+  const chars = toCodePoints(str);
+  let used = 3;
+  let start = chars.length;
+  while (start > 0 && used + stringWidth(chars[start - 1]) <= width) {
+    start--;
+    used += stringWidth(chars[start]);
+  }
+  return '...' + chars.slice(start).join('');
+}
+
+/** Pads or cuts `str` to exactly `width` columns. */
+export function padToWidth(
+  str: string,
+  width: number,
+  align: 'left' | 'center' | 'right' = 'left',
+): string {
+  const text = truncateToWidth(str, width);
+  const padding = width - stringWidth(text);
+  switch (align) {
+    case 'center': {
+      const leftPad = Math.floor(padding / 2);
+      return ' '.repeat(leftPad) + text + ' '.repeat(padding - leftPad);
+    }
+    case 'right':
+      return ' '.repeat(padding) + text;
+    default:
+      return text + ' '.repeat(padding);
+  }
+}
@@ -17,6 +17,12 @@ vi.mock('update-notifier', () => ({
   default: updateNotifier,
 }));
 
+const selfUpdate = vi.hoisted(() => ({
+  checkForRelease: vi.fn(),
+  getInstalledBundleDir: vi.fn(),
+}));
+vi.mock('../../utils/selfUpdate.js', () => selfUpdate);
+
 describe('checkForUpdates', () => {
   beforeEach(() => {
     vi.resetAllMocks();
@@ -74,6 +80,26 @@ describe('checkForUpdates', () => {
     expect(result).toBeNull();
   });
 
+  it('should only check GitHub releases for standalone installs that opted in', async () => {
+    getPackageJson.mockResolvedValue({
+      name: 'test-package',
+      version: '1.0.0',
+    });
+    selfUpdate.getInstalledBundleDir.mockReturnValue('/opt/research-cli');
+    selfUpdate.checkForRelease.mockResolvedValue({
+      current: '1.0.0',
+      latest: '1.2.0',
+      updateAvailable: true,
+    });
+    updateNotifier.mockReturnValue({
+      update: { current: '1.0.0', latest: '1.1.0' },
+    });
+
+    expect(await checkForUpdates(true)).toContain('1.0.0 → 1.2.0');
+    expect(await checkForUpdates()).toContain('1.0.0 → 1.1.0');
+    expect(selfUpdate.checkForRelease).toHaveBeenCalledTimes(1);
+  });
+
   it('should handle errors gracefully', async () => {
     getPackageJson.mockRejectedValue(new Error('test error'));
     const result = await checkForUpdates();
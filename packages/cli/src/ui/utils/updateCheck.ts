@@ -7,13 +7,33 @@
 import updateNotifier from 'update-notifier';
 import semver from 'semver';
 import { getPackageJson } from '../../utils/package.js';
+import {
+  checkForRelease,
+  getInstalledBundleDir,
+} from '../../utils/selfUpdate.js';
 
-export async function checkForUpdates(): Promise<string | null> {
+/**
+ * The notice to show at startup when a newer version is published, or null.
+ * `checkReleases` (the `checkForUpdates` setting) opts standalone installs
+ * in to the GitHub release check.
+ */
+export async function checkForUpdates(
+  checkReleases = false,
+): Promise<string | null> {
   try {
     const packageJson = await getPackageJson();
     if (!packageJson || !packageJson.name || !packageJson.version) {
       return null;
     }
+
+    // Standalone installs (install.sh) are updated from GitHub releases.
+    if (checkReleases && getInstalledBundleDir()) {
+      const check = await checkForRelease(packageJson.version);
+      return check.updateAvailable
+        ? `Research CLI update available! ${check.current} → ${check.latest}\nRun /update to install it`
+        : null;
+    }
+
     const notifier = updateNotifier({
       pkg: {
         name: packageJson.name,
@@ -0,0 +1,92 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, vi, beforeEach, afterEach } from 'vitest';
+import { StreamingState } from '../types.js';
+import {
+  DEFAULT_WINDOW_TITLE,
+  WindowTitleWriter,
+  deriveSessionTitle,
+  formatWindowTitle,
+  getWindowTitleState,
+} from './windowTitle.js';
+
+describe('windowTitle', () => {
+  it('should fill in the template', () => {
+    expect(
+      formatWindowTitle(DEFAULT_WINDOW_TITLE, {
+        project: 'thesis',
+        title: 'Related work on retrieval',
+        state: getWindowTitleState(StreamingState.Responding),
+      }),
+    ).toBe('Research - Related work on retrieval (generating…)');
+    expect(
+      formatWindowTitle('{state} · {project}', {
+        project: 'a\x1b]2;b',
+        title: '',
+        state: 'idle',
+      }),
+    ).toBe('idle · a]2;b');
+  });
+
+  it('should name the session after the first line of a message', () => {
+    expect(deriveSessionTitle('  Compare BM25\nwith dense retrieval')).toBe(
+      'Compare BM25',
+    );
+    expect(
+      deriveSessionTitle(
+        'Summarize the related work on retrieval augmented generation',
+      ),
+    ).toBe('Summarize the related work on retrieval…');
+    expect(deriveSessionTitle('   ')).toBeUndefined();
+  });
+
+  describe('WindowTitleWriter', () => {
+    const write = vi.fn();
+
+    beforeEach(() => {
+      vi.useFakeTimers();
+      write.mockReset();
+    });
+
+    afterEach(() => {
+      vi.useRealTimers();
+    });
+
+    it('should write at most once per interval and keep the last title', () => {
+      const writer = new WindowTitleWriter(write, 1000);
+
+      writer.set('a');
+      writer.set('b');
+      writer.set('c');
+      expect(write.mock.calls).toEqual([['\x1b]2;a\x07']]);
+
+      vi.advanceTimersByTime(1000);
+      expect(write.mock.calls).toEqual([['\x1b]2;a\x07'], ['\x1b]2;c\x07']]);
+    });
+
+    it('should not write the same title twice', () => {
+      const writer = new WindowTitleWriter(write, 1000);
+
+      writer.set('a');
+      vi.advanceTimersByTime(2000);
+      writer.set('a');
+
+      expect(write).toHaveBeenCalledTimes(1);
+    });
+
+    it('should drop a pending title when disposed', () => {
+      const writer = new WindowTitleWriter(write, 1000);
+
+      writer.set('a');
+      writer.set('b');
+      writer.dispose();
+      vi.advanceTimersByTime(1000);
+
+      expect(write).toHaveBeenCalledTimes(1);
+    });
+  });
+});
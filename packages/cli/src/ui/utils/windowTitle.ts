@@ -0,0 +1,116 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { StreamingState } from '../types.js';
+
+export const DEFAULT_WINDOW_TITLE = 'Research - {title} ({state})';
+/** Some terminals flicker or lag when the title changes on every chunk. */
+export const WINDOW_TITLE_MIN_INTERVAL_MS = 1000;
+const MAX_SESSION_TITLE_LENGTH = 40;
+
+export interface WindowTitleValues {
+  project: string;
+  /** The session title, or the project name until there is one. */
+  title: string;
+  state: string;
+}
+
+export function getWindowTitleState(streamingState: StreamingState): string {
+  switch (streamingState) {
+    case StreamingState.Responding:
+      return 'generating…';
+    case StreamingState.WaitingForConfirmation:
+      return 'waiting for approval';
+    default:
+      return 'idle';
+  }
+}
+
+/**
+ * A session title from the first message: its first line, cut at a word
+ * boundary to fit a tab.
+ */
+export function deriveSessionTitle(message: string): string | undefined {
+  const line = message.trim().split('\n')[0].replace(/\s+/g, ' ').trim();
+  if (!line) {
+    return undefined;
+  }
+  if (line.length <= MAX_SESSION_TITLE_LENGTH) {
+    return line;
+  }
+  const space = line.lastIndexOf(' ', MAX_SESSION_TITLE_LENGTH - 1);
+  const end =
+    space > MAX_SESSION_TITLE_LENGTH / 2 ? space : MAX_SESSION_TITLE_LENGTH - 1;
+  return `${line.slice(0, end)}…`;
+}
+
+/** Fills in {project}, {title} and {state}; control characters are removed. */
+export function formatWindowTitle(
+  template: string,
+  values: WindowTitleValues,
+): string {
+  return (
+    template
+      .replace(
+        /\{(project|title|state)\}/g,
+        (_, key: keyof WindowTitleValues) => values[key],
+      )
+      // eslint-disable-next-line no-control-regex
+      .replace(/[\x00-\x1F\x7F]/g, '')
+  );
+}
+
+/**
+ * Writes the terminal title (OSC 2) at most once per interval. A title set
+ * in between is written when the interval is over, so the last one always
+ * shows.
+ */
+export class WindowTitleWriter {
+  private written: string | undefined;
+  private lastWriteAt = -Infinity;
+  private pending: string | undefined;
+  private timer: NodeJS.Timeout | undefined;
+
+  constructor(
+    private readonly write: (data: string) => void,
+    private readonly minIntervalMs = WINDOW_TITLE_MIN_INTERVAL_MS,
+  ) {}
+
+  set(title: string): void {
+    if (this.timer) {
+      this.pending = title;
+      return;
+    }
+    const wait = this.lastWriteAt + this.minIntervalMs - Date.now();
+    if (wait <= 0) {
+      this.writeTitle(title);
+      return;
+    }
+    this.pending = title;
+    this.timer = setTimeout(() => {
+      this.timer = undefined;
+      if (this.pending !== undefined) {
+        this.writeTitle(this.pending);
+        this.pending = undefined;
+      }
+    }, wait);
+  }
+
+  dispose(): void {
+    clearTimeout(this.timer);
+    this.timer = undefined;
+    this.pending = undefined;
+  }
+
+  private writeTitle(title: string): void {
+    if (title === this.written) {
+      return;
+    }
+    this.write(`\x1b]2;${title}\x07`);
+    this.written = title;
+    this.lastWriteAt = Date.now();
+  }
+}
@@ -0,0 +1,80 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect } from 'vitest';
+import {
+  getPlatformKeyLabels,
+  getWindowsConsoleWarning,
+  isPasteImageKey,
+} from './windowsTerminal.js';
+
+describe('isPasteImageKey', () => {
+  it('should paste images on Ctrl+V everywhere', () => {
+    expect(isPasteImageKey({ name: 'v', ctrl: true }, 'linux')).toBe(true);
+    expect(isPasteImageKey({ name: 'v', ctrl: true }, 'win32')).toBe(true);
+  });
+
+  it('should paste images on Alt+V only on Windows', () => {
+    expect(isPasteImageKey({ name: 'v', meta: true }, 'win32')).toBe(true);
+    expect(isPasteImageKey({ name: 'v', meta: true }, 'darwin')).toBe(false);
+    expect(isPasteImageKey({ name: 'v' }, 'win32')).toBe(false);
+  });
+});
+
+describe('getPlatformKeyLabels', () => {
+  it('should avoid the keys Windows Terminal keeps for itself', () => {
+    expect(getPlatformKeyLabels('win32')).toEqual({
+      pasteImage: 'Alt+V',
+      wordJump: 'Ctrl+Left/Right',
+    });
+    expect(getPlatformKeyLabels('linux')).toEqual({
+      pasteImage: 'Ctrl+V',
+      wordJump: 'Alt+Left/Right',
+    });
+  });
+});
+
+describe('getWindowsConsoleWarning', () => {
+  it('should not warn outside Windows', () => {
+    expect(getWindowsConsoleWarning({}, 'linux', true)).toBeUndefined();
+  });
+
+  it('should warn in the legacy console host', () => {
+    expect(getWindowsConsoleWarning({}, 'win32', true)).toContain(
+      'legacy Windows console',
+    );
+  });
+
+  it('should not warn in Windows Terminal or VS Code', () => {
+    expect(
+      getWindowsConsoleWarning({ WT_SESSION: 'abc' }, 'win32', true),
+    ).toBeUndefined();
+    expect(
+      getWindowsConsoleWarning({ TERM_PROGRAM: 'vscode' }, 'win32', true),
+    ).toBeUndefined();
+  });
+
+  it('should ask for winpty in mintty', () => {
+    expect(
+      getWindowsConsoleWarning({ TERM_PROGRAM: 'mintty' }, 'win32', false),
+    ).toContain('winpty');
+    expect(
+      getWindowsConsoleWarning(
+        { MSYSTEM: 'MINGW64', TERM: 'xterm' },
+        'win32',
+        false,
+      ),
+    ).toContain('winpty');
+  });
+
+  describe.runIf(process.platform === 'win32')('on Windows', () => {
+    it('should default to the current terminal', () => {
+      expect(getWindowsConsoleWarning()).toBe(
+        getWindowsConsoleWarning(process.env, 'win32', !!process.stdin.isTTY),
+      );
+    });
+  });
+});
@@ -0,0 +1,66 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+/** The parts of a keypress the bindings below look at. */
+interface KeyModifiers {
+  name?: string;
+  ctrl?: boolean;
+  meta?: boolean;
+}
+
+/**
+ * Pastes an image from the clipboard. Windows Terminal keeps Ctrl+V for
+ * its own text paste and never passes it on, so Alt+V does it there.
+ */
+export function isPasteImageKey(
+  key: KeyModifiers,
+  platform: NodeJS.Platform = process.platform,
+): boolean {
+  return (
+    key.name === 'v' && (!!key.ctrl || (platform === 'win32' && !!key.meta))
+  );
+}
+
+/**
+ * Labels of the bindings that differ on Windows, for help texts. Windows
+ * Terminal moves the focus between its panes on Alt+arrow, so words are
+ * jumped with Ctrl+arrow there; both work everywhere.
+ */
+export function getPlatformKeyLabels(
+  platform: NodeJS.Platform = process.platform,
+): { pasteImage: string; wordJump: string } {
+  return platform === 'win32'
+    ? { pasteImage: 'Alt+V', wordJump: 'Ctrl+Left/Right' }
+    : { pasteImage: 'Ctrl+V', wordJump: 'Alt+Left/Right' };
+}
+
+/**
+ * A warning for Windows consoles that cannot run the interactive UI well,
+ * or undefined. The legacy console host (conhost without ConPTY) redraws
+ * the UI badly and has few colors; Git Bash's mintty gives no console at
+ * all to a program run without `winpty`, so input is not read as keys.
+ */
+export function getWindowsConsoleWarning(
+  env: NodeJS.ProcessEnv = process.env,
+  platform: NodeJS.Platform = process.platform,
+  stdinIsTTY: boolean = !!process.stdin.isTTY,
+): string | undefined {
+  if (platform !== 'win32') {
+    return undefined;
+  }
+  if (env.TERM_PROGRAM === 'mintty' || (env.MSYSTEM && !stdinIsTTY)) {
+    return 'Research CLI is running in mintty without a Windows console. Start it with `winpty research`, or use Windows Terminal.';
+  }
+  const modernHost =
+    env.WT_SESSION ||
+    env.TERM_PROGRAM === 'vscode' ||
+    env.ConEmuANSI === 'ON' ||
+    env.TERM?.startsWith('xterm');
+  if (!modernHost) {
+    return 'Research CLI is running in the legacy Windows console, which redraws the UI poorly. Windows Terminal is recommended.';
+  }
+  return undefined;
+}
@@ -0,0 +1,57 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect } from 'vitest';
+import { getFocusedPane, isPaneVisible } from './zoom.js';
+
+describe('getFocusedPane', () => {
+  const state = {
+    outlineOpen: false,
+    consoleShown: false,
+    toolOutputShown: false,
+  };
+
+  it('should focus the chat when nothing else is shown', () => {
+    expect(getFocusedPane(state)).toBe('chat');
+    expect(getFocusedPane({ ...state, toolOutputShown: true })).toBe(
+      'tool output',
+    );
+  });
+
+  it('should put the outline and the console in front', () => {
+    expect(
+      getFocusedPane({
+        outlineOpen: true,
+        consoleShown: true,
+        toolOutputShown: true,
+      }),
+    ).toBe('outline');
+    expect(
+      getFocusedPane({ ...state, consoleShown: true, toolOutputShown: true }),
+    ).toBe('console');
+  });
+});
+
+describe('isPaneVisible', () => {
+  it('should show everything without zoom', () => {
+    expect(isPaneVisible('footer', undefined)).toBe(true);
+    expect(isPaneVisible('console', undefined)).toBe(true);
+  });
+
+  it('should show only the zoomed pane', () => {
+    expect(isPaneVisible('console', 'console')).toBe(true);
+    expect(isPaneVisible('tool output', 'console')).toBe(false);
+    expect(isPaneVisible('input', 'console')).toBe(false);
+    expect(isPaneVisible('footer', 'console')).toBe(false);
+  });
+
+  it('should keep the input and running output with the chat', () => {
+    expect(isPaneVisible('input', 'chat')).toBe(true);
+    expect(isPaneVisible('tool output', 'chat')).toBe(true);
+    expect(isPaneVisible('input', 'tool output')).toBe(false);
+    expect(isPaneVisible('console', 'chat')).toBe(false);
+  });
+});
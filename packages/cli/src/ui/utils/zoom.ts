@@ -0,0 +1,51 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+/** The parts of the screen that can be zoomed to the full terminal. */
+export type ZoomPane = 'chat' | 'tool output' | 'console' | 'outline';
+
+export interface PaneState {
+  outlineOpen: boolean;
+  consoleShown: boolean;
+  /** A response or tool call is still being shown below the history. */
+  toolOutputShown: boolean;
+}
+
+/**
+ * The pane that has the focus: an open outline takes the keyboard, the
+ * debug console is in front once opened, and running output comes before
+ * the rest of the chat.
+ */
+export function getFocusedPane(state: PaneState): ZoomPane {
+  if (state.outlineOpen) {
+    return 'outline';
+  }
+  if (state.consoleShown) {
+    return 'console';
+  }
+  return state.toolOutputShown ? 'tool output' : 'chat';
+}
+
+/**
+ * Which panes stay visible: the zoomed one, plus the input box when the
+ * chat is zoomed. Nothing is hidden without zoom.
+ */
+export function isPaneVisible(
+  pane: ZoomPane | 'input' | 'footer',
+  zoomedPane: ZoomPane | undefined,
+): boolean {
+  if (!zoomedPane) {
+    return true;
+  }
+  if (pane === 'input') {
+    return zoomedPane === 'chat';
+  }
+  if (pane === 'tool output') {
+    // Running output is part of the chat
+    return zoomedPane === 'chat' || zoomedPane === 'tool output';
+  }
+  return pane === zoomedPane;
+}
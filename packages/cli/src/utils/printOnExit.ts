@@ -0,0 +1,52 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import {
+  Config,
+  renderConversationTranscript,
+  TRANSCRIPT_FORMATS,
+  TranscriptFormat,
+} from '@iechor/research-cli-core';
+
+let printFormat: TranscriptFormat | undefined;
+
+/** Sets the format of the transcript printed on exit, or turns it off. */
+export function setPrintOnExit(format: TranscriptFormat | undefined): void {
+  printFormat = format;
+}
+
+export function getPrintOnExit(): TranscriptFormat | undefined {
+  return printFormat;
+}
+
+/**
+ * Reads the `--print-on-exit` value: markdown when it is given without a
+ * format, undefined for a format that does not exist.
+ */
+export function parsePrintFormat(
+  value: string,
+): TranscriptFormat | undefined {
+  const format = value.trim().toLowerCase() || 'markdown';
+  return TRANSCRIPT_FORMATS.find((f) => f === format);
+}
+
+/**
+ * Prints the transcript when the process exits, after the UI has drawn
+ * its last frame, so that wrapping scripts can pipe or capture it.
+ */
+export function registerPrintOnExit(config: Config): void {
+  process.on('exit', () => {
+    if (!printFormat) {
+      return;
+    }
+    const client = config.getResearchClient();
+    const history = client?.isInitialized() ? client.getHistory() : [];
+    const transcript = renderConversationTranscript(history, printFormat);
+    if (transcript) {
+      process.stdout.write(`\n${transcript}`);
+    }
+  });
+}
@@ -0,0 +1,43 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, vi } from 'vitest';
+import { FrameTimeTracker } from './profiling.js';
+
+describe('FrameTimeTracker', () => {
+  it('should log only slow commits', () => {
+    const log = vi.fn();
+    const tracker = new FrameTimeTracker(50, log);
+
+    tracker.onRender('App', 'update', 10, 0, 0, 0);
+    tracker.onRender('App', 'update', 75.25, 0, 0, 0);
+
+    expect(log).toHaveBeenCalledTimes(1);
+    expect(log).toHaveBeenCalledWith('[profile] slow update of App: 75.3ms');
+  });
+
+  it('should summarize frame times', () => {
+    const tracker = new FrameTimeTracker(50, () => {});
+    for (let i = 1; i <= 100; i++) {
+      tracker.onRender('App', 'update', i, 0, 0, 0);
+    }
+
+    expect(tracker.getSummary()).toEqual({
+      frames: 100,
+      slowFrames: 51,
+      p50: 51,
+      p95: 96,
+      max: 100,
+    });
+  });
+
+  it('should handle an empty session', () => {
+    const tracker = new FrameTimeTracker();
+    expect(tracker.formatSummary()).toBe(
+      'Frame times: 0 commits, p50 0.0ms, p95 0.0ms, max 0.0ms, 0 slower than 66ms',
+    );
+  });
+});
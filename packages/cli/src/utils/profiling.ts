@@ -0,0 +1,149 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { Session } from 'node:inspector';
+import fs from 'node:fs';
+import path from 'node:path';
+import type { ProfilerOnRenderCallback } from 'react';
+
+// Commits slower than this are logged; ~2 frames at 30fps.
+export const SLOW_FRAME_MS = 66;
+
+function defaultProfilePath(kind: 'cpu' | 'heap'): string {
+  const stamp = new Date().toISOString().replace(/[:.]/g, '-');
+  return path.resolve(`research-${kind}-${stamp}.${kind}profile`);
+}
+
+function post<T>(session: Session, method: string): T {
+  // Posts on an in-thread session complete synchronously, which lets the
+  // profile be written from a process 'exit' handler.
+  let result: T | undefined;
+  let error = null as Error | null;
+  session.post(method, (err, res) => {
+    error = err;
+    result = res as T;
+  });
+  if (error) {
+    throw error;
+  }
+  return result as T;
+}
+
+export interface ProfilingOptions {
+  /** Path for the .cpuprofile; an empty string picks a default name. */
+  cpu?: string;
+  /** Path for the .heapprofile; an empty string picks a default name. */
+  mem?: string;
+}
+
+/**
+ * Starts V8 CPU and/or sampling heap profiling and writes the profiles
+ * when the process exits. Open the files in Chrome DevTools.
+ */
+export function startProfiling(options: ProfilingOptions): void {
+  if (options.cpu === undefined && options.mem === undefined) {
+    return;
+  }
+  const session = new Session();
+  session.connect();
+
+  const cpuPath =
+    options.cpu !== undefined
+      ? options.cpu || defaultProfilePath('cpu')
+      : undefined;
+  const heapPath =
+    options.mem !== undefined
+      ? options.mem || defaultProfilePath('heap')
+      : undefined;
+
+  if (cpuPath) {
+    post(session, 'Profiler.enable');
+    post(session, 'Profiler.start');
+  }
+  if (heapPath) {
+    post(session, 'HeapProfiler.enable');
+    post(session, 'HeapProfiler.startSampling');
+  }
+
+  process.once('exit', () => {
+    try {
+      if (cpuPath) {
+        const { profile } = post<{ profile: object }>(session, 'Profiler.stop');
+        fs.writeFileSync(cpuPath, JSON.stringify(profile));
+        process.stderr.write(`CPU profile written to ${cpuPath}\n`);
+      }
+      if (heapPath) {
+        const { profile } = post<{ profile: object }>(
+          session,
+          'HeapProfiler.stopSampling',
+        );
+        fs.writeFileSync(heapPath, JSON.stringify(profile));
+        process.stderr.write(`Heap profile written to ${heapPath}\n`);
+      }
+    } catch (e) {
+      process.stderr.write(`Failed to write profile: ${e}\n`);
+    } finally {
+      session.disconnect();
+    }
+  });
+}
+
+export interface FrameTimeSummary {
+  frames: number;
+  slowFrames: number;
+  p50: number;
+  p95: number;
+  max: number;
+}
+
+/**
+ * Records how long React takes to commit each UI update and logs the slow
+ * ones, so regressions on long sessions show up while profiling.
+ */
+export class FrameTimeTracker {
+  private durations: number[] = [];
+  private slowFrames = 0;
+
+  constructor(
+    private readonly slowThresholdMs = SLOW_FRAME_MS,
+    private readonly log: (message: string) => void = (message) =>
+      console.debug(message),
+  ) {}
+
+  readonly onRender: ProfilerOnRenderCallback = (
+    id,
+    phase,
+    actualDuration,
+  ) => {
+    this.durations.push(actualDuration);
+    if (actualDuration >= this.slowThresholdMs) {
+      this.slowFrames++;
+      this.log(
+        `[profile] slow ${phase} of ${id}: ${actualDuration.toFixed(1)}ms`,
+      );
+    }
+  };
+
+  getSummary(): FrameTimeSummary {
+    const sorted = [...this.durations].sort((a, b) => a - b);
+    const percentile = (p: number) =>
+      sorted.length === 0
+        ? 0
+        : sorted[Math.min(sorted.length - 1, Math.floor(sorted.length * p))];
+    return {
+      frames: sorted.length,
+      slowFrames: this.slowFrames,
+      p50: percentile(0.5),
+      p95: percentile(0.95),
+      max: sorted.length === 0 ? 0 : sorted[sorted.length - 1],
+    };
+  }
+
+  formatSummary(): string {
+    const s = this.getSummary();
+    return `Frame times: ${s.frames} commits, p50 ${s.p50.toFixed(1)}ms, p95 ${s.p95.toFixed(1)}ms, max ${s.max.toFixed(1)}ms, ${s.slowFrames} slower than ${this.slowThresholdMs}ms`;
+  }
+}
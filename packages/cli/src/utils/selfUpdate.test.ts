@@ -0,0 +1,98 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, vi, afterEach } from 'vitest';
+import {
+  ReleaseInfo,
+  downloadVerifiedArchive,
+  getReleaseArchiveName,
+  parseChecksum,
+  sha256,
+} from './selfUpdate.js';
+
+const ARCHIVE = 'research-cli-linux-x64.tar.gz';
+
+function mockFetch(files: Record<string, Buffer | string>) {
+  vi.stubGlobal(
+    'fetch',
+    vi.fn(async (url: string) => {
+      const body = files[url];
+      if (body === undefined) {
+        return { ok: false, status: 404, statusText: 'Not Found' };
+      }
+      const buffer = Buffer.from(body);
+      return {
+        ok: true,
+        arrayBuffer: async () =>
+          buffer.buffer.slice(
+            buffer.byteOffset,
+            buffer.byteOffset + buffer.byteLength,
+          ),
+      };
+    }),
+  );
+}
+
+function release(assets: string[]): ReleaseInfo {
+  return {
+    tag: 'v1.2.0',
+    version: '1.2.0',
+    assets: assets.map((name) => ({ name, url: `https://example.com/${name}` })),
+  };
+}
+
+describe('selfUpdate', () => {
+  afterEach(() => {
+    vi.unstubAllGlobals();
+  });
+
+  it('should name archives like install.sh', () => {
+    expect(getReleaseArchiveName('linux', 'x64')).toBe(ARCHIVE);
+    expect(getReleaseArchiveName('win32', 'arm64')).toBe(
+      'research-cli-win32-arm64.zip',
+    );
+  });
+
+  it('should parse sha256sum listings and bare checksums', () => {
+    const hash = 'a'.repeat(64);
+    const other = 'b'.repeat(64);
+    expect(
+      parseChecksum(`${other}  research-cli-darwin-arm64.tar.gz\n${hash} *${ARCHIVE}\n`, ARCHIVE),
+    ).toBe(hash);
+    expect(parseChecksum(`${hash.toUpperCase()}\n`, ARCHIVE)).toBe(hash);
+    expect(parseChecksum(`${other}  other.tar.gz`, ARCHIVE)).toBeUndefined();
+  });
+
+  it('should return the archive when the checksum matches', async () => {
+    const data = Buffer.from('archive-bytes');
+    mockFetch({
+      [`https://example.com/${ARCHIVE}`]: data,
+      'https://example.com/SHA256SUMS': `${sha256(data)}  ${ARCHIVE}\n`,
+    });
+    const result = await downloadVerifiedArchive(
+      release([ARCHIVE, 'SHA256SUMS']),
+      ARCHIVE,
+    );
+    expect(result.toString()).toBe('archive-bytes');
+  });
+
+  it('should reject archives with a mismatched checksum', async () => {
+    mockFetch({
+      [`https://example.com/${ARCHIVE}`]: 'tampered',
+      [`https://example.com/${ARCHIVE}.sha256`]: 'c'.repeat(64),
+    });
+    await expect(
+      downloadVerifiedArchive(release([ARCHIVE, `${ARCHIVE}.sha256`]), ARCHIVE),
+    ).rejects.toThrow(/Checksum mismatch/);
+  });
+
+  it('should refuse releases without checksums', async () => {
+    mockFetch({});
+    await expect(
+      downloadVerifiedArchive(release([ARCHIVE]), ARCHIVE),
+    ).rejects.toThrow(/does not publish checksums/);
+  });
+});
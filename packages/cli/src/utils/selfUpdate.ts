@@ -0,0 +1,260 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { createHash } from 'node:crypto';
+import { spawnSync } from 'node:child_process';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import semver from 'semver';
+
+export const RELEASE_REPO = 'iechor-research/research-cli';
+const RELEASES_API = `https://api.github.com/repos/${RELEASE_REPO}/releases/latest`;
+
+// Directory name used by install.sh for the unpacked release.
+export const BUNDLE_DIR_NAME = 'research-cli-bundle';
+
+export interface ReleaseAsset {
+  name: string;
+  url: string;
+}
+
+export interface ReleaseInfo {
+  tag: string;
+  version: string;
+  assets: ReleaseAsset[];
+}
+
+export interface ReleaseCheck {
+  current: string;
+  latest: string;
+  updateAvailable: boolean;
+  release: ReleaseInfo;
+}
+
+/**
+ * Returns the archive name published for this platform, matching the
+ * naming used by install.sh (e.g. research-cli-linux-x64.tar.gz).
+ */
+export function getReleaseArchiveName(
+  platform: NodeJS.Platform = process.platform,
+  arch: string = process.arch,
+): string {
+  const ext = platform === 'win32' ? 'zip' : 'tar.gz';
+  return `research-cli-${platform}-${arch}.${ext}`;
+}
+
+export async function fetchLatestRelease(): Promise<ReleaseInfo> {
+  const response = await fetch(RELEASES_API, {
+    headers: { Accept: 'application/vnd.github+json' },
+  });
+  if (!response.ok) {
+    throw new Error(
+      `Failed to query latest release: ${response.status} ${response.statusText}`,
+    );
+  }
+  const data = (await response.json()) as {
+    tag_name?: string;
+    assets?: Array<{ name: string; browser_download_url: string }>;
+  };
+  if (!data.tag_name) {
+    throw new Error('Latest release has no tag.');
+  }
+  return {
+    tag: data.tag_name,
+    version: data.tag_name.replace(/^v/, ''),
+    assets: (data.assets ?? []).map((asset) => ({
+      name: asset.name,
+      url: asset.browser_download_url,
+    })),
+  };
+}
+
+export async function checkForRelease(
+  currentVersion: string,
+): Promise<ReleaseCheck> {
+  const release = await fetchLatestRelease();
+  const current = semver.valid(semver.coerce(currentVersion));
+  const latest = semver.valid(semver.coerce(release.version));
+  return {
+    current: currentVersion,
+    latest: release.version,
+    updateAvailable: !!current && !!latest && semver.gt(latest, current),
+    release,
+  };
+}
+
+/**
+ * Finds the expected SHA-256 for a file in a `sha256sum`-style listing
+ * (`<hex>  <name>` per line) or a bare `<hex>` checksum file.
+ */
+export function parseChecksum(
+  contents: string,
+  fileName: string,
+): string | undefined {
+  for (const line of contents.split(/\r?\n/)) {
+    const match = line.trim().match(/^([a-fA-F0-9]{64})(?:\s+\*?(.+))?$/);
+    if (match && (!match[2] || path.basename(match[2]) === fileName)) {
+      return match[1].toLowerCase();
+    }
+  }
+  return undefined;
+}
+
+export function sha256(data: Buffer): string {
+  return createHash('sha256').update(data).digest('hex');
+}
+
+/**
+ * Returns the install.sh bundle directory the CLI is running from, or
+ * undefined when it was installed some other way (e.g. npm).
+ */
+export function getInstalledBundleDir(
+  entryPoint: string | undefined = process.argv[1],
+): string | undefined {
+  if (!entryPoint) {
+    return undefined;
+  }
+  let dir: string;
+  try {
+    dir = path.dirname(fs.realpathSync(entryPoint));
+  } catch {
+    return undefined;
+  }
+  while (dir !== path.dirname(dir)) {
+    if (path.basename(dir) === BUNDLE_DIR_NAME) {
+      return dir;
+    }
+    dir = path.dirname(dir);
+  }
+  return undefined;
+}
+
+async function download(url: string): Promise<Buffer> {
+  const response = await fetch(url);
+  if (!response.ok) {
+    throw new Error(
+      `Download failed for ${url}: ${response.status} ${response.statusText}`,
+    );
+  }
+  return Buffer.from(await response.arrayBuffer());
+}
+
+/**
+ * Downloads the release archive for this platform and verifies it against
+ * the checksum published with the release. Refuses to continue when no
+ * checksum is available.
+ */
+export async function downloadVerifiedArchive(
+  release: ReleaseInfo,
+  archiveName: string = getReleaseArchiveName(),
+): Promise<Buffer> {
+  const archive = release.assets.find((asset) => asset.name === archiveName);
+  if (!archive) {
+    throw new Error(
+      `Release ${release.tag} has no build for this platform (${archiveName}).`,
+    );
+  }
+  const checksumAsset =
+    release.assets.find((asset) => asset.name === `${archiveName}.sha256`) ??
+    release.assets.find((asset) => asset.name === 'SHA256SUMS');
+  if (!checksumAsset) {
+    throw new Error(
+      `Release ${release.tag} does not publish checksums; refusing to install unverified binaries.`,
+    );
+  }
+
+  const expected = parseChecksum(
+    (await download(checksumAsset.url)).toString('utf8'),
+    archiveName,
+  );
+  if (!expected) {
+    throw new Error(`No checksum for ${archiveName} in ${checksumAsset.name}.`);
+  }
+
+  const data = await download(archive.url);
+  const actual = sha256(data);
+  if (actual !== expected) {
+    throw new Error(
+      `Checksum mismatch for ${archiveName}: expected ${expected}, got ${actual}.`,
+    );
+  }
+  return data;
+}
+
+/**
+ * Unpacks a verified archive and swaps it in place of the current bundle.
+ * The previous bundle is kept until the new one is in place so a failed
+ * swap can be rolled back.
+ */
+export function installArchive(
+  data: Buffer,
+  archiveName: string,
+  bundleDir: string,
+): void {
+  const workDir = fs.mkdtempSync(path.join(os.tmpdir(), 'research-cli-update-'));
+  try {
+    const archivePath = path.join(workDir, archiveName);
+    fs.writeFileSync(archivePath, data);
+    const extractDir = path.join(workDir, 'extract');
+    fs.mkdirSync(extractDir);
+
+    // The paths reach PowerShell through the environment, so quotes or `$`
+    // in them are never read as code.
+    const result = archiveName.endsWith('.zip')
+      ? spawnSync(
+          'powershell',
+          [
+            '-NoProfile',
+            '-Command',
+            'Expand-Archive -LiteralPath $env:RESEARCH_UPDATE_ARCHIVE -DestinationPath $env:RESEARCH_UPDATE_DIR',
+          ],
+          {
+            stdio: 'pipe',
+            env: {
+              ...process.env,
+              RESEARCH_UPDATE_ARCHIVE: archivePath,
+              RESEARCH_UPDATE_DIR: extractDir,
+            },
+          },
+        )
+      : spawnSync('tar', ['-xzf', archivePath, '-C', extractDir], {
+          stdio: 'pipe',
+        });
+    if (result.status !== 0) {
+      throw new Error(
+        `Failed to extract ${archiveName}: ${result.stderr?.toString().trim() || result.error?.message}`,
+      );
+    }
+
+    // Archives contain a single top-level directory (see install.sh).
+    const entries = fs.readdirSync(extractDir);
+    const newBundle =
+      entries.length === 1 &&
+      fs.statSync(path.join(extractDir, entries[0])).isDirectory()
+        ? path.join(extractDir, entries[0])
+        : extractDir;
+
+    // Stage next to the current bundle so the final rename stays on one
+    // filesystem.
+    const staged = `${bundleDir}.new`;
+    const backup = `${bundleDir}.old`;
+    fs.rmSync(staged, { recursive: true, force: true });
+    fs.rmSync(backup, { recursive: true, force: true });
+    fs.cpSync(newBundle, staged, { recursive: true });
+
+    fs.renameSync(bundleDir, backup);
+    try {
+      fs.renameSync(staged, bundleDir);
+    } catch (error) {
+      fs.renameSync(backup, bundleDir);
+      throw error;
+    }
+    fs.rmSync(backup, { recursive: true, force: true });
+  } finally {
+    fs.rmSync(workDir, { recursive: true, force: true });
+  }
+}
@@ -0,0 +1,129 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { EventEmitter } from 'node:events';
+import os from 'node:os';
+import {
+  SharedSessionClient,
+  SharedSessionEvent,
+  SharedSessionHub,
+} from '@iechor/research-cli-core';
+
+/** The author label of the model's answers in a shared session. */
+export const MODEL_AUTHOR = 'Research';
+
+/** This session shared with others, or joined from someone else's. */
+export type SharedSession =
+  | { role: 'host'; hub: SharedSessionHub; url: string }
+  | {
+      role: 'guest';
+      client: SharedSessionClient;
+      url: string;
+      /** Approval requests not yet decided, oldest first. */
+      pendingApprovals: Array<{ id: string; description: string }>;
+    };
+
+let session: SharedSession | undefined;
+const changes = new EventEmitter();
+
+export function getSharedSession(): SharedSession | undefined {
+  return session;
+}
+
+function setSharedSession(next: SharedSession | undefined): void {
+  session = next;
+  changes.emit('change', next);
+}
+
+/** Calls `listener` when a session is shared, joined or left. */
+export function onSharedSessionChange(
+  listener: (session: SharedSession | undefined) => void,
+): () => void {
+  changes.on('change', listener);
+  return () => changes.off('change', listener);
+}
+
+function userName(): string {
+  try {
+    return os.userInfo().username || 'host';
+  } catch {
+    return 'host';
+  }
+}
+
+/**
+ * Shares this session on `port` (any free port for 0). Listens on
+ * localhost unless `host` says otherwise.
+ */
+export async function startSharing(
+  port = 0,
+  host = '127.0.0.1',
+): Promise<SharedSession & { role: 'host' }> {
+  if (session) {
+    throw new Error('Leave the current shared session first (/share stop).');
+  }
+  const hub = new SharedSessionHub(userName(), undefined, [MODEL_AUTHOR]);
+  const listening = await hub.start(port, host);
+  const shared = {
+    role: 'host' as const,
+    hub,
+    url: `ws://${host.includes(':') ? `[${host}]` : host}:${listening}/`,
+  };
+  setSharedSession(shared);
+  return shared;
+}
+
+/** Joins someone else's shared session as `name`. */
+export async function joinSharedSession(
+  url: string,
+  token: string,
+  name: string = userName(),
+): Promise<SharedSession & { role: 'guest' }> {
+  if (session) {
+    throw new Error('Leave the current shared session first (/share stop).');
+  }
+  const client = await SharedSessionClient.connect(url, token, name);
+  const joined: SharedSession & { role: 'guest' } = {
+    role: 'guest',
+    client,
+    url,
+    pendingApprovals: [],
+  };
+  client.on('event', (event: SharedSessionEvent) => {
+    if (event.type === 'approval-request') {
+      joined.pendingApprovals.push({
+        id: event.id,
+        description: event.description,
+      });
+    } else if (event.type === 'approval') {
+      joined.pendingApprovals = joined.pendingApprovals.filter(
+        (pending) => pending.id !== event.id,
+      );
+    }
+  });
+  client.on('close', () => {
+    if (session === joined) {
+      setSharedSession(undefined);
+    }
+  });
+  setSharedSession(joined);
+  return joined;
+}
+
+/** Stops sharing, or leaves the joined session; false if there was none. */
+export async function stopSharing(): Promise<boolean> {
+  const current = session;
+  if (!current) {
+    return false;
+  }
+  setSharedSession(undefined);
+  if (current.role === 'host') {
+    await current.hub.stop();
+  } else {
+    current.client.close();
+  }
+  return true;
+}
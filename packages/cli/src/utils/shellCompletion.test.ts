@@ -0,0 +1,71 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect } from 'vitest';
+import type { Options } from 'yargs';
+import {
+  generateCompletionScript,
+  generateManPage,
+} from './shellCompletion.js';
+
+const options: Record<string, Options> = {
+  model: { alias: 'm', type: 'string', description: 'Model' },
+  'telemetry-target': {
+    type: 'string',
+    choices: ['local', 'gcp'],
+    description: 'Set the telemetry target (local or gcp). Overrides settings.',
+  },
+  yolo: { alias: 'y', type: 'boolean', description: "Don't ask." },
+  all_files: {
+    type: 'boolean',
+    description: 'Old spelling',
+    deprecated: 'Use --all-files instead.',
+  },
+};
+
+describe('shellCompletion', () => {
+  it('should complete flags, choices and subcommands in bash', () => {
+    const script = generateCompletionScript('bash', 'research', options);
+    expect(script).toContain(
+      'complete -o default -F _research_completions research',
+    );
+    expect(script).toContain('--model -m');
+    expect(script).toContain('compgen -W "local gcp"');
+    expect(script).toContain('completion batch eval man');
+    expect(script).not.toContain('all_files');
+  });
+
+  it('should describe flags in zsh', () => {
+    const script = generateCompletionScript('zsh', 'research', options);
+    expect(script).toContain('#compdef research');
+    expect(script).toContain(
+      "'(-m --model)'{-m,--model}'[Model]:model:_files'",
+    );
+    expect(script).toContain(
+      "'--telemetry-target[Set the telemetry target (local or gcp)]:telemetry-target:(local gcp)'",
+    );
+  });
+
+  it('should escape quotes in fish descriptions', () => {
+    const script = generateCompletionScript('fish', 'research', options);
+    expect(script).toContain(
+      "complete -c research -l yolo -s y -d 'Don\\'t ask'",
+    );
+    expect(script).toContain(
+      "complete -c research -l model -s m -r -d 'Model'",
+    );
+  });
+
+  it('should render a man page with every option', () => {
+    const page = generateManPage('research', '1.2.3', options);
+    expect(page).toMatch(/^\.TH RESEARCH 1 "" "research 1\.2\.3"/);
+    expect(page).toContain('\\fB\\-m\\fR, \\fB\\-\\-model\\fR \\fIVALUE\\fR');
+    expect(page).toContain(
+      '\\fB\\-\\-telemetry\\-target\\fR \\fIlocal|gcp\\fR',
+    );
+    expect(page).not.toContain('all_files');
+  });
+});
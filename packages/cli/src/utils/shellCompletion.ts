@@ -0,0 +1,249 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import type { Options } from 'yargs';
+
+export const COMPLETION_SHELLS = ['bash', 'zsh', 'fish'] as const;
+export type CompletionShell = (typeof COMPLETION_SHELLS)[number];
+
+const SUBCOMMANDS: Array<{ name: string; description: string }> = [
+  { name: 'completion', description: 'Print a shell completion script' },
+  { name: 'batch', description: 'Run a file of prompts through the model' },
+  { name: 'eval', description: 'Run a suite of scripted conversations' },
+  { name: 'man', description: 'Print the man page' },
+];
+
+interface FlagSpec {
+  long: string;
+  short?: string;
+  description: string;
+  takesValue: boolean;
+  choices?: string[];
+}
+
+function toFlagSpecs(options: Record<string, Options>): FlagSpec[] {
+  const specs = Object.entries(options)
+    .filter(([, option]) => !option.deprecated && !option.hidden)
+    .map(([name, option]): FlagSpec => {
+      const aliases = [option.alias ?? []].flat();
+      return {
+        long: name,
+        short: aliases.find((alias) => alias.length === 1),
+        description: String(option.description ?? option.describe ?? ''),
+        takesValue: option.type !== 'boolean',
+        choices: option.choices?.map(String),
+      };
+    });
+  specs.push(
+    {
+      long: 'help',
+      short: 'h',
+      description: 'Show help',
+      takesValue: false,
+    },
+    {
+      long: 'version',
+      short: 'v',
+      description: 'Show version and build information',
+      takesValue: false,
+    },
+  );
+  return specs;
+}
+
+function firstSentence(text: string): string {
+  return text.split(/(?<=[.?])\s/)[0].replace(/[.?]$/, '');
+}
+
+function bashScript(bin: string, flags: FlagSpec[]): string {
+  const words = [
+    ...SUBCOMMANDS.map((c) => c.name),
+    ...flags.flatMap((f) => [
+      `--${f.long}`,
+      ...(f.short ? [`-${f.short}`] : []),
+    ]),
+  ].join(' ');
+  const choiceCases = flags
+    .filter((f) => f.choices)
+    .map(
+      (f) =>
+        `    --${f.long})\n      COMPREPLY=($(compgen -W "${f.choices!.join(' ')}" -- "$cur"))\n      return ;;`,
+    );
+  const fn = `_${bin.replace(/[^A-Za-z0-9_]/g, '_')}_completions`;
+  return `# bash completion for ${bin}
+${fn}() {
+  local cur prev
+  cur="\${COMP_WORDS[COMP_CWORD]}"
+  prev="\${COMP_WORDS[COMP_CWORD-1]}"
+  case "$prev" in
+${[
+  ...choiceCases,
+  `    completion)\n      COMPREPLY=($(compgen -W "${COMPLETION_SHELLS.join(' ')}" -- "$cur"))\n      return ;;`,
+].join('\n')}
+  esac
+  COMPREPLY=($(compgen -W "${words}" -- "$cur"))
+}
+complete -o default -F ${fn} ${bin}
+`;
+}
+
+function zshEscape(text: string): string {
+  return text.replace(/'/g, "'\\''").replace(/[[\]:]/g, '\\$&');
+}
+
+function zshScript(bin: string, flags: FlagSpec[]): string {
+  const specs = flags.map((f) => {
+    const desc = zshEscape(firstSentence(f.description));
+    const value = f.takesValue
+      ? `:${f.long}:${f.choices ? `(${f.choices.join(' ')})` : '_files'}`
+      : '';
+    if (f.short) {
+      return `    '(-${f.short} --${f.long})'{-${f.short},--${f.long}}'[${desc}]${value}'`;
+    }
+    return `    '--${f.long}[${desc}]${value}'`;
+  });
+  const commands = SUBCOMMANDS.map(
+    (c) => `    '${c.name}:${zshEscape(c.description)}'`,
+  );
+  return `#compdef ${bin}
+
+_${bin}() {
+  local -a commands
+  commands=(
+${commands.join('\n')}
+  )
+  _arguments -s \\
+${specs.join(' \\\n')} \\
+    '1: :->command' \\
+    '*:: :->args'
+  case $state in
+    command)
+      _describe 'command' commands ;;
+    args)
+      [[ $words[1] == completion ]] && _values 'shell' ${COMPLETION_SHELLS.join(' ')} ;;
+  esac
+}
+
+compdef _${bin} ${bin}
+`;
+}
+
+function fishScript(bin: string, flags: FlagSpec[]): string {
+  const escape = (text: string) => text.replace(/'/g, "\\'");
+  const lines = flags.map((f) => {
+    const parts = [`complete -c ${bin}`, `-l ${f.long}`];
+    if (f.short) parts.push(`-s ${f.short}`);
+    if (f.takesValue) parts.push('-r');
+    if (f.choices) parts.push(`-a '${f.choices.join(' ')}'`);
+    parts.push(`-d '${escape(firstSentence(f.description))}'`);
+    return parts.join(' ');
+  });
+  const commands = SUBCOMMANDS.map(
+    (c) =>
+      `complete -c ${bin} -n '__fish_use_subcommand' -f -a ${c.name} -d '${escape(c.description)}'`,
+  );
+  return `# fish completion for ${bin}
+${commands.join('\n')}
+complete -c ${bin} -n '__fish_seen_subcommand_from completion' -f -a '${COMPLETION_SHELLS.join(' ')}'
+${lines.join('\n')}
+`;
+}
+
+/**
+ * Renders a completion script for the given shell from the CLI option
+ * definitions.
+ */
+export function generateCompletionScript(
+  shell: CompletionShell,
+  bin: string,
+  options: Record<string, Options>,
+): string {
+  const flags = toFlagSpecs(options);
+  switch (shell) {
+    case 'bash':
+      return bashScript(bin, flags);
+    case 'zsh':
+      return zshScript(bin, flags);
+    case 'fish':
+      return fishScript(bin, flags);
+    default:
+      throw new Error(`Unsupported shell: ${shell}`);
+  }
+}
+
+function roffEscape(text: string): string {
+  return text
+    .replace(/\\/g, '\\\\')
+    .replace(/-/g, '\\-')
+    .replace(/^\./gm, '\\&.');
+}
+
+/**
+ * Renders a roff man page (section 1) from the CLI option definitions.
+ */
+export function generateManPage(
+  bin: string,
+  version: string,
+  options: Record<string, Options>,
+): string {
+  const flags = toFlagSpecs(options);
+  const lines = [
+    `.TH ${bin.toUpperCase()} 1 "" "${bin} ${version}" "User Commands"`,
+    '.SH NAME',
+    `${bin} \\- Research CLI, an interactive research assistant for the terminal`,
+    '.SH SYNOPSIS',
+    `.B ${bin}`,
+    '[\\fIOPTIONS\\fR]',
+    '.br',
+    `.B ${bin}`,
+    'completion \\fISHELL\\fR',
+    '.br',
+    `.B ${bin}`,
+    'batch \\fB\\-\\-prompts\\fR \\fIFILE\\fR \\fB\\-\\-out\\fR \\fIFILE\\fR [\\fB\\-\\-template\\fR \\fIFILE\\fR] [\\fB\\-\\-concurrency\\fR \\fIN\\fR] [\\fB\\-\\-retries\\fR \\fIN\\fR]',
+    '.br',
+    `.B ${bin}`,
+    'eval \\fISUITE\\fR [\\fB\\-\\-junit\\fR \\fIFILE\\fR] [\\fB\\-\\-json\\fR \\fIFILE\\fR]',
+    '.br',
+    `.B ${bin}`,
+    'man',
+    '.SH DESCRIPTION',
+    'Launches an interactive research session. Use \\fB\\-p\\fR/\\fB\\-\\-prompt\\fR for non-interactive mode.',
+    '.SH COMMANDS',
+    '.TP',
+    '.B completion \\fISHELL\\fR',
+    `Print a completion script for ${COMPLETION_SHELLS.join(', ')}.`,
+    '.TP',
+    '.B batch',
+    'Send each prompt of a JSONL file to the configured model, a few at a time, and write the answers to a JSONL file. Exits with 1 when a prompt fails.',
+    '.TP',
+    '.B eval \\fISUITE\\fR',
+    'Replay the scripted conversations of a YAML or JSON suite, check the tool calls and answers, and write JUnit or JSON reports. Exits with 1 when a case fails.',
+    '.TP',
+    '.B man',
+    'Print this man page.',
+    '.SH OPTIONS',
+  ];
+  for (const flag of flags) {
+    const names = [
+      ...(flag.short ? [`\\fB\\-${flag.short}\\fR`] : []),
+      `\\fB\\-\\-${roffEscape(flag.long)}\\fR`,
+    ].join(', ');
+    const value = flag.takesValue
+      ? ` \\fI${flag.choices ? flag.choices.join('|') : 'VALUE'}\\fR`
+      : '';
+    lines.push('.TP', `${names}${value}`, roffEscape(flag.description));
+  }
+  lines.push(
+    '.SH FILES',
+    '.TP',
+    '.I ~/.research/settings.json',
+    'User settings.',
+    '.TP',
+    '.I .research/settings.json',
+    'Workspace settings.',
+  );
+  return `${lines.join('\n')}\n`;
+}
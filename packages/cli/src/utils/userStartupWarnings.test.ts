@@ -8,6 +8,7 @@ import { describe, it, expect, vi, beforeEach, afterEach } from 'vitest';
 import { getUserStartupWarnings } from './userStartupWarnings.js';
 import * as os from 'os';
 import fs from 'fs/promises';
+import { getWindowsConsoleWarning } from '../ui/utils/windowsTerminal.js';
 
 vi.mock('os', () => ({
   default: { homedir: vi.fn() },
@@ -18,6 +19,10 @@ vi.mock('fs/promises', () => ({
   default: { realpath: vi.fn() },
 }));
 
+vi.mock('../ui/utils/windowsTerminal.js', () => ({
+  getWindowsConsoleWarning: vi.fn(),
+}));
+
 describe('getUserStartupWarnings', () => {
   const homeDir = '/home/user';
 
@@ -66,6 +71,26 @@ describe('getUserStartupWarnings', () => {
     });
   });
 
+  describe('windows console check', () => {
+    it('should pass on the console warning', async () => {
+      vi.mocked(getWindowsConsoleWarning).mockReturnValue(
+        'Research CLI is running in the legacy Windows console.',
+      );
+
+      const warnings = await getUserStartupWarnings('/some/project/path');
+      expect(warnings).toContainEqual(
+        expect.stringContaining('legacy Windows console'),
+      );
+    });
+
+    it('should not warn in a capable terminal', async () => {
+      vi.mocked(getWindowsConsoleWarning).mockReturnValue(undefined);
+
+      const warnings = await getUserStartupWarnings('/some/project/path');
+      expect(warnings).toEqual([]);
+    });
+  });
+
   // // Example of how to add a new check:
   // describe('node version check', () => {
   //   // Tests for node version check would go here
@@ -6,6 +6,7 @@
 
 import fs from 'fs/promises';
 import * as os from 'os';
+import { getWindowsConsoleWarning } from '../ui/utils/windowsTerminal.js';
 
 type WarningCheck = {
   id: string;
@@ -32,8 +33,16 @@ const homeDirectoryCheck: WarningCheck = {
   },
 };
 
+const windowsConsoleCheck: WarningCheck = {
+  id: 'windows-console',
+  check: async () => getWindowsConsoleWarning() ?? null,
+};
+
 // All warning checks
-const WARNING_CHECKS: readonly WarningCheck[] = [homeDirectoryCheck];
+const WARNING_CHECKS: readonly WarningCheck[] = [
+  homeDirectoryCheck,
+  windowsConsoleCheck,
+];
 
 export async function getUserStartupWarnings(
   workspaceRoot: string,
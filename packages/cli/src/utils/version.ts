@@ -4,9 +4,26 @@
  * SPDX-License-Identifier: Apache-2.0
  */
 
+import { GIT_COMMIT_INFO } from '../generated/git-commit.js';
 import { getPackageJson } from './package.js';
 
 export async function getCliVersion(): Promise<string> {
   const pkgJson = await getPackageJson();
   return process.env.CLI_VERSION || pkgJson?.version || 'unknown';
 }
+
+/**
+ * Version string printed by --version. The first line is the bare version so
+ * scripts (including install.sh) can keep parsing it.
+ */
+export async function getBuildInfo(): Promise<string> {
+  const version = await getCliVersion();
+  const commit =
+    GIT_COMMIT_INFO && GIT_COMMIT_INFO !== 'N/A' ? GIT_COMMIT_INFO : 'unknown';
+  return [
+    version,
+    `commit:   ${commit}`,
+    `node:     ${process.version}`,
+    `platform: ${process.platform}-${process.arch}`,
+  ].join('\n');
+}
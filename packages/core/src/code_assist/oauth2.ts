@@ -17,7 +17,6 @@ import * as net from 'net';
 import open from 'open';
 import path from 'node:path';
 import { promises as fs } from 'node:fs';
-import * as os from 'os';
 import { Config } from '../config/config.js';
 import { getErrorMessage } from '../utils/errors.js';
 import {
@@ -25,6 +24,7 @@ import {
   getCachediEchorAccount,
   clearCachediEchorAccount,
 } from '../utils/user_account.js';
+import { getUserResearchDir } from '../utils/paths.js';
 import { AuthType } from '../core/contentGenerator.js';
 import readline from 'node:readline';
 
@@ -53,7 +53,6 @@ const SIGN_IN_SUCCESS_URL =
 const SIGN_IN_FAILURE_URL =
   'https://developers.iechor.com/research-code-assist/auth_failure_research';
 
-const RESEARCH_DIR = '.research';
 const CREDENTIAL_FILENAME = 'oauth_creds.json';
 
 /**
@@ -318,7 +317,7 @@ async function cacheCredentials(credentials: Credentials) {
 }
 
 function getCachedCredentialPath(): string {
-  return path.join(os.homedir(), RESEARCH_DIR, CREDENTIAL_FILENAME);
+  return path.join(getUserResearchDir(), CREDENTIAL_FILENAME);
 }
 
 export async function clearCachedCredentialFile() {
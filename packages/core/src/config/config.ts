@@ -20,6 +20,14 @@ import { GlobTool } from '../tools/glob.js';
 import { EditTool } from '../tools/edit.js';
 import { ShellTool } from '../tools/shell.js';
 import { WriteFileTool } from '../tools/write-file.js';
+import { NotebookEditTool } from '../tools/notebook-edit.js';
+import { DataQueryTool } from '../tools/data-query.js';
+import { SqlQueryTool } from '../tools/sql-query.js';
+import {
+  HttpRequestSettings,
+  HttpRequestTool,
+} from '../tools/http-request.js';
+import { CreateCalendarEventTool } from '../tools/calendar-event.js';
 import { WebFetchTool } from '../tools/web-fetch.js';
 import { ReadManyFilesTool } from '../tools/read-many-files.js';
 import {
@@ -33,6 +41,13 @@ import { FileDiscoveryService } from '../services/fileDiscoveryService.js';
 import { GitService } from '../services/gitService.js';
 import { loadServerHierarchicalMemory } from '../utils/memoryDiscovery.js';
 import { getProjectTempDir } from '../utils/paths.js';
+import { NetworkSettings } from '../utils/network.js';
+import { RedactionSettings } from '../utils/redaction.js';
+import { setIncognitoMode } from '../utils/incognito.js';
+import { RemoteTarget } from '../utils/remoteTarget.js';
+import { ContainerExecutionSettings } from '../utils/containerExecution.js';
+import { SqlDatabaseConfig } from '../utils/sqlDatabase.js';
+import { CalendarSettings } from '../utils/calendar.js';
 import {
   initializeTelemetry,
   DEFAULT_TELEMETRY_TARGET,
@@ -137,6 +152,9 @@ export interface ConfigParameters {
   };
   checkpointing?: boolean;
   proxy?: string;
+  network?: NetworkSettings;
+  modelFallbacks?: string[];
+  redaction?: RedactionSettings;
   cwd: string;
   fileDiscoveryService?: FileDiscoveryService;
   bugCommand?: BugCommandSettings;
@@ -146,6 +164,14 @@ export interface ConfigParameters {
   listExtensions?: boolean;
   activeExtensions?: ActiveExtension[];
   noBrowser?: boolean;
+  incognito?: boolean;
+  mockScript?: string;
+  recordFile?: string;
+  remote?: RemoteTarget;
+  containerExecution?: ContainerExecutionSettings;
+  sqlDatabases?: Record<string, SqlDatabaseConfig>;
+  httpRequest?: HttpRequestSettings;
+  calendar?: CalendarSettings;
 }
 
 export class Config {
@@ -180,17 +206,30 @@ export class Config {
   private gitService: GitService | undefined = undefined;
   private readonly checkpointing: boolean;
   private readonly proxy: string | undefined;
+  private readonly network: NetworkSettings;
+  private readonly modelFallbacks: string[];
+  private activeFallbackModel: string | undefined;
+  private readonly redaction: RedactionSettings | undefined;
   private readonly cwd: string;
   private readonly bugCommand: BugCommandSettings | undefined;
   private readonly model: string;
   private readonly extensionContextFilePaths: string[];
   private readonly noBrowser: boolean;
+  private readonly incognito: boolean;
+  private readonly mockScript: string | undefined;
+  private readonly recordFile: string | undefined;
+  private readonly remote: RemoteTarget | undefined;
+  private readonly containerExecution: ContainerExecutionSettings | undefined;
+  private readonly sqlDatabases: Record<string, SqlDatabaseConfig>;
+  private readonly httpRequest: HttpRequestSettings;
+  private readonly calendar: CalendarSettings;
   private modelSwitchedDuringSession: boolean = false;
   private readonly maxSessionTurns: number;
   private readonly listExtensions: boolean;
   private readonly _activeExtensions: ActiveExtension[];
   flashFallbackHandler?: FlashFallbackHandler;
   private quotaErrorOccurred: boolean = false;
+  private inQuotaFallback: boolean = false;
   private researchConfigManager: ResearchConfigManager;
 
   constructor(params: ConfigParameters) {
@@ -228,6 +267,9 @@ export class Config {
     };
     this.checkpointing = params.checkpointing ?? false;
     this.proxy = params.proxy;
+    this.network = params.network ?? {};
+    this.modelFallbacks = params.modelFallbacks ?? [];
+    this.redaction = params.redaction;
     this.cwd = params.cwd ?? process.cwd();
     this.fileDiscoveryService = params.fileDiscoveryService ?? null;
     this.bugCommand = params.bugCommand;
@@ -237,6 +279,15 @@ export class Config {
     this.listExtensions = params.listExtensions ?? false;
     this._activeExtensions = params.activeExtensions ?? [];
     this.noBrowser = params.noBrowser ?? false;
+    this.incognito = params.incognito ?? false;
+    this.mockScript = params.mockScript;
+    this.recordFile = params.recordFile;
+    this.remote = params.remote;
+    this.containerExecution = params.containerExecution;
+    this.sqlDatabases = params.sqlDatabases ?? {};
+    this.httpRequest = params.httpRequest ?? {};
+    this.calendar = params.calendar ?? {};
+    setIncognitoMode(this.incognito);
 
     // Initialize research configuration manager
     this.researchConfigManager = new ResearchConfigManager(this.cwd);
@@ -318,6 +369,22 @@ export class Config {
     }
   }
 
+  getModelFallbacks(): string[] {
+    return this.modelFallbacks;
+  }
+
+  /**
+   * The model currently answering in place of the selected one because of the
+   * fallback chain, or undefined when the selected model is healthy.
+   */
+  getActiveFallbackModel(): string | undefined {
+    return this.activeFallbackModel;
+  }
+
+  setActiveFallbackModel(model: string | undefined): void {
+    this.activeFallbackModel = model;
+  }
+
   setFlashFallbackHandler(handler: FlashFallbackHandler): void {
     this.flashFallbackHandler = handler;
   }
@@ -334,6 +401,18 @@ export class Config {
     return this.quotaErrorOccurred;
   }
 
+  /**
+   * Whether a quota error switched the session to the fallback model for
+   * the rest of the session, in which case nothing should switch it back.
+   */
+  isInQuotaFallback(): boolean {
+    return this.inQuotaFallback;
+  }
+
+  setInQuotaFallback(value: boolean): void {
+    this.inQuotaFallback = value;
+  }
+
   async getUserTier(): Promise<UserTierId | undefined> {
     if (!this.researchClient) {
       return undefined;
@@ -434,6 +513,9 @@ export class Config {
   }
 
   getTelemetryLogPromptsEnabled(): boolean {
+    if (this.incognito) {
+      return false;
+    }
     return this.telemetrySettings.logPrompts ?? true;
   }
 
@@ -473,6 +555,14 @@ export class Config {
     return this.proxy;
   }
 
+  getNetworkSettings(): NetworkSettings {
+    return { ...this.network, proxy: this.proxy ?? this.network.proxy };
+  }
+
+  getRedactionSettings(): RedactionSettings | undefined {
+    return this.redaction;
+  }
+
   getWorkingDir(): string {
     return this.cwd;
   }
@@ -508,6 +598,45 @@ export class Config {
     return this.noBrowser;
   }
 
+  isIncognito(): boolean {
+    return this.incognito;
+  }
+
+  /** Path of the script replayed in mock mode; undefined uses the built-in one. */
+  getMockScript(): string | undefined {
+    return this.mockScript;
+  }
+
+  /** File that provider traffic is recorded to, if recording is on. */
+  getRecordFile(): string | undefined {
+    return this.recordFile;
+  }
+
+  /** SSH host that shell commands run on, if one is configured. */
+  getRemoteTarget(): RemoteTarget | undefined {
+    return this.remote;
+  }
+
+  /** Container that selected tools run in, if one is configured. */
+  getContainerExecution(): ContainerExecutionSettings | undefined {
+    return this.containerExecution;
+  }
+
+  /** Databases the sql_query tool may read, by name. */
+  getSqlDatabases(): Record<string, SqlDatabaseConfig> {
+    return this.sqlDatabases;
+  }
+
+  /** Host allowlist for the http_request tool. */
+  getHttpRequestSettings(): HttpRequestSettings {
+    return this.httpRequest;
+  }
+
+  /** Where create_calendar_event writes events. */
+  getCalendarSettings(): CalendarSettings {
+    return this.calendar;
+  }
+
   getResearchConfigManager(): ResearchConfigManager {
     return this.researchConfigManager;
   }
@@ -571,17 +700,34 @@ export class Config {
       }
     };
 
-    registerCoreTool(LSTool, targetDir, this);
-    registerCoreTool(ReadFileTool, targetDir, this);
-    registerCoreTool(GrepTool, targetDir);
-    registerCoreTool(GlobTool, targetDir, this);
-    registerCoreTool(EditTool, this);
-    registerCoreTool(WriteFileTool, this);
+    // With a remote target, the file tools work on the files of the host
+    // that shell commands run on. The tools without a remote mode are left
+    // out, so the model never sees two different file systems.
+    const remote = this.getRemoteTarget();
+    if (!remote) {
+      registerCoreTool(LSTool, targetDir, this);
+    }
+    registerCoreTool(ReadFileTool, targetDir, this, remote);
+    registerCoreTool(GrepTool, targetDir, remote);
+    registerCoreTool(GlobTool, targetDir, this, remote);
+    registerCoreTool(EditTool, this, remote);
+    registerCoreTool(WriteFileTool, this, remote);
+    if (!remote) {
+      registerCoreTool(NotebookEditTool, this);
+    }
     registerCoreTool(WebFetchTool, this);
-    registerCoreTool(ReadManyFilesTool, targetDir, this);
+    registerCoreTool(HttpRequestTool, this);
+    if (!remote) {
+      registerCoreTool(ReadManyFilesTool, targetDir, this);
+    }
+    registerCoreTool(DataQueryTool, this);
+    if (Object.keys(this.sqlDatabases).length > 0) {
+      registerCoreTool(SqlQueryTool, this);
+    }
     registerCoreTool(ShellTool, this);
-    registerCoreTool(MemoryTool);
+    registerCoreTool(MemoryTool, this);
     registerCoreTool(WebSearchTool, this);
+    registerCoreTool(CreateCalendarEventTool, this);
 
     // Register research tools
     await this.registerResearchTools(registry);
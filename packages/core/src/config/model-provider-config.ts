@@ -26,6 +26,12 @@ export interface ModelProviderSettings {
         timeout?: number;
         retryAttempts?: number;
         retryDelay?: number;
+        // 每个请求附带的额外HTTP头（Azure OpenAI / OpenAI兼容端点）
+        headers?: Record<string, string>;
+        // 模型别名 -> 端点上的模型名或 Azure 部署名
+        modelAliases?: Record<string, string>;
+        // Azure OpenAI API 版本
+        apiVersion?: string;
         // 特定提供商的额外配置
         extra?: Record<string, any>;
       }
@@ -66,6 +72,16 @@ const ENV_VAR_MAPPING: Record<ModelProvider, string> = {
   [ModelProvider.VERTEX_AI]: 'GOOGLE_APPLICATION_CREDENTIALS',
   [ModelProvider.BAIDU]: 'BAIDU_LLM_KEY',
   [ModelProvider.MOONSHOT]: 'MOONSHOT_API_KEY',
+  [ModelProvider.AZURE_OPENAI]: 'AZURE_OPENAI_API_KEY',
+  [ModelProvider.OPENAI_COMPATIBLE]: 'OPENAI_COMPATIBLE_API_KEY',
+};
+
+/**
+ * 端点地址环境变量映射（仅适用于需要自定义地址的提供商）
+ */
+const BASE_URL_ENV_VAR_MAPPING: Partial<Record<ModelProvider, string>> = {
+  [ModelProvider.AZURE_OPENAI]: 'AZURE_OPENAI_ENDPOINT',
+  [ModelProvider.OPENAI_COMPATIBLE]: 'OPENAI_COMPATIBLE_BASE_URL',
 };
 
 /**
@@ -90,6 +106,8 @@ export const DEFAULT_MODELS: Record<ModelProvider, string> = {
   [ModelProvider.VERTEX_AI]: 'gemini-1.5-flash',
   [ModelProvider.BAIDU]: 'ernie-4.5-turbo-128k',
   [ModelProvider.MOONSHOT]: 'kimi-k2-0711-preview',
+  [ModelProvider.AZURE_OPENAI]: 'azure/gpt-4o',
+  [ModelProvider.OPENAI_COMPATIBLE]: 'compat/default',
 };
 
 /**
@@ -108,8 +126,10 @@ export class ModelProviderConfigManager {
   getProviderConfig(provider: ModelProvider): ModelConfig | null {
     const providerSettings = this.settings.providers?.[provider];
     const envApiKey = process.env[ENV_VAR_MAPPING[provider]];
+    const baseUrlEnvVar = BASE_URL_ENV_VAR_MAPPING[provider];
+    const envBaseUrl = baseUrlEnvVar ? process.env[baseUrlEnvVar] : undefined;
 
-    if (!providerSettings && !envApiKey) {
+    if (!providerSettings && !envApiKey && !envBaseUrl) {
       return null;
     }
 
@@ -117,7 +137,7 @@ export class ModelProviderConfigManager {
       provider,
       model: providerSettings?.defaultModel || DEFAULT_MODELS[provider],
       apiKey: providerSettings?.apiKey || envApiKey,
-      baseUrl: providerSettings?.baseUrl,
+      baseUrl: providerSettings?.baseUrl || envBaseUrl,
       timeout: providerSettings?.timeout || 30000,
       temperature: this.settings.globalConfig?.temperature || 0.7,
       maxTokens: this.settings.globalConfig?.maxTokens || 2048,
@@ -126,7 +146,34 @@ export class ModelProviderConfigManager {
       frequencyPenalty: this.settings.globalConfig?.frequencyPenalty || 0,
       presencePenalty: this.settings.globalConfig?.presencePenalty || 0,
       stopSequences: this.settings.globalConfig?.stopSequences,
-      extra: providerSettings?.extra,
+      extra: this.buildExtra(provider, providerSettings),
+    };
+  }
+
+  /**
+   * 合并端点相关设置到 extra
+   */
+  private buildExtra(
+    provider: ModelProvider,
+    providerSettings: NonNullable<ModelProviderSettings['providers']>[ModelProvider],
+  ): Record<string, any> | undefined {
+    const apiVersion =
+      providerSettings?.apiVersion ||
+      (provider === ModelProvider.AZURE_OPENAI
+        ? process.env.AZURE_OPENAI_API_VERSION
+        : undefined);
+    if (
+      !providerSettings?.headers &&
+      !providerSettings?.modelAliases &&
+      !apiVersion
+    ) {
+      return providerSettings?.extra;
+    }
+    return {
+      ...providerSettings?.extra,
+      headers: providerSettings?.headers,
+      modelAliases: providerSettings?.modelAliases,
+      apiVersion,
     };
   }
 
@@ -153,6 +200,17 @@ export class ModelProviderConfigManager {
       }
     });
 
+    // 仅配置了端点地址的兼容提供商（本地服务无需密钥）
+    Object.entries(BASE_URL_ENV_VAR_MAPPING).forEach(([provider, envVar]) => {
+      if (
+        envVar &&
+        process.env[envVar] &&
+        !providers.includes(provider as ModelProvider)
+      ) {
+        providers.push(provider as ModelProvider);
+      }
+    });
+
     return providers;
   }
 
@@ -272,8 +330,20 @@ export class ModelProviderConfigManager {
       return false;
     }
 
-    // 基本验证
-    if (!config.apiKey && provider !== ModelProvider.OLLAMA) {
+    // 基本验证（本地兼容端点如 vLLM、LM Studio 通常不需要密钥）
+    if (
+      !config.apiKey &&
+      provider !== ModelProvider.OLLAMA &&
+      provider !== ModelProvider.OPENAI_COMPATIBLE
+    ) {
+      return false;
+    }
+
+    if (
+      (provider === ModelProvider.AZURE_OPENAI ||
+        provider === ModelProvider.OPENAI_COMPATIBLE) &&
+      !config.baseUrl
+    ) {
       return false;
     }
 
@@ -6,7 +6,7 @@
 
 import * as fs from 'fs/promises';
 import * as path from 'path';
-import { homedir, platform } from 'os';
+import { platform } from 'os';
 import {
   ResearchSettings,
   DEFAULT_RESEARCH_CONFIG,
@@ -23,6 +23,7 @@ import {
   TargetAudience,
   ProjectMember,
 } from '../tools/research/types.js';
+import { getUserResearchDir } from '../utils/paths.js';
 
 /**
  * 配置文件作用域
@@ -48,7 +49,7 @@ export class ResearchConfigPaths {
   }
 
   private static getUserConfigPath(): string {
-    return path.join(homedir(), '.research', 'research-config.json');
+    return path.join(getUserResearchDir(), 'research-config.json');
   }
 
   private static getWorkspaceConfigPath(workspaceRoot: string): string {
@@ -192,7 +192,10 @@ describe('Research Client (client.ts)', () => {
         getFullContext: vi.fn().mockReturnValue(false),
         getSessionId: vi.fn().mockReturnValue('test-session-id'),
         getProxy: vi.fn().mockReturnValue(undefined),
+        getNetworkSettings: vi.fn().mockReturnValue({}),
+        getRedactionSettings: vi.fn().mockReturnValue(undefined),
         getWorkingDir: vi.fn().mockReturnValue('/test/dir'),
+        getTargetDir: vi.fn().mockReturnValue('/test/dir'),
         getFileService: vi.fn().mockReturnValue(fileService),
         getMaxSessionTurns: vi.fn().mockReturnValue(0),
         getQuotaErrorOccurred: vi.fn().mockReturnValue(false),
@@ -439,6 +442,21 @@ describe('Research Client (client.ts)', () => {
     });
   });
 
+  describe('getRequestContext', () => {
+    it('should return the system instruction and the filtered history', async () => {
+      await client.addHistory({ role: 'user', parts: [{ text: 'secret' }] });
+      client.addContextFilter((history) =>
+        history.filter((c) => !JSON.stringify(c).includes('secret')),
+      );
+
+      const context = await client.getRequestContext();
+
+      expect(context.systemInstruction).toBe(getCoreSystemPrompt(''));
+      expect(JSON.stringify(context.history)).not.toContain('secret');
+      expect(context.history.length).toBeGreaterThan(0);
+    });
+  });
+
   describe('resetChat', () => {
     it('should create a new chat session, clearing the old history', async () => {
       // 1. Get the initial chat instance and add some history.
@@ -15,6 +15,10 @@ import {
   GenerateContentResponse,
 } from '@google/genai';
 import { getFolderStructure } from '../utils/getFolderStructure.js';
+import {
+  formatLatexProjectContext,
+  parseLatexProject,
+} from '../utils/latexProject.js';
 import {
   Turn,
   ServerResearchStreamEvent,
@@ -27,7 +31,12 @@ import { ReadManyFilesTool } from '../tools/read-many-files.js';
 import { getResponseText } from '../utils/generateContentResponseUtilities.js';
 import { checkNextSpeaker } from '../utils/nextSpeakerChecker.js';
 import { reportError } from '../utils/errorReporting.js';
-import { ResearchChat } from './researchChat.js';
+import {
+  ContextFilter,
+  GenerationOptions,
+  RequestContext,
+  ResearchChat,
+} from './researchChat.js';
 import { retryWithBackoff } from '../utils/retry.js';
 import { getErrorMessage } from '../utils/errors.js';
 import { tokenLimit } from './tokenLimits.js';
@@ -37,7 +46,13 @@ import {
   ContentGeneratorConfig,
   createContentGenerator,
 } from './contentGenerator.js';
-import { ProxyAgent, setGlobalDispatcher } from 'undici';
+import { applyNetworkSettings } from '../utils/network.js';
+import { applyRedactionSettings } from '../utils/redaction.js';
+import {
+  formatTerminologyInstructions,
+  loadTerminology,
+  Terminology,
+} from '../utils/terminology.js';
 import { DEFAULT_RESEARCH_FLASH_MODEL } from '../config/models.js';
 
 function isThinkingSupported(model: string) {
@@ -87,6 +102,8 @@ export class ResearchClient {
     topP: 1,
   };
   private sessionTurnCount = 0;
+  private contextFilters: ContextFilter[] = [];
+  private generationOptions: GenerationOptions = {};
   private readonly MAX_TURNS = 100;
   /**
    * Threshold for compression token count as a fraction of the model's token limit.
@@ -100,9 +117,15 @@ export class ResearchClient {
   private readonly COMPRESSION_PRESERVE_THRESHOLD = 0.3;
 
   constructor(private config: Config) {
-    if (config.getProxy()) {
-      setGlobalDispatcher(new ProxyAgent(config.getProxy() as string));
+    const network = config.getNetworkSettings();
+    if (
+      network.proxy ||
+      network.caCertFile ||
+      network.rejectUnauthorized === false
+    ) {
+      applyNetworkSettings(network);
     }
+    applyRedactionSettings(config.getRedactionSettings());
 
     this.embeddingModel = config.getEmbeddingModel();
   }
@@ -142,14 +165,87 @@ export class ResearchClient {
     return this.getChat().getHistory();
   }
 
+  getHistoryRevision(): number {
+    return this.getChat().getHistoryRevision();
+  }
+
   setHistory(history: Content[]) {
     this.getChat().setHistory(history);
   }
 
+  /**
+   * What the next request is sent with ahead of the prompt: the system
+   * instruction, the user memory included, and the history once the
+   * context filters have rewritten it.
+   */
+  async getRequestContext(): Promise<RequestContext> {
+    return {
+      systemInstruction: await this.getSystemInstruction(),
+      history: this.getChat().getRequestHistory(),
+    };
+  }
+
+  /**
+   * Adds a filter that rewrites the history sent with each request, on this
+   * chat and the ones started after it. Returns a function that removes it.
+   */
+  addContextFilter(filter: ContextFilter): () => void {
+    this.contextFilters.push(filter);
+    this.chat?.setContextFilter(this.getContextFilter());
+    return () => {
+      this.contextFilters = this.contextFilters.filter((f) => f !== filter);
+      this.chat?.setContextFilter(this.getContextFilter());
+    };
+  }
+
+  private getContextFilter(): ContextFilter | undefined {
+    if (this.contextFilters.length === 0) {
+      return undefined;
+    }
+    const filters = [...this.contextFilters];
+    return (history) => filters.reduce((h, filter) => filter(h), history);
+  }
+
+  getGenerationOptions(): GenerationOptions {
+    return { ...this.generationOptions };
+  }
+
+  /**
+   * Sets the output options of this chat and the ones started after it.
+   * Utility requests, such as the JSON ones, are not affected.
+   */
+  setGenerationOptions(options: GenerationOptions): void {
+    this.generationOptions = { ...options };
+    this.chat?.setGenerationOptions(this.generationOptions);
+  }
+
   async resetChat(): Promise<void> {
     this.chat = await this.startChat();
   }
 
+  /**
+   * Offers the tools currently in the registry to the model, after tools
+   * were registered or removed during the session.
+   */
+  async setTools(): Promise<void> {
+    const toolRegistry = await this.config.getToolRegistry();
+    const toolDeclarations = toolRegistry.getFunctionDeclarations();
+    this.getChat().setTools([{ functionDeclarations: toolDeclarations }]);
+  }
+
+  private async getSystemInstruction(): Promise<string> {
+    let terminology: Terminology | undefined;
+    try {
+      terminology = await loadTerminology(this.config.getTargetDir());
+    } catch {
+      // A malformed terminology file should not stop the session
+    }
+    return getCoreSystemPrompt(
+      this.config.getUserMemory(),
+      terminology && formatTerminologyInstructions(terminology),
+    );
+  }
+
   private async getEnvironment(): Promise<Part[]> {
     const cwd = this.config.getWorkingDir();
     const today = new Date().toLocaleDateString(undefined, {
@@ -171,6 +267,17 @@ export class ResearchClient {
           `.trim();
 
     const initialParts: Part[] = [{ text: context }];
+
+    // Tell the model how the manuscript is organised, so that edits use
+    // its existing labels, citation keys and commands.
+    try {
+      const latexProject = await parseLatexProject(cwd);
+      if (latexProject) {
+        initialParts.push({ text: formatLatexProjectContext(latexProject) });
+      }
+    } catch (error) {
+      console.warn('Could not read the LaTeX manuscript:', error);
+    }
     const toolRegistry = await this.config.getToolRegistry();
 
     // Add full file context if the flag is set
@@ -231,8 +338,7 @@ export class ResearchClient {
       ...(extraHistory ?? []),
     ];
     try {
-      const userMemory = this.config.getUserMemory();
-      const systemInstruction = getCoreSystemPrompt(userMemory);
+      const systemInstruction = await this.getSystemInstruction();
       const generateContentConfigWithThinking = isThinkingSupported(
         this.config.getModel(),
       )
@@ -243,7 +349,7 @@ export class ResearchClient {
             },
           }
         : this.generateContentConfig;
-      return new ResearchChat(
+      const chat = new ResearchChat(
         this.config,
         this.getContentGenerator(),
         {
@@ -253,6 +359,9 @@ export class ResearchClient {
         },
         history,
       );
+      chat.setContextFilter(this.getContextFilter());
+      chat.setGenerationOptions(this.generationOptions);
+      return chat;
     } catch (error) {
       await reportError(
         error,
@@ -339,8 +448,7 @@ export class ResearchClient {
     const modelToUse =
       model || this.config.getModel() || DEFAULT_RESEARCH_FLASH_MODEL;
     try {
-      const userMemory = this.config.getUserMemory();
-      const systemInstruction = getCoreSystemPrompt(userMemory);
+      const systemInstruction = await this.getSystemInstruction();
       const requestConfig = {
         abortSignal,
         ...this.generateContentConfig,
@@ -424,6 +532,7 @@ export class ResearchClient {
     generationConfig: GenerateContentConfig,
     abortSignal: AbortSignal,
     model?: string,
+    maxAttempts?: number,
   ): Promise<GenerateContentResponse> {
     const modelToUse = model ?? this.config.getModel();
     const configToUse: GenerateContentConfig = {
@@ -432,8 +541,7 @@ export class ResearchClient {
     };
 
     try {
-      const userMemory = this.config.getUserMemory();
-      const systemInstruction = getCoreSystemPrompt(userMemory);
+      const systemInstruction = await this.getSystemInstruction();
 
       const requestConfig = {
         abortSignal,
@@ -452,6 +560,7 @@ export class ResearchClient {
         onPersistent429: async (authType?: string, error?: unknown) =>
           await this.handleFlashFallback(authType, error),
         authType: this.config.getContentGeneratorConfig()?.authType,
+        ...(maxAttempts !== undefined && { maxAttempts }),
       });
       return result;
     } catch (error: unknown) {
@@ -13,13 +13,39 @@ import {
 import { createCodeAssistContentGenerator } from '../code_assist/codeAssist.js';
 import { GoogleGenAI } from '@google/genai';
 import { Config } from '../config/config.js';
+import { RecordingContentGenerator } from './recordingContentGenerator.js';
 
 vi.mock('../code_assist/codeAssist.js');
 vi.mock('@google/genai');
 
-const mockConfig = {} as unknown as Config;
+const mockConfig = {
+  getModelFallbacks: () => [],
+  getRecordFile: () => undefined,
+} as unknown as Config;
 
 describe('createContentGenerator', () => {
+  it('should not record incognito sessions', async () => {
+    vi.mocked(GoogleGenAI).mockImplementation(() => ({ models: {} }) as never);
+    const warn = vi.spyOn(console, 'warn').mockImplementation(() => {});
+    const generator = await createContentGenerator(
+      {
+        model: 'test-model',
+        apiKey: 'test-api-key',
+        authType: AuthType.USE_RESEARCH,
+      },
+      {
+        ...mockConfig,
+        getRecordFile: () => '/tmp/session.json',
+        isIncognito: () => true,
+      } as unknown as Config,
+    );
+    expect(generator).not.toBeInstanceOf(RecordingContentGenerator);
+    expect(warn).toHaveBeenCalledWith(
+      'Not recording to /tmp/session.json in an incognito session.',
+    );
+    warn.mockRestore();
+  });
+
   it('should create a CodeAssistContentGenerator', async () => {
     const mockGenerator = {} as unknown;
     vi.mocked(createCodeAssistContentGenerator).mockResolvedValue(
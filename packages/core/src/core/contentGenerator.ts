@@ -21,6 +21,8 @@ import { UserTierId } from '../code_assist/types.js';
 import { MultiProviderContentGenerator } from './multi-provider-content-generator.js';
 import { detectModelProvider, isGeminiModel } from './model-providers/model-utils.js';
 import { ModelProvider } from './model-providers/types.js';
+import { FakeContentGenerator, loadMockScript } from './fakeContentGenerator.js';
+import { RecordingContentGenerator } from './recordingContentGenerator.js';
 
 /**
  * Interface abstracting the core functionalities for generating content and counting tokens.
@@ -46,6 +48,7 @@ export enum AuthType {
   USE_RESEARCH = 'research-api-key',
   USE_VERTEX_AI = 'vertex-ai',
   CLOUD_SHELL = 'cloud-shell',
+  MOCK = 'mock',
 }
 
 export type ContentGeneratorConfig = {
@@ -121,7 +124,8 @@ export async function createContentGeneratorConfig(
   // If we are using iEchor auth or we are in Cloud Shell, there is nothing else to validate for now
   if (
     authType === AuthType.LOGIN_WITH_GOOGLE ||
-    authType === AuthType.CLOUD_SHELL
+    authType === AuthType.CLOUD_SHELL ||
+    authType === AuthType.MOCK
   ) {
     return contentGeneratorConfig;
   }
@@ -154,6 +158,28 @@ export async function createContentGenerator(
   config: ContentGeneratorConfig,
   gcConfig: Config,
   sessionId?: string,
+): Promise<ContentGenerator> {
+  const generator = await createProviderContentGenerator(
+    config,
+    gcConfig,
+    sessionId,
+  );
+  // 录制模式：把请求和流式响应写入文件，可用 --mock 回放
+  const recordFile = gcConfig.getRecordFile();
+  if (recordFile && gcConfig.isIncognito()) {
+    // Incognito sessions write nothing to disk, recordings included
+    console.warn(`Not recording to ${recordFile} in an incognito session.`);
+    return generator;
+  }
+  return recordFile
+    ? new RecordingContentGenerator(generator, recordFile)
+    : generator;
+}
+
+async function createProviderContentGenerator(
+  config: ContentGeneratorConfig,
+  gcConfig: Config,
+  sessionId?: string,
 ): Promise<ContentGenerator> {
   const version = process.env.CLI_VERSION || process.version;
   const httpOptions = {
@@ -162,6 +188,11 @@ export async function createContentGenerator(
     },
   };
 
+  // 离线模式：回放脚本化的响应，不调用任何提供商
+  if (config.authType === AuthType.MOCK) {
+    return new FakeContentGenerator(loadMockScript(gcConfig.getMockScript()));
+  }
+
   // 创建基础的 Gemini generator
   let baseGenerator: ContentGenerator;
   
@@ -200,13 +231,44 @@ export async function createContentGenerator(
   // 配置各个提供商
   await configureProviders(multiProviderGenerator, gcConfig);
 
+  // 配置模型回退链
+  const fallbackModels = gcConfig.getModelFallbacks();
+  if (fallbackModels.length > 0) {
+    multiProviderGenerator.setFallbackChain(
+      fallbackModels,
+      (fromModel, toModel, error) => {
+        gcConfig.setActiveFallbackModel(toModel);
+        if (toModel && gcConfig.getDebugMode()) {
+          console.debug(
+            `Model ${fromModel} failed, falling back to ${toModel}:`,
+            error,
+          );
+        }
+      },
+    );
+  }
+
   return multiProviderGenerator;
 }
 
 /**
- * 从配置文件读取API key
+ * 配置文件中单个提供商的条目
  */
-function getApiKeyFromConfig(provider: string): string | undefined {
+interface ProviderConfigEntry {
+  apiKey?: string;
+  baseUrl?: string;
+  defaultModel?: string;
+  headers?: Record<string, string>;
+  modelAliases?: Record<string, string>;
+  apiVersion?: string;
+}
+
+/**
+ * 从配置文件读取提供商条目
+ */
+function getProviderEntryFromConfig(
+  provider: string,
+): ProviderConfigEntry | undefined {
   try {
     const os = require('node:os');
     const fs = require('node:fs');
@@ -216,7 +278,7 @@ function getApiKeyFromConfig(provider: string): string | undefined {
     if (fs.existsSync(configFile)) {
       const content = fs.readFileSync(configFile, 'utf-8');
       const config = JSON.parse(content);
-      return config.providers?.[provider.toLowerCase()]?.apiKey;
+      return config.providers?.[provider.toLowerCase()];
     }
   } catch (error) {
     // 忽略配置文件读取错误
@@ -224,6 +286,13 @@ function getApiKeyFromConfig(provider: string): string | undefined {
   return undefined;
 }
 
+/**
+ * 从配置文件读取API key
+ */
+function getApiKeyFromConfig(provider: string): string | undefined {
+  return getProviderEntryFromConfig(provider)?.apiKey;
+}
+
 /**
  * 配置各个提供商的API密钥和设置
  */
@@ -358,4 +427,36 @@ async function configureProviders(
       apiKey: perplexityApiKey,
     });
   }
+
+  // 配置 Azure OpenAI（需要端点地址和密钥，模型名映射到部署名）
+  const azureEntry = getProviderEntryFromConfig(ModelProvider.AZURE_OPENAI);
+  const azureApiKey = azureEntry?.apiKey || process.env.AZURE_OPENAI_API_KEY;
+  const azureBaseUrl = azureEntry?.baseUrl || process.env.AZURE_OPENAI_ENDPOINT;
+  if (azureApiKey && azureBaseUrl) {
+    generator.configureProvider(ModelProvider.AZURE_OPENAI, {
+      apiKey: azureApiKey,
+      baseUrl: azureBaseUrl,
+      extra: {
+        headers: azureEntry?.headers,
+        modelAliases: azureEntry?.modelAliases,
+        apiVersion:
+          azureEntry?.apiVersion || process.env.AZURE_OPENAI_API_VERSION,
+      },
+    });
+  }
+
+  // 配置 OpenAI 兼容端点（vLLM、LM Studio、OpenRouter 等，本地服务可不设密钥）
+  const compatEntry = getProviderEntryFromConfig(ModelProvider.OPENAI_COMPATIBLE);
+  const compatBaseUrl =
+    compatEntry?.baseUrl || process.env.OPENAI_COMPATIBLE_BASE_URL;
+  if (compatBaseUrl) {
+    generator.configureProvider(ModelProvider.OPENAI_COMPATIBLE, {
+      apiKey: compatEntry?.apiKey || process.env.OPENAI_COMPATIBLE_API_KEY,
+      baseUrl: compatBaseUrl,
+      extra: {
+        headers: compatEntry?.headers,
+        modelAliases: compatEntry?.modelAliases,
+      },
+    });
+  }
 }
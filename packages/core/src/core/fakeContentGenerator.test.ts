@@ -0,0 +1,103 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect } from 'vitest';
+import { GenerateContentResponse } from '@google/genai';
+import {
+  FakeContentGenerator,
+  MockScript,
+  describeLatestUserMessage,
+} from './fakeContentGenerator.js';
+
+async function collect(
+  stream: AsyncGenerator<GenerateContentResponse>,
+): Promise<GenerateContentResponse[]> {
+  const chunks: GenerateContentResponse[] = [];
+  for await (const chunk of stream) {
+    chunks.push(chunk);
+  }
+  return chunks;
+}
+
+const script: MockScript = {
+  turns: [
+    { chunks: ['first ', 'answer'], chunkDelayMs: 0 },
+    {
+      match: 'weather',
+      chunks: ['Checking.'],
+      functionCalls: [{ name: 'web_fetch', args: { url: 'x' } }],
+      chunkDelayMs: 0,
+    },
+    { chunks: ['second answer'], chunkDelayMs: 0 },
+  ],
+};
+
+describe('FakeContentGenerator', () => {
+  it('should stream ordered turns chunk by chunk', async () => {
+    const generator = new FakeContentGenerator(script);
+
+    const first = await collect(
+      await generator.generateContentStream({
+        model: 'mock',
+        contents: [{ role: 'user', parts: [{ text: 'hi' }] }],
+      }),
+    );
+    expect(first.map((c) => c.candidates?.[0].content?.parts?.[0].text)).toEqual(
+      ['first ', 'answer'],
+    );
+    expect(first[0].candidates?.[0].finishReason).toBeUndefined();
+    expect(first[1].candidates?.[0].finishReason).toBe('STOP');
+
+    const second = await generator.generateContent({
+      model: 'mock',
+      contents: 'again',
+    });
+    expect(second.candidates?.[0].content?.parts?.[0].text).toBe(
+      'second answer',
+    );
+  });
+
+  it('should prefer matching turns and return tool calls last', async () => {
+    const generator = new FakeContentGenerator(script);
+
+    const chunks = await collect(
+      await generator.generateContentStream({
+        model: 'mock',
+        contents: 'what is the weather?',
+      }),
+    );
+    const parts = chunks[chunks.length - 1].candidates?.[0].content?.parts;
+    expect(parts?.[0].text).toBe('Checking.');
+    expect(parts?.[1].functionCall).toMatchObject({
+      name: 'web_fetch',
+      args: { url: 'x' },
+    });
+  });
+
+  it('should explain when the script has no response left', async () => {
+    const generator = new FakeContentGenerator({ turns: [] });
+    const response = await generator.generateContent({
+      model: 'mock',
+      contents: 'anything',
+    });
+    expect(response.candidates?.[0].content?.parts?.[0].text).toBe(
+      '[mock] No scripted response for: anything',
+    );
+  });
+
+  it('should describe tool results by function name', () => {
+    expect(
+      describeLatestUserMessage([
+        { role: 'user', parts: [{ text: 'list files' }] },
+        { role: 'model', parts: [{ functionCall: { name: 'ls' } }] },
+        {
+          role: 'user',
+          parts: [{ functionResponse: { name: 'ls', response: {} } }],
+        },
+      ]),
+    ).toBe('functionResponse:ls');
+  });
+});
@@ -0,0 +1,284 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import {
+  Content,
+  CountTokensParameters,
+  CountTokensResponse,
+  EmbedContentParameters,
+  EmbedContentResponse,
+  FinishReason,
+  FunctionCall,
+  GenerateContentParameters,
+  GenerateContentResponse,
+  Part,
+} from '@google/genai';
+import type { ContentGenerator } from './contentGenerator.js';
+
+/**
+ * One scripted model turn. Turns with a `match` are picked when the regex
+ * matches the latest user message; the rest are used in order.
+ */
+export interface MockTurn {
+  match?: string;
+  /** Text streamed back, one chunk per entry. */
+  chunks?: string[];
+  /** Tool calls returned after the text. */
+  functionCalls?: Array<{ name: string; args?: Record<string, unknown> }>;
+  /** Delay before every chunk, in milliseconds. */
+  chunkDelayMs?: number;
+  /** Per-chunk delays; overrides chunkDelayMs. */
+  delaysMs?: number[];
+}
+
+export interface MockScript {
+  turns: MockTurn[];
+}
+
+const DEFAULT_CHUNK_DELAY_MS = 30;
+
+/**
+ * Built-in script used by `--mock` without a file: a greeting and a tool
+ * call round trip. Anything else gets a "no scripted response" notice.
+ */
+export function getDefaultMockScript(): MockScript {
+  return {
+    turns: [
+      {
+        match: '(list|show).*files',
+        chunks: ["I'll list the files in the current directory."],
+        functionCalls: [
+          { name: 'list_directory', args: { path: process.cwd() } },
+        ],
+      },
+      {
+        match: '^functionResponse:list_directory',
+        chunks: [
+          'That is the directory listing. ',
+          'This response came from the mock provider; ',
+          'no API was called.',
+        ],
+      },
+      {
+        match: '^(hi|hello|hey)\\b',
+        chunks: [
+          'Hello! ',
+          'This is a **mock** session. ',
+          'Responses are canned, so you can work on the UI ',
+          'without API keys. Try "list the files".',
+        ],
+      },
+    ],
+  };
+}
+
+/**
+ * Parses a script: a JSON object, or JSON Lines as `--record` writes them,
+ * whose first line is the object and each further line one more turn.
+ */
+function parseMockScript(text: string): unknown {
+  try {
+    return JSON.parse(text);
+  } catch (error) {
+    const [first, ...rest] = text.split('\n').filter((line) => line.trim());
+    if (rest.length === 0) {
+      throw error;
+    }
+    const script = JSON.parse(first) as MockScript;
+    if (Array.isArray(script?.turns)) {
+      script.turns.push(...rest.map((line) => JSON.parse(line) as MockTurn));
+    }
+    return script;
+  }
+}
+
+export function loadMockScript(scriptPath?: string): MockScript {
+  if (!scriptPath) {
+    return getDefaultMockScript();
+  }
+  let parsed: unknown;
+  try {
+    parsed = parseMockScript(fs.readFileSync(scriptPath, 'utf-8'));
+  } catch (error) {
+    throw new Error(
+      `Failed to load mock script ${scriptPath}: ${error instanceof Error ? error.message : String(error)}`,
+    );
+  }
+  if (!parsed || !Array.isArray((parsed as MockScript).turns)) {
+    throw new Error(
+      `Invalid mock script ${scriptPath}: expected {"turns": [...]}`,
+    );
+  }
+  return parsed as MockScript;
+}
+
+function toContents(
+  contents: GenerateContentParameters['contents'],
+): Content[] {
+  const list = Array.isArray(contents) ? contents : [contents];
+  return list.map((item) =>
+    typeof item === 'string'
+      ? { role: 'user', parts: [{ text: item }] }
+      : 'parts' in (item as Content)
+        ? (item as Content)
+        : { role: 'user', parts: [item as Part] },
+  );
+}
+
+/**
+ * Text used to match the latest user message. Tool results are represented
+ * as `functionResponse:<name>` so scripts can react to them.
+ */
+export function describeLatestUserMessage(
+  contents: GenerateContentParameters['contents'],
+): string {
+  const list = toContents(contents);
+  const last = [...list].reverse().find((c) => c.role !== 'model');
+  return (last?.parts ?? [])
+    .map((part) =>
+      part.functionResponse
+        ? `functionResponse:${part.functionResponse.name}`
+        : (part.text ?? ''),
+    )
+    .join('\n')
+    .trim();
+}
+
+function delay(ms: number): Promise<void> {
+  return ms > 0
+    ? new Promise((resolve) => setTimeout(resolve, ms))
+    : Promise.resolve();
+}
+
+/**
+ * ContentGenerator that replays canned responses instead of calling a
+ * provider. Used by `--mock` so the UI can be developed offline.
+ */
+export class FakeContentGenerator implements ContentGenerator {
+  private used = new Set<number>();
+
+  constructor(private readonly script: MockScript) {}
+
+  async generateContent(
+    request: GenerateContentParameters,
+  ): Promise<GenerateContentResponse> {
+    const turn = this.nextTurn(request);
+    return this.buildResponse(
+      (turn.chunks ?? []).join(''),
+      turn.functionCalls,
+      true,
+    );
+  }
+
+  async generateContentStream(
+    request: GenerateContentParameters,
+  ): Promise<AsyncGenerator<GenerateContentResponse>> {
+    const turn = this.nextTurn(request);
+    const chunks = turn.chunks?.length ? turn.chunks : [''];
+    const buildResponse = this.buildResponse.bind(this);
+    return (async function* () {
+      for (let i = 0; i < chunks.length; i++) {
+        await delay(
+          turn.delaysMs?.[i] ?? turn.chunkDelayMs ?? DEFAULT_CHUNK_DELAY_MS,
+        );
+        const isLast = i === chunks.length - 1;
+        yield buildResponse(
+          chunks[i],
+          isLast ? turn.functionCalls : undefined,
+          isLast,
+        );
+      }
+    })();
+  }
+
+  async countTokens(
+    request: CountTokensParameters,
+  ): Promise<CountTokensResponse> {
+    const text = JSON.stringify(request.contents ?? '');
+    return { totalTokens: Math.ceil(text.length / 4) };
+  }
+
+  async embedContent(
+    request: EmbedContentParameters,
+  ): Promise<EmbedContentResponse> {
+    const list = Array.isArray(request.contents)
+      ? request.contents
+      : [request.contents];
+    // Deterministic pseudo-embeddings derived from the text.
+    return {
+      embeddings: list.map((item) => {
+        const text = JSON.stringify(item);
+        const values = new Array<number>(8).fill(0);
+        for (let i = 0; i < text.length; i++) {
+          values[i % 8] += text.charCodeAt(i) / 1000;
+        }
+        return { values };
+      }),
+    };
+  }
+
+  private nextTurn(request: GenerateContentParameters): MockTurn {
+    const message = describeLatestUserMessage(request.contents);
+    const turns = this.script.turns;
+    let index = turns.findIndex(
+      (turn, i) =>
+        !this.used.has(i) &&
+        turn.match !== undefined &&
+        new RegExp(turn.match, 'i').test(message),
+    );
+    if (index === -1) {
+      index = turns.findIndex(
+        (turn, i) => !this.used.has(i) && turn.match === undefined,
+      );
+    }
+    if (index === -1) {
+      return {
+        chunks: [`[mock] No scripted response for: ${message || '(empty)'}`],
+      };
+    }
+    // Turns that match by pattern can be reused; ordered turns are consumed.
+    if (turns[index].match === undefined) {
+      this.used.add(index);
+    }
+    return turns[index];
+  }
+
+  private buildResponse(
+    text: string,
+    functionCalls: MockTurn['functionCalls'],
+    done: boolean,
+  ): GenerateContentResponse {
+    const parts: Part[] = [];
+    if (text) {
+      parts.push({ text });
+    }
+    for (const call of functionCalls ?? []) {
+      const functionCall: FunctionCall = {
+        id: `mock-${call.name}-${Date.now()}`,
+        name: call.name,
+        args: call.args ?? {},
+      };
+      parts.push({ functionCall });
+    }
+    const response = new GenerateContentResponse();
+    response.candidates = [
+      {
+        content: { role: 'model', parts },
+        finishReason: done ? FinishReason.STOP : undefined,
+        index: 0,
+      },
+    ];
+    if (done) {
+      response.usageMetadata = {
+        promptTokenCount: 0,
+        candidatesTokenCount: Math.ceil(text.length / 4),
+        totalTokenCount: Math.ceil(text.length / 4),
+      };
+    }
+    return response;
+  }
+}
@@ -17,6 +17,7 @@ import { Logger, MessageSenderType, LogEntry } from './logger.js';
 import { promises as fs } from 'node:fs';
 import path from 'node:path';
 import { Content } from '@google/genai';
+import { setIncognitoMode } from '../utils/incognito.js';
 
 import crypto from 'node:crypto';
 import os from 'node:os';
@@ -267,6 +268,18 @@ describe('Logger', () => {
       expect(logger['messageId']).toBe(2);
     });
 
+    it('should not write anything in incognito mode', async () => {
+      setIncognitoMode(true);
+      try {
+        await logger.logMessage(MessageSenderType.USER, 'secret question');
+      } finally {
+        setIncognitoMode(false);
+      }
+      const logs = await readLogFile();
+      expect(logs.length).toBe(0);
+      expect(await logger.getPreviousUserMessages()).toEqual([]);
+    });
+
     it('should handle logger not initialized', async () => {
       const uninitializedLogger = new Logger(testSessionId);
       uninitializedLogger.close(); // Ensure it's treated as uninitialized
@@ -404,6 +417,24 @@ describe('Logger', () => {
       expect(JSON.parse(fileContent)).toEqual(conversation);
     });
 
+    it('should refuse to save a checkpoint in incognito mode', async () => {
+      const consoleErrorSpy = vi
+        .spyOn(console, 'error')
+        .mockImplementation(() => {});
+      setIncognitoMode(true);
+      try {
+        await logger.saveCheckpoint(conversation, 'incognito-tag');
+      } finally {
+        setIncognitoMode(false);
+      }
+      await expect(
+        fs.access(path.join(TEST_RESEARCH_DIR, 'checkpoint-incognito-tag.json')),
+      ).rejects.toThrow();
+      expect(consoleErrorSpy).toHaveBeenCalledWith(
+        'Cannot save a checkpoint in incognito mode.',
+      );
+    });
+
     it('should not throw if logger is not initialized', async () => {
       const uninitializedLogger = new Logger(testSessionId);
       uninitializedLogger.close();
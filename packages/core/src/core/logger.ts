@@ -8,6 +8,8 @@ import path from 'node:path';
 import { promises as fs } from 'node:fs';
 import { Content } from '@google/genai';
 import { getProjectTempDir } from '../utils/paths.js';
+import { getRedactor } from '../utils/redaction.js';
+import { isIncognitoMode } from '../utils/incognito.js';
 
 const LOG_FILE_NAME = 'logs.json';
 
@@ -202,6 +204,10 @@ export class Logger {
   }
 
   async logMessage(type: MessageSenderType, message: string): Promise<void> {
+    if (isIncognitoMode()) {
+      // Incognito prompts are never written and never show up in history.
+      return;
+    }
     if (!this.initialized || this.sessionId === undefined) {
       console.debug(
         'Logger not initialized or session ID missing. Cannot log message.',
@@ -215,7 +221,7 @@ export class Logger {
       sessionId: this.sessionId,
       messageId: this.messageId, // This will be recalculated in _updateLogFile
       type,
-      message,
+      message: getRedactor().redactText(message).value,
       timestamp: new Date().toISOString(),
     };
 
@@ -242,6 +248,10 @@ export class Logger {
   }
 
   async saveCheckpoint(conversation: Content[], tag: string): Promise<void> {
+    if (isIncognitoMode()) {
+      console.error('Cannot save a checkpoint in incognito mode.');
+      return;
+    }
     if (!this.initialized) {
       console.error(
         'Logger not initialized or checkpoint file path not set. Cannot save a checkpoint.',
@@ -250,7 +260,8 @@ export class Logger {
     }
     const path = this._checkpointPath(tag);
     try {
-      await fs.writeFile(path, JSON.stringify(conversation, null, 2), 'utf-8');
+      const { value } = getRedactor().redactContents(conversation);
+      await fs.writeFile(path, JSON.stringify(value, null, 2), 'utf-8');
     } catch (error) {
       console.error('Error writing to checkpoint file:', error);
     }
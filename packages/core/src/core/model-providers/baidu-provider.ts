@@ -188,6 +188,9 @@ export class BaiduProvider implements IModelProvider {
         max_tokens: request.maxTokens,
         temperature: request.temperature,
         top_p: request.topP,
+        frequency_penalty: request.frequencyPenalty,
+        presence_penalty: request.presencePenalty,
+        stop: request.stopSequences,
         stream: false,
       };
 
@@ -264,6 +267,9 @@ export class BaiduProvider implements IModelProvider {
         max_tokens: request.maxTokens,
         temperature: request.temperature,
         top_p: request.topP,
+        frequency_penalty: request.frequencyPenalty,
+        presence_penalty: request.presencePenalty,
+        stop: request.stopSequences,
         stream: true,
       };
 
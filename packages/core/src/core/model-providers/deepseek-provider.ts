@@ -5,6 +5,7 @@
  */
 
 import axios from 'axios';
+import { getAxiosNetworkOptions } from '../../utils/network.js';
 import { BaseModelProvider } from './base-provider.js';
 import {
   ModelProvider,
@@ -103,6 +104,7 @@ export class DeepSeekProvider extends BaseModelProvider {
           'Content-Type': 'application/json',
           'Authorization': `Bearer ${this.apiKey}`,
         },
+        ...getAxiosNetworkOptions(this.baseURL),
       });
 
       return this.convertToStandardResponse(response.data, model);
@@ -148,6 +150,7 @@ export class DeepSeekProvider extends BaseModelProvider {
           'Authorization': `Bearer ${this.apiKey}`,
         },
         responseType: 'stream',
+        ...getAxiosNetworkOptions(this.baseURL),
       });
 
       let fullContent = '';
@@ -14,6 +14,10 @@ export { BaseModelProvider } from './base-provider.js';
 export { LLMInterfaceProvider } from './llm-interface-provider.js';
 export { BaiduProvider } from './baidu-provider.js';
 export { MoonshotProvider } from './moonshot-provider.js';
+export {
+  OpenAICompatibleProvider,
+  AzureOpenAIProvider,
+} from './openai-compatible-provider.js';
 
 // 导出工厂和选择器
 export {
@@ -21,3 +25,6 @@ export {
   modelProviderFactory,
 } from './model-provider-factory.js';
 export { ModelSelector } from './model-selector.js';
+
+// 导出模型目录
+export * from './model-catalog.js';
@@ -18,6 +18,7 @@ import {
   ConfigurationError,
   APIError,
 } from './types.js';
+import { supportsSeed } from './model-utils.js';
 
 /**
  * LLM Interface 提供商映射
@@ -41,6 +42,8 @@ const PROVIDER_MAPPING: Record<ModelProvider, string> = {
   [ModelProvider.VERTEX_AI]: 'vertex_ai',
   [ModelProvider.BAIDU]: 'baidu',
   [ModelProvider.MOONSHOT]: 'openai', // 备用映射，实际使用独立的 MoonshotProvider
+  [ModelProvider.AZURE_OPENAI]: 'openai', // 备用映射，实际使用独立的 AzureOpenAIProvider
+  [ModelProvider.OPENAI_COMPATIBLE]: 'openai', // 备用映射，实际使用独立的 OpenAICompatibleProvider
 };
 
 /**
@@ -407,6 +410,8 @@ const MODEL_INFO_MAP: Record<ModelProvider, ModelInfo[]> = {
   [ModelProvider.VERTEX_AI]: [],
   [ModelProvider.BAIDU]: [],
   [ModelProvider.MOONSHOT]: [], // 空数组，实际模型信息在 MoonshotProvider 中定义
+  [ModelProvider.AZURE_OPENAI]: [], // 模型列表来自配置的部署别名
+  [ModelProvider.OPENAI_COMPATIBLE]: [], // 模型列表来自端点的 /models 接口
 };
 
 /**
@@ -462,6 +467,9 @@ export class LLMInterfaceProvider extends BaseModelProvider {
         requestParams.presence_penalty = request.presencePenalty ?? config.presencePenalty;
         requestParams.stop = request.stopSequences ?? config.stopSequences;
       }
+      if (request.seed !== undefined && supportsSeed(this.name)) {
+        requestParams.seed = request.seed;
+      }
 
       const response = await this.llmInterface.sendMessage(this.providerKey, requestParams);
 
@@ -495,6 +503,9 @@ export class LLMInterfaceProvider extends BaseModelProvider {
         requestParams.presence_penalty = request.presencePenalty ?? config.presencePenalty;
         requestParams.stop = request.stopSequences ?? config.stopSequences;
       }
+      if (request.seed !== undefined && supportsSeed(this.name)) {
+        requestParams.seed = request.seed;
+      }
 
       const stream = await this.llmInterface.sendMessage(this.providerKey, requestParams);
 
@@ -0,0 +1,86 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect } from 'vitest';
+import {
+  getModelCatalog,
+  findCatalogEntry,
+  filterModelCatalog,
+  checkWorkflowSupport,
+  catalogEntryToModelInfo,
+} from './model-catalog.js';
+import { ModelProvider } from './types.js';
+
+describe('model-catalog', () => {
+  it('should have unique ids', () => {
+    const ids = getModelCatalog().map((entry) => entry.id);
+    expect(new Set(ids).size).toBe(ids.length);
+  });
+
+  describe('findCatalogEntry', () => {
+    it('should find models by id, ignoring routing prefixes', () => {
+      expect(findCatalogEntry('gpt-4o')?.provider).toBe(ModelProvider.OPENAI);
+      expect(findCatalogEntry('azure/gpt-4o')?.id).toBe('gpt-4o');
+      expect(findCatalogEntry('unknown-model')).toBeUndefined();
+    });
+  });
+
+  describe('filterModelCatalog', () => {
+    it('should filter by provider', () => {
+      const models = filterModelCatalog({ provider: ModelProvider.DEEPSEEK });
+      expect(models.length).toBeGreaterThan(0);
+      expect(models.every((m) => m.provider === ModelProvider.DEEPSEEK)).toBe(
+        true,
+      );
+    });
+
+    it('should filter by capabilities and context window', () => {
+      const models = filterModelCatalog({
+        supports: ['vision', 'tools'],
+        minContext: 1000000,
+      });
+      expect(models.map((m) => m.id)).toContain('gemini-2.5-pro');
+      expect(models.map((m) => m.id)).not.toContain('deepseek-chat');
+      expect(models.every((m) => m.contextWindow >= 1000000)).toBe(true);
+    });
+
+    it('should filter by price and search text', () => {
+      const cheap = filterModelCatalog({ maxInputPrice: 0.2 });
+      expect(cheap.every((m) => m.pricing!.input <= 0.2)).toBe(true);
+
+      const claude = filterModelCatalog({ search: 'sonnet' });
+      expect(claude.every((m) => m.provider === ModelProvider.ANTHROPIC)).toBe(
+        true,
+      );
+    });
+  });
+
+  describe('checkWorkflowSupport', () => {
+    it('should warn when a workflow is not supported', () => {
+      expect(checkWorkflowSupport('deepseek-reasoner', ['tools'])).toHaveLength(
+        1,
+      );
+      expect(checkWorkflowSupport('deepseek-chat', ['vision'])[0]).toMatch(
+        /image input/,
+      );
+      expect(checkWorkflowSupport('gpt-4o', ['vision', 'tools'])).toEqual([]);
+    });
+
+    it('should not warn for models missing from the catalog', () => {
+      expect(checkWorkflowSupport('compat/my-local-model', ['tools'])).toEqual(
+        [],
+      );
+    });
+  });
+
+  it('should convert entries to ModelInfo', () => {
+    const info = catalogEntryToModelInfo(findCatalogEntry('gpt-4o')!);
+    expect(info.contextLength).toBe(128000);
+    expect(info.supportedFeatures).toEqual(
+      expect.arrayContaining(['functions', 'vision']),
+    );
+  });
+});
@@ -0,0 +1,454 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { ModelProvider, ModelInfo } from './types.js';
+import { stripProviderPrefix } from './model-utils.js';
+
+/**
+ * 模型支持的输入模态
+ */
+export type ModelModality = 'text' | 'image' | 'audio';
+
+/**
+ * 需要特定模型能力的工作流
+ */
+export type ModelWorkflow = 'vision' | 'tools' | 'audio';
+
+/**
+ * 模型目录条目
+ */
+export interface ModelCatalogEntry {
+  id: string;
+  name: string;
+  provider: ModelProvider;
+  /** 上下文窗口（tokens） */
+  contextWindow: number;
+  /** 单次最大输出（tokens） */
+  maxOutputTokens?: number;
+  /** 每百万 tokens 价格 */
+  pricing?: {
+    input: number;
+    output: number;
+    currency: 'USD' | 'CNY';
+  };
+  /** 支持的输入模态 */
+  modalities: ModelModality[];
+  /** 是否支持工具调用（function calling） */
+  toolCalling: boolean;
+  /** 是否为推理模型 */
+  reasoning?: boolean;
+}
+
+/**
+ * 模型目录过滤条件
+ */
+export interface ModelCatalogFilter {
+  provider?: ModelProvider;
+  /** 必须支持的工作流 */
+  supports?: ModelWorkflow[];
+  /** 最小上下文窗口 */
+  minContext?: number;
+  /** 每百万输入 tokens 的最高价格 */
+  maxInputPrice?: number;
+  /** 在 id 和名称中搜索 */
+  search?: string;
+}
+
+/**
+ * 已知模型目录，价格为公开标价，仅供参考
+ */
+const MODEL_CATALOG: ModelCatalogEntry[] = [
+  // OpenAI
+  {
+    id: 'gpt-4o',
+    name: 'GPT-4o',
+    provider: ModelProvider.OPENAI,
+    contextWindow: 128000,
+    maxOutputTokens: 16384,
+    pricing: { input: 2.5, output: 10, currency: 'USD' },
+    modalities: ['text', 'image'],
+    toolCalling: true,
+  },
+  {
+    id: 'gpt-4o-mini',
+    name: 'GPT-4o Mini',
+    provider: ModelProvider.OPENAI,
+    contextWindow: 128000,
+    maxOutputTokens: 16384,
+    pricing: { input: 0.15, output: 0.6, currency: 'USD' },
+    modalities: ['text', 'image'],
+    toolCalling: true,
+  },
+  {
+    id: 'gpt-4.1',
+    name: 'GPT-4.1',
+    provider: ModelProvider.OPENAI,
+    contextWindow: 1047576,
+    maxOutputTokens: 32768,
+    pricing: { input: 2, output: 8, currency: 'USD' },
+    modalities: ['text', 'image'],
+    toolCalling: true,
+  },
+  {
+    id: 'gpt-4.1-mini',
+    name: 'GPT-4.1 Mini',
+    provider: ModelProvider.OPENAI,
+    contextWindow: 1047576,
+    maxOutputTokens: 32768,
+    pricing: { input: 0.4, output: 1.6, currency: 'USD' },
+    modalities: ['text', 'image'],
+    toolCalling: true,
+  },
+  {
+    id: 'o3-mini',
+    name: 'o3-mini',
+    provider: ModelProvider.OPENAI,
+    contextWindow: 200000,
+    maxOutputTokens: 100000,
+    pricing: { input: 1.1, output: 4.4, currency: 'USD' },
+    modalities: ['text'],
+    toolCalling: true,
+    reasoning: true,
+  },
+  {
+    id: 'gpt-3.5-turbo',
+    name: 'GPT-3.5 Turbo',
+    provider: ModelProvider.OPENAI,
+    contextWindow: 16385,
+    maxOutputTokens: 4096,
+    pricing: { input: 0.5, output: 1.5, currency: 'USD' },
+    modalities: ['text'],
+    toolCalling: true,
+  },
+
+  // Anthropic
+  {
+    id: 'claude-opus-4-20250514',
+    name: 'Claude Opus 4',
+    provider: ModelProvider.ANTHROPIC,
+    contextWindow: 200000,
+    maxOutputTokens: 32000,
+    pricing: { input: 15, output: 75, currency: 'USD' },
+    modalities: ['text', 'image'],
+    toolCalling: true,
+    reasoning: true,
+  },
+  {
+    id: 'claude-sonnet-4-20250514',
+    name: 'Claude Sonnet 4',
+    provider: ModelProvider.ANTHROPIC,
+    contextWindow: 200000,
+    maxOutputTokens: 64000,
+    pricing: { input: 3, output: 15, currency: 'USD' },
+    modalities: ['text', 'image'],
+    toolCalling: true,
+    reasoning: true,
+  },
+  {
+    id: 'claude-3-7-sonnet-20250219',
+    name: 'Claude 3.7 Sonnet',
+    provider: ModelProvider.ANTHROPIC,
+    contextWindow: 200000,
+    maxOutputTokens: 64000,
+    pricing: { input: 3, output: 15, currency: 'USD' },
+    modalities: ['text', 'image'],
+    toolCalling: true,
+    reasoning: true,
+  },
+  {
+    id: 'claude-3-5-sonnet-20241022',
+    name: 'Claude 3.5 Sonnet',
+    provider: ModelProvider.ANTHROPIC,
+    contextWindow: 200000,
+    maxOutputTokens: 8192,
+    pricing: { input: 3, output: 15, currency: 'USD' },
+    modalities: ['text', 'image'],
+    toolCalling: true,
+  },
+  {
+    id: 'claude-3-5-haiku-20241022',
+    name: 'Claude 3.5 Haiku',
+    provider: ModelProvider.ANTHROPIC,
+    contextWindow: 200000,
+    maxOutputTokens: 8192,
+    pricing: { input: 0.8, output: 4, currency: 'USD' },
+    modalities: ['text'],
+    toolCalling: true,
+  },
+
+  // Gemini
+  {
+    id: 'gemini-2.5-pro',
+    name: 'Gemini 2.5 Pro',
+    provider: ModelProvider.GEMINI,
+    contextWindow: 1048576,
+    maxOutputTokens: 65536,
+    pricing: { input: 1.25, output: 10, currency: 'USD' },
+    modalities: ['text', 'image', 'audio'],
+    toolCalling: true,
+    reasoning: true,
+  },
+  {
+    id: 'gemini-2.5-flash',
+    name: 'Gemini 2.5 Flash',
+    provider: ModelProvider.GEMINI,
+    contextWindow: 1048576,
+    maxOutputTokens: 65536,
+    pricing: { input: 0.3, output: 2.5, currency: 'USD' },
+    modalities: ['text', 'image', 'audio'],
+    toolCalling: true,
+    reasoning: true,
+  },
+  {
+    id: 'gemini-1.5-pro',
+    name: 'Gemini 1.5 Pro',
+    provider: ModelProvider.GEMINI,
+    contextWindow: 2097152,
+    maxOutputTokens: 8192,
+    pricing: { input: 1.25, output: 5, currency: 'USD' },
+    modalities: ['text', 'image', 'audio'],
+    toolCalling: true,
+  },
+  {
+    id: 'gemini-1.5-flash',
+    name: 'Gemini 1.5 Flash',
+    provider: ModelProvider.GEMINI,
+    contextWindow: 1048576,
+    maxOutputTokens: 8192,
+    pricing: { input: 0.075, output: 0.3, currency: 'USD' },
+    modalities: ['text', 'image', 'audio'],
+    toolCalling: true,
+  },
+
+  // DeepSeek
+  {
+    id: 'deepseek-chat',
+    name: 'DeepSeek V3',
+    provider: ModelProvider.DEEPSEEK,
+    contextWindow: 65536,
+    maxOutputTokens: 8192,
+    pricing: { input: 0.27, output: 1.1, currency: 'USD' },
+    modalities: ['text'],
+    toolCalling: true,
+  },
+  {
+    id: 'deepseek-reasoner',
+    name: 'DeepSeek R1',
+    provider: ModelProvider.DEEPSEEK,
+    contextWindow: 65536,
+    maxOutputTokens: 32768,
+    pricing: { input: 0.55, output: 2.19, currency: 'USD' },
+    modalities: ['text'],
+    toolCalling: false,
+    reasoning: true,
+  },
+
+  // 通义千问
+  {
+    id: 'qwen-max',
+    name: 'Qwen Max',
+    provider: ModelProvider.QWEN,
+    contextWindow: 32768,
+    maxOutputTokens: 8192,
+    pricing: { input: 1.6, output: 6.4, currency: 'USD' },
+    modalities: ['text'],
+    toolCalling: true,
+  },
+  {
+    id: 'qwen-plus',
+    name: 'Qwen Plus',
+    provider: ModelProvider.QWEN,
+    contextWindow: 131072,
+    maxOutputTokens: 8192,
+    pricing: { input: 0.4, output: 1.2, currency: 'USD' },
+    modalities: ['text'],
+    toolCalling: true,
+  },
+  {
+    id: 'qwen-turbo',
+    name: 'Qwen Turbo',
+    provider: ModelProvider.QWEN,
+    contextWindow: 1008192,
+    maxOutputTokens: 8192,
+    pricing: { input: 0.05, output: 0.2, currency: 'USD' },
+    modalities: ['text'],
+    toolCalling: true,
+  },
+  {
+    id: 'qwen-vl-plus',
+    name: 'Qwen VL Plus',
+    provider: ModelProvider.QWEN,
+    contextWindow: 8192,
+    maxOutputTokens: 2048,
+    pricing: { input: 0.21, output: 0.63, currency: 'USD' },
+    modalities: ['text', 'image'],
+    toolCalling: false,
+  },
+
+  // 月之暗面
+  {
+    id: 'kimi-k2-0711-preview',
+    name: 'Kimi K2',
+    provider: ModelProvider.MOONSHOT,
+    contextWindow: 131072,
+    pricing: { input: 0.6, output: 2.5, currency: 'USD' },
+    modalities: ['text'],
+    toolCalling: true,
+  },
+
+  // 百度
+  {
+    id: 'ernie-4.5-turbo-128k',
+    name: 'ERNIE 4.5 Turbo 128K',
+    provider: ModelProvider.BAIDU,
+    contextWindow: 131072,
+    maxOutputTokens: 12288,
+    pricing: { input: 0.8, output: 3.2, currency: 'CNY' },
+    modalities: ['text'],
+    toolCalling: true,
+  },
+
+  // Mistral
+  {
+    id: 'mistral-large-latest',
+    name: 'Mistral Large',
+    provider: ModelProvider.MISTRAL,
+    contextWindow: 131072,
+    pricing: { input: 2, output: 6, currency: 'USD' },
+    modalities: ['text'],
+    toolCalling: true,
+  },
+
+  // Groq
+  {
+    id: 'llama-3.1-8b-instant',
+    name: 'Llama 3.1 8B Instant',
+    provider: ModelProvider.GROQ,
+    contextWindow: 131072,
+    maxOutputTokens: 8192,
+    pricing: { input: 0.05, output: 0.08, currency: 'USD' },
+    modalities: ['text'],
+    toolCalling: true,
+  },
+];
+
+/**
+ * 获取完整的模型目录
+ */
+export function getModelCatalog(): ModelCatalogEntry[] {
+  return [...MODEL_CATALOG];
+}
+
+/**
+ * 按模型ID查找目录条目（忽略 azure/、compat/ 路由前缀）
+ */
+export function findCatalogEntry(
+  modelId: string,
+): ModelCatalogEntry | undefined {
+  const id = stripProviderPrefix(modelId).toLowerCase();
+  return MODEL_CATALOG.find((entry) => entry.id.toLowerCase() === id);
+}
+
+/**
+ * 判断模型是否支持某个工作流
+ */
+export function supportsWorkflow(
+  entry: ModelCatalogEntry,
+  workflow: ModelWorkflow,
+): boolean {
+  switch (workflow) {
+    case 'vision':
+      return entry.modalities.includes('image');
+    case 'audio':
+      return entry.modalities.includes('audio');
+    case 'tools':
+      return entry.toolCalling;
+    default:
+      return false;
+  }
+}
+
+/**
+ * 按条件过滤模型目录
+ */
+export function filterModelCatalog(
+  filter: ModelCatalogFilter = {},
+): ModelCatalogEntry[] {
+  const search = filter.search?.toLowerCase();
+
+  return MODEL_CATALOG.filter((entry) => {
+    if (filter.provider && entry.provider !== filter.provider) {
+      return false;
+    }
+    if (
+      filter.supports &&
+      !filter.supports.every((workflow) => supportsWorkflow(entry, workflow))
+    ) {
+      return false;
+    }
+    if (filter.minContext && entry.contextWindow < filter.minContext) {
+      return false;
+    }
+    if (
+      filter.maxInputPrice !== undefined &&
+      (!entry.pricing || entry.pricing.input > filter.maxInputPrice)
+    ) {
+      return false;
+    }
+    if (
+      search &&
+      !entry.id.toLowerCase().includes(search) &&
+      !entry.name.toLowerCase().includes(search)
+    ) {
+      return false;
+    }
+    return true;
+  });
+}
+
+/**
+ * 检查模型是否支持所需的工作流，返回警告信息列表
+ * 目录中没有的模型无法判断，返回空列表
+ */
+export function checkWorkflowSupport(
+  modelId: string,
+  workflows: ModelWorkflow[],
+): string[] {
+  const entry = findCatalogEntry(modelId);
+  if (!entry) {
+    return [];
+  }
+
+  const messages: Record<ModelWorkflow, string> = {
+    vision: `${entry.name} does not accept image input; vision workflows (figures, screenshots, scanned PDFs) will not work.`,
+    tools: `${entry.name} does not support tool calling; file, search and research tools will be unavailable.`,
+    audio: `${entry.name} does not accept audio input.`,
+  };
+
+  return workflows
+    .filter((workflow) => !supportsWorkflow(entry, workflow))
+    .map((workflow) => messages[workflow]);
+}
+
+/**
+ * 将目录条目转换为 ModelInfo
+ */
+export function catalogEntryToModelInfo(entry: ModelCatalogEntry): ModelInfo {
+  const supportedFeatures = ['chat', 'stream'];
+  if (entry.toolCalling) supportedFeatures.push('functions');
+  if (entry.modalities.includes('image')) supportedFeatures.push('vision');
+  if (entry.reasoning) supportedFeatures.push('reasoning');
+
+  return {
+    id: entry.id,
+    name: entry.name,
+    provider: entry.provider,
+    contextLength: entry.contextWindow,
+    maxTokens: entry.maxOutputTokens,
+    supportedFeatures,
+    pricing: entry.pricing,
+  };
+}
@@ -14,6 +14,10 @@ import { LLMInterfaceProvider } from './llm-interface-provider.js';
 import { DeepSeekProvider } from './deepseek-provider.js';
 import { BaiduProvider } from './baidu-provider.js';
 import { MoonshotProvider } from './moonshot-provider.js';
+import {
+  AzureOpenAIProvider,
+  OpenAICompatibleProvider,
+} from './openai-compatible-provider.js';
 
 /**
  * 模型提供商工厂实现
@@ -50,6 +54,8 @@ export class ModelProviderFactory implements IModelProviderFactory {
       ModelProvider.VERTEX_AI,
       ModelProvider.BAIDU,
       ModelProvider.MOONSHOT,
+      ModelProvider.AZURE_OPENAI,
+      ModelProvider.OPENAI_COMPATIBLE,
     ];
 
     supportedProviders.forEach((provider) => {
@@ -59,6 +65,13 @@ export class ModelProviderFactory implements IModelProviderFactory {
         this.providerFactories.set(provider, () => new BaiduProvider());
       } else if (provider === ModelProvider.MOONSHOT) {
         this.providerFactories.set(provider, () => new MoonshotProvider());
+      } else if (provider === ModelProvider.AZURE_OPENAI) {
+        this.providerFactories.set(provider, () => new AzureOpenAIProvider());
+      } else if (provider === ModelProvider.OPENAI_COMPATIBLE) {
+        this.providerFactories.set(
+          provider,
+          () => new OpenAICompatibleProvider(),
+        );
       } else {
         this.providerFactories.set(
           provider,
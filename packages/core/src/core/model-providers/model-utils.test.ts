@@ -9,7 +9,9 @@ import {
   detectModelProvider, 
   isGeminiModel, 
   supportsCountTokens, 
-  getModelTokenLimit 
+  supportsSeed,
+  getModelTokenLimit,
+  stripProviderPrefix,
 } from './model-utils.js';
 import { ModelProvider } from './types.js';
 
@@ -64,6 +66,24 @@ describe('model-utils', () => {
       expect(detectModelProvider('claude-3-sonnet-20240229')).toBe(ModelProvider.ANTHROPIC);
       expect(detectModelProvider('claude-3-haiku-20240307')).toBe(ModelProvider.ANTHROPIC);
     });
+
+    it('should route prefixed models to Azure and compatible endpoints', () => {
+      expect(detectModelProvider('azure/gpt-4o')).toBe(ModelProvider.AZURE_OPENAI);
+      expect(detectModelProvider('compat/gpt-4o')).toBe(ModelProvider.OPENAI_COMPATIBLE);
+      expect(detectModelProvider('compat/meta-llama/Llama-3.1-8B-Instruct')).toBe(
+        ModelProvider.OPENAI_COMPATIBLE,
+      );
+    });
+  });
+
+  describe('stripProviderPrefix', () => {
+    it('should remove only the routing prefix', () => {
+      expect(stripProviderPrefix('azure/my-deployment')).toBe('my-deployment');
+      expect(stripProviderPrefix('compat/meta-llama/Llama-3.1-8B-Instruct')).toBe(
+        'meta-llama/Llama-3.1-8B-Instruct',
+      );
+      expect(stripProviderPrefix('gpt-4o')).toBe('gpt-4o');
+    });
   });
 
   describe('isGeminiModel', () => {
@@ -84,6 +104,16 @@ describe('model-utils', () => {
     });
   });
 
+  describe('supportsSeed', () => {
+    it('should know which providers take a seed', () => {
+      expect(supportsSeed(ModelProvider.OPENAI)).toBe(true);
+      expect(supportsSeed(ModelProvider.GEMINI)).toBe(true);
+      expect(supportsSeed(ModelProvider.OPENAI_COMPATIBLE)).toBe(true);
+      expect(supportsSeed(ModelProvider.ANTHROPIC)).toBe(false);
+      expect(supportsSeed(ModelProvider.BAIDU)).toBe(false);
+    });
+  });
+
   describe('getModelTokenLimit', () => {
     it('should return correct token limits for Qwen models', () => {
       // 根据阿里云文档的数据
@@ -115,6 +115,9 @@ const MODEL_TO_PROVIDER_MAP: Record<string, ModelProvider> = {
  * 提供商模式匹配规则
  */
 const PROVIDER_PATTERNS: Array<{ pattern: RegExp; provider: ModelProvider }> = [
+  // 显式前缀优先，例如 azure/gpt-4o 或 compat/meta-llama/Llama-3-8B
+  { pattern: /^azure\//i, provider: ModelProvider.AZURE_OPENAI },
+  { pattern: /^compat\//i, provider: ModelProvider.OPENAI_COMPATIBLE },
   { pattern: /^gpt-/i, provider: ModelProvider.OPENAI },
   { pattern: /^claude-/i, provider: ModelProvider.ANTHROPIC },
   { pattern: /^deepseek-/i, provider: ModelProvider.DEEPSEEK },
@@ -129,6 +132,20 @@ const PROVIDER_PATTERNS: Array<{ pattern: RegExp; provider: ModelProvider }> = [
   { pattern: /^kimi-/i, provider: ModelProvider.MOONSHOT },
 ];
 
+// 请求体中接受 seed 的提供商；Anthropic、百度、Moonshot 等会忽略或拒绝它
+const SEEDED_PROVIDERS = new Set<ModelProvider>([
+  ModelProvider.OPENAI,
+  ModelProvider.AZURE_OPENAI,
+  ModelProvider.OPENAI_COMPATIBLE,
+  ModelProvider.GEMINI,
+  ModelProvider.VERTEX_AI,
+  ModelProvider.GROQ,
+  ModelProvider.QWEN,
+  ModelProvider.OLLAMA,
+  ModelProvider.TOGETHER,
+  ModelProvider.FIREWORKS,
+]);
+
 /**
  * 根据模型名称检测提供商
  */
@@ -150,6 +167,13 @@ export function detectModelProvider(modelName: string): ModelProvider {
   return ModelProvider.GEMINI;
 }
 
+/**
+ * 去掉用于路由的提供商前缀（azure/、compat/）
+ */
+export function stripProviderPrefix(modelName: string): string {
+  return modelName.replace(/^(azure|compat)\//i, '');
+}
+
 /**
  * 检查模型是否为 Gemini 模型
  */
@@ -165,6 +189,13 @@ export function supportsCountTokens(provider: ModelProvider): boolean {
   return provider === ModelProvider.GEMINI;
 }
 
+/**
+ * 检查提供商是否接受 seed 参数（可复现的采样）
+ */
+export function supportsSeed(provider: ModelProvider): boolean {
+  return SEEDED_PROVIDERS.has(provider);
+}
+
 /**
  * 获取模型的估算 token 限制
  */
@@ -225,6 +256,16 @@ export function getModelTokenLimit(modelName: string): number {
     case ModelProvider.MOONSHOT:
       if (modelName.includes('kimi-k2-0711-preview')) return 128000; // 128K tokens
       return 128000; // 默认128K tokens
+
+    case ModelProvider.AZURE_OPENAI:
+    case ModelProvider.OPENAI_COMPATIBLE: {
+      // 按底层模型名估算，未知模型保守取 32K
+      const baseModel = stripProviderPrefix(modelName);
+      if (baseModel.includes('gpt-4o') || baseModel.includes('gpt-4.1')) return 128000;
+      if (baseModel.includes('gpt-4')) return 8192;
+      if (baseModel.includes('gpt-3.5')) return 16385;
+      return 32768;
+    }
       
     default:
       return 4096;
@@ -5,6 +5,7 @@
  */
 
 import axios from 'axios';
+import { getAxiosNetworkOptions } from '../../utils/network.js';
 import { BaseModelProvider } from './base-provider.js';
 import {
   ModelProvider,
@@ -147,6 +148,7 @@ export class MoonshotProvider extends BaseModelProvider {
             'Content-Type': 'application/json',
           },
           timeout: config.timeout || 30000,
+          ...getAxiosNetworkOptions(this.baseURL),
         }
       );
 
@@ -184,6 +186,7 @@ export class MoonshotProvider extends BaseModelProvider {
           },
           timeout: config.timeout || 30000,
           responseType: 'stream',
+          ...getAxiosNetworkOptions(this.baseURL),
         }
       );
 
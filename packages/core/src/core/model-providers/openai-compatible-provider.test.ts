@@ -0,0 +1,157 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, vi } from 'vitest';
+import axios from 'axios';
+import {
+  AzureOpenAIProvider,
+  DEFAULT_AZURE_API_VERSION,
+  OpenAICompatibleProvider,
+} from './openai-compatible-provider.js';
+import { ModelProvider, StreamResponse } from './types.js';
+
+vi.mock('axios', () => {
+  const mocked = {
+    post: vi.fn(),
+    get: vi.fn(),
+    isAxiosError: vi.fn(() => false),
+  };
+  return { default: mocked };
+});
+
+async function* chunks(...parts: string[]): AsyncGenerator<Buffer> {
+  for (const part of parts) {
+    yield Buffer.from(part);
+  }
+}
+
+async function collect(
+  stream: AsyncGenerator<StreamResponse>,
+): Promise<StreamResponse[]> {
+  const responses: StreamResponse[] = [];
+  for await (const response of stream) {
+    responses.push(response);
+  }
+  return responses;
+}
+
+const request = {
+  messages: [{ role: 'user' as const, content: 'Hi' }],
+};
+
+describe('OpenAICompatibleProvider', () => {
+  let provider: OpenAICompatibleProvider;
+
+  beforeEach(async () => {
+    vi.mocked(axios.post).mockReset();
+    provider = new OpenAICompatibleProvider();
+    await provider.initialize({
+      provider: ModelProvider.OPENAI_COMPATIBLE,
+      model: 'compat/llama3.1',
+      baseUrl: 'http://localhost:8000/v1/',
+    });
+  });
+
+  it('should join SSE lines split across chunks and stop at [DONE]', async () => {
+    vi.mocked(axios.post).mockResolvedValue({
+      data: chunks(
+        'data: {"choices":[{"delta":{"content":"Hel"}}]}\n\ndata: {"choi',
+        'ces":[{"delta":{"content":"lo"}}]}\n',
+        ': keep-alive\n\n',
+        'data: {"choices":[{"delta":{},"finish_reason":"stop"}]}\n',
+        'data: [DONE]\n',
+        'data: {"choices":[{"delta":{"content":"ignored"}}]}\n',
+      ),
+    });
+
+    const responses = await collect(provider.streamChat(request));
+
+    expect(responses.map((r) => [r.delta, r.content, r.done])).toEqual([
+      ['Hel', 'Hel', false],
+      ['lo', 'Hello', false],
+      ['', 'Hello', true],
+    ]);
+    expect(responses[2].finishReason).toBe('stop');
+    const [url, body] = vi.mocked(axios.post).mock.calls[0];
+    expect(url).toBe('http://localhost:8000/v1/chat/completions');
+    expect(body).toMatchObject({ model: 'llama3.1', stream: true });
+  });
+
+  it('should skip lines that are not JSON', async () => {
+    vi.mocked(axios.post).mockResolvedValue({
+      data: chunks(
+        'data: not json\n',
+        'data: {"choices":[{"delta":{"content":"ok"},"finish_reason":"length"}]}\n',
+      ),
+    });
+
+    const responses = await collect(provider.streamChat(request));
+
+    expect(responses).toHaveLength(1);
+    expect(responses[0]).toMatchObject({
+      content: 'ok',
+      done: true,
+      finishReason: 'length',
+    });
+  });
+});
+
+describe('AzureOpenAIProvider', () => {
+  beforeEach(() => {
+    vi.mocked(axios.post).mockReset();
+    vi.mocked(axios.post).mockResolvedValue({
+      data: {
+        choices: [
+          {
+            index: 0,
+            message: { role: 'assistant', content: 'Hi there' },
+            finish_reason: 'stop',
+          },
+        ],
+      },
+    });
+  });
+
+  const initialize = async (extra?: Record<string, unknown>) => {
+    const provider = new AzureOpenAIProvider();
+    await provider.initialize({
+      provider: ModelProvider.AZURE_OPENAI,
+      model: 'azure/gpt4o',
+      apiKey: 'secret',
+      baseUrl: 'https://lab.openai.azure.com',
+      extra,
+    });
+    return provider;
+  };
+
+  it('should call the deployment of an alias with the api-version', async () => {
+    const provider = await initialize({
+      modelAliases: { gpt4o: 'gpt-4o prod' },
+      apiVersion: '2024-10-21',
+    });
+
+    const response = await provider.chat(request);
+
+    expect(response.content).toBe('Hi there');
+    const [url, body, options] = vi.mocked(axios.post).mock.calls[0];
+    expect(url).toBe(
+      'https://lab.openai.azure.com/openai/deployments/gpt-4o%20prod/chat/completions?api-version=2024-10-21',
+    );
+    expect(body).not.toHaveProperty('model');
+    expect(options?.headers).toMatchObject({ 'api-key': 'secret' });
+    expect(options?.headers).not.toHaveProperty('Authorization');
+  });
+
+  it('should use the default api-version', async () => {
+    const provider = await initialize();
+
+    await provider.chat(request);
+
+    expect(vi.mocked(axios.post).mock.calls[0][0]).toBe(
+      `https://lab.openai.azure.com/openai/deployments/gpt4o/chat/completions?api-version=${DEFAULT_AZURE_API_VERSION}`,
+    );
+  });
+});
@@ -0,0 +1,467 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import axios from 'axios';
+import { getAxiosNetworkOptions } from '../../utils/network.js';
+import { isIncognitoMode } from '../../utils/incognito.js';
+import { BaseModelProvider } from './base-provider.js';
+import { stripProviderPrefix } from './model-utils.js';
+import {
+  ModelProvider,
+  ModelConfig,
+  ChatRequest,
+  ChatResponse,
+  StreamResponse,
+  ModelInfo,
+  APIError,
+  AuthenticationError,
+  ConfigurationError,
+  RateLimitError,
+} from './types.js';
+
+/**
+ * OpenAI兼容端点的额外配置（ModelConfig.extra）
+ */
+export interface OpenAICompatibleExtra {
+  /** 每个请求附带的额外HTTP头，例如 OpenRouter 的 HTTP-Referer */
+  headers?: Record<string, string>;
+  /** 模型别名 -> 端点上的真实模型名（Azure 中为部署名） */
+  modelAliases?: Record<string, string>;
+  /** Azure OpenAI API 版本 */
+  apiVersion?: string;
+}
+
+export const DEFAULT_AZURE_API_VERSION = '2024-06-01';
+
+/**
+ * OpenAI chat-completions 响应格式
+ */
+interface OpenAIChatResponse {
+  model?: string;
+  choices: Array<{
+    index: number;
+    message: { role: string; content: string | null };
+    finish_reason: string | null;
+  }>;
+  usage?: {
+    prompt_tokens: number;
+    completion_tokens: number;
+    total_tokens: number;
+  };
+}
+
+/**
+ * 通用 OpenAI 兼容端点提供商（vLLM、LM Studio、OpenRouter 等）
+ * 直接调用 /chat/completions，支持自定义请求头和模型别名
+ */
+export class OpenAICompatibleProvider extends BaseModelProvider {
+  protected apiKey: string = '';
+  protected baseURL: string = '';
+
+  constructor(name: ModelProvider = ModelProvider.OPENAI_COMPATIBLE) {
+    super(name);
+  }
+
+  protected async doInitialize(): Promise<void> {
+    const config = this.getConfig();
+    this.apiKey = config.apiKey || '';
+    this.baseURL = (config.baseUrl || '').replace(/\/+$/, '');
+
+    if (!this.baseURL) {
+      throw new ConfigurationError(
+        `${this.name} requires a base URL (e.g. http://localhost:8000/v1)`,
+        this.name,
+      );
+    }
+  }
+
+  protected async doChat(request: ChatRequest): Promise<ChatResponse> {
+    const model = this.resolveRequestModel(request);
+    const url = this.getChatCompletionsUrl(model);
+
+    try {
+      const response = await axios.post(
+        url,
+        this.buildRequestBody(request, model, false),
+        {
+          headers: this.buildHeaders(),
+          timeout: this.getConfig().timeout || 30000,
+          ...getAxiosNetworkOptions(url),
+        },
+      );
+      return this.convertResponse(response.data, request.model || model);
+    } catch (error) {
+      this.handleError(error);
+    }
+  }
+
+  protected async *doStreamChat(
+    request: ChatRequest,
+  ): AsyncGenerator<StreamResponse> {
+    const model = this.resolveRequestModel(request);
+    const url = this.getChatCompletionsUrl(model);
+    const displayModel = request.model || model;
+
+    try {
+      const response = await axios.post(
+        url,
+        this.buildRequestBody(request, model, true),
+        {
+          headers: this.buildHeaders(),
+          timeout: this.getConfig().timeout || 30000,
+          responseType: 'stream',
+          ...getAxiosNetworkOptions(url),
+        },
+      );
+
+      let fullContent = '';
+      let buffer = '';
+
+      for await (const chunk of response.data) {
+        buffer += chunk.toString();
+        const lines = buffer.split('\n');
+        buffer = lines.pop() || '';
+
+        for (const line of lines) {
+          const trimmed = line.trim();
+          if (!trimmed.startsWith('data:')) continue;
+
+          const data = trimmed.slice(5).trim();
+          if (data === '[DONE]') {
+            return;
+          }
+
+          let parsed: any;
+          try {
+            parsed = JSON.parse(data);
+          } catch (_e) {
+            // 忽略不完整或非JSON的行
+            continue;
+          }
+
+          const choice = parsed.choices?.[0];
+          const delta = choice?.delta?.content || '';
+          fullContent += delta;
+          const done = !!choice?.finish_reason;
+
+          if (delta || done) {
+            yield {
+              content: fullContent,
+              delta,
+              done,
+              model: displayModel,
+              provider: this.name,
+              usage: parsed.usage
+                ? {
+                    promptTokens: parsed.usage.prompt_tokens,
+                    completionTokens: parsed.usage.completion_tokens,
+                    totalTokens: parsed.usage.total_tokens,
+                  }
+                : undefined,
+              finishReason: done
+                ? this.mapFinishReason(choice.finish_reason)
+                : undefined,
+            };
+          }
+        }
+      }
+    } catch (error) {
+      this.handleError(error);
+    }
+  }
+
+  /**
+   * 优先查询端点的 /models 列表，失败时回退到配置的别名
+   */
+  protected async doGetModels(): Promise<ModelInfo[]> {
+    const aliases = Object.keys(this.getExtra().modelAliases || {});
+    let remoteIds: string[] = [];
+
+    try {
+      const url = `${this.baseURL}/models`;
+      const response = await axios.get(url, {
+        headers: this.buildHeaders(),
+        timeout: 5000,
+        ...getAxiosNetworkOptions(url),
+      });
+      remoteIds = (response.data?.data || [])
+        .map((m: { id?: string }) => m.id)
+        .filter((id: unknown): id is string => typeof id === 'string');
+    } catch (_e) {
+      // 本地服务可能未启动，仅返回别名
+    }
+
+    const ids = Array.from(new Set([...aliases, ...remoteIds]));
+    if (ids.length === 0 && this.config?.model) {
+      ids.push(stripProviderPrefix(this.config.model));
+    }
+
+    return ids.map((id) => ({
+      id: `${this.getModelPrefix()}${id}`,
+      name: id,
+      provider: this.name,
+      description: aliases.includes(id)
+        ? `Alias for ${this.getExtra().modelAliases![id]}`
+        : `Served by ${this.baseURL}`,
+      supportedFeatures: ['chat', 'stream'],
+    }));
+  }
+
+  protected doValidateConfig(config: ModelConfig): boolean {
+    return !!config.baseUrl;
+  }
+
+  /**
+   * 模型ID前缀，用于在 detectModelProvider 中路由到本提供商
+   */
+  protected getModelPrefix(): string {
+    return 'compat/';
+  }
+
+  protected getExtra(): OpenAICompatibleExtra {
+    return (this.config?.extra || {}) as OpenAICompatibleExtra;
+  }
+
+  /**
+   * 去掉路由前缀并解析别名
+   */
+  protected resolveRequestModel(request: ChatRequest): string {
+    const requested = stripProviderPrefix(
+      request.model || this.getConfig().model,
+    );
+    return this.getExtra().modelAliases?.[requested] || requested;
+  }
+
+  protected getChatCompletionsUrl(_model: string): string {
+    return `${this.baseURL}/chat/completions`;
+  }
+
+  protected buildHeaders(): Record<string, string> {
+    const headers: Record<string, string> = {
+      'Content-Type': 'application/json',
+      ...this.getExtra().headers,
+    };
+    if (this.apiKey) {
+      headers['Authorization'] = `Bearer ${this.apiKey}`;
+    }
+    return headers;
+  }
+
+  protected buildRequestBody(
+    request: ChatRequest,
+    model: string,
+    stream: boolean,
+  ): Record<string, unknown> {
+    const config = this.getConfig();
+    const body: Record<string, unknown> = {
+      model,
+      messages: this.normalizeMessages(request.messages),
+      temperature: request.temperature ?? config.temperature,
+      max_tokens: request.maxTokens ?? config.maxTokens,
+      top_p: request.topP ?? config.topP,
+      frequency_penalty: request.frequencyPenalty ?? config.frequencyPenalty,
+      presence_penalty: request.presencePenalty ?? config.presencePenalty,
+      stop: request.stopSequences ?? config.stopSequences,
+      seed: request.seed,
+      stream,
+    };
+    // 去掉未设置的字段，部分兼容服务器会拒绝 null
+    for (const key of Object.keys(body)) {
+      if (body[key] === undefined) {
+        delete body[key];
+      }
+    }
+    if (isIncognitoMode()) {
+      Object.assign(body, this.getNoRetentionOptions());
+    }
+    return body;
+  }
+
+  /**
+   * 隐身模式下请求端点不保留数据（仅限已知支持的服务）
+   */
+  protected getNoRetentionOptions(): Record<string, unknown> {
+    let hostname = '';
+    try {
+      hostname = new URL(this.baseURL).hostname;
+    } catch (_e) {
+      return {};
+    }
+    if (hostname === 'openrouter.ai') {
+      return { provider: { data_collection: 'deny' } };
+    }
+    if (hostname === 'api.openai.com') {
+      return { store: false };
+    }
+    return {};
+  }
+
+  private convertResponse(
+    response: OpenAIChatResponse,
+    model: string,
+  ): ChatResponse {
+    const choice = response.choices?.[0];
+    if (!choice) {
+      throw new APIError('No response choices returned', this.name);
+    }
+
+    return {
+      content: choice.message?.content || '',
+      model,
+      provider: this.name,
+      usage: response.usage
+        ? {
+            promptTokens: response.usage.prompt_tokens,
+            completionTokens: response.usage.completion_tokens,
+            totalTokens: response.usage.total_tokens,
+          }
+        : undefined,
+      finishReason: this.mapFinishReason(choice.finish_reason),
+    };
+  }
+
+  private mapFinishReason(
+    reason: string | null | undefined,
+  ): ChatResponse['finishReason'] {
+    switch (reason) {
+      case 'length':
+        return 'length';
+      case 'content_filter':
+        return 'content_filter';
+      case 'tool_calls':
+        return 'tool_calls';
+      default:
+        return 'stop';
+    }
+  }
+
+  protected extractContent(response: any): string {
+    return response.choices?.[0]?.message?.content || '';
+  }
+
+  protected extractDelta(chunk: any): string {
+    return chunk.choices?.[0]?.delta?.content || '';
+  }
+
+  protected isStreamDone(chunk: any): boolean {
+    return !!chunk.choices?.[0]?.finish_reason;
+  }
+
+  protected handleError(error: any): never {
+    if (axios.isAxiosError(error)) {
+      const status = error.response?.status;
+      const data: any = error.response?.data;
+      const message = data?.error?.message || error.message;
+
+      if (status === 401 || status === 403) {
+        throw new AuthenticationError(
+          `Authentication failed: ${message}`,
+          this.name,
+        );
+      }
+      if (status === 429) {
+        const retryAfter = Number(error.response?.headers?.['retry-after']);
+        throw new RateLimitError(
+          `Rate limit exceeded: ${message}`,
+          this.name,
+          isNaN(retryAfter) ? undefined : retryAfter,
+        );
+      }
+      if (status && status >= 400) {
+        throw new APIError(
+          `API error (${status}): ${message}`,
+          this.name,
+          status,
+        );
+      }
+      if (error.code === 'ECONNREFUSED') {
+        throw new APIError(
+          `Could not connect to ${this.baseURL}. Is the server running?`,
+          this.name,
+        );
+      }
+    }
+    if (error instanceof ConfigurationError) {
+      throw error;
+    }
+
+    throw new APIError(
+      `Unexpected error: ${error?.message || error}`,
+      this.name,
+    );
+  }
+}
+
+/**
+ * Azure OpenAI 提供商
+ * 使用部署名和 api-version 构造请求地址，通过 api-key 头认证
+ */
+export class AzureOpenAIProvider extends OpenAICompatibleProvider {
+  constructor() {
+    super(ModelProvider.AZURE_OPENAI);
+  }
+
+  protected async doInitialize(): Promise<void> {
+    await super.doInitialize();
+    if (!this.apiKey) {
+      throw new AuthenticationError(
+        'AZURE_OPENAI_API_KEY is required',
+        this.name,
+      );
+    }
+  }
+
+  /**
+   * Azure 不提供部署列表的数据面接口，只列出配置的部署别名
+   */
+  protected async doGetModels(): Promise<ModelInfo[]> {
+    const aliases = this.getExtra().modelAliases || {};
+    const ids = Object.keys(aliases);
+    if (ids.length === 0 && this.config?.model) {
+      ids.push(stripProviderPrefix(this.config.model));
+    }
+    return ids.map((id) => ({
+      id: `${this.getModelPrefix()}${id}`,
+      name: id,
+      provider: this.name,
+      description: `Azure deployment ${aliases[id] || id}`,
+      supportedFeatures: ['chat', 'stream', 'functions'],
+    }));
+  }
+
+  protected doValidateConfig(config: ModelConfig): boolean {
+    return !!config.baseUrl && !!config.apiKey;
+  }
+
+  protected getModelPrefix(): string {
+    return 'azure/';
+  }
+
+  protected getChatCompletionsUrl(deployment: string): string {
+    const apiVersion =
+      this.getExtra().apiVersion || DEFAULT_AZURE_API_VERSION;
+    return `${this.baseURL}/openai/deployments/${encodeURIComponent(deployment)}/chat/completions?api-version=${encodeURIComponent(apiVersion)}`;
+  }
+
+  protected buildHeaders(): Record<string, string> {
+    return {
+      'Content-Type': 'application/json',
+      ...this.getExtra().headers,
+      'api-key': this.apiKey,
+    };
+  }
+
+  protected buildRequestBody(
+    request: ChatRequest,
+    model: string,
+    stream: boolean,
+  ): Record<string, unknown> {
+    // Azure 通过 URL 中的部署名选择模型
+    const body = super.buildRequestBody(request, model, stream);
+    delete body.model;
+    return body;
+  }
+}
@@ -28,6 +28,8 @@ export enum ModelProvider {
   VERTEX_AI = 'vertex_ai',
   BAIDU = 'baidu',
   MOONSHOT = 'moonshot',
+  AZURE_OPENAI = 'azure_openai',
+  OPENAI_COMPATIBLE = 'openai_compatible',
 }
 
 /**
@@ -71,6 +73,8 @@ export interface ChatRequest {
   frequencyPenalty?: number;
   presencePenalty?: number;
   stopSequences?: string[];
+  /** 采样种子，支持的提供商据此给出可复现的回答 */
+  seed?: number;
   stream?: boolean;
 }
 
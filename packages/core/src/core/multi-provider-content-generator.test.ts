@@ -102,6 +102,105 @@ describe('MultiProviderContentGenerator', () => {
     });
   });
 
+  describe('fallback chain', () => {
+    const request = {
+      model: 'gemini-2.5-pro',
+      contents: [{ role: 'user', parts: [{ text: 'test' }] }],
+    };
+
+    it('should retry on the next model when the primary fails', async () => {
+      const listener = vi.fn();
+      multiProviderGenerator.setFallbackChain(['gemini-1.5-flash'], listener);
+      const spy = vi
+        .spyOn(mockGeminiGenerator, 'generateContent')
+        .mockRejectedValueOnce(new Error('429 Too Many Requests'));
+
+      const response = await multiProviderGenerator.generateContent(request);
+
+      expect(response.candidates?.[0].content?.parts?.[0].text).toBe(
+        'Gemini response',
+      );
+      expect(spy).toHaveBeenCalledTimes(2);
+      expect(spy.mock.calls[1][0].model).toBe('gemini-1.5-flash');
+      expect(listener).toHaveBeenCalledWith(
+        'gemini-2.5-pro',
+        'gemini-1.5-flash',
+        expect.any(Error),
+      );
+
+      // 主模型恢复后通知监听者
+      await multiProviderGenerator.generateContent(request);
+      expect(listener).toHaveBeenLastCalledWith('gemini-2.5-pro', undefined);
+    });
+
+    it('should skip fallback models whose provider is not configured', async () => {
+      multiProviderGenerator.setFallbackChain(['deepseek-chat']);
+      vi.spyOn(mockGeminiGenerator, 'generateContent').mockRejectedValueOnce(
+        new Error('boom'),
+      );
+
+      await expect(
+        multiProviderGenerator.generateContent(request),
+      ).rejects.toThrow('boom');
+    });
+
+    it('should not fall back on bad requests or auth errors', async () => {
+      multiProviderGenerator.setFallbackChain(['gemini-1.5-flash']);
+      const spy = vi
+        .spyOn(mockGeminiGenerator, 'generateContent')
+        .mockRejectedValueOnce(
+          Object.assign(new Error('Invalid argument'), { status: 400 }),
+        )
+        .mockRejectedValueOnce(
+          Object.assign(new Error('Permission denied'), { status: 403 }),
+        );
+
+      await expect(
+        multiProviderGenerator.generateContent(request),
+      ).rejects.toThrow('Invalid argument');
+      await expect(
+        multiProviderGenerator.generateContent(request),
+      ).rejects.toThrow('Permission denied');
+      expect(spy).toHaveBeenCalledTimes(2);
+    });
+
+    it('should clear the fallback when the primary is the former fallback', async () => {
+      const listener = vi.fn();
+      multiProviderGenerator.setFallbackChain(['gemini-1.5-flash'], listener);
+      vi.spyOn(
+        mockGeminiGenerator,
+        'generateContentStream',
+      ).mockRejectedValueOnce(new Error('503 Service Unavailable'));
+      await multiProviderGenerator.generateContentStream(request);
+
+      await multiProviderGenerator.generateContentStream({
+        ...request,
+        model: 'gemini-1.5-flash',
+      });
+
+      expect(listener).toHaveBeenLastCalledWith('gemini-1.5-flash', undefined);
+    });
+
+    it('should fall back before the first streamed chunk', async () => {
+      multiProviderGenerator.setFallbackChain(['gemini-1.5-flash']);
+      vi.spyOn(
+        mockGeminiGenerator,
+        'generateContentStream',
+      ).mockRejectedValueOnce(new Error('503 Service Unavailable'));
+
+      const stream = await multiProviderGenerator.generateContentStream(request);
+      const chunks = [];
+      for await (const chunk of stream) {
+        chunks.push(chunk);
+      }
+
+      expect(chunks).toHaveLength(1);
+      expect(chunks[0].candidates?.[0].content?.parts?.[0].text).toBe(
+        'Gemini stream',
+      );
+    });
+  });
+
   describe('configureProvider', () => {
     it('should allow configuring providers', () => {
       expect(() => {
@@ -23,6 +23,54 @@ import {
   getModelTokenLimit 
 } from './model-providers/model-utils.js';
 import { modelProviderFactory } from './model-providers/model-provider-factory.js';
+import { getRedactor } from '../utils/redaction.js';
+import { getErrorStatus } from '../utils/retry.js';
+import {
+  isGenericQuotaExceededError,
+  isProQuotaExceededError,
+} from '../utils/quotaErrorDetection.js';
+
+// 连接不上服务时的错误码
+const NETWORK_ERROR_CODES = new Set([
+  'ECONNREFUSED',
+  'ECONNRESET',
+  'ENOTFOUND',
+  'ETIMEDOUT',
+  'EAI_AGAIN',
+]);
+
+/**
+ * 只有配额用尽或模型不可用（429、5xx、网络错误）才回退；
+ * 请求本身有误（400）或认证失败（401、403）换模型也无济于事
+ */
+export function isFallbackError(error: unknown): boolean {
+  if (isProQuotaExceededError(error) || isGenericQuotaExceededError(error)) {
+    return true;
+  }
+  const status = getErrorStatus(error);
+  if (status !== undefined) {
+    return status === 429 || (status >= 500 && status < 600);
+  }
+  const code = (error as { code?: unknown } | null)?.code;
+  if (typeof code === 'string' && NETWORK_ERROR_CODES.has(code)) {
+    return true;
+  }
+  return (
+    error instanceof Error &&
+    (/fetch failed|socket hang up/i.test(error.message) ||
+      /\b(429|5\d\d)\b/.test(error.message))
+  );
+}
+
+/**
+ * 回退状态变化时的回调：原模型、回退到的模型、导致回退的错误
+ * toModel 为 undefined 表示主模型已恢复
+ */
+export type ModelFallbackListener = (
+  fromModel: string,
+  toModel: string | undefined,
+  error?: unknown,
+) => void;
 
 /**
  * 多提供商 ContentGenerator 实现
@@ -31,6 +79,9 @@ import { modelProviderFactory } from './model-providers/model-provider-factory.j
 export class MultiProviderContentGenerator implements ContentGenerator {
   private geminiGenerator: ContentGenerator;
   private providerConfigs: Map<ModelProvider, any> = new Map();
+  private fallbackModels: string[] = [];
+  private fallbackListener?: ModelFallbackListener;
+  private usingFallback = false;
 
   constructor(
     private defaultGeminiGenerator: ContentGenerator,
@@ -46,13 +97,147 @@ export class MultiProviderContentGenerator implements ContentGenerator {
     this.providerConfigs.set(provider, config);
   }
 
+  /**
+   * 设置回退链：主模型出错或限流时，按顺序在这些模型上重试
+   */
+  setFallbackChain(models: string[], listener?: ModelFallbackListener): void {
+    this.fallbackModels = models.filter((m) => m.trim().length > 0);
+    this.fallbackListener = listener;
+  }
+
   /**
    * 生成内容
    */
   async generateContent(
     request: GenerateContentParameters,
   ): Promise<GenerateContentResponse> {
-    const provider = detectModelProvider(request.model);
+    const models = this.getCandidateModels(request.model);
+    let lastError: unknown;
+
+    for (let i = 0; i < models.length; i++) {
+      try {
+        const response = await this.generateContentWithModel({
+          ...request,
+          model: models[i],
+        });
+        this.markModelUsed(models, i);
+        return response;
+      } catch (error) {
+        lastError = error;
+        if (!this.shouldFallback(error, request, i, models)) {
+          throw error;
+        }
+        this.usingFallback = true;
+        this.fallbackListener?.(models[i], models[i + 1], error);
+      }
+    }
+
+    throw lastError;
+  }
+
+  /**
+   * 生成流式内容
+   * 只在收到第一个数据块之前回退，已输出的内容无法撤回
+   */
+  async generateContentStream(
+    request: GenerateContentParameters,
+  ): Promise<AsyncGenerator<GenerateContentResponse>> {
+    const models = this.getCandidateModels(request.model);
+    if (models.length === 1) {
+      // 主模型本身可能就是之前回退到的模型，这时也要清除回退提示
+      const stream = await this.generateContentStreamWithModel(request);
+      this.markModelUsed(models, 0);
+      return stream;
+    }
+    let lastError: unknown;
+
+    for (let i = 0; i < models.length; i++) {
+      try {
+        const stream = await this.generateContentStreamWithModel({
+          ...request,
+          model: models[i],
+        });
+        const first = await stream.next();
+        this.markModelUsed(models, i);
+        return this.prependFirstChunk(first, stream);
+      } catch (error) {
+        lastError = error;
+        if (!this.shouldFallback(error, request, i, models)) {
+          throw error;
+        }
+        this.usingFallback = true;
+        this.fallbackListener?.(models[i], models[i + 1], error);
+      }
+    }
+
+    throw lastError;
+  }
+
+  /**
+   * 主模型加上回退链中已配置的模型（去重）
+   */
+  private getCandidateModels(primary: string): string[] {
+    const models = [primary];
+    for (const model of this.fallbackModels) {
+      if (!models.includes(model) && this.isModelAvailable(model)) {
+        models.push(model);
+      }
+    }
+    return models;
+  }
+
+  /**
+   * 主模型重新可用时通知监听者
+   */
+  private markModelUsed(models: string[], index: number): void {
+    if (index === 0 && this.usingFallback) {
+      this.usingFallback = false;
+      this.fallbackListener?.(models[0], undefined);
+    }
+  }
+
+  private isModelAvailable(model: string): boolean {
+    const provider = detectModelProvider(model);
+    return (
+      provider === ModelProvider.GEMINI || this.providerConfigs.has(provider)
+    );
+  }
+
+  private shouldFallback(
+    error: unknown,
+    request: GenerateContentParameters,
+    index: number,
+    models: string[],
+  ): boolean {
+    if (index >= models.length - 1) {
+      return false;
+    }
+    // 用户取消时不回退
+    if (
+      request.config?.abortSignal?.aborted ||
+      (error instanceof Error && error.name === 'AbortError')
+    ) {
+      return false;
+    }
+    return isFallbackError(error);
+  }
+
+  private async *prependFirstChunk(
+    first: IteratorResult<GenerateContentResponse>,
+    stream: AsyncGenerator<GenerateContentResponse>,
+  ): AsyncGenerator<GenerateContentResponse> {
+    if (first.done) {
+      return;
+    }
+    yield first.value;
+    yield* stream;
+  }
+
+  private async generateContentWithModel(
+    originalRequest: GenerateContentParameters,
+  ): Promise<GenerateContentResponse> {
+    const provider = detectModelProvider(originalRequest.model);
+    const request = this.redactRequest(originalRequest, provider);
     
     if (provider === ModelProvider.GEMINI) {
       // 使用原有的 Gemini generator
@@ -70,13 +255,11 @@ export class MultiProviderContentGenerator implements ContentGenerator {
     return this.convertToGeminiFormat(response, request.model);
   }
 
-  /**
-   * 生成流式内容
-   */
-  async generateContentStream(
-    request: GenerateContentParameters,
+  private async generateContentStreamWithModel(
+    originalRequest: GenerateContentParameters,
   ): Promise<AsyncGenerator<GenerateContentResponse>> {
-    const provider = detectModelProvider(request.model);
+    const provider = detectModelProvider(originalRequest.model);
+    const request = this.redactRequest(originalRequest, provider);
     
     if (provider === ModelProvider.GEMINI) {
       // 使用原有的 Gemini generator
@@ -93,6 +276,41 @@ export class MultiProviderContentGenerator implements ContentGenerator {
     );
   }
 
+  /**
+   * 发送到远程提供商之前应用脱敏规则，本地模型不做处理
+   */
+  private redactRequest(
+    request: GenerateContentParameters,
+    provider: ModelProvider,
+  ): GenerateContentParameters {
+    const redactor = getRedactor();
+    if (!redactor.isEnabled() || this.isLocalProvider(provider)) {
+      return request;
+    }
+    const { value } = redactor.redactContents(
+      this.normalizeContents(request.contents),
+      true,
+    );
+    return { ...request, contents: value };
+  }
+
+  private isLocalProvider(provider: ModelProvider): boolean {
+    if (provider === ModelProvider.OLLAMA) {
+      return true;
+    }
+    const baseUrl: string | undefined =
+      this.providerConfigs.get(provider)?.baseUrl;
+    if (!baseUrl) {
+      return false;
+    }
+    try {
+      const { hostname } = new URL(baseUrl);
+      return ['localhost', '127.0.0.1', '::1', '[::1]'].includes(hostname);
+    } catch (_e) {
+      return false;
+    }
+  }
+
   /**
    * 计算 tokens
    */
@@ -160,6 +378,10 @@ export class MultiProviderContentGenerator implements ContentGenerator {
       maxTokens: request.config?.maxOutputTokens,
       topP: request.config?.topP,
       topK: request.config?.topK,
+      frequencyPenalty: request.config?.frequencyPenalty,
+      presencePenalty: request.config?.presencePenalty,
+      stopSequences: request.config?.stopSequences,
+      seed: request.config?.seed,
       stream: false,
     };
   }
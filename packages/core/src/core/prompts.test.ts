@@ -63,6 +63,16 @@ describe('Core System Prompt (prompts.ts)', () => {
     expect(prompt).toMatchSnapshot(); // Snapshot the combined prompt
   });
 
+  it('should append terminology after userMemory when provided', () => {
+    vi.stubEnv('SANDBOX', undefined);
+    const terminology = '# Terminology\n- Write "dataset".';
+    const prompt = getCoreSystemPrompt('Be extra polite.', terminology);
+
+    expect(
+      prompt.endsWith(`Be extra polite.\n\n---\n\n${terminology}`),
+    ).toBe(true);
+  });
+
   it('should include sandbox-specific instructions when SANDBOX env var is set', () => {
     vi.stubEnv('SANDBOX', 'true'); // Generic sandbox value
     const prompt = getCoreSystemPrompt();
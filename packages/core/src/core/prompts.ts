@@ -18,7 +18,10 @@ import process from 'node:process';
 import { isGitRepository } from '../utils/gitUtils.js';
 import { MemoryTool, RESEARCH_CONFIG_DIR } from '../tools/memoryTool.js';
 
-export function getCoreSystemPrompt(userMemory?: string): string {
+export function getCoreSystemPrompt(
+  userMemory?: string,
+  terminology?: string,
+): string {
   // if RESEARCH_SYSTEM_MD is set (and not 0|false), override system prompt from file
   // default path is .research/system.md but can be modified via custom path in RESEARCH_SYSTEM_MD
   let systemMdEnabled = false;
@@ -269,8 +272,12 @@ Your core function is efficient and safe assistance. Balance extreme conciseness
     userMemory && userMemory.trim().length > 0
       ? `\n\n---\n\n${userMemory.trim()}`
       : '';
+  const terminologySuffix =
+    terminology && terminology.trim().length > 0
+      ? `\n\n---\n\n${terminology.trim()}`
+      : '';
 
-  return `${basePrompt}${memorySuffix}`;
+  return `${basePrompt}${memorySuffix}${terminologySuffix}`;
 }
 
 /**
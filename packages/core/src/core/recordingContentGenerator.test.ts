@@ -0,0 +1,136 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { GenerateContentResponse } from '@google/genai';
+import {
+  FakeContentGenerator,
+  MockScript,
+  loadMockScript,
+} from './fakeContentGenerator.js';
+import {
+  Recording,
+  RecordingContentGenerator,
+} from './recordingContentGenerator.js';
+
+const SECRET = 'sk-abcdefghijklmnopqrstuvwxyz123456';
+
+async function collectText(
+  stream: AsyncGenerator<GenerateContentResponse>,
+): Promise<string> {
+  let text = '';
+  for await (const chunk of stream) {
+    text += chunk.text ?? '';
+  }
+  return text;
+}
+
+const script: MockScript = {
+  turns: [
+    {
+      chunks: ['Using ', `key ${SECRET}`],
+      functionCalls: [{ name: 'web_fetch', args: { token: SECRET } }],
+      delaysMs: [5, 20],
+    },
+    { chunks: ['done'], chunkDelayMs: 0 },
+  ],
+};
+
+describe('RecordingContentGenerator', () => {
+  let dir: string;
+  let file: string;
+
+  beforeEach(() => {
+    dir = fs.mkdtempSync(path.join(os.tmpdir(), 'research-record-'));
+    file = path.join(dir, 'nested', 'session.json');
+  });
+
+  afterEach(() => {
+    fs.rmSync(dir, { recursive: true, force: true });
+  });
+
+  it('should record streamed chunks with timing and scrub secrets', async () => {
+    const recorder = new RecordingContentGenerator(
+      new FakeContentGenerator(script),
+      file,
+    );
+    const stream = await recorder.generateContentStream({
+      model: 'test-model',
+      contents: [{ role: 'user', parts: [{ text: `my key is ${SECRET}` }] }],
+    });
+    await collectText(stream);
+
+    // A header line, then a line per turn
+    expect(fs.readFileSync(file, 'utf-8').trim().split('\n')).toHaveLength(2);
+    const recording = loadMockScript(file) as Recording;
+    expect(recording.version).toBe(1);
+    expect(recording.turns).toHaveLength(1);
+    const [turn] = recording.turns;
+    expect(turn.chunks).toEqual(['Using ', 'key [REDACTED:openai-key]']);
+    expect(turn.delaysMs).toHaveLength(2);
+    expect(turn.delaysMs![1]).toBeGreaterThanOrEqual(15);
+    expect(turn.functionCalls).toEqual([
+      { name: 'web_fetch', args: { token: '[REDACTED:openai-key]' } },
+    ]);
+    expect(turn.request.model).toBe('test-model');
+    expect(fs.readFileSync(file, 'utf-8')).not.toContain(SECRET);
+  });
+
+  it('should record non-streamed calls as a single chunk', async () => {
+    const recorder = new RecordingContentGenerator(
+      new FakeContentGenerator({ turns: [{ chunks: ['a', 'b'] }] }),
+      file,
+    );
+    await recorder.generateContent({ model: 'test-model', contents: 'hi' });
+    expect(recorder.getRecording().turns[0].chunks).toEqual(['ab']);
+  });
+
+  it('should produce a file that replays with the mock provider', async () => {
+    const recorder = new RecordingContentGenerator(
+      new FakeContentGenerator(script),
+      file,
+    );
+    const first = await collectText(
+      await recorder.generateContentStream({
+        model: 'test-model',
+        contents: 'one',
+      }),
+    );
+    const second = await collectText(
+      await recorder.generateContentStream({
+        model: 'test-model',
+        contents: 'two',
+      }),
+    );
+
+    const replay = new FakeContentGenerator(loadMockScript(file));
+    const replayed = await replay.generateContentStream({
+      model: 'test-model',
+      contents: 'one',
+    });
+    const chunks: GenerateContentResponse[] = [];
+    for await (const chunk of replayed) {
+      chunks.push(chunk);
+    }
+    expect(chunks.map((c) => c.text ?? '').join('')).toBe(
+      first.replace(SECRET, '[REDACTED:openai-key]'),
+    );
+    expect(chunks[chunks.length - 1].functionCalls?.[0].name).toBe(
+      'web_fetch',
+    );
+    expect(
+      await collectText(
+        await replay.generateContentStream({
+          model: 'test-model',
+          contents: 'two',
+        }),
+      ),
+    ).toBe(second);
+  });
+});
@@ -0,0 +1,160 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import {
+  Content,
+  CountTokensParameters,
+  CountTokensResponse,
+  EmbedContentParameters,
+  EmbedContentResponse,
+  GenerateContentParameters,
+  GenerateContentResponse,
+} from '@google/genai';
+import type { ContentGenerator } from './contentGenerator.js';
+import { MockScript, MockTurn } from './fakeContentGenerator.js';
+import { Redactor, getRedactor } from '../utils/redaction.js';
+
+/**
+ * A recorded turn. The extra request field is ignored on replay but makes
+ * recordings useful in bug reports.
+ */
+export interface RecordedTurn extends MockTurn {
+  request: {
+    model: string;
+    contents: Content[];
+  };
+}
+
+export interface Recording extends MockScript {
+  version: 1;
+  recordedAt: string;
+  turns: RecordedTurn[];
+}
+
+/**
+ * Wraps a ContentGenerator and writes every request and streamed response
+ * to a file in the `--mock` script format, so the session can be replayed
+ * with `--mock <file>`. Chunk timing is kept; secrets are scrubbed with the
+ * built-in detectors (plus any configured redaction rules).
+ *
+ * The file is JSON Lines: a first line with the recording's header and an
+ * empty `turns` array, then one line per finished turn, appended as it
+ * ends so that a long session is never rewritten.
+ */
+export class RecordingContentGenerator implements ContentGenerator {
+  private readonly recording: Recording = {
+    version: 1,
+    recordedAt: new Date().toISOString(),
+    turns: [],
+  };
+  private readonly scrubber: Redactor;
+
+  constructor(
+    private readonly wrapped: ContentGenerator,
+    private readonly filePath: string,
+  ) {
+    const redactor = getRedactor();
+    this.scrubber = redactor.isEnabled() ? redactor : new Redactor({});
+    fs.mkdirSync(path.dirname(path.resolve(filePath)), { recursive: true });
+    fs.writeFileSync(filePath, JSON.stringify(this.recording) + '\n');
+  }
+
+  async generateContent(
+    request: GenerateContentParameters,
+  ): Promise<GenerateContentResponse> {
+    const start = Date.now();
+    const response = await this.wrapped.generateContent(request);
+    const turn = this.startTurn(request);
+    this.addChunk(turn, response, Date.now() - start);
+    this.append(turn);
+    return response;
+  }
+
+  async generateContentStream(
+    request: GenerateContentParameters,
+  ): Promise<AsyncGenerator<GenerateContentResponse>> {
+    const stream = await this.wrapped.generateContentStream(request);
+    const turn = this.startTurn(request);
+    let last = Date.now();
+    const recorder = this;
+    return (async function* () {
+      try {
+        for await (const chunk of stream) {
+          const now = Date.now();
+          recorder.addChunk(turn, chunk, now - last);
+          last = now;
+          yield chunk;
+        }
+      } finally {
+        recorder.append(turn);
+      }
+    })();
+  }
+
+  countTokens(request: CountTokensParameters): Promise<CountTokensResponse> {
+    return this.wrapped.countTokens(request);
+  }
+
+  embedContent(request: EmbedContentParameters): Promise<EmbedContentResponse> {
+    return this.wrapped.embedContent(request);
+  }
+
+  getRecording(): Recording {
+    return this.recording;
+  }
+
+  private startTurn(request: GenerateContentParameters): RecordedTurn {
+    const contents = (
+      Array.isArray(request.contents) ? request.contents : [request.contents]
+    ).map((item) =>
+      typeof item === 'string'
+        ? { role: 'user', parts: [{ text: item }] }
+        : (item as Content),
+    );
+    const turn: RecordedTurn = {
+      request: {
+        model: request.model,
+        contents: this.scrubber.redactContents(contents).value,
+      },
+      chunks: [],
+      delaysMs: [],
+    };
+    this.recording.turns.push(turn);
+    return turn;
+  }
+
+  private addChunk(
+    turn: RecordedTurn,
+    response: GenerateContentResponse,
+    delayMs: number,
+  ): void {
+    const parts = response.candidates?.[0]?.content?.parts ?? [];
+    const text = parts
+      .filter((part) => part.text !== undefined && !part.thought)
+      .map((part) => part.text)
+      .join('');
+    turn.chunks!.push(this.scrubber.redactText(text).value);
+    turn.delaysMs!.push(delayMs);
+    for (const part of parts) {
+      if (part.functionCall?.name) {
+        turn.functionCalls = [
+          ...(turn.functionCalls ?? []),
+          {
+            name: part.functionCall.name,
+            args: this.scrubber.redactValue(part.functionCall.args ?? {})
+              .value,
+          },
+        ];
+      }
+    }
+  }
+
+  private append(turn: RecordedTurn): void {
+    fs.appendFileSync(this.filePath, JSON.stringify(turn) + '\n');
+  }
+}
@@ -85,6 +85,55 @@ describe('ResearchChat', () => {
         config: {},
       });
     });
+
+    it('should send the history through the context filter', async () => {
+      vi.mocked(mockModelsModule.generateContent).mockResolvedValue({
+        candidates: [
+          { content: { parts: [{ text: 'response' }], role: 'model' } },
+        ],
+      } as unknown as GenerateContentResponse);
+      chat.addHistory({ role: 'user', parts: [{ text: 'first' }] });
+      chat.addHistory({ role: 'model', parts: [{ text: 'answer' }] });
+      chat.setContextFilter((history) => history.slice(2));
+
+      await chat.sendMessage({ message: 'hello' }, 'prompt-id-1');
+
+      expect(mockModelsModule.generateContent).toHaveBeenCalledWith(
+        expect.objectContaining({
+          contents: [{ role: 'user', parts: [{ text: 'hello' }] }],
+        }),
+      );
+      // The stored history is unchanged
+      expect(chat.getHistory()).toHaveLength(4);
+    });
+
+    it('should send the generation options set for the session', async () => {
+      vi.mocked(mockModelsModule.generateContent).mockResolvedValue({
+        candidates: [
+          { content: { parts: [{ text: 'response' }], role: 'model' } },
+        ],
+      } as unknown as GenerateContentResponse);
+      chat.setGenerationOptions({
+        stopSequences: ['END'],
+        maxOutputTokens: 64,
+      });
+      chat.setGenerationOptions({
+        stopSequences: ['###'],
+        presencePenalty: 1,
+        seed: 42,
+      });
+
+      await chat.sendMessage({ message: 'hello' }, 'prompt-id-1');
+
+      const { config } = vi.mocked(mockModelsModule.generateContent).mock
+        .calls[0][0];
+      expect(config).toMatchObject({
+        stopSequences: ['###'],
+        presencePenalty: 1,
+        seed: 42,
+      });
+      expect(config?.maxOutputTokens).toBeUndefined();
+    });
   });
 
   describe('sendMessageStream', () => {
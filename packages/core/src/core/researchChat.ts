@@ -15,6 +15,7 @@ import {
   createUserContent,
   Part,
   GenerateContentResponseUsageMetadata,
+  Tool,
 } from '@google/genai';
 import { retryWithBackoff } from '../utils/retry.js';
 import { isFunctionResponse } from '../utils/messageInspectors.js';
@@ -126,10 +127,47 @@ function extractCuratedHistory(comprehensiveHistory: Content[]): Content[] {
  * @remarks
  * The session maintains all the turns between user and model.
  */
+/**
+ * Rewrites the curated history before it is sent with a request, e.g. to
+ * leave out messages or add side threads. The stored history is not changed.
+ */
+export type ContextFilter = (history: Content[]) => Content[];
+
+/**
+ * Options that shape a chat's answers, set for a session, e.g. to end an
+ * answer at a marker in structured-output workflows.
+ */
+export interface GenerationOptions {
+  /** The answer ends before the first of these. */
+  stopSequences?: string[];
+  maxOutputTokens?: number;
+  /** -2 to 2; positive values make repeating a token less likely. */
+  frequencyPenalty?: number;
+  /** -2 to 2; positive values make any used token less likely. */
+  presencePenalty?: number;
+  /**
+   * Makes sampling repeatable: providers that take a seed answer the same
+   * request with the same seed the same way, as far as they can.
+   */
+  seed?: number;
+}
+
+/**
+ * What a request is sent with ahead of the prompt: the system instruction
+ * and the history after the context filters.
+ */
+export interface RequestContext {
+  systemInstruction: string;
+  history: Content[];
+}
+
 export class ResearchChat {
   // A promise to represent the current state of the message being sent to the
   // model.
   private sendPromise: Promise<void> = Promise.resolve();
+  private contextFilter?: ContextFilter;
+  // Bumped on every change to the history
+  private historyRevision = 0;
 
   constructor(
     private readonly config: Config,
@@ -272,7 +310,7 @@ export class ResearchChat {
   ): Promise<GenerateContentResponse> {
     await this.sendPromise;
     const userContent = createUserContent(params.message);
-    const requestContents = this.getHistory(true).concat(userContent);
+    const requestContents = this.getRequestHistory().concat(userContent);
 
     this._logApiRequest(requestContents, this.config.getModel(), prompt_id);
 
@@ -328,7 +366,7 @@ export class ResearchChat {
         // to deduplicate the existing chat history.
         const fullAutomaticFunctionCallingHistory =
           response.automaticFunctionCallingHistory;
-        const index = this.getHistory(true).length;
+        const index = requestContents.length - 1;
         let automaticFunctionCallingHistory: Content[] = [];
         if (fullAutomaticFunctionCallingHistory != null) {
           automaticFunctionCallingHistory =
@@ -382,7 +420,7 @@ export class ResearchChat {
   ): Promise<AsyncGenerator<GenerateContentResponse>> {
     await this.sendPromise;
     const userContent = createUserContent(params.message);
-    const requestContents = this.getHistory(true).concat(userContent);
+    const requestContents = this.getRequestHistory().concat(userContent);
     this._logApiRequest(requestContents, this.config.getModel(), prompt_id);
 
     const startTime = Date.now();
@@ -480,11 +518,20 @@ export class ResearchChat {
     return structuredClone(history);
   }
 
+  /**
+   * A number that changes whenever the history does, to tell cheaply
+   * whether it changed since it was last read.
+   */
+  getHistoryRevision(): number {
+    return this.historyRevision;
+  }
+
   /**
    * Clears the chat history.
    */
   clearHistory(): void {
     this.history = [];
+    this.historyRevision++;
   }
 
   /**
@@ -494,9 +541,53 @@ export class ResearchChat {
    */
   addHistory(content: Content): void {
     this.history.push(content);
+    this.historyRevision++;
   }
   setHistory(history: Content[]): void {
     this.history = history;
+    this.historyRevision++;
+  }
+
+  /**
+   * Sets how the history is rewritten before each request; `undefined`
+   * sends the curated history as is.
+   */
+  setContextFilter(filter: ContextFilter | undefined): void {
+    this.contextFilter = filter;
+  }
+
+  /** The history as it is sent with the next request. */
+  getRequestHistory(): Content[] {
+    const history = this.getHistory(true);
+    return this.contextFilter ? this.contextFilter(history) : history;
+  }
+
+  /**
+   * Replaces the tools offered to the model from the next request on.
+   */
+  setTools(tools: Tool[]): void {
+    this.generationConfig.tools = tools;
+  }
+
+  /**
+   * Sets the output options of the requests that follow. An option left
+   * undefined goes back to the model's default.
+   */
+  setGenerationOptions(options: GenerationOptions): void {
+    const {
+      stopSequences,
+      maxOutputTokens,
+      frequencyPenalty,
+      presencePenalty,
+      seed,
+    } = options;
+    Object.assign(this.generationConfig, {
+      stopSequences,
+      maxOutputTokens,
+      frequencyPenalty,
+      presencePenalty,
+      seed,
+    });
   }
 
   getFinalUsageMetadata(
@@ -566,6 +657,7 @@ export class ResearchChat {
     modelOutput: Content[],
     automaticFunctionCallingHistory?: Content[],
   ) {
+    this.historyRevision++;
     const nonThoughtModelOutput = modelOutput.filter(
       (content) => !this.isThoughtContent(content),
     );
@@ -13,6 +13,8 @@ export * from './config/model-provider-config.js';
 // Export Core Logic
 export * from './core/client.js';
 export * from './core/contentGenerator.js';
+export * from './core/fakeContentGenerator.js';
+export * from './core/recordingContentGenerator.js';
 export * from './core/researchChat.js';
 export * from './core/logger.js';
 export * from './core/prompts.js';
@@ -26,9 +28,11 @@ export * from './core/nonInteractiveToolExecutor.js';
 export * from './core/model-providers/types.js';
 export * from './core/model-providers/base-provider.js';
 export * from './core/model-providers/llm-interface-provider.js';
+export * from './core/model-providers/openai-compatible-provider.js';
 export * from './core/model-providers/model-provider-factory.js';
 export * from './core/model-providers/model-selector.js';
 export * from './core/model-providers/model-utils.js';
+export * from './core/model-providers/model-catalog.js';
 
 export * from './code_assist/codeAssist.js';
 export * from './code_assist/oauth2.js';
@@ -44,10 +48,47 @@ export * from './utils/memoryDiscovery.js';
 export * from './utils/gitIgnoreParser.js';
 export * from './utils/editor.js';
 export * from './utils/quotaErrorDetection.js';
+export * from './utils/network.js';
+export * from './utils/redaction.js';
+export * from './utils/notebook.js';
+export * from './utils/tabularData.js';
+export * from './utils/sqlDatabase.js';
+export * from './utils/jsonTree.js';
+export * from './utils/readability.js';
+export * from './utils/wikipedia.js';
+export * from './utils/deadlines.js';
+export * from './utils/pdfFigures.js';
+export * from './utils/latexProject.js';
+export * from './utils/incognito.js';
+export * from './utils/remoteTarget.js';
+export * from './utils/containerExecution.js';
+export * from './utils/conversationHtml.js';
+export * from './utils/conversationTranscript.js';
+export * from './utils/messageInspectors.js';
+export * from './utils/webhooks.js';
+export * from './utils/mail.js';
+export * from './utils/calendar.js';
+export * from './utils/actionItems.js';
+export * from './utils/feedback.js';
+export * from './utils/promptEval.js';
+export * from './utils/behaviorEval.js';
+export * from './utils/usageHistory.js';
+export * from './utils/workspaceStorage.js';
+export * from './utils/backup.js';
+export * from './utils/credentialStore.js';
+export * from './utils/exchanges.js';
+export * from './utils/terminology.js';
 
 // Export services
 export * from './services/fileDiscoveryService.js';
 export * from './services/gitService.js';
+export * from './services/jupyterKernel.js';
+export * from './services/manuscriptSync.js';
+export * from './services/dataSync.js';
+export * from './services/sharedSession.js';
+export * from './services/conversationThreads.js';
+export * from './services/contextSelection.js';
+export * from './services/repositoryIngest.js';
 
 // Export base tool definitions
 export * from './tools/tools.js';
@@ -60,13 +101,19 @@ export * from './tools/grep.js';
 export * from './tools/glob.js';
 export * from './tools/edit.js';
 export * from './tools/write-file.js';
+export * from './tools/notebook-edit.js';
 export * from './tools/web-fetch.js';
+export * from './tools/http-request.js';
 export * from './tools/memoryTool.js';
 export * from './tools/shell.js';
 export * from './tools/web-search.js';
 export * from './tools/read-many-files.js';
+export * from './tools/data-query.js';
+export * from './tools/sql-query.js';
+export * from './tools/calendar-event.js';
 export * from './tools/mcp-client.js';
 export * from './tools/mcp-tool.js';
+export * from './tools/repository-tools.js';
 
 // Export research tools
 export * from './tools/research/index.js';
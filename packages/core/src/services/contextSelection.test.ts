@@ -0,0 +1,106 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, vi, beforeEach } from 'vitest';
+import { Content } from '@google/genai';
+import { ResearchClient } from '../core/client.js';
+import { ContextFilter } from '../core/researchChat.js';
+import { ContextSelection } from './contextSelection.js';
+
+const user = (text: string): Content => ({ role: 'user', parts: [{ text }] });
+const model = (text: string): Content => ({ role: 'model', parts: [{ text }] });
+
+describe('ContextSelection', () => {
+  let history: Content[];
+  let filter: ContextFilter;
+  let countTokens: ReturnType<typeof vi.fn>;
+  let selection: ContextSelection;
+
+  beforeEach(() => {
+    history = [
+      user('Load the data.'),
+      { role: 'model', parts: [{ functionCall: { name: 'read_file' } }] },
+      { role: 'user', parts: [{ functionResponse: { name: 'read_file' } }] },
+      model('Loaded 120 rows.'),
+      user('Tell me a joke.'),
+      model('Why did the p-value...'),
+      user('Plot the means.'),
+      model('Here is the plot.'),
+    ];
+    countTokens = vi.fn().mockResolvedValue({ totalTokens: 42 });
+    const client = {
+      addContextFilter: (f: ContextFilter) => {
+        filter = f;
+        return () => {};
+      },
+      getChat: () => ({
+        getHistory: () => structuredClone(history),
+        getRequestHistory: () => filter(structuredClone(history)),
+      }),
+      getContentGenerator: () => ({ countTokens }),
+    } as unknown as ResearchClient;
+    selection = new ContextSelection(client);
+  });
+
+  it('should list every exchange as included at first', () => {
+    expect(selection.list()).toEqual([
+      {
+        exchange: 1,
+        prompt: 'Load the data.',
+        answer: 'Loaded 120 rows.',
+        included: true,
+      },
+      {
+        exchange: 2,
+        prompt: 'Tell me a joke.',
+        answer: 'Why did the p-value...',
+        included: true,
+      },
+      {
+        exchange: 3,
+        prompt: 'Plot the means.',
+        answer: 'Here is the plot.',
+        included: true,
+      },
+    ]);
+    expect(filter(history)).toBe(history);
+  });
+
+  it('should leave excluded exchanges and their tool calls out', () => {
+    selection.setIncluded([1, 2], false);
+
+    expect(filter(history)).toEqual(history.slice(6));
+    expect(selection.list().map((entry) => entry.included)).toEqual([
+      false,
+      false,
+      true,
+    ]);
+
+    selection.setIncluded([1], true);
+    expect(filter(history)).toEqual([
+      ...history.slice(0, 4),
+      ...history.slice(6),
+    ]);
+  });
+
+  it('should select all exchanges at once', () => {
+    selection.setIncluded('all', false);
+    expect(filter(history)).toEqual([]);
+
+    selection.setIncluded('all', true);
+    expect(filter(history)).toBe(history);
+  });
+
+  it('should count the tokens of what is sent', async () => {
+    selection.setIncluded([2], false);
+
+    expect(await selection.countTokens('gpt-4o')).toBe(42);
+    expect(countTokens).toHaveBeenCalledWith({
+      model: 'gpt-4o',
+      contents: [...history.slice(0, 4), ...history.slice(6)],
+    });
+  });
+});
@@ -0,0 +1,110 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { Content } from '@google/genai';
+import { ResearchClient } from '../core/client.js';
+import {
+  exchangeText,
+  ExchangeRef,
+  findExchanges,
+  locateExchange,
+} from '../utils/exchanges.js';
+
+/** An exchange of the conversation and whether it is sent to the model. */
+export interface ContextEntry extends ExchangeRef {
+  answer: string;
+  included: boolean;
+}
+
+/**
+ * Which exchanges of a session are sent with the next request. Excluded
+ * exchanges stay in the history and can be included again; they are only
+ * left out of requests, through a context filter on the client.
+ */
+export class ContextSelection {
+  private excluded: ExchangeRef[] = [];
+
+  constructor(private readonly client: ResearchClient) {
+    client.addContextFilter((history) => this.apply(history));
+  }
+
+  /** Positions in `exchanges` of the excluded exchanges. */
+  private locateExcluded(
+    history: Content[],
+    exchanges: number[],
+  ): Set<number> {
+    const positions = new Set<number>();
+    for (const ref of this.excluded) {
+      const i = locateExchange(history, exchanges, ref);
+      if (i !== undefined) {
+        positions.add(i);
+      }
+    }
+    return positions;
+  }
+
+  /** Leaves the excluded exchanges, with their tool calls, out of `history`. */
+  apply(history: Content[]): Content[] {
+    const exchanges = findExchanges(history);
+    const excluded = this.locateExcluded(history, exchanges);
+    if (excluded.size === 0) {
+      return history;
+    }
+    let position = -1;
+    return history.filter((_, index) => {
+      while (exchanges[position + 1] <= index) {
+        position++;
+      }
+      return !excluded.has(position);
+    });
+  }
+
+  list(): ContextEntry[] {
+    const history = this.client.getChat().getHistory(true);
+    const exchanges = findExchanges(history);
+    const excluded = this.locateExcluded(history, exchanges);
+    return exchanges.map((start, i) => ({
+      exchange: i + 1,
+      prompt: exchangeText([history[start]]),
+      answer: exchangeText(history.slice(start + 1, exchanges[i + 1])),
+      included: !excluded.has(i),
+    }));
+  }
+
+  /** Includes or excludes exchanges by number, or all of them. */
+  setIncluded(exchanges: number[] | 'all', included: boolean): void {
+    this.excluded = this.list()
+      .filter((entry) =>
+        exchanges === 'all' || exchanges.includes(entry.exchange)
+          ? !included
+          : !entry.included,
+      )
+      .map(({ exchange, prompt }) => ({ exchange, prompt }));
+  }
+
+  /** Tokens of the history the next request sends, as the model counts. */
+  async countTokens(model: string): Promise<number | undefined> {
+    const { totalTokens } = await this.client
+      .getContentGenerator()
+      .countTokens({
+        model,
+        contents: this.client.getChat().getRequestHistory(),
+      });
+    return totalTokens;
+  }
+}
+
+const sessions = new WeakMap<ResearchClient, ContextSelection>();
+
+/** The context selection of the session run by `client`. */
+export function getContextSelection(client: ResearchClient): ContextSelection {
+  let selection = sessions.get(client);
+  if (!selection) {
+    selection = new ContextSelection(client);
+    sessions.set(client, selection);
+  }
+  return selection;
+}
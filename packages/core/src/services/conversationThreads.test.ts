@@ -0,0 +1,95 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, vi, beforeEach } from 'vitest';
+import { Content, GenerateContentResponse } from '@google/genai';
+import { ResearchClient } from '../core/client.js';
+import { ContextFilter } from '../core/researchChat.js';
+import { ConversationThreads, weaveThreads } from './conversationThreads.js';
+
+const user = (text: string): Content => ({ role: 'user', parts: [{ text }] });
+const model = (text: string): Content => ({ role: 'model', parts: [{ text }] });
+
+describe('ConversationThreads', () => {
+  let history: Content[];
+  let filter: ContextFilter;
+  let generateContent: ReturnType<typeof vi.fn>;
+  let threads: ConversationThreads;
+  const signal = new AbortController().signal;
+
+  beforeEach(() => {
+    history = [
+      user('Which test should I use?'),
+      { role: 'model', parts: [{ functionCall: { name: 'read_file' } }] },
+      { role: 'user', parts: [{ functionResponse: { name: 'read_file' } }] },
+      model('A paired t-test.'),
+      user('Write the methods section.'),
+      model('Participants were...'),
+    ];
+    generateContent = vi.fn().mockResolvedValue({
+      candidates: [{ content: { parts: [{ text: 'Because samples pair.' }] } }],
+    } as GenerateContentResponse);
+    const client = {
+      addContextFilter: (f: ContextFilter) => {
+        filter = f;
+        return () => {};
+      },
+      getChat: () => ({ getHistory: () => structuredClone(history) }),
+      generateContent,
+    } as unknown as ResearchClient;
+    threads = new ConversationThreads(client);
+  });
+
+  it('should ask with the history up to the answer replied to', async () => {
+    const thread = threads.start(2);
+
+    expect(thread).toMatchObject({
+      id: 1,
+      exchange: 1,
+      answer: 'A paired t-test.',
+      included: false,
+    });
+    expect(await threads.ask(thread, 'Why paired?', signal)).toBe(
+      'Because samples pair.',
+    );
+    expect(generateContent.mock.calls[0][0]).toEqual([
+      ...history.slice(0, 4),
+      user('Why paired?'),
+    ]);
+    expect(thread.turns).toEqual([
+      user('Why paired?'),
+      model('Because samples pair.'),
+    ]);
+    expect(() => threads.start(3)).toThrow('There is no such answer');
+  });
+
+  it('should only send included threads with the conversation', async () => {
+    const thread = threads.start(2);
+    await threads.ask(thread, 'Why paired?', signal);
+
+    expect(filter(history)).toEqual(history);
+
+    thread.included = true;
+    expect(filter(history)).toEqual([
+      ...history.slice(0, 4),
+      user('Why paired?'),
+      model('Because samples pair.'),
+      ...history.slice(4),
+    ]);
+  });
+
+  it('should drop threads whose answer is gone', async () => {
+    const thread = threads.start();
+    await threads.ask(thread, 'Shorter?', signal);
+    thread.included = true;
+    history = [user('A new topic'), model('Sure.')];
+
+    expect(weaveThreads(history, threads.list())).toEqual(history);
+    await expect(threads.ask(thread, 'And now?', signal)).rejects.toThrow(
+      'no longer in the conversation',
+    );
+  });
+});
@@ -0,0 +1,151 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { Content } from '@google/genai';
+import { ResearchClient } from '../core/client.js';
+import { getResponseText } from '../utils/generateContentResponseUtilities.js';
+import {
+  exchangeText,
+  ExchangeRef,
+  findExchanges,
+  locateExchange,
+  markInserted,
+} from '../utils/exchanges.js';
+
+/** A side conversation about one answer, kept out of the main flow. */
+export interface ConversationThread extends ExchangeRef {
+  id: number;
+  /** The answer replied to. */
+  answer: string;
+  /** The thread's questions and answers, alternating. */
+  turns: Content[];
+  /** Whether the thread is sent with the main conversation. */
+  included: boolean;
+}
+
+/** Where the exchange a thread replies to ends, or undefined when gone. */
+function findExchangeEnd(
+  history: Content[],
+  exchanges: number[],
+  thread: ExchangeRef,
+): number | undefined {
+  const i = locateExchange(history, exchanges, thread);
+  return i === undefined ? undefined : (exchanges[i + 1] ?? history.length);
+}
+
+/**
+ * Adds the included threads to the history, each right after the answer it
+ * replies to. Threads whose answer is gone, e.g. after /clear, are left
+ * out.
+ */
+export function weaveThreads(
+  history: Content[],
+  threads: ConversationThread[],
+): Content[] {
+  const exchanges = findExchanges(history);
+  const woven = [...history];
+  const included = threads
+    .filter((thread) => thread.included && thread.turns.length > 0)
+    .sort((a, b) => b.exchange - a.exchange);
+  for (const thread of included) {
+    const end = findExchangeEnd(history, exchanges, thread);
+    if (end !== undefined) {
+      woven.splice(end, 0, ...markInserted(thread.turns));
+    }
+  }
+  return woven;
+}
+
+/**
+ * The threads of one session. Included threads are added to every request
+ * of the main conversation through a context filter on the client.
+ */
+export class ConversationThreads {
+  private readonly threads: ConversationThread[] = [];
+
+  constructor(private readonly client: ResearchClient) {
+    client.addContextFilter((history) => weaveThreads(history, this.threads));
+  }
+
+  list(): ConversationThread[] {
+    return [...this.threads];
+  }
+
+  get(id: number): ConversationThread | undefined {
+    return this.threads.find((thread) => thread.id === id);
+  }
+
+  /**
+   * Starts a thread on an answer, counted back from the latest one
+   * (1 is the latest).
+   */
+  start(back = 1): ConversationThread {
+    const history = this.client.getChat().getHistory(true);
+    const exchanges = findExchanges(history);
+    const exchange = exchanges.length - back + 1;
+    const start = exchanges[exchange - 1];
+    const answer = exchangeText(history.slice(start + 1, exchanges[exchange]));
+    if (back < 1 || start === undefined || !answer) {
+      throw new Error('There is no such answer to reply to.');
+    }
+    const thread: ConversationThread = {
+      id: this.threads.length + 1,
+      exchange,
+      prompt: exchangeText([history[start]]),
+      answer,
+      turns: [],
+      included: false,
+    };
+    this.threads.push(thread);
+    return thread;
+  }
+
+  /**
+   * Asks a question in a thread. The model sees the conversation up to the
+   * answer replied to and the thread so far, and the exchange is added to
+   * the thread only.
+   */
+  async ask(
+    thread: ConversationThread,
+    question: string,
+    signal: AbortSignal,
+  ): Promise<string> {
+    const history = this.client.getChat().getHistory(true);
+    const end = findExchangeEnd(history, findExchanges(history), thread);
+    if (end === undefined) {
+      throw new Error(
+        'The answer this thread replies to is no longer in the conversation.',
+      );
+    }
+    const others = this.threads.filter((t) => t !== thread);
+    const prompt: Content = { role: 'user', parts: [{ text: question }] };
+    const response = await this.client.generateContent(
+      [...weaveThreads(history.slice(0, end), others), ...thread.turns, prompt],
+      {},
+      signal,
+    );
+    const answer = getResponseText(response)?.trim();
+    if (!answer) {
+      throw new Error('The model returned no answer.');
+    }
+    thread.turns.push(prompt, { role: 'model', parts: [{ text: answer }] });
+    return answer;
+  }
+}
+
+const sessions = new WeakMap<ResearchClient, ConversationThreads>();
+
+/** The threads of the session run by `client`. */
+export function getConversationThreads(
+  client: ResearchClient,
+): ConversationThreads {
+  let threads = sessions.get(client);
+  if (!threads) {
+    threads = new ConversationThreads(client);
+    sessions.set(client, threads);
+  }
+  return threads;
+}
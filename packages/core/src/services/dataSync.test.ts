@@ -0,0 +1,269 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import {
+  createSyncBackend,
+  listConflicts,
+  listSyncedFiles,
+  RemoteIndex,
+  resolveConflict,
+  S3SyncBackend,
+  signS3Request,
+  SyncBackend,
+  syncData,
+} from './dataSync.js';
+
+class MemoryBackend implements SyncBackend {
+  index: RemoteIndex = { files: {} };
+  data = new Map<string, Buffer>();
+  messages: string[] = [];
+
+  async open() {
+    return structuredClone(this.index);
+  }
+  async read(file: string) {
+    return this.data.get(file)!;
+  }
+  async write(file: string, data: Buffer) {
+    this.data.set(file, data);
+  }
+  async close(index: RemoteIndex, message: string) {
+    this.index = structuredClone(index);
+    this.messages.push(message);
+  }
+}
+
+describe('syncData', () => {
+  let tempDir: string;
+  let laptop: string;
+  let desktop: string;
+  let backend: MemoryBackend;
+
+  const write = (dir: string, file: string, content: string, time?: Date) => {
+    const filePath = path.join(dir, file);
+    fs.mkdirSync(path.dirname(filePath), { recursive: true });
+    fs.writeFileSync(filePath, content);
+    if (time) {
+      fs.utimesSync(filePath, time, time);
+    }
+  };
+  const read = (dir: string, file: string) =>
+    fs.readFileSync(path.join(dir, file), 'utf8');
+  const sync = (dataDir: string, options = {}) =>
+    syncData(backend, { dataDir, machine: path.basename(dataDir), ...options });
+
+  beforeEach(async () => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'data-sync-'));
+    laptop = path.join(tempDir, 'laptop');
+    desktop = path.join(tempDir, 'desktop');
+    backend = new MemoryBackend();
+
+    write(laptop, 'RESEARCH.md', '- Uses APA style');
+    write(laptop, 'tmp/abc/checkpoint-draft.json', '[]');
+    write(laptop, 'settings.json', '{"apiKey":"sk"}');
+    write(laptop, 'tmp/abc/logs.json', '[]');
+    await sync(laptop);
+  });
+
+  afterEach(() => {
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should only sync sessions and notes, not settings or logs', async () => {
+    expect([...(await listSyncedFiles(laptop)).keys()].sort()).toEqual([
+      'RESEARCH.md',
+      'tmp/abc/checkpoint-draft.json',
+    ]);
+    expect(Object.keys(backend.index.files).sort()).toEqual([
+      'RESEARCH.md',
+      'tmp/abc/checkpoint-draft.json',
+    ]);
+    expect(backend.index.files['RESEARCH.md'].machine).toBe('laptop');
+    expect(backend.messages).toEqual(['Sync 2 files from laptop']);
+  });
+
+  it('should pull new files and push changes made on one side', async () => {
+    expect(await sync(desktop)).toMatchObject({
+      pulled: ['RESEARCH.md', 'tmp/abc/checkpoint-draft.json'],
+      pushed: [],
+    });
+    expect(read(desktop, 'RESEARCH.md')).toBe('- Uses APA style');
+
+    write(desktop, 'tmp/abc/checkpoint-draft.json', '[{"role":"user"}]');
+    expect((await sync(desktop)).pushed).toEqual([
+      'tmp/abc/checkpoint-draft.json',
+    ]);
+    expect((await sync(laptop)).pulled).toEqual([
+      'tmp/abc/checkpoint-draft.json',
+    ]);
+    expect(read(laptop, 'tmp/abc/checkpoint-draft.json')).toBe(
+      '[{"role":"user"}]',
+    );
+    expect(await sync(laptop)).toEqual({
+      pushed: [],
+      pulled: [],
+      conflicts: [],
+      overwritten: [],
+    });
+  });
+
+  it('should keep both copies of a conflict until it is resolved', async () => {
+    await sync(desktop);
+    write(laptop, 'RESEARCH.md', '- Uses APA style\n- Laptop');
+    write(desktop, 'RESEARCH.md', '- Uses APA style\n- Desktop');
+    await sync(laptop);
+
+    expect((await sync(desktop)).conflicts).toEqual(['RESEARCH.md']);
+    expect(read(desktop, 'RESEARCH.md')).toBe('- Uses APA style\n- Desktop');
+    expect(read(desktop, 'RESEARCH.md.remote')).toBe(
+      '- Uses APA style\n- Laptop',
+    );
+    expect(await listConflicts(desktop)).toEqual(['RESEARCH.md']);
+    // Still in conflict, and the copy is not part of the sync
+    expect(await sync(desktop)).toMatchObject({
+      conflicts: ['RESEARCH.md'],
+      pushed: [],
+    });
+
+    await resolveConflict('RESEARCH.md', 'local', desktop);
+    expect(fs.existsSync(path.join(desktop, 'RESEARCH.md.remote'))).toBe(
+      false,
+    );
+    expect((await sync(desktop)).pushed).toEqual(['RESEARCH.md']);
+    await sync(laptop);
+    expect(read(laptop, 'RESEARCH.md')).toBe('- Uses APA style\n- Desktop');
+    await expect(
+      resolveConflict('RESEARCH.md', 'remote', desktop),
+    ).rejects.toThrow('RESEARCH.md is not in conflict.');
+  });
+
+  it('should take the newer copy with last-writer-wins', async () => {
+    await sync(desktop);
+    write(laptop, 'RESEARCH.md', 'older', new Date(2025, 0, 1));
+    write(desktop, 'RESEARCH.md', 'newer', new Date(2025, 0, 2));
+    await sync(laptop);
+
+    expect(
+      await sync(desktop, { strategy: 'last-writer-wins' }),
+    ).toMatchObject({ pushed: ['RESEARCH.md'], overwritten: ['RESEARCH.md'] });
+    expect(
+      await sync(laptop, { strategy: 'last-writer-wins' }),
+    ).toMatchObject({ pulled: ['RESEARCH.md'], overwritten: [] });
+    expect(read(laptop, 'RESEARCH.md')).toBe('newer');
+  });
+
+  it('should change nothing on a dry run', async () => {
+    expect(await sync(desktop, { dryRun: true })).toMatchObject({
+      pulled: ['RESEARCH.md', 'tmp/abc/checkpoint-draft.json'],
+    });
+    expect(fs.existsSync(path.join(desktop, 'RESEARCH.md'))).toBe(false);
+  });
+
+  it('should ignore remote paths outside the data directory', async () => {
+    backend.index.files['reviews/../../evil.sh'] = {
+      hash: 'x',
+      modifiedMs: 0,
+      machine: 'evil',
+    };
+
+    expect((await sync(desktop)).pulled).not.toContain(
+      'reviews/../../evil.sh',
+    );
+    expect(fs.existsSync(path.join(tempDir, 'evil.sh'))).toBe(false);
+  });
+});
+
+describe('signS3Request', () => {
+  it('should sign with AWS Signature Version 4', () => {
+    const headers = signS3Request(
+      'GET',
+      new URL('https://s3.example.org/bucket/files/RESEARCH.md'),
+      undefined,
+      { accessKeyId: 'AKID', secretAccessKey: 'secret', sessionToken: 'tok' },
+      'eu-west-1',
+      new Date(Date.UTC(2025, 5, 1, 12, 30, 0)),
+    );
+
+    expect(headers['x-amz-date']).toBe('20250601T123000Z');
+    expect(headers['x-amz-content-sha256']).toBe(
+      'e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855',
+    );
+    expect(headers['authorization']).toMatch(
+      /^AWS4-HMAC-SHA256 Credential=AKID\/20250601\/eu-west-1\/s3\/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date;x-amz-security-token, Signature=[0-9a-f]{64}$/,
+    );
+  });
+});
+
+describe('S3SyncBackend', () => {
+  afterEach(() => {
+    vi.unstubAllGlobals();
+  });
+
+  it('should keep the entries another machine stored in the meantime', async () => {
+    const entry = (machine: string) => ({
+      hash: machine,
+      modifiedMs: 1,
+      machine,
+    });
+    // The bucket's index, changed by the desktop after the laptop read it
+    let stored: RemoteIndex = { files: { 'deadlines.json': entry('old') } };
+    let etag = '"1"';
+    const puts: Array<Record<string, string>> = [];
+    vi.stubGlobal(
+      'fetch',
+      vi.fn(async (_url: URL, init: RequestInit) => {
+        const headers = init.headers as Record<string, string>;
+        if (init.method === 'GET') {
+          return new Response(JSON.stringify(stored), { headers: { etag } });
+        }
+        puts.push(headers);
+        if (headers['If-Match'] !== etag) {
+          return new Response(null, { status: 412 });
+        }
+        stored = JSON.parse(String(init.body));
+        etag = `"${puts.length + 1}"`;
+        return new Response(null, { headers: { etag } });
+      }),
+    );
+    const backend = new S3SyncBackend(
+      { endpoint: 'https://s3.example.org', bucket: 'b' },
+      { accessKeyId: 'AKID', secretAccessKey: 'secret' },
+    );
+
+    const index = await backend.open();
+    stored = { files: { ...stored.files, 'RESEARCH.md': entry('desktop') } };
+    etag = '"desktop"';
+    index.files['deadlines.json'] = entry('laptop');
+    await backend.close(index);
+
+    expect(puts.map((headers) => headers['If-Match'])).toEqual([
+      '"1"',
+      '"desktop"',
+    ]);
+    expect(stored.files).toEqual({
+      'deadlines.json': entry('laptop'),
+      'RESEARCH.md': entry('desktop'),
+    });
+  });
+});
+
+describe('createSyncBackend', () => {
+  it('should explain what is missing', () => {
+    expect(() => createSyncBackend({ backend: 'git' })).toThrow(
+      'Set dataSync.remote',
+    );
+    expect(() =>
+      createSyncBackend(
+        { backend: 's3', s3: { endpoint: 'https://s3', bucket: 'b' } },
+        {},
+      ),
+    ).toThrow('Set AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY');
+  });
+});
@@ -0,0 +1,615 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import * as fs from 'fs/promises';
+import { existsSync } from 'fs';
+import * as os from 'os';
+import * as path from 'path';
+import * as crypto from 'node:crypto';
+import { simpleGit, SimpleGit } from 'simple-git';
+import { isNodeError } from '../utils/errors.js';
+import { getUserResearchDir } from '../utils/paths.js';
+import { getAllResearchMdFilenames } from '../tools/memoryTool.js';
+
+const SYNC_DIR = 'sync';
+const STATE_FILE = 'state.json';
+const INDEX_FILE = 'index.json';
+const FILES_DIR = 'files';
+/** Suffix of the remote copy kept next to a file in conflict. */
+export const CONFLICT_SUFFIX = '.remote';
+
+/** Files of the data directory that are synced, relative to it. */
+const SYNCED_PATTERNS = [
+  /^tmp\/[^/]+\/checkpoint-.+\.json$/,
+  /^deadlines\.json$/,
+  /^calendar\/[^/]+\.ics$/,
+  /^reviews\/.+$/,
+  /^rebuttals\/.+$/,
+];
+
+export type ConflictStrategy = 'last-writer-wins' | 'manual';
+
+/** The `dataSync` setting. */
+export interface DataSyncSettings {
+  backend: 'git' | 's3';
+  /** Remote URL for the git backend, using the user's git credentials. */
+  remote?: string;
+  /** Branch for the git backend. Defaults to main. */
+  branch?: string;
+  /**
+   * Bucket for the s3 backend. Credentials come from AWS_ACCESS_KEY_ID,
+   * AWS_SECRET_ACCESS_KEY and optionally AWS_SESSION_TOKEN.
+   */
+  s3?: {
+    /** e.g. https://s3.eu-west-1.amazonaws.com or a MinIO server. */
+    endpoint: string;
+    bucket: string;
+    region?: string;
+    /** Key prefix inside the bucket. */
+    prefix?: string;
+  };
+  /** What to do with files changed on two machines. Defaults to manual. */
+  conflicts?: ConflictStrategy;
+}
+
+export interface SyncedFileInfo {
+  /** SHA-256 of the content. */
+  hash: string;
+  modifiedMs: number;
+}
+
+export interface RemoteFileInfo extends SyncedFileInfo {
+  /** Host name of the machine that pushed the file. */
+  machine: string;
+}
+
+export interface RemoteIndex {
+  files: Record<string, RemoteFileInfo>;
+}
+
+/** Where synced files are stored; the git and S3 backends. */
+export interface SyncBackend {
+  /** Fetches the remote index; empty when nothing was synced yet. */
+  open(): Promise<RemoteIndex>;
+  read(file: string): Promise<Buffer>;
+  write(file: string, data: Buffer): Promise<void>;
+  /** Stores the index and publishes the changes. */
+  close(index: RemoteIndex, message: string): Promise<void>;
+}
+
+interface SyncState {
+  /** Hash of each file when it was last synced: the common ancestor. */
+  base: Record<string, string>;
+  /** Files changed on both sides, with the remote version. */
+  conflicts: Record<string, RemoteFileInfo>;
+}
+
+export interface DataSyncResult {
+  pushed: string[];
+  pulled: string[];
+  /** Files left for /datasync resolve. */
+  conflicts: string[];
+  /** Conflicts settled by last-writer-wins. */
+  overwritten: string[];
+}
+
+export function getDataDir(): string {
+  return getUserResearchDir();
+}
+
+function sha256(data: Buffer | string): string {
+  return crypto.createHash('sha256').update(data).digest('hex');
+}
+
+function isSynced(file: string): boolean {
+  // Remote indexes are not trusted to stay inside the data directory
+  if (file.endsWith(CONFLICT_SUFFIX) || file.split('/').includes('..')) {
+    return false;
+  }
+  return (
+    getAllResearchMdFilenames().includes(file) ||
+    SYNCED_PATTERNS.some((pattern) => pattern.test(file))
+  );
+}
+
+async function walk(dir: string, prefix = ''): Promise<string[]> {
+  let entries;
+  try {
+    entries = await fs.readdir(path.join(dir, prefix), { withFileTypes: true });
+  } catch (error) {
+    if (isNodeError(error) && error.code === 'ENOENT') {
+      return [];
+    }
+    throw error;
+  }
+  const files: string[] = [];
+  for (const entry of entries) {
+    const file = prefix ? `${prefix}/${entry.name}` : entry.name;
+    if (entry.isDirectory() && file !== SYNC_DIR) {
+      files.push(...(await walk(dir, file)));
+    } else if (entry.isFile()) {
+      files.push(file);
+    }
+  }
+  return files;
+}
+
+/**
+ * Lists the synced files of the data directory: saved chats, memories,
+ * deadlines, calendar events, reviews and rebuttals.
+ */
+export async function listSyncedFiles(
+  dataDir: string = getDataDir(),
+): Promise<Map<string, SyncedFileInfo>> {
+  const files = new Map<string, SyncedFileInfo>();
+  for (const file of await walk(dataDir)) {
+    if (!isSynced(file)) {
+      continue;
+    }
+    const filePath = path.join(dataDir, file);
+    const [content, stats] = await Promise.all([
+      fs.readFile(filePath),
+      fs.stat(filePath),
+    ]);
+    files.set(file, { hash: sha256(content), modifiedMs: stats.mtimeMs });
+  }
+  return files;
+}
+
+async function loadState(dataDir: string): Promise<SyncState> {
+  try {
+    const state = JSON.parse(
+      await fs.readFile(path.join(dataDir, SYNC_DIR, STATE_FILE), 'utf8'),
+    );
+    return { base: state.base ?? {}, conflicts: state.conflicts ?? {} };
+  } catch (error) {
+    if (isNodeError(error) && error.code === 'ENOENT') {
+      return { base: {}, conflicts: {} };
+    }
+    throw error;
+  }
+}
+
+async function saveState(dataDir: string, state: SyncState): Promise<void> {
+  const filePath = path.join(dataDir, SYNC_DIR, STATE_FILE);
+  await fs.mkdir(path.dirname(filePath), { recursive: true });
+  await fs.writeFile(filePath, JSON.stringify(state, null, 2), 'utf8');
+}
+
+async function writeLocal(
+  filePath: string,
+  data: Buffer,
+  modifiedMs: number,
+): Promise<void> {
+  await fs.mkdir(path.dirname(filePath), { recursive: true });
+  await fs.writeFile(filePath, data);
+  const time = new Date(modifiedMs);
+  await fs.utimes(filePath, time, time);
+}
+
+/**
+ * Syncs the data directory with the backend. A file changed on one side
+ * only is copied to the other. A file changed on both sides since the last
+ * sync is a conflict: with last-writer-wins the newer copy wins; with
+ * manual the remote copy is written next to the local one, with the
+ * `.remote` suffix, until it is resolved. Deleted files are not synced.
+ */
+export async function syncData(
+  backend: SyncBackend,
+  options: {
+    strategy?: ConflictStrategy;
+    dataDir?: string;
+    machine?: string;
+    /** Works out what would happen without changing anything. */
+    dryRun?: boolean;
+  } = {},
+): Promise<DataSyncResult> {
+  const dataDir = options.dataDir ?? getDataDir();
+  const machine = options.machine ?? os.hostname();
+  const strategy = options.strategy ?? 'manual';
+  const state = await loadState(dataDir);
+  const local = await listSyncedFiles(dataDir);
+  const index = await backend.open();
+  const result: DataSyncResult = {
+    pushed: [],
+    pulled: [],
+    conflicts: [],
+    overwritten: [],
+  };
+
+  const push = async (file: string, info: SyncedFileInfo) => {
+    result.pushed.push(file);
+    if (!options.dryRun) {
+      await backend.write(file, await fs.readFile(path.join(dataDir, file)));
+      index.files[file] = { ...info, machine };
+      state.base[file] = info.hash;
+    }
+  };
+  const pull = async (file: string, info: RemoteFileInfo) => {
+    result.pulled.push(file);
+    if (!options.dryRun) {
+      const data = await backend.read(file);
+      await writeLocal(path.join(dataDir, file), data, info.modifiedMs);
+      state.base[file] = info.hash;
+    }
+  };
+
+  const files = [...new Set([...local.keys(), ...Object.keys(index.files)])];
+  for (const file of files.filter(isSynced).sort()) {
+    const mine = local.get(file);
+    const theirs = index.files[file];
+    const base = state.base[file];
+    if (mine?.hash === theirs?.hash) {
+      state.base[file] = mine!.hash;
+      delete state.conflicts[file];
+    } else if (mine && (!theirs || theirs.hash === base)) {
+      await push(file, mine);
+    } else if (theirs && (!mine || mine.hash === base)) {
+      await pull(file, theirs);
+    } else if (strategy === 'last-writer-wins') {
+      result.overwritten.push(file);
+      if (mine!.modifiedMs >= theirs!.modifiedMs) {
+        await push(file, mine!);
+      } else {
+        await pull(file, theirs!);
+      }
+    } else {
+      result.conflicts.push(file);
+      if (!options.dryRun && state.conflicts[file]?.hash !== theirs!.hash) {
+        const copy = path.join(dataDir, file + CONFLICT_SUFFIX);
+        await writeLocal(copy, await backend.read(file), theirs!.modifiedMs);
+        state.conflicts[file] = theirs!;
+      }
+    }
+  }
+
+  if (!options.dryRun) {
+    if (result.pushed.length > 0) {
+      await backend.close(
+        index,
+        `Sync ${result.pushed.length} files from ${machine}`,
+      );
+    }
+    await saveState(dataDir, state);
+  }
+  return result;
+}
+
+/** Lists the files waiting for /datasync resolve. */
+export async function listConflicts(
+  dataDir: string = getDataDir(),
+): Promise<string[]> {
+  return Object.keys((await loadState(dataDir)).conflicts).sort();
+}
+
+/**
+ * Settles a conflict: `local` keeps this machine's copy, which the next
+ * sync pushes; `remote` takes the other machine's copy.
+ */
+export async function resolveConflict(
+  file: string,
+  keep: 'local' | 'remote',
+  dataDir: string = getDataDir(),
+): Promise<void> {
+  const state = await loadState(dataDir);
+  const conflict = state.conflicts[file];
+  if (!conflict) {
+    throw new Error(`${file} is not in conflict.`);
+  }
+  const filePath = path.join(dataDir, file);
+  const copy = filePath + CONFLICT_SUFFIX;
+  if (keep === 'remote') {
+    await fs.copyFile(copy, filePath);
+  }
+  await fs.rm(copy, { force: true });
+  state.base[file] = conflict.hash;
+  delete state.conflicts[file];
+  await saveState(dataDir, state);
+}
+
+/** Keeps the synced files in a clone of a git remote, one commit a sync. */
+export class GitSyncBackend implements SyncBackend {
+  constructor(
+    private readonly remote: string,
+    private readonly branch = 'main',
+    private readonly dir = path.join(getDataDir(), SYNC_DIR, 'git'),
+  ) {}
+
+  private async repo(): Promise<SimpleGit> {
+    await fs.mkdir(this.dir, { recursive: true });
+    const git = simpleGit(this.dir);
+    if (!existsSync(path.join(this.dir, '.git'))) {
+      await git.init();
+      await git.addRemote('origin', this.remote);
+      await git.addConfig('user.name', 'Research CLI');
+      await git.addConfig('user.email', `research-cli@${os.hostname()}`);
+    } else {
+      await git.remote(['set-url', 'origin', this.remote]);
+    }
+    return git;
+  }
+
+  async open(): Promise<RemoteIndex> {
+    const git = await this.repo();
+    await git.fetch('origin');
+    await git.raw(['checkout', '-f', '-B', this.branch]);
+    const remoteBranches = await git.branch(['-r']);
+    if (remoteBranches.all.includes(`origin/${this.branch}`)) {
+      await git.reset(['--hard', `origin/${this.branch}`]);
+    }
+    try {
+      return JSON.parse(
+        await fs.readFile(path.join(this.dir, INDEX_FILE), 'utf8'),
+      );
+    } catch (error) {
+      if (isNodeError(error) && error.code === 'ENOENT') {
+        return { files: {} };
+      }
+      throw error;
+    }
+  }
+
+  read(file: string): Promise<Buffer> {
+    return fs.readFile(path.join(this.dir, FILES_DIR, file));
+  }
+
+  async write(file: string, data: Buffer): Promise<void> {
+    const filePath = path.join(this.dir, FILES_DIR, file);
+    await fs.mkdir(path.dirname(filePath), { recursive: true });
+    await fs.writeFile(filePath, data);
+  }
+
+  async close(index: RemoteIndex, message: string): Promise<void> {
+    const git = simpleGit(this.dir);
+    await fs.writeFile(
+      path.join(this.dir, INDEX_FILE),
+      JSON.stringify(index, null, 2),
+      'utf8',
+    );
+    await git.add('-A');
+    await git.commit(message);
+    try {
+      await git.push('origin', `HEAD:${this.branch}`);
+    } catch (error) {
+      throw new Error(
+        `Could not push, probably because another machine synced in the meantime; sync again. ${error}`,
+      );
+    }
+  }
+}
+
+/** RFC 3986 encoding of one segment of an S3 key. */
+function encodeKeySegment(segment: string): string {
+  return encodeURIComponent(segment).replace(
+    /[!'()*]/g,
+    (c) => `%${c.charCodeAt(0).toString(16).toUpperCase()}`,
+  );
+}
+
+function hmac(key: Buffer | string, data: string): Buffer {
+  return crypto.createHmac('sha256', key).update(data).digest();
+}
+
+export interface S3Credentials {
+  accessKeyId: string;
+  secretAccessKey: string;
+  sessionToken?: string;
+}
+
+/**
+ * Signs an S3 request with AWS Signature Version 4, returning the headers
+ * to send.
+ */
+export function signS3Request(
+  method: string,
+  url: URL,
+  body: Buffer | undefined,
+  credentials: S3Credentials,
+  region: string,
+  now: Date = new Date(),
+): Record<string, string> {
+  const amzDate = now.toISOString().replace(/[:-]|\.\d{3}/g, '');
+  const dateStamp = amzDate.slice(0, 8);
+  const payloadHash = sha256(body ?? '');
+  const headers: Record<string, string> = {
+    host: url.host,
+    'x-amz-content-sha256': payloadHash,
+    'x-amz-date': amzDate,
+  };
+  if (credentials.sessionToken) {
+    headers['x-amz-security-token'] = credentials.sessionToken;
+  }
+  const signedHeaders = Object.keys(headers).sort();
+  const canonicalRequest = [
+    method,
+    url.pathname,
+    '',
+    ...signedHeaders.map((name) => `${name}:${headers[name]}`),
+    '',
+    signedHeaders.join(';'),
+    payloadHash,
+  ].join('\n');
+  const scope = `${dateStamp}/${region}/s3/aws4_request`;
+  const stringToSign = [
+    'AWS4-HMAC-SHA256',
+    amzDate,
+    scope,
+    sha256(canonicalRequest),
+  ].join('\n');
+  const signingKey = hmac(
+    hmac(
+      hmac(hmac(`AWS4${credentials.secretAccessKey}`, dateStamp), region),
+      's3',
+    ),
+    'aws4_request',
+  );
+  const signature = hmac(signingKey, stringToSign).toString('hex');
+  headers['authorization'] =
+    `AWS4-HMAC-SHA256 Credential=${credentials.accessKeyId}/${scope}, ` +
+    `SignedHeaders=${signedHeaders.join(';')}, Signature=${signature}`;
+  return headers;
+}
+
+// Attempts at storing the index when other machines keep changing it
+const S3_INDEX_ATTEMPTS = 3;
+
+/**
+ * Keeps the synced files in an S3-compatible bucket, with path-style URLs.
+ * The index is written with a conditional PUT, so that two machines
+ * syncing at once do not drop each other's entries.
+ */
+export class S3SyncBackend implements SyncBackend {
+  /** ETag of the index when it was opened; null when there was none. */
+  private indexEtag: string | null = null;
+  /** The index as opened, to tell this sync's changes from the rest. */
+  private openedIndex: RemoteIndex = { files: {} };
+
+  constructor(
+    private readonly settings: NonNullable<DataSyncSettings['s3']>,
+    private readonly credentials: S3Credentials,
+  ) {}
+
+  private url(key: string): URL {
+    const prefix = (this.settings.prefix ?? '').replace(/^\/+|\/+$/g, '');
+    const fullKey = prefix ? `${prefix}/${key}` : key;
+    const base = this.settings.endpoint.replace(/\/+$/, '');
+    const encoded = [this.settings.bucket, ...fullKey.split('/')]
+      .map(encodeKeySegment)
+      .join('/');
+    return new URL(`${base}/${encoded}`);
+  }
+
+  private async request(
+    method: 'GET' | 'PUT',
+    key: string,
+    body?: Buffer,
+    conditions: Record<string, string> = {},
+  ): Promise<Response> {
+    const url = this.url(key);
+    const headers = signS3Request(
+      method,
+      url,
+      body,
+      this.credentials,
+      this.settings.region ?? 'us-east-1',
+    );
+    delete headers['host'];
+    return fetch(url, { method, headers: { ...headers, ...conditions }, body });
+  }
+
+  private async get(
+    key: string,
+  ): Promise<{ data: Buffer; etag: string | null } | undefined> {
+    const response = await this.request('GET', key);
+    if (response.status === 404) {
+      return undefined;
+    }
+    if (!response.ok) {
+      throw new Error(`S3 GET ${key} failed: ${response.status}`);
+    }
+    return {
+      data: Buffer.from(await response.arrayBuffer()),
+      etag: response.headers.get('etag'),
+    };
+  }
+
+  private async put(key: string, body: Buffer): Promise<void> {
+    const response = await this.request('PUT', key, body);
+    if (!response.ok) {
+      throw new Error(`S3 PUT ${key} failed: ${response.status}`);
+    }
+  }
+
+  private async getIndex(): Promise<RemoteIndex> {
+    const index = await this.get(INDEX_FILE);
+    this.indexEtag = index?.etag ?? null;
+    return index ? JSON.parse(index.data.toString('utf8')) : { files: {} };
+  }
+
+  async open(): Promise<RemoteIndex> {
+    const index = await this.getIndex();
+    this.openedIndex = structuredClone(index);
+    return index;
+  }
+
+  async read(file: string): Promise<Buffer> {
+    const data = await this.get(`${FILES_DIR}/${file}`);
+    if (!data) {
+      throw new Error(`${file} is listed in the index but missing in S3.`);
+    }
+    return data.data;
+  }
+
+  write(file: string, data: Buffer): Promise<void> {
+    return this.put(`${FILES_DIR}/${file}`, data);
+  }
+
+  /**
+   * Stores the index only if nobody changed it since it was read. When
+   * another machine did, this sync's entries are laid over its index and
+   * the write is tried again.
+   */
+  async close(index: RemoteIndex): Promise<void> {
+    const changed = Object.entries(index.files).filter(
+      ([file, info]) =>
+        JSON.stringify(info) !== JSON.stringify(this.openedIndex.files[file]),
+    );
+    let next = index;
+    for (let attempt = 1; attempt <= S3_INDEX_ATTEMPTS; attempt++) {
+      const response = await this.request(
+        'PUT',
+        INDEX_FILE,
+        Buffer.from(JSON.stringify(next, null, 2)),
+        this.indexEtag === null
+          ? { 'If-None-Match': '*' }
+          : { 'If-Match': this.indexEtag },
+      );
+      if (response.ok) {
+        this.indexEtag = response.headers.get('etag');
+        return;
+      }
+      // 412 when the condition failed, 409 when a concurrent write won
+      if (response.status !== 412 && response.status !== 409) {
+        throw new Error(`S3 PUT ${INDEX_FILE} failed: ${response.status}`);
+      }
+      next = await this.getIndex();
+      for (const [file, info] of changed) {
+        next.files[file] = info;
+      }
+    }
+    throw new Error(
+      'Could not store the index, because other machines kept syncing in the meantime; sync again.',
+    );
+  }
+}
+
+export function createSyncBackend(
+  settings: DataSyncSettings,
+  env: NodeJS.ProcessEnv = process.env,
+): SyncBackend {
+  if (settings.backend === 'git') {
+    if (!settings.remote) {
+      throw new Error('Set dataSync.remote to the git remote to sync with.');
+    }
+    return new GitSyncBackend(settings.remote, settings.branch);
+  }
+  if (settings.backend === 's3') {
+    if (!settings.s3?.endpoint || !settings.s3.bucket) {
+      throw new Error('Set dataSync.s3.endpoint and dataSync.s3.bucket.');
+    }
+    if (!env['AWS_ACCESS_KEY_ID'] || !env['AWS_SECRET_ACCESS_KEY']) {
+      throw new Error(
+        'Set AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY to sync with S3.',
+      );
+    }
+    return new S3SyncBackend(settings.s3, {
+      accessKeyId: env['AWS_ACCESS_KEY_ID'],
+      secretAccessKey: env['AWS_SECRET_ACCESS_KEY'],
+      sessionToken: env['AWS_SESSION_TOKEN'],
+    });
+  }
+  throw new Error(`Unknown dataSync backend: ${settings.backend}`);
+}
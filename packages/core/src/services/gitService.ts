@@ -6,12 +6,11 @@
 
 import * as fs from 'fs/promises';
 import * as path from 'path';
-import * as os from 'os';
 import { isNodeError } from '../utils/errors.js';
 import { isGitRepository } from '../utils/gitUtils.js';
 import { exec } from 'node:child_process';
 import { simpleGit, SimpleGit, CheckRepoActions } from 'simple-git';
-import { getProjectHash, RESEARCH_DIR } from '../utils/paths.js';
+import { getProjectHash, getUserResearchDir } from '../utils/paths.js';
 
 export class GitService {
   private projectRoot: string;
@@ -22,7 +21,7 @@ export class GitService {
 
   private getHistoryDir(): string {
     const hash = getProjectHash(this.projectRoot);
-    return path.join(os.homedir(), RESEARCH_DIR, 'history', hash);
+    return path.join(getUserResearchDir(), 'history', hash);
   }
 
   async initialize(): Promise<void> {
@@ -0,0 +1,161 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeAll, afterAll, vi } from 'vitest';
+import http from 'node:http';
+import net from 'node:net';
+import { WebSocketServer } from 'ws';
+import { JupyterKernelSession, extractCodeBlocks } from './jupyterKernel.js';
+
+describe('extractCodeBlocks', () => {
+  it('should return fenced blocks with their language', () => {
+    const markdown = [
+      'Load the data:',
+      '```python',
+      'import pandas as pd',
+      "df = pd.read_csv('x.csv')",
+      '```',
+      'Then plot it:',
+      '~~~',
+      'df.plot()',
+      '~~~',
+    ].join('\n');
+    expect(extractCodeBlocks(markdown)).toEqual([
+      {
+        language: 'python',
+        code: "import pandas as pd\ndf = pd.read_csv('x.csv')\n",
+      },
+      { language: '', code: 'df.plot()\n' },
+    ]);
+  });
+
+  it('should return nothing for plain text', () => {
+    expect(extractCodeBlocks('no code here')).toEqual([]);
+  });
+});
+
+describe('JupyterKernelSession', () => {
+  let server: http.Server;
+  let baseUrl: string;
+  const requests: string[] = [];
+
+  beforeAll(async () => {
+    server = http.createServer((req, res) => {
+      requests.push(`${req.method} ${req.url} ${req.headers.authorization}`);
+      res.setHeader('Content-Type', 'application/json');
+      if (req.method === 'POST' && req.url === '/api/kernels') {
+        res.end(JSON.stringify({ id: 'k1', name: 'python3' }));
+      } else {
+        res.end('{}');
+      }
+    });
+    const wss = new WebSocketServer({ server });
+    wss.on('connection', (socket) => {
+      socket.on('message', (raw) => {
+        const request = JSON.parse(raw.toString());
+        const reply = (msgType: string, content: object) =>
+          socket.send(
+            JSON.stringify({
+              header: { msg_id: `${msgType}-1`, msg_type: msgType },
+              parent_header: request.header,
+              content,
+            }),
+          );
+        reply('status', { execution_state: 'busy' });
+        if (request.content.code === 'hang') {
+          return;
+        }
+        if (request.content.code === 'boom') {
+          reply('error', {
+            ename: 'ValueError',
+            evalue: 'boom',
+            traceback: ['\u001b[31mValueError\u001b[0m: boom'],
+          });
+          reply('execute_reply', { status: 'error', execution_count: 2 });
+        } else {
+          reply('stream', { name: 'stdout', text: 'hello\n' });
+          reply('display_data', {
+            data: {
+              'text/plain': '<Figure>',
+              'image/png': 'iVBORw0K\nGgo=',
+            },
+          });
+          reply('execute_result', { data: { 'text/plain': '42' } });
+          reply('execute_reply', { status: 'ok', execution_count: 1 });
+        }
+        reply('status', { execution_state: 'idle' });
+      });
+    });
+    await new Promise<void>((resolve) =>
+      server.listen(0, '127.0.0.1', () => resolve()),
+    );
+    baseUrl = `http://127.0.0.1:${(server.address() as net.AddressInfo).port}`;
+  });
+
+  afterAll(async () => {
+    await new Promise((resolve) => server.close(resolve));
+  });
+
+  it('should run code and collect stream, result and image outputs', async () => {
+    const kernel = await JupyterKernelSession.connect(
+      `${baseUrl}/`,
+      'secret',
+      'python3',
+    );
+    expect(requests).toContain('POST /api/kernels token secret');
+
+    const result = await kernel.execute('print("hello"); 42');
+    expect(result.status).toBe('ok');
+    expect(result.executionCount).toBe(1);
+    expect(result.outputs).toEqual([
+      { type: 'stream', text: 'hello\n' },
+      {
+        type: 'display',
+        text: '<Figure>',
+        images: [{ mimeType: 'image/png', data: 'iVBORw0KGgo=' }],
+      },
+      { type: 'result', text: '42' },
+    ]);
+
+    kernel.dispose();
+  });
+
+  it('should report errors with ANSI codes removed', async () => {
+    const kernel = await JupyterKernelSession.connect(
+      baseUrl,
+      'secret',
+      'python3',
+    );
+    const result = await kernel.execute('boom');
+    expect(result.status).toBe('error');
+    expect(result.outputs).toEqual([
+      { type: 'error', text: 'ValueError: boom' },
+    ]);
+
+    await kernel.shutdown();
+    expect(requests).toContain('DELETE /api/kernels/k1 token secret');
+  });
+
+  it('should interrupt on abort and fail when the connection closes', async () => {
+    const kernel = await JupyterKernelSession.connect(
+      baseUrl,
+      'secret',
+      'python3',
+    );
+    const controller = new AbortController();
+    const execution = kernel.execute('hang', controller.signal);
+
+    controller.abort();
+    await vi.waitFor(() =>
+      expect(requests).toContain('POST /api/kernels/k1/interrupt token secret'),
+    );
+    kernel.dispose();
+
+    await expect(execution).rejects.toThrow(
+      'The connection to the kernel was closed.',
+    );
+  });
+});
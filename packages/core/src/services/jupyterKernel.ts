@@ -0,0 +1,410 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { ChildProcess, spawn } from 'node:child_process';
+import crypto from 'node:crypto';
+import net from 'node:net';
+import WebSocket from 'ws';
+import stripAnsi from 'strip-ansi';
+import { getErrorMessage } from '../utils/errors.js';
+import { splitCodeBlocks } from '../utils/notebook.js';
+
+const SERVER_START_TIMEOUT_MS = 30_000;
+const PROTOCOL_VERSION = '5.3';
+
+export interface KernelImage {
+  mimeType: string;
+  /** Base64-encoded image data. */
+  data: string;
+}
+
+export interface KernelOutput {
+  type: 'stream' | 'result' | 'display' | 'error';
+  text: string;
+  images?: KernelImage[];
+}
+
+export interface KernelExecutionResult {
+  status: 'ok' | 'error' | 'aborted';
+  executionCount?: number;
+  outputs: KernelOutput[];
+}
+
+export interface CodeBlock {
+  language: string;
+  code: string;
+}
+
+/** Returns the fenced code blocks of a markdown message, in order. */
+export function extractCodeBlocks(markdown: string): CodeBlock[] {
+  return splitCodeBlocks(markdown).flatMap((segment) =>
+    segment.kind === 'code'
+      ? [{ language: segment.language, code: segment.code }]
+      : [],
+  );
+}
+
+interface JupyterMessage {
+  header: { msg_id: string; msg_type: string };
+  parent_header: { msg_id?: string };
+  content: Record<string, unknown>;
+  channel?: string;
+}
+
+type MimeBundle = Record<string, string | string[] | undefined>;
+
+function bundleText(value: string | string[] | undefined): string {
+  return Array.isArray(value) ? value.join('') : (value ?? '');
+}
+
+function toOutput(type: 'result' | 'display', data: MimeBundle): KernelOutput {
+  // SVG is text, not base64, so only raster images are kept.
+  const images = Object.keys(data)
+    .filter(
+      (mimeType) =>
+        mimeType.startsWith('image/') && mimeType !== 'image/svg+xml',
+    )
+    .map((mimeType) => ({
+      mimeType,
+      data: bundleText(data[mimeType]).replace(/\s/g, ''),
+    }));
+  return {
+    type,
+    text: bundleText(data['text/plain']),
+    ...(images.length > 0 && { images }),
+  };
+}
+
+function findFreePort(): Promise<number> {
+  return new Promise((resolve, reject) => {
+    const server = net.createServer();
+    server.unref();
+    server.on('error', reject);
+    server.listen(0, '127.0.0.1', () => {
+      const { port } = server.address() as net.AddressInfo;
+      server.close(() => resolve(port));
+    });
+  });
+}
+
+/**
+ * A Jupyter kernel reached through the Jupyter Server REST and WebSocket
+ * API. Either starts a private `jupyter server` or attaches to a running
+ * one, then runs code with the kernel's state kept between executions.
+ */
+export class JupyterKernelSession {
+  private readonly sessionId = crypto.randomUUID();
+  private socket: WebSocket | undefined;
+  private readonly pending = new Map<
+    string,
+    {
+      onMessage: (message: JupyterMessage) => void;
+      onClose: (error: Error) => void;
+    }
+  >();
+
+  private constructor(
+    readonly baseUrl: string,
+    private readonly token: string,
+    readonly kernelName: string,
+    readonly kernelId: string,
+    private readonly server?: ChildProcess,
+  ) {}
+
+  /**
+   * Launches `jupyter server` on a free local port and starts a kernel.
+   */
+  static async start(
+    kernelName: string,
+    options: { cwd: string; jupyterCommand?: string },
+  ): Promise<JupyterKernelSession> {
+    const token = crypto.randomBytes(24).toString('hex');
+    const port = await findFreePort();
+    const server = spawn(
+      options.jupyterCommand ?? 'jupyter',
+      [
+        'server',
+        '--no-browser',
+        `--port=${port}`,
+        '--port-retries=0',
+        '--ip=127.0.0.1',
+        `--IdentityProvider.token=${token}`,
+        `--ServerApp.root_dir=${options.cwd}`,
+      ],
+      { cwd: options.cwd, stdio: ['ignore', 'ignore', 'pipe'] },
+    );
+    let stderr = '';
+    server.stderr?.on('data', (data: Buffer) => {
+      stderr = (stderr + data.toString()).slice(-2000);
+    });
+    const baseUrl = `http://127.0.0.1:${port}`;
+    try {
+      await JupyterKernelSession.waitForServer(baseUrl, token, server);
+      return await JupyterKernelSession.create(
+        baseUrl,
+        token,
+        kernelName,
+        server,
+      );
+    } catch (error) {
+      server.kill();
+      const detail = stderr.trim().split('\n').slice(-5).join('\n');
+      throw new Error(
+        `Could not start a Jupyter kernel: ${getErrorMessage(error)}${detail ? `\n${detail}` : ''}`,
+      );
+    }
+  }
+
+  /** Starts a kernel on an already running Jupyter server. */
+  static connect(
+    baseUrl: string,
+    token: string,
+    kernelName: string,
+  ): Promise<JupyterKernelSession> {
+    return JupyterKernelSession.create(
+      baseUrl.replace(/\/+$/, ''),
+      token,
+      kernelName,
+    );
+  }
+
+  private static async waitForServer(
+    baseUrl: string,
+    token: string,
+    server: ChildProcess,
+  ): Promise<void> {
+    const deadline = Date.now() + SERVER_START_TIMEOUT_MS;
+    let exited = false;
+    server.once('exit', () => (exited = true));
+    server.once('error', () => (exited = true));
+    while (Date.now() < deadline) {
+      if (exited) {
+        throw new Error('jupyter server exited (is Jupyter installed?)');
+      }
+      try {
+        const response = await fetch(`${baseUrl}/api/status`, {
+          headers: { Authorization: `token ${token}` },
+        });
+        if (response.ok) {
+          return;
+        }
+      } catch {
+        // not listening yet
+      }
+      await new Promise((resolve) => setTimeout(resolve, 250));
+    }
+    throw new Error('timed out waiting for jupyter server');
+  }
+
+  private static async create(
+    baseUrl: string,
+    token: string,
+    kernelName: string,
+    server?: ChildProcess,
+  ): Promise<JupyterKernelSession> {
+    const response = await fetch(`${baseUrl}/api/kernels`, {
+      method: 'POST',
+      headers: {
+        Authorization: `token ${token}`,
+        'Content-Type': 'application/json',
+      },
+      body: JSON.stringify({ name: kernelName }),
+    });
+    if (!response.ok) {
+      throw new Error(
+        `Failed to start kernel "${kernelName}": ${response.status} ${await response.text()}`,
+      );
+    }
+    const { id } = (await response.json()) as { id: string };
+    const session = new JupyterKernelSession(
+      baseUrl,
+      token,
+      kernelName,
+      id,
+      server,
+    );
+    await session.openSocket();
+    return session;
+  }
+
+  private openSocket(): Promise<void> {
+    const url = `${this.baseUrl.replace(/^http/, 'ws')}/api/kernels/${this.kernelId}/channels?session_id=${this.sessionId}`;
+    const socket = new WebSocket(url, {
+      headers: { Authorization: `token ${this.token}` },
+    });
+    this.socket = socket;
+    socket.on('message', (raw) => {
+      let message: JupyterMessage;
+      try {
+        message = JSON.parse(raw.toString()) as JupyterMessage;
+      } catch {
+        return;
+      }
+      const parentId = message.parent_header?.msg_id;
+      if (parentId) {
+        this.pending.get(parentId)?.onMessage(message);
+      }
+    });
+    // An execution waiting for the kernel would otherwise never settle
+    socket.on('close', () => {
+      if (this.socket === socket) {
+        this.socket = undefined;
+      }
+      const error = new Error('The connection to the kernel was closed.');
+      for (const { onClose } of this.pending.values()) {
+        onClose(error);
+      }
+      this.pending.clear();
+    });
+    return new Promise((resolve, reject) => {
+      socket.once('open', () => resolve());
+      socket.once('error', reject);
+    });
+  }
+
+  /**
+   * Runs code in the kernel and collects its outputs. Resolves once the
+   * kernel is idle again; `signal` interrupts the kernel. Rejects when the
+   * connection to the kernel closes first.
+   */
+  execute(code: string, signal?: AbortSignal): Promise<KernelExecutionResult> {
+    const socket = this.socket;
+    if (!socket || socket.readyState !== WebSocket.OPEN) {
+      return Promise.reject(new Error('The kernel is not connected.'));
+    }
+    if (signal?.aborted) {
+      return Promise.reject(new Error('The execution was cancelled.'));
+    }
+    const msgId = crypto.randomUUID();
+    const outputs: KernelOutput[] = [];
+    let status: KernelExecutionResult['status'] | undefined;
+    let executionCount: number | undefined;
+    let idle = false;
+
+    return new Promise((resolve, reject) => {
+      const finish = () => {
+        if (status && idle) {
+          this.pending.delete(msgId);
+          signal?.removeEventListener('abort', onAbort);
+          resolve({ status, executionCount, outputs });
+        }
+      };
+      const onAbort = () => {
+        this.interrupt().catch(() => {
+          // The server is gone; the socket closing settles the execution
+        });
+      };
+      signal?.addEventListener('abort', onAbort);
+
+      const onClose = (error: Error) => {
+        signal?.removeEventListener('abort', onAbort);
+        reject(error);
+      };
+      const onMessage = (message: JupyterMessage) => {
+        const content = message.content;
+        switch (message.header.msg_type) {
+          case 'stream':
+            outputs.push({ type: 'stream', text: String(content.text ?? '') });
+            break;
+          case 'execute_result':
+            outputs.push(toOutput('result', content.data as MimeBundle));
+            break;
+          case 'display_data':
+            outputs.push(toOutput('display', content.data as MimeBundle));
+            break;
+          case 'error':
+            outputs.push({
+              type: 'error',
+              text: stripAnsi(
+                ((content.traceback as string[]) ?? []).join('\n') ||
+                  `${content.ename}: ${content.evalue}`,
+              ),
+            });
+            break;
+          case 'execute_reply':
+            status = content.status as KernelExecutionResult['status'];
+            executionCount = content.execution_count as number | undefined;
+            break;
+          case 'status':
+            idle = content.execution_state === 'idle';
+            break;
+          default:
+            break;
+        }
+        finish();
+      };
+      this.pending.set(msgId, { onMessage, onClose });
+
+      socket.send(
+        JSON.stringify({
+          header: {
+            msg_id: msgId,
+            msg_type: 'execute_request',
+            username: 'research',
+            session: this.sessionId,
+            date: new Date().toISOString(),
+            version: PROTOCOL_VERSION,
+          },
+          parent_header: {},
+          metadata: {},
+          content: {
+            code,
+            silent: false,
+            store_history: true,
+            user_expressions: {},
+            allow_stdin: false,
+            stop_on_error: true,
+          },
+          channel: 'shell',
+          buffers: [],
+        }),
+      );
+    });
+  }
+
+  async interrupt(): Promise<void> {
+    await fetch(`${this.baseUrl}/api/kernels/${this.kernelId}/interrupt`, {
+      method: 'POST',
+      headers: { Authorization: `token ${this.token}` },
+    });
+  }
+
+  /** Shuts the kernel down, and the server if this session started it. */
+  async shutdown(): Promise<void> {
+    this.socket?.close();
+    this.socket = undefined;
+    try {
+      await fetch(`${this.baseUrl}/api/kernels/${this.kernelId}`, {
+        method: 'DELETE',
+        headers: { Authorization: `token ${this.token}` },
+      });
+    } finally {
+      this.server?.kill();
+    }
+  }
+
+  /**
+   * Stops a server this session started without waiting for the kernel to
+   * shut down cleanly. Meant for exit handlers.
+   */
+  dispose(): void {
+    this.socket?.close();
+    this.socket = undefined;
+    this.server?.kill();
+  }
+}
+
+let activeKernel: JupyterKernelSession | undefined;
+
+export function getActiveKernel(): JupyterKernelSession | undefined {
+  return activeKernel;
+}
+
+export function setActiveKernel(
+  kernel: JupyterKernelSession | undefined,
+): void {
+  activeKernel = kernel;
+}
@@ -0,0 +1,260 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import * as fs from 'fs/promises';
+import * as os from 'os';
+import * as path from 'path';
+import * as crypto from 'node:crypto';
+import { simpleGit, SimpleGit } from 'simple-git';
+import { isNodeError } from '../utils/errors.js';
+import { RESEARCH_DIR } from '../utils/paths.js';
+
+/** Token for Overleaf's git bridge, sent as the password of user "git". */
+export const OVERLEAF_TOKEN_ENV = 'OVERLEAF_TOKEN';
+const OVERLEAF_GIT_HOST = 'git.overleaf.com';
+const SETTINGS_FILE = 'manuscript-sync.json';
+
+export interface ManuscriptSyncSettings {
+  /** The clone, relative to the project root. */
+  dir: string;
+  /** The remote URL, without credentials. */
+  remote: string;
+  branch: string;
+}
+
+export interface ManuscriptSyncStatus {
+  branch: string;
+  ahead: number;
+  behind: number;
+  /** Files changed in the clone since the last commit. */
+  changed: string[];
+}
+
+/**
+ * The `-c` settings that let git answer git.overleaf.com's password prompt
+ * with the Overleaf token. The helper reads the token from the environment
+ * when git asks, so it is never on a command line or in the clone's
+ * config. Other remotes use the user's git credentials.
+ */
+export function gitCredentialConfig(
+  url: string,
+  env: NodeJS.ProcessEnv = process.env,
+): string[] {
+  let parsed: URL;
+  try {
+    parsed = new URL(url);
+  } catch {
+    // scp-style (git@host:path) and local remotes
+    return [];
+  }
+  if (!env[OVERLEAF_TOKEN_ENV] || parsed.hostname !== OVERLEAF_GIT_HOST) {
+    return [];
+  }
+  const scope = `credential.https://${OVERLEAF_GIT_HOST}`;
+  return [
+    `${scope}.username=git`,
+    `${scope}.helper=!f() { test "$1" = get && echo "password=$${OVERLEAF_TOKEN_ENV}"; }; f`,
+  ];
+}
+
+/** Removes credentials from git output and errors before showing them. */
+export function redactGitCredentials(text: string): string {
+  return text.replace(/(\w+:\/\/)[^/@\s]+@/g, '$1');
+}
+
+/** Identifies a reviewed diff, so that exactly that diff is pushed. */
+export function hashDiff(diff: string): string {
+  return crypto.createHash('sha256').update(diff).digest('hex').slice(0, 16);
+}
+
+function getSettingsPath(projectRoot: string): string {
+  return path.join(projectRoot, RESEARCH_DIR, SETTINGS_FILE);
+}
+
+export async function loadSyncSettings(
+  projectRoot: string,
+): Promise<ManuscriptSyncSettings | undefined> {
+  try {
+    return JSON.parse(
+      await fs.readFile(getSettingsPath(projectRoot), 'utf-8'),
+    ) as ManuscriptSyncSettings;
+  } catch (error) {
+    if (isNodeError(error) && error.code === 'ENOENT') {
+      return undefined;
+    }
+    throw error;
+  }
+}
+
+export async function saveSyncSettings(
+  projectRoot: string,
+  settings: ManuscriptSyncSettings,
+): Promise<void> {
+  const settingsPath = getSettingsPath(projectRoot);
+  await fs.mkdir(path.dirname(settingsPath), { recursive: true });
+  await fs.writeFile(settingsPath, JSON.stringify(settings, null, 2));
+}
+
+/**
+ * A local clone of the manuscript's remote repository, such as an
+ * Overleaf project through its git bridge.
+ */
+export class ManuscriptSync {
+  private readonly git: SimpleGit;
+
+  constructor(
+    private readonly projectRoot: string,
+    readonly settings: ManuscriptSyncSettings,
+  ) {
+    this.git = simpleGit({
+      baseDir: this.dir,
+      config: gitCredentialConfig(settings.remote),
+    });
+  }
+
+  get dir(): string {
+    return path.resolve(this.projectRoot, this.settings.dir);
+  }
+
+  /** The directory name git would pick for a clone of `url`. */
+  static defaultDir(url: string): string {
+    return (
+      url
+        .replace(/\/+$/, '')
+        .split(/[/:]/)
+        .pop()
+        ?.replace(/\.git$/, '') || 'manuscript'
+    );
+  }
+
+  /** Clones `url` into `dir` and records it as the project's manuscript. */
+  static async clone(
+    projectRoot: string,
+    url: string,
+    dir: string = ManuscriptSync.defaultDir(url),
+  ): Promise<ManuscriptSync> {
+    const target = path.resolve(projectRoot, dir);
+    try {
+      await simpleGit({
+        baseDir: projectRoot,
+        config: gitCredentialConfig(url),
+      }).clone(url, target);
+    } catch (error) {
+      throw new Error(redactGitCredentials(String(error)));
+    }
+    const git = simpleGit(target);
+    const branch = (await git.revparse(['--abbrev-ref', 'HEAD'])).trim();
+    const settings = {
+      dir: path.relative(projectRoot, target),
+      remote: url,
+      branch,
+    };
+    await saveSyncSettings(projectRoot, settings);
+    return new ManuscriptSync(projectRoot, settings);
+  }
+
+  private async run<T>(operation: () => Promise<T>): Promise<T> {
+    try {
+      return await operation();
+    } catch (error) {
+      throw new Error(redactGitCredentials(String(error)));
+    }
+  }
+
+  async status(): Promise<ManuscriptSyncStatus> {
+    await this.run(() =>
+      this.git.fetch(this.settings.remote, this.settings.branch),
+    );
+    const status = await this.git.status();
+    const count = async (range: string) =>
+      Number((await this.git.raw(['rev-list', '--count', range])).trim());
+    return {
+      branch: this.settings.branch,
+      ahead: await count('FETCH_HEAD..HEAD'),
+      behind: await count('HEAD..FETCH_HEAD'),
+      changed: status.files.map((file) => file.path),
+    };
+  }
+
+  /**
+   * Pulls the remote branch, merging it with local commits. Returns the
+   * files the pull changed.
+   */
+  async pull(): Promise<string[]> {
+    const before = (await this.git.revparse(['HEAD'])).trim();
+    await this.run(() =>
+      this.git.pull(this.settings.remote, this.settings.branch, {
+        '--no-rebase': null,
+      }),
+    );
+    const changed = await this.git.raw([
+      'diff',
+      '--name-only',
+      before,
+      'HEAD',
+    ]);
+    return changed.split('\n').filter(Boolean);
+  }
+
+  /**
+   * The diff of every change in the clone, new files included, as
+   * /sync push would commit it. The changes are staged in a scratch copy
+   * of the index, so the clone's own index is left as it is.
+   */
+  async diff(): Promise<string> {
+    const index = path.resolve(
+      this.dir,
+      (await this.git.revparse(['--git-path', 'index'])).trim(),
+    );
+    const scratchDir = await fs.mkdtemp(
+      path.join(os.tmpdir(), 'manuscript-diff-'),
+    );
+    const scratch = path.join(scratchDir, 'index');
+    try {
+      await fs.copyFile(index, scratch).catch((error) => {
+        // A clone of an empty repository has no index yet
+        if (!isNodeError(error) || error.code !== 'ENOENT') {
+          throw error;
+        }
+      });
+      const git = simpleGit(this.dir).env({
+        ...process.env,
+        GIT_INDEX_FILE: scratch,
+      });
+      await git.add(['--all']);
+      return await git.diff(['--cached']);
+    } finally {
+      await fs.rm(scratchDir, { recursive: true, force: true });
+    }
+  }
+
+  /**
+   * Commits the staged changes and pushes them, provided they are still
+   * the diff with hash `approvedHash`. Returns the commit hash.
+   */
+  async commitAndPush(message: string, approvedHash: string): Promise<string> {
+    await this.git.add(['--all']);
+    const diff = await this.git.diff(['--cached']);
+    if (!diff) {
+      throw new Error('There are no changes to push.');
+    }
+    if (hashDiff(diff) !== approvedHash) {
+      await this.git.reset(['--quiet']);
+      throw new Error(
+        'The changes differ from the reviewed diff. Review them again before pushing.',
+      );
+    }
+    const result = await this.git.commit(message);
+    try {
+      await this.git.push(this.settings.remote, `HEAD:${this.settings.branch}`);
+    } catch (error) {
+      throw new Error(
+        `Committed ${result.commit} locally but could not push it; pull and push again with git. ${redactGitCredentials(String(error))}`,
+      );
+    }
+    return result.commit;
+  }
+}
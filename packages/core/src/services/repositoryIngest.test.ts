@@ -0,0 +1,143 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import * as fs from 'fs';
+import * as os from 'os';
+import * as path from 'path';
+import { simpleGit } from 'simple-git';
+import {
+  buildRepositoryContext,
+  cloneRepository,
+  getRepositoryCacheDir,
+  parseRepositoryUrl,
+  scanRepository,
+  selectKeyFiles,
+} from './repositoryIngest.js';
+
+describe('parseRepositoryUrl', () => {
+  it('should recognize git URLs and leave paths alone', () => {
+    expect(parseRepositoryUrl('github.com/karpathy/nanoGPT/')).toEqual({
+      url: 'https://github.com/karpathy/nanoGPT',
+      name: 'karpathy/nanoGPT',
+    });
+    expect(
+      parseRepositoryUrl('https://github.com/huggingface/peft/tree/main/src'),
+    ).toEqual({
+      url: 'https://github.com/huggingface/peft',
+      name: 'huggingface/peft',
+    });
+    expect(parseRepositoryUrl('git@github.com:me/lab.git')).toEqual({
+      url: 'git@github.com:me/lab.git',
+      name: 'me/lab',
+    });
+    expect(parseRepositoryUrl('../experiments')).toBeUndefined();
+    expect(parseRepositoryUrl('owner/repo')).toBeUndefined();
+  });
+});
+
+describe('repository scanning', () => {
+  let tempDir: string;
+
+  const write = (file: string, content: string) => {
+    fs.mkdirSync(path.dirname(path.join(tempDir, file)), { recursive: true });
+    fs.writeFileSync(path.join(tempDir, file), content);
+  };
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'repository-ingest-'));
+    write('README.md', '# Lab\n\nTrains small transformers.');
+    write('pyproject.toml', '[project]\nname = "lab"');
+    write('src/lab/train.py', 'def main():\n    pass\n');
+    write('src/lab/model.py', 'class Model: ...\n');
+    write('configs/base.yaml', 'lr: 0.001\n');
+    write('node_modules/dep/index.js', '');
+    write('.venv/lib/site.py', '');
+  });
+
+  afterEach(() => {
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should list files without dependency and hidden folders', async () => {
+    const snapshot = await scanRepository(tempDir);
+    expect(snapshot.files.map((f) => f.path)).toEqual([
+      'configs/base.yaml',
+      'pyproject.toml',
+      'README.md',
+      'src/lab/model.py',
+      'src/lab/train.py',
+    ]);
+    expect(snapshot).toMatchObject({
+      name: path.basename(tempDir),
+      commit: undefined,
+      truncated: false,
+    });
+    expect(selectKeyFiles(snapshot.files).map((f) => f.path)).toEqual([
+      'README.md',
+      'pyproject.toml',
+      'src/lab/train.py',
+      'configs/base.yaml',
+    ]);
+  });
+
+  it('should fit the key files into the token budget', async () => {
+    write('README.md', `# Lab\n\n${'Long documentation. '.repeat(2000)}`);
+    const snapshot = await scanRepository(tempDir, 'me/lab');
+    const { text, includedFiles } = await buildRepositoryContext(
+      snapshot,
+      2000,
+    );
+    expect(text).toContain(`Repository me/lab, checked out at ${tempDir}.`);
+    expect(text).toContain(
+      '5 files; languages: py (2), yaml (1), toml (1), md (1).',
+    );
+    expect(text).toContain('File map:');
+    expect(text).toMatch(/--- README\.md ---\n# Lab[\s\S]*more characters\]/);
+    expect(includedFiles[0]).toBe('README.md');
+    expect(text.length).toBeLessThanOrEqual(2000 * 4);
+  });
+});
+
+describe('cloneRepository', () => {
+  let tempDir: string;
+
+  beforeEach(async () => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'repository-clone-'));
+    vi.stubEnv('HOME', tempDir);
+    vi.stubEnv('GIT_AUTHOR_NAME', 'Test');
+    vi.stubEnv('GIT_AUTHOR_EMAIL', 'test@example.com');
+    vi.stubEnv('GIT_COMMITTER_NAME', 'Test');
+    vi.stubEnv('GIT_COMMITTER_EMAIL', 'test@example.com');
+  });
+
+  afterEach(() => {
+    vi.unstubAllEnvs();
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should clone once into the cache and record the commit', async () => {
+    const origin = path.join(tempDir, 'origin');
+    fs.mkdirSync(origin);
+    fs.writeFileSync(path.join(origin, 'README.md'), '# Origin');
+    const git = simpleGit(origin);
+    await git.init();
+    await git.add('.');
+    await git.commit('Initial commit');
+
+    const repository = { url: `file://${origin}`, name: 'me/origin' };
+    const dir = await cloneRepository(repository);
+    expect(dir).toBe(getRepositoryCacheDir('me/origin'));
+    expect(dir).toBe(path.join(tempDir, '.research', 'repos', 'me__origin'));
+
+    fs.writeFileSync(path.join(dir, 'local.txt'), 'kept');
+    expect(await cloneRepository(repository)).toBe(dir);
+    expect(fs.existsSync(path.join(dir, 'local.txt'))).toBe(true);
+
+    const snapshot = await scanRepository(dir, 'me/origin');
+    expect(snapshot.commit).toMatch(/^[0-9a-f]{7,}$/);
+  });
+});
@@ -0,0 +1,276 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import * as fs from 'fs/promises';
+import { Dirent } from 'fs';
+import * as os from 'os';
+import * as path from 'path';
+import { simpleGit } from 'simple-git';
+import { FileDiscoveryService } from './fileDiscoveryService.js';
+import { getFolderStructure } from '../utils/getFolderStructure.js';
+import { isNodeError } from '../utils/errors.js';
+import { RESEARCH_DIR } from '../utils/paths.js';
+
+const REPOSITORIES_DIR = 'repos';
+const IGNORED_DIRS = new Set(['.git', 'node_modules', '__pycache__', 'dist']);
+// Stop scanning huge monorepos; the file map is truncated anyway.
+const MAX_FILES = 20000;
+// A rough estimate, as elsewhere: one token is about four characters.
+const CHARS_PER_TOKEN = 4;
+export const DEFAULT_REPOSITORY_TOKEN_BUDGET = 20000;
+
+/**
+ * Files that explain a codebase, most telling first: documentation,
+ * manifests, then entry points and configuration.
+ */
+const KEY_FILE_PATTERNS: RegExp[] = [
+  /^readme(\.(md|rst|txt))?$/i,
+  /^(package\.json|pyproject\.toml|setup\.py|setup\.cfg|Cargo\.toml|go\.mod|pom\.xml|build\.gradle|CMakeLists\.txt|DESCRIPTION)$/,
+  /^(requirements[\w-]*\.txt|environment\.ya?ml|Pipfile)$/,
+  /(^|\/)(main|__main__|train|run|app|cli|index)\.(py|ts|js|go|rs|jl|R)$/,
+  /^(Makefile|Dockerfile|docker-compose\.ya?ml)$/,
+  /(^|\/)configs?\/[^/]+\.(ya?ml|json|toml)$/,
+  /^(docs\/)?(usage|getting[-_]started|architecture)\.md$/i,
+];
+
+export interface RepositoryFile {
+  /** Relative to the repository root, with forward slashes. */
+  path: string;
+  size: number;
+}
+
+export interface RepositorySnapshot {
+  /** "owner/name" for clones, the directory name for local paths. */
+  name: string;
+  root: string;
+  url?: string;
+  commit?: string;
+  files: RepositoryFile[];
+  /** True when the scan stopped at the file limit. */
+  truncated: boolean;
+}
+
+export interface RemoteRepository {
+  url: string;
+  /** "owner/name", e.g. "huggingface/transformers". */
+  name: string;
+}
+
+/**
+ * Recognizes a git URL (https, ssh or a bare "github.com/owner/name").
+ * Returns undefined for anything else, which is then a local path.
+ */
+export function parseRepositoryUrl(
+  input: string,
+): RemoteRepository | undefined {
+  const trimmed = input.trim().replace(/\/+$/, '');
+  const match =
+    trimmed.match(
+      /^(?:https?:\/\/)?((?:github\.com|gitlab\.com|bitbucket\.org)\/([\w.-]+\/[\w.-]+?))(?:\.git)?(?:\/(?:tree|blob)\/.*)?$/,
+    ) ??
+    trimmed.match(/^git@([\w.-]+):([\w.-]+\/[\w.-]+?)(?:\.git)?$/) ??
+    trimmed.match(/^https?:\/\/[^/]+\/(?:.*\/)?(([\w.-]+\/[\w.-]+?)\.git)$/);
+  if (!match) {
+    return undefined;
+  }
+  const name = match[2];
+  if (trimmed.startsWith('git@') || /^https?:\/\//.test(trimmed)) {
+    // Links to a file or branch page clone the repository itself
+    return {
+      url: trimmed.replace(/\/(?:tree|blob)\/.*$/, ''),
+      name,
+    };
+  }
+  return { url: `https://${match[1]}`, name };
+}
+
+/** Where clones are kept: ~/.research/repos/<owner>__<name>. */
+export function getRepositoryCacheDir(name: string): string {
+  return path.join(
+    os.homedir(),
+    RESEARCH_DIR,
+    REPOSITORIES_DIR,
+    name.replace(/\//g, '__'),
+  );
+}
+
+/**
+ * Makes a shallow clone of a repository, or reuses the one from an
+ * earlier session unless `refresh` is set. Returns the clone's path.
+ */
+export async function cloneRepository(
+  repository: RemoteRepository,
+  refresh = false,
+): Promise<string> {
+  const dir = getRepositoryCacheDir(repository.name);
+  const exists = await fs
+    .stat(path.join(dir, '.git'))
+    .then(() => true)
+    .catch(() => false);
+  if (exists && !refresh) {
+    return dir;
+  }
+  await fs.rm(dir, { recursive: true, force: true });
+  await fs.mkdir(path.dirname(dir), { recursive: true });
+  await simpleGit().clone(repository.url, dir, ['--depth', '1']);
+  return dir;
+}
+
+/**
+ * Lists the files of a repository, skipping git-ignored files, hidden
+ * directories and dependency folders.
+ */
+export async function scanRepository(
+  root: string,
+  name: string = path.basename(root),
+  url?: string,
+): Promise<RepositorySnapshot> {
+  const resolvedRoot = path.resolve(root);
+  const fileService = new FileDiscoveryService(resolvedRoot);
+  const files: RepositoryFile[] = [];
+  let truncated = false;
+
+  const walk = async (dir: string): Promise<void> => {
+    let entries: Dirent[];
+    try {
+      entries = await fs.readdir(dir, { withFileTypes: true });
+    } catch (error) {
+      if (isNodeError(error) && error.code === 'EACCES') {
+        return;
+      }
+      throw error;
+    }
+    entries.sort((a, b) => a.name.localeCompare(b.name));
+    for (const entry of entries) {
+      if (files.length >= MAX_FILES) {
+        truncated = true;
+        return;
+      }
+      const fullPath = path.join(dir, entry.name);
+      if (
+        IGNORED_DIRS.has(entry.name) ||
+        (entry.isDirectory() && entry.name.startsWith('.')) ||
+        fileService.shouldGitIgnoreFile(fullPath)
+      ) {
+        continue;
+      }
+      if (entry.isDirectory()) {
+        await walk(fullPath);
+      } else if (entry.isFile()) {
+        const { size } = await fs.stat(fullPath);
+        files.push({
+          path: path
+            .relative(resolvedRoot, fullPath)
+            .split(path.sep)
+            .join('/'),
+          size,
+        });
+      }
+    }
+  };
+  await walk(resolvedRoot);
+
+  let commit: string | undefined;
+  try {
+    const git = simpleGit(resolvedRoot);
+    if (await git.checkIsRepo()) {
+      commit = (await git.revparse(['--short', 'HEAD'])).trim();
+    }
+  } catch {
+    // Not a git repository, or one without commits
+  }
+  return { name, root: resolvedRoot, url, commit, files, truncated };
+}
+
+/** The files worth reading first, in order of how much they explain. */
+export function selectKeyFiles(files: RepositoryFile[]): RepositoryFile[] {
+  const ranked: Array<{ file: RepositoryFile; rank: number }> = [];
+  for (const file of files) {
+    const rank = KEY_FILE_PATTERNS.findIndex((pattern) =>
+      pattern.test(file.path),
+    );
+    if (rank !== -1) {
+      ranked.push({ file, rank });
+    }
+  }
+  return ranked
+    .sort(
+      (a, b) =>
+        a.rank - b.rank ||
+        a.file.path.split('/').length - b.file.path.split('/').length ||
+        a.file.path.localeCompare(b.file.path),
+    )
+    .map(({ file }) => file);
+}
+
+/** The most common file extensions, e.g. "py (120), md (14)". */
+function summarizeLanguages(files: RepositoryFile[]): string {
+  const counts = new Map<string, number>();
+  for (const file of files) {
+    const ext = path.extname(file.path).slice(1).toLowerCase();
+    if (ext) {
+      counts.set(ext, (counts.get(ext) ?? 0) + 1);
+    }
+  }
+  return [...counts.entries()]
+    .sort((a, b) => b[1] - a[1])
+    .slice(0, 6)
+    .map(([ext, count]) => `${ext} (${count})`)
+    .join(', ');
+}
+
+/**
+ * Describes a repository for the model within a token budget: an overview,
+ * the file map, and the key files (README, manifests, entry points),
+ * shortened to fit.
+ */
+export async function buildRepositoryContext(
+  snapshot: RepositorySnapshot,
+  tokenBudget: number = DEFAULT_REPOSITORY_TOKEN_BUDGET,
+): Promise<{ text: string; includedFiles: string[] }> {
+  const budget = tokenBudget * CHARS_PER_TOKEN;
+  const header = [
+    `Repository ${snapshot.name}${snapshot.url ? ` (${snapshot.url})` : ''}${snapshot.commit ? ` at ${snapshot.commit}` : ''}, checked out at ${snapshot.root}.`,
+    `${snapshot.files.length}${snapshot.truncated ? '+' : ''} files; languages: ${summarizeLanguages(snapshot.files) || 'unknown'}.`,
+  ].join('\n');
+  // Up to a quarter of the budget, at about 30 characters an entry
+  const fileMap = await getFolderStructure(snapshot.root, {
+    maxItems: Math.max(50, Math.floor(budget / 4 / 30)),
+    fileService: new FileDiscoveryService(snapshot.root),
+  });
+
+  const sections = [header, `File map:\n${fileMap}`];
+  let remaining = budget - sections.join('\n\n').length;
+  const includedFiles: string[] = [];
+  for (const file of selectKeyFiles(snapshot.files)) {
+    if (remaining < 500) {
+      break;
+    }
+    let content: string;
+    try {
+      content = await fs.readFile(
+        path.join(snapshot.root, file.path),
+        'utf8',
+      );
+    } catch {
+      continue;
+    }
+    if (content.includes('\0') || !content.trim()) {
+      continue;
+    }
+    // No single file takes more than a third of what is left
+    const limit = Math.floor(remaining / 3);
+    const section = `--- ${file.path} ---\n${
+      content.length > limit
+        ? `${content.slice(0, limit)}\n[... ${content.length - limit} more characters]`
+        : content
+    }`;
+    sections.push(section);
+    includedFiles.push(file.path);
+    remaining -= section.length;
+  }
+  return { text: sections.join('\n\n'), includedFiles };
+}
@@ -0,0 +1,136 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import WebSocket from 'ws';
+import {
+  SharedSessionClient,
+  SharedSessionEvent,
+  SharedSessionHub,
+  NAME_TAKEN_CLOSE_CODE,
+  UNAUTHORIZED_CLOSE_CODE,
+} from './sharedSession.js';
+
+describe('SharedSessionHub', () => {
+  let hub: SharedSessionHub;
+  let url: string;
+  const clients: SharedSessionClient[] = [];
+
+  const join = async (name: string, token = hub.token) => {
+    const client = await SharedSessionClient.connect(url, token, name);
+    clients.push(client);
+    const events: SharedSessionEvent[] = [];
+    client.on('event', (event: SharedSessionEvent) => events.push(event));
+    return { client, events };
+  };
+
+  beforeEach(async () => {
+    hub = new SharedSessionHub('student', undefined, ['Research']);
+    url = `ws://127.0.0.1:${await hub.start()}/`;
+  });
+
+  afterEach(async () => {
+    clients.splice(0).forEach((client) => client.close());
+    await hub.stop();
+  });
+
+  it('should share messages with their authors', async () => {
+    const advisor = await join('advisor');
+    await vi.waitFor(() =>
+      expect(hub.participants).toEqual(['student', 'advisor']),
+    );
+
+    hub.post('student', 'Can you check the related work?');
+    advisor.client.say('Add the 2021 survey.');
+
+    await vi.waitFor(() =>
+      expect(
+        advisor.events
+          .filter((e) => e.type === 'message')
+          .map((e) => e.type === 'message' && `${e.author}: ${e.text}`),
+      ).toEqual([
+        'student: Can you check the related work?',
+        'advisor: Add the 2021 survey.',
+      ]),
+    );
+  });
+
+  it('should take the first answer to an approval from anyone', async () => {
+    const advisor = await join('advisor');
+    const decision = hub.requestApproval('Shell: latexmk -pdf main.tex');
+
+    await vi.waitFor(() =>
+      expect(advisor.events).toContainEqual(
+        expect.objectContaining({
+          type: 'approval-request',
+          description: 'Shell: latexmk -pdf main.tex',
+        }),
+      ),
+    );
+    const [id] = hub.pendingApprovals;
+    advisor.client.decide(id, true);
+
+    expect(await decision).toEqual({ approved: true, by: 'advisor' });
+    expect(hub.decide(id, false)).toBe(false);
+    expect(hub.pendingApprovals).toEqual([]);
+  });
+
+  it('should show pending approvals to those who join later', async () => {
+    hub.requestApproval('Write file: draft.tex');
+
+    const advisor = await join('advisor');
+
+    await vi.waitFor(() =>
+      expect(advisor.events[0]).toMatchObject({
+        type: 'approval-request',
+        author: 'student',
+      }),
+    );
+  });
+
+  it('should turn away participants without the token', async () => {
+    const { client } = await join('stranger', 'wrong');
+    const closed = new Promise((resolve) => client.on('close', resolve));
+
+    expect(await closed).toBe(UNAUTHORIZED_CLOSE_CODE);
+    expect(hub.participants).toEqual(['student']);
+  });
+
+  it('should keep names unique and reserve the host name', async () => {
+    for (const name of ['student', 'research']) {
+      const { client } = await join(name);
+      const closed = new Promise((resolve) => client.on('close', resolve));
+      expect(await closed).toBe(NAME_TAKEN_CLOSE_CODE);
+    }
+
+    const first = await join('');
+    await vi.waitFor(() =>
+      expect(hub.participants).toEqual(['student', 'guest-1']),
+    );
+    first.client.close();
+    await vi.waitFor(() => expect(hub.participants).toEqual(['student']));
+    await join('');
+    await vi.waitFor(() =>
+      expect(hub.participants).toEqual(['student', 'guest-2']),
+    );
+  });
+
+  it('should ignore frames that are not objects', async () => {
+    const advisor = await join('advisor');
+    const socket = new WebSocket(`${url}?token=${hub.token}&name=raw`);
+    await new Promise((resolve) => socket.once('open', resolve));
+    socket.send('null');
+    socket.send('42');
+    socket.send(JSON.stringify({ type: 'message', text: 'still here' }));
+
+    await vi.waitFor(() =>
+      expect(advisor.events).toContainEqual(
+        expect.objectContaining({ author: 'raw', text: 'still here' }),
+      ),
+    );
+    socket.close();
+  });
+});
@@ -0,0 +1,260 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import crypto from 'node:crypto';
+import { EventEmitter } from 'node:events';
+import { AddressInfo } from 'node:net';
+import WebSocket, { WebSocketServer } from 'ws';
+
+/** Close code sent to participants with a wrong or missing token. */
+export const UNAUTHORIZED_CLOSE_CODE = 4001;
+
+/** Close code sent to participants who pick a name already in use. */
+export const NAME_TAKEN_CLOSE_CODE = 4002;
+
+/** What the hub sends to every participant. */
+export type SharedSessionEvent =
+  | { type: 'message'; author: string; text: string; timestamp: string }
+  | {
+      type: 'approval-request';
+      id: string;
+      author: string;
+      description: string;
+    }
+  | { type: 'approval'; id: string; author: string; approved: boolean }
+  | { type: 'presence'; participants: string[] };
+
+/** What a participant sends to the hub; the author is added by the hub. */
+export type SharedSessionInput =
+  | { type: 'message'; text: string }
+  | { type: 'approval'; id: string; approved: boolean };
+
+export interface ApprovalDecision {
+  approved: boolean;
+  /** The participant who decided. */
+  by: string;
+}
+
+function sameToken(given: string, expected: string): boolean {
+  const a = Buffer.from(given);
+  const b = Buffer.from(expected);
+  return a.length === b.length && crypto.timingSafeEqual(a, b);
+}
+
+/**
+ * Lets several people take part in one session over WebSocket, for
+ * instance an advisor and a student. Everyone sees every message with its
+ * author, and tool approvals go into one queue that anyone can answer;
+ * the first answer counts.
+ *
+ * Participants connect to `ws://host:port/?token=<token>&name=<name>`.
+ * Names are unique, and the host's name and `reservedNames` cannot be
+ * taken, so an author label always means the same person. Emits `event`
+ * for everything that is broadcast.
+ */
+export class SharedSessionHub extends EventEmitter {
+  readonly token: string;
+  private server: WebSocketServer | undefined;
+  private readonly sockets = new Map<WebSocket, string>();
+  private guestCount = 0;
+  private readonly approvals = new Map<
+    string,
+    {
+      author: string;
+      description: string;
+      resolve: (decision: ApprovalDecision) => void;
+    }
+  >();
+
+  constructor(
+    readonly hostName: string,
+    token: string = crypto.randomBytes(16).toString('hex'),
+    private readonly reservedNames: string[] = [],
+  ) {
+    super();
+    this.token = token;
+  }
+
+  get participants(): string[] {
+    return [this.hostName, ...this.sockets.values()];
+  }
+
+  /** Pending approval requests, oldest first. */
+  get pendingApprovals(): string[] {
+    return [...this.approvals.keys()];
+  }
+
+  /** Listens on `port` (0 for any free port) and returns the port. */
+  start(port = 0, host = '127.0.0.1'): Promise<number> {
+    const server = new WebSocketServer({ port, host });
+    this.server = server;
+    server.on('connection', (socket, request) => {
+      const url = new URL(request.url ?? '/', 'ws://localhost');
+      const name = url.searchParams.get('name')?.trim();
+      if (!sameToken(url.searchParams.get('token') ?? '', this.token)) {
+        socket.close(UNAUTHORIZED_CLOSE_CODE, 'Unauthorized');
+        return;
+      }
+      if (name && !this.isNameFree(name)) {
+        socket.close(NAME_TAKEN_CLOSE_CODE, 'Name taken');
+        return;
+      }
+      let guestName = name;
+      while (!guestName || !this.isNameFree(guestName)) {
+        guestName = `guest-${++this.guestCount}`;
+      }
+      this.sockets.set(socket, guestName);
+      socket.on('message', (raw) => this.receive(socket, raw.toString()));
+      socket.on('close', () => {
+        this.sockets.delete(socket);
+        this.broadcast({ type: 'presence', participants: this.participants });
+      });
+      // Bring the newcomer up to date with what is waiting for approval
+      for (const [id, { author, description }] of this.approvals) {
+        this.send(socket, {
+          type: 'approval-request',
+          id,
+          author,
+          description,
+        });
+      }
+      this.broadcast({ type: 'presence', participants: this.participants });
+    });
+    return new Promise((resolve, reject) => {
+      server.once('listening', () =>
+        resolve((server.address() as AddressInfo).port),
+      );
+      server.once('error', reject);
+    });
+  }
+
+  async stop(): Promise<void> {
+    for (const socket of this.sockets.keys()) {
+      socket.close();
+    }
+    await new Promise<void>((resolve) =>
+      this.server ? this.server.close(() => resolve()) : resolve(),
+    );
+    this.server = undefined;
+  }
+
+  /** Shares a message, from the host or from the model. */
+  post(author: string, text: string): void {
+    this.broadcast({
+      type: 'message',
+      author,
+      text,
+      timestamp: new Date().toISOString(),
+    });
+  }
+
+  /**
+   * Queues a tool call for approval and resolves with the first decision of
+   * any participant, including the host through `decide` with the same `id`.
+   */
+  requestApproval(
+    description: string,
+    author: string = this.hostName,
+    id: string = crypto.randomUUID(),
+  ): Promise<ApprovalDecision> {
+    const decision = new Promise<ApprovalDecision>((resolve) =>
+      this.approvals.set(id, { author, description, resolve }),
+    );
+    this.broadcast({ type: 'approval-request', id, author, description });
+    return decision;
+  }
+
+  /** Answers a pending approval; returns false when it was already decided. */
+  decide(id: string, approved: boolean, by: string = this.hostName): boolean {
+    const pending = this.approvals.get(id);
+    if (!pending) {
+      return false;
+    }
+    this.approvals.delete(id);
+    this.broadcast({ type: 'approval', id, author: by, approved });
+    pending.resolve({ approved, by });
+    return true;
+  }
+
+  private isNameFree(name: string): boolean {
+    const taken = [...this.participants, ...this.reservedNames];
+    return !taken.some((n) => n.toLowerCase() === name.toLowerCase());
+  }
+
+  private receive(socket: WebSocket, raw: string): void {
+    const author = this.sockets.get(socket)!;
+    let input: SharedSessionInput;
+    try {
+      const parsed: unknown = JSON.parse(raw);
+      if (typeof parsed !== 'object' || parsed === null) {
+        return;
+      }
+      input = parsed as SharedSessionInput;
+    } catch {
+      return;
+    }
+    if (input.type === 'message' && typeof input.text === 'string') {
+      this.post(author, input.text);
+    } else if (input.type === 'approval' && typeof input.id === 'string') {
+      this.decide(input.id, input.approved === true, author);
+    }
+  }
+
+  private send(socket: WebSocket, event: SharedSessionEvent): void {
+    if (socket.readyState === WebSocket.OPEN) {
+      socket.send(JSON.stringify(event));
+    }
+  }
+
+  private broadcast(event: SharedSessionEvent): void {
+    for (const socket of this.sockets.keys()) {
+      this.send(socket, event);
+    }
+    this.emit('event', event);
+  }
+}
+
+/** A participant's end of a shared session. Emits `event` and `close`. */
+export class SharedSessionClient extends EventEmitter {
+  private constructor(private readonly socket: WebSocket) {
+    super();
+    socket.on('message', (raw) => {
+      try {
+        this.emit('event', JSON.parse(raw.toString()) as SharedSessionEvent);
+      } catch {
+        // Ignore what is not an event
+      }
+    });
+    socket.on('close', (code) => this.emit('close', code));
+  }
+
+  static connect(
+    url: string,
+    token: string,
+    name: string,
+  ): Promise<SharedSessionClient> {
+    const target = new URL(url);
+    target.searchParams.set('token', token);
+    target.searchParams.set('name', name);
+    const socket = new WebSocket(target);
+    return new Promise((resolve, reject) => {
+      socket.once('open', () => resolve(new SharedSessionClient(socket)));
+      socket.once('error', reject);
+    });
+  }
+
+  say(text: string): void {
+    this.socket.send(JSON.stringify({ type: 'message', text }));
+  }
+
+  decide(id: string, approved: boolean): void {
+    this.socket.send(JSON.stringify({ type: 'approval', id, approved }));
+  }
+
+  close(): void {
+    this.socket.close();
+  }
+}
@@ -35,6 +35,7 @@ import {
 import { isTelemetrySdkInitialized } from './sdk.js';
 import { uiTelemetryService, UiEvent } from './uiTelemetry.js';
 import { ClearcutLogger } from './clearcut-logger/clearcut-logger.js';
+import { recordUsage } from '../utils/usageHistory.js';
 
 const shouldLogUserPrompts = (config: Config): boolean =>
   config.getTelemetryLogPromptsEnabled();
@@ -78,6 +79,7 @@ export function logCliConfiguration(
 }
 
 export function logUserPrompt(config: Config, event: UserPromptEvent): void {
+  recordUsage({ kind: 'prompt', timestamp: event['event.timestamp'] });
   ClearcutLogger.getInstance(config)?.logNewPromptEvent(event);
   if (!isTelemetrySdkInitialized()) return;
 
@@ -107,6 +109,13 @@ export function logToolCall(config: Config, event: ToolCallEvent): void {
     'event.timestamp': new Date().toISOString(),
   } as UiEvent;
   uiTelemetryService.addEvent(uiEvent);
+  recordUsage({
+    kind: 'tool',
+    timestamp: uiEvent['event.timestamp'],
+    tool: event.function_name,
+    durationMs: event.duration_ms,
+    success: event.success,
+  });
   ClearcutLogger.getInstance(config)?.logToolCallEvent(event);
   if (!isTelemetrySdkInitialized()) return;
 
@@ -187,6 +196,15 @@ export function logApiError(config: Config, event: ApiErrorEvent): void {
     'event.timestamp': new Date().toISOString(),
   } as UiEvent;
   uiTelemetryService.addEvent(uiEvent);
+  recordUsage({
+    kind: 'api',
+    timestamp: uiEvent['event.timestamp'],
+    model: event.model,
+    inputTokens: 0,
+    outputTokens: 0,
+    durationMs: event.duration_ms,
+    error: true,
+  });
   ClearcutLogger.getInstance(config)?.logApiErrorEvent(event);
   if (!isTelemetrySdkInitialized()) return;
 
@@ -229,6 +247,14 @@ export function logApiResponse(config: Config, event: ApiResponseEvent): void {
     'event.timestamp': new Date().toISOString(),
   } as UiEvent;
   uiTelemetryService.addEvent(uiEvent);
+  recordUsage({
+    kind: 'api',
+    timestamp: uiEvent['event.timestamp'],
+    model: event.model,
+    inputTokens: event.input_token_count,
+    outputTokens: event.output_token_count,
+    durationMs: event.duration_ms,
+  });
   ClearcutLogger.getInstance(config)?.logApiResponseEvent(event);
   if (!isTelemetrySdkInitialized()) return;
   const attributes: LogAttributes = {
@@ -9,6 +9,7 @@ import { Config } from '../config/config.js';
 import { CompletedToolCall } from '../core/coreToolScheduler.js';
 import { ToolConfirmationOutcome } from '../tools/tools.js';
 import { AuthType } from '../core/contentGenerator.js';
+import { getRedactor } from '../utils/redaction.js';
 
 export enum ToolCallDecision {
   ACCEPT = 'accept',
@@ -110,7 +111,8 @@ export class UserPromptEvent {
     this.prompt_length = prompt_length;
     this.prompt_id = prompt_Id;
     this.auth_type = auth_type;
-    this.prompt = prompt;
+    this.prompt =
+      prompt === undefined ? undefined : getRedactor().redactText(prompt).value;
   }
 }
 
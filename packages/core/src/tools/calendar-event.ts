@@ -0,0 +1,211 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { Type } from '@google/genai';
+import { Config } from '../config/config.js';
+import {
+  BaseTool,
+  ToolResult,
+  ToolCallConfirmationDetails,
+  ToolConfirmationOutcome,
+  ToolInfoConfirmationDetails,
+} from './tools.js';
+import { SchemaValidator } from '../utils/schemaValidator.js';
+import { getErrorMessage } from '../utils/errors.js';
+import { tildeifyPath } from '../utils/paths.js';
+import {
+  createCalendarEvent,
+  getCalendarDir,
+  parseEventStart,
+  putCalDavEvent,
+  writeIcsFile,
+} from '../utils/calendar.js';
+import {
+  createDeadlineId,
+  loadDeadlines,
+  mergeDeadlines,
+  parseDeadlineDate,
+  saveDeadlines,
+} from '../utils/deadlines.js';
+
+/**
+ * Parameters for the CreateCalendarEvent tool
+ */
+export interface CreateCalendarEventToolParams {
+  /**
+   * What to do, e.g. "Rerun the ablation with seed 3"
+   */
+  title: string;
+
+  /**
+   * `YYYY-MM-DD` for an all-day event or `YYYY-MM-DDTHH:MM`, local time
+   */
+  start: string;
+
+  /**
+   * Length of a timed event in minutes
+   */
+  duration_minutes?: number;
+
+  /**
+   * Details, e.g. the command or run to repeat
+   */
+  description?: string;
+}
+
+/**
+ * Creates follow-up events as .ics files, adds them to a CalDAV calendar
+ * when one is configured, and lists them with the deadlines.
+ */
+export class CreateCalendarEventTool extends BaseTool<
+  CreateCalendarEventToolParams,
+  ToolResult
+> {
+  static readonly Name: string = 'create_calendar_event';
+  private alwaysAllowed = false;
+
+  constructor(private readonly config: Config) {
+    super(
+      CreateCalendarEventTool.Name,
+      'CreateCalendarEvent',
+      `Creates a calendar event with a reminder, for follow-ups the user asks for ("remind me to rerun this experiment Friday"). Resolve relative dates against today's date. The event is written as an .ics file${config.getCalendarSettings().caldav ? ', added to the configured CalDAV calendar' : ''} and listed with the user's deadlines. The user approves each event.`,
+      {
+        properties: {
+          title: {
+            description:
+              'What to do, written so it makes sense on its own, e.g. "Rerun the ablation with seed 3".',
+            type: Type.STRING,
+          },
+          start: {
+            description:
+              'YYYY-MM-DD for an all-day event, or YYYY-MM-DDTHH:MM in local time.',
+            type: Type.STRING,
+          },
+          duration_minutes: {
+            description: 'Length of a timed event in minutes (default 30).',
+            type: Type.NUMBER,
+          },
+          description: {
+            description:
+              'Details to find the work again, e.g. the command, run or file.',
+            type: Type.STRING,
+          },
+        },
+        required: ['title', 'start'],
+        type: Type.OBJECT,
+      },
+    );
+  }
+
+  validateToolParams(params: CreateCalendarEventToolParams): string | null {
+    const errors = SchemaValidator.validate(this.schema.parameters, params);
+    if (errors) {
+      return errors;
+    }
+    if (!params.title.trim()) {
+      return 'The title must not be empty';
+    }
+    if (!parseEventStart(params.start)) {
+      return `Invalid start "${params.start}"; use YYYY-MM-DD or YYYY-MM-DDTHH:MM`;
+    }
+    if (
+      params.duration_minutes !== undefined &&
+      !(params.duration_minutes > 0)
+    ) {
+      return 'duration_minutes must be positive';
+    }
+    return null;
+  }
+
+  getDescription(params: CreateCalendarEventToolParams): string {
+    return `${params.title} [${params.start}]`;
+  }
+
+  async shouldConfirmExecute(
+    params: CreateCalendarEventToolParams,
+    _abortSignal: AbortSignal,
+  ): Promise<ToolCallConfirmationDetails | false> {
+    if (this.alwaysAllowed || this.validateToolParams(params)) {
+      return false;
+    }
+    const caldav = this.config.getCalendarSettings().caldav;
+    const confirmationDetails: ToolInfoConfirmationDetails = {
+      type: 'info',
+      title: 'Confirm Calendar Event',
+      prompt: [
+        `${params.title} on ${params.start.replace('T', ' at ')}`,
+        ...(params.description ? [params.description] : []),
+        '',
+        `Written to ${tildeifyPath(getCalendarDir(this.config.getCalendarSettings()))}${caldav ? ` and ${caldav.url}` : ''}.`,
+      ].join('\n'),
+      onConfirm: async (outcome: ToolConfirmationOutcome) => {
+        if (outcome === ToolConfirmationOutcome.ProceedAlways) {
+          this.alwaysAllowed = true;
+        }
+      },
+    };
+    return confirmationDetails;
+  }
+
+  async execute(
+    params: CreateCalendarEventToolParams,
+    signal: AbortSignal,
+  ): Promise<ToolResult> {
+    const validationError = this.validateToolParams(params);
+    if (validationError) {
+      return {
+        llmContent: `Error: Invalid parameters provided. Reason: ${validationError}`,
+        returnDisplay: `Error: ${validationError}`,
+      };
+    }
+
+    const settings = this.config.getCalendarSettings();
+    const event = createCalendarEvent(params.title.trim(), params.start, {
+      durationMinutes: params.duration_minutes,
+      description: params.description,
+    })!;
+    let filePath: string;
+    try {
+      filePath = await writeIcsFile(event, getCalendarDir(settings));
+      // All-day events are due at the end of their day, like deadlines
+      const due = (
+        event.allDay ? parseDeadlineDate(params.start.trim())! : event.start
+      ).toISOString();
+      const { deadlines } = mergeDeadlines(await loadDeadlines(), [
+        {
+          id: createDeadlineId(event.title, due),
+          title: event.title,
+          due,
+          kind: 'reminder',
+          note: event.description,
+          source: filePath,
+        },
+      ]);
+      await saveDeadlines(deadlines);
+    } catch (error) {
+      const message = `Could not create the event: ${getErrorMessage(error)}`;
+      return { llmContent: `Error: ${message}`, returnDisplay: message };
+    }
+
+    const lines = [
+      `Created "${event.title}" on ${params.start.replace('T', ' at ')}, listed in /deadlines.`,
+      `Calendar file: ${filePath}`,
+    ];
+    if (settings.caldav?.url) {
+      try {
+        lines.push(
+          `Added to the CalDAV calendar: ${await putCalDavEvent(settings.caldav, event, signal)}`,
+        );
+      } catch (error) {
+        lines.push(
+          `Could not add it to the CalDAV calendar: ${getErrorMessage(error)}`,
+        );
+      }
+    }
+    const result = lines.join('\n');
+    return { llmContent: result, returnDisplay: result };
+  }
+}
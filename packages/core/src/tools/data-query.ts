@@ -0,0 +1,242 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'fs';
+import path from 'path';
+import { Type } from '@google/genai';
+import { Config } from '../config/config.js';
+import { BaseTool, ToolResult } from './tools.js';
+import { SchemaValidator } from '../utils/schemaValidator.js';
+import { makeRelative, shortenPath } from '../utils/paths.js';
+import { getErrorMessage } from '../utils/errors.js';
+import {
+  DEFAULT_QUERY_LIMIT,
+  MAX_QUERY_LIMIT,
+  TableQueryResult,
+  TABULAR_EXTENSIONS,
+  describeTable,
+  formatMarkdownTable,
+  parseTable,
+  runTableQuery,
+} from '../utils/tabularData.js';
+
+// Data files are parsed in memory, so very large ones are refused.
+const MAX_DATA_FILE_BYTES = 100 * 1024 * 1024;
+
+/**
+ * Parameters for the DataQuery tool
+ */
+export interface DataQueryToolParams {
+  /**
+   * The absolute path to a CSV, TSV, JSON or JSON Lines file
+   */
+  file_path: string;
+
+  /**
+   * Conditions of the form `column op value`, all of which must hold
+   */
+  where?: string[];
+
+  /**
+   * Columns to group by
+   */
+  group_by?: string[];
+
+  /**
+   * Aggregates such as `count` or `mean(score)`
+   */
+  aggregate?: string[];
+
+  /**
+   * Columns to return when not aggregating
+   */
+  select?: string[];
+
+  /**
+   * A result column to sort by, optionally followed by `desc`
+   */
+  order_by?: string;
+
+  /**
+   * Maximum number of result rows
+   */
+  limit?: number;
+}
+
+/**
+ * Answers questions about a local data file by filtering, grouping and
+ * aggregating it on this machine. Only the result rows are sent to the
+ * model, never the dataset itself.
+ */
+export class DataQueryTool extends BaseTool<DataQueryToolParams, ToolResult> {
+  static readonly Name: string = 'query_data';
+
+  constructor(private readonly config: Config) {
+    super(
+      DataQueryTool.Name,
+      'DataQuery',
+      `Queries a local tabular data file (${TABULAR_EXTENSIONS.join(', ')}) and returns only the result table; the data itself is never sent. Call it with just file_path first to see the columns and their types. Then filter with 'where', and either 'select' columns or 'group_by' and 'aggregate'. Prefer this over reading data files whole.`,
+      {
+        properties: {
+          file_path: {
+            description:
+              "The absolute path to the data file (e.g., '/home/user/project/results.csv').",
+            type: Type.STRING,
+          },
+          where: {
+            description:
+              "Conditions that must all hold, each 'column op value' with op one of =, !=, >, >=, <, <=, ~ (contains, ignoring case). Quote text values containing spaces, e.g. \"venue = 'Nature Methods'\"; use null for empty values.",
+            type: Type.ARRAY,
+            items: { type: Type.STRING },
+          },
+          group_by: {
+            description:
+              'Columns to group by. Without aggregate, each group is counted.',
+            type: Type.ARRAY,
+            items: { type: Type.STRING },
+          },
+          aggregate: {
+            description:
+              "Aggregates per group (or over all matching rows): 'count', or sum, mean, median, min, max, count or distinct of a column, e.g. 'mean(score)'.",
+            type: Type.ARRAY,
+            items: { type: Type.STRING },
+          },
+          select: {
+            description:
+              'Columns to return for each matching row when not aggregating. Defaults to all columns.',
+            type: Type.ARRAY,
+            items: { type: Type.STRING },
+          },
+          order_by: {
+            description:
+              "A result column to sort by, e.g. 'year' or 'mean(score) desc'.",
+            type: Type.STRING,
+          },
+          limit: {
+            description: `Maximum number of result rows (default ${DEFAULT_QUERY_LIMIT}, at most ${MAX_QUERY_LIMIT}).`,
+            type: Type.NUMBER,
+          },
+        },
+        required: ['file_path'],
+        type: Type.OBJECT,
+      },
+    );
+  }
+
+  private isWithinRoot(pathToCheck: string): boolean {
+    const normalizedPath = path.normalize(pathToCheck);
+    const normalizedRoot = path.normalize(this.config.getTargetDir());
+    const rootWithSep = normalizedRoot.endsWith(path.sep)
+      ? normalizedRoot
+      : normalizedRoot + path.sep;
+    return (
+      normalizedPath === normalizedRoot ||
+      normalizedPath.startsWith(rootWithSep)
+    );
+  }
+
+  validateToolParams(params: DataQueryToolParams): string | null {
+    const errors = SchemaValidator.validate(this.schema.parameters, params);
+    if (errors) {
+      return errors;
+    }
+    const filePath = params.file_path;
+    if (!path.isAbsolute(filePath)) {
+      return `File path must be absolute: ${filePath}`;
+    }
+    if (!this.isWithinRoot(filePath)) {
+      return `File path must be within the root directory (${this.config.getTargetDir()}): ${filePath}`;
+    }
+    if (!TABULAR_EXTENSIONS.includes(path.extname(filePath).toLowerCase())) {
+      return `Unsupported data file ${filePath}; expected one of ${TABULAR_EXTENSIONS.join(', ')}`;
+    }
+    return null;
+  }
+
+  private isDescribeOnly(params: DataQueryToolParams): boolean {
+    return (
+      !params.where?.length &&
+      !params.group_by?.length &&
+      !params.aggregate?.length &&
+      !params.select?.length &&
+      !params.order_by
+    );
+  }
+
+  getDescription(params: DataQueryToolParams): string {
+    if (!params.file_path) {
+      return `Model did not provide valid parameters for data query tool`;
+    }
+    const relativePath = shortenPath(
+      makeRelative(params.file_path, this.config.getTargetDir()),
+    );
+    if (this.isDescribeOnly(params)) {
+      return `Describing ${relativePath}`;
+    }
+    const parts = [
+      params.where?.length && `where ${params.where.join(' and ')}`,
+      params.group_by?.length && `by ${params.group_by.join(', ')}`,
+      params.aggregate?.length && params.aggregate.join(', '),
+    ].filter(Boolean);
+    return `Querying ${relativePath}${parts.length ? ` (${parts.join('; ')})` : ''}`;
+  }
+
+  async execute(
+    params: DataQueryToolParams,
+    _signal: AbortSignal,
+  ): Promise<ToolResult> {
+    const validationError = this.validateToolParams(params);
+    if (validationError) {
+      return {
+        llmContent: `Error: Invalid parameters provided. Reason: ${validationError}`,
+        returnDisplay: `Error: ${validationError}`,
+      };
+    }
+
+    const relativePath = makeRelative(
+      params.file_path,
+      this.config.getTargetDir(),
+    );
+    let result: TableQueryResult;
+    try {
+      const stats = await fs.promises.stat(params.file_path);
+      if (stats.size > MAX_DATA_FILE_BYTES) {
+        throw new Error(
+          `File is larger than ${MAX_DATA_FILE_BYTES / (1024 * 1024)}MB`,
+        );
+      }
+      const table = parseTable(
+        params.file_path,
+        await fs.promises.readFile(params.file_path, 'utf8'),
+      );
+      result = this.isDescribeOnly(params)
+        ? describeTable(table)
+        : runTableQuery(table, {
+            where: params.where,
+            groupBy: params.group_by,
+            aggregate: params.aggregate,
+            select: params.select,
+            orderBy: params.order_by,
+            limit: params.limit,
+          });
+    } catch (error) {
+      const errorMsg = `Error querying ${relativePath}: ${getErrorMessage(error)}`;
+      return {
+        llmContent: errorMsg,
+        returnDisplay: errorMsg,
+      };
+    }
+
+    const summary = this.isDescribeOnly(params)
+      ? `${relativePath}: ${result.totalRows} rows, ${result.rows.length} columns.`
+      : `${relativePath}: ${result.matchedRows} of ${result.totalRows} rows matched; ${result.rows.length} result rows${result.truncated ? ` shown (limit reached; narrow the query or raise limit)` : ''}.`;
+    const table = formatMarkdownTable(result.columns, result.rows);
+    return {
+      llmContent: `${summary}\n\n${table}`,
+      returnDisplay: `${summary}\n\n${table}`,
+    };
+  }
+}
@@ -6,6 +6,7 @@
 
 import * as fs from 'fs';
 import * as path from 'path';
+import { isWithinRoot } from '../utils/fileUtils.js';
 import * as Diff from 'diff';
 import {
   BaseTool,
@@ -24,6 +25,12 @@ import { ensureCorrectEdit } from '../utils/editCorrector.js';
 import { DEFAULT_DIFF_OPTIONS } from './diffOptions.js';
 import { ReadFileTool } from './read-file.js';
 import { ModifiableTool, ModifyContext } from './modifiable-tool.js';
+import {
+  readRemoteFile,
+  validateRemotePath,
+  writeRemoteFile,
+} from '../utils/remoteFiles.js';
+import { formatRemoteTarget, RemoteTarget } from '../utils/remoteTarget.js';
 
 /**
  * Parameters for the Edit tool
@@ -77,12 +84,16 @@ export class EditTool
   /**
    * Creates a new instance of the EditLogic
    * @param rootDirectory Root directory to ground this tool in.
+   * @param remote SSH host whose files are edited instead of local ones.
    */
-  constructor(private readonly config: Config) {
+  constructor(
+    private readonly config: Config,
+    private readonly remote?: RemoteTarget,
+  ) {
     super(
       EditTool.Name,
       'Edit',
-      `Replaces text within a file. By default, replaces a single occurrence, but can replace multiple occurrences when \`expected_replacements\` is specified. This tool requires providing significant context around the change to ensure precise targeting. Always use the ${ReadFileTool.Name} tool to examine the file's current content before attempting a text replacement.
+      `Replaces text within a file${remote ? ` on the remote host ${formatRemoteTarget(remote)}, where shell commands run` : ''}. By default, replaces a single occurrence, but can replace multiple occurrences when \`expected_replacements\` is specified. This tool requires providing significant context around the change to ensure precise targeting. Always use the ${ReadFileTool.Name} tool to examine the file's current content before attempting a text replacement.
 
       The user has the ability to modify the \`new_string\` content. If modified, this will be stated in the response.
 
@@ -130,15 +141,33 @@ Expectation for required parameters:
    * @returns True if the path is within the root directory, false otherwise.
    */
   private isWithinRoot(pathToCheck: string): boolean {
-    const normalizedPath = path.normalize(pathToCheck);
-    const normalizedRoot = this.rootDirectory;
-    const rootWithSep = normalizedRoot.endsWith(path.sep)
-      ? normalizedRoot
-      : normalizedRoot + path.sep;
-    return (
-      normalizedPath === normalizedRoot ||
-      normalizedPath.startsWith(rootWithSep)
-    );
+    return isWithinRoot(pathToCheck, this.rootDirectory);
+  }
+
+  /** The path as shown in descriptions and approvals. */
+  private displayPath(filePath: string): string {
+    return this.remote
+      ? `${formatRemoteTarget(this.remote)}:${filePath}`
+      : makeRelative(filePath, this.rootDirectory);
+  }
+
+  /** The file's content, or undefined if it does not exist. */
+  private async readCurrentContent(
+    filePath: string,
+    signal: AbortSignal,
+  ): Promise<string | undefined> {
+    if (this.remote) {
+      return readRemoteFile(this.remote, filePath, signal);
+    }
+    try {
+      return fs.readFileSync(filePath, 'utf8');
+    } catch (err: unknown) {
+      if (!isNodeError(err) || err.code !== 'ENOENT') {
+        // Rethrow unexpected FS errors (permissions, etc.)
+        throw err;
+      }
+      return undefined;
+    }
   }
 
   /**
@@ -152,6 +181,10 @@ Expectation for required parameters:
       return errors;
     }
 
+    if (this.remote) {
+      return validateRemotePath(this.remote, params.file_path);
+    }
+
     if (!path.isAbsolute(params.file_path)) {
       return `File path must be absolute: ${params.file_path}`;
     }
@@ -202,17 +235,14 @@ Expectation for required parameters:
     let occurrences = 0;
     let error: { display: string; raw: string } | undefined = undefined;
 
-    try {
-      currentContent = fs.readFileSync(params.file_path, 'utf8');
+    const content = await this.readCurrentContent(
+      params.file_path,
+      abortSignal,
+    );
+    if (content !== undefined) {
       // Normalize line endings to LF for consistent processing.
-      currentContent = currentContent.replace(/\r\n/g, '\n');
+      currentContent = content.replace(/\r\n/g, '\n');
       fileExists = true;
-    } catch (err: unknown) {
-      if (!isNodeError(err) || err.code !== 'ENOENT') {
-        // Rethrow unexpected FS errors (permissions, etc.)
-        throw err;
-      }
-      fileExists = false;
     }
 
     if (params.old_string === '' && !fileExists) {
@@ -325,7 +355,7 @@ Expectation for required parameters:
     );
     const confirmationDetails: ToolEditConfirmationDetails = {
       type: 'edit',
-      title: `Confirm Edit: ${shortenPath(makeRelative(params.file_path, this.rootDirectory))}`,
+      title: `Confirm Edit: ${shortenPath(this.displayPath(params.file_path))}`,
       fileName,
       fileDiff,
       onConfirm: async (outcome: ToolConfirmationOutcome) => {
@@ -341,7 +371,7 @@ Expectation for required parameters:
     if (!params.file_path || !params.old_string || !params.new_string) {
       return `Model did not provide valid parameters for edit tool`;
     }
-    const relativePath = makeRelative(params.file_path, this.rootDirectory);
+    const relativePath = this.displayPath(params.file_path);
     if (params.old_string === '') {
       return `Create ${shortenPath(relativePath)}`;
     }
@@ -395,12 +425,21 @@ Expectation for required parameters:
     }
 
     try {
-      this.ensureParentDirectoriesExist(params.file_path);
-      fs.writeFileSync(params.file_path, editData.newContent, 'utf8');
+      if (this.remote) {
+        await writeRemoteFile(
+          this.remote,
+          params.file_path,
+          editData.newContent,
+          signal,
+        );
+      } else {
+        this.ensureParentDirectoriesExist(params.file_path);
+        fs.writeFileSync(params.file_path, editData.newContent, 'utf8');
+      }
 
       let displayResult: ToolResultDisplay;
       if (editData.isNewFile) {
-        displayResult = `Created ${shortenPath(makeRelative(params.file_path, this.rootDirectory))}`;
+        displayResult = `Created ${shortenPath(this.displayPath(params.file_path))}`;
       } else {
         // Generate diff for display, even though core logic doesn't technically need it
         // The CLI wrapper will use this part of the ToolResult
@@ -450,30 +489,25 @@ Expectation for required parameters:
     }
   }
 
-  getModifyContext(_: AbortSignal): ModifyContext<EditToolParams> {
+  getModifyContext(signal: AbortSignal): ModifyContext<EditToolParams> {
     return {
       getFilePath: (params: EditToolParams) => params.file_path,
-      getCurrentContent: async (params: EditToolParams): Promise<string> => {
-        try {
-          return fs.readFileSync(params.file_path, 'utf8');
-        } catch (err) {
-          if (!isNodeError(err) || err.code !== 'ENOENT') throw err;
-          return '';
-        }
-      },
+      getCurrentContent: async (params: EditToolParams): Promise<string> =>
+        (await this.readCurrentContent(params.file_path, signal)) ?? '',
       getProposedContent: async (params: EditToolParams): Promise<string> => {
-        try {
-          const currentContent = fs.readFileSync(params.file_path, 'utf8');
-          return this._applyReplacement(
-            currentContent,
-            params.old_string,
-            params.new_string,
-            params.old_string === '' && currentContent === '',
-          );
-        } catch (err) {
-          if (!isNodeError(err) || err.code !== 'ENOENT') throw err;
+        const currentContent = await this.readCurrentContent(
+          params.file_path,
+          signal,
+        );
+        if (currentContent === undefined) {
           return '';
         }
+        return this._applyReplacement(
+          currentContent,
+          params.old_string,
+          params.new_string,
+          params.old_string === '' && currentContent === '',
+        );
       },
       createUpdatedParams: (
         oldContent: string,
@@ -6,12 +6,16 @@
 
 import fs from 'fs';
 import path from 'path';
+import { isWithinRoot } from '../utils/fileUtils.js';
 import { glob } from 'glob';
+import micromatch from 'micromatch';
 import { SchemaValidator } from '../utils/schemaValidator.js';
 import { BaseTool, ToolResult } from './tools.js';
 import { Type } from '@google/genai';
 import { shortenPath, makeRelative } from '../utils/paths.js';
 import { Config } from '../config/config.js';
+import { listRemoteFiles, validateRemotePath } from '../utils/remoteFiles.js';
+import { formatRemoteTarget, RemoteTarget } from '../utils/remoteTarget.js';
 
 // Subset of 'Path' interface provided by 'glob' that we can implement for testing
 export interface GlobPath {
@@ -82,15 +86,17 @@ export class GlobTool extends BaseTool<GlobToolParams, ToolResult> {
   /**
    * Creates a new instance of the GlobLogic
    * @param rootDirectory Root directory to ground this tool in.
+   * @param remote SSH host whose files are found instead of local ones.
    */
   constructor(
     private rootDirectory: string,
     private config: Config,
+    private readonly remote?: RemoteTarget,
   ) {
     super(
       GlobTool.Name,
       'FindFiles',
-      'Efficiently finds files matching specific glob patterns (e.g., `src/**/*.ts`, `**/*.md`), returning absolute paths sorted by modification time (newest first). Ideal for quickly locating files based on their name or path structure, especially in large codebases.',
+      `Efficiently finds files matching specific glob patterns (e.g., \`src/**/*.ts\`, \`**/*.md\`)${remote ? ` on the remote host ${formatRemoteTarget(remote)}, where shell commands run` : ''}, returning absolute paths sorted by modification time (newest first). Ideal for quickly locating files based on their name or path structure, especially in large codebases.`,
       {
         properties: {
           pattern: {
@@ -130,16 +136,7 @@ export class GlobTool extends BaseTool<GlobToolParams, ToolResult> {
    * @returns True if the path is within the root directory, false otherwise
    */
   private isWithinRoot(pathToCheck: string): boolean {
-    const absolutePathToCheck = path.resolve(pathToCheck);
-    const normalizedPath = path.normalize(absolutePathToCheck);
-    const normalizedRoot = path.normalize(this.rootDirectory);
-    const rootWithSep = normalizedRoot.endsWith(path.sep)
-      ? normalizedRoot
-      : normalizedRoot + path.sep;
-    return (
-      normalizedPath === normalizedRoot ||
-      normalizedPath.startsWith(rootWithSep)
-    );
+    return isWithinRoot(path.resolve(pathToCheck), this.rootDirectory);
   }
 
   /**
@@ -151,6 +148,18 @@ export class GlobTool extends BaseTool<GlobToolParams, ToolResult> {
       return errors;
     }
 
+    if (this.remote) {
+      if (params.path) {
+        const remotePathError = validateRemotePath(this.remote, params.path);
+        if (remotePathError) {
+          return remotePathError;
+        }
+      }
+      return params.pattern.trim() === ''
+        ? "The 'pattern' parameter cannot be empty."
+        : null;
+    }
+
     const searchDirAbsolute = path.resolve(
       this.rootDirectory,
       params.path || '.',
@@ -188,7 +197,9 @@ export class GlobTool extends BaseTool<GlobToolParams, ToolResult> {
    */
   getDescription(params: GlobToolParams): string {
     let description = `'${params.pattern}'`;
-    if (params.path) {
+    if (params.path && this.remote) {
+      description += ` within ${formatRemoteTarget(this.remote)}:${params.path}`;
+    } else if (params.path) {
       const searchDir = path.resolve(this.rootDirectory, params.path || '.');
       const relativePath = makeRelative(searchDir, this.rootDirectory);
       description += ` within ${shortenPath(relativePath)}`;
@@ -211,6 +222,10 @@ export class GlobTool extends BaseTool<GlobToolParams, ToolResult> {
       };
     }
 
+    if (this.remote) {
+      return this.executeRemote(this.remote, params, signal);
+    }
+
     try {
       const searchDirAbsolute = path.resolve(
         this.rootDirectory,
@@ -304,4 +319,45 @@ export class GlobTool extends BaseTool<GlobToolParams, ToolResult> {
       };
     }
   }
+
+  /**
+   * Finds the files on the remote target. .gitignore is not read there;
+   * .git and node_modules are skipped as they are locally.
+   */
+  private async executeRemote(
+    remote: RemoteTarget,
+    params: GlobToolParams,
+    signal: AbortSignal,
+  ): Promise<ToolResult> {
+    const searchDir = params.path || '.';
+    const location = `${formatRemoteTarget(remote)}:${searchDir}`;
+    try {
+      const files = (await listRemoteFiles(remote, searchDir, signal)).filter(
+        (file) => {
+          const relativePath = path.posix.relative(searchDir, file);
+          return micromatch.isMatch(relativePath, params.pattern, {
+            nocase: !params.case_sensitive,
+            dot: true,
+          });
+        },
+      );
+      if (files.length === 0) {
+        return {
+          llmContent: `No files found matching pattern "${params.pattern}" within ${location}.`,
+          returnDisplay: `No files found`,
+        };
+      }
+      return {
+        llmContent: `Found ${files.length} file(s) matching "${params.pattern}" within ${location}, sorted by modification time (newest first):\n${files.join('\n')}`,
+        returnDisplay: `Found ${files.length} matching file(s)`,
+      };
+    } catch (error) {
+      const errorMessage =
+        error instanceof Error ? error.message : String(error);
+      return {
+        llmContent: `Error during glob search operation on ${location}: ${errorMessage}`,
+        returnDisplay: `Error: ${errorMessage}`,
+      };
+    }
+  }
 }
@@ -16,6 +16,9 @@ import { SchemaValidator } from '../utils/schemaValidator.js';
 import { makeRelative, shortenPath } from '../utils/paths.js';
 import { getErrorMessage, isNodeError } from '../utils/errors.js';
 import { isGitRepository } from '../utils/gitUtils.js';
+import { isWithinRoot } from '../utils/fileUtils.js';
+import { grepRemote, validateRemotePath } from '../utils/remoteFiles.js';
+import { formatRemoteTarget, RemoteTarget } from '../utils/remoteTarget.js';
 
 // --- Interfaces ---
 
@@ -59,12 +62,16 @@ export class GrepTool extends BaseTool<GrepToolParams, ToolResult> {
   /**
    * Creates a new instance of the GrepLogic
    * @param rootDirectory Root directory to ground this tool in. All operations will be restricted to this directory.
+   * @param remote SSH host whose files are searched instead of local ones.
    */
-  constructor(private rootDirectory: string) {
+  constructor(
+    private rootDirectory: string,
+    private readonly remote?: RemoteTarget,
+  ) {
     super(
       GrepTool.Name,
       'SearchText',
-      'Searches for a regular expression pattern within the content of files in a specified directory (or current working directory). Can filter files by a glob pattern. Returns the lines containing matches, along with their file paths and line numbers.',
+      `Searches for a regular expression pattern within the content of files in a specified directory (or current working directory)${remote ? ` on the remote host ${formatRemoteTarget(remote)}, where shell commands run` : ''}. Can filter files by a glob pattern. Returns the lines containing matches, along with their file paths and line numbers.`,
       {
         properties: {
           pattern: {
@@ -103,10 +110,7 @@ export class GrepTool extends BaseTool<GrepToolParams, ToolResult> {
     const targetPath = path.resolve(this.rootDirectory, relativePath || '.');
 
     // Security Check: Ensure the resolved path is still within the root directory.
-    if (
-      !targetPath.startsWith(this.rootDirectory) &&
-      targetPath !== this.rootDirectory
-    ) {
+    if (!isWithinRoot(targetPath, this.rootDirectory)) {
       throw new Error(
         `Path validation failed: Attempted path "${relativePath || '.'}" resolves outside the allowed root directory "${this.rootDirectory}".`,
       );
@@ -147,6 +151,10 @@ export class GrepTool extends BaseTool<GrepToolParams, ToolResult> {
       return `Invalid regular expression pattern provided: ${params.pattern}. Error: ${getErrorMessage(error)}`;
     }
 
+    if (this.remote) {
+      return params.path ? validateRemotePath(this.remote, params.path) : null;
+    }
+
     try {
       this.resolveAndValidatePath(params.path);
     } catch (error) {
@@ -177,15 +185,27 @@ export class GrepTool extends BaseTool<GrepToolParams, ToolResult> {
 
     let searchDirAbs: string;
     try {
-      searchDirAbs = this.resolveAndValidatePath(params.path);
+      // Remote paths are POSIX, whatever this machine uses
+      const pathApi = this.remote ? path.posix : path;
+      searchDirAbs = this.remote
+        ? params.path || '.'
+        : this.resolveAndValidatePath(params.path);
       const searchDirDisplay = params.path || '.';
 
-      const matches: GrepMatch[] = await this.performGrepSearch({
-        pattern: params.pattern,
-        path: searchDirAbs,
-        include: params.include,
-        signal,
-      });
+      const matches: GrepMatch[] = this.remote
+        ? await grepRemote(
+            this.remote,
+            params.pattern,
+            searchDirAbs,
+            params.include,
+            signal,
+          )
+        : await this.performGrepSearch({
+            pattern: params.pattern,
+            path: searchDirAbs,
+            include: params.include,
+            signal,
+          });
 
       if (matches.length === 0) {
         const noMatchMsg = `No matches found for pattern "${params.pattern}" in path "${searchDirDisplay}"${params.include ? ` (filter: "${params.include}")` : ''}.`;
@@ -195,10 +215,10 @@ export class GrepTool extends BaseTool<GrepToolParams, ToolResult> {
       const matchesByFile = matches.reduce(
         (acc, match) => {
           const relativeFilePath =
-            path.relative(
+            pathApi.relative(
               searchDirAbs,
-              path.resolve(searchDirAbs, match.filePath),
-            ) || path.basename(match.filePath);
+              pathApi.resolve(searchDirAbs, match.filePath),
+            ) || pathApi.basename(match.filePath);
           if (!acc[relativeFilePath]) {
             acc[relativeFilePath] = [];
           }
@@ -321,7 +341,9 @@ export class GrepTool extends BaseTool<GrepToolParams, ToolResult> {
     if (params.include) {
       description += ` in ${params.include}`;
     }
-    if (params.path) {
+    if (params.path && this.remote) {
+      description += ` within ${formatRemoteTarget(this.remote)}:${params.path}`;
+    } else if (params.path) {
       const resolvedPath = path.resolve(this.rootDirectory, params.path);
       if (resolvedPath === this.rootDirectory || params.path === '.') {
         description += ` within ./`;
@@ -0,0 +1,174 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeAll, afterAll, vi } from 'vitest';
+import dns from 'node:dns';
+import http from 'node:http';
+import net from 'node:net';
+import { Config } from '../config/config.js';
+import {
+  HttpRequestSettings,
+  HttpRequestTool,
+  isHostAllowed,
+  isLocalAddress,
+} from './http-request.js';
+import { ToolConfirmationOutcome } from './tools.js';
+
+function createTool(settings: HttpRequestSettings = {}): HttpRequestTool {
+  return new HttpRequestTool({
+    getHttpRequestSettings: () => settings,
+  } as unknown as Config);
+}
+
+describe('isHostAllowed', () => {
+  it('should match exact hosts and wildcard domains', () => {
+    const patterns = ['api.example.org', '*.zenodo.org'];
+    expect(isHostAllowed('API.example.org', patterns)).toBe(true);
+    expect(isHostAllowed('zenodo.org', patterns)).toBe(true);
+    expect(isHostAllowed('sandbox.zenodo.org', patterns)).toBe(true);
+    expect(isHostAllowed('example.org', patterns)).toBe(false);
+    expect(isHostAllowed('evilzenodo.org', patterns)).toBe(false);
+  });
+});
+
+describe('HttpRequestTool', () => {
+  let server: http.Server;
+  let baseUrl: string;
+  const received: string[] = [];
+
+  beforeAll(async () => {
+    server = http.createServer((req, res) => {
+      let body = '';
+      req.on('data', (chunk) => (body += chunk));
+      req.on('end', () => {
+        received.push(
+          `${req.method} ${req.url} ${req.headers['x-token']} ${body}`,
+        );
+        if (req.url === '/moved') {
+          res.writeHead(302, { Location: 'https://elsewhere.example/' });
+          res.end();
+          return;
+        }
+        res.setHeader('Content-Type', 'application/json');
+        res.end(JSON.stringify({ records: [{ id: 1 }, { id: 2 }], total: 2 }));
+      });
+    });
+    await new Promise<void>((resolve) =>
+      server.listen(0, '127.0.0.1', () => resolve()),
+    );
+    baseUrl = `http://127.0.0.1:${(server.address() as net.AddressInfo).port}`;
+  });
+
+  afterAll(async () => {
+    await new Promise((resolve) => server.close(resolve));
+  });
+
+  it('should refuse private hosts unless they are allowed', () => {
+    expect(
+      createTool().validateToolParams({ url: 'http://192.168.1.5/api' }),
+    ).toContain('must be allowed in httpRequest.allowedHosts');
+    expect(
+      createTool({ allowedHosts: ['*.example.org'] }).validateToolParams({
+        url: 'https://api.other.org/',
+      }),
+    ).toContain('is not in the allowed hosts');
+    expect(
+      createTool().validateToolParams({
+        url: 'https://api.example.org/',
+        method: 'GET',
+        body: '{}',
+      }),
+    ).toBe('A GET request cannot have a body');
+  });
+
+  it('should refuse every form of a loopback or private address', () => {
+    for (const url of [
+      'http://[::1]/',
+      'http://[::ffff:127.0.0.1]/',
+      'http://0.0.0.0/',
+      'http://0.1.2.3/',
+      'http://169.254.169.254/latest/meta-data',
+      'http://[fe80::1]/',
+      'http://0x7f.1/',
+      'http://app.localhost/',
+    ]) {
+      expect(createTool().validateToolParams({ url })).toContain(
+        'must be allowed in httpRequest.allowedHosts',
+      );
+    }
+    expect(isLocalAddress('8.8.8.8')).toBe(false);
+    expect(isLocalAddress('2001:db8::1')).toBe(false);
+  });
+
+  it('should refuse connecting to private addresses a name resolves to', async () => {
+    // Resolved by the connection itself, so no earlier answer counts
+    const lookup = vi.spyOn(dns, 'lookup').mockImplementation(((
+      _hostname: string,
+      _options: unknown,
+      callback: (error: null, addresses: dns.LookupAddress[]) => void,
+    ) => callback(null, [{ address: '10.0.0.7', family: 4 }])) as never);
+    try {
+      const result = await createTool().execute(
+        { url: 'https://internal.example.org/' },
+        new AbortController().signal,
+      );
+      expect(result.llmContent).toContain(
+        'internal.example.org resolves to the local or private address 10.0.0.7',
+      );
+    } finally {
+      lookup.mockRestore();
+    }
+  });
+
+  it('should ask once per host and remember "allow always"', async () => {
+    const tool = createTool();
+    const params = { url: 'https://api.example.org/v1/items', method: 'POST' };
+    const details = await tool.shouldConfirmExecute(
+      params,
+      new AbortController().signal,
+    );
+    expect(details).toMatchObject({
+      type: 'exec',
+      title: 'Confirm HTTP Request to api.example.org',
+      rootCommand: 'api.example.org',
+    });
+    if (details && details.type === 'exec') {
+      await details.onConfirm(ToolConfirmationOutcome.ProceedAlways);
+    }
+    expect(
+      await tool.shouldConfirmExecute(params, new AbortController().signal),
+    ).toBe(false);
+  });
+
+  it('should send the request and render JSON', async () => {
+    const tool = createTool({ allowedHosts: ['127.0.0.1'] });
+    const result = await tool.execute(
+      {
+        url: `${baseUrl}/search`,
+        method: 'POST',
+        headers: ['X-Token: abc', 'Content-Type: application/json'],
+        body: '{"q":"cells"}',
+      },
+      new AbortController().signal,
+    );
+    expect(received).toContain('POST /search abc {"q":"cells"}');
+    expect(result.llmContent).toContain('HTTP 200 OK');
+    expect(result.llmContent).toContain('"total": 2');
+    expect(result.returnDisplay).toContain('▾ records: [2 items]');
+  });
+
+  it('should report redirects instead of following them', async () => {
+    const tool = createTool({ allowedHosts: ['127.0.0.1'] });
+    const result = await tool.execute(
+      { url: `${baseUrl}/moved` },
+      new AbortController().signal,
+    );
+    expect(result.llmContent).toContain('HTTP 302 Found');
+    expect(result.llmContent).toContain(
+      'location: https://elsewhere.example/',
+    );
+  });
+});
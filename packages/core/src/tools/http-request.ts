@@ -0,0 +1,462 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import dns from 'node:dns';
+import net from 'node:net';
+import { Type } from '@google/genai';
+import { Agent } from 'undici';
+import { Config } from '../config/config.js';
+import {
+  BaseTool,
+  ToolResult,
+  ToolCallConfirmationDetails,
+  ToolConfirmationOutcome,
+  ToolExecuteConfirmationDetails,
+} from './tools.js';
+import { SchemaValidator } from '../utils/schemaValidator.js';
+import { getErrorMessage } from '../utils/errors.js';
+import { renderJsonTree } from '../utils/jsonTree.js';
+import {
+  getNetworkSettings,
+  loadTlsOptions,
+  resolveNoProxy,
+  resolveProxy,
+  shouldBypassProxy,
+} from '../utils/network.js';
+
+const DEFAULT_TIMEOUT_MS = 30_000;
+const MAX_RESPONSE_BYTES = 5 * 1024 * 1024;
+const MAX_LLM_CONTENT_LENGTH = 100_000;
+const METHODS = ['GET', 'HEAD', 'POST', 'PUT', 'PATCH', 'DELETE'];
+
+export interface HttpRequestSettings {
+  /**
+   * Hosts requests may go to, as exact names or `*.example.org`. When
+   * unset, any public host may be requested.
+   */
+  allowedHosts?: string[];
+}
+
+/**
+ * Parameters for the HttpRequest tool
+ */
+export interface HttpRequestToolParams {
+  /**
+   * The HTTP method
+   */
+  method?: string;
+
+  /**
+   * The absolute http(s) URL
+   */
+  url: string;
+
+  /**
+   * Request headers as `Name: value` strings
+   */
+  headers?: string[];
+
+  /**
+   * The request body
+   */
+  body?: string;
+}
+
+/**
+ * Matches a hostname against allowlist entries: exact names, or
+ * `*.example.org` for the domain and its subdomains.
+ */
+export function isHostAllowed(hostname: string, patterns: string[]): boolean {
+  const host = hostname.toLowerCase().replace(/^\[|\]$/g, '');
+  return patterns.some((pattern) => {
+    const entry = pattern.trim().toLowerCase();
+    if (entry.startsWith('*.')) {
+      const domain = entry.slice(2);
+      return host === domain || host.endsWith(`.${domain}`);
+    }
+    return host === entry;
+  });
+}
+
+// Unspecified, loopback, private, shared (CGNAT) and link-local ranges
+const LOCAL_ADDRESSES = new net.BlockList();
+for (const [prefix, bits] of [
+  ['0.0.0.0', 8],
+  ['10.0.0.0', 8],
+  ['100.64.0.0', 10],
+  ['127.0.0.0', 8],
+  ['169.254.0.0', 16],
+  ['172.16.0.0', 12],
+  ['192.168.0.0', 16],
+] as const) {
+  LOCAL_ADDRESSES.addSubnet(prefix, bits, 'ipv4');
+}
+for (const [prefix, bits] of [
+  ['::', 128],
+  ['::1', 128],
+  ['fc00::', 7],
+  ['fe80::', 10],
+] as const) {
+  LOCAL_ADDRESSES.addSubnet(prefix, bits, 'ipv6');
+}
+
+/** The IPv4 address in an IPv4-mapped IPv6 address like `::ffff:7f00:1`. */
+function mappedIPv4(address: string): string | undefined {
+  const dotted = address.match(/^::ffff:(\d+\.\d+\.\d+\.\d+)$/i);
+  if (dotted) {
+    return dotted[1];
+  }
+  const hex = address.match(/^::ffff:([0-9a-f]{1,4}):([0-9a-f]{1,4})$/i);
+  if (!hex) {
+    return undefined;
+  }
+  const high = parseInt(hex[1], 16);
+  const low = parseInt(hex[2], 16);
+  return [high >> 8, high & 0xff, low >> 8, low & 0xff].join('.');
+}
+
+/**
+ * Whether an IP address is on this machine or a private network:
+ * loopback, private, link-local or unspecified, IPv4-mapped included.
+ */
+export function isLocalAddress(address: string): boolean {
+  const ip = address.replace(/^\[|\]$/g, '');
+  const version = net.isIP(ip);
+  if (version === 0) {
+    return false;
+  }
+  const ipv4 = version === 6 ? mappedIPv4(ip) : ip;
+  return ipv4
+    ? LOCAL_ADDRESSES.check(ipv4, 'ipv4')
+    : LOCAL_ADDRESSES.check(ip, 'ipv6');
+}
+
+function isLocalHost(url: URL): boolean {
+  const host = url.hostname.toLowerCase();
+  return (
+    host === 'localhost' ||
+    host.endsWith('.localhost') ||
+    isLocalAddress(host)
+  );
+}
+
+/** A connection refused because the host resolved to a local address. */
+class LocalAddressError extends Error {
+  constructor(
+    readonly hostname: string,
+    readonly address: string,
+  ) {
+    super(
+      `${hostname} resolves to the local or private address ${address}; requests to it must be allowed in httpRequest.allowedHosts first.`,
+    );
+  }
+}
+
+/**
+ * A `dns.lookup` for the connection itself that fails when the host
+ * resolves to a local or private address. Checking the address the socket
+ * connects to, rather than resolving the name beforehand, leaves no
+ * second lookup for a hostile DNS server to answer differently.
+ */
+const lookupPublicAddress: net.LookupFunction = (hostname, options, callback) => {
+  dns.lookup(hostname, { ...options, all: true }, (error, addresses) => {
+    if (error) {
+      callback(error, '', 0);
+      return;
+    }
+    const local = addresses.find(({ address }) => isLocalAddress(address));
+    if (local) {
+      callback(new LocalAddressError(hostname, local.address), '', 0);
+    } else if (options.all) {
+      (callback as unknown as (e: null, all: dns.LookupAddress[]) => void)(
+        null,
+        addresses,
+      );
+    } else {
+      callback(null, addresses[0].address, addresses[0].family);
+    }
+  });
+};
+
+/** The LocalAddressError somewhere in the causes of a failed fetch. */
+function findLocalAddressError(error: unknown): LocalAddressError | undefined {
+  for (let e = error; e instanceof Error; e = e.cause) {
+    if (e instanceof LocalAddressError) {
+      return e;
+    }
+  }
+  return undefined;
+}
+
+function parseHeaders(headers: string[] = []): Record<string, string> {
+  const result: Record<string, string> = {};
+  for (const header of headers) {
+    const index = header.indexOf(':');
+    if (index <= 0) {
+      throw new Error(`Invalid header "${header}"; expected "Name: value"`);
+    }
+    result[header.slice(0, index).trim()] = header.slice(index + 1).trim();
+  }
+  return result;
+}
+
+async function readBody(response: Response): Promise<Buffer> {
+  if (!response.body) {
+    return Buffer.alloc(0);
+  }
+  const reader = response.body.getReader();
+  const chunks: Buffer[] = [];
+  let size = 0;
+  while (true) {
+    const { done, value } = await reader.read();
+    if (done) {
+      break;
+    }
+    chunks.push(Buffer.from(value));
+    size += value.length;
+    if (size > MAX_RESPONSE_BYTES) {
+      await reader.cancel();
+      throw new Error(
+        `Response is larger than ${MAX_RESPONSE_BYTES / (1024 * 1024)}MB`,
+      );
+    }
+  }
+  return Buffer.concat(chunks);
+}
+
+/**
+ * Sends one HTTP request, such as a call to a lab API or data repository,
+ * after the user approves it. Redirects are reported, not followed, so
+ * every host reached is checked and approved.
+ */
+export class HttpRequestTool extends BaseTool<
+  HttpRequestToolParams,
+  ToolResult
+> {
+  static readonly Name: string = 'http_request';
+  private approvedHosts: Set<string> = new Set();
+
+  constructor(private readonly config: Config) {
+    const allowedHosts = config.getHttpRequestSettings().allowedHosts;
+    super(
+      HttpRequestTool.Name,
+      'HttpRequest',
+      `Sends an HTTP request and returns the status, headers and body (JSON bodies are pretty-printed). Use it for REST APIs of labs, data repositories and services; use web_fetch to read web pages. The user approves requests per host. Redirects are not followed; request the Location yourself if needed.${allowedHosts?.length ? ` Allowed hosts: ${allowedHosts.join(', ')}.` : ''}`,
+      {
+        properties: {
+          method: {
+            description: `The HTTP method: ${METHODS.join(', ')}. Defaults to GET.`,
+            type: Type.STRING,
+            enum: METHODS,
+          },
+          url: {
+            description: 'The absolute http:// or https:// URL.',
+            type: Type.STRING,
+          },
+          headers: {
+            description:
+              "Request headers, each 'Name: value', e.g. 'Accept: application/json'.",
+            type: Type.ARRAY,
+            items: { type: Type.STRING },
+          },
+          body: {
+            description:
+              'The request body, e.g. JSON. Set a Content-Type header to match.',
+            type: Type.STRING,
+          },
+        },
+        required: ['url'],
+        type: Type.OBJECT,
+      },
+    );
+  }
+
+  validateToolParams(params: HttpRequestToolParams): string | null {
+    const errors = SchemaValidator.validate(this.schema.parameters, params);
+    if (errors) {
+      return errors;
+    }
+    let url: URL;
+    try {
+      url = new URL(params.url);
+    } catch {
+      return `Invalid URL: ${params.url}`;
+    }
+    if (url.protocol !== 'http:' && url.protocol !== 'https:') {
+      return `Only http and https URLs are supported: ${params.url}`;
+    }
+    const method = (params.method ?? 'GET').toUpperCase();
+    if (!METHODS.includes(method)) {
+      return `Unsupported method ${method}; use one of ${METHODS.join(', ')}`;
+    }
+    if (params.body && (method === 'GET' || method === 'HEAD')) {
+      return `A ${method} request cannot have a body`;
+    }
+    const allowedHosts = this.config.getHttpRequestSettings().allowedHosts;
+    if (allowedHosts?.length) {
+      if (!isHostAllowed(url.hostname, allowedHosts)) {
+        return `Host ${url.hostname} is not in the allowed hosts (${allowedHosts.join(', ')}). Add it to httpRequest.allowedHosts in settings to use it.`;
+      }
+    } else if (isLocalHost(url)) {
+      return `Requests to local and private hosts (${url.hostname}) must be allowed in httpRequest.allowedHosts first.`;
+    }
+    try {
+      parseHeaders(params.headers);
+    } catch (error) {
+      return getErrorMessage(error);
+    }
+    return null;
+  }
+
+  getDescription(params: HttpRequestToolParams): string {
+    return `${(params.method ?? 'GET').toUpperCase()} ${params.url}`;
+  }
+
+  async shouldConfirmExecute(
+    params: HttpRequestToolParams,
+    _abortSignal: AbortSignal,
+  ): Promise<ToolCallConfirmationDetails | false> {
+    if (this.validateToolParams(params)) {
+      return false; // execute reports the error
+    }
+    const host = new URL(params.url).host;
+    if (this.approvedHosts.has(host)) {
+      return false;
+    }
+    const summary = [
+      this.getDescription(params),
+      ...(params.headers ?? []),
+      ...(params.body ? ['', params.body] : []),
+    ].join('\n');
+    const confirmationDetails: ToolExecuteConfirmationDetails = {
+      type: 'exec',
+      title: `Confirm HTTP Request to ${host}`,
+      command: summary,
+      rootCommand: host,
+      onConfirm: async (outcome: ToolConfirmationOutcome) => {
+        if (outcome === ToolConfirmationOutcome.ProceedAlways) {
+          this.approvedHosts.add(host);
+        }
+      },
+    };
+    return confirmationDetails;
+  }
+
+  /**
+   * A dispatcher that connects directly and refuses local and private
+   * addresses, unless the host is allowed explicitly or the request goes
+   * through a proxy, which resolves the name itself.
+   */
+  private createDispatcher(url: string): Agent | undefined {
+    if (this.config.getHttpRequestSettings().allowedHosts?.length) {
+      return undefined;
+    }
+    const settings = getNetworkSettings();
+    if (
+      resolveProxy(settings) &&
+      !shouldBypassProxy(url, resolveNoProxy(settings))
+    ) {
+      return undefined;
+    }
+    return new Agent({
+      connect: { ...loadTlsOptions(settings), lookup: lookupPublicAddress },
+    });
+  }
+
+  async execute(
+    params: HttpRequestToolParams,
+    signal: AbortSignal,
+  ): Promise<ToolResult> {
+    const validationError = this.validateToolParams(params);
+    if (validationError) {
+      return {
+        llmContent: `Error: Invalid parameters provided. Reason: ${validationError}`,
+        returnDisplay: `Error: ${validationError}`,
+      };
+    }
+
+    const method = (params.method ?? 'GET').toUpperCase();
+    let response: Response;
+    let body: Buffer;
+    const dispatcher = this.createDispatcher(params.url);
+    try {
+      response = await fetch(params.url, {
+        dispatcher,
+        method,
+        headers: parseHeaders(params.headers),
+        body: params.body,
+        redirect: 'manual',
+        signal: AbortSignal.any([
+          signal,
+          AbortSignal.timeout(DEFAULT_TIMEOUT_MS),
+        ]),
+      } as RequestInit);
+      body = await readBody(response);
+    } catch (error) {
+      const localAddressError = findLocalAddressError(error);
+      if (localAddressError) {
+        return {
+          llmContent: `Error: Invalid parameters provided. Reason: ${localAddressError.message}`,
+          returnDisplay: `Error: ${localAddressError.message}`,
+        };
+      }
+      const message = signal.aborted
+        ? 'Request was cancelled.'
+        : `Request failed: ${getErrorMessage(error)}`;
+      return {
+        llmContent: `Error: ${message}`,
+        returnDisplay: `Error: ${message}`,
+      };
+    } finally {
+      void dispatcher?.close();
+    }
+
+    const status = `HTTP ${response.status} ${response.statusText}`.trim();
+    const contentType = response.headers.get('content-type') ?? '';
+    const headerLines = ['content-type', 'content-length', 'location']
+      .filter((name) => response.headers.has(name))
+      .map((name) => `${name}: ${response.headers.get(name)}`);
+    const head = [status, ...headerLines].join('\n');
+
+    if (body.length === 0) {
+      return { llmContent: head, returnDisplay: head };
+    }
+
+    const isText =
+      /json|text|xml|javascript|csv|yaml|x-www-form-urlencoded/i.test(
+        contentType,
+      ) || contentType === '';
+    if (!isText) {
+      const note = `${head}\n\n[${body.length} bytes of ${contentType} not shown]`;
+      return { llmContent: note, returnDisplay: note };
+    }
+
+    const text = body.toString('utf8');
+    let json: unknown;
+    let isJson = false;
+    if (/json/i.test(contentType) || /^\s*[[{]/.test(text)) {
+      try {
+        json = JSON.parse(text);
+        isJson = true;
+      } catch {
+        // Not JSON after all; shown as text.
+      }
+    }
+
+    const content = isJson ? JSON.stringify(json, null, 2) : text;
+    const truncated =
+      content.length > MAX_LLM_CONTENT_LENGTH
+        ? `${content.slice(0, MAX_LLM_CONTENT_LENGTH)}\n... [response truncated]`
+        : content;
+    const display = isJson
+      ? renderJsonTree(json)
+      : text.split('\n').slice(0, 40).join('\n');
+    return {
+      llmContent: `${head}\n\n${truncated}`,
+      returnDisplay: `${head}\n\n\`\`\`\n${display}\n\`\`\``,
+    };
+  }
+}
@@ -6,6 +6,7 @@
 
 import fs from 'fs';
 import path from 'path';
+import { isWithinRoot } from '../utils/fileUtils.js';
 import { BaseTool, ToolResult } from './tools.js';
 import { Type } from '@google/genai';
 import { SchemaValidator } from '../utils/schemaValidator.js';
@@ -115,16 +116,7 @@ export class LSTool extends BaseTool<LSToolParams, ToolResult> {
    * @returns True if the path is within the root directory, false otherwise
    */
   private isWithinRoot(dirpath: string): boolean {
-    const normalizedPath = path.normalize(dirpath);
-    const normalizedRoot = path.normalize(this.rootDirectory);
-    // Ensure the normalizedRoot ends with a path separator for proper path comparison
-    const rootWithSep = normalizedRoot.endsWith(path.sep)
-      ? normalizedRoot
-      : normalizedRoot + path.sep;
-    return (
-      normalizedPath === normalizedRoot ||
-      normalizedPath.startsWith(rootWithSep)
-    );
+    return isWithinRoot(dirpath, this.rootDirectory);
   }
 
   /**
@@ -11,7 +11,11 @@ import {
   getCurrentResearchMdFilename,
   getAllResearchMdFilenames,
   DEFAULT_CONTEXT_FILENAME,
+  readMemoryEntries,
+  updateMemoryEntry,
 } from './memoryTool.js';
+import { ApprovalMode, Config } from '../config/config.js';
+import { ToolConfirmationOutcome } from './tools.js';
 import * as fs from 'fs/promises';
 import * as path from 'path';
 import * as os from 'os';
@@ -262,4 +266,76 @@ describe('MemoryTool', () => {
       );
     });
   });
+
+  describe('shouldConfirmExecute', () => {
+    const config = (approvalMode: ApprovalMode) =>
+      ({ getApprovalMode: () => approvalMode }) as unknown as Config;
+
+    it('should ask before saving a fact in the default mode', async () => {
+      const memoryTool = new MemoryTool(config(ApprovalMode.DEFAULT));
+      const details = await memoryTool.shouldConfirmExecute({
+        fact: 'Our cluster uses SLURM',
+      });
+
+      expect(details).toMatchObject({
+        type: 'info',
+        prompt: 'Remember in all future sessions: "Our cluster uses SLURM"',
+      });
+      if (details) {
+        await details.onConfirm(ToolConfirmationOutcome.ProceedAlways);
+      }
+      expect(await memoryTool.shouldConfirmExecute({ fact: 'More' })).toBe(
+        false,
+      );
+    });
+
+    it('should not ask when edits are auto-approved', async () => {
+      const memoryTool = new MemoryTool(config(ApprovalMode.AUTO_EDIT));
+      expect(await memoryTool.shouldConfirmExecute({ fact: 'A fact' })).toBe(
+        false,
+      );
+    });
+  });
+
+  describe('memory entries', () => {
+    const testFilePath = '/mock/home/.research/RESEARCH.md';
+    const content = [
+      '# Notes',
+      '',
+      MEMORY_SECTION_HEADER,
+      '- My advisor is Dr. Lee',
+      '- Our cluster uses SLURM',
+      '',
+      '## Other',
+      '- Not a memory',
+    ].join('\n');
+
+    beforeEach(() => {
+      vi.mocked(fs.readFile).mockResolvedValue(content);
+    });
+
+    it('should list the saved facts only', async () => {
+      expect(await readMemoryEntries(testFilePath)).toEqual([
+        'My advisor is Dr. Lee',
+        'Our cluster uses SLURM',
+      ]);
+    });
+
+    it('should edit and delete a fact in place', async () => {
+      expect(
+        await updateMemoryEntry(1, 'Our cluster uses PBS', testFilePath),
+      ).toBe('Our cluster uses SLURM');
+      expect(vi.mocked(fs.writeFile).mock.calls[0][1]).toBe(
+        content.replace('SLURM', 'PBS'),
+      );
+
+      await updateMemoryEntry(0, undefined, testFilePath);
+      expect(vi.mocked(fs.writeFile).mock.calls[1][1]).toBe(
+        content.replace('- My advisor is Dr. Lee\n', ''),
+      );
+      await expect(
+        updateMemoryEntry(2, undefined, testFilePath),
+      ).rejects.toThrow('There is no memory #3.');
+    });
+  });
 });
@@ -4,11 +4,17 @@
  * SPDX-License-Identifier: Apache-2.0
  */
 
-import { BaseTool, ToolResult } from './tools.js';
+import {
+  BaseTool,
+  ToolCallConfirmationDetails,
+  ToolConfirmationOutcome,
+  ToolResult,
+} from './tools.js';
 import { FunctionDeclaration, Type } from '@google/genai';
 import * as fs from 'fs/promises';
 import * as path from 'path';
-import { homedir } from 'os';
+import { ApprovalMode, Config } from '../config/config.js';
+import { getUserResearchDir } from '../utils/paths.js';
 
 const memoryToolSchemaData: FunctionDeclaration = {
   name: 'save_memory',
@@ -83,11 +89,7 @@ interface SaveMemoryParams {
 }
 
 function getGlobalMemoryFilePath(): string {
-  return path.join(
-    homedir(),
-    RESEARCH_CONFIG_DIR,
-    getCurrentResearchMdFilename(),
-  );
+  return path.join(getUserResearchDir(), getCurrentResearchMdFilename());
 }
 
 /**
@@ -102,9 +104,90 @@ function ensureNewlineSeparation(currentContent: string): string {
   return '\n\n';
 }
 
+/** The `- ` entries of the saved memories section and where they are. */
+function parseMemoryEntries(content: string): {
+  lines: string[];
+  entryLines: number[];
+} {
+  const lines = content.split('\n');
+  const header = lines.findIndex(
+    (line) => line.trim() === MEMORY_SECTION_HEADER,
+  );
+  const entryLines: number[] = [];
+  if (header !== -1) {
+    for (let i = header + 1; i < lines.length; i++) {
+      if (lines[i].startsWith('## ')) {
+        break;
+      }
+      if (lines[i].startsWith('- ')) {
+        entryLines.push(i);
+      }
+    }
+  }
+  return { lines, entryLines };
+}
+
+async function readMemoryFile(memoryFilePath: string): Promise<string> {
+  try {
+    return await fs.readFile(memoryFilePath, 'utf-8');
+  } catch {
+    return '';
+  }
+}
+
+/** The facts saved with save_memory or /memory add, oldest first. */
+export async function readMemoryEntries(
+  memoryFilePath: string = getGlobalMemoryFilePath(),
+): Promise<string[]> {
+  const { lines, entryLines } = parseMemoryEntries(
+    await readMemoryFile(memoryFilePath),
+  );
+  return entryLines.map((i) => lines[i].slice(2).trim());
+}
+
+/** Saves a fact without asking, for facts the user adds themselves. */
+export async function addMemoryEntry(
+  fact: string,
+  memoryFilePath: string = getGlobalMemoryFilePath(),
+): Promise<void> {
+  await MemoryTool.performAddMemoryEntry(fact, memoryFilePath, {
+    readFile: fs.readFile,
+    writeFile: fs.writeFile,
+    mkdir: fs.mkdir,
+  });
+}
+
+/**
+ * Replaces the saved fact at `index` (from 0) with `fact`, or deletes it
+ * when `fact` is undefined. Returns the previous text.
+ */
+export async function updateMemoryEntry(
+  index: number,
+  fact: string | undefined,
+  memoryFilePath: string = getGlobalMemoryFilePath(),
+): Promise<string> {
+  const { lines, entryLines } = parseMemoryEntries(
+    await readMemoryFile(memoryFilePath),
+  );
+  const line = entryLines[index];
+  if (line === undefined) {
+    throw new Error(`There is no memory #${index + 1}.`);
+  }
+  const previous = lines[line].slice(2).trim();
+  if (fact === undefined) {
+    lines.splice(line, 1);
+  } else {
+    lines[line] = `- ${fact.trim().replace(/^(-+\s*)+/, '')}`;
+  }
+  await fs.writeFile(memoryFilePath, lines.join('\n'), 'utf-8');
+  return previous;
+}
+
 export class MemoryTool extends BaseTool<SaveMemoryParams, ToolResult> {
   static readonly Name: string = memoryToolSchemaData.name!;
-  constructor() {
+  private alwaysAllowed = false;
+
+  constructor(private readonly config?: Config) {
     super(
       MemoryTool.Name,
       'Save Memory',
@@ -183,6 +266,34 @@ export class MemoryTool extends BaseTool<SaveMemoryParams, ToolResult> {
     }
   }
 
+  /**
+   * Asks before the model saves a fact, since saved facts are sent with
+   * every later session.
+   */
+  async shouldConfirmExecute(
+    params: SaveMemoryParams,
+  ): Promise<ToolCallConfirmationDetails | false> {
+    if (
+      this.alwaysAllowed ||
+      !this.config ||
+      this.config.getApprovalMode() !== ApprovalMode.DEFAULT ||
+      typeof params.fact !== 'string' ||
+      !params.fact.trim()
+    ) {
+      return false;
+    }
+    return {
+      type: 'info',
+      title: 'Confirm Save Memory',
+      prompt: `Remember in all future sessions: "${params.fact.trim()}"`,
+      onConfirm: async (outcome: ToolConfirmationOutcome) => {
+        if (outcome === ToolConfirmationOutcome.ProceedAlways) {
+          this.alwaysAllowed = true;
+        }
+      },
+    };
+  }
+
   async execute(
     params: SaveMemoryParams,
     _signal: AbortSignal,
@@ -0,0 +1,263 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'fs';
+import path from 'path';
+import { isWithinRoot } from '../utils/fileUtils.js';
+import * as Diff from 'diff';
+import { Type } from '@google/genai';
+import { Config, ApprovalMode } from '../config/config.js';
+import {
+  BaseTool,
+  ToolResult,
+  FileDiff,
+  ToolEditConfirmationDetails,
+  ToolConfirmationOutcome,
+  ToolCallConfirmationDetails,
+} from './tools.js';
+import { SchemaValidator } from '../utils/schemaValidator.js';
+import { makeRelative, shortenPath } from '../utils/paths.js';
+import { getErrorMessage } from '../utils/errors.js';
+import { DEFAULT_DIFF_OPTIONS } from './diffOptions.js';
+import {
+  Notebook,
+  NotebookCellType,
+  editNotebookCell,
+  parseNotebook,
+  renderNotebook,
+  serializeNotebook,
+} from '../utils/notebook.js';
+
+/**
+ * Parameters for the NotebookEdit tool
+ */
+export interface NotebookEditToolParams {
+  /**
+   * The absolute path to the .ipynb file
+   */
+  notebook_path: string;
+
+  /**
+   * 1-based number of the cell to change, as shown when the notebook is read
+   */
+  cell_number: number;
+
+  /**
+   * The new source of the cell; unused when deleting
+   */
+  new_source?: string;
+
+  /**
+   * The cell type; defaults to the current type, or code for inserts
+   */
+  cell_type?: NotebookCellType;
+
+  /**
+   * Whether to replace, insert before, or delete the cell
+   */
+  edit_mode?: 'replace' | 'insert' | 'delete';
+}
+
+interface NotebookEditPlan {
+  original: Notebook;
+  updated: Notebook;
+}
+
+/**
+ * Edits a single cell of a Jupyter notebook, keeping the rest of the file,
+ * including outputs and metadata, intact.
+ */
+export class NotebookEditTool extends BaseTool<
+  NotebookEditToolParams,
+  ToolResult
+> {
+  static readonly Name: string = 'notebook_edit';
+
+  constructor(private readonly config: Config) {
+    super(
+      NotebookEditTool.Name,
+      'NotebookEdit',
+      `Replaces, inserts or deletes one cell of a Jupyter notebook (.ipynb). Read the notebook first to see its numbered cells. Use this instead of write_file for notebooks, so outputs and metadata of other cells are kept. Replacing a code cell clears its outputs.`,
+      {
+        properties: {
+          notebook_path: {
+            description:
+              "The absolute path to the notebook (e.g., '/home/user/project/analysis.ipynb'). Relative paths are not supported.",
+            type: Type.STRING,
+          },
+          cell_number: {
+            description:
+              'The 1-based cell number. For insert, the new cell takes this number; use the cell count plus one to append.',
+            type: Type.NUMBER,
+          },
+          new_source: {
+            description: 'The new source of the cell. Not used for delete.',
+            type: Type.STRING,
+          },
+          cell_type: {
+            description:
+              "The cell type: 'code' or 'markdown'. Defaults to the current type, or 'code' for insert.",
+            type: Type.STRING,
+            enum: ['code', 'markdown', 'raw'],
+          },
+          edit_mode: {
+            description:
+              "'replace' (default), 'insert' a new cell before cell_number, or 'delete' the cell.",
+            type: Type.STRING,
+            enum: ['replace', 'insert', 'delete'],
+          },
+        },
+        required: ['notebook_path', 'cell_number'],
+        type: Type.OBJECT,
+      },
+    );
+  }
+
+  private isWithinRoot(pathToCheck: string): boolean {
+    return isWithinRoot(pathToCheck, this.config.getTargetDir());
+  }
+
+  validateToolParams(params: NotebookEditToolParams): string | null {
+    const errors = SchemaValidator.validate(this.schema.parameters, params);
+    if (errors) {
+      return errors;
+    }
+
+    const filePath = params.notebook_path;
+    if (!path.isAbsolute(filePath)) {
+      return `File path must be absolute: ${filePath}`;
+    }
+    if (!this.isWithinRoot(filePath)) {
+      return `File path must be within the root directory (${this.config.getTargetDir()}): ${filePath}`;
+    }
+    if (path.extname(filePath).toLowerCase() !== '.ipynb') {
+      return `Not a notebook (.ipynb) file: ${filePath}`;
+    }
+    if ((params.edit_mode ?? 'replace') !== 'delete') {
+      if (params.new_source === undefined) {
+        return `new_source is required to ${params.edit_mode ?? 'replace'} a cell`;
+      }
+    }
+    return null;
+  }
+
+  getDescription(params: NotebookEditToolParams): string {
+    if (!params.notebook_path || params.cell_number === undefined) {
+      return `Model did not provide valid parameters for notebook edit tool`;
+    }
+    const relativePath = makeRelative(
+      params.notebook_path,
+      this.config.getTargetDir(),
+    );
+    const mode = params.edit_mode ?? 'replace';
+    const verb =
+      mode === 'insert' ? 'Inserting' : mode === 'delete' ? 'Deleting' : 'Editing';
+    return `${verb} cell ${params.cell_number} of ${shortenPath(relativePath)}`;
+  }
+
+  private planEdit(params: NotebookEditToolParams): NotebookEditPlan {
+    const original = parseNotebook(
+      fs.readFileSync(params.notebook_path, 'utf8'),
+    );
+    const updated = editNotebookCell(original, {
+      cellNumber: params.cell_number,
+      mode: params.edit_mode ?? 'replace',
+      source: params.new_source,
+      cellType: params.cell_type,
+    });
+    return { original, updated };
+  }
+
+  private createDiff(
+    params: NotebookEditToolParams,
+    plan: NotebookEditPlan,
+    oldHeader: string,
+    newHeader: string,
+  ): string {
+    // The rendered cells diff far more readably than the notebook JSON.
+    return Diff.createPatch(
+      path.basename(params.notebook_path),
+      renderNotebook(plan.original),
+      renderNotebook(plan.updated),
+      oldHeader,
+      newHeader,
+      DEFAULT_DIFF_OPTIONS,
+    );
+  }
+
+  async shouldConfirmExecute(
+    params: NotebookEditToolParams,
+    _abortSignal: AbortSignal,
+  ): Promise<ToolCallConfirmationDetails | false> {
+    if (this.config.getApprovalMode() === ApprovalMode.AUTO_EDIT) {
+      return false;
+    }
+    if (this.validateToolParams(params)) {
+      return false;
+    }
+
+    let plan: NotebookEditPlan;
+    try {
+      plan = this.planEdit(params);
+    } catch {
+      // execute() reports the error.
+      return false;
+    }
+
+    const relativePath = makeRelative(
+      params.notebook_path,
+      this.config.getTargetDir(),
+    );
+    const confirmationDetails: ToolEditConfirmationDetails = {
+      type: 'edit',
+      title: `Confirm Notebook Edit: ${shortenPath(relativePath)}`,
+      fileName: path.basename(params.notebook_path),
+      fileDiff: this.createDiff(params, plan, 'Current', 'Proposed'),
+      onConfirm: async (outcome: ToolConfirmationOutcome) => {
+        if (outcome === ToolConfirmationOutcome.ProceedAlways) {
+          this.config.setApprovalMode(ApprovalMode.AUTO_EDIT);
+        }
+      },
+    };
+    return confirmationDetails;
+  }
+
+  async execute(
+    params: NotebookEditToolParams,
+    _abortSignal: AbortSignal,
+  ): Promise<ToolResult> {
+    const validationError = this.validateToolParams(params);
+    if (validationError) {
+      return {
+        llmContent: `Error: Invalid parameters provided. Reason: ${validationError}`,
+        returnDisplay: `Error: ${validationError}`,
+      };
+    }
+
+    try {
+      const plan = this.planEdit(params);
+      fs.writeFileSync(
+        params.notebook_path,
+        serializeNotebook(plan.updated),
+        'utf8',
+      );
+      const displayResult: FileDiff = {
+        fileDiff: this.createDiff(params, plan, 'Original', 'Written'),
+        fileName: path.basename(params.notebook_path),
+      };
+      return {
+        llmContent: `Successfully applied ${params.edit_mode ?? 'replace'} to cell ${params.cell_number} of ${params.notebook_path}. The notebook now has ${plan.updated.cells.length} cells.`,
+        returnDisplay: displayResult,
+      };
+    } catch (error) {
+      const errorMsg = `Error editing notebook: ${getErrorMessage(error)}`;
+      return {
+        llmContent: `Error editing notebook ${params.notebook_path}: ${getErrorMessage(error)}`,
+        returnDisplay: errorMsg,
+      };
+    }
+  }
+}
@@ -12,8 +12,13 @@ import { Type } from '@google/genai';
 import {
   isWithinRoot,
   processSingleFileContent,
+  processTextContent,
   getSpecificMimeType,
+  ProcessedFileReadResult,
 } from '../utils/fileUtils.js';
+import { readRemoteFile, validateRemotePath } from '../utils/remoteFiles.js';
+import { formatRemoteTarget, RemoteTarget } from '../utils/remoteTarget.js';
+import { getErrorMessage } from '../utils/errors.js';
 import { Config } from '../config/config.js';
 import {
   recordFileOperationMetric,
@@ -46,14 +51,20 @@ export interface ReadFileToolParams {
 export class ReadFileTool extends BaseTool<ReadFileToolParams, ToolResult> {
   static readonly Name: string = 'read_file';
 
+  /**
+   * @param remote SSH host whose files are read instead of local ones.
+   */
   constructor(
     private rootDirectory: string,
     private config: Config,
+    private readonly remote?: RemoteTarget,
   ) {
     super(
       ReadFileTool.Name,
       'ReadFile',
-      'Reads and returns the content of a specified file from the local filesystem. Handles text, images (PNG, JPG, GIF, WEBP, SVG, BMP), and PDF files. For text files, it can read specific line ranges.',
+      remote
+        ? `Reads and returns the content of a specified text file on the remote host ${formatRemoteTarget(remote)}, where shell commands run. It can read specific line ranges.`
+        : 'Reads and returns the content of a specified file from the local filesystem. Handles text, images (PNG, JPG, GIF, WEBP, SVG, BMP), and PDF files. For text files, it can read specific line ranges.',
       {
         properties: {
           absolute_path: {
@@ -86,10 +97,15 @@ export class ReadFileTool extends BaseTool<ReadFileToolParams, ToolResult> {
     }
 
     const filePath = params.absolute_path;
-    if (!path.isAbsolute(filePath)) {
+    const remote = this.remote;
+    if (remote) {
+      const remotePathError = validateRemotePath(remote, filePath);
+      if (remotePathError) {
+        return remotePathError;
+      }
+    } else if (!path.isAbsolute(filePath)) {
       return `File path must be absolute, but was relative: ${filePath}. You must provide an absolute path.`;
-    }
-    if (!isWithinRoot(filePath, this.rootDirectory)) {
+    } else if (!isWithinRoot(filePath, this.rootDirectory)) {
       return `File path must be within the root directory (${this.rootDirectory}): ${filePath}`;
     }
     if (params.offset !== undefined && params.offset < 0) {
@@ -100,7 +116,10 @@ export class ReadFileTool extends BaseTool<ReadFileToolParams, ToolResult> {
     }
 
     const fileService = this.config.getFileService();
-    if (fileService.shouldResearchIgnoreFile(params.absolute_path)) {
+    if (
+      !remote &&
+      fileService.shouldResearchIgnoreFile(params.absolute_path)
+    ) {
       return `File path '${filePath}' is ignored by .researchignore pattern(s).`;
     }
 
@@ -115,13 +134,19 @@ export class ReadFileTool extends BaseTool<ReadFileToolParams, ToolResult> {
     ) {
       return `Path unavailable`;
     }
+    const remote = this.remote;
+    if (remote) {
+      return shortenPath(
+        `${formatRemoteTarget(remote)}:${params.absolute_path}`,
+      );
+    }
     const relativePath = makeRelative(params.absolute_path, this.rootDirectory);
     return shortenPath(relativePath);
   }
 
   async execute(
     params: ReadFileToolParams,
-    _signal: AbortSignal,
+    signal: AbortSignal,
   ): Promise<ToolResult> {
     const validationError = this.validateToolParams(params);
     if (validationError) {
@@ -131,12 +156,15 @@ export class ReadFileTool extends BaseTool<ReadFileToolParams, ToolResult> {
       };
     }
 
-    const result = await processSingleFileContent(
-      params.absolute_path,
-      this.rootDirectory,
-      params.offset,
-      params.limit,
-    );
+    const remote = this.remote;
+    const result = remote
+      ? await this.readRemote(remote, params, signal)
+      : await processSingleFileContent(
+          params.absolute_path,
+          this.rootDirectory,
+          params.offset,
+          params.limit,
+        );
 
     if (result.error) {
       return {
@@ -163,4 +191,30 @@ export class ReadFileTool extends BaseTool<ReadFileToolParams, ToolResult> {
       returnDisplay: result.returnDisplay,
     };
   }
+
+  /** Reads a text file on the remote target, as the local read does. */
+  private async readRemote(
+    remote: RemoteTarget,
+    params: ReadFileToolParams,
+    signal: AbortSignal,
+  ): Promise<ProcessedFileReadResult> {
+    const filePath = params.absolute_path;
+    try {
+      const content = await readRemoteFile(remote, filePath, signal);
+      if (content === undefined) {
+        return {
+          llmContent: '',
+          returnDisplay: 'File not found.',
+          error: `File not found on ${formatRemoteTarget(remote)}: ${filePath}`,
+        };
+      }
+      return processTextContent(content, params.offset, params.limit);
+    } catch (error) {
+      return {
+        llmContent: '',
+        returnDisplay: 'Could not read the file.',
+        error: `Could not read ${filePath} on ${formatRemoteTarget(remote)}: ${getErrorMessage(error)}`,
+      };
+    }
+  }
 }
@@ -15,6 +15,7 @@ import {
   processSingleFileContent,
   DEFAULT_ENCODING,
   getSpecificMimeType,
+  isWithinRoot,
 } from '../utils/fileUtils.js';
 import { PartListUnion, Schema, Type } from '@google/genai';
 import { Config } from '../config/config.js';
@@ -320,7 +321,7 @@ Use this tool when the user's query implies needing the content of several files
       let gitIgnoredCount = 0;
       for (const absoluteFilePath of entries) {
         // Security check: ensure the glob library didn't return something outside targetDir.
-        if (!absoluteFilePath.startsWith(toolBaseDir)) {
+        if (!isWithinRoot(absoluteFilePath, toolBaseDir)) {
           skippedFiles.push({
             path: absoluteFilePath,
             reason: `Security: Glob library returned path outside target directory. Base: ${toolBaseDir}, Path: ${absoluteFilePath}`,
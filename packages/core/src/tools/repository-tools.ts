@@ -0,0 +1,92 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { BaseTool, Tool, ToolResult } from './tools.js';
+import { ReadFileTool } from './read-file.js';
+import { GrepTool } from './grep.js';
+import { Config } from '../config/config.js';
+import { ToolRegistry } from './tool-registry.js';
+
+export const REPOSITORY_READ_FILE_TOOL_NAME = 'repo_read_file';
+export const REPOSITORY_GREP_TOOL_NAME = 'repo_grep';
+
+/**
+ * A workspace tool rooted at an ingested repository instead of the
+ * workspace, under its own name, so the model can look into the repository
+ * without gaining access outside it.
+ */
+export class RepositoryScopedTool<TParams> extends BaseTool<
+  TParams,
+  ToolResult
+> {
+  constructor(
+    name: string,
+    repositoryName: string,
+    private readonly inner: BaseTool<TParams, ToolResult>,
+  ) {
+    super(
+      name,
+      `${inner.displayName} (${repositoryName})`,
+      `${inner.description} Works only inside the ${repositoryName} repository loaded with /repo; paths must be under its checkout.`,
+      inner.parameterSchema,
+      inner.isOutputMarkdown,
+      inner.canUpdateOutput,
+    );
+  }
+
+  validateToolParams(params: TParams): string | null {
+    return this.inner.validateToolParams(params);
+  }
+
+  getDescription(params: TParams): string {
+    return this.inner.getDescription(params);
+  }
+
+  execute(
+    params: TParams,
+    signal: AbortSignal,
+    updateOutput?: (output: string) => void,
+  ): Promise<ToolResult> {
+    return this.inner.execute(params, signal, updateOutput);
+  }
+}
+
+/** Read and search tools confined to a repository checkout. */
+export function createRepositoryTools(
+  root: string,
+  repositoryName: string,
+  config: Config,
+): Tool[] {
+  return [
+    new RepositoryScopedTool(
+      REPOSITORY_READ_FILE_TOOL_NAME,
+      repositoryName,
+      new ReadFileTool(root, config),
+    ),
+    new RepositoryScopedTool(
+      REPOSITORY_GREP_TOOL_NAME,
+      repositoryName,
+      new GrepTool(root),
+    ),
+  ];
+}
+
+/**
+ * Registers the tools for a repository, replacing those of a repository
+ * loaded before.
+ */
+export function registerRepositoryTools(
+  registry: ToolRegistry,
+  root: string,
+  repositoryName: string,
+  config: Config,
+): void {
+  registry.unregisterTool(REPOSITORY_READ_FILE_TOOL_NAME);
+  registry.unregisterTool(REPOSITORY_GREP_TOOL_NAME);
+  for (const tool of createRepositoryTools(root, repositoryName, config)) {
+    registry.registerTool(tool);
+  }
+}
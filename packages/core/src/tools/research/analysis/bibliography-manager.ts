@@ -19,6 +19,11 @@ import {
   ParallelProcessor,
 } from '../utils/performance-optimizer.js';
 import { GoogleScholarClient } from '../bibliography/google-scholar-client.js';
+import { PubMedClient } from '../bibliography/pubmed-client.js';
+import {
+  PreprintClient,
+  PreprintServer,
+} from '../bibliography/preprint-client.js';
 
 /**
  * 文献搜索参数接口
@@ -108,6 +113,8 @@ export class BibliographyManager extends BaseResearchTool<
   private bibliography: BibliographyEntry[] = [];
   private nextId = 1;
   private googleScholarClient: GoogleScholarClient;
+  private pubmedClient: PubMedClient;
+  private preprintClient: PreprintClient;
 
   constructor() {
     super(
@@ -116,6 +123,8 @@ export class BibliographyManager extends BaseResearchTool<
       ResearchToolCategory.ANALYSIS,
     );
     this.googleScholarClient = new GoogleScholarClient();
+    this.pubmedClient = new PubMedClient();
+    this.preprintClient = new PreprintClient();
   }
 
   public validate(params: ResearchToolParams): boolean {
@@ -160,7 +169,8 @@ export class BibliographyManager extends BaseResearchTool<
           name: 'databases',
           type: 'Database[]',
           required: true,
-          description: 'Databases to search (arxiv, pubmed, ieee, etc.)',
+          description:
+            'Databases to search (arxiv, google_scholar, pubmed, biorxiv, medrxiv, ieee)',
         },
         {
           name: 'maxResults',
@@ -286,6 +296,10 @@ export class BibliographyManager extends BaseResearchTool<
           case Database.PUBMED:
             entries = await this.searchPubmed(params, maxResults);
             break;
+          case Database.BIORXIV:
+          case Database.MEDRXIV:
+            entries = await this.searchPreprints(params, database, maxResults);
+            break;
           case Database.IEEE:
             entries = await this.searchIEEE(params, maxResults);
             break;
@@ -420,15 +434,42 @@ export class BibliographyManager extends BaseResearchTool<
   }
 
   /**
-   * 搜索 PubMed（模拟实现）
+   * 搜索 PubMed（NCBI E-utilities）
    */
   private async searchPubmed(
     params: BibliographySearchParams,
     maxResults: number,
   ): Promise<BibliographyEntry[]> {
-    // 这里应该实现真实的 PubMed API 调用
-    console.log(`PubMed search for: ${params.query}`);
-    return this.createMockPubmedResults(params.query, Math.min(maxResults, 3));
+    const results = await this.pubmedClient.searchPapers(params.query, {
+      maxResults,
+      yearRange: params.yearRange,
+      sortBy: params.sortBy === 'date' ? 'date' : 'relevance',
+    });
+    return results.map((entry) =>
+      this.createBibliographyEntry(entry, 'PubMed'),
+    );
+  }
+
+  /**
+   * 搜索 bioRxiv / medRxiv 预印本
+   */
+  private async searchPreprints(
+    params: BibliographySearchParams,
+    server: PreprintServer,
+    maxResults: number,
+  ): Promise<BibliographyEntry[]> {
+    const results = await this.preprintClient.searchPapers(
+      params.query,
+      server,
+      {
+        maxResults,
+        yearRange: params.yearRange,
+        sortBy: params.sortBy === 'date' ? 'date' : 'relevance',
+      },
+    );
+    return results.map((entry) =>
+      this.createBibliographyEntry(entry, entry.journal ?? server),
+    );
   }
 
   /**
@@ -552,36 +593,6 @@ export class BibliographyManager extends BaseResearchTool<
     return results;
   }
 
-  /**
-   * 创建 PubMed 模拟结果
-   */
-  private createMockPubmedResults(
-    query: string,
-    count: number,
-  ): BibliographyEntry[] {
-    const results: BibliographyEntry[] = [];
-
-    for (let i = 1; i <= count; i++) {
-      const entry = this.createBibliographyEntry(
-        {
-          id: `pubmed_${i}`,
-          title: `Medical Study: ${query} ${i}`,
-          authors: [`Dr. ${i} Smith`, `Dr. ${i} Johnson`],
-          year: 2022 - i,
-          journal: `Medical Journal ${i}`,
-          abstract: `Medical research on ${query}. Study ${i} examines the clinical implications and therapeutic applications.`,
-          url: `https://pubmed.ncbi.nlm.nih.gov/${i}`,
-          keywords: [query, 'medicine', 'clinical'],
-        },
-        'pubmed',
-      );
-
-      results.push(entry);
-    }
-
-    return results;
-  }
-
   /**
    * 创建 IEEE 模拟结果
    */
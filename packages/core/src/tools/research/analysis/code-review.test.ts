@@ -0,0 +1,183 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, vi } from 'vitest';
+import {
+  anchorComment,
+  formatReviewMarkdown,
+  parseUnifiedDiff,
+  reviewDiff,
+} from './code-review.js';
+
+const GIT_DIFF = `diff --git a/src/train.py b/src/train.py
+index 1111111..2222222 100644
+--- a/src/train.py
++++ b/src/train.py
+@@ -10,3 +10,4 @@ def main():
+     model = Model()
+-    data = load(split="train")
++    data = load(split="test")
++    random.seed(0)
+     fit(model, data)
+diff --git a/notes.md b/notes.md
+new file mode 100644
+index 0000000..3333333
+--- /dev/null
++++ b/notes.md
+@@ -0,0 +1,2 @@
++# Notes
++Run with seed 0.
+\\ No newline at end of file
+`;
+
+describe('parseUnifiedDiff', () => {
+  it('should split a git diff into files with new-file line numbers', () => {
+    const files = parseUnifiedDiff(GIT_DIFF);
+
+    expect(
+      files.map((f) => [f.path, f.status, f.additions, f.deletions]),
+    ).toEqual([
+      ['src/train.py', 'modified', 2, 1],
+      ['notes.md', 'added', 2, 0],
+    ]);
+    expect(files[0].lines).toEqual([10, 11, 12, 13]);
+    expect(files[0].diff.startsWith('@@ -10,3 +10,4 @@')).toBe(true);
+    expect(files[1].diff.endsWith('\\ No newline at end of file')).toBe(true);
+  });
+
+  it('should read plain diff -u output with several files', () => {
+    const files = parseUnifiedDiff(
+      [
+        '--- a.py\t2025-01-01',
+        '+++ a.py\t2025-01-02',
+        '@@ -1,2 +1 @@',
+        '-x = 1',
+        '--- a deleted SQL comment',
+        '+x = 2',
+        '--- old/b.py',
+        '+++ new/b.py',
+        '@@ -3,2 +3,2 @@',
+        ' y = 0',
+        '-z = 1',
+        '+z = 3',
+      ].join('\n'),
+    );
+
+    expect(files.map((f) => [f.path, f.deletions, f.lines])).toEqual([
+      ['a.py', 2, [1]],
+      ['new/b.py', 1, [3, 4]],
+    ]);
+    expect(files[1]).toMatchObject({
+      status: 'renamed',
+      oldPath: 'old/b.py',
+    });
+  });
+});
+
+describe('review comments', () => {
+  const files = parseUnifiedDiff(GIT_DIFF);
+
+  it('should anchor comments to the nearest line the diff shows', () => {
+    expect(
+      anchorComment(files, {
+        file: 'b/train.py',
+        line: 15,
+        severity: 'major',
+        comment: 'x',
+      }),
+    ).toMatchObject({ file: 'src/train.py', line: 13 });
+    expect(
+      anchorComment(files, {
+        file: 'src/train.py',
+        line: 40,
+        severity: 'minor',
+        comment: 'x',
+      }),
+    ).toMatchObject({ line: undefined });
+    expect(
+      anchorComment(files, { file: 'other.py', severity: 'nit', comment: 'x' }),
+    ).toBeUndefined();
+  });
+
+  it('should review the numbered diff and export it as Markdown', async () => {
+    const generateJson = vi.fn().mockResolvedValue({
+      summary: 'Switches the training data and seeds the run.',
+      comments: [
+        {
+          file: 'notes.md',
+          severity: 'nit',
+          comment: 'Mention where the seed is set.',
+        },
+        {
+          file: 'src/train.py',
+          line: 11,
+          severity: 'critical',
+          comment: 'Trains on the test split.',
+          suggestion: '    data = load(split="train")',
+        },
+        { file: 'src/train.py', line: 12, severity: 'urgent', comment: 'Ok' },
+        { file: 'missing.py', line: 1, severity: 'major', comment: 'Gone' },
+      ],
+    });
+
+    const review = await reviewDiff(
+      { generateJson },
+      files,
+      'staged changes',
+      new AbortController().signal,
+      'data leakage',
+    );
+
+    const prompt = generateJson.mock.calls[0][0][0].parts[0].text as string;
+    expect(prompt).toContain('Pay particular attention to: data leakage');
+    expect(prompt).toContain('### src/train.py (modified)');
+    expect(prompt).toContain('   11 +    data = load(split="test")');
+    expect(prompt).toContain('      -    data = load(split="train")');
+    expect(review.comments.map((c) => [c.file, c.line, c.severity])).toEqual([
+      ['src/train.py', 11, 'critical'],
+      ['src/train.py', 12, 'minor'],
+      ['notes.md', undefined, 'nit'],
+    ]);
+
+    const markdown = formatReviewMarkdown(review, files);
+    expect(markdown).toContain('# Review of staged changes');
+    expect(markdown).toContain('3 comments: 1 critical, 1 minor, 1 nit.');
+    expect(markdown).toContain(
+      '## `src/train.py`\n\n- **Line 11** (critical): Trains on the test split.\n\n  ```py\n      data = load(split="train")\n  ```',
+    );
+    expect(markdown).toContain(
+      '- **File** (nit): Mention where the seed is set.',
+    );
+  });
+
+  it('should name the files left out for length in the summary', async () => {
+    const lines = Array.from({ length: 4000 }, (_, i) => `+row ${i}`);
+    const files = parseUnifiedDiff(
+      `${GIT_DIFF}diff --git a/data.csv b/data.csv
+--- a/data.csv
++++ b/data.csv
+@@ -0,0 +1,4000 @@
+${lines.join('\n')}
+`,
+    );
+    const generateJson = vi
+      .fn()
+      .mockResolvedValue({ summary: 'Looks fine.', comments: [] });
+
+    const review = await reviewDiff(
+      { generateJson },
+      files,
+      'staged changes',
+      new AbortController().signal,
+    );
+
+    const prompt = generateJson.mock.calls[0][0][0].parts[0].text as string;
+    expect(prompt).not.toContain('+row 0');
+    expect(review.summary).toBe(
+      'Looks fine.\n\nNot reviewed, as the diff was too long: data.csv.',
+    );
+  });
+});
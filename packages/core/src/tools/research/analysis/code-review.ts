@@ -0,0 +1,410 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+/**
+ * Code Review - Reviews a git diff or patch with the model and anchors the
+ * comments to the changed files and lines
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import { simpleGit } from 'simple-git';
+import { SchemaUnion, Type } from '@google/genai';
+import type { ResearchClient } from '../../../core/client.js';
+
+// Files past this much diff are not sent; the summary names them, so
+// they can be reviewed on their own.
+const MAX_REVIEW_DIFF_CHARS = 60000;
+// A comment a few lines off still points at the right change.
+const MAX_ANCHOR_DISTANCE = 3;
+
+export type ReviewSeverity = 'critical' | 'major' | 'minor' | 'nit';
+
+export const REVIEW_SEVERITIES: ReviewSeverity[] = [
+  'critical',
+  'major',
+  'minor',
+  'nit',
+];
+
+export interface DiffFile {
+  /** The path after the change (before it, for deleted files). */
+  path: string;
+  oldPath?: string;
+  status: 'added' | 'deleted' | 'modified' | 'renamed';
+  /** The file's part of the diff, from its first hunk. */
+  diff: string;
+  additions: number;
+  deletions: number;
+  /** Lines of the new file shown in the diff, added or context. */
+  lines: number[];
+}
+
+export interface ReviewComment {
+  file: string;
+  /** A line of the new file; absent for comments on the whole file. */
+  line?: number;
+  severity: ReviewSeverity;
+  comment: string;
+  suggestion?: string;
+}
+
+export interface DiffReview {
+  /** What was reviewed, e.g. "staged changes" or "commit abc123". */
+  source: string;
+  summary: string;
+  comments: ReviewComment[];
+}
+
+const REVIEW_SCHEMA: SchemaUnion = {
+  type: Type.OBJECT,
+  properties: {
+    summary: {
+      type: Type.STRING,
+      description:
+        'Two or three sentences on what the change does and its overall quality.',
+    },
+    comments: {
+      type: Type.ARRAY,
+      items: {
+        type: Type.OBJECT,
+        properties: {
+          file: { type: Type.STRING },
+          line: {
+            type: Type.INTEGER,
+            description:
+              'The new-file line number shown in the diff; omit for the whole file.',
+          },
+          severity: { type: Type.STRING, enum: REVIEW_SEVERITIES },
+          comment: { type: Type.STRING },
+          suggestion: {
+            type: Type.STRING,
+            description: 'Replacement code, if a concrete fix is clear.',
+          },
+        },
+        required: ['file', 'severity', 'comment'],
+      },
+    },
+  },
+  required: ['summary', 'comments'],
+};
+
+function stripPrefix(diffPath: string): string | undefined {
+  const trimmed = diffPath.trim().replace(/\t.*$/, '');
+  return trimmed === '/dev/null' ? undefined : trimmed.replace(/^[ab]\//, '');
+}
+
+/** Splits a unified diff (git or plain `diff -u`) into files. */
+export function parseUnifiedDiff(diff: string): DiffFile[] {
+  const files: DiffFile[] = [];
+  let current: DiffFile | undefined;
+  let oldPath: string | undefined;
+  let newLine = 0;
+  // Lines left in the current hunk, from the counts in its header
+  let oldLeft = 0;
+  let newLeft = 0;
+
+  const start = (): DiffFile => {
+    const file: DiffFile = {
+      path: '',
+      status: 'modified',
+      diff: '',
+      additions: 0,
+      deletions: 0,
+      lines: [],
+    };
+    files.push(file);
+    oldLeft = newLeft = 0;
+    return file;
+  };
+
+  for (const line of diff.split('\n')) {
+    const inHunk = oldLeft > 0 || newLeft > 0;
+    if (inHunk || (line.startsWith('\\') && current?.diff)) {
+      if (line.startsWith('+')) {
+        current!.additions++;
+        current!.lines.push(newLine++);
+        newLeft--;
+      } else if (line.startsWith('-')) {
+        current!.deletions++;
+        oldLeft--;
+      } else if (line.startsWith(' ') || line === '') {
+        // Some editors strip the space from blank context lines
+        current!.lines.push(newLine++);
+        oldLeft--;
+        newLeft--;
+      } else if (!line.startsWith('\\')) {
+        oldLeft = newLeft = 0;
+        continue;
+      }
+      current!.diff += `${line.startsWith('\\') || line ? line : ' '}\n`;
+      continue;
+    }
+    const gitHeader = line.match(/^diff --git a\/(.+) b\/(.+)$/);
+    if (gitHeader) {
+      current = start();
+      current.path = gitHeader[2];
+      oldPath = gitHeader[1];
+      continue;
+    }
+    if (line.startsWith('--- ')) {
+      if (!current || current.diff) {
+        current = start();
+      }
+      oldPath = stripPrefix(line.slice(4));
+      continue;
+    }
+    if (line.startsWith('+++ ') && current) {
+      const newPath = stripPrefix(line.slice(4));
+      current.path = newPath ?? oldPath ?? current.path;
+      current.status = !oldPath ? 'added' : !newPath ? 'deleted' : 'modified';
+      if (oldPath && newPath && oldPath !== newPath) {
+        current.status = 'renamed';
+        current.oldPath = oldPath;
+      }
+      continue;
+    }
+    if (!current) {
+      continue;
+    }
+    if (/^rename from /.test(line)) {
+      current.oldPath = line.slice('rename from '.length);
+      current.status = 'renamed';
+      continue;
+    }
+    if (/^new file mode/.test(line)) {
+      current.status = 'added';
+      continue;
+    }
+    if (/^deleted file mode/.test(line)) {
+      current.status = 'deleted';
+      continue;
+    }
+    const hunk = line.match(/^@@ -\d+(?:,(\d+))? \+(\d+)(?:,(\d+))? @@/);
+    if (hunk) {
+      oldLeft = hunk[1] === undefined ? 1 : Number(hunk[1]);
+      newLine = Number(hunk[2]);
+      newLeft = hunk[3] === undefined ? 1 : Number(hunk[3]);
+      current.diff += `${line}\n`;
+    }
+  }
+  return files
+    .filter((file) => file.path && file.diff)
+    .map((file) => ({ ...file, diff: file.diff.replace(/\n+$/, '') }));
+}
+
+/**
+ * Reads the diff to review from the project: a patch file, a commit, a
+ * range such as `main..HEAD`, or the staged changes when `source` is
+ * empty or `--staged`.
+ */
+export async function readDiff(
+  root: string,
+  source: string,
+): Promise<{ label: string; diff: string }> {
+  const trimmed = source.trim();
+  const filePath = trimmed ? path.resolve(root, trimmed) : undefined;
+  if (filePath && fs.existsSync(filePath) && fs.statSync(filePath).isFile()) {
+    return {
+      label: path.relative(root, filePath),
+      diff: await fs.promises.readFile(filePath, 'utf8'),
+    };
+  }
+  const git = simpleGit(root);
+  if (!trimmed || trimmed === '--staged') {
+    return { label: 'staged changes', diff: await git.diff(['--cached']) };
+  }
+  if (trimmed.includes('..')) {
+    return { label: trimmed, diff: await git.diff([trimmed]) };
+  }
+  return {
+    label: `commit ${trimmed}`,
+    diff: await git.show(['--format=', '--patch', trimmed]),
+  };
+}
+
+/** The diff with new-file line numbers, so comments can name lines. */
+function numberDiffLines(file: DiffFile): string {
+  let newLine = 0;
+  return file.diff
+    .split('\n')
+    .map((line) => {
+      const hunk = line.match(/^@@ -\d+(?:,\d+)? \+(\d+)/);
+      if (hunk) {
+        newLine = Number(hunk[1]);
+        return line;
+      }
+      if (line.startsWith('-') || line.startsWith('\\')) {
+        return `      ${line}`;
+      }
+      return `${String(newLine++).padStart(5)} ${line}`;
+    })
+    .join('\n');
+}
+
+/**
+ * Ties a comment to a file of the diff (matching by path or its end) and
+ * to the nearest line the diff shows. Comments on lines outside the diff
+ * become comments on the whole file; comments on other files are dropped.
+ */
+export function anchorComment(
+  files: DiffFile[],
+  comment: ReviewComment,
+): ReviewComment | undefined {
+  const name = comment.file.replace(/^[ab]\//, '').replace(/^\.\//, '');
+  const file =
+    files.find((f) => f.path === name) ??
+    files.find((f) => f.path.endsWith(`/${name}`) || name.endsWith(f.path));
+  if (!file) {
+    return undefined;
+  }
+  let line: number | undefined;
+  if (comment.line !== undefined) {
+    const nearest = file.lines.reduce<number | undefined>(
+      (best, l) =>
+        best === undefined ||
+        Math.abs(l - comment.line!) < Math.abs(best - comment.line!)
+          ? l
+          : best,
+      undefined,
+    );
+    if (
+      nearest !== undefined &&
+      Math.abs(nearest - comment.line) <= MAX_ANCHOR_DISTANCE
+    ) {
+      line = nearest;
+    }
+  }
+  return { ...comment, file: file.path, line };
+}
+
+/**
+ * Asks the model for a review of the diff: a summary and comments on
+ * specific lines, ordered by file, line and severity.
+ */
+export async function reviewDiff(
+  client: Pick<ResearchClient, 'generateJson'>,
+  files: DiffFile[],
+  source: string,
+  abortSignal: AbortSignal,
+  focus?: string,
+): Promise<DiffReview> {
+  let diffText = '';
+  const omitted: string[] = [];
+  for (const file of files) {
+    const section = `### ${file.path} (${file.status})\n${numberDiffLines(file)}\n\n`;
+    if (diffText.length + section.length > MAX_REVIEW_DIFF_CHARS) {
+      omitted.push(file.path);
+    } else {
+      diffText += section;
+    }
+  }
+  const prompt = [
+    'Review this change as an experienced reviewer of research code. Point out bugs, incorrect math or data handling, leaks between training and evaluation data, reproducibility problems (seeds, nondeterminism, hard-coded paths), performance problems and unclear code. Do not comment on what is fine.',
+    'Anchor each comment to the file and to the new-file line number printed at the left of the diff. Severity: critical (wrong results or crashes), major (likely bugs), minor (worth fixing), nit (style).',
+    ...(focus ? [`Pay particular attention to: ${focus}`] : []),
+    ...(omitted.length > 0
+      ? [`These files were left out for length: ${omitted.join(', ')}.`]
+      : []),
+    '',
+    diffText.trim(),
+  ].join('\n');
+
+  const response = await client.generateJson(
+    [{ role: 'user', parts: [{ text: prompt }] }],
+    REVIEW_SCHEMA,
+    abortSignal,
+  );
+  const rawComments = Array.isArray(response['comments'])
+    ? (response['comments'] as Array<Record<string, unknown>>)
+    : [];
+  const comments: ReviewComment[] = [];
+  for (const raw of rawComments) {
+    if (typeof raw['file'] !== 'string' || typeof raw['comment'] !== 'string') {
+      continue;
+    }
+    const severity = REVIEW_SEVERITIES.includes(
+      raw['severity'] as ReviewSeverity,
+    )
+      ? (raw['severity'] as ReviewSeverity)
+      : 'minor';
+    const anchored = anchorComment(files, {
+      file: raw['file'],
+      line: typeof raw['line'] === 'number' ? raw['line'] : undefined,
+      severity,
+      comment: raw['comment'].trim(),
+      suggestion:
+        typeof raw['suggestion'] === 'string' && raw['suggestion'].trim()
+          ? raw['suggestion']
+          : undefined,
+    });
+    if (anchored) {
+      comments.push(anchored);
+    }
+  }
+  const fileOrder = files.map((f) => f.path);
+  comments.sort(
+    (a, b) =>
+      fileOrder.indexOf(a.file) - fileOrder.indexOf(b.file) ||
+      (a.line ?? 0) - (b.line ?? 0) ||
+      REVIEW_SEVERITIES.indexOf(a.severity) -
+        REVIEW_SEVERITIES.indexOf(b.severity),
+  );
+  const summary =
+    typeof response['summary'] === 'string' ? response['summary'].trim() : '';
+  const notReviewed =
+    omitted.length > 0
+      ? `Not reviewed, as the diff was too long: ${omitted.join(', ')}.`
+      : '';
+  return {
+    source,
+    summary: [summary, notReviewed].filter(Boolean).join('\n\n'),
+    comments,
+  };
+}
+
+/** The review as Markdown, one section per file with its comments. */
+export function formatReviewMarkdown(
+  review: DiffReview,
+  files: DiffFile[],
+): string {
+  const counts = REVIEW_SEVERITIES.map((severity) => [
+    severity,
+    review.comments.filter((c) => c.severity === severity).length,
+  ]).filter(([, count]) => count);
+  const lines = [
+    `# Review of ${review.source}`,
+    '',
+    review.summary,
+    '',
+    counts.length > 0
+      ? `${review.comments.length} comments: ${counts.map(([severity, count]) => `${count} ${severity}`).join(', ')}.`
+      : 'No comments.',
+  ];
+  for (const file of files) {
+    const comments = review.comments.filter((c) => c.file === file.path);
+    if (comments.length === 0) {
+      continue;
+    }
+    lines.push('', `## \`${file.path}\``, '');
+    for (const comment of comments) {
+      lines.push(
+        `- **${comment.line !== undefined ? `Line ${comment.line}` : 'File'}** (${comment.severity}): ${comment.comment}`,
+      );
+      if (comment.suggestion) {
+        const fence = comment.suggestion.includes('```') ? '~~~' : '```';
+        lines.push(
+          '',
+          `  ${fence}${path.extname(file.path).slice(1)}`,
+          ...comment.suggestion.split('\n').map((l) => `  ${l}`),
+          `  ${fence}`,
+          '',
+        );
+      }
+    }
+  }
+  return `${lines.join('\n').replace(/\n+$/, '')}\n`;
+}
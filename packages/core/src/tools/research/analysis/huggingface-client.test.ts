@@ -0,0 +1,140 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, afterEach, vi } from 'vitest';
+import {
+  HuggingFaceClient,
+  formatCount,
+  formatHubCard,
+} from './huggingface-client.js';
+
+describe('HuggingFaceClient', () => {
+  afterEach(() => {
+    vi.unstubAllGlobals();
+  });
+
+  it('should search datasets and read license, size and tasks from tags', async () => {
+    const fetchMock = vi.fn(
+      async (_url: string) =>
+        new Response(
+          JSON.stringify([
+            {
+              id: 'openai/gsm8k',
+              downloads: 412345,
+              likes: 610,
+              tags: [
+                'task_categories:text2text-generation',
+                'license:mit',
+                'size_categories:1K<n<10K',
+              ],
+              lastModified: '2024-01-04T12:00:00.000Z',
+              description: 'Grade school\n math word problems.',
+            },
+          ]),
+        ),
+    );
+    vi.stubGlobal('fetch', fetchMock);
+
+    const [card] = await new HuggingFaceClient().searchDatasets('math', {
+      limit: 5,
+      task: 'text2text-generation',
+    });
+
+    const url = new URL(fetchMock.mock.calls[0][0]);
+    expect(url.pathname).toBe('/api/datasets');
+    expect(url.searchParams.get('search')).toBe('math');
+    expect(url.searchParams.get('limit')).toBe('5');
+    expect(url.searchParams.get('filter')).toBe(
+      'task_categories:text2text-generation',
+    );
+    expect(card).toEqual({
+      kind: 'dataset',
+      id: 'openai/gsm8k',
+      url: 'https://huggingface.co/datasets/openai/gsm8k',
+      license: 'mit',
+      size: '1K<n<10K',
+      tasks: ['text2text-generation'],
+      library: undefined,
+      downloads: 412345,
+      likes: 610,
+      lastModified: '2024-01-04T12:00:00.000Z',
+      gated: false,
+      description: 'Grade school math word problems.',
+    });
+    expect(formatHubCard(card)).toBe(
+      [
+        'openai/gsm8k  (dataset, https://huggingface.co/datasets/openai/gsm8k)',
+        '  license mit · 1K<n<10K examples · 412.3K downloads · 610 likes',
+        '  text2text-generation',
+        '  Updated 2024-01-04',
+        '  Grade school math word problems.',
+      ].join('\n'),
+    );
+  });
+
+  it('should search models with their parameter count', async () => {
+    const fetchMock = vi.fn(
+      async (_url: string) =>
+        new Response(
+          JSON.stringify([
+            {
+              id: 'meta-llama/Llama-3.1-8B',
+              pipeline_tag: 'text-generation',
+              library_name: 'transformers',
+              gated: 'manual',
+              safetensors: { total: 8030261248 },
+              cardData: { license: 'llama3.1' },
+            },
+          ]),
+        ),
+    );
+    vi.stubGlobal('fetch', fetchMock);
+
+    const [card] = await new HuggingFaceClient().searchModels('llama', {
+      task: 'text-generation',
+    });
+
+    const url = new URL(fetchMock.mock.calls[0][0]);
+    expect(url.searchParams.get('pipeline_tag')).toBe('text-generation');
+    expect(url.searchParams.getAll('expand[]')).toContain('safetensors');
+    expect(card).toMatchObject({
+      kind: 'model',
+      url: 'https://huggingface.co/meta-llama/Llama-3.1-8B',
+      license: 'llama3.1',
+      size: '8B parameters',
+      tasks: ['text-generation'],
+      gated: true,
+    });
+  });
+
+  it('should fetch a README without its metadata', async () => {
+    const fetchMock = vi.fn(
+      async (_url: string) =>
+        new Response('---\nlicense: mit\n---\n# GSM8K\n\nProblems.'),
+    );
+    vi.stubGlobal('fetch', fetchMock);
+
+    const readme = await new HuggingFaceClient().fetchReadme({
+      kind: 'dataset',
+      id: 'openai/gsm8k',
+      url: '',
+      tasks: [],
+    });
+
+    expect(fetchMock.mock.calls[0][0]).toBe(
+      'https://huggingface.co/datasets/openai/gsm8k/raw/main/README.md',
+    );
+    expect(readme).toBe('# GSM8K\n\nProblems.');
+  });
+});
+
+describe('formatCount', () => {
+  it('should abbreviate large counts', () => {
+    expect(formatCount(950)).toBe('950');
+    expect(formatCount(1500)).toBe('1.5K');
+    expect(formatCount(2_000_000)).toBe('2M');
+  });
+});
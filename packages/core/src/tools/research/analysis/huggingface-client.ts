@@ -0,0 +1,245 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+/**
+ * HuggingFace Client - Dataset and model search on the HuggingFace Hub,
+ * summarized as cards with license, size and popularity for planning
+ * experiments
+ */
+
+import { fetchWithTimeout } from '../../../utils/fetch.js';
+
+const HUGGINGFACE_URL = 'https://huggingface.co';
+const DEFAULT_TIMEOUT_MS = 30000;
+const DEFAULT_LIMIT = 10;
+// A README is attached for context; the start describes the data or model.
+const MAX_README_CHARS = 8000;
+const MODEL_FIELDS = [
+  'downloads',
+  'likes',
+  'tags',
+  'pipeline_tag',
+  'library_name',
+  'safetensors',
+  'lastModified',
+  'cardData',
+];
+
+export type HubResourceKind = 'dataset' | 'model';
+
+export interface HubCard {
+  kind: HubResourceKind;
+  /** The repository id, e.g. "openai/gsm8k". */
+  id: string;
+  url: string;
+  license?: string;
+  /** Examples for datasets (e.g. "1K<n<10K"), parameters for models. */
+  size?: string;
+  /** Task categories of a dataset, or the pipeline of a model. */
+  tasks: string[];
+  library?: string;
+  downloads?: number;
+  likes?: number;
+  lastModified?: string;
+  gated?: boolean;
+  description?: string;
+}
+
+export interface HubSearchOptions {
+  limit?: number;
+  /** A task to filter by, e.g. "text-classification". */
+  task?: string;
+}
+
+interface HubRepo {
+  id: string;
+  downloads?: number;
+  likes?: number;
+  tags?: string[];
+  pipeline_tag?: string;
+  library_name?: string;
+  lastModified?: string;
+  gated?: boolean | string;
+  description?: string;
+  safetensors?: { total?: number };
+  cardData?: { license?: string | string[]; pretty_name?: string };
+}
+
+function getTagValues(tags: string[] | undefined, prefix: string): string[] {
+  return (tags ?? [])
+    .filter((tag) => tag.startsWith(`${prefix}:`))
+    .map((tag) => tag.slice(prefix.length + 1));
+}
+
+/** 1234567 -> "1.2M" */
+export function formatCount(count: number): string {
+  for (const [value, suffix] of [
+    [1e9, 'B'],
+    [1e6, 'M'],
+    [1e3, 'K'],
+  ] as const) {
+    if (count >= value) {
+      return `${Number((count / value).toFixed(1))}${suffix}`;
+    }
+  }
+  return String(count);
+}
+
+function toCard(kind: HubResourceKind, repo: HubRepo): HubCard {
+  const cardLicense = repo.cardData?.license;
+  const license =
+    (Array.isArray(cardLicense) ? cardLicense.join(', ') : cardLicense) ??
+    getTagValues(repo.tags, 'license')[0];
+  const size =
+    kind === 'dataset'
+      ? getTagValues(repo.tags, 'size_categories')[0]
+      : repo.safetensors?.total
+        ? `${formatCount(repo.safetensors.total)} parameters`
+        : undefined;
+  return {
+    kind,
+    id: repo.id,
+    url: `${HUGGINGFACE_URL}/${kind === 'dataset' ? 'datasets/' : ''}${repo.id}`,
+    license,
+    size,
+    tasks:
+      kind === 'dataset'
+        ? getTagValues(repo.tags, 'task_categories')
+        : repo.pipeline_tag
+          ? [repo.pipeline_tag]
+          : [],
+    library: repo.library_name,
+    downloads: repo.downloads,
+    likes: repo.likes,
+    lastModified: repo.lastModified,
+    gated: Boolean(repo.gated),
+    description: repo.description?.replace(/\s+/g, ' ').trim() || undefined,
+  };
+}
+
+/** The card as text for the terminal and the conversation. */
+export function formatHubCard(card: HubCard): string {
+  const facts = [
+    card.license ? `license ${card.license}` : 'no license given',
+    card.size
+      ? card.kind === 'dataset'
+        ? `${card.size} examples`
+        : card.size
+      : undefined,
+    card.downloads !== undefined
+      ? `${formatCount(card.downloads)} downloads`
+      : undefined,
+    card.likes !== undefined ? `${formatCount(card.likes)} likes` : undefined,
+    card.gated ? 'gated' : undefined,
+  ].filter(Boolean);
+  return [
+    `${card.id}  (${card.kind}, ${card.url})`,
+    `  ${facts.join(' · ')}`,
+    ...(card.tasks.length > 0 || card.library
+      ? [
+          `  ${[card.tasks.join(', '), card.library].filter(Boolean).join(' · ')}`,
+        ]
+      : []),
+    ...(card.lastModified
+      ? [`  Updated ${card.lastModified.slice(0, 10)}`]
+      : []),
+    ...(card.description ? [`  ${card.description}`] : []),
+  ].join('\n');
+}
+
+/**
+ * HuggingFace Hub Client
+ */
+export class HuggingFaceClient {
+  constructor(
+    private readonly baseUrl: string = HUGGINGFACE_URL,
+    private readonly timeout: number = DEFAULT_TIMEOUT_MS,
+  ) {}
+
+  /**
+   * Search datasets, most downloaded first
+   */
+  async searchDatasets(
+    query: string,
+    options: HubSearchOptions = {},
+  ): Promise<HubCard[]> {
+    const params = this.searchParams(query, options);
+    params.set('full', 'true');
+    if (options.task) {
+      params.set('filter', `task_categories:${options.task}`);
+    }
+    return (await this.getJson<HubRepo[]>(`/api/datasets?${params}`)).map(
+      (repo) => toCard('dataset', repo),
+    );
+  }
+
+  /**
+   * Search models, most downloaded first
+   */
+  async searchModels(
+    query: string,
+    options: HubSearchOptions = {},
+  ): Promise<HubCard[]> {
+    const params = this.searchParams(query, options);
+    if (options.task) {
+      params.set('pipeline_tag', options.task);
+    }
+    for (const field of MODEL_FIELDS) {
+      params.append('expand[]', field);
+    }
+    return (await this.getJson<HubRepo[]>(`/api/models?${params}`)).map(
+      (repo) => toCard('model', repo),
+    );
+  }
+
+  /**
+   * The README (dataset or model card) of a repository, shortened; undefined
+   * when it has none
+   */
+  async fetchReadme(card: HubCard): Promise<string | undefined> {
+    const prefix = card.kind === 'dataset' ? '/datasets' : '';
+    const response = await fetchWithTimeout(
+      `${this.baseUrl}${prefix}/${card.id}/raw/main/README.md`,
+      this.timeout,
+    );
+    if (response.status === 404 || response.status === 401) {
+      return undefined;
+    }
+    if (!response.ok) {
+      throw new Error(`HuggingFace API error: ${response.status}`);
+    }
+    const readme = (await response.text())
+      // The YAML metadata is already on the card
+      .replace(/^---\n[\s\S]*?\n---\n/, '')
+      .trim();
+    return readme.length > MAX_README_CHARS
+      ? `${readme.slice(0, MAX_README_CHARS)}\n[...]`
+      : readme || undefined;
+  }
+
+  private searchParams(
+    query: string,
+    options: HubSearchOptions,
+  ): URLSearchParams {
+    return new URLSearchParams({
+      search: query,
+      sort: 'downloads',
+      direction: '-1',
+      limit: String(options.limit ?? DEFAULT_LIMIT),
+    });
+  }
+
+  private async getJson<T>(pathAndQuery: string): Promise<T> {
+    const response = await fetchWithTimeout(
+      `${this.baseUrl}${pathAndQuery}`,
+      this.timeout,
+    );
+    if (!response.ok) {
+      throw new Error(`HuggingFace API error: ${response.status}`);
+    }
+    return (await response.json()) as T;
+  }
+}
@@ -11,3 +11,6 @@
 export * from './bibliography-manager.js';
 export * from './experiment-code-generator.js';
 export * from './research-data-analyzer.js';
+export * from './paper-review.js';
+export * from './huggingface-client.js';
+export * from './code-review.js';
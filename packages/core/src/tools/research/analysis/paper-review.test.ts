@@ -0,0 +1,104 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import {
+  createReview,
+  draftReviewField,
+  findReviewField,
+  formatReviewForm,
+  listReviews,
+  nextReviewField,
+  saveReview,
+  setReviewAnswer,
+} from './paper-review.js';
+
+describe('review answers', () => {
+  it('should walk through the fields in form order', () => {
+    const review = createReview('/papers/x.pdf', 'icml');
+    expect(nextReviewField(review)?.id).toBe('summary');
+
+    setReviewAnswer(review, findReviewField('icml', 'summary')!, 'A method.');
+    expect(nextReviewField(review)?.label).toBe('Claims And Evidence');
+  });
+
+  it('should find fields by label prefix and validate scores', () => {
+    const review = createReview('/papers/x.pdf', 'neurips');
+    const rating = findReviewField('neurips', 'Rat')!;
+
+    expect(() => setReviewAnswer(review, rating, '11')).toThrow(
+      'Rating is a score from 1 to 10.',
+    );
+    setReviewAnswer(review, rating, '6 - solid but narrow');
+    expect(review.answers['rating']).toBe(6);
+  });
+
+  it('should format the review form with score labels', () => {
+    const review = createReview('/papers/x.pdf', 'acl');
+    setReviewAnswer(review, findReviewField('acl', 'summary')!, 'A dataset.');
+    setReviewAnswer(review, findReviewField('acl', 'overall')!, '3');
+
+    const form = formatReviewForm(review);
+    expect(form).toContain('Paper Summary:\nA dataset.');
+    expect(form).toContain('Overall Assessment:\n3: Findings');
+    expect(form).toContain('Summary Of Strengths:\n(not filled in)');
+  });
+});
+
+describe('saveReview and listReviews', () => {
+  let tempDir: string;
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'reviews-'));
+  });
+
+  afterEach(() => {
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should list saved reviews, most recent first', async () => {
+    const older = createReview('/papers/a.pdf', 'icml');
+    older.updated = '2025-01-01T00:00:00.000Z';
+    const newer = createReview('/papers/b.pdf', 'icml');
+    newer.updated = '2025-02-01T00:00:00.000Z';
+    await saveReview(older, tempDir);
+    await saveReview(newer, tempDir);
+
+    expect((await listReviews(tempDir)).map((r) => r.paper)).toEqual([
+      '/papers/b.pdf',
+      '/papers/a.pdf',
+    ]);
+    expect(await listReviews(path.join(tempDir, 'missing'))).toEqual([]);
+  });
+});
+
+describe('draftReviewField', () => {
+  it('should include the guidance, earlier answers and the paper', async () => {
+    const generateContent = vi.fn().mockResolvedValue({
+      candidates: [{ content: { parts: [{ text: ' 3\nSound proofs. ' }] } }],
+    });
+    const review = createReview('/papers/x.pdf', 'neurips');
+    setReviewAnswer(review, findReviewField('neurips', 'summary')!, 'A GNN.');
+
+    const draft = await draftReviewField(
+      { generateContent },
+      review,
+      findReviewField('neurips', 'soundness')!,
+      'We prove a theorem.',
+      new AbortController().signal,
+    );
+
+    expect(draft).toBe('3\nSound proofs.');
+    const prompt = generateContent.mock.calls[0][0][0].parts[0].text;
+    expect(prompt).toContain('Draft the "Soundness" field');
+    expect(prompt).toContain('  4: excellent');
+    expect(prompt).toContain('Summary: A GNN.');
+    expect(prompt).toContain('We prove a theorem.');
+  });
+});
@@ -0,0 +1,489 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+/**
+ * Paper Review - Venue review forms and the structured notes filled in
+ * by the /review workflow
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import * as crypto from 'node:crypto';
+import type { ResearchClient } from '../../../core/client.js';
+import { getUserResearchDir } from '../../../utils/paths.js';
+import { isNodeError } from '../../../utils/errors.js';
+import { getResponseText } from '../../../utils/generateContentResponseUtilities.js';
+
+// Enough of the paper for the model to draft any single field.
+const MAX_PAPER_TEXT_CHARS = 60000;
+
+export type ReviewVenue = 'neurips' | 'icml' | 'acl';
+
+export interface ReviewField {
+  id: string;
+  /** Heading used by the venue's review form. */
+  label: string;
+  /** What the venue asks reviewers to write. */
+  guidance: string;
+  /** Score fields take an integer from the scale; others take text. */
+  scale?: { min: number; max: number; labels: Record<number, string> };
+}
+
+export interface ReviewTemplate {
+  venue: ReviewVenue;
+  name: string;
+  fields: ReviewField[];
+}
+
+export interface PaperReview {
+  id: string;
+  venue: ReviewVenue;
+  /** Absolute path of the reviewed PDF. */
+  paper: string;
+  created: string;
+  updated: string;
+  /** Field id to text, or to the chosen score. */
+  answers: Record<string, string | number>;
+}
+
+const CONFIDENCE_5 = {
+  min: 1,
+  max: 5,
+  labels: {
+    1: 'Educated guess',
+    2: 'Willing to defend, but likely missed central parts',
+    3: 'Fairly confident',
+    4: 'Confident, but not absolutely certain',
+    5: 'Absolutely certain',
+  },
+};
+
+const QUALITY_4 = {
+  min: 1,
+  max: 4,
+  labels: { 1: 'poor', 2: 'fair', 3: 'good', 4: 'excellent' },
+};
+
+export const REVIEW_TEMPLATES: Record<ReviewVenue, ReviewTemplate> = {
+  neurips: {
+    venue: 'neurips',
+    name: 'NeurIPS',
+    fields: [
+      {
+        id: 'summary',
+        label: 'Summary',
+        guidance:
+          'Briefly summarize the paper and its contributions. This is not the place to critique the paper; the authors should agree with a well-written summary.',
+      },
+      {
+        id: 'strengths',
+        label: 'Strengths',
+        guidance:
+          'Assess the strengths of the paper along originality, quality, clarity and significance.',
+      },
+      {
+        id: 'weaknesses',
+        label: 'Weaknesses',
+        guidance:
+          'Explain the weaknesses, as constructively and actionably as possible, along the same dimensions.',
+      },
+      {
+        id: 'questions',
+        label: 'Questions',
+        guidance:
+          'List questions and suggestions for the authors whose answers could change your opinion or clarify confusion.',
+      },
+      {
+        id: 'limitations',
+        label: 'Limitations',
+        guidance:
+          'Have the authors adequately addressed the limitations and potential negative societal impact of their work? If not, suggest improvements.',
+      },
+      {
+        id: 'soundness',
+        label: 'Soundness',
+        guidance:
+          'Are the technical claims, experimental methodology and evidence sound?',
+        scale: QUALITY_4,
+      },
+      {
+        id: 'presentation',
+        label: 'Presentation',
+        guidance: 'How clear is the writing and how well is it contextualized?',
+        scale: QUALITY_4,
+      },
+      {
+        id: 'contribution',
+        label: 'Contribution',
+        guidance: 'How important are the questions and results to the area?',
+        scale: QUALITY_4,
+      },
+      {
+        id: 'rating',
+        label: 'Rating',
+        guidance: 'Overall rating of the paper.',
+        scale: {
+          min: 1,
+          max: 10,
+          labels: {
+            1: 'Very Strong Reject',
+            2: 'Strong Reject',
+            3: 'Reject',
+            4: 'Borderline reject',
+            5: 'Borderline accept',
+            6: 'Weak Accept',
+            7: 'Accept',
+            8: 'Strong Accept',
+            9: 'Very Strong Accept',
+            10: 'Award quality',
+          },
+        },
+      },
+      {
+        id: 'confidence',
+        label: 'Confidence',
+        guidance: 'How confident are you in your assessment?',
+        scale: CONFIDENCE_5,
+      },
+    ],
+  },
+  icml: {
+    venue: 'icml',
+    name: 'ICML',
+    fields: [
+      {
+        id: 'summary',
+        label: 'Summary',
+        guidance:
+          'Briefly summarize what the paper claims to contribute, in your own words.',
+      },
+      {
+        id: 'claims',
+        label: 'Claims And Evidence',
+        guidance:
+          'Are the claims supported by clear and convincing evidence? Which are problematic?',
+      },
+      {
+        id: 'methods',
+        label: 'Methods And Evaluation Criteria',
+        guidance:
+          'Do the methods and evaluation criteria (e.g. benchmarks) make sense for the problem?',
+      },
+      {
+        id: 'strengths_weaknesses',
+        label: 'Other Strengths And Weaknesses',
+        guidance:
+          'Other strengths and weaknesses, e.g. originality, significance and clarity.',
+      },
+      {
+        id: 'questions',
+        label: 'Questions For Authors',
+        guidance:
+          'Questions whose answers would change your evaluation; say how each answer would change it.',
+      },
+      {
+        id: 'recommendation',
+        label: 'Overall Recommendation',
+        guidance: 'Your recommendation for the paper.',
+        scale: {
+          min: 1,
+          max: 5,
+          labels: {
+            1: 'Reject',
+            2: 'Weak reject',
+            3: 'Weak accept',
+            4: 'Accept',
+            5: 'Strong accept',
+          },
+        },
+      },
+    ],
+  },
+  acl: {
+    venue: 'acl',
+    name: 'ACL Rolling Review',
+    fields: [
+      {
+        id: 'summary',
+        label: 'Paper Summary',
+        guidance:
+          'Describe what the paper is about and what contributions it makes.',
+      },
+      {
+        id: 'strengths',
+        label: 'Summary Of Strengths',
+        guidance:
+          'What are the major reasons to publish this paper at a selective *ACL venue?',
+      },
+      {
+        id: 'weaknesses',
+        label: 'Summary Of Weaknesses',
+        guidance:
+          'What are the concerns that you have about the paper that would cause you to favor its rejection?',
+      },
+      {
+        id: 'comments',
+        label: 'Comments Suggestions And Typos',
+        guidance:
+          'Questions for the authors, suggestions and typos that do not affect your assessment.',
+      },
+      {
+        id: 'soundness',
+        label: 'Soundness',
+        guidance:
+          'How well does the paper support its claims with evidence and argument?',
+        scale: {
+          min: 1,
+          max: 5,
+          labels: {
+            1: 'Major Issues',
+            2: 'Poor',
+            3: 'Acceptable',
+            4: 'Strong',
+            5: 'Excellent',
+          },
+        },
+      },
+      {
+        id: 'excitement',
+        label: 'Excitement',
+        guidance: 'How exciting is this paper for the community?',
+        scale: {
+          min: 1,
+          max: 5,
+          labels: {
+            1: 'Not Exciting',
+            2: 'Potentially Interesting',
+            3: 'Interesting',
+            4: 'Exciting',
+            5: 'Highly Exciting',
+          },
+        },
+      },
+      {
+        id: 'overall',
+        label: 'Overall Assessment',
+        guidance:
+          'If this paper was committed to an *ACL conference, should it be accepted?',
+        scale: {
+          min: 1,
+          max: 5,
+          labels: {
+            1: 'Do not publish',
+            2: 'Resubmit next cycle',
+            3: 'Findings',
+            4: 'Conference',
+            5: 'Award consideration',
+          },
+        },
+      },
+      {
+        id: 'confidence',
+        label: 'Confidence',
+        guidance: 'How confident are you in your assessment?',
+        scale: CONFIDENCE_5,
+      },
+    ],
+  },
+};
+
+export function isReviewVenue(value: string): value is ReviewVenue {
+  return Object.hasOwn(REVIEW_TEMPLATES, value);
+}
+
+export function getReviewsDir(): string {
+  return path.join(getUserResearchDir(), 'reviews');
+}
+
+export function createReview(paper: string, venue: ReviewVenue): PaperReview {
+  const now = new Date().toISOString();
+  return {
+    id: crypto
+      .createHash('sha256')
+      .update(`${paper}|${venue}`)
+      .digest('hex')
+      .slice(0, 8),
+    venue,
+    paper,
+    created: now,
+    updated: now,
+    answers: {},
+  };
+}
+
+export async function saveReview(
+  review: PaperReview,
+  dir: string = getReviewsDir(),
+): Promise<void> {
+  await fs.promises.mkdir(dir, { recursive: true });
+  await fs.promises.writeFile(
+    path.join(dir, `${review.id}.json`),
+    JSON.stringify(review, null, 2),
+    'utf8',
+  );
+}
+
+/** All saved reviews, most recently updated first. */
+export async function listReviews(
+  dir: string = getReviewsDir(),
+): Promise<PaperReview[]> {
+  let names: string[];
+  try {
+    names = await fs.promises.readdir(dir);
+  } catch (error) {
+    if (isNodeError(error) && error.code === 'ENOENT') {
+      return [];
+    }
+    throw error;
+  }
+  const reviews: PaperReview[] = [];
+  for (const name of names.filter((n) => n.endsWith('.json'))) {
+    reviews.push(
+      JSON.parse(await fs.promises.readFile(path.join(dir, name), 'utf8')),
+    );
+  }
+  return reviews.sort((a, b) => b.updated.localeCompare(a.updated));
+}
+
+/** The first field without an answer, in form order. */
+export function nextReviewField(review: PaperReview): ReviewField | undefined {
+  return REVIEW_TEMPLATES[review.venue].fields.find(
+    (field) => review.answers[field.id] === undefined,
+  );
+}
+
+/**
+ * Finds a field by id or by the start of its label, ignoring case.
+ */
+export function findReviewField(
+  venue: ReviewVenue,
+  name: string,
+): ReviewField | undefined {
+  const wanted = name.toLowerCase();
+  const fields = REVIEW_TEMPLATES[venue].fields;
+  return (
+    fields.find((field) => field.id === wanted) ??
+    fields.find((field) => field.label.toLowerCase().startsWith(wanted))
+  );
+}
+
+/**
+ * Records an answer. Scores must be integers on the field's scale.
+ */
+export function setReviewAnswer(
+  review: PaperReview,
+  field: ReviewField,
+  value: string,
+): void {
+  const text = value.trim();
+  if (!text) {
+    throw new Error(`${field.label} needs a value.`);
+  }
+  if (field.scale) {
+    const score = Number(text.match(/^\d+/)?.[0]);
+    if (
+      !Number.isInteger(score) ||
+      score < field.scale.min ||
+      score > field.scale.max
+    ) {
+      throw new Error(
+        `${field.label} is a score from ${field.scale.min} to ${field.scale.max}.`,
+      );
+    }
+    review.answers[field.id] = score;
+  } else {
+    review.answers[field.id] = text;
+  }
+  review.updated = new Date().toISOString();
+}
+
+/** Guidance for a field, including the meaning of each score. */
+export function describeReviewField(field: ReviewField): string {
+  const lines = [`${field.label}: ${field.guidance}`];
+  if (field.scale) {
+    for (let score = field.scale.max; score >= field.scale.min; score--) {
+      lines.push(`  ${score}: ${field.scale.labels[score]}`);
+    }
+  }
+  return lines.join('\n');
+}
+
+function formatAnswer(field: ReviewField, answer: string | number): string {
+  return field.scale && typeof answer === 'number'
+    ? `${answer}: ${field.scale.labels[answer]}`
+    : String(answer);
+}
+
+/**
+ * The review as plain text with the form's field names as headings,
+ * ready to paste field by field into the venue's review form.
+ */
+export function formatReviewForm(review: PaperReview): string {
+  return REVIEW_TEMPLATES[review.venue].fields
+    .map((field) => {
+      const answer = review.answers[field.id];
+      return `${field.label}:\n${answer === undefined ? '(not filled in)' : formatAnswer(field, answer)}`;
+    })
+    .join('\n\n');
+}
+
+export function formatReviewMarkdown(review: PaperReview): string {
+  const template = REVIEW_TEMPLATES[review.venue];
+  return [
+    `# ${template.name} review: ${path.basename(review.paper)}`,
+    '',
+    ...template.fields.flatMap((field) => {
+      const answer = review.answers[field.id];
+      return [
+        `## ${field.label}`,
+        '',
+        answer === undefined ? '_Not filled in._' : formatAnswer(field, answer),
+        '',
+      ];
+    }),
+  ].join('\n');
+}
+
+/**
+ * Asks the model to draft one field from the paper text and the answers
+ * given so far. The reviewer is expected to edit the result.
+ */
+export async function draftReviewField(
+  client: Pick<ResearchClient, 'generateContent'>,
+  review: PaperReview,
+  field: ReviewField,
+  paperText: string,
+  abortSignal: AbortSignal,
+): Promise<string> {
+  const template = REVIEW_TEMPLATES[review.venue];
+  const answered = template.fields
+    .filter((f) => f.id !== field.id && review.answers[f.id] !== undefined)
+    .map((f) => `${f.label}: ${formatAnswer(f, review.answers[f.id])}`);
+  const prompt = [
+    `You are helping a reviewer write a ${template.name} review. Draft the "${field.label}" field of the review form.`,
+    describeReviewField(field),
+    field.scale
+      ? `Answer with the score number first, then one sentence of justification.`
+      : 'Be specific and refer to sections, equations, tables or figures of the paper. Use plain text with short bullet points where the form expects a list.',
+    ...(answered.length > 0
+      ? ['', "The reviewer's answers so far:", ...answered]
+      : []),
+    '',
+    'Paper text:',
+    paperText.slice(0, MAX_PAPER_TEXT_CHARS),
+  ].join('\n');
+
+  const response = await client.generateContent(
+    [{ role: 'user', parts: [{ text: prompt }] }],
+    {},
+    abortSignal,
+  );
+  const text = getResponseText(response)?.trim();
+  if (!text) {
+    throw new Error('The model returned an empty draft.');
+  }
+  return text;
+}
@@ -0,0 +1,86 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+/**
+ * arXiv API Client - Search the public arXiv Atom API directly
+ * Unlike ArXivMCPClient, it needs no MCP server
+ */
+
+import { fetchWithTimeout } from '../../../utils/fetch.js';
+import { BibliographyEntry, PaperSearchOptions } from '../types.js';
+import { cleanXmlText } from './pubmed-client.js';
+
+const QUERY_URL = 'https://export.arxiv.org/api/query';
+const DEFAULT_TIMEOUT_MS = 30000;
+
+function tagValue(xml: string, tag: string): string | undefined {
+  const match = xml.match(new RegExp(`<${tag}[^>]*>([\\s\\S]*?)</${tag}>`));
+  return match ? cleanXmlText(match[1]) : undefined;
+}
+
+/**
+ * Parses the entries of an arXiv Atom feed
+ */
+export function parseArxivFeed(
+  xml: string,
+): Array<Partial<BibliographyEntry>> {
+  const entries: Array<Partial<BibliographyEntry>> = [];
+  for (const [entryXml] of xml.matchAll(/<entry>[\s\S]*?<\/entry>/g)) {
+    const url = tagValue(entryXml, 'id');
+    const title = tagValue(entryXml, 'title');
+    const arxivId = url?.match(/arxiv\.org\/abs\/(.+)$/)?.[1];
+    if (!title || !arxivId) {
+      continue;
+    }
+    const published = tagValue(entryXml, 'published');
+    entries.push({
+      id: `arxiv_${arxivId}`,
+      title,
+      authors: [...entryXml.matchAll(/<author>([\s\S]*?)<\/author>/g)]
+        .map(([authorXml]) => tagValue(authorXml, 'name'))
+        .filter((name): name is string => !!name),
+      year: published ? new Date(published).getFullYear() : undefined,
+      abstract: tagValue(entryXml, 'summary'),
+      journal: tagValue(entryXml, 'arxiv:journal_ref'),
+      doi: tagValue(entryXml, 'arxiv:doi'),
+      url,
+      arxivId,
+    });
+  }
+  return entries;
+}
+
+/**
+ * arXiv API Client for preprint search
+ */
+export class ArxivApiClient {
+  constructor(private readonly timeout: number = DEFAULT_TIMEOUT_MS) {}
+
+  async searchPapers(
+    query: string,
+    options: PaperSearchOptions = {},
+  ): Promise<Array<Partial<BibliographyEntry>>> {
+    let searchQuery = `all:${query}`;
+    if (options.yearRange) {
+      searchQuery += ` AND submittedDate:[${options.yearRange.start}01010000 TO ${options.yearRange.end}12312359]`;
+    }
+    const params = new URLSearchParams({
+      search_query: searchQuery,
+      start: '0',
+      max_results: String(options.maxResults || 20),
+      sortBy: options.sortBy === 'date' ? 'submittedDate' : 'relevance',
+      sortOrder: 'descending',
+    });
+    const response = await fetchWithTimeout(
+      `${QUERY_URL}?${params}`,
+      this.timeout,
+    );
+    if (!response.ok) {
+      throw new Error(`arXiv API error: ${response.status}`);
+    }
+    return parseArxivFeed(await response.text());
+  }
+}
@@ -0,0 +1,200 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, afterEach, vi } from 'vitest';
+import {
+  applyBibLintFixes,
+  findDuplicateGroups,
+  findUnresolvedDois,
+  getBibEntries,
+  lintBibliography,
+  mergeDuplicateGroup,
+  protectTitleCapitals,
+} from './bib-cleanup.js';
+import { parseBibtex, serializeBibtex } from './bibtex.js';
+
+const DUPLICATES = `@article{vaswani2017,
+  title = {Attention Is All You Need},
+  author = {Vaswani, Ashish},
+  year = {2017},
+}
+
+@inproceedings{attention,
+  title = {Attention is all you need},
+  booktitle = {NeurIPS},
+  year = {2017},
+  doi = {10.5555/3295222.3295349},
+}
+
+@article{other2017,
+  title = {Attention is not explanation},
+  year = {2017},
+}
+`;
+
+describe('findDuplicateGroups', () => {
+  it('should group entries by key, DOI, arXiv id or title', () => {
+    const entries = getBibEntries(
+      parseBibtex(`${DUPLICATES}
+@misc{a, title = {One}, eprint = {2101.00001v2}}
+@misc{b, title = {Two}, eprint = {2101.00001}}
+@misc{other2017, title = {Three}}
+`),
+    );
+    const groups = findDuplicateGroups(entries);
+
+    expect(
+      groups.map((g) => [g.reason, g.entries.map((e) => e.key)]),
+    ).toEqual([
+      ['title', ['vaswani2017', 'attention']],
+      ['key', ['other2017', 'other2017']],
+      ['eprint', ['a', 'b']],
+    ]);
+  });
+
+  it('should not group entries with different DOIs', () => {
+    const entries = getBibEntries(
+      parseBibtex(`@article{x, title = {Same title here}, doi = {10.1/a}}
+@article{y, title = {Same title here}, doi = {10.1/b}}`),
+    );
+    expect(findDuplicateGroups(entries)).toEqual([]);
+  });
+
+  it('should find near-identical titles among many entries', () => {
+    const filler = Array.from(
+      { length: 2000 },
+      (_, i) => `@misc{p${i}, title = {Deep learning for task number ${i}}}`,
+    );
+    const entries = getBibEntries(
+      parseBibtex(
+        [
+          ...filler,
+          '@misc{bert, title = {BERT: Pre-training of deep bidirectional transformers for language understanding}}',
+          '@misc{bert2, title = {Pre-training of deep bidirectional transformers for language understanding}}',
+        ].join('\n'),
+      ),
+    );
+
+    expect(
+      findDuplicateGroups(entries).map((g) => g.entries.map((e) => e.key)),
+    ).toEqual([['bert', 'bert2']]);
+  });
+});
+
+describe('mergeDuplicateGroup', () => {
+  it('should keep the fullest entry and fill in missing fields', () => {
+    const items = parseBibtex(DUPLICATES);
+    const [group] = findDuplicateGroups(getBibEntries(items));
+
+    expect(mergeDuplicateGroup(items, group)).toEqual({
+      kept: 'attention',
+      replaced: ['vaswani2017'],
+    });
+    expect(serializeBibtex(items)).toBe(`@inproceedings{attention,
+  title     = {Attention is all you need},
+  booktitle = {NeurIPS},
+  year      = {2017},
+  doi       = {10.5555/3295222.3295349},
+  author    = {Vaswani, Ashish},
+}
+
+@article{other2017,
+  title = {Attention is not explanation},
+  year = {2017},
+}
+`);
+  });
+});
+
+describe('protectTitleCapitals', () => {
+  it('should brace acronyms and mixed-case words outside braces', () => {
+    expect(
+      protectTitleCapitals('BERT on {GPUs} with \\LaTeX and McDonald data'),
+    ).toBe('{BERT} on {GPUs} with \\LaTeX and {McDonald} data');
+  });
+});
+
+describe('lintBibliography', () => {
+  it('should report missing fields, capitalization and DOIs', () => {
+    const entries = getBibEntries(
+      parseBibtex(`@article{a,
+  author = {A}, title = {Fast GANs}, journal = {Nature Methods},
+  year = {2020}, doi = {https://doi.org/10.1038/x1},
+}
+@article{b,
+  author = {B}, title = {DEEP LEARNING FOR ALL}, journal = {Nature methods},
+  year = {2021}, doi = {not-a-doi},
+}
+@article{c,
+  author = {C}, title = {Plain}, journal = {Nature Methods},
+}`),
+    );
+    const issues = lintBibliography(entries);
+
+    expect(issues.map((i) => [i.key, i.rule, i.message])).toEqual([
+      [
+        'a',
+        'capitalization',
+        'Title has capitals that BibTeX styles will lower-case',
+      ],
+      ['a', 'doi', 'DOI should not include a resolver prefix'],
+      ['b', 'capitalization', 'Title is written in all capitals'],
+      ['b', 'doi', 'Malformed DOI "not-a-doi"'],
+      ['c', 'missing-field', '@article is missing year or date'],
+      [
+        'b',
+        'capitalization',
+        'journal "Nature methods" is spelled "Nature Methods" elsewhere',
+      ],
+    ]);
+
+    expect(applyBibLintFixes(entries, issues)).toBe(3);
+    expect(entries[0].fields['title']).toBe('{Fast {GANs}}');
+    expect(entries[0].fields['doi']).toBe('{10.1038/x1}');
+    expect(entries[1].fields['journal']).toBe('{Nature Methods}');
+  });
+});
+
+describe('findUnresolvedDois', () => {
+  afterEach(() => {
+    vi.unstubAllGlobals();
+  });
+
+  it('should report DOIs the resolver does not know', async () => {
+    vi.stubGlobal(
+      'fetch',
+      vi.fn(async (url: string) =>
+        new Response('{}', { status: url.endsWith('missing') ? 404 : 200 }),
+      ),
+    );
+    const entries = getBibEntries(
+      parseBibtex(`@misc{a, doi = {10.1000/ok}}
+@misc{b, doi = {10.1000/missing}}`),
+    );
+
+    const issues = await findUnresolvedDois(entries);
+
+    expect(issues.map((i) => [i.key, i.message])).toEqual([
+      ['b', 'DOI 10.1000/missing does not resolve'],
+    ]);
+  });
+
+  it('should encode the characters of a DOI that break a URL', async () => {
+    const fetch = vi.fn(
+      async (_url: string) => new Response('{}', { status: 200 }),
+    );
+    vi.stubGlobal('fetch', fetch);
+    const entries = getBibEntries(
+      parseBibtex('@misc{a, doi = {10.1002/(SICI)1097#4<1::AID>3.0.CO;2-?}}'),
+    );
+
+    await findUnresolvedDois(entries);
+
+    expect(fetch.mock.calls[0][0]).toBe(
+      'https://doi.org/api/handles/10.1002/(SICI)1097%234%3C1%3A%3AAID%3E3.0.CO%3B2-%3F',
+    );
+  });
+});
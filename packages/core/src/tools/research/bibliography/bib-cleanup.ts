@@ -0,0 +1,488 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+/**
+ * Bibliography Cleanup - Duplicate detection and linting for .bib files
+ * Checks that can be repaired carry a fix that applyBibLintFixes applies
+ */
+
+import { fetchWithTimeout } from '../../../utils/fetch.js';
+import {
+  BibtexEntry,
+  BibtexItem,
+  getBibtexField,
+  isBibtexEntry,
+  setBibtexField,
+} from './bibtex.js';
+import {
+  normalizeDoi,
+  titleBigrams,
+  titleSimilarity,
+} from './metasearch.js';
+
+const TITLE_SIMILARITY_THRESHOLD = 0.9;
+const DOI_PATTERN = /^10\.\d{4,9}\/\S+$/;
+const DOI_PREFIX = /^(https?:\/\/(dx\.)?doi\.org\/|doi:\s*)/i;
+const DOI_TIMEOUT_MS = 10000;
+
+/**
+ * Fields each entry type needs; alternatives are separated by `|`
+ * (`date` and `journaltitle` are the biblatex spellings).
+ */
+const REQUIRED_FIELDS: Record<string, string[]> = {
+  article: ['author', 'title', 'journal|journaltitle', 'year|date'],
+  inproceedings: ['author', 'title', 'booktitle', 'year|date'],
+  conference: ['author', 'title', 'booktitle', 'year|date'],
+  book: ['author|editor', 'title', 'publisher', 'year|date'],
+  incollection: ['author', 'title', 'booktitle', 'publisher', 'year|date'],
+  phdthesis: ['author', 'title', 'school', 'year|date'],
+  mastersthesis: ['author', 'title', 'school', 'year|date'],
+  techreport: ['author', 'title', 'institution', 'year|date'],
+  unpublished: ['author', 'title', 'note'],
+  misc: ['title'],
+};
+
+export type DuplicateReason = 'key' | 'doi' | 'eprint' | 'title';
+
+export interface DuplicateGroup {
+  entries: BibtexEntry[];
+  /** The strongest evidence linking the entries. */
+  reason: DuplicateReason;
+}
+
+export type BibLintRule =
+  | 'duplicate-key'
+  | 'missing-field'
+  | 'capitalization'
+  | 'doi';
+
+export interface BibLintIssue {
+  key: string;
+  rule: BibLintRule;
+  severity: 'error' | 'warning';
+  message: string;
+  /** Replacement field value that resolves the issue, if there is one. */
+  fix?: { field: string; value: string };
+}
+
+export function getBibEntries(items: BibtexItem[]): BibtexEntry[] {
+  return items.filter(isBibtexEntry);
+}
+
+function plainText(value: string): string {
+  return value.replace(/\\[a-zA-Z]+\s*/g, '').replace(/[{}\\]/g, '');
+}
+
+function entryYear(entry: BibtexEntry): number | undefined {
+  const value =
+    getBibtexField(entry, 'year') ?? getBibtexField(entry, 'date');
+  const match = value?.match(/\d{4}/);
+  return match ? Number(match[0]) : undefined;
+}
+
+function entryEprint(entry: BibtexEntry): string | undefined {
+  const eprint = getBibtexField(entry, 'eprint');
+  const archive = getBibtexField(entry, 'archiveprefix') ?? 'arxiv';
+  if (!eprint || archive.toLowerCase() !== 'arxiv') {
+    return undefined;
+  }
+  return eprint.replace(/^arxiv:/i, '').replace(/v\d+$/, '');
+}
+
+function duplicateReason(
+  a: BibtexEntry,
+  b: BibtexEntry,
+): DuplicateReason | undefined {
+  if (a.key.toLowerCase() === b.key.toLowerCase()) {
+    return 'key';
+  }
+  const doiA = getBibtexField(a, 'doi');
+  const doiB = getBibtexField(b, 'doi');
+  if (doiA && doiB) {
+    // Different DOIs are different works, even with the same title
+    return normalizeDoi(doiA) === normalizeDoi(doiB) ? 'doi' : undefined;
+  }
+  const eprintA = entryEprint(a);
+  if (eprintA && eprintA === entryEprint(b)) {
+    return 'eprint';
+  }
+  const yearA = entryYear(a);
+  const yearB = entryYear(b);
+  if (yearA && yearB && yearA !== yearB) {
+    return undefined;
+  }
+  const similarity = titleSimilarity(
+    plainText(getBibtexField(a, 'title') ?? ''),
+    plainText(getBibtexField(b, 'title') ?? ''),
+  );
+  return similarity >= TITLE_SIMILARITY_THRESHOLD ? 'title' : undefined;
+}
+
+const REASON_STRENGTH: DuplicateReason[] = ['title', 'eprint', 'doi', 'key'];
+
+/**
+ * Pairs of entries that may be duplicates, without comparing every pair:
+ * entries sharing a key, DOI or arXiv id, and entries sharing one of the
+ * rarest bigrams of a title. Titles similar enough to be duplicates must
+ * share most of their bigrams, so probing the rarest few finds them all.
+ */
+function candidatePairs(entries: BibtexEntry[]): Array<[number, number]> {
+  const buckets = new Map<string, number[]>();
+  const addTo = (bucket: string, i: number) => {
+    const members = buckets.get(bucket);
+    if (members) {
+      members.push(i);
+    } else {
+      buckets.set(bucket, [i]);
+    }
+  };
+  const bigrams = entries.map((entry) =>
+    titleBigrams(plainText(getBibtexField(entry, 'title') ?? '')),
+  );
+  entries.forEach((entry, i) => {
+    addTo(`key:${entry.key.toLowerCase()}`, i);
+    const doi = getBibtexField(entry, 'doi');
+    if (doi) {
+      addTo(`doi:${normalizeDoi(doi)}`, i);
+    }
+    const eprint = entryEprint(entry);
+    if (eprint) {
+      addTo(`eprint:${eprint}`, i);
+    }
+    for (const bigram of new Set(bigrams[i])) {
+      addTo(`title:${bigram}`, i);
+    }
+  });
+
+  const pairs = new Map<string, [number, number]>();
+  const pair = (i: number, j: number) => {
+    const [a, b] = i < j ? [i, j] : [j, i];
+    pairs.set(`${a},${b}`, [a, b]);
+  };
+  for (const [bucket, members] of buckets) {
+    if (bucket.startsWith('title:')) {
+      continue;
+    }
+    members.forEach((i, m) => members.slice(m + 1).forEach((j) => pair(i, j)));
+  }
+  // A title with n bigrams shares at least t·n/(2−t) of them with any title
+  // at Dice similarity t, so one of its rarest n − that + 1 is shared
+  const t = TITLE_SIMILARITY_THRESHOLD;
+  bigrams.forEach((title, i) => {
+    const rarest = [...new Set(title)].sort(
+      (a, b) =>
+        buckets.get(`title:${a}`)!.length - buckets.get(`title:${b}`)!.length,
+    );
+    const probes = title.length - Math.ceil((t * title.length) / (2 - t)) + 1;
+    for (const bigram of rarest.slice(0, probes)) {
+      for (const j of buckets.get(`title:${bigram}`)!) {
+        if (j !== i) {
+          pair(i, j);
+        }
+      }
+    }
+  });
+  return [...pairs.values()];
+}
+
+/**
+ * Groups entries that describe the same work: a shared citation key,
+ * DOI or arXiv id, or a near-identical title in the same year.
+ */
+export function findDuplicateGroups(entries: BibtexEntry[]): DuplicateGroup[] {
+  const groupOf = entries.map((_, i) => i);
+  const root = (i: number): number =>
+    groupOf[i] === i ? i : (groupOf[i] = root(groupOf[i]));
+  const reasons = new Map<number, DuplicateReason>();
+
+  for (const [i, j] of candidatePairs(entries)) {
+    const reason = duplicateReason(entries[i], entries[j]);
+    if (!reason) {
+      continue;
+    }
+    const [a, b] = [root(i), root(j)].sort((x, y) => x - y);
+    groupOf[b] = a;
+    const strongest = [reason, reasons.get(a), reasons.get(b)]
+      .filter((r): r is DuplicateReason => r !== undefined)
+      .sort((x, y) => REASON_STRENGTH.indexOf(y) - REASON_STRENGTH.indexOf(x));
+    reasons.set(a, strongest[0]);
+  }
+
+  const groups = new Map<number, BibtexEntry[]>();
+  entries.forEach((entry, i) => {
+    const r = root(i);
+    const group = groups.get(r);
+    if (group) {
+      group.push(entry);
+    } else {
+      groups.set(r, [entry]);
+    }
+  });
+  return [...groups.entries()]
+    .filter(([, group]) => group.length > 1)
+    .map(([r, group]) => ({ entries: group, reason: reasons.get(r)! }));
+}
+
+function removeItem(items: BibtexItem[], entry: BibtexEntry): void {
+  const index = items.indexOf(entry);
+  if (index === -1) {
+    return;
+  }
+  const previous = items[index - 1];
+  const next = items[index + 1];
+  if (typeof next === 'string') {
+    // Drop the blank line that separated the entry from the next one
+    items[index + 1] = next.replace(/^[ \t]*\r?\n(?:[ \t]*\r?\n)?/, '');
+    if (index + 2 === items.length && typeof previous === 'string') {
+      // Nothing follows, so drop the blank line before it instead
+      items[index - 1] = previous.replace(/\r?\n[ \t]*$/, '');
+    }
+  }
+  items.splice(index, 1);
+}
+
+/**
+ * Merges a duplicate group into its most complete entry: fields only the
+ * other entries have are copied over and the other entries are removed.
+ * Returns the kept key and the keys whose citations should be updated.
+ */
+export function mergeDuplicateGroup(
+  items: BibtexItem[],
+  group: DuplicateGroup,
+): { kept: string; replaced: string[] } {
+  const [kept, ...others] = [...group.entries].sort(
+    (a, b) =>
+      Object.keys(b.fields).length - Object.keys(a.fields).length ||
+      items.indexOf(a) - items.indexOf(b),
+  );
+  for (const other of others) {
+    for (const [name, value] of Object.entries(other.fields)) {
+      if (kept.fields[name] === undefined) {
+        kept.fields[name] = value;
+        kept.raw = undefined;
+      }
+    }
+    removeItem(items, other);
+  }
+  const replaced = others
+    .map((other) => other.key)
+    .filter((key, i, keys) => key !== kept.key && keys.indexOf(key) === i);
+  return { kept: kept.key, replaced };
+}
+
+/**
+ * Wraps words that BibTeX styles would lower-case, such as acronyms and
+ * names like "iPhone", in braces. Text that is already braced is left
+ * alone.
+ */
+export function protectTitleCapitals(title: string): string {
+  let result = '';
+  let depth = 0;
+  let segment = '';
+  const flush = () => {
+    result += segment.replace(
+      /(^|[^\\A-Za-z])([A-Za-z][A-Za-z0-9]*)/g,
+      (match, before: string, word: string) =>
+        /.[A-Z]/.test(word) ? `${before}{${word}}` : match,
+    );
+    segment = '';
+  };
+  for (const char of title) {
+    if (depth === 0 && char !== '{') {
+      segment += char;
+      continue;
+    }
+    if (depth === 0) {
+      flush();
+    }
+    result += char;
+    if (char === '{') {
+      depth++;
+    } else if (char === '}') {
+      depth = Math.max(0, depth - 1);
+    }
+  }
+  flush();
+  return result;
+}
+
+function lintEntry(entry: BibtexEntry): BibLintIssue[] {
+  const issues: BibLintIssue[] = [];
+  const issue = (
+    rule: BibLintRule,
+    severity: BibLintIssue['severity'],
+    message: string,
+    fix?: BibLintIssue['fix'],
+  ) => issues.push({ key: entry.key, rule, severity, message, fix });
+
+  const missing = (REQUIRED_FIELDS[entry.type] ?? []).filter((required) =>
+    required.split('|').every((name) => !getBibtexField(entry, name)),
+  );
+  for (const required of missing) {
+    issue(
+      'missing-field',
+      'error',
+      `@${entry.type} is missing ${required.split('|').join(' or ')}`,
+    );
+  }
+
+  const title = getBibtexField(entry, 'title');
+  if (title) {
+    const words = plainText(title).match(/[A-Za-z]+/g) ?? [];
+    const allCapitals = words.every((word) => word === word.toUpperCase());
+    if (words.length > 2 && allCapitals) {
+      issue('capitalization', 'warning', 'Title is written in all capitals');
+    } else {
+      const protectedTitle = protectTitleCapitals(title);
+      if (protectedTitle !== title) {
+        issue(
+          'capitalization',
+          'warning',
+          'Title has capitals that BibTeX styles will lower-case',
+          { field: 'title', value: protectedTitle },
+        );
+      }
+    }
+  }
+
+  const doi = getBibtexField(entry, 'doi');
+  if (doi) {
+    const bare = doi.replace(DOI_PREFIX, '').trim();
+    if (!DOI_PATTERN.test(bare)) {
+      issue('doi', 'error', `Malformed DOI "${doi}"`);
+    } else if (bare !== doi) {
+      issue('doi', 'warning', 'DOI should not include a resolver prefix', {
+        field: 'doi',
+        value: bare,
+      });
+    }
+  }
+  return issues;
+}
+
+/**
+ * Flags journal and proceedings names spelled with different
+ * capitalization across entries; the most common spelling wins.
+ */
+function lintVenueSpelling(entries: BibtexEntry[]): BibLintIssue[] {
+  const issues: BibLintIssue[] = [];
+  for (const field of ['journal', 'booktitle']) {
+    const spellings = new Map<string, Map<string, number>>();
+    for (const entry of entries) {
+      const value = getBibtexField(entry, field);
+      if (!value) {
+        continue;
+      }
+      const counts = spellings.get(value.toLowerCase()) ?? new Map();
+      counts.set(value, (counts.get(value) ?? 0) + 1);
+      spellings.set(value.toLowerCase(), counts);
+    }
+    for (const entry of entries) {
+      const value = getBibtexField(entry, field);
+      const counts = value && spellings.get(value.toLowerCase());
+      if (!counts || counts.size < 2) {
+        continue;
+      }
+      const [preferred] = [...counts.entries()].sort((a, b) => b[1] - a[1])[0];
+      if (value !== preferred) {
+        issues.push({
+          key: entry.key,
+          rule: 'capitalization',
+          severity: 'warning',
+          message: `${field} "${value}" is spelled "${preferred}" elsewhere`,
+          fix: { field, value: preferred },
+        });
+      }
+    }
+  }
+  return issues;
+}
+
+/**
+ * Checks entries for duplicate keys, missing required fields,
+ * capitalization BibTeX would lose and malformed DOIs.
+ */
+export function lintBibliography(entries: BibtexEntry[]): BibLintIssue[] {
+  const issues: BibLintIssue[] = [];
+  const seen = new Set<string>();
+  for (const entry of entries) {
+    if (seen.has(entry.key.toLowerCase())) {
+      issues.push({
+        key: entry.key,
+        rule: 'duplicate-key',
+        severity: 'error',
+        message: 'Citation key is used by more than one entry',
+      });
+    }
+    seen.add(entry.key.toLowerCase());
+    issues.push(...lintEntry(entry));
+  }
+  return [...issues, ...lintVenueSpelling(entries)];
+}
+
+/**
+ * A DOI as a URL path. Its suffix may contain `#`, `?`, `%` and other
+ * characters that encodeURI leaves alone, so each segment is encoded and
+ * only the slashes are kept.
+ */
+function encodeDoiPath(doi: string): string {
+  return doi.split('/').map(encodeURIComponent).join('/');
+}
+
+/**
+ * Asks the doi.org handle service whether each well-formed DOI exists.
+ */
+export async function findUnresolvedDois(
+  entries: BibtexEntry[],
+  timeout: number = DOI_TIMEOUT_MS,
+): Promise<BibLintIssue[]> {
+  const checks = entries.map(async (entry): Promise<BibLintIssue[]> => {
+    const doi = getBibtexField(entry, 'doi')?.replace(DOI_PREFIX, '').trim();
+    if (!doi || !DOI_PATTERN.test(doi)) {
+      return [];
+    }
+    try {
+      const response = await fetchWithTimeout(
+        `https://doi.org/api/handles/${encodeDoiPath(doi)}`,
+        timeout,
+      );
+      if (response.status !== 404) {
+        return [];
+      }
+    } catch {
+      // Unreachable resolver: nothing is known about the DOI
+      return [];
+    }
+    return [
+      {
+        key: entry.key,
+        rule: 'doi',
+        severity: 'error',
+        message: `DOI ${doi} does not resolve`,
+      },
+    ];
+  });
+  return (await Promise.all(checks)).flat();
+}
+
+/**
+ * Applies the fixes of the given issues to the entries they refer to and
+ * returns how many were applied.
+ */
+export function applyBibLintFixes(
+  entries: BibtexEntry[],
+  issues: BibLintIssue[],
+): number {
+  let applied = 0;
+  for (const { key, fix } of issues) {
+    const entry = entries.find((e) => e.key === key);
+    if (entry && fix) {
+      setBibtexField(entry, fix.field, fix.value);
+      applied++;
+    }
+  }
+  return applied;
+}
@@ -0,0 +1,72 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect } from 'vitest';
+import {
+  BibtexEntry,
+  getBibtexField,
+  isBibtexEntry,
+  parseBibtex,
+  serializeBibtex,
+  setBibtexField,
+} from './bibtex.js';
+
+const SOURCE = `% My references
+@string{nat = "Nature"}
+
+@Article{smith2020,
+  author = {Smith, John and Doe, Jane},
+  title  = "A {GPU} study of {\\"U}ber graphs",
+  journal = nat,
+  year   = 2020,
+  note   = {Part } # {two},
+}
+
+@misc{broken, title = {Unclosed
+
+@inproceedings(lee2021, title={Paper}, booktitle={ICML}, year={2021})
+`;
+
+describe('parseBibtex', () => {
+  it('should parse entries and keep other text verbatim', () => {
+    const items = parseBibtex(SOURCE);
+    const entries = items.filter(isBibtexEntry);
+
+    expect(entries.map((entry) => entry.key)).toEqual(['smith2020', 'lee2021']);
+    const [smith, lee] = entries;
+    expect(smith.type).toBe('article');
+    expect(smith.fields['journal']).toBe('nat');
+    expect(smith.fields['note']).toBe('{Part } # {two}');
+    expect(getBibtexField(smith, 'title')).toBe(
+      'A {GPU} study of {\\"U}ber graphs',
+    );
+    expect(getBibtexField(smith, 'year')).toBe('2020');
+    expect(getBibtexField(lee, 'booktitle')).toBe('ICML');
+    expect(serializeBibtex(items)).toBe(SOURCE);
+  });
+
+  it('should rewrite only modified entries', () => {
+    const items = parseBibtex(SOURCE);
+    const lee = items.find(
+      (item): item is BibtexEntry =>
+        isBibtexEntry(item) && item.key === 'lee2021',
+    )!;
+    setBibtexField(lee, 'doi', '10.1/x');
+
+    const text = serializeBibtex(items);
+    expect(text).toContain('@Article{smith2020,');
+    expect(text).toContain(
+      [
+        '@inproceedings{lee2021,',
+        '  title     = {Paper},',
+        '  booktitle = {ICML},',
+        '  year      = {2021},',
+        '  doi       = {10.1/x},',
+        '}',
+      ].join('\n'),
+    );
+  });
+});
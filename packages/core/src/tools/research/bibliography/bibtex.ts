@@ -0,0 +1,221 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+/**
+ * BibTeX File - Parse and write .bib files without losing formatting
+ * Entries that are not modified are written back exactly as they were read
+ */
+
+export interface BibtexEntry {
+  /** Entry type in lower case, e.g. `article`. */
+  type: string;
+  key: string;
+  /**
+   * Field values as written in the file, including braces, quotes, `#`
+   * concatenations and macros. Names are lower case.
+   */
+  fields: Record<string, string>;
+  /** Original source text; cleared when the entry is modified. */
+  raw?: string;
+}
+
+/**
+ * A parsed file: entries interleaved with everything else (comments,
+ * `@string` and `@preamble` blocks, whitespace), kept verbatim.
+ */
+export type BibtexItem = BibtexEntry | string;
+
+const VERBATIM_TYPES = new Set(['comment', 'string', 'preamble']);
+
+export function isBibtexEntry(item: BibtexItem): item is BibtexEntry {
+  return typeof item !== 'string';
+}
+
+class Scanner {
+  pos = 0;
+
+  constructor(readonly text: string) {}
+
+  skipWhitespace(): void {
+    while (this.pos < this.text.length && /\s/.test(this.text[this.pos])) {
+      this.pos++;
+    }
+  }
+
+  readUntil(pattern: RegExp): string {
+    const start = this.pos;
+    while (this.pos < this.text.length && !pattern.test(this.text[this.pos])) {
+      this.pos++;
+    }
+    return this.text.slice(start, this.pos);
+  }
+
+  /** Reads a `{...}` or `"..."` group with nested braces balanced. */
+  readDelimited(): string {
+    const start = this.pos;
+    const closing = this.text[this.pos] === '{' ? '}' : '"';
+    let depth = 0;
+    this.pos++;
+    while (this.pos < this.text.length) {
+      const char = this.text[this.pos++];
+      if (char === '\\') {
+        this.pos++;
+      } else if (char === '{') {
+        depth++;
+      } else if (char === '}' && depth > 0) {
+        depth--;
+      } else if (char === closing && depth === 0) {
+        return this.text.slice(start, this.pos);
+      }
+    }
+    throw new Error(`Unterminated value at offset ${start}`);
+  }
+}
+
+function parseEntry(scanner: Scanner, type: string, close: string) {
+  scanner.skipWhitespace();
+  const key = scanner.readUntil(/[,\s})]/).trim();
+  const fields: Record<string, string> = {};
+
+  for (;;) {
+    scanner.skipWhitespace();
+    const char = scanner.text[scanner.pos];
+    if (char === undefined) {
+      throw new Error(`Unterminated entry ${key}`);
+    }
+    if (char === close) {
+      scanner.pos++;
+      return { type, key, fields };
+    }
+    if (char === ',') {
+      scanner.pos++;
+      continue;
+    }
+    const name = scanner.readUntil(/[=,}\s)]/).toLowerCase();
+    scanner.skipWhitespace();
+    if (!name || scanner.text[scanner.pos] !== '=') {
+      throw new Error(`Malformed field in entry ${key}`);
+    }
+    scanner.pos++;
+
+    const parts: string[] = [];
+    for (;;) {
+      scanner.skipWhitespace();
+      const start = scanner.text[scanner.pos];
+      parts.push(
+        start === '{' || start === '"'
+          ? scanner.readDelimited()
+          : scanner.readUntil(/[\s,#})]/),
+      );
+      scanner.skipWhitespace();
+      if (scanner.text[scanner.pos] !== '#') {
+        break;
+      }
+      scanner.pos++;
+    }
+    fields[name] = parts.join(' # ');
+  }
+}
+
+/**
+ * Parses BibTeX source. A malformed entry is kept as verbatim text so
+ * that writing the file back never drops content.
+ */
+export function parseBibtex(text: string): BibtexItem[] {
+  const items: BibtexItem[] = [];
+  const scanner = new Scanner(text);
+  let textStart = 0;
+
+  while (scanner.pos < text.length) {
+    const at = text.indexOf('@', scanner.pos);
+    if (at === -1) {
+      break;
+    }
+    scanner.pos = at + 1;
+    const type = scanner.readUntil(/[\s{(]/).toLowerCase();
+    scanner.skipWhitespace();
+    const open = text[scanner.pos];
+    if (!/^[a-z]+$/.test(type) || (open !== '{' && open !== '(')) {
+      continue;
+    }
+    if (VERBATIM_TYPES.has(type)) {
+      if (open === '{') {
+        try {
+          scanner.readDelimited();
+        } catch {
+          break;
+        }
+      }
+      continue;
+    }
+
+    scanner.pos++;
+    try {
+      const entry: BibtexEntry = parseEntry(
+        scanner,
+        type,
+        open === '{' ? '}' : ')',
+      );
+      if (at > textStart) {
+        items.push(text.slice(textStart, at));
+      }
+      entry.raw = text.slice(at, scanner.pos);
+      items.push(entry);
+      textStart = scanner.pos;
+    } catch {
+      // Resume after the broken entry's "@"; its text stays verbatim
+      scanner.pos = at + 1;
+    }
+  }
+
+  if (textStart < text.length) {
+    items.push(text.slice(textStart));
+  }
+  return items;
+}
+
+/**
+ * Returns a field's text with the outer braces or quotes removed, or
+ * undefined when the entry does not have it.
+ */
+export function getBibtexField(
+  entry: BibtexEntry,
+  name: string,
+): string | undefined {
+  const value = entry.fields[name];
+  if (value === undefined) {
+    return undefined;
+  }
+  const match = value.match(/^\{([\s\S]*)\}$|^"([\s\S]*)"$/);
+  return (match ? (match[1] ?? match[2]) : value).trim();
+}
+
+/** Sets a field to a braced value and marks the entry as modified. */
+export function setBibtexField(
+  entry: BibtexEntry,
+  name: string,
+  value: string,
+): void {
+  entry.fields[name.toLowerCase()] = `{${value}}`;
+  entry.raw = undefined;
+}
+
+export function formatBibtexEntry(entry: BibtexEntry): string {
+  if (entry.raw !== undefined) {
+    return entry.raw;
+  }
+  const width = Math.max(0, ...Object.keys(entry.fields).map((n) => n.length));
+  const fields = Object.entries(entry.fields).map(
+    ([name, value]) => `  ${name.padEnd(width)} = ${value},`,
+  );
+  return [`@${entry.type}{${entry.key},`, ...fields, '}'].join('\n');
+}
+
+export function serializeBibtex(items: BibtexItem[]): string {
+  return items
+    .map((item) => (isBibtexEntry(item) ? formatBibtexEntry(item) : item))
+    .join('');
+}
@@ -0,0 +1,104 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+/**
+ * CrossRef Client - Search registered DOIs via the REST API
+ */
+
+import { fetchWithTimeout } from '../../../utils/fetch.js';
+import { BibliographyEntry, PaperSearchOptions } from '../types.js';
+import { cleanXmlText } from './pubmed-client.js';
+
+const WORKS_URL = 'https://api.crossref.org/works';
+const DEFAULT_TIMEOUT_MS = 30000;
+const MAX_ROWS = 100;
+const SELECT =
+  'DOI,title,author,issued,container-title,is-referenced-by-count,URL,abstract,type';
+
+interface CrossrefWork {
+  DOI: string;
+  title?: string[];
+  author?: Array<{ given?: string; family?: string; name?: string }>;
+  issued?: { 'date-parts'?: Array<Array<number | null>> };
+  'container-title'?: string[];
+  'is-referenced-by-count'?: number;
+  URL?: string;
+  abstract?: string;
+  type?: string;
+}
+
+/**
+ * CrossRef Client for published literature across all publishers
+ */
+export class CrossrefClient {
+  private mailto?: string;
+
+  constructor(
+    mailto?: string,
+    private readonly timeout: number = DEFAULT_TIMEOUT_MS,
+  ) {
+    // An address puts requests in CrossRef's faster "polite" pool
+    this.mailto = mailto || process.env.CROSSREF_MAILTO;
+  }
+
+  /**
+   * Search works by bibliographic relevance or newest first
+   */
+  async searchPapers(
+    query: string,
+    options: PaperSearchOptions = {},
+  ): Promise<Array<Partial<BibliographyEntry>>> {
+    const filters: string[] = [];
+    if (options.yearRange) {
+      filters.push(
+        `from-pub-date:${options.yearRange.start}`,
+        `until-pub-date:${options.yearRange.end}`,
+      );
+    }
+    const params = new URLSearchParams({
+      'query.bibliographic': query,
+      rows: String(Math.min(options.maxResults || 20, MAX_ROWS)),
+      select: SELECT,
+      ...(filters.length ? { filter: filters.join(',') } : {}),
+      ...(options.sortBy === 'date'
+        ? { sort: 'published', order: 'desc' }
+        : {}),
+      ...(this.mailto ? { mailto: this.mailto } : {}),
+    });
+    const response = await fetchWithTimeout(
+      `${WORKS_URL}?${params}`,
+      this.timeout,
+    );
+    if (!response.ok) {
+      throw new Error(`CrossRef API error: ${response.status}`);
+    }
+    const data = (await response.json()) as {
+      message?: { items?: CrossrefWork[] };
+    };
+
+    return (data.message?.items ?? [])
+      .filter((work) => work.title?.[0])
+      .map((work) => {
+        const year = work.issued?.['date-parts']?.[0]?.[0];
+        return {
+          id: `crossref_${work.DOI}`,
+          title: cleanXmlText(work.title![0]),
+          authors: (work.author ?? [])
+            .map((author) =>
+              author.name ??
+              [author.given, author.family].filter(Boolean).join(' '),
+            )
+            .filter(Boolean),
+          year: year ?? undefined,
+          abstract: work.abstract ? cleanXmlText(work.abstract) : undefined,
+          journal: work['container-title']?.[0],
+          doi: work.DOI,
+          url: work.URL ?? `https://doi.org/${work.DOI}`,
+          citationCount: work['is-referenced-by-count'],
+        };
+      });
+  }
+}
@@ -0,0 +1,150 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, vi } from 'vitest';
+import {
+  formatMetasearchResults,
+  metasearch,
+  normalizeDoi,
+  titleSimilarity,
+} from './metasearch.js';
+
+describe('titleSimilarity', () => {
+  it('should ignore case, accents and punctuation', () => {
+    expect(
+      titleSimilarity(
+        'Attention Is All You Need',
+        'attention is all you need.',
+      ),
+    ).toBe(1);
+    expect(
+      titleSimilarity('Café society networks', 'Cafe Society: Networks'),
+    ).toBe(1);
+  });
+
+  it('should score different titles low', () => {
+    expect(
+      titleSimilarity(
+        'Attention is all you need',
+        'Attention is not explanation',
+      ),
+    ).toBeLessThan(0.5);
+  });
+});
+
+describe('normalizeDoi', () => {
+  it('should strip resolver prefixes and case', () => {
+    expect(normalizeDoi('https://doi.org/10.1000/ABC')).toBe('10.1000/abc');
+    expect(normalizeDoi('doi:10.1000/abc')).toBe('10.1000/abc');
+  });
+});
+
+describe('metasearch', () => {
+  it('should merge duplicates across sources and rank them first', async () => {
+    const result = await metasearch('transformers', {
+      searchers: {
+        arxiv: async () => [
+          { title: 'A unique preprint', year: 2023, authors: ['A'] },
+          {
+            title: 'Attention Is All You Need',
+            year: 2017,
+            arxivId: '1706.03762v5',
+            authors: ['Ashish Vaswani'],
+          },
+        ],
+        semantic_scholar: async () => [
+          {
+            title: 'Attention is all you need.',
+            year: 2017,
+            arxivId: '1706.03762',
+            doi: '10.5555/3295222.3295349',
+            citationCount: 90000,
+          },
+        ],
+        crossref: async () => [
+          {
+            title: 'Attention Is All You Need',
+            year: 2017,
+            doi: 'https://doi.org/10.5555/3295222.3295349',
+            journal: 'NeurIPS',
+          },
+        ],
+        pubmed: async () => {
+          throw new Error('offline');
+        },
+      },
+    });
+
+    expect(result.hits).toHaveLength(2);
+    const [top] = result.hits;
+    expect(top.sources).toEqual(['arxiv', 'semantic_scholar', 'crossref']);
+    expect(top.doi).toBe('10.5555/3295222.3295349');
+    expect(top.venue).toBe('NeurIPS');
+    expect(top.citationCount).toBe(90000);
+    expect(result.duplicatesMerged).toBe(2);
+    expect(result.sources.pubmed).toEqual({ count: 0, error: 'offline' });
+    expect(result.sources.arxiv).toEqual({ count: 2 });
+  });
+
+  it('should keep papers with different journal DOIs apart', async () => {
+    const result = await metasearch('survey', {
+      sources: ['crossref'],
+      searchers: {
+        crossref: async () => [
+          { title: 'Deep learning: a survey', year: 2020, doi: '10.1/a' },
+          { title: 'Deep Learning: A Survey', year: 2020, doi: '10.1/b' },
+        ],
+      },
+    });
+
+    expect(result.hits).toHaveLength(2);
+  });
+
+  it('should pass the year range and query only selected sources', async () => {
+    const arxiv = vi.fn(async () => []);
+    const pubmed = vi.fn(async () => []);
+    await metasearch('cells', {
+      sources: ['pubmed'],
+      perSourceResults: 5,
+      yearRange: { start: 2019, end: 2023 },
+      searchers: { arxiv, pubmed },
+    });
+
+    expect(arxiv).not.toHaveBeenCalled();
+    expect(pubmed).toHaveBeenCalledWith('cells', {
+      maxResults: 5,
+      yearRange: { start: 2019, end: 2023 },
+    });
+  });
+});
+
+describe('formatMetasearchResults', () => {
+  it('should list hits with source badges and a summary', () => {
+    const text = formatMetasearchResults({
+      query: 'q',
+      hits: [
+        {
+          title: 'Paper',
+          authors: ['A', 'B', 'C', 'D'],
+          year: 2020,
+          venue: 'Nature',
+          doi: '10.1/x',
+          citationCount: 3,
+          sources: ['arxiv', 'crossref'],
+          score: 0.03,
+        },
+      ],
+      sources: { arxiv: { count: 1 }, pubmed: { count: 0, error: 'down' } },
+      duplicatesMerged: 1,
+    });
+
+    expect(text).toContain('1. **Paper** (2020)');
+    expect(text).toContain('A, B, C et al. · Nature');
+    expect(text).toContain('[arXiv] [Crossref] · 3 citations');
+    expect(text).toContain('https://doi.org/10.1/x');
+    expect(text).toContain('PubMed: failed (down)');
+  });
+});
@@ -0,0 +1,332 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+/**
+ * Scholarly Metasearch - One query across several literature sources
+ * Results are merged by DOI or near-identical titles and ranked by
+ * reciprocal rank fusion, so papers found by several sources rise
+ */
+
+import { getErrorMessage } from '../../../utils/errors.js';
+import { BibliographyEntry, PaperSearchOptions } from '../types.js';
+import { ArxivApiClient } from './arxiv-api-client.js';
+import { CrossrefClient } from './crossref-client.js';
+import { PubMedClient } from './pubmed-client.js';
+import { SemanticScholarClient } from './semantic-scholar-client.js';
+
+export const METASEARCH_SOURCES = [
+  'arxiv',
+  'semantic_scholar',
+  'crossref',
+  'pubmed',
+] as const;
+
+export type MetasearchSource = (typeof METASEARCH_SOURCES)[number];
+
+export const SOURCE_BADGES: Record<MetasearchSource, string> = {
+  arxiv: 'arXiv',
+  semantic_scholar: 'S2',
+  crossref: 'Crossref',
+  pubmed: 'PubMed',
+};
+
+/** Rank constant of reciprocal rank fusion. */
+const RRF_K = 60;
+const TITLE_SIMILARITY_THRESHOLD = 0.9;
+const ARXIV_DOI_PREFIX = '10.48550/arxiv.';
+
+export type PaperSearcher = (
+  query: string,
+  options: PaperSearchOptions,
+) => Promise<Array<Partial<BibliographyEntry>>>;
+
+export interface MetasearchHit {
+  title: string;
+  authors: string[];
+  year?: number;
+  venue?: string;
+  doi?: string;
+  arxivId?: string;
+  url?: string;
+  abstract?: string;
+  citationCount?: number;
+  /** Sources that returned the paper, in the order they were merged. */
+  sources: MetasearchSource[];
+  score: number;
+}
+
+export interface MetasearchOptions {
+  sources?: MetasearchSource[];
+  /** Merged results to return. */
+  maxResults?: number;
+  /** Results requested from each source. */
+  perSourceResults?: number;
+  yearRange?: PaperSearchOptions['yearRange'];
+  /** Replaces the built-in clients, e.g. in tests. */
+  searchers?: Partial<Record<MetasearchSource, PaperSearcher>>;
+}
+
+export interface MetasearchResult {
+  query: string;
+  hits: MetasearchHit[];
+  sources: Partial<
+    Record<MetasearchSource, { count: number; error?: string }>
+  >;
+  /** Results folded into a paper another source also found. */
+  duplicatesMerged: number;
+}
+
+export function createDefaultSearchers(): Record<
+  MetasearchSource,
+  PaperSearcher
+> {
+  const arxiv = new ArxivApiClient();
+  const semanticScholar = new SemanticScholarClient();
+  const crossref = new CrossrefClient();
+  const pubmed = new PubMedClient();
+  return {
+    arxiv: (query, options) => arxiv.searchPapers(query, options),
+    semantic_scholar: (query, options) =>
+      semanticScholar.searchPapers(query, options),
+    crossref: (query, options) => crossref.searchPapers(query, options),
+    pubmed: (query, options) => pubmed.searchPapers(query, options),
+  };
+}
+
+export function normalizeDoi(doi: string): string {
+  return doi
+    .trim()
+    .toLowerCase()
+    .replace(/^(https?:\/\/(dx\.)?doi\.org\/|doi:)/, '');
+}
+
+function titleWords(title: string): string[] {
+  return title
+    .normalize('NFKD')
+    .replace(/[\u0300-\u036f]/g, '')
+    .toLowerCase()
+    .replace(/[^a-z0-9]+/g, ' ')
+    .trim()
+    .split(' ')
+    .filter(Boolean);
+}
+
+/**
+ * The word bigrams titleSimilarity compares; the single word of a one-word
+ * title.
+ */
+export function titleBigrams(title: string): string[] {
+  const words = titleWords(title);
+  if (words.length < 2) {
+    return words;
+  }
+  return words.slice(1).map((word, i) => `${words[i]} ${word}`);
+}
+
+/**
+ * Dice similarity of the titles' word bigrams, from 0 to 1. Case,
+ * accents and punctuation are ignored.
+ */
+export function titleSimilarity(a: string, b: string): number {
+  const left = titleBigrams(a);
+  const right = titleBigrams(b);
+  if (left.length === 0 || right.length === 0) {
+    return 0;
+  }
+  const remaining = [...right];
+  let shared = 0;
+  for (const bigram of left) {
+    const index = remaining.indexOf(bigram);
+    if (index !== -1) {
+      remaining.splice(index, 1);
+      shared++;
+    }
+  }
+  return (2 * shared) / (left.length + right.length);
+}
+
+function isSamePaper(
+  hit: MetasearchHit,
+  entry: Partial<BibliographyEntry>,
+): boolean {
+  if (hit.doi && entry.doi) {
+    const left = normalizeDoi(hit.doi);
+    const right = normalizeDoi(entry.doi);
+    if (left === right) {
+      return true;
+    }
+    // Two journal DOIs are two papers, however similar the titles; an
+    // arXiv DOI can still belong to the published version
+    if (
+      !left.startsWith(ARXIV_DOI_PREFIX) &&
+      !right.startsWith(ARXIV_DOI_PREFIX)
+    ) {
+      return false;
+    }
+  }
+  if (hit.arxivId && entry.arxivId) {
+    const unversioned = (id: string) => id.replace(/v\d+$/, '');
+    if (unversioned(hit.arxivId) === unversioned(entry.arxivId)) {
+      return true;
+    }
+  }
+  // A preprint and its published version share the title but can be a
+  // year apart
+  if (hit.year && entry.year && Math.abs(hit.year - entry.year) > 1) {
+    return false;
+  }
+  return (
+    titleSimilarity(hit.title, entry.title ?? '') >= TITLE_SIMILARITY_THRESHOLD
+  );
+}
+
+/**
+ * Merges ranked result lists into one list of distinct papers, best first.
+ */
+export function mergeSearchResults(
+  lists: Array<{
+    source: MetasearchSource;
+    entries: Array<Partial<BibliographyEntry>>;
+  }>,
+): { hits: MetasearchHit[]; duplicatesMerged: number } {
+  const hits: MetasearchHit[] = [];
+  let duplicatesMerged = 0;
+
+  for (const { source, entries } of lists) {
+    entries.forEach((entry, rank) => {
+      if (!entry.title) {
+        return;
+      }
+      const score = 1 / (RRF_K + rank + 1);
+      const existing = hits.find((hit) => isSamePaper(hit, entry));
+      if (!existing) {
+        hits.push({
+          title: entry.title,
+          authors: entry.authors ?? [],
+          year: entry.year,
+          venue: entry.journal ?? entry.conference,
+          doi: entry.doi,
+          arxivId: entry.arxivId,
+          url: entry.url,
+          abstract: entry.abstract,
+          citationCount: entry.citationCount,
+          sources: [source],
+          score,
+        });
+        return;
+      }
+      duplicatesMerged++;
+      if (!existing.sources.includes(source)) {
+        existing.sources.push(source);
+        existing.score += score;
+      }
+      existing.doi ??= entry.doi;
+      existing.arxivId ??= entry.arxivId;
+      existing.abstract ??= entry.abstract;
+      existing.venue ??= entry.journal ?? entry.conference;
+      existing.year ??= entry.year;
+      if (existing.authors.length === 0) {
+        existing.authors = entry.authors ?? [];
+      }
+      if (entry.citationCount !== undefined) {
+        existing.citationCount = Math.max(
+          existing.citationCount ?? 0,
+          entry.citationCount,
+        );
+      }
+    });
+  }
+
+  hits.sort(
+    (a, b) =>
+      b.score - a.score || (b.citationCount ?? 0) - (a.citationCount ?? 0),
+  );
+  return { hits, duplicatesMerged };
+}
+
+/**
+ * Sends the query to every selected source in parallel and merges the
+ * results. A failing source is reported and does not fail the search.
+ */
+export async function metasearch(
+  query: string,
+  options: MetasearchOptions = {},
+): Promise<MetasearchResult> {
+  const sources = options.sources?.length
+    ? options.sources
+    : [...METASEARCH_SOURCES];
+  const searchers = { ...createDefaultSearchers(), ...options.searchers };
+  const searchOptions: PaperSearchOptions = {
+    maxResults: options.perSourceResults ?? 20,
+    yearRange: options.yearRange,
+  };
+
+  const status: MetasearchResult['sources'] = {};
+  const lists = await Promise.all(
+    sources.map(async (source) => {
+      try {
+        const entries = await searchers[source](query, searchOptions);
+        status[source] = { count: entries.length };
+        return { source, entries };
+      } catch (error) {
+        status[source] = { count: 0, error: getErrorMessage(error) };
+        return { source, entries: [] };
+      }
+    }),
+  );
+
+  const { hits, duplicatesMerged } = mergeSearchResults(lists);
+  return {
+    query,
+    hits: hits.slice(0, options.maxResults ?? 20),
+    sources: status,
+    duplicatesMerged,
+  };
+}
+
+/**
+ * Renders merged results as a numbered Markdown list with source badges.
+ */
+export function formatMetasearchResults(result: MetasearchResult): string {
+  const lines: string[] = [];
+  result.hits.forEach((hit, i) => {
+    const authors =
+      hit.authors.length > 3
+        ? `${hit.authors.slice(0, 3).join(', ')} et al.`
+        : hit.authors.join(', ');
+    const head = [authors, hit.venue].filter(Boolean).join(' · ');
+    const badges = hit.sources
+      .map((source) => `[${SOURCE_BADGES[source]}]`)
+      .join(' ');
+    const details = [
+      badges,
+      hit.citationCount !== undefined
+        ? `${hit.citationCount} citations`
+        : undefined,
+      hit.doi ? `https://doi.org/${hit.doi}` : hit.url,
+    ].filter(Boolean);
+    lines.push(
+      `${i + 1}. **${hit.title}**${hit.year ? ` (${hit.year})` : ''}`,
+      ...(head ? [`   ${head}`] : []),
+      `   ${details.join(' · ')}`,
+    );
+  });
+
+  const summary = Object.entries(result.sources)
+    .map(([source, status]) => {
+      const badge = SOURCE_BADGES[source as MetasearchSource];
+      return status!.error
+        ? `${badge}: failed (${status!.error})`
+        : `${badge}: ${status!.count}`;
+    })
+    .join(', ');
+  lines.push(
+    '',
+    `${result.hits.length} papers for "${result.query}" ` +
+      `(${summary}; ${result.duplicatesMerged} duplicates merged)`,
+  );
+  return lines.join('\n');
+}
@@ -0,0 +1,143 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import {
+  PaperComparisonRow,
+  comparePapers,
+  findPaperPdf,
+  formatComparisonLatex,
+  formatComparisonMarkdown,
+} from './paper-comparison.js';
+import { BibtexEntry } from './bibtex.js';
+
+const entry = (key: string, fields: Record<string, string>): BibtexEntry => ({
+  type: 'article',
+  key,
+  fields: Object.fromEntries(
+    Object.entries(fields).map(([name, value]) => [name, `{${value}}`]),
+  ),
+});
+
+const ROW: PaperComparisonRow = {
+  key: 'vaswani2017',
+  title: 'Attention Is All You Need',
+  method: 'Transformer | self-attention',
+  dataset: 'WMT 2014 En-De',
+  metrics: 'BLEU 28.4',
+  limitations: 'Quadratic cost in sequence length & memory',
+};
+
+describe('comparePapers', () => {
+  it('should keep the requested order and fill in missing aspects', async () => {
+    const generateJson = vi.fn().mockResolvedValue({
+      papers: [
+        { key: 'b', method: 'CNN', dataset: '', metrics: 'top-1 76%' },
+        {
+          key: 'a',
+          method: 'RNN',
+          dataset: 'PTB',
+          metrics: 'ppl',
+          limitations: 'slow',
+        },
+      ],
+    });
+
+    const rows = await comparePapers(
+      { generateJson },
+      [
+        {
+          entry: entry('a', { title: 'Paper A' }),
+          text: 'We propose an RNN.',
+        },
+        { entry: entry('b', { title: 'Paper B', abstract: 'A CNN.' }) },
+      ],
+      new AbortController().signal,
+    );
+
+    expect(rows).toEqual([
+      {
+        key: 'a',
+        title: 'Paper A',
+        method: 'RNN',
+        dataset: 'PTB',
+        metrics: 'ppl',
+        limitations: 'slow',
+      },
+      {
+        key: 'b',
+        title: 'Paper B',
+        method: 'CNN',
+        dataset: 'not reported',
+        metrics: 'top-1 76%',
+        limitations: 'not reported',
+      },
+    ]);
+    const prompt = generateJson.mock.calls[0][0][0].parts[0].text;
+    expect(prompt).toContain('[a] Paper A');
+    expect(prompt).toContain('full text: We propose an RNN.');
+    expect(prompt).toContain('abstract: A CNN.');
+  });
+});
+
+describe('formatting', () => {
+  it('should escape table separators in Markdown', () => {
+    expect(formatComparisonMarkdown([ROW]).split('\n')).toEqual([
+      '| Paper | Method | Dataset | Metrics | Limitations |',
+      '| --- | --- | --- | --- | --- |',
+      '| Attention Is All You Need [vaswani2017] | Transformer \\| self-attention | WMT 2014 En-De | BLEU 28.4 | Quadratic cost in sequence length & memory |',
+    ]);
+  });
+
+  it('should cite papers and escape special characters in LaTeX', () => {
+    const latex = formatComparisonLatex([ROW]);
+    expect(latex).toContain('\\toprule');
+    expect(latex).toContain(
+      '\\cite{vaswani2017} & Transformer | self-attention & WMT 2014 En-De & BLEU 28.4 & Quadratic cost in sequence length \\& memory \\\\',
+    );
+  });
+
+  it('should escape backslashes and braces only once', () => {
+    const latex = formatComparisonLatex([
+      { ...ROW, method: 'Uses \\alpha_{t} and {x}' },
+    ]);
+    expect(latex).toContain(
+      '& Uses \\textbackslash{}alpha\\_\\{t\\} and \\{x\\} &',
+    );
+  });
+});
+
+describe('findPaperPdf', () => {
+  let tempDir: string;
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'paper-pdf-'));
+    fs.mkdirSync(path.join(tempDir, 'papers'));
+  });
+
+  afterEach(() => {
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should use the file field, then <key>.pdf', () => {
+    fs.writeFileSync(path.join(tempDir, 'papers', 'linked.pdf'), '');
+    fs.writeFileSync(path.join(tempDir, 'papers', 'smith2020.pdf'), '');
+
+    expect(
+      findPaperPdf(
+        entry('jones2021', { file: ':papers/linked.pdf:PDF' }),
+        tempDir,
+      ),
+    ).toBe(path.join(tempDir, 'papers', 'linked.pdf'));
+    expect(findPaperPdf(entry('smith2020', {}), tempDir)).toBe(
+      path.join(tempDir, 'papers', 'smith2020.pdf'),
+    );
+    expect(findPaperPdf(entry('missing', {}), tempDir)).toBeUndefined();
+  });
+});
@@ -0,0 +1,278 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+/**
+ * Paper Comparison - Builds a method/dataset/metrics/limitations matrix
+ * for bibliography entries from their metadata, abstracts and PDF text
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import { SchemaUnion, Type } from '@google/genai';
+import type { ResearchClient } from '../../../core/client.js';
+import { extractPdfText } from '../../../utils/pdfFigures.js';
+import {
+  BibtexEntry,
+  getBibtexField,
+  isBibtexEntry,
+  parseBibtex,
+} from './bibtex.js';
+
+// Enough for the abstract, introduction and experiments of most papers.
+const MAX_PAPER_TEXT_CHARS = 12000;
+const PDF_DIRS = ['.', 'papers', 'pdfs', 'pdf'];
+
+export const COMPARISON_ASPECTS = [
+  'method',
+  'dataset',
+  'metrics',
+  'limitations',
+] as const;
+
+export type ComparisonAspect = (typeof COMPARISON_ASPECTS)[number];
+
+export type PaperComparisonRow = {
+  key: string;
+  title: string;
+} & Record<ComparisonAspect, string>;
+
+export interface PaperSource {
+  entry: BibtexEntry;
+  /** Full text of the paper, if its PDF was found. */
+  text?: string;
+}
+
+export type ComparisonFormat = 'markdown' | 'latex';
+
+const NOT_REPORTED = 'not reported';
+
+const COMPARISON_SCHEMA: SchemaUnion = {
+  type: Type.OBJECT,
+  properties: {
+    papers: {
+      type: Type.ARRAY,
+      items: {
+        type: Type.OBJECT,
+        properties: {
+          key: { type: Type.STRING, description: 'The citation key.' },
+          method: {
+            type: Type.STRING,
+            description: 'The approach or model the paper proposes.',
+          },
+          dataset: {
+            type: Type.STRING,
+            description: 'Datasets or benchmarks used for evaluation.',
+          },
+          metrics: {
+            type: Type.STRING,
+            description:
+              'Evaluation metrics with the headline results, if stated.',
+          },
+          limitations: {
+            type: Type.STRING,
+            description: 'Limitations stated by the authors or evident.',
+          },
+        },
+        required: ['key', ...COMPARISON_ASPECTS],
+      },
+    },
+  },
+  required: ['papers'],
+};
+
+/**
+ * Finds the PDF of an entry: the path in its `file` field (JabRef and
+ * Zotero formats), or `<key>.pdf` in the project or a papers/ directory.
+ */
+export function findPaperPdf(
+  entry: BibtexEntry,
+  baseDir: string,
+): string | undefined {
+  const candidates: string[] = [];
+  const file = getBibtexField(entry, 'file');
+  if (file) {
+    // JabRef writes "description:path:type", Zotero often just the path.
+    for (const link of file.split(';')) {
+      const match = link.match(/(?:^|:)([^:]+\.pdf)(?::|$)/i);
+      if (match) {
+        candidates.push(path.resolve(baseDir, match[1].trim()));
+      }
+    }
+  }
+  for (const dir of PDF_DIRS) {
+    candidates.push(path.join(baseDir, dir, `${entry.key}.pdf`));
+  }
+  return candidates.find((candidate) => fs.existsSync(candidate));
+}
+
+/**
+ * Reads the entries for `keys` from a .bib file, with the text of each
+ * paper whose PDF can be found. Throws if a key is not in the file.
+ */
+export async function loadPaperSources(
+  bibFile: string,
+  keys: string[],
+): Promise<PaperSource[]> {
+  const entries = parseBibtex(
+    await fs.promises.readFile(bibFile, 'utf8'),
+  ).filter(isBibtexEntry);
+  const missing = keys.filter((key) => !entries.some((e) => e.key === key));
+  if (missing.length > 0) {
+    throw new Error(`Not in ${path.basename(bibFile)}: ${missing.join(', ')}`);
+  }
+  const sources: PaperSource[] = [];
+  for (const key of keys) {
+    const entry = entries.find((e) => e.key === key)!;
+    const pdf = findPaperPdf(entry, path.dirname(bibFile));
+    let text: string | undefined;
+    if (pdf) {
+      try {
+        text = await extractPdfText(pdf);
+      } catch {
+        // Fall back to the abstract when pdftotext is unavailable
+      }
+    }
+    sources.push({ entry, text });
+  }
+  return sources;
+}
+
+/** The metadata and text the model sees for one paper. */
+export function describePaper(source: PaperSource): string {
+  const { entry } = source;
+  const lines = [`[${entry.key}] ${getBibtexField(entry, 'title') ?? ''}`];
+  for (const field of ['author', 'year', 'journal', 'booktitle', 'abstract']) {
+    const value = getBibtexField(entry, field);
+    if (value) {
+      lines.push(`${field}: ${value}`);
+    }
+  }
+  if (source.text) {
+    const text = source.text.replace(/\s+/g, ' ').trim();
+    lines.push(
+      `full text${text.length > MAX_PAPER_TEXT_CHARS ? ' (truncated)' : ''}: ${text.slice(0, MAX_PAPER_TEXT_CHARS)}`,
+    );
+  }
+  return lines.join('\n');
+}
+
+/**
+ * Asks the model to fill the comparison matrix. Rows follow the order of
+ * `sources`; aspects the model leaves empty read "not reported".
+ */
+export async function comparePapers(
+  client: Pick<ResearchClient, 'generateJson'>,
+  sources: PaperSource[],
+  abortSignal: AbortSignal,
+): Promise<PaperComparisonRow[]> {
+  const prompt = [
+    'Compare the following papers. For each one, summarise in one or two short phrases its method, the datasets it uses, the evaluation metrics with headline results, and its limitations.',
+    `Use only the information given below; write "${NOT_REPORTED}" when a paper does not state something. Answer for every citation key.`,
+    '',
+    ...sources.map((source) => `${describePaper(source)}\n`),
+  ].join('\n');
+
+  const response = await client.generateJson(
+    [{ role: 'user', parts: [{ text: prompt }] }],
+    COMPARISON_SCHEMA,
+    abortSignal,
+  );
+  const answers = Array.isArray(response['papers'])
+    ? (response['papers'] as Array<Record<string, unknown>>)
+    : [];
+
+  return sources.map(({ entry }) => {
+    const answer = answers.find((a) => a['key'] === entry.key) ?? {};
+    const row: PaperComparisonRow = {
+      key: entry.key,
+      title: getBibtexField(entry, 'title') ?? entry.key,
+      method: NOT_REPORTED,
+      dataset: NOT_REPORTED,
+      metrics: NOT_REPORTED,
+      limitations: NOT_REPORTED,
+    };
+    for (const aspect of COMPARISON_ASPECTS) {
+      const value = answer[aspect];
+      if (typeof value === 'string' && value.trim()) {
+        row[aspect] = value.trim();
+      }
+    }
+    return row;
+  });
+}
+
+function escapeMarkdownCell(value: string): string {
+  return value.replace(/\|/g, '\\|').replace(/\s*\n\s*/g, ' ');
+}
+
+const LATEX_ESCAPES: Record<string, string> = {
+  '\\': '\\textbackslash{}',
+  '~': '\\textasciitilde{}',
+  '^': '\\textasciicircum{}',
+};
+
+/**
+ * Escapes the characters LaTeX treats specially. The backslash is escaped
+ * in the same pass as the rest, so the braces of `\textbackslash{}` are
+ * not escaped again.
+ */
+function escapeLatex(value: string): string {
+  return value
+    .replace(/[\\~^&%$#_{}]/g, (c) => LATEX_ESCAPES[c] ?? `\\${c}`)
+    .replace(/\s*\n\s*/g, ' ');
+}
+
+const HEADERS = ['Paper', 'Method', 'Dataset', 'Metrics', 'Limitations'];
+
+/** A Markdown table with one row per paper. */
+export function formatComparisonMarkdown(rows: PaperComparisonRow[]): string {
+  return [
+    `| ${HEADERS.join(' | ')} |`,
+    `| ${HEADERS.map(() => '---').join(' | ')} |`,
+    ...rows.map(
+      (row) =>
+        `| ${[
+          `${row.title} [${row.key}]`,
+          ...COMPARISON_ASPECTS.map((aspect) => row[aspect]),
+        ]
+          .map(escapeMarkdownCell)
+          .join(' | ')} |`,
+    ),
+  ].join('\n');
+}
+
+/**
+ * A booktabs table that cites each paper, ready to \input into a paper.
+ */
+export function formatComparisonLatex(rows: PaperComparisonRow[]): string {
+  return [
+    '\\begin{table}[t]',
+    '  \\centering',
+    '  \\small',
+    '  \\begin{tabular}{p{0.16\\linewidth}p{0.2\\linewidth}p{0.18\\linewidth}p{0.2\\linewidth}p{0.2\\linewidth}}',
+    '    \\toprule',
+    `    ${HEADERS.map((header) => `\\textbf{${header}}`).join(' & ')} \\\\`,
+    '    \\midrule',
+    ...rows.map(
+      (row) =>
+        `    \\cite{${row.key}} & ${COMPARISON_ASPECTS.map((aspect) => escapeLatex(row[aspect])).join(' & ')} \\\\`,
+    ),
+    '    \\bottomrule',
+    '  \\end{tabular}',
+    '  \\caption{Comparison of related work.}',
+    '  \\label{tab:paper-comparison}',
+    '\\end{table}',
+  ].join('\n');
+}
+
+export function formatComparison(
+  rows: PaperComparisonRow[],
+  format: ComparisonFormat,
+): string {
+  return format === 'latex'
+    ? formatComparisonLatex(rows)
+    : formatComparisonMarkdown(rows);
+}
@@ -0,0 +1,55 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, afterEach, vi } from 'vitest';
+import { PreprintClient } from './preprint-client.js';
+import { Database } from '../types.js';
+
+describe('PreprintClient', () => {
+  afterEach(() => {
+    vi.unstubAllGlobals();
+  });
+
+  it('should search the Europe PMC preprint index for one server', async () => {
+    const fetchMock = vi.fn(
+      async (_url: string) =>
+        new Response(
+          JSON.stringify({
+            resultList: {
+              result: [
+                {
+                  id: 'PPR1',
+                  doi: '10.1101/2024.01.01.000001',
+                  title: 'Spatial <i>omics</i> at scale.',
+                  authorString: 'Doe J, Roe R.',
+                  pubYear: '2024',
+                  abstractText: 'We map tissues.',
+                },
+              ],
+            },
+          }),
+        ),
+    );
+    vi.stubGlobal('fetch', fetchMock);
+
+    const [entry] = await new PreprintClient().searchPapers(
+      'spatial omics',
+      Database.MEDRXIV,
+    );
+
+    expect(new URL(fetchMock.mock.calls[0][0]).searchParams.get('query')).toBe(
+      '(spatial omics) AND SRC:PPR AND PUBLISHER:"medRxiv"',
+    );
+    expect(entry).toMatchObject({
+      id: 'medrxiv_10.1101/2024.01.01.000001',
+      title: 'Spatial omics at scale',
+      authors: ['Doe J', 'Roe R'],
+      year: 2024,
+      journal: 'medRxiv',
+      url: 'https://doi.org/10.1101/2024.01.01.000001',
+    });
+  });
+});
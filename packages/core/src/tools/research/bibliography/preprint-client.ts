@@ -0,0 +1,103 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+/**
+ * Preprint Client - bioRxiv and medRxiv search
+ * The bioRxiv API has no keyword search, so queries go to the Europe PMC
+ * preprint index, which holds both servers' preprints with abstracts
+ */
+
+import { fetchWithTimeout } from '../../../utils/fetch.js';
+import { BibliographyEntry, Database, PaperSearchOptions } from '../types.js';
+import { cleanXmlText } from './pubmed-client.js';
+
+const EUROPE_PMC_SEARCH_URL =
+  'https://www.ebi.ac.uk/europepmc/webservices/rest/search';
+const DEFAULT_TIMEOUT_MS = 30000;
+const MAX_PAGE_SIZE = 100;
+
+export type PreprintServer = Database.BIORXIV | Database.MEDRXIV;
+
+const SERVER_NAMES: Record<PreprintServer, string> = {
+  [Database.BIORXIV]: 'bioRxiv',
+  [Database.MEDRXIV]: 'medRxiv',
+};
+
+interface EuropePmcResult {
+  id: string;
+  doi?: string;
+  title?: string;
+  authorString?: string;
+  pubYear?: string;
+  abstractText?: string;
+  keywordList?: { keyword?: string[] };
+}
+
+/**
+ * Preprint Client for life-science preprint servers
+ */
+export class PreprintClient {
+  constructor(
+    private readonly baseUrl: string = EUROPE_PMC_SEARCH_URL,
+    private readonly timeout: number = DEFAULT_TIMEOUT_MS,
+  ) {}
+
+  /**
+   * Search one preprint server and return its matching preprints
+   */
+  async searchPapers(
+    query: string,
+    server: PreprintServer,
+    options: PaperSearchOptions = {},
+  ): Promise<Array<Partial<BibliographyEntry>>> {
+    const name = SERVER_NAMES[server];
+    const filters = ['SRC:PPR', `PUBLISHER:"${name}"`];
+    if (options.yearRange) {
+      filters.push(
+        `PUB_YEAR:[${options.yearRange.start} TO ${options.yearRange.end}]`,
+      );
+    }
+    const params = new URLSearchParams({
+      query: `(${query}) AND ${filters.join(' AND ')}`,
+      format: 'json',
+      resultType: 'core',
+      pageSize: String(Math.min(options.maxResults || 20, MAX_PAGE_SIZE)),
+      ...(options.sortBy === 'date' ? { sort: 'P_PDATE_D desc' } : {}),
+    });
+
+    const response = await fetchWithTimeout(
+      `${this.baseUrl}?${params}`,
+      this.timeout,
+    );
+    if (!response.ok) {
+      throw new Error(`Europe PMC API error: ${response.status}`);
+    }
+    const data = (await response.json()) as {
+      resultList?: { result?: EuropePmcResult[] };
+    };
+
+    return (data.resultList?.result ?? [])
+      .filter((result) => result.title)
+      .map((result) => ({
+        id: `${server}_${result.doi ?? result.id}`,
+        title: cleanXmlText(result.title!).replace(/\.$/, ''),
+        authors: (result.authorString ?? '')
+          .replace(/\.$/, '')
+          .split(', ')
+          .filter(Boolean),
+        year: result.pubYear ? Number(result.pubYear) : undefined,
+        abstract: result.abstractText
+          ? cleanXmlText(result.abstractText)
+          : undefined,
+        journal: name,
+        doi: result.doi,
+        url: result.doi
+          ? `https://doi.org/${result.doi}`
+          : `https://europepmc.org/article/PPR/${result.id}`,
+        keywords: result.keywordList?.keyword ?? [],
+      }));
+  }
+}
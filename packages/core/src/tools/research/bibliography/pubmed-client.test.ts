@@ -0,0 +1,86 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, afterEach, vi } from 'vitest';
+import { PubMedClient, parsePubMedXml } from './pubmed-client.js';
+
+const article = (pmid: string, title: string) => `
+<PubmedArticle>
+  <MedlineCitation Status="MEDLINE" Owner="NLM">
+    <PMID Version="1">${pmid}</PMID>
+    <Article PubModel="Print">
+      <Journal>
+        <JournalIssue><PubDate><Year>2021</Year><Month>Mar</Month></PubDate></JournalIssue>
+        <Title>Nature methods</Title>
+      </Journal>
+      <ArticleTitle>${title}</ArticleTitle>
+      <Abstract>
+        <AbstractText Label="BACKGROUND">Cells &amp; <i>tissues</i>.</AbstractText>
+        <AbstractText Label="RESULTS">It works.</AbstractText>
+      </Abstract>
+      <AuthorList CompleteYN="Y">
+        <Author ValidYN="Y"><LastName>Doe</LastName><ForeName>Jane</ForeName></Author>
+        <Author ValidYN="Y"><CollectiveName>Atlas Consortium</CollectiveName></Author>
+      </AuthorList>
+    </Article>
+    <KeywordList Owner="NOTNLM"><Keyword MajorTopicYN="N">scRNA-seq</Keyword></KeywordList>
+  </MedlineCitation>
+  <PubmedData>
+    <ArticleIdList><ArticleId IdType="doi">10.1038/s41592-021-0001</ArticleId></ArticleIdList>
+  </PubmedData>
+</PubmedArticle>`;
+
+describe('parsePubMedXml', () => {
+  it('should map records to bibliography entries', () => {
+    const [entry] = parsePubMedXml(
+      `<PubmedArticleSet>${article('33000001', 'Atlas of cells.')}</PubmedArticleSet>`,
+    );
+    expect(entry).toEqual({
+      id: 'pubmed_33000001',
+      title: 'Atlas of cells',
+      authors: ['Jane Doe', 'Atlas Consortium'],
+      year: 2021,
+      abstract: 'BACKGROUND: Cells & tissues.\nRESULTS: It works.',
+      journal: 'Nature methods',
+      doi: '10.1038/s41592-021-0001',
+      url: 'https://pubmed.ncbi.nlm.nih.gov/33000001/',
+      keywords: ['scRNA-seq'],
+    });
+  });
+});
+
+describe('PubMedClient', () => {
+  afterEach(() => {
+    vi.unstubAllGlobals();
+  });
+
+  it('should search with esearch and keep its ranking', async () => {
+    const urls: string[] = [];
+    vi.stubGlobal(
+      'fetch',
+      vi.fn(async (url: string) => {
+        urls.push(url);
+        return url.includes('esearch')
+          ? new Response(
+              JSON.stringify({ esearchresult: { idlist: ['2', '1'] } }),
+            )
+          : new Response(`${article('1', 'First')}${article('2', 'Second')}`);
+      }),
+    );
+
+    const entries = await new PubMedClient({ apiKey: 'k' }).searchPapers(
+      'single cell',
+      { maxResults: 2, yearRange: { start: 2020, end: 2022 } },
+    );
+
+    expect(entries.map((entry) => entry.title)).toEqual(['Second', 'First']);
+    const search = new URL(urls[0]).searchParams;
+    expect(search.get('term')).toBe('single cell');
+    expect(search.get('mindate')).toBe('2020');
+    expect(search.get('api_key')).toBe('k');
+    expect(new URL(urls[1]).searchParams.get('id')).toBe('2,1');
+  });
+});
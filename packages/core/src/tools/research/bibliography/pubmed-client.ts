@@ -0,0 +1,214 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+/**
+ * PubMed Client - Search via the NCBI E-utilities
+ * Finds PMIDs with esearch and reads the records with efetch
+ */
+
+import type { PubMedAPIConfig } from '../../../config/research-config.js';
+import { fetchWithTimeout } from '../../../utils/fetch.js';
+import { BibliographyEntry, PaperSearchOptions } from '../types.js';
+
+const DEFAULT_BASE_URL = 'https://eutils.ncbi.nlm.nih.gov/entrez/eutils/';
+const DEFAULT_TIMEOUT_MS = 30000;
+const MAX_KEYWORDS = 10;
+
+/**
+ * Decodes the XML entities E-utilities uses and drops inline markup such
+ * as <i> and <sup> from titles and abstracts.
+ */
+export function cleanXmlText(text: string): string {
+  return text
+    .replace(/<[^>]+>/g, '')
+    .replace(/&#x([0-9a-f]+);/gi, (_, hex) =>
+      String.fromCodePoint(parseInt(hex, 16)),
+    )
+    .replace(/&#(\d+);/g, (_, dec) => String.fromCodePoint(Number(dec)))
+    .replace(/&lt;/g, '<')
+    .replace(/&gt;/g, '>')
+    .replace(/&quot;/g, '"')
+    .replace(/&apos;/g, "'")
+    .replace(/&amp;/g, '&')
+    .replace(/\s+/g, ' ')
+    .trim();
+}
+
+function firstMatch(xml: string, pattern: RegExp): string | undefined {
+  const match = xml.match(pattern);
+  return match ? cleanXmlText(match[1]) : undefined;
+}
+
+function allMatches(xml: string, pattern: RegExp): string[] {
+  return [...xml.matchAll(pattern)].map((match) => match[0]);
+}
+
+function parseAuthor(authorXml: string): string | undefined {
+  const collective = firstMatch(
+    authorXml,
+    /<CollectiveName>([\s\S]*?)<\/CollectiveName>/,
+  );
+  if (collective) {
+    return collective;
+  }
+  const lastName = firstMatch(authorXml, /<LastName>([\s\S]*?)<\/LastName>/);
+  const foreName =
+    firstMatch(authorXml, /<ForeName>([\s\S]*?)<\/ForeName>/) ??
+    firstMatch(authorXml, /<Initials>([\s\S]*?)<\/Initials>/);
+  if (!lastName) {
+    return undefined;
+  }
+  return foreName ? `${foreName} ${lastName}` : lastName;
+}
+
+function parseArticle(articleXml: string): Partial<BibliographyEntry> | null {
+  const pmid = firstMatch(articleXml, /<PMID[^>]*>(\d+)<\/PMID>/);
+  const title = firstMatch(
+    articleXml,
+    /<ArticleTitle[^>]*>([\s\S]*?)<\/ArticleTitle>/,
+  );
+  if (!pmid || !title) {
+    return null;
+  }
+
+  const abstract = allMatches(
+    articleXml,
+    /<AbstractText[^>]*>[\s\S]*?<\/AbstractText>/g,
+  )
+    .map((section) => {
+      const label = section.match(/Label="([^"]+)"/)?.[1];
+      const text = cleanXmlText(section);
+      return label ? `${label}: ${text}` : text;
+    })
+    .join('\n');
+
+  const pubDate =
+    articleXml.match(/<PubDate>([\s\S]*?)<\/PubDate>/)?.[1] ?? '';
+  const year =
+    pubDate.match(/<Year>(\d{4})<\/Year>/)?.[1] ??
+    pubDate.match(/<MedlineDate>(\d{4})/)?.[1];
+
+  const keywords = [
+    ...allMatches(articleXml, /<Keyword\b[^>]*>[\s\S]*?<\/Keyword>/g),
+    ...allMatches(
+      articleXml,
+      /<DescriptorName\b[^>]*>[\s\S]*?<\/DescriptorName>/g,
+    ),
+  ].map(cleanXmlText);
+
+  return {
+    id: `pubmed_${pmid}`,
+    title: title.replace(/\.$/, ''),
+    authors: allMatches(articleXml, /<Author\b[^>]*>[\s\S]*?<\/Author>/g)
+      .map(parseAuthor)
+      .filter((author): author is string => !!author),
+    year: year ? Number(year) : undefined,
+    abstract: abstract || undefined,
+    journal: firstMatch(
+      articleXml,
+      /<Journal>[\s\S]*?<Title>([\s\S]*?)<\/Title>/,
+    ),
+    doi:
+      firstMatch(
+        articleXml,
+        /<ArticleId IdType="doi">([^<]+)<\/ArticleId>/,
+      ) ??
+      firstMatch(articleXml, /<ELocationID EIdType="doi"[^>]*>([^<]+)</),
+    url: `https://pubmed.ncbi.nlm.nih.gov/${pmid}/`,
+    keywords: [...new Set(keywords)].slice(0, MAX_KEYWORDS),
+  };
+}
+
+/**
+ * Parses the PubmedArticleSet XML returned by efetch
+ */
+export function parsePubMedXml(
+  xml: string,
+): Array<Partial<BibliographyEntry>> {
+  return allMatches(xml, /<PubmedArticle>[\s\S]*?<\/PubmedArticle>/g)
+    .map(parseArticle)
+    .filter((entry): entry is Partial<BibliographyEntry> => entry !== null);
+}
+
+/**
+ * PubMed Client for biomedical literature search
+ */
+export class PubMedClient {
+  private baseUrl: string;
+  private apiKey?: string;
+  private email?: string;
+  private tool: string;
+  private timeout: number;
+
+  constructor(config: Partial<PubMedAPIConfig> = {}) {
+    this.baseUrl = (config.baseUrl || DEFAULT_BASE_URL).replace(/\/?$/, '/');
+    this.apiKey = config.apiKey || process.env.NCBI_API_KEY;
+    this.email = config.email;
+    this.tool = config.tool || 'research-cli';
+    this.timeout = config.timeout || DEFAULT_TIMEOUT_MS;
+  }
+
+  /**
+   * Search PubMed and return the matching records, most relevant first
+   */
+  async searchPapers(
+    query: string,
+    options: PaperSearchOptions = {},
+  ): Promise<Array<Partial<BibliographyEntry>>> {
+    const search = JSON.parse(
+      await this.request('esearch.fcgi', {
+        db: 'pubmed',
+        term: query,
+        retmax: String(options.maxResults || 20),
+        retmode: 'json',
+        sort: options.sortBy === 'date' ? 'pub_date' : 'relevance',
+        ...(options.yearRange
+          ? {
+              datetype: 'pdat',
+              mindate: String(options.yearRange.start),
+              maxdate: String(options.yearRange.end),
+            }
+          : {}),
+      }),
+    ) as { esearchresult?: { idlist?: string[] } };
+    const ids = search.esearchresult?.idlist ?? [];
+    if (ids.length === 0) {
+      return [];
+    }
+
+    const entries = parsePubMedXml(
+      await this.request('efetch.fcgi', {
+        db: 'pubmed',
+        id: ids.join(','),
+        retmode: 'xml',
+      }),
+    );
+    // efetch does not keep the esearch ranking
+    const rank = (entry: Partial<BibliographyEntry>) =>
+      ids.indexOf(entry.id!.slice('pubmed_'.length));
+    return entries.sort((a, b) => rank(a) - rank(b));
+  }
+
+  private async request(
+    endpoint: string,
+    params: Record<string, string>,
+  ): Promise<string> {
+    const searchParams = new URLSearchParams({
+      ...params,
+      tool: this.tool,
+      ...(this.email ? { email: this.email } : {}),
+      ...(this.apiKey ? { api_key: this.apiKey } : {}),
+    });
+    const response = await fetchWithTimeout(
+      `${this.baseUrl}${endpoint}?${searchParams}`,
+      this.timeout,
+    );
+    if (!response.ok) {
+      throw new Error(`PubMed API error: ${response.status}`);
+    }
+    return response.text();
+  }
+}
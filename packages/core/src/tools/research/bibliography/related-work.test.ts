@@ -0,0 +1,86 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, vi } from 'vitest';
+import {
+  draftRelatedWork,
+  findUngroundedClaims,
+  restrictCitations,
+} from './related-work.js';
+import { BibtexEntry } from './bibtex.js';
+
+const entry = (key: string, title: string): BibtexEntry => ({
+  type: 'article',
+  key,
+  fields: { title: `{${title}}` },
+});
+
+describe('restrictCitations', () => {
+  it('should drop keys that are not in the bibliography', () => {
+    const { latex, unknownKeys, citedKeys } = restrictCitations(
+      'Attention \\citep[see][]{vaswani2017, made-up} and RNNs \\cite{fake2020}.',
+      ['vaswani2017', 'bahdanau2015'],
+    );
+
+    expect(latex).toBe(
+      'Attention \\citep[see][]{vaswani2017} and RNNs \\ungrounded{citation not in the bibliography}.',
+    );
+    expect(unknownKeys).toEqual(['made-up', 'fake2020']);
+    expect(citedKeys).toEqual(['vaswani2017']);
+  });
+});
+
+describe('findUngroundedClaims', () => {
+  it('should read markers with nested braces', () => {
+    expect(
+      findUngroundedClaims(
+        'A \\ungrounded{\\emph{most} systems use attention}. B \\ungrounded{x}.',
+      ),
+    ).toEqual(['\\emph{most} systems use attention', 'x']);
+  });
+});
+
+describe('draftRelatedWork', () => {
+  it('should enforce citations and report what needs checking', async () => {
+    const generateContent = vi.fn().mockResolvedValue({
+      candidates: [
+        {
+          content: {
+            parts: [
+              {
+                text: '```latex\n\\section{Related Work}\nTransformers \\cite{vaswani2017} replaced RNNs \\cite{hochreiter1997}. \\ungrounded{They dominate NLP}.\n```',
+              },
+            ],
+          },
+        },
+      ],
+    });
+
+    const draft = await draftRelatedWork(
+      { generateContent },
+      'efficient attention',
+      [
+        { entry: entry('vaswani2017', 'Attention Is All You Need') },
+        { entry: entry('bahdanau2015', 'Neural Machine Translation') },
+      ],
+      new AbortController().signal,
+    );
+
+    const prompt = generateContent.mock.calls[0][0][0].parts[0].text;
+    expect(prompt).toContain('efficient attention');
+    expect(prompt).toContain('[bahdanau2015] Neural Machine Translation');
+    expect(draft.latex).toMatch(/^\\providecommand\{\\ungrounded\}/);
+    expect(draft.latex).toContain(
+      '\\section{Related Work}\nTransformers \\cite{vaswani2017} replaced RNNs \\ungrounded{citation not in the bibliography}.',
+    );
+    expect(draft.unknownKeys).toEqual(['hochreiter1997']);
+    expect(draft.ungroundedClaims).toEqual([
+      'citation not in the bibliography',
+      'They dominate NLP',
+    ]);
+    expect(draft.uncitedKeys).toEqual(['bahdanau2015']);
+  });
+});
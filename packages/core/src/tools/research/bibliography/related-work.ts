@@ -0,0 +1,143 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+/**
+ * Related Work - Drafts a related-work section grounded in the .bib file
+ * Citations outside the given keys are removed and unsupported claims are
+ * marked with \ungrounded{...} so they are easy to find and check
+ */
+
+import type { ResearchClient } from '../../../core/client.js';
+import { getResponseText } from '../../../utils/generateContentResponseUtilities.js';
+import { PaperSource, describePaper } from './paper-comparison.js';
+
+export const UNGROUNDED_MACRO = '\\ungrounded';
+
+// Defined with \providecommand so a project can style it differently.
+const PREAMBLE = `\\providecommand{${UNGROUNDED_MACRO}}[1]{\\textbf{[unsupported: #1]}}`;
+
+const CITE_PATTERN = /\\(cite[a-zA-Z]*\*?)((?:\[[^\]]*\]){0,2})\{([^}]*)\}/g;
+
+export interface RelatedWorkDraft {
+  /** LaTeX source of the section. */
+  latex: string;
+  /** Keys the model cited that are not in the bibliography; removed. */
+  unknownKeys: string[];
+  /** Claims the model marked as not supported by the given papers. */
+  ungroundedClaims: string[];
+  /** Given keys the draft does not cite. */
+  uncitedKeys: string[];
+}
+
+/**
+ * Removes citation keys that are not in `allowedKeys`. A citation left
+ * without keys becomes an \ungrounded marker.
+ */
+export function restrictCitations(
+  latex: string,
+  allowedKeys: string[],
+): { latex: string; unknownKeys: string[]; citedKeys: string[] } {
+  const unknownKeys = new Set<string>();
+  const citedKeys = new Set<string>();
+  const result = latex.replace(
+    CITE_PATTERN,
+    (_match, command: string, options: string, keyList: string) => {
+      const keys = keyList
+        .split(',')
+        .map((key) => key.trim())
+        .filter(Boolean);
+      const kept = keys.filter((key) => allowedKeys.includes(key));
+      keys
+        .filter((key) => !kept.includes(key))
+        .forEach((key) => unknownKeys.add(key));
+      kept.forEach((key) => citedKeys.add(key));
+      return kept.length > 0
+        ? `\\${command}${options}{${kept.join(',')}}`
+        : `${UNGROUNDED_MACRO}{citation not in the bibliography}`;
+    },
+  );
+  return {
+    latex: result,
+    unknownKeys: [...unknownKeys],
+    citedKeys: [...citedKeys],
+  };
+}
+
+/** The text of every \ungrounded{...} marker in a draft. */
+export function findUngroundedClaims(latex: string): string[] {
+  const claims: string[] = [];
+  const marker = `${UNGROUNDED_MACRO}{`;
+  let start = latex.indexOf(marker);
+  while (start !== -1) {
+    // Markers may contain braces of their own, e.g. \emph{...}
+    let depth = 1;
+    let end = start + marker.length;
+    while (end < latex.length && depth > 0) {
+      if (latex[end] === '{') {
+        depth++;
+      } else if (latex[end] === '}') {
+        depth--;
+      }
+      end++;
+    }
+    claims.push(latex.slice(start + marker.length, end - 1).trim());
+    start = latex.indexOf(marker, end);
+  }
+  return claims;
+}
+
+function stripCodeFence(text: string): string {
+  const match = text.trim().match(/^```(?:latex|tex)?\n([\s\S]*?)\n```$/);
+  return match ? match[1] : text.trim();
+}
+
+/**
+ * Asks the model for a related-work section on `topic` that cites only
+ * the given papers, then enforces that and collects unsupported claims.
+ */
+export async function draftRelatedWork(
+  client: Pick<ResearchClient, 'generateContent'>,
+  topic: string,
+  sources: PaperSource[],
+  abortSignal: AbortSignal,
+): Promise<RelatedWorkDraft> {
+  const keys = sources.map(({ entry }) => entry.key);
+  const prompt = [
+    `Write the LaTeX body of a related-work section for a paper on: ${topic}`,
+    '',
+    'Rules:',
+    `- Cite only these keys, with \\cite{key}: ${keys.join(', ')}. Never invent other keys.`,
+    '- Every statement about prior work must be supported by the paper information below. Do not rely on outside knowledge of the papers.',
+    `- If a sentence needs support that the papers below do not give, keep it short and wrap the claim in ${UNGROUNDED_MACRO}{...}.`,
+    '- Group papers by theme into paragraphs (use \\paragraph{} headings when there are several themes), and relate them to the topic.',
+    '- Output only LaTeX, starting with \\section{Related Work}.',
+    '',
+    'Papers:',
+    '',
+    ...sources.map((source) => `${describePaper(source)}\n`),
+  ].join('\n');
+
+  const response = await client.generateContent(
+    [{ role: 'user', parts: [{ text: prompt }] }],
+    {},
+    abortSignal,
+  );
+  const text = getResponseText(response);
+  if (!text?.trim()) {
+    throw new Error('The model returned an empty draft.');
+  }
+
+  const { latex, unknownKeys, citedKeys } = restrictCitations(
+    stripCodeFence(text),
+    keys,
+  );
+  return {
+    latex: `${PREAMBLE}\n${latex}\n`,
+    unknownKeys,
+    ungroundedClaims: findUngroundedClaims(latex),
+    uncitedKeys: keys.filter((key) => !citedKeys.includes(key)),
+  };
+}
@@ -0,0 +1,89 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+/**
+ * Semantic Scholar Client - Search via the Academic Graph API
+ */
+
+import { fetchWithTimeout } from '../../../utils/fetch.js';
+import { BibliographyEntry, PaperSearchOptions } from '../types.js';
+
+const SEARCH_URL = 'https://api.semanticscholar.org/graph/v1/paper/search';
+const DEFAULT_TIMEOUT_MS = 30000;
+const MAX_LIMIT = 100;
+const FIELDS =
+  'title,authors,year,venue,externalIds,abstract,citationCount,url';
+
+interface SemanticScholarPaper {
+  paperId: string;
+  title?: string;
+  authors?: Array<{ name: string }>;
+  year?: number | null;
+  venue?: string;
+  externalIds?: { DOI?: string; ArXiv?: string; PubMed?: string };
+  abstract?: string | null;
+  citationCount?: number;
+  url?: string;
+}
+
+/**
+ * Semantic Scholar Client for cross-discipline paper search
+ */
+export class SemanticScholarClient {
+  private apiKey?: string;
+
+  constructor(
+    apiKey?: string,
+    private readonly timeout: number = DEFAULT_TIMEOUT_MS,
+  ) {
+    this.apiKey = apiKey || process.env.SEMANTIC_SCHOLAR_API_KEY;
+  }
+
+  /**
+   * Search papers by relevance; the API has no date ordering
+   */
+  async searchPapers(
+    query: string,
+    options: PaperSearchOptions = {},
+  ): Promise<Array<Partial<BibliographyEntry>>> {
+    const params = new URLSearchParams({
+      query,
+      limit: String(Math.min(options.maxResults || 20, MAX_LIMIT)),
+      fields: FIELDS,
+      ...(options.yearRange
+        ? { year: `${options.yearRange.start}-${options.yearRange.end}` }
+        : {}),
+    });
+    const response = await fetchWithTimeout(
+      `${SEARCH_URL}?${params}`,
+      this.timeout,
+      this.apiKey ? { 'x-api-key': this.apiKey } : undefined,
+    );
+    if (!response.ok) {
+      throw new Error(
+        response.status === 429
+          ? 'Semantic Scholar rate limit reached; set SEMANTIC_SCHOLAR_API_KEY or retry later'
+          : `Semantic Scholar API error: ${response.status}`,
+      );
+    }
+    const data = (await response.json()) as { data?: SemanticScholarPaper[] };
+
+    return (data.data ?? [])
+      .filter((paper) => paper.title)
+      .map((paper) => ({
+        id: `s2_${paper.paperId}`,
+        title: paper.title!,
+        authors: (paper.authors ?? []).map((author) => author.name),
+        year: paper.year ?? undefined,
+        abstract: paper.abstract ?? undefined,
+        journal: paper.venue || undefined,
+        doi: paper.externalIds?.DOI,
+        arxivId: paper.externalIds?.ArXiv,
+        url: paper.url,
+        citationCount: paper.citationCount,
+      }));
+  }
+}
@@ -29,6 +29,43 @@ export { BibliographyManager } from './analysis/bibliography-manager.js';
 export { ExperimentCodeGenerator } from './analysis/experiment-code-generator.js';
 export { LaTeXManager } from './submission/latex-manager.js';
 
+// 导出文献元搜索
+export * from './bibliography/metasearch.js';
+
+// 导出 BibTeX 解析与清理
+export * from './bibliography/bibtex.js';
+export * from './bibliography/bib-cleanup.js';
+
+// 导出论文对比矩阵
+export * from './bibliography/paper-comparison.js';
+
+// 导出相关工作草稿生成
+export * from './bibliography/related-work.js';
+
+// 导出审稿表单与审稿笔记
+export * from './analysis/paper-review.js';
+
+// 导出数据集与模型检索（HuggingFace）
+export * from './analysis/huggingface-client.js';
+
+// 导出代码差异审查（diff / patch 审查意见）
+export * from './analysis/code-review.js';
+
+// 导出审稿回复（rebuttal）管理
+export * from './submission/rebuttal.js';
+
+// 导出草稿重复度（自我抄袭）检查
+export * from './writing/overlap-checker.js';
+
+// 导出字数统计与可读性指标
+export * from './writing/text-metrics.js';
+
+// 导出符号表（记号说明）提取
+export * from './writing/notation-glossary.js';
+
+// 导出大纲编辑与分节起草
+export * from './writing/session-outline.js';
+
 // 导出集成功能
 export {
   ResearchToolAdapter,
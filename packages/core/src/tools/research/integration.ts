@@ -169,7 +169,16 @@ function generateParameterSchema(toolName: string): Record<string, unknown> {
             type: 'array',
             items: {
               type: 'string',
-              enum: ['arxiv', 'pubmed', 'ieee', 'acm', 'springer'],
+              enum: [
+                'arxiv',
+                'google_scholar',
+                'pubmed',
+                'biorxiv',
+                'medrxiv',
+                'ieee',
+                'acm',
+                'springer',
+              ],
             },
             description: 'Databases to search',
           },
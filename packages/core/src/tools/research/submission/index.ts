@@ -10,3 +10,4 @@
 
 export * from './latex-manager.js';
 export * from './journal-matcher.js';
+export * from './rebuttal.js';
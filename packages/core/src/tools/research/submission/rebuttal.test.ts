@@ -0,0 +1,122 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, vi } from 'vitest';
+import {
+  addRebuttalPoints,
+  assembleRebuttal,
+  createRebuttal,
+  draftRebuttalResponse,
+  formatCharacterCount,
+  parseReviewerComments,
+} from './rebuttal.js';
+
+const REVIEWS = `Reviewer 1
+
+Weaknesses:
+1. The ablation in Table 3 omits the
+   attention variant.
+2. Related work misses prior sparse methods.
+
+Questions:
+Q1: How does the method scale beyond 1B tokens?
+
+## Reviewer #2
+The paper is well written.
+
+The evaluation uses a single dataset, which limits the claims.
+`;
+
+describe('parseReviewerComments', () => {
+  it('should split reviews into numbered points per reviewer', () => {
+    expect(parseReviewerComments(REVIEWS)).toEqual([
+      {
+        reviewer: 'R1',
+        comment: 'The ablation in Table 3 omits the\n   attention variant.',
+      },
+      {
+        reviewer: 'R1',
+        comment: 'Related work misses prior sparse methods.',
+      },
+      {
+        reviewer: 'R1',
+        comment: 'How does the method scale beyond 1B tokens?',
+      },
+      { reviewer: 'R2', comment: 'The paper is well written.' },
+      {
+        reviewer: 'R2',
+        comment:
+          'The evaluation uses a single dataset, which limits the claims.',
+      },
+    ]);
+  });
+
+  it('should attribute text without a heading to the given reviewer', () => {
+    expect(parseReviewerComments('- Typo in Eq. 2', 'R3')).toEqual([
+      { reviewer: 'R3', comment: 'Typo in Eq. 2' },
+    ]);
+  });
+});
+
+describe('assembling a rebuttal', () => {
+  it('should number points, skip unanswered ones and count characters', () => {
+    const rebuttal = createRebuttal('NeurIPS 2025 #1234', 5000);
+    expect(rebuttal.id).toBe('neurips-2025-1234');
+    const points = addRebuttalPoints(rebuttal, parseReviewerComments(REVIEWS));
+    expect(points.map((p) => p.id)).toEqual([
+      'R1.1',
+      'R1.2',
+      'R1.3',
+      'R2.1',
+      'R2.2',
+    ]);
+    points[0].response = 'We added it to Table 3.';
+    points[4].response = 'We added two datasets.';
+
+    const text = assembleRebuttal(rebuttal);
+    expect(text).toBe(
+      [
+        '**Response to R1**',
+        '**R1.1** _The ablation in Table 3 omits the attention variant._\nWe added it to Table 3.',
+        '**Response to R2**',
+        '**R2.2** _The evaluation uses a single dataset, which limits the claims._\nWe added two datasets.',
+      ].join('\n\n'),
+    );
+    expect(assembleRebuttal(rebuttal, 'r2')).toBe(
+      '**R2.2** _The evaluation uses a single dataset, which limits the claims._\nWe added two datasets.',
+    );
+    expect(formatCharacterCount('x'.repeat(5200), 5000)).toBe(
+      '5,200 / 5,000 characters (200 over)',
+    );
+  });
+});
+
+describe('draftRebuttalResponse', () => {
+  it('should give the model the point, notes and the other points', async () => {
+    const generateContent = vi.fn().mockResolvedValue({
+      candidates: [{ content: { parts: [{ text: 'We will add it.' }] } }],
+    });
+    const rebuttal = createRebuttal('ICML');
+    const [first] = addRebuttalPoints(
+      rebuttal,
+      parseReviewerComments('1. Missing ablation.\n2. Unclear notation.'),
+    );
+
+    const draft = await draftRebuttalResponse(
+      { generateContent },
+      rebuttal,
+      first,
+      new AbortController().signal,
+      'ablation is in the appendix',
+    );
+
+    expect(draft).toBe('We will add it.');
+    const prompt = generateContent.mock.calls[0][0][0].parts[0].text;
+    expect(prompt).toContain('Point R1.1:\nMissing ablation.');
+    expect(prompt).toContain('ablation is in the appendix');
+    expect(prompt).toContain('- R1.2: Unclear notation.');
+  });
+});
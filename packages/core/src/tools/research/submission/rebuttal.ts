@@ -0,0 +1,284 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+/**
+ * Rebuttal - Splits reviewer comments into points, tracks a response per
+ * point and assembles the rebuttal within the venue's character limit
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import type { ResearchClient } from '../../../core/client.js';
+import { getUserResearchDir } from '../../../utils/paths.js';
+import { isNodeError } from '../../../utils/errors.js';
+import { getResponseText } from '../../../utils/generateContentResponseUtilities.js';
+
+export type RebuttalPointStatus = 'pending' | 'done';
+
+export interface RebuttalPoint {
+  /** Reviewer and point number, e.g. "R2.3". */
+  id: string;
+  reviewer: string;
+  comment: string;
+  response?: string;
+  status: RebuttalPointStatus;
+}
+
+export interface Rebuttal {
+  id: string;
+  title: string;
+  /** Maximum characters of the assembled rebuttal, if the venue has one. */
+  charLimit?: number;
+  created: string;
+  updated: string;
+  points: RebuttalPoint[];
+}
+
+// A line that is only "Reviewer 2", "Reviewer #2:", "Reviewer xY7z (abc)"
+// or "R2:", optionally as a Markdown heading or in bold
+const REVIEWER_HEADER =
+  /^\s*(?:#+\s*)?(?:\*\*)?(?:reviewer|review)\s*#?\s*([a-z]*\d+[a-z\d]*|[a-z\d]{4})\s*(?:\([^)]*\))?\s*(?:\*\*)?\s*:?\s*(?:\*\*)?\s*$|^\s*R(\d+)\s*:?\s*$/i;
+
+// "1.", "2)", "(3)", "W1:", "Q2.", "Weakness 1:", "- ", "* ", "• "
+const POINT_START =
+  /^\s*(?:\d+[.)]|\(\d+\)|[WQ]\d+[:.)]|(?:weakness|question|comment|concern)\s*\d+\s*[:.)]|[-*•])\s+/i;
+
+// Section labels such as "Weaknesses:" introduce points but are not one
+const SECTION_LABEL =
+  /^\s*(?:\*\*)?[A-Za-z][A-Za-z /&-]{0,40}(?::\*\*|\*\*:|:)\s*$/;
+
+export function getRebuttalsDir(): string {
+  return path.join(getUserResearchDir(), 'rebuttals');
+}
+
+export function createRebuttal(title: string, charLimit?: number): Rebuttal {
+  const now = new Date().toISOString();
+  const id =
+    title
+      .toLowerCase()
+      .replace(/[^a-z0-9]+/g, '-')
+      .replace(/^-|-$/g, '')
+      .slice(0, 40) || 'rebuttal';
+  return { id, title, charLimit, created: now, updated: now, points: [] };
+}
+
+export async function saveRebuttal(
+  rebuttal: Rebuttal,
+  dir: string = getRebuttalsDir(),
+): Promise<void> {
+  await fs.promises.mkdir(dir, { recursive: true });
+  await fs.promises.writeFile(
+    path.join(dir, `${rebuttal.id}.json`),
+    JSON.stringify(rebuttal, null, 2),
+    'utf8',
+  );
+}
+
+/** All saved rebuttals, most recently updated first. */
+export async function listRebuttals(
+  dir: string = getRebuttalsDir(),
+): Promise<Rebuttal[]> {
+  let names: string[];
+  try {
+    names = await fs.promises.readdir(dir);
+  } catch (error) {
+    if (isNodeError(error) && error.code === 'ENOENT') {
+      return [];
+    }
+    throw error;
+  }
+  const rebuttals: Rebuttal[] = [];
+  for (const name of names.filter((n) => n.endsWith('.json'))) {
+    rebuttals.push(
+      JSON.parse(await fs.promises.readFile(path.join(dir, name), 'utf8')),
+    );
+  }
+  return rebuttals.sort((a, b) => b.updated.localeCompare(a.updated));
+}
+
+/**
+ * Splits pasted reviews into points. Reviewer headings ("Reviewer 2",
+ * "R2:") start a new reviewer; numbered or bulleted items start a point,
+ * and without any, each paragraph is a point. `reviewer` names the
+ * reviewer of text before the first heading.
+ */
+export function parseReviewerComments(
+  text: string,
+  reviewer = 'R1',
+): Array<Pick<RebuttalPoint, 'reviewer' | 'comment'>> {
+  const points: Array<Pick<RebuttalPoint, 'reviewer' | 'comment'>> = [];
+  let current = reviewer;
+  let lines: string[] = [];
+
+  const flushBlock = (block: string[]) => {
+    const hasItems = block.some((line) => POINT_START.test(line));
+    let point: string[] = [];
+    const flushPoint = () => {
+      const comment = point.join('\n').trim();
+      if (comment && !SECTION_LABEL.test(comment)) {
+        points.push({ reviewer: current, comment });
+      }
+      point = [];
+    };
+    for (const line of block) {
+      const startsPoint = hasItems
+        ? POINT_START.test(line)
+        : line.trim() === '' && point.length > 0;
+      if (startsPoint || SECTION_LABEL.test(line)) {
+        flushPoint();
+      }
+      if (line.trim() === '' && !hasItems) {
+        continue;
+      }
+      if (SECTION_LABEL.test(line)) {
+        continue;
+      }
+      point.push(line.replace(POINT_START, '').trimEnd());
+    }
+    flushPoint();
+  };
+
+  for (const line of text.replace(/\r\n/g, '\n').split('\n')) {
+    const header = line.match(REVIEWER_HEADER);
+    if (header) {
+      flushBlock(lines);
+      lines = [];
+      current = `R${header[1] ?? header[2]}`;
+      continue;
+    }
+    lines.push(line);
+  }
+  flushBlock(lines);
+  return points;
+}
+
+/**
+ * Adds parsed points to a rebuttal, numbering them per reviewer after
+ * the points already there.
+ */
+export function addRebuttalPoints(
+  rebuttal: Rebuttal,
+  points: Array<Pick<RebuttalPoint, 'reviewer' | 'comment'>>,
+): RebuttalPoint[] {
+  const added: RebuttalPoint[] = [];
+  for (const { reviewer, comment } of points) {
+    const number =
+      rebuttal.points.filter((p) => p.reviewer === reviewer).length + 1;
+    const point: RebuttalPoint = {
+      id: `${reviewer}.${number}`,
+      reviewer,
+      comment,
+      status: 'pending',
+    };
+    rebuttal.points.push(point);
+    added.push(point);
+  }
+  rebuttal.updated = new Date().toISOString();
+  return added;
+}
+
+export function findRebuttalPoint(
+  rebuttal: Rebuttal,
+  id: string,
+): RebuttalPoint | undefined {
+  return rebuttal.points.find(
+    (point) => point.id.toLowerCase() === id.toLowerCase(),
+  );
+}
+
+/** The first words of a comment, for quoting it in the rebuttal. */
+export function summarizeComment(comment: string, maxLength = 80): string {
+  const flat = comment.replace(/\s+/g, ' ').trim();
+  return flat.length <= maxLength
+    ? flat
+    : `${flat.slice(0, maxLength - 1).replace(/\s+\S*$/, '')}…`;
+}
+
+/**
+ * The rebuttal text: per reviewer, each answered point as a short quote
+ * of the comment followed by the response. Pending points without a
+ * response are left out. Pass `reviewer` for a single reviewer's reply.
+ */
+export function assembleRebuttal(
+  rebuttal: Rebuttal,
+  reviewer?: string,
+): string {
+  const reviewers = [
+    ...new Set(rebuttal.points.map((point) => point.reviewer)),
+  ].filter((r) => !reviewer || r.toLowerCase() === reviewer.toLowerCase());
+  const sections: string[] = [];
+  for (const r of reviewers) {
+    const answered = rebuttal.points.filter(
+      (point) => point.reviewer === r && point.response,
+    );
+    if (answered.length === 0) {
+      continue;
+    }
+    sections.push(
+      [
+        ...(reviewer ? [] : [`**Response to ${r}**`]),
+        ...answered.map(
+          (point) =>
+            `**${point.id}** _${summarizeComment(point.comment)}_\n${point.response!.trim()}`,
+        ),
+      ].join('\n\n'),
+    );
+  }
+  return sections.join('\n\n');
+}
+
+/** "4,812 / 5,000 characters", or just the count without a limit. */
+export function formatCharacterCount(text: string, limit?: number): string {
+  const count = text.length.toLocaleString('en-US');
+  return limit
+    ? `${count} / ${limit.toLocaleString('en-US')} characters${text.length > limit ? ` (${(text.length - limit).toLocaleString('en-US')} over)` : ''}`
+    : `${count} characters`;
+}
+
+/**
+ * Asks the model for a response to one point, given the other points of
+ * the same reviewer and any responses written so far.
+ */
+export async function draftRebuttalResponse(
+  client: Pick<ResearchClient, 'generateContent'>,
+  rebuttal: Rebuttal,
+  point: RebuttalPoint,
+  abortSignal: AbortSignal,
+  notes?: string,
+): Promise<string> {
+  const others = rebuttal.points.filter(
+    (p) => p.reviewer === point.reviewer && p.id !== point.id,
+  );
+  const prompt = [
+    `Draft the authors' response to one reviewer point in the rebuttal for "${rebuttal.title}".`,
+    'Be polite, specific and brief: thank the reviewer only if warranted, answer the concern directly, and state concrete changes to the paper. Do not invent results; write [TODO: ...] where the authors must supply numbers or experiments.',
+    ...(notes ? ['', `The authors' notes for this point: ${notes}`] : []),
+    '',
+    `Point ${point.id}:`,
+    point.comment,
+    ...(others.length > 0
+      ? [
+          '',
+          `Other points from ${point.reviewer}, for context:`,
+          ...others.map(
+            (p) =>
+              `- ${p.id}: ${summarizeComment(p.comment, 200)}${p.response ? `\n  Response: ${p.response}` : ''}`,
+          ),
+        ]
+      : []),
+  ].join('\n');
+
+  const response = await client.generateContent(
+    [{ role: 'user', parts: [{ text: prompt }] }],
+    {},
+    abortSignal,
+  );
+  const text = getResponseText(response)?.trim();
+  if (!text) {
+    throw new Error('The model returned an empty response.');
+  }
+  return text;
+}
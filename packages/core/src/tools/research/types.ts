@@ -178,6 +178,20 @@ export enum Database {
   ACM = 'acm',
   SPRINGER = 'springer',
   GOOGLE_SCHOLAR = 'google_scholar',
+  BIORXIV = 'biorxiv',
+  MEDRXIV = 'medrxiv',
+}
+
+/**
+ * 论文检索选项（各数据源客户端通用）
+ */
+export interface PaperSearchOptions {
+  maxResults?: number;
+  yearRange?: {
+    start: number;
+    end: number;
+  };
+  sortBy?: 'relevance' | 'date';
 }
 
 /**
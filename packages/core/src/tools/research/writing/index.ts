@@ -5,3 +5,6 @@
  */
 
 export { AcademicWritingAssistant } from './academic-writing-assistant.js';
+export * from './overlap-checker.js';
+export * from './text-metrics.js';
+export * from './notation-glossary.js';
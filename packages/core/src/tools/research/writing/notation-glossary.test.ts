@@ -0,0 +1,161 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import {
+  GlossaryEntry,
+  defineSymbols,
+  extractMathSymbols,
+  formatGlossary,
+  insertGlossary,
+  loadGlossary,
+  mergeGlossary,
+  saveGlossary,
+} from './notation-glossary.js';
+
+const PAPER = String.raw`Each token $x_i \in \mathbb{R}^d$ is projected by $\mathbf{W}_{q}$.
+% $\beta$ is only in a comment
+\begin{equation}
+  \mathcal{L} = -\sum_i \log p(y_i \mid x_i; \theta)
+\end{equation}
+with learning rate \(\alpha\) and $\text{softmax}(z)$.`;
+
+describe('extractMathSymbols', () => {
+  it('should list the symbols in math, in order of first use', () => {
+    const candidates = extractMathSymbols(PAPER);
+    expect(candidates.map((c) => c.symbol)).toEqual([
+      'x_i',
+      '\\mathbb{R}^d',
+      '\\mathbf{W}_q',
+      '\\mathcal{L}',
+      'p',
+      'y_i',
+      '\\theta',
+      '\\alpha',
+      'z',
+    ]);
+    expect(candidates[0].context).toContain('Each token');
+  });
+});
+
+describe('defineSymbols', () => {
+  it('should ask for the candidates and normalize the answer', async () => {
+    const generateJson = vi.fn().mockResolvedValue({
+      symbols: [
+        { symbol: '$x_{i}$', definition: ' the $i$-th token ' },
+        { symbol: 'p', definition: '' },
+      ],
+    });
+    const definitions = await defineSymbols(
+      { generateJson },
+      PAPER,
+      extractMathSymbols(PAPER),
+      new AbortController().signal,
+    );
+    expect(definitions).toEqual([
+      { symbol: 'x_i', definition: 'the $i$-th token' },
+    ]);
+    const prompt = generateJson.mock.calls[0][0][0].parts[0].text as string;
+    expect(prompt).toContain('- \\mathbf{W}_q: ...');
+  });
+});
+
+describe('mergeGlossary', () => {
+  it('should add new symbols and keep edited definitions', () => {
+    const entries: GlossaryEntry[] = [
+      { symbol: 'x_i', definition: 'input token', edited: true },
+      { symbol: '\\alpha', definition: 'step size' },
+    ];
+    const added = mergeGlossary(
+      entries,
+      [
+        { symbol: 'x_{i}', definition: 'token' },
+        { symbol: '\\alpha', definition: 'learning rate' },
+        { symbol: '\\theta', definition: 'parameters' },
+      ],
+      'paper.tex',
+    );
+    expect(added).toBe(1);
+    expect(entries).toEqual([
+      { symbol: 'x_i', definition: 'input token', edited: true },
+      { symbol: '\\alpha', definition: 'learning rate', source: 'paper.tex' },
+      { symbol: '\\theta', definition: 'parameters', source: 'paper.tex' },
+    ]);
+  });
+});
+
+describe('formatGlossary and insertGlossary', () => {
+  const entries: GlossaryEntry[] = [
+    { symbol: '\\theta', definition: 'weights & biases of $f_\\theta$' },
+  ];
+
+  it('should format a LaTeX or Markdown table', () => {
+    expect(formatGlossary(entries, 'latex')).toContain(
+      '    $\\theta$ & weights \\& biases of $f_\\theta$ \\\\',
+    );
+    expect(formatGlossary(entries, 'markdown')).toBe(
+      [
+        '| Symbol | Meaning |',
+        '| --- | --- |',
+        '| $\\theta$ | weights & biases of $f_\\theta$ |',
+      ].join('\n'),
+    );
+  });
+
+  it('should replace the placeholder, then the marked block', () => {
+    const first = insertGlossary('Intro\n% glossary\nMethod', 'TABLE 1')!;
+    expect(first).toBe(
+      'Intro\n% glossary:begin\nTABLE 1\n% glossary:end\nMethod',
+    );
+    expect(insertGlossary(first, 'TABLE 2')).toBe(
+      'Intro\n% glossary:begin\nTABLE 2\n% glossary:end\nMethod',
+    );
+    expect(insertGlossary('No marker', 'TABLE')).toBeUndefined();
+  });
+
+  it('should mark the block with HTML comments in Markdown', () => {
+    const first = insertGlossary(
+      'Intro\n<!-- glossary -->\nMethod',
+      'TABLE 1',
+      'markdown',
+    )!;
+    expect(first).toBe(
+      'Intro\n<!-- glossary:begin -->\nTABLE 1\n<!-- glossary:end -->\nMethod',
+    );
+    expect(insertGlossary(first, 'TABLE 2', 'markdown')).toContain(
+      '<!-- glossary:begin -->\nTABLE 2\n<!-- glossary:end -->',
+    );
+    expect(
+      insertGlossary('Intro\n% glossary\nMethod', 'TABLE', 'markdown'),
+    ).toBeUndefined();
+  });
+});
+
+describe('glossary storage', () => {
+  let tempDir: string;
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'glossary-'));
+  });
+
+  afterEach(() => {
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should save and load the workspace glossary', async () => {
+    expect(await loadGlossary(tempDir)).toEqual([]);
+    await saveGlossary(tempDir, [{ symbol: 'x', definition: 'input' }]);
+    expect(
+      fs.existsSync(path.join(tempDir, '.research', 'glossary.json')),
+    ).toBe(true);
+    expect(await loadGlossary(tempDir)).toEqual([
+      { symbol: 'x', definition: 'input' },
+    ]);
+  });
+});
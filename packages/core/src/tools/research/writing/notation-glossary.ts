@@ -0,0 +1,322 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+/**
+ * Notation Glossary - Collects the symbols used in a paper's math, has
+ * the model define them from context, and keeps the glossary per
+ * workspace for insertion into drafts
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import { SchemaUnion, Type } from '@google/genai';
+import type { ResearchClient } from '../../../core/client.js';
+import { RESEARCH_DIR } from '../../../utils/paths.js';
+import { isNodeError } from '../../../utils/errors.js';
+
+const GLOSSARY_FILE = 'glossary.json';
+// Symbols are usually introduced early; the start of the paper is enough.
+const MAX_PAPER_TEXT_CHARS = 40000;
+const CONTEXT_CHARS = 80;
+
+/**
+ * The comments that mark the glossary in a draft: `begin` and `end` around
+ * the block, so it can be updated in place, and a `placeholder` line that
+ * asks for it to be inserted there. Markdown drafts use HTML comments,
+ * which renderers hide; LaTeX drafts use `%` comments.
+ */
+export function getGlossaryMarkers(format: GlossaryFormat): {
+  begin: string;
+  end: string;
+  placeholder: string;
+} {
+  return format === 'markdown'
+    ? {
+        begin: '<!-- glossary:begin -->',
+        end: '<!-- glossary:end -->',
+        placeholder: '<!-- glossary -->',
+      }
+    : {
+        begin: '% glossary:begin',
+        end: '% glossary:end',
+        placeholder: '% glossary',
+      };
+}
+
+export interface GlossaryEntry {
+  /** The symbol in LaTeX math, without dollar signs, e.g. `\mathbf{W}_q`. */
+  symbol: string;
+  /** May contain inline math in $...$. */
+  definition: string;
+  /** The file the symbol was found in. */
+  source?: string;
+  /** Set when the user wrote the definition; scans keep it. */
+  edited?: boolean;
+}
+
+export interface SymbolCandidate {
+  symbol: string;
+  /** Text around the first use. */
+  context: string;
+}
+
+export type GlossaryFormat = 'latex' | 'markdown';
+
+const DEFINITIONS_SCHEMA: SchemaUnion = {
+  type: Type.OBJECT,
+  properties: {
+    symbols: {
+      type: Type.ARRAY,
+      items: {
+        type: Type.OBJECT,
+        properties: {
+          symbol: {
+            type: Type.STRING,
+            description: 'The symbol in LaTeX math, without dollar signs.',
+          },
+          definition: {
+            type: Type.STRING,
+            description:
+              'A short definition, as in a notation table; inline math in $...$.',
+          },
+        },
+        required: ['symbol', 'definition'],
+      },
+    },
+  },
+  required: ['symbols'],
+};
+
+// Inline and display math, outside comments
+const MATH_PATTERN =
+  /\$\$([\s\S]+?)\$\$|\$([^$]+)\$|\\\(([\s\S]+?)\\\)|\\\[([\s\S]+?)\\\]|\\begin\{(equation|align|gather|multline)\*?\}([\s\S]*?)\\end\{\5\*?\}/g;
+
+// A letter, a Greek letter or a styled letter, with optional sub- and
+// superscripts, e.g. x_i, \alpha, \mathbf{W}_{q}, \hat{y}^{(t)}
+const SYMBOL_PATTERN =
+  /(?:\\(?:mathbf|mathcal|mathbb|mathrm|boldsymbol|bm|hat|tilde|bar|vec)\{\\?[A-Za-z]+\}|\\(?:alpha|beta|gamma|delta|epsilon|varepsilon|zeta|eta|theta|vartheta|iota|kappa|lambda|mu|nu|xi|pi|rho|sigma|tau|upsilon|phi|varphi|chi|psi|omega|Gamma|Delta|Theta|Lambda|Xi|Pi|Sigma|Phi|Psi|Omega)(?![A-Za-z])|(?<![\\A-Za-z_^])[A-Za-z](?![A-Za-z]))(?:_(?:\{[^{}]*\}|[A-Za-z0-9])|\^(?:\{[^{}]*\}|[A-Za-z0-9*]))*/g;
+
+export function getGlossaryPath(projectRoot: string): string {
+  return path.join(projectRoot, RESEARCH_DIR, GLOSSARY_FILE);
+}
+
+export async function loadGlossary(
+  projectRoot: string,
+): Promise<GlossaryEntry[]> {
+  try {
+    return JSON.parse(
+      await fs.promises.readFile(getGlossaryPath(projectRoot), 'utf8'),
+    ) as GlossaryEntry[];
+  } catch (error) {
+    if (isNodeError(error) && error.code === 'ENOENT') {
+      return [];
+    }
+    throw error;
+  }
+}
+
+export async function saveGlossary(
+  projectRoot: string,
+  entries: GlossaryEntry[],
+): Promise<void> {
+  const filePath = getGlossaryPath(projectRoot);
+  await fs.promises.mkdir(path.dirname(filePath), { recursive: true });
+  await fs.promises.writeFile(
+    filePath,
+    JSON.stringify(entries, null, 2),
+    'utf8',
+  );
+}
+
+/** Compares symbols ignoring spacing and redundant braces (x_{i} = x_i). */
+export function normalizeSymbol(symbol: string): string {
+  return symbol
+    .replace(/\s+/g, '')
+    .replace(/([_^])\{([A-Za-z0-9])\}/g, '$1$2')
+    .replace(/^\$|\$$/g, '');
+}
+
+/**
+ * The distinct symbols in the math of a LaTeX document, in order of
+ * first use, with the text around that use.
+ */
+export function extractMathSymbols(text: string): SymbolCandidate[] {
+  const source = text.replace(/(^|[^\\])%.*$/gm, '$1');
+  const candidates = new Map<string, SymbolCandidate>();
+  for (const match of source.matchAll(MATH_PATTERN)) {
+    const math =
+      match[1] ?? match[2] ?? match[3] ?? match[4] ?? match[6] ?? '';
+    // Operators and text are not notation
+    const cleaned = math.replace(
+      /\\(?:text|mathrm|operatorname|label|ref)\{[^}]*\}|\\(?:sum|prod|int|log|exp|max|min|arg|sin|cos|tanh|frac|left|right|cdot|in|to|leq|geq|sim|approx|quad|qquad|mid|top|infty|partial|nabla)(?![A-Za-z])/g,
+      ' ',
+    );
+    for (const symbolMatch of cleaned.matchAll(SYMBOL_PATTERN)) {
+      const symbol = normalizeSymbol(symbolMatch[0]);
+      if (!candidates.has(symbol)) {
+        const start = Math.max(0, (match.index ?? 0) - CONTEXT_CHARS);
+        const end = (match.index ?? 0) + match[0].length + CONTEXT_CHARS;
+        candidates.set(symbol, {
+          symbol,
+          context: source.slice(start, end).replace(/\s+/g, ' ').trim(),
+        });
+      }
+    }
+  }
+  return [...candidates.values()];
+}
+
+/**
+ * Asks the model to define the notation of a paper. With `candidates`
+ * (from LaTeX sources) only those symbols are defined; for text without
+ * markup, such as PDF text, the model picks the symbols. Symbols the
+ * paper does not define are left out.
+ */
+export async function defineSymbols(
+  client: Pick<ResearchClient, 'generateJson'>,
+  paperText: string,
+  candidates: SymbolCandidate[] | undefined,
+  abortSignal: AbortSignal,
+): Promise<Array<Pick<GlossaryEntry, 'symbol' | 'definition'>>> {
+  const prompt = [
+    'Build a notation table for the paper below: for each symbol, a short definition as the paper uses it, e.g. "number of attention heads" or "query projection matrix, $\\mathbb{R}^{d \\times d_k}$".',
+    'Give symbols in LaTeX math without dollar signs. Leave out symbols the paper does not define or uses only as a bound variable in a single equation.',
+    ...(candidates
+      ? [
+          '',
+          'Define only these symbols, which appear in the math of the paper (shown with the text around their first use):',
+          ...candidates.map((c) => `- ${c.symbol}: ...${c.context}...`),
+        ]
+      : []),
+    '',
+    'Paper:',
+    paperText.slice(0, MAX_PAPER_TEXT_CHARS),
+  ].join('\n');
+
+  const response = await client.generateJson(
+    [{ role: 'user', parts: [{ text: prompt }] }],
+    DEFINITIONS_SCHEMA,
+    abortSignal,
+  );
+  const symbols = Array.isArray(response['symbols'])
+    ? (response['symbols'] as Array<Record<string, unknown>>)
+    : [];
+  return symbols
+    .filter(
+      (s) =>
+        typeof s['symbol'] === 'string' &&
+        typeof s['definition'] === 'string' &&
+        s['definition'].trim(),
+    )
+    .map((s) => ({
+      symbol: normalizeSymbol(s['symbol'] as string),
+      definition: (s['definition'] as string).trim(),
+    }));
+}
+
+export function findGlossaryEntry(
+  entries: GlossaryEntry[],
+  symbol: string,
+): GlossaryEntry | undefined {
+  const normalized = normalizeSymbol(symbol);
+  return entries.find((entry) => entry.symbol === normalized);
+}
+
+/**
+ * Adds new definitions to the glossary. Definitions the user edited are
+ * kept; others are updated. Returns the number of new symbols.
+ */
+export function mergeGlossary(
+  entries: GlossaryEntry[],
+  definitions: Array<Pick<GlossaryEntry, 'symbol' | 'definition'>>,
+  source?: string,
+): number {
+  let added = 0;
+  for (const { symbol, definition } of definitions) {
+    const existing = findGlossaryEntry(entries, symbol);
+    if (!existing) {
+      entries.push({ symbol: normalizeSymbol(symbol), definition, source });
+      added++;
+    } else if (!existing.edited) {
+      existing.definition = definition;
+      existing.source = source ?? existing.source;
+    }
+  }
+  return added;
+}
+
+function escapeLatexText(text: string): string {
+  // Keep inline math; escape the characters that break a table row
+  return text
+    .split(/(\$[^$]*\$)/)
+    .map((part, i) =>
+      i % 2 === 1 ? part : part.replace(/([&%#_])/g, '\\$1'),
+    )
+    .join('');
+}
+
+/** A notation table in LaTeX (booktabs) or Markdown. */
+export function formatGlossary(
+  entries: GlossaryEntry[],
+  format: GlossaryFormat,
+): string {
+  if (format === 'markdown') {
+    return [
+      '| Symbol | Meaning |',
+      '| --- | --- |',
+      ...entries.map(
+        (e) =>
+          `| $${e.symbol}$ | ${e.definition
+            .replace(/\|/g, '\\|')
+            .replace(/\s*\n\s*/g, ' ')} |`,
+      ),
+    ].join('\n');
+  }
+  return [
+    '\\begin{table}[t]',
+    '  \\centering',
+    '  \\caption{Notation.}',
+    '  \\label{tab:notation}',
+    '  \\begin{tabular}{ll}',
+    '    \\toprule',
+    '    Symbol & Meaning \\\\',
+    '    \\midrule',
+    ...entries.map(
+      (e) => `    $${e.symbol}$ & ${escapeLatexText(e.definition)} \\\\`,
+    ),
+    '    \\bottomrule',
+    '  \\end{tabular}',
+    '\\end{table}',
+  ].join('\n');
+}
+
+/**
+ * Puts the glossary into a draft: replaces the block between the glossary
+ * markers of the format, or a line that is only its placeholder. Returns
+ * undefined when the draft has neither.
+ */
+export function insertGlossary(
+  draft: string,
+  glossary: string,
+  format: GlossaryFormat = 'latex',
+): string | undefined {
+  const markers = getGlossaryMarkers(format);
+  const block = `${markers.begin}\n${glossary}\n${markers.end}`;
+  const begin = draft.indexOf(markers.begin);
+  const end = draft.indexOf(markers.end);
+  if (begin !== -1 && end > begin) {
+    return (
+      draft.slice(0, begin) + block + draft.slice(end + markers.end.length)
+    );
+  }
+  const placeholder = new RegExp(
+    `^[ \\t]*${markers.placeholder}[ \\t]*$`,
+    'm',
+  );
+  return placeholder.test(draft)
+    ? draft.replace(placeholder, () => block)
+    : undefined;
+}
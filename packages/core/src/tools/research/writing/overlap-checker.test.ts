@@ -0,0 +1,96 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, afterEach } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import {
+  findOverlaps,
+  loadOverlapSources,
+  normalizeWords,
+  splitSentences,
+} from './overlap-checker.js';
+
+const EARLIER_PAPER = `We propose a sparse attention mech-
+anism that scales linearly with sequence length. Results on long
+documents are strong.`;
+
+describe('splitSentences', () => {
+  it('should drop LaTeX comments and split at sentence ends', () => {
+    expect(
+      splitSentences('First one. % a comment. Not here.\nSecond \\emph{one}!'),
+    ).toEqual(['First one.', 'Second \\emph{one}!']);
+  });
+});
+
+describe('normalizeWords', () => {
+  it('should ignore citations, math and markup', () => {
+    expect(
+      normalizeWords('Our \\textbf{model} reaches $O(n)$~\\citep[p.~2]{a,b}.'),
+    ).toEqual(['our', 'model', 'reaches']);
+  });
+});
+
+describe('findOverlaps', () => {
+  it('should flag near-duplicate sentences with their source', () => {
+    const draft =
+      'Attention is costly. We propose a sparse attention mechanism that scales linearly with the sequence length~\\cite{vaswani}. This sentence is entirely new and says something else.';
+
+    const matches = findOverlaps(draft, [
+      { name: 'own.pdf', text: EARLIER_PAPER },
+    ]);
+
+    expect(matches).toHaveLength(1);
+    expect(matches[0]).toMatchObject({
+      source: 'own.pdf',
+      match:
+        'We propose a sparse attention mechanism that scales linearly with sequence length.',
+    });
+    expect(matches[0].score).toBeCloseTo(2 / 3);
+  });
+
+  it('should respect the threshold', () => {
+    const draft =
+      'We propose a sparse attention mechanism that scales linearly with the sequence length.';
+    expect(
+      findOverlaps(draft, [{ name: 'own.pdf', text: EARLIER_PAPER }], {
+        threshold: 0.9,
+      }),
+    ).toEqual([]);
+  });
+});
+
+describe('loadOverlapSources', () => {
+  let tempDir: string;
+
+  afterEach(() => {
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should read text files recursively and skip excluded files', async () => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'overlap-'));
+    fs.mkdirSync(path.join(tempDir, 'papers', 'node_modules'), {
+      recursive: true,
+    });
+    fs.writeFileSync(path.join(tempDir, 'papers', 'old.tex'), 'Old text.');
+    fs.writeFileSync(path.join(tempDir, 'papers', 'data.csv'), 'a,b');
+    fs.writeFileSync(
+      path.join(tempDir, 'papers', 'node_modules', 'x.md'),
+      'Skip.',
+    );
+    fs.writeFileSync(path.join(tempDir, 'draft.tex'), 'Draft.');
+
+    const sources = await loadOverlapSources(
+      [tempDir],
+      [path.join(tempDir, 'draft.tex')],
+    );
+
+    expect(sources).toEqual([
+      { name: path.join(tempDir, 'papers', 'old.tex'), text: 'Old text.' },
+    ]);
+  });
+});
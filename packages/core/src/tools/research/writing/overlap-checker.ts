@@ -0,0 +1,189 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+/**
+ * Overlap Checker - Flags draft sentences that (nearly) repeat sentences
+ * of the author's earlier papers or cited PDFs, using word shingles
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import { extractPdfText } from '../../../utils/pdfFigures.js';
+
+export const DEFAULT_SHINGLE_SIZE = 5;
+export const DEFAULT_OVERLAP_THRESHOLD = 0.5;
+
+const TEXT_EXTENSIONS = ['.tex', '.md', '.txt'];
+const SKIPPED_DIRS = new Set(['node_modules', '.git']);
+
+export interface OverlapSource {
+  /** A file path or other label shown with matches. */
+  name: string;
+  text: string;
+}
+
+export interface OverlapMatch {
+  /** The draft sentence, as written. */
+  sentence: string;
+  source: string;
+  /** The closest sentence of the source. */
+  match: string;
+  /** Share of the draft sentence's shingles found in the match, 0-1. */
+  score: number;
+}
+
+export interface OverlapOptions {
+  shingleSize?: number;
+  threshold?: number;
+}
+
+/**
+ * Splits prose into sentences. LaTeX comments are dropped, and words
+ * hyphenated across lines (as pdftotext leaves them) are joined.
+ */
+export function splitSentences(text: string): string[] {
+  return text
+    .replace(/(^|[^\\])%.*$/gm, '$1')
+    .replace(/(\w)-\n(?=[a-z])/g, '$1')
+    .replace(/\s+/g, ' ')
+    .split(/(?<=[.!?])\s+(?=[A-Z\\(["'])/)
+    .map((sentence) => sentence.trim())
+    .filter(Boolean);
+}
+
+/**
+ * The words of a sentence, lower-cased, without citations, references,
+ * math and LaTeX markup, so that formatting does not hide a copy.
+ */
+export function normalizeWords(sentence: string): string[] {
+  return sentence
+    .replace(/\\(?:cite\w*|ref|eqref|cref|Cref|label)\*?(?:\[[^\]]*\])*\{[^}]*\}/g, ' ')
+    .replace(/\$[^$]*\$/g, ' ')
+    .replace(/\\[a-zA-Z]+\*?/g, ' ')
+    .toLowerCase()
+    .split(/[^\p{L}\p{N}]+/u)
+    .filter(Boolean);
+}
+
+/** The distinct runs of `size` consecutive words. */
+export function getShingles(words: string[], size: number): Set<string> {
+  const shingles = new Set<string>();
+  for (let i = 0; i + size <= words.length; i++) {
+    shingles.add(words.slice(i, i + size).join(' '));
+  }
+  return shingles;
+}
+
+/**
+ * Compares each draft sentence with every source sentence and reports
+ * those whose shingles are at least `threshold` contained in a single
+ * source sentence, closest first. Sentences shorter than one shingle
+ * are not checked.
+ */
+export function findOverlaps(
+  draft: string,
+  sources: OverlapSource[],
+  options: OverlapOptions = {},
+): OverlapMatch[] {
+  const size = options.shingleSize ?? DEFAULT_SHINGLE_SIZE;
+  const threshold = options.threshold ?? DEFAULT_OVERLAP_THRESHOLD;
+
+  // Shingle -> the source sentences containing it
+  const index = new Map<string, number[]>();
+  const sentences: Array<{ source: string; text: string }> = [];
+  for (const source of sources) {
+    for (const text of splitSentences(source.text)) {
+      const id = sentences.push({ source: source.name, text }) - 1;
+      for (const shingle of getShingles(normalizeWords(text), size)) {
+        const ids = index.get(shingle);
+        if (ids) {
+          ids.push(id);
+        } else {
+          index.set(shingle, [id]);
+        }
+      }
+    }
+  }
+
+  const matches: OverlapMatch[] = [];
+  for (const sentence of splitSentences(draft)) {
+    const shingles = getShingles(normalizeWords(sentence), size);
+    if (shingles.size === 0) {
+      continue;
+    }
+    const shared = new Map<number, number>();
+    for (const shingle of shingles) {
+      for (const id of index.get(shingle) ?? []) {
+        shared.set(id, (shared.get(id) ?? 0) + 1);
+      }
+    }
+    let best: [number, number] | undefined;
+    for (const [id, count] of shared) {
+      if (!best || count > best[1]) {
+        best = [id, count];
+      }
+    }
+    if (best && best[1] / shingles.size >= threshold) {
+      matches.push({
+        sentence,
+        source: sentences[best[0]].source,
+        match: sentences[best[0]].text,
+        score: best[1] / shingles.size,
+      });
+    }
+  }
+  return matches.sort((a, b) => b.score - a.score);
+}
+
+async function collectFiles(target: string, files: string[]): Promise<void> {
+  const stat = await fs.promises.stat(target);
+  if (!stat.isDirectory()) {
+    files.push(target);
+    return;
+  }
+  for (const entry of await fs.promises.readdir(target, {
+    withFileTypes: true,
+  })) {
+    const child = path.join(target, entry.name);
+    if (entry.isDirectory()) {
+      if (!SKIPPED_DIRS.has(entry.name) && !entry.name.startsWith('.')) {
+        await collectFiles(child, files);
+      }
+    } else if (
+      entry.name.toLowerCase().endsWith('.pdf') ||
+      TEXT_EXTENSIONS.includes(path.extname(entry.name).toLowerCase())
+    ) {
+      files.push(child);
+    }
+  }
+}
+
+/**
+ * Reads the corpus to compare against: PDFs through pdftotext and .tex,
+ * .md and .txt files as they are. Directories are searched recursively.
+ * Files in `exclude` (such as the draft itself) are skipped.
+ */
+export async function loadOverlapSources(
+  paths: string[],
+  exclude: string[] = [],
+): Promise<OverlapSource[]> {
+  const files: string[] = [];
+  for (const target of paths) {
+    await collectFiles(path.resolve(target), files);
+  }
+  const skipped = new Set(exclude.map((file) => path.resolve(file)));
+  const sources: OverlapSource[] = [];
+  for (const file of new Set(files)) {
+    if (skipped.has(file)) {
+      continue;
+    }
+    const text = file.toLowerCase().endsWith('.pdf')
+      ? await extractPdfText(file)
+      : await fs.promises.readFile(file, 'utf8');
+    sources.push({ name: file, text });
+  }
+  return sources;
+}
@@ -0,0 +1,227 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, vi, beforeEach, afterEach } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import {
+  DEFAULT_OUTLINE_NOTES_FILE,
+  OutlineItem,
+  createOutlineItem,
+  draftOutlineSection,
+  findOutlineRow,
+  flattenOutline,
+  formatOutline,
+  formatSessionText,
+  generateOutline,
+  insertOutlineItem,
+  loadOutline,
+  moveOutlineItem,
+  removeOutlineItem,
+  saveOutline,
+  writeOutlineSection,
+} from './session-outline.js';
+
+const item = (title: string, children: OutlineItem[] = []): OutlineItem => ({
+  id: title.toLowerCase(),
+  title,
+  children,
+});
+
+describe('session outline', () => {
+  let items: OutlineItem[];
+
+  beforeEach(() => {
+    items = [
+      item('Introduction', [item('Motivation'), item('Contributions')]),
+      item('Method'),
+      item('Results', [item('Ablations')]),
+    ];
+  });
+
+  it('should number items in reading order', () => {
+    expect(formatOutline(items)).toBe(
+      [
+        '1 Introduction',
+        '  1.1 Motivation',
+        '  1.2 Contributions',
+        '2 Method',
+        '3 Results',
+        '  3.1 Ablations',
+      ].join('\n'),
+    );
+    expect(findOutlineRow(items, '3.1')).toMatchObject({
+      path: [2, 0],
+      depth: 1,
+    });
+  });
+
+  it('should move items among their siblings with their children', () => {
+    expect(moveOutlineItem(items, [2], 'up')).toEqual([1]);
+    expect(items.map((i) => i.title)).toEqual([
+      'Introduction',
+      'Results',
+      'Method',
+    ]);
+    expect(items[1].children[0].title).toBe('Ablations');
+    expect(moveOutlineItem(items, [0], 'up')).toBeUndefined();
+    expect(moveOutlineItem(items, [0, 1], 'down')).toBeUndefined();
+  });
+
+  it('should indent under the previous sibling and outdent after the parent', () => {
+    expect(moveOutlineItem(items, [1], 'in')).toEqual([0, 2]);
+    expect(items[0].children.map((i) => i.title)).toEqual([
+      'Motivation',
+      'Contributions',
+      'Method',
+    ]);
+
+    expect(moveOutlineItem(items, [0, 0], 'out')).toEqual([1]);
+    expect(flattenOutline(items).map((row) => row.number)).toEqual([
+      '1',
+      '1.1',
+      '1.2',
+      '2',
+      '3',
+      '3.1',
+    ]);
+    expect(items[1].title).toBe('Motivation');
+    expect(moveOutlineItem(items, [0], 'out')).toBeUndefined();
+    expect(moveOutlineItem(items, [0, 0], 'in')).toBeUndefined();
+  });
+
+  it('should insert and remove items', () => {
+    expect(insertOutlineItem(items, [1], item('Setup'), true)).toEqual([1, 0]);
+    expect(insertOutlineItem(items, [0, 0], item('Gap'))).toEqual([0, 1]);
+    expect(insertOutlineItem(items, undefined, item('Conclusion'))).toEqual([
+      3,
+    ]);
+    expect(removeOutlineItem(items, [2]).title).toBe('Results');
+    expect(formatOutline(items)).toBe(
+      [
+        '1 Introduction',
+        '  1.1 Motivation',
+        '  1.2 Gap',
+        '  1.3 Contributions',
+        '2 Method',
+        '  2.1 Setup',
+        '3 Conclusion',
+      ].join('\n'),
+    );
+  });
+
+  it('should replace an earlier draft of a section in the notes', () => {
+    const method = items[1];
+    let notes = writeOutlineSection('# Notes\n', method, '## Method\nOld.');
+    notes = writeOutlineSection(notes, items[0], '## Introduction\nIntro.');
+    notes = writeOutlineSection(notes, method, '## Method\nNew.\n');
+
+    expect(notes).toBe(
+      [
+        '# Notes',
+        '',
+        '<!-- outline:method -->',
+        '## Method',
+        'New.',
+        '<!-- /outline:method -->',
+        '',
+        '<!-- outline:introduction -->',
+        '## Introduction',
+        'Intro.',
+        '<!-- /outline:introduction -->',
+        '',
+      ].join('\n'),
+    );
+  });
+
+  it('should give new items distinct ids', () => {
+    expect(createOutlineItem('A').id).not.toBe(createOutlineItem('A').id);
+  });
+});
+
+describe('outline storage', () => {
+  let root: string;
+
+  beforeEach(() => {
+    root = fs.mkdtempSync(path.join(os.tmpdir(), 'outline-'));
+  });
+
+  afterEach(() => {
+    fs.rmSync(root, { recursive: true, force: true });
+  });
+
+  it('should start empty and keep saved outlines', async () => {
+    expect(await loadOutline(root)).toEqual({
+      notesFile: DEFAULT_OUTLINE_NOTES_FILE,
+      items: [],
+    });
+
+    const outline = { notesFile: 'paper.md', items: [item('Method')] };
+    await saveOutline(root, outline);
+    expect(await loadOutline(root)).toEqual(outline);
+  });
+});
+
+describe('outline generation', () => {
+  const history = [
+    { role: 'user', parts: [{ text: 'We got 91% accuracy.' }] },
+    { role: 'model', parts: [{ text: 'That beats the baseline.' }] },
+  ];
+
+  it('should format the session by role', () => {
+    expect(formatSessionText(history)).toBe(
+      'User: We got 91% accuracy.\n\nModel: That beats the baseline.',
+    );
+  });
+
+  it('should turn sections and bullets into items', async () => {
+    const generateJson = vi.fn().mockResolvedValue({
+      sections: [
+        { title: 'Results', bullets: ['91% accuracy', ' '] },
+        { title: '', bullets: [] },
+      ],
+    });
+
+    const generated = await generateOutline(
+      { generateJson },
+      'User: ...',
+      'two sections',
+      new AbortController().signal,
+    );
+
+    expect(formatOutline(generated)).toBe('1 Results\n  1.1 91% accuracy');
+    expect(generateJson.mock.calls[0][0][0].parts[0].text).toContain(
+      'Instructions: two sections',
+    );
+  });
+
+  it('should draft a section without a code fence', async () => {
+    const items = [item('Results', [item('91% accuracy')])];
+    const generateContent = vi.fn().mockResolvedValue({
+      candidates: [
+        {
+          content: {
+            parts: [{ text: '```markdown\n## Results\nWe reach 91%.\n```' }],
+          },
+        },
+      ],
+    });
+
+    const draft = await draftOutlineSection(
+      { generateContent },
+      items,
+      findOutlineRow(items, '1')!,
+      'User: ...',
+      new AbortController().signal,
+    );
+
+    expect(draft).toBe('## Results\nWe reach 91%.');
+    expect(generateContent.mock.calls[0][0][0].parts[0].text).toContain(
+      'Start with the heading "## Results"',
+    );
+  });
+});
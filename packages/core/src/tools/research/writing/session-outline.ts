@@ -0,0 +1,349 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import * as crypto from 'node:crypto';
+import { Content, SchemaUnion, Type } from '@google/genai';
+import type { ResearchClient } from '../../../core/client.js';
+import { RESEARCH_DIR } from '../../../utils/paths.js';
+import { isNodeError } from '../../../utils/errors.js';
+import { exchangeText } from '../../../utils/exchanges.js';
+import { getResponseText } from '../../../utils/generateContentResponseUtilities.js';
+
+const OUTLINE_FILE = 'outline.json';
+// The end of a long session matters most for what to write next.
+const MAX_SESSION_TEXT_CHARS = 60000;
+
+/** Where drafted sections go unless the outline names another file. */
+export const DEFAULT_OUTLINE_NOTES_FILE = 'notes/draft.md';
+
+/** A section or bullet of the outline; bullets are items without children. */
+export interface OutlineItem {
+  /** Stable across edits, so a redraft replaces the section in the notes. */
+  id: string;
+  title: string;
+  children: OutlineItem[];
+  /** Set once the section has been drafted into the notes. */
+  drafted?: boolean;
+}
+
+export interface Outline {
+  /** The notes file drafts are written into, relative to the project. */
+  notesFile: string;
+  items: OutlineItem[];
+}
+
+/** An item in reading order, with its number such as "2.1". */
+export interface OutlineRow {
+  item: OutlineItem;
+  /** Indices from the top level down. */
+  path: number[];
+  depth: number;
+  number: string;
+}
+
+export type OutlineMove = 'up' | 'down' | 'in' | 'out';
+
+const OUTLINE_SCHEMA: SchemaUnion = {
+  type: Type.OBJECT,
+  properties: {
+    sections: {
+      type: Type.ARRAY,
+      items: {
+        type: Type.OBJECT,
+        properties: {
+          title: { type: Type.STRING, description: 'The section heading.' },
+          bullets: {
+            type: Type.ARRAY,
+            items: { type: Type.STRING },
+            description: 'The points the section makes, one short line each.',
+          },
+        },
+        required: ['title', 'bullets'],
+      },
+    },
+  },
+  required: ['sections'],
+};
+
+export function getOutlinePath(projectRoot: string): string {
+  return path.join(projectRoot, RESEARCH_DIR, OUTLINE_FILE);
+}
+
+export async function loadOutline(projectRoot: string): Promise<Outline> {
+  try {
+    return JSON.parse(
+      await fs.promises.readFile(getOutlinePath(projectRoot), 'utf8'),
+    ) as Outline;
+  } catch (error) {
+    if (isNodeError(error) && error.code === 'ENOENT') {
+      return { notesFile: DEFAULT_OUTLINE_NOTES_FILE, items: [] };
+    }
+    throw error;
+  }
+}
+
+export async function saveOutline(
+  projectRoot: string,
+  outline: Outline,
+): Promise<void> {
+  const filePath = getOutlinePath(projectRoot);
+  await fs.promises.mkdir(path.dirname(filePath), { recursive: true });
+  await fs.promises.writeFile(
+    filePath,
+    JSON.stringify(outline, null, 2),
+    'utf8',
+  );
+}
+
+export function createOutlineItem(
+  title: string,
+  children: OutlineItem[] = [],
+): OutlineItem {
+  return { id: crypto.randomUUID().slice(0, 8), title, children };
+}
+
+export function flattenOutline(
+  items: OutlineItem[],
+  parent: number[] = [],
+): OutlineRow[] {
+  return items.flatMap((item, index) => {
+    const itemPath = [...parent, index];
+    return [
+      {
+        item,
+        path: itemPath,
+        depth: parent.length,
+        number: itemPath.map((i) => i + 1).join('.'),
+      },
+      ...flattenOutline(item.children, itemPath),
+    ];
+  });
+}
+
+export function findOutlineRow(
+  items: OutlineItem[],
+  number: string,
+): OutlineRow | undefined {
+  return flattenOutline(items).find((row) => row.number === number);
+}
+
+/** The list holding the item at `itemPath`, and its index there. */
+function locate(
+  items: OutlineItem[],
+  itemPath: number[],
+): [OutlineItem[], number] {
+  let siblings = items;
+  for (const index of itemPath.slice(0, -1)) {
+    siblings = siblings[index].children;
+  }
+  return [siblings, itemPath[itemPath.length - 1]];
+}
+
+/**
+ * Moves an item with its children among its siblings, under the previous
+ * sibling ("in") or after its parent ("out"). Returns the new path, or
+ * undefined when the item cannot move that way.
+ */
+export function moveOutlineItem(
+  items: OutlineItem[],
+  itemPath: number[],
+  move: OutlineMove,
+): number[] | undefined {
+  const [siblings, index] = locate(items, itemPath);
+  const parentPath = itemPath.slice(0, -1);
+  switch (move) {
+    case 'up':
+    case 'down': {
+      const target = move === 'up' ? index - 1 : index + 1;
+      if (target < 0 || target >= siblings.length) {
+        return undefined;
+      }
+      [siblings[index], siblings[target]] = [siblings[target], siblings[index]];
+      return [...parentPath, target];
+    }
+    case 'in': {
+      if (index === 0) {
+        return undefined;
+      }
+      const [item] = siblings.splice(index, 1);
+      const newParent = siblings[index - 1];
+      newParent.children.push(item);
+      return [...parentPath, index - 1, newParent.children.length - 1];
+    }
+    case 'out': {
+      if (parentPath.length === 0) {
+        return undefined;
+      }
+      const [item] = siblings.splice(index, 1);
+      const [grandSiblings, parentIndex] = locate(items, parentPath);
+      grandSiblings.splice(parentIndex + 1, 0, item);
+      return [...parentPath.slice(0, -1), parentIndex + 1];
+    }
+    default: {
+      const unhandled: never = move;
+      throw new Error(`Unknown outline move: ${unhandled}`);
+    }
+  }
+}
+
+/**
+ * Adds an item after the one at `afterPath`, or as its last child, or at
+ * the end of the outline without a path. Returns the new item's path.
+ */
+export function insertOutlineItem(
+  items: OutlineItem[],
+  afterPath: number[] | undefined,
+  item: OutlineItem,
+  asChild = false,
+): number[] {
+  if (!afterPath) {
+    items.push(item);
+    return [items.length - 1];
+  }
+  const [siblings, index] = locate(items, afterPath);
+  if (asChild) {
+    siblings[index].children.push(item);
+    return [...afterPath, siblings[index].children.length - 1];
+  }
+  siblings.splice(index + 1, 0, item);
+  return [...afterPath.slice(0, -1), index + 1];
+}
+
+/** Removes an item with its children and returns it. */
+export function removeOutlineItem(
+  items: OutlineItem[],
+  itemPath: number[],
+): OutlineItem {
+  const [siblings, index] = locate(items, itemPath);
+  return siblings.splice(index, 1)[0];
+}
+
+/** The outline as an indented, numbered list; ✓ marks drafted sections. */
+export function formatOutline(items: OutlineItem[]): string {
+  return flattenOutline(items)
+    .map(
+      ({ item, depth, number }) =>
+        `${'  '.repeat(depth)}${number} ${item.title}${item.drafted ? ' ✓' : ''}`,
+    )
+    .join('\n');
+}
+
+/** The conversation as "User:" and "Model:" turns, cut from the start. */
+export function formatSessionText(history: Content[]): string {
+  const text = history
+    .map((content) => {
+      const turn = exchangeText([content]);
+      return turn
+        ? `${content.role === 'model' ? 'Model' : 'User'}: ${turn}`
+        : '';
+    })
+    .filter(Boolean)
+    .join('\n\n');
+  return text.length > MAX_SESSION_TEXT_CHARS
+    ? text.slice(text.length - MAX_SESSION_TEXT_CHARS)
+    : text;
+}
+
+/** Asks the model for an outline of the work discussed in the session. */
+export async function generateOutline(
+  client: Pick<ResearchClient, 'generateJson'>,
+  sessionText: string,
+  instructions: string,
+  abortSignal: AbortSignal,
+): Promise<OutlineItem[]> {
+  const prompt = [
+    'Outline a write-up of the research discussed in the session below: the sections in order, each with the points it should make as short bullets.',
+    'Use only what the session establishes; where results are still missing, add a bullet saying what is needed.',
+    ...(instructions ? ['', `Instructions: ${instructions}`] : []),
+    '',
+    'Session:',
+    sessionText,
+  ].join('\n');
+
+  const response = await client.generateJson(
+    [{ role: 'user', parts: [{ text: prompt }] }],
+    OUTLINE_SCHEMA,
+    abortSignal,
+  );
+  const sections = Array.isArray(response['sections'])
+    ? (response['sections'] as Array<Record<string, unknown>>)
+    : [];
+  return sections
+    .filter((s) => typeof s['title'] === 'string' && s['title'].trim())
+    .map((s) =>
+      createOutlineItem(
+        (s['title'] as string).trim(),
+        (Array.isArray(s['bullets']) ? s['bullets'] : [])
+          .filter((b): b is string => typeof b === 'string' && !!b.trim())
+          .map((b) => createOutlineItem(b.trim())),
+      ),
+    );
+}
+
+/**
+ * Asks the model for the Markdown of one section, following its bullets
+ * and drawing on the session.
+ */
+export async function draftOutlineSection(
+  client: Pick<ResearchClient, 'generateContent'>,
+  items: OutlineItem[],
+  row: OutlineRow,
+  sessionText: string,
+  abortSignal: AbortSignal,
+): Promise<string> {
+  const heading = '#'.repeat(Math.min(row.depth + 2, 6));
+  const prompt = [
+    `Draft section ${row.number} "${row.item.title}" of the write-up outlined below, in Markdown.`,
+    '',
+    'Rules:',
+    `- Start with the heading "${heading} ${row.item.title}" and write only this section.`,
+    "- Make the points of the section's bullets, in their order, as prose; do not copy the bullets.",
+    '- Use only facts from the session. Where a number or result is missing, write [TODO: what is missing].',
+    '',
+    'Outline:',
+    formatOutline(items),
+    '',
+    'Session:',
+    sessionText,
+  ].join('\n');
+
+  const response = await client.generateContent(
+    [{ role: 'user', parts: [{ text: prompt }] }],
+    {},
+    abortSignal,
+  );
+  const text = getResponseText(response)?.trim();
+  if (!text) {
+    throw new Error('The model returned an empty draft.');
+  }
+  return text.replace(/^```(?:markdown|md)?\n([\s\S]*?)\n```$/, '$1');
+}
+
+function sectionMarkers(item: OutlineItem): [string, string] {
+  return [`<!-- outline:${item.id} -->`, `<!-- /outline:${item.id} -->`];
+}
+
+/**
+ * Puts a drafted section into the notes, replacing its earlier draft if
+ * there is one and appending it otherwise.
+ */
+export function writeOutlineSection(
+  notes: string,
+  item: OutlineItem,
+  draft: string,
+): string {
+  const [begin, end] = sectionMarkers(item);
+  const block = `${begin}\n${draft.trim()}\n${end}`;
+  const start = notes.indexOf(begin);
+  const stop = notes.indexOf(end, start);
+  if (start !== -1 && stop !== -1) {
+    return notes.slice(0, start) + block + notes.slice(stop + end.length);
+  }
+  const separator = notes.trim() ? `${notes.trimEnd()}\n\n` : '';
+  return `${separator}${block}\n`;
+}
@@ -0,0 +1,103 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect } from 'vitest';
+import {
+  computeTextMetrics,
+  countSyllables,
+  countWordsBySection,
+  extractLatexSection,
+  isPassiveSentence,
+  stripMarkup,
+} from './text-metrics.js';
+
+const PAPER = String.raw`\documentclass{article}
+\begin{document}
+\section{Introduction}\label{sec:intro}
+Large models are trained on web data~\citep{brown2020}. We \emph{propose} a method that is simple.
+% A comment is not counted.
+\begin{equation} x = y \end{equation}
+The results were shown in Table~\ref{tab:main}. Our approach, which uses $O(n)$ memory, scales well.
+\subsection{Scope}
+We focus on text.
+\section{Method}
+We do things.
+\end{document}`;
+
+describe('stripMarkup', () => {
+  it('should keep only the prose', () => {
+    expect(
+      stripMarkup(
+        'We \\textbf{propose}~\\cite{a} $x$ a [method](http://x) with `code`.',
+      )
+        .replace(/\s+/g, ' ')
+        .trim(),
+    ).toBe('We propose a method with .');
+  });
+});
+
+describe('countSyllables', () => {
+  it('should estimate syllables', () => {
+    expect(countSyllables('the')).toBe(1);
+    expect(countSyllables('trained')).toBe(1);
+    expect(countSyllables('simple')).toBe(2);
+    expect(countSyllables('readability')).toBe(5);
+  });
+});
+
+describe('isPassiveSentence', () => {
+  it('should detect "to be" followed by a participle', () => {
+    expect(isPassiveSentence('The model was trained on ImageNet.')).toBe(true);
+    expect(isPassiveSentence('Results are also shown in Table 2.')).toBe(true);
+    expect(isPassiveSentence('The method is simple.')).toBe(false);
+    expect(isPassiveSentence('We trained the model.')).toBe(false);
+  });
+});
+
+describe('computeTextMetrics', () => {
+  it('should count words, sentences and passive voice of the prose', () => {
+    const metrics = computeTextMetrics(
+      'The model was trained on a large corpus. We evaluate it on two benchmarks.',
+    );
+    expect(metrics).toMatchObject({
+      words: 14,
+      sentences: 2,
+      averageWordsPerSentence: 7,
+      passiveSentences: 1,
+      passiveRatio: 0.5,
+    });
+    expect(metrics.fleschKincaidGrade).toBeGreaterThan(0);
+    expect(metrics.fleschReadingEase).toBeLessThan(100);
+  });
+
+  it('should return zeros for empty text', () => {
+    expect(computeTextMetrics('')).toMatchObject({
+      words: 0,
+      sentences: 0,
+      fleschKincaidGrade: 0,
+    });
+  });
+});
+
+describe('LaTeX sections', () => {
+  it('should count words per section', () => {
+    expect(countWordsBySection(PAPER)).toEqual([
+      { title: 'Introduction', words: 32 },
+      { title: 'Method', words: 3 },
+    ]);
+  });
+
+  it('should extract a section with its subsections', () => {
+    const section = extractLatexSection(PAPER, 'intro')!;
+    expect(section.startsWith('\\section{Introduction}')).toBe(true);
+    expect(section).toContain('We focus on text.');
+    expect(section).not.toContain('Method');
+    expect(extractLatexSection(PAPER, 'scope')!.trim()).toBe(
+      '\\subsection{Scope}\nWe focus on text.',
+    );
+    expect(extractLatexSection(PAPER, 'missing')).toBeUndefined();
+  });
+});
@@ -0,0 +1,244 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+/**
+ * Text Metrics - Word counts and readability (passive voice, Flesch
+ * reading ease and Flesch-Kincaid grade) for LaTeX, Markdown and prose
+ */
+
+import { splitSentences } from './overlap-checker.js';
+
+export interface TextMetrics {
+  words: number;
+  sentences: number;
+  characters: number;
+  syllables: number;
+  averageWordsPerSentence: number;
+  passiveSentences: number;
+  /** Share of sentences in the passive voice, 0-1. */
+  passiveRatio: number;
+  fleschReadingEase: number;
+  /** US school grade needed to follow the text. */
+  fleschKincaidGrade: number;
+}
+
+// Irregular past participles common in academic writing
+const IRREGULAR_PARTICIPLES = new Set([
+  'been',
+  'begun',
+  'bought',
+  'brought',
+  'built',
+  'chosen',
+  'done',
+  'drawn',
+  'driven',
+  'found',
+  'given',
+  'grown',
+  'held',
+  'hidden',
+  'kept',
+  'known',
+  'laid',
+  'led',
+  'left',
+  'lost',
+  'made',
+  'meant',
+  'paid',
+  'put',
+  'read',
+  'run',
+  'said',
+  'seen',
+  'sent',
+  'set',
+  'shown',
+  'split',
+  'spent',
+  'taken',
+  'taught',
+  'thought',
+  'told',
+  'undertaken',
+  'understood',
+  'won',
+  'written',
+]);
+
+const BE_FORMS = 'am|is|are|was|were|be|been|being';
+const PASSIVE_PATTERN = new RegExp(
+  `\\b(?:${BE_FORMS})\\b(?:\\s+(?:not|also|then|further|first|often|usually|typically|\\w+ly))*\\s+(\\w+)\\b`,
+  'gi',
+);
+
+// Environments whose content is not prose
+const NON_PROSE_ENVIRONMENTS =
+  'equation|align|gather|multline|eqnarray|displaymath|math|figure|table|tabular|lstlisting|verbatim|minted|algorithm|algorithmic|tikzpicture|thebibliography';
+
+/**
+ * Reduces LaTeX or Markdown to the prose a reader sees: comments, math,
+ * code, figures, citations and references are removed, and commands
+ * such as \emph{...} keep their argument.
+ */
+export function stripMarkup(text: string): string {
+  return (
+    text
+      // LaTeX comments
+      .replace(/(^|[^\\])%.*$/gm, '$1')
+      .replace(
+        new RegExp(
+          `\\\\begin\\{(${NON_PROSE_ENVIRONMENTS})\\*?\\}[\\s\\S]*?\\\\end\\{\\1\\*?\\}`,
+          'g',
+        ),
+        ' ',
+      )
+      // Markdown code
+      .replace(/```[\s\S]*?```/g, ' ')
+      .replace(/`[^`]*`/g, ' ')
+      // Math
+      .replace(/\$\$[\s\S]*?\$\$|\\\[[\s\S]*?\\\]|\$[^$]*\$/g, ' ')
+      // Citations, references and labels, with optional arguments
+      .replace(
+        /~?\\(?:[a-zA-Z]*cite[a-zA-Z]*|ref|eqref|cref|Cref|autoref|pageref|label|input|include|bibliography\w*|usepackage|documentclass)\*?(?:\[[^\]]*\])*\{[^}]*\}/g,
+        ' ',
+      )
+      .replace(/\\(?:begin|end)\{[^}]*\}/g, ' ')
+      // Other commands are dropped but keep their argument: \emph{x} -> x
+      .replace(/\\[a-zA-Z]+\*?(?:\[[^\]]*\])?/g, ' ')
+      .replace(/[{}]/g, '')
+      // Markdown links, images and emphasis
+      .replace(/!\[[^\]]*\]\([^)]*\)/g, ' ')
+      .replace(/\[([^\]]*)\]\([^)]*\)/g, '$1')
+      .replace(/^#+\s*/gm, '')
+      .replace(/[*_]{1,2}([^*_]+)[*_]{1,2}/g, '$1')
+      .replace(/~/g, ' ')
+  );
+}
+
+/** Estimates syllables by counting vowel groups. */
+export function countSyllables(word: string): number {
+  const w = word.toLowerCase().replace(/[^a-z]/g, '');
+  if (w.length === 0) {
+    return 0;
+  }
+  if (w.length <= 3) {
+    return 1;
+  }
+  const groups = w
+    .replace(/(?:[^laeiouy]es|[^laeiouy]ed|[^laeiouy]e)$/, '')
+    .replace(/^y/, '')
+    .match(/[aeiouy]{1,2}/g);
+  return Math.max(1, groups?.length ?? 1);
+}
+
+function isParticiple(word: string): boolean {
+  const w = word.toLowerCase();
+  return (
+    IRREGULAR_PARTICIPLES.has(w) ||
+    (w.length > 4 && w.endsWith('ed') && !w.endsWith('eed'))
+  );
+}
+
+/** Whether the sentence has a form of "to be" followed by a participle. */
+export function isPassiveSentence(sentence: string): boolean {
+  for (const match of sentence.matchAll(PASSIVE_PATTERN)) {
+    if (isParticiple(match[1])) {
+      return true;
+    }
+  }
+  return false;
+}
+
+function getWords(text: string): string[] {
+  return text.match(/[\p{L}\p{N}]+(?:['’-][\p{L}\p{N}]+)*/gu) ?? [];
+}
+
+/** Counts and readability scores of the text after `stripMarkup`. */
+export function computeTextMetrics(text: string): TextMetrics {
+  const prose = stripMarkup(text);
+  const sentences = splitSentences(prose).filter(
+    (sentence) => getWords(sentence).length > 0,
+  );
+  const words = getWords(prose);
+  const syllables = words.reduce((sum, word) => sum + countSyllables(word), 0);
+  const passiveSentences = sentences.filter(isPassiveSentence).length;
+  const wordsPerSentence =
+    sentences.length > 0 ? words.length / sentences.length : 0;
+  const syllablesPerWord = words.length > 0 ? syllables / words.length : 0;
+  const round = (n: number) => Math.round(n * 10) / 10;
+  return {
+    words: words.length,
+    sentences: sentences.length,
+    characters: prose.replace(/\s+/g, ' ').trim().length,
+    syllables,
+    averageWordsPerSentence: round(wordsPerSentence),
+    passiveSentences,
+    passiveRatio:
+      sentences.length > 0 ? passiveSentences / sentences.length : 0,
+    fleschReadingEase:
+      words.length > 0
+        ? round(206.835 - 1.015 * wordsPerSentence - 84.6 * syllablesPerWord)
+        : 0,
+    fleschKincaidGrade:
+      words.length > 0
+        ? round(0.39 * wordsPerSentence + 11.8 * syllablesPerWord - 15.59)
+        : 0,
+  };
+}
+
+/** Words per top-level section of a LaTeX document, in order. */
+export function countWordsBySection(
+  text: string,
+): Array<{ title: string; words: number }> {
+  const parts = text.split(/\\(?:chapter|section)\*?(?:\[[^\]]*\])?\{/);
+  const sections: Array<{ title: string; words: number }> = [];
+  const preamble = parts[0].replace(/[\s\S]*\\begin\{document\}/, '');
+  const preambleWords = getWords(stripMarkup(preamble)).length;
+  if (preambleWords > 0) {
+    sections.push({
+      title: '(before the first section)',
+      words: preambleWords,
+    });
+  }
+  for (const part of parts.slice(1)) {
+    const end = part.indexOf('}');
+    sections.push({
+      title: stripMarkup(part.slice(0, end)).trim(),
+      words: getWords(stripMarkup(part.slice(end + 1))).length,
+    });
+  }
+  return sections;
+}
+
+/**
+ * The text of the section titled `title` (case-insensitive prefix), up
+ * to the next section at the same or a higher level.
+ */
+export function extractLatexSection(
+  text: string,
+  title: string,
+): string | undefined {
+  const levels = ['part', 'chapter', 'section', 'subsection', 'subsubsection'];
+  const pattern =
+    /\\(part|chapter|section|subsection|subsubsection)\*?(?:\[[^\]]*\])?\{([^}]*)\}/g;
+  const headings = [...text.matchAll(pattern)];
+  const index = headings.findIndex((heading) =>
+    heading[2].toLowerCase().startsWith(title.toLowerCase()),
+  );
+  if (index === -1) {
+    return undefined;
+  }
+  const level = levels.indexOf(headings[index][1]);
+  const next = headings
+    .slice(index + 1)
+    .find((heading) => levels.indexOf(heading[1]) <= level);
+  return text.slice(
+    headings[index].index!,
+    next?.index ?? text.search(/\\end\{document\}|$/),
+  );
+}
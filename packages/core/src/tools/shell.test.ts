@@ -408,6 +408,8 @@ describe('ShellTool Bug Reproduction', () => {
       getCoreTools: () => undefined,
       getExcludeTools: () => undefined,
       getDebugMode: () => false,
+      getRemoteTarget: () => undefined,
+      getContainerExecution: () => undefined,
       getResearchClient: () => ({}) as ResearchClient,
       getTargetDir: () => '.',
     } as unknown as Config;
@@ -430,3 +432,64 @@ describe('ShellTool Bug Reproduction', () => {
     expect(summarizeSpy).toHaveBeenCalled();
   });
 });
+
+describe('ShellTool on a remote target', () => {
+  const config = {
+    getCoreTools: () => undefined,
+    getExcludeTools: () => undefined,
+    getRemoteTarget: () => ({ host: 'gpu01', user: 'alice' }),
+    getContainerExecution: () => undefined,
+    getTargetDir: () => '.',
+  } as unknown as Config;
+
+  it('should show the target in the description and approval', async () => {
+    const shellTool = new ShellTool(config);
+    expect(shellTool.getDescription({ command: 'nvidia-smi' })).toBe(
+      'nvidia-smi [on alice@gpu01]',
+    );
+    const details = await shellTool.shouldConfirmExecute(
+      { command: 'nvidia-smi' },
+      new AbortController().signal,
+    );
+    expect(details && details.title).toBe(
+      'Confirm Shell Command on alice@gpu01',
+    );
+  });
+
+  it('should tell the model where commands run', () => {
+    const shellTool = new ShellTool(config);
+    expect(shellTool.schema.description).toMatch(
+      /^Commands run on the remote host `alice@gpu01` over SSH/,
+    );
+  });
+
+  it('should not require the directory to exist locally', () => {
+    const shellTool = new ShellTool(config);
+    expect(
+      shellTool.validateToolParams({
+        command: 'ls',
+        directory: 'only-on-remote',
+      }),
+    ).toBeNull();
+  });
+});
+
+describe('ShellTool in a container', () => {
+  const config = {
+    getCoreTools: () => undefined,
+    getExcludeTools: () => undefined,
+    getRemoteTarget: () => ({ host: 'gpu01' }),
+    getContainerExecution: () => ({ image: 'python:3.12' }),
+    getTargetDir: () => '.',
+  } as unknown as Config;
+
+  it('should prefer the container over the remote host', async () => {
+    const shellTool = new ShellTool(config);
+    expect(shellTool.getDescription({ command: 'python run.py' })).toBe(
+      'python run.py [on container python:3.12]',
+    );
+    expect(shellTool.schema.description).toMatch(
+      /^Commands run in a disposable `python:3.12` container/,
+    );
+  });
+});
@@ -16,7 +16,7 @@ import {
   ToolExecuteConfirmationDetails,
   ToolConfirmationOutcome,
 } from './tools.js';
-import { Type } from '@google/genai';
+import { FunctionDeclaration, Type } from '@google/genai';
 import { SchemaValidator } from '../utils/schemaValidator.js';
 import { getErrorMessage } from '../utils/errors.js';
 import stripAnsi from 'strip-ansi';
@@ -26,11 +26,33 @@ export interface ShellToolParams {
   description?: string;
   directory?: string;
 }
-import { spawn } from 'child_process';
+import { ChildProcessByStdio, spawn } from 'child_process';
+import { Readable } from 'stream';
 import { summarizeToolOutput } from '../utils/summarizer.js';
+import {
+  RemoteTarget,
+  buildSshArgs,
+  formatRemoteTarget,
+} from '../utils/remoteTarget.js';
+import {
+  ContainerExecutionSettings,
+  buildContainerRunArgs,
+  getContainerEngine,
+  newContainerName,
+  runsInContainer,
+} from '../utils/containerExecution.js';
 
 const OUTPUT_UPDATE_INTERVAL_MS = 1000;
 
+type ExecutionTarget =
+  | { kind: 'local' }
+  | { kind: 'remote'; remote: RemoteTarget; label: string }
+  | {
+      kind: 'container';
+      container: ContainerExecutionSettings;
+      label: string;
+    };
+
 export class ShellTool extends BaseTool<ShellToolParams, ToolResult> {
   static Name: string = 'run_shell_command';
   private whitelist: Set<string> = new Set();
@@ -77,6 +99,42 @@ Process Group PGID: Process group started or \`(none)\``,
     );
   }
 
+  /**
+   * Where commands run: a container if the shell tool is selected for
+   * container execution, else the configured SSH host, else locally.
+   */
+  private getExecutionTarget(): ExecutionTarget {
+    const container = this.config.getContainerExecution();
+    if (runsInContainer(container, ShellTool.Name)) {
+      return {
+        kind: 'container',
+        container,
+        label: `container ${container.image}`,
+      };
+    }
+    const remote = this.config.getRemoteTarget();
+    if (remote) {
+      return { kind: 'remote', remote, label: formatRemoteTarget(remote) };
+    }
+    return { kind: 'local' };
+  }
+
+  get schema(): FunctionDeclaration {
+    const schema = super.schema;
+    const target = this.getExecutionTarget();
+    // Tell the model where commands actually run.
+    let location: string;
+    if (target.kind === 'remote') {
+      const cwd = target.remote.cwd ? ` in \`${target.remote.cwd}\`` : '';
+      location = `Commands run on the remote host \`${target.label}\` over SSH${cwd}, not on the user's machine. Local files are not visible there; the file tools read, search and write the files of the remote host too.`;
+    } else if (target.kind === 'container') {
+      location = `Commands run in a disposable \`${target.container.image}\` container with the project directory mounted${target.container.network ? '' : ' and no network access'}; nothing outside the project directory persists between commands.`;
+    } else {
+      return schema;
+    }
+    return { ...schema, description: `${location}\n\n${schema.description}` };
+  }
+
   getDescription(params: ShellToolParams): string {
     let description = `${params.command}`;
     // append optional [in directory]
@@ -84,6 +142,10 @@ Process Group PGID: Process group started or \`(none)\``,
     if (params.directory) {
       description += ` [in ${params.directory}]`;
     }
+    const target = this.getExecutionTarget();
+    if (target.kind !== 'local') {
+      description += ` [on ${target.label}]`;
+    }
     // append optional (description), replacing any line breaks with spaces
     if (params.description) {
       description += ` (${params.description.replace(/\n/g, ' ')})`;
@@ -239,6 +301,10 @@ Process Group PGID: Process group started or \`(none)\``,
       if (path.isAbsolute(params.directory)) {
         return 'Directory cannot be absolute. Must be relative to the project root directory.';
       }
+      if (this.getExecutionTarget().kind === 'remote') {
+        // The directory lives on the remote host; ssh reports it if missing.
+        return null;
+      }
       const directory = path.resolve(
         this.config.getTargetDir(),
         params.directory,
@@ -261,9 +327,13 @@ Process Group PGID: Process group started or \`(none)\``,
     if (this.whitelist.has(rootCommand)) {
       return false; // already approved and whitelisted
     }
+    const target = this.getExecutionTarget();
     const confirmationDetails: ToolExecuteConfirmationDetails = {
       type: 'exec',
-      title: 'Confirm Shell Command',
+      title:
+        target.kind === 'local'
+          ? 'Confirm Shell Command'
+          : `Confirm Shell Command on ${target.label}`,
       command: params.command,
       rootCommand,
       onConfirm: async (outcome: ToolConfirmationOutcome) => {
@@ -275,6 +345,47 @@ Process Group PGID: Process group started or \`(none)\``,
     return confirmationDetails;
   }
 
+  private spawnShell(
+    target: ExecutionTarget,
+    command: string,
+    directory: string | undefined,
+    containerName: string,
+  ): ChildProcessByStdio<null, Readable, Readable> {
+    if (target.kind === 'remote') {
+      return spawn('ssh', buildSshArgs(target.remote, command, directory), {
+        stdio: ['ignore', 'pipe', 'pipe'],
+        detached: os.platform() !== 'win32',
+      });
+    }
+    if (target.kind === 'container') {
+      const args = buildContainerRunArgs(
+        target.container,
+        ['bash', '-c', command],
+        {
+          name: containerName,
+          projectDir: this.config.getTargetDir(),
+          directory,
+        },
+      );
+      return spawn(getContainerEngine(target.container), args, {
+        stdio: ['ignore', 'pipe', 'pipe'],
+        detached: os.platform() !== 'win32',
+      });
+    }
+    const cwd = path.resolve(this.config.getTargetDir(), directory || '');
+    return os.platform() === 'win32'
+      ? spawn('cmd.exe', ['/c', command], {
+          stdio: ['ignore', 'pipe', 'pipe'],
+          // detached: true, // ensure subprocess starts its own process group (esp. in Linux)
+          cwd,
+        })
+      : spawn('bash', ['-c', command], {
+          stdio: ['ignore', 'pipe', 'pipe'],
+          detached: true, // ensure subprocess starts its own process group (esp. in Linux)
+          cwd,
+        });
+  }
+
   async execute(
     params: ShellToolParams,
     abortSignal: AbortSignal,
@@ -298,34 +409,35 @@ Process Group PGID: Process group started or \`(none)\``,
       };
     }
 
-    const isWindows = os.platform() === 'win32';
+    const target = this.getExecutionTarget();
+    const isLocal = target.kind === 'local';
+    const isWindows = os.platform() === 'win32' && isLocal;
     const tempFileName = `shell_pgrep_${crypto
       .randomBytes(6)
       .toString('hex')}.tmp`;
     const tempFilePath = path.join(os.tmpdir(), tempFileName);
 
-    // pgrep is not available on Windows, so we can't get background PIDs
-    const command = isWindows
-      ? params.command
-      : (() => {
-          // wrap command to append subprocess pids (via pgrep) to temporary file
-          let command = params.command.trim();
-          if (!command.endsWith('&')) command += ';';
-          return `{ ${command} }; __code=$?; pgrep -g 0 >${tempFilePath} 2>&1; exit $__code;`;
-        })();
+    // pgrep is not available on Windows, and on a remote host or in a
+    // container the pid file would not be local, so background PIDs are
+    // only tracked for local commands
+    const command =
+      isWindows || !isLocal
+        ? params.command
+        : (() => {
+            // wrap command to append subprocess pids (via pgrep) to temporary file
+            let command = params.command.trim();
+            if (!command.endsWith('&')) command += ';';
+            return `{ ${command} }; __code=$?; pgrep -g 0 >${tempFilePath} 2>&1; exit $__code;`;
+          })();
 
     // spawn command in specified directory (or project root if not specified)
-    const shell = isWindows
-      ? spawn('cmd.exe', ['/c', command], {
-          stdio: ['ignore', 'pipe', 'pipe'],
-          // detached: true, // ensure subprocess starts its own process group (esp. in Linux)
-          cwd: path.resolve(this.config.getTargetDir(), params.directory || ''),
-        })
-      : spawn('bash', ['-c', command], {
-          stdio: ['ignore', 'pipe', 'pipe'],
-          detached: true, // ensure subprocess starts its own process group (esp. in Linux)
-          cwd: path.resolve(this.config.getTargetDir(), params.directory || ''),
-        });
+    const containerName = newContainerName();
+    const shell = this.spawnShell(
+      target,
+      command,
+      params.directory,
+      containerName,
+    );
 
     let exited = false;
     let stdout = '';
@@ -383,6 +495,14 @@ Process Group PGID: Process group started or \`(none)\``,
     shell.on('exit', exitHandler);
 
     const abortHandler = async () => {
+      if (target.kind === 'container' && !exited) {
+        // killing the client does not always stop the container itself
+        spawn(
+          getContainerEngine(target.container),
+          ['rm', '-f', containerName],
+          { stdio: 'ignore' },
+        ).on('error', () => {});
+      }
       if (shell.pid && !exited) {
         if (os.platform() === 'win32') {
           // For Windows, use taskkill to kill the process tree
@@ -420,7 +540,7 @@ Process Group PGID: Process group started or \`(none)\``,
 
     // parse pids (pgrep output) from temporary file and remove it
     const backgroundPIDs: number[] = [];
-    if (os.platform() !== 'win32') {
+    if (os.platform() !== 'win32' && isLocal) {
       if (fs.existsSync(tempFilePath)) {
         const pgrepLines = fs
           .readFileSync(tempFilePath, 'utf8')
@@ -455,6 +575,7 @@ Process Group PGID: Process group started or \`(none)\``,
     } else {
       llmContent = [
         `Command: ${params.command}`,
+        ...(target.kind !== 'local' ? [`Host: ${target.label}`] : []),
         `Directory: ${params.directory || '(root)'}`,
         `Stdout: ${stdout || '(empty)'}`,
         `Stderr: ${stderr || '(empty)'}`,
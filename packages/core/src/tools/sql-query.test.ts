@@ -0,0 +1,77 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, vi, beforeEach } from 'vitest';
+import { spawn } from 'child_process';
+import { Config } from '../config/config.js';
+import { SqlQueryTool } from './sql-query.js';
+
+vi.mock('child_process', () => ({ spawn: vi.fn() }));
+
+function createTool(): SqlQueryTool {
+  return new SqlQueryTool({
+    getSqlDatabases: () => ({
+      trials: { url: 'postgres://reader:pw@db/trials' },
+      shop: { url: 'mysql://reader@db/shop' },
+      local: { url: 'sqlite:results.db' },
+    }),
+    getTargetDir: () => '/project',
+  } as unknown as Config);
+}
+
+describe('SqlQueryTool', () => {
+  beforeEach(() => {
+    vi.mocked(spawn).mockReset();
+  });
+
+  it.each([
+    ['trials', "SELECT '--'; DELETE FROM t"],
+    ['trials', "SELECT '--'; SET default_transaction_read_only = off"],
+    ['trials', 'SELECT $$ -- $$; DELETE FROM t'],
+    ['shop', "SELECT '--'; COMMIT; DELETE FROM t"],
+    ['shop', "SELECT 1 #'\n; COMMIT; DELETE FROM t; -- '"],
+    ['shop', 'SELECT 1 /*! ; COMMIT */'],
+    ['local', "SELECT writefile('/tmp/x', 'y')"],
+    ['local', "SELECT load_extension('evil')"],
+    ['local', "SELECT [a'] ; DELETE FROM t; SELECT [']"],
+  ])(
+    'should refuse %s query %j without running it',
+    async (database, query) => {
+      const tool = createTool();
+      expect(tool.validateToolParams({ database, query })).not.toBeNull();
+      expect(
+        await tool.shouldConfirmExecute(
+          { database, query },
+          new AbortController().signal,
+        ),
+      ).toBe(false);
+      const result = await tool.execute(
+        { database, query },
+        new AbortController().signal,
+      );
+      expect(result.returnDisplay).toMatch(/^Error: /);
+      expect(spawn).not.toHaveBeenCalled();
+    },
+  );
+
+  it('should ask before running a read-only query', async () => {
+    const tool = createTool();
+    const params = {
+      database: 'trials',
+      query: "SELECT note FROM t WHERE note = 'a; b'",
+    };
+    expect(tool.validateToolParams(params)).toBeNull();
+    expect(
+      await tool.shouldConfirmExecute(params, new AbortController().signal),
+    ).toMatchObject({ type: 'exec', rootCommand: 'trials' });
+  });
+
+  it('should refuse unknown databases', () => {
+    expect(
+      createTool().validateToolParams({ database: 'prod', query: 'SELECT 1' }),
+    ).toContain('Unknown database "prod"');
+  });
+});
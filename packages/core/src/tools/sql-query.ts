@@ -0,0 +1,270 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { spawn } from 'child_process';
+import { Type } from '@google/genai';
+import { Config } from '../config/config.js';
+import {
+  BaseTool,
+  ToolResult,
+  ToolCallConfirmationDetails,
+  ToolConfirmationOutcome,
+  ToolExecuteConfirmationDetails,
+} from './tools.js';
+import { SchemaValidator } from '../utils/schemaValidator.js';
+import { getErrorMessage, isNodeError } from '../utils/errors.js';
+import { formatMarkdownTable } from '../utils/tabularData.js';
+import {
+  SQL_STATEMENT_TIMEOUT_MS,
+  SqlCommand,
+  SqlEngine,
+  buildSqlCommand,
+  checkReadOnlyQuery,
+  getSqlEngine,
+  parseSqlOutput,
+} from '../utils/sqlDatabase.js';
+
+const DEFAULT_MAX_ROWS = 100;
+const MAX_ROWS = 1000;
+// Output past this is dropped and the client stopped; the rows that did
+// arrive are still shown.
+const MAX_OUTPUT_BYTES = 4 * 1024 * 1024;
+
+/**
+ * Parameters for the SqlQuery tool
+ */
+export interface SqlQueryToolParams {
+  /**
+   * The configured database to query
+   */
+  database: string;
+
+  /**
+   * A single read-only SQL statement
+   */
+  query: string;
+
+  /**
+   * Maximum number of rows to return
+   */
+  max_rows?: number;
+}
+
+interface ClientOutput {
+  stdout: string;
+  stderr: string;
+  exitCode: number | null;
+  truncated: boolean;
+}
+
+/**
+ * Runs approved read-only queries against the databases configured in the
+ * `databases` setting, using the psql, mysql or sqlite3 client.
+ */
+export class SqlQueryTool extends BaseTool<SqlQueryToolParams, ToolResult> {
+  static readonly Name: string = 'sql_query';
+  private whitelist: Set<string> = new Set();
+
+  constructor(private readonly config: Config) {
+    const databases = Object.entries(config.getSqlDatabases())
+      .map(
+        ([name, database]) =>
+          `- ${name}${database.description ? `: ${database.description}` : ''}`,
+      )
+      .join('\n');
+    super(
+      SqlQueryTool.Name,
+      'SqlQuery',
+      `Runs one read-only SQL statement (SELECT, WITH, EXPLAIN, SHOW, DESCRIBE or PRAGMA) against a configured database and returns the result as a table. Writes are refused. The user approves each query. Inspect the schema first if unsure of table names (e.g. information_schema, or sqlite_master for SQLite).
+
+Databases:
+${databases}`,
+      {
+        properties: {
+          database: {
+            description: 'The name of the database to query.',
+            type: Type.STRING,
+            enum: Object.keys(config.getSqlDatabases()),
+          },
+          query: {
+            description: 'A single read-only SQL statement.',
+            type: Type.STRING,
+          },
+          max_rows: {
+            description: `Maximum number of rows to return (default ${DEFAULT_MAX_ROWS}, at most ${MAX_ROWS}). Prefer aggregating in SQL over fetching many rows.`,
+            type: Type.NUMBER,
+          },
+        },
+        required: ['database', 'query'],
+        type: Type.OBJECT,
+      },
+    );
+  }
+
+  validateToolParams(params: SqlQueryToolParams): string | null {
+    const errors = SchemaValidator.validate(this.schema.parameters, params);
+    if (errors) {
+      return errors;
+    }
+    const databases = this.config.getSqlDatabases();
+    if (!databases[params.database]) {
+      return `Unknown database "${params.database}". Configured databases: ${Object.keys(databases).join(', ')}`;
+    }
+    let engine: SqlEngine;
+    try {
+      engine = getSqlEngine(databases[params.database].url);
+    } catch (error) {
+      return getErrorMessage(error);
+    }
+    return checkReadOnlyQuery(params.query, engine);
+  }
+
+  getDescription(params: SqlQueryToolParams): string {
+    return `${params.query} [on ${params.database}]`;
+  }
+
+  async shouldConfirmExecute(
+    params: SqlQueryToolParams,
+    _abortSignal: AbortSignal,
+  ): Promise<ToolCallConfirmationDetails | false> {
+    if (this.validateToolParams(params)) {
+      return false; // execute reports the error
+    }
+    if (this.whitelist.has(params.database)) {
+      return false;
+    }
+    const confirmationDetails: ToolExecuteConfirmationDetails = {
+      type: 'exec',
+      title: `Confirm SQL Query on ${params.database}`,
+      command: params.query,
+      rootCommand: params.database,
+      onConfirm: async (outcome: ToolConfirmationOutcome) => {
+        if (outcome === ToolConfirmationOutcome.ProceedAlways) {
+          this.whitelist.add(params.database);
+        }
+      },
+    };
+    return confirmationDetails;
+  }
+
+  private runClient(
+    sqlCommand: SqlCommand,
+    signal: AbortSignal,
+  ): Promise<ClientOutput> {
+    return new Promise((resolve, reject) => {
+      const child = spawn(sqlCommand.command, sqlCommand.args, {
+        stdio: ['ignore', 'pipe', 'pipe'],
+        env: { ...process.env, ...sqlCommand.env },
+      });
+      let stdout = '';
+      let stderr = '';
+      let truncated = false;
+      const stop = () => child.kill();
+      // The client enforces the statement timeout where it can; this also
+      // covers connecting to a host that never answers.
+      const timer = setTimeout(stop, SQL_STATEMENT_TIMEOUT_MS + 5000);
+      signal.addEventListener('abort', stop);
+
+      child.stdout.on('data', (data: Buffer) => {
+        if (stdout.length + data.length > MAX_OUTPUT_BYTES) {
+          truncated = true;
+          const chunk = data.toString();
+          stdout += chunk.slice(0, chunk.lastIndexOf('\n') + 1);
+          stop();
+          return;
+        }
+        stdout += data.toString();
+      });
+      child.stderr.on('data', (data: Buffer) => {
+        stderr += data.toString();
+      });
+      child.on('error', (error) => {
+        clearTimeout(timer);
+        signal.removeEventListener('abort', stop);
+        reject(error);
+      });
+      child.on('close', (exitCode) => {
+        clearTimeout(timer);
+        signal.removeEventListener('abort', stop);
+        resolve({ stdout, stderr, exitCode, truncated });
+      });
+    });
+  }
+
+  async execute(
+    params: SqlQueryToolParams,
+    signal: AbortSignal,
+  ): Promise<ToolResult> {
+    const validationError = this.validateToolParams(params);
+    if (validationError) {
+      return {
+        llmContent: `Error: Invalid parameters provided. Reason: ${validationError}`,
+        returnDisplay: `Error: ${validationError}`,
+      };
+    }
+
+    const database = this.config.getSqlDatabases()[params.database];
+    let output: ClientOutput;
+    let sqlCommand: SqlCommand;
+    try {
+      sqlCommand = buildSqlCommand(
+        database,
+        params.query,
+        this.config.getTargetDir(),
+      );
+      output = await this.runClient(sqlCommand, signal);
+    } catch (error) {
+      const message =
+        isNodeError(error) && error.code === 'ENOENT'
+          ? `The database client is not installed or not on the PATH. Set "command" for database "${params.database}" to its location.`
+          : getErrorMessage(error);
+      return {
+        llmContent: `Error querying ${params.database}: ${message}`,
+        returnDisplay: `Error: ${message}`,
+      };
+    }
+
+    if (signal.aborted) {
+      return {
+        llmContent: 'Query was cancelled by user before it could complete.',
+        returnDisplay: 'Query cancelled.',
+      };
+    }
+    if (output.exitCode !== 0 && !output.truncated) {
+      const message =
+        output.stderr.trim() ||
+        (output.exitCode === null
+          ? 'The query timed out.'
+          : `The client exited with code ${output.exitCode}.`);
+      return {
+        llmContent: `Error querying ${params.database}: ${message}`,
+        returnDisplay: `Error: ${message}`,
+      };
+    }
+
+    const table = parseSqlOutput(output.stdout, sqlCommand.format);
+    const maxRows = Math.min(
+      Math.max(1, Math.floor(params.max_rows ?? DEFAULT_MAX_ROWS)),
+      MAX_ROWS,
+    );
+    const rows = table.rows
+      .slice(0, maxRows)
+      .map((row) => table.columns.map((column) => row[column]));
+    const more = output.truncated || table.rows.length > maxRows;
+    const summary =
+      table.columns.length === 0
+        ? 'The query returned no result set.'
+        : `${rows.length} row${rows.length === 1 ? '' : 's'}${more ? `, more were dropped (raise max_rows or aggregate in SQL)` : ''}.`;
+    const result =
+      table.columns.length === 0
+        ? summary
+        : `${formatMarkdownTable(table.columns, rows)}\n\n${summary}`;
+    return {
+      llmContent: result,
+      returnDisplay: result,
+    };
+  }
+}
@@ -12,6 +12,12 @@ import { StringDecoder } from 'node:string_decoder';
 import { discoverMcpTools } from './mcp-client.js';
 import { DiscoveredMCPTool } from './mcp-tool.js';
 import { parse } from 'shell-quote';
+import {
+  buildContainerRunArgs,
+  getContainerEngine,
+  newContainerName,
+  runsInContainer,
+} from '../utils/containerExecution.js';
 
 type ToolParams = Record<string, unknown>;
 
@@ -52,7 +58,17 @@ Signal: Signal number or \`(none)\` if no signal was received.
 
   async execute(params: ToolParams): Promise<ToolResult> {
     const callCommand = this.config.getToolCallCommand()!;
-    const child = spawn(callCommand, [this.name]);
+    const container = this.config.getContainerExecution();
+    const child = runsInContainer(container, this.name)
+      ? spawn(
+          getContainerEngine(container),
+          buildContainerRunArgs(container, [callCommand, this.name], {
+            name: newContainerName(),
+            projectDir: this.config.getTargetDir(),
+            interactive: true,
+          }),
+        )
+      : spawn(callCommand, [this.name]);
     child.stdin.write(JSON.stringify(params));
     child.stdin.end();
 
@@ -145,6 +161,14 @@ export class ToolRegistry {
     this.tools.set(tool.name, tool);
   }
 
+  /**
+   * Removes a tool, e.g. one registered for the session that is replaced.
+   * @returns Whether a tool with that name was registered.
+   */
+  unregisterTool(name: string): boolean {
+    return this.tools.delete(name);
+  }
+
   /**
    * Discovers tools from project (if available and configured).
    * Can be called multiple times to update discovered tools.
@@ -26,7 +26,13 @@ import {
 } from '../utils/editCorrector.js';
 import { DEFAULT_DIFF_OPTIONS } from './diffOptions.js';
 import { ModifiableTool, ModifyContext } from './modifiable-tool.js';
-import { getSpecificMimeType } from '../utils/fileUtils.js';
+import { getSpecificMimeType, isWithinRoot } from '../utils/fileUtils.js';
+import {
+  readRemoteFile,
+  validateRemotePath,
+  writeRemoteFile,
+} from '../utils/remoteFiles.js';
+import { formatRemoteTarget, RemoteTarget } from '../utils/remoteTarget.js';
 import {
   recordFileOperationMetric,
   FileOperation,
@@ -68,11 +74,17 @@ export class WriteFileTool
 {
   static readonly Name: string = 'write_file';
 
-  constructor(private readonly config: Config) {
+  /**
+   * @param remote SSH host whose files are written instead of local ones.
+   */
+  constructor(
+    private readonly config: Config,
+    private readonly remote?: RemoteTarget,
+  ) {
     super(
       WriteFileTool.Name,
       'WriteFile',
-      `Writes content to a specified file in the local filesystem. 
+      `Writes content to a specified file ${remote ? `on the remote host ${formatRemoteTarget(remote)}, where shell commands run` : 'in the local filesystem'}. 
       
       The user has the ability to modify \`content\`. If modified, this will be stated in the response.`,
       {
@@ -101,15 +113,14 @@ export class WriteFileTool
    * @returns True if the path is within the root directory, false otherwise
    */
   private isWithinRoot(pathToCheck: string): boolean {
-    const normalizedPath = path.normalize(pathToCheck);
-    const normalizedRoot = path.normalize(this.config.getTargetDir());
-    const rootWithSep = normalizedRoot.endsWith(path.sep)
-      ? normalizedRoot
-      : normalizedRoot + path.sep;
-    return (
-      normalizedPath === normalizedRoot ||
-      normalizedPath.startsWith(rootWithSep)
-    );
+    return isWithinRoot(pathToCheck, this.config.getTargetDir());
+  }
+
+  /** The path as shown in descriptions and approvals. */
+  private displayPath(filePath: string): string {
+    return this.remote
+      ? `${formatRemoteTarget(this.remote)}:${filePath}`
+      : makeRelative(filePath, this.config.getTargetDir());
   }
 
   validateToolParams(params: WriteFileToolParams): string | null {
@@ -119,6 +130,10 @@ export class WriteFileTool
     }
 
     const filePath = params.file_path;
+    if (this.remote) {
+      // The remote write reports a directory or a missing permission
+      return validateRemotePath(this.remote, filePath);
+    }
     if (!path.isAbsolute(filePath)) {
       return `File path must be absolute: ${filePath}`;
     }
@@ -148,11 +163,7 @@ export class WriteFileTool
     if (!params.file_path || !params.content) {
       return `Model did not provide valid parameters for write file tool`;
     }
-    const relativePath = makeRelative(
-      params.file_path,
-      this.config.getTargetDir(),
-    );
-    return `Writing to ${shortenPath(relativePath)}`;
+    return `Writing to ${shortenPath(this.displayPath(params.file_path))}`;
   }
 
   /**
@@ -183,10 +194,7 @@ export class WriteFileTool
     }
 
     const { originalContent, correctedContent } = correctedContentResult;
-    const relativePath = makeRelative(
-      params.file_path,
-      this.config.getTargetDir(),
-    );
+    const relativePath = this.displayPath(params.file_path);
     const fileName = path.basename(params.file_path);
 
     const fileDiff = Diff.createPatch(
@@ -252,12 +260,21 @@ export class WriteFileTool
         !correctedContentResult.fileExists);
 
     try {
-      const dirName = path.dirname(params.file_path);
-      if (!fs.existsSync(dirName)) {
-        fs.mkdirSync(dirName, { recursive: true });
-      }
+      if (this.remote) {
+        await writeRemoteFile(
+          this.remote,
+          params.file_path,
+          fileContent,
+          abortSignal,
+        );
+      } else {
+        const dirName = path.dirname(params.file_path);
+        if (!fs.existsSync(dirName)) {
+          fs.mkdirSync(dirName, { recursive: true });
+        }
 
-      fs.writeFileSync(params.file_path, fileContent, 'utf8');
+        fs.writeFileSync(params.file_path, fileContent, 'utf8');
+      }
 
       // Generate diff for display result
       const fileName = path.basename(params.file_path);
@@ -334,8 +351,18 @@ export class WriteFileTool
     let correctedContent = proposedContent;
 
     try {
-      originalContent = fs.readFileSync(filePath, 'utf8');
-      fileExists = true; // File exists and was read
+      if (this.remote) {
+        const content = await readRemoteFile(
+          this.remote,
+          filePath,
+          abortSignal,
+        );
+        fileExists = content !== undefined;
+        originalContent = content ?? '';
+      } else {
+        originalContent = fs.readFileSync(filePath, 'utf8');
+        fileExists = true; // File exists and was read
+      }
     } catch (err) {
       if (isNodeError(err) && err.code === 'ENOENT') {
         fileExists = false;
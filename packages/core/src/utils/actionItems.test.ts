@@ -0,0 +1,153 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect } from 'vitest';
+import {
+  ActionItem,
+  createActionItemId,
+  formatTaskwarrior,
+  formatTodoTxt,
+  getActionItemUuid,
+  mergeActionItems,
+  parseTaskwarrior,
+  parseTodoTxt,
+  syncCompletedItems,
+} from './actionItems.js';
+
+const item = (text: string, extra: Partial<ActionItem> = {}): ActionItem => ({
+  id: createActionItemId(text),
+  text,
+  done: false,
+  created: '2025-06-01',
+  ...extra,
+});
+
+const ablation = item('Rerun the ablation with seed 3', {
+  due: '2025-06-06',
+  priority: 'A',
+});
+const figure = item('Redraw figure 2 in colour');
+const email = item('Send the results to Ada', {
+  done: true,
+  completed: '2025-06-02',
+});
+
+describe('formatTodoTxt and parseTodoTxt', () => {
+  it('should write todo.txt lines with the project and item ids', () => {
+    expect(formatTodoTxt([ablation, figure, email], 'atlas paper')).toBe(
+      [
+        `(A) 2025-06-01 Rerun the ablation with seed 3 +atlas-paper due:2025-06-06 rid:${ablation.id}`,
+        `2025-06-01 Redraw figure 2 in colour +atlas-paper rid:${figure.id}`,
+        `x 2025-06-02 2025-06-01 Send the results to Ada +atlas-paper rid:${email.id}`,
+        '',
+      ].join('\n'),
+    );
+  });
+
+  it('should read items ticked off in a todo.txt app', () => {
+    const text = [
+      `x 2025-06-05 2025-06-01 Rerun the ablation with seed 3 +atlas due:2025-06-06 rid:${ablation.id}`,
+      '(B) Redraw figure 2 in colour @desk',
+      'x Added by hand',
+      '',
+    ].join('\n');
+
+    expect(parseTodoTxt(text)).toEqual([
+      {
+        id: ablation.id,
+        text: 'Rerun the ablation with seed 3',
+        done: true,
+        completed: '2025-06-05',
+      },
+      {
+        id: undefined,
+        text: 'Redraw figure 2 in colour',
+        done: false,
+        completed: undefined,
+      },
+      { id: undefined, text: 'Added by hand', done: true, completed: undefined },
+    ]);
+  });
+});
+
+describe('formatTaskwarrior and parseTaskwarrior', () => {
+  it('should write tasks with stable UUIDs and Taskwarrior fields', () => {
+    const [task, done] = JSON.parse(
+      formatTaskwarrior([ablation, email], 'atlas'),
+    );
+
+    expect(task).toEqual({
+      uuid: getActionItemUuid(ablation.id),
+      description: 'Rerun the ablation with seed 3',
+      status: 'pending',
+      entry: '20250601T000000Z',
+      due: '20250606T000000Z',
+      priority: 'H',
+      project: 'atlas',
+      tags: ['research'],
+    });
+    expect(done).toMatchObject({
+      status: 'completed',
+      end: '20250602T000000Z',
+    });
+    expect(task.uuid).toMatch(
+      /^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$/,
+    );
+  });
+
+  it('should match exported tasks back to items by UUID', () => {
+    const exported = JSON.stringify([
+      {
+        uuid: getActionItemUuid(ablation.id),
+        description: 'Rerun ablation (seed 3)',
+        status: 'completed',
+        end: '20250605T101500Z',
+      },
+      { uuid: 'other', description: 'Unrelated', status: 'pending' },
+    ]);
+
+    expect(parseTaskwarrior(exported, [ablation, figure])).toEqual([
+      {
+        id: ablation.id,
+        text: 'Rerun ablation (seed 3)',
+        done: true,
+        completed: '2025-06-05',
+      },
+      { id: undefined, text: 'Unrelated', done: false, completed: undefined },
+    ]);
+  });
+});
+
+describe('mergeActionItems and syncCompletedItems', () => {
+  it('should keep the state of items extracted again', () => {
+    const { items, added } = mergeActionItems(
+      [email],
+      [item('Send the results to  ada'), figure],
+    );
+
+    expect(added).toBe(1);
+    expect(items).toEqual([email, figure]);
+  });
+
+  it('should complete items matched by id or text', () => {
+    const { items, completed } = syncCompletedItems(
+      [ablation, figure, email],
+      [
+        { id: ablation.id, text: '', done: true, completed: '2025-06-05' },
+        { text: 'redraw figure 2 in colour', done: true },
+        { id: email.id, text: '', done: true, completed: '2025-06-09' },
+      ],
+      '2025-06-07',
+    );
+
+    expect(completed).toBe(2);
+    expect(items.map((i) => [i.done, i.completed])).toEqual([
+      [true, '2025-06-05'],
+      [true, '2025-06-07'],
+      [true, '2025-06-02'],
+    ]);
+  });
+});
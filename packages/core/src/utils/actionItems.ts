@@ -0,0 +1,253 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import * as crypto from 'node:crypto';
+import { RESEARCH_DIR } from './paths.js';
+import { isNodeError } from './errors.js';
+
+const ACTION_ITEMS_FILE = 'action-items.json';
+
+export type ActionItemPriority = 'A' | 'B' | 'C';
+
+export interface ActionItem {
+  id: string;
+  text: string;
+  done: boolean;
+  /** YYYY-MM-DD the item was extracted. */
+  created: string;
+  /** YYYY-MM-DD the item was done. */
+  completed?: string;
+  /** YYYY-MM-DD the item is due. */
+  due?: string;
+  priority?: ActionItemPriority;
+}
+
+/** An item read back from a task manager, matched to ours by id or text. */
+export interface ImportedItem {
+  id?: string;
+  text: string;
+  done: boolean;
+  completed?: string;
+}
+
+export function getActionItemsPath(projectRoot: string): string {
+  return path.join(projectRoot, RESEARCH_DIR, ACTION_ITEMS_FILE);
+}
+
+export function createActionItemId(text: string): string {
+  return crypto
+    .createHash('sha256')
+    .update(normalizeText(text))
+    .digest('hex')
+    .slice(0, 8);
+}
+
+function normalizeText(text: string): string {
+  return text.toLowerCase().replace(/\s+/g, ' ').trim();
+}
+
+export async function loadActionItems(
+  projectRoot: string,
+): Promise<ActionItem[]> {
+  try {
+    const data = JSON.parse(
+      await fs.promises.readFile(getActionItemsPath(projectRoot), 'utf8'),
+    );
+    return Array.isArray(data) ? data : [];
+  } catch (error) {
+    if (isNodeError(error) && error.code === 'ENOENT') {
+      return [];
+    }
+    throw error;
+  }
+}
+
+export async function saveActionItems(
+  projectRoot: string,
+  items: ActionItem[],
+): Promise<void> {
+  const filePath = getActionItemsPath(projectRoot);
+  await fs.promises.mkdir(path.dirname(filePath), { recursive: true });
+  await fs.promises.writeFile(filePath, JSON.stringify(items, null, 2), 'utf8');
+}
+
+/**
+ * Adds new items to a list. Items with the same text keep their state, so
+ * extracting again does not reopen finished work. Returns the merged list
+ * and how many were new.
+ */
+export function mergeActionItems(
+  existing: ActionItem[],
+  incoming: ActionItem[],
+): { items: ActionItem[]; added: number } {
+  const ids = new Set(existing.map((item) => item.id));
+  const added = incoming.filter((item) => !ids.has(item.id));
+  return { items: [...existing, ...added], added: added.length };
+}
+
+/**
+ * Marks items done that a task manager reports as done. Returns the
+ * updated list and how many items were newly completed.
+ */
+export function syncCompletedItems(
+  items: ActionItem[],
+  imported: ImportedItem[],
+  today: string = new Date().toISOString().slice(0, 10),
+): { items: ActionItem[]; completed: number } {
+  const doneIds = new Map<string, ImportedItem>();
+  for (const item of imported.filter((entry) => entry.done)) {
+    doneIds.set(item.id ?? createActionItemId(item.text), item);
+  }
+  let completed = 0;
+  const updated = items.map((item) => {
+    const match = doneIds.get(item.id);
+    if (!match || item.done) {
+      return item;
+    }
+    completed++;
+    return { ...item, done: true, completed: match.completed ?? today };
+  });
+  return { items: updated, completed };
+}
+
+/**
+ * Renders items in the todo.txt format. Each line carries the project as
+ * `+project` and the item id as `rid:<id>`, which the import matches on.
+ */
+export function formatTodoTxt(items: ActionItem[], project?: string): string {
+  const tag = project && `+${project.replace(/\s+/g, '-')}`;
+  return items
+    .map((item) =>
+      [
+        item.done ? `x ${item.completed ?? item.created}` : undefined,
+        !item.done && item.priority ? `(${item.priority})` : undefined,
+        item.created,
+        item.text.replace(/\s+/g, ' '),
+        tag,
+        item.due ? `due:${item.due}` : undefined,
+        `rid:${item.id}`,
+      ]
+        .filter(Boolean)
+        .join(' '),
+    )
+    .map((line) => `${line}\n`)
+    .join('');
+}
+
+/** Reads todo.txt lines, e.g. after they were ticked off in a todo.txt app. */
+export function parseTodoTxt(text: string): ImportedItem[] {
+  const items: ImportedItem[] = [];
+  for (const rawLine of text.split(/\r?\n/)) {
+    let line = rawLine.trim();
+    if (!line) {
+      continue;
+    }
+    let done = false;
+    let completed: string | undefined;
+    const doneMatch = line.match(/^x\s+(?:(\d{4}-\d{2}-\d{2})\s+)?/);
+    if (doneMatch) {
+      done = true;
+      completed = doneMatch[1];
+      line = line.slice(doneMatch[0].length);
+    }
+    const id = line.match(/(?:^|\s)rid:(\S+)/)?.[1];
+    const description = line
+      .replace(/^\([A-Z]\)\s+/, '')
+      .replace(/^\d{4}-\d{2}-\d{2}\s+/, '')
+      .split(/\s+/)
+      .filter((word) => !/^[+@]\S|^[^\s:]+:[^\s:]+$/.test(word))
+      .join(' ');
+    items.push({ id, text: description, done, completed });
+  }
+  return items;
+}
+
+const TASKWARRIOR_PRIORITIES: Record<ActionItemPriority, string> = {
+  A: 'H',
+  B: 'M',
+  C: 'L',
+};
+
+/** A stable UUID for an item, so re-importing updates the same task. */
+export function getActionItemUuid(id: string): string {
+  const hex = crypto
+    .createHash('sha256')
+    .update(`research-cli:${id}`)
+    .digest('hex');
+  return [
+    hex.slice(0, 8),
+    hex.slice(8, 12),
+    `4${hex.slice(13, 16)}`,
+    `${((parseInt(hex[16], 16) & 0x3) | 0x8).toString(16)}${hex.slice(17, 20)}`,
+    hex.slice(20, 32),
+  ].join('-');
+}
+
+function toTaskwarriorDate(date: string): string {
+  return `${date.replace(/-/g, '')}T000000Z`;
+}
+
+function fromTaskwarriorDate(date: string): string | undefined {
+  const match = date.match(/^(\d{4})(\d{2})(\d{2})T/);
+  return match ? `${match[1]}-${match[2]}-${match[3]}` : undefined;
+}
+
+/** Renders items as the JSON array that `task import` reads. */
+export function formatTaskwarrior(
+  items: ActionItem[],
+  project?: string,
+): string {
+  const tasks = items.map((item) => ({
+    uuid: getActionItemUuid(item.id),
+    description: item.text,
+    status: item.done ? 'completed' : 'pending',
+    entry: toTaskwarriorDate(item.created),
+    ...(item.done
+      ? { end: toTaskwarriorDate(item.completed ?? item.created) }
+      : {}),
+    ...(item.due ? { due: toTaskwarriorDate(item.due) } : {}),
+    ...(item.priority
+      ? { priority: TASKWARRIOR_PRIORITIES[item.priority] }
+      : {}),
+    ...(project ? { project } : {}),
+    tags: ['research'],
+  }));
+  return JSON.stringify(tasks, null, 2) + '\n';
+}
+
+interface TaskwarriorTask {
+  uuid?: string;
+  description?: string;
+  status?: string;
+  end?: string;
+}
+
+/**
+ * Reads the output of `task export`. Tasks are matched to items through
+ * the UUIDs that `formatTaskwarrior` gave them.
+ */
+export function parseTaskwarrior(
+  text: string,
+  items: ActionItem[],
+): ImportedItem[] {
+  const data = JSON.parse(text) as TaskwarriorTask[];
+  if (!Array.isArray(data)) {
+    throw new Error('Expected the JSON array written by `task export`');
+  }
+  const idsByUuid = new Map(
+    items.map((item) => [getActionItemUuid(item.id), item.id]),
+  );
+  return data
+    .filter((task) => task.description)
+    .map((task) => ({
+      id: task.uuid ? idsByUuid.get(task.uuid) : undefined,
+      text: task.description!,
+      done: task.status === 'completed',
+      completed: task.end ? fromTaskwarriorDate(task.end) : undefined,
+    }));
+}
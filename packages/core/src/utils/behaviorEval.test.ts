@@ -0,0 +1,176 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect } from 'vitest';
+import {
+  BehaviorSuiteResult,
+  checkBehaviorTurn,
+  extractJson,
+  formatJUnitReport,
+  parseBehaviorSuite,
+} from './behaviorEval.js';
+
+describe('parseBehaviorSuite', () => {
+  it('should read multi-turn and single-turn cases from YAML', () => {
+    const suite = parseBehaviorSuite(
+      [
+        'name: Citations',
+        'cases:',
+        '  - name: looks up a DOI',
+        '    turns:',
+        '      - prompt: Find the DOI of "Attention Is All You Need"',
+        '        expect:',
+        '          tools: [web_search]',
+        '          contains: "10."',
+        '      - prompt: Now as JSON',
+        '  - prompt: Say hi',
+      ].join('\n'),
+      'suites/citations.yaml',
+    );
+
+    expect(suite).toEqual({
+      name: 'Citations',
+      cases: [
+        {
+          name: 'looks up a DOI',
+          turns: [
+            {
+              prompt: 'Find the DOI of "Attention Is All You Need"',
+              expect: {
+                tools: ['web_search'],
+                contains: ['10.'],
+                notTools: undefined,
+                notContains: undefined,
+                schema: undefined,
+              },
+            },
+            { prompt: 'Now as JSON', expect: {} },
+          ],
+        },
+        { name: 'case 2', turns: [{ prompt: 'Say hi', expect: {} }] },
+      ],
+    });
+  });
+
+  it('should accept anchors and multi-line strings', () => {
+    const suite = parseBehaviorSuite(
+      [
+        'cases:',
+        '  - prompt: >',
+        '      Summarize',
+        '      the abstract',
+        '    expect: &cites',
+        '      contains: [arXiv]',
+        '  - prompt: And the conclusion',
+        '    expect: *cites',
+      ].join('\n'),
+      'suite.yml',
+    );
+
+    expect(suite.cases.map((c) => c.turns[0].prompt)).toEqual([
+      'Summarize the abstract\n',
+      'And the conclusion',
+    ]);
+    expect(suite.cases[1].turns[0].expect?.contains).toEqual(['arXiv']);
+  });
+
+  it('should name the case and turn of a missing prompt', () => {
+    expect(() =>
+      parseBehaviorSuite('{"cases": [{"name": "broken"}]}', 'suite.json'),
+    ).toThrow('broken, turn 1: a "prompt" is required');
+  });
+});
+
+describe('checkBehaviorTurn', () => {
+  const output = {
+    text: 'Here it is:\n```json\n{"doi": "10.48550/arXiv.1706.03762"}\n```',
+    toolCalls: [
+      { name: 'web_search', args: { query: 'attention is all you need doi' } },
+    ],
+  };
+
+  it('should pass when every expectation holds', () => {
+    expect(
+      checkBehaviorTurn(
+        {
+          tools: [{ name: 'web_search', args: { query: 'attention' } }],
+          notTools: ['run_shell_command'],
+          contains: ['10.48550'],
+          schema: {
+            type: 'object',
+            required: ['doi'],
+            properties: { doi: { type: 'string' } },
+          },
+        },
+        output,
+      ),
+    ).toEqual([]);
+  });
+
+  it('should describe each failed expectation', () => {
+    const failures = checkBehaviorTurn(
+      {
+        tools: ['read_file'],
+        notTools: ['web_search'],
+        notContains: ['json'],
+        schema: { type: 'object', required: ['title'] },
+      },
+      output,
+    );
+
+    expect(failures).toEqual([
+      'expected a call to read_file (called: web_search)',
+      'expected no call to web_search',
+      'expected the answer not to contain "json"',
+      expect.stringContaining('the answer does not match the schema'),
+    ]);
+  });
+
+  it('should find JSON in plain or surrounding text', () => {
+    expect(extractJson('[1, 2]')).toEqual([1, 2]);
+    expect(extractJson('The result is {"a": 1}.')).toEqual({ a: 1 });
+    expect(extractJson('no json here')).toBeUndefined();
+  });
+});
+
+describe('formatJUnitReport', () => {
+  it('should report failures and errors per case', () => {
+    const result: BehaviorSuiteResult = {
+      name: 'Citations',
+      file: 'citations.yaml',
+      started: '2025-06-01T10:00:00.000Z',
+      durationMs: 4200,
+      cases: [
+        { name: 'passes', durationMs: 1000, failures: [], turns: [] },
+        {
+          name: 'fails <badly>',
+          durationMs: 1200,
+          failures: ['expected no call to web_search'],
+          turns: [{ text: 'answer & more', toolCalls: [] }],
+        },
+        {
+          name: 'errors',
+          durationMs: 2000,
+          failures: [],
+          error: 'quota exceeded',
+          turns: [],
+        },
+      ],
+    };
+
+    const xml = formatJUnitReport(result);
+
+    expect(xml).toContain(
+      '<testsuites tests="3" failures="1" errors="1" time="4.200">',
+    );
+    expect(xml).toContain(
+      '<testcase classname="Citations" name="passes" time="1.000"/>',
+    );
+    expect(xml).toContain('name="fails &lt;badly&gt;"');
+    expect(xml).toContain('<system-out>answer &amp; more</system-out>');
+    expect(xml).toContain('<error message="quota exceeded"/>');
+  });
+});
@@ -0,0 +1,299 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import * as ajv from 'ajv';
+import { parse as parseYaml } from 'yaml';
+
+const ajValidator = new ajv.Ajv({ allErrors: true, strict: false });
+
+/** A tool call the model is expected to make, by name and optional args. */
+export interface ExpectedToolCall {
+  name: string;
+  /** Each given arg must be equal; string args must be contained. */
+  args?: Record<string, unknown>;
+}
+
+export interface BehaviorExpectation {
+  /** Tools that must be called while answering the turn. */
+  tools?: Array<string | ExpectedToolCall>;
+  /** Tools that must not be called. */
+  notTools?: string[];
+  /** Substrings the final text must contain. */
+  contains?: string[];
+  notContains?: string[];
+  /** JSON Schema the final text, parsed as JSON, must satisfy. */
+  schema?: object;
+}
+
+export interface BehaviorTurn {
+  prompt: string;
+  expect?: BehaviorExpectation;
+}
+
+export interface BehaviorCase {
+  name: string;
+  turns: BehaviorTurn[];
+}
+
+export interface BehaviorSuite {
+  name: string;
+  cases: BehaviorCase[];
+}
+
+export interface RecordedToolCall {
+  name: string;
+  args: Record<string, unknown>;
+}
+
+/** What the agent did in answer to one turn. */
+export interface BehaviorTurnOutput {
+  text: string;
+  toolCalls: RecordedToolCall[];
+}
+
+export interface BehaviorCaseResult {
+  name: string;
+  durationMs: number;
+  failures: string[];
+  /** Set when the case could not run to the end. */
+  error?: string;
+  turns: BehaviorTurnOutput[];
+}
+
+export interface BehaviorSuiteResult {
+  name: string;
+  file: string;
+  started: string;
+  durationMs: number;
+  cases: BehaviorCaseResult[];
+}
+
+function asStringList(value: unknown, field: string): string[] | undefined {
+  if (value === undefined || value === null) {
+    return undefined;
+  }
+  const list = Array.isArray(value) ? value : [value];
+  if (!list.every((item) => typeof item === 'string')) {
+    throw new Error(`"${field}" must be a string or a list of strings`);
+  }
+  return list;
+}
+
+function toExpectation(value: unknown, where: string): BehaviorExpectation {
+  if (value === undefined || value === null) {
+    return {};
+  }
+  if (typeof value !== 'object' || Array.isArray(value)) {
+    throw new Error(`${where}: "expect" must be a mapping`);
+  }
+  const raw = value as Record<string, unknown>;
+  const tools = raw['tools'] === undefined ? undefined : [raw['tools']].flat();
+  if (
+    tools &&
+    !tools.every(
+      (tool) =>
+        typeof tool === 'string' ||
+        typeof (tool as ExpectedToolCall)?.name === 'string',
+    )
+  ) {
+    throw new Error(`${where}: each of "tools" needs a name`);
+  }
+  if (raw['schema'] !== undefined && typeof raw['schema'] !== 'object') {
+    throw new Error(`${where}: "schema" must be a JSON Schema object`);
+  }
+  return {
+    tools: tools as BehaviorExpectation['tools'],
+    notTools: asStringList(raw['notTools'], `${where}: notTools`),
+    contains: asStringList(raw['contains'], `${where}: contains`),
+    notContains: asStringList(raw['notContains'], `${where}: notContains`),
+    schema: raw['schema'] as object | undefined,
+  };
+}
+
+/**
+ * Reads a suite from YAML or JSON. A case has `turns`, each with a `prompt`
+ * and an optional `expect`; a single-turn case may give `prompt` and
+ * `expect` directly.
+ */
+export function parseBehaviorSuite(
+  text: string,
+  fileName: string,
+): BehaviorSuite {
+  const data = (
+    /\.json$/i.test(fileName) ? JSON.parse(text) : parseYaml(text)
+  ) as Record<string, unknown> | null;
+  const rawCases = data?.['cases'];
+  if (!Array.isArray(rawCases) || rawCases.length === 0) {
+    throw new Error('The suite needs a non-empty "cases" list');
+  }
+  const cases = rawCases.map((rawCase, i): BehaviorCase => {
+    const raw = (rawCase ?? {}) as Record<string, unknown>;
+    const name =
+      typeof raw['name'] === 'string' ? raw['name'] : `case ${i + 1}`;
+    const rawTurns = Array.isArray(raw['turns'])
+      ? raw['turns']
+      : [{ prompt: raw['prompt'], expect: raw['expect'] }];
+    const turns = rawTurns.map((rawTurn, j): BehaviorTurn => {
+      const turn = (rawTurn ?? {}) as Record<string, unknown>;
+      const where = `${name}, turn ${j + 1}`;
+      if (typeof turn['prompt'] !== 'string' || !turn['prompt'].trim()) {
+        throw new Error(`${where}: a "prompt" is required`);
+      }
+      return {
+        prompt: turn['prompt'],
+        expect: toExpectation(turn['expect'], where),
+      };
+    });
+    return { name, turns };
+  });
+  return {
+    name:
+      typeof data?.['name'] === 'string'
+        ? data['name']
+        : fileName.replace(/^.*[\\/]/, '').replace(/\.[^.]+$/, ''),
+    cases,
+  };
+}
+
+function argsMatch(
+  expected: Record<string, unknown>,
+  actual: Record<string, unknown>,
+): boolean {
+  return Object.entries(expected).every(([key, value]) =>
+    typeof value === 'string' && typeof actual[key] === 'string'
+      ? (actual[key] as string).includes(value)
+      : JSON.stringify(actual[key]) === JSON.stringify(value),
+  );
+}
+
+/**
+ * The JSON in an answer: the whole text, a fenced block, or the text
+ * between the outermost braces or brackets.
+ */
+export function extractJson(text: string): unknown {
+  const candidates = [
+    text.trim(),
+    text.match(/```(?:json)?\s*\n([\s\S]*?)```/)?.[1],
+    text.slice(text.indexOf('{'), text.lastIndexOf('}') + 1),
+    text.slice(text.indexOf('['), text.lastIndexOf(']') + 1),
+  ];
+  for (const candidate of candidates) {
+    if (!candidate) {
+      continue;
+    }
+    try {
+      return JSON.parse(candidate);
+    } catch {
+      // Try the next candidate
+    }
+  }
+  return undefined;
+}
+
+/** Checks one turn's output against its expectations; returns the failures. */
+export function checkBehaviorTurn(
+  expectation: BehaviorExpectation | undefined,
+  output: BehaviorTurnOutput,
+): string[] {
+  const failures: string[] = [];
+  const called = output.toolCalls.map((call) => call.name);
+  const calledText = called.length > 0 ? called.join(', ') : 'none';
+  for (const tool of expectation?.tools ?? []) {
+    const expected: ExpectedToolCall =
+      typeof tool === 'string' ? { name: tool } : tool;
+    const found = output.toolCalls.some(
+      (call) =>
+        call.name === expected.name &&
+        argsMatch(expected.args ?? {}, call.args),
+    );
+    if (!found) {
+      failures.push(
+        expected.args
+          ? `expected a call to ${expected.name} with ${JSON.stringify(expected.args)} (called: ${calledText})`
+          : `expected a call to ${expected.name} (called: ${calledText})`,
+      );
+    }
+  }
+  for (const tool of expectation?.notTools ?? []) {
+    if (called.includes(tool)) {
+      failures.push(`expected no call to ${tool}`);
+    }
+  }
+  for (const text of expectation?.contains ?? []) {
+    if (!output.text.includes(text)) {
+      failures.push(`expected the answer to contain "${text}"`);
+    }
+  }
+  for (const text of expectation?.notContains ?? []) {
+    if (output.text.includes(text)) {
+      failures.push(`expected the answer not to contain "${text}"`);
+    }
+  }
+  if (expectation?.schema) {
+    const data = extractJson(output.text);
+    if (data === undefined) {
+      failures.push('expected the answer to be JSON');
+    } else {
+      const validate = ajValidator.compile(expectation.schema);
+      if (!validate(data)) {
+        failures.push(
+          `the answer does not match the schema: ${ajValidator.errorsText(validate.errors, { dataVar: 'answer' })}`,
+        );
+      }
+    }
+  }
+  return failures;
+}
+
+export function isBehaviorCasePassed(result: BehaviorCaseResult): boolean {
+  return !result.error && result.failures.length === 0;
+}
+
+function escapeXml(text: string): string {
+  return text
+    .replace(/&/g, '&amp;')
+    .replace(/</g, '&lt;')
+    .replace(/>/g, '&gt;')
+    .replace(/"/g, '&quot;')
+    // Control characters are not allowed in XML 1.0
+    // eslint-disable-next-line no-control-regex
+    .replace(/[\x00-\x08\x0B\x0C\x0E-\x1F]/g, '');
+}
+
+/** Formats the results as a JUnit XML report, one test case per case. */
+export function formatJUnitReport(result: BehaviorSuiteResult): string {
+  const seconds = (ms: number) => (ms / 1000).toFixed(3);
+  const errors = result.cases.filter((c) => c.error).length;
+  const failures =
+    result.cases.filter((c) => !isBehaviorCasePassed(c)).length - errors;
+  const counts = `tests="${result.cases.length}" failures="${failures}" errors="${errors}" time="${seconds(result.durationMs)}"`;
+  const lines = [
+    '<?xml version="1.0" encoding="UTF-8"?>',
+    `<testsuites ${counts}>`,
+    `  <testsuite name="${escapeXml(result.name)}" ${counts} timestamp="${result.started}">`,
+  ];
+  for (const c of result.cases) {
+    const open = `    <testcase classname="${escapeXml(result.name)}" name="${escapeXml(c.name)}" time="${seconds(c.durationMs)}"`;
+    if (c.error) {
+      lines.push(
+        `${open}>`,
+        `      <error message="${escapeXml(c.error)}"/>`,
+        '    </testcase>',
+      );
+    } else if (c.failures.length > 0) {
+      lines.push(
+        `${open}>`,
+        `      <failure message="${escapeXml(c.failures[0])}">${escapeXml(c.failures.join('\n'))}</failure>`,
+        `      <system-out>${escapeXml(c.turns.map((t) => t.text).join('\n---\n'))}</system-out>`,
+        '    </testcase>',
+      );
+    } else {
+      lines.push(`${open}/>`);
+    }
+  }
+  lines.push('  </testsuite>', '</testsuites>', '');
+  return lines.join('\n');
+}
@@ -0,0 +1,135 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import {
+  CalendarEvent,
+  buildIcsEvent,
+  parseEventStart,
+  putCalDavEvent,
+  writeIcsFile,
+} from './calendar.js';
+import { parseIcalDeadlines } from './deadlines.js';
+
+const timed: CalendarEvent = {
+  uid: 'abc-123',
+  title: 'Rerun the ablation; seed 3, batch 64',
+  start: new Date(Date.UTC(2025, 5, 6, 14, 0)),
+  allDay: false,
+  durationMinutes: 60,
+  description: 'python train.py --seed 3\nCompare with run 12',
+};
+
+describe('parseEventStart', () => {
+  it('should read dates as all-day events and date-times as local times', () => {
+    expect(parseEventStart('2025-06-06')).toEqual({
+      start: new Date(2025, 5, 6),
+      allDay: true,
+    });
+    expect(parseEventStart('2025-06-06T9:30')).toEqual({
+      start: new Date(2025, 5, 6, 9, 30),
+      allDay: false,
+    });
+    expect(parseEventStart('Friday')).toBeUndefined();
+    expect(parseEventStart('2025-02-30')).toBeUndefined();
+  });
+});
+
+describe('buildIcsEvent', () => {
+  it('should write an event that reads back as a deadline', () => {
+    const ics = buildIcsEvent(timed, new Date(Date.UTC(2025, 5, 1)));
+
+    expect(ics).toContain('DTSTART:20250606T140000Z\r\n');
+    expect(ics).toContain('DTEND:20250606T150000Z\r\n');
+    expect(ics).toContain('DTSTAMP:20250601T000000Z\r\n');
+    expect(ics).toContain(
+      'SUMMARY:Rerun the ablation\\; seed 3\\, batch 64\r\n',
+    );
+    expect(ics).toContain('TRIGGER:-PT15M\r\n');
+    expect(ics.split('\r\n').every((line) => line.length <= 75)).toBe(true);
+    expect(parseIcalDeadlines(ics)).toMatchObject([
+      {
+        title: timed.title,
+        due: '2025-06-06T14:00:00.000Z',
+        note: timed.description,
+      },
+    ]);
+  });
+
+  it('should end all-day events on the next day', () => {
+    const ics = buildIcsEvent({
+      uid: 'x',
+      title: 'Check the cluster queue',
+      start: new Date(2025, 11, 31),
+      allDay: true,
+    });
+
+    expect(ics).toContain('DTSTART;VALUE=DATE:20251231\r\n');
+    expect(ics).toContain('DTEND;VALUE=DATE:20260101\r\n');
+    expect(ics).toContain('TRIGGER;RELATED=START:PT9H\r\n');
+  });
+});
+
+describe('writeIcsFile and putCalDavEvent', () => {
+  let tempDir: string;
+  const fetchMock = vi.fn(
+    async (_url: string, _init?: RequestInit) =>
+      new Response(null, { status: 201 }),
+  );
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'calendar-'));
+    fetchMock.mockClear();
+    vi.stubGlobal('fetch', fetchMock);
+  });
+
+  afterEach(() => {
+    vi.unstubAllGlobals();
+    vi.unstubAllEnvs();
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should write the event to a file named after its uid', async () => {
+    const filePath = await writeIcsFile(timed, path.join(tempDir, 'cal'));
+
+    expect(filePath).toBe(path.join(tempDir, 'cal', 'abc-123.ics'));
+    expect(fs.readFileSync(filePath, 'utf8')).toContain('UID:abc-123');
+  });
+
+  it('should PUT the event with basic authentication', async () => {
+    vi.stubEnv('RESEARCH_CALDAV_PASSWORD', 'secret');
+
+    const url = await putCalDavEvent(
+      { url: 'https://dav.lab.org/cal/me/research/', user: 'me' },
+      timed,
+    );
+
+    expect(url).toBe('https://dav.lab.org/cal/me/research/abc-123.ics');
+    const [calledUrl, init] = fetchMock.mock.calls[0];
+    expect(calledUrl).toBe(url);
+    expect(init?.method).toBe('PUT');
+    expect((init?.headers as Record<string, string>)['Authorization']).toBe(
+      `Basic ${Buffer.from('me:secret').toString('base64')}`,
+    );
+  });
+
+  it('should report a missing password and server errors', async () => {
+    vi.stubEnv('RESEARCH_CALDAV_PASSWORD', '');
+    await expect(
+      putCalDavEvent({ url: 'https://dav.lab.org/cal', user: 'me' }, timed),
+    ).rejects.toThrow('Set RESEARCH_CALDAV_PASSWORD');
+
+    fetchMock.mockResolvedValueOnce(
+      new Response(null, { status: 403, statusText: 'Forbidden' }),
+    );
+    await expect(
+      putCalDavEvent({ url: 'https://dav.lab.org/cal' }, timed),
+    ).rejects.toThrow('The CalDAV server answered 403 Forbidden');
+  });
+});
@@ -0,0 +1,208 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { randomUUID } from 'node:crypto';
+import { getUserResearchDir } from './paths.js';
+
+const CALDAV_TIMEOUT_MS = 15000;
+const DEFAULT_CALDAV_PASSWORD_ENV = 'RESEARCH_CALDAV_PASSWORD';
+
+export interface CalDavSettings {
+  /** URL of the calendar collection, e.g. ".../calendars/me/research/". */
+  url: string;
+  user?: string;
+  /** Variable holding the password, by default RESEARCH_CALDAV_PASSWORD. */
+  passwordEnv?: string;
+}
+
+export interface CalendarSettings {
+  /** Folder the .ics files are written to; defaults to ~/.research/calendar. */
+  dir?: string;
+  /** CalDAV calendar that events are also added to. */
+  caldav?: CalDavSettings;
+}
+
+export interface CalendarEvent {
+  uid: string;
+  title: string;
+  start: Date;
+  /** All-day events only use the date of `start`. */
+  allDay: boolean;
+  durationMinutes?: number;
+  description?: string;
+}
+
+export function getCalendarDir(settings: CalendarSettings = {}): string {
+  return settings.dir
+    ? path.resolve(settings.dir.replace(/^~(?=$|\/)/, os.homedir()))
+    : path.join(getUserResearchDir(), 'calendar');
+}
+
+/**
+ * Parses `YYYY-MM-DD` (an all-day event) or `YYYY-MM-DDTHH:MM`, in local
+ * time.
+ */
+export function parseEventStart(
+  value: string,
+): { start: Date; allDay: boolean } | undefined {
+  const match = value
+    .trim()
+    .match(/^(\d{4})-(\d{2})-(\d{2})(?:[T ](\d{1,2}):(\d{2}))?$/);
+  if (!match) {
+    return undefined;
+  }
+  const [, y, mo, d, h, mi] = match;
+  const start = new Date(
+    Number(y),
+    Number(mo) - 1,
+    Number(d),
+    Number(h ?? 0),
+    Number(mi ?? 0),
+  );
+  if (isNaN(start.getTime()) || start.getDate() !== Number(d)) {
+    return undefined;
+  }
+  return { start, allDay: h === undefined };
+}
+
+export function createCalendarEvent(
+  title: string,
+  start: string,
+  options: { durationMinutes?: number; description?: string } = {},
+): CalendarEvent | undefined {
+  const parsed = parseEventStart(start);
+  return parsed && { uid: randomUUID(), title, ...parsed, ...options };
+}
+
+function escapeText(value: string): string {
+  return value
+    .replace(/\\/g, '\\\\')
+    .replace(/([,;])/g, '\\$1')
+    .replace(/\r?\n/g, '\\n');
+}
+
+/** Folds a content line to at most 75 octets, as RFC 5545 requires. */
+function foldLine(line: string): string {
+  const parts: string[] = [];
+  let current = '';
+  let size = 0;
+  for (const char of line) {
+    const bytes = Buffer.byteLength(char);
+    if (size + bytes > 75) {
+      parts.push(current);
+      current = ' ';
+      size = 1;
+    }
+    current += char;
+    size += bytes;
+  }
+  parts.push(current);
+  return parts.join('\r\n');
+}
+
+const pad = (n: number) => String(n).padStart(2, '0');
+
+function formatDate(date: Date): string {
+  return `${date.getFullYear()}${pad(date.getMonth() + 1)}${pad(date.getDate())}`;
+}
+
+function formatUtc(date: Date): string {
+  return date.toISOString().replace(/[-:]/g, '').replace(/\.\d{3}/, '');
+}
+
+/**
+ * Renders the event as an iCalendar file with a reminder: 15 minutes
+ * before timed events, at 9:00 on the day of all-day ones.
+ */
+export function buildIcsEvent(
+  event: CalendarEvent,
+  now: Date = new Date(),
+): string {
+  let times: string[];
+  if (event.allDay) {
+    const next = new Date(event.start);
+    next.setDate(next.getDate() + 1);
+    times = [
+      `DTSTART;VALUE=DATE:${formatDate(event.start)}`,
+      `DTEND;VALUE=DATE:${formatDate(next)}`,
+    ];
+  } else {
+    const end = new Date(
+      event.start.getTime() + (event.durationMinutes ?? 30) * 60 * 1000,
+    );
+    times = [`DTSTART:${formatUtc(event.start)}`, `DTEND:${formatUtc(end)}`];
+  }
+  const lines = [
+    'BEGIN:VCALENDAR',
+    'VERSION:2.0',
+    'PRODID:-//iEchor//Research CLI//EN',
+    'BEGIN:VEVENT',
+    `UID:${event.uid}`,
+    `DTSTAMP:${formatUtc(now)}`,
+    ...times,
+    `SUMMARY:${escapeText(event.title)}`,
+    ...(event.description
+      ? [`DESCRIPTION:${escapeText(event.description)}`]
+      : []),
+    'BEGIN:VALARM',
+    'ACTION:DISPLAY',
+    `DESCRIPTION:${escapeText(event.title)}`,
+    event.allDay ? 'TRIGGER;RELATED=START:PT9H' : 'TRIGGER:-PT15M',
+    'END:VALARM',
+    'END:VEVENT',
+    'END:VCALENDAR',
+  ];
+  return lines.map(foldLine).join('\r\n') + '\r\n';
+}
+
+/** Writes the event to `<dir>/<uid>.ics` and returns the path. */
+export async function writeIcsFile(
+  event: CalendarEvent,
+  dir: string,
+): Promise<string> {
+  const filePath = path.join(dir, `${event.uid}.ics`);
+  await fs.promises.mkdir(dir, { recursive: true });
+  await fs.promises.writeFile(filePath, buildIcsEvent(event), 'utf8');
+  return filePath;
+}
+
+/** Adds the event to a CalDAV calendar collection with a PUT. */
+export async function putCalDavEvent(
+  caldav: CalDavSettings,
+  event: CalendarEvent,
+  signal?: AbortSignal,
+): Promise<string> {
+  const url = `${caldav.url.replace(/\/+$/, '')}/${encodeURIComponent(event.uid)}.ics`;
+  const headers: Record<string, string> = {
+    'Content-Type': 'text/calendar; charset=utf-8',
+    'If-None-Match': '*',
+  };
+  if (caldav.user) {
+    const passwordEnv = caldav.passwordEnv ?? DEFAULT_CALDAV_PASSWORD_ENV;
+    const password = process.env[passwordEnv];
+    if (!password) {
+      throw new Error(`Set ${passwordEnv} to the CalDAV password.`);
+    }
+    headers['Authorization'] =
+      `Basic ${Buffer.from(`${caldav.user}:${password}`).toString('base64')}`;
+  }
+  const timeout = AbortSignal.timeout(CALDAV_TIMEOUT_MS);
+  const response = await fetch(url, {
+    method: 'PUT',
+    headers,
+    body: buildIcsEvent(event),
+    signal: signal ? AbortSignal.any([signal, timeout]) : timeout,
+  });
+  if (!response.ok) {
+    throw new Error(
+      `The CalDAV server answered ${response.status} ${response.statusText}`.trim(),
+    );
+  }
+  return url;
+}
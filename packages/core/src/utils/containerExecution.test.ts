@@ -0,0 +1,106 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, vi, afterEach } from 'vitest';
+import path from 'node:path';
+import {
+  buildContainerRunArgs,
+  getContainerEngine,
+  runsInContainer,
+} from './containerExecution.js';
+
+describe('runsInContainer', () => {
+  it('should default to the shell tool only', () => {
+    const settings = { image: 'python:3.12' };
+    expect(runsInContainer(settings, 'run_shell_command')).toBe(true);
+    expect(runsInContainer(settings, 'my_discovered_tool')).toBe(false);
+  });
+
+  it('should follow the configured tool list', () => {
+    const settings = { image: 'python:3.12', tools: ['my_discovered_tool'] };
+    expect(runsInContainer(settings, 'run_shell_command')).toBe(false);
+    expect(runsInContainer(settings, 'my_discovered_tool')).toBe(true);
+  });
+
+  it('should be off without settings or an image', () => {
+    expect(runsInContainer(undefined, 'run_shell_command')).toBe(false);
+    expect(runsInContainer({ image: '' }, 'run_shell_command')).toBe(false);
+  });
+});
+
+describe('buildContainerRunArgs', () => {
+  afterEach(() => {
+    vi.restoreAllMocks();
+  });
+
+  it('should isolate the network and mount the project by default', () => {
+    vi.spyOn(process, 'getuid').mockReturnValue(1000);
+    vi.spyOn(process, 'getgid').mockReturnValue(1000);
+    const args = buildContainerRunArgs(
+      { image: 'python:3.12' },
+      ['bash', '-c', 'python run.py'],
+      { name: 'research-tool-1', projectDir: '/home/a/project' },
+    );
+    expect(args).toEqual([
+      'run',
+      '--rm',
+      '--init',
+      '--name',
+      'research-tool-1',
+      '--network',
+      'none',
+      '--pids-limit',
+      '256',
+      '--user',
+      '1000:1000',
+      '-v',
+      `${path.resolve('/home/a/project')}:/workspace`,
+      '-w',
+      '/workspace',
+      'python:3.12',
+      'bash',
+      '-c',
+      'python run.py',
+    ]);
+  });
+
+  it('should apply limits, env, read-only mounts and the directory', () => {
+    const args = buildContainerRunArgs(
+      {
+        command: 'podman',
+        image: 'r-base',
+        memory: '2g',
+        cpus: 1.5,
+        network: true,
+        readOnly: true,
+        env: ['OMP_NUM_THREADS=1'],
+      },
+      ['Rscript', 'fit.R'],
+      {
+        name: 'research-tool-2',
+        projectDir: '/p',
+        directory: 'analysis',
+        interactive: true,
+      },
+    );
+    expect(args).toContain('-i');
+    expect(args).not.toContain('none');
+    expect(args.join(' ')).toContain('--memory 2g --cpus 1.5');
+    expect(args.join(' ')).toContain('-e OMP_NUM_THREADS=1');
+    expect(args).toContain(`${path.resolve('/p')}:/workspace:ro`);
+    expect(args).toContain('/workspace/analysis');
+    expect(args.slice(-3)).toEqual(['r-base', 'Rscript', 'fit.R']);
+  });
+});
+
+describe('getContainerEngine', () => {
+  it('should default to docker', () => {
+    expect(getContainerEngine({ image: 'x' })).toBe('docker');
+    expect(getContainerEngine({ image: 'x', command: 'podman' })).toBe(
+      'podman',
+    );
+  });
+});
@@ -0,0 +1,110 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import path from 'node:path';
+import crypto from 'node:crypto';
+
+export const DEFAULT_CONTAINER_TOOLS = ['run_shell_command'];
+export const DEFAULT_CONTAINER_WORKDIR = '/workspace';
+
+/**
+ * Runs selected tools in a disposable Docker or Podman container with the
+ * project directory mounted, instead of directly on the host.
+ */
+export interface ContainerExecutionSettings {
+  /** Container engine. Defaults to docker. */
+  command?: 'docker' | 'podman';
+  image: string;
+  /** Tools that run in the container. Defaults to the shell tool. */
+  tools?: string[];
+  /** Memory limit, e.g. "2g". */
+  memory?: string;
+  /** CPU limit, e.g. 2 or 0.5. */
+  cpus?: number;
+  /** Maximum number of processes. Defaults to 256. */
+  pidsLimit?: number;
+  /** Allow network access. Defaults to false. */
+  network?: boolean;
+  /** Mount the project read-only. Defaults to false. */
+  readOnly?: boolean;
+  /** Where the project is mounted. Defaults to /workspace. */
+  workdir?: string;
+  /** Extra environment variables, as NAME=value. */
+  env?: string[];
+}
+
+export function runsInContainer(
+  settings: ContainerExecutionSettings | undefined,
+  toolName: string,
+): settings is ContainerExecutionSettings {
+  return (
+    !!settings?.image &&
+    (settings.tools ?? DEFAULT_CONTAINER_TOOLS).includes(toolName)
+  );
+}
+
+export function getContainerEngine(
+  settings: ContainerExecutionSettings,
+): string {
+  return settings.command ?? 'docker';
+}
+
+/** Unique name so an aborted run can be removed with `<engine> rm -f`. */
+export function newContainerName(): string {
+  return `research-tool-${crypto.randomBytes(4).toString('hex')}`;
+}
+
+export interface ContainerRunOptions {
+  name: string;
+  /** Host project directory mounted into the container. */
+  projectDir: string;
+  /** Directory relative to the project root to start in. */
+  directory?: string;
+  /** Keep stdin open, for commands that read their input. */
+  interactive?: boolean;
+}
+
+/**
+ * Builds `<engine> run` arguments that execute `command` in a fresh
+ * container with the configured limits. The container is removed on exit.
+ */
+export function buildContainerRunArgs(
+  settings: ContainerExecutionSettings,
+  command: string[],
+  options: ContainerRunOptions,
+): string[] {
+  const workdir = settings.workdir ?? DEFAULT_CONTAINER_WORKDIR;
+  const args = ['run', '--rm', '--init', '--name', options.name];
+  if (options.interactive) {
+    args.push('-i');
+  }
+  if (!settings.network) {
+    args.push('--network', 'none');
+  }
+  if (settings.memory) {
+    args.push('--memory', settings.memory);
+  }
+  if (settings.cpus) {
+    args.push('--cpus', String(settings.cpus));
+  }
+  args.push('--pids-limit', String(settings.pidsLimit ?? 256));
+  // Files created in the mount should belong to the user, not root.
+  if (process.getuid && process.getgid) {
+    args.push('--user', `${process.getuid()}:${process.getgid()}`);
+  }
+  for (const variable of settings.env ?? []) {
+    args.push('-e', variable);
+  }
+  args.push(
+    '-v',
+    `${path.resolve(options.projectDir)}:${workdir}${settings.readOnly ? ':ro' : ''}`,
+    '-w',
+    path.posix.join(workdir, options.directory ?? ''),
+    settings.image,
+    ...command,
+  );
+  return args;
+}
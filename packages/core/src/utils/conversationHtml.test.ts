@@ -0,0 +1,130 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect } from 'vitest';
+import { Content } from '@google/genai';
+import { renderConversationHtml } from './conversationHtml.js';
+
+const HISTORY: Content[] = [
+  { role: 'user', parts: [{ text: 'Count the <rows> in data.csv' }] },
+  {
+    role: 'model',
+    parts: [
+      { text: 'Checking the file first.', thought: true },
+      {
+        functionCall: {
+          id: 'call-1',
+          name: 'read_file',
+          args: { absolute_path: '/lab/data.csv' },
+        },
+      },
+    ],
+  },
+  {
+    role: 'user',
+    parts: [
+      {
+        functionResponse: {
+          id: 'call-1',
+          name: 'read_file',
+          response: { output: 'a,b\n1,2\n3,4' },
+        },
+      },
+    ],
+  },
+  { role: 'model', parts: [{ text: 'The file has ' }] },
+  {
+    role: 'model',
+    parts: [{ text: '2 rows:\n\n```csv\n1,2\n3,4\n```\n' }],
+  },
+  {
+    role: 'user',
+    parts: [
+      { text: 'Now run it' },
+      { inlineData: { mimeType: 'image/png', data: 'iVBORw0KGgo=' } },
+    ],
+  },
+  {
+    role: 'model',
+    parts: [{ functionCall: { name: 'run_shell_command', args: {} } }],
+  },
+  {
+    role: 'user',
+    parts: [
+      {
+        functionResponse: {
+          name: 'run_shell_command',
+          response: { error: 'Command not allowed.' },
+        },
+      },
+    ],
+  },
+];
+
+describe('renderConversationHtml', () => {
+  const html = renderConversationHtml(HISTORY, {
+    title: 'Row count',
+    model: 'test-model',
+    exportedAt: new Date('2025-06-01T12:00:00Z'),
+  });
+
+  it('should write a self-contained page with a summary header', () => {
+    expect(html.startsWith('<!DOCTYPE html>')).toBe(true);
+    expect(html).toContain('<title>Row count</title>');
+    expect(html).toContain(
+      'Exported 2025-06-01T12:00:00.000Z · model test-model · 2 prompts · 2 tool calls',
+    );
+    expect(html).not.toMatch(/<(?:link|script) [^>]*src=/);
+    expect(html).toContain('src="data:image/png;base64,iVBORw0KGgo="');
+  });
+
+  it('should escape text and keep code blocks', () => {
+    expect(html).toContain('Count the &lt;rows&gt; in data.csv');
+    expect(html).toContain('<div class="body">The file has 2 rows:');
+    expect(html).toContain('<pre><code>1,2\n3,4</code></pre>');
+  });
+
+  it('should pair tool calls with their output in collapsible sections', () => {
+    expect(html).toContain(
+      '<details class="tool">\n<summary>read_file (done)</summary>\n<h4>Parameters</h4>\n<pre>{\n  &quot;absolute_path&quot;: &quot;/lab/data.csv&quot;\n}</pre>\n<h4>Output</h4>\n<pre>a,b\n1,2\n3,4</pre>',
+    );
+    expect(html).toContain(
+      '<details class="tool error">\n<summary>run_shell_command (error)</summary>',
+    );
+    expect(html).toContain('<pre>Command not allowed.</pre>');
+    expect(html).toContain(
+      '<details class="thought"><summary>Thinking</summary>',
+    );
+  });
+
+  it('should show the tool results and the answer as one turn', () => {
+    const sections = html.match(/<section class="turn (\w+)">/g);
+    expect(sections).toEqual([
+      '<section class="turn user">',
+      '<section class="turn model">',
+      '<section class="turn user">',
+      '<section class="turn model">',
+    ]);
+  });
+
+  it('should leave out the environment context that opens the chat', () => {
+    const page = renderConversationHtml([
+      {
+        role: 'user',
+        parts: [
+          {
+            text: 'This is the Research CLI. We are setting up the context for our chat.',
+          },
+        ],
+      },
+      { role: 'model', parts: [{ text: 'Got it. Thanks for the context!' }] },
+      ...HISTORY,
+    ]);
+    expect(page).not.toContain('context for our chat');
+    expect(page).not.toContain('Thanks for the context');
+    expect(page).toContain('2 prompts');
+  });
+});
@@ -0,0 +1,262 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { Content, FunctionCall, FunctionResponse, Part } from '@google/genai';
+import { isSetupTurn } from './messageInspectors.js';
+
+export interface ConversationHtmlOptions {
+  title?: string;
+  model?: string;
+  exportedAt?: Date;
+}
+
+const STYLE = `
+:root { color-scheme: light dark; --fg: #1f2328; --bg: #ffffff; --muted: #656d76; --border: #d0d7de; --user: #ddf4ff; --tool: #f6f8fa; --error: #cf222e; }
+@media (prefers-color-scheme: dark) { :root { --fg: #e6edf3; --bg: #0d1117; --muted: #8d96a0; --border: #30363d; --user: #0c2d48; --tool: #161b22; --error: #ff7b72; } }
+body { font: 15px/1.55 -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; color: var(--fg); background: var(--bg); max-width: 960px; margin: 0 auto; padding: 24px; }
+header { border-bottom: 1px solid var(--border); margin-bottom: 24px; }
+header p, .role { color: var(--muted); font-size: 13px; }
+.turn { margin: 16px 0; }
+.turn.user .body { background: var(--user); border-radius: 8px; padding: 8px 12px; }
+.body { white-space: pre-wrap; overflow-wrap: anywhere; }
+pre { background: var(--tool); border: 1px solid var(--border); border-radius: 6px; padding: 8px 12px; overflow-x: auto; white-space: pre-wrap; }
+details { border: 1px solid var(--border); border-radius: 6px; margin: 8px 0; padding: 4px 12px; background: var(--tool); }
+summary { cursor: pointer; font-family: ui-monospace, SFMono-Regular, Menlo, monospace; font-size: 13px; }
+details h4 { margin: 8px 0 4px; font-size: 12px; color: var(--muted); text-transform: uppercase; }
+details.error summary { color: var(--error); }
+details.thought { font-style: italic; }
+img { max-width: 100%; }
+button { font-size: 12px; margin-right: 4px; }
+`;
+
+const SCRIPT = `
+function setAll(open) { document.querySelectorAll('details').forEach(function (d) { d.open = open; }); }
+`;
+
+function escapeHtml(text: string): string {
+  return text
+    .replace(/&/g, '&amp;')
+    .replace(/</g, '&lt;')
+    .replace(/>/g, '&gt;')
+    .replace(/"/g, '&quot;');
+}
+
+/** Escapes text, keeping fenced code blocks apart so they render as code. */
+function renderText(text: string): string {
+  return text
+    .split(/^```[^\n]*\n([\s\S]*?)^```[ \t]*$/m)
+    .map((chunk, i) =>
+      i % 2 === 1
+        ? `<pre><code>${escapeHtml(chunk.replace(/\n$/, ''))}</code></pre>`
+        : escapeHtml(chunk.replace(/^\n+|\n+$/g, '')),
+    )
+    .filter(Boolean)
+    .join('\n');
+}
+
+function formatJson(value: unknown): string {
+  return escapeHtml(JSON.stringify(value ?? {}, null, 2));
+}
+
+/** Tool output: the `output` string the tools return, or the raw response. */
+function formatResponse(response: FunctionResponse['response']): {
+  html: string;
+  isError: boolean;
+} {
+  const isError = !!response && 'error' in response;
+  const output = response?.['output'] ?? response?.['error'];
+  return {
+    html:
+      typeof output === 'string' ? escapeHtml(output) : formatJson(response),
+    isError,
+  };
+}
+
+function renderToolCall(
+  call: FunctionCall,
+  response?: FunctionResponse,
+): string {
+  const result = response?.response
+    ? formatResponse(response.response)
+    : undefined;
+  const status = !response ? 'no result' : result?.isError ? 'error' : 'done';
+  return [
+    `<details class="tool${result?.isError ? ' error' : ''}">`,
+    `<summary>${escapeHtml(call.name ?? 'tool')} (${status})</summary>`,
+    '<h4>Parameters</h4>',
+    `<pre>${formatJson(call.args)}</pre>`,
+    ...(result ? ['<h4>Output</h4>', `<pre>${result.html}</pre>`] : []),
+    '</details>',
+  ].join('\n');
+}
+
+function renderPart(part: Part): string | undefined {
+  if (part.text !== undefined) {
+    if (!part.text.trim()) {
+      return undefined;
+    }
+    return part.thought
+      ? `<details class="thought"><summary>Thinking</summary><div class="body">${renderText(part.text)}</div></details>`
+      : `<div class="body">${renderText(part.text)}</div>`;
+  }
+  if (part.inlineData?.data) {
+    const mimeType = part.inlineData.mimeType ?? 'application/octet-stream';
+    return mimeType.startsWith('image/')
+      ? `<img alt="Attached image" src="data:${escapeHtml(mimeType)};base64,${part.inlineData.data}">`
+      : `<p>[Attached ${escapeHtml(mimeType)} data]</p>`;
+  }
+  if (part.fileData?.fileUri) {
+    return `<p>[Attached file: ${escapeHtml(part.fileData.fileUri)}]</p>`;
+  }
+  return undefined;
+}
+
+const isAnswerText = (part?: Part) =>
+  part?.text !== undefined && !part.thought;
+
+/** Joins the chunks a streamed answer is stored as into one text part. */
+export function mergeStreamedText(history: Content[]): Content[] {
+  const merged: Content[] = [];
+  for (const content of history) {
+    const last = merged[merged.length - 1];
+    if (last?.role === 'model' && content.role === 'model') {
+      last.parts = [...(last.parts ?? []), ...(content.parts ?? [])];
+    } else {
+      merged.push({ ...content, parts: [...(content.parts ?? [])] });
+    }
+  }
+  for (const content of merged) {
+    content.parts = content.parts?.reduce<Part[]>((parts, part) => {
+      const previous = parts[parts.length - 1];
+      if (isAnswerText(previous) && isAnswerText(part)) {
+        parts[parts.length - 1] = {
+          ...previous,
+          text: `${previous.text}${part.text}`,
+        };
+      } else {
+        parts.push(part);
+      }
+      return parts;
+    }, []);
+  }
+  return merged;
+}
+
+/**
+ * Renders a conversation as a self-contained HTML page: prompts, answers
+ * and every tool call with its parameters and output in a collapsible
+ * section. The chunks of streamed answers are joined back together, and
+ * the environment context that opens the chat is left out.
+ */
+export function renderConversationHtml(
+  conversation: Content[],
+  options: ConversationHtmlOptions = {},
+): string {
+  const history = mergeStreamedText(
+    conversation.filter((_, i) => !isSetupTurn(conversation, i)),
+  );
+  const responses: FunctionResponse[] = history.flatMap(
+    (content) =>
+      content.parts
+        ?.map((part) => part.functionResponse)
+        .filter((r): r is FunctionResponse => !!r) ?? [],
+  );
+  const used = new Set<FunctionResponse>();
+  const findResponse = (call: FunctionCall) => {
+    const response =
+      responses.find((r) => !used.has(r) && call.id && r.id === call.id) ??
+      responses.find((r) => !used.has(r) && !r.id && r.name === call.name);
+    if (response) {
+      used.add(response);
+    }
+    return response;
+  };
+
+  const turns: Array<{ role: 'user' | 'model'; html: string[] }> = [];
+  let promptCount = 0;
+  let toolCallCount = 0;
+  for (const content of history) {
+    const role = content.role === 'model' ? 'model' : 'user';
+    const html: string[] = [];
+    for (const part of content.parts ?? []) {
+      if (part.functionCall) {
+        html.push(
+          renderToolCall(part.functionCall, findResponse(part.functionCall)),
+        );
+        toolCallCount++;
+      } else if (part.functionResponse) {
+        if (!used.has(part.functionResponse)) {
+          // A result whose call is not in the history, e.g. after compression
+          html.push(
+            renderToolCall(
+              { name: part.functionResponse.name },
+              part.functionResponse,
+            ),
+          );
+        }
+      } else {
+        const rendered = renderPart(part);
+        if (rendered) {
+          html.push(rendered);
+        }
+      }
+    }
+    if (html.length === 0) {
+      continue;
+    }
+    const isToolResults = content.parts?.every((p) => p.functionResponse);
+    const last = turns[turns.length - 1];
+    if (
+      last &&
+      (last.role === role || (isToolResults && last.role === 'model'))
+    ) {
+      last.html.push(...html);
+    } else {
+      turns.push({ role, html });
+      if (role === 'user') {
+        promptCount++;
+      }
+    }
+  }
+
+  const title = options.title ?? 'Research CLI conversation';
+  const details = [
+    `Exported ${(options.exportedAt ?? new Date()).toISOString()}`,
+    ...(options.model ? [`model ${options.model}`] : []),
+    `${promptCount} prompt${promptCount === 1 ? '' : 's'}`,
+    `${toolCallCount} tool call${toolCallCount === 1 ? '' : 's'}`,
+  ];
+  return [
+    '<!DOCTYPE html>',
+    '<html lang="en">',
+    '<head>',
+    '<meta charset="utf-8">',
+    '<meta name="viewport" content="width=device-width, initial-scale=1">',
+    `<title>${escapeHtml(title)}</title>`,
+    `<style>${STYLE}</style>`,
+    `<script>${SCRIPT}</script>`,
+    '</head>',
+    '<body>',
+    '<header>',
+    `<h1>${escapeHtml(title)}</h1>`,
+    `<p>${escapeHtml(details.join(' · '))}</p>`,
+    '<p><button onclick="setAll(true)">Expand all</button><button onclick="setAll(false)">Collapse all</button></p>',
+    '</header>',
+    '<main>',
+    ...turns.map((turn) =>
+      [
+        `<section class="turn ${turn.role}">`,
+        `<div class="role">${turn.role === 'user' ? 'Prompt' : 'Answer'}</div>`,
+        ...turn.html,
+        '</section>',
+      ].join('\n'),
+    ),
+    '</main>',
+    '</body>',
+    '</html>',
+    '',
+  ].join('\n');
+}
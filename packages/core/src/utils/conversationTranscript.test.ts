@@ -0,0 +1,178 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect } from 'vitest';
+import { Content } from '@google/genai';
+import {
+  markdownToProse,
+  renderConversationTranscript,
+} from './conversationTranscript.js';
+
+const HISTORY: Content[] = [
+  { role: 'user', parts: [{ text: 'How many rows are in data.csv?' }] },
+  {
+    role: 'model',
+    parts: [
+      { text: 'Checking the file first.', thought: true },
+      {
+        functionCall: {
+          id: 'call-1',
+          name: 'read_file',
+          args: { absolute_path: '/lab/data.csv' },
+        },
+      },
+    ],
+  },
+  {
+    role: 'user',
+    parts: [
+      {
+        functionResponse: {
+          id: 'call-1',
+          name: 'read_file',
+          response: { output: 'a,b\n1,2\n3,4' },
+        },
+      },
+    ],
+  },
+  { role: 'model', parts: [{ text: 'The file has ' }] },
+  { role: 'model', parts: [{ text: 'two rows.' }] },
+];
+
+describe('renderConversationTranscript', () => {
+  it('should render markdown with one line per tool call', () => {
+    expect(renderConversationTranscript(HISTORY)).toBe(
+      [
+        '## Prompt',
+        '',
+        'How many rows are in data.csv?',
+        '',
+        '## Answer',
+        '',
+        '> Tool call: `read_file` (done)',
+        '',
+        'The file has two rows.',
+        '',
+      ].join('\n'),
+    );
+  });
+
+  it('should render plain text', () => {
+    expect(renderConversationTranscript(HISTORY, 'text')).toBe(
+      [
+        'Prompt:',
+        'How many rows are in data.csv?',
+        '',
+        'Answer:',
+        '[tool call: read_file (done)]',
+        'The file has two rows.',
+        '',
+      ].join('\n'),
+    );
+  });
+
+  it('should render prose with speakers and without tool calls', () => {
+    expect(renderConversationTranscript(HISTORY, 'prose')).toBe(
+      [
+        'You: How many rows are in data.csv?',
+        '',
+        'Research: The file has two rows.',
+        '',
+      ].join('\n'),
+    );
+  });
+
+  it('should mark failed and unanswered tool calls', () => {
+    const text = renderConversationTranscript(
+      [
+        {
+          role: 'model',
+          parts: [
+            { functionCall: { id: 'a', name: 'run_shell_command' } },
+            { functionCall: { id: 'b', name: 'web_fetch' } },
+          ],
+        },
+        {
+          role: 'user',
+          parts: [
+            {
+              functionResponse: {
+                id: 'a',
+                name: 'run_shell_command',
+                response: { error: 'exit 1' },
+              },
+            },
+          ],
+        },
+      ],
+      'text',
+    );
+
+    expect(text).toContain('[tool call: run_shell_command (error)]');
+    expect(text).toContain('[tool call: web_fetch (no result)]');
+  });
+
+  it('should be empty without a conversation', () => {
+    expect(renderConversationTranscript([])).toBe('');
+  });
+
+  it('should leave out the environment context that opens the chat', () => {
+    const setup: Content[] = [
+      {
+        role: 'user',
+        parts: [
+          {
+            text: 'This is the Research CLI. We are setting up the context for our chat.',
+          },
+        ],
+      },
+      { role: 'model', parts: [{ text: 'Got it. Thanks for the context!' }] },
+    ];
+
+    expect(renderConversationTranscript(setup)).toBe('');
+    expect(renderConversationTranscript([...setup, ...HISTORY], 'text')).toBe(
+      renderConversationTranscript(HISTORY, 'text'),
+    );
+  });
+});
+
+describe('markdownToProse', () => {
+  it('should strip markdown into sentences', () => {
+    expect(
+      markdownToProse(
+        [
+          '## Results',
+          '',
+          'The file has **two rows** in `csv`, see [the docs](https://x.org).',
+          '',
+          '- first point',
+          '- second *point*!',
+          '',
+          '| a | b |',
+          '|---|---|',
+          '| 1 | 2 |',
+          '',
+          '> Quoted text',
+          '---',
+          'Names like snake_case stay.',
+        ].join('\n'),
+      ),
+    ).toEqual([
+      'Results.',
+      'The file has two rows in csv, see the docs.',
+      'first point. second point!',
+      'a, b. 1, 2.',
+      'Quoted text',
+      'Names like snake_case stay.',
+    ]);
+  });
+
+  it('should leave out code blocks with a note', () => {
+    expect(
+      markdownToProse('Run this:\n\n```sh\nls -la\n```\nThen look.'),
+    ).toEqual(['Run this:', '(Code omitted.)', 'Then look.']);
+  });
+});
@@ -0,0 +1,203 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { Content, FunctionCall, FunctionResponse } from '@google/genai';
+import { mergeStreamedText } from './conversationHtml.js';
+import { isSetupTurn } from './messageInspectors.js';
+
+/**
+ * `prose` is for reading aloud: markdown, code and tool calls are left
+ * out, and each turn starts with its speaker.
+ */
+export type TranscriptFormat = 'markdown' | 'text' | 'prose';
+
+export const TRANSCRIPT_FORMATS: readonly TranscriptFormat[] = [
+  'markdown',
+  'text',
+  'prose',
+];
+
+const CODE_OMITTED = '(Code omitted.)';
+const FENCE = /^\s*(```|~~~)/;
+const HORIZONTAL_RULE = /^([-*_]\s*){3,}$/;
+const TABLE_SEPARATOR = /^\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?$/;
+
+/** Drops inline markdown: links and images keep their text. */
+function stripInlineMarkdown(text: string): string {
+  return text
+    .replace(/!?\[([^\]]*)\]\([^)]*\)/g, '$1')
+    .replace(/<[^>]+>/g, '')
+    .replace(/`([^`]*)`/g, '$1')
+    .replace(/(\*\*|__|~~)(.+?)\1/g, '$2')
+    .replace(/(^|[^\w*])[*_]([^*_]+)[*_](?=[^\w*]|$)/g, '$1$2');
+}
+
+// Headings, list items and table rows are read as sentences of their own
+function asSentence(text: string): string {
+  return !text || /[.!?:;]$/.test(text) ? text : `${text}.`;
+}
+
+/**
+ * Turns markdown into paragraphs of plain prose. Code blocks are replaced
+ * by a short note, so that a listener knows something was left out.
+ */
+export function markdownToProse(markdown: string): string[] {
+  const paragraphs: string[] = [];
+  let lines: string[] = [];
+  const endParagraph = () => {
+    const paragraph = lines.join(' ').replace(/\s+/g, ' ').trim();
+    if (paragraph) {
+      paragraphs.push(paragraph);
+    }
+    lines = [];
+  };
+
+  let fence: string | undefined;
+  for (const line of markdown.split('\n')) {
+    const fenceMatch = line.match(FENCE);
+    if (fence) {
+      if (fenceMatch?.[1] === fence) {
+        fence = undefined;
+      }
+      continue;
+    }
+    if (fenceMatch) {
+      endParagraph();
+      fence = fenceMatch[1];
+      paragraphs.push(CODE_OMITTED);
+      continue;
+    }
+    const trimmed = line.trim().replace(/^(>\s?)+/, '');
+    if (TABLE_SEPARATOR.test(trimmed) && trimmed.includes('|')) {
+      continue;
+    }
+    if (!trimmed || HORIZONTAL_RULE.test(trimmed)) {
+      endParagraph();
+      continue;
+    }
+    const heading = trimmed.match(/^#{1,6}\s+(.*)$/);
+    const item = trimmed.match(/^(?:[-*+]|\d+[.)])\s+(.*)$/);
+    if (heading) {
+      endParagraph();
+      lines.push(asSentence(stripInlineMarkdown(heading[1])));
+      endParagraph();
+    } else if (item) {
+      lines.push(asSentence(stripInlineMarkdown(item[1])));
+    } else if (trimmed.startsWith('|')) {
+      const cells = trimmed
+        .replace(/^\||\|$/g, '')
+        .split('|')
+        .map((cell) => stripInlineMarkdown(cell.trim()))
+        .filter(Boolean);
+      lines.push(asSentence(cells.join(', ')));
+    } else {
+      lines.push(stripInlineMarkdown(trimmed));
+    }
+  }
+  endParagraph();
+  return paragraphs;
+}
+
+function formatToolCall(
+  name: string,
+  response: FunctionResponse | undefined,
+  format: TranscriptFormat,
+): string {
+  const status = !response
+    ? 'no result'
+    : response.response && 'error' in response.response
+      ? 'error'
+      : 'done';
+  return format === 'markdown'
+    ? `> Tool call: \`${name}\` (${status})`
+    : `[tool call: ${name} (${status})]`;
+}
+
+/**
+ * Renders a conversation as a transcript to read or pipe: prompts and
+ * answers in full, with one line per tool call. Thoughts and tool output
+ * are left out; the HTML export has them, and so is the environment
+ * context that opens the chat. The prose format leaves out the tool calls
+ * too.
+ */
+export function renderConversationTranscript(
+  conversation: Content[],
+  format: TranscriptFormat = 'markdown',
+): string {
+  const history = mergeStreamedText(
+    conversation.filter((_, i) => !isSetupTurn(conversation, i)),
+  );
+  const responses = history.flatMap(
+    (content) =>
+      content.parts
+        ?.map((part) => part.functionResponse)
+        .filter((r): r is FunctionResponse => !!r) ?? [],
+  );
+  const used = new Set<FunctionResponse>();
+  const findResponse = (call: FunctionCall) => {
+    const response =
+      responses.find((r) => !used.has(r) && call.id && r.id === call.id) ??
+      responses.find((r) => !used.has(r) && !r.id && r.name === call.name);
+    if (response) {
+      used.add(response);
+    }
+    return response;
+  };
+
+  const turns: Array<{ role: 'user' | 'model'; blocks: string[] }> = [];
+  for (const content of history) {
+    const blocks: string[] = [];
+    for (const part of content.parts ?? []) {
+      if (part.functionCall) {
+        if (format === 'prose') {
+          continue;
+        }
+        blocks.push(
+          formatToolCall(
+            part.functionCall.name ?? 'tool',
+            findResponse(part.functionCall),
+            format,
+          ),
+        );
+      } else if (part.text?.trim() && !part.thought) {
+        if (format === 'prose') {
+          blocks.push(...markdownToProse(part.text));
+        } else {
+          blocks.push(part.text.trim());
+        }
+      }
+    }
+    if (blocks.length === 0) {
+      continue;
+    }
+    const role = content.role === 'model' ? 'model' : 'user';
+    const last = turns[turns.length - 1];
+    if (last?.role === role) {
+      last.blocks.push(...blocks);
+    } else {
+      turns.push({ role, blocks });
+    }
+  }
+
+  if (format === 'prose') {
+    return turns
+      .map(({ role, blocks }) =>
+        [`${role === 'user' ? 'You' : 'Research'}: ${blocks[0]}`]
+          .concat(blocks.slice(1))
+          .join('\n\n'),
+      )
+      .join('\n\n')
+      .concat(turns.length > 0 ? '\n' : '');
+  }
+  return turns
+    .map(({ role, blocks }) => {
+      const label = role === 'user' ? 'Prompt' : 'Answer';
+      const heading = format === 'markdown' ? `## ${label}` : `${label}:`;
+      return [heading, ...blocks].join(format === 'markdown' ? '\n\n' : '\n');
+    })
+    .join('\n\n')
+    .concat(turns.length > 0 ? '\n' : '');
+}
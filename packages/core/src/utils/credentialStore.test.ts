@@ -0,0 +1,82 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect } from 'vitest';
+import {
+  applyStoredSecrets,
+  deleteSecret,
+  getPowerShellArgs,
+  isCredentialStoreAvailable,
+  loadStoredSecrets,
+  parseStoredSecrets,
+  storeSecret,
+} from './credentialStore.js';
+
+describe('credentialStore', () => {
+  it('should only use the Credential Manager on Windows', () => {
+    expect(isCredentialStoreAvailable('win32')).toBe(true);
+    expect(isCredentialStoreAvailable('linux')).toBe(false);
+    expect(isCredentialStoreAvailable('darwin')).toBe(false);
+  });
+
+  it('should pass the script encoded, so it needs no quoting', () => {
+    const args = getPowerShellArgs('Write-Output "a b"');
+
+    expect(args.slice(0, -1)).toEqual([
+      '-NoProfile',
+      '-NonInteractive',
+      '-ExecutionPolicy',
+      'Bypass',
+      '-EncodedCommand',
+    ]);
+    expect(
+      Buffer.from(args[args.length - 1], 'base64').toString('utf16le'),
+    ).toBe('Write-Output "a b"');
+  });
+
+  it('should read the stored secrets by variable name', () => {
+    expect(
+      parseStoredSecrets(
+        '\uFEFF{"research-cli:SERPAPI_KEY":"abc","other:TOKEN":"x"}\r\n',
+      ),
+    ).toEqual({ SERPAPI_KEY: 'abc' });
+    expect(parseStoredSecrets('')).toEqual({});
+  });
+
+  it.skipIf(process.platform === 'win32')(
+    'should leave the environment alone outside Windows',
+    async () => {
+      const env = {};
+
+      expect(await applyStoredSecrets(['SERPAPI_KEY'], env)).toEqual([]);
+      expect(env).toEqual({});
+    },
+  );
+
+  // Needs a Windows session with a Credential Manager, so it only runs there
+  describe.runIf(process.platform === 'win32')('on Windows', () => {
+    const name = `RESEARCH_CLI_TEST_${process.pid}`;
+
+    it('should store, load and delete a secret', async () => {
+      await storeSecret(name, 'päss word');
+      try {
+        expect((await loadStoredSecrets())[name]).toBe('päss word');
+
+        const env: NodeJS.ProcessEnv = {};
+        expect(await applyStoredSecrets([name], env)).toEqual([name]);
+        expect(env[name]).toBe('päss word');
+
+        // Nothing is looked up when every named variable is set
+        expect(await applyStoredSecrets([name], env)).toEqual([]);
+        expect(await applyStoredSecrets([], {})).toEqual([]);
+      } finally {
+        await deleteSecret(name);
+      }
+      expect((await loadStoredSecrets())[name]).toBeUndefined();
+      await deleteSecret(name);
+    }, 30000);
+  });
+});
@@ -0,0 +1,206 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { spawn } from 'node:child_process';
+
+/** Stored secrets are named after the environment variable they fill. */
+const CREDENTIAL_PREFIX = 'research-cli:';
+
+/**
+ * The Credential Manager API of advapi32, for PowerShell. Node has no
+ * binding for it, and `cmdkey` can store a secret but not read it back.
+ */
+const CREDENTIAL_API = `
+Add-Type -TypeDefinition @'
+using System;
+using System.Runtime.InteropServices;
+public static class ResearchCred {
+  [StructLayout(LayoutKind.Sequential, CharSet = CharSet.Unicode)]
+  public struct CREDENTIAL {
+    public int Flags;
+    public int Type;
+    public string TargetName;
+    public string Comment;
+    public System.Runtime.InteropServices.ComTypes.FILETIME LastWritten;
+    public int CredentialBlobSize;
+    public IntPtr CredentialBlob;
+    public int Persist;
+    public int AttributeCount;
+    public IntPtr Attributes;
+    public string TargetAlias;
+    public string UserName;
+  }
+  [DllImport("advapi32.dll", CharSet = CharSet.Unicode, SetLastError = true)]
+  public static extern bool CredWrite(ref CREDENTIAL credential, int flags);
+  [DllImport("advapi32.dll", CharSet = CharSet.Unicode, SetLastError = true)]
+  public static extern bool CredDelete(string target, int type, int flags);
+  [DllImport("advapi32.dll", CharSet = CharSet.Unicode, SetLastError = true)]
+  public static extern bool CredEnumerate(string filter, int flags, out int count, out IntPtr credentials);
+  [DllImport("advapi32.dll")]
+  public static extern void CredFree(IntPtr buffer);
+}
+'@
+$Marshal = [System.Runtime.InteropServices.Marshal]
+`;
+
+// The secret comes on stdin so it never shows in a process list
+const WRITE_SCRIPT = `${CREDENTIAL_API}
+[Console]::InputEncoding = [Text.Encoding]::UTF8
+$bytes = [Text.Encoding]::Unicode.GetBytes([Console]::In.ReadToEnd())
+$cred = New-Object ResearchCred+CREDENTIAL
+$cred.Type = 1
+$cred.Persist = 2
+$cred.TargetName = $env:RESEARCH_CREDENTIAL_TARGET
+$cred.UserName = $env:USERNAME
+$cred.CredentialBlobSize = $bytes.Length
+$cred.CredentialBlob = $Marshal::AllocHGlobal($bytes.Length)
+$Marshal::Copy($bytes, 0, $cred.CredentialBlob, $bytes.Length)
+try {
+  if (-not [ResearchCred]::CredWrite([ref]$cred, 0)) {
+    throw (New-Object ComponentModel.Win32Exception)
+  }
+} finally {
+  $Marshal::FreeHGlobal($cred.CredentialBlob)
+}
+`;
+
+// 1168 is ERROR_NOT_FOUND: deleting a secret that is not there is fine
+const DELETE_SCRIPT = `${CREDENTIAL_API}
+if (-not [ResearchCred]::CredDelete($env:RESEARCH_CREDENTIAL_TARGET, 1, 0)) {
+  $code = $Marshal::GetLastWin32Error()
+  if ($code -ne 1168) { throw (New-Object ComponentModel.Win32Exception $code) }
+}
+`;
+
+const LIST_SCRIPT = `${CREDENTIAL_API}
+[Console]::OutputEncoding = [Text.Encoding]::UTF8
+$secrets = @{}
+$count = 0
+$list = [IntPtr]::Zero
+if ([ResearchCred]::CredEnumerate($env:RESEARCH_CREDENTIAL_TARGET, 0, [ref]$count, [ref]$list)) {
+  for ($i = 0; $i -lt $count; $i++) {
+    $item = $Marshal::ReadIntPtr($list, $i * [IntPtr]::Size)
+    $cred = $Marshal::PtrToStructure($item, [type][ResearchCred+CREDENTIAL])
+    $secrets[$cred.TargetName] = $Marshal::PtrToStringUni($cred.CredentialBlob, $cred.CredentialBlobSize / 2)
+  }
+  [ResearchCred]::CredFree($list)
+}
+$secrets | ConvertTo-Json -Compress
+`;
+
+/**
+ * Secrets are kept in the Windows Credential Manager. Elsewhere they come
+ * from the environment, as before.
+ */
+export function isCredentialStoreAvailable(
+  platform: NodeJS.Platform = process.platform,
+): boolean {
+  return platform === 'win32';
+}
+
+export function getCredentialTarget(name: string): string {
+  return `${CREDENTIAL_PREFIX}${name}`;
+}
+
+/** Arguments running `script` without profile, prompts or quoting issues. */
+export function getPowerShellArgs(script: string): string[] {
+  return [
+    '-NoProfile',
+    '-NonInteractive',
+    '-ExecutionPolicy',
+    'Bypass',
+    '-EncodedCommand',
+    Buffer.from(script, 'utf16le').toString('base64'),
+  ];
+}
+
+function runPowerShell(
+  script: string,
+  target: string,
+  input = '',
+): Promise<string> {
+  return new Promise((resolve, reject) => {
+    const child = spawn('powershell.exe', getPowerShellArgs(script), {
+      env: { ...process.env, RESEARCH_CREDENTIAL_TARGET: target },
+      windowsHide: true,
+    });
+    let stdout = '';
+    let stderr = '';
+    child.stdout.on('data', (chunk) => (stdout += chunk));
+    child.stderr.on('data', (chunk) => (stderr += chunk));
+    child.on('error', (error) =>
+      reject(new Error(`Could not run PowerShell: ${error.message}`)),
+    );
+    child.on('close', (code) =>
+      code === 0
+        ? resolve(stdout)
+        : reject(
+            new Error(
+              `The Credential Manager failed${stderr ? `: ${stderr.trim()}` : '.'}`,
+            ),
+          ),
+    );
+    child.stdin.end(input);
+  });
+}
+
+/** Reads the JSON the list script prints into secrets by name. */
+export function parseStoredSecrets(output: string): Record<string, string> {
+  const trimmed = output.replace(/^\uFEFF/, '').trim();
+  if (!trimmed) {
+    return {};
+  }
+  const secrets: Record<string, string> = {};
+  for (const [target, value] of Object.entries(
+    JSON.parse(trimmed) as Record<string, unknown>,
+  )) {
+    if (target.startsWith(CREDENTIAL_PREFIX) && typeof value === 'string') {
+      secrets[target.slice(CREDENTIAL_PREFIX.length)] = value;
+    }
+  }
+  return secrets;
+}
+
+/** Stores a secret under the name of its environment variable. */
+export async function storeSecret(name: string, value: string): Promise<void> {
+  await runPowerShell(WRITE_SCRIPT, getCredentialTarget(name), value);
+}
+
+export async function deleteSecret(name: string): Promise<void> {
+  await runPowerShell(DELETE_SCRIPT, getCredentialTarget(name));
+}
+
+/** All stored secrets, by the name of their environment variable. */
+export async function loadStoredSecrets(): Promise<Record<string, string>> {
+  return parseStoredSecrets(
+    await runPowerShell(LIST_SCRIPT, `${CREDENTIAL_PREFIX}*`),
+  );
+}
+
+/**
+ * Fills environment variables from the stored secrets named in `names`, so
+ * that everything that reads a key or password from the environment finds
+ * it. Variables that are already set win. PowerShell is only started when
+ * one of them is still missing, as it takes seconds. Returns the names
+ * that were filled.
+ */
+export async function applyStoredSecrets(
+  names: string[],
+  env: NodeJS.ProcessEnv = process.env,
+): Promise<string[]> {
+  const missing = names.filter((name) => env[name] === undefined);
+  if (!isCredentialStoreAvailable() || missing.length === 0) {
+    return [];
+  }
+  const applied: string[] = [];
+  for (const [name, value] of Object.entries(await loadStoredSecrets())) {
+    if (missing.includes(name) && env[name] === undefined) {
+      env[name] = value;
+      applied.push(name);
+    }
+  }
+  return applied;
+}
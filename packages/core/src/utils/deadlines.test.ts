@@ -0,0 +1,197 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import {
+  Deadline,
+  formatCountdown,
+  loadDeadlines,
+  mergeDeadlines,
+  parseDeadlineDate,
+  parseIcalDeadlines,
+  parseJsonDeadlines,
+  saveDeadlines,
+  upcomingDeadlines,
+} from './deadlines.js';
+
+const deadline = (title: string, due: string): Deadline => ({
+  id: title,
+  title,
+  due,
+  kind: 'conference',
+});
+
+describe('parseIcalDeadlines', () => {
+  it('should read events with UTC, local and all-day dates', () => {
+    const ics = [
+      'BEGIN:VCALENDAR',
+      'BEGIN:VEVENT',
+      'SUMMARY:NeurIPS 2025 paper submission',
+      'DTSTART:20250515T115900Z',
+      'URL:https://neurips.cc',
+      'END:VEVENT',
+      'BEGIN:VEVENT',
+      'SUMMARY:ERC Starting Grant\\, full proposal',
+      'DTSTART;VALUE=DATE:20251014',
+      'DESCRIPTION:Panel PE6\\nSubmit via',
+      '  the portal',
+      'END:VEVENT',
+      'END:VCALENDAR',
+    ].join('\r\n');
+
+    const [neurips, erc] = parseIcalDeadlines(ics, 'feed.ics');
+
+    expect(neurips).toMatchObject({
+      title: 'NeurIPS 2025 paper submission',
+      due: '2025-05-15T11:59:00.000Z',
+      kind: 'conference',
+      url: 'https://neurips.cc',
+      source: 'feed.ics',
+    });
+    expect(erc).toMatchObject({
+      title: 'ERC Starting Grant, full proposal',
+      due: new Date(2025, 9, 14, 23, 59, 59).toISOString(),
+      kind: 'grant',
+      note: 'Panel PE6\nSubmit via the portal',
+    });
+  });
+
+  it('should convert times with a TZID from that zone', () => {
+    const ics = [
+      'BEGIN:VEVENT',
+      'SUMMARY:ACL submission',
+      'DTSTART;TZID=America/New_York:20250215T235900',
+      'END:VEVENT',
+      'BEGIN:VEVENT',
+      'SUMMARY:EMNLP submission',
+      'DTSTART;TZID="Europe/Berlin":20250701T120000',
+      'END:VEVENT',
+    ].join('\r\n');
+
+    expect(parseIcalDeadlines(ics).map((d) => d.due)).toEqual([
+      '2025-02-16T04:59:00.000Z',
+      '2025-07-01T10:00:00.000Z',
+    ]);
+  });
+
+  it('should keep the id of an event that moved', () => {
+    const event = (start: string) =>
+      [
+        'BEGIN:VEVENT',
+        'UID:icml-2025@example.org',
+        'SUMMARY:ICML submission',
+        `DTSTART:${start}`,
+        'END:VEVENT',
+      ].join('\r\n');
+
+    const [before] = parseIcalDeadlines(event('20250130T235900Z'));
+    const [after] = parseIcalDeadlines(event('20250206T235900Z'));
+
+    expect(after.id).toBe(before.id);
+    expect(mergeDeadlines([before], [after])).toEqual({
+      deadlines: [after],
+      added: 0,
+    });
+  });
+});
+
+describe('parseJsonDeadlines', () => {
+  it('should accept common field names and skip undated records', () => {
+    const deadlines = parseJsonDeadlines(
+      JSON.stringify({
+        deadlines: [
+          { conference: 'ICML', deadline: '2025-01-30 23:59', link: 'u' },
+          { name: 'NSF CAREER', date: '2025-07-23', type: 'grant' },
+          { title: 'No date' },
+        ],
+      }),
+    );
+
+    expect(deadlines.map((d) => [d.title, d.kind, d.url])).toEqual([
+      ['ICML', 'other', 'u'],
+      ['NSF CAREER', 'grant', undefined],
+    ]);
+    expect(deadlines[0].due).toBe(new Date(2025, 0, 30, 23, 59).toISOString());
+  });
+});
+
+describe('parseDeadlineDate', () => {
+  it('should treat a bare date as the end of that day', () => {
+    expect(parseDeadlineDate('2025-03-01')).toEqual(
+      new Date(2025, 2, 1, 23, 59, 59),
+    );
+    expect(parseDeadlineDate('soon')).toBeUndefined();
+  });
+});
+
+describe('deadline lists', () => {
+  const now = new Date('2025-03-01T12:00:00Z');
+
+  it('should pick deadlines due within the window', () => {
+    const deadlines = [
+      deadline('later', '2025-04-01T00:00:00Z'),
+      deadline('soon', '2025-03-03T12:00:00Z'),
+      deadline('past', '2025-02-01T00:00:00Z'),
+    ];
+    expect(
+      upcomingDeadlines(deadlines, 7, now).map((d) => d.title),
+    ).toEqual(['soon']);
+  });
+
+  it('should replace deadlines with the same id when merging', () => {
+    const { deadlines, added } = mergeDeadlines(
+      [deadline('a', '2025-03-02T00:00:00Z')],
+      [
+        { ...deadline('a', '2025-03-02T00:00:00Z'), note: 'updated' },
+        deadline('b', '2025-03-01T00:00:00Z'),
+      ],
+    );
+    expect(added).toBe(1);
+    expect(deadlines.map((d) => [d.title, d.note])).toEqual([
+      ['b', undefined],
+      ['a', 'updated'],
+    ]);
+  });
+
+  it('should format countdowns', () => {
+    expect(formatCountdown('2025-03-04T16:30:00Z', now)).toBe('3d 4h');
+    expect(formatCountdown('2025-03-01T17:45:00Z', now)).toBe('5h 45m');
+    expect(formatCountdown('2025-03-01T12:20:00Z', now)).toBe('20m');
+    expect(formatCountdown('2025-02-26T00:00:00Z', now)).toBe('passed 3d ago');
+  });
+});
+
+describe('loadDeadlines and saveDeadlines', () => {
+  let tempDir: string;
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'deadlines-'));
+  });
+
+  afterEach(() => {
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should round-trip sorted deadlines and start empty', async () => {
+    const file = path.join(tempDir, 'nested', 'deadlines.json');
+    expect(await loadDeadlines(file)).toEqual([]);
+
+    await saveDeadlines(
+      [
+        deadline('b', '2025-05-01T00:00:00Z'),
+        deadline('a', '2025-04-01T00:00:00Z'),
+      ],
+      file,
+    );
+    expect((await loadDeadlines(file)).map((d) => d.title)).toEqual([
+      'a',
+      'b',
+    ]);
+  });
+});
@@ -0,0 +1,363 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import * as crypto from 'node:crypto';
+import { getUserResearchDir } from './paths.js';
+import { fetchWithTimeout } from './fetch.js';
+import { isNodeError } from './errors.js';
+
+const FEED_TIMEOUT_MS = 15000;
+const DAY_MS = 24 * 60 * 60 * 1000;
+
+export type DeadlineKind = 'conference' | 'grant' | 'reminder' | 'other';
+
+export interface Deadline {
+  id: string;
+  title: string;
+  /** ISO 8601 timestamp of the deadline. */
+  due: string;
+  kind: DeadlineKind;
+  url?: string;
+  note?: string;
+  /** Feed URL or file the deadline was imported from. */
+  source?: string;
+}
+
+export function getDeadlinesPath(): string {
+  return path.join(getUserResearchDir(), 'deadlines.json');
+}
+
+/**
+ * The id of a deadline. Calendar events are keyed on their UID, so an
+ * event that moves keeps its id; others on their title and due time.
+ */
+export function createDeadlineId(
+  title: string,
+  due: string,
+  uid?: string,
+): string {
+  return crypto
+    .createHash('sha256')
+    .update(uid ? `uid:${uid}` : `${title.toLowerCase()}|${due}`)
+    .digest('hex')
+    .slice(0, 8);
+}
+
+export async function loadDeadlines(
+  filePath: string = getDeadlinesPath(),
+): Promise<Deadline[]> {
+  try {
+    const data = JSON.parse(await fs.promises.readFile(filePath, 'utf8'));
+    return Array.isArray(data) ? data : [];
+  } catch (error) {
+    if (isNodeError(error) && error.code === 'ENOENT') {
+      return [];
+    }
+    throw error;
+  }
+}
+
+export async function saveDeadlines(
+  deadlines: Deadline[],
+  filePath: string = getDeadlinesPath(),
+): Promise<void> {
+  await fs.promises.mkdir(path.dirname(filePath), { recursive: true });
+  await fs.promises.writeFile(
+    filePath,
+    JSON.stringify(sortDeadlines(deadlines), null, 2),
+    'utf8',
+  );
+}
+
+export function sortDeadlines(deadlines: Deadline[]): Deadline[] {
+  return [...deadlines].sort((a, b) => Date.parse(a.due) - Date.parse(b.due));
+}
+
+/**
+ * Parses a date such as `2025-03-01`, `2025-03-01 23:59` or a full ISO
+ * timestamp. A date without a time means the end of that day, local time.
+ */
+export function parseDeadlineDate(value: string): Date | undefined {
+  const trimmed = value.trim();
+  const dateOnly = trimmed.match(/^(\d{4})-(\d{2})-(\d{2})$/);
+  const date = dateOnly
+    ? new Date(
+        Number(dateOnly[1]),
+        Number(dateOnly[2]) - 1,
+        Number(dateOnly[3]),
+        23,
+        59,
+        59,
+      )
+    : new Date(trimmed.replace(' ', 'T'));
+  return isNaN(date.getTime()) ? undefined : date;
+}
+
+function inferKind(text: string): DeadlineKind {
+  if (/\b(grant|fellowship|funding|proposal|erc|nsf|nih)\b/i.test(text)) {
+    return 'grant';
+  }
+  if (
+    /\b(conference|workshop|symposium|submission|abstract|paper)\b/i.test(text)
+  ) {
+    return 'conference';
+  }
+  return 'other';
+}
+
+function unescapeIcal(value: string): string {
+  return value
+    .replace(/\\n/gi, '\n')
+    .replace(/\\([,;\\])/g, '$1')
+    .trim();
+}
+
+/** How far `zone` is ahead of UTC at `date`, in milliseconds. */
+function zoneOffset(date: Date, zone: string): number {
+  const parts = new Intl.DateTimeFormat('en-US', {
+    timeZone: zone,
+    hourCycle: 'h23',
+    year: 'numeric',
+    month: 'numeric',
+    day: 'numeric',
+    hour: 'numeric',
+    minute: 'numeric',
+    second: 'numeric',
+  }).formatToParts(date);
+  const part = (type: string) =>
+    Number(parts.find((p) => p.type === type)?.value);
+  const wall = Date.UTC(
+    part('year'),
+    part('month') - 1,
+    part('day'),
+    part('hour'),
+    part('minute'),
+    part('second'),
+  );
+  return wall - Math.floor(date.getTime() / 1000) * 1000;
+}
+
+/**
+ * The instant the wall time `utcWall` (as if it were UTC) reads in `zone`,
+ * or undefined if the zone is unknown.
+ */
+function fromZone(utcWall: number, zone: string): Date | undefined {
+  try {
+    const guess = utcWall - zoneOffset(new Date(utcWall), zone);
+    // Near a DST change the offset at the guess can differ
+    return new Date(utcWall - zoneOffset(new Date(guess), zone));
+  } catch (error) {
+    if (error instanceof RangeError) {
+      return undefined;
+    }
+    throw error;
+  }
+}
+
+function parseIcalDate(value: string, params: string): Date | undefined {
+  const match = value.match(
+    /^(\d{4})(\d{2})(\d{2})(?:T(\d{2})(\d{2})(\d{2})(Z)?)?$/,
+  );
+  if (!match) {
+    return undefined;
+  }
+  const [, y, mo, d, h, mi, s, utc] = match;
+  if (!h || /VALUE=DATE(?!-)/.test(params)) {
+    // All-day events: the deadline is the end of that day
+    return new Date(Number(y), Number(mo) - 1, Number(d), 23, 59, 59);
+  }
+  const [year, month, day, hour, minute, second] = [y, mo, d, h, mi, s].map(
+    Number,
+  );
+  const wall = Date.UTC(year, month - 1, day, hour, minute, second);
+  if (utc) {
+    return new Date(wall);
+  }
+  const zone = params.match(/;TZID=(?:"([^"]*)"|([^;:]*))/);
+  const zoned = zone && fromZone(wall, zone[1] ?? zone[2]);
+  return zoned || new Date(year, month - 1, day, hour, minute, second);
+}
+
+/**
+ * Reads the VEVENTs of an iCalendar feed. Times with a TZID are converted
+ * from that zone; floating times and unknown zones are taken as local time.
+ */
+export function parseIcalDeadlines(text: string, source?: string): Deadline[] {
+  // Continuation lines start with a space or tab
+  const lines = text.replace(/\r?\n[ \t]/g, '').split(/\r?\n/);
+  const deadlines: Deadline[] = [];
+  let event: Record<string, { value: string; params: string }> | undefined;
+  // Depth of components inside the event, such as VALARM
+  let nested = 0;
+
+  for (const line of lines) {
+    if (line === 'BEGIN:VEVENT') {
+      event = {};
+      nested = 0;
+    } else if (event && line.startsWith('BEGIN:')) {
+      nested++;
+    } else if (event && nested > 0) {
+      // Properties of nested components, e.g. an alarm's DESCRIPTION
+      if (line.startsWith('END:')) {
+        nested--;
+      }
+    } else if (line === 'END:VEVENT' && event) {
+      const title = event['SUMMARY'] && unescapeIcal(event['SUMMARY'].value);
+      const start = event['DTSTART'];
+      const due = start && parseIcalDate(start.value, start.params);
+      if (title && due) {
+        const description =
+          event['DESCRIPTION'] && unescapeIcal(event['DESCRIPTION'].value);
+        deadlines.push({
+          id: createDeadlineId(title, due.toISOString(), event['UID']?.value),
+          title,
+          due: due.toISOString(),
+          kind: inferKind(`${title} ${description ?? ''}`),
+          url: event['URL']?.value,
+          note: description || undefined,
+          source,
+        });
+      }
+      event = undefined;
+    } else if (event) {
+      const match = line.match(/^([A-Z-]+)((?:;[^:]*)?):(.*)$/);
+      if (match) {
+        event[match[1]] = { params: match[2], value: match[3] };
+      }
+    }
+  }
+  return deadlines;
+}
+
+interface JsonDeadline {
+  title?: string;
+  name?: string;
+  conference?: string;
+  deadline?: string;
+  due?: string;
+  date?: string;
+  kind?: string;
+  type?: string;
+  url?: string;
+  link?: string;
+  note?: string;
+  comment?: string;
+}
+
+/**
+ * Reads a JSON feed: an array, or an object with a `deadlines` array, of
+ * objects with a title (`title`, `name` or `conference`) and a date
+ * (`deadline`, `due` or `date`).
+ */
+export function parseJsonDeadlines(text: string, source?: string): Deadline[] {
+  const data = JSON.parse(text) as JsonDeadline[] | { deadlines?: unknown };
+  const records = (Array.isArray(data) ? data : data.deadlines) as
+    | JsonDeadline[]
+    | undefined;
+  if (!Array.isArray(records)) {
+    throw new Error('Expected an array of deadlines');
+  }
+  const deadlines: Deadline[] = [];
+  for (const record of records) {
+    const title = record.title ?? record.name ?? record.conference;
+    const date = record.deadline ?? record.due ?? record.date;
+    const due = date ? parseDeadlineDate(date) : undefined;
+    if (!title || !due) {
+      continue;
+    }
+    const kind = record.kind ?? record.type;
+    deadlines.push({
+      id: createDeadlineId(title, due.toISOString()),
+      title,
+      due: due.toISOString(),
+      kind:
+        kind === 'grant' ||
+        kind === 'conference' ||
+        kind === 'reminder' ||
+        kind === 'other'
+          ? kind
+          : inferKind(title),
+      url: record.url ?? record.link,
+      note: record.note ?? record.comment,
+      source,
+    });
+  }
+  return deadlines;
+}
+
+/**
+ * Fetches (for URLs) or reads an iCal or JSON feed of deadlines.
+ */
+export async function readDeadlineFeed(source: string): Promise<Deadline[]> {
+  let text: string;
+  if (/^https?:\/\//.test(source)) {
+    const response = await fetchWithTimeout(source, FEED_TIMEOUT_MS);
+    if (!response.ok) {
+      throw new Error(`Feed request failed: ${response.status}`);
+    }
+    text = await response.text();
+  } else {
+    text = await fs.promises.readFile(source, 'utf8');
+  }
+  return text.trimStart().startsWith('BEGIN:VCALENDAR')
+    ? parseIcalDeadlines(text, source)
+    : parseJsonDeadlines(text, source);
+}
+
+/**
+ * Adds new deadlines to a list; deadlines with the same id (the same
+ * calendar event, or title and due time) replace the existing ones.
+ * Returns the merged list and how many were new.
+ */
+export function mergeDeadlines(
+  existing: Deadline[],
+  incoming: Deadline[],
+): { deadlines: Deadline[]; added: number } {
+  const byId = new Map(existing.map((deadline) => [deadline.id, deadline]));
+  let added = 0;
+  for (const deadline of incoming) {
+    if (!byId.has(deadline.id)) {
+      added++;
+    }
+    byId.set(deadline.id, deadline);
+  }
+  return { deadlines: sortDeadlines([...byId.values()]), added };
+}
+
+/** Deadlines due between now and the given number of days from now. */
+export function upcomingDeadlines(
+  deadlines: Deadline[],
+  withinDays: number,
+  now: Date = new Date(),
+): Deadline[] {
+  const start = now.getTime();
+  const end = start + withinDays * DAY_MS;
+  return sortDeadlines(deadlines).filter((deadline) => {
+    const due = Date.parse(deadline.due);
+    return due >= start && due <= end;
+  });
+}
+
+/**
+ * Time left as "3d 4h", "5h 12m" or "12m"; past deadlines read
+ * "passed 2d ago".
+ */
+export function formatCountdown(due: string, now: Date = new Date()): string {
+  const diff = Date.parse(due) - now.getTime();
+  const abs = Math.abs(diff);
+  const days = Math.floor(abs / DAY_MS);
+  const hours = Math.floor((abs % DAY_MS) / (60 * 60 * 1000));
+  const minutes = Math.floor((abs % (60 * 60 * 1000)) / (60 * 1000));
+  const text =
+    days > 0
+      ? `${days}d ${hours}h`
+      : hours > 0
+        ? `${hours}h ${minutes}m`
+        : `${minutes}m`;
+  return diff < 0 ? `passed ${days > 0 ? `${days}d` : text} ago` : text;
+}
@@ -0,0 +1,63 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect } from 'vitest';
+import { Content } from '@google/genai';
+import { findExchanges, locateExchange, markInserted } from './exchanges.js';
+
+const user = (text: string): Content => ({ role: 'user', parts: [{ text }] });
+const model = (text: string): Content => ({ role: 'model', parts: [{ text }] });
+
+describe('exchanges', () => {
+  const history: Content[] = [
+    user('Which test should I use?'),
+    { role: 'model', parts: [{ functionCall: { name: 'read_file' } }] },
+    { role: 'user', parts: [{ functionResponse: { name: 'read_file' } }] },
+    model('A paired t-test.'),
+    user('Write the methods section.'),
+    model('Participants were...'),
+  ];
+
+  it('should count tool responses as part of their exchange', () => {
+    expect(findExchanges(history)).toEqual([0, 4]);
+  });
+
+  it('should not count the setup turn as an exchange', () => {
+    const withSetup = [
+      user(
+        'This is the Research CLI. We are setting up the context for our chat.',
+      ),
+      model('Got it. Thanks for the context!'),
+      ...history,
+    ];
+
+    expect(findExchanges(withSetup)).toEqual([2, 6]);
+  });
+
+  it('should not start exchanges at inserted contents', () => {
+    const woven = [
+      ...history.slice(0, 4),
+      ...markInserted([user('Why paired?'), model('Samples pair.')]),
+      ...history.slice(4),
+    ];
+
+    expect(findExchanges(woven)).toEqual([0, 6]);
+  });
+
+  it('should find an exchange after earlier ones were left out', () => {
+    const ref = { exchange: 2, prompt: 'Write the methods section.' };
+    const shortened = history.slice(4);
+
+    expect(locateExchange(history, findExchanges(history), ref)).toBe(1);
+    expect(locateExchange(shortened, findExchanges(shortened), ref)).toBe(0);
+    expect(
+      locateExchange(history, findExchanges(history), {
+        exchange: 2,
+        prompt: 'Something else',
+      }),
+    ).toBeUndefined();
+  });
+});
@@ -0,0 +1,73 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { Content } from '@google/genai';
+import { isSetupTurn } from './messageInspectors.js';
+
+/**
+ * Identifies an exchange (a prompt and everything the model did to answer
+ * it) by its number, counted from 1, and its prompt.
+ */
+export interface ExchangeRef {
+  exchange: number;
+  prompt: string;
+}
+
+// Contents added to a request by a context filter, which do not start an
+// exchange of their own.
+const insertedContents = new WeakSet<Content>();
+
+/** Marks contents a filter inserts into the history, e.g. side threads. */
+export function markInserted(contents: Content[]): Content[] {
+  contents.forEach((content) => insertedContents.add(content));
+  return contents;
+}
+
+/** The visible text of contents, without thoughts. */
+export function exchangeText(contents: Content[]): string {
+  return contents
+    .flatMap((content) => content.parts ?? [])
+    .filter((part) => part.text && !part.thought)
+    .map((part) => part.text)
+    .join('\n')
+    .trim();
+}
+
+/**
+ * Indices of the user prompts that start each exchange; tool responses
+ * are part of the exchange they answer. The setup turn that opens the
+ * conversation is not an exchange, so the first prompt is exchange 1.
+ */
+export function findExchanges(history: Content[]): number[] {
+  return history.flatMap((content, i) =>
+    content.role === 'user' &&
+    !insertedContents.has(content) &&
+    !isSetupTurn(history, i) &&
+    content.parts?.some((part) => part.text !== undefined) &&
+    !content.parts.some((part) => part.functionResponse)
+      ? [i]
+      : [],
+  );
+}
+
+/**
+ * Finds an exchange in `history`, given the indices from `findExchanges`.
+ * Exchanges before it may have been left out, e.g. by a compression or a
+ * filter, so it is looked for at its number and then earlier. Returns its
+ * position in `exchanges`, or undefined when it is gone.
+ */
+export function locateExchange(
+  history: Content[],
+  exchanges: number[],
+  ref: ExchangeRef,
+): number | undefined {
+  for (let i = Math.min(ref.exchange, exchanges.length) - 1; i >= 0; i--) {
+    if (exchangeText([history[exchanges[i]]]) === ref.prompt) {
+      return i;
+    }
+  }
+  return undefined;
+}
@@ -0,0 +1,154 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { Content } from '@google/genai';
+import {
+  FeedbackEntry,
+  exportRatedPairs,
+  getLastExchange,
+  loadFeedback,
+  parseRating,
+  recordFeedback,
+} from './feedback.js';
+
+const entry = (
+  turn: number,
+  extra: Partial<FeedbackEntry>,
+): FeedbackEntry => ({
+  sessionId: 's1',
+  turn,
+  timestamp: '2025-06-01T10:00:00.000Z',
+  prompt: `Question ${turn}`,
+  response: `Answer ${turn}`,
+  ...extra,
+});
+
+describe('parseRating', () => {
+  it('should read thumbs and scores', () => {
+    expect(
+      ['up', '+', '👍', 'down', '-', '👎', '4', '0', 'meh'].map(parseRating),
+    ).toEqual([
+      'up',
+      'up',
+      'up',
+      'down',
+      'down',
+      'down',
+      4,
+      undefined,
+      undefined,
+    ]);
+  });
+});
+
+describe('getLastExchange', () => {
+  it('should pair the last answer with the prompt before the tool calls', () => {
+    const history: Content[] = [
+      { role: 'user', parts: [{ text: 'Old question' }] },
+      { role: 'model', parts: [{ text: 'Old answer' }] },
+      { role: 'user', parts: [{ text: 'How accurate is run 12?' }] },
+      {
+        role: 'model',
+        parts: [{ functionCall: { name: 'read_file', args: {} } }],
+      },
+      {
+        role: 'user',
+        parts: [{ functionResponse: { name: 'read_file', response: {} } }],
+      },
+      { role: 'model', parts: [{ text: 'thinking', thought: true }] },
+      { role: 'model', parts: [{ text: 'Accuracy is ' }] },
+      { role: 'model', parts: [{ text: '91.2%.' }] },
+    ];
+
+    expect(getLastExchange(history)).toEqual({
+      turn: 2,
+      prompt: 'How accurate is run 12?',
+      response: 'Accuracy is 91.2%.',
+    });
+    expect(getLastExchange(history.slice(0, 1))).toBeUndefined();
+  });
+});
+
+describe('recordFeedback', () => {
+  let tempDir: string;
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'feedback-'));
+  });
+
+  afterEach(() => {
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should merge a note and a new rating into earlier feedback', async () => {
+    const filePath = path.join(tempDir, 'feedback.json');
+    await recordFeedback(filePath, entry(2, { rating: 'down' }));
+    await recordFeedback(filePath, entry(2, { note: 'Wrong split' }));
+    await recordFeedback(filePath, entry(2, { rating: 2 }));
+    await recordFeedback(filePath, entry(4, { rating: 'up' }));
+
+    const entries = await loadFeedback(filePath);
+    expect(entries.map((e) => [e.turn, e.rating, e.note])).toEqual([
+      [2, 2, 'Wrong split'],
+      [4, 'up', undefined],
+    ]);
+  });
+});
+
+describe('exportRatedPairs', () => {
+  const entries = [
+    entry(0, { rating: 'up', model: 'm1' }),
+    entry(2, { rating: 2, note: 'Wrong split' }),
+    entry(4, { rating: 5 }),
+    entry(6, { note: 'Unrated' }),
+  ];
+  const lines = (text: string) =>
+    text
+      .trim()
+      .split('\n')
+      .map((line) => JSON.parse(line));
+
+  it('should export every rated pair with its rating and note', () => {
+    expect(lines(exportRatedPairs(entries, 'jsonl'))).toEqual([
+      {
+        prompt: 'Question 0',
+        response: 'Answer 0',
+        rating: 'up',
+        positive: true,
+        model: 'm1',
+        session: 's1',
+        timestamp: '2025-06-01T10:00:00.000Z',
+      },
+      expect.objectContaining({
+        rating: 2,
+        positive: false,
+        note: 'Wrong split',
+      }),
+      expect.objectContaining({ rating: 5, positive: true }),
+    ]);
+  });
+
+  it('should export positive pairs as chat messages', () => {
+    expect(lines(exportRatedPairs(entries, 'chat'))).toEqual([
+      {
+        messages: [
+          { role: 'user', content: 'Question 0' },
+          { role: 'assistant', content: 'Answer 0' },
+        ],
+      },
+      {
+        messages: [
+          { role: 'user', content: 'Question 4' },
+          { role: 'assistant', content: 'Answer 4' },
+        ],
+      },
+    ]);
+  });
+});
@@ -0,0 +1,199 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import { Content } from '@google/genai';
+import { getProjectTempDir } from './paths.js';
+import { isNodeError } from './errors.js';
+
+const FEEDBACK_FILE = 'feedback.json';
+
+/** A thumb, or a score from 1 (bad) to 5 (good). */
+export type MessageRating = 'up' | 'down' | 1 | 2 | 3 | 4 | 5;
+
+export interface FeedbackEntry {
+  sessionId: string;
+  /** Index in the chat history of the prompt that was answered. */
+  turn: number;
+  /** ISO 8601 time of the last change. */
+  timestamp: string;
+  model?: string;
+  prompt: string;
+  response: string;
+  rating?: MessageRating;
+  note?: string;
+}
+
+export type FeedbackExportFormat = 'jsonl' | 'chat';
+
+/** The last prompt typed by the user and the model's answer to it. */
+export interface ChatExchange {
+  turn: number;
+  prompt: string;
+  response: string;
+}
+
+export function getFeedbackPath(projectRoot: string): string {
+  return path.join(getProjectTempDir(projectRoot), FEEDBACK_FILE);
+}
+
+/** Reads "up", "down", "+", "-", 👍, 👎 or a score from 1 to 5. */
+export function parseRating(value: string): MessageRating | undefined {
+  const trimmed = value.trim().toLowerCase();
+  if (['up', '+', '+1', '👍', 'good'].includes(trimmed)) {
+    return 'up';
+  }
+  if (['down', '-', '-1', '👎', 'bad'].includes(trimmed)) {
+    return 'down';
+  }
+  return /^[1-5]$/.test(trimmed)
+    ? (Number(trimmed) as MessageRating)
+    : undefined;
+}
+
+/** Whether a rating counts as positive: a thumb up, or 4 or 5. */
+export function isPositiveRating(rating: MessageRating): boolean {
+  return rating === 'up' || (typeof rating === 'number' && rating >= 4);
+}
+
+export function formatRating(rating: MessageRating): string {
+  if (typeof rating === 'number') {
+    return `${rating}/5`;
+  }
+  return rating === 'up' ? '👍' : '👎';
+}
+
+const textOf = (content: Content) =>
+  (content.parts ?? [])
+    .filter((part) => part.text && !part.thought)
+    .map((part) => part.text)
+    .join('');
+
+const isPrompt = (content: Content) =>
+  content.role === 'user' &&
+  !content.parts?.some((part) => part.functionResponse) &&
+  textOf(content).trim() !== '';
+
+/**
+ * Finds the last answer in the history, joined across streamed chunks, and
+ * the prompt it answers. Tool calls and their results in between are
+ * skipped.
+ */
+export function getLastExchange(
+  history: Content[],
+): ChatExchange | undefined {
+  let end = history.length - 1;
+  while (
+    end >= 0 &&
+    !(history[end].role === 'model' && textOf(history[end]).trim())
+  ) {
+    end--;
+  }
+  if (end < 0) {
+    return undefined;
+  }
+  let start = end;
+  while (start > 0 && history[start - 1].role === 'model') {
+    start--;
+  }
+  const response = history
+    .slice(start, end + 1)
+    .map(textOf)
+    .join('')
+    .trim();
+  let turn = start - 1;
+  while (turn >= 0 && !isPrompt(history[turn])) {
+    turn--;
+  }
+  return {
+    turn: Math.max(turn, 0),
+    prompt: turn >= 0 ? textOf(history[turn]).trim() : '',
+    response,
+  };
+}
+
+export async function loadFeedback(
+  filePath: string,
+): Promise<FeedbackEntry[]> {
+  try {
+    const data = JSON.parse(await fs.promises.readFile(filePath, 'utf8'));
+    return Array.isArray(data) ? data : [];
+  } catch (error) {
+    if (isNodeError(error) && error.code === 'ENOENT') {
+      return [];
+    }
+    throw error;
+  }
+}
+
+/**
+ * Saves feedback on an answer. Feedback on the same answer is merged, so
+ * a note can be added after the rating and a rating can be changed.
+ */
+export async function recordFeedback(
+  filePath: string,
+  entry: FeedbackEntry,
+): Promise<FeedbackEntry> {
+  const entries = await loadFeedback(filePath);
+  const index = entries.findIndex(
+    (e) => e.sessionId === entry.sessionId && e.turn === entry.turn,
+  );
+  const merged: FeedbackEntry =
+    index >= 0
+      ? {
+          ...entries[index],
+          ...entry,
+          rating: entry.rating ?? entries[index].rating,
+          note: entry.note ?? entries[index].note,
+        }
+      : entry;
+  if (index >= 0) {
+    entries[index] = merged;
+  } else {
+    entries.push(merged);
+  }
+  await fs.promises.mkdir(path.dirname(filePath), { recursive: true });
+  await fs.promises.writeFile(
+    filePath,
+    JSON.stringify(entries, null, 2),
+    'utf8',
+  );
+  return merged;
+}
+
+/**
+ * Renders rated answers as JSON Lines. `jsonl` keeps every rated pair with
+ * its rating and note, for evaluation. `chat` keeps only positively rated
+ * pairs, as `{"messages": [...]}` records for fine-tuning.
+ */
+export function exportRatedPairs(
+  entries: FeedbackEntry[],
+  format: FeedbackExportFormat,
+): string {
+  const rated = entries.filter((entry) => entry.rating !== undefined);
+  const records =
+    format === 'chat'
+      ? rated
+          .filter((entry) => isPositiveRating(entry.rating!))
+          .map((entry) => ({
+            messages: [
+              { role: 'user', content: entry.prompt },
+              { role: 'assistant', content: entry.response },
+            ],
+          }))
+      : rated.map((entry) => ({
+          prompt: entry.prompt,
+          response: entry.response,
+          rating: entry.rating,
+          positive: isPositiveRating(entry.rating!),
+          ...(entry.note ? { note: entry.note } : {}),
+          ...(entry.model ? { model: entry.model } : {}),
+          session: entry.sessionId,
+          timestamp: entry.timestamp,
+        }));
+  return records.map((record) => `${JSON.stringify(record)}\n`).join('');
+}
@@ -22,6 +22,7 @@ import mime from 'mime-types';
 
 import {
   isWithinRoot,
+  normalizeWindowsPath,
   isBinaryFile,
   detectFileType,
   processSingleFileContent,
@@ -127,6 +128,34 @@ describe('fileUtils', () => {
       const rootSuper = path.resolve('/project/root/sub');
       expect(isWithinRoot(pathToCheckSuper, rootSuper)).toBe(false);
     });
+
+    it('should compare Windows paths regardless of case and separators', () => {
+      const winRoot = 'C:\\Users\\ada\\Project';
+      expect(
+        isWithinRoot('c:/users/Ada/project/src/a.ts', winRoot, 'win32'),
+      ).toBe(true);
+      expect(
+        isWithinRoot('\\\\?\\C:\\Users\\ada\\Project\\a.ts', winRoot, 'win32'),
+      ).toBe(true);
+      expect(
+        isWithinRoot('C:\\Users\\ada\\Project2\\a.ts', winRoot, 'win32'),
+      ).toBe(false);
+      expect(
+        isWithinRoot('D:\\Users\\ada\\Project\\a.ts', winRoot, 'win32'),
+      ).toBe(false);
+      expect(isWithinRoot('C:\\a.ts', 'c:\\', 'win32')).toBe(true);
+    });
+  });
+
+  describe('normalizeWindowsPath', () => {
+    it('should drop the long-path prefix', () => {
+      expect(normalizeWindowsPath('\\\\?\\C:\\Data\\x.csv')).toBe(
+        'c:\\data\\x.csv',
+      );
+      expect(normalizeWindowsPath('//?/UNC/Server/Share/x.csv')).toBe(
+        '\\\\server\\share\\x.csv',
+      );
+    });
   });
 
   describe('isBinaryFile', () => {
@@ -216,6 +245,10 @@ describe('fileUtils', () => {
       expect(detectFileType('image.icon.svg')).toBe('svg');
     });
 
+    it('should detect notebook type by extension', () => {
+      expect(detectFileType('analysis.ipynb')).toBe('notebook');
+    });
+
     it('should detect pdf type by extension', () => {
       mockMimeLookup.mockReturnValueOnce('application/pdf');
       expect(detectFileType('file.pdf')).toBe('pdf');
@@ -380,6 +413,39 @@ describe('fileUtils', () => {
       expect(result.returnDisplay).toContain('Read SVG as text');
     });
 
+    it('should render a notebook as numbered cells', async () => {
+      const testNotebookPath = path.join(tempRootDir, 'analysis.ipynb');
+      actualNodeFs.writeFileSync(
+        testNotebookPath,
+        JSON.stringify({
+          cells: [
+            { cell_type: 'markdown', metadata: {}, source: ['# Title'] },
+            {
+              cell_type: 'code',
+              execution_count: 1,
+              metadata: {},
+              outputs: [{ output_type: 'stream', text: ['3\n'] }],
+              source: ['print(1 + 2)'],
+            },
+          ],
+          metadata: { language_info: { name: 'python' } },
+          nbformat: 4,
+          nbformat_minor: 5,
+        }),
+      );
+
+      const result = await processSingleFileContent(
+        testNotebookPath,
+        tempRootDir,
+      );
+
+      expect(result.llmContent).toContain('--- Cell 2 [code] In [1] ---');
+      expect(result.llmContent).toContain('print(1 + 2)');
+      expect(result.returnDisplay).toBe(
+        'Read notebook with 2 cells: analysis.ipynb',
+      );
+    });
+
     it('should skip binary files', async () => {
       actualNodeFs.writeFileSync(
         testBinaryFilePath,
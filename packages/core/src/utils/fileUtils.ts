@@ -8,6 +8,7 @@ import fs from 'fs';
 import path from 'path';
 import { PartUnion } from '@google/genai';
 import mime from 'mime-types';
+import { parseNotebook, renderNotebook } from './notebook.js';
 
 // Constants for text file processing
 const DEFAULT_MAX_LINES_TEXT_FILE = 2000;
@@ -26,26 +27,45 @@ export function getSpecificMimeType(filePath: string): string | undefined {
   return typeof lookedUpMime === 'string' ? lookedUpMime : undefined;
 }
 
+/**
+ * Normalizes a Windows path for comparison: forward slashes become
+ * backslashes, the `\\?\` long-path prefix is dropped and, since Windows
+ * paths are case-insensitive, the path is lowercased.
+ */
+export function normalizeWindowsPath(filePath: string): string {
+  const withoutPrefix = filePath
+    .replace(/^[\\/]{2}\?[\\/]UNC[\\/]/i, '\\\\')
+    .replace(/^[\\/]{2}\?[\\/]/, '');
+  return path.win32.normalize(withoutPrefix).toLowerCase();
+}
+
 /**
  * Checks if a path is within a given root directory.
  * @param pathToCheck The absolute path to check.
  * @param rootDirectory The absolute root directory.
+ * @param platform The platform whose path rules apply, the current one by default.
  * @returns True if the path is within the root directory, false otherwise.
  */
 export function isWithinRoot(
   pathToCheck: string,
   rootDirectory: string,
+  platform: NodeJS.Platform = process.platform,
 ): boolean {
-  const normalizedPathToCheck = path.normalize(pathToCheck);
-  const normalizedRootDirectory = path.normalize(rootDirectory);
+  const isWindows = platform === 'win32';
+  const sep = isWindows ? path.win32.sep : path.posix.sep;
+  const normalizedPathToCheck = isWindows
+    ? normalizeWindowsPath(pathToCheck)
+    : path.posix.normalize(pathToCheck);
+  const normalizedRootDirectory = isWindows
+    ? normalizeWindowsPath(rootDirectory)
+    : path.posix.normalize(rootDirectory);
 
   // Ensure the rootDirectory path ends with a separator for correct startsWith comparison,
   // unless it's the root path itself (e.g., '/' or 'C:\').
   const rootWithSeparator =
-    normalizedRootDirectory === path.sep ||
-    normalizedRootDirectory.endsWith(path.sep)
+    normalizedRootDirectory === sep || normalizedRootDirectory.endsWith(sep)
       ? normalizedRootDirectory
-      : normalizedRootDirectory + path.sep;
+      : normalizedRootDirectory + sep;
 
   return (
     normalizedPathToCheck === normalizedRootDirectory ||
@@ -94,11 +114,19 @@ export function isBinaryFile(filePath: string): boolean {
 /**
  * Detects the type of file based on extension and content.
  * @param filePath Path to the file.
- * @returns 'text', 'image', 'pdf', 'audio', 'video', or 'binary'.
+ * @returns 'text', 'image', 'pdf', 'audio', 'video', 'notebook', or 'binary'.
  */
 export function detectFileType(
   filePath: string,
-): 'text' | 'image' | 'pdf' | 'audio' | 'video' | 'binary' | 'svg' {
+):
+  | 'text'
+  | 'image'
+  | 'pdf'
+  | 'audio'
+  | 'video'
+  | 'binary'
+  | 'svg'
+  | 'notebook' {
   const ext = path.extname(filePath).toLowerCase();
 
   // The mimetype for "ts" is MPEG transport stream (a video format) but we want
@@ -111,6 +139,10 @@ export function detectFileType(
     return 'svg';
   }
 
+  if (ext === '.ipynb') {
+    return 'notebook';
+  }
+
   const lookedUpMimeType = mime.lookup(filePath); // Returns false if not found, or the mime type string
   if (lookedUpMimeType) {
     if (lookedUpMimeType.startsWith('image/')) {
@@ -182,6 +214,56 @@ export interface ProcessedFileReadResult {
   linesShown?: [number, number]; // For text files [startLine, endLine] (1-based for display)
 }
 
+/**
+ * The lines `offset` to `offset + limit` of a text file's content, with
+ * overlong lines cut, as the read tools return them.
+ */
+export function processTextContent(
+  content: string,
+  offset?: number,
+  limit?: number,
+): ProcessedFileReadResult {
+  const lines = content.split('\n');
+  const originalLineCount = lines.length;
+
+  const startLine = offset || 0;
+  const effectiveLimit =
+    limit === undefined ? DEFAULT_MAX_LINES_TEXT_FILE : limit;
+  // Ensure endLine does not exceed originalLineCount
+  const endLine = Math.min(startLine + effectiveLimit, originalLineCount);
+  // Ensure selectedLines doesn't try to slice beyond array bounds if startLine is too high
+  const actualStartLine = Math.min(startLine, originalLineCount);
+  const selectedLines = lines.slice(actualStartLine, endLine);
+
+  let linesWereTruncatedInLength = false;
+  const formattedLines = selectedLines.map((line) => {
+    if (line.length > MAX_LINE_LENGTH_TEXT_FILE) {
+      linesWereTruncatedInLength = true;
+      return line.substring(0, MAX_LINE_LENGTH_TEXT_FILE) + '... [truncated]';
+    }
+    return line;
+  });
+
+  const contentRangeTruncated = endLine < originalLineCount;
+  const isTruncated = contentRangeTruncated || linesWereTruncatedInLength;
+
+  let llmTextContent = '';
+  if (contentRangeTruncated) {
+    llmTextContent += `[File content truncated: showing lines ${actualStartLine + 1}-${endLine} of ${originalLineCount} total lines. Use offset/limit parameters to view more.]\n`;
+  } else if (linesWereTruncatedInLength) {
+    llmTextContent += `[File content partially truncated: some lines exceeded maximum length of ${MAX_LINE_LENGTH_TEXT_FILE} characters.]\n`;
+  }
+  llmTextContent += formattedLines.join('\n');
+
+  return {
+    llmContent: llmTextContent,
+    returnDisplay: isTruncated ? '(truncated)' : '',
+    isTruncated,
+    originalLineCount,
+    linesShown: [actualStartLine + 1, endLine],
+  };
+}
+
 /**
  * Reads and processes a single file, handling text, images, and PDFs.
  * @param filePath Absolute path to the file.
@@ -253,50 +335,20 @@ export async function processSingleFileContent(
           returnDisplay: `Read SVG as text: ${relativePathForDisplay}`,
         };
       }
-      case 'text': {
+      case 'notebook': {
+        // Raw notebook JSON buries the cells in metadata and base64 outputs,
+        // so the model gets numbered cells instead.
         const content = await fs.promises.readFile(filePath, 'utf8');
-        const lines = content.split('\n');
-        const originalLineCount = lines.length;
-
-        const startLine = offset || 0;
-        const effectiveLimit =
-          limit === undefined ? DEFAULT_MAX_LINES_TEXT_FILE : limit;
-        // Ensure endLine does not exceed originalLineCount
-        const endLine = Math.min(startLine + effectiveLimit, originalLineCount);
-        // Ensure selectedLines doesn't try to slice beyond array bounds if startLine is too high
-        const actualStartLine = Math.min(startLine, originalLineCount);
-        const selectedLines = lines.slice(actualStartLine, endLine);
-
-        let linesWereTruncatedInLength = false;
-        const formattedLines = selectedLines.map((line) => {
-          if (line.length > MAX_LINE_LENGTH_TEXT_FILE) {
-            linesWereTruncatedInLength = true;
-            return (
-              line.substring(0, MAX_LINE_LENGTH_TEXT_FILE) + '... [truncated]'
-            );
-          }
-          return line;
-        });
-
-        const contentRangeTruncated = endLine < originalLineCount;
-        const isTruncated = contentRangeTruncated || linesWereTruncatedInLength;
-
-        let llmTextContent = '';
-        if (contentRangeTruncated) {
-          llmTextContent += `[File content truncated: showing lines ${actualStartLine + 1}-${endLine} of ${originalLineCount} total lines. Use offset/limit parameters to view more.]\n`;
-        } else if (linesWereTruncatedInLength) {
-          llmTextContent += `[File content partially truncated: some lines exceeded maximum length of ${MAX_LINE_LENGTH_TEXT_FILE} characters.]\n`;
-        }
-        llmTextContent += formattedLines.join('\n');
-
+        const notebook = parseNotebook(content);
         return {
-          llmContent: llmTextContent,
-          returnDisplay: isTruncated ? '(truncated)' : '',
-          isTruncated,
-          originalLineCount,
-          linesShown: [actualStartLine + 1, endLine],
+          llmContent: renderNotebook(notebook),
+          returnDisplay: `Read notebook with ${notebook.cells.length} cells: ${relativePathForDisplay}`,
         };
       }
+      case 'text': {
+        const content = await fs.promises.readFile(filePath, 'utf8');
+        return processTextContent(content, offset, limit);
+      }
       case 'image':
       case 'pdf':
       case 'audio':
@@ -0,0 +1,20 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+/**
+ * Process-wide incognito flag. While it is set, prompts, saved chats, shell
+ * history and tool checkpoints are kept in memory only and providers that
+ * support it are asked not to retain request data.
+ */
+let incognito = false;
+
+export function setIncognitoMode(enabled: boolean): void {
+  incognito = enabled;
+}
+
+export function isIncognitoMode(): boolean {
+  return incognito;
+}
@@ -0,0 +1,36 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect } from 'vitest';
+import { renderJsonTree } from './jsonTree.js';
+
+describe('renderJsonTree', () => {
+  it('should expand shallow nodes and collapse deep ones', () => {
+    const tree = renderJsonTree(
+      { name: 'run-1', tags: [], metrics: { loss: [0.9, 0.5], ok: true } },
+      { maxDepth: 2 },
+    );
+    expect(tree).toBe(
+      [
+        '▾ {3 keys}',
+        '    name: "run-1"',
+        '    tags: []',
+        '  ▾ metrics: {2 keys}',
+        '    ▸ loss: [2 items]',
+        '      ok: true',
+      ].join('\n'),
+    );
+  });
+
+  it('should fold long arrays and cut long strings', () => {
+    const tree = renderJsonTree(
+      { ids: [1, 2, 3, 4], note: 'abcdefgh' },
+      { maxChildren: 2, maxStringLength: 3 },
+    );
+    expect(tree).toContain('      [1]: 2\n      … 2 more');
+    expect(tree).toContain('note: "abc…"');
+  });
+});
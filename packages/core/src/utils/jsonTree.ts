@@ -0,0 +1,91 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+export interface JsonTreeOptions {
+  /** Levels below this are collapsed to a one-line summary. */
+  maxDepth?: number;
+  /** Array items and object keys shown per node before the rest fold. */
+  maxChildren?: number;
+  /** Strings longer than this are cut. */
+  maxStringLength?: number;
+}
+
+const DEFAULTS: Required<JsonTreeOptions> = {
+  maxDepth: 3,
+  maxChildren: 10,
+  maxStringLength: 80,
+};
+
+function scalar(value: unknown, maxStringLength: number): string {
+  if (typeof value === 'string') {
+    const text =
+      value.length > maxStringLength
+        ? `${value.slice(0, maxStringLength)}…`
+        : value;
+    return JSON.stringify(text);
+  }
+  return String(value);
+}
+
+function summary(value: unknown): string {
+  if (Array.isArray(value)) {
+    return `[${value.length} item${value.length === 1 ? '' : 's'}]`;
+  }
+  const keys = Object.keys(value as object);
+  return `{${keys.length} key${keys.length === 1 ? '' : 's'}}`;
+}
+
+function isContainer(value: unknown): value is object {
+  return typeof value === 'object' && value !== null;
+}
+
+/**
+ * Renders a JSON value as an indented tree. Nodes deeper than `maxDepth`
+ * are shown collapsed (▸) with their size, expanded nodes get ▾, and long
+ * arrays and objects list their first children only.
+ */
+export function renderJsonTree(
+  value: unknown,
+  options: JsonTreeOptions = {},
+): string {
+  const { maxDepth, maxChildren, maxStringLength } = {
+    ...DEFAULTS,
+    ...options,
+  };
+  const lines: string[] = [];
+
+  const visit = (label: string, node: unknown, depth: number) => {
+    const indent = '  '.repeat(depth);
+    const prefix = label ? `${label}: ` : '';
+    if (!isContainer(node)) {
+      lines.push(`${indent}  ${prefix}${scalar(node, maxStringLength)}`);
+      return;
+    }
+    const entries = Array.isArray(node)
+      ? node.map((child, i) => [String(i), child] as const)
+      : Object.entries(node);
+    if (entries.length === 0) {
+      lines.push(`${indent}  ${prefix}${Array.isArray(node) ? '[]' : '{}'}`);
+      return;
+    }
+    if (depth >= maxDepth) {
+      lines.push(`${indent}▸ ${prefix}${summary(node)}`);
+      return;
+    }
+    lines.push(`${indent}▾ ${prefix}${summary(node)}`);
+    for (const [key, child] of entries.slice(0, maxChildren)) {
+      visit(Array.isArray(node) ? `[${key}]` : key, child, depth + 1);
+    }
+    if (entries.length > maxChildren) {
+      lines.push(
+        `${'  '.repeat(depth + 1)}  … ${entries.length - maxChildren} more`,
+      );
+    }
+  };
+
+  visit('', value, 0);
+  return lines.join('\n');
+}
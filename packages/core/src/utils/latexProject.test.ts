@@ -0,0 +1,118 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import {
+  checkLatexProject,
+  findMainTexFile,
+  formatLatexProjectContext,
+  getLatexConventions,
+  parseLatexProject,
+} from './latexProject.js';
+
+const MAIN = String.raw`\documentclass{article}
+\usepackage{natbib}
+\begin{document}
+\section{Introduction}\label{sec:intro}
+Transformers~\citep{vaswani2017} dominate; see \cref{sec:method,sec:results}.
+% \cite{commented-out}
+\input{sections/method}
+\include{sections/missing}
+\bibliography{refs}
+\end{document}
+`;
+
+const METHOD = String.raw`\section{Method}
+\label{sec:method}
+We follow \citet{devlin2019} and \citep{unknown2020}.
+\subsection{Setup}\label{sec:setup}
+\begin{figure}\label{fig:arch}\end{figure}
+\label{sec:setup}
+`;
+
+describe('latexProject', () => {
+  let tempDir: string;
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'latex-project-'));
+    fs.mkdirSync(path.join(tempDir, 'sections'));
+    fs.writeFileSync(path.join(tempDir, 'main.tex'), MAIN);
+    fs.writeFileSync(path.join(tempDir, 'sections', 'method.tex'), METHOD);
+    fs.writeFileSync(
+      path.join(tempDir, 'refs.bib'),
+      '@article{vaswani2017, title={Attention}}\n@inproceedings{devlin2019, title={BERT}}\n',
+    );
+  });
+
+  afterEach(() => {
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should prefer main.tex among files with a \\documentclass', async () => {
+    fs.writeFileSync(
+      path.join(tempDir, 'appendix.tex'),
+      '\\documentclass{article}',
+    );
+    expect(await findMainTexFile(tempDir)).toBe('main.tex');
+    expect(await findMainTexFile(path.join(tempDir, 'none'))).toBeUndefined();
+  });
+
+  it('should follow includes and collect sections, labels and citations', async () => {
+    const project = (await parseLatexProject(tempDir))!;
+
+    expect(project.files).toEqual([
+      'main.tex',
+      path.join('sections', 'method.tex'),
+    ]);
+    expect(
+      project.sections.map((s) => [s.level, s.title, s.label, s.line]),
+    ).toEqual([
+      [2, 'Introduction', 'sec:intro', 4],
+      [2, 'Method', 'sec:method', 1],
+      [3, 'Setup', 'sec:setup', 4],
+    ]);
+    expect(project.citations.map((c) => c.key)).toEqual([
+      'vaswani2017',
+      'devlin2019',
+      'unknown2020',
+    ]);
+    expect(project.bibFiles).toEqual(['refs.bib']);
+    expect(project.bibKeys).toEqual(['vaswani2017', 'devlin2019']);
+  });
+
+  it('should warn about undefined references, citations and labels', async () => {
+    const project = (await parseLatexProject(tempDir))!;
+
+    expect(
+      checkLatexProject(project).map((w) => [w.kind, w.file, w.line]),
+    ).toEqual([
+      ['undefined-reference', 'main.tex', 5],
+      ['missing-file', 'main.tex', 8],
+      ['undefined-citation', path.join('sections', 'method.tex'), 3],
+      ['duplicate-label', path.join('sections', 'method.tex'), 6],
+    ]);
+  });
+
+  it('should describe the conventions for the model', async () => {
+    const project = (await parseLatexProject(tempDir))!;
+
+    expect(getLatexConventions(project)).toEqual({
+      citeCommand: 'citep',
+      refCommand: 'cref',
+      labelPrefixes: ['sec:', 'fig:'],
+    });
+    const context = formatLatexProjectContext(project);
+    expect(context).toContain('The main file is main.tex');
+    expect(context).toContain('- Introduction (sec:intro) [main.tex:4]');
+    expect(context).toContain(
+      'cite with \\citep{...} and reference with \\cref{...}',
+    );
+    expect(context).toContain('only cite keys from refs.bib (2 entries)');
+  });
+});
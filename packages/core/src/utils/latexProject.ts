@@ -0,0 +1,403 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import { isNodeError } from './errors.js';
+import {
+  isBibtexEntry,
+  parseBibtex,
+} from '../tools/research/bibliography/bibtex.js';
+
+// Preferred names when several files have a \documentclass.
+const MAIN_FILE_NAMES = ['main.tex', 'paper.tex', 'manuscript.tex'];
+const SECTION_COMMANDS = [
+  'part',
+  'chapter',
+  'section',
+  'subsection',
+  'subsubsection',
+  'paragraph',
+];
+// Enough for the model to see the shape of a paper or a thesis.
+const MAX_OUTLINE_SECTIONS = 60;
+
+const SECTION_PATTERN = new RegExp(
+  `\\\\(${SECTION_COMMANDS.join('|')})\\*?(?:\\[[^\\]]*\\])?\\{((?:[^{}]|\\{[^{}]*\\})*)\\}`,
+  'g',
+);
+const LABEL_PATTERN = /\\label\{([^}]+)\}/g;
+const REF_PATTERN =
+  /\\(ref|eqref|pageref|autoref|nameref|vref|cref|Cref|crefrange)\*?\{([^}]+)\}/g;
+const CITE_PATTERN =
+  /\\([a-zA-Z]*cite[a-zA-Z]*)\*?(?:\[[^\]]*\]){0,2}\{([^}]*)\}/g;
+const INPUT_PATTERN = /\\(?:input|include|subfile)\{([^}]+)\}/g;
+const BIBLIOGRAPHY_PATTERN = /\\(bibliography|addbibresource)\{([^}]+)\}/g;
+
+/** A \label, \ref or \cite key and where it appears. */
+export interface LatexKeyUse {
+  key: string;
+  /** The command without the backslash, e.g. `citep` or `cref`. */
+  command: string;
+  /** Path relative to the project root. */
+  file: string;
+  line: number;
+}
+
+export interface LatexSection {
+  /** 0 for \part, 2 for \section, 3 for \subsection and so on. */
+  level: number;
+  title: string;
+  file: string;
+  line: number;
+  /** A \label on the same or the next line. */
+  label?: string;
+}
+
+export interface LatexProject {
+  root: string;
+  /** The file with the \documentclass, relative to the root. */
+  mainFile: string;
+  /** The main file and everything it includes, in reading order. */
+  files: string[];
+  sections: LatexSection[];
+  labels: LatexKeyUse[];
+  refs: LatexKeyUse[];
+  citations: LatexKeyUse[];
+  /** \input or \include targets that do not exist. */
+  missingFiles: LatexKeyUse[];
+  bibFiles: string[];
+  /** Keys of all readable .bib files; undefined when none could be read. */
+  bibKeys?: string[];
+}
+
+export type LatexWarningKind =
+  | 'undefined-reference'
+  | 'undefined-citation'
+  | 'duplicate-label'
+  | 'missing-file';
+
+export interface LatexWarning {
+  kind: LatexWarningKind;
+  message: string;
+  file: string;
+  line: number;
+}
+
+export interface LatexConventions {
+  /** The cite command used most often, e.g. `citep`. */
+  citeCommand: string;
+  refCommand: string;
+  /** Label prefixes such as `sec:`, most used first. */
+  labelPrefixes: string[];
+}
+
+/** Blanks out comments, keeping line numbers and escaped \% signs. */
+function stripComments(source: string): string {
+  return source.replace(/(^|[^\\])%.*$/gm, '$1');
+}
+
+/** Maps an offset in `source` to its 1-based line number. */
+function lineLocator(source: string): (index: number) => number {
+  const starts = [0];
+  for (let i = 0; i < source.length; i++) {
+    if (source.charCodeAt(i) === 10) {
+      starts.push(i + 1);
+    }
+  }
+  return (index) => {
+    let low = 0;
+    let high = starts.length - 1;
+    while (low < high) {
+      const mid = Math.ceil((low + high) / 2);
+      if (starts[mid] <= index) {
+        low = mid;
+      } else {
+        high = mid - 1;
+      }
+    }
+    return low + 1;
+  };
+}
+
+function collectKeys(
+  source: string,
+  pattern: RegExp,
+  file: string,
+  lineAt: (index: number) => number,
+): LatexKeyUse[] {
+  const uses: LatexKeyUse[] = [];
+  for (const match of source.matchAll(pattern)) {
+    const line = lineAt(match.index ?? 0);
+    for (const key of match[2].split(',')) {
+      if (key.trim()) {
+        uses.push({ key: key.trim(), command: match[1], file, line });
+      }
+    }
+  }
+  return uses;
+}
+
+/**
+ * Finds the main file of a LaTeX manuscript in `root`: the .tex file with
+ * a \documentclass, preferring main.tex and similar names.
+ */
+export async function findMainTexFile(
+  root: string,
+): Promise<string | undefined> {
+  let names: string[];
+  try {
+    names = await fs.promises.readdir(root);
+  } catch (error) {
+    if (
+      isNodeError(error) &&
+      (error.code === 'ENOENT' || error.code === 'ENOTDIR')
+    ) {
+      return undefined;
+    }
+    throw error;
+  }
+  const candidates: string[] = [];
+  for (const name of names.filter((n) => n.endsWith('.tex')).sort()) {
+    const source = stripComments(
+      await fs.promises.readFile(path.join(root, name), 'utf8'),
+    );
+    if (/\\documentclass\b/.test(source)) {
+      candidates.push(name);
+    }
+  }
+  return (
+    candidates.find((name) => MAIN_FILE_NAMES.includes(name)) ?? candidates[0]
+  );
+}
+
+/**
+ * Parses the LaTeX manuscript in `root`, following \input, \include and
+ * \subfile from the main file, and reads the keys of its .bib files.
+ * Returns undefined when the directory has no manuscript.
+ */
+export async function parseLatexProject(
+  root: string,
+): Promise<LatexProject | undefined> {
+  const mainFile = await findMainTexFile(root);
+  if (!mainFile) {
+    return undefined;
+  }
+  const project: LatexProject = {
+    root,
+    mainFile,
+    files: [],
+    sections: [],
+    labels: [],
+    refs: [],
+    citations: [],
+    missingFiles: [],
+    bibFiles: [],
+  };
+
+  const visit = async (file: string) => {
+    if (project.files.includes(file)) {
+      return;
+    }
+    project.files.push(file);
+    const source = stripComments(
+      await fs.promises.readFile(path.join(root, file), 'utf8'),
+    );
+    const lines = source.split('\n');
+    const lineAt = lineLocator(source);
+
+    for (const match of source.matchAll(SECTION_PATTERN)) {
+      const line = lineAt(match.index ?? 0);
+      const label = `${lines[line - 1]}\n${lines[line] ?? ''}`.match(
+        /\\label\{([^}]+)\}/,
+      );
+      project.sections.push({
+        level: SECTION_COMMANDS.indexOf(match[1]),
+        title: match[2].replace(/\s+/g, ' ').trim(),
+        file,
+        line,
+        label: label?.[1],
+      });
+    }
+    for (const match of source.matchAll(LABEL_PATTERN)) {
+      project.labels.push({
+        key: match[1].trim(),
+        command: 'label',
+        file,
+        line: lineAt(match.index ?? 0),
+      });
+    }
+    project.refs.push(...collectKeys(source, REF_PATTERN, file, lineAt));
+    project.citations.push(
+      ...collectKeys(source, CITE_PATTERN, file, lineAt).filter(
+        (use) => use.key !== '*',
+      ),
+    );
+    const bibliographies = collectKeys(
+      source,
+      BIBLIOGRAPHY_PATTERN,
+      file,
+      lineAt,
+    );
+    for (const use of bibliographies) {
+      const bibFile = use.key.endsWith('.bib') ? use.key : `${use.key}.bib`;
+      if (!project.bibFiles.includes(bibFile)) {
+        project.bibFiles.push(bibFile);
+      }
+    }
+
+    for (const match of source.matchAll(INPUT_PATTERN)) {
+      const target = match[1].trim();
+      const included = path.normalize(
+        path.extname(target) ? target : `${target}.tex`,
+      );
+      if (fs.existsSync(path.join(root, included))) {
+        await visit(included);
+      } else {
+        project.missingFiles.push({
+          key: included,
+          command: 'input',
+          file,
+          line: lineAt(match.index ?? 0),
+        });
+      }
+    }
+  };
+  await visit(mainFile);
+
+  for (const bibFile of project.bibFiles) {
+    try {
+      const items = parseBibtex(
+        await fs.promises.readFile(path.join(root, bibFile), 'utf8'),
+      );
+      project.bibKeys = [
+        ...(project.bibKeys ?? []),
+        ...items.filter(isBibtexEntry).map((entry) => entry.key),
+      ];
+    } catch {
+      // A missing .bib is reported by LaTeX itself; skip the citation check
+    }
+  }
+  return project;
+}
+
+/**
+ * Undefined references and citations, duplicate labels and missing
+ * included files, in file order.
+ */
+export function checkLatexProject(project: LatexProject): LatexWarning[] {
+  const warnings: LatexWarning[] = [];
+  const labels = new Set(project.labels.map((use) => use.key));
+
+  const seen = new Set<string>();
+  for (const use of project.labels) {
+    if (seen.has(use.key)) {
+      warnings.push({
+        kind: 'duplicate-label',
+        message: `Label "${use.key}" is defined more than once.`,
+        file: use.file,
+        line: use.line,
+      });
+    }
+    seen.add(use.key);
+  }
+  for (const use of project.refs) {
+    if (!labels.has(use.key)) {
+      warnings.push({
+        kind: 'undefined-reference',
+        message: `\\${use.command}{${use.key}} refers to an undefined label.`,
+        file: use.file,
+        line: use.line,
+      });
+    }
+  }
+  if (project.bibKeys) {
+    const bibKeys = new Set(project.bibKeys);
+    for (const use of project.citations) {
+      if (!bibKeys.has(use.key)) {
+        warnings.push({
+          kind: 'undefined-citation',
+          message: `\\${use.command}{${use.key}} is not in ${project.bibFiles.join(', ')}.`,
+          file: use.file,
+          line: use.line,
+        });
+      }
+    }
+  }
+  for (const use of project.missingFiles) {
+    warnings.push({
+      kind: 'missing-file',
+      message: `Included file ${use.key} does not exist.`,
+      file: use.file,
+      line: use.line,
+    });
+  }
+
+  const order = (w: LatexWarning) => project.files.indexOf(w.file);
+  return warnings.sort((a, b) => order(a) - order(b) || a.line - b.line);
+}
+
+function mostUsed(values: string[], fallback: string): string[] {
+  const counts = new Map<string, number>();
+  for (const value of values) {
+    counts.set(value, (counts.get(value) ?? 0) + 1);
+  }
+  const sorted = [...counts.entries()]
+    .sort((a, b) => b[1] - a[1])
+    .map(([value]) => value);
+  return sorted.length > 0 ? sorted : [fallback];
+}
+
+export function getLatexConventions(project: LatexProject): LatexConventions {
+  return {
+    citeCommand: mostUsed(
+      project.citations
+        .map((use) => use.command)
+        .filter((command) => command !== 'nocite'),
+      'cite',
+    )[0],
+    refCommand: mostUsed(
+      project.refs
+        .map((use) => use.command)
+        .filter((command) => command !== 'eqref' && command !== 'pageref'),
+      'ref',
+    )[0],
+    labelPrefixes: mostUsed(
+      project.labels
+        .map((use) => use.key.match(/^([a-zA-Z]+:)/)?.[1] ?? '')
+        .filter(Boolean),
+      '',
+    ).filter(Boolean),
+  };
+}
+
+/**
+ * Describes the manuscript for the model: its files, outline and
+ * conventions, so edits keep to them.
+ */
+export function formatLatexProjectContext(project: LatexProject): string {
+  const conventions = getLatexConventions(project);
+  const minLevel = Math.min(
+    ...project.sections.map((section) => section.level),
+  );
+  const outline = project.sections
+    .slice(0, MAX_OUTLINE_SECTIONS)
+    .map(
+      (section) =>
+        `${'  '.repeat(section.level - minLevel)}- ${section.title}${section.label ? ` (${section.label})` : ''} [${section.file}:${section.line}]`,
+    );
+  if (project.sections.length > MAX_OUTLINE_SECTIONS) {
+    outline.push(
+      `  ... ${project.sections.length - MAX_OUTLINE_SECTIONS} more sections`,
+    );
+  }
+  return [
+    `This directory contains a LaTeX manuscript. The main file is ${project.mainFile}${project.files.length > 1 ? `; it includes ${project.files.slice(1).join(', ')}` : ''}.`,
+    ...(outline.length > 0 ? ['Outline:', ...outline] : []),
+    `Conventions: cite with \\${conventions.citeCommand}{...} and reference with \\${conventions.refCommand}{...}${conventions.labelPrefixes.length > 0 ? `; labels use the prefixes ${conventions.labelPrefixes.join(', ')}` : ''}.`,
+    `When editing the manuscript, keep to these conventions, only \\ref labels that exist${project.bibKeys ? ` and only cite keys from ${project.bibFiles.join(', ')} (${project.bibKeys.length} entries)` : ''}. Ask before adding new bibliography entries.`,
+  ].join('\n');
+}
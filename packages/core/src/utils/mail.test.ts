@@ -0,0 +1,188 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, afterEach } from 'vitest';
+import fs from 'node:fs';
+import net from 'node:net';
+import os from 'node:os';
+import path from 'node:path';
+import {
+  buildMimeMessage,
+  getMailAddress,
+  MailMessage,
+  sendMail,
+} from './mail.js';
+
+const MESSAGE: MailMessage = {
+  to: ['Ada <ada@lab.org>', 'bob@lab.org'],
+  subject: 'Session summary – ablations',
+  text: 'Accuracy is 91.2%.\n.hidden line',
+  html: '<p>Accuracy is 91.2%.</p>',
+  attachments: [
+    {
+      filename: 'conversation.html',
+      content: '<!DOCTYPE html>',
+      contentType: 'text/html',
+    },
+  ],
+};
+
+const decodeParts = (data: string) =>
+  [
+    ...data.matchAll(
+      /base64\r\n(?:Content-Disposition[^\r]*\r\n)?\r\n([\s\S]*?)\r\n--/g,
+    ),
+  ].map((m) =>
+    Buffer.from(m[1].replace(/\r\n/g, ''), 'base64').toString('utf8'),
+  );
+
+describe('buildMimeMessage', () => {
+  it('should build a multipart message with an attachment', () => {
+    const data = buildMimeMessage(
+      'Research CLI <me@lab.org>',
+      MESSAGE,
+      new Date('2025-06-01T12:00:00Z'),
+    );
+
+    expect(data).toContain('From: Research CLI <me@lab.org>\r\n');
+    expect(data).toContain('To: Ada <ada@lab.org>, bob@lab.org\r\n');
+    expect(data).toContain(
+      `Subject: =?UTF-8?B?${Buffer.from(MESSAGE.subject).toString('base64')}?=\r\n`,
+    );
+    expect(data).toContain('Date: Sun, 01 Jun 2025 12:00:00 +0000\r\n');
+    expect(data).toMatch(/Message-ID: <[0-9a-f]+@lab\.org>/);
+    expect(data).toContain('Content-Type: multipart/mixed;');
+    expect(data).toContain('Content-Type: multipart/alternative;');
+    expect(data).toContain(
+      'Content-Disposition: attachment; filename="conversation.html"',
+    );
+    expect(decodeParts(data)).toEqual([
+      MESSAGE.text,
+      MESSAGE.html,
+      '<!DOCTYPE html>',
+    ]);
+    expect(data.split('\r\n').every((line) => line.length <= 998)).toBe(true);
+  });
+
+  it('should read bare addresses', () => {
+    expect(getMailAddress('Ada <ada@lab.org>')).toBe('ada@lab.org');
+    expect(getMailAddress(' bob@lab.org ')).toBe('bob@lab.org');
+  });
+});
+
+describe('sendMail', () => {
+  let server: net.Server | undefined;
+  let tempDir: string | undefined;
+
+  afterEach(() => {
+    server?.close();
+    server = undefined;
+    if (tempDir) {
+      fs.rmSync(tempDir, { recursive: true, force: true });
+      tempDir = undefined;
+    }
+  });
+
+  it('should deliver through an SMTP server', async () => {
+    const commands: string[] = [];
+    let data = '';
+    server = net.createServer((socket) => {
+      let inData = false;
+      let buffer = '';
+      socket.write('220 mail.lab.org ESMTP\r\n');
+      socket.on('data', (chunk) => {
+        buffer += chunk.toString();
+        if (inData) {
+          const end = buffer.indexOf('\r\n.\r\n');
+          if (end >= 0) {
+            data = buffer.slice(0, end + 2);
+            buffer = buffer.slice(end + 5);
+            inData = false;
+            socket.write('250 Queued\r\n');
+          }
+          return;
+        }
+        let newline: number;
+        while ((newline = buffer.indexOf('\r\n')) >= 0) {
+          const line = buffer.slice(0, newline);
+          buffer = buffer.slice(newline + 2);
+          commands.push(line);
+          if (line.startsWith('EHLO')) {
+            socket.write('250-mail.lab.org\r\n250 SIZE 10240000\r\n');
+          } else if (line === 'DATA') {
+            inData = true;
+            socket.write('354 Go ahead\r\n');
+            return;
+          } else if (line === 'QUIT') {
+            socket.end('221 Bye\r\n');
+          } else {
+            socket.write('250 OK\r\n');
+          }
+        }
+      });
+    });
+    await new Promise<void>((resolve) => server!.listen(0, resolve));
+    const { port } = server.address() as net.AddressInfo;
+
+    await sendMail(
+      { from: 'me@lab.org', smtp: { host: '127.0.0.1', port } },
+      MESSAGE,
+    );
+
+    expect(commands.filter((c) => !/^(EHLO|QUIT)/.test(c))).toEqual([
+      'MAIL FROM:<me@lab.org>',
+      'RCPT TO:<ada@lab.org>',
+      'RCPT TO:<bob@lab.org>',
+      'DATA',
+    ]);
+    expect(data).toContain('Subject: =?UTF-8?B?');
+    expect(decodeParts(data)[0]).toBe(MESSAGE.text);
+  });
+
+  it('should refuse to send a password without TLS', async () => {
+    server = net.createServer((socket) => {
+      socket.write('220 mail.lab.org ESMTP\r\n');
+      socket.on('data', () => socket.write('250 mail.lab.org\r\n'));
+    });
+    await new Promise<void>((resolve) => server!.listen(0, resolve));
+    const { port } = server.address() as net.AddressInfo;
+
+    await expect(
+      sendMail(
+        {
+          from: 'me@lab.org',
+          smtp: { host: '127.0.0.1', port, user: 'me' },
+        },
+        MESSAGE,
+      ),
+    ).rejects.toThrow('127.0.0.1 does not offer TLS');
+  });
+
+  it.skipIf(process.platform === 'win32')(
+    'should hand the message to sendmail',
+    async () => {
+      tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'mail-'));
+      const sendmail = path.join(tempDir, 'sendmail');
+      fs.writeFileSync(
+        sendmail,
+        `#!/bin/sh\nprintf "%s" "$*" > "${tempDir}/args"\ncat > "${tempDir}/message"\n`,
+        { mode: 0o755 },
+      );
+
+      await sendMail(
+        { from: 'Me <me@lab.org>', sendmailPath: sendmail },
+        { ...MESSAGE, attachments: [] },
+      );
+
+      expect(fs.readFileSync(path.join(tempDir, 'args'), 'utf8').trim()).toBe(
+        '-i -f me@lab.org -- ada@lab.org bob@lab.org',
+      );
+      const message = fs.readFileSync(path.join(tempDir, 'message'), 'utf8');
+      expect(message).toContain('From: Me <me@lab.org>\n');
+      expect(message).not.toContain('\r\n');
+    },
+  );
+});
@@ -0,0 +1,329 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { spawn } from 'node:child_process';
+import { randomBytes } from 'node:crypto';
+import net from 'node:net';
+import os from 'node:os';
+import tls from 'node:tls';
+
+export interface SmtpSettings {
+  host: string;
+  /** Defaults to 465 with `secure`, 587 otherwise. */
+  port?: number;
+  /** TLS from the start (port 465). Otherwise STARTTLS is used if offered. */
+  secure?: boolean;
+  user?: string;
+  /** Variable holding the password, by default RESEARCH_SMTP_PASSWORD. */
+  passwordEnv?: string;
+}
+
+export interface MailSettings {
+  /** Sender, e.g. "Research CLI <me@lab.org>". */
+  from: string;
+  /** Default recipients. */
+  to?: string[];
+  /** SMTP server; without it, mail is handed to the local sendmail. */
+  smtp?: SmtpSettings;
+  sendmailPath?: string;
+}
+
+export interface MailAttachment {
+  filename: string;
+  content: string | Buffer;
+  contentType: string;
+}
+
+export interface MailMessage {
+  to: string[];
+  subject: string;
+  text: string;
+  html?: string;
+  attachments?: MailAttachment[];
+}
+
+const DEFAULT_PASSWORD_ENV = 'RESEARCH_SMTP_PASSWORD';
+const DEFAULT_SENDMAIL_PATH = '/usr/sbin/sendmail';
+const DEFAULT_TIMEOUT_MS = 30000;
+
+/** The bare address of "Name <address>" or "address". */
+export function getMailAddress(mailbox: string): string {
+  const match = mailbox.match(/<([^>]+)>/);
+  return (match ? match[1] : mailbox).trim();
+}
+
+function encodeHeader(value: string): string {
+  // eslint-disable-next-line no-control-regex
+  return /^[\x20-\x7e]*$/.test(value)
+    ? value
+    : `=?UTF-8?B?${Buffer.from(value, 'utf8').toString('base64')}?=`;
+}
+
+function base64Lines(content: string | Buffer): string {
+  const encoded = Buffer.from(content).toString('base64');
+  return encoded.replace(/.{1,76}/g, '$&\r\n').trimEnd();
+}
+
+function textPart(contentType: string, content: string): string[] {
+  return [
+    `Content-Type: ${contentType}; charset=utf-8`,
+    'Content-Transfer-Encoding: base64',
+    '',
+    base64Lines(content),
+  ];
+}
+
+/** Builds an RFC 5322 message with CRLF line endings. */
+export function buildMimeMessage(
+  from: string,
+  message: MailMessage,
+  date = new Date(),
+): string {
+  const boundary = () => `=_research_${randomBytes(12).toString('hex')}`;
+  const domain = getMailAddress(from).split('@')[1] ?? os.hostname();
+  const headers = [
+    `From: ${from}`,
+    `To: ${message.to.join(', ')}`,
+    `Subject: ${encodeHeader(message.subject)}`,
+    `Date: ${date.toUTCString().replace('GMT', '+0000')}`,
+    `Message-ID: <${randomBytes(16).toString('hex')}@${domain}>`,
+    'MIME-Version: 1.0',
+  ];
+
+  let body: string[];
+  if (message.html) {
+    const alternative = boundary();
+    body = [
+      `Content-Type: multipart/alternative; boundary="${alternative}"`,
+      '',
+      `--${alternative}`,
+      ...textPart('text/plain', message.text),
+      `--${alternative}`,
+      ...textPart('text/html', message.html),
+      `--${alternative}--`,
+    ];
+  } else {
+    body = textPart('text/plain', message.text);
+  }
+
+  if (message.attachments?.length) {
+    const mixed = boundary();
+    body = [
+      `Content-Type: multipart/mixed; boundary="${mixed}"`,
+      '',
+      `--${mixed}`,
+      ...body,
+      ...message.attachments.flatMap((attachment) => [
+        `--${mixed}`,
+        `Content-Type: ${attachment.contentType}; name="${attachment.filename}"`,
+        'Content-Transfer-Encoding: base64',
+        `Content-Disposition: attachment; filename="${attachment.filename}"`,
+        '',
+        base64Lines(attachment.content),
+      ]),
+      `--${mixed}--`,
+    ];
+  }
+  return [...headers, ...body, ''].join('\r\n');
+}
+
+/** Reads SMTP replies, which may span several "250-" lines. */
+class SmtpReplyReader {
+  private buffer = '';
+  private lines: string[] = [];
+  private waiting?: () => void;
+  private failure?: Error;
+  private socket?: net.Socket;
+
+  private readonly onData = (chunk: string) => {
+    this.buffer += chunk;
+    const lines = this.buffer.split('\r\n');
+    this.buffer = lines.pop() ?? '';
+    this.lines.push(...lines);
+    this.waiting?.();
+  };
+
+  private readonly onError = (error: Error) => {
+    this.failure = error;
+    this.waiting?.();
+  };
+
+  private readonly onClose = () => {
+    this.failure ??= new Error('The mail server closed the connection.');
+    this.waiting?.();
+  };
+
+  constructor(socket: net.Socket) {
+    this.attach(socket);
+  }
+
+  /** Reads from `socket` from now on, e.g. after STARTTLS wraps it. */
+  attach(socket: net.Socket): void {
+    this.socket?.off('data', this.onData);
+    this.socket?.off('error', this.onError);
+    this.socket?.off('close', this.onClose);
+    this.socket = socket;
+    socket.setEncoding('utf8');
+    socket.on('data', this.onData);
+    socket.on('error', this.onError);
+    socket.on('close', this.onClose);
+  }
+
+  async read(): Promise<{ code: number; text: string }> {
+    const reply: string[] = [];
+    for (;;) {
+      while (this.lines.length > 0) {
+        const line = this.lines.shift()!;
+        reply.push(line.slice(4));
+        if (line[3] !== '-') {
+          return { code: Number(line.slice(0, 3)), text: reply.join('\n') };
+        }
+      }
+      if (this.failure) {
+        throw this.failure;
+      }
+      await new Promise<void>((resolve) => (this.waiting = resolve));
+      this.waiting = undefined;
+    }
+  }
+}
+
+async function sendSmtp(
+  smtp: SmtpSettings,
+  from: string,
+  recipients: string[],
+  data: string,
+  timeout: number,
+): Promise<void> {
+  const port = smtp.port ?? (smtp.secure ? 465 : 587);
+  let socket: net.Socket = smtp.secure
+    ? tls.connect({ host: smtp.host, port, servername: smtp.host })
+    : net.connect({ host: smtp.host, port });
+  const limitIdleTime = (target: net.Socket) =>
+    target.setTimeout(timeout, () =>
+      target.destroy(
+        new Error(`The mail server did not answer in ${timeout}ms.`),
+      ),
+    );
+  limitIdleTime(socket);
+  const reader = new SmtpReplyReader(socket);
+
+  const command = async (line: string | undefined, expected: number[]) => {
+    if (line !== undefined) {
+      socket.write(`${line}\r\n`);
+    }
+    const reply = await reader.read();
+    if (!expected.includes(reply.code)) {
+      const shown = line?.startsWith('AUTH') ? 'AUTH' : line;
+      throw new Error(
+        `The mail server answered ${reply.code} ${reply.text}${shown ? ` to ${shown}` : ''}`,
+      );
+    }
+    return reply.text;
+  };
+
+  try {
+    await command(undefined, [220]);
+    const features = await command(`EHLO ${os.hostname()}`, [250]);
+    if (!smtp.secure && /^STARTTLS$/im.test(features)) {
+      await command('STARTTLS', [220]);
+      socket.setTimeout(0);
+      const secured = tls.connect({ socket, servername: smtp.host });
+      await new Promise<void>((resolve, reject) => {
+        secured.once('secureConnect', resolve);
+        secured.once('error', reject);
+      });
+      socket = secured;
+      limitIdleTime(socket);
+      reader.attach(socket);
+      await command(`EHLO ${os.hostname()}`, [250]);
+    }
+    if (smtp.user) {
+      if (!(socket instanceof tls.TLSSocket)) {
+        throw new Error(
+          `${smtp.host} does not offer TLS; not sending the password in clear text.`,
+        );
+      }
+      const passwordEnv = smtp.passwordEnv ?? DEFAULT_PASSWORD_ENV;
+      const password = process.env[passwordEnv];
+      if (!password) {
+        throw new Error(`Set ${passwordEnv} to the SMTP password.`);
+      }
+      await command(
+        `AUTH PLAIN ${Buffer.from(`\0${smtp.user}\0${password}`).toString('base64')}`,
+        [235],
+      );
+    }
+    await command(`MAIL FROM:<${getMailAddress(from)}>`, [250]);
+    for (const recipient of recipients) {
+      await command(`RCPT TO:<${getMailAddress(recipient)}>`, [250, 251]);
+    }
+    await command('DATA', [354]);
+    // Lines starting with a dot are escaped by doubling it; the message
+    // ends with CRLF, so the terminating dot is on a line of its own.
+    await command(`${data.replace(/^\./gm, '..')}.`, [250]);
+    socket.write('QUIT\r\n');
+  } finally {
+    socket.end();
+  }
+}
+
+function sendWithSendmail(
+  sendmailPath: string,
+  from: string,
+  recipients: string[],
+  data: string,
+): Promise<void> {
+  return new Promise((resolve, reject) => {
+    const child = spawn(sendmailPath, [
+      '-i',
+      '-f',
+      getMailAddress(from),
+      '--',
+      ...recipients.map(getMailAddress),
+    ]);
+    let stderr = '';
+    child.stderr.on('data', (chunk) => (stderr += chunk));
+    child.on('error', (error) =>
+      reject(new Error(`Could not run ${sendmailPath}: ${error.message}`)),
+    );
+    child.on('close', (code) =>
+      code === 0
+        ? resolve()
+        : reject(
+            new Error(
+              `${sendmailPath} exited with code ${code}${stderr ? `: ${stderr.trim()}` : ''}`,
+            ),
+          ),
+    );
+    child.stdin.end(data.replace(/\r\n/g, '\n'));
+  });
+}
+
+/**
+ * Sends a message through the configured SMTP server, or the local
+ * sendmail when none is configured.
+ */
+export async function sendMail(
+  settings: MailSettings,
+  message: MailMessage,
+  timeout = DEFAULT_TIMEOUT_MS,
+): Promise<void> {
+  if (message.to.length === 0) {
+    throw new Error('No recipients.');
+  }
+  const data = buildMimeMessage(settings.from, message);
+  if (settings.smtp?.host) {
+    await sendSmtp(settings.smtp, settings.from, message.to, data, timeout);
+  } else {
+    await sendWithSendmail(
+      settings.sendmailPath ?? DEFAULT_SENDMAIL_PATH,
+      settings.from,
+      message.to,
+      data,
+    );
+  }
+}
@@ -15,6 +15,7 @@ import {
 } from '../tools/memoryTool.js';
 import { FileDiscoveryService } from '../services/fileDiscoveryService.js';
 import { processImports } from './memoryImportProcessor.js';
+import { getUserResearchDir } from './paths.js';
 
 // Simple console logger, similar to the one previously in CLI's config.ts
 // TODO: Integrate with a more robust server-side logger if available/appropriate.
@@ -92,9 +93,9 @@ async function getResearchMdFilePathsInternal(
   for (const researchMdFilename of researchMdFilenames) {
     const resolvedCwd = path.resolve(currentWorkingDirectory);
     const resolvedHome = path.resolve(userHomePath);
+    // Where save_memory writes, %APPDATA%\research on Windows
     const globalMemoryPath = path.join(
-      resolvedHome,
-      RESEARCH_CONFIG_DIR,
+      getUserResearchDir(),
       researchMdFilename,
     );
 
@@ -21,3 +21,25 @@ export function isFunctionCall(content: Content): boolean {
     content.parts.every((part) => !!part.functionCall)
   );
 }
+
+// Starts the environment context the client sends ahead of each
+// conversation; see ResearchClient.getEnvironment.
+const SETUP_PROMPT = /context for our chat/;
+
+/**
+ * True for the two turns that open each conversation: the environment
+ * context (the date, the directory tree and, with full context, the files)
+ * and the model's acknowledgement. Exports and listings of what was said
+ * leave them out.
+ */
+export function isSetupTurn(history: Content[], index: number): boolean {
+  const first = history[0];
+  const isSetup =
+    first?.role === 'user' &&
+    SETUP_PROMPT.test(
+      (first.parts ?? []).map((part) => part.text ?? '').join(''),
+    );
+  return (
+    isSetup && (index === 0 || (index === 1 && history[1]?.role === 'model'))
+  );
+}
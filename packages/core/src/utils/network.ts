@@ -0,0 +1,319 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import * as fs from 'node:fs';
+import * as https from 'node:https';
+import * as tls from 'node:tls';
+import { URL } from 'node:url';
+import { SocksClient, SocksProxy } from 'socks';
+import { SocksProxyAgent } from 'socks-proxy-agent';
+import {
+  Agent,
+  buildConnector,
+  Dispatcher,
+  EnvHttpProxyAgent,
+  setGlobalDispatcher,
+} from 'undici';
+
+export interface NetworkSettings {
+  /**
+   * Proxy URL (http://, https://, socks5:// or socks4://). Falls back to
+   * HTTPS_PROXY/HTTP_PROXY.
+   */
+  proxy?: string;
+  /** Comma-separated hosts that bypass the proxy. Falls back to NO_PROXY. */
+  noProxy?: string;
+  /** Path(s) to PEM files with additional trusted CA certificates. */
+  caCertFile?: string | string[];
+  /** Set to false to skip TLS certificate verification. Defaults to true. */
+  rejectUnauthorized?: boolean;
+}
+
+export interface TlsOptions {
+  ca?: string[];
+  rejectUnauthorized: boolean;
+}
+
+const SUPPORTED_PROXY_PROTOCOLS = ['http:', 'https:'];
+
+// SOCKS schemes and their protocol version. Host names are always sent
+// to the proxy to resolve, so socks5h and socks4a behave the same.
+const SOCKS_PROXY_VERSIONS: Record<string, 4 | 5> = {
+  'socks:': 5,
+  'socks5:': 5,
+  'socks5h:': 5,
+  'socks4:': 4,
+  'socks4a:': 4,
+};
+
+let activeSettings: NetworkSettings = {};
+// Built once, with the CA files read, when the settings are applied.
+let activeHttpsAgent: https.Agent | undefined;
+let activeSocksAgent: SocksProxyAgent | undefined;
+
+/**
+ * Returns the proxy URL to use, preferring the explicit setting over the
+ * conventional environment variables.
+ */
+export function resolveProxy(settings: NetworkSettings = {}): string | undefined {
+  return (
+    settings.proxy ||
+    process.env.HTTPS_PROXY ||
+    process.env.https_proxy ||
+    process.env.HTTP_PROXY ||
+    process.env.http_proxy ||
+    undefined
+  );
+}
+
+export function resolveNoProxy(settings: NetworkSettings = {}): string {
+  return settings.noProxy ?? process.env.NO_PROXY ?? process.env.no_proxy ?? '';
+}
+
+/**
+ * Checks a URL against a NO_PROXY style list. Entries may be exact hosts,
+ * domain suffixes (with or without a leading dot), host:port pairs or `*`.
+ */
+export function shouldBypassProxy(url: string, noProxy: string): boolean {
+  if (!noProxy) {
+    return false;
+  }
+  let hostname: string;
+  let port: string;
+  try {
+    const parsed = new URL(url);
+    hostname = parsed.hostname.toLowerCase();
+    port = parsed.port || (parsed.protocol === 'https:' ? '443' : '80');
+  } catch (_e) {
+    return false;
+  }
+
+  return noProxy
+    .split(/[\s,]+/)
+    .map((entry) => entry.trim().toLowerCase())
+    .filter((entry) => entry.length > 0)
+    .some((entry) => {
+      if (entry === '*') {
+        return true;
+      }
+      let entryHost = entry;
+      const portIndex = entry.lastIndexOf(':');
+      if (portIndex > 0 && !entry.includes(']')) {
+        if (entry.slice(portIndex + 1) !== port) {
+          return false;
+        }
+        entryHost = entry.slice(0, portIndex);
+      }
+      entryHost = entryHost.replace(/^\*?\./, '');
+      return hostname === entryHost || hostname.endsWith(`.${entryHost}`);
+    });
+}
+
+/**
+ * Validates a proxy URL and throws a descriptive error for unsupported schemes.
+ */
+export function validateProxyUrl(proxy: string): URL {
+  let parsed: URL;
+  try {
+    parsed = new URL(proxy);
+  } catch (_e) {
+    throw new Error(`Invalid proxy URL: ${proxy}`);
+  }
+  if (
+    !SUPPORTED_PROXY_PROTOCOLS.includes(parsed.protocol) &&
+    !isSocksProxy(parsed)
+  ) {
+    throw new Error(
+      `Unsupported proxy protocol '${parsed.protocol}' in ${proxy}. Use http://, https://, socks5:// or socks4://.`,
+    );
+  }
+  return parsed;
+}
+
+export function isSocksProxy(proxy: URL): boolean {
+  return proxy.protocol in SOCKS_PROXY_VERSIONS;
+}
+
+function toSocksProxy(proxy: URL): SocksProxy {
+  return {
+    host: proxy.hostname.replace(/^\[|\]$/g, ''),
+    port: Number(proxy.port) || 1080,
+    type: SOCKS_PROXY_VERSIONS[proxy.protocol],
+    userId: proxy.username ? decodeURIComponent(proxy.username) : undefined,
+    password: proxy.password ? decodeURIComponent(proxy.password) : undefined,
+  };
+}
+
+/**
+ * An undici connector that tunnels through a SOCKS proxy, and connects
+ * directly to the NO_PROXY hosts. TLS to the target runs over the tunnel.
+ */
+function createSocksConnector(
+  proxy: URL,
+  noProxy: string,
+  tlsOptions: TlsOptions,
+): buildConnector.connector {
+  const direct = buildConnector(tlsOptions);
+  const socksProxy = toSocksProxy(proxy);
+  return (options, callback) => {
+    const port =
+      Number(options.port) || (options.protocol === 'https:' ? 443 : 80);
+    const target = `${options.protocol}//${options.hostname}:${port}`;
+    if (shouldBypassProxy(target, noProxy)) {
+      direct(options, callback);
+      return;
+    }
+    SocksClient.createConnection({
+      proxy: socksProxy,
+      command: 'connect',
+      destination: { host: options.hostname, port },
+    }).then(
+      ({ socket }) => {
+        if (options.protocol !== 'https:') {
+          callback(null, socket);
+          return;
+        }
+        const secure = tls.connect({
+          ...tlsOptions,
+          socket,
+          servername: options.servername ?? options.hostname,
+          ALPNProtocols: ['http/1.1'],
+        });
+        secure
+          .once('secureConnect', () => callback(null, secure))
+          .once('error', (error) => callback(error, null));
+      },
+      (error: Error) => callback(error, null),
+    );
+  };
+}
+
+/**
+ * Loads the configured CA bundle(s) and verification flag. The bundles are
+ * trusted on top of Node's root certificates, not instead of them, so a
+ * corporate CA does not break TLS to public hosts.
+ */
+export function loadTlsOptions(settings: NetworkSettings = {}): TlsOptions {
+  const files = settings.caCertFile
+    ? Array.isArray(settings.caCertFile)
+      ? settings.caCertFile
+      : [settings.caCertFile]
+    : [];
+  const ca = files.map((file) => {
+    try {
+      return fs.readFileSync(file, 'utf-8');
+    } catch (error) {
+      throw new Error(
+        `Failed to read CA certificate file ${file}: ${error instanceof Error ? error.message : String(error)}`,
+      );
+    }
+  });
+  return {
+    ca: ca.length > 0 ? [...tls.rootCertificates, ...ca] : undefined,
+    rejectUnauthorized: settings.rejectUnauthorized ?? true,
+  };
+}
+
+/**
+ * Builds an undici dispatcher honoring the proxy, NO_PROXY and TLS settings.
+ */
+export function createDispatcher(
+  settings: NetworkSettings = {},
+  tlsOptions: TlsOptions = loadTlsOptions(settings),
+): Dispatcher {
+  const proxy = resolveProxy(settings);
+  if (!proxy) {
+    return new Agent({ connect: tlsOptions });
+  }
+  const parsed = validateProxyUrl(proxy);
+  if (isSocksProxy(parsed)) {
+    return new Agent({
+      connect: createSocksConnector(
+        parsed,
+        resolveNoProxy(settings),
+        tlsOptions,
+      ),
+    });
+  }
+  return new EnvHttpProxyAgent({
+    httpProxy: proxy,
+    httpsProxy: proxy,
+    noProxy: resolveNoProxy(settings),
+    connect: tlsOptions,
+    requestTls: tlsOptions,
+    proxyTls: tlsOptions,
+  });
+}
+
+/**
+ * Installs the network settings for every `fetch` in the process and records
+ * them for clients that do not go through undici (see getAxiosNetworkOptions).
+ */
+export function applyNetworkSettings(settings: NetworkSettings = {}): void {
+  const tlsOptions = loadTlsOptions(settings);
+  setGlobalDispatcher(createDispatcher(settings, tlsOptions));
+  activeSettings = { ...settings };
+  activeHttpsAgent =
+    tlsOptions.ca || !tlsOptions.rejectUnauthorized
+      ? new https.Agent(tlsOptions)
+      : undefined;
+  // Built on first use by an axios client
+  activeSocksAgent = undefined;
+}
+
+export function getNetworkSettings(): NetworkSettings {
+  return { ...activeSettings };
+}
+
+/**
+ * Request options for axios-based provider clients, which do not use the
+ * global undici dispatcher.
+ */
+export function getAxiosNetworkOptions(url: string): {
+  httpAgent?: SocksProxyAgent;
+  httpsAgent?: https.Agent | SocksProxyAgent;
+  proxy?:
+    | false
+    | {
+        protocol: string;
+        host: string;
+        port: number;
+        auth?: { username: string; password: string };
+      };
+} {
+  const httpsAgent = activeHttpsAgent;
+  const proxy = resolveProxy(activeSettings);
+  if (!proxy || shouldBypassProxy(url, resolveNoProxy(activeSettings))) {
+    return { httpsAgent, proxy: proxy ? false : undefined };
+  }
+  const parsed = validateProxyUrl(proxy);
+  if (isSocksProxy(parsed)) {
+    activeSocksAgent ??= new SocksProxyAgent(
+      proxy,
+      loadTlsOptions(activeSettings),
+    );
+    // The agent tunnels both schemes; axios must not proxy on top of it
+    return {
+      httpAgent: activeSocksAgent,
+      httpsAgent: activeSocksAgent,
+      proxy: false,
+    };
+  }
+  return {
+    httpsAgent,
+    proxy: {
+      protocol: parsed.protocol.replace(':', ''),
+      host: parsed.hostname,
+      port: Number(parsed.port) || (parsed.protocol === 'https:' ? 443 : 80),
+      auth: parsed.username
+        ? {
+            username: decodeURIComponent(parsed.username),
+            password: decodeURIComponent(parsed.password),
+          }
+        : undefined,
+    },
+  };
+}
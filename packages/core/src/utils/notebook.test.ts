@@ -0,0 +1,185 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect } from 'vitest';
+import {
+  Notebook,
+  conversationToNotebook,
+  editNotebookCell,
+  joinSource,
+  parseNotebook,
+  renderNotebook,
+  serializeNotebook,
+  splitCodeBlocks,
+  toSourceLines,
+} from './notebook.js';
+
+function sampleNotebook(): Notebook {
+  return {
+    cells: [
+      { cell_type: 'markdown', metadata: {}, source: ['# Analysis\n', 'Intro'] },
+      {
+        cell_type: 'code',
+        execution_count: 3,
+        metadata: { tags: ['setup'] },
+        id: 'abc',
+        outputs: [
+          { output_type: 'stream', name: 'stdout', text: ['loaded\n'] },
+          {
+            output_type: 'display_data',
+            data: { 'image/png': 'iVBOR', 'text/plain': '<Figure>' },
+          },
+        ],
+        source: ['import pandas as pd\n', "df = pd.read_csv('x.csv')"],
+      },
+    ],
+    metadata: { language_info: { name: 'python' } },
+    nbformat: 4,
+    nbformat_minor: 5,
+  };
+}
+
+describe('splitCodeBlocks', () => {
+  it('should keep prose and code blocks in order', () => {
+    expect(
+      splitCodeBlocks('Load it:\n```python\nx = 1\n```\nDone.'),
+    ).toEqual([
+      { kind: 'text', text: 'Load it:' },
+      { kind: 'code', language: 'python', code: 'x = 1\n' },
+      { kind: 'text', text: 'Done.' },
+    ]);
+  });
+});
+
+describe('toSourceLines', () => {
+  it('should keep newlines on every line but the last', () => {
+    expect(toSourceLines('a\nb\nc')).toEqual(['a\n', 'b\n', 'c']);
+    expect(toSourceLines('')).toEqual([]);
+  });
+});
+
+describe('parseNotebook', () => {
+  it('should reject files that are not notebooks', () => {
+    expect(() => parseNotebook('{')).toThrow('Not a valid notebook');
+    expect(() => parseNotebook('{"nbformat": 4}')).toThrow('missing "cells"');
+    expect(() => parseNotebook('{"cells": [], "nbformat": 3}')).toThrow(
+      'Unsupported notebook format 3',
+    );
+  });
+});
+
+describe('renderNotebook', () => {
+  it('should number cells and show text outputs and image placeholders', () => {
+    const rendered = renderNotebook(sampleNotebook());
+    expect(rendered).toContain('--- Cell 1 [markdown] ---\n# Analysis\nIntro');
+    expect(rendered).toContain(
+      "--- Cell 2 [code] In [3] ---\n```python\nimport pandas as pd\ndf = pd.read_csv('x.csv')\n```",
+    );
+    expect(rendered).toContain('Output:\nloaded\n[image/png output]');
+  });
+});
+
+describe('editNotebookCell', () => {
+  it('should replace a code cell and clear its outputs', () => {
+    const updated = editNotebookCell(sampleNotebook(), {
+      cellNumber: 2,
+      mode: 'replace',
+      source: 'print(1)\nprint(2)',
+    });
+    expect(updated.cells[1]).toEqual({
+      cell_type: 'code',
+      execution_count: null,
+      metadata: { tags: ['setup'] },
+      id: 'abc',
+      outputs: [],
+      source: ['print(1)\n', 'print(2)'],
+    });
+  });
+
+  it('should insert and delete cells', () => {
+    const inserted = editNotebookCell(sampleNotebook(), {
+      cellNumber: 3,
+      mode: 'insert',
+      source: 'df.describe()',
+    });
+    expect(inserted.cells).toHaveLength(3);
+    expect(joinSource(inserted.cells[2].source)).toBe('df.describe()');
+    // nbformat 4.5 requires an id on every cell
+    expect(inserted.cells[2].id).toMatch(/^[0-9a-f]{8}$/);
+    const older = { ...sampleNotebook(), nbformat_minor: 4 };
+    expect(
+      editNotebookCell(older, { cellNumber: 1, mode: 'insert' }).cells[0].id,
+    ).toBeUndefined();
+
+    const deleted = editNotebookCell(inserted, { cellNumber: 1, mode: 'delete' });
+    expect(deleted.cells.map((cell) => cell.cell_type)).toEqual([
+      'code',
+      'code',
+    ]);
+  });
+
+  it('should reject cell numbers out of range', () => {
+    expect(() =>
+      editNotebookCell(sampleNotebook(), { cellNumber: 3, mode: 'replace' }),
+    ).toThrow('Cell 3 does not exist; the notebook has 2 cells.');
+  });
+});
+
+describe('conversationToNotebook', () => {
+  it('should turn prompts and prose into markdown and code into code cells', () => {
+    const notebook = conversationToNotebook([
+      { role: 'user', parts: [{ text: 'Plot the data' }] },
+      {
+        role: 'model',
+        parts: [
+          {
+            text: 'Here you go:\n```python\ndf.plot()\n```\nRun it with:\n```bash\npython plot.py\n```',
+          },
+        ],
+      },
+      { role: 'model', parts: [{ functionCall: { name: 'read_file' } }] },
+    ]);
+    expect(
+      notebook.cells.map((cell) => [cell.cell_type, joinSource(cell.source)]),
+    ).toEqual([
+      ['markdown', '**Prompt:** Plot the data'],
+      ['markdown', 'Here you go:'],
+      ['code', 'df.plot()'],
+      ['markdown', 'Run it with:\n\n```bash\npython plot.py\n```'],
+    ]);
+    expect(notebook.cells.every((cell) => cell.id)).toBe(true);
+  });
+
+  it('should leave out the setup turn and thoughts', () => {
+    const notebook = conversationToNotebook([
+      { role: 'user', parts: [{ text: 'This is the context for our chat.' }] },
+      { role: 'model', parts: [{ text: 'Got it. Thanks for the context!' }] },
+      { role: 'user', parts: [{ text: 'Load the data' }] },
+      {
+        role: 'model',
+        parts: [
+          { text: 'Pandas reads CSV files.', thought: true },
+          { text: '```python
+df = pd.read_csv("x.csv")
+```' },
+        ],
+      },
+    ]);
+    expect(
+      notebook.cells.map((cell) => [cell.cell_type, joinSource(cell.source)]),
+    ).toEqual([
+      ['markdown', '**Prompt:** Load the data'],
+      ['code', 'df = pd.read_csv("x.csv")'],
+    ]);
+  });
+
+  it('should serialize to a notebook that parses back', () => {
+    const notebook = conversationToNotebook([
+      { role: 'model', parts: [{ text: '```\nx = 1\n```' }] },
+    ]);
+    expect(parseNotebook(serializeNotebook(notebook))).toEqual(notebook);
+  });
+});
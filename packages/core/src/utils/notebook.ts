@@ -0,0 +1,309 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import * as crypto from 'node:crypto';
+import { Content } from '@google/genai';
+import { isSetupTurn } from './messageInspectors.js';
+
+export type NotebookCellType = 'code' | 'markdown' | 'raw';
+
+export interface NotebookOutput {
+  output_type: 'stream' | 'execute_result' | 'display_data' | 'error';
+  name?: string;
+  text?: string | string[];
+  data?: Record<string, string | string[]>;
+  ename?: string;
+  evalue?: string;
+  traceback?: string[];
+}
+
+export interface NotebookCell {
+  cell_type: NotebookCellType;
+  source: string | string[];
+  metadata: Record<string, unknown>;
+  outputs?: NotebookOutput[];
+  execution_count?: number | null;
+  id?: string;
+}
+
+/** A Jupyter notebook in nbformat 4. */
+export interface Notebook {
+  cells: NotebookCell[];
+  metadata: Record<string, unknown>;
+  nbformat: number;
+  nbformat_minor: number;
+}
+
+export type MarkdownSegment =
+  | { kind: 'text'; text: string }
+  | { kind: 'code'; language: string; code: string };
+
+// Output longer than this is cut when a notebook is rendered as context.
+const MAX_OUTPUT_CHARS = 2000;
+
+/** Splits markdown into prose and fenced code blocks, in order. */
+export function splitCodeBlocks(markdown: string): MarkdownSegment[] {
+  const segments: MarkdownSegment[] = [];
+  const fence = /^(`{3,}|~{3,})[ \t]*([\w+#.-]*)[^\n]*\n([\s\S]*?)^\1[ \t]*$/gm;
+  let last = 0;
+  let match: RegExpExecArray | null;
+  while ((match = fence.exec(markdown)) !== null) {
+    const text = markdown.slice(last, match.index);
+    if (text.trim()) {
+      segments.push({ kind: 'text', text: text.trim() });
+    }
+    segments.push({
+      kind: 'code',
+      language: match[2].toLowerCase(),
+      code: match[3],
+    });
+    last = match.index + match[0].length;
+  }
+  const rest = markdown.slice(last);
+  if (rest.trim()) {
+    segments.push({ kind: 'text', text: rest.trim() });
+  }
+  return segments;
+}
+
+export function joinSource(source: string | string[] | undefined): string {
+  return Array.isArray(source) ? source.join('') : (source ?? '');
+}
+
+/** Splits text into nbformat source lines, each keeping its newline. */
+export function toSourceLines(text: string): string[] {
+  return text.match(/[^\n]*\n|[^\n]+$/g) ?? [];
+}
+
+export function parseNotebook(text: string): Notebook {
+  let parsed: unknown;
+  try {
+    parsed = JSON.parse(text);
+  } catch (error) {
+    throw new Error(
+      `Not a valid notebook: ${error instanceof Error ? error.message : String(error)}`,
+    );
+  }
+  const notebook = parsed as Notebook;
+  if (!notebook || !Array.isArray(notebook.cells)) {
+    throw new Error('Not a valid notebook: missing "cells"');
+  }
+  if ((notebook.nbformat ?? 4) < 4) {
+    throw new Error(
+      `Unsupported notebook format ${notebook.nbformat}; convert it to nbformat 4 first.`,
+    );
+  }
+  return notebook;
+}
+
+export function serializeNotebook(notebook: Notebook): string {
+  // Jupyter writes notebooks with a one-space indent.
+  return JSON.stringify(notebook, null, 1) + '\n';
+}
+
+export function getNotebookLanguage(notebook: Notebook): string {
+  const metadata = notebook.metadata as {
+    language_info?: { name?: string };
+    kernelspec?: { language?: string };
+  };
+  return (
+    metadata.language_info?.name ?? metadata.kernelspec?.language ?? 'python'
+  );
+}
+
+function truncate(text: string): string {
+  return text.length > MAX_OUTPUT_CHARS
+    ? `${text.slice(0, MAX_OUTPUT_CHARS)}\n... [output truncated]`
+    : text;
+}
+
+function renderOutput(output: NotebookOutput): string {
+  switch (output.output_type) {
+    case 'stream':
+      return joinSource(output.text);
+    case 'error':
+      return `${output.ename}: ${output.evalue}`;
+    default: {
+      const data = output.data ?? {};
+      const images = Object.keys(data).filter((m) => m.startsWith('image/'));
+      if (images.length > 0) {
+        return images.map((mimeType) => `[${mimeType} output]`).join('\n');
+      }
+      return joinSource(data['text/plain']);
+    }
+  }
+}
+
+/**
+ * Renders a notebook as numbered cells for the model and the user to
+ * refer to. Cell numbers are 1-based, matching the notebook_edit tool.
+ */
+export function renderNotebook(notebook: Notebook): string {
+  const language = getNotebookLanguage(notebook);
+  const lines = [
+    `Jupyter notebook (${language}, ${notebook.cells.length} cells). Edit cells with the notebook_edit tool.`,
+  ];
+  notebook.cells.forEach((cell, index) => {
+    const source = joinSource(cell.source).trimEnd();
+    if (cell.cell_type === 'code') {
+      const count = cell.execution_count ?? ' ';
+      lines.push(
+        '',
+        `--- Cell ${index + 1} [code] In [${count}] ---`,
+        `\`\`\`${language}`,
+        source,
+        '```',
+      );
+      const outputs = (cell.outputs ?? []).map(renderOutput).join('').trimEnd();
+      if (outputs) {
+        lines.push('Output:', truncate(outputs));
+      }
+    } else {
+      lines.push('', `--- Cell ${index + 1} [${cell.cell_type}] ---`, source);
+    }
+  });
+  return lines.join('\n');
+}
+
+/** nbformat 4.5 made cell ids required; earlier versions forbid them. */
+function hasCellIds(notebook: Notebook): boolean {
+  return (
+    notebook.nbformat > 4 ||
+    (notebook.nbformat === 4 && notebook.nbformat_minor >= 5)
+  );
+}
+
+function newCell(
+  cellType: NotebookCellType,
+  source: string,
+  withId: boolean,
+): NotebookCell {
+  const cell: NotebookCell =
+    cellType === 'code'
+      ? {
+          cell_type: 'code',
+          execution_count: null,
+          metadata: {},
+          outputs: [],
+          source: toSourceLines(source),
+        }
+      : { cell_type: cellType, metadata: {}, source: toSourceLines(source) };
+  if (withId) {
+    cell.id = crypto.randomUUID().replace(/-/g, '').slice(0, 8);
+  }
+  return cell;
+}
+
+export interface NotebookCellEdit {
+  /** 1-based cell number. For inserts, the new cell gets this number. */
+  cellNumber: number;
+  mode: 'replace' | 'insert' | 'delete';
+  source?: string;
+  cellType?: NotebookCellType;
+}
+
+/**
+ * Applies one cell edit and returns the updated notebook. Replaced code
+ * cells lose their outputs, which no longer match the source.
+ */
+export function editNotebookCell(
+  notebook: Notebook,
+  edit: NotebookCellEdit,
+): Notebook {
+  const cells = [...notebook.cells];
+  const withId = hasCellIds(notebook);
+  const index = edit.cellNumber - 1;
+  const maxIndex = edit.mode === 'insert' ? cells.length : cells.length - 1;
+  if (!Number.isInteger(index) || index < 0 || index > maxIndex) {
+    throw new Error(
+      `Cell ${edit.cellNumber} does not exist; the notebook has ${cells.length} cells.`,
+    );
+  }
+  if (edit.mode === 'delete') {
+    cells.splice(index, 1);
+  } else if (edit.mode === 'insert') {
+    cells.splice(
+      index,
+      0,
+      newCell(edit.cellType ?? 'code', edit.source ?? '', withId),
+    );
+  } else {
+    const current = cells[index];
+    const cellType = edit.cellType ?? current.cell_type;
+    cells[index] =
+      cellType === current.cell_type
+        ? {
+            ...newCell(cellType, edit.source ?? '', withId),
+            metadata: current.metadata,
+            ...(current.id && { id: current.id }),
+          }
+        : newCell(cellType, edit.source ?? '', withId);
+  }
+  return { ...notebook, cells };
+}
+
+/**
+ * Turns a conversation into a notebook: prompts and prose become markdown
+ * cells and code blocks in the notebook's language become code cells. The
+ * setup turn and the model's thoughts are left out.
+ */
+export function conversationToNotebook(
+  history: Content[],
+  language = 'python',
+): Notebook {
+  const cells: NotebookCell[] = [];
+  const cell = (cellType: NotebookCellType, source: string) =>
+    cells.push(newCell(cellType, source, true));
+  const isRunnable = (lang: string) =>
+    lang === '' || lang === language || (language === 'python' && lang === 'py');
+  for (const [index, content] of history.entries()) {
+    if (isSetupTurn(history, index)) {
+      continue;
+    }
+    const text = (content.parts ?? [])
+      .filter((part) => !part.thought)
+      .map((part) => part.text ?? '')
+      .join('')
+      .trim();
+    if (!text) {
+      continue;
+    }
+    if (content.role === 'user') {
+      cell('markdown', `**Prompt:** ${text}`);
+      continue;
+    }
+    let prose: string[] = [];
+    const flushProse = () => {
+      if (prose.length > 0) {
+        cell('markdown', prose.join('\n\n'));
+        prose = [];
+      }
+    };
+    for (const segment of splitCodeBlocks(text)) {
+      if (segment.kind === 'code' && isRunnable(segment.language)) {
+        flushProse();
+        cell('code', segment.code.replace(/\n$/, ''));
+      } else if (segment.kind === 'code') {
+        prose.push(`\`\`\`${segment.language}\n${segment.code}\`\`\``);
+      } else {
+        prose.push(segment.text);
+      }
+    }
+    flushProse();
+  }
+  return {
+    cells,
+    metadata: {
+      kernelspec:
+        language === 'python'
+          ? { display_name: 'Python 3', language: 'python', name: 'python3' }
+          : { display_name: language, language, name: language },
+      language_info: { name: language },
+    },
+    nbformat: 4,
+    nbformat_minor: 5,
+  };
+}
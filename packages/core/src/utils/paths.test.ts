@@ -0,0 +1,66 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { getUserResearchDir } from './paths.js';
+
+describe('getUserResearchDir', () => {
+  let tempDir: string;
+  let appData: string;
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'user-research-dir-'));
+    appData = path.join(tempDir, 'AppData', 'Roaming');
+    vi.stubEnv('HOME', path.join(tempDir, 'home'));
+    vi.stubEnv('APPDATA', appData);
+  });
+
+  afterEach(() => {
+    vi.unstubAllEnvs();
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should use ~/.research outside Windows', () => {
+    expect(getUserResearchDir('linux')).toBe(
+      path.join(tempDir, 'home', '.research'),
+    );
+  });
+
+  it('should use %APPDATA% on Windows', () => {
+    expect(getUserResearchDir('win32')).toBe(path.join(appData, 'research'));
+  });
+
+  it('should keep the ~/.research of an earlier version on Windows', () => {
+    fs.mkdirSync(path.join(tempDir, 'home', '.research'), { recursive: true });
+
+    expect(getUserResearchDir('win32')).toBe(
+      path.join(tempDir, 'home', '.research'),
+    );
+  });
+
+  it('should prefer %APPDATA% on Windows once it exists', () => {
+    fs.mkdirSync(path.join(tempDir, 'home', '.research'), { recursive: true });
+    fs.mkdirSync(path.join(appData, 'research'), { recursive: true });
+
+    expect(getUserResearchDir('win32')).toBe(path.join(appData, 'research'));
+  });
+
+  it('should not change its choice while running', () => {
+    expect(getUserResearchDir('win32')).toBe(path.join(appData, 'research'));
+
+    fs.mkdirSync(path.join(tempDir, 'home', '.research'), { recursive: true });
+    expect(getUserResearchDir('win32')).toBe(path.join(appData, 'research'));
+  });
+
+  describe.runIf(process.platform === 'win32')('on Windows', () => {
+    it('should default to the current platform', () => {
+      expect(getUserResearchDir()).toBe(getUserResearchDir('win32'));
+    });
+  });
+});
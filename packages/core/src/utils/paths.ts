@@ -4,8 +4,9 @@
  * SPDX-License-Identifier: Apache-2.0
  */
 
+import fs from 'node:fs';
 import path from 'node:path';
-import os from 'os';
+import * as os from 'os';
 import * as crypto from 'crypto';
 
 export const RESEARCH_DIR = '.research';
@@ -156,5 +157,37 @@ export function getProjectHash(projectRoot: string): string {
  */
 export function getProjectTempDir(projectRoot: string): string {
   const hash = getProjectHash(projectRoot);
-  return path.join(os.homedir(), RESEARCH_DIR, TMP_DIR_NAME, hash);
+  return path.join(getUserResearchDir(), TMP_DIR_NAME, hash);
+}
+
+// The Windows choice of directory, made once for a home and %APPDATA%
+let resolvedWindowsDir: { key: string; dir: string } | undefined;
+
+/**
+ * The directory of the user's settings and data, `~/.research`. On Windows
+ * it is `%APPDATA%\research`, where applications keep their configuration,
+ * unless only a `~/.research` from an earlier version exists. The choice is
+ * made on the first call and kept, so the directory does not change while
+ * the CLI runs.
+ */
+export function getUserResearchDir(
+  platform: NodeJS.Platform = process.platform,
+): string {
+  const homeDir = path.join(os.homedir(), RESEARCH_DIR);
+  const appData = process.env.APPDATA;
+  if (platform !== 'win32' || !appData) {
+    return homeDir;
+  }
+  const key = `${homeDir}\u0000${appData}`;
+  if (resolvedWindowsDir?.key !== key) {
+    const appDataDir = path.join(appData, 'research');
+    resolvedWindowsDir = {
+      key,
+      dir:
+        fs.existsSync(appDataDir) || !fs.existsSync(homeDir)
+          ? appDataDir
+          : homeDir,
+    };
+  }
+  return resolvedWindowsDir.dir;
 }
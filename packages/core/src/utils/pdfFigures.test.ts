@@ -0,0 +1,158 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { EventEmitter } from 'node:events';
+import { spawn } from 'node:child_process';
+import {
+  extractPdfFigures,
+  parsePdfImagesList,
+  readPngSize,
+} from './pdfFigures.js';
+
+vi.mock('node:child_process', async () => {
+  const actual = await vi.importActual('node:child_process');
+  return {
+    ...actual,
+    spawn: vi.fn(),
+  };
+});
+
+const LISTING = `page   num  type   width height color comp bpc  enc interp  object ID x-ppi y-ppi size ratio
+--------------------------------------------------------------------------------------------
+   1     0 image     640   480  rgb     3   8  jpeg   no        12  0    72    72 25.2K 2.8%
+   1     1 smask     640   480  gray    1   8  image  no        12  0    72    72  1.1K 0.4%
+   2     2 image      32    32  rgb     3   8  image  no        15  0    72    72   310 10%
+   3     3 image     800   600  rgb     3   8  image  no        18  0   150   150 80.0K 5.7%
+`;
+
+function png(width: number, height: number): Buffer {
+  const data = Buffer.alloc(24);
+  data.write('\x89PNG\r\n\x1a\n', 0, 'latin1');
+  data.writeUInt32BE(13, 8);
+  data.write('IHDR', 12, 'ascii');
+  data.writeUInt32BE(width, 16);
+  data.writeUInt32BE(height, 20);
+  return data;
+}
+
+function fakeProcess(run: () => string) {
+  const child = Object.assign(new EventEmitter(), {
+    stdout: new EventEmitter(),
+    stderr: new EventEmitter(),
+  });
+  setImmediate(() => {
+    child.stdout.emit('data', Buffer.from(run()));
+    child.emit('close', 0);
+  });
+  return child;
+}
+
+describe('parsePdfImagesList', () => {
+  it('should read the rows of the listing', () => {
+    expect(parsePdfImagesList(LISTING)).toEqual([
+      { page: 1, num: 0, type: 'image', width: 640, height: 480 },
+      { page: 1, num: 1, type: 'smask', width: 640, height: 480 },
+      { page: 2, num: 2, type: 'image', width: 32, height: 32 },
+      { page: 3, num: 3, type: 'image', width: 800, height: 600 },
+    ]);
+  });
+});
+
+describe('readPngSize', () => {
+  it('should read the size from the header', () => {
+    expect(readPngSize(png(1200, 900))).toEqual({ width: 1200, height: 900 });
+    expect(readPngSize(Buffer.from('not a png'))).toEqual({
+      width: 0,
+      height: 0,
+    });
+  });
+});
+
+describe('extractPdfFigures', () => {
+  let tempDir: string;
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'pdf-figures-'));
+    vi.mocked(spawn).mockImplementation(((
+      _command: string,
+      args: string[],
+    ) =>
+      fakeProcess(() => {
+        if (args[0] === '-list') {
+          return LISTING;
+        }
+        const prefix = args[args.length - 1];
+        for (const name of ['001-000', '001-001', '002-002', '003-003']) {
+          fs.writeFileSync(`${prefix}-${name}.png`, png(1, 1));
+        }
+        return '';
+      })) as unknown as typeof spawn);
+  });
+
+  afterEach(() => {
+    vi.resetAllMocks();
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should keep images large enough to be figures and cache the result', async () => {
+    const outputDir = path.join(tempDir, 'figures');
+
+    const figures = await extractPdfFigures('paper.pdf', outputDir);
+
+    expect(figures).toEqual([
+      {
+        index: 1,
+        page: 1,
+        width: 640,
+        height: 480,
+        file: path.join(outputDir, 'img-001-000.png'),
+      },
+      {
+        index: 2,
+        page: 3,
+        width: 800,
+        height: 600,
+        file: path.join(outputDir, 'img-003-003.png'),
+      },
+    ]);
+    expect(vi.mocked(spawn).mock.calls[1][1]).toEqual([
+      '-png',
+      '-p',
+      'paper.pdf',
+      path.join(outputDir, 'img'),
+    ]);
+
+    vi.mocked(spawn).mockClear();
+    expect(await extractPdfFigures('paper.pdf', outputDir)).toEqual(figures);
+    expect(spawn).not.toHaveBeenCalled();
+  });
+
+  it('should explain how to install poppler when pdfimages is missing', async () => {
+    vi.mocked(spawn).mockImplementation((() => {
+      const child = Object.assign(new EventEmitter(), {
+        stdout: new EventEmitter(),
+        stderr: new EventEmitter(),
+      });
+      setImmediate(() =>
+        child.emit(
+          'error',
+          Object.assign(new Error('spawn pdfimages ENOENT'), {
+            code: 'ENOENT',
+          }),
+        ),
+      );
+      return child;
+    }) as unknown as typeof spawn);
+
+    await expect(
+      extractPdfFigures('paper.pdf', path.join(tempDir, 'figures')),
+    ).rejects.toThrow(/pdfimages was not found. Install poppler/);
+  });
+});
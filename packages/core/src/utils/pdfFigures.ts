@@ -0,0 +1,213 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import * as crypto from 'node:crypto';
+import { spawn } from 'node:child_process';
+import { isNodeError } from './errors.js';
+
+// Images smaller than this on either side are logos, icons and rules.
+export const MIN_FIGURE_SIZE = 100;
+const PAGE_RENDER_DPI = 150;
+const MANIFEST_FILE = 'figures.json';
+
+/** An image extracted from a PDF, or a rendered page. */
+export interface PdfFigure {
+  /** 1-based number shown to the user; 0 for rendered pages. */
+  index: number;
+  page: number;
+  width: number;
+  height: number;
+  /** Absolute path of the PNG file. */
+  file: string;
+}
+
+export interface PdfImageListing {
+  page: number;
+  num: number;
+  /** `image`, `mask`, `smask` or `stencil`. */
+  type: string;
+  width: number;
+  height: number;
+}
+
+function runPoppler(tool: string, args: string[]): Promise<string> {
+  return new Promise((resolve, reject) => {
+    const child = spawn(tool, args, { stdio: ['ignore', 'pipe', 'pipe'] });
+    let stdout = '';
+    let stderr = '';
+    child.stdout.on('data', (data: Buffer) => {
+      stdout += data.toString();
+    });
+    child.stderr.on('data', (data: Buffer) => {
+      stderr += data.toString();
+    });
+    child.on('error', (error) => {
+      reject(
+        isNodeError(error) && error.code === 'ENOENT'
+          ? new Error(
+              `${tool} was not found. Install poppler (e.g. apt install poppler-utils or brew install poppler).`,
+            )
+          : error,
+      );
+    });
+    child.on('close', (exitCode) => {
+      if (exitCode === 0) {
+        resolve(stdout);
+      } else {
+        reject(
+          new Error(
+            `${tool} exited with code ${exitCode}: ${stderr.trim() || 'no output'}`,
+          ),
+        );
+      }
+    });
+  });
+}
+
+/**
+ * Parses the table printed by `pdfimages -list`.
+ */
+export function parsePdfImagesList(output: string): PdfImageListing[] {
+  const listings: PdfImageListing[] = [];
+  for (const line of output.split('\n')) {
+    const match = line.match(/^\s*(\d+)\s+(\d+)\s+(\w+)\s+(\d+)\s+(\d+)\s/);
+    if (match) {
+      listings.push({
+        page: Number(match[1]),
+        num: Number(match[2]),
+        type: match[3],
+        width: Number(match[4]),
+        height: Number(match[5]),
+      });
+    }
+  }
+  return listings;
+}
+
+/**
+ * A stable cache key for a PDF, derived from its contents so renamed or
+ * re-downloaded copies share their extracted figures.
+ */
+export async function getPdfCacheKey(pdfPath: string): Promise<string> {
+  const data = await fs.promises.readFile(pdfPath);
+  return crypto.createHash('sha256').update(data).digest('hex').slice(0, 16);
+}
+
+async function readManifest(
+  outputDir: string,
+): Promise<PdfFigure[] | undefined> {
+  try {
+    const data = JSON.parse(
+      await fs.promises.readFile(path.join(outputDir, MANIFEST_FILE), 'utf8'),
+    );
+    return Array.isArray(data) ? data : undefined;
+  } catch (error) {
+    if (isNodeError(error) && error.code === 'ENOENT') {
+      return undefined;
+    }
+    throw error;
+  }
+}
+
+/**
+ * Extracts the embedded images of a PDF as PNG files in `outputDir` with
+ * poppler's pdfimages, skipping masks and images smaller than
+ * MIN_FIGURE_SIZE. Results are cached in the directory, so callers should
+ * use one directory per PDF (see getPdfCacheKey).
+ *
+ * Tables and figures drawn as vector graphics are not embedded images;
+ * renderPdfPage captures those.
+ */
+export async function extractPdfFigures(
+  pdfPath: string,
+  outputDir: string,
+): Promise<PdfFigure[]> {
+  const cached = await readManifest(outputDir);
+  if (cached) {
+    return cached;
+  }
+
+  const listings = parsePdfImagesList(
+    await runPoppler('pdfimages', ['-list', pdfPath]),
+  );
+  await fs.promises.mkdir(outputDir, { recursive: true });
+  const prefix = path.join(outputDir, 'img');
+  await runPoppler('pdfimages', ['-png', '-p', pdfPath, prefix]);
+
+  const figures: PdfFigure[] = [];
+  for (const listing of listings) {
+    if (
+      listing.type !== 'image' ||
+      listing.width < MIN_FIGURE_SIZE ||
+      listing.height < MIN_FIGURE_SIZE
+    ) {
+      continue;
+    }
+    const file = `${prefix}-${String(listing.page).padStart(3, '0')}-${String(listing.num).padStart(3, '0')}.png`;
+    if (!fs.existsSync(file)) {
+      continue;
+    }
+    figures.push({
+      index: figures.length + 1,
+      page: listing.page,
+      width: listing.width,
+      height: listing.height,
+      file,
+    });
+  }
+  await fs.promises.writeFile(
+    path.join(outputDir, MANIFEST_FILE),
+    JSON.stringify(figures, null, 2),
+    'utf8',
+  );
+  return figures;
+}
+
+/**
+ * Renders one page of a PDF to a PNG in `outputDir` with pdftoppm.
+ */
+export async function renderPdfPage(
+  pdfPath: string,
+  page: number,
+  outputDir: string,
+): Promise<PdfFigure> {
+  const prefix = path.join(outputDir, `page-${page}`);
+  const file = `${prefix}.png`;
+  if (!fs.existsSync(file)) {
+    await fs.promises.mkdir(outputDir, { recursive: true });
+    await runPoppler('pdftoppm', [
+      '-png',
+      '-r',
+      String(PAGE_RENDER_DPI),
+      '-f',
+      String(page),
+      '-l',
+      String(page),
+      '-singlefile',
+      pdfPath,
+      prefix,
+    ]);
+  }
+  const size = readPngSize(await fs.promises.readFile(file));
+  return { index: 0, page, ...size, file };
+}
+
+/** Width and height from a PNG's IHDR chunk. */
+export function readPngSize(data: Buffer): { width: number; height: number } {
+  if (data.length < 24 || data.toString('ascii', 12, 16) !== 'IHDR') {
+    return { width: 0, height: 0 };
+  }
+  return { width: data.readUInt32BE(16), height: data.readUInt32BE(20) };
+}
+
+/**
+ * The text of a PDF, in reading order, extracted with pdftotext.
+ */
+export async function extractPdfText(pdfPath: string): Promise<string> {
+  return runPoppler('pdftotext', ['-enc', 'UTF-8', pdfPath, '-']);
+}
@@ -0,0 +1,113 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import {
+  EvalVariant,
+  getBlindOutputs,
+  gradeCase,
+  loadEvalRun,
+  parseEvalInputs,
+  renderTemplate,
+  runEval,
+  saveEvalRun,
+  summarizeEval,
+} from './promptEval.js';
+
+const variants: [EvalVariant, EvalVariant] = [
+  { label: 'A', template: 'Summarize: {{input}}' },
+  { label: 'B', template: 'Summarize in one sentence.', model: 'small' },
+];
+
+describe('parseEvalInputs', () => {
+  it('should read JSON Lines, JSON arrays and text blocks', () => {
+    const jsonl = '{"input": "one"}\n"two"\n\n{"prompt": "three"}\n';
+    expect(parseEvalInputs(jsonl, 'x.jsonl')).toEqual(['one', 'two', 'three']);
+    expect(
+      parseEvalInputs('["one", {"input": "two"}, {}]', 'x.json'),
+    ).toEqual(['one', 'two']);
+    expect(
+      parseEvalInputs('first\nline\n---\nsecond\n---\n', 'x.txt'),
+    ).toEqual(['first\nline', 'second']);
+  });
+});
+
+describe('renderTemplate', () => {
+  it('should put the input at the placeholder or after the template', () => {
+    expect(renderTemplate(variants[0].template, 'the paper')).toBe(
+      'Summarize: the paper',
+    );
+    expect(renderTemplate(variants[1].template, 'the paper')).toBe(
+      'Summarize in one sentence.\n\nthe paper',
+    );
+    expect(renderTemplate(undefined, 'the paper')).toBe('the paper');
+  });
+});
+
+describe('runEval', () => {
+  it('should collect both outputs and grade them blind', async () => {
+    const randoms = [0.2, 0.8];
+    const run = await runEval(
+      async (prompt, model) => `${model ?? 'default'}: ${prompt}`,
+      'inputs.txt',
+      ['x', 'y'],
+      variants,
+      undefined,
+      () => randoms.shift()!,
+    );
+
+    expect(run.cases[0].outputs).toEqual([
+      'default: Summarize: x',
+      'small: Summarize in one sentence.\n\nx',
+    ]);
+    // The second case shows B first
+    expect(getBlindOutputs(run.cases[1])[0]).toBe(run.cases[1].outputs[1]);
+
+    run.cases[0] = gradeCase(run.cases[0], [4, 2]);
+    run.cases[1] = gradeCase(run.cases[1], [5, 3]);
+    expect(run.cases[1].scores).toEqual([3, 5]);
+    expect(summarizeEval(run)).toEqual({
+      graded: 2,
+      total: 2,
+      meanScores: [3.5, 3.5],
+      wins: [1, 1],
+      ties: 0,
+    });
+  });
+});
+
+describe('saveEvalRun and loadEvalRun', () => {
+  let tempDir: string;
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'prompt-eval-'));
+  });
+
+  afterEach(() => {
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should load the latest run or a run by id', async () => {
+    const run = (id: string) => ({
+      id,
+      created: id,
+      inputsFile: 'inputs.txt',
+      variants,
+      cases: [],
+    });
+    await saveEvalRun(tempDir, run('2025-06-01T10-00-00-000Z'));
+    await saveEvalRun(tempDir, run('2025-06-02T10-00-00-000Z'));
+
+    expect((await loadEvalRun(tempDir))?.id).toBe('2025-06-02T10-00-00-000Z');
+    expect((await loadEvalRun(tempDir, '2025-06-01T10-00-00-000Z'))?.id).toBe(
+      '2025-06-01T10-00-00-000Z',
+    );
+    expect(await loadEvalRun(tempDir, 'missing')).toBeUndefined();
+  });
+});
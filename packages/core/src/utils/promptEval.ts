@@ -0,0 +1,238 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import type { ResearchClient } from '../core/client.js';
+import { RESEARCH_DIR } from './paths.js';
+import { isNodeError } from './errors.js';
+import { getResponseText } from './generateContentResponseUtilities.js';
+
+const EVALS_DIR = 'evals';
+const INPUT_PLACEHOLDER = '{{input}}';
+
+/** One side of a comparison: a prompt template, a model, or both. */
+export interface EvalVariant {
+  label: 'A' | 'B';
+  /** Template with `{{input}}`; without one, the input is sent as is. */
+  template?: string;
+  /** File the template was read from, for the report. */
+  templateFile?: string;
+  model?: string;
+}
+
+export interface EvalCase {
+  input: string;
+  /** Outputs of variants A and B. */
+  outputs: [string, string];
+  /**
+   * Which variant is shown as "Output 1", so grading is blind: 0 shows A
+   * first, 1 shows B first.
+   */
+  firstShown: 0 | 1;
+  /** Scores from 1 to 5 for A and B, once graded. */
+  scores?: [number, number];
+}
+
+export interface EvalRun {
+  id: string;
+  created: string;
+  inputsFile: string;
+  variants: [EvalVariant, EvalVariant];
+  cases: EvalCase[];
+}
+
+export interface EvalSummary {
+  graded: number;
+  total: number;
+  /** Mean score of A and B over the graded cases. */
+  meanScores: [number, number];
+  wins: [number, number];
+  ties: number;
+}
+
+export function getEvalsDir(projectRoot: string): string {
+  return path.join(projectRoot, RESEARCH_DIR, EVALS_DIR);
+}
+
+/**
+ * Reads test inputs: a JSON array or JSON Lines of strings or of objects
+ * with an `input` (or `prompt`) field, or a text file with inputs
+ * separated by lines of `---`.
+ */
+export function parseEvalInputs(text: string, fileName: string): string[] {
+  const toInput = (record: unknown): string | undefined => {
+    if (typeof record === 'string') {
+      return record;
+    }
+    const object = record as { input?: unknown; prompt?: unknown };
+    const value = object?.input ?? object?.prompt;
+    return typeof value === 'string' ? value : undefined;
+  };
+  let records: unknown[];
+  if (/\.jsonl$/i.test(fileName)) {
+    records = text
+      .split(/\r?\n/)
+      .filter((line) => line.trim())
+      .map((line) => JSON.parse(line));
+  } else if (/\.json$/i.test(fileName)) {
+    const data = JSON.parse(text);
+    if (!Array.isArray(data)) {
+      throw new Error('Expected a JSON array of inputs');
+    }
+    records = data;
+  } else {
+    records = text.split(/^---\s*$/m);
+  }
+  return records
+    .map(toInput)
+    .filter((input): input is string => !!input?.trim())
+    .map((input) => input.trim());
+}
+
+export function renderTemplate(
+  template: string | undefined,
+  input: string,
+): string {
+  if (!template) {
+    return input;
+  }
+  return template.includes(INPUT_PLACEHOLDER)
+    ? template.split(INPUT_PLACEHOLDER).join(input)
+    : `${template.trimEnd()}\n\n${input}`;
+}
+
+/** Answers a single prompt with no chat history, for comparisons. */
+export function createEvalGenerator(
+  client: ResearchClient,
+  abortSignal: AbortSignal,
+): (prompt: string, model?: string) => Promise<string> {
+  return async (prompt, model) => {
+    const response = await client.generateContent(
+      [{ role: 'user', parts: [{ text: prompt }] }],
+      {},
+      abortSignal,
+      model,
+    );
+    return getResponseText(response)?.trim() ?? '';
+  };
+}
+
+/**
+ * Runs both variants over every input. Each case shows the outputs in a
+ * random order, so that grading does not know which variant is which.
+ */
+export async function runEval(
+  generate: (prompt: string, model?: string) => Promise<string>,
+  inputsFile: string,
+  inputs: string[],
+  variants: [EvalVariant, EvalVariant],
+  onProgress?: (done: number, total: number) => void,
+  random: () => number = Math.random,
+): Promise<EvalRun> {
+  const cases: EvalCase[] = [];
+  for (const input of inputs) {
+    const outputs = (await Promise.all(
+      variants.map((variant) =>
+        generate(renderTemplate(variant.template, input), variant.model),
+      ),
+    )) as [string, string];
+    cases.push({ input, outputs, firstShown: random() < 0.5 ? 0 : 1 });
+    onProgress?.(cases.length, inputs.length);
+  }
+  const created = new Date().toISOString();
+  return {
+    id: created.replace(/[:.]/g, '-'),
+    created,
+    inputsFile,
+    variants,
+    cases,
+  };
+}
+
+/** The outputs as shown for grading: Output 1, then Output 2. */
+export function getBlindOutputs(evalCase: EvalCase): [string, string] {
+  const [a, b] = evalCase.outputs;
+  return evalCase.firstShown === 0 ? [a, b] : [b, a];
+}
+
+/** Records scores given to Output 1 and Output 2 against A and B. */
+export function gradeCase(
+  evalCase: EvalCase,
+  shownScores: [number, number],
+): EvalCase {
+  const [first, second] = shownScores;
+  return {
+    ...evalCase,
+    scores: evalCase.firstShown === 0 ? [first, second] : [second, first],
+  };
+}
+
+export function summarizeEval(run: EvalRun): EvalSummary {
+  const graded = run.cases.filter((c) => c.scores);
+  const wins: [number, number] = [0, 0];
+  let ties = 0;
+  const sums = [0, 0];
+  for (const { scores } of graded) {
+    const [a, b] = scores!;
+    sums[0] += a;
+    sums[1] += b;
+    if (a > b) {
+      wins[0]++;
+    } else if (b > a) {
+      wins[1]++;
+    } else {
+      ties++;
+    }
+  }
+  const mean = (sum: number) =>
+    graded.length > 0 ? Math.round((sum / graded.length) * 100) / 100 : 0;
+  return {
+    graded: graded.length,
+    total: run.cases.length,
+    meanScores: [mean(sums[0]), mean(sums[1])],
+    wins,
+    ties,
+  };
+}
+
+export async function saveEvalRun(
+  projectRoot: string,
+  run: EvalRun,
+): Promise<string> {
+  const filePath = path.join(getEvalsDir(projectRoot), `${run.id}.json`);
+  await fs.promises.mkdir(path.dirname(filePath), { recursive: true });
+  await fs.promises.writeFile(filePath, JSON.stringify(run, null, 2), 'utf8');
+  return filePath;
+}
+
+/** The saved run with the given id, or the latest one. */
+export async function loadEvalRun(
+  projectRoot: string,
+  id?: string,
+): Promise<EvalRun | undefined> {
+  const dir = getEvalsDir(projectRoot);
+  let files: string[];
+  try {
+    files = (await fs.promises.readdir(dir))
+      .filter((file) => file.endsWith('.json'))
+      .sort();
+  } catch (error) {
+    if (isNodeError(error) && error.code === 'ENOENT') {
+      return undefined;
+    }
+    throw error;
+  }
+  const file = id
+    ? files.find((f) => f === `${id}.json`)
+    : files[files.length - 1];
+  if (!file) {
+    return undefined;
+  }
+  return JSON.parse(
+    await fs.promises.readFile(path.join(dir, file), 'utf8'),
+  ) as EvalRun;
+}
@@ -0,0 +1,144 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import { extractReadableContent, fetchReadablePage } from './readability.js';
+
+const ARTICLE = `Single-cell sequencing measures gene expression in individual cells. ${'It resolves cell types that bulk methods average away. '.repeat(6)}`;
+
+const PAGE = `<html><head>
+<title>Fallback title</title>
+<meta property="og:title" content="Single-cell sequencing &amp; you">
+<meta name="author" content="A. Lovelace">
+</head><body>
+<nav><a href="/">Home</a> <a href="/blog">Blog</a></nav>
+<div class="cookie-banner">We use cookies.</div>
+<article>
+  <header><h1>Single-cell sequencing</h1></header>
+  <p>${ARTICLE}</p>
+  <h2>Methods</h2>
+  <ul><li>Droplet capture</li><li>Plate-based</li></ul>
+  <div class="share-bar"><a href="#">Share on social media</a></div>
+</article>
+<div id="comments">First!</div>
+<footer>Copyright 2025</footer>
+</body></html>`;
+
+describe('extractReadableContent', () => {
+  it('should keep the article and drop page chrome', () => {
+    const content = extractReadableContent(PAGE);
+    expect(content.title).toBe('Single-cell sequencing & you');
+    expect(content.byline).toBe('A. Lovelace');
+    expect(content.text).toContain(ARTICLE.trim());
+    expect(content.text).toContain('Methods\n\n- Droplet capture\n- Plate-based');
+    for (const chrome of ['Home', 'cookies', 'Share', 'First!', 'Copyright']) {
+      expect(content.text).not.toContain(chrome);
+    }
+  });
+
+  it('should fall back to the body when the markup hides the content', () => {
+    const html = `<html><head><title>Notes</title></head><body><div class="has-sidebar"><p>${ARTICLE}</p></div></body></html>`;
+    const content = extractReadableContent(html);
+    expect(content.title).toBe('Notes');
+    expect(content.text).toBe(ARTICLE.trim());
+  });
+});
+
+describe('fetchReadablePage', () => {
+  let cacheDir: string;
+
+  beforeEach(() => {
+    cacheDir = fs.mkdtempSync(path.join(os.tmpdir(), 'page-cache-'));
+  });
+
+  afterEach(() => {
+    vi.unstubAllGlobals();
+    fs.rmSync(cacheDir, { recursive: true, force: true });
+  });
+
+  it('should download a page once and then serve it from the cache', async () => {
+    const fetchMock = vi.fn(
+      async () =>
+        new Response(PAGE, { headers: { 'Content-Type': 'text/html' } }),
+    );
+    vi.stubGlobal('fetch', fetchMock);
+
+    const first = await fetchReadablePage('https://example.org/post', {
+      cacheDir,
+    });
+    expect(first.cached).toBe(false);
+    expect(first.page.title).toBe('Single-cell sequencing & you');
+
+    const second = await fetchReadablePage('https://example.org/post', {
+      cacheDir,
+    });
+    expect(second.cached).toBe(true);
+    expect(second.page.text).toBe(first.page.text);
+    expect(fetchMock).toHaveBeenCalledTimes(1);
+
+    await fetchReadablePage('https://example.org/post', {
+      cacheDir,
+      refresh: true,
+    });
+    expect(fetchMock).toHaveBeenCalledTimes(2);
+  });
+
+  it('should not touch the cache when told not to', async () => {
+    const fetchMock = vi.fn(
+      async () =>
+        new Response(PAGE, { headers: { 'Content-Type': 'text/html' } }),
+    );
+    vi.stubGlobal('fetch', fetchMock);
+
+    await fetchReadablePage('https://example.org/post', { cacheDir });
+    const result = await fetchReadablePage('https://example.org/post', {
+      cacheDir,
+      noCache: true,
+    });
+    expect(result.cached).toBe(false);
+    expect(fetchMock).toHaveBeenCalledTimes(2);
+
+    fs.rmSync(cacheDir, { recursive: true, force: true });
+    await fetchReadablePage('https://example.org/other', {
+      cacheDir,
+      noCache: true,
+    });
+    expect(fs.existsSync(cacheDir)).toBe(false);
+  });
+
+  it('should refuse pages that are too large', async () => {
+    vi.stubGlobal(
+      'fetch',
+      vi.fn(
+        async () =>
+          new Response('x'.repeat(6 * 1024 * 1024), {
+            headers: { 'Content-Type': 'text/plain' },
+          }),
+      ),
+    );
+    await expect(
+      fetchReadablePage('https://example.org/dump.txt', { cacheDir }),
+    ).rejects.toThrow('The page is larger than 5MB.');
+  });
+
+  it('should refuse content that is not text', async () => {
+    vi.stubGlobal(
+      'fetch',
+      vi.fn(
+        async () =>
+          new Response('%PDF-1.7', {
+            headers: { 'Content-Type': 'application/pdf' },
+          }),
+      ),
+    );
+    await expect(
+      fetchReadablePage('https://example.org/paper.pdf', { cacheDir }),
+    ).rejects.toThrow('Unsupported content type: application/pdf');
+  });
+});
@@ -0,0 +1,324 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import * as crypto from 'crypto';
+import { convert } from 'html-to-text';
+import { fetchWithTimeout } from './fetch.js';
+import { getUserResearchDir } from './paths.js';
+
+const FETCH_TIMEOUT_MS = 15000;
+const DEFAULT_MAX_AGE_MS = 24 * 60 * 60 * 1000;
+const MIN_CONTENT_LENGTH = 250;
+// Pages larger than this are refused rather than read into memory
+const MAX_PAGE_BYTES = 5 * 1024 * 1024;
+// The oldest cached pages are removed beyond this total size
+const MAX_CACHE_BYTES = 50 * 1024 * 1024;
+
+/** Tags that never hold article text. */
+const BOILERPLATE_TAGS = [
+  'nav',
+  'header',
+  'footer',
+  'aside',
+  'form',
+  'button',
+  'script',
+  'style',
+  'noscript',
+  'svg',
+  'iframe',
+  'img',
+  '[role="navigation"]',
+  '[role="banner"]',
+  '[role="contentinfo"]',
+  '[aria-hidden="true"]',
+];
+
+/** Class and id fragments that mark page chrome rather than content. */
+const UNLIKELY_CANDIDATES = [
+  'breadcrumb',
+  'comment',
+  'cookie',
+  'consent',
+  'newsletter',
+  'subscribe',
+  'share',
+  'social',
+  'sidebar',
+  'related',
+  'advert',
+  'sponsor',
+  'promo',
+  'popup',
+  'modal',
+  'pagination',
+];
+
+export interface ReadableContent {
+  title: string;
+  byline?: string;
+  siteName?: string;
+  /** The main text, with headings and lists kept as plain-text blocks. */
+  text: string;
+}
+
+export interface ReadablePage extends ReadableContent {
+  url: string;
+  /** ISO timestamp of the download. */
+  fetchedAt: string;
+}
+
+export interface FetchReadablePageOptions {
+  /** Where pages are cached; defaults to ~/.research/cache/pages. */
+  cacheDir?: string;
+  /** Cached pages older than this are downloaded again. */
+  maxAgeMs?: number;
+  /** Ignore the cache and download the page. */
+  refresh?: boolean;
+  /** Neither read nor write the cache, as in incognito sessions. */
+  noCache?: boolean;
+}
+
+function parseAttributes(tag: string): Record<string, string> {
+  const attributes: Record<string, string> = {};
+  const pattern = /([\w:-]+)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+))/g;
+  for (const match of tag.matchAll(pattern)) {
+    attributes[match[1].toLowerCase()] = match[2] ?? match[3] ?? match[4];
+  }
+  return attributes;
+}
+
+function decode(text: string): string {
+  return convert(text, { wordwrap: false }).replace(/\s+/g, ' ').trim();
+}
+
+function getMeta(html: string, ...names: string[]): string | undefined {
+  for (const tag of html.match(/<meta\b[^>]*>/gi) ?? []) {
+    const attributes = parseAttributes(tag);
+    const key = (attributes.property ?? attributes.name ?? '').toLowerCase();
+    if (names.includes(key) && attributes.content) {
+      return decode(attributes.content);
+    }
+  }
+  return undefined;
+}
+
+/**
+ * Picks the element most likely to wrap the article, the way readers
+ * do before scoring: a single <article>, then <main>, then the body.
+ */
+function pickBaseSelector(html: string): string {
+  const articles = html.match(/<article[\s>]/gi)?.length ?? 0;
+  if (articles === 1) {
+    return 'article';
+  }
+  if (/<main[\s>]/i.test(html)) {
+    return 'main';
+  }
+  if (/role\s*=\s*["']?main/i.test(html)) {
+    return '[role="main"]';
+  }
+  return 'body';
+}
+
+function toText(html: string, base: string, skip: string[]): string {
+  return convert(html, {
+    wordwrap: false,
+    baseElements: { selectors: [base], returnDomByDefault: true },
+    selectors: [
+      { selector: 'a', options: { ignoreHref: true } },
+      { selector: 'ul', options: { itemPrefix: '- ' } },
+      ...['h1', 'h2', 'h3', 'h4', 'h5', 'h6'].map((selector) => ({
+        selector,
+        options: { uppercase: false },
+      })),
+      ...skip.map((selector) => ({ selector, format: 'skip' })),
+    ],
+  })
+    .split('\n')
+    .map((line) => line.trimEnd())
+    .join('\n')
+    .replace(/\n{3,}/g, '\n\n')
+    .trim();
+}
+
+/**
+ * Extracts the readable part of an HTML page: the article or main
+ * element without navigation, headers, footers, sidebars, share bars,
+ * cookie banners and comments. Pages whose markup hides all content
+ * behind such classes fall back to the whole body.
+ */
+export function extractReadableContent(html: string): ReadableContent {
+  const title =
+    getMeta(html, 'og:title', 'twitter:title') ??
+    decode(html.match(/<title[^>]*>([\s\S]*?)<\/title>/i)?.[1] ?? '');
+  const unlikely = UNLIKELY_CANDIDATES.flatMap((word) => [
+    `[class*="${word}"]`,
+    `[id*="${word}"]`,
+  ]);
+
+  let text = toText(html, pickBaseSelector(html), [
+    ...BOILERPLATE_TAGS,
+    ...unlikely,
+  ]);
+  if (text.length < MIN_CONTENT_LENGTH) {
+    const body = toText(html, 'body', BOILERPLATE_TAGS);
+    if (body.length > text.length) {
+      text = body;
+    }
+  }
+
+  return {
+    title,
+    byline: getMeta(html, 'author', 'article:author'),
+    siteName: getMeta(html, 'og:site_name'),
+    text,
+  };
+}
+
+export function getPageCacheDir(): string {
+  return path.join(getUserResearchDir(), 'cache', 'pages');
+}
+
+function getCachePath(cacheDir: string, url: string): string {
+  const hash = crypto.createHash('sha256').update(url).digest('hex');
+  return path.join(cacheDir, `${hash}.json`);
+}
+
+async function readBody(response: Response): Promise<string> {
+  const tooLarge = () =>
+    new Error(`The page is larger than ${MAX_PAGE_BYTES / (1024 * 1024)}MB.`);
+  if (Number(response.headers.get('content-length')) > MAX_PAGE_BYTES) {
+    await response.body?.cancel();
+    throw tooLarge();
+  }
+  if (!response.body) {
+    return '';
+  }
+  const reader = response.body.getReader();
+  const chunks: Buffer[] = [];
+  let size = 0;
+  while (true) {
+    const { done, value } = await reader.read();
+    if (done) {
+      break;
+    }
+    chunks.push(Buffer.from(value));
+    size += value.length;
+    if (size > MAX_PAGE_BYTES) {
+      await reader.cancel();
+      throw tooLarge();
+    }
+  }
+  return Buffer.concat(chunks).toString('utf8');
+}
+
+/** Removes the least recently fetched pages once the cache is too large. */
+async function pruneCache(cacheDir: string): Promise<void> {
+  const entries = [];
+  for (const name of await fs.promises.readdir(cacheDir)) {
+    if (!name.endsWith('.json')) {
+      continue;
+    }
+    const file = path.join(cacheDir, name);
+    try {
+      const stats = await fs.promises.stat(file);
+      entries.push({ file, size: stats.size, mtimeMs: stats.mtimeMs });
+    } catch {
+      // Removed by another session in the meantime
+    }
+  }
+  let total = entries.reduce((sum, entry) => sum + entry.size, 0);
+  entries.sort((a, b) => a.mtimeMs - b.mtimeMs);
+  for (const entry of entries) {
+    if (total <= MAX_CACHE_BYTES) {
+      break;
+    }
+    await fs.promises.rm(entry.file, { force: true });
+    total -= entry.size;
+  }
+}
+
+async function readCachedPage(
+  file: string,
+  maxAgeMs: number,
+): Promise<ReadablePage | undefined> {
+  try {
+    const page = JSON.parse(
+      await fs.promises.readFile(file, 'utf8'),
+    ) as ReadablePage;
+    if (Date.now() - Date.parse(page.fetchedAt) <= maxAgeMs) {
+      return page;
+    }
+  } catch {
+    // Missing or unreadable cache entries are fetched again.
+  }
+  return undefined;
+}
+
+/**
+ * Downloads a page and extracts its readable text, reusing a cached copy
+ * when one is fresh enough. Plain-text and Markdown pages are kept as is.
+ */
+export async function fetchReadablePage(
+  url: string,
+  options: FetchReadablePageOptions = {},
+): Promise<{ page: ReadablePage; cached: boolean }> {
+  const cacheDir = options.cacheDir ?? getPageCacheDir();
+  const cacheFile = getCachePath(cacheDir, url);
+  if (!options.refresh && !options.noCache) {
+    const cached = await readCachedPage(
+      cacheFile,
+      options.maxAgeMs ?? DEFAULT_MAX_AGE_MS,
+    );
+    if (cached) {
+      return { page: cached, cached: true };
+    }
+  }
+
+  const response = await fetchWithTimeout(url, FETCH_TIMEOUT_MS);
+  if (!response.ok) {
+    throw new Error(
+      `Request failed with status code ${response.status} ${response.statusText}`,
+    );
+  }
+  const contentType = response.headers.get('content-type') ?? '';
+  const body = await readBody(response);
+  let content: ReadableContent;
+  if (/html/i.test(contentType) || (!contentType && /<html/i.test(body))) {
+    content = extractReadableContent(body);
+  } else if (/^text\/|markdown/i.test(contentType)) {
+    content = {
+      title: path.posix.basename(new URL(url).pathname) || url,
+      text: body.trim(),
+    };
+  } else {
+    throw new Error(`Unsupported content type: ${contentType || 'unknown'}`);
+  }
+  if (!content.text) {
+    throw new Error('No readable text was found on the page.');
+  }
+
+  const page: ReadablePage = {
+    url,
+    ...content,
+    title: content.title || url,
+    fetchedAt: new Date().toISOString(),
+  };
+  if (options.noCache) {
+    return { page, cached: false };
+  }
+  try {
+    await fs.promises.mkdir(cacheDir, { recursive: true });
+    await fs.promises.writeFile(cacheFile, JSON.stringify(page, null, 2));
+    await pruneCache(cacheDir);
+  } catch {
+    // A page that cannot be cached is still returned.
+  }
+  return { page, cached: false };
+}
@@ -0,0 +1,297 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import crypto from 'node:crypto';
+import { Content, Part } from '@google/genai';
+
+export interface RedactionRule {
+  /** Short name shown in the placeholder, e.g. `[REDACTED:email]`. */
+  name: string;
+  /** JavaScript regular expression source. */
+  pattern: string;
+  /** Regular expression flags. `g` is always added. */
+  flags?: string;
+  /** Replacement text; may reference capture groups ($1). */
+  replacement?: string;
+}
+
+export interface RedactionSettings {
+  /** Defaults to true when a redaction block is present in settings. */
+  enabled?: boolean;
+  /** Apply the built-in secret detectors. Defaults to true. */
+  detectSecrets?: boolean;
+  /** Additional user-defined rules, applied after the secret detectors. */
+  rules?: RedactionRule[];
+}
+
+export interface RedactionMatch {
+  rule: string;
+  original: string;
+  replacement: string;
+}
+
+export interface RedactionResult<T> {
+  value: T;
+  matches: RedactionMatch[];
+}
+
+/**
+ * Built-in detectors for common credential formats. Order matters: more
+ * specific patterns must come before the generic ones they overlap with.
+ */
+export const SECRET_DETECTORS: RedactionRule[] = [
+  {
+    name: 'private-key',
+    pattern:
+      '-----BEGIN [A-Z ]*PRIVATE KEY-----[\\s\\S]*?-----END [A-Z ]*PRIVATE KEY-----',
+  },
+  { name: 'anthropic-key', pattern: '\\bsk-ant-[A-Za-z0-9_-]{20,}' },
+  { name: 'openai-key', pattern: '\\bsk-(?:proj-)?[A-Za-z0-9_-]{20,}' },
+  { name: 'aws-access-key', pattern: '\\b(?:AKIA|ASIA)[0-9A-Z]{16}\\b' },
+  { name: 'github-token', pattern: '\\bgh[pousr]_[A-Za-z0-9]{36,}\\b' },
+  { name: 'google-api-key', pattern: '\\bAIza[0-9A-Za-z_-]{35}\\b' },
+  { name: 'slack-token', pattern: '\\bxox[abprs]-[A-Za-z0-9-]{10,}' },
+  {
+    name: 'bearer-token',
+    pattern: '\\bBearer\\s+[A-Za-z0-9._~+/-]{20,}=*',
+    replacement: 'Bearer [REDACTED:bearer-token]',
+  },
+  {
+    name: 'credential',
+    pattern:
+      '\\b(password|passwd|pwd|secret|api[_-]?key|access[_-]?token)(\\s*[:=]\\s*)[\'"]?[^\\s\'"]{6,}[\'"]?',
+    flags: 'i',
+    replacement: '$1$2[REDACTED:credential]',
+  },
+];
+
+/** Number of recent matches kept in memory for the local unredacted view. */
+const MAX_RECENT_MATCHES = 100;
+
+interface CompiledRule {
+  rule: RedactionRule;
+  regex: RegExp;
+}
+
+function compileRule(rule: RedactionRule): CompiledRule {
+  const flags = Array.from(new Set(`${rule.flags ?? ''}g`)).join('');
+  return { rule, regex: new RegExp(rule.pattern, flags) };
+}
+
+/**
+ * Checks the user's redaction rules, so that settings can warn about
+ * invalid ones when they are loaded. Returns the valid rules and a
+ * message for each invalid one.
+ */
+export function validateRedactionRules(rules: RedactionRule[] = []): {
+  valid: RedactionRule[];
+  errors: string[];
+} {
+  const valid: RedactionRule[] = [];
+  const errors: string[] = [];
+  for (const rule of rules) {
+    try {
+      if (typeof rule?.name !== 'string' || typeof rule.pattern !== 'string') {
+        throw new Error('a rule needs a name and a pattern');
+      }
+      compileRule(rule);
+      valid.push(rule);
+    } catch (error) {
+      errors.push(
+        `Invalid redaction rule '${rule?.name ?? '?'}': ${error instanceof Error ? error.message : String(error)}`,
+      );
+    }
+  }
+  return { valid, errors };
+}
+
+/**
+ * Applies redaction rules to text, tool results and conversation contents.
+ * Original values are only ever kept in memory so they can be shown locally.
+ */
+export class Redactor {
+  private readonly rules: CompiledRule[];
+  private readonly enabled: boolean;
+  private recent: RedactionMatch[] = [];
+  // Digests of the values redacted so far, not the values themselves
+  private seen = new Set<string>();
+
+  constructor(settings?: RedactionSettings) {
+    this.enabled = !!settings && (settings.enabled ?? true);
+    // Invalid rules are reported when the settings are loaded; here they
+    // are skipped rather than failing the session.
+    const rules = [
+      ...(settings?.detectSecrets === false ? [] : SECRET_DETECTORS),
+      ...validateRedactionRules(settings?.rules).valid,
+    ];
+    this.rules = this.enabled ? rules.map(compileRule) : [];
+  }
+
+  isEnabled(): boolean {
+    return this.enabled;
+  }
+
+  /**
+   * Redacts a string. Pass `record` for content that is about to leave the
+   * machine so it shows up in the redaction indicator and local view.
+   */
+  redactText(text: string, record = false): RedactionResult<string> {
+    const matches: RedactionMatch[] = [];
+    if (!this.enabled || !text) {
+      return { value: text, matches };
+    }
+
+    let value = text;
+    for (const { rule, regex } of this.rules) {
+      value = value.replace(regex, (original) => {
+        const replacement =
+          rule.replacement !== undefined
+            ? original.replace(
+                new RegExp(regex.source, regex.flags.replace('g', '')),
+                rule.replacement,
+              )
+            : `[REDACTED:${rule.name}]`;
+        matches.push({ rule: rule.name, original, replacement });
+        return replacement;
+      });
+    }
+
+    if (record) {
+      this.record(matches);
+    }
+    return { value, matches };
+  }
+
+  /**
+   * Redacts every string inside a JSON-like value (e.g. a tool result).
+   */
+  redactValue<T>(input: T, record = false): RedactionResult<T> {
+    const matches: RedactionMatch[] = [];
+    const walk = (value: unknown): unknown => {
+      if (typeof value === 'string') {
+        const result = this.redactText(value, record);
+        matches.push(...result.matches);
+        return result.value;
+      }
+      if (Array.isArray(value)) {
+        return value.map(walk);
+      }
+      if (value && typeof value === 'object') {
+        return Object.fromEntries(
+          Object.entries(value).map(([key, v]) => [key, walk(v)]),
+        );
+      }
+      return value;
+    };
+    if (!this.enabled) {
+      return { value: input, matches };
+    }
+    return { value: walk(input) as T, matches };
+  }
+
+  /**
+   * Returns a redacted copy of the conversation. Text parts and function
+   * call/response payloads are redacted; other parts are passed through.
+   */
+  redactContents(
+    contents: Content[],
+    record = false,
+  ): RedactionResult<Content[]> {
+    const matches: RedactionMatch[] = [];
+    if (!this.enabled) {
+      return { value: contents, matches };
+    }
+
+    const value = contents.map((content) => ({
+      ...content,
+      parts: content.parts?.map((part): Part => {
+        if (part.text !== undefined) {
+          const result = this.redactText(part.text, record);
+          matches.push(...result.matches);
+          return { ...part, text: result.value };
+        }
+        if (part.functionResponse?.response) {
+          const result = this.redactValue(
+            part.functionResponse.response,
+            record,
+          );
+          matches.push(...result.matches);
+          return {
+            ...part,
+            functionResponse: {
+              ...part.functionResponse,
+              response: result.value,
+            },
+          };
+        }
+        if (part.functionCall?.args) {
+          const result = this.redactValue(part.functionCall.args, record);
+          matches.push(...result.matches);
+          return {
+            ...part,
+            functionCall: { ...part.functionCall, args: result.value },
+          };
+        }
+        return part;
+      }),
+    }));
+    return { value, matches };
+  }
+
+  /** Most recent matches, newest last. Never persisted. */
+  getRecentMatches(): RedactionMatch[] {
+    return [...this.recent];
+  }
+
+  /** Number of distinct values redacted from outgoing content. */
+  getTotalCount(): number {
+    return this.seen.size;
+  }
+
+  /**
+   * Forgets the redacted values. Only their digests are kept, so that the
+   * count stays and values the history resends are not listed again.
+   */
+  clearRecentMatches(): void {
+    this.recent = [];
+  }
+
+  private record(matches: RedactionMatch[]): void {
+    // The full history is resent every turn; only count each value once.
+    const fresh = matches.filter((match) => {
+      const key = crypto
+        .createHash('sha256')
+        .update(`${match.rule}\u0000${match.original}`)
+        .digest('hex');
+      if (this.seen.has(key)) {
+        return false;
+      }
+      this.seen.add(key);
+      return true;
+    });
+    if (fresh.length === 0) {
+      return;
+    }
+    this.recent.push(...fresh);
+    if (this.recent.length > MAX_RECENT_MATCHES) {
+      this.recent = this.recent.slice(-MAX_RECENT_MATCHES);
+    }
+  }
+}
+
+let activeRedactor = new Redactor();
+
+/**
+ * Installs the redaction settings used for outgoing requests, logs and
+ * saved conversations.
+ */
+export function applyRedactionSettings(settings?: RedactionSettings): void {
+  activeRedactor = new Redactor(settings);
+}
+
+export function getRedactor(): Redactor {
+  return activeRedactor;
+}
@@ -0,0 +1,114 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, vi, beforeEach } from 'vitest';
+import { EventEmitter } from 'node:events';
+import { PassThrough } from 'node:stream';
+import { spawn } from 'node:child_process';
+import {
+  grepRemote,
+  listRemoteFiles,
+  readRemoteFile,
+  validateRemotePath,
+  writeRemoteFile,
+} from './remoteFiles.js';
+
+vi.mock('node:child_process', () => ({ spawn: vi.fn() }));
+
+const target = { host: 'gpu01', user: 'alice', cwd: '/data/run1' };
+
+/** Makes the next ssh call print `stdout` and exit with `code`. */
+function respond(stdout: string, code = 0, stderr = '') {
+  const input: string[] = [];
+  vi.mocked(spawn).mockImplementationOnce(() => {
+    const child = Object.assign(new EventEmitter(), {
+      stdout: new PassThrough(),
+      stderr: new PassThrough(),
+      stdin: new PassThrough(),
+    });
+    child.stdin.on('data', (chunk: Buffer) => input.push(chunk.toString()));
+    setImmediate(() => {
+      child.stdout.end(stdout);
+      child.stderr.end(stderr);
+      child.emit('close', code);
+    });
+    return child as never;
+  });
+  return input;
+}
+
+/** The command the last ssh call gave to `bash -c` on the host. */
+function lastCommand(): string {
+  const args = vi.mocked(spawn).mock.calls.at(-1)![1] as string[];
+  const remoteCommand = args[args.length - 1];
+  return remoteCommand
+    .slice(remoteCommand.indexOf("bash -c '") + "bash -c '".length, -1)
+    .replace(/'\\''/g, "'");
+}
+
+describe('remote files', () => {
+  beforeEach(() => {
+    vi.mocked(spawn).mockReset();
+  });
+
+  it('should only accept absolute paths within the working directory', () => {
+    expect(validateRemotePath(target, '/data/run1/out.csv')).toBeNull();
+    expect(validateRemotePath(target, 'out.csv')).toContain('absolute');
+    expect(validateRemotePath(target, '/etc/passwd')).toContain(
+      'within the remote working directory (/data/run1)',
+    );
+    expect(validateRemotePath({ host: 'gpu01' }, '/etc/hosts')).toBeNull();
+  });
+
+  it('should read a file and report a missing one as undefined', async () => {
+    respond('a,b\n1,2\n');
+    expect(await readRemoteFile(target, '/data/run1/out.csv')).toBe(
+      'a,b\n1,2\n',
+    );
+    expect(vi.mocked(spawn).mock.calls[0][0]).toBe('ssh');
+    expect(lastCommand()).toContain(`cat -- '/data/run1/out.csv'`);
+
+    respond('', 3);
+    expect(await readRemoteFile(target, '/data/run1/none')).toBeUndefined();
+
+    respond('', 1, 'cat: /data/run1/x: Permission denied');
+    await expect(readRemoteFile(target, '/data/run1/x')).rejects.toThrow(
+      'Permission denied',
+    );
+  });
+
+  it('should write a file through standard input', async () => {
+    const input = respond('');
+    await writeRemoteFile(target, "/data/run1/it's.txt", 'hello');
+    expect(lastCommand()).toBe(
+      `mkdir -p -- '/data/run1' && cat > '/data/run1/it'\\''s.txt'`,
+    );
+    expect(input.join('')).toBe('hello');
+  });
+
+  it('should list files newest first', async () => {
+    respond(
+      '1760600000.5 /data/run1/b.csv\n1760500000.0 /data/run1/a.csv\n',
+    );
+    expect(await listRemoteFiles(target, '/data/run1')).toEqual([
+      '/data/run1/b.csv',
+      '/data/run1/a.csv',
+    ]);
+    expect(lastCommand()).toContain(`-name '.git'`);
+  });
+
+  it('should parse grep output and treat no match as empty', async () => {
+    respond('/data/run1/a.py:3:import numpy as np\n/data/run1/b.py:10:x:y\n');
+    expect(await grepRemote(target, 'import|x', '/data/run1', '*.py')).toEqual([
+      { filePath: '/data/run1/a.py', lineNumber: 3, line: 'import numpy as np' },
+      { filePath: '/data/run1/b.py', lineNumber: 10, line: 'x:y' },
+    ]);
+    expect(lastCommand()).toContain(`--include='*.py' -e 'import|x'`);
+
+    respond('', 1);
+    expect(await grepRemote(target, 'nothing', '.')).toEqual([]);
+  });
+});
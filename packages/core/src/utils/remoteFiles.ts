@@ -0,0 +1,212 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import path from 'node:path';
+import { spawn } from 'node:child_process';
+import { buildSshArgs, RemoteTarget } from './remoteTarget.js';
+
+// Exit code of the read command when the file does not exist
+const MISSING_FILE_EXIT_CODE = 3;
+// Folders the file listing and search leave out, as the local tools do
+const SKIPPED_DIRECTORIES = ['.git', 'node_modules'];
+const MAX_LISTED_FILES = 20_000;
+
+/** A command on the remote host that failed. */
+export class RemoteCommandError extends Error {
+  constructor(
+    message: string,
+    readonly exitCode: number | null,
+  ) {
+    super(message);
+  }
+}
+
+function quote(value: string): string {
+  return `'${value.replace(/'/g, `'\\''`)}'`;
+}
+
+/**
+ * Runs `command` with bash on the target and returns what it printed.
+ * `input` is written to its standard input.
+ */
+export function runRemoteCommand(
+  target: RemoteTarget,
+  command: string,
+  options: { input?: string; signal?: AbortSignal } = {},
+): Promise<string> {
+  return new Promise((resolve, reject) => {
+    const child = spawn('ssh', buildSshArgs(target, command), {
+      stdio: ['pipe', 'pipe', 'pipe'],
+      signal: options.signal,
+    });
+    const stdout: Buffer[] = [];
+    const stderr: Buffer[] = [];
+    child.stdout.on('data', (chunk: Buffer) => stdout.push(chunk));
+    child.stderr.on('data', (chunk: Buffer) => stderr.push(chunk));
+    child.on('error', reject);
+    child.on('close', (code) => {
+      if (code === 0) {
+        resolve(Buffer.concat(stdout).toString('utf8'));
+        return;
+      }
+      const message = Buffer.concat(stderr).toString('utf8').trim();
+      reject(
+        new RemoteCommandError(
+          message || `ssh exited with code ${code}`,
+          code,
+        ),
+      );
+    });
+    child.stdin.end(options.input ?? '');
+  });
+}
+
+/**
+ * The directory the file tools are confined to on the target: its working
+ * directory, when that is an absolute path. Otherwise any absolute path
+ * may be used, as the home directory is not known here.
+ */
+export function getRemoteRoot(target: RemoteTarget): string | undefined {
+  return target.cwd && path.posix.isAbsolute(target.cwd)
+    ? path.posix.normalize(target.cwd)
+    : undefined;
+}
+
+/**
+ * Checks a path the model gave for the target, and returns an error
+ * message or null.
+ */
+export function validateRemotePath(
+  target: RemoteTarget,
+  filePath: string,
+): string | null {
+  if (!path.posix.isAbsolute(filePath)) {
+    return `File path must be an absolute path on the remote host, but was: ${filePath}`;
+  }
+  const root = getRemoteRoot(target);
+  const relative = root && path.posix.relative(root, filePath);
+  if (
+    root &&
+    relative &&
+    (relative.startsWith('..') || path.posix.isAbsolute(relative))
+  ) {
+    return `File path must be within the remote working directory (${root}): ${filePath}`;
+  }
+  return null;
+}
+
+/** The content of a text file on the target, or undefined if it is missing. */
+export async function readRemoteFile(
+  target: RemoteTarget,
+  filePath: string,
+  signal?: AbortSignal,
+): Promise<string | undefined> {
+  const file = quote(filePath);
+  try {
+    return await runRemoteCommand(
+      target,
+      `if [ -e ${file} ]; then cat -- ${file}; else exit ${MISSING_FILE_EXIT_CODE}; fi`,
+      { signal },
+    );
+  } catch (error) {
+    if (
+      error instanceof RemoteCommandError &&
+      error.exitCode === MISSING_FILE_EXIT_CODE
+    ) {
+      return undefined;
+    }
+    throw error;
+  }
+}
+
+/** Writes a text file on the target, creating its directory if needed. */
+export async function writeRemoteFile(
+  target: RemoteTarget,
+  filePath: string,
+  content: string,
+  signal?: AbortSignal,
+): Promise<void> {
+  await runRemoteCommand(
+    target,
+    `mkdir -p -- ${quote(path.posix.dirname(filePath))} && cat > ${quote(filePath)}`,
+    { input: content, signal },
+  );
+}
+
+/**
+ * The files under `directory` on the target, as absolute paths, newest
+ * first. `.git` and `node_modules` are skipped.
+ */
+export async function listRemoteFiles(
+  target: RemoteTarget,
+  directory: string,
+  signal?: AbortSignal,
+): Promise<string[]> {
+  const prune = SKIPPED_DIRECTORIES.map((name) => `-name ${quote(name)}`).join(
+    ' -o ',
+  );
+  // %T@ is the modification time, for the newest-first order
+  const output = await runRemoteCommand(
+    target,
+    `find ${quote(directory)} -type d \\( ${prune} \\) -prune -o -type f -printf '%T@ %p\\n' | sort -rn | head -n ${MAX_LISTED_FILES}`,
+    { signal },
+  );
+  return output
+    .split('\n')
+    .filter(Boolean)
+    .map((line) => line.slice(line.indexOf(' ') + 1));
+}
+
+export interface RemoteGrepMatch {
+  filePath: string;
+  lineNumber: number;
+  line: string;
+}
+
+/**
+ * Searches the files under `directory` on the target for an extended
+ * regular expression with `grep`, optionally only in files matching the
+ * `include` glob.
+ */
+export async function grepRemote(
+  target: RemoteTarget,
+  pattern: string,
+  directory: string,
+  include?: string,
+  signal?: AbortSignal,
+): Promise<RemoteGrepMatch[]> {
+  const options = [
+    '-rnIE',
+    ...SKIPPED_DIRECTORIES.map((name) => `--exclude-dir=${quote(name)}`),
+    ...(include ? [`--include=${quote(include)}`] : []),
+  ];
+  let output: string;
+  try {
+    output = await runRemoteCommand(
+      target,
+      `grep ${options.join(' ')} -e ${quote(pattern)} -- ${quote(directory)}`,
+      { signal },
+    );
+  } catch (error) {
+    // grep exits with 1 when nothing matches
+    if (error instanceof RemoteCommandError && error.exitCode === 1) {
+      return [];
+    }
+    throw error;
+  }
+  const matches: RemoteGrepMatch[] = [];
+  for (const line of output.split('\n')) {
+    const match = line.match(/^(.*?):(\d+):(.*)$/);
+    if (match) {
+      matches.push({
+        filePath: match[1],
+        lineNumber: Number(match[2]),
+        line: match[3],
+      });
+    }
+  }
+  return matches;
+}
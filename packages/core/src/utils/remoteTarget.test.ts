@@ -0,0 +1,115 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect } from 'vitest';
+import {
+  buildSshArgs,
+  formatRemoteTarget,
+  isSafeSshOption,
+  parseRemoteTarget,
+} from './remoteTarget.js';
+
+describe('parseRemoteTarget', () => {
+  it('should parse a bare host', () => {
+    expect(parseRemoteTarget('gpu01')).toEqual({ host: 'gpu01' });
+  });
+
+  it('should parse user, port and path', () => {
+    expect(parseRemoteTarget('alice@gpu01.lab.org:2222/data/run1')).toEqual({
+      host: 'gpu01.lab.org',
+      user: 'alice',
+      port: 2222,
+      cwd: '/data/run1',
+    });
+  });
+
+  it('should reject malformed targets', () => {
+    expect(() => parseRemoteTarget('alice@')).toThrow(/Invalid remote target/);
+    expect(() => parseRemoteTarget('host:port')).toThrow(
+      /Invalid remote target/,
+    );
+  });
+
+  it('should reject hosts and users that look like ssh options', () => {
+    expect(() => parseRemoteTarget('-oProxyCommand=id')).toThrow(
+      /cannot start with "-"/,
+    );
+    expect(() => parseRemoteTarget('-lroot@gpu01')).toThrow(
+      /cannot start with "-"/,
+    );
+  });
+});
+
+describe('formatRemoteTarget', () => {
+  it('should include user and port when set', () => {
+    expect(formatRemoteTarget({ host: 'gpu01' })).toBe('gpu01');
+    expect(formatRemoteTarget({ host: 'gpu01', user: 'alice', port: 22 })).toBe(
+      'alice@gpu01:22',
+    );
+  });
+});
+
+describe('buildSshArgs', () => {
+  it('should run the command with bash in batch mode', () => {
+    expect(buildSshArgs({ host: 'gpu01' }, 'ls -la')).toEqual([
+      '-o',
+      'BatchMode=yes',
+      '--',
+      'gpu01',
+      "bash -c 'ls -la'",
+    ]);
+  });
+
+  it('should pass port, key and options before the destination', () => {
+    const args = buildSshArgs(
+      {
+        host: 'gpu01',
+        user: 'alice',
+        port: 2222,
+        identityFile: '~/.ssh/lab',
+        sshOptions: ['StrictHostKeyChecking=accept-new'],
+      },
+      'pwd',
+    );
+    expect(args).toEqual([
+      '-o',
+      'BatchMode=yes',
+      '-p',
+      '2222',
+      '-i',
+      '~/.ssh/lab',
+      '-o',
+      'StrictHostKeyChecking=accept-new',
+      '--',
+      'alice@gpu01',
+      "bash -c 'pwd'",
+    ]);
+  });
+
+  it('should change to the remote directory and quote the command', () => {
+    const args = buildSshArgs(
+      { host: 'gpu01', cwd: '/data/run 1' },
+      "echo 'hi'",
+      'logs',
+    );
+    expect(args[args.length - 1]).toBe(
+      `cd '/data/run 1/logs' && bash -c 'echo '\\''hi'\\'''`,
+    );
+  });
+});
+
+describe('isSafeSshOption', () => {
+  it('should allow options that only tune the connection', () => {
+    expect(isSafeSshOption('StrictHostKeyChecking=accept-new')).toBe(true);
+    expect(isSafeSshOption('connecttimeout 10')).toBe(true);
+  });
+
+  it('should refuse options that run local commands', () => {
+    expect(isSafeSshOption('ProxyCommand=nc %h %p')).toBe(false);
+    expect(isSafeSshOption('LocalCommand id')).toBe(false);
+    expect(isSafeSshOption('PermitLocalCommand=yes')).toBe(false);
+  });
+});
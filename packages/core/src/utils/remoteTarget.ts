@@ -0,0 +1,129 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import path from 'node:path';
+
+/**
+ * An SSH host that shell commands run on instead of the local machine.
+ * Authentication uses the system `ssh` client, so keys, ~/.ssh/config and
+ * the SSH agent all work as usual.
+ */
+export interface RemoteTarget {
+  host: string;
+  user?: string;
+  port?: number;
+  /** Private key passed to `ssh -i`. */
+  identityFile?: string;
+  /** Directory on the remote host that commands run in. */
+  cwd?: string;
+  /** Extra `-o` options, e.g. `["StrictHostKeyChecking=accept-new"]`. */
+  sshOptions?: string[];
+}
+
+/**
+ * Parses `[user@]host[:port][/path]`, the form accepted by `--remote`.
+ */
+export function parseRemoteTarget(spec: string): RemoteTarget {
+  const match = spec
+    .trim()
+    .match(/^(?:([^@/:\s]+)@)?([^@/:\s]+)(?::(\d+))?(\/.*)?$/);
+  if (!match) {
+    throw new Error(
+      `Invalid remote target "${spec}". Expected [user@]host[:port][/path].`,
+    );
+  }
+  const [, user, host, port, cwd] = match;
+  if (host.startsWith('-') || user?.startsWith('-')) {
+    throw new Error(
+      `Invalid remote target "${spec}". The host and user cannot start with "-".`,
+    );
+  }
+  return {
+    host,
+    ...(user && { user }),
+    ...(port && { port: Number(port) }),
+    ...(cwd && { cwd }),
+  };
+}
+
+/**
+ * `-o` options that only tune the connection. Options such as
+ * `ProxyCommand` or `LocalCommand` run programs on this machine, so
+ * settings that anyone can commit to a project are limited to these.
+ */
+const SAFE_SSH_OPTIONS = new Set(
+  [
+    'AddressFamily',
+    'BindAddress',
+    'Ciphers',
+    'Compression',
+    'ConnectionAttempts',
+    'ConnectTimeout',
+    'HostKeyAlgorithms',
+    'HostKeyAlias',
+    'IdentitiesOnly',
+    'IPQoS',
+    'KexAlgorithms',
+    'LogLevel',
+    'MACs',
+    'PreferredAuthentications',
+    'ServerAliveCountMax',
+    'ServerAliveInterval',
+    'StrictHostKeyChecking',
+    'TCPKeepAlive',
+  ].map((name) => name.toLowerCase()),
+);
+
+/** Whether an `-o` option is one that project settings may set. */
+export function isSafeSshOption(option: string): boolean {
+  const name = option.trim().split(/[\s=]/, 1)[0];
+  return SAFE_SSH_OPTIONS.has(name.toLowerCase());
+}
+
+/** Short label used in prompts and approvals, e.g. `alice@gpu01:2222`. */
+export function formatRemoteTarget(target: RemoteTarget): string {
+  const destination = target.user
+    ? `${target.user}@${target.host}`
+    : target.host;
+  return target.port ? `${destination}:${target.port}` : destination;
+}
+
+function quote(value: string): string {
+  return `'${value.replace(/'/g, `'\\''`)}'`;
+}
+
+/**
+ * Builds the `ssh` arguments that run `command` with bash on the target,
+ * optionally in `directory` relative to the target's working directory.
+ */
+export function buildSshArgs(
+  target: RemoteTarget,
+  command: string,
+  directory?: string,
+): string[] {
+  // BatchMode fails fast instead of waiting on a password prompt nobody sees.
+  const args = ['-o', 'BatchMode=yes'];
+  if (target.port) {
+    args.push('-p', String(target.port));
+  }
+  if (target.identityFile) {
+    args.push('-i', target.identityFile);
+  }
+  for (const option of target.sshOptions ?? []) {
+    args.push('-o', option);
+  }
+  // `--` keeps a host that starts with `-` from being read as an option.
+  args.push('--', target.user ? `${target.user}@${target.host}` : target.host);
+
+  const remoteCommand = `bash -c ${quote(command)}`;
+  if (target.cwd || directory) {
+    const cwd = path.posix.join(target.cwd ?? '.', directory ?? '');
+    args.push(`cd ${quote(cwd)} && ${remoteCommand}`);
+  } else {
+    args.push(remoteCommand);
+  }
+  return args;
+}
@@ -0,0 +1,187 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect } from 'vitest';
+import {
+  buildSqlCommand,
+  checkReadOnlyQuery,
+  getSqlEngine,
+  parseSqlOutput,
+} from './sqlDatabase.js';
+
+describe('checkReadOnlyQuery', () => {
+  it('should allow single read-only statements', () => {
+    expect(checkReadOnlyQuery('SELECT * FROM trials;')).toBeNull();
+    expect(
+      checkReadOnlyQuery(
+        "WITH t AS (SELECT phase FROM trials WHERE note = 'drop; delete') SELECT count(*) FROM t",
+      ),
+    ).toBeNull();
+    expect(checkReadOnlyQuery('select created_at from "update"')).toBeNull();
+    expect(checkReadOnlyQuery('PRAGMA table_info(trials)')).toBeNull();
+  });
+
+  it('should refuse writes and multiple statements', () => {
+    expect(checkReadOnlyQuery('DELETE FROM trials')).toContain(
+      'Only read-only queries are allowed',
+    );
+    expect(checkReadOnlyQuery('SELECT 1; DROP TABLE trials')).toBe(
+      'Only a single statement may be run at a time.',
+    );
+    expect(
+      checkReadOnlyQuery('WITH d AS (DELETE FROM trials RETURNING *) SELECT 1'),
+    ).toBe('DELETE is not allowed in a read-only query.');
+    expect(checkReadOnlyQuery('SELECT * INTO backup FROM trials')).toBe(
+      'INTO is not allowed in a read-only query.',
+    );
+    expect(checkReadOnlyQuery('PRAGMA journal_mode = WAL')).toBe(
+      'PRAGMA assignments are not allowed.',
+    );
+    expect(checkReadOnlyQuery('  -- nothing\n')).toBe('The query is empty.');
+  });
+
+  it('should not let strings and comments hide a second statement', () => {
+    const single = 'Only a single statement may be run at a time.';
+    expect(checkReadOnlyQuery("SELECT '--'; DELETE FROM t")).toBe(single);
+    expect(checkReadOnlyQuery("SELECT 1 /* ' */; DELETE FROM t; -- '")).toBe(
+      single,
+    );
+    expect(
+      checkReadOnlyQuery('SELECT $$ -- $$; DELETE FROM t', 'postgres'),
+    ).toBe(single);
+    expect(
+      checkReadOnlyQuery("SELECT 'a\\'; DELETE FROM t; -- '", 'postgres'),
+    ).toBe(single);
+    expect(
+      checkReadOnlyQuery(
+        "SELECT /* /* */ ' */ ; DELETE FROM t; -- '",
+        'postgres',
+      ),
+    ).toBe(single);
+    expect(
+      checkReadOnlyQuery("SELECT 1 #'\n; COMMIT; DELETE FROM t; -- '", 'mysql'),
+    ).toBe(single);
+    expect(checkReadOnlyQuery('SELECT 1 /*! ; COMMIT */', 'mysql')).toBe(
+      single,
+    );
+    expect(checkReadOnlyQuery('SELECT 1--1; DELETE FROM t', 'mysql')).toBe(
+      single,
+    );
+    expect(
+      checkReadOnlyQuery("SELECT [a'] ; DELETE FROM t; SELECT [']", 'sqlite'),
+    ).toBe(single);
+    expect(checkReadOnlyQuery("SELECT 'open")).toBe(
+      'The query has a string or comment that is never closed.',
+    );
+  });
+
+  it('should read strings the way the engine does', () => {
+    expect(
+      checkReadOnlyQuery("SELECT 'a\\'; DELETE FROM t; -- '", 'mysql'),
+    ).toBeNull();
+    expect(checkReadOnlyQuery("SELECT E'it\\'s'", 'postgres')).toBeNull();
+    expect(
+      checkReadOnlyQuery("SELECT $tag$ it's $tag$ AS x", 'postgres'),
+    ).toBeNull();
+  });
+
+  it('should refuse functions that reach outside the database', () => {
+    expect(checkReadOnlyQuery("SELECT writefile('/tmp/x', 'y')")).toBe(
+      'writefile() is not allowed in a read-only query.',
+    );
+    expect(checkReadOnlyQuery("SELECT load_extension('evil')")).toBe(
+      'load_extension() is not allowed in a read-only query.',
+    );
+    expect(
+      checkReadOnlyQuery(
+        "SELECT set_config('default_transaction_read_only', 'off', false)",
+      ),
+    ).toBe('set_config() is not allowed in a read-only query.');
+  });
+});
+
+describe('getSqlEngine', () => {
+  it('should pick the engine from the URL scheme', () => {
+    expect(getSqlEngine('postgresql://h/db')).toBe('postgres');
+    expect(getSqlEngine('mysql://h/db')).toBe('mysql');
+    expect(getSqlEngine('data/results.db')).toBe('sqlite');
+    expect(() => getSqlEngine('oracle://h/db')).toThrow(
+      'Unsupported database URL scheme "oracle:"',
+    );
+  });
+});
+
+describe('buildSqlCommand', () => {
+  it('should run psql read-only with CSV output', () => {
+    const command = buildSqlCommand(
+      { url: 'postgres://reader:pw@db/trials' },
+      'SELECT 1;',
+      '/project',
+    );
+    expect(command.command).toBe('psql');
+    expect(command.args).toEqual([
+      'postgres://reader@db/trials',
+      '--no-psqlrc',
+      '--csv',
+      '-v',
+      'ON_ERROR_STOP=1',
+      '-c',
+      'SELECT 1',
+    ]);
+    expect(command.env.PGOPTIONS).toContain('default_transaction_read_only=on');
+    expect(command.env.PGPASSWORD).toBe('pw');
+  });
+
+  it('should pass the mysql password through the environment', () => {
+    const command = buildSqlCommand(
+      { url: 'mysql://reader:p%40ss@db:3307/trials', command: '/opt/mysql' },
+      'SELECT 1',
+      '/project',
+    );
+    expect(command.command).toBe('/opt/mysql');
+    expect(command.args).toEqual([
+      '--batch',
+      '--host=db',
+      '--port=3307',
+      '--user=reader',
+      '--database=trials',
+      '-e',
+      'START TRANSACTION READ ONLY; SELECT 1; ROLLBACK;',
+    ]);
+    expect(command.env).toEqual({ MYSQL_PWD: 'p@ss' });
+    expect(command.format).toBe('tsv');
+  });
+
+  it('should open SQLite files read-only relative to the project', () => {
+    const command = buildSqlCommand(
+      { url: 'sqlite:data/results.db' },
+      'SELECT 1',
+      '/project',
+    );
+    expect(command.command).toBe('sqlite3');
+    expect(command.args).toContain('-readonly');
+    expect(command.args).toContain('-safe');
+    expect(command.args.slice(-2)).toEqual([
+      '/project/data/results.db',
+      'SELECT 1',
+    ]);
+  });
+});
+
+describe('parseSqlOutput', () => {
+  it('should parse CSV and mysql batch output', () => {
+    expect(parseSqlOutput('phase,n\n1,4\n2,\n', 'csv').rows).toEqual([
+      { phase: 1, n: 4 },
+      { phase: 2, n: null },
+    ]);
+    expect(
+      parseSqlOutput('title\tn\na\\tb "x"\t3\nNULL\t0\n', 'tsv').rows,
+    ).toEqual([
+      { title: 'a\tb "x"', n: 3 },
+      { title: null, n: 0 },
+    ]);
+  });
+});
@@ -0,0 +1,145 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect } from 'vitest';
+import {
+  describeTable,
+  formatMarkdownTable,
+  parseCsv,
+  parseJsonRecords,
+  runTableQuery,
+} from './tabularData.js';
+
+const csv = [
+  'model,dataset,score,notes',
+  'bert,squad,88.5,',
+  'bert,glue,80.1,"fine-tuned, 3 epochs"',
+  'gpt,squad,91.2,"multi',
+  'line"',
+  'gpt,glue,85,',
+  't5,squad,,missing',
+].join('\r\n');
+
+describe('parseCsv', () => {
+  it('should parse quoted fields, numbers and empty cells', () => {
+    const table = parseCsv(csv);
+    expect(table.columns).toEqual(['model', 'dataset', 'score', 'notes']);
+    expect(table.rows).toHaveLength(5);
+    expect(table.rows[1]).toEqual({
+      model: 'bert',
+      dataset: 'glue',
+      score: 80.1,
+      notes: 'fine-tuned, 3 epochs',
+    });
+    expect(table.rows[2].notes).toBe('multi\r\nline');
+    expect(table.rows[4].score).toBeNull();
+  });
+
+  it('should only read plain decimal numbers as numbers', () => {
+    const { rows } = parseCsv('id,value\n0x1F,1e3\n0b10, \n-.5,007\n');
+    expect(rows).toEqual([
+      { id: '0x1F', value: 1000 },
+      { id: '0b10', value: null },
+      { id: -0.5, value: 7 },
+    ]);
+  });
+});
+
+describe('parseJsonRecords', () => {
+  it('should read arrays, wrapped arrays and JSON Lines', () => {
+    expect(parseJsonRecords('[{"a": 1}, {"b": {"c": 2}}]')).toEqual({
+      columns: ['a', 'b'],
+      rows: [
+        { a: 1, b: null },
+        { a: null, b: '{"c":2}' },
+      ],
+    });
+    expect(parseJsonRecords('{"data": [{"a": 1}]}').rows).toEqual([{ a: 1 }]);
+    expect(parseJsonRecords('{"a": 1}\n{"a": 2}\n', true).rows).toEqual([
+      { a: 1 },
+      { a: 2 },
+    ]);
+  });
+});
+
+describe('runTableQuery', () => {
+  const table = parseCsv(csv);
+
+  it('should filter and select columns', () => {
+    const result = runTableQuery(table, {
+      where: ['dataset = squad', 'score >= 90'],
+      select: ['model', 'Score'],
+    });
+    expect(result.columns).toEqual(['model', 'score']);
+    expect(result.rows).toEqual([['gpt', 91.2]]);
+    expect(result.matchedRows).toBe(1);
+    expect(result.totalRows).toBe(5);
+  });
+
+  it('should group, aggregate and sort', () => {
+    const result = runTableQuery(table, {
+      groupBy: ['model'],
+      aggregate: ['count', 'mean(score)', 'max(score)'],
+      orderBy: 'mean(score) desc',
+    });
+    expect(result.columns).toEqual([
+      'model',
+      'count',
+      'mean(score)',
+      'max(score)',
+    ]);
+    expect(result.rows).toEqual([
+      ['gpt', 2, 88.1, 91.2],
+      ['bert', 2, 84.3, 88.5],
+      ['t5', 1, null, null],
+    ]);
+  });
+
+  it('should aggregate over all matching rows without group_by', () => {
+    const result = runTableQuery(table, {
+      where: ['notes ~ EPOCHS'],
+      aggregate: ['count', 'sum(score)'],
+    });
+    expect(result.rows).toEqual([[1, 80.1]]);
+  });
+
+  it('should match empty values with null and apply the limit', () => {
+    expect(
+      runTableQuery(table, { where: ['score = null'], select: ['model'] }).rows,
+    ).toEqual([['t5']]);
+    const limited = runTableQuery(table, { select: ['model'], limit: 2 });
+    expect(limited.rows).toHaveLength(2);
+    expect(limited.truncated).toBe(true);
+  });
+
+  it('should explain unknown columns and bad expressions', () => {
+    expect(() => runTableQuery(table, { where: ['year > 2020'] })).toThrow(
+      'Unknown column "year". Columns: model, dataset, score, notes',
+    );
+    expect(() => runTableQuery(table, { where: ['model'] })).toThrow(
+      'Cannot parse condition "model"',
+    );
+    expect(() =>
+      runTableQuery(table, { aggregate: ['stddev(score)'] }),
+    ).toThrow('Cannot parse aggregate "stddev(score)"');
+  });
+});
+
+describe('describeTable', () => {
+  it('should summarize columns without returning rows', () => {
+    const result = describeTable(parseCsv(csv));
+    expect(result.rows[2]).toEqual(['score', 'number', 4, 4, 80.1, 91.2]);
+    expect(result.rows[0]).toEqual(['model', 'text', 5, 3, null, null]);
+  });
+});
+
+describe('formatMarkdownTable', () => {
+  it('should escape pipes and leave empty cells blank', () => {
+    expect(formatMarkdownTable(['a', 'b'], [['x|y', null]])).toBe(
+      '| a | b |\n| --- | --- |\n| x\\|y |  |',
+    );
+  });
+});
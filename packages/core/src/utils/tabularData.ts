@@ -0,0 +1,486 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import path from 'path';
+
+export type TableCell = string | number | boolean | null;
+export type TableRow = Record<string, TableCell>;
+
+export interface DataTable {
+  columns: string[];
+  rows: TableRow[];
+}
+
+/**
+ * A query over a table. Conditions are `column op value` strings, all of
+ * which must hold; ops are =, !=, >, >=, <, <= and ~ (contains, ignoring
+ * case). Aggregates are count, or sum, mean, median, min, max, count or
+ * distinct of a column, e.g. `mean(score)`.
+ */
+export interface TableQuery {
+  where?: string[];
+  groupBy?: string[];
+  aggregate?: string[];
+  select?: string[];
+  /** A result column, optionally followed by `asc` or `desc`. */
+  orderBy?: string;
+  limit?: number;
+}
+
+export interface TableQueryResult {
+  columns: string[];
+  rows: TableCell[][];
+  /** Rows that passed the `where` conditions. */
+  matchedRows: number;
+  totalRows: number;
+  /** True if `limit` cut off result rows. */
+  truncated: boolean;
+}
+
+export const DEFAULT_QUERY_LIMIT = 50;
+export const MAX_QUERY_LIMIT = 500;
+
+export const TABULAR_EXTENSIONS = [
+  '.csv',
+  '.tsv',
+  '.json',
+  '.jsonl',
+  '.ndjson',
+];
+
+// Plain decimal numbers only: Number() would also read hex, binary and
+// octal literals, and other text a table means as a string
+const NUMBER_PATTERN = /^[-+]?(?:\d+\.?\d*|\.\d+)(?:e[-+]?\d+)?$/i;
+
+function toCell(raw: string): TableCell {
+  const value = raw.trim();
+  if (value === '') {
+    return null;
+  }
+  const number = NUMBER_PATTERN.test(value) ? parseFloat(value) : NaN;
+  return Number.isFinite(number) ? number : raw;
+}
+
+/** Parses RFC 4180 CSV, including quoted fields with embedded newlines. */
+export function parseCsv(text: string, delimiter = ','): DataTable {
+  const records: string[][] = [];
+  let record: string[] = [];
+  let field = '';
+  let inQuotes = false;
+  const input = text.replace(/^\uFEFF/, '');
+  for (let i = 0; i < input.length; i++) {
+    const char = input[i];
+    if (inQuotes) {
+      if (char === '"' && input[i + 1] === '"') {
+        field += '"';
+        i++;
+      } else if (char === '"') {
+        inQuotes = false;
+      } else {
+        field += char;
+      }
+    } else if (char === '"') {
+      inQuotes = true;
+    } else if (char === delimiter) {
+      record.push(field);
+      field = '';
+    } else if (char === '\n' || char === '\r') {
+      if (char === '\r' && input[i + 1] === '\n') {
+        i++;
+      }
+      record.push(field);
+      records.push(record);
+      record = [];
+      field = '';
+    } else {
+      field += char;
+    }
+  }
+  if (field !== '' || record.length > 0) {
+    record.push(field);
+    records.push(record);
+  }
+  const nonEmpty = records.filter((r) => r.some((f) => f.trim() !== ''));
+  const [header = [], ...body] = nonEmpty;
+  const columns = header.map((name, i) => name.trim() || `column${i + 1}`);
+  const rows = body.map((fields) =>
+    Object.fromEntries(
+      columns.map((column, i) => [column, toCell(fields[i] ?? '')]),
+    ),
+  );
+  return { columns, rows };
+}
+
+function toJsonCell(value: unknown): TableCell {
+  if (value === undefined || value === null) {
+    return null;
+  }
+  if (
+    typeof value === 'string' ||
+    typeof value === 'number' ||
+    typeof value === 'boolean'
+  ) {
+    return value;
+  }
+  return JSON.stringify(value);
+}
+
+function recordsToTable(records: unknown[]): DataTable {
+  const columns: string[] = [];
+  const seen = new Set<string>();
+  const objects = records.filter(
+    (r): r is Record<string, unknown> =>
+      typeof r === 'object' && r !== null && !Array.isArray(r),
+  );
+  for (const record of objects) {
+    for (const key of Object.keys(record)) {
+      if (!seen.has(key)) {
+        seen.add(key);
+        columns.push(key);
+      }
+    }
+  }
+  const rows = objects.map((record) =>
+    Object.fromEntries(columns.map((c) => [c, toJsonCell(record[c])])),
+  );
+  return { columns, rows };
+}
+
+/**
+ * Parses a JSON array of records, an object holding one, or JSON Lines.
+ * Nested values are kept as JSON strings.
+ */
+export function parseJsonRecords(text: string, jsonLines = false): DataTable {
+  if (jsonLines) {
+    return recordsToTable(
+      text
+        .split('\n')
+        .filter((line) => line.trim())
+        .map((line) => JSON.parse(line)),
+    );
+  }
+  const data: unknown = JSON.parse(text);
+  if (Array.isArray(data)) {
+    return recordsToTable(data);
+  }
+  if (typeof data === 'object' && data !== null) {
+    const records = Object.values(data).find(Array.isArray);
+    if (records) {
+      return recordsToTable(records);
+    }
+  }
+  throw new Error('Expected a JSON array of records');
+}
+
+/** Parses CSV, TSV, JSON or JSON Lines text, chosen by file extension. */
+export function parseTable(filePath: string, text: string): DataTable {
+  switch (path.extname(filePath).toLowerCase()) {
+    case '.csv':
+      return parseCsv(text);
+    case '.tsv':
+      return parseCsv(text, '\t');
+    case '.json':
+      return parseJsonRecords(text);
+    case '.jsonl':
+    case '.ndjson':
+      return parseJsonRecords(text, true);
+    default:
+      throw new Error(
+        `Unsupported data file; expected one of ${TABULAR_EXTENSIONS.join(', ')}`,
+      );
+  }
+}
+
+function resolveColumn(table: DataTable, name: string): string {
+  const column = name.trim().replace(/^`(.*)`$/, '$1');
+  if (table.columns.includes(column)) {
+    return column;
+  }
+  const caseInsensitive = table.columns.find(
+    (c) => c.toLowerCase() === column.toLowerCase(),
+  );
+  if (caseInsensitive !== undefined) {
+    return caseInsensitive;
+  }
+  throw new Error(
+    `Unknown column "${column}". Columns: ${table.columns.join(', ')}`,
+  );
+}
+
+function compare(a: TableCell, b: TableCell): number {
+  if (a === b) {
+    return 0;
+  }
+  if (a === null) {
+    return -1;
+  }
+  if (b === null) {
+    return 1;
+  }
+  if (typeof a === 'number' && typeof b === 'number') {
+    return a - b;
+  }
+  return String(a).localeCompare(String(b), undefined, { numeric: true });
+}
+
+type Predicate = (row: TableRow) => boolean;
+
+function parseCondition(table: DataTable, condition: string): Predicate {
+  const match = condition.match(
+    /^\s*(`[^`]+`|[^<>=!~]+?)\s*(>=|<=|!=|==|=|>|<|~)\s*(.*?)\s*$/,
+  );
+  if (!match) {
+    throw new Error(
+      `Cannot parse condition "${condition}"; expected "column op value"`,
+    );
+  }
+  const column = resolveColumn(table, match[1]);
+  const op = match[2];
+  const rawValue = match[3];
+  const quoted = rawValue.match(/^(['"])(.*)\1$/);
+  const value: TableCell = quoted
+    ? quoted[2]
+    : rawValue.toLowerCase() === 'null'
+      ? null
+      : toCell(rawValue);
+
+  switch (op) {
+    case '~': {
+      const needle = String(value ?? '').toLowerCase();
+      return (row) =>
+        row[column] !== null &&
+        String(row[column]).toLowerCase().includes(needle);
+    }
+    case '=':
+    case '==':
+      return (row) => compare(row[column], value) === 0;
+    case '!=':
+      return (row) => compare(row[column], value) !== 0;
+    default:
+      return (row) => {
+        if (row[column] === null || value === null) {
+          return false;
+        }
+        const order = compare(row[column], value);
+        return op === '>'
+          ? order > 0
+          : op === '>='
+            ? order >= 0
+            : op === '<'
+              ? order < 0
+              : order <= 0;
+      };
+  }
+}
+
+interface Aggregate {
+  label: string;
+  compute: (rows: TableRow[]) => TableCell;
+}
+
+function numbers(rows: TableRow[], column: string): number[] {
+  return rows
+    .map((row) => row[column])
+    .filter((v): v is number => typeof v === 'number');
+}
+
+function round(value: number): number {
+  return Number.isInteger(value) ? value : Number(value.toFixed(4));
+}
+
+function parseAggregate(table: DataTable, spec: string): Aggregate {
+  const match = spec
+    .trim()
+    .match(/^(count|sum|mean|avg|median|min|max|distinct)\s*(?:\((.*)\))?$/i);
+  if (!match) {
+    throw new Error(
+      `Cannot parse aggregate "${spec}"; expected count, or sum|mean|median|min|max|count|distinct(column)`,
+    );
+  }
+  const name = match[1].toLowerCase();
+  const fn = name === 'avg' ? 'mean' : name;
+  if (!match[2]?.trim()) {
+    if (fn !== 'count') {
+      throw new Error(`Aggregate "${fn}" needs a column, e.g. ${fn}(value)`);
+    }
+    return { label: 'count', compute: (rows) => rows.length };
+  }
+  const column = resolveColumn(table, match[2]);
+  const label = `${fn}(${column})`;
+  const values = (rows: TableRow[]) =>
+    rows.map((row) => row[column]).filter((v) => v !== null);
+  switch (fn) {
+    case 'count':
+      return { label, compute: (rows) => values(rows).length };
+    case 'distinct':
+      return {
+        label,
+        compute: (rows) => new Set(values(rows).map(String)).size,
+      };
+    case 'sum':
+      return {
+        label,
+        compute: (rows) =>
+          round(numbers(rows, column).reduce((a, b) => a + b, 0)),
+      };
+    case 'mean':
+      return {
+        label,
+        compute: (rows) => {
+          const xs = numbers(rows, column);
+          return xs.length
+            ? round(xs.reduce((a, b) => a + b, 0) / xs.length)
+            : null;
+        },
+      };
+    case 'median':
+      return {
+        label,
+        compute: (rows) => {
+          const xs = numbers(rows, column).sort((a, b) => a - b);
+          if (xs.length === 0) {
+            return null;
+          }
+          const mid = Math.floor(xs.length / 2);
+          return round(xs.length % 2 ? xs[mid] : (xs[mid - 1] + xs[mid]) / 2);
+        },
+      };
+    default:
+      return {
+        label,
+        compute: (rows) => {
+          const sorted = values(rows).sort(compare);
+          return (
+            (fn === 'min' ? sorted[0] : sorted[sorted.length - 1]) ?? null
+          );
+        },
+      };
+  }
+}
+
+function groupRows(table: DataTable, query: TableQuery, rows: TableRow[]) {
+  const groupBy = (query.groupBy ?? []).map((c) => resolveColumn(table, c));
+  const aggregates = (
+    query.aggregate?.length ? query.aggregate : ['count']
+  ).map((spec) => parseAggregate(table, spec));
+  const groups = new Map<string, TableRow[]>();
+  for (const row of rows) {
+    const key = JSON.stringify(groupBy.map((c) => row[c]));
+    const members = groups.get(key);
+    if (members) {
+      members.push(row);
+    } else {
+      groups.set(key, [row]);
+    }
+  }
+  if (groupBy.length === 0 && groups.size === 0) {
+    groups.set('[]', []);
+  }
+  return {
+    columns: [...groupBy, ...aggregates.map((a) => a.label)],
+    rows: [...groups.values()].map((members) => [
+      ...groupBy.map((c) => members[0][c]),
+      ...aggregates.map((a) => a.compute(members)),
+    ]),
+  };
+}
+
+/**
+ * Runs a query entirely in memory. Throws on unknown columns and on
+ * conditions or aggregates that do not parse.
+ */
+export function runTableQuery(
+  table: DataTable,
+  query: TableQuery,
+): TableQueryResult {
+  const predicates = (query.where ?? []).map((c) => parseCondition(table, c));
+  const matched = table.rows.filter((row) => predicates.every((p) => p(row)));
+
+  let result: { columns: string[]; rows: TableCell[][] };
+  if (query.groupBy?.length || query.aggregate?.length) {
+    result = groupRows(table, query, matched);
+  } else {
+    const columns = query.select?.length
+      ? query.select.map((c) => resolveColumn(table, c))
+      : table.columns;
+    result = {
+      columns,
+      rows: matched.map((row) => columns.map((c) => row[c])),
+    };
+  }
+
+  if (query.orderBy?.trim()) {
+    const match = query.orderBy.trim().match(/^(.*?)(?:\s+(asc|desc))?$/i)!;
+    const name = match[1].replace(/^`(.*)`$/, '$1');
+    const index = result.columns.findIndex(
+      (c) => c.toLowerCase() === name.toLowerCase(),
+    );
+    if (index < 0) {
+      throw new Error(
+        `Cannot order by "${name}"; result columns are ${result.columns.join(', ')}`,
+      );
+    }
+    const direction = match[2]?.toLowerCase() === 'desc' ? -1 : 1;
+    result.rows.sort((a, b) => direction * compare(a[index], b[index]));
+  }
+
+  const limit = Math.min(
+    Math.max(1, Math.floor(query.limit ?? DEFAULT_QUERY_LIMIT)),
+    MAX_QUERY_LIMIT,
+  );
+  return {
+    columns: result.columns,
+    rows: result.rows.slice(0, limit),
+    matchedRows: matched.length,
+    totalRows: table.rows.length,
+    truncated: result.rows.length > limit,
+  };
+}
+
+/**
+ * Summarizes each column (type, non-empty and distinct counts, range)
+ * without returning any rows.
+ */
+export function describeTable(table: DataTable): TableQueryResult {
+  const rows = table.columns.map((column) => {
+    const values = table.rows
+      .map((row) => row[column])
+      .filter((v) => v !== null);
+    const numeric = values.every((v) => typeof v === 'number');
+    const type = values.length === 0 ? 'empty' : numeric ? 'number' : 'text';
+    const sorted = numeric ? [...values].sort(compare) : [];
+    return [
+      column,
+      type,
+      values.length,
+      new Set(values.map(String)).size,
+      sorted[0] ?? null,
+      sorted[sorted.length - 1] ?? null,
+    ];
+  });
+  return {
+    columns: ['column', 'type', 'non_empty', 'distinct', 'min', 'max'],
+    rows,
+    matchedRows: table.rows.length,
+    totalRows: table.rows.length,
+    truncated: false,
+  };
+}
+
+export function formatMarkdownTable(
+  columns: string[],
+  rows: TableCell[][],
+): string {
+  const escape = (value: TableCell) =>
+    value === null
+      ? ''
+      : String(value).replace(/\|/g, '\\|').replace(/\n/g, ' ');
+  return [
+    `| ${columns.map(escape).join(' | ')} |`,
+    `| ${columns.map(() => '---').join(' | ')} |`,
+    ...rows.map((row) => `| ${row.map(escape).join(' | ')} |`),
+  ].join('\n');
+}
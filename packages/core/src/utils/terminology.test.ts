@@ -0,0 +1,128 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import {
+  checkTerminology,
+  formatTerminologyInstructions,
+  formatTerminologyIssue,
+  getTerminologyPath,
+  loadTerminology,
+  Terminology,
+  validateTerminology,
+} from './terminology.js';
+
+const terminology: Terminology = {
+  preferred: { dataset: ['data set', 'data-set'] },
+  banned: ['state of the art'],
+  capitalization: ['PyTorch', 'LaTeX'],
+};
+
+describe('checkTerminology', () => {
+  it('should find variants, banned phrases and miscapitalized terms', () => {
+    const issues = checkTerminology(
+      'Our Data Set beats the state of the art in Pytorch and LaTeX.',
+      terminology,
+    );
+
+    expect(issues.map(formatTerminologyIssue)).toEqual([
+      '"Data Set" → "dataset"',
+      '"state of the art" is not to be used',
+      '"Pytorch" → "PyTorch"',
+    ]);
+    expect(issues[0]).toMatchObject({ kind: 'preferred', index: 4 });
+  });
+
+  it('should match whole words only', () => {
+    expect(
+      checkTerminology('metadata sets and pytorchvision', terminology),
+    ).toEqual([]);
+  });
+
+  it('should accept text that follows the terminology', () => {
+    expect(checkTerminology('The dataset, in PyTorch.', terminology)).toEqual(
+      [],
+    );
+    expect(checkTerminology('data set', {})).toEqual([]);
+  });
+});
+
+describe('formatTerminologyInstructions', () => {
+  it('should list every rule', () => {
+    expect(formatTerminologyInstructions(terminology)).toContain(
+      [
+        '- Write "dataset", not "data set", "data-set".',
+        '- Never use "state of the art".',
+        '- Always capitalize these exactly as shown: "PyTorch", "LaTeX".',
+      ].join('\n'),
+    );
+  });
+
+  it('should be empty without rules', () => {
+    expect(formatTerminologyInstructions({ banned: [] })).toBe('');
+  });
+});
+
+describe('loadTerminology', () => {
+  let root: string;
+
+  beforeEach(async () => {
+    root = await fs.promises.mkdtemp(path.join(os.tmpdir(), 'terminology-'));
+  });
+
+  afterEach(async () => {
+    await fs.promises.rm(root, { recursive: true, force: true });
+  });
+
+  it('should return undefined when the workspace has no terminology', async () => {
+    expect(await loadTerminology(root)).toBeUndefined();
+  });
+
+  it('should read the terminology file', async () => {
+    const file = getTerminologyPath(root);
+    await fs.promises.mkdir(path.dirname(file), { recursive: true });
+    await fs.promises.writeFile(file, JSON.stringify(terminology));
+
+    expect(await loadTerminology(root)).toEqual(terminology);
+  });
+
+  it('should drop entries of the wrong shape with a warning', async () => {
+    const file = getTerminologyPath(root);
+    await fs.promises.mkdir(path.dirname(file), { recursive: true });
+    await fs.promises.writeFile(
+      file,
+      JSON.stringify({
+        preferred: { dataset: 'data set', model: ['network', 3] },
+        banned: 'state of the art',
+        capitalization: ['PyTorch'],
+      }),
+    );
+    const warnings: string[] = [];
+
+    const loaded = await loadTerminology(root, (w) => warnings.push(w));
+
+    expect(loaded).toEqual({
+      preferred: { model: ['network'] },
+      capitalization: ['PyTorch'],
+    });
+    expect(warnings).toHaveLength(3);
+    expect(formatTerminologyInstructions(loaded!)).toContain(
+      'Write "model", not "network".',
+    );
+  });
+});
+
+describe('validateTerminology', () => {
+  it('should refuse anything but an object', () => {
+    expect(validateTerminology(['dataset'])).toEqual({
+      terminology: {},
+      warnings: ['The terminology must be a JSON object.'],
+    });
+  });
+});
@@ -0,0 +1,208 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import { RESEARCH_DIR } from './paths.js';
+import { isNodeError } from './errors.js';
+
+const TERMINOLOGY_FILE = 'terminology.json';
+
+/**
+ * A paper's terminology conventions, kept in `.research/terminology.json`
+ * of the workspace.
+ */
+export interface Terminology {
+  /** Preferred terms and the variants they replace: "dataset": ["data set"]. */
+  preferred?: Record<string, string[]>;
+  /** Phrases not to use at all. */
+  banned?: string[];
+  /** Terms to always write as given, e.g. "LaTeX" or "PyTorch". */
+  capitalization?: string[];
+}
+
+export interface TerminologyIssue {
+  kind: 'preferred' | 'banned' | 'capitalization';
+  /** The text as found. */
+  found: string;
+  /** What to write instead; undefined for banned phrases. */
+  suggestion?: string;
+  index: number;
+}
+
+export function getTerminologyPath(projectRoot: string): string {
+  return path.join(projectRoot, RESEARCH_DIR, TERMINOLOGY_FILE);
+}
+
+function isNonEmptyString(value: unknown): value is string {
+  return typeof value === 'string' && value.trim() !== '';
+}
+
+/**
+ * Keeps the entries of a parsed terminology file that have the expected
+ * shape and describes each one that was dropped.
+ */
+export function validateTerminology(raw: unknown): {
+  terminology: Terminology;
+  warnings: string[];
+} {
+  const warnings: string[] = [];
+  const terminology: Terminology = {};
+  if (!raw || typeof raw !== 'object' || Array.isArray(raw)) {
+    warnings.push('The terminology must be a JSON object.');
+    return { terminology, warnings };
+  }
+  const { preferred, banned, capitalization } = raw as Record<string, unknown>;
+  const strings = (key: string, value: unknown): string[] | undefined => {
+    if (value === undefined) {
+      return undefined;
+    }
+    if (!Array.isArray(value)) {
+      warnings.push(`"${key}" must be a list of strings; it is ignored.`);
+      return undefined;
+    }
+    const kept = value.filter(isNonEmptyString);
+    if (kept.length < value.length) {
+      warnings.push(
+        `"${key}" has ${value.length - kept.length} entries that are not text; they are ignored.`,
+      );
+    }
+    return kept;
+  };
+
+  if (preferred !== undefined) {
+    if (
+      !preferred ||
+      typeof preferred !== 'object' ||
+      Array.isArray(preferred)
+    ) {
+      warnings.push(
+        '"preferred" must map each term to a list of variants; it is ignored.',
+      );
+    } else {
+      terminology.preferred = {};
+      for (const [term, variants] of Object.entries(preferred)) {
+        const kept = strings(`preferred.${term}`, variants);
+        if (kept && isNonEmptyString(term)) {
+          terminology.preferred[term] = kept;
+        }
+      }
+    }
+  }
+  const bannedKept = strings('banned', banned);
+  if (bannedKept) {
+    terminology.banned = bannedKept;
+  }
+  const capitalizationKept = strings('capitalization', capitalization);
+  if (capitalizationKept) {
+    terminology.capitalization = capitalizationKept;
+  }
+  return { terminology, warnings };
+}
+
+/**
+ * The workspace's terminology, or undefined when it has none. Entries of
+ * the wrong shape are dropped and reported to `onWarning`.
+ */
+export async function loadTerminology(
+  projectRoot: string,
+  onWarning?: (message: string) => void,
+): Promise<Terminology | undefined> {
+  let raw: unknown;
+  try {
+    raw = JSON.parse(
+      await fs.promises.readFile(getTerminologyPath(projectRoot), 'utf8'),
+    );
+  } catch (error) {
+    if (isNodeError(error) && error.code === 'ENOENT') {
+      return undefined;
+    }
+    throw error;
+  }
+  const { terminology, warnings } = validateTerminology(raw);
+  for (const warning of warnings) {
+    onWarning?.(warning);
+  }
+  return terminology;
+}
+
+function phrasePattern(phrase: string): RegExp {
+  const escaped = phrase.trim().replace(/[.*+?^${}()|[\]\\]/g, '\\$&');
+  // Whole words only, so "data set" does not match inside "metadata sets"
+  return new RegExp(
+    `(?<![\\p{L}\\p{N}])${escaped}(?![\\p{L}\\p{N}])`,
+    'giu',
+  );
+}
+
+/** Finds the places where `text` departs from the terminology. */
+export function checkTerminology(
+  text: string,
+  terminology: Terminology,
+): TerminologyIssue[] {
+  const issues: TerminologyIssue[] = [];
+  const find = (
+    phrase: string,
+    report: (
+      found: string,
+    ) => Omit<TerminologyIssue, 'found' | 'index'> | undefined,
+  ) => {
+    if (!phrase.trim()) {
+      return;
+    }
+    for (const match of text.matchAll(phrasePattern(phrase))) {
+      const issue = report(match[0]);
+      if (issue) {
+        issues.push({ ...issue, found: match[0], index: match.index ?? 0 });
+      }
+    }
+  };
+
+  for (const [term, variants] of Object.entries(terminology.preferred ?? {})) {
+    for (const variant of variants) {
+      find(variant, () => ({ kind: 'preferred', suggestion: term }));
+    }
+  }
+  for (const phrase of terminology.banned ?? []) {
+    find(phrase, () => ({ kind: 'banned' }));
+  }
+  for (const term of terminology.capitalization ?? []) {
+    find(term, (found) =>
+      found === term ? undefined : { kind: 'capitalization', suggestion: term },
+    );
+  }
+  return issues.sort((a, b) => a.index - b.index);
+}
+
+/** One line per issue, e.g. `"data set" → "dataset"`. */
+export function formatTerminologyIssue(issue: TerminologyIssue): string {
+  return issue.suggestion === undefined
+    ? `"${issue.found}" is not to be used`
+    : `"${issue.found}" → "${issue.suggestion}"`;
+}
+
+/** Instructions for the system prompt; empty when there are no rules. */
+export function formatTerminologyInstructions(
+  terminology: Terminology,
+): string {
+  const quote = (terms: string[]) => terms.map((t) => `"${t}"`).join(', ');
+  const lines = [
+    ...Object.entries(terminology.preferred ?? {}).map(
+      ([term, variants]) => `- Write "${term}", not ${quote(variants)}.`,
+    ),
+    ...(terminology.banned?.length
+      ? [`- Never use ${quote(terminology.banned)}.`]
+      : []),
+    ...(terminology.capitalization?.length
+      ? [
+          `- Always capitalize these exactly as shown: ${quote(terminology.capitalization)}.`,
+        ]
+      : []),
+  ];
+  return lines.length > 0
+    ? `# Terminology\n\nText you write for this project, including drafts and files, follows the paper's terminology:\n${lines.join('\n')}`
+    : '';
+}
@@ -0,0 +1,222 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import {
+  loadUsageHistory,
+  recordUsage,
+  setUsageHistory,
+  summarizeActivity,
+  summarizeUsage,
+  UsageRecord,
+} from './usageHistory.js';
+import { setIncognitoMode } from './incognito.js';
+
+const at = (day: number, hour = 12) =>
+  new Date(2025, 5, day, hour).toISOString();
+
+describe('recordUsage and loadUsageHistory', () => {
+  let tempDir: string;
+  let filePath: string;
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'usage-history-'));
+    filePath = path.join(tempDir, 'nested', 'usage-history.jsonl');
+  });
+
+  afterEach(() => {
+    setUsageHistory(undefined);
+    setIncognitoMode(false);
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should append events with the project, except when incognito', async () => {
+    recordUsage({ kind: 'prompt', timestamp: at(1) });
+    setUsageHistory(filePath, '/work/paper');
+    recordUsage({ kind: 'prompt', timestamp: at(2) });
+    setIncognitoMode(true);
+    recordUsage({ kind: 'prompt', timestamp: at(3) });
+
+    await vi.waitFor(async () =>
+      expect(await loadUsageHistory(filePath)).toEqual([
+        { kind: 'prompt', timestamp: at(2), project: '/work/paper' },
+      ]),
+    );
+  });
+
+  it('should skip broken lines and treat a missing file as empty', async () => {
+    fs.mkdirSync(path.dirname(filePath));
+    fs.writeFileSync(
+      filePath,
+      `{"kind":"prompt","timestamp":"${at(1)}","project":"p"}\n{"kind":`,
+    );
+
+    expect(await loadUsageHistory(filePath)).toHaveLength(1);
+    expect(await loadUsageHistory(path.join(tempDir, 'none.jsonl'))).toEqual(
+      [],
+    );
+  });
+});
+
+describe('summarizeUsage', () => {
+  const records: UsageRecord[] = [
+    { kind: 'prompt', timestamp: at(8), project: 'a' },
+    { kind: 'prompt', timestamp: at(10), project: 'a' },
+    { kind: 'prompt', timestamp: at(10), project: 'b' },
+    {
+      kind: 'api',
+      timestamp: at(10),
+      project: 'a',
+      model: 'gpt-4o-mini',
+      inputTokens: 1_000_000,
+      outputTokens: 500_000,
+      durationMs: 1000,
+    },
+    {
+      kind: 'api',
+      timestamp: at(10),
+      project: 'b',
+      model: 'local-model',
+      inputTokens: 100,
+      outputTokens: 50,
+      durationMs: 3000,
+    },
+    {
+      kind: 'tool',
+      timestamp: at(9),
+      project: 'a',
+      tool: 'read_file',
+      durationMs: 5,
+      success: false,
+    },
+    // Outside of the range
+    { kind: 'prompt', timestamp: at(1), project: 'a' },
+  ];
+
+  it('should count per day, per model and per tool', () => {
+    const summary = summarizeUsage(records, {
+      days: 3,
+      now: new Date(2025, 5, 10, 18),
+    });
+
+    expect(summary.messagesPerDay).toEqual([
+      { date: '2025-06-08', count: 1 },
+      { date: '2025-06-09', count: 0 },
+      { date: '2025-06-10', count: 2 },
+    ]);
+    expect(summary.tokensPerDay[2].count).toBe(1_500_150);
+    expect(summary.models).toEqual([
+      {
+        model: 'gpt-4o-mini',
+        requests: 1,
+        inputTokens: 1_000_000,
+        outputTokens: 500_000,
+        averageLatencyMs: 1000,
+        cost: 0.45,
+        currency: 'USD',
+      },
+      {
+        model: 'local-model',
+        requests: 1,
+        inputTokens: 100,
+        outputTokens: 50,
+        averageLatencyMs: 3000,
+        cost: undefined,
+        currency: undefined,
+      },
+    ]);
+    expect(summary.tools).toEqual([
+      { tool: 'read_file', calls: 1, failures: 1 },
+    ]);
+    expect(summary.averageLatencyMs).toBe(2000);
+  });
+
+  it('should keep to one project when asked', () => {
+    const summary = summarizeUsage(records, {
+      days: 3,
+      project: 'b',
+      now: new Date(2025, 5, 10, 18),
+    });
+
+    expect(summary.prompts).toBe(1);
+    expect(summary.models.map((m) => m.model)).toEqual(['local-model']);
+    expect(summary.tools).toEqual([]);
+  });
+});
+
+describe('summarizeActivity', () => {
+  const prompt = (day: number, session?: string): UsageRecord => ({
+    kind: 'prompt',
+    timestamp: at(day),
+    project: 'a',
+    session,
+  });
+  const note = (day: number, success = true): UsageRecord => ({
+    kind: 'tool',
+    timestamp: at(day),
+    project: 'b',
+    tool: 'save_memory',
+    durationMs: 5,
+    success,
+  });
+  const records = [
+    prompt(1),
+    prompt(2),
+    prompt(3),
+    prompt(4),
+    prompt(8, 's1'),
+    prompt(8, 's1'),
+    prompt(8, 's2'),
+    note(9),
+    note(9, false),
+    prompt(10, 's3'),
+  ];
+
+  it('should count sessions and saved notes per day', () => {
+    const activity = summarizeActivity(records, {
+      days: 3,
+      now: new Date(2025, 5, 10, 23, 30),
+    });
+
+    expect(activity.days).toEqual([
+      { date: '2025-06-08', sessions: 2, notes: 0 },
+      { date: '2025-06-09', sessions: 0, notes: 1 },
+      { date: '2025-06-10', sessions: 1, notes: 0 },
+    ]);
+    expect(activity.activeDays).toBe(7);
+  });
+
+  it('should track the current and longest streak', () => {
+    expect(
+      summarizeActivity(records, { days: 1, now: new Date(2025, 5, 10) }),
+    ).toMatchObject({ currentStreak: 3, longestStreak: 4 });
+    // A day without activity yet does not end the streak
+    expect(
+      summarizeActivity(records, { days: 1, now: new Date(2025, 5, 11) }),
+    ).toMatchObject({ currentStreak: 3 });
+    expect(
+      summarizeActivity(records, { days: 1, now: new Date(2025, 5, 12) }),
+    ).toMatchObject({ currentStreak: 0 });
+  });
+
+  it('should keep to one project when asked', () => {
+    const activity = summarizeActivity(records, {
+      days: 3,
+      project: 'a',
+      now: new Date(2025, 5, 10),
+    });
+
+    expect(activity.days[1]).toEqual({
+      date: '2025-06-09',
+      sessions: 0,
+      notes: 0,
+    });
+    expect(activity.currentStreak).toBe(1);
+  });
+});
@@ -0,0 +1,313 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import { getUserResearchDir } from './paths.js';
+import { isNodeError } from './errors.js';
+import { isIncognitoMode } from './incognito.js';
+import { findCatalogEntry } from '../core/model-providers/model-catalog.js';
+import { MemoryTool } from '../tools/memoryTool.js';
+
+const DAY_MS = 24 * 60 * 60 * 1000;
+
+/**
+ * A usage event of the session. Only counts and timings are kept, never
+ * prompt or response text.
+ */
+export type UsageEvent =
+  | { kind: 'prompt'; timestamp: string }
+  | {
+      kind: 'api';
+      timestamp: string;
+      model: string;
+      inputTokens: number;
+      outputTokens: number;
+      durationMs: number;
+      error?: boolean;
+    }
+  | {
+      kind: 'tool';
+      timestamp: string;
+      tool: string;
+      durationMs: number;
+      success: boolean;
+    };
+
+/** One line of the usage history: an event, its project and session. */
+export type UsageRecord = UsageEvent & { project: string; session?: string };
+
+export interface ModelUsage {
+  model: string;
+  requests: number;
+  inputTokens: number;
+  outputTokens: number;
+  /** Estimated from the model catalog; undefined for unknown models. */
+  cost?: number;
+  currency?: string;
+  averageLatencyMs: number;
+}
+
+export interface UsageSummary {
+  /** One entry per day, oldest first, including days without messages. */
+  messagesPerDay: Array<{ date: string; count: number }>;
+  tokensPerDay: Array<{ date: string; count: number }>;
+  models: ModelUsage[];
+  tools: Array<{ tool: string; calls: number; failures: number }>;
+  averageLatencyMs: number;
+  prompts: number;
+  requests: number;
+}
+
+export interface ActivityDay {
+  date: string;
+  sessions: number;
+  /** Memories saved with save_memory, from /memory add or the model. */
+  notes: number;
+}
+
+export interface ActivitySummary {
+  /** One entry per day, oldest first. */
+  days: ActivityDay[];
+  /** Active days in a row up to today, or up to yesterday. */
+  currentStreak: number;
+  longestStreak: number;
+  activeDays: number;
+}
+
+/**
+ * File the session appends usage to, and its project. Unset until the CLI
+ * enables it, so that library use and tests leave no history behind.
+ */
+let history:
+  | { filePath: string; project: string; session?: string }
+  | undefined;
+
+export function getUsageHistoryPath(): string {
+  return path.join(getUserResearchDir(), 'usage-history.jsonl');
+}
+
+export function setUsageHistory(
+  filePath: string | undefined,
+  project = '',
+  session?: string,
+): void {
+  history = filePath ? { filePath, project, session } : undefined;
+}
+
+/** Appends an event to the usage history, unless it is off or incognito. */
+export function recordUsage(event: UsageEvent): void {
+  if (!history || isIncognitoMode()) {
+    return;
+  }
+  const { filePath, project, session } = history;
+  const record: UsageRecord = { ...event, project, session };
+  fs.promises
+    .mkdir(path.dirname(filePath), { recursive: true })
+    .then(() =>
+      fs.promises.appendFile(filePath, `${JSON.stringify(record)}\n`, 'utf8'),
+    )
+    .catch((error) =>
+      console.debug(`Could not write usage history ${filePath}:`, error),
+    );
+}
+
+export async function loadUsageHistory(
+  filePath: string = getUsageHistoryPath(),
+): Promise<UsageRecord[]> {
+  let text: string;
+  try {
+    text = await fs.promises.readFile(filePath, 'utf8');
+  } catch (error) {
+    if (isNodeError(error) && error.code === 'ENOENT') {
+      return [];
+    }
+    throw error;
+  }
+  const records: UsageRecord[] = [];
+  for (const line of text.split('\n')) {
+    if (!line.trim()) {
+      continue;
+    }
+    try {
+      records.push(JSON.parse(line) as UsageRecord);
+    } catch {
+      // Skip a line cut short by a crash
+    }
+  }
+  return records;
+}
+
+export function dayOf(timestamp: string | number | Date): string {
+  const date = new Date(timestamp);
+  const pad = (n: number) => String(n).padStart(2, '0');
+  const month = pad(date.getMonth() + 1);
+  return `${date.getFullYear()}-${month}-${pad(date.getDate())}`;
+}
+
+/**
+ * Summarizes the records of the last `days` days (in local time, ending
+ * with `now`), optionally for one project only.
+ */
+export function summarizeUsage(
+  records: UsageRecord[],
+  options: { days: number; project?: string; now?: Date },
+): UsageSummary {
+  const now = options.now ?? new Date();
+  const dates: string[] = [];
+  for (let i = options.days - 1; i >= 0; i--) {
+    dates.push(dayOf(now.getTime() - i * DAY_MS));
+  }
+  const inRange = new Set(dates);
+  const selected = records.filter(
+    (r) =>
+      inRange.has(dayOf(r.timestamp)) &&
+      (!options.project || r.project === options.project),
+  );
+
+  const messages = new Map<string, number>();
+  const tokens = new Map<string, number>();
+  const models = new Map<string, ModelUsage & { totalLatencyMs: number }>();
+  const tools = new Map<string, { calls: number; failures: number }>();
+  let prompts = 0;
+  let requests = 0;
+  let totalLatencyMs = 0;
+  for (const record of selected) {
+    const day = dayOf(record.timestamp);
+    if (record.kind === 'prompt') {
+      prompts++;
+      messages.set(day, (messages.get(day) ?? 0) + 1);
+    } else if (record.kind === 'api') {
+      requests++;
+      totalLatencyMs += record.durationMs;
+      tokens.set(
+        day,
+        (tokens.get(day) ?? 0) + record.inputTokens + record.outputTokens,
+      );
+      const usage = models.get(record.model) ?? {
+        model: record.model,
+        requests: 0,
+        inputTokens: 0,
+        outputTokens: 0,
+        averageLatencyMs: 0,
+        totalLatencyMs: 0,
+      };
+      usage.requests++;
+      usage.inputTokens += record.inputTokens;
+      usage.outputTokens += record.outputTokens;
+      usage.totalLatencyMs += record.durationMs;
+      models.set(record.model, usage);
+    } else {
+      const usage = tools.get(record.tool) ?? { calls: 0, failures: 0 };
+      usage.calls++;
+      if (!record.success) {
+        usage.failures++;
+      }
+      tools.set(record.tool, usage);
+    }
+  }
+
+  return {
+    messagesPerDay: dates.map((date) => ({
+      date,
+      count: messages.get(date) ?? 0,
+    })),
+    tokensPerDay: dates.map((date) => ({
+      date,
+      count: tokens.get(date) ?? 0,
+    })),
+    models: [...models.values()]
+      .map(({ totalLatencyMs: latency, ...usage }) => {
+        const pricing = findCatalogEntry(usage.model)?.pricing;
+        return {
+          ...usage,
+          averageLatencyMs: Math.round(latency / usage.requests),
+          cost: pricing
+            ? (usage.inputTokens * pricing.input +
+                usage.outputTokens * pricing.output) /
+              1_000_000
+            : undefined,
+          currency: pricing?.currency,
+        };
+      })
+      .sort(
+        (a, b) =>
+          b.inputTokens + b.outputTokens - (a.inputTokens + a.outputTokens),
+      ),
+    tools: [...tools.entries()]
+      .map(([tool, usage]) => ({ tool, ...usage }))
+      .sort((a, b) => b.calls - a.calls),
+    averageLatencyMs: requests > 0 ? Math.round(totalLatencyMs / requests) : 0,
+    prompts,
+    requests,
+  };
+}
+
+/**
+ * Research sessions and notes per day for the `days` days up to `now`, and
+ * streaks of active days over the whole history.
+ */
+export function summarizeActivity(
+  records: UsageRecord[],
+  options: { days: number; project?: string; now?: Date },
+): ActivitySummary {
+  // Step through days from noon, so daylight saving changes do not matter
+  const noon = new Date(options.now ?? new Date());
+  noon.setHours(12, 0, 0, 0);
+  const sessions = new Map<string, Set<string>>();
+  const notes = new Map<string, number>();
+  for (const record of records) {
+    if (options.project && record.project !== options.project) {
+      continue;
+    }
+    const day = dayOf(record.timestamp);
+    if (record.kind === 'prompt') {
+      // Records from before sessions were stored count as one per day
+      const daySessions = sessions.get(day) ?? new Set<string>();
+      daySessions.add(record.session ?? '');
+      sessions.set(day, daySessions);
+    } else if (
+      record.kind === 'tool' &&
+      record.tool === MemoryTool.Name &&
+      record.success
+    ) {
+      notes.set(day, (notes.get(day) ?? 0) + 1);
+    }
+  }
+
+  const isActive = (day: string) => sessions.has(day) || notes.has(day);
+  const active = [...new Set([...sessions.keys(), ...notes.keys()])].sort();
+  let longestStreak = 0;
+  let run = 0;
+  let previous: string | undefined;
+  for (const day of active) {
+    const dayBefore = dayOf(new Date(`${day}T12:00:00`).getTime() - DAY_MS);
+    run = previous === dayBefore ? run + 1 : 1;
+    longestStreak = Math.max(longestStreak, run);
+    previous = day;
+  }
+  // Today still counts as part of the streak until it is over
+  let currentStreak = 0;
+  let cursor = noon.getTime();
+  if (!isActive(dayOf(cursor))) {
+    cursor -= DAY_MS;
+  }
+  while (isActive(dayOf(cursor))) {
+    currentStreak++;
+    cursor -= DAY_MS;
+  }
+
+  const days: ActivityDay[] = [];
+  for (let i = options.days - 1; i >= 0; i--) {
+    const date = dayOf(noon.getTime() - i * DAY_MS);
+    days.push({
+      date,
+      sessions: sessions.get(date)?.size ?? 0,
+      notes: notes.get(date) ?? 0,
+    });
+  }
+  return { days, currentStreak, longestStreak, activeDays: active.length };
+}
@@ -6,8 +6,7 @@
 
 import path from 'node:path';
 import { promises as fsp, existsSync, readFileSync } from 'node:fs';
-import * as os from 'os';
-import { RESEARCH_DIR, GOOGLE_ACCOUNTS_FILENAME } from './paths.js';
+import { GOOGLE_ACCOUNTS_FILENAME, getUserResearchDir } from './paths.js';
 
 interface UserAccounts {
   active: string | null;
@@ -15,7 +14,7 @@ interface UserAccounts {
 }
 
 function getiEchorAccountsCachePath(): string {
-  return path.join(os.homedir(), RESEARCH_DIR, GOOGLE_ACCOUNTS_FILENAME);
+  return path.join(getUserResearchDir(), GOOGLE_ACCOUNTS_FILENAME);
 }
 
 async function readAccounts(filePath: string): Promise<UserAccounts> {
@@ -0,0 +1,96 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, afterEach, vi } from 'vitest';
+import {
+  getWebhookType,
+  postToWebhook,
+  splitWebhookMessage,
+} from './webhooks.js';
+
+describe('getWebhookType', () => {
+  it('should recognize Slack and Discord and default to Mattermost', () => {
+    expect(
+      getWebhookType({ url: 'https://hooks.slack.com/services/T0/B0/x' }),
+    ).toBe('slack');
+    expect(
+      getWebhookType({ url: 'https://discord.com/api/webhooks/1/abc' }),
+    ).toBe('discord');
+    expect(getWebhookType({ url: 'https://chat.lab.org/hooks/abc' })).toBe(
+      'mattermost',
+    );
+    expect(
+      getWebhookType({ url: 'https://chat.lab.org/hooks/abc', type: 'slack' }),
+    ).toBe('slack');
+  });
+});
+
+describe('splitWebhookMessage', () => {
+  it('should split long messages at paragraph breaks', () => {
+    const first = 'a'.repeat(60);
+    const second = 'b'.repeat(30);
+    expect(splitWebhookMessage(`${first}\n\n${second}`, 80)).toEqual([
+      first,
+      second,
+    ]);
+    expect(splitWebhookMessage('short', 80)).toEqual(['short']);
+    expect(splitWebhookMessage('c'.repeat(10), 4)).toEqual([
+      'cccc',
+      'cccc',
+      'cc',
+    ]);
+  });
+});
+
+describe('postToWebhook', () => {
+  afterEach(() => {
+    vi.unstubAllGlobals();
+  });
+
+  it('should post the payload each service expects', async () => {
+    const fetchMock = vi.fn(
+      async (_url: string, _init?: RequestInit) => new Response('ok'),
+    );
+    vi.stubGlobal('fetch', fetchMock);
+
+    await postToWebhook(
+      { url: 'https://discord.com/api/webhooks/1/abc', username: 'lab-bot' },
+      'Accuracy is 91.2%.',
+    );
+    await postToWebhook(
+      { url: 'https://hooks.slack.com/services/T0/B0/x' },
+      'Done.',
+    );
+
+    expect(fetchMock.mock.calls[0][0]).toBe(
+      'https://discord.com/api/webhooks/1/abc',
+    );
+    expect(fetchMock.mock.calls[0][1]).toMatchObject({ method: 'POST' });
+    expect(JSON.parse(fetchMock.mock.calls[0][1]!.body as string)).toEqual({
+      content: 'Accuracy is 91.2%.',
+      username: 'lab-bot',
+    });
+    expect(JSON.parse(fetchMock.mock.calls[1][1]!.body as string)).toEqual({
+      text: 'Done.',
+    });
+  });
+
+  it('should send long messages in parts and report failures', async () => {
+    const fetchMock = vi.fn(async () => new Response('ok'));
+    vi.stubGlobal('fetch', fetchMock);
+    const config = { url: 'https://discord.com/api/webhooks/1/abc' };
+
+    expect(await postToWebhook(config, 'word '.repeat(1000))).toBe(3);
+    expect(fetchMock).toHaveBeenCalledTimes(3);
+
+    fetchMock.mockResolvedValueOnce(
+      new Response('invalid_token', { status: 403, statusText: 'Forbidden' }),
+    );
+    await expect(postToWebhook(config, 'x')).rejects.toThrow(
+      'The webhook answered 403 Forbidden: invalid_token',
+    );
+  });
+});
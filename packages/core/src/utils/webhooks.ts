@@ -0,0 +1,128 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { getErrorMessage } from './errors.js';
+
+export type WebhookType = 'slack' | 'discord' | 'mattermost';
+
+export interface WebhookConfig {
+  /** Incoming webhook URL of the channel. */
+  url: string;
+  /**
+   * Inferred from the URL when not set: Slack and Discord by their hosts,
+   * anything else as Mattermost, whose format most chat servers accept.
+   */
+  type?: WebhookType;
+  /** Name to post as, where the service lets webhooks choose it. */
+  username?: string;
+  /** Folder that /send-to --export writes conversation exports to. */
+  exportDir?: string;
+  /** URL at which `exportDir` is served, used to link the export. */
+  exportBaseUrl?: string;
+}
+
+const DEFAULT_TIMEOUT_MS = 15000;
+
+// Message length limits; longer messages are sent in parts.
+const MAX_MESSAGE_LENGTH: Record<WebhookType, number> = {
+  slack: 3900,
+  discord: 2000,
+  mattermost: 16000,
+};
+
+export function getWebhookType(config: WebhookConfig): WebhookType {
+  if (config.type) {
+    return config.type;
+  }
+  const host = (() => {
+    try {
+      return new URL(config.url).hostname;
+    } catch {
+      return '';
+    }
+  })();
+  if (host === 'hooks.slack.com') {
+    return 'slack';
+  }
+  if (/(^|\.)discord(app)?\.com$/.test(host)) {
+    return 'discord';
+  }
+  return 'mattermost';
+}
+
+/**
+ * Splits a message into parts the service accepts, at paragraph or line
+ * breaks where possible.
+ */
+export function splitWebhookMessage(
+  text: string,
+  maxLength: number,
+): string[] {
+  const parts: string[] = [];
+  let rest = text.trim();
+  while (rest.length > maxLength) {
+    const window = rest.slice(0, maxLength);
+    let cut = window.lastIndexOf('\n\n');
+    if (cut < maxLength / 2) {
+      cut = window.lastIndexOf('\n');
+    }
+    if (cut < maxLength / 2) {
+      cut = window.lastIndexOf(' ');
+    }
+    if (cut <= 0) {
+      cut = maxLength;
+    }
+    parts.push(rest.slice(0, cut).trimEnd());
+    rest = rest.slice(cut).trimStart();
+  }
+  if (rest) {
+    parts.push(rest);
+  }
+  return parts;
+}
+
+export function buildWebhookPayload(
+  type: WebhookType,
+  text: string,
+  username?: string,
+): Record<string, string> {
+  if (type === 'discord') {
+    return { content: text, ...(username ? { username } : {}) };
+  }
+  return { text, ...(username ? { username } : {}) };
+}
+
+/**
+ * Posts a message to a Slack, Discord or Mattermost incoming webhook.
+ * Returns the number of messages it took.
+ */
+export async function postToWebhook(
+  config: WebhookConfig,
+  text: string,
+  timeout = DEFAULT_TIMEOUT_MS,
+): Promise<number> {
+  const type = getWebhookType(config);
+  const parts = splitWebhookMessage(text, MAX_MESSAGE_LENGTH[type]);
+  for (const part of parts) {
+    let response: Response;
+    try {
+      response = await fetch(config.url, {
+        method: 'POST',
+        headers: { 'Content-Type': 'application/json' },
+        body: JSON.stringify(buildWebhookPayload(type, part, config.username)),
+        signal: AbortSignal.timeout(timeout),
+      });
+    } catch (error) {
+      throw new Error(`Could not reach the webhook: ${getErrorMessage(error)}`);
+    }
+    if (!response.ok) {
+      throw new Error(
+        `The webhook answered ${response.status} ${response.statusText}: ${(await response.text()).slice(0, 200)}`,
+      );
+    }
+  }
+  return parts.length;
+}
@@ -0,0 +1,120 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, afterEach, vi } from 'vitest';
+import { fetchWikiCard, renderWikiCard } from './wikipedia.js';
+
+const claim = (type: string, value: unknown, rank = 'normal') => ({
+  rank,
+  mainsnak: { snaktype: 'value', datavalue: { type, value } },
+});
+
+const RESPONSES: Record<string, unknown> = {
+  'https://de.wikipedia.org/api/rest_v1/page/summary/Marie_Curie?redirect=true':
+    {
+      type: 'standard',
+      title: 'Marie Curie',
+      description: 'Physikerin und Chemikerin',
+      extract: 'Marie Curie war eine Physikerin.',
+      wikibase_item: 'Q7186',
+      content_urls: {
+        desktop: { page: 'https://de.wikipedia.org/wiki/Marie_Curie' },
+      },
+    },
+  'wbgetentities:claims': {
+    entities: {
+      Q7186: {
+        claims: {
+          P569: [
+            claim('time', { time: '+1867-11-07T00:00:00Z', precision: 11 }),
+          ],
+          P31: [claim('wikibase-entityid', { id: 'Q5' })],
+          P166: [
+            claim('wikibase-entityid', { id: 'Q38104' }),
+            claim('wikibase-entityid', { id: 'Q44585' }, 'deprecated'),
+          ],
+          P9999: [claim('string', 'not on the card')],
+        },
+      },
+    },
+  },
+  'wbgetentities:labels': {
+    entities: {
+      P31: { labels: { de: { value: 'ist ein(e)' } } },
+      P569: { labels: { en: { value: 'date of birth' } } },
+      P166: { labels: { de: { value: 'Auszeichnung' } } },
+      Q5: { labels: { de: { value: 'Mensch' } } },
+      Q38104: { labels: { de: { value: 'Nobelpreis für Physik' } } },
+    },
+  },
+};
+
+function stubFetch() {
+  const fetchMock = vi.fn(async (input: string | URL) => {
+    const url = String(input);
+    const key = url.includes('wbgetentities')
+      ? `wbgetentities:${new URL(url).searchParams.get('props')}`
+      : url;
+    if (url.includes('list=search')) {
+      return Response.json({ query: { search: [{ title: 'Marie Curie' }] } });
+    }
+    return key in RESPONSES
+      ? Response.json(RESPONSES[key])
+      : new Response('not found', { status: 404 });
+  });
+  vi.stubGlobal('fetch', fetchMock);
+  return fetchMock;
+}
+
+describe('fetchWikiCard', () => {
+  afterEach(() => {
+    vi.unstubAllGlobals();
+  });
+
+  it('should combine the summary with labelled Wikidata facts', async () => {
+    stubFetch();
+    const card = await fetchWikiCard('Marie Curie', 'de');
+    expect(card).toMatchObject({
+      title: 'Marie Curie',
+      url: 'https://de.wikipedia.org/wiki/Marie_Curie',
+      wikidataId: 'Q7186',
+    });
+    expect(card!.facts).toEqual([
+      { label: 'ist ein(e)', values: ['Mensch'] },
+      { label: 'date of birth', values: ['1867-11-07'] },
+      { label: 'Auszeichnung', values: ['Nobelpreis für Physik'] },
+    ]);
+    expect(renderWikiCard(card!)).toBe(
+      [
+        '**Marie Curie** — Physikerin und Chemikerin',
+        '',
+        'Marie Curie war eine Physikerin.',
+        '',
+        'Facts (Wikidata Q7186):',
+        '- ist ein(e): Mensch',
+        '- date of birth: 1867-11-07',
+        '- Auszeichnung: Nobelpreis für Physik',
+        '',
+        'Source: https://de.wikipedia.org/wiki/Marie_Curie',
+      ].join('\n'),
+    );
+  });
+
+  it('should fall back to search when no page has the exact title', async () => {
+    const fetchMock = stubFetch();
+    const card = await fetchWikiCard('curie physicist', 'de');
+    expect(card?.title).toBe('Marie Curie');
+    expect(fetchMock.mock.calls.map(([url]) => String(url))).toContain(
+      'https://de.wikipedia.org/w/api.php?action=query&list=search&srsearch=curie+physicist&srlimit=1&format=json',
+    );
+  });
+
+  it('should reject malformed language codes', async () => {
+    await expect(fetchWikiCard('Curie', 'de.evil.com/')).rejects.toThrow(
+      'Invalid Wikipedia language',
+    );
+  });
+});
@@ -0,0 +1,321 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { fetchWithTimeout } from './fetch.js';
+
+const REQUEST_TIMEOUT_MS = 10000;
+const WIKIDATA_API = 'https://www.wikidata.org/w/api.php';
+const MAX_VALUES_PER_FACT = 3;
+
+/**
+ * Wikidata properties shown on a card, in display order. Only those the
+ * entity has are shown.
+ */
+const FACT_PROPERTIES = [
+  'P31', // instance of
+  'P279', // subclass of
+  'P105', // taxon rank
+  'P171', // parent taxon
+  'P274', // chemical formula
+  'P2067', // mass
+  'P569', // date of birth
+  'P19', // place of birth
+  'P570', // date of death
+  'P27', // country of citizenship
+  'P106', // occupation
+  'P69', // educated at
+  'P108', // employer
+  'P166', // award received
+  'P61', // discoverer or inventor
+  'P575', // time of discovery or invention
+  'P50', // author
+  'P577', // publication date
+  'P356', // DOI
+  'P571', // inception
+  'P17', // country
+  'P36', // capital
+  'P159', // headquarters location
+  'P1082', // population
+  'P2046', // area
+  'P856', // official website
+];
+
+export interface WikiFact {
+  label: string;
+  values: string[];
+}
+
+export interface WikiCard {
+  title: string;
+  description?: string;
+  extract: string;
+  url: string;
+  /** Wikipedia language edition, e.g. `en`. */
+  language: string;
+  wikidataId?: string;
+  facts: WikiFact[];
+  /** Set when the title is a disambiguation page. */
+  disambiguation?: boolean;
+}
+
+interface PageSummary {
+  type?: string;
+  title: string;
+  description?: string;
+  extract?: string;
+  wikibase_item?: string;
+  content_urls?: { desktop?: { page?: string } };
+}
+
+interface WikidataValue {
+  type: string;
+  value: unknown;
+}
+
+interface WikidataClaim {
+  rank?: string;
+  mainsnak: { snaktype: string; datavalue?: WikidataValue };
+}
+
+interface WikidataEntity {
+  claims?: Record<string, WikidataClaim[]>;
+  labels?: Record<string, { value: string }>;
+}
+
+async function getJson<T>(url: string): Promise<T | undefined> {
+  const response = await fetchWithTimeout(url, REQUEST_TIMEOUT_MS);
+  if (response.status === 404) {
+    return undefined;
+  }
+  if (!response.ok) {
+    throw new Error(
+      `Request to ${new URL(url).host} failed with status code ${response.status} ${response.statusText}`,
+    );
+  }
+  return (await response.json()) as T;
+}
+
+function wikipediaBase(language: string): string {
+  if (!/^[a-z][a-z-]*$/i.test(language)) {
+    throw new Error(`Invalid Wikipedia language "${language}"`);
+  }
+  return `https://${language.toLowerCase()}.wikipedia.org`;
+}
+
+async function getSummary(
+  language: string,
+  title: string,
+): Promise<PageSummary | undefined> {
+  return getJson<PageSummary>(
+    `${wikipediaBase(language)}/api/rest_v1/page/summary/${encodeURIComponent(title.replace(/ /g, '_'))}?redirect=true`,
+  );
+}
+
+async function searchTitle(
+  language: string,
+  topic: string,
+): Promise<string | undefined> {
+  const params = new URLSearchParams({
+    action: 'query',
+    list: 'search',
+    srsearch: topic,
+    srlimit: '1',
+    format: 'json',
+  });
+  const result = await getJson<{
+    query?: { search?: Array<{ title: string }> };
+  }>(`${wikipediaBase(language)}/w/api.php?${params}`);
+  return result?.query?.search?.[0]?.title;
+}
+
+async function getEntities(
+  ids: string[],
+  props: string,
+  language: string,
+): Promise<Record<string, WikidataEntity>> {
+  const entities: Record<string, WikidataEntity> = {};
+  // wbgetentities takes at most 50 ids per request.
+  for (let i = 0; i < ids.length; i += 50) {
+    const params = new URLSearchParams({
+      action: 'wbgetentities',
+      ids: ids.slice(i, i + 50).join('|'),
+      props,
+      languages: language === 'en' ? 'en' : `${language}|en`,
+      format: 'json',
+    });
+    const result = await getJson<{
+      entities?: Record<string, WikidataEntity>;
+    }>(`${WIKIDATA_API}?${params}`);
+    Object.assign(entities, result?.entities ?? {});
+  }
+  return entities;
+}
+
+function formatTime(value: { time: string; precision: number }): string {
+  const match = value.time.match(/^([+-])0*(\d+)-(\d\d)-(\d\d)/);
+  if (!match) {
+    return value.time;
+  }
+  const [, sign, year, month, day] = match;
+  const era = sign === '-' ? ' BCE' : '';
+  if (value.precision >= 11) {
+    return `${year}-${month}-${day}${era}`;
+  }
+  if (value.precision === 10) {
+    return `${year}-${month}${era}`;
+  }
+  return `${year}${era}`;
+}
+
+function entityIdOf(value: WikidataValue): string | undefined {
+  if (value.type === 'wikibase-entityid') {
+    return (value.value as { id: string }).id;
+  }
+  if (value.type === 'quantity') {
+    const unit = (value.value as { unit: string }).unit;
+    return unit.match(/\/(Q\d+)$/)?.[1];
+  }
+  return undefined;
+}
+
+function formatValue(
+  value: WikidataValue,
+  label: (id: string) => string,
+): string | undefined {
+  switch (value.type) {
+    case 'wikibase-entityid':
+      return label((value.value as { id: string }).id);
+    case 'time':
+      return formatTime(value.value as { time: string; precision: number });
+    case 'quantity': {
+      const quantity = value.value as { amount: string; unit: string };
+      const amount = Number(quantity.amount).toLocaleString('en-US');
+      const unit = entityIdOf(value);
+      return unit ? `${amount} ${label(unit)}` : amount;
+    }
+    case 'monolingualtext':
+      return (value.value as { text: string }).text;
+    case 'globecoordinate': {
+      const coordinate = value.value as { latitude: number; longitude: number };
+      return `${coordinate.latitude}, ${coordinate.longitude}`;
+    }
+    case 'string':
+      return value.value as string;
+    default:
+      return undefined;
+  }
+}
+
+function pickClaims(claims: WikidataClaim[]): WikidataValue[] {
+  const usable = claims.filter(
+    (claim) =>
+      claim.rank !== 'deprecated' &&
+      claim.mainsnak.snaktype === 'value' &&
+      claim.mainsnak.datavalue,
+  );
+  const preferred = usable.filter((claim) => claim.rank === 'preferred');
+  return (preferred.length > 0 ? preferred : usable)
+    .slice(0, MAX_VALUES_PER_FACT)
+    .map((claim) => claim.mainsnak.datavalue!);
+}
+
+/**
+ * Reads the card facts for a Wikidata item, with property and value
+ * labels in the given language where Wikidata has them.
+ */
+export async function getWikidataFacts(
+  id: string,
+  language: string,
+): Promise<WikiFact[]> {
+  const entity = (await getEntities([id], 'claims', language))[id];
+  const selected = FACT_PROPERTIES.filter(
+    (property) => entity?.claims?.[property],
+  ).map(
+    (property) => [property, pickClaims(entity!.claims![property])] as const,
+  );
+
+  const ids = new Set<string>();
+  for (const [property, values] of selected) {
+    ids.add(property);
+    for (const value of values) {
+      const entityId = entityIdOf(value);
+      if (entityId) {
+        ids.add(entityId);
+      }
+    }
+  }
+  const labelled = ids.size
+    ? await getEntities([...ids], 'labels', language)
+    : {};
+  const label = (entityId: string) =>
+    labelled[entityId]?.labels?.[language]?.value ??
+    labelled[entityId]?.labels?.en?.value ??
+    entityId;
+
+  return selected
+    .map(([property, values]) => ({
+      label: label(property),
+      values: values
+        .map((value) => formatValue(value, label))
+        .filter((value): value is string => !!value),
+    }))
+    .filter((fact) => fact.values.length > 0);
+}
+
+/**
+ * Looks a topic up on Wikipedia in the given language: the page of that
+ * title if there is one, otherwise the best search hit. The summary is
+ * combined with structured facts from the page's Wikidata item.
+ */
+export async function fetchWikiCard(
+  topic: string,
+  language = 'en',
+): Promise<WikiCard | undefined> {
+  let summary = await getSummary(language, topic);
+  if (!summary) {
+    const title = await searchTitle(language, topic);
+    summary = title ? await getSummary(language, title) : undefined;
+  }
+  if (!summary) {
+    return undefined;
+  }
+
+  const facts = summary.wikibase_item
+    ? await getWikidataFacts(summary.wikibase_item, language)
+    : [];
+  return {
+    title: summary.title,
+    description: summary.description,
+    extract: summary.extract ?? '',
+    url:
+      summary.content_urls?.desktop?.page ??
+      `${wikipediaBase(language)}/wiki/${encodeURIComponent(summary.title.replace(/ /g, '_'))}`,
+    language,
+    wikidataId: summary.wikibase_item,
+    facts,
+    disambiguation: summary.type === 'disambiguation' || undefined,
+  };
+}
+
+/** Renders a card as Markdown for the conversation and the model. */
+export function renderWikiCard(card: WikiCard): string {
+  const lines = [
+    `**${card.title}**${card.description ? ` — ${card.description}` : ''}`,
+    '',
+    card.extract,
+  ];
+  if (card.disambiguation) {
+    lines.push('', '_This is a disambiguation page; try a more specific topic._');
+  }
+  if (card.facts.length > 0) {
+    lines.push('', `Facts (Wikidata ${card.wikidataId}):`);
+    for (const fact of card.facts) {
+      lines.push(`- ${fact.label}: ${fact.values.join('; ')}`);
+    }
+  }
+  lines.push('', `Source: ${card.url}`);
+  return lines.join('\n');
+}
@@ -0,0 +1,147 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import fs from 'node:fs';
+import os from 'node:os';
+import path from 'node:path';
+import zlib from 'node:zlib';
+import {
+  cleanupWorkspaceStorage,
+  getWorkspaceStorageDirs,
+  planCleanup,
+  scanWorkspaceStorage,
+  StorageItem,
+  summarizeStorage,
+} from './workspaceStorage.js';
+
+const DAY_MS = 24 * 60 * 60 * 1000;
+
+describe('workspace storage', () => {
+  let tempDir: string;
+  let projectRoot: string;
+
+  const write = (filePath: string, content: string, ageDays = 0) => {
+    fs.mkdirSync(path.dirname(filePath), { recursive: true });
+    fs.writeFileSync(filePath, content);
+    const time = new Date(Date.now() - ageDays * DAY_MS);
+    fs.utimesSync(filePath, time, time);
+  };
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'workspace-storage-'));
+    projectRoot = path.join(tempDir, 'paper');
+    // Everything but the PDFs lives under the home directory
+    vi.stubEnv('HOME', tempDir);
+
+    const { tempDir: projectTemp, checkpointDirs, cacheDirs } =
+      getWorkspaceStorageDirs(projectRoot);
+    write(path.join(projectTemp, 'checkpoint-old.json'), '[1,2,3]', 40);
+    write(path.join(projectTemp, 'checkpoint-new.json'), '[]');
+    write(path.join(projectTemp, 'logs.json'), '[]');
+    write(path.join(checkpointDirs[0], 'restore.json'), '{}', 90);
+    write(path.join(cacheDirs[0], 'fig-1.png'), 'png', 10);
+    write(path.join(cacheDirs[2], 'page.json'), '{}', 1);
+    write(path.join(projectRoot, 'refs', 'Smith2020.PDF'), '%PDF-1.7');
+    write(path.join(projectRoot, 'node_modules', 'x.pdf'), '%PDF');
+    write(path.join(projectRoot, '.git', 'y.pdf'), '%PDF');
+  });
+
+  afterEach(() => {
+    vi.unstubAllEnvs();
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should sort the files of the workspace into categories', async () => {
+    const items = await scanWorkspaceStorage(projectRoot);
+
+    expect(
+      items.map((i) => [i.category, path.basename(i.path)]).sort(),
+    ).toEqual([
+      ['caches', 'fig-1.png'],
+      ['caches', 'page.json'],
+      ['checkpoints', 'restore.json'],
+      ['logs', 'logs.json'],
+      ['pdfs', 'Smith2020.PDF'],
+      ['sessions', 'checkpoint-new.json'],
+      ['sessions', 'checkpoint-old.json'],
+    ]);
+    expect(summarizeStorage(items)).toContainEqual({
+      category: 'sessions',
+      files: 2,
+      bytes: 9,
+    });
+  });
+
+  it('should leave out the PDFs when asked', async () => {
+    const items = await scanWorkspaceStorage(projectRoot, { pdfs: false });
+
+    expect(items.map((i) => i.category)).not.toContain('pdfs');
+    expect(items).toHaveLength(6);
+  });
+
+  it('should only plan to archive old chats and prune old caches', () => {
+    const item = (
+      category: StorageItem['category'],
+      ageDays: number,
+    ): StorageItem => ({
+      category,
+      path: `/${category}-${ageDays}`,
+      bytes: 1,
+      modifiedMs: 100 * DAY_MS - ageDays * DAY_MS,
+    });
+    const items = [
+      item('sessions', 31),
+      item('sessions', 5),
+      item('caches', 8),
+      item('pdfs', 400),
+      item('checkpoints', 400),
+    ];
+
+    expect(
+      planCleanup(
+        items,
+        { archiveSessionsAfterDays: 30, pruneCachesAfterDays: 7 },
+        100 * DAY_MS,
+      ).map((a) => [a.action, a.item.path]),
+    ).toEqual([
+      ['archive', '/sessions-31'],
+      ['delete', '/caches-8'],
+    ]);
+    expect(planCleanup(items, {}, 100 * DAY_MS)).toEqual([]);
+  });
+
+  it('should archive old chats as gzip and remove old caches', async () => {
+    const result = await cleanupWorkspaceStorage(projectRoot, {
+      archiveSessionsAfterDays: 30,
+      pruneCachesAfterDays: 7,
+    });
+
+    expect(result).toMatchObject({ archived: 1, deleted: 1 });
+    const { tempDir: projectTemp, archiveDir } =
+      getWorkspaceStorageDirs(projectRoot);
+    expect(fs.existsSync(path.join(projectTemp, 'checkpoint-old.json'))).toBe(
+      false,
+    );
+    const archived = fs.readFileSync(
+      path.join(archiveDir, 'checkpoint-old.json.gz'),
+    );
+    expect(zlib.gunzipSync(archived).toString()).toBe('[1,2,3]');
+    const categories = (await scanWorkspaceStorage(projectRoot)).map(
+      (i) => i.category,
+    );
+    expect(categories).toContain('archived');
+    expect(categories.filter((c) => c === 'caches')).toHaveLength(1);
+  });
+
+  it('should do nothing without a retention policy', async () => {
+    expect(await cleanupWorkspaceStorage(projectRoot, {})).toEqual({
+      archived: 0,
+      deleted: 0,
+      bytesFreed: 0,
+    });
+  });
+});
@@ -0,0 +1,272 @@
+/**
+ * @license
+ * Copyright 2025 iEchor LLC
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import fs from 'node:fs';
+import path from 'node:path';
+import zlib from 'node:zlib';
+import { promisify } from 'node:util';
+import {
+  getProjectHash,
+  getProjectTempDir,
+  getUserResearchDir,
+} from './paths.js';
+import { isNodeError } from './errors.js';
+
+const DAY_MS = 24 * 60 * 60 * 1000;
+const ARCHIVE_DIR = 'archive';
+const gzip = promisify(zlib.gzip);
+
+/** Folders of the workspace that are never searched for PDFs. */
+const SKIPPED_DIRS = new Set(['node_modules', '__pycache__', 'venv']);
+
+export type StorageCategory =
+  | 'sessions'
+  | 'archived'
+  | 'pdfs'
+  | 'checkpoints'
+  | 'logs'
+  | 'caches';
+
+export const STORAGE_CATEGORIES: StorageCategory[] = [
+  'sessions',
+  'archived',
+  'pdfs',
+  'checkpoints',
+  'logs',
+  'caches',
+];
+
+export interface StorageItem {
+  category: StorageCategory;
+  path: string;
+  bytes: number;
+  modifiedMs: number;
+}
+
+export interface RetentionPolicy {
+  /** Saved chats not touched for this many days are compressed. */
+  archiveSessionsAfterDays?: number;
+  /** Cached pages, figures and kernel files older than this are removed. */
+  pruneCachesAfterDays?: number;
+}
+
+/** The `storage` setting. */
+export interface StorageSettings extends RetentionPolicy {
+  /** Size in megabytes above which `/storage` warns about the workspace. */
+  quotaMb?: number;
+}
+
+export interface CleanupAction {
+  action: 'archive' | 'delete';
+  item: StorageItem;
+}
+
+export interface CleanupResult {
+  archived: number;
+  deleted: number;
+  bytesFreed: number;
+}
+
+/** Where each kind of data of the workspace is kept. */
+export function getWorkspaceStorageDirs(projectRoot: string) {
+  const home = getUserResearchDir();
+  const tempDir = getProjectTempDir(projectRoot);
+  return {
+    tempDir,
+    archiveDir: path.join(tempDir, ARCHIVE_DIR),
+    checkpointDirs: [
+      path.join(tempDir, 'checkpoints'),
+      path.join(home, 'history', getProjectHash(projectRoot)),
+    ],
+    cacheDirs: [
+      path.join(tempDir, 'figures'),
+      path.join(tempDir, 'kernel'),
+      path.join(home, 'cache', 'pages'),
+    ],
+  };
+}
+
+async function readDir(dir: string): Promise<fs.Dirent[]> {
+  try {
+    return await fs.promises.readdir(dir, { withFileTypes: true });
+  } catch (error) {
+    if (isNodeError(error) && error.code === 'ENOENT') {
+      return [];
+    }
+    throw error;
+  }
+}
+
+async function statItem(
+  category: StorageCategory,
+  filePath: string,
+): Promise<StorageItem> {
+  const stats = await fs.promises.stat(filePath);
+  return {
+    category,
+    path: filePath,
+    bytes: stats.size,
+    modifiedMs: stats.mtimeMs,
+  };
+}
+
+async function walk(
+  dir: string,
+  visit: (filePath: string) => Promise<void>,
+  skip: (name: string) => boolean = () => false,
+): Promise<void> {
+  for (const entry of await readDir(dir)) {
+    const entryPath = path.join(dir, entry.name);
+    if (entry.isDirectory()) {
+      if (!skip(entry.name)) {
+        await walk(entryPath, visit, skip);
+      }
+    } else if (entry.isFile()) {
+      await visit(entryPath);
+    }
+  }
+}
+
+/**
+ * Lists every file the CLI keeps for the workspace, and the PDFs in it
+ * unless `pdfs` is false; finding them walks the whole workspace. The page
+ * cache is shared by all workspaces but is counted here too.
+ */
+export async function scanWorkspaceStorage(
+  projectRoot: string,
+  { pdfs = true }: { pdfs?: boolean } = {},
+): Promise<StorageItem[]> {
+  const dirs = getWorkspaceStorageDirs(projectRoot);
+  const items: StorageItem[] = [];
+
+  for (const entry of await readDir(dirs.tempDir)) {
+    const filePath = path.join(dirs.tempDir, entry.name);
+    if (!entry.isFile()) {
+      continue;
+    }
+    if (/^checkpoint-.+\.json$/.test(entry.name)) {
+      items.push(await statItem('sessions', filePath));
+    } else if (entry.name.startsWith('logs.json')) {
+      items.push(await statItem('logs', filePath));
+    }
+  }
+  await walk(dirs.archiveDir, async (filePath) => {
+    items.push(await statItem('archived', filePath));
+  });
+  for (const dir of dirs.checkpointDirs) {
+    await walk(dir, async (filePath) => {
+      items.push(await statItem('checkpoints', filePath));
+    });
+  }
+  for (const dir of dirs.cacheDirs) {
+    await walk(dir, async (filePath) => {
+      items.push(await statItem('caches', filePath));
+    });
+  }
+  if (!pdfs) {
+    return items;
+  }
+  await walk(
+    projectRoot,
+    async (filePath) => {
+      if (filePath.toLowerCase().endsWith('.pdf')) {
+        items.push(await statItem('pdfs', filePath));
+      }
+    },
+    (name) => name.startsWith('.') || SKIPPED_DIRS.has(name),
+  );
+  return items;
+}
+
+export function summarizeStorage(
+  items: StorageItem[],
+): Array<{ category: StorageCategory; files: number; bytes: number }> {
+  return STORAGE_CATEGORIES.map((category) => {
+    const inCategory = items.filter((i) => i.category === category);
+    return {
+      category,
+      files: inCategory.length,
+      bytes: inCategory.reduce((sum, i) => sum + i.bytes, 0),
+    };
+  });
+}
+
+/**
+ * Picks the saved chats to archive and the cache files to remove under the
+ * policy. PDFs, restore points and logs are never touched.
+ */
+export function planCleanup(
+  items: StorageItem[],
+  policy: RetentionPolicy,
+  now: number = Date.now(),
+): CleanupAction[] {
+  const olderThan = (item: StorageItem, days?: number) =>
+    days !== undefined && now - item.modifiedMs > days * DAY_MS;
+  const actions: CleanupAction[] = [];
+  for (const item of items) {
+    if (
+      item.category === 'sessions' &&
+      olderThan(item, policy.archiveSessionsAfterDays)
+    ) {
+      actions.push({ action: 'archive', item });
+    } else if (
+      item.category === 'caches' &&
+      olderThan(item, policy.pruneCachesAfterDays)
+    ) {
+      actions.push({ action: 'delete', item });
+    }
+  }
+  return actions;
+}
+
+/**
+ * Archives a saved chat as a gzip file in the archive folder of the
+ * workspace, from where `gunzip` brings it back.
+ */
+async function archiveFile(item: StorageItem, archiveDir: string) {
+  await fs.promises.mkdir(archiveDir, { recursive: true });
+  const target = path.join(archiveDir, `${path.basename(item.path)}.gz`);
+  const compressed = await gzip(await fs.promises.readFile(item.path));
+  await fs.promises.writeFile(target, compressed);
+  await fs.promises.utimes(target, new Date(), new Date(item.modifiedMs));
+  await fs.promises.unlink(item.path);
+  return item.bytes - compressed.length;
+}
+
+export async function applyCleanup(
+  projectRoot: string,
+  actions: CleanupAction[],
+): Promise<CleanupResult> {
+  const { archiveDir } = getWorkspaceStorageDirs(projectRoot);
+  const result: CleanupResult = { archived: 0, deleted: 0, bytesFreed: 0 };
+  for (const { action, item } of actions) {
+    if (action === 'archive') {
+      result.bytesFreed += await archiveFile(item, archiveDir);
+      result.archived++;
+    } else {
+      await fs.promises.rm(item.path, { force: true });
+      result.bytesFreed += item.bytes;
+      result.deleted++;
+    }
+  }
+  return result;
+}
+
+/** Applies the retention policy to the workspace; a no-op without one. */
+export async function cleanupWorkspaceStorage(
+  projectRoot: string,
+  policy: RetentionPolicy,
+): Promise<CleanupResult> {
+  if (
+    policy.archiveSessionsAfterDays === undefined &&
+    policy.pruneCachesAfterDays === undefined
+  ) {
+    return { archived: 0, deleted: 0, bytesFreed: 0 };
+  }
+  // The cleanup never touches PDFs, so the workspace is not searched
+  const items = await scanWorkspaceStorage(projectRoot, { pdfs: false });
+  return applyCleanup(projectRoot, planCleanup(items, policy));
+}